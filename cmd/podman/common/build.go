@@ -21,6 +21,7 @@ import (
 	enchelpers "github.com/containers/ocicrypt/helpers"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/cmd/podman/utils"
+	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/dmikushin/podman-shared/pkg/env"
 	"github.com/openshift/imagebuilder"
@@ -48,6 +49,8 @@ type BuildFlagsWrapper struct {
 	SquashAll bool
 	// Cleanup removes built images from remote connections on success
 	Cleanup bool
+	// SharedCache is the directory of the shared build cache
+	SharedCache string
 }
 
 // FarmBuildHiddenFlags are the flags hidden from the farm build command because they are either not
@@ -69,6 +72,9 @@ func DefineBuildFlags(cmd *cobra.Command, buildOpts *BuildFlagsWrapper, isFarmBu
 
 	// Podman flags
 	flags.BoolVarP(&buildOpts.SquashAll, "squash-all", "", false, "Squash all layers into a single layer")
+	sharedCacheFlagName := "shared-cache"
+	flags.StringVar(&buildOpts.SharedCache, sharedCacheFlagName, define.BuildSharedCacheAuto, "Export and import the intermediate images to and from a shared build cache `directory` (\"auto\"|\"none\"|path)")
+	_ = cmd.RegisterFlagCompletionFunc(sharedCacheFlagName, completion.AutocompleteDefault)
 
 	// Bud flags
 	budFlags := buildahCLI.GetBudFlags(&buildOpts.BudResults)
@@ -262,11 +268,83 @@ func ParseBuildOpts(cmd *cobra.Command, args []string, buildOpts *BuildFlagsWrap
 	}
 	apiBuildOpts.BuildOptions = *buildahDefineOpts
 	apiBuildOpts.ContainerFiles = containerFiles
+	if cmd.Flag("output").Changed {
+		if err := setImageBuildOutput(&apiBuildOpts); err != nil {
+			return nil, err
+		}
+	}
 	apiBuildOpts.Authfile = buildOpts.Authfile
+	apiBuildOpts.SharedCache = buildOpts.SharedCache
 
 	return &apiBuildOpts, err
 }
 
+// setImageBuildOutput handles the --output types which export the built image
+// as an OCI layout directory or archive instead of committing it to the local
+// storage.  All other types are left to buildah.
+func setImageBuildOutput(opts *entities.BuildOptions) error {
+	outputs := make([]string, 0, len(opts.BuildOutputs))
+	imageOutput := ""
+	for _, output := range opts.BuildOutputs {
+		typ, dest := "", ""
+		for option := range strings.SplitSeq(output, ",") {
+			key, value, _ := strings.Cut(option, "=")
+			switch key {
+			case "type":
+				typ = value
+			case "dest":
+				dest = value
+			}
+		}
+		if typ != define.OCIManifestDir && typ != define.OCIArchive {
+			outputs = append(outputs, output)
+			continue
+		}
+
+		switch {
+		case imageOutput != "":
+			return fmt.Errorf("invalid build output option %q, only one image output can be specified", output)
+		case opts.Output != "" || opts.Manifest != "":
+			return fmt.Errorf("invalid build output option %q, the image is not stored locally and cannot be used with --tag or --manifest", output)
+		case dest == "":
+			return fmt.Errorf("invalid build output option %q, \"dest\" must be present", output)
+		case dest == "-" && typ != define.OCIArchive:
+			return fmt.Errorf("invalid build output option %q, \"type=%s\" can not be used with \"dest=-\"", output, typ)
+		}
+
+		if dest == "-" {
+			f, err := os.CreateTemp("", "podman-build-output-*.tar")
+			if err != nil {
+				return err
+			}
+			if err := f.Close(); err != nil {
+				return err
+			}
+			dest = f.Name()
+			opts.ArchiveToStdout = dest
+			// keep the progress out of the archive
+			if opts.Out == os.Stdout {
+				opts.Out = os.Stderr
+			}
+		} else {
+			absDest, err := filepath.Abs(dest)
+			if err != nil {
+				return err
+			}
+			dest = absDest
+		}
+		imageOutput = "oci:" + dest
+		if typ == define.OCIArchive {
+			imageOutput = "oci-archive:" + dest
+		}
+	}
+	if imageOutput != "" {
+		opts.BuildOutputs = outputs
+		opts.Output = imageOutput
+	}
+	return nil
+}
+
 // buildFlagsWrapperToOptions converts the local build flags to the build options used
 // in the API which embed Buildah types used across the build code.  Doing the
 // conversion here prevents the API from doing that (redundantly).
@@ -458,6 +536,17 @@ func buildFlagsWrapperToOptions(c *cobra.Command, contextDir string, flags *Buil
 		return nil, err
 	}
 
+	manifest := flags.Manifest
+	if manifest == "" && output != "" && (len(platforms) > 1 || flags.AllPlatforms) {
+		// Assemble the images of all platforms into a manifest list named
+		// by --tag instead of tagging each of the images in turn.
+		if len(tags) > 0 {
+			return nil, errors.New("only one --tag can be used when building for multiple platforms without --manifest")
+		}
+		manifest = output
+		output = ""
+	}
+
 	decConfig, err := getDecryptConfig(flags.DecryptionKeys)
 	if err != nil {
 		return nil, fmt.Errorf("unable to obtain decrypt config: %w", err)
@@ -570,7 +659,7 @@ func buildFlagsWrapperToOptions(c *cobra.Command, contextDir string, flags *Buil
 		LogRusage:               flags.LogRusage,
 		LogFile:                 flags.Logfile,
 		LogSplitByPlatform:      flags.LogSplitByPlatform,
-		Manifest:                flags.Manifest,
+		Manifest:                manifest,
 		MaxPullPushRetries:      flags.Retry,
 		NamespaceOptions:        nsValues,
 		NoCache:                 flags.NoCache,
@@ -18,6 +18,7 @@ import (
 	"github.com/dmikushin/podman-shared/libpod/events"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/dmikushin/podman-shared/pkg/inspect"
+	"github.com/dmikushin/podman-shared/pkg/scan"
 	"github.com/dmikushin/podman-shared/pkg/signal"
 	systemdDefine "github.com/dmikushin/podman-shared/pkg/systemd/define"
 	"github.com/dmikushin/podman-shared/pkg/util"
@@ -37,7 +38,7 @@ var (
 	// LogLevels supported by podman
 	LogLevels = []string{"trace", "debug", "info", "warn", "warning", "error", "fatal", "panic"}
 	// ValidSaveFormats is the list of support podman save formats
-	ValidSaveFormats = []string{define.OCIManifestDir, define.OCIArchive, define.V2s2ManifestDir, define.V2s2Archive}
+	ValidSaveFormats = []string{define.OCIManifestDir, define.OCIArchive, define.V2s2ManifestDir, define.V2s2Archive, define.OCIArtifactArchive}
 )
 
 type completeType int
@@ -738,6 +739,30 @@ func AutocompleteSecrets(cmd *cobra.Command, args []string, toComplete string) (
 	return getSecrets(cmd, toComplete, completeDefault)
 }
 
+// AutocompleteRootfs - Autocomplete the prepared root file systems.
+func AutocompleteRootfs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if !validCurrentCmdLine(cmd, args, toComplete) {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	engine, err := setupImageEngine(cmd)
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	rootfs, err := engine.RootfsList(registry.Context())
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	suggestions := []string{}
+	for _, r := range rootfs {
+		if strings.HasPrefix(r.Name, toComplete) {
+			suggestions = append(suggestions, r.Name)
+		}
+	}
+	return suggestions, cobra.ShellCompDirectiveNoFileComp
+}
+
 func AutocompleteSecretCreate(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
 	if len(args) == 1 {
 		return nil, cobra.ShellCompDirectiveDefault
@@ -1148,6 +1173,13 @@ func AutocompleteCgroupMode(_ *cobra.Command, _ []string, _ string) ([]string, c
 	return cgroupModes, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteMachineNetwork - Autocomplete machine network modes.
+// -> "user", "switch"
+func AutocompleteMachineNetwork(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	networkModes := []string{"user", "switch"}
+	return networkModes, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteImageVolume - Autocomplete image volume options.
 // -> "bind", "tmpfs", "ignore"
 func AutocompleteImageVolume(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
@@ -1179,7 +1211,7 @@ func AutocompleteLogOpt(_ *cobra.Command, _ []string, toComplete string) ([]stri
 // AutocompletePullOption - Autocomplete pull options for create and run command.
 // -> "always", "missing", "never"
 func AutocompletePullOption(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-	pullOptions := []string{"always", "missing", "never", "newer"}
+	pullOptions := []string{"always", "missing", "never", "newer", util.PullPolicyNewerWithMaxAge}
 	return pullOptions, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -1599,7 +1631,7 @@ func AutocompleteEventFilter(cmd *cobra.Command, _ []string, toComplete string)
 			events.Commit.String(), events.Create.String(), events.Exec.String(), events.ExecDied.String(),
 			events.Exited.String(), events.Export.String(), events.Import.String(), events.Init.String(), events.Kill.String(),
 			events.LoadFromArchive.String(), events.Mount.String(), events.NetworkConnect.String(),
-			events.NetworkDisconnect.String(), events.Pause.String(), events.Prune.String(), events.Pull.String(),
+			events.NetworkDisconnect.String(), events.OOM.String(), events.Pause.String(), events.Prune.String(), events.Pull.String(),
 			events.PullError.String(), events.Push.String(), events.Refresh.String(), events.Remove.String(),
 			events.Rename.String(), events.Renumber.String(), events.Restart.String(), events.Restore.String(),
 			events.Save.String(), events.Start.String(), events.Stop.String(), events.Sync.String(), events.Tag.String(),
@@ -1698,7 +1730,7 @@ func AutocompletePodPsSort(_ *cobra.Command, _ []string, _ string) ([]string, co
 // AutocompletePsSort - Autocomplete images sort options.
 // -> "command", "created", "id", "image", "names", "runningfor", "size", "status"
 func AutocompletePsSort(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-	sortBy := []string{"command", "created", "id", "image", "names", "runningfor", "size", "status"}
+	sortBy := []string{"command", "created", "id", "image", "memory", "names", "runningfor", "size", "status"}
 	return sortBy, cobra.ShellCompDirectiveNoFileComp
 }
 
@@ -1707,11 +1739,58 @@ func AutocompleteImageSaveFormat(_ *cobra.Command, _ []string, _ string) ([]stri
 	return ValidSaveFormats, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteExportFormat - Autocomplete container export format options.
+// -> "tar", "oci-archive"
+func AutocompleteExportFormat(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	formats := []string{entities.ExportFormatTar, entities.ExportFormatOCIArchive}
+	return formats, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteMonitor - Autocomplete container monitors.
+// -> "conmon", "conmon-rs" or a path
+func AutocompleteMonitor(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if strings.HasPrefix(toComplete, "/") {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return []string{"conmon", "conmon-rs"}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteSBOMFormat - Autocomplete image sbom format options.
+// -> "spdx", "cyclonedx"
+func AutocompleteSBOMFormat(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	formats := []string{"spdx", "cyclonedx"}
+	return formats, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteEventStreamFormat - Autocomplete events stream format options.
+// -> "cloudevents"
+func AutocompleteEventStreamFormat(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	return []string{events.CloudEventsFormat}, cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteScanners - Autocomplete the vulnerability scanners of the
+// local scan configuration.
+func AutocompleteScanners(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	cfg, err := scan.ReadConfig()
+	if err != nil {
+		cobra.CompErrorln(err.Error())
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return cfg.ScannerNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// AutocompleteScanSeverity - Autocomplete vulnerability severities.
+// -> "unknown", "low", "medium", "high", "critical"
+func AutocompleteScanSeverity(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	severities := []string{"unknown", "low", "medium", "high", "critical"}
+	return severities, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteWaitCondition - Autocomplete wait condition options.
 // -> "unknown", "configured", "created", "running", "stopped", "paused", "exited", "removing"
 func AutocompleteWaitCondition(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 	states := []string{"unknown", "configured", "created", "exited",
-		"healthy", "initialized", "paused", "removing", "running",
+		"healthy", "initialized", "paused", "removed", "removing", "running",
 		"stopped", "stopping", "unhealthy"}
 	return states, cobra.ShellCompDirectiveNoFileComp
 }
@@ -1723,6 +1802,13 @@ func AutocompleteCgroupManager(_ *cobra.Command, _ []string, _ string) ([]string
 	return types, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteDBBackend - Autocomplete database backend options.
+// -> "sqlite", "boltdb"
+func AutocompleteDBBackend(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	types := []string{config.DBBackendSQLite.String(), config.DBBackendBoltDB.String()}
+	return types, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteContainersConfModules- Autocomplete containers.conf modules.
 func AutocompleteContainersConfModules(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	dirs, err := config.ModuleDirectories()
@@ -1775,6 +1861,13 @@ func AutocompleteLogLevel(_ *cobra.Command, _ []string, _ string) ([]string, cob
 	return LogLevels, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteNUMAPolicy - Autocomplete NUMA memory policy options.
+// -> "default", "bind", "interleave", "local", "preferred"
+func AutocompleteNUMAPolicy(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	policies := []string{define.NUMAPolicyDefault, define.NUMAPolicyBind, define.NUMAPolicyInterleave, define.NUMAPolicyLocal, define.NUMAPolicyPreferred}
+	return policies, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteSDNotify - Autocomplete sdnotify options.
 // -> "container", "conmon", "ignore"
 func AutocompleteSDNotify(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
@@ -1787,20 +1880,23 @@ var containerStatuses = []string{"created", "running", "paused", "stopped", "exi
 // AutocompletePsFilters - Autocomplete ps filter options.
 func AutocompletePsFilters(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	kv := keyValueCompletion{
-		"ancestor=": func(s string) ([]string, cobra.ShellCompDirective) { return getImages(cmd, s) },
-		"before=":   func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeDefault) },
-		"command=":  func(s string) ([]string, cobra.ShellCompDirective) { return getCommands(cmd, s) },
-		"exited=":   nil,
+		"ancestor=":      func(s string) ([]string, cobra.ShellCompDirective) { return getImages(cmd, s) },
+		"before=":        func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeDefault) },
+		"command=":       func(s string) ([]string, cobra.ShellCompDirective) { return getCommands(cmd, s) },
+		"created-since=": nil,
+		"exited=":        nil,
+		"exited-range=":  nil,
 		"health=": func(_ string) ([]string, cobra.ShellCompDirective) {
 			return []string{define.HealthCheckHealthy,
 				define.HealthCheckUnhealthy}, cobra.ShellCompDirectiveNoFileComp
 		},
-		"id=":      func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeIDs) },
-		"label=":   nil,
-		"name=":    func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeNames) },
-		"network=": func(s string) ([]string, cobra.ShellCompDirective) { return getNetworks(cmd, s, completeDefault) },
-		"pod=":     func(s string) ([]string, cobra.ShellCompDirective) { return getPods(cmd, s, completeDefault) },
-		"since=":   func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeDefault) },
+		"id=":           func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeIDs) },
+		"image-digest=": nil,
+		"label=":        nil,
+		"name=":         func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeNames) },
+		"network=":      func(s string) ([]string, cobra.ShellCompDirective) { return getNetworks(cmd, s, completeDefault) },
+		"pod=":          func(s string) ([]string, cobra.ShellCompDirective) { return getPods(cmd, s, completeDefault) },
+		"since=":        func(s string) ([]string, cobra.ShellCompDirective) { return getContainers(cmd, s, completeDefault) },
 		"status=": func(_ string) ([]string, cobra.ShellCompDirective) {
 			return containerStatuses, cobra.ShellCompDirectiveNoFileComp
 		},
@@ -1935,6 +2031,13 @@ func AutocompleteCheckpointCompressType(_ *cobra.Command, _ []string, _ string)
 	return types, cobra.ShellCompDirectiveNoFileComp
 }
 
+// AutocompleteKubeIngressType - Autocomplete kube generate ingress kinds.
+// -> "httproute", "ingress"
+func AutocompleteKubeIngressType(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	types := []string{define.K8sKindHTTPRoute, define.K8sKindIngress}
+	return types, cobra.ShellCompDirectiveNoFileComp
+}
+
 // AutocompleteCompressionFormat - Autocomplete compression-format type options.
 func AutocompleteCompressionFormat(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
 	types := []string{"gzip", "zstd", "zstd:chunked"}
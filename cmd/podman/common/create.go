@@ -137,7 +137,7 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		createFlags.StringArrayVar(
 			&cf.EnvFile,
 			envFileFlagName, []string{},
-			"Read in a file of environment variables",
+			"Read in a file, directory or https:// URL of environment variables",
 		)
 		_ = cmd.RegisterFlagCompletionFunc(envFileFlagName, completion.AutocompleteDefault)
 
@@ -295,10 +295,18 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		createFlags.StringVar(
 			&cf.Pull,
 			pullFlagName, cf.Pull,
-			`Pull image policy ("always"|"missing"|"never"|"newer")`,
+			`Pull image policy ("always"|"missing"|"never"|"newer"|"newer-with-max-age")`,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(pullFlagName, AutocompletePullOption)
 
+		pullMaxAgeFlagName := "pull-max-age"
+		createFlags.StringVar(
+			&cf.PullMaxAge,
+			pullMaxAgeFlagName, registry.PullMaxAgeDefault(),
+			"Only check the registry for images pulled longer than `DURATION` ago with the newer-with-max-age pull policy",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(pullMaxAgeFlagName, completion.AutocompleteNone)
+
 		createFlags.BoolVarP(
 			&cf.Quiet,
 			"quiet", "q", false,
@@ -322,6 +330,21 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 			"read-only-tmpfs", cf.ReadWriteTmpFS,
 			"When running --read-only containers mount read-write tmpfs on /dev, /dev/shm, /run, /tmp and /var/tmp",
 		)
+		readOnlyTmpfsSizeFlagName := "read-only-tmpfs-size"
+		createFlags.StringVar(
+			&cf.ReadWriteTmpFSSize,
+			readOnlyTmpfsSizeFlagName, "",
+			"Size of each read-write tmpfs mounted on /run, /tmp and /var/tmp by --read-only-tmpfs",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(readOnlyTmpfsSizeFlagName, completion.AutocompleteNone)
+
+		readOnlyTmpfsOptFlagName := "read-only-tmpfs-opt"
+		createFlags.StringArrayVar(
+			&cf.ReadWriteTmpFSOpts,
+			readOnlyTmpfsOptFlagName, []string{},
+			"Mount `PATH:OPTIONS` of the read-write tmpfs mounted on PATH (/run, /tmp or /var/tmp) by --read-only-tmpfs, \"off\" disables it",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(readOnlyTmpfsOptFlagName, completion.AutocompleteNone)
 		requiresFlagName := "requires"
 		createFlags.StringSliceVar(
 			&cf.Requires,
@@ -388,6 +411,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(systemdFlagName, AutocompleteSystemdFlag)
 
+		numaPolicyFlagName := "numa-policy"
+		createFlags.StringVar(
+			&cf.NUMAPolicy,
+			numaPolicyFlagName, "",
+			`NUMA memory policy of the container ("default"|"bind"|"interleave"|"local"|"preferred")`,
+		)
+		_ = cmd.RegisterFlagCompletionFunc(numaPolicyFlagName, AutocompleteNUMAPolicy)
+
 		personalityFlagName := "personality"
 		createFlags.StringVar(
 			&cf.Personality,
@@ -432,6 +463,50 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(timezoneFlagName, completion.AutocompleteNone) //TODO: add timezone completion
 
+		createFlags.BoolVar(
+			&cf.TZFromHost,
+			"tz-from-host", false,
+			"Follow the timezone of the host, using the timezone database of the host",
+		)
+
+		localeFlagName := "locale"
+		createFlags.StringVar(
+			&cf.Locale,
+			localeFlagName, "",
+			"Set the locale of the container, generating it when the image lacks it (`locale` or host)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(localeFlagName, completion.AutocompleteNone)
+
+		monitorFlagName := "monitor"
+		createFlags.StringVar(
+			&cf.Monitor,
+			monitorFlagName, "",
+			"Container monitor managing the container (conmon, conmon-rs or `path`)",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(monitorFlagName, AutocompleteMonitor)
+
+		runtimeClassFlagName := "runtime-class"
+		createFlags.StringVar(
+			&cf.RuntimeClass,
+			runtimeClassFlagName, "",
+			"Run the container with the OCI runtime and annotations of the runtime `class`",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(runtimeClassFlagName, completion.AutocompleteNone)
+
+		createFlags.BoolVar(
+			&cf.TrackFlows,
+			"track-flows", false,
+			"Track the network connections of the container with their byte counts",
+		)
+
+		statsHistoryFlagName := "stats-history"
+		createFlags.StringVar(
+			&cf.StatsHistory,
+			statsHistoryFlagName, "",
+			"Record the resource usage of the container in its stats history at the `interval`",
+		)
+		_ = cmd.RegisterFlagCompletionFunc(statsHistoryFlagName, completion.AutocompleteNone)
+
 		umaskFlagName := "umask"
 		createFlags.StringVar(
 			&cf.Umask,
@@ -558,6 +633,18 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 			"shared-base-layers", false,
 			"Skip copying base layers and use them directly from shared storage",
 		)
+
+		createFlags.BoolVar(
+			&cf.StorageTransient,
+			"storage-transient", false,
+			"Keep the container state under the run root and remove the container when the system reboots",
+		)
+
+		createFlags.BoolVar(
+			&cf.PodmanInPodman,
+			"podman-in-podman", false,
+			"Add the devices and security options needed to run rootless Podman in the container",
+		)
 	}
 	if mode == entities.CreateMode || mode == entities.UpdateMode {
 		createFlags.BoolVar(
@@ -966,6 +1053,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		)
 		_ = cmd.RegisterFlagCompletionFunc(memoryReservationFlagName, completion.AutocompleteNone)
 
+		memoryHighFlagName := "memory-high"
+		createFlags.StringVar(
+			&cf.MemoryHigh,
+			memoryHighFlagName, "",
+			"Memory throttle limit above which the container is throttled and its memory reclaimed, or max "+sizeWithUnitFormat,
+		)
+		_ = cmd.RegisterFlagCompletionFunc(memoryHighFlagName, completion.AutocompleteNone)
+
 		memorySwappinessFlagName := "memory-swappiness"
 		createFlags.Int64Var(
 			&cf.MemorySwappiness,
@@ -1078,6 +1173,14 @@ func DefineCreateFlags(cmd *cobra.Command, cf *entities.ContainerCreateOptions,
 		"Block IO weight (relative device weight, format: `DEVICE_NAME:WEIGHT`)",
 	)
 	_ = cmd.RegisterFlagCompletionFunc(blkioWeightDeviceFlagName, completion.AutocompleteDefault)
+
+	ioMaxFlagName := "io-max"
+	createFlags.StringArrayVar(
+		&cf.IOMax,
+		ioMaxFlagName, []string{},
+		"Limit IO of a device (format: `DEVICE_NAME:rbps=RATE,wbps=RATE,riops=RATE,wiops=RATE`)",
+	)
+	_ = cmd.RegisterFlagCompletionFunc(ioMaxFlagName, completion.AutocompleteDefault)
 }
 
 func GetHealthCheckOverrideConfig(cmd *cobra.Command, vals *entities.ContainerCreateOptions) (*manifest.Schema2HealthConfig, error) {
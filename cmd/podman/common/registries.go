@@ -1,18 +1,30 @@
 package common
 
 import (
+	"context"
 	"os"
 
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/image/v5/pkg/shortnames"
 	"go.podman.io/image/v5/types"
 )
 
 // SetRegistriesConfPath sets the registries.conf path for the specified context.
-// NOTE: this is a verbatim copy from c/common/libimage which we're not using
-// to prevent leaking c/storage into this file.  Maybe this should go into c/image?
+// NOTE: this is a copy from c/common/libimage which we're not using to
+// prevent leaking c/storage into this file, extended by the --registries-conf
+// of the project or connection.  Maybe this should go into c/image?
 func SetRegistriesConfPath(systemContext *types.SystemContext) {
 	if systemContext.SystemRegistriesConfPath != "" {
 		return
 	}
+	if path := registry.PodmanConfig().RegistriesConf; path != "" {
+		systemContext.SystemRegistriesConfPath = path
+		systemContext.UserShortNameAliasConfPath = registry.PodmanConfig().ShortNameAliasConf
+		return
+	}
 	if envOverride, ok := os.LookupEnv("CONTAINERS_REGISTRIES_CONF"); ok {
 		systemContext.SystemRegistriesConfPath = envOverride
 		return
@@ -22,3 +34,52 @@ func SetRegistriesConfPath(systemContext *types.SystemContext) {
 		return
 	}
 }
+
+// PullImage pulls the image and returns the name it was pulled by.  The
+// server of a remote client resolves short names with its own
+// registries.conf, so if a registries.conf is given to the client, e.g. the
+// one of the project or of the connection, short names are resolved by the
+// client instead and the candidates are pulled in order.
+func PullImage(ctx context.Context, name string, options entities.ImagePullOptions) (*entities.ImagePullReport, string, error) {
+	podmanConfig := registry.PodmanConfig()
+	if !registry.IsRemote() || podmanConfig.RegistriesConf == "" || !shortnames.IsShortName(name) {
+		report, err := registry.ImageEngine().Pull(ctx, name, options)
+		return report, name, err
+	}
+
+	// Like locally, an image of the server matching the short name is
+	// used unless it must be pulled.
+	if options.PullPolicy != config.PullPolicyAlways && options.PullPolicy != config.PullPolicyNewer {
+		exists, err := registry.ImageEngine().Exists(ctx, name)
+		if err != nil {
+			return nil, "", err
+		}
+		if exists.Value {
+			report, err := registry.ImageEngine().Pull(ctx, name, options)
+			return report, name, err
+		}
+	}
+
+	sysCtx := &types.SystemContext{}
+	SetRegistriesConfPath(sysCtx)
+	resolved, err := shortnames.Resolve(sysCtx, name)
+	if err != nil {
+		return nil, "", err
+	}
+	logrus.Debug(resolved.Description())
+
+	var pullErrors []error
+	for _, candidate := range resolved.PullCandidates {
+		candidateName := candidate.Value.String()
+		report, err := registry.ImageEngine().Pull(ctx, candidateName, options)
+		if err != nil {
+			pullErrors = append(pullErrors, err)
+			continue
+		}
+		if err := candidate.Record(); err != nil {
+			logrus.Errorf("Failed to record short-name alias of %q: %v", name, err)
+		}
+		return report, candidateName, nil
+	}
+	return nil, "", resolved.FormatPullErrors(pullErrors)
+}
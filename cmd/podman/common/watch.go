@@ -0,0 +1,72 @@
+package common
+
+import (
+	"context"
+	"time"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/libpod/events"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+)
+
+// JSONStreamFormat prints one JSON object per line instead of a JSON array.
+// Combined with --watch only the entries which changed are printed again.
+const JSONStreamFormat = "json-stream"
+
+// WatchRefresh returns a channel which receives a value whenever an event of
+// one of the given types is reported and, at the latest, once the interval
+// has elapsed so that time-dependent columns stay up to date.  Bursts of
+// events are coalesced into a single refresh.
+func WatchRefresh(ctx context.Context, interval time.Duration, eventTypes ...string) <-chan struct{} {
+	refresh := make(chan struct{}, 1)
+	trigger := func() {
+		select {
+		case refresh <- struct{}{}:
+		default:
+		}
+	}
+
+	filters := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		filters = append(filters, "type="+eventType)
+	}
+	eventChan := make(chan events.ReadResult)
+	go func() {
+		// The remote client blocks until the stream ends, the local one
+		// returns right away.
+		err := registry.ContainerEngine().Events(ctx, entities.EventsOptions{
+			EventChan: eventChan,
+			Filter:    filters,
+			Stream:    true,
+		})
+		if err != nil {
+			logrus.Errorf("Failed to read events, refreshing every %s only: %v", interval, err)
+		}
+	}()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case evt, ok := <-eventChan:
+				if !ok {
+					// a nil channel blocks forever, keep refreshing on the interval
+					eventChan = nil
+					continue
+				}
+				if evt.Error != nil {
+					logrus.Errorf("Failed to read event: %v", evt.Error)
+					continue
+				}
+				trigger()
+			case <-ticker.C:
+				trigger()
+			}
+		}
+	}()
+	return refresh
+}
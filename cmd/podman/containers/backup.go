@@ -0,0 +1,72 @@
+package containers
+
+import (
+	"errors"
+	"os"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/parse"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"golang.org/x/term"
+)
+
+var (
+	backupDescription = `Back up the configuration of a container, the changes of its writable layer and the content of its named volumes to a tar archive.
+
+  The backup can be restored with "podman container restore-backup", on this host or on another connection.`
+
+	backupCommand = &cobra.Command{
+		Use:               "backup [options] CONTAINER",
+		Short:             "Back up a container with its named volumes",
+		Long:              backupDescription,
+		RunE:              backup,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainerOneArg,
+		Example: `podman container backup --output web.tar web
+  podman container backup web > web.tar`,
+	}
+)
+
+var (
+	backupOpts   entities.ContainerBackupOptions
+	backupOutput string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: backupCommand,
+		Parent:  containerCmd,
+	})
+	flags := backupCommand.Flags()
+
+	outputFlagName := "output"
+	flags.StringVarP(&backupOutput, outputFlagName, "o", "", "Write to a specified file (default: stdout, which must be redirected)")
+	_ = backupCommand.RegisterFlagCompletionFunc(outputFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&backupOpts.IgnoreVolumes, "ignore-volumes", false, "Do not include the named volumes of the container")
+}
+
+func backup(_ *cobra.Command, args []string) error {
+	if len(backupOutput) == 0 {
+		file := os.Stdout
+		if term.IsTerminal(int(file.Fd())) {
+			return errors.New("refusing to write the backup to terminal. Use -o flag or redirect")
+		}
+		backupOpts.Output = file
+	} else {
+		if err := parse.ValidateFileName(backupOutput); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(backupOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		backupOpts.Output = file
+	}
+	return registry.ContainerEngine().ContainerBackup(registry.Context(), strings.TrimPrefix(args[0], "/"), backupOpts)
+}
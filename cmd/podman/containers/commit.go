@@ -9,6 +9,7 @@ import (
 	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/spf13/cobra"
 	"go.podman.io/common/pkg/completion"
 )
@@ -47,7 +48,7 @@ var (
 	commitOptions = entities.CommitOptions{
 		ImageName: "",
 	}
-	configFile, iidFile string
+	configFile, iidFile, changesFrom string
 )
 
 func commitFlags(cmd *cobra.Command) {
@@ -57,6 +58,10 @@ func commitFlags(cmd *cobra.Command) {
 	flags.StringArrayVarP(&commitOptions.Changes, changeFlagName, "c", []string{}, "Apply the following possible instructions to the created image (default []): "+strings.Join(common.ChangeCmds, " | "))
 	_ = cmd.RegisterFlagCompletionFunc(changeFlagName, common.AutocompleteChangeInstructions)
 
+	changesFromFlagName := "changes-from"
+	flags.StringVar(&changesFrom, changesFromFlagName, "", "Apply the instructions of a `file` of Dockerfile-style instructions to the created image")
+	_ = cmd.RegisterFlagCompletionFunc(changesFromFlagName, completion.AutocompleteDefault)
+
 	configFileFlagName := "config"
 	flags.StringVar(&configFile, configFileFlagName, "", "`file` containing a container configuration to merge into the image")
 	_ = cmd.RegisterFlagCompletionFunc(configFileFlagName, completion.AutocompleteDefault)
@@ -111,6 +116,18 @@ func commit(_ *cobra.Command, args []string) error {
 		}
 		commitOptions.Config = cfg
 	}
+	if len(changesFrom) > 0 {
+		f, err := os.Open(changesFrom)
+		if err != nil {
+			return fmt.Errorf("--changes-from: %w", err)
+		}
+		defer f.Close()
+		changes, err := util.ReadChanges(f)
+		if err != nil {
+			return fmt.Errorf("--changes-from: reading %s: %w", changesFrom, err)
+		}
+		commitOptions.Changes = append(changes, commitOptions.Changes...)
+	}
 	response, err := registry.ContainerEngine().ContainerCommit(context.Background(), container, commitOptions)
 	if err != nil {
 		return err
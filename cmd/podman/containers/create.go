@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/containers/buildah/pkg/cli"
 	"github.com/dmikushin/podman-shared/cmd/podman/common"
@@ -20,8 +22,9 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"go.podman.io/common/pkg/auth"
-	"go.podman.io/common/pkg/config"
+	"go.podman.io/common/pkg/completion"
 	"go.podman.io/image/v5/transports/alltransports"
 	"go.podman.io/image/v5/types"
 	"golang.org/x/term"
@@ -36,11 +39,12 @@ var (
 		Short:             "Create but do not start a container",
 		Long:              createDescription,
 		RunE:              create,
-		Args:              cobra.MinimumNArgs(1),
+		Args:              createArgs,
 		ValidArgsFunction: common.AutocompleteCreateRun,
 		Example: `podman create alpine ls
   podman create --annotation HELLO=WORLD alpine ls
-  podman create -t -i --name myctr alpine ls`,
+  podman create -t -i --name myctr alpine ls
+  podman create --spec web.json --name web2`,
 	}
 
 	containerCreateCommand = &cobra.Command{
@@ -52,15 +56,37 @@ var (
 		ValidArgsFunction: createCommand.ValidArgsFunction,
 		Example: `podman container create alpine ls
   podman container create --annotation HELLO=WORLD alpine ls
-  podman container create -t -i --name myctr alpine ls`,
+  podman container create -t -i --name myctr alpine ls
+  podman container create --spec web.json --name web2`,
 	}
 )
 
 var (
 	InitContainerType string
 	cliVals           entities.ContainerCreateOptions
+	specFile          string
 )
 
+// createSpecFlags are the flags which can be used together with --spec, all
+// other options of the container are read from the spec file.
+var createSpecFlags = []string{
+	"spec", "name", "pod", "replace", "cidfile",
+	"pull", "pull-max-age", "quiet", "authfile", "tls-verify", "creds", "cert-dir", "retry", "retry-delay",
+	"decryption-key", "signature-policy", "arch", "os", "variant", "platform",
+}
+
+// createArgs requires the image argument, unless the container is created
+// from a spec file.
+func createArgs(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("spec") {
+		if len(args) > 0 {
+			return errors.New("the image and command cannot be set with --spec")
+		}
+		return nil
+	}
+	return cobra.MinimumNArgs(1)(cmd, args)
+}
+
 func createFlags(cmd *cobra.Command) {
 	flags := cmd.Flags()
 
@@ -72,6 +98,10 @@ func createFlags(cmd *cobra.Command) {
 	)
 	_ = cmd.RegisterFlagCompletionFunc(initContainerFlagName, common.AutocompleteInitCtr)
 
+	specFlagName := "spec"
+	flags.StringVar(&specFile, specFlagName, "", "Create the container from a SpecGenerator JSON `file` (- for stdin)")
+	_ = cmd.RegisterFlagCompletionFunc(specFlagName, completion.AutocompleteDefault)
+
 	flags.SetInterspersed(false)
 	common.DefineCreateDefaults(&cliVals)
 	common.DefineCreateFlags(cmd, &cliVals, entities.CreateMode)
@@ -118,6 +148,9 @@ func commonFlags(cmd *cobra.Command) error {
 }
 
 func create(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("spec") {
+		return createFromSpec(cmd)
+	}
 	if err := commonFlags(cmd); err != nil {
 		return err
 	}
@@ -147,6 +180,9 @@ func create(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		imageName = name
+		if err := checkScanPolicy(imageName); err != nil {
+			return err
+		}
 	}
 
 	if cmd.Flags().Changed("authfile") {
@@ -205,6 +241,91 @@ func create(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// createFromSpec creates the container from the SpecGenerator JSON document
+// of the --spec file, as written by podman generate spec.  Only the name, the
+// pod and the pull options can be set on the command line.
+func createFromSpec(cmd *cobra.Command) error {
+	var err error
+	cmd.Flags().Visit(func(f *pflag.Flag) {
+		if err == nil && cmd.InheritedFlags().Lookup(f.Name) == nil && !slices.Contains(createSpecFlags, f.Name) {
+			err = fmt.Errorf("--%s cannot be set with --spec, set it in the spec file instead", f.Name)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(cliVals.Pod, "new:") {
+		return errors.New("--pod new: cannot be used with --spec")
+	}
+
+	var content []byte
+	if specFile == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(specFile)
+	}
+	if err != nil {
+		return fmt.Errorf("reading spec file: %w", err)
+	}
+	// Start from the defaults of the fields the document does not set.
+	s := specgen.NewSpecGenerator("", false)
+	if err := json.Unmarshal(content, s); err != nil {
+		return fmt.Errorf("parsing spec file %s: %w", specFile, err)
+	}
+
+	if cmd.Flags().Changed("name") {
+		s.Name = cliVals.Name
+	}
+	if cmd.Flags().Changed("pod") {
+		s.Pod = cliVals.Pod
+	}
+	if s.Rootfs == "" {
+		if s.Image == "" {
+			return fmt.Errorf("spec file %s sets neither an image nor a rootfs", specFile)
+		}
+		rawImageName := s.RawImageName
+		if rawImageName == "" {
+			rawImageName = s.Image
+		}
+		imageName, err := pullImage(cmd, rawImageName, &cliVals)
+		if err != nil {
+			return err
+		}
+		if err := checkScanPolicy(imageName); err != nil {
+			return err
+		}
+		s.Image = imageName
+		s.RawImageName = rawImageName
+	}
+	if cmd.Flags().Changed("authfile") {
+		if err := auth.CheckAuthFile(cliVals.Authfile); err != nil {
+			return err
+		}
+	}
+
+	// Include the command used to create the container.
+	s.ContainerCreateCommand = os.Args
+
+	if cliVals.Replace {
+		if err := replaceContainer(s.Name); err != nil {
+			return err
+		}
+	}
+
+	report, err := registry.ContainerEngine().ContainerCreate(registry.Context(), s)
+	if err != nil {
+		return err
+	}
+
+	if cliVals.CIDFile != "" {
+		if err := util.CreateIDFile(cliVals.CIDFile, report.Id); err != nil {
+			return err
+		}
+	}
+	fmt.Println(report.Id)
+	return nil
+}
+
 func replaceContainer(name string) error {
 	if len(name) == 0 {
 		return errors.New("cannot replace container without --name being set")
@@ -346,10 +467,17 @@ func CreateInit(c *cobra.Command, vals entities.ContainerCreateOptions, isInfra
 
 // Pulls image if any also parses and populates OS, Arch and Variant in specified container create options
 func pullImage(cmd *cobra.Command, imageName string, cliVals *entities.ContainerCreateOptions) (string, error) {
-	pullPolicy, err := config.ParsePullPolicy(cliVals.Pull)
+	pullPolicy, withMaxAge, err := util.ParsePullPolicy(cliVals.Pull)
 	if err != nil {
 		return "", err
 	}
+	var pullMaxAge time.Duration
+	if withMaxAge {
+		pullMaxAge, err = util.ParsePullMaxAge(cliVals.PullMaxAge)
+		if err != nil {
+			return "", err
+		}
+	}
 
 	if cliVals.Platform != "" || cliVals.Arch != "" || cliVals.OS != "" {
 		if cliVals.Platform != "" {
@@ -382,6 +510,7 @@ func pullImage(cmd *cobra.Command, imageName string, cliVals *entities.Container
 		Variant:          cliVals.Variant,
 		SignaturePolicy:  cliVals.SignaturePolicy,
 		PullPolicy:       pullPolicy,
+		PullMaxAge:       pullMaxAge,
 		SkipTLSVerify:    skipTLSVerify,
 		OciDecryptConfig: decConfig,
 		CertDir:          cliVals.CertDir,
@@ -414,10 +543,11 @@ func pullImage(cmd *cobra.Command, imageName string, cliVals *entities.Container
 		pullOptions.Password = creds.Password
 	}
 
-	pullReport, pullErr := registry.ImageEngine().Pull(registry.Context(), imageName, pullOptions)
+	pullReport, pulledName, pullErr := common.PullImage(registry.Context(), imageName, pullOptions)
 	if pullErr != nil {
 		return "", pullErr
 	}
+	imageName = pulledName
 
 	// Return the input name such that the image resolves to correct
 	// repo/tag in the backend (see #8082).  Unless we're referring to
@@ -429,6 +559,19 @@ func pullImage(cmd *cobra.Command, imageName string, cliVals *entities.Container
 	return imageName, nil
 }
 
+// checkScanPolicy refuses images with vulnerabilities reaching the run
+// threshold of the scan configuration of the server.
+func checkScanPolicy(imageName string) error {
+	scanReport, err := registry.ImageEngine().Scan(registry.Context(), imageName, entities.ImageScanOptions{RunPolicy: true})
+	if err != nil {
+		return fmt.Errorf("enforcing the scan policy: %w", err)
+	}
+	if scanReport.Blocked {
+		return fmt.Errorf("image %s has %d vulnerabilities of severity %s or higher, refused by the scan policy (see podman image scan)", imageName, scanReport.Exceeding(), scanReport.Threshold)
+	}
+	return nil
+}
+
 func rmPodIfNecessary(cmd *cobra.Command, s *specgen.SpecGenerator) error {
 	if !strings.HasPrefix(cmd.Flag("pod").Value.String(), "new:") {
 		return nil
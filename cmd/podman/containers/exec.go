@@ -73,7 +73,7 @@ func execFlags(cmd *cobra.Command) {
 	_ = cmd.RegisterFlagCompletionFunc(envFlagName, completion.AutocompleteNone)
 
 	envFileFlagName := "env-file"
-	flags.StringArrayVar(&envFile, envFileFlagName, []string{}, "Read in a file of environment variables")
+	flags.StringArrayVar(&envFile, envFileFlagName, []string{}, "Read in a file, directory or https:// URL of environment variables")
 	_ = cmd.RegisterFlagCompletionFunc(envFileFlagName, completion.AutocompleteDefault)
 
 	flags.BoolVarP(&execOpts.Interactive, "interactive", "i", false, "Make STDIN available to the contained process")
@@ -130,7 +130,7 @@ func exec(cmd *cobra.Command, args []string) error {
 	// Validate given environment variables
 	execOpts.Envs = make(map[string]string)
 	for _, f := range envFile {
-		fileEnv, err := envLib.ParseFile(f)
+		fileEnv, err := envLib.ParseSource(f)
 		if err != nil {
 			return err
 		}
@@ -6,21 +6,23 @@ import (
 
 	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	putils "github.com/dmikushin/podman-shared/cmd/podman/utils"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/spf13/cobra"
 )
 
 var (
-	containerExistsDescription = `If the named container exists in local storage, podman container exists exits with 0, otherwise the exit code will be 1.`
+	containerExistsDescription = `If the named containers exist in local storage, podman container exists exits with 0, otherwise the exit code will be 1.`
 
 	existsCommand = &cobra.Command{
-		Use:   "exists [options] CONTAINER",
+		Use:   "exists [options] CONTAINER [CONTAINER...]",
 		Short: "Check if a container exists in local storage",
 		Long:  containerExistsDescription,
 		Example: `podman container exists --external containerID
-  podman container exists myctr || podman run --name myctr [etc...]`,
+  podman container exists myctr || podman run --name myctr [etc...]
+  podman container exists web db cache`,
 		RunE:              exists,
-		Args:              cobra.ExactArgs(1),
+		Args:              cobra.MinimumNArgs(1),
 		ValidArgsFunction: common.AutocompleteContainers,
 	}
 )
@@ -42,12 +44,29 @@ func exists(cmd *cobra.Command, args []string) error {
 	options := entities.ContainerExistsOptions{
 		External: external,
 	}
-	response, err := registry.ContainerEngine().ContainerExists(context.Background(), strings.TrimPrefix(args[0], "/"), options)
+	if len(args) == 1 {
+		response, err := registry.ContainerEngine().ContainerExists(context.Background(), strings.TrimPrefix(args[0], "/"), options)
+		if err != nil {
+			return err
+		}
+		if !response.Value {
+			registry.SetExitCode(1)
+		}
+		return nil
+	}
+
+	// Check all containers at once, which is a single request for the
+	// remote client.
+	args = putils.RemoveSlash(args)
+	reports, err := registry.ContainerEngine().ContainersExist(context.Background(), args, options)
 	if err != nil {
 		return err
 	}
-	if !response.Value {
-		registry.SetExitCode(1)
+	for _, name := range args {
+		if !reports[name] {
+			registry.SetExitCode(1)
+			break
+		}
 	}
 	return nil
 }
@@ -3,6 +3,7 @@ package containers
 import (
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"strings"
 
@@ -53,6 +54,10 @@ func exportFlags(cmd *cobra.Command) {
 	outputFlagName := "output"
 	flags.StringVarP(&outputFile, outputFlagName, "o", "", "Write to a specified file (default: stdout, which must be redirected)")
 	_ = cmd.RegisterFlagCompletionFunc(outputFlagName, completion.AutocompleteDefault)
+
+	formatFlagName := "format"
+	flags.StringVar(&exportOpts.Format, formatFlagName, entities.ExportFormatTar, "Export the root file system as tar or the container with its configuration as oci-archive")
+	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteExportFormat)
 }
 
 func init() {
@@ -69,6 +74,9 @@ func init() {
 }
 
 func export(_ *cobra.Command, args []string) error {
+	if exportOpts.Format != entities.ExportFormatTar && exportOpts.Format != entities.ExportFormatOCIArchive {
+		return fmt.Errorf("unsupported export format %q, must be %s or %s", exportOpts.Format, entities.ExportFormatTar, entities.ExportFormatOCIArchive)
+	}
 	if len(outputFile) == 0 {
 		file := os.Stdout
 		if term.IsTerminal(int(file.Fd())) {
@@ -0,0 +1,323 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/domain/infra"
+	"github.com/dmikushin/podman-shared/pkg/specgen"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/config"
+)
+
+var (
+	migrateDescription = `Move a container to the host of another connection.
+
+  A running container is checkpointed and restored on the destination, keeping the state of its processes.
+  With --cold, or when it is not running, the container is stopped, committed and created again on the destination.
+  The writable layer and the named volumes of the container are transferred, its image only when it is
+  missing on the destination, so nothing but the changes of the container is transferred when both hosts
+  use the same shared storage.`
+	migrateCommand = &cobra.Command{
+		Use:               "migrate [options] CONTAINER",
+		Short:             "Move a container to another connection",
+		Long:              migrateDescription,
+		RunE:              migrate,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainers,
+		Example: `podman container migrate --destination node2 web
+  podman container migrate --cold --keep --destination node2 db`,
+	}
+)
+
+type migrateOptionsType struct {
+	destination    string
+	cold           bool
+	keep           bool
+	ignoreVolumes  bool
+	tcpEstablished bool
+}
+
+var migrateOptions migrateOptionsType
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: migrateCommand,
+		Parent:  containerCmd,
+	})
+	flags := migrateCommand.Flags()
+
+	destinationFlagName := "destination"
+	flags.StringVarP(&migrateOptions.destination, destinationFlagName, "d", "", "Connection to move the container to")
+	_ = migrateCommand.RegisterFlagCompletionFunc(destinationFlagName, common.AutocompleteSystemConnections)
+	_ = migrateCommand.MarkFlagRequired(destinationFlagName)
+
+	flags.BoolVar(&migrateOptions.cold, "cold", false, "Stop the container and start it again on the destination instead of checkpointing it")
+	flags.BoolVarP(&migrateOptions.keep, "keep", "k", false, "Keep the container on the source")
+	flags.BoolVar(&migrateOptions.ignoreVolumes, "ignore-volumes", false, "Do not transfer the named volumes of the container")
+	flags.BoolVar(&migrateOptions.tcpEstablished, "tcp-established", false, "Migrate a running container with established TCP connections")
+}
+
+// migration moves a container from the engines of the current connection to
+// the ones of the destination.
+type migration struct {
+	ctx       context.Context
+	src       entities.ContainerEngine
+	srcImages entities.ImageEngine
+	dst       entities.ContainerEngine
+	dstImages entities.ImageEngine
+	tmpDir    string
+}
+
+func migrate(_ *cobra.Command, args []string) error {
+	cfg, err := config.Default()
+	if err != nil {
+		return err
+	}
+	con, err := cfg.GetConnection(migrateOptions.destination, false)
+	if err != nil {
+		return err
+	}
+	dstConfig := &entities.PodmanConfig{
+		EngineMode:  entities.TunnelMode,
+		URI:         con.URI,
+		Identity:    con.Identity,
+		TLSCertFile: con.TLSCert,
+		TLSKeyFile:  con.TLSKey,
+		TLSCAFile:   con.TLSCA,
+		MachineMode: con.IsMachine,
+	}
+	m := &migration{
+		ctx:       registry.Context(),
+		src:       registry.ContainerEngine(),
+		srcImages: registry.ImageEngine(),
+	}
+	if m.dst, err = infra.NewContainerEngine(dstConfig); err != nil {
+		return fmt.Errorf("connecting to %q: %w", con.Name, err)
+	}
+	if m.dstImages, err = infra.NewImageEngine(dstConfig); err != nil {
+		return fmt.Errorf("connecting to %q: %w", con.Name, err)
+	}
+	if m.tmpDir, err = os.MkdirTemp("", "podman-migrate-"); err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(m.tmpDir); err != nil {
+			logrus.Errorf("Removing %s: %v", m.tmpDir, err)
+		}
+	}()
+
+	inspected, errs, err := m.src.ContainerInspect(m.ctx, []string{strings.TrimPrefix(args[0], "/")}, entities.InspectOptions{})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	ctr := inspected[0]
+
+	var id string
+	if ctr.State.Running && !migrateOptions.cold {
+		id, err = m.live(ctr)
+	} else {
+		id, err = m.coldMigrate(ctr)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !migrateOptions.keep {
+		reports, err := m.src.ContainerRm(m.ctx, []string{ctr.ID}, entities.RmOptions{Force: true})
+		if err != nil {
+			return fmt.Errorf("removing container %s after migrating it: %w", ctr.Name, err)
+		}
+		for _, r := range reports {
+			if r.Err != nil {
+				return fmt.Errorf("removing container %s after migrating it: %w", ctr.Name, r.Err)
+			}
+		}
+	}
+	fmt.Println(id)
+	return nil
+}
+
+// transferImage copies the image to the destination, unless it is already
+// there, which is the case when both hosts use the same shared storage.
+func (m *migration) transferImage(image string) error {
+	exists, err := m.dstImages.Exists(m.ctx, image)
+	if err != nil {
+		return err
+	}
+	if exists.Value {
+		logrus.Debugf("Image %s is available on the destination", image)
+		return nil
+	}
+	fmt.Fprintf(os.Stderr, "Transferring image %s\n", image)
+	archive := filepath.Join(m.tmpDir, "image.tar")
+	defer os.Remove(archive)
+	if err := m.srcImages.Save(m.ctx, image, nil, entities.ImageSaveOptions{Format: "docker-archive", Output: archive, Quiet: true}); err != nil {
+		return fmt.Errorf("saving image %s: %w", image, err)
+	}
+	if _, err := m.dstImages.Load(m.ctx, entities.ImageLoadOptions{Input: archive, Quiet: true}); err != nil {
+		return fmt.Errorf("loading image %s on the destination: %w", image, err)
+	}
+	return nil
+}
+
+// live checkpoints the running container and restores it on the
+// destination.  The container is restored on the source again when the
+// destination fails to restore it.
+func (m *migration) live(ctr *entities.ContainerInspectReport) (string, error) {
+	if err := m.transferImage(ctr.ImageName); err != nil {
+		return "", err
+	}
+	archive := filepath.Join(m.tmpDir, "checkpoint.tar.gz")
+	fmt.Fprintf(os.Stderr, "Checkpointing container %s\n", ctr.Name)
+	reports, err := m.src.ContainerCheckpoint(m.ctx, []string{ctr.ID}, entities.CheckpointOptions{
+		Export:         archive,
+		Keep:           true,
+		IgnoreVolumes:  migrateOptions.ignoreVolumes,
+		TCPEstablished: migrateOptions.tcpEstablished,
+	})
+	if err == nil && len(reports) > 0 {
+		err = reports[0].Err
+	}
+	if err != nil {
+		return "", fmt.Errorf("checkpointing container %s: %w", ctr.Name, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Restoring container %s on the destination\n", ctr.Name)
+	restored, err := m.dst.ContainerRestore(m.ctx, nil, entities.RestoreOptions{
+		Import:         archive,
+		IgnoreVolumes:  migrateOptions.ignoreVolumes,
+		TCPEstablished: migrateOptions.tcpEstablished,
+	})
+	if err == nil && len(restored) > 0 {
+		err = restored[0].Err
+	}
+	if err == nil && len(restored) == 0 {
+		err = errors.New("no container was restored")
+	}
+	if err != nil {
+		if _, restoreErr := m.src.ContainerRestore(m.ctx, []string{ctr.ID}, entities.RestoreOptions{TCPEstablished: migrateOptions.tcpEstablished}); restoreErr != nil {
+			logrus.Errorf("Restoring container %s on the source: %v", ctr.Name, restoreErr)
+		}
+		return "", fmt.Errorf("restoring container %s on the destination: %w", ctr.Name, err)
+	}
+	return restored[0].Id, nil
+}
+
+// coldMigrate stops the container, commits its writable layer and creates it
+// again on the destination from the committed image.
+func (m *migration) coldMigrate(ctr *entities.ContainerInspectReport) (string, error) {
+	running := ctr.State.Running
+	if running {
+		fmt.Fprintf(os.Stderr, "Stopping container %s\n", ctr.Name)
+		reports, err := m.src.ContainerStop(m.ctx, []string{ctr.ID}, entities.StopOptions{})
+		if err == nil && len(reports) > 0 {
+			err = reports[0].Err
+		}
+		if err != nil {
+			return "", fmt.Errorf("stopping container %s: %w", ctr.Name, err)
+		}
+	}
+
+	generated, err := m.src.GenerateSpec(m.ctx, &entities.GenerateSpecOptions{ID: ctr.ID})
+	if err != nil {
+		return "", err
+	}
+	s := new(specgen.SpecGenerator)
+	if err := json.Unmarshal(generated.Data, s); err != nil {
+		return "", fmt.Errorf("parsing the configuration of container %s: %w", ctr.Name, err)
+	}
+
+	image := "localhost/" + strings.ToLower(ctr.Name) + ":migrated-" + ctr.ID[:12]
+	fmt.Fprintf(os.Stderr, "Committing container %s to %s\n", ctr.Name, image)
+	if _, err := m.src.ContainerCommit(m.ctx, ctr.ID, entities.CommitOptions{ImageName: image, Format: "oci", Quiet: true}); err != nil {
+		return "", fmt.Errorf("committing container %s: %w", ctr.Name, err)
+	}
+	defer func() {
+		if _, errs := m.srcImages.Remove(m.ctx, []string{image}, entities.ImageRemoveOptions{}); len(errs) > 0 {
+			logrus.Errorf("Removing image %s: %v", image, errs[0])
+		}
+	}()
+	if err := m.transferImage(image); err != nil {
+		return "", err
+	}
+
+	if !migrateOptions.ignoreVolumes {
+		for _, mount := range ctr.Mounts {
+			if mount.Type != "volume" {
+				continue
+			}
+			if err := m.transferVolume(mount.Name); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	s.Image = image
+	s.RawImageName = image
+	created, err := m.dst.ContainerCreate(m.ctx, s)
+	if err != nil {
+		return "", fmt.Errorf("creating container %s on the destination: %w", ctr.Name, err)
+	}
+	if running {
+		reports, err := m.dst.ContainerStart(m.ctx, []string{created.Id}, entities.ContainerStartOptions{})
+		if err == nil && len(reports) > 0 {
+			err = reports[0].Err
+		}
+		if err != nil {
+			return "", fmt.Errorf("starting container %s on the destination: %w", ctr.Name, err)
+		}
+	}
+	return created.Id, nil
+}
+
+// transferVolume creates the named volume on the destination and copies its
+// content, streaming it from the source.
+func (m *migration) transferVolume(name string) error {
+	exists, err := m.dst.VolumeExists(m.ctx, name)
+	if err != nil {
+		return err
+	}
+	if exists.Value {
+		return fmt.Errorf("volume %s already exists on the destination, use --ignore-volumes to not transfer volumes", name)
+	}
+	inspected, errs, err := m.src.VolumeInspect(m.ctx, []string{name}, entities.InspectOptions{})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs[0]
+	}
+	vol := inspected[0]
+	if _, err := m.dst.VolumeCreate(m.ctx, entities.VolumeCreateOptions{
+		Name:    vol.Name,
+		Driver:  vol.Driver,
+		Labels:  vol.Labels,
+		Options: vol.Options,
+	}); err != nil {
+		return fmt.Errorf("creating volume %s on the destination: %w", name, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "Transferring volume %s\n", name)
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(m.src.VolumeExport(m.ctx, name, entities.VolumeExportOptions{Output: writer}))
+	}()
+	if err := m.dst.VolumeImport(m.ctx, name, entities.VolumeImportOptions{Input: reader}); err != nil {
+		reader.CloseWithError(err)
+		return fmt.Errorf("transferring volume %s: %w", name, err)
+	}
+	return nil
+}
@@ -28,21 +28,24 @@ var (
 		ValidArgsFunction: common.AutocompleteContainersRunning,
 		Example: `podman pause mywebserver
   podman pause 860a4b23
-  podman pause --all`,
+  podman pause --all
+  podman pause --freeze-timeout 30 mywebserver`,
 	}
 
 	containerPauseCommand = &cobra.Command{
-		Use:   pauseCommand.Use,
-		Short: pauseCommand.Short,
-		Long:  pauseCommand.Long,
-		RunE:  pauseCommand.RunE,
+		Use:     pauseCommand.Use,
+		Aliases: []string{"freeze"},
+		Short:   pauseCommand.Short,
+		Long:    pauseCommand.Long,
+		RunE:    pauseCommand.RunE,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validate.CheckAllLatestAndIDFile(cmd, args, false, "cidfile")
 		},
 		ValidArgsFunction: pauseCommand.ValidArgsFunction,
 		Example: `podman container pause mywebserver
   podman container pause 860a4b23
-  podman container pause --all`,
+  podman container pause --all
+  podman container freeze --freeze-timeout 30 mywebserver`,
 	}
 )
 
@@ -66,6 +69,10 @@ func pauseFlags(cmd *cobra.Command) {
 	flags.StringArrayVarP(&filters, filterFlagName, "f", []string{}, "Filter output based on conditions given")
 	_ = cmd.RegisterFlagCompletionFunc(filterFlagName, common.AutocompletePsFilters)
 
+	freezeTimeoutFlagName := "freeze-timeout"
+	flags.UintVar(&pauseOpts.FreezeTimeout, freezeTimeoutFlagName, 0, "Unpause the containers automatically after `seconds`, 0 to keep them paused")
+	_ = cmd.RegisterFlagCompletionFunc(freezeTimeoutFlagName, completion.AutocompleteNone)
+
 	if registry.IsRemote() {
 		_ = flags.MarkHidden("cidfile")
 	}
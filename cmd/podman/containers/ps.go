@@ -4,7 +4,9 @@ import (
 	"cmp"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"reflect"
 	"slices"
 	"strconv"
 	"strings"
@@ -34,7 +36,8 @@ var (
 		ValidArgsFunction: completion.AutocompleteNone,
 		Example: `podman ps -a
   podman ps -a --format "{{.ID}}  {{.Image}}  {{.Labels}}  {{.Mounts}}"
-  podman ps --size --sort names`,
+  podman ps --size --sort names
+  podman ps -a --watch 2 --format json-stream`,
 	}
 
 	psContainerCommand = &cobra.Command{
@@ -81,7 +84,7 @@ func listFlagSet(cmd *cobra.Command) {
 	_ = cmd.RegisterFlagCompletionFunc(filterFlagName, common.AutocompletePsFilters)
 
 	formatFlagName := "format"
-	flags.StringVar(&listOpts.Format, formatFlagName, "", "Pretty-print containers to JSON or using a Go template")
+	flags.StringVar(&listOpts.Format, formatFlagName, "", "Pretty-print containers to JSON, JSON lines (json-stream) or using a Go template")
 	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&psReporter{}))
 
 	lastFlagName := "last"
@@ -97,10 +100,10 @@ func listFlagSet(cmd *cobra.Command) {
 	flags.BoolVar(&listOpts.Sync, "sync", false, "Sync container state with OCI runtime")
 
 	watchFlagName := "watch"
-	flags.UintVarP(&listOpts.Watch, watchFlagName, "w", 0, "Watch the ps output on an interval in seconds")
+	flags.UintVarP(&listOpts.Watch, watchFlagName, "w", 0, "Refresh the ps output on container events and at least every interval in seconds")
 	_ = cmd.RegisterFlagCompletionFunc(watchFlagName, completion.AutocompleteNone)
 
-	sort := validate.Value(&listOpts.Sort, "command", "created", "id", "image", "names", "runningfor", "size", "status")
+	sort := validate.Value(&listOpts.Sort, "command", "created", "id", "image", "memory", "names", "runningfor", "size", "status")
 	sortFlagName := "sort"
 	flags.Var(sort, sortFlagName, "Sort output by: "+sort.Choices())
 	_ = cmd.RegisterFlagCompletionFunc(sortFlagName, common.AutocompletePsSort)
@@ -138,20 +141,28 @@ func checkFlags(c *cobra.Command) error {
 	return nil
 }
 
-func jsonOut(responses []entities.ListContainer) error {
-	type jsonFormat struct {
-		entities.ListContainer
-		Created int64
+// psJSON is the JSON representation of a container in the ps output.
+type psJSON struct {
+	entities.ListContainer
+	Created int64
+	// Removed is only set by json-stream when the container is no longer
+	// part of the listing.
+	Removed bool `json:",omitempty"`
+}
+
+func newPsJSON(con entities.ListContainer) psJSON {
+	con.CreatedAt = units.HumanDuration(time.Since(con.Created)) + " ago"
+	con.Status = psReporter{con}.Status()
+	return psJSON{
+		ListContainer: con,
+		Created:       con.Created.Unix(),
 	}
-	r := make([]jsonFormat, 0)
+}
+
+func jsonOut(responses []entities.ListContainer) error {
+	r := make([]psJSON, 0)
 	for _, con := range responses {
-		con.CreatedAt = units.HumanDuration(time.Since(con.Created)) + " ago"
-		con.Status = psReporter{con}.Status()
-		jf := jsonFormat{
-			ListContainer: con,
-			Created:       con.Created.Unix(),
-		}
-		r = append(r, jf)
+		r = append(r, newPsJSON(con))
 	}
 	b, err := json.MarshalIndent(r, "", "  ")
 	if err != nil {
@@ -161,6 +172,47 @@ func jsonOut(responses []entities.ListContainer) error {
 	return nil
 }
 
+// jsonStreamOut prints one JSON object per line for each container.  In watch
+// mode the listing is refreshed and only the containers which changed, were
+// added or disappeared since the previous listing are printed again.
+func jsonStreamOut(responses []entities.ListContainer, refresh <-chan struct{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	previous := make(map[string]entities.ListContainer)
+	for {
+		current := make(map[string]entities.ListContainer, len(responses))
+		for _, con := range responses {
+			current[con.ID] = con
+			if prev, ok := previous[con.ID]; ok && reflect.DeepEqual(prev, con) {
+				continue
+			}
+			if err := enc.Encode(newPsJSON(con)); err != nil {
+				return err
+			}
+		}
+		for _, id := range slices.Sorted(maps.Keys(previous)) {
+			if _, ok := current[id]; ok {
+				continue
+			}
+			entry := newPsJSON(previous[id])
+			entry.Removed = true
+			if err := enc.Encode(entry); err != nil {
+				return err
+			}
+		}
+		if refresh == nil {
+			return nil
+		}
+
+		previous = current
+		<-refresh
+		var err error
+		responses, err = getResponses()
+		if err != nil {
+			return err
+		}
+	}
+}
+
 func quietOut(responses []entities.ListContainer) {
 	for _, r := range responses {
 		id := r.ID
@@ -171,18 +223,9 @@ func quietOut(responses []entities.ListContainer) {
 	}
 }
 
+// getResponses lists the containers, sorted by the engine.
 func getResponses() ([]entities.ListContainer, error) {
-	responses, err := registry.ContainerEngine().ContainerList(registry.Context(), listOpts)
-	if err != nil {
-		return nil, err
-	}
-	if len(listOpts.Sort) > 0 {
-		responses, err = entities.SortPsOutput(listOpts.Sort, responses)
-		if err != nil {
-			return nil, err
-		}
-	}
-	return responses, nil
+	return registry.ContainerEngine().ContainerList(registry.Context(), listOpts)
 }
 
 func ps(cmd *cobra.Command, _ []string) error {
@@ -205,14 +248,15 @@ func ps(cmd *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
-	if len(listOpts.Sort) > 0 {
-		listContainers, err = entities.SortPsOutput(listOpts.Sort, listContainers)
-		if err != nil {
-			return err
-		}
+
+	var refresh <-chan struct{}
+	if listOpts.Watch > 0 {
+		refresh = common.WatchRefresh(registry.Context(), time.Duration(listOpts.Watch)*time.Second, "container")
 	}
 
 	switch {
+	case listOpts.Format == common.JSONStreamFormat:
+		return jsonStreamOut(listContainers, refresh)
 	case report.IsJSON(listOpts.Format):
 		return jsonOut(listContainers)
 	case listOpts.Quiet && !cmd.Flags().Changed("format"):
@@ -253,7 +297,7 @@ func ps(cmd *cobra.Command, _ []string) error {
 	}
 
 	switch {
-	// Output table Watch > 0 will refresh screen
+	// Output table Watch > 0 will refresh screen on events and on the interval
 	case listOpts.Watch > 0:
 		// responses will grow to the largest number of processes reported on, but will not thrash the gc
 		var responses []psReporter
@@ -279,7 +323,7 @@ func ps(cmd *cobra.Command, _ []string) error {
 				return err
 			}
 
-			time.Sleep(time.Duration(listOpts.Watch) * time.Second)
+			<-refresh
 		}
 	default:
 		if err := headers(); err != nil {
@@ -385,6 +429,9 @@ func (l psReporter) Status() string {
 	if hc != "" {
 		state += " (" + hc + ")"
 	}
+	if l.ListContainer.StorageTransient {
+		state += " (transient)"
+	}
 	return state
 }
 
@@ -0,0 +1,32 @@
+package containers
+
+import (
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	recordStatsCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "record-stats CONTAINER",
+		Short:             "Record a sample of the resource usage of a container in its stats history",
+		Long:              "Record a sample of the resource usage of a container in its stats history. The sample is recorded by the systemd timer of containers created with --stats-history.",
+		RunE:              recordStats,
+		Args:              cobra.ExactArgs(1),
+		Hidden:            true,
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example:           `podman container record-stats ctrID`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: recordStatsCommand,
+		Parent:  containerCmd,
+	})
+}
+
+func recordStats(_ *cobra.Command, args []string) error {
+	return registry.ContainerEngine().ContainerStatsRecord(registry.Context(), args[0])
+}
@@ -0,0 +1,60 @@
+package containers
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	restoreBackupDescription = `Recreate a container, with the changes of its writable layer and its named volumes, from a backup written by "podman container backup".
+
+  The image of the container is pulled if it is missing.`
+
+	restoreBackupCommand = &cobra.Command{
+		Use:               "restore-backup [options] FILE",
+		Short:             "Restore a container from a backup",
+		Long:              restoreBackupDescription,
+		RunE:              restoreBackup,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman container restore-backup web.tar
+  podman --connection prod container restore-backup --name web-copy web.tar`,
+	}
+)
+
+var restoreBackupOpts entities.ContainerRestoreBackupOptions
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: restoreBackupCommand,
+		Parent:  containerCmd,
+	})
+	flags := restoreBackupCommand.Flags()
+
+	nameFlagName := "name"
+	flags.StringVarP(&restoreBackupOpts.Name, nameFlagName, "n", "", "Restore the container with the specified name instead of the name in the backup")
+	_ = restoreBackupCommand.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&restoreBackupOpts.IgnoreVolumes, "ignore-volumes", false, "Do not restore the named volumes of the backup")
+}
+
+func restoreBackup(_ *cobra.Command, args []string) error {
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	restoreBackupOpts.Input = file
+
+	report, err := registry.ContainerEngine().ContainerRestoreBackup(registry.Context(), restoreBackupOpts)
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Id)
+	return nil
+}
@@ -73,6 +73,9 @@ func runFlags(cmd *cobra.Command) {
 	flags.UintSliceVar(&runOpts.PreserveFD, preserveFdFlagName, nil, "Pass a file descriptor into the container")
 	_ = cmd.RegisterFlagCompletionFunc(preserveFdFlagName, completion.AutocompleteNone)
 
+	socketActivationFlagName := "socket-activation"
+	flags.BoolVar(&cliVals.SocketActivation, socketActivationFlagName, false, "Pass the sockets of LISTEN_FDS or of --preserve-fds to the container with socket activation")
+
 	flags.BoolVarP(&runOpts.Detach, "detach", "d", false, "Run container in background and print container ID")
 
 	detachKeysFlagName := "detach-keys"
@@ -85,6 +88,7 @@ func runFlags(cmd *cobra.Command) {
 	if registry.IsRemote() {
 		_ = flags.MarkHidden(preserveFdsFlagName)
 		_ = flags.MarkHidden(preserveFdFlagName)
+		_ = flags.MarkHidden(socketActivationFlagName)
 		_ = flags.MarkHidden("conmon-pidfile")
 		_ = flags.MarkHidden("pidfile")
 	}
@@ -144,6 +148,9 @@ func run(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("file descriptor %d is not available - the preserve-fds option requires that file descriptors must be passed", fd)
 		}
 	}
+	if cliVals.SocketActivation && runOpts.PreserveFDs == 0 && len(runOpts.PreserveFD) == 0 && os.Getenv("LISTEN_FDS") == "" {
+		return errors.New("--socket-activation requires sockets passed in LISTEN_FDS or with --preserve-fds")
+	}
 
 	imageName := args[0]
 	rawImageName := ""
@@ -154,6 +161,9 @@ func run(cmd *cobra.Command, args []string) error {
 			return err
 		}
 		imageName = name
+		if err := checkScanPolicy(imageName); err != nil {
+			return err
+		}
 	}
 
 	if cliVals.Replace {
@@ -13,6 +13,7 @@ import (
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
 )
 
 var (
@@ -64,6 +65,12 @@ func startFlags(cmd *cobra.Command) {
 
 	flags.BoolVar(&startOptions.All, "all", false, "Start all containers regardless of their state or configuration")
 
+	if !registry.IsRemote() {
+		jobsFlagName := "jobs"
+		flags.UintVar(&startOptions.Jobs, jobsFlagName, 0, "Number of containers started in parallel, 0 for the default")
+		_ = cmd.RegisterFlagCompletionFunc(jobsFlagName, completion.AutocompleteNone)
+	}
+
 	if registry.IsRemote() {
 		_ = flags.MarkHidden("sig-proxy")
 	}
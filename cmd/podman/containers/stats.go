@@ -29,7 +29,8 @@ var (
 		ValidArgsFunction: common.AutocompleteContainersRunning,
 		Example: `podman stats --all --no-stream
   podman stats ctrID
-  podman stats --no-stream --format "table {{.ID}} {{.Name}} {{.MemUsage}}" ctrID`,
+  podman stats --no-stream --format "table {{.ID}} {{.Name}} {{.MemUsage}}" ctrID
+  podman stats --history --since 1h ctrID`,
 	}
 
 	containerStatsCommand = &cobra.Command{
@@ -41,7 +42,8 @@ var (
 		ValidArgsFunction: statsCommand.ValidArgsFunction,
 		Example: `podman container stats --all --no-stream
   podman container stats ctrID
-  podman container stats --no-stream --format "table {{.ID}} {{.Name}} {{.MemUsage}}" ctrID`,
+  podman container stats --no-stream --format "table {{.ID}} {{.Name}} {{.MemUsage}}" ctrID
+  podman container stats --history --since 1h ctrID`,
 	}
 )
 
@@ -54,6 +56,11 @@ type statsOptionsCLI struct {
 	NoReset  bool
 	NoStream bool
 	Interval int
+	History  bool
+	Since    string
+	Until    string
+	// MemoryBreakdown switches the default table to the memory breakdown.
+	MemoryBreakdown bool
 }
 
 var (
@@ -70,12 +77,21 @@ func statFlags(cmd *cobra.Command) {
 	flags.StringVar(&statsOptions.Format, formatFlagName, "", "Pretty-print container statistics to JSON or using a Go template")
 	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&containerStats{}))
 
+	flags.BoolVar(&statsOptions.MemoryBreakdown, "memory-breakdown", false, "Show the memory breakdown and working set of the containers instead of the default columns")
 	flags.BoolVar(&notrunc, "no-trunc", false, "Do not truncate output")
 	flags.BoolVar(&statsOptions.NoReset, "no-reset", false, "Disable resetting the screen between intervals")
 	flags.BoolVar(&statsOptions.NoStream, "no-stream", false, "Disable streaming stats and only pull the first result, default setting is false")
 	intervalFlagName := "interval"
 	flags.IntVarP(&statsOptions.Interval, intervalFlagName, "i", 5, "Time in seconds between stats reports")
 	_ = cmd.RegisterFlagCompletionFunc(intervalFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&statsOptions.History, "history", false, "Show the samples recorded in the stats history of the containers")
+	sinceFlagName := "since"
+	flags.StringVar(&statsOptions.Since, sinceFlagName, "", "Show the samples of the stats history recorded since the `time`")
+	_ = cmd.RegisterFlagCompletionFunc(sinceFlagName, completion.AutocompleteNone)
+	untilFlagName := "until"
+	flags.StringVar(&statsOptions.Until, untilFlagName, "", "Show the samples of the stats history recorded until the `time`")
+	_ = cmd.RegisterFlagCompletionFunc(untilFlagName, completion.AutocompleteNone)
 }
 
 func init() {
@@ -95,7 +111,18 @@ func init() {
 
 // stats is different in that it will assume running containers if
 // no input is given, so we need to validate differently
-func checkStatOptions(_ *cobra.Command, args []string) error {
+func checkStatOptions(cmd *cobra.Command, args []string) error {
+	if !statsOptions.History && (cmd.Flags().Changed("since") || cmd.Flags().Changed("until")) {
+		return errors.New("--since and --until can only be used with --history")
+	}
+	if statsOptions.MemoryBreakdown {
+		if statsOptions.History {
+			return errors.New("--memory-breakdown cannot be used with --history")
+		}
+		if cmd.Flags().Changed("format") {
+			return errors.New("--memory-breakdown and --format cannot be used together")
+		}
+	}
 	opts := 0
 	if statsOptions.All {
 		opts++
@@ -113,6 +140,9 @@ func checkStatOptions(_ *cobra.Command, args []string) error {
 }
 
 func stats(cmd *cobra.Command, args []string) error {
+	if statsOptions.History {
+		return statsHistory(cmd, args)
+	}
 	// Convert to the entities options.  We should not leak CLI-only
 	// options into the backend and separate concerns.
 	opts := entities.ContainerStatsOptions{
@@ -146,6 +176,10 @@ func outputStats(cmd *cobra.Command, reports []define.ContainerStats) error {
 		"MemUsage":      "MEM USAGE / LIMIT",
 		"MemUsageBytes": "MEM USAGE / LIMIT",
 		"MemPerc":       "MEM %",
+		"MemAnon":       "MEM ANON",
+		"MemFile":       "MEM FILE",
+		"MemSlab":       "MEM SLAB",
+		"MemWorkingSet": "WORKING SET",
 		"NetIO":         "NET IO",
 		"BlockIO":       "BLOCK IO",
 		"PIDS":          "PIDS",
@@ -167,6 +201,9 @@ func outputStats(cmd *cobra.Command, reports []define.ContainerStats) error {
 	var err error
 	if cmd.Flags().Changed("format") {
 		rpt, err = rpt.Parse(report.OriginUser, statsOptions.Format)
+	} else if statsOptions.MemoryBreakdown {
+		format := "{{range .}}{{.ID}}\t{{.Name}}\t{{.MemUsage}}\t{{.MemWorkingSet}}\t{{.MemAnon}}\t{{.MemFile}}\t{{.MemSlab}}\t{{.MemPerc}}\n{{end -}}"
+		rpt, err = rpt.Parse(report.OriginPodman, format)
 	} else {
 		format := "{{range .}}{{.ID}}\t{{.Name}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDS}}\t{{.UpTime}}\t{{.AVGCPU}}\n{{end -}}"
 		rpt, err = rpt.Parse(report.OriginPodman, format)
@@ -238,6 +275,22 @@ func (s *containerStats) MemUsageBytes() string {
 	return combineBytesValues(s.ContainerStats.MemUsage, s.ContainerStats.MemLimit)
 }
 
+func (s *containerStats) MemAnon() string {
+	return units.HumanSize(float64(s.ContainerStats.MemAnon))
+}
+
+func (s *containerStats) MemFile() string {
+	return units.HumanSize(float64(s.ContainerStats.MemFile))
+}
+
+func (s *containerStats) MemSlab() string {
+	return units.HumanSize(float64(s.ContainerStats.MemSlab))
+}
+
+func (s *containerStats) MemWorkingSet() string {
+	return units.HumanSize(float64(s.ContainerStats.MemWorkingSet))
+}
+
 func floatToPercentString(f float64) string {
 	return fmt.Sprintf("%.2f%%", f)
 }
@@ -259,6 +312,10 @@ func outputJSON(stats []containerStats) error {
 		AverageCPU string `json:"avg_cpu"`
 		MemUsage   string `json:"mem_usage"`
 		MemPerc    string `json:"mem_percent"`
+		MemAnon    string `json:"mem_anon"`
+		MemFile    string `json:"mem_file"`
+		MemSlab    string `json:"mem_slab"`
+		WorkingSet string `json:"mem_working_set"`
 		NetIO      string `json:"net_io"`
 		BlockIO    string `json:"block_io"`
 		Pids       string `json:"pids"`
@@ -273,6 +330,10 @@ func outputJSON(stats []containerStats) error {
 			AverageCPU: j.AVGCPU(),
 			MemUsage:   j.MemUsage(),
 			MemPerc:    j.MemPerc(),
+			MemAnon:    j.MemAnon(),
+			MemFile:    j.MemFile(),
+			MemSlab:    j.MemSlab(),
+			WorkingSet: j.MemWorkingSet(),
 			NetIO:      j.NetIO(),
 			BlockIO:    j.BlockIO(),
 			Pids:       j.PIDS(),
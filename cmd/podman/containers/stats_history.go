@@ -0,0 +1,150 @@
+package containers
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	putils "github.com/dmikushin/podman-shared/cmd/podman/utils"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/report"
+)
+
+// statsHistory prints the samples of the stats history of the containers, one
+// line per sample.
+func statsHistory(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("interval") {
+		return errors.New("--interval cannot be used with --history")
+	}
+	opts := entities.ContainerStatsHistoryOptions{
+		All:    statsOptions.All,
+		Latest: statsOptions.Latest,
+		Since:  statsOptions.Since,
+		Until:  statsOptions.Until,
+	}
+	args = putils.RemoveSlash(args)
+	reports, err := registry.ContainerEngine().ContainerStatsHistory(registry.Context(), args, opts)
+	if err != nil {
+		return err
+	}
+
+	samples := []containerStatsSample{}
+	for _, r := range reports {
+		for _, s := range r.Samples {
+			samples = append(samples, containerStatsSample{ContainerStatsSample: s, ContainerID: r.Id, Name: r.Name})
+		}
+	}
+
+	if report.IsJSON(statsOptions.Format) {
+		return outputHistoryJSON(samples)
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, statsOptions.Format)
+	} else {
+		format := "{{range .}}{{.ID}}\t{{.Name}}\t{{.Time}}\t{{.CPUPerc}}\t{{.MemUsage}}\t{{.MemPerc}}\t{{.NetIO}}\t{{.BlockIO}}\t{{.PIDS}}\n{{end -}}"
+		rpt, err = rpt.Parse(report.OriginPodman, format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders {
+		headers := report.Headers(containerStatsSample{}, map[string]string{
+			"ID":       "ID",
+			"CPUPerc":  "CPU %",
+			"MemUsage": "MEM USAGE / LIMIT",
+			"MemPerc":  "MEM %",
+			"NetIO":    "NET IO",
+			"BlockIO":  "BLOCK IO",
+			"PIDS":     "PIDS",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return err
+		}
+	}
+	return rpt.Execute(samples)
+}
+
+type containerStatsSample struct {
+	define.ContainerStatsSample
+	ContainerID string
+	Name        string
+}
+
+func (s *containerStatsSample) ID() string {
+	if notrunc {
+		return s.ContainerID
+	}
+	return s.ContainerID[0:12]
+}
+
+func (s *containerStatsSample) Time() string {
+	return s.ContainerStatsSample.Time.Local().Format(time.RFC3339)
+}
+
+func (s *containerStatsSample) CPUPerc() string {
+	return floatToPercentString(s.CPU)
+}
+
+func (s *containerStatsSample) MemUsage() string {
+	return combineHumanValues(s.ContainerStatsSample.MemUsage, s.MemLimit)
+}
+
+func (s *containerStatsSample) MemPerc() string {
+	return floatToPercentString(s.ContainerStatsSample.MemPerc)
+}
+
+func (s *containerStatsSample) NetIO() string {
+	return combineHumanValues(s.NetInput, s.NetOutput)
+}
+
+func (s *containerStatsSample) BlockIO() string {
+	return combineHumanValues(s.BlockInput, s.BlockOutput)
+}
+
+func (s *containerStatsSample) PIDS() string {
+	return strconv.FormatUint(s.PIDs, 10)
+}
+
+func outputHistoryJSON(samples []containerStatsSample) error {
+	type jsample struct {
+		Id         string    `json:"id"`
+		Name       string    `json:"name"`
+		Time       time.Time `json:"time"`
+		CpuPercent string    `json:"cpu_percent"`
+		MemUsage   string    `json:"mem_usage"`
+		MemPerc    string    `json:"mem_percent"`
+		NetIO      string    `json:"net_io"`
+		BlockIO    string    `json:"block_io"`
+		Pids       string    `json:"pids"`
+	}
+	jsamples := make([]jsample, 0, len(samples))
+	for _, s := range samples {
+		jsamples = append(jsamples, jsample{
+			Id:         s.ID(),
+			Name:       s.Name,
+			Time:       s.ContainerStatsSample.Time,
+			CpuPercent: s.CPUPerc(),
+			MemUsage:   s.MemUsage(),
+			MemPerc:    s.MemPerc(),
+			NetIO:      s.NetIO(),
+			BlockIO:    s.BlockIO(),
+			Pids:       s.PIDS(),
+		})
+	}
+	b, err := json.MarshalIndent(jsamples, "", " ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(b))
+	return nil
+}
@@ -73,6 +73,12 @@ func stopFlags(cmd *cobra.Command) {
 	flags.StringArrayVarP(&filters, filterFlagName, "f", []string{}, "Filter output based on conditions given")
 	_ = cmd.RegisterFlagCompletionFunc(filterFlagName, common.AutocompletePsFilters)
 
+	if !registry.IsRemote() {
+		jobsFlagName := "jobs"
+		flags.UintVar(&stopOptions.Jobs, jobsFlagName, 0, "Number of containers stopped in parallel, 0 for the default")
+		_ = cmd.RegisterFlagCompletionFunc(jobsFlagName, completion.AutocompleteNone)
+	}
+
 	if registry.IsRemote() {
 		_ = flags.MarkHidden("cidfile")
 		_ = flags.MarkHidden("ignore")
@@ -0,0 +1,32 @@
+package containers
+
+import (
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/spf13/cobra"
+)
+
+var (
+	thawExpiredCommand = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "thaw-expired CONTAINER",
+		Short:             "Unpause a container whose freeze timeout expired",
+		Long:              "Unpause a container whose freeze timeout expired. The container is unpaused by the systemd timer of containers paused with --freeze-timeout.",
+		RunE:              thawExpired,
+		Args:              cobra.ExactArgs(1),
+		Hidden:            true,
+		ValidArgsFunction: common.AutocompleteContainersPaused,
+		Example:           `podman container thaw-expired ctrID`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: thawExpiredCommand,
+		Parent:  containerCmd,
+	})
+}
+
+func thawExpired(_ *cobra.Command, args []string) error {
+	return registry.ContainerEngine().ContainerThawExpired(registry.Context(), args[0])
+}
@@ -34,10 +34,11 @@ var (
 	}
 
 	containerUnpauseCommand = &cobra.Command{
-		Use:   unpauseCommand.Use,
-		Short: unpauseCommand.Short,
-		Long:  unpauseCommand.Long,
-		RunE:  unpauseCommand.RunE,
+		Use:     unpauseCommand.Use,
+		Aliases: []string{"thaw"},
+		Short:   unpauseCommand.Short,
+		Long:    unpauseCommand.Long,
+		RunE:    unpauseCommand.RunE,
 		Args: func(cmd *cobra.Command, args []string) error {
 			return validate.CheckAllLatestAndIDFile(cmd, args, false, "cidfile")
 		},
@@ -13,8 +13,10 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/specgen"
 	"github.com/dmikushin/podman-shared/pkg/specgenutil"
 	"github.com/dmikushin/podman-shared/pkg/util"
+	"github.com/docker/go-units"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
 )
 
 var (
@@ -43,7 +45,11 @@ var (
 
 type ContainerUpdateOptions struct {
 	entities.ContainerCreateOptions
-	Latest bool
+	RemoveDevices  []string
+	DeviceCgroup   []string
+	MemoryReclaim  string
+	NetworkOptions []string
+	Latest         bool
 }
 
 var updateOptions ContainerUpdateOptions
@@ -51,6 +57,27 @@ var updateOptions ContainerUpdateOptions
 func updateFlags(cmd *cobra.Command) {
 	common.DefineCreateDefaults(&updateOptions.ContainerCreateOptions)
 	common.DefineCreateFlags(cmd, &updateOptions.ContainerCreateOptions, entities.UpdateMode)
+
+	flags := cmd.Flags()
+	deviceFlagName := "device"
+	flags.StringArrayVar(&updateOptions.Devices, deviceFlagName, []string{}, "Add a host device to the container")
+	_ = cmd.RegisterFlagCompletionFunc(deviceFlagName, completion.AutocompleteDefault)
+
+	deviceRmFlagName := "device-rm"
+	flags.StringArrayVar(&updateOptions.RemoveDevices, deviceRmFlagName, []string{}, "Remove a device, given by its path in the container, from the container")
+	_ = cmd.RegisterFlagCompletionFunc(deviceRmFlagName, completion.AutocompleteNone)
+
+	deviceCgroupRuleFlagName := "device-cgroup-rule"
+	flags.StringArrayVar(&updateOptions.DeviceCgroup, deviceCgroupRuleFlagName, []string{}, "Add a rule to the cgroup allowed devices list")
+	_ = cmd.RegisterFlagCompletionFunc(deviceCgroupRuleFlagName, completion.AutocompleteNone)
+
+	memoryReclaimFlagName := "memory-reclaim"
+	flags.StringVar(&updateOptions.MemoryReclaim, memoryReclaimFlagName, "", "Reclaim memory from the running container (format: `<number>[<unit>]`, where unit = b (bytes), k (kibibytes), m (mebibytes), or g (gibibytes))")
+	_ = cmd.RegisterFlagCompletionFunc(memoryReclaimFlagName, completion.AutocompleteNone)
+
+	networkOptFlagName := "network-opt"
+	flags.StringArrayVar(&updateOptions.NetworkOptions, networkOptFlagName, []string{}, "Set a bandwidth option on the networks of the container (format: `[network:]option=value`)")
+	_ = cmd.RegisterFlagCompletionFunc(networkOptFlagName, completion.AutocompleteNone)
 }
 
 func init() {
@@ -159,6 +186,29 @@ func update(cmd *cobra.Command, args []string) error {
 		Latest:                          updateOptions.Latest,
 	}
 
+	if len(updateOptions.Devices) != 0 || len(updateOptions.RemoveDevices) != 0 || len(updateOptions.DeviceCgroup) != 0 {
+		opts.Devices = &define.UpdateContainerDevices{
+			AddDevices:           updateOptions.Devices,
+			RemoveDevices:        updateOptions.RemoveDevices,
+			AddDeviceCgroupRules: updateOptions.DeviceCgroup,
+		}
+	}
+
+	if updateOptions.MemoryReclaim != "" {
+		size, err := units.RAMInBytes(updateOptions.MemoryReclaim)
+		if err != nil || size <= 0 {
+			return fmt.Errorf("invalid value %q for memory-reclaim, must be a positive size", updateOptions.MemoryReclaim)
+		}
+		opts.MemoryReclaim = &size
+	}
+
+	if len(updateOptions.NetworkOptions) > 0 {
+		opts.NetworkOptions, err = parseUpdateNetworkOptions(updateOptions.NetworkOptions)
+		if err != nil {
+			return err
+		}
+	}
+
 	if !updateOptions.Latest {
 		opts.NameOrID = strings.TrimPrefix(args[0], "/")
 	}
@@ -197,3 +247,25 @@ func update(cmd *cobra.Command, args []string) error {
 	fmt.Println(rep)
 	return nil
 }
+
+// parseUpdateNetworkOptions parses the [network:]option=value network options
+// of podman update into the options per network, an option without network
+// applies to all networks of the container.
+func parseUpdateNetworkOptions(options []string) (map[string]map[string]string, error) {
+	parsed := make(map[string]map[string]string)
+	for _, option := range options {
+		network, keyValue, found := strings.Cut(option, ":")
+		if !found {
+			network, keyValue = "", option
+		}
+		key, value, found := strings.Cut(keyValue, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid network option %q, must be [network:]option=value", option)
+		}
+		if parsed[network] == nil {
+			parsed[network] = make(map[string]string)
+		}
+		parsed[network][key] = value
+	}
+	return parsed, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/cmd/podman/utils"
 	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/spf13/cobra"
 	"go.podman.io/common/pkg/completion"
@@ -42,6 +43,7 @@ var (
 var (
 	waitOptions  = entities.WaitOptions{}
 	waitInterval string
+	waitStream   bool
 )
 
 func waitFlags(cmd *cobra.Command) {
@@ -59,6 +61,8 @@ func waitFlags(cmd *cobra.Command) {
 
 	waitExitFirst := "exit-first-match"
 	flags.BoolVar(&waitOptions.ExitFirstMatch, waitExitFirst, false, "Wait for exit of first container which matches conditions, ignore other ones")
+
+	flags.BoolVar(&waitStream, "stream", false, "Wait for every condition of every container and print each one as soon as it is met")
 }
 
 func init() {
@@ -95,6 +99,12 @@ func wait(cmd *cobra.Command, args []string) error {
 	if waitOptions.Latest && len(args) > 0 {
 		return errors.New("--latest and containers cannot be used together")
 	}
+	if waitStream {
+		if waitOptions.ExitFirstMatch {
+			return errors.New("--stream and --exit-first-match cannot be used together")
+		}
+		return waitConditions(args)
+	}
 
 	responses, err := registry.ContainerEngine().ContainerWait(context.Background(), args, waitOptions)
 	if err != nil {
@@ -109,3 +119,26 @@ func wait(cmd *cobra.Command, args []string) error {
 	}
 	return errs.PrintErrors()
 }
+
+// waitConditions prints the name of the container and the condition, along
+// with the exit code for exit conditions, whenever a condition is met.
+func waitConditions(args []string) error {
+	var errs utils.OutputErrors
+	waitOptions.ConditionChan = make(chan entities.WaitConditionReport)
+	if _, err := registry.ContainerEngine().ContainerWait(context.Background(), args, waitOptions); err != nil {
+		return err
+	}
+	for r := range waitOptions.ConditionChan {
+		if r.Error != "" {
+			errs = append(errs, fmt.Errorf("%s: %s", r.Name, r.Error))
+			continue
+		}
+		switch r.Condition {
+		case define.ContainerStateExited.String(), define.ContainerStateStopped.String():
+			fmt.Println(r.Name, r.Condition, r.ExitCode)
+		default:
+			fmt.Println(r.Name, r.Condition)
+		}
+	}
+	return errs.PrintErrors()
+}
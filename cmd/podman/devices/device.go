@@ -0,0 +1,23 @@
+package devices
+
+import (
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Command: podman _device_
+	deviceCmd = &cobra.Command{
+		Use:   "device",
+		Short: "Manage devices",
+		Long:  "Manage devices that can be passed to containers",
+		RunE:  validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: deviceCmd,
+	})
+}
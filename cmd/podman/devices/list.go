@@ -0,0 +1,88 @@
+package devices
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	listDescription = `List the devices defined in the CDI specifications and the GPU devices found on the host.
+
+  CDI devices can be passed to containers by name with --device, e.g. --device nvidia.com/gpu=all.`
+	listCmd = &cobra.Command{
+		Use:               "list [options]",
+		Aliases:           []string{"ls"},
+		Short:             "List devices",
+		Long:              listDescription,
+		RunE:              list,
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman device list
+  podman device list --format "{{.Name}} {{.Spec}}"`,
+	}
+	listFlag = listFlagType{}
+)
+
+type listFlagType struct {
+	format    string
+	noHeading bool
+	quiet     bool
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: listCmd,
+		Parent:  deviceCmd,
+	})
+
+	flags := listCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&listFlag.format, formatFlagName, "{{range .}}{{.Name}}\t{{.Source}}\t{{.Vendor}}\t{{.Class}}\n{{end -}}", "Format device output using Go template")
+	_ = listCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.DeviceListReport{}))
+
+	flags.BoolVarP(&listFlag.noHeading, "noheading", "n", false, "Do not print headers")
+	flags.BoolVarP(&listFlag.quiet, "quiet", "q", false, "Print device names only")
+}
+
+func list(cmd *cobra.Command, _ []string) error {
+	devices, err := registry.ContainerEngine().DeviceList(registry.Context())
+	if err != nil {
+		return err
+	}
+
+	if listFlag.quiet && !cmd.Flags().Changed("format") {
+		for _, device := range devices {
+			fmt.Println(device.Name)
+		}
+		return nil
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, listFlag.format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, listFlag.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !listFlag.noHeading {
+		headers := report.Headers(entities.DeviceListReport{}, nil)
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(devices)
+}
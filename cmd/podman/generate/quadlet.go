@@ -0,0 +1,98 @@
+package generate
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	quadletFiles       bool
+	quadletFormat      string
+	quadletOptions     = entities.GenerateQuadletOptions{}
+	quadletDescription = `Generate Quadlet units recreating a container or a pod.
+  A .container or .pod unit is generated along with .volume and .network units for the named volumes and networks in use. Place them in a Quadlet unit search path, see podman-systemd.unit(5).
+`
+
+	quadletCmd = &cobra.Command{
+		Use:               "quadlet [options] {CONTAINER|POD}",
+		Short:             "Generate Quadlet units",
+		Long:              quadletDescription,
+		RunE:              quadlet,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainersAndPods,
+		Example: `podman generate quadlet CTR
+  podman generate quadlet --files POD`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: quadletCmd,
+		Parent:  GenerateCmd,
+	})
+	flags := quadletCmd.Flags()
+	flags.BoolVarP(&quadletFiles, "files", "f", false, "Generate unit files in the current directory instead of printing to stdout")
+	flags.BoolVarP(&quadletOptions.NoHeader, "no-header", "", false, "Skip header generation")
+
+	formatFlagName := "format"
+	flags.StringVar(&quadletFormat, formatFlagName, "", "Print the created units in specified format (json)")
+	_ = quadletCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(nil))
+}
+
+func quadlet(_ *cobra.Command, args []string) error {
+	if registry.IsRemote() {
+		logrus.Warnln("The generated units should be placed on your remote system")
+	}
+
+	reports, err := registry.ContainerEngine().GenerateQuadlet(registry.Context(), args[0], quadletOptions)
+	if err != nil {
+		return err
+	}
+
+	if quadletFiles {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("getting current working directory: %w", err)
+		}
+		for name, content := range reports.Units {
+			path := filepath.Join(cwd, name)
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				return err
+			}
+			// add newline if default format is given
+			if quadletFormat == "" {
+				path += "\n"
+			}
+			// modify in place so we can print the
+			// paths when --files is set
+			reports.Units[name] = path
+		}
+	}
+
+	switch {
+	case report.IsJSON(quadletFormat):
+		return printJSON(reports.Units)
+	case quadletFormat == "":
+		// print the units sorted by file name and separated by an empty line
+		for _, name := range slices.Sorted(maps.Keys(reports.Units)) {
+			content := reports.Units[name]
+			if !quadletFiles {
+				content += "\n"
+			}
+			fmt.Print(content)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown --format argument: %s", quadletFormat)
+	}
+}
@@ -20,7 +20,8 @@ var (
 		RunE:              spec,
 		Args:              cobra.ExactArgs(1),
 		ValidArgsFunction: common.AutocompleteContainersAndPods,
-		Example:           `podman generate spec ctrID`,
+		Example: `podman generate spec ctrID
+  podman generate spec --strip-host-specific -f ctr.json ctrID`,
 	}
 )
 
@@ -46,6 +47,8 @@ func init() {
 	nameFlagName := "name"
 	flags.BoolVarP(&opts.Name, nameFlagName, "n", true, "Specify a new name for the generated spec")
 
+	flags.BoolVar(&opts.StripHostSpecific, "strip-host-specific", false, "Remove host paths, IDs and addresses to use the spec on another host")
+
 	flags.SetNormalizeFunc(utils.AliasFlags)
 }
 
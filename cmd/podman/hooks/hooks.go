@@ -0,0 +1,23 @@
+package hooks
+
+import (
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Command: podman _hooks_
+	hooksCmd = &cobra.Command{
+		Use:   "hooks",
+		Short: "Manage OCI hooks",
+		Long:  "List, validate and test the OCI hooks of the hooks directories",
+		RunE:  validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: hooksCmd,
+	})
+}
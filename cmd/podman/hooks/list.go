@@ -0,0 +1,112 @@
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	listDescription = `List the OCI hooks of the hooks directories.
+
+  A hook overrides the hooks of the same name in earlier directories. Invalid hooks are listed with their error, they are ignored when containers start.`
+	listCmd = &cobra.Command{
+		Use:               "list [options]",
+		Aliases:           []string{"ls"},
+		Short:             "List OCI hooks",
+		Long:              listDescription,
+		RunE:              list,
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman hooks list
+  podman hooks list --format "{{.Name}} {{.Path}}"`,
+	}
+	listFlag = listFlagType{}
+)
+
+type listFlagType struct {
+	format    string
+	noHeading bool
+	quiet     bool
+}
+
+// hookReporter joins the stages of a hook for templates.
+type hookReporter struct {
+	entities.HookListReport
+}
+
+func (h hookReporter) Stages() string {
+	return strings.Join(h.HookListReport.Stages, ",")
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: listCmd,
+		Parent:  hooksCmd,
+	})
+
+	addListFlags(listCmd, &listFlag)
+}
+
+// addListFlags adds the output flags of commands listing hooks.
+func addListFlags(cmd *cobra.Command, listFlag *listFlagType) {
+	flags := cmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&listFlag.format, formatFlagName, "{{range .}}{{.Name}}\t{{.Stages}}\t{{.When}}\t{{.Error}}\n{{end -}}", "Format hook output using Go template")
+	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&hookReporter{}))
+
+	flags.BoolVarP(&listFlag.noHeading, "noheading", "n", false, "Do not print headers")
+	flags.BoolVarP(&listFlag.quiet, "quiet", "q", false, "Print hook names only")
+}
+
+func list(cmd *cobra.Command, _ []string) error {
+	hooks, err := registry.ContainerEngine().HookList(registry.Context())
+	if err != nil {
+		return err
+	}
+	return printHooks(cmd, &listFlag, hooks)
+}
+
+// printHooks prints hooks with the output flags of the command.
+func printHooks(cmd *cobra.Command, listFlag *listFlagType, hooks []*entities.HookListReport) error {
+	if listFlag.quiet && !cmd.Flags().Changed("format") {
+		for _, hook := range hooks {
+			fmt.Println(hook.Name)
+		}
+		return nil
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	var err error
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, listFlag.format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, listFlag.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !listFlag.noHeading {
+		headers := report.Headers(hookReporter{}, nil)
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	reporters := make([]hookReporter, 0, len(hooks))
+	for _, hook := range hooks {
+		reporters = append(reporters, hookReporter{*hook})
+	}
+	return rpt.Execute(reporters)
+}
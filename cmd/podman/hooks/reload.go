@@ -0,0 +1,41 @@
+package hooks
+
+import (
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	reloadDescription = `Read the hooks directories from containers.conf again and list the OCI hooks found in them.
+
+  Reloading lets a running Podman service use changed hooks directories without a restart. The hooks themselves are read every time a container starts.`
+	reloadCmd = &cobra.Command{
+		Use:               "reload [options]",
+		Short:             "Reload the OCI hooks directories",
+		Long:              reloadDescription,
+		RunE:              reload,
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman --remote hooks reload
+  podman hooks reload --quiet`,
+	}
+	reloadFlag = listFlagType{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: reloadCmd,
+		Parent:  hooksCmd,
+	})
+	addListFlags(reloadCmd, &reloadFlag)
+}
+
+func reload(cmd *cobra.Command, _ []string) error {
+	hooks, err := registry.ContainerEngine().HookReload(registry.Context())
+	if err != nil {
+		return err
+	}
+	return printHooks(cmd, &reloadFlag, hooks)
+}
@@ -0,0 +1,101 @@
+package hooks
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	testDescription = `Show which OCI hooks match the configuration of a container.
+
+  The matching hooks are added to the container when it starts.`
+	testCmd = &cobra.Command{
+		Use:               "test [options] CONTAINER",
+		Short:             "Test OCI hooks against a container",
+		Long:              testDescription,
+		RunE:              test,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteContainers,
+		Example: `podman hooks test ctr
+  podman hooks test --quiet ctr`,
+	}
+	testFlag = testFlagType{}
+)
+
+type testFlagType struct {
+	format    string
+	noHeading bool
+	quiet     bool
+}
+
+// testReporter joins the stages of a hook for templates.
+type testReporter struct {
+	entities.HookTestReport
+}
+
+func (h testReporter) Stages() string {
+	return strings.Join(h.HookTestReport.Stages, ",")
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: testCmd,
+		Parent:  hooksCmd,
+	})
+
+	flags := testCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&testFlag.format, formatFlagName, "{{range .}}{{.Name}}\t{{.Matched}}\t{{.Stages}}\t{{.When}}\t{{.Error}}\n{{end -}}", "Format hook output using Go template")
+	_ = testCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&testReporter{}))
+
+	flags.BoolVarP(&testFlag.noHeading, "noheading", "n", false, "Do not print headers")
+	flags.BoolVarP(&testFlag.quiet, "quiet", "q", false, "Print the names of the matching hooks only")
+}
+
+func test(cmd *cobra.Command, args []string) error {
+	hooks, err := registry.ContainerEngine().HookTest(registry.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	if testFlag.quiet && !cmd.Flags().Changed("format") {
+		for _, hook := range hooks {
+			if hook.Matched {
+				fmt.Println(hook.Name)
+			}
+		}
+		return nil
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, testFlag.format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, testFlag.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !testFlag.noHeading {
+		headers := report.Headers(testReporter{}, nil)
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	reporters := make([]testReporter, 0, len(hooks))
+	for _, hook := range hooks {
+		reporters = append(reporters, testReporter{*hook})
+	}
+	return rpt.Execute(reporters)
+}
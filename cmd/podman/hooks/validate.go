@@ -0,0 +1,85 @@
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/utils"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/hooks"
+)
+
+var (
+	validateDescription = `Validate OCI hook files.
+
+  Without arguments, the hooks of the hooks directories are validated. Files and directories given as arguments are read on the client.`
+	validateCmd = &cobra.Command{
+		Use:               "validate [PATH...]",
+		Short:             "Validate OCI hooks",
+		Long:              validateDescription,
+		RunE:              validateHooks,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman hooks validate
+  podman hooks validate /usr/share/containers/oci/hooks.d/nvidia.json`,
+	}
+)
+
+// hookExtensionStages are the stages libpod runs hooks at itself.
+var hookExtensionStages = []string{"precreate", "poststop"}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: validateCmd,
+		Parent:  hooksCmd,
+	})
+}
+
+func validateHooks(_ *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	if len(args) == 0 {
+		reports, err := registry.ContainerEngine().HookList(registry.Context())
+		if err != nil {
+			return err
+		}
+		for _, r := range reports {
+			if r.Error != "" {
+				errs = append(errs, hookError(r.Path, errors.New(r.Error)))
+				continue
+			}
+			fmt.Println(r.Path)
+		}
+		return errs.PrintErrors()
+	}
+
+	for _, arg := range args {
+		paths := []string{arg}
+		if info, err := os.Stat(arg); err == nil && info.IsDir() {
+			matches, err := filepath.Glob(filepath.Join(arg, "*.json"))
+			if err != nil {
+				return err
+			}
+			paths = matches
+		}
+		for _, path := range paths {
+			if _, err := hooks.Read(path, hookExtensionStages); err != nil {
+				errs = append(errs, hookError(path, err))
+				continue
+			}
+			fmt.Println(path)
+		}
+	}
+	return errs.PrintErrors()
+}
+
+// hookError names the hook in err, unless the hooks package already did.
+func hookError(path string, err error) error {
+	if strings.Contains(err.Error(), path) {
+		return err
+	}
+	return fmt.Errorf("%s: %w", path, err)
+}
@@ -2,6 +2,8 @@ package images
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"os/exec"
 
@@ -9,6 +11,8 @@ import (
 	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/cmd/podman/utils"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/errorhandling"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 )
@@ -96,6 +100,16 @@ func build(cmd *cobra.Command, args []string) error {
 			}
 		}()
 	}
+	if apiBuildOpts.ArchiveToStdout != "" {
+		defer os.Remove(apiBuildOpts.ArchiveToStdout)
+	}
+	if apiBuildOpts.Manifest != "" && !cmd.Flag("manifest").Changed {
+		// --tag names the manifest list of a multi-platform build, replace
+		// the one of an earlier build instead of adding to it
+		if err := removeManifestList(apiBuildOpts.Manifest); err != nil {
+			return err
+		}
+	}
 	report, err := registry.ImageEngine().Build(registry.Context(), apiBuildOpts.ContainerFiles, *apiBuildOpts)
 
 	if err != nil {
@@ -128,5 +142,49 @@ func build(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if apiBuildOpts.Manifest != "" && !apiBuildOpts.Quiet {
+		if err := printManifestPlatforms(apiBuildOpts.Manifest); err != nil {
+			return err
+		}
+	}
+
+	if apiBuildOpts.ArchiveToStdout != "" {
+		f, err := os.Open(apiBuildOpts.ArchiveToStdout)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err := io.Copy(os.Stdout, f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeManifestList removes the manifest list of the given name if it exists.
+func removeManifestList(name string) error {
+	exists, err := registry.ImageEngine().ManifestExists(registry.Context(), name)
+	if err != nil || !exists.Value {
+		return err
+	}
+	_, rmErrors := registry.ImageEngine().ManifestRm(registry.Context(), []string{name}, entities.ImageRemoveOptions{})
+	return errorhandling.JoinErrors(rmErrors)
+}
+
+// printManifestPlatforms reports the image built for each platform of a
+// manifest list on stderr, keeping the ID of the list the last line of stdout.
+func printManifestPlatforms(name string) error {
+	list, err := registry.ImageEngine().ManifestInspect(registry.Context(), name, entities.ManifestInspectOptions{})
+	if err != nil {
+		return err
+	}
+	for _, instance := range list.Manifests {
+		platform := instance.Platform.OS + "/" + instance.Platform.Architecture
+		if instance.Platform.Variant != "" {
+			platform += "/" + instance.Platform.Variant
+		}
+		fmt.Fprintf(os.Stderr, "%s: %s\n", platform, instance.Digest)
+	}
 	return nil
 }
@@ -111,5 +111,8 @@ func load(_ *cobra.Command, _ []string) error {
 		return err
 	}
 	fmt.Println("Loaded image: " + strings.Join(response.Names, "\nLoaded image: "))
+	for _, artifact := range response.Artifacts {
+		fmt.Println("Loaded artifact: " + artifact)
+	}
 	return nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
 	"go.podman.io/common/pkg/report"
 )
 
@@ -18,6 +19,9 @@ var (
 
   podman image mount IMAGE-NAME-OR-ID
     Mounts the specified image and prints the mountpoint
+
+  podman image mount --all-layers --target DIR IMAGE-NAME-OR-ID
+    Mounts each layer of the specified image read-only under DIR and prints the mapping
 `
 
 	mountCommand = &cobra.Command{
@@ -34,7 +38,8 @@ var (
 		Example: `podman image mount imgID
   podman image mount imgID1 imgID2 imgID3
   podman image mount
-  podman image mount --all`,
+  podman image mount --all
+  podman image mount --all-layers --target /tmp/layers imgID`,
 	}
 )
 
@@ -50,6 +55,12 @@ func mountFlags(cmd *cobra.Command) {
 	formatFlagName := "format"
 	flags.StringVar(&mountOpts.Format, formatFlagName, "", "Print the mounted images in specified format (json)")
 	_ = cmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(nil))
+
+	flags.BoolVar(&mountOpts.AllLayers, "all-layers", false, "Mount each layer of the image separately and read-only under the --target directory")
+
+	targetFlagName := "target"
+	flags.StringVar(&mountOpts.Target, targetFlagName, "", "Directory to mount the layers of the image under")
+	_ = cmd.RegisterFlagCompletionFunc(targetFlagName, completion.AutocompleteDefault)
 }
 
 func init() {
@@ -64,12 +75,26 @@ func mount(cmd *cobra.Command, args []string) error {
 	if len(args) > 0 && mountOpts.All {
 		return errors.New("when using the --all switch, you may not pass any image names or IDs")
 	}
+	if mountOpts.AllLayers {
+		if len(args) != 1 || mountOpts.All {
+			return errors.New("--all-layers requires exactly one image name or ID")
+		}
+		if mountOpts.Target == "" {
+			return errors.New("--all-layers requires a --target directory")
+		}
+	} else if cmd.Flags().Changed("target") {
+		return errors.New("--target can only be used with --all-layers")
+	}
 
 	reports, err := registry.ImageEngine().Mount(registry.Context(), args, mountOpts)
 	if err != nil {
 		return err
 	}
 
+	if mountOpts.AllLayers {
+		return printLayerMounts(cmd, reports)
+	}
+
 	if len(args) == 1 && mountOpts.Format == "" && !mountOpts.All {
 		if len(reports) != 1 {
 			return fmt.Errorf("internal error: expected 1 report but got %d", len(reports))
@@ -125,6 +150,32 @@ func printJSON(reports []*entities.ImageMountReport) error {
 	return nil
 }
 
+func printLayerMounts(cmd *cobra.Command, reports []*entities.ImageMountReport) error {
+	if len(reports) != 1 {
+		return fmt.Errorf("internal error: expected 1 report but got %d", len(reports))
+	}
+	switch {
+	case report.IsJSON(mountOpts.Format):
+		b, err := json.MarshalIndent(reports[0].Layers, "", " ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	case mountOpts.Format == "":
+		break // see default format below
+	default:
+		return fmt.Errorf("unknown --format argument: %q", mountOpts.Format)
+	}
+
+	rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginPodman, "{{range . }}{{.ID}}\t{{.DiffID}}\t{{.Path}}\n{{end -}}")
+	if err != nil {
+		return err
+	}
+	defer rpt.Flush()
+	return rpt.Execute(reports[0].Layers)
+}
+
 type mountReporter struct {
 	*entities.ImageMountReport
 }
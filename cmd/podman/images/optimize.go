@@ -0,0 +1,81 @@
+package images
+
+import (
+	"fmt"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	optimizeDescription = `Write an optimized copy of an image under a new name.
+
+  The layers of the copy are compressed with zstd and the whiteouts which do not hide anything are removed.
+  With --merge-threshold, layers smaller than the threshold are merged with the following layers.`
+	optimizeCmd = &cobra.Command{
+		Use:               "optimize [options] IMAGE TARGET",
+		Args:              cobra.ExactArgs(2),
+		Short:             "Recompress and merge the layers of an image",
+		Long:              optimizeDescription,
+		RunE:              optimize,
+		ValidArgsFunction: common.AutocompleteImages,
+		Example: `podman image optimize myapp:latest myapp:optimized
+  podman image optimize --merge-threshold 1MB --compression-level 19 myapp:latest myapp:small`,
+	}
+	optimizeOpts = struct {
+		entities.ImageOptimizeOptions
+		MergeThresholdCLI   string
+		CompressionLevelCLI int
+	}{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: optimizeCmd,
+		Parent:  imageCmd,
+	})
+	flags := optimizeCmd.Flags()
+
+	mergeThresholdFlagName := "merge-threshold"
+	flags.StringVar(&optimizeOpts.MergeThresholdCLI, mergeThresholdFlagName, "0", "Merge layers smaller than `SIZE` with the following layers, 0 to not merge layers")
+	_ = optimizeCmd.RegisterFlagCompletionFunc(mergeThresholdFlagName, completion.AutocompleteNone)
+
+	compressionLevelFlagName := "compression-level"
+	flags.IntVar(&optimizeOpts.CompressionLevelCLI, compressionLevelFlagName, 0, "Level of the zstd compression")
+	_ = optimizeCmd.RegisterFlagCompletionFunc(compressionLevelFlagName, completion.AutocompleteNone)
+}
+
+func optimize(cmd *cobra.Command, args []string) error {
+	threshold, err := units.FromHumanSize(optimizeOpts.MergeThresholdCLI)
+	if err != nil {
+		return fmt.Errorf("invalid merge threshold %q: %w", optimizeOpts.MergeThresholdCLI, err)
+	}
+	optimizeOpts.MergeThreshold = threshold
+	if cmd.Flags().Changed("compression-level") {
+		optimizeOpts.CompressionLevel = &optimizeOpts.CompressionLevelCLI
+	}
+
+	report, err := registry.ImageEngine().Optimize(registry.Context(), args[0], args[1], optimizeOpts.ImageOptimizeOptions)
+	if err != nil {
+		return err
+	}
+
+	humanSize := func(size int64) string {
+		return units.HumanSizeWithPrecision(float64(size), 4)
+	}
+	fmt.Printf("Image:             %s\n", report.Name)
+	fmt.Printf("ID:                %s\n", report.ID)
+	fmt.Printf("Layers:            %d -> %d\n", report.LayersBefore, report.LayersAfter)
+	fmt.Printf("Whiteouts removed: %d\n", report.WhiteoutsRemoved)
+	fmt.Printf("Size:              %s -> %s\n", humanSize(report.SizeBefore), humanSize(report.SizeAfter))
+	fmt.Printf("Compressed size:   %s -> %s", humanSize(report.CompressedSizeBefore), humanSize(report.CompressedSizeAfter))
+	if report.CompressedSizeBefore > 0 {
+		fmt.Printf(" (%+.1f%%)", float64(report.CompressedSizeAfter-report.CompressedSizeBefore)*100/float64(report.CompressedSizeBefore))
+	}
+	fmt.Println()
+	return nil
+}
@@ -15,7 +15,6 @@ import (
 	"github.com/spf13/cobra"
 	"go.podman.io/common/pkg/auth"
 	"go.podman.io/common/pkg/completion"
-	"go.podman.io/common/pkg/config"
 	"go.podman.io/image/v5/types"
 )
 
@@ -27,6 +26,7 @@ type pullOptionsWrapper struct {
 	CredentialsCLI string
 	DecryptionKeys []string
 	PolicyCLI      string
+	MaxAgeCLI      string
 }
 
 var (
@@ -105,9 +105,13 @@ func pullFlags(cmd *cobra.Command) {
 
 	policyFlagName := "policy"
 	// Explicitly set the default to "always" to avoid the default being "missing"
-	flags.StringVar(&pullOptions.PolicyCLI, policyFlagName, "always", `Pull image policy ("always"|"missing"|"never"|"newer")`)
+	flags.StringVar(&pullOptions.PolicyCLI, policyFlagName, "always", `Pull image policy ("always"|"missing"|"never"|"newer"|"newer-with-max-age")`)
 	_ = cmd.RegisterFlagCompletionFunc(policyFlagName, common.AutocompletePullOption)
 
+	maxAgeFlagName := "max-age"
+	flags.StringVar(&pullOptions.MaxAgeCLI, maxAgeFlagName, registry.PullMaxAgeDefault(), "Only check the registry for images pulled longer than `DURATION` ago with the newer-with-max-age policy")
+	_ = cmd.RegisterFlagCompletionFunc(maxAgeFlagName, completion.AutocompleteNone)
+
 	flags.Bool("disable-content-trust", false, "This is a Docker specific option and is a NOOP")
 	flags.BoolVarP(&pullOptions.Quiet, "quiet", "q", false, "Suppress output information when pulling images")
 	flags.BoolVar(&pullOptions.TLSVerifyCLI, "tls-verify", true, "Require HTTPS and verify certificates when contacting registries")
@@ -134,6 +138,8 @@ func pullFlags(cmd *cobra.Command) {
 		flags.StringVar(&pullOptions.CertDir, certDirFlagName, "", "`Pathname` of a directory containing TLS certificates and keys")
 		_ = cmd.RegisterFlagCompletionFunc(certDirFlagName, completion.AutocompleteDefault)
 
+		flags.BoolVar(&pullOptions.ToShared, "to-shared", false, "Pull the image into the additional image store on shared storage instead of the local storage")
+
 		signaturePolicyFlagName := "signature-policy"
 		flags.StringVar(&pullOptions.SignaturePolicy, signaturePolicyFlagName, "", "`Pathname` of signature policy file (not usually used)")
 		_ = flags.MarkHidden(signaturePolicyFlagName)
@@ -150,11 +156,20 @@ func imagePull(cmd *cobra.Command, args []string) error {
 		pullOptions.SkipTLSVerify = types.NewOptionalBool(!pullOptions.TLSVerifyCLI)
 	}
 
-	pullPolicy, err := config.ParsePullPolicy(pullOptions.PolicyCLI)
+	pullPolicy, withMaxAge, err := util.ParsePullPolicy(pullOptions.PolicyCLI)
 	if err != nil {
 		return err
 	}
 	pullOptions.PullPolicy = pullPolicy
+	if withMaxAge {
+		if pullOptions.ToShared {
+			return errors.New("--to-shared can not be used with the newer-with-max-age policy")
+		}
+		pullOptions.PullMaxAge, err = util.ParsePullMaxAge(pullOptions.MaxAgeCLI)
+		if err != nil {
+			return err
+		}
+	}
 
 	if cmd.Flags().Changed("retry") {
 		retry, err := cmd.Flags().GetUint("retry")
@@ -221,7 +236,7 @@ func imagePull(cmd *cobra.Command, args []string) error {
 	// scattering logic across (too) many parts of the code.
 	var errs utils.OutputErrors
 	for _, arg := range args {
-		pullReport, err := registry.ImageEngine().Pull(registry.Context(), arg, pullOptions.ImagePullOptions)
+		pullReport, _, err := common.PullImage(registry.Context(), arg, pullOptions.ImagePullOptions)
 		if err != nil {
 			errs = append(errs, err)
 			continue
@@ -0,0 +1,113 @@
+package images
+
+import (
+	"bytes"
+	stdjson "encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/util"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/auth"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/image/v5/types"
+)
+
+var (
+	sbomDescription = `Generate a software bill of materials of an image.
+
+  The packages are listed from the package databases (dpkg, apk and rpm) found in the layers of the image.
+  With --attach, the SBOM is also pushed to a registry as an artifact referring to the image.`
+	sbomCmd = &cobra.Command{
+		Use:               "sbom [options] IMAGE",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Generate an SBOM of an image",
+		Long:              sbomDescription,
+		RunE:              sbom,
+		ValidArgsFunction: common.AutocompleteImages,
+		Example: `podman image sbom alpine:latest
+  podman image sbom --format cyclonedx --output alpine.cdx.json alpine:latest
+  podman image sbom --attach registry.example.com/repo/alpine:latest alpine:latest`,
+	}
+	sbomOpts = struct {
+		entities.ImageSBOMOptions
+		Output         string
+		TLSVerifyCLI   bool
+		CredentialsCLI string
+	}{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: sbomCmd,
+		Parent:  imageCmd,
+	})
+	flags := sbomCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVarP(&sbomOpts.Format, formatFlagName, "f", "spdx", "Format of the SBOM (spdx or cyclonedx)")
+	_ = sbomCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteSBOMFormat)
+
+	outputFlagName := "output"
+	flags.StringVarP(&sbomOpts.Output, outputFlagName, "o", "", "Write the SBOM to a file instead of stdout")
+	_ = sbomCmd.RegisterFlagCompletionFunc(outputFlagName, completion.AutocompleteDefault)
+
+	attachFlagName := "attach"
+	flags.StringVar(&sbomOpts.Attach, attachFlagName, "", "Push the SBOM to a registry as a referrer of the image `REFERENCE`")
+	_ = sbomCmd.RegisterFlagCompletionFunc(attachFlagName, completion.AutocompleteNone)
+
+	authfileFlagName := "authfile"
+	flags.StringVar(&sbomOpts.Authfile, authfileFlagName, auth.GetDefaultAuthFile(), "Path of the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
+	_ = sbomCmd.RegisterFlagCompletionFunc(authfileFlagName, completion.AutocompleteDefault)
+
+	credsFlagName := "creds"
+	flags.StringVar(&sbomOpts.CredentialsCLI, credsFlagName, "", "`Credentials` (USERNAME:PASSWORD) to use for authenticating to a registry")
+	_ = sbomCmd.RegisterFlagCompletionFunc(credsFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&sbomOpts.TLSVerifyCLI, "tls-verify", true, "Require HTTPS and verify certificates when contacting registries")
+}
+
+func sbom(cmd *cobra.Command, args []string) error {
+	if cmd.Flags().Changed("tls-verify") {
+		sbomOpts.SkipTLSVerify = types.NewOptionalBool(!sbomOpts.TLSVerifyCLI)
+	}
+	if cmd.Flags().Changed("authfile") {
+		if err := auth.CheckAuthFile(sbomOpts.Authfile); err != nil {
+			return err
+		}
+	}
+	if sbomOpts.CredentialsCLI != "" {
+		creds, err := util.ParseRegistryCreds(sbomOpts.CredentialsCLI)
+		if err != nil {
+			return err
+		}
+		sbomOpts.Username = creds.Username
+		sbomOpts.Password = creds.Password
+	}
+
+	report, err := registry.ImageEngine().SBOM(registry.Context(), args[0], sbomOpts.ImageSBOMOptions)
+	if err != nil {
+		return err
+	}
+
+	// the SBOM is compacted when it is sent over the REST API
+	var content bytes.Buffer
+	if err := stdjson.Indent(&content, report.SBOM, "", "  "); err != nil {
+		return err
+	}
+	content.WriteByte('\n')
+	if sbomOpts.Output != "" {
+		if err := os.WriteFile(sbomOpts.Output, content.Bytes(), 0o644); err != nil {
+			return err
+		}
+	} else if _, err := content.WriteTo(os.Stdout); err != nil {
+		return err
+	}
+	if report.Referrer != "" {
+		fmt.Fprintf(os.Stderr, "SBOM attached as %s\n", report.Referrer)
+	}
+	return nil
+}
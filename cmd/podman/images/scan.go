@@ -0,0 +1,107 @@
+package images
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/scan"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	scanDescription = `Scan an image for vulnerabilities.
+
+  The root filesystem of the image is mounted and scanned with an external scanner, trivy and grype are supported out of the box and more can be configured.
+  The vulnerabilities are reported in a common schema, whatever the scanner.`
+	scanCmd = &cobra.Command{
+		Use:               "scan [options] IMAGE",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Scan an image for vulnerabilities",
+		Long:              scanDescription,
+		RunE:              scanImage,
+		ValidArgsFunction: common.AutocompleteImages,
+		Example: `podman image scan alpine:latest
+  podman image scan --scanner grype --threshold high alpine:latest
+  podman image scan --format json alpine:latest`,
+	}
+	scanOpts   entities.ImageScanOptions
+	scanFormat string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: scanCmd,
+		Parent:  imageCmd,
+	})
+	flags := scanCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&scanFormat, formatFlagName, "", "Change the output to JSON or a Go template")
+	_ = scanCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&scan.Vulnerability{}))
+
+	scannerFlagName := "scanner"
+	flags.StringVar(&scanOpts.Scanner, scannerFlagName, "", "Scanner to use instead of the default scanner of the scan configuration")
+	_ = scanCmd.RegisterFlagCompletionFunc(scannerFlagName, common.AutocompleteScanners)
+
+	thresholdFlagName := "threshold"
+	flags.StringVar(&scanOpts.Threshold, thresholdFlagName, "", "Fail if the image has vulnerabilities of this `severity` or higher")
+	_ = scanCmd.RegisterFlagCompletionFunc(thresholdFlagName, common.AutocompleteScanSeverity)
+}
+
+func scanImage(cmd *cobra.Command, args []string) error {
+	if scanOpts.Threshold != "" {
+		if _, err := scan.ParseSeverity(scanOpts.Threshold); err != nil {
+			return err
+		}
+	}
+	results, err := registry.ImageEngine().Scan(registry.Context(), args[0], scanOpts)
+	if err != nil {
+		return err
+	}
+
+	if report.IsJSON(scanFormat) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	} else if err := printVulnerabilities(cmd, results.Vulnerabilities); err != nil {
+		return err
+	}
+
+	if results.Blocked {
+		return fmt.Errorf("image %s has %d vulnerabilities of severity %s or higher", args[0], results.Exceeding(), results.Threshold)
+	}
+	return nil
+}
+
+func printVulnerabilities(cmd *cobra.Command, vulns []scan.Vulnerability) error {
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	var err error
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, scanFormat)
+	} else {
+		format := "{{range .}}{{.ID}}\t{{.Severity}}\t{{.Package}}\t{{.InstalledVersion}}\t{{.FixedVersion}}\n{{end -}}"
+		rpt, err = rpt.Parse(report.OriginPodman, format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders {
+		hdrs := report.Headers(scan.Vulnerability{}, map[string]string{
+			"InstalledVersion": "INSTALLED",
+			"FixedVersion":     "FIXED",
+		})
+		if err := rpt.Execute(hdrs); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(vulns)
+}
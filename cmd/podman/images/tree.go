@@ -18,7 +18,8 @@ var (
 		Long:              treeDescription,
 		RunE:              tree,
 		ValidArgsFunction: common.AutocompleteImages,
-		Example:           "podman image tree alpine:latest",
+		Example: `podman image tree alpine:latest
+  podman image tree --du alpine:latest`,
 	}
 	treeOpts entities.ImageTreeOptions
 )
@@ -29,6 +30,8 @@ func init() {
 		Parent:  imageCmd,
 	})
 	treeCmd.Flags().BoolVar(&treeOpts.WhatRequires, "whatrequires", false, "Show all child images and layers of the specified image")
+	treeCmd.Flags().BoolVar(&treeOpts.DiskUsage, "du", false, "Show the disk usage of the layers and whether they are shared with other images")
+	treeCmd.MarkFlagsMutuallyExclusive("whatrequires", "du")
 }
 
 func tree(_ *cobra.Command, args []string) error {
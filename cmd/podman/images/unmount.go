@@ -10,6 +10,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
+	"go.podman.io/common/pkg/completion"
 )
 
 var (
@@ -40,6 +41,8 @@ var (
 func unmountFlags(flags *pflag.FlagSet) {
 	flags.BoolVarP(&unmountOpts.All, "all", "a", false, "Unmount all of the currently mounted images")
 	flags.BoolVarP(&unmountOpts.Force, "force", "f", false, "Force the complete unmount of the specified mounted images")
+	flags.BoolVar(&unmountOpts.AllLayers, "all-layers", false, "Unmount the layers of the image mounted under the --target directory")
+	flags.StringVar(&unmountOpts.Target, "target", "", "Directory the layers of the image are mounted under")
 }
 
 func init() {
@@ -48,6 +51,7 @@ func init() {
 		Command: unmountCommand,
 	})
 	unmountFlags(unmountCommand.Flags())
+	_ = unmountCommand.RegisterFlagCompletionFunc("target", completion.AutocompleteDefault)
 }
 
 func unmount(_ *cobra.Command, args []string) error {
@@ -58,6 +62,16 @@ func unmount(_ *cobra.Command, args []string) error {
 	if len(args) > 0 && unmountOpts.All {
 		return errors.New("when using the --all switch, you may not pass any image names or IDs")
 	}
+	if unmountOpts.AllLayers {
+		if len(args) != 1 {
+			return errors.New("--all-layers requires exactly one image name or ID")
+		}
+		if unmountOpts.Target == "" {
+			return errors.New("--all-layers requires a --target directory")
+		}
+	} else if unmountOpts.Target != "" {
+		return errors.New("--target can only be used with --all-layers")
+	}
 	reports, err := registry.ImageEngine().Unmount(registry.Context(), args, unmountOpts)
 	if err != nil {
 		return err
@@ -6,10 +6,15 @@ import (
 	"github.com/dmikushin/podman-shared/cmd/podman/utils"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
 )
 
 type downKubeOptions struct {
-	Force bool
+	Force        bool
+	Volumes      bool
+	ConfigMaps   bool
+	Secrets      bool
+	ForceTimeout uint
 }
 
 var (
@@ -26,7 +31,8 @@ var (
 		ValidArgsFunction: common.AutocompleteDefaultOneArg,
 		Example: `podman kube down nginx.yml
    cat nginx.yml | podman kube down -
-   podman kube down https://example.com/nginx.yml`,
+   podman kube down https://example.com/nginx.yml
+   podman kube down --volumes --secrets=false --force-timeout 5 nginx.yml`,
 	}
 
 	downOptions = downKubeOptions{}
@@ -45,12 +51,28 @@ func downFlags(cmd *cobra.Command) {
 	flags.SetNormalizeFunc(utils.AliasFlags)
 
 	flags.BoolVar(&downOptions.Force, "force", false, "remove volumes")
+	flags.BoolVar(&downOptions.Volumes, "volumes", false, "remove the volumes of PersistentVolumeClaims")
+	flags.BoolVar(&downOptions.ConfigMaps, "configmaps", false, "remove the volumes created for ConfigMap and Secret volumes")
+	flags.BoolVar(&downOptions.Secrets, "secrets", true, "remove the secrets created from Secrets")
+
+	forceTimeoutFlagName := "force-timeout"
+	flags.UintVar(&downOptions.ForceTimeout, forceTimeoutFlagName, 0, "Seconds to wait for each pod to stop before killing its containers")
+	_ = cmd.RegisterFlagCompletionFunc(forceTimeoutFlagName, completion.AutocompleteNone)
 }
 
-func down(_ *cobra.Command, args []string) error {
+func down(cmd *cobra.Command, args []string) error {
 	reader, err := readerFromArg(args[0])
 	if err != nil {
 		return err
 	}
-	return teardown(reader, entities.PlayKubeDownOptions{Force: downOptions.Force})
+	options := entities.PlayKubeDownOptions{
+		Force:       downOptions.Force,
+		Volumes:     downOptions.Volumes,
+		ConfigMaps:  downOptions.ConfigMaps,
+		KeepSecrets: !downOptions.Secrets,
+	}
+	if cmd.Flags().Changed("force-timeout") {
+		options.Timeout = &downOptions.ForceTimeout
+	}
+	return teardown(reader, options)
 }
@@ -32,6 +32,7 @@ var (
 		Example: `podman kube generate ctrID
   podman kube generate podID
   podman kube generate --service podID
+  podman kube generate --ingress httproute podID
   podman kube generate volumeName
   podman kube generate ctrID podID volumeName --service`,
 	}
@@ -65,6 +66,10 @@ func generateFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig) {
 	flags := cmd.Flags()
 	flags.BoolVarP(&generateOptions.Service, "service", "s", false, "Generate YAML for a Kubernetes service object")
 
+	ingressFlagName := "ingress"
+	flags.StringVar(&generateOptions.Ingress, ingressFlagName, "", "Generate YAML for an object routing HTTP traffic to the service: ingress or httproute (implies --service)")
+	_ = cmd.RegisterFlagCompletionFunc(ingressFlagName, common.AutocompleteKubeIngressType)
+
 	filenameFlagName := "filename"
 	flags.StringVarP(&generateFile, filenameFlagName, "f", "", "Write output to the specified path")
 	_ = cmd.RegisterFlagCompletionFunc(filenameFlagName, completion.AutocompleteDefault)
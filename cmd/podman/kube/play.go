@@ -165,6 +165,9 @@ func playFlags(cmd *cobra.Command) {
 	publishAllPortsFlagName := "publish-all"
 	flags.BoolVar(&playOptions.PublishAllPorts, publishAllPortsFlagName, false, "Whether to publish all ports defined in the K8S YAML file (containerPort, hostPort), if false only hostPort will be published")
 
+	networkPolicyFlagName := "network-policy"
+	flags.BoolVar(&playOptions.NetworkPolicy, networkPolicyFlagName, false, "Enforce the NetworkPolicy objects of the K8S YAML file with firewall rules")
+
 	waitFlagName := "wait"
 	flags.BoolVarP(&playOptions.Wait, waitFlagName, "w", false, "Clean up all objects created when a SIGTERM is received or pods exit")
 
@@ -461,6 +464,19 @@ func teardown(body io.Reader, options entities.PlayKubeDownOptions) error {
 		}
 	}
 
+	// Output rm'd ConfigMap and Secret volumes
+	if options.Force || options.ConfigMaps {
+		fmt.Println("ConfigMap and Secret volumes removed:")
+		for _, removed := range reports.ConfigMapRmReport {
+			switch {
+			case removed.Err != nil:
+				volRmErrors = append(volRmErrors, removed.Err)
+			default:
+				fmt.Println(removed.Id)
+			}
+		}
+	}
+
 	return volRmErrors.PrintErrors()
 }
 
@@ -5,9 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"strings"
 
 	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	pkgAuth "github.com/dmikushin/podman-shared/pkg/auth"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/spf13/cobra"
 	"go.podman.io/common/pkg/auth"
@@ -17,7 +20,8 @@ import (
 
 type loginOptionsWrapper struct {
 	auth.LoginOptions
-	tlsVerify bool
+	tlsVerify        bool
+	credentialHelper string
 }
 
 var (
@@ -31,7 +35,8 @@ var (
 		ValidArgsFunction: common.AutocompleteRegistries,
 		Example: `podman login quay.io
   podman login --username ... --password ... quay.io
-  podman login --authfile dir/auth.json quay.io`,
+  podman login --authfile dir/auth.json quay.io
+  podman login --credential-helper secretservice quay.io`,
 	}
 )
 
@@ -56,6 +61,10 @@ func init() {
 	flags.String(secretFlagName, "", "Retrieve password from a podman secret")
 	_ = loginCommand.RegisterFlagCompletionFunc(secretFlagName, common.AutocompleteSecrets)
 
+	credentialHelperFlagName := "credential-helper"
+	flags.StringVar(&loginOptions.credentialHelper, credentialHelperFlagName, "", "Store the credentials of the registry in the specified credential helper")
+	_ = loginCommand.RegisterFlagCompletionFunc(credentialHelperFlagName, completion.AutocompleteNone)
+
 	loginOptions.Stdin = os.Stdin
 	loginOptions.Stdout = os.Stdout
 	loginOptions.AcceptUnspecifiedRegistry = true
@@ -99,6 +108,34 @@ func login(cmd *cobra.Command, args []string) error {
 		DockerInsecureSkipTLSVerify: skipTLS,
 	}
 	common.SetRegistriesConfPath(sysCtx)
+	if loginOptions.credentialHelper != "" {
+		if err := selectCredentialHelper(args, loginOptions.credentialHelper); err != nil {
+			return err
+		}
+	}
 	loginOptions.GetLoginSet = cmd.Flag("get-login").Changed
 	return auth.Login(context.Background(), sysCtx, &loginOptions.LoginOptions, args)
 }
+
+// selectCredentialHelper records the credential helper in the credHelpers of
+// the auth file, so that the credentials of the registry are stored in and
+// looked up from the helper from now on.
+func selectCredentialHelper(args []string, helper string) error {
+	if len(args) != 1 {
+		return errors.New("--credential-helper requires a registry to log in to")
+	}
+	if helper != pkgAuth.AuthFileHelper {
+		if _, err := exec.LookPath("docker-credential-" + helper); err != nil {
+			return fmt.Errorf("credential helper %q: %w", helper, err)
+		}
+	}
+	authfile := loginOptions.AuthFile
+	if loginOptions.DockerCompatAuthFile != "" {
+		authfile = loginOptions.DockerCompatAuthFile
+	}
+	if authfile == "" {
+		authfile = pkgAuth.DefaultAuthFile()
+	}
+	registry := strings.TrimPrefix(strings.TrimPrefix(args[0], "https://"), "http://")
+	return pkgAuth.SetCredentialHelper(authfile, strings.TrimSuffix(registry, "/"), helper)
+}
@@ -6,7 +6,10 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	ldefine "github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/libpod/events"
@@ -41,8 +44,11 @@ var (
 
 // Flags which have a meaning when unspecified that differs from the flag default
 type InitOptionalFlags struct {
-	UserModeNetworking bool
-	tlsVerify          bool
+	UserModeNetworking  bool
+	tlsVerify           bool
+	network             string
+	SystemdUserServices bool
+	sysctls             []string
 }
 
 // maxMachineNameSize is set to thirty to limit huge machine names primarily
@@ -157,8 +163,30 @@ func init() {
 	flags.BoolVar(&initOptionalFlags.UserModeNetworking, userModeNetFlagName, false,
 		"Whether this machine should use user-mode networking, routing traffic through a host user-space process")
 
+	systemdUserServicesFlagName := "systemd-user-services"
+	flags.BoolVar(&initOptionalFlags.SystemdUserServices, systemdUserServicesFlagName, true,
+		"Whether the remote user runs a systemd user instance (WSL only)")
+
+	flags.BoolVar(&initOpts.CgroupsV2, "cgroups-v2", false,
+		"Boot systemd on the unified cgroup v2 hierarchy (WSL only)")
+
+	sysctlFlagName := "sysctl"
+	flags.StringArrayVar(&initOptionalFlags.sysctls, sysctlFlagName, []string{},
+		"Kernel parameters applied when the machine boots: key=value (WSL only)")
+	_ = initCmd.RegisterFlagCompletionFunc(sysctlFlagName, completion.AutocompleteNone)
+
+	networkFlagName := "network"
+	flags.StringVar(&initOptionalFlags.network, networkFlagName, "",
+		"Network mode of the machine: user (gvproxy user-mode networking) or switch (Hyper-V default switch)")
+	_ = initCmd.RegisterFlagCompletionFunc(networkFlagName, common.AutocompleteMachineNetwork)
+
 	flags.BoolVar(&initOptionalFlags.tlsVerify, "tls-verify", true,
 		"Require HTTPS and verify certificates when contacting registries")
+
+	sharedLayerStoreFlagName := "shared-layer-store"
+	flags.StringVar(&initOpts.SharedLayerStore, sharedLayerStoreFlagName, "",
+		"Store the images in the host `DIRECTORY`, shared with other machines, and create containers with --shared-base-layers")
+	_ = initCmd.RegisterFlagCompletionFunc(sharedLayerStoreFlagName, completion.AutocompleteDefault)
 }
 
 func initMachine(cmd *cobra.Command, args []string) error {
@@ -213,11 +241,35 @@ func initMachine(cmd *cobra.Command, args []string) error {
 		initOpts.Volumes[idx] = os.ExpandEnv(vol)
 	}
 
+	if initOpts.SharedLayerStore != "" {
+		if provider.VMType() == define.WSLVirt {
+			return fmt.Errorf("--shared-layer-store is not supported by the %s provider", define.WSLVirt.String())
+		}
+		store, err := filepath.Abs(os.ExpandEnv(initOpts.SharedLayerStore))
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(store, 0o755); err != nil {
+			return fmt.Errorf("creating shared layer store: %w", err)
+		}
+		initOpts.SharedLayerStore = store
+	}
+
 	// Process optional flags (flags where unspecified / nil has meaning )
 	if cmd.Flags().Changed("user-mode-networking") {
 		initOpts.UserModeNetworking = &initOptionalFlags.UserModeNetworking
 	}
 
+	if cmd.Flags().Changed("network") {
+		if err := setNetworkMode(); err != nil {
+			return err
+		}
+	}
+
+	if err := setWSLProvisionOptions(cmd); err != nil {
+		return err
+	}
+
 	if cmd.Flags().Changed("memory") {
 		if err := checkMaxMemory(strongunits.MiB(initOpts.Memory)); err != nil {
 			return err
@@ -259,6 +311,12 @@ func initMachine(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if initOpts.SharedLayerStore != "" {
+		if err := setSharedBaseLayersDefault(initOpts.Name); err != nil {
+			return err
+		}
+	}
+
 	newMachineEvent(events.Init, events.Event{Name: initOpts.Name})
 	fmt.Println("Machine init complete")
 
@@ -273,6 +331,73 @@ func initMachine(cmd *cobra.Command, args []string) error {
 	return err
 }
 
+// setSharedBaseLayersDefault makes the connections of the machine create
+// containers with --shared-base-layers by default, so that they use the
+// layers of the shared layer store instead of copying them.
+func setSharedBaseLayersDefault(name string) error {
+	return registry.EditConnectionDefaults(func(all registry.ConnectionDefaults) error {
+		for _, connection := range []string{name, name + "-root"} {
+			if all[connection] == nil {
+				all[connection] = make(map[string]string)
+			}
+			all[connection]["shared-base-layers"] = "true"
+		}
+		return nil
+	})
+}
+
+// setNetworkMode validates the --network flag against the provider and the
+// --user-mode-networking flag and stores the result in initOpts
+func setNetworkMode() error {
+	mode, err := define.ParseNetworkMode(initOptionalFlags.network)
+	if err != nil {
+		return err
+	}
+	switch mode {
+	case define.NetworkModeSwitch:
+		if provider.VMType() != define.HyperVVirt {
+			return fmt.Errorf("network mode %q is only supported by the %s provider", mode, define.HyperVVirt.String())
+		}
+	case define.NetworkModeUser:
+		if initOpts.UserModeNetworking != nil && !*initOpts.UserModeNetworking {
+			return fmt.Errorf("network mode %q conflicts with --user-mode-networking=false", mode)
+		}
+		userModeNetworking := true
+		initOpts.UserModeNetworking = &userModeNetworking
+	}
+	initOpts.NetworkMode = mode
+	return nil
+}
+
+// setWSLProvisionOptions validates the WSL only flags and stores them in initOpts
+func setWSLProvisionOptions(cmd *cobra.Command) error {
+	for _, name := range []string{"systemd-user-services", "cgroups-v2", "sysctl"} {
+		if cmd.Flags().Changed(name) && provider.VMType() != define.WSLVirt {
+			return fmt.Errorf("--%s is only supported by the %s provider", name, define.WSLVirt.String())
+		}
+	}
+
+	if cmd.Flags().Changed("systemd-user-services") {
+		if !initOptionalFlags.SystemdUserServices && !initOpts.Rootful {
+			return errors.New("--systemd-user-services=false requires --rootful, rootless API forwarding depends on the systemd user instance")
+		}
+		initOpts.SystemdUserServices = &initOptionalFlags.SystemdUserServices
+	}
+
+	if len(initOptionalFlags.sysctls) > 0 {
+		initOpts.Sysctls = make(map[string]string, len(initOptionalFlags.sysctls))
+		for _, sysctl := range initOptionalFlags.sysctls {
+			key, value, ok := strings.Cut(sysctl, "=")
+			key = strings.TrimSpace(key)
+			if !ok || key == "" || strings.ContainsAny(key, " \t\n") {
+				return fmt.Errorf("invalid sysctl %q: must be in the form key=value", sysctl)
+			}
+			initOpts.Sysctls[key] = strings.TrimSpace(value)
+		}
+	}
+	return nil
+}
+
 // checkMaxMemory gets the total system memory and compares it to the variable.  if the variable
 // is larger than the total memory, it returns an error
 func checkMaxMemory(newMem strongunits.MiB) error {
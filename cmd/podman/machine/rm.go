@@ -3,9 +3,12 @@
 package machine
 
 import (
+	"errors"
+
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/libpod/events"
 	"github.com/dmikushin/podman-shared/pkg/machine"
+	"github.com/dmikushin/podman-shared/pkg/machine/define"
 	"github.com/dmikushin/podman-shared/pkg/machine/env"
 	"github.com/dmikushin/podman-shared/pkg/machine/shim"
 	"github.com/dmikushin/podman-shared/pkg/machine/vmconfigs"
@@ -68,6 +71,18 @@ func rm(_ *cobra.Command, args []string) error {
 	if err := shim.Remove(mc, provider, dirs, destroyOptions); err != nil {
 		return err
 	}
+	// The defaults of the connections of the machine are removed with
+	// the connections, unless the removal was not confirmed.
+	var notExist *define.ErrVMDoesNotExist
+	if _, err := vmconfigs.LoadMachineByName(vmName, dirs); errors.As(err, &notExist) {
+		if err := registry.EditConnectionDefaults(func(defaults registry.ConnectionDefaults) error {
+			delete(defaults, vmName)
+			delete(defaults, vmName+"-root")
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
 	newMachineEvent(events.Remove, events.Event{Name: vmName})
 	return nil
 }
@@ -9,9 +9,11 @@ import (
 
 	_ "github.com/dmikushin/podman-shared/cmd/podman/artifact"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/completion"
+	_ "github.com/dmikushin/podman-shared/cmd/podman/devices"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/farm"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/generate"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/healthcheck"
+	_ "github.com/dmikushin/podman-shared/cmd/podman/hooks"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/images"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/kube"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/machine"
@@ -20,7 +22,10 @@ import (
 	_ "github.com/dmikushin/podman-shared/cmd/podman/networks"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/pods"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/quadlet"
+	_ "github.com/dmikushin/podman-shared/cmd/podman/registries"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	_ "github.com/dmikushin/podman-shared/cmd/podman/rootfs"
+	_ "github.com/dmikushin/podman-shared/cmd/podman/seccomp"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/secrets"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/system"
 	_ "github.com/dmikushin/podman-shared/cmd/podman/system/connection"
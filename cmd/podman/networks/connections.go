@@ -0,0 +1,120 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	networkConnectionsDescription = `List the network connections of containers.
+
+  Connections are tracked for containers created with --track-flows, from the connection tracking of the kernel.`
+	networkConnectionsCommand = &cobra.Command{
+		Use:               "connections [options] CONTAINER [CONTAINER...]",
+		Short:             "List the network connections of containers",
+		Long:              networkConnectionsDescription,
+		RunE:              networkConnections,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: common.AutocompleteContainersRunning,
+		Example: `podman network connections ctr1
+  podman network connections --format "{{.Remote}} {{.BytesReceived}}" ctr1 ctr2`,
+	}
+	networkConnectionsOptions = struct {
+		format    string
+		noHeading bool
+	}{}
+)
+
+// connectionReporter is a connection of a container for templates.
+type connectionReporter struct {
+	Container string
+	define.InspectNetworkFlow
+}
+
+// Local returns the local address and port of the connection.
+func (c connectionReporter) Local() string {
+	return net.JoinHostPort(c.LocalAddress, strconv.Itoa(int(c.LocalPort)))
+}
+
+// Remote returns the remote address and port of the connection.
+func (c connectionReporter) Remote() string {
+	return net.JoinHostPort(c.RemoteAddress, strconv.Itoa(int(c.RemotePort)))
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: networkConnectionsCommand,
+		Parent:  networkCmd,
+	})
+	flags := networkConnectionsCommand.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&networkConnectionsOptions.format, formatFlagName, "{{range .}}{{.Container}}\t{{.Protocol}}\t{{.Direction}}\t{{.Local}}\t{{.Remote}}\t{{.State}}\t{{.BytesSent}}\t{{.BytesReceived}}\n{{end -}}", "Format connection output using Go template")
+	_ = networkConnectionsCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&connectionReporter{}))
+
+	flags.BoolVarP(&networkConnectionsOptions.noHeading, "noheading", "n", false, "Do not print headers")
+}
+
+func networkConnections(cmd *cobra.Command, args []string) error {
+	reports, errs, err := registry.ContainerEngine().ContainerInspect(registry.Context(), args, entities.InspectOptions{})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	var connections []connectionReporter
+	for _, ctr := range reports {
+		for _, flow := range ctr.NetworkFlows {
+			connections = append(connections, connectionReporter{Container: ctr.Name, InspectNetworkFlow: flow})
+		}
+	}
+
+	if report.IsJSON(networkConnectionsOptions.format) {
+		if connections == nil {
+			connections = []connectionReporter{}
+		}
+		prettyJSON, err := json.MarshalIndent(connections, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(prettyJSON))
+		return nil
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, networkConnectionsOptions.format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, networkConnectionsOptions.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !networkConnectionsOptions.noHeading {
+		headers := report.Headers(connectionReporter{}, map[string]string{
+			"Local":         "local",
+			"Remote":        "remote",
+			"BytesSent":     "sent",
+			"BytesReceived": "received",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(connections)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/parse"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
@@ -89,6 +90,8 @@ func networkCreateFlags(cmd *cobra.Command) {
 
 	flags.BoolVar(&networkCreateOptions.DisableDNS, "disable-dns", false, "disable dns plugin")
 
+	flags.BoolVar(&networkCreateOptions.DNSCache, "dns-cache", false, "cache the answers of the DNS servers of this network (requires aardvark-dns with DNS cache support)")
+
 	flags.BoolVar(&networkCreateOptions.IgnoreIfExists, "ignore", false, "Don't fail if network already exists")
 	dnsserverFlagName := "dns"
 	flags.StringSliceVar(&networkCreateOptions.NetworkDNSServers, dnsserverFlagName, nil, "DNS servers this network will use")
@@ -118,6 +121,12 @@ func networkCreate(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("unable to parse options: %w", err)
 	}
+	if networkCreateOptions.DNSCache {
+		if networkCreateOptions.DisableDNS {
+			return errors.New("--dns-cache and --disable-dns cannot be used together")
+		}
+		networkCreateOptions.Labels[define.DNSCacheLabel] = "true"
+	}
 
 	network := types.Network{
 		Name:              name,
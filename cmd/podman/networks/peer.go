@@ -0,0 +1,115 @@
+package network
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/domain/infra"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/config"
+)
+
+var (
+	networkPeerDescription = `Exchange the WireGuard peers of a network between this host and the hosts of system connections.
+
+  The network must have been created with the wireguard driver on every host, with distinct subnets.`
+	networkPeerCommand = &cobra.Command{
+		Use:               "peer [options] NETWORK [CONNECTION...]",
+		Short:             "Exchange the WireGuard peers of a network between hosts",
+		Long:              networkPeerDescription,
+		RunE:              networkPeer,
+		Args:              cobra.MinimumNArgs(1),
+		ValidArgsFunction: autocompleteNetworkPeer,
+		Example: `podman network peer overlay host1 host2
+  podman network peer --farm farm1 overlay`,
+	}
+	networkPeerFarm string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: networkPeerCommand,
+		Parent:  networkCmd,
+	})
+	flags := networkPeerCommand.Flags()
+
+	farmFlagName := "farm"
+	flags.StringVar(&networkPeerFarm, farmFlagName, "", "Exchange the peers with the hosts of the connections of the `FARM`")
+	_ = networkPeerCommand.RegisterFlagCompletionFunc(farmFlagName, common.AutoCompleteFarms)
+}
+
+func autocompleteNetworkPeer(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return common.AutocompleteNetworks(cmd, args, toComplete)
+	}
+	return common.AutocompleteSystemConnections(cmd, args, toComplete)
+}
+
+func networkPeer(_ *cobra.Command, args []string) error {
+	name := args[0]
+	cons, err := networkPeerConnections(args[1:])
+	if err != nil {
+		return err
+	}
+	if len(cons) == 0 {
+		return errors.New("at least one connection or --farm is required")
+	}
+
+	// this host first, then the hosts of the connections
+	engines := []entities.ContainerEngine{registry.ContainerEngine()}
+	hosts := []string{"local"}
+	for _, con := range cons {
+		engine, err := infra.NewContainerEngine(&entities.PodmanConfig{
+			EngineMode:  entities.TunnelMode,
+			URI:         con.URI,
+			Identity:    con.Identity,
+			MachineMode: con.IsMachine,
+		})
+		if err != nil {
+			return fmt.Errorf("connecting to %q: %w", con.Name, err)
+		}
+		engines = append(engines, engine)
+		hosts = append(hosts, con.Name)
+	}
+
+	peers := make([]entities.NetworkWireGuardPeer, 0, len(engines))
+	for i, engine := range engines {
+		peer, err := engine.NetworkWireGuardPeer(registry.Context(), name)
+		if err != nil {
+			return fmt.Errorf("getting the peer of %s: %w", hosts[i], err)
+		}
+		peers = append(peers, *peer)
+	}
+	for i, engine := range engines {
+		if err := engine.NetworkWireGuardSetPeers(registry.Context(), name, peers); err != nil {
+			return fmt.Errorf("setting the peers of %s: %w", hosts[i], err)
+		}
+		fmt.Println(hosts[i])
+	}
+	return nil
+}
+
+// networkPeerConnections returns the connections of the names and of the
+// farm set with --farm.
+func networkPeerConnections(names []string) ([]config.Connection, error) {
+	cfg := registry.PodmanConfig().ContainersConfDefaultsRO
+	var cons []config.Connection
+	if networkPeerFarm != "" {
+		farmCons, err := cfg.GetFarmConnections(networkPeerFarm)
+		if err != nil {
+			return nil, err
+		}
+		cons = append(cons, farmCons...)
+	}
+	for _, name := range names {
+		con, err := cfg.GetConnection(name, false)
+		if err != nil {
+			return nil, err
+		}
+		cons = append(cons, *con)
+	}
+	return cons, nil
+}
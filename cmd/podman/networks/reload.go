@@ -1,6 +1,7 @@
 package network
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/dmikushin/podman-shared/cmd/podman/common"
@@ -15,17 +16,23 @@ import (
 var (
 	networkReloadDescription = `Reload container networks, recreating firewall rules`
 	networkReloadCommand     = &cobra.Command{
-		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
-		Use:         "reload [options] [CONTAINER...]",
-		Short:       "Reload firewall rules for one or more containers",
-		Long:        networkReloadDescription,
-		RunE:        networkReload,
+		Use:   "reload [options] [CONTAINER...]",
+		Short: "Reload firewall rules for one or more containers",
+		Long:  networkReloadDescription,
+		RunE:  networkReload,
 		Args: func(cmd *cobra.Command, args []string) error {
-			return validate.CheckAllLatestAndIDFile(cmd, args, false, "")
+			if err := validate.CheckAllLatestAndIDFile(cmd, args, false, ""); err != nil {
+				return err
+			}
+			if cmd.Flag("graceful").Changed && !reloadOptions.All {
+				return errors.New("--graceful can only be used with --all")
+			}
+			return nil
 		},
 		ValidArgsFunction: common.AutocompleteContainers,
 		Example: `podman network reload 3c13ef6dd843
-  podman network reload test1 test2`,
+  podman network reload test1 test2
+  podman network reload --all --graceful`,
 	}
 )
 
@@ -35,6 +42,7 @@ var (
 
 func reloadFlags(flags *pflag.FlagSet) {
 	flags.BoolVarP(&reloadOptions.All, "all", "a", false, "Reload network configuration of all containers")
+	flags.BoolVar(&reloadOptions.Graceful, "graceful", false, "Replace firewall rules in place without dropping established connections")
 }
 
 func init() {
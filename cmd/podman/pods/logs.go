@@ -8,7 +8,6 @@ import (
 	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/cmd/podman/validate"
-	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/spf13/cobra"
@@ -113,11 +112,6 @@ func logs(_ *cobra.Command, args []string) error {
 		logsPodOptions.Until = until
 	}
 
-	// Remote can only process one container at a time
-	if registry.IsRemote() && logsPodOptions.ContainerName == "" {
-		return fmt.Errorf("-c or --container cannot be empty: %w", define.ErrInvalidArg)
-	}
-
 	logsPodOptions.StdoutWriter = os.Stdout
 	logsPodOptions.StderrWriter = os.Stderr
 
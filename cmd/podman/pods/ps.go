@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
+	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -40,6 +43,7 @@ var (
 	inputFilters []string
 	noTrunc      bool
 	psInput      entities.PodPSOptions
+	watch        uint
 )
 
 func init() {
@@ -57,7 +61,7 @@ func init() {
 	_ = psCmd.RegisterFlagCompletionFunc(filterFlagName, common.AutocompletePodPsFilters)
 
 	formatFlagName := "format"
-	flags.StringVar(&psInput.Format, formatFlagName, "", "Pretty-print pods to JSON or using a Go template")
+	flags.StringVar(&psInput.Format, formatFlagName, "", "Pretty-print pods to JSON, JSON lines (json-stream) or using a Go template")
 	_ = psCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&ListPodReporter{}))
 
 	flags.BoolP("noheading", "n", false, "Do not print headers")
@@ -69,6 +73,10 @@ func init() {
 	flags.StringVar(&psInput.Sort, sortFlagName, "created", "Sort output by created, id, name, or number")
 	_ = psCmd.RegisterFlagCompletionFunc(sortFlagName, common.AutocompletePodPsSort)
 
+	watchFlagName := "watch"
+	flags.UintVarP(&watch, watchFlagName, "w", 0, "Refresh the output on pod and container events and at least every interval in seconds")
+	_ = psCmd.RegisterFlagCompletionFunc(watchFlagName, completion.AutocompleteNone)
+
 	validate.AddLatestFlag(psCmd, &psInput.Latest)
 
 	flags.SetNormalizeFunc(utils.AliasFlags)
@@ -88,16 +96,22 @@ func pods(cmd *cobra.Command, _ []string) error {
 			psInput.Filters[fname] = append(psInput.Filters[fname], filter)
 		}
 	}
-	responses, err := registry.ContainerEngine().PodPs(context.Background(), psInput)
+	if watch > 0 && psInput.Latest {
+		return errors.New("the watch and latest flags cannot be used together")
+	}
+	responses, err := getPodResponses()
 	if err != nil {
 		return err
 	}
 
-	if err := sortPodPsOutput(psInput.Sort, responses); err != nil {
-		return err
+	var refresh <-chan struct{}
+	if watch > 0 {
+		refresh = common.WatchRefresh(registry.Context(), time.Duration(watch)*time.Second, "pod", "container")
 	}
 
 	switch {
+	case psInput.Format == common.JSONStreamFormat:
+		return jsonStreamOut(responses, refresh)
 	case report.IsJSON(psInput.Format):
 		b, err := json.MarshalIndent(responses, "", "  ")
 		if err != nil {
@@ -112,11 +126,6 @@ func pods(cmd *cobra.Command, _ []string) error {
 		return nil
 	}
 
-	lpr := make([]ListPodReporter, 0, len(responses))
-	for _, r := range responses {
-		lpr = append(lpr, ListPodReporter{r})
-	}
-
 	rpt := report.New(os.Stdout, cmd.Name())
 	defer rpt.Flush()
 
@@ -134,28 +143,107 @@ func pods(cmd *cobra.Command, _ []string) error {
 		renderHeaders = false
 	}
 
-	if renderHeaders && rpt.RenderHeaders {
-		headers := report.Headers(ListPodReporter{}, map[string]string{
-			"Id":                 "POD ID",
-			"Name":               "NAME",
-			"Status":             "STATUS",
-			"Labels":             "LABELS",
-			"NumberOfContainers": "# OF CONTAINERS",
-			"Created":            "CREATED",
-			"InfraID":            "INFRA ID",
-			"ContainerIds":       "IDS",
-			"ContainerNames":     "NAMES",
-			"ContainerStatuses":  "STATUS",
-			"Cgroup":             "CGROUP",
-			"Namespace":          "NAMESPACES",
-			"Restarts":           "RESTARTS",
-		})
-
-		if err := rpt.Execute(headers); err != nil {
+	for {
+		lpr := make([]ListPodReporter, 0, len(responses))
+		for _, r := range responses {
+			lpr = append(lpr, ListPodReporter{r})
+		}
+
+		if refresh != nil {
+			common.ClearScreen()
+		}
+		if renderHeaders && rpt.RenderHeaders {
+			headers := report.Headers(ListPodReporter{}, map[string]string{
+				"Id":                 "POD ID",
+				"Name":               "NAME",
+				"Status":             "STATUS",
+				"Labels":             "LABELS",
+				"NumberOfContainers": "# OF CONTAINERS",
+				"Created":            "CREATED",
+				"InfraID":            "INFRA ID",
+				"ContainerIds":       "IDS",
+				"ContainerNames":     "NAMES",
+				"ContainerStatuses":  "STATUS",
+				"Cgroup":             "CGROUP",
+				"Namespace":          "NAMESPACES",
+				"Restarts":           "RESTARTS",
+			})
+
+			if err := rpt.Execute(headers); err != nil {
+				return err
+			}
+		}
+		if err := rpt.Execute(lpr); err != nil {
+			return err
+		}
+		if refresh == nil {
+			return nil
+		}
+		if err := rpt.Flush(); err != nil {
+			// do not loop if Flush() has failed
+			return err
+		}
+
+		<-refresh
+		if responses, err = getPodResponses(); err != nil {
+			return err
+		}
+	}
+}
+
+func getPodResponses() ([]*entities.ListPodsReport, error) {
+	responses, err := registry.ContainerEngine().PodPs(context.Background(), psInput)
+	if err != nil {
+		return nil, err
+	}
+	if err := sortPodPsOutput(psInput.Sort, responses); err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
+
+// jsonStreamOut prints one JSON object per line for each pod.  In watch mode
+// the listing is refreshed and only the pods which changed, were added or
+// disappeared since the previous listing are printed again.
+func jsonStreamOut(responses []*entities.ListPodsReport, refresh <-chan struct{}) error {
+	type jsonStreamFormat struct {
+		*entities.ListPodsReport
+		// Removed is set when the pod is no longer part of the listing.
+		Removed bool `json:",omitempty"`
+	}
+
+	enc := json.NewEncoder(os.Stdout)
+	previous := make(map[string]*entities.ListPodsReport)
+	for {
+		current := make(map[string]*entities.ListPodsReport, len(responses))
+		for _, pod := range responses {
+			current[pod.Id] = pod
+			if prev, ok := previous[pod.Id]; ok && reflect.DeepEqual(prev, pod) {
+				continue
+			}
+			if err := enc.Encode(jsonStreamFormat{ListPodsReport: pod}); err != nil {
+				return err
+			}
+		}
+		for _, id := range slices.Sorted(maps.Keys(previous)) {
+			if _, ok := current[id]; ok {
+				continue
+			}
+			if err := enc.Encode(jsonStreamFormat{ListPodsReport: previous[id], Removed: true}); err != nil {
+				return err
+			}
+		}
+		if refresh == nil {
+			return nil
+		}
+
+		previous = current
+		<-refresh
+		var err error
+		if responses, err = getPodResponses(); err != nil {
 			return err
 		}
 	}
-	return rpt.Execute(lpr)
 }
 
 func podPsFormat() string {
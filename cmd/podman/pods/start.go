@@ -55,6 +55,12 @@ func init() {
 	_ = startCommand.RegisterFlagCompletionFunc(podIDFileFlagName, completion.AutocompleteDefault)
 
 	validate.AddLatestFlag(startCommand, &startOptions.Latest)
+
+	if !registry.IsRemote() {
+		jobsFlagName := "jobs"
+		flags.UintVar(&startOptions.Jobs, jobsFlagName, 0, "Number of containers started in parallel, 0 for the default")
+		_ = startCommand.RegisterFlagCompletionFunc(jobsFlagName, completion.AutocompleteNone)
+	}
 }
 
 func start(_ *cobra.Command, args []string) error {
@@ -63,6 +63,12 @@ func init() {
 
 	validate.AddLatestFlag(stopCommand, &stopOptions.Latest)
 
+	if !registry.IsRemote() {
+		jobsFlagName := "jobs"
+		flags.UintVar(&stopOptions.Jobs, jobsFlagName, 0, "Number of containers stopped in parallel, 0 for the default")
+		_ = stopCommand.RegisterFlagCompletionFunc(jobsFlagName, completion.AutocompleteNone)
+	}
+
 	if registry.IsRemote() {
 		_ = flags.MarkHidden("ignore")
 	}
@@ -0,0 +1,71 @@
+package pods
+
+import (
+	"fmt"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/specgenutil"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	podUpdateDescription = `Change the ports published by a pod.
+
+  The ports are published by the infra container of the pod. When the pod is running, its port forwarding is reconfigured in place.`
+	updateCommand = &cobra.Command{
+		Use:               "update [options] POD",
+		Short:             "Update an existing pod",
+		Long:              podUpdateDescription,
+		RunE:              update,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompletePods,
+		Example: `podman pod update --publish-add 8080:80 mypod
+  podman pod update --publish-remove 8080:80 --publish-add 9090:80 mypod`,
+	}
+)
+
+var (
+	updatePublishAdd    []string
+	updatePublishRemove []string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: updateCommand,
+		Parent:  podCmd,
+	})
+
+	flags := updateCommand.Flags()
+	publishAddFlagName := "publish-add"
+	flags.StringSliceVar(&updatePublishAdd, publishAddFlagName, []string{}, "Publish a container's port, or a range of ports, to the host")
+	_ = updateCommand.RegisterFlagCompletionFunc(publishAddFlagName, completion.AutocompleteNone)
+
+	publishRemoveFlagName := "publish-remove"
+	flags.StringSliceVar(&updatePublishRemove, publishRemoveFlagName, []string{}, "Remove a published port, or a range of ports")
+	_ = updateCommand.RegisterFlagCompletionFunc(publishRemoveFlagName, completion.AutocompleteNone)
+}
+
+func update(_ *cobra.Command, args []string) error {
+	publishAdd, err := specgenutil.CreatePortBindings(updatePublishAdd)
+	if err != nil {
+		return fmt.Errorf("parsing --publish-add: %w", err)
+	}
+	publishRemove, err := specgenutil.CreatePortBindings(updatePublishRemove)
+	if err != nil {
+		return fmt.Errorf("parsing --publish-remove: %w", err)
+	}
+
+	report, err := registry.ContainerEngine().PodUpdate(registry.Context(), &entities.PodUpdateOptions{
+		NameOrID:      args[0],
+		PublishAdd:    publishAdd,
+		PublishRemove: publishRemove,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Println(report.Id)
+	return nil
+}
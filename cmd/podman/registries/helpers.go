@@ -0,0 +1,199 @@
+package registries
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/dmikushin/podman-shared/pkg/auth"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+	"go.podman.io/image/v5/types"
+)
+
+var (
+	// Command: podman registry _helpers_
+	helpersCmd = &cobra.Command{
+		Use:   "helpers",
+		Short: "Inspect the credential helpers",
+		Long:  "Inspect the credential helpers which supply the credentials of registries",
+		RunE:  validate.SubCommandExists,
+	}
+
+	helpersListCmd = &cobra.Command{
+		Use:               "ls [options]",
+		Aliases:           []string{"list"},
+		Short:             "List the configured credential helpers",
+		Long:              "List the credential helpers configured in registries.conf and the ones selected per registry in the auth file.",
+		RunE:              helpersList,
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           "podman registry helpers ls",
+	}
+
+	helpersTestCmd = &cobra.Command{
+		Use:               "test [options] REGISTRY",
+		Short:             "Test which credential helpers supply credentials for a registry",
+		Long:              "Query the configured credential helpers, in the order they are used, for the credentials of a registry.",
+		RunE:              helpersTest,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteRegistries,
+		Example: `podman registry helpers test quay.io
+  podman registry helpers test --helper secretservice quay.io`,
+	}
+)
+
+var helpersOptions struct {
+	authfile  string
+	format    string
+	helper    string
+	noHeading bool
+}
+
+type helperListReport struct {
+	Name       string
+	Registries string
+	Path       string
+}
+
+type helperTestReport struct {
+	Helper   string
+	Status   string
+	Username string
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: helpersCmd,
+		Parent:  registryCmd,
+	})
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: helpersListCmd,
+		Parent:  helpersCmd,
+	})
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: helpersTestCmd,
+		Parent:  helpersCmd,
+	})
+
+	for _, cmd := range []*cobra.Command{helpersListCmd, helpersTestCmd} {
+		flags := cmd.Flags()
+
+		authfileFlagName := "authfile"
+		flags.StringVar(&helpersOptions.authfile, authfileFlagName, "", "path of the authentication file. Use REGISTRY_AUTH_FILE environment variable to override")
+		_ = cmd.RegisterFlagCompletionFunc(authfileFlagName, completion.AutocompleteDefault)
+
+		formatFlagName := "format"
+		flags.StringVar(&helpersOptions.format, formatFlagName, "", "Format the output using a Go template")
+
+		flags.BoolVarP(&helpersOptions.noHeading, "noheading", "n", false, "Do not print headers")
+	}
+	_ = helpersListCmd.RegisterFlagCompletionFunc("format", common.AutocompleteFormat(&helperListReport{}))
+	_ = helpersTestCmd.RegisterFlagCompletionFunc("format", common.AutocompleteFormat(&helperTestReport{}))
+
+	helperFlagName := "helper"
+	helpersTestCmd.Flags().StringVar(&helpersOptions.helper, helperFlagName, "", "Only test the specified credential helper")
+	_ = helpersTestCmd.RegisterFlagCompletionFunc(helperFlagName, completion.AutocompleteNone)
+}
+
+func authfile() string {
+	if helpersOptions.authfile != "" {
+		return helpersOptions.authfile
+	}
+	return auth.DefaultAuthFile()
+}
+
+func credentialHelpers() ([]auth.CredentialHelper, error) {
+	sysCtx := &types.SystemContext{}
+	common.SetRegistriesConfPath(sysCtx)
+	return auth.CredentialHelpers(sysCtx, authfile())
+}
+
+func helpersList(cmd *cobra.Command, _ []string) error {
+	helpers, err := credentialHelpers()
+	if err != nil {
+		return err
+	}
+	reports := make([]helperListReport, 0, len(helpers))
+	for _, helper := range helpers {
+		registries := "*"
+		if len(helper.Registries) > 0 {
+			registries = strings.Join(helper.Registries, ",")
+		}
+		path := helper.Path
+		if path == "" {
+			path = "not installed"
+		}
+		reports = append(reports, helperListReport{Name: helper.Name, Registries: registries, Path: path})
+	}
+	return writeReport(cmd, "{{range .}}{{.Name}}\t{{.Registries}}\t{{.Path}}\n{{end -}}", helperListReport{}, reports)
+}
+
+func helpersTest(cmd *cobra.Command, args []string) error {
+	target := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(args[0], "https://"), "http://"), "/")
+
+	var names []string
+	if helpersOptions.helper != "" {
+		names = []string{helpersOptions.helper}
+	} else {
+		helpers, err := credentialHelpers()
+		if err != nil {
+			return err
+		}
+		for _, helper := range helpers {
+			// helpers selected per registry are used through the auth file
+			if len(helper.Registries) == 0 {
+				names = append(names, helper.Name)
+			}
+		}
+	}
+
+	supplied := false
+	reports := make([]helperTestReport, 0, len(names))
+	for _, name := range names {
+		rpt := helperTestReport{Helper: name, Status: "no credentials"}
+		username, err := auth.HelperUsername(authfile(), name, target)
+		switch {
+		case err != nil:
+			rpt.Status = "error: " + err.Error()
+		case username != "":
+			rpt.Status = "ok"
+			rpt.Username = username
+			supplied = true
+		}
+		reports = append(reports, rpt)
+	}
+	if err := writeReport(cmd, "{{range .}}{{.Helper}}\t{{.Status}}\t{{.Username}}\n{{end -}}", helperTestReport{}, reports); err != nil {
+		return err
+	}
+	if !supplied {
+		return fmt.Errorf("no credential helper supplies credentials for %s", target)
+	}
+	return nil
+}
+
+func writeReport(cmd *cobra.Command, defaultFormat string, headerType any, reports any) error {
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	var err error
+	if cmd.Flag("format").Changed {
+		rpt, err = rpt.Parse(report.OriginUser, helpersOptions.format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, defaultFormat)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !helpersOptions.noHeading {
+		if err := rpt.Execute(report.Headers(headerType, nil)); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(reports)
+}
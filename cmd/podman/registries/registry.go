@@ -0,0 +1,23 @@
+package registries
+
+import (
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Command: podman _registry_
+	registryCmd = &cobra.Command{
+		Use:   "registry",
+		Short: "Manage the configuration of container registries",
+		Long:  "Manage the configuration of container registries",
+		RunE:  validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: registryCmd,
+	})
+}
@@ -28,6 +28,9 @@ const (
 
 	// EngineMode used as cobra.Annotation when command supports a limited number of Engines
 	EngineMode = "EngineMode"
+
+	// LazyStorage used as cobra.Annotation when command does not need the container storage, which is then only set up if used
+	LazyStorage = "LazyStorage"
 )
 
 var (
@@ -183,3 +186,15 @@ func RetryDelayDefault() string {
 
 	return PodmanConfig().ContainersConfDefaultsRO.Engine.RetryDelay
 }
+
+// PullMaxAgeDefault returns the default maximum age of the
+// newer-with-max-age pull policy, $PODMAN_PULL_MAX_AGE, which can be set in
+// the env of the engine table of containers.conf.
+func PullMaxAgeDefault() string {
+	// Loading containers.conf sets the environment of the engine.
+	_ = PodmanConfig()
+	if maxAge, found := os.LookupEnv("PODMAN_PULL_MAX_AGE"); found {
+		return maxAge
+	}
+	return util.DefaultPullMaxAge
+}
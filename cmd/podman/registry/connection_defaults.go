@@ -0,0 +1,144 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.podman.io/storage/pkg/homedir"
+	"go.podman.io/storage/pkg/ioutils"
+	"go.podman.io/storage/pkg/lockfile"
+)
+
+const connectionDefaultsFile = "podman-connection-defaults.json"
+
+var connectionDefaultKeyRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// ConnectionDefaults maps a connection name to the option values used by
+// default when that connection is active, e.g. "shared-base-layers": "true".
+// The keys are the long names of command line options.
+type ConnectionDefaults map[string]map[string]string
+
+// connectionDefaultsConfigFile returns the path of the connection defaults
+// file, it is stored next to the connections file.
+func connectionDefaultsConfigFile() (string, error) {
+	if path, found := os.LookupEnv("PODMAN_CONNECTIONS_CONF"); found {
+		return filepath.Join(filepath.Dir(path), connectionDefaultsFile), nil
+	}
+	configHome, err := homedir.GetConfigHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configHome, "containers", connectionDefaultsFile), nil
+}
+
+// ParseConnectionDefaults parses key=value pairs given to
+// `podman system connection add --set`
+func ParseConnectionDefaults(pairs []string) (map[string]string, error) {
+	defaults := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimPrefix(key, "--")
+		if !ok || !connectionDefaultKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("invalid connection default %q: must be in the form option=value", pair)
+		}
+		defaults[key] = value
+	}
+	return defaults, nil
+}
+
+// ReadConnectionDefaults reads the connection defaults file, a missing file
+// results in empty defaults
+func ReadConnectionDefaults() (ConnectionDefaults, error) {
+	path, err := connectionDefaultsConfigFile()
+	if err != nil {
+		return nil, err
+	}
+	return readConnectionDefaults(path)
+}
+
+func readConnectionDefaults(path string) (ConnectionDefaults, error) {
+	defaults := make(ConnectionDefaults)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return defaults, nil
+		}
+		return nil, err
+	}
+	if err := JSONLibrary().Unmarshal(content, &defaults); err != nil {
+		return nil, fmt.Errorf("parse %q: %w", path, err)
+	}
+	return defaults, nil
+}
+
+// EditConnectionDefaults must be used to edit the connection defaults. The
+// file is read and written automatically under a lock, the callback only
+// needs to modify the defaults as needed.
+func EditConnectionDefaults(callback func(defaults ConnectionDefaults) error) error {
+	path, err := connectionDefaultsConfigFile()
+	if err != nil {
+		return err
+	}
+
+	lock, err := lockfile.GetLockFile(path + ".lock")
+	if err != nil {
+		return fmt.Errorf("obtain lock file: %w", err)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+
+	defaults, err := readConnectionDefaults(path)
+	if err != nil {
+		return fmt.Errorf("read connection defaults file: %w", err)
+	}
+
+	if err := callback(defaults); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	content, err := JSONLibrary().Marshal(defaults)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(path, content, 0o644)
+}
+
+// ApplyConnectionDefaults sets the options of cmd to the defaults stored for
+// the given connection. Options given on the command line take precedence
+// and defaults for options the command does not have are ignored.
+func ApplyConnectionDefaults(cmd *cobra.Command, connection string) error {
+	all, err := ReadConnectionDefaults()
+	if err != nil {
+		return err
+	}
+	defaults := all[connection]
+
+	keys := make([]string, 0, len(defaults))
+	for key := range defaults {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		flag := cmd.Flags().Lookup(key)
+		if flag == nil || flag.Changed {
+			continue
+		}
+		logrus.Debugf("Using default --%s=%s of connection %q", key, defaults[key], connection)
+		if err := cmd.Flags().Set(key, defaults[key]); err != nil {
+			return fmt.Errorf("applying default --%s of connection %q: %w", key, connection, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.podman.io/storage/pkg/homedir"
+)
+
+const (
+	// ProjectRegistriesConf is the registries.conf of a project, it is
+	// looked up in the working directory and its parents.
+	ProjectRegistriesConf = ".podman/registries.conf"
+	// shortNameAliasesFile caches the short-name aliases recorded while a
+	// project or connection registries.conf is in use.
+	shortNameAliasesFile = "short-name-aliases.conf"
+)
+
+// FindProjectRegistriesConf returns the path of the ProjectRegistriesConf in
+// dir or the closest of its parents, or an empty string if there is none.
+func FindProjectRegistriesConf(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, ProjectRegistriesConf)
+		info, err := os.Stat(path)
+		switch {
+		case err == nil && !info.IsDir():
+			return path, nil
+		case err != nil && !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, fs.ErrPermission):
+			return "", err
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// ApplyScopedRegistriesConf selects the registries.conf of the project in the
+// working directory unless --registries-conf or one of the registries.conf
+// environment variables is set.  It must be called before the connection
+// defaults are applied so that the project takes precedence over the
+// registries.conf of the connection.  The short-name aliases recorded while a
+// project or connection registries.conf is in use are kept apart from the
+// ones of the user.
+func ApplyScopedRegistriesConf(cmd *cobra.Command, podmanConfig *entities.PodmanConfig) error {
+	flag := cmd.Flags().Lookup("registries-conf")
+	if flag == nil {
+		return nil
+	}
+	if !flag.Changed && os.Getenv("CONTAINERS_REGISTRIES_CONF") == "" && os.Getenv("REGISTRIES_CONFIG_PATH") == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		path, err := FindProjectRegistriesConf(cwd)
+		if err != nil {
+			return fmt.Errorf("looking up %s: %w", ProjectRegistriesConf, err)
+		}
+		if path != "" {
+			logrus.Debugf("Using registries.conf %q of the project", path)
+			if err := cmd.Flags().Set("registries-conf", path); err != nil {
+				return err
+			}
+			podmanConfig.ShortNameAliasConf = filepath.Join(filepath.Dir(path), shortNameAliasesFile)
+		}
+	}
+	return nil
+}
+
+// ApplyConnectionRegistriesConf keeps the short-name aliases recorded with the
+// registries.conf used for the active connection apart from the ones of the
+// user and of other connections.
+func ApplyConnectionRegistriesConf(podmanConfig *entities.PodmanConfig) error {
+	if podmanConfig.ConnectionName == "" || podmanConfig.RegistriesConf == "" || podmanConfig.ShortNameAliasConf != "" {
+		return nil
+	}
+	cacheHome, err := homedir.GetCacheHome()
+	if err != nil {
+		return err
+	}
+	podmanConfig.ShortNameAliasConf = filepath.Join(cacheHome, "containers", "connections", podmanConfig.ConnectionName, shortNameAliasesFile)
+	return nil
+}
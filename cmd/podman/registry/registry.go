@@ -68,7 +68,8 @@ func NewContainerEngine(cmd *cobra.Command, _ []string) (entities.ContainerEngin
 			logrus.Debugf("Performing system reset, runtime validation checks will be relaxed")
 			podmanOptions.IsReset = true
 		}
-		if cmd.Name() == "renumber" && cmd.Parent().Name() == "system" {
+		// A dry run must not recreate the locks.
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); cmd.Name() == "renumber" && cmd.Parent().Name() == "system" && !dryRun {
 			logrus.Debugf("Performing system renumber, runtime validation checks will be relaxed")
 			podmanOptions.IsRenumber = true
 		}
@@ -165,6 +165,7 @@ func readRemoteCliFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig)
 		if err != nil {
 			return err
 		}
+		podmanConfig.ConnectionName = con.Name
 		podmanConfig.URI = con.URI
 		podmanConfig.Identity = con.Identity
 		podmanConfig.TLSCertFile = con.TLSCert
@@ -172,6 +173,7 @@ func readRemoteCliFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig)
 		podmanConfig.TLSCAFile = con.TLSCA
 		podmanConfig.MachineMode = con.IsMachine
 	case url.Changed:
+		podmanConfig.ConnectionName = ""
 		podmanConfig.URI = url.Value.String()
 	case contextConn != nil && contextConn.Changed:
 		service := contextConn.Value.String()
@@ -180,6 +182,7 @@ func readRemoteCliFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig)
 			if err != nil {
 				return err
 			}
+			podmanConfig.ConnectionName = con.Name
 			podmanConfig.URI = con.URI
 			podmanConfig.Identity = con.Identity
 			podmanConfig.TLSCertFile = con.TLSCert
@@ -188,6 +191,7 @@ func readRemoteCliFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig)
 			podmanConfig.MachineMode = con.IsMachine
 		}
 	case host.Changed:
+		podmanConfig.ConnectionName = ""
 		podmanConfig.URI = host.Value.String()
 	default:
 		// No cli options set, in case CONTAINER_CONNECTION was set to something
@@ -223,6 +227,7 @@ func setupRemoteConnection(podmanConfig *entities.PodmanConfig) string {
 		podmanConfig.TLSKeyFile = con.TLSKey
 		podmanConfig.TLSCAFile = con.TLSCA
 		podmanConfig.MachineMode = con.IsMachine
+		podmanConfig.ConnectionName = con.Name
 		return con.Name
 	case hostEnv != "":
 		if sshkeyEnv != "" {
@@ -238,6 +243,7 @@ func setupRemoteConnection(podmanConfig *entities.PodmanConfig) string {
 			podmanConfig.TLSKeyFile = con.TLSKey
 			podmanConfig.TLSCAFile = con.TLSCA
 			podmanConfig.MachineMode = con.IsMachine
+			podmanConfig.ConnectionName = con.Name
 			return con.Name
 		}
 		podmanConfig.URI = registry.DefaultAPIAddress()
@@ -340,6 +346,19 @@ func persistentPreRunE(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("read cli flags: %w", err)
 	}
 
+	if err := registry.ApplyScopedRegistriesConf(cmd, podmanConfig); err != nil {
+		return err
+	}
+
+	if registry.IsRemote() && podmanConfig.ConnectionName != "" {
+		if err := registry.ApplyConnectionDefaults(cmd, podmanConfig.ConnectionName); err != nil {
+			return err
+		}
+		if err := registry.ApplyConnectionRegistriesConf(podmanConfig); err != nil {
+			return err
+		}
+	}
+
 	// Special case if command is hidden completion command ("__complete","__completeNoDesc")
 	// Since __completeNoDesc is an alias the cm.Name is always __complete
 	if cmd.Name() == cobra.ShellCompRequestCmd {
@@ -361,6 +380,10 @@ func persistentPreRunE(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	if _, found := cmd.Annotations[registry.LazyStorage]; found {
+		podmanConfig.LazyStorage = true
+	}
+
 	// Prep the engines
 	if _, err := registry.NewImageEngine(cmd, args); err != nil {
 		// Note: this is gross, but it is the hand we are dealt
@@ -623,7 +646,9 @@ func rootFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig) {
 		pFlags.StringVar(&podmanConfig.GraphRoot, rootFlagName, "", "Path to the graph root directory where images, containers, etc. are stored")
 		_ = cmd.RegisterFlagCompletionFunc(rootFlagName, completion.AutocompleteDefault)
 
-		pFlags.StringVar(&podmanConfig.RegistriesConf, "registries-conf", "", "Path to a registries.conf to use for image processing")
+		rootRWFlagName := "root-rw"
+		pFlags.StringVar(&podmanConfig.ReadWriteRoot, rootRWFlagName, "", "Path to a writable graph root where images, containers, etc. are stored, using the graph root read-only")
+		_ = cmd.RegisterFlagCompletionFunc(rootRWFlagName, completion.AutocompleteDefault)
 
 		runrootFlagName := "runroot"
 		pFlags.StringVar(&podmanConfig.Runroot, runrootFlagName, "", "Path to the 'run directory' where all state information is stored")
@@ -635,6 +660,14 @@ func rootFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig) {
 
 		pFlags.BoolVar(&podmanConfig.TransientStore, "transient-store", false, "Enable transient container storage")
 
+		sharedLayerCacheFlagName := "shared-layer-cache"
+		pFlags.StringVar(&podmanConfig.SharedLayerCache, sharedLayerCacheFlagName, "", "Copy the shared base layers mounted often to the local `DIRECTORY` and mount the copies")
+		_ = cmd.RegisterFlagCompletionFunc(sharedLayerCacheFlagName, completion.AutocompleteDefault)
+
+		sharedLayerCacheSizeFlagName := "shared-layer-cache-size"
+		pFlags.StringVar(&podmanConfig.SharedLayerCacheSize, sharedLayerCacheSizeFlagName, "10GB", "Remove the least recently used layers of the shared layer cache to keep it under `SIZE`")
+		_ = cmd.RegisterFlagCompletionFunc(sharedLayerCacheSizeFlagName, completion.AutocompleteNone)
+
 		pFlags.StringArrayVar(&podmanConfig.PullOptions, "pull-option", nil, "Specify an option to change how the image is pulled")
 
 		runtimeFlagName := "runtime"
@@ -664,7 +697,6 @@ func rootFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig) {
 			"max-workers",
 			"memory-profile",
 			"pull-option",
-			"registries-conf",
 			"trace",
 		} {
 			if err := pFlags.MarkHidden(f); err != nil {
@@ -672,6 +704,10 @@ func rootFlags(cmd *cobra.Command, podmanConfig *entities.PodmanConfig) {
 			}
 		}
 	}
+	registriesConfFlagName := "registries-conf"
+	pFlags.StringVar(&podmanConfig.RegistriesConf, registriesConfFlagName, "", "Path to a registries.conf to use for image processing")
+	_ = cmd.RegisterFlagCompletionFunc(registriesConfFlagName, completion.AutocompleteDefault)
+
 	storageOptFlagName := "storage-opt"
 	pFlags.StringArrayVar(&podmanConfig.StorageOpts, storageOptFlagName, []string{}, "Used to pass an option to the storage driver")
 	_ = cmd.RegisterFlagCompletionFunc(storageOptFlagName, completion.AutocompleteNone)
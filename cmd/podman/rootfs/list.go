@@ -0,0 +1,103 @@
+package rootfs
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	listCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "list [options]",
+		Aliases:           []string{"ls"},
+		Short:             "List prepared root file systems",
+		RunE:              list,
+		Args:              validate.NoArgs,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman rootfs list
+  podman rootfs list --format "{{.Name}} {{.Path}}"`,
+	}
+	listFlag = listFlagType{}
+)
+
+type listFlagType struct {
+	format    string
+	noHeading bool
+	quiet     bool
+}
+
+type rootfsReporter struct {
+	entities.RootfsListReport
+}
+
+// CreatedSince returns how long ago the root file system was prepared.
+func (r rootfsReporter) CreatedSince() string {
+	return units.HumanDuration(time.Since(r.Created)) + " ago"
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: listCmd,
+		Parent:  rootfsCmd,
+	})
+
+	flags := listCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVar(&listFlag.format, formatFlagName, "{{range .}}{{.Name}}\t{{.Image}}\t{{.Linked}}\t{{.CreatedSince}}\t{{.Path}}\n{{end -}}", "Format root file system output using Go template")
+	_ = listCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&rootfsReporter{}))
+
+	flags.BoolVarP(&listFlag.noHeading, "noheading", "n", false, "Do not print headers")
+	flags.BoolVarP(&listFlag.quiet, "quiet", "q", false, "Print root file system names only")
+}
+
+func list(cmd *cobra.Command, _ []string) error {
+	responses, err := registry.ImageEngine().RootfsList(registry.Context())
+	if err != nil {
+		return err
+	}
+
+	if listFlag.quiet && !cmd.Flags().Changed("format") {
+		for _, response := range responses {
+			fmt.Println(response.Name)
+		}
+		return nil
+	}
+
+	rootfs := make([]rootfsReporter, 0, len(responses))
+	for _, response := range responses {
+		rootfs = append(rootfs, rootfsReporter{*response})
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, listFlag.format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, listFlag.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders && !listFlag.noHeading {
+		headers := report.Headers(rootfsReporter{}, map[string]string{
+			"CreatedSince": "CREATED",
+		})
+		if err := rpt.Execute(headers); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(rootfs)
+}
@@ -0,0 +1,54 @@
+package rootfs
+
+import (
+	"fmt"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	prepareDescription = `Prepare a directory with the file system of an image and print its path.
+
+  The directory can be used with --rootfs, with the :O suffix changes are written to an overlay and the directory is left unchanged.`
+	prepareCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "prepare [options] IMAGE",
+		Short:             "Prepare a root file system from an image",
+		Long:              prepareDescription,
+		RunE:              prepare,
+		Args:              cobra.ExactArgs(1),
+		ValidArgsFunction: common.AutocompleteImages,
+		Example: `podman rootfs prepare fedora
+  podman run --rootfs $(podman rootfs prepare --name dev quay.io/fedora/fedora-bootc):O bash
+  podman rootfs prepare --link --replace quay.io/centos-bootc/centos-bootc:stream9`,
+	}
+	prepareOptions = entities.RootfsPrepareOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: prepareCmd,
+		Parent:  rootfsCmd,
+	})
+	flags := prepareCmd.Flags()
+
+	nameFlagName := "name"
+	flags.StringVar(&prepareOptions.Name, nameFlagName, "", "Name of the root file system (default: the repository of the image)")
+	_ = prepareCmd.RegisterFlagCompletionFunc(nameFlagName, completion.AutocompleteNone)
+
+	flags.BoolVar(&prepareOptions.Link, "link", false, "Use the mounted image instead of copying it")
+	flags.BoolVar(&prepareOptions.Replace, "replace", false, "Replace an existing root file system with the same name")
+}
+
+func prepare(_ *cobra.Command, args []string) error {
+	response, err := registry.ImageEngine().RootfsPrepare(registry.Context(), args[0], prepareOptions)
+	if err != nil {
+		return err
+	}
+	fmt.Println(response.Path)
+	return nil
+}
@@ -0,0 +1,55 @@
+package rootfs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/utils"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rmCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "rm [options] NAME [NAME...]",
+		Aliases:           []string{"remove"},
+		Short:             "Remove prepared root file systems",
+		RunE:              rm,
+		ValidArgsFunction: common.AutocompleteRootfs,
+		Example: `podman rootfs rm fedora
+  podman rootfs rm --all`,
+	}
+	rmOptions = entities.RootfsRmOptions{}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rmCmd,
+		Parent:  rootfsCmd,
+	})
+	flags := rmCmd.Flags()
+	flags.BoolVarP(&rmOptions.All, "all", "a", false, "Remove all root file systems")
+	flags.BoolVarP(&rmOptions.Ignore, "ignore", "i", false, "Ignore errors when a specified root file system is missing")
+}
+
+func rm(_ *cobra.Command, args []string) error {
+	var errs utils.OutputErrors
+	if (len(args) > 0 && rmOptions.All) || (len(args) < 1 && !rmOptions.All) {
+		return errors.New("`podman rootfs rm` requires one argument, or the --all flag")
+	}
+	responses, err := registry.ImageEngine().RootfsRm(registry.Context(), args, rmOptions)
+	if err != nil {
+		return err
+	}
+	for _, r := range responses {
+		if r.Err == nil {
+			fmt.Println(r.Name)
+		} else {
+			errs = append(errs, r.Err)
+		}
+	}
+	return errs.PrintErrors()
+}
@@ -0,0 +1,24 @@
+package rootfs
+
+import (
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Command: podman _rootfs_
+	rootfsCmd = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "rootfs",
+		Short:       "Manage root file systems prepared from images",
+		Long:        "Manage directories holding the file systems of images, to be used with --rootfs",
+		RunE:        validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: rootfsCmd,
+	})
+}
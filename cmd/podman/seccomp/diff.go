@@ -0,0 +1,90 @@
+//go:build linux && !remote
+
+package seccomp
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/seccomp"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+	goSeccomp "go.podman.io/common/pkg/seccomp"
+)
+
+var (
+	diffDescription = `Compare a seccomp profile with the default profile, or with the profile given as second argument.
+
+  The syscalls the profiles treat differently are listed with the action of each profile.`
+	diffCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "diff [options] PROFILE [REFERENCE]",
+		Args:              cobra.RangeArgs(1, 2),
+		Short:             "Compare a seccomp profile with the default profile",
+		Long:              diffDescription,
+		RunE:              diff,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman seccomp diff ./profile.json
+  podman seccomp diff --format json ./profile.json
+  podman seccomp diff ./new.json ./old.json`,
+	}
+	diffFormat string
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: diffCmd,
+		Parent:  seccompCmd,
+	})
+	flags := diffCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVarP(&diffFormat, formatFlagName, "f", "", "Change the output to JSON or a Go template")
+	_ = diffCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&seccomp.Change{}))
+}
+
+func diff(cmd *cobra.Command, args []string) error {
+	profile, err := seccomp.ReadProfile(args[0])
+	if err != nil {
+		return err
+	}
+	var reference *goSeccomp.Seccomp
+	if len(args) > 1 {
+		reference, err = seccomp.ReadProfile(args[1])
+	} else {
+		reference, err = defaultProfile()
+	}
+	if err != nil {
+		return err
+	}
+	result := seccomp.Compare(reference, profile)
+
+	if report.IsJSON(diffFormat) {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		return enc.Encode(result)
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, diffFormat)
+	} else {
+		if result.ReferenceDefaultAction != result.ProfileDefaultAction {
+			fmt.Printf("Default action: %s -> %s\n", result.ReferenceDefaultAction, result.ProfileDefaultAction)
+		}
+		rpt, err = rpt.Parse(report.OriginPodman, "{{range .}}{{.Syscall}}\t{{.Reference}}\t{{.Profile}}\n{{end -}}")
+	}
+	if err != nil {
+		return err
+	}
+	if rpt.RenderHeaders && len(result.Changes) > 0 {
+		if err := rpt.Execute(report.Headers(seccomp.Change{}, nil)); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(result.Changes)
+}
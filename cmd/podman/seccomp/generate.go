@@ -0,0 +1,155 @@
+//go:build linux && !remote
+
+package seccomp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/seccomp"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.podman.io/common/pkg/completion"
+	goSeccomp "go.podman.io/common/pkg/seccomp"
+)
+
+var (
+	generateDescription = `Generate a minimal seccomp profile from the syscalls a container makes.
+
+  The arguments are passed to podman run, which runs the container with a profile sending the syscalls allowed by the base profile to a seccomp notify listener.  The listener records them and lets them continue, and the generated profile only allows the recorded syscalls, with the conditions of the base profile.  Use -- to pass options to podman run.`
+	generateCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "generate [options] [--] [RUN-OPTIONS] IMAGE [COMMAND [ARG...]]",
+		Args:              cobra.MinimumNArgs(1),
+		Short:             "Generate a seccomp profile from a recorded run",
+		Long:              generateDescription,
+		RunE:              generate,
+		ValidArgsFunction: common.AutocompleteCreateRun,
+		Example: `podman seccomp generate -o nginx.json nginx
+  podman seccomp generate -o ls.json -- --net none alpine ls /
+  podman run --security-opt seccomp=ls.json alpine ls /`,
+	}
+	generateOpts struct {
+		Base   string
+		Output string
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: generateCmd,
+		Parent:  seccompCmd,
+	})
+	flags := generateCmd.Flags()
+	flags.SetInterspersed(false)
+
+	baseFlagName := "base"
+	flags.StringVar(&generateOpts.Base, baseFlagName, "", "Restrict the `profile` instead of the default profile")
+	_ = generateCmd.RegisterFlagCompletionFunc(baseFlagName, completion.AutocompleteDefault)
+
+	outputFlagName := "output"
+	flags.StringVarP(&generateOpts.Output, outputFlagName, "o", "", "Write the profile to `file`")
+	_ = generateCmd.MarkFlagRequired(outputFlagName)
+	_ = generateCmd.RegisterFlagCompletionFunc(outputFlagName, completion.AutocompleteDefault)
+}
+
+func generate(cmd *cobra.Command, args []string) error {
+	var (
+		base *goSeccomp.Seccomp
+		err  error
+	)
+	if generateOpts.Base != "" {
+		base, err = seccomp.ReadProfile(generateOpts.Base)
+	} else {
+		base, err = defaultProfile()
+	}
+	if err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "podman-seccomp-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	// the OCI runtime connects to the listener from the user namespace
+	// of the container, when rootless
+	if err := os.Chmod(dir, 0o755); err != nil {
+		return err
+	}
+
+	socketPath := filepath.Join(dir, "listener.sock")
+	recorder, err := seccomp.NewRecorder(socketPath)
+	if err != nil {
+		return fmt.Errorf("creating the seccomp notify listener: %w", err)
+	}
+	profilePath := filepath.Join(dir, "recording.json")
+	content, err := json.Marshal(seccomp.RecordingProfile(base, socketPath))
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(profilePath, content, 0o644); err != nil {
+		return err
+	}
+
+	podman, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	runArgs := append(globalArgs(cmd), "run", "--rm", "--security-opt", "seccomp="+profilePath)
+	runArgs = append(runArgs, args...)
+	logrus.Debugf("Recording the syscalls of: %s %s", podman, strings.Join(runArgs, " "))
+	run := exec.Command(podman, runArgs...)
+	run.Stdin = os.Stdin
+	run.Stdout = os.Stdout
+	run.Stderr = os.Stderr
+	runErr := run.Run()
+
+	syscalls, err := recorder.Stop()
+	if err != nil {
+		return fmt.Errorf("recording the syscalls of the container: %w", err)
+	}
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return runErr
+	}
+	if len(syscalls) == 0 {
+		return errors.New("no syscall was recorded, the container did not start")
+	}
+
+	content, err = json.MarshalIndent(seccomp.MinimalProfile(base, syscalls), "", "    ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(generateOpts.Output, append(content, '\n'), 0o644); err != nil {
+		return err
+	}
+	if exitErr != nil {
+		logrus.Warnf("The container exited with code %d, the profile may miss syscalls of a successful run", exitErr.ExitCode())
+		registry.SetExitCode(exitErr.ExitCode())
+	}
+	return nil
+}
+
+// globalArgs returns the global options podman was started with, so that
+// the container is run with the same configuration.
+func globalArgs(cmd *cobra.Command) []string {
+	var args []string
+	cmd.Root().PersistentFlags().Visit(func(flag *pflag.Flag) {
+		if values, ok := flag.Value.(pflag.SliceValue); ok {
+			for _, value := range values.GetSlice() {
+				args = append(args, fmt.Sprintf("--%s=%s", flag.Name, value))
+			}
+			return
+		}
+		args = append(args, fmt.Sprintf("--%s=%s", flag.Name, flag.Value.String()))
+	})
+	return args
+}
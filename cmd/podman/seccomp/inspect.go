@@ -0,0 +1,112 @@
+//go:build linux && !remote
+
+package seccomp
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/seccomp"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	inspectDescription = `Display the default seccomp profile, or the given profile.
+
+  The default profile is the seccomp_profile of containers.conf, the seccomp.json installed on the system or the profile built into podman.`
+	inspectCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "inspect [options] [PROFILE]",
+		Args:              cobra.MaximumNArgs(1),
+		Short:             "Display a seccomp profile",
+		Long:              inspectDescription,
+		RunE:              inspect,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example: `podman seccomp inspect
+  podman seccomp inspect --syscalls
+  podman seccomp inspect --syscalls --format "{{.Syscall}}" ./profile.json`,
+	}
+	inspectOpts struct {
+		Format   string
+		Syscalls bool
+	}
+)
+
+// syscallEffect is a row of podman seccomp inspect --syscalls.
+type syscallEffect struct {
+	Syscall string
+	Action  string
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: inspectCmd,
+		Parent:  seccompCmd,
+	})
+	flags := inspectCmd.Flags()
+
+	formatFlagName := "format"
+	flags.StringVarP(&inspectOpts.Format, formatFlagName, "f", "", "Format the syscalls listed by --syscalls with a Go template")
+	_ = inspectCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&syscallEffect{}))
+
+	flags.BoolVar(&inspectOpts.Syscalls, "syscalls", false, "List the syscalls with a rule in the profile and their action")
+}
+
+func inspect(cmd *cobra.Command, args []string) error {
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		var err error
+		path, err = seccomp.DefaultProfilePath(registry.PodmanConfig().ContainersConfDefaultsRO.Containers.SeccompProfile)
+		if err != nil {
+			return err
+		}
+	}
+	profile, err := loadProfile(path)
+	if err != nil {
+		return err
+	}
+
+	if !inspectOpts.Syscalls {
+		if cmd.Flags().Changed("format") {
+			return errors.New("--format can only be used with --syscalls")
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "    ")
+		return enc.Encode(profile)
+	}
+
+	rows := make([]syscallEffect, 0)
+	for name, effect := range seccomp.Effects(profile) {
+		rows = append(rows, syscallEffect{Syscall: name, Action: effect.String()})
+	}
+	rows = append(rows, syscallEffect{Syscall: "*", Action: string(profile.DefaultAction)})
+	slices.SortFunc(rows, func(a, b syscallEffect) int {
+		return cmp.Compare(a.Syscall, b.Syscall)
+	})
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, inspectOpts.Format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman, "{{range .}}{{.Syscall}}\t{{.Action}}\n{{end -}}")
+	}
+	if err != nil {
+		return err
+	}
+	if rpt.RenderHeaders {
+		if err := rpt.Execute(report.Headers(syscallEffect{}, nil)); err != nil {
+			return fmt.Errorf("failed to write report column headers: %w", err)
+		}
+	}
+	return rpt.Execute(rows)
+}
@@ -0,0 +1,50 @@
+//go:build linux && !remote
+
+package seccomp
+
+import (
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/dmikushin/podman-shared/pkg/seccomp"
+	"github.com/spf13/cobra"
+	goSeccomp "go.podman.io/common/pkg/seccomp"
+)
+
+var (
+	// Pull in configured json library
+	json = registry.JSONLibrary()
+
+	// Command: podman _seccomp_
+	seccompCmd = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "seccomp",
+		Short:       "Inspect, compare and generate seccomp profiles",
+		Long:        "Inspect the default seccomp profile, compare custom profiles with it and generate minimal profiles from the syscalls a container makes",
+		RunE:        validate.SubCommandExists,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: seccompCmd,
+	})
+}
+
+// defaultProfile returns the profile used for containers started without a
+// seccomp security option.
+func defaultProfile() (*goSeccomp.Seccomp, error) {
+	path, err := seccomp.DefaultProfilePath(registry.PodmanConfig().ContainersConfDefaultsRO.Containers.SeccompProfile)
+	if err != nil {
+		return nil, err
+	}
+	return loadProfile(path)
+}
+
+// loadProfile returns the profile at path, or the profile built into podman
+// if path is empty.
+func loadProfile(path string) (*goSeccomp.Seccomp, error) {
+	if path == "" {
+		return goSeccomp.DefaultProfile(), nil
+	}
+	return seccomp.ReadProfile(path)
+}
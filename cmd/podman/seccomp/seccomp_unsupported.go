@@ -0,0 +1,5 @@
+//go:build !linux || remote
+
+package seccomp
+
+// init do not register _podman seccomp_ command on unsupported platforms
@@ -42,6 +42,7 @@ func init() {
 	flags.BoolVarP(&checkOptions.Quick, "quick", "q", false, "Skip time-consuming checks. The default is to include time-consuming checks")
 	flags.BoolVarP(&checkOptions.Repair, "repair", "r", false, "Remove inconsistent images")
 	flags.BoolVarP(&checkOptions.RepairLossy, "force", "f", false, "Remove inconsistent images and containers")
+	flags.BoolVar(&checkOptions.RepairContainers, "repair-containers", false, "Recreate missing container storage and database entries")
 	flags.DurationP("max", "m", 24*time.Hour, "Maximum allowed age of unreferenced layers")
 	_ = checkCommand.RegisterFlagCompletionFunc("max", completion.AutocompleteNone)
 }
@@ -74,6 +75,7 @@ func check(cmd *cobra.Command, _ []string) error {
 	recheckOptions := checkOptions
 	recheckOptions.Repair = false
 	recheckOptions.RepairLossy = false
+	recheckOptions.RepairContainers = false
 	if response, err = registry.ContainerEngine().SystemCheck(context.Background(), recheckOptions); err != nil {
 		return err
 	}
@@ -85,6 +87,11 @@ func check(cmd *cobra.Command, _ []string) error {
 }
 
 func printSystemCheckResults(report *types.SystemCheckReport) error {
+	for repairedContainer, repairs := range report.RepairedContainers {
+		for _, repair := range repairs {
+			fmt.Printf("Repaired container %s: %s\n", repairedContainer, repair)
+		}
+	}
 	if !report.Errors {
 		return nil
 	}
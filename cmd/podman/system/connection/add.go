@@ -60,6 +60,7 @@ var (
 		TLSCertFile string
 		TLSKeyFile  string
 		TLSCAFile   string
+		Set         []string
 	}{}
 )
 
@@ -101,6 +102,10 @@ func init() {
 
 	flags.BoolVarP(&cOpts.Default, "default", "d", false, "Set connection to be default")
 
+	setFlagName := "set"
+	flags.StringArrayVar(&cOpts.Set, setFlagName, []string{}, "Default `option=value` used by commands run against this connection")
+	_ = addCmd.RegisterFlagCompletionFunc(setFlagName, completion.AutocompleteNone)
+
 	registry.Commands = append(registry.Commands, registry.CliCommand{
 		Command: createCmd,
 		Parent:  system.ContextCmd,
@@ -118,6 +123,11 @@ func init() {
 }
 
 func add(cmd *cobra.Command, args []string) error {
+	defaults, err := registry.ParseConnectionDefaults(cOpts.Set)
+	if err != nil {
+		return err
+	}
+
 	// Default to ssh schema if none given
 
 	entities := &ssh.ConnectionCreateOptions{
@@ -168,7 +178,10 @@ func add(cmd *cobra.Command, args []string) error {
 	}
 	switch uri.Scheme {
 	case "ssh":
-		return ssh.Create(entities, sshMode)
+		if err := ssh.Create(entities, sshMode); err != nil {
+			return err
+		}
+		return setConnectionDefaults(args[0], defaults)
 	case "unix":
 		if cmd.Flags().Changed("identity") {
 			return errors.New("--identity option not supported for unix scheme")
@@ -214,7 +227,7 @@ func add(cmd *cobra.Command, args []string) error {
 	}
 
 	connection := args[0]
-	return config.EditConnectionConfig(func(cfg *config.ConnectionsFile) error {
+	err = config.EditConnectionConfig(func(cfg *config.ConnectionsFile) error {
 		if cOpts.Default {
 			cfg.Connection.Default = connection
 		}
@@ -241,6 +254,22 @@ func add(cmd *cobra.Command, args []string) error {
 		}
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+	return setConnectionDefaults(connection, defaults)
+}
+
+// setConnectionDefaults replaces the defaults stored for the connection
+func setConnectionDefaults(connection string, defaults map[string]string) error {
+	return registry.EditConnectionDefaults(func(all registry.ConnectionDefaults) error {
+		if len(defaults) == 0 {
+			delete(all, connection)
+			return nil
+		}
+		all[connection] = defaults
+		return nil
+	})
 }
 
 func create(_ *cobra.Command, args []string) error {
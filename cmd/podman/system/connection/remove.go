@@ -53,7 +53,7 @@ func init() {
 }
 
 func rm(_ *cobra.Command, args []string) error {
-	return config.EditConnectionConfig(func(cfg *config.ConnectionsFile) error {
+	err := config.EditConnectionConfig(func(cfg *config.ConnectionsFile) error {
 		if rmOpts.All {
 			cfg.Connection.Connections = nil
 			cfg.Connection.Default = ""
@@ -80,4 +80,16 @@ func rm(_ *cobra.Command, args []string) error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	return registry.EditConnectionDefaults(func(defaults registry.ConnectionDefaults) error {
+		if rmOpts.All {
+			clear(defaults)
+			return nil
+		}
+		delete(defaults, args[0])
+		return nil
+	})
 }
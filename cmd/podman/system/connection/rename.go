@@ -33,7 +33,7 @@ func init() {
 }
 
 func rename(_ *cobra.Command, args []string) error {
-	return config.EditConnectionConfig(func(cfg *config.ConnectionsFile) error {
+	err := config.EditConnectionConfig(func(cfg *config.ConnectionsFile) error {
 		if _, found := cfg.Connection.Connections[args[0]]; !found {
 			return fmt.Errorf("%q destination is not defined. See \"podman system connection add ...\" to create a connection", args[0])
 		}
@@ -47,4 +47,15 @@ func rename(_ *cobra.Command, args []string) error {
 
 		return nil
 	})
+	if err != nil {
+		return err
+	}
+
+	return registry.EditConnectionDefaults(func(defaults registry.ConnectionDefaults) error {
+		if d, found := defaults[args[0]]; found {
+			defaults[args[1]] = d
+			delete(defaults, args[0])
+		}
+		return nil
+	})
 }
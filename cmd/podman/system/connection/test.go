@@ -0,0 +1,208 @@
+package connection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/system"
+	"github.com/dmikushin/podman-shared/pkg/bindings"
+	bsystem "github.com/dmikushin/podman-shared/pkg/bindings/system"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	testCmd = &cobra.Command{
+		Use:   "test [options] [NAME...]",
+		Short: "Test destination(s) for the Podman service(s)",
+		Long: `Dial the destination(s) for the Podman service(s), measure the round-trip latency and report the service version and features.
+  If no NAME is given, all destinations are tested.`,
+		ValidArgsFunction: common.AutocompleteSystemConnections,
+		RunE:              test,
+		Example: `podman system connection test
+  podman system connection test --count 10 production
+  podman system connection test --format json`,
+	}
+
+	testOpts = struct {
+		Count   uint
+		Format  string
+		Timeout time.Duration
+	}{}
+)
+
+// connectionTestReport describes the result of testing one connection
+type connectionTestReport struct {
+	Name       string
+	URI        string
+	Connect    time.Duration
+	Latency    time.Duration
+	Version    string
+	APIVersion string
+	Features   []string
+	Error      string `json:",omitempty"`
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: testCmd,
+		Parent:  system.ConnectionCmd,
+	})
+	flags := testCmd.Flags()
+
+	countFlagName := "count"
+	flags.UintVarP(&testOpts.Count, countFlagName, "c", 3, "Number of pings used to measure latency")
+	_ = testCmd.RegisterFlagCompletionFunc(countFlagName, completion.AutocompleteNone)
+
+	formatFlagName := "format"
+	flags.StringVarP(&testOpts.Format, formatFlagName, "f", "", "Change the output format to JSON or a Go template")
+	_ = testCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&connectionTestReport{}))
+
+	timeoutFlagName := "timeout"
+	flags.DurationVar(&testOpts.Timeout, timeoutFlagName, 30*time.Second, "Maximum time to spend testing one destination")
+	_ = testCmd.RegisterFlagCompletionFunc(timeoutFlagName, completion.AutocompleteNone)
+}
+
+func test(cmd *cobra.Command, args []string) error {
+	if testOpts.Count == 0 {
+		return errors.New("--count must be greater than 0")
+	}
+
+	cons, err := registry.PodmanConfig().ContainersConfDefaultsRO.GetAllConnections()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range args {
+		if !slices.ContainsFunc(cons, func(con config.Connection) bool { return con.Name == name }) {
+			return fmt.Errorf("%q destination is not defined. See \"podman system connection add ...\" to create a connection", name)
+		}
+	}
+
+	reports := make([]connectionTestReport, 0, len(cons))
+	for _, con := range cons {
+		if len(args) > 0 && !slices.Contains(args, con.Name) {
+			continue
+		}
+		reports = append(reports, testConnection(cmd.Context(), con))
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Name < reports[j].Name
+	})
+
+	if report.IsJSON(testOpts.Format) {
+		buf, err := registry.JSONLibrary().MarshalIndent(reports, "", "    ")
+		if err == nil {
+			fmt.Println(string(buf))
+		}
+		return err
+	}
+
+	rpt := report.New(os.Stdout, cmd.Name())
+	defer rpt.Flush()
+
+	if cmd.Flags().Changed("format") {
+		rpt, err = rpt.Parse(report.OriginUser, testOpts.Format)
+	} else {
+		rpt, err = rpt.Parse(report.OriginPodman,
+			"{{range .}}{{.Name}}\t{{.URI}}\t{{.Connect}}\t{{.Latency}}\t{{.Version}}\t{{.APIVersion}}\t{{join .Features \",\"}}\t{{.Error}}\n{{end -}}")
+	}
+	if err != nil {
+		return err
+	}
+
+	if rpt.RenderHeaders {
+		if err := rpt.Execute([]map[string]string{{
+			"Name":       "Name",
+			"URI":        "URI",
+			"Connect":    "Connect",
+			"Latency":    "Latency",
+			"Version":    "Version",
+			"APIVersion": "API Version",
+			"Features":   "Features",
+			"Error":      "Error",
+		}}); err != nil {
+			return err
+		}
+	}
+	return rpt.Execute(reports)
+}
+
+// testConnection dials the destination, pings it testOpts.Count times and
+// fetches the version of the service. Failures are recorded in the report
+// so that the remaining connections are still tested.
+func testConnection(ctx context.Context, con config.Connection) connectionTestReport {
+	rpt := connectionTestReport{
+		Name: con.Name,
+		URI:  con.URI,
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, testOpts.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	ctx, err := bindings.NewConnectionWithOptions(ctx, bindings.Options{
+		URI:         con.URI,
+		Identity:    con.Identity,
+		TLSCertFile: con.TLSCert,
+		TLSKeyFile:  con.TLSKey,
+		TLSCAFile:   con.TLSCA,
+		Machine:     con.IsMachine,
+	})
+	if err != nil {
+		rpt.Error = err.Error()
+		return rpt
+	}
+	rpt.Connect = time.Since(start).Round(time.Microsecond)
+
+	latency, err := pingLatency(ctx, testOpts.Count)
+	if err != nil {
+		rpt.Error = err.Error()
+		return rpt
+	}
+	rpt.Latency = latency
+
+	version, err := bsystem.Version(ctx, nil)
+	if err != nil {
+		rpt.Error = err.Error()
+		return rpt
+	}
+	rpt.Version = version.Server.Version
+	rpt.APIVersion = version.Server.APIVersion
+	rpt.Features = version.Server.Features
+	return rpt
+}
+
+// pingLatency returns the average round-trip time of count pings
+func pingLatency(ctx context.Context, count uint) (time.Duration, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var total time.Duration
+	for range count {
+		start := time.Now()
+		response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/_ping", nil, nil)
+		if err != nil {
+			return 0, err
+		}
+		response.Body.Close()
+		if response.StatusCode != http.StatusOK {
+			return 0, fmt.Errorf("ping response was %d", response.StatusCode)
+		}
+		total += time.Since(start)
+	}
+	return (total / time.Duration(count)).Round(time.Microsecond), nil
+}
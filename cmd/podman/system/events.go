@@ -2,6 +2,7 @@ package system
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 
@@ -27,6 +28,9 @@ var (
 		Long:              eventsDescription,
 		RunE:              eventsCmd,
 		ValidArgsFunction: completion.AutocompleteNone,
+		Annotations: map[string]string{
+			registry.LazyStorage: "",
+		},
 		Example: `podman events
   podman events --filter event=create
   podman events --format {{.Image}}
@@ -40,14 +44,16 @@ var (
 		Long:              eventsCommand.Long,
 		RunE:              eventsCommand.RunE,
 		ValidArgsFunction: eventsCommand.ValidArgsFunction,
+		Annotations:       eventsCommand.Annotations,
 		Example:           `podman system events`,
 	}
 )
 
 var (
-	eventOptions entities.EventsOptions
-	eventFormat  string
-	noTrunc      bool
+	eventOptions      entities.EventsOptions
+	eventFormat       string
+	eventStreamFormat string
+	noTrunc           bool
 )
 
 type Event struct {
@@ -74,6 +80,10 @@ type Event struct {
 	HealthStatus string `json:"health_status,omitempty"`
 	// Error code for certain events involving errors.
 	Error string `json:",omitempty"`
+	// PublishedPorts of the container on create and start events
+	PublishedPorts string `json:",omitempty"`
+	// OOMReport of the container on oom events
+	OOMReport string `json:",omitempty"`
 
 	events.Details
 }
@@ -92,6 +102,8 @@ func newEventFromLibpodEvent(e *events.Event) Event {
 		Details:           e.Details,
 		TimeNano:          e.Time.UnixNano(),
 		Error:             e.Error,
+		PublishedPorts:    e.PublishedPorts,
+		OOMReport:         e.OOMReport,
 	}
 }
 
@@ -125,6 +137,10 @@ func eventsFlags(cmd *cobra.Command) {
 
 	flags.BoolVar(&eventOptions.Stream, "stream", true, "stream events and do not exit when returning the last known event")
 
+	streamFormatFlagName := "stream-format"
+	flags.StringVar(&eventStreamFormat, streamFormatFlagName, "", "print events in the format: cloudevents")
+	_ = cmd.RegisterFlagCompletionFunc(streamFormatFlagName, common.AutocompleteEventStreamFormat)
+
 	sinceFlagName := "since"
 	flags.StringVar(&eventOptions.Since, sinceFlagName, "", "show all events created since timestamp")
 	_ = cmd.RegisterFlagCompletionFunc(sinceFlagName, completion.AutocompleteNone)
@@ -144,10 +160,26 @@ func eventsCmd(cmd *cobra.Command, _ []string) error {
 	eventOptions.EventChan = eventChannel
 
 	var (
-		rpt    *report.Formatter
-		doJSON bool
+		rpt              *report.Formatter
+		doJSON           bool
+		cloudEventSource string
 	)
 
+	switch eventStreamFormat {
+	case "":
+	case events.CloudEventsFormat:
+		if cmd.Flags().Changed("format") {
+			return errors.New("--stream-format and --format are mutually exclusive")
+		}
+		source, err := cloudEventsSource()
+		if err != nil {
+			return err
+		}
+		cloudEventSource = source
+	default:
+		return fmt.Errorf("invalid stream format %q, must be %s", eventStreamFormat, events.CloudEventsFormat)
+	}
+
 	if cmd.Flags().Changed("format") {
 		doJSON = report.IsJSON(eventFormat)
 		if !doJSON {
@@ -172,6 +204,12 @@ func eventsCmd(cmd *cobra.Command, _ []string) error {
 			continue
 		}
 		switch {
+		case cloudEventSource != "":
+			b, err := json.Marshal(evt.Event.ToCloudEvent(cloudEventSource))
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(b))
 		case doJSON:
 			e := newEventFromLibpodEvent(evt.Event)
 			jsonStr, err := e.ToJSONString()
@@ -189,3 +227,20 @@ func eventsCmd(cmd *cobra.Command, _ []string) error {
 	}
 	return nil
 }
+
+// cloudEventsSource returns the source of CloudEvents for the events of the
+// host running the containers, which is the server for remote clients.
+func cloudEventsSource() (string, error) {
+	if !registry.IsRemote() {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return "", err
+		}
+		return events.CloudEventSource(hostname), nil
+	}
+	info, err := registry.ContainerEngine().Info(registry.Context())
+	if err != nil {
+		return "", err
+	}
+	return events.CloudEventSource(info.Host.Hostname), nil
+}
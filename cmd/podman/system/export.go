@@ -0,0 +1,297 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/parse"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/libnetwork/types"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/storage/pkg/archive"
+	"golang.org/x/term"
+)
+
+var (
+	systemExportDescription = `Export the containers, volumes, networks and the references of the images, and optionally the images, to a bundle.
+
+  The bundle is imported on another host with "podman system import".  The data of secrets is not exported, only their names and drivers.`
+
+	systemExportCommand = &cobra.Command{
+		Use:               "export [options]",
+		Args:              validate.NoArgs,
+		Short:             "Export the state of podman to a bundle",
+		Long:              systemExportDescription,
+		RunE:              systemExport,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system export --output node.tar
+  podman system export --include-images > node.tar`,
+	}
+)
+
+var systemExportOptions struct {
+	output        string
+	includeImages bool
+}
+
+// A bundle of podman system export is a tar archive with the files below.
+const (
+	// bundleManifestFile is the stateBundle of the bundle.
+	bundleManifestFile = "bundle.json"
+	// bundleImagesFile is a multi-image docker archive of the images, only
+	// with --include-images.
+	bundleImagesFile = "images.tar"
+	// bundleVolumesDir holds a tar archive of the content of each volume,
+	// named after the volume.
+	bundleVolumesDir = "volumes"
+	// bundleContainersDir holds a podman container backup of each
+	// container without its volumes, named after the container.
+	bundleContainersDir = "containers"
+
+	// bundleVersion is the version of the bundle format.
+	bundleVersion = 1
+)
+
+// stateBundle describes the objects of a bundle, in the order they are
+// imported.
+type stateBundle struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	// Networks are the networks besides the default network.
+	Networks []types.Network `json:"networks,omitempty"`
+	// Secrets are the secrets, without their data.
+	Secrets []bundleSecret `json:"secrets,omitempty"`
+	// Images are the names of the images, pulled on import unless the
+	// images are part of the bundle.
+	Images         []string       `json:"images,omitempty"`
+	ImagesIncluded bool           `json:"imagesIncluded,omitempty"`
+	Volumes        []bundleVolume `json:"volumes,omitempty"`
+	// Containers are the names of the containers, by creation time.
+	Containers []string `json:"containers,omitempty"`
+}
+
+// bundleSecret is the metadata of a secret in a bundle.
+type bundleSecret struct {
+	Name          string            `json:"name"`
+	Driver        string            `json:"driver"`
+	DriverOptions map[string]string `json:"driverOptions,omitempty"`
+	Labels        map[string]string `json:"labels,omitempty"`
+}
+
+// bundleVolume is the configuration of a volume in a bundle.
+type bundleVolume struct {
+	Name    string            `json:"name"`
+	Driver  string            `json:"driver"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: systemExportCommand,
+		Parent:  systemCmd,
+	})
+	flags := systemExportCommand.Flags()
+
+	outputFlagName := "output"
+	flags.StringVarP(&systemExportOptions.output, outputFlagName, "o", "", "Write to a specified file (default: stdout, which must be redirected)")
+	_ = systemExportCommand.RegisterFlagCompletionFunc(outputFlagName, completion.AutocompleteDefault)
+
+	flags.BoolVar(&systemExportOptions.includeImages, "include-images", false, "Include the images in the bundle instead of their names")
+}
+
+func systemExport(_ *cobra.Command, _ []string) error {
+	output := os.Stdout
+	if len(systemExportOptions.output) == 0 {
+		if term.IsTerminal(int(output.Fd())) {
+			return errors.New("refusing to export to terminal. Use -o flag or redirect")
+		}
+	} else {
+		if err := parse.ValidateFileName(systemExportOptions.output); err != nil {
+			return err
+		}
+		file, err := os.OpenFile(systemExportOptions.output, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		output = file
+	}
+
+	dir, err := os.MkdirTemp("", "podman-system-export-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logrus.Errorf("Removing %s: %v", dir, err)
+		}
+	}()
+	for _, sub := range []string{bundleVolumesDir, bundleContainersDir} {
+		if err := os.Mkdir(filepath.Join(dir, sub), 0o700); err != nil {
+			return err
+		}
+	}
+
+	bundle, err := exportState(dir)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(bundle, "", " ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(dir, bundleManifestFile), data, 0o600); err != nil {
+		return err
+	}
+
+	// The manifest comes first, so that the bundle can be checked before
+	// the rest is unpacked.
+	files := []string{bundleManifestFile}
+	if bundle.ImagesIncluded && len(bundle.Images) > 0 {
+		files = append(files, bundleImagesFile)
+	}
+	files = append(files, bundleVolumesDir, bundleContainersDir)
+	input, err := archive.TarWithOptions(dir, &archive.TarOptions{
+		Compression:  archive.Uncompressed,
+		IncludeFiles: files,
+	})
+	if err != nil {
+		return fmt.Errorf("creating bundle: %w", err)
+	}
+	defer input.Close()
+	if _, err := io.Copy(output, input); err != nil {
+		return fmt.Errorf("writing bundle: %w", err)
+	}
+	return nil
+}
+
+// exportState writes the images, volumes and containers to the directory of
+// the bundle and returns the manifest of the bundle.
+func exportState(dir string) (*stateBundle, error) {
+	ctx := registry.Context()
+	engine := registry.ContainerEngine()
+	bundle := &stateBundle{
+		Version:        bundleVersion,
+		Created:        time.Now(),
+		ImagesIncluded: systemExportOptions.includeImages,
+	}
+
+	networks, err := engine.NetworkList(ctx, entities.NetworkListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defaultNetwork := registry.PodmanConfig().ContainersConfDefaultsRO.Network.DefaultNetwork
+	for _, network := range networks {
+		if network.Name == defaultNetwork {
+			continue
+		}
+		bundle.Networks = append(bundle.Networks, network)
+	}
+
+	secrets, err := engine.SecretList(ctx, entities.SecretListRequest{})
+	if err != nil {
+		return nil, err
+	}
+	for _, secret := range secrets {
+		bundle.Secrets = append(bundle.Secrets, bundleSecret{
+			Name:          secret.Spec.Name,
+			Driver:        secret.Spec.Driver.Name,
+			DriverOptions: secret.Spec.Driver.Options,
+			Labels:        secret.Spec.Labels,
+		})
+	}
+
+	images, err := registry.ImageEngine().List(ctx, entities.ImageListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		for _, tag := range image.RepoTags {
+			if tag != "<none>:<none>" && !slices.Contains(bundle.Images, tag) {
+				bundle.Images = append(bundle.Images, tag)
+			}
+		}
+	}
+	slices.Sort(bundle.Images)
+	if systemExportOptions.includeImages && len(bundle.Images) > 0 {
+		fmt.Fprintf(os.Stderr, "Exporting %d images\n", len(bundle.Images))
+		if err := registry.ImageEngine().Save(ctx, bundle.Images[0], bundle.Images[1:], entities.ImageSaveOptions{
+			Format:            "docker-archive",
+			MultiImageArchive: true,
+			Output:            filepath.Join(dir, bundleImagesFile),
+			Quiet:             true,
+		}); err != nil {
+			return nil, fmt.Errorf("saving images: %w", err)
+		}
+	}
+
+	volumes, err := engine.VolumeList(ctx, entities.VolumeListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, volume := range volumes {
+		fmt.Fprintf(os.Stderr, "Exporting volume %s\n", volume.Name)
+		if err := writeBundleFile(filepath.Join(dir, bundleVolumesDir, volume.Name+".tar"), func(w io.Writer) error {
+			return engine.VolumeExport(ctx, volume.Name, entities.VolumeExportOptions{Output: w})
+		}); err != nil {
+			return nil, fmt.Errorf("exporting volume %s: %w", volume.Name, err)
+		}
+		bundle.Volumes = append(bundle.Volumes, bundleVolume{
+			Name:    volume.Name,
+			Driver:  volume.Driver,
+			Labels:  volume.Labels,
+			Options: volume.Options,
+		})
+	}
+
+	containers, err := engine.ContainerList(ctx, entities.ContainerListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+	// Containers are created again in the order they were created, so that
+	// dependencies on other containers are satisfied.
+	slices.SortFunc(containers, func(a, b entities.ListContainer) int {
+		return a.Created.Compare(b.Created)
+	})
+	for _, ctr := range containers {
+		if ctr.IsInfra {
+			continue
+		}
+		name := ctr.Names[0]
+		if ctr.Pod != "" {
+			logrus.Warnf("Container %s is exported without its pod", name)
+		}
+		fmt.Fprintf(os.Stderr, "Exporting container %s\n", name)
+		if err := writeBundleFile(filepath.Join(dir, bundleContainersDir, name+".tar"), func(w io.Writer) error {
+			return engine.ContainerBackup(ctx, ctr.ID, entities.ContainerBackupOptions{Output: w, IgnoreVolumes: true})
+		}); err != nil {
+			return nil, fmt.Errorf("exporting container %s: %w", name, err)
+		}
+		bundle.Containers = append(bundle.Containers, name)
+	}
+	return bundle, nil
+}
+
+// writeBundleFile writes the file of the bundle with the content written by
+// the function.
+func writeBundleFile(path string, write func(io.Writer) error) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,206 @@
+package system
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/errorhandling"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/libnetwork/types"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/storage/pkg/archive"
+)
+
+var (
+	systemImportDescription = `Import the containers, volumes, networks and images of a bundle written by "podman system export".
+
+  Objects which already exist are kept.  Images which are not part of the bundle are pulled.  Secrets must be created again, their data is not part of the bundle.`
+
+	systemImportCommand = &cobra.Command{
+		Use:               "import FILE",
+		Args:              cobra.ExactArgs(1),
+		Short:             "Import the state of podman from a bundle",
+		Long:              systemImportDescription,
+		RunE:              systemImport,
+		ValidArgsFunction: completion.AutocompleteDefault,
+		Example:           `podman system import node.tar`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: systemImportCommand,
+		Parent:  systemCmd,
+	})
+}
+
+func systemImport(_ *cobra.Command, args []string) error {
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	dir, err := os.MkdirTemp("", "podman-system-import-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			logrus.Errorf("Removing %s: %v", dir, err)
+		}
+	}()
+	if err := archive.Untar(file, dir, nil); err != nil {
+		return fmt.Errorf("unpacking bundle: %w", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, bundleManifestFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("invalid bundle, %s is missing", bundleManifestFile)
+		}
+		return err
+	}
+	bundle := &stateBundle{}
+	if err := json.Unmarshal(data, bundle); err != nil {
+		return fmt.Errorf("invalid bundle, decoding %s: %w", bundleManifestFile, err)
+	}
+	if bundle.Version != bundleVersion {
+		return fmt.Errorf("unsupported bundle version %d", bundle.Version)
+	}
+
+	return importState(dir, bundle)
+}
+
+// importState creates the objects of the bundle unpacked in the directory.
+// It goes on after an object failed to be imported and returns the errors
+// of all objects.
+func importState(dir string, bundle *stateBundle) error {
+	ctx := registry.Context()
+	engine := registry.ContainerEngine()
+	var errs []error
+	imported := map[string]int{}
+
+	for _, network := range bundle.Networks {
+		exists, err := engine.NetworkExists(ctx, network.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if exists.Value {
+			logrus.Warnf("Network %s already exists, it is kept", network.Name)
+			continue
+		}
+		// The ID is assigned when the network is created.
+		network.ID = ""
+		if _, err := engine.NetworkCreate(ctx, network, &types.NetworkCreateOptions{}); err != nil {
+			errs = append(errs, fmt.Errorf("creating network %s: %w", network.Name, err))
+			continue
+		}
+		imported["networks"]++
+	}
+
+	for _, secret := range bundle.Secrets {
+		exists, err := engine.SecretExists(ctx, secret.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !exists.Value {
+			logrus.Warnf("Secret %s (driver %s) must be created again, its data is not part of the bundle", secret.Name, secret.Driver)
+		}
+	}
+
+	if bundle.ImagesIncluded && len(bundle.Images) > 0 {
+		fmt.Fprintf(os.Stderr, "Importing %d images\n", len(bundle.Images))
+		if _, err := registry.ImageEngine().Load(ctx, entities.ImageLoadOptions{Input: filepath.Join(dir, bundleImagesFile), Quiet: true}); err != nil {
+			errs = append(errs, fmt.Errorf("loading images: %w", err))
+		} else {
+			imported["images"] += len(bundle.Images)
+		}
+	} else {
+		for _, image := range bundle.Images {
+			fmt.Fprintf(os.Stderr, "Pulling image %s\n", image)
+			if _, err := registry.ImageEngine().Pull(ctx, image, entities.ImagePullOptions{PullPolicy: config.PullPolicyMissing, Quiet: true}); err != nil {
+				errs = append(errs, fmt.Errorf("pulling image %s: %w", image, err))
+				continue
+			}
+			imported["images"]++
+		}
+	}
+
+	for _, volume := range bundle.Volumes {
+		exists, err := engine.VolumeExists(ctx, volume.Name)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if exists.Value {
+			logrus.Warnf("Volume %s already exists, it is kept", volume.Name)
+			continue
+		}
+		if err := importVolume(dir, volume); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		imported["volumes"]++
+	}
+
+	for _, name := range bundle.Containers {
+		exists, err := engine.ContainerExists(ctx, name, entities.ContainerExistsOptions{})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if exists.Value {
+			logrus.Warnf("Container %s already exists, it is kept", name)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "Importing container %s\n", name)
+		f, err := os.Open(filepath.Join(dir, bundleContainersDir, name+".tar"))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("invalid bundle: %w", err))
+			continue
+		}
+		_, err = engine.ContainerRestoreBackup(ctx, entities.ContainerRestoreBackupOptions{Input: f, IgnoreVolumes: true})
+		f.Close()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("importing container %s: %w", name, err))
+			continue
+		}
+		imported["containers"]++
+	}
+
+	fmt.Printf("Imported %d networks, %d images, %d volumes and %d containers\n",
+		imported["networks"], imported["images"], imported["volumes"], imported["containers"])
+	return errorhandling.JoinErrors(errs)
+}
+
+// importVolume creates the volume of the bundle and imports its content.
+func importVolume(dir string, volume bundleVolume) error {
+	ctx := registry.Context()
+	engine := registry.ContainerEngine()
+	fmt.Fprintf(os.Stderr, "Importing volume %s\n", volume.Name)
+	if _, err := engine.VolumeCreate(ctx, entities.VolumeCreateOptions{
+		Name:    volume.Name,
+		Driver:  volume.Driver,
+		Label:   volume.Labels,
+		Options: volume.Options,
+	}); err != nil {
+		return fmt.Errorf("creating volume %s: %w", volume.Name, err)
+	}
+	f, err := os.Open(filepath.Join(dir, bundleVolumesDir, volume.Name+".tar"))
+	if err != nil {
+		return fmt.Errorf("invalid bundle: %w", err)
+	}
+	defer f.Close()
+	if err := engine.VolumeImport(ctx, volume.Name, entities.VolumeImportOptions{Input: f}); err != nil {
+		return fmt.Errorf("importing volume %s: %w", volume.Name, err)
+	}
+	return nil
+}
@@ -2,53 +2,155 @@ package system
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
 )
 
 var (
+	locksDescription = `Display how many of the locks of containers, pods and volumes are in use, the locks shared by several of them and the locks presently being held.
+
+  Locks shared by several objects can lead to deadlocks, and running out of locks makes creating containers, pods and volumes fail. Both are fixed with podman system renumber.`
 	locksCommand = &cobra.Command{
-		Use:    "locks",
-		Short:  "Debug Libpod's use of locks, identifying any potential conflicts",
-		Args:   validate.NoArgs,
-		Hidden: true,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			return runLocks()
-		},
-		Example: "podman system locks",
+		Use:               "locks [options]",
+		Short:             "Debug Libpod's use of locks, identifying any potential conflicts",
+		Long:              locksDescription,
+		Args:              validate.NoArgs,
+		RunE:              runLocks,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system locks
+  podman system locks --all
+  podman system locks --format json`,
 	}
+	locksAll    bool
+	locksFormat string
 )
 
+// lockRow is a row of podman system locks --all.
+type lockRow struct {
+	Lock uint32
+	Type string
+	ID   string
+	Name string
+}
+
+// locksFreeWarningRatio is the fraction of the locks under which the number
+// of free locks is reported as a risk of exhaustion.
+const locksFreeWarningRatio = 10
+
 func init() {
 	registry.Commands = append(registry.Commands, registry.CliCommand{
 		Command: locksCommand,
 		Parent:  systemCmd,
 	})
+	flags := locksCommand.Flags()
+	flags.BoolVarP(&locksAll, "all", "a", false, "Display the lock of every container, pod and volume")
+	formatFlagName := "format"
+	flags.StringVarP(&locksFormat, formatFlagName, "f", "", "Change the output to JSON or a Go template")
+	_ = locksCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.LocksReport{}))
 }
-func runLocks() error {
-	report, err := registry.ContainerEngine().Locks(registry.Context())
+
+func runLocks(cmd *cobra.Command, _ []string) error {
+	locks, err := registry.ContainerEngine().Locks(registry.Context())
 	if err != nil {
 		return err
 	}
 
-	for lockNum, objects := range report.LockConflicts {
-		fmt.Printf("Lock %d is in use by the following\n:", lockNum)
+	switch {
+	case report.IsJSON(locksFormat):
+		b, err := json.MarshalIndent(locks, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	case cmd.Flags().Changed("format"):
+		rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginUnknown, locksFormat)
+		if err != nil {
+			return err
+		}
+		defer rpt.Flush()
+		return rpt.Execute(locks)
+	}
+
+	fmt.Printf("Lock type: %s\n", locks.LockType)
+	if locks.NumLocks == 0 {
+		fmt.Printf("Locks in use: %d\n", len(locks.Allocations))
+	} else {
+		fmt.Printf("Locks in use: %d of %d (%d free)\n", locks.NumLocks-locks.LocksFree, locks.NumLocks, locks.LocksFree)
+		if locks.LocksFree < locks.NumLocks/locksFreeWarningRatio {
+			fmt.Printf("\nOnly %d locks are free, creating containers, pods and volumes fails once none is left. Recommend increasing num_locks in containers.conf and running `podman system renumber`.\n", locks.LocksFree)
+		}
+	}
+	fmt.Println()
+
+	for lockNum, objects := range locks.LockConflicts {
+		fmt.Printf("Lock %d is in use by the following:\n", lockNum)
 		for _, obj := range objects {
 			fmt.Printf("\t%s\n", obj)
 		}
 	}
 
-	if len(report.LockConflicts) > 0 {
+	if len(locks.LockConflicts) > 0 {
 		fmt.Printf("\nLock conflicts have been detected. Recommend immediate use of `podman system renumber` to resolve.\n\n")
 	} else {
-		fmt.Printf("\nNo lock conflicts have been detected.\n\n")
+		fmt.Printf("No lock conflicts have been detected.\n\n")
+	}
+
+	owners := make(map[uint32][]string)
+	for _, allocation := range locks.Allocations {
+		owners[allocation.LockID] = append(owners[allocation.LockID], lockOwner(allocation))
+	}
+	for _, lockNum := range locks.LocksHeld {
+		if objects, ok := owners[lockNum]; ok {
+			fmt.Printf("Lock %d is presently being held by %s\n", lockNum, strings.Join(objects, ", "))
+		} else {
+			fmt.Printf("Lock %d is presently being held\n", lockNum)
+		}
+	}
+
+	if !locksAll {
+		return nil
+	}
+	if len(locks.LocksHeld) > 0 {
+		fmt.Println()
+	}
+	rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginPodman, "{{range .}}{{.Lock}}\t{{.Type}}\t{{.ID}}\t{{.Name}}\n{{end -}}")
+	if err != nil {
+		return err
+	}
+	defer rpt.Flush()
+	if err := rpt.Execute(report.Headers(lockRow{}, nil)); err != nil {
+		return fmt.Errorf("failed to write report column headers: %w", err)
+	}
+	rows := make([]lockRow, 0, len(locks.Allocations))
+	for _, allocation := range locks.Allocations {
+		rows = append(rows, lockRow{Lock: allocation.LockID, Type: allocation.Type, ID: shortLockID(allocation), Name: allocation.Name})
 	}
+	return rpt.Execute(rows)
+}
 
-	for _, lockNum := range report.LocksHeld {
-		fmt.Printf("Lock %d is presently being held\n", lockNum)
+// lockOwner describes the object the lock is allocated to.
+func lockOwner(allocation define.LockAllocation) string {
+	if allocation.Type == "volume" {
+		return "volume " + allocation.Name
 	}
+	return fmt.Sprintf("%s %s (%s)", allocation.Type, allocation.Name, shortLockID(allocation))
+}
 
-	return nil
+// shortLockID returns the ID of the object the lock is allocated to,
+// truncated for containers and pods.
+func shortLockID(allocation define.LockAllocation) string {
+	if allocation.Type == "volume" || len(allocation.ID) <= 12 {
+		return allocation.ID
+	}
+	return allocation.ID[:12]
 }
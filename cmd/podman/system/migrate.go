@@ -3,6 +3,9 @@
 package system
 
 import (
+	"fmt"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/cmd/podman/validate"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
@@ -14,7 +17,8 @@ var (
 	migrateDescription = `
         podman system migrate
 
-        Migrate existing containers to a new version of Podman.
+        Migrate existing containers to a new version of Podman, or convert
+        the database to another backend.
 `
 
 	migrateCommand = &cobra.Command{
@@ -46,8 +50,16 @@ func init() {
 	newRuntimeFlagName := "new-runtime"
 	flags.StringVar(&migrateOptions.NewRuntime, newRuntimeFlagName, "", "Specify a new runtime for all containers")
 	_ = migrateCommand.RegisterFlagCompletionFunc(newRuntimeFlagName, completion.AutocompleteNone)
+
+	dbBackendFlagName := "db-backend"
+	flags.StringVar(&migrateOptions.DBBackend, dbBackendFlagName, "", "Convert the database to the given backend (sqlite, boltdb)")
+	_ = migrateCommand.RegisterFlagCompletionFunc(dbBackendFlagName, common.AutocompleteDBBackend)
 }
 
 func migrate(_ *cobra.Command, _ []string) error {
-	return registry.ContainerEngine().Migrate(registry.Context(), migrateOptions)
+	report, err := registry.ContainerEngine().Migrate(registry.Context(), migrateOptions)
+	if report != nil && report.DBBackup != "" {
+		fmt.Printf("Backup of the %s database saved to %s\n", report.DBBackend, report.DBBackup)
+	}
+	return err
 }
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/parse"
@@ -13,6 +14,7 @@ import (
 	"github.com/dmikushin/podman-shared/cmd/podman/utils"
 	"github.com/dmikushin/podman-shared/cmd/podman/validate"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities/reports"
 	"github.com/docker/go-units"
 	"github.com/spf13/cobra"
 	"go.podman.io/common/pkg/completion"
@@ -50,6 +52,7 @@ func init() {
 	flags.BoolVar(&pruneOptions.External, "external", false, "Remove container data in storage not controlled by podman")
 	flags.BoolVar(&pruneOptions.Build, "build", false, "Remove build containers")
 	flags.BoolVar(&pruneOptions.Volume, "volumes", false, "Prune volumes")
+	flags.BoolVar(&pruneOptions.DryRun, "dry-run", false, "Show what would be removed, with sizes, without removing anything")
 	filterFlagName := "filter"
 	flags.StringArrayVar(&filters, filterFlagName, []string{}, "Provide filter values (e.g. 'label=<key>=<value>')")
 	_ = pruneCommand.RegisterFlagCompletionFunc(filterFlagName, common.AutocompletePruneFilters)
@@ -57,8 +60,8 @@ func init() {
 
 func prune(_ *cobra.Command, _ []string) error {
 	var err error
-	// Prompt for confirmation if --force is not set, unless --external
-	if !force && !pruneOptions.External {
+	// Prompt for confirmation if --force is not set, unless --external or --dry-run
+	if !force && !pruneOptions.External && !pruneOptions.DryRun {
 		reader := bufio.NewReader(os.Stdin)
 		volumeString := ""
 		if pruneOptions.Volume {
@@ -91,6 +94,9 @@ func prune(_ *cobra.Command, _ []string) error {
 	if err != nil {
 		return err
 	}
+	if pruneOptions.DryRun {
+		return printDryRunReport(response)
+	}
 	// Print container prune results
 	err = utils.PrintContainerPruneResults(response.ContainerPruneReports, true)
 	if err != nil {
@@ -125,6 +131,42 @@ func prune(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// printDryRunReport prints what would be removed by system prune, with sizes
+func printDryRunReport(response *entities.SystemPruneReport) error {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	printSection := func(heading string, pruneReports []*reports.PruneReport) {
+		if len(pruneReports) == 0 {
+			return
+		}
+		fmt.Fprintln(w, heading)
+		for _, r := range pruneReports {
+			if r.Err != nil {
+				fmt.Fprintf(w, "%s\t%s\n", r.Id, r.Err)
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\n", r.Id, units.HumanSize(float64(r.Size)))
+		}
+	}
+
+	if len(response.PodPruneReport) > 0 {
+		fmt.Fprintln(w, "Pods that would be deleted")
+		for _, r := range response.PodPruneReport {
+			fmt.Fprintln(w, r.Id)
+		}
+	}
+	printSection("Containers that would be deleted", response.ContainerPruneReports)
+	printSection("Volumes that would be deleted", response.VolumePruneReports)
+	printSection("Images that would be deleted", response.ImagePruneReports)
+	if len(response.NetworkPruneReports) > 0 {
+		fmt.Fprintln(w, "Networks that would be deleted")
+		for _, r := range response.NetworkPruneReports {
+			fmt.Fprintln(w, r.Name)
+		}
+	}
+	fmt.Fprintf(w, "Total reclaimable space: %s\n", units.HumanSize(float64(response.ReclaimedSpace)))
+	return w.Flush()
+}
+
 func createPruneWarningMessage(pruneOpts entities.SystemPruneOptions) string {
 	if pruneOpts.All {
 		return `WARNING! This command removes:
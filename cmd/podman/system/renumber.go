@@ -3,10 +3,18 @@
 package system
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/spf13/cobra"
 	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
 )
 
 var (
@@ -19,22 +27,98 @@ var (
 
 	renumberCommand = &cobra.Command{
 		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
-		Use:               "renumber",
+		Use:               "renumber [options]",
 		Args:              validate.NoArgs,
 		Short:             "Migrate lock numbers",
 		Long:              renumberDescription,
 		RunE:              renumber,
 		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system renumber
+  podman system renumber --dry-run`,
 	}
+	renumberOpts   entities.SystemRenumberOptions
+	renumberFormat string
 )
 
+// renumberRow is a row of podman system renumber --dry-run.
+type renumberRow struct {
+	Type    string
+	ID      string
+	Name    string
+	Lock    uint32
+	NewLock string
+}
+
 func init() {
 	registry.Commands = append(registry.Commands, registry.CliCommand{
 		Command: renumberCommand,
 		Parent:  systemCmd,
 	})
+	flags := renumberCommand.Flags()
+	flags.BoolVar(&renumberOpts.DryRun, "dry-run", false, "Display the locks the renumbering would allocate without changing them")
+	formatFlagName := "format"
+	flags.StringVarP(&renumberFormat, formatFlagName, "f", "", "Change the output of --dry-run to JSON or a Go template")
+	_ = renumberCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.SystemRenumberReport{}))
 }
 
-func renumber(_ *cobra.Command, _ []string) error {
-	return registry.ContainerEngine().Renumber(registry.Context())
+func renumber(cmd *cobra.Command, _ []string) error {
+	if cmd.Flags().Changed("format") && !renumberOpts.DryRun {
+		return errors.New("--format can only be used with --dry-run")
+	}
+	preview, err := registry.ContainerEngine().Renumber(registry.Context(), renumberOpts)
+	if err != nil || !renumberOpts.DryRun {
+		return err
+	}
+
+	switch {
+	case report.IsJSON(renumberFormat):
+		b, err := json.MarshalIndent(preview, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	case cmd.Flags().Changed("format"):
+		rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginUnknown, renumberFormat)
+		if err != nil {
+			return err
+		}
+		defer rpt.Flush()
+		return rpt.Execute(preview)
+	}
+
+	rows := make([]renumberRow, 0, len(preview.Locks))
+	changed, missing := 0, 0
+	for _, lock := range preview.Locks {
+		row := renumberRow{Type: lock.Type, ID: shortLockID(lock.LockAllocation), Name: lock.Name, Lock: lock.LockID, NewLock: "none"}
+		if lock.NewLockID == nil {
+			missing++
+		} else {
+			row.NewLock = strconv.FormatUint(uint64(*lock.NewLockID), 10)
+			if *lock.NewLockID != lock.LockID {
+				changed++
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginPodman, "{{range .}}{{.Type}}\t{{.ID}}\t{{.Name}}\t{{.Lock}}\t{{.NewLock}}\n{{end -}}")
+	if err != nil {
+		return err
+	}
+	if err := rpt.Execute(report.Headers(renumberRow{}, map[string]string{"NewLock": "NEW LOCK"})); err != nil {
+		return fmt.Errorf("failed to write report column headers: %w", err)
+	}
+	if err := rpt.Execute(rows); err != nil {
+		return err
+	}
+	if err := rpt.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d of %d locks would change\n", changed+missing, len(rows))
+	if missing > 0 {
+		return fmt.Errorf("%d containers, pods and volumes need more locks than the %d of num_locks in containers.conf, the renumbering would fail", len(rows), preview.NumLocks)
+	}
+	return nil
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/rootless"
 	"github.com/dmikushin/podman-shared/pkg/systemd"
 	"github.com/dmikushin/podman-shared/pkg/util"
+	"github.com/docker/go-units"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -40,16 +41,24 @@ Enable a listening service for API access to Podman commands.
   podman system service --time=0 tcp://localhost:8888
   podman system service --time=0 --tls-cert=tls.crt --tls-key=tls.key tcp://localhost:8888
   podman system service --time=0 --tls-cert=tls.crt --tls-key=tls.key --tls-client-ca=ca.crt tcp://localhost:8888
+  podman system service --time=0 --registry-cache=:5000 --registry-cache-max-size=50GB unix:///tmp/podman.sock
+  podman system service --time=0 --registry-cache=0.0.0.0:5000 --registry-cache-allow-upstream=quay.io --tls-cert=tls.crt --tls-key=tls.key --tls-client-ca=ca.crt unix:///tmp/podman.sock
     `,
 	}
 
 	srvArgs = struct {
-		CorsHeaders     string
-		PProfAddr       string
-		Timeout         uint
-		TLSCertFile     string
-		TLSKeyFile      string
-		TLSClientCAFile string
+		CorsHeaders                   []string
+		DBProfile                     bool
+		PProfAddr                     string
+		RegistryCacheAddr             string
+		RegistryCacheUpstream         string
+		RegistryCacheAllowedUpstreams []string
+		RegistryCacheMaxSize          string
+		RegistryCacheTagTTL           time.Duration
+		Timeout                       uint
+		TLSCertFile                   string
+		TLSKeyFile                    string
+		TLSClientCAFile               string
 	}{}
 )
 
@@ -68,9 +77,31 @@ func init() {
 	_ = srvCmd.RegisterFlagCompletionFunc(timeFlagName, completion.AutocompleteNone)
 	flags.SetNormalizeFunc(aliasTimeoutFlag)
 
-	flags.StringVarP(&srvArgs.CorsHeaders, "cors", "", "", "Set CORS Headers")
+	flags.StringArrayVar(&srvArgs.CorsHeaders, "cors", nil, "Allow cross-origin requests from origins, per route group with GROUP=ORIGINS")
 	_ = srvCmd.RegisterFlagCompletionFunc("cors", completion.AutocompleteNone)
 
+	flags.BoolVar(&srvArgs.DBProfile, "db-profile", false, "Record the time spent running database queries, served at /libpod/system/dbprofile")
+
+	registryCacheFlagName := "registry-cache"
+	flags.StringVar(&srvArgs.RegistryCacheAddr, registryCacheFlagName, "", "Serve a pull-through registry cache on the network `ADDRESS`, like :5000 for localhost:5000")
+	_ = srvCmd.RegisterFlagCompletionFunc(registryCacheFlagName, completion.AutocompleteNone)
+
+	registryCacheUpstreamFlagName := "registry-cache-upstream"
+	flags.StringVar(&srvArgs.RegistryCacheUpstream, registryCacheUpstreamFlagName, "docker.io", "Registry of the repositories of the registry cache whose name does not start with a registry")
+	_ = srvCmd.RegisterFlagCompletionFunc(registryCacheUpstreamFlagName, completion.AutocompleteNone)
+
+	registryCacheAllowUpstreamFlagName := "registry-cache-allow-upstream"
+	flags.StringArrayVar(&srvArgs.RegistryCacheAllowedUpstreams, registryCacheAllowUpstreamFlagName, nil, "Allow the registry cache to fetch the repositories whose name starts with `REGISTRY`")
+	_ = srvCmd.RegisterFlagCompletionFunc(registryCacheAllowUpstreamFlagName, completion.AutocompleteNone)
+
+	registryCacheMaxSizeFlagName := "registry-cache-max-size"
+	flags.StringVar(&srvArgs.RegistryCacheMaxSize, registryCacheMaxSizeFlagName, "0", "Remove the least recently used content of the registry cache to keep it under `SIZE`, 0 for no limit")
+	_ = srvCmd.RegisterFlagCompletionFunc(registryCacheMaxSizeFlagName, completion.AutocompleteNone)
+
+	registryCacheTagTTLFlagName := "registry-cache-tag-ttl"
+	flags.DurationVar(&srvArgs.RegistryCacheTagTTL, registryCacheTagTTLFlagName, 5*time.Minute, "Time a tag is served from the registry cache before checking it in the upstream registry again")
+	_ = srvCmd.RegisterFlagCompletionFunc(registryCacheTagTTLFlagName, completion.AutocompleteNone)
+
 	flags.StringVarP(&srvArgs.PProfAddr, "pprof-address", "", "",
 		"Binding network address for pprof profile endpoints, default: do not expose endpoints")
 	_ = flags.MarkHidden("pprof-address")
@@ -123,14 +154,25 @@ func service(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("--tls-key provided without --tls-cert")
 	}
 
+	registryCacheMaxSize, err := units.FromHumanSize(srvArgs.RegistryCacheMaxSize)
+	if err != nil {
+		return fmt.Errorf("invalid registry cache maximum size %q: %w", srvArgs.RegistryCacheMaxSize, err)
+	}
+
 	return restService(cmd.Flags(), registry.PodmanConfig(), entities.ServiceOptions{
-		CorsHeaders:     srvArgs.CorsHeaders,
-		PProfAddr:       srvArgs.PProfAddr,
-		Timeout:         time.Duration(srvArgs.Timeout) * time.Second,
-		URI:             apiURI,
-		TLSCertFile:     srvArgs.TLSCertFile,
-		TLSKeyFile:      srvArgs.TLSKeyFile,
-		TLSClientCAFile: srvArgs.TLSClientCAFile,
+		CorsHeaders:                   srvArgs.CorsHeaders,
+		DBProfile:                     srvArgs.DBProfile,
+		PProfAddr:                     srvArgs.PProfAddr,
+		RegistryCacheAddr:             srvArgs.RegistryCacheAddr,
+		RegistryCacheUpstream:         srvArgs.RegistryCacheUpstream,
+		RegistryCacheAllowedUpstreams: srvArgs.RegistryCacheAllowedUpstreams,
+		RegistryCacheMaxSize:          registryCacheMaxSize,
+		RegistryCacheTagTTL:           srvArgs.RegistryCacheTagTTL,
+		Timeout:                       time.Duration(srvArgs.Timeout) * time.Second,
+		URI:                           apiURI,
+		TLSCertFile:                   srvArgs.TLSCertFile,
+		TLSKeyFile:                    srvArgs.TLSKeyFile,
+		TLSClientCAFile:               srvArgs.TLSClientCAFile,
 	})
 }
 
@@ -3,17 +3,23 @@
 package system
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/libpod"
 	api "github.com/dmikushin/podman-shared/pkg/api/server"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/dmikushin/podman-shared/pkg/domain/infra"
+	"github.com/dmikushin/podman-shared/pkg/registrycache"
+	"github.com/dmikushin/podman-shared/pkg/util/tlsutil"
 	"github.com/coreos/go-systemd/v22/activation"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/pflag"
@@ -31,6 +37,12 @@ func restService(flags *pflag.FlagSet, cfg *entities.PodmanConfig, opts entities
 		return err
 	}
 
+	if opts.DBProfile {
+		if err := libpodRuntime.EnableDBProfile(); err != nil {
+			return err
+		}
+	}
+
 	if opts.URI == "" {
 		if _, found := os.LookupEnv("LISTEN_PID"); !found {
 			return errors.New("no service URI provided and socket activation protocol is not active")
@@ -123,6 +135,14 @@ func restService(flags *pflag.FlagSet, cfg *entities.PodmanConfig, opts entities
 	// Close the fd right away to not leak it during the entire time of the service.
 	devNullfile.Close()
 
+	if opts.RegistryCacheAddr != "" {
+		stop, err := serveRegistryCache(libpodRuntime, opts)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+
 	maybeMoveToSubCgroup()
 
 	maybeStartServiceReaper()
@@ -143,3 +163,87 @@ func restService(flags *pflag.FlagSet, cfg *entities.PodmanConfig, opts entities
 	}
 	return err
 }
+
+// serveRegistryCache serves the registry cache, stored next to the images,
+// on opts.RegistryCacheAddr.  It returns a function stopping it.
+//
+// The cache keeps the compressed blobs of the registries in its own
+// directory: containers-storage only has the uncompressed layers, which do
+// not match the digests of the manifests.
+func serveRegistryCache(libpodRuntime *libpod.Runtime, opts entities.ServiceOptions) (func(), error) {
+	addr, loopback, err := registryCacheAddr(opts.RegistryCacheAddr)
+	if err != nil {
+		return nil, err
+	}
+	var tlsConfig *tls.Config
+	if !loopback {
+		if opts.TLSCertFile == "" || opts.TLSClientCAFile == "" {
+			return nil, fmt.Errorf("serving the registry cache on %s, outside of localhost, requires --tls-cert, --tls-key and --tls-client-ca", addr)
+		}
+		cert, err := tls.LoadX509KeyPair(opts.TLSCertFile, opts.TLSKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		pool, err := tlsutil.ReadCertBundle(opts.TLSClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig = &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			ClientCAs:    pool,
+			ClientAuth:   tls.RequireAndVerifyClientCert,
+		}
+	}
+
+	cache, err := registrycache.New(registrycache.Options{
+		Dir:              filepath.Join(libpodRuntime.StorageConfig().GraphRoot, "registry-cache"),
+		Upstream:         opts.RegistryCacheUpstream,
+		AllowedUpstreams: opts.RegistryCacheAllowedUpstreams,
+		MaxSize:          opts.RegistryCacheMaxSize,
+		TagTTL:           opts.RegistryCacheTagTTL,
+		SystemContext:    libpodRuntime.SystemContext(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("setting up the registry cache: %w", err)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		_ = cache.Close()
+		return nil, fmt.Errorf("unable to create socket %v: %w", addr, err)
+	}
+	if tlsConfig != nil {
+		listener = tls.NewListener(listener, tlsConfig)
+	} else {
+		logrus.Warnf("Serving the registry cache without TLS on %s, clients must list it as an insecure registry", listener.Addr())
+	}
+
+	srv := &http.Server{Handler: cache, ReadHeaderTimeout: 30 * time.Second}
+	go func() {
+		if err := srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logrus.Errorf("Serving the registry cache: %v", err)
+		}
+	}()
+	libpodRuntime.SetRegistryCache(cache)
+	return func() {
+		if err := srv.Close(); err != nil {
+			logrus.Warnf("Error when stopping the registry cache: %v", err)
+		}
+		if err := cache.Close(); err != nil {
+			logrus.Warnf("Error when saving the registry cache: %v", err)
+		}
+	}, nil
+}
+
+// registryCacheAddr returns the address to serve the registry cache on,
+// localhost when addr has no host, and whether it is a loopback address.
+func registryCacheAddr(addr string) (string, bool, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid registry cache address %q: %w", addr, err)
+	}
+	if host == "" {
+		host = "localhost"
+	}
+	ip := net.ParseIP(host)
+	return net.JoinHostPort(host, port), host == "localhost" || (ip != nil && ip.IsLoopback()), nil
+}
@@ -0,0 +1,70 @@
+package system
+
+import (
+	"fmt"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+)
+
+var (
+	sharedStorageCmd = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "shared-storage",
+		Short:       "Manage the use of shared storage",
+		Long:        "Manage how the base layers of containers created with --shared-base-layers are used from shared storage",
+		RunE:        validate.SubCommandExists,
+	}
+
+	sharedStorageCacheCmd = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "cache",
+		Short:       "Manage the local cache of the shared base layers",
+		Long:        "Manage the local copies of the shared base layers made with --shared-layer-cache",
+		RunE:        validate.SubCommandExists,
+	}
+
+	sharedStorageCachePurgeDescription = `Remove the copies of the shared base layers from the local cache set with --shared-layer-cache.
+
+  The copies mounted by containers are kept.`
+	sharedStorageCachePurgeCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "purge",
+		Args:              validate.NoArgs,
+		Short:             "Remove the shared base layers from the local cache",
+		Long:              sharedStorageCachePurgeDescription,
+		RunE:              sharedStorageCachePurge,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example:           `podman --shared-layer-cache /var/cache/podman-layers system shared-storage cache purge`,
+	}
+)
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: sharedStorageCmd,
+		Parent:  systemCmd,
+	})
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: sharedStorageCacheCmd,
+		Parent:  sharedStorageCmd,
+	})
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: sharedStorageCachePurgeCmd,
+		Parent:  sharedStorageCacheCmd,
+	})
+}
+
+func sharedStorageCachePurge(_ *cobra.Command, _ []string) error {
+	report, err := registry.ContainerEngine().SharedLayerCachePurge(registry.Context())
+	if err != nil {
+		return err
+	}
+	for _, layer := range report.Layers {
+		fmt.Println(layer)
+	}
+	fmt.Printf("Total reclaimed space: %s\n", units.HumanSize(float64(report.Size)))
+	return nil
+}
@@ -0,0 +1,160 @@
+package system
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
+	"github.com/dmikushin/podman-shared/cmd/podman/registry"
+	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/spf13/cobra"
+	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
+)
+
+var (
+	subIDCmd = &cobra.Command{
+		Annotations: map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:         "subid",
+		Short:       "Manage the subordinate ID pools of --userns=auto",
+		Long:        "Inspect and grow the /etc/subuid and /etc/subgid pools that --userns=auto allocates user namespaces from",
+		RunE:        validate.SubCommandExists,
+	}
+
+	subIDStatusDescription = `Display the subordinate UIDs and GIDs of the user --userns=auto allocates user namespaces from, and how many of them containers use.`
+	subIDStatusCmd         = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "status [options]",
+		Args:              validate.NoArgs,
+		Short:             "Display the subordinate ID pools of --userns=auto",
+		Long:              subIDStatusDescription,
+		RunE:              subIDStatus,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system subid status
+  podman system subid status --format json
+  podman system subid status --format "{{.UIDs.Free}}"`,
+	}
+	subIDStatusFormat string
+
+	subIDExpandDescription = `Add a range of subordinate UIDs and GIDs to a user, after the ranges of all the users.
+
+  The range is added to the user --userns=auto allocates user namespaces from, unless --user is given.`
+	subIDExpandCmd = &cobra.Command{
+		Annotations:       map[string]string{registry.EngineMode: registry.ABIMode},
+		Use:               "expand [options]",
+		Args:              validate.NoArgs,
+		Short:             "Add subordinate IDs to the pools of a user",
+		Long:              subIDExpandDescription,
+		RunE:              subIDExpand,
+		ValidArgsFunction: completion.AutocompleteNone,
+		Example: `podman system subid expand
+  podman system subid expand --size 1048576
+  podman system subid expand --user alice`,
+	}
+	subIDExpandOpts entities.SystemSubIDExpandOptions
+)
+
+// subIDPoolRow is a row of podman system subid status.
+type subIDPoolRow struct {
+	Pool   string
+	Size   int
+	Used   int
+	Free   int
+	Ranges string
+}
+
+func init() {
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: subIDCmd,
+		Parent:  systemCmd,
+	})
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: subIDStatusCmd,
+		Parent:  subIDCmd,
+	})
+	statusFlags := subIDStatusCmd.Flags()
+	formatFlagName := "format"
+	statusFlags.StringVarP(&subIDStatusFormat, formatFlagName, "f", "", "Change the output to JSON or a Go template")
+	_ = subIDStatusCmd.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(&entities.SystemSubIDStatusReport{}))
+
+	registry.Commands = append(registry.Commands, registry.CliCommand{
+		Command: subIDExpandCmd,
+		Parent:  subIDCmd,
+	})
+	expandFlags := subIDExpandCmd.Flags()
+	sizeFlagName := "size"
+	expandFlags.IntVar(&subIDExpandOpts.Size, sizeFlagName, 65536, "Number of UIDs and GIDs to add")
+	_ = subIDExpandCmd.RegisterFlagCompletionFunc(sizeFlagName, completion.AutocompleteNone)
+	userFlagName := "user"
+	expandFlags.StringVar(&subIDExpandOpts.User, userFlagName, "", "Add the IDs to `user` instead of the user of --userns=auto")
+	_ = subIDExpandCmd.RegisterFlagCompletionFunc(userFlagName, completion.AutocompleteNone)
+}
+
+func subIDStatus(cmd *cobra.Command, _ []string) error {
+	status, err := registry.ContainerEngine().SubIDStatus(registry.Context())
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case report.IsJSON(subIDStatusFormat):
+		b, err := json.MarshalIndent(status, "", "    ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(b))
+		return nil
+	case cmd.Flags().Changed("format"):
+		rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginUnknown, subIDStatusFormat)
+		if err != nil {
+			return err
+		}
+		defer rpt.Flush()
+		return rpt.Execute(status)
+	}
+
+	fmt.Printf("User: %s\n", status.User)
+	fmt.Printf("Containers: %d\n", status.Containers)
+	fmt.Printf("Computed user namespace size: %d to %d\n\n", status.AutoUserNsMinSize, status.AutoUserNsMaxSize)
+
+	rpt, err := report.New(os.Stdout, cmd.Name()).Parse(report.OriginPodman, "{{range .}}{{.Pool}}\t{{.Size}}\t{{.Used}}\t{{.Free}}\t{{.Ranges}}\n{{end -}}")
+	if err != nil {
+		return err
+	}
+	defer rpt.Flush()
+	if err := rpt.Execute(report.Headers(subIDPoolRow{}, nil)); err != nil {
+		return fmt.Errorf("failed to write report column headers: %w", err)
+	}
+	return rpt.Execute([]subIDPoolRow{poolRow("uid", status.UIDs), poolRow("gid", status.GIDs)})
+}
+
+func poolRow(name string, pool define.SubIDPool) subIDPoolRow {
+	return subIDPoolRow{Pool: name, Size: pool.Size, Used: pool.Used, Free: pool.Free, Ranges: formatRanges(pool)}
+}
+
+func formatRanges(pool define.SubIDPool) string {
+	if len(pool.Ranges) == 0 {
+		return "none"
+	}
+	ranges := make([]string, 0, len(pool.Ranges))
+	for _, r := range pool.Ranges {
+		ranges = append(ranges, fmt.Sprintf("%d-%d", r.Start, r.End()-1))
+	}
+	return strings.Join(ranges, ",")
+}
+
+func subIDExpand(cmd *cobra.Command, _ []string) error {
+	expanded, err := registry.ContainerEngine().SubIDExpand(registry.Context(), subIDExpandOpts)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Added UIDs and GIDs %d-%d to user %s\n", expanded.UIDs.Start, expanded.UIDs.End()-1, expanded.User)
+	if cmd.Flags().Changed("user") {
+		fmt.Printf("Run podman system migrate as %s for rootless containers to use them\n", expanded.User)
+	}
+	return nil
+}
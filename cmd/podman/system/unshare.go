@@ -4,6 +4,7 @@ import (
 	"errors"
 	"os"
 
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/cmd/podman/utils"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
@@ -25,7 +26,8 @@ var (
 		ValidArgsFunction: completion.AutocompleteDefault,
 		Example: `podman unshare id
   podman unshare cat /proc/self/uid_map
-  podman unshare podman-script.sh`,
+  podman unshare podman-script.sh
+  podman unshare --container ctr cat /proc/self/uid_map`,
 	}
 )
 
@@ -36,6 +38,10 @@ func init() {
 	flags := unshareCommand.Flags()
 	flags.SetInterspersed(false)
 	flags.BoolVar(&unshareOptions.RootlessNetNS, "rootless-netns", false, "Join the rootless network namespace used for CNI and netavark networking")
+	containerFlagName := "container"
+	flags.StringVar(&unshareOptions.Container, containerFlagName, "", "Join the user, mount and network namespaces of a running container")
+	_ = unshareCommand.RegisterFlagCompletionFunc(containerFlagName, common.AutocompleteContainersRunning)
+	flags.BoolVar(&unshareOptions.KeepNet, "keep-net", false, "Keep the network namespace when joining the namespaces of a container")
 	// backwards compat still allow --rootless-cni
 	flags.SetNormalizeFunc(func(_ *pflag.FlagSet, name string) pflag.NormalizedName {
 		if name == "rootless-cni" {
@@ -46,8 +52,17 @@ func init() {
 }
 
 func unshare(_ *cobra.Command, args []string) error {
-	if isRootless := rootless.IsRootless(); !isRootless {
-		return errors.New("please use unshare with rootless")
+	if unshareOptions.Container != "" {
+		if unshareOptions.RootlessNetNS {
+			return errors.New("--container and --rootless-netns cannot be used together")
+		}
+	} else {
+		if unshareOptions.KeepNet {
+			return errors.New("--keep-net can only be used with --container")
+		}
+		if isRootless := rootless.IsRootless(); !isRootless {
+			return errors.New("please use unshare with rootless")
+		}
 	}
 	// exec the specified command, if there is one
 	if len(args) < 1 {
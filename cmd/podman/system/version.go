@@ -24,6 +24,7 @@ var (
 		ValidArgsFunction: completion.AutocompleteNone,
 		Annotations: map[string]string{
 			registry.ParentNSRequired: "",
+			registry.LazyStorage:      "",
 		},
 	}
 	versionFormat string
@@ -1,44 +1,104 @@
 package volumes
 
 import (
+	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
+	"github.com/dmikushin/podman-shared/cmd/podman/common"
 	"github.com/dmikushin/podman-shared/cmd/podman/registry"
 	"github.com/dmikushin/podman-shared/cmd/podman/utils"
-	"github.com/dmikushin/podman-shared/cmd/podman/validate"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/spf13/cobra"
-	"go.podman.io/common/pkg/completion"
+	"go.podman.io/common/pkg/report"
 )
 
 var (
-	reloadDescription = `Check all configured volume plugins and update the libpod database with all available volumes.
+	reloadDescription = `Check the configured volume plugins and update the libpod database with all available volumes.
 
-  Existing volumes are also removed from the database when they are no longer present in the plugin.`
+  Existing volumes are also removed from the database when they are no longer present in the plugin.
+  Volume names used by several plugins, or by a volume of another driver, are reported as conflicts.`
 	reloadCommand = &cobra.Command{
-		Use:               "reload",
-		Args:              validate.NoArgs,
-		Short:             "Reload all volumes from volume plugins",
-		Long:              reloadDescription,
-		RunE:              reload,
-		ValidArgsFunction: completion.AutocompleteNone,
+		Use:   "reload [options] [PLUGIN...]",
+		Short: "Reload all volumes from volume plugins",
+		Long:  reloadDescription,
+		RunE:  reload,
+		Args: func(_ *cobra.Command, args []string) error {
+			if reloadOpts.All && len(args) > 0 {
+				return errors.New("--all and plugin names cannot be used together")
+			}
+			return nil
+		},
+		ValidArgsFunction: autocompleteVolumePlugins,
+		Example: `podman volume reload
+  podman volume reload --all --format json
+  podman volume reload csi-driver`,
 	}
 )
 
+var (
+	reloadOpts   entities.VolumeReloadOptions
+	reloadFormat string
+)
+
+// volumeReloadJSON is the report of podman volume reload --format json.
+type volumeReloadJSON struct {
+	Added     []string                      `json:"Added"`
+	Removed   []string                      `json:"Removed"`
+	Conflicts []define.VolumeReloadConflict `json:"Conflicts"`
+	Errors    []string                      `json:"Errors"`
+}
+
 func init() {
 	registry.Commands = append(registry.Commands, registry.CliCommand{
 		Command: reloadCommand,
 		Parent:  volumeCmd,
 	})
+	flags := reloadCommand.Flags()
+	flags.BoolVarP(&reloadOpts.All, "all", "a", false, "Reload the volumes of all configured volume plugins")
+	formatFlagName := "format"
+	flags.StringVar(&reloadFormat, formatFlagName, "", "Format the report as JSON")
+	_ = reloadCommand.RegisterFlagCompletionFunc(formatFlagName, common.AutocompleteFormat(nil))
 }
 
-func reload(_ *cobra.Command, _ []string) error {
-	report, err := registry.ContainerEngine().VolumeReload(registry.Context())
+func reload(_ *cobra.Command, args []string) error {
+	if reloadFormat != "" && !report.IsJSON(reloadFormat) {
+		return fmt.Errorf("unsupported format %q, only json is supported", reloadFormat)
+	}
+	reloadOpts.Plugins = args
+	rep, err := registry.ContainerEngine().VolumeReload(registry.Context(), reloadOpts)
 	if err != nil {
 		return err
 	}
-	printReload("Added", report.Added)
-	printReload("Removed", report.Removed)
-	errs := (utils.OutputErrors)(report.Errors)
+	if report.IsJSON(reloadFormat) {
+		out := volumeReloadJSON{
+			Added:     nonNil(rep.Added),
+			Removed:   nonNil(rep.Removed),
+			Conflicts: nonNil(rep.Conflicts),
+			Errors:    []string{},
+		}
+		for _, err := range rep.Errors {
+			out.Errors = append(out.Errors, err.Error())
+		}
+		if err := utils.PrintGenericJSON(out); err != nil {
+			return err
+		}
+		if len(rep.Errors) > 0 {
+			registry.SetExitCode(define.ExecErrorCodeGeneric)
+		}
+		return nil
+	}
+	printReload("Added", rep.Added)
+	printReload("Removed", rep.Removed)
+	if len(rep.Conflicts) > 0 {
+		fmt.Println("Conflicts:")
+		for _, conflict := range rep.Conflicts {
+			fmt.Printf("%s: %s\n", conflict.Name, strings.Join(conflict.Drivers, ", "))
+		}
+	}
+	errs := (utils.OutputErrors)(rep.Errors)
 	return errs.PrintErrors()
 }
 
@@ -50,3 +110,23 @@ func printReload(typ string, values []string) {
 		}
 	}
 }
+
+// nonNil returns s, or an empty slice if s is nil, so that it is written
+// as [] in JSON.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+func autocompleteVolumePlugins(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+	var plugins []string
+	for name := range registry.PodmanConfig().ContainersConfDefaultsRO.Engine.VolumePlugins {
+		if !slices.Contains(args, name) {
+			plugins = append(plugins, name)
+		}
+	}
+	slices.Sort(plugins)
+	return plugins, cobra.ShellCompDirectiveNoFileComp
+}
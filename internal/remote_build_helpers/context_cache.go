@@ -0,0 +1,168 @@
+package remote_build_helpers
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/opencontainers/go-digest"
+	"go.podman.io/storage/pkg/archive"
+)
+
+const (
+	// ContextDigestRecord is the PAX record of a build context tar entry
+	// whose content is not sent because the server has it cached.  The
+	// value of the record is the digest of the content.
+	ContextDigestRecord = "PODMAN.context.digest"
+	// ContextCacheRecord is the PAX record of a build context tar entry
+	// whose content should be added to the cache of the server.  The value
+	// of the record is the digest of the content.
+	ContextCacheRecord = "PODMAN.context.cache"
+)
+
+// ContextCacheDir returns the directory of the build context cache in the
+// given graph root.
+func ContextCacheDir(graphRoot string) string {
+	return filepath.Join(graphRoot, "build-context-cache")
+}
+
+// ContextCache is a content-addressed cache of the build context files sent
+// by remote clients, so that subsequent builds of the same context only need
+// to send the files which changed.
+type ContextCache struct {
+	dir string
+}
+
+// NewContextCache returns a build context cache stored in dir.
+func NewContextCache(dir string) *ContextCache {
+	return &ContextCache{dir: dir}
+}
+
+func (c *ContextCache) path(d digest.Digest) string {
+	return filepath.Join(c.dir, d.Algorithm().String(), d.Encoded())
+}
+
+// Missing returns the digests which are not in the cache.
+func (c *ContextCache) Missing(digests []string) ([]string, error) {
+	missing := []string{}
+	for _, value := range digests {
+		d, err := digest.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid digest %q: %w", value, err)
+		}
+		if _, err := os.Stat(c.path(d)); err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				return nil, err
+			}
+			missing = append(missing, value)
+		}
+	}
+	return missing, nil
+}
+
+// Expand returns an uncompressed tar stream of the given, possibly
+// compressed, build context.  The content of entries marked with
+// ContextDigestRecord is read from the cache, the content of entries marked
+// with ContextCacheRecord is added to the cache.  Other entries, e.g. build
+// secrets, are never cached.
+func (c *ContextCache) Expand(r io.Reader) io.ReadCloser {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(c.expand(r, pw))
+	}()
+	return pr
+}
+
+func (c *ContextCache) expand(r io.Reader, w io.Writer) error {
+	decompressed, err := archive.DecompressStream(r)
+	if err != nil {
+		return err
+	}
+	defer decompressed.Close()
+
+	tr := tar.NewReader(decompressed)
+	tw := tar.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return tw.Close()
+		}
+		if err != nil {
+			return err
+		}
+
+		value, cached := hdr.PAXRecords[ContextDigestRecord]
+		_, cache := hdr.PAXRecords[ContextCacheRecord]
+		switch {
+		case cached:
+			delete(hdr.PAXRecords, ContextDigestRecord)
+			if err := c.copyFromCache(tw, hdr, value); err != nil {
+				return fmt.Errorf("restoring %q from the build context cache: %w", hdr.Name, err)
+			}
+		case cache && hdr.Typeflag == tar.TypeReg:
+			delete(hdr.PAXRecords, ContextCacheRecord)
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if err := c.copyAndStore(tw, tr); err != nil {
+				return err
+			}
+		default:
+			if err := tw.WriteHeader(hdr); err != nil {
+				return err
+			}
+			if _, err := io.Copy(tw, tr); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// copyFromCache writes the entry with the cached content of the digest.
+func (c *ContextCache) copyFromCache(tw *tar.Writer, hdr *tar.Header, value string) error {
+	d, err := digest.Parse(value)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(c.path(d))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr.Size = info.Size()
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// copyAndStore copies the content of a context file and adds it to the cache.
+func (c *ContextCache) copyAndStore(w io.Writer, r io.Reader) error {
+	dir := filepath.Join(c.dir, digest.Canonical.String())
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	digester := digest.Canonical.Digester()
+	if _, err := io.Copy(io.MultiWriter(w, tmp, digester.Hash()), r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path(digester.Digest()))
+}
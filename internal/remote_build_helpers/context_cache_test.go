@@ -0,0 +1,90 @@
+package remote_build_helpers
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tarEntry struct {
+	name    string
+	content string
+	record  string
+	digest  digest.Digest
+}
+
+func writeContextTar(t *testing.T, entries []tarEntry) io.Reader {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0o644, Size: int64(len(e.content)), Typeflag: tar.TypeReg}
+		if e.record != "" {
+			hdr.PAXRecords = map[string]string{e.record: e.digest.String()}
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		_, err := tw.Write([]byte(e.content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return &buf
+}
+
+func readContextTar(t *testing.T, r io.Reader) map[string]string {
+	files := make(map[string]string)
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		require.NoError(t, err)
+		assert.NotContains(t, hdr.PAXRecords, ContextDigestRecord)
+		assert.NotContains(t, hdr.PAXRecords, ContextCacheRecord)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		files[hdr.Name] = string(content)
+	}
+}
+
+func TestContextCache(t *testing.T) {
+	cache := NewContextCache(t.TempDir())
+	main := digest.FromString("main")
+	secret := digest.FromString("secret")
+
+	missing, err := cache.Missing([]string{main.String(), secret.String()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{main.String(), secret.String()}, missing)
+
+	// first build: everything is sent, only the marked file is cached
+	expanded := cache.Expand(writeContextTar(t, []tarEntry{
+		{name: "main.go", content: "main", record: ContextCacheRecord, digest: main},
+		{name: "secret", content: "secret"},
+	}))
+	assert.Equal(t, map[string]string{"main.go": "main", "secret": "secret"}, readContextTar(t, expanded))
+
+	missing, err = cache.Missing([]string{main.String(), secret.String()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{secret.String()}, missing)
+
+	// second build: the cached file is sent without content
+	expanded = cache.Expand(writeContextTar(t, []tarEntry{
+		{name: "main.go", record: ContextDigestRecord, digest: main},
+		{name: "copy.go", record: ContextDigestRecord, digest: main},
+	}))
+	assert.Equal(t, map[string]string{"main.go": "main", "copy.go": "main"}, readContextTar(t, expanded))
+
+	// unknown digests fail the build
+	expanded = cache.Expand(writeContextTar(t, []tarEntry{
+		{name: "secret", record: ContextDigestRecord, digest: secret},
+	}))
+	_, err = io.ReadAll(expanded)
+	assert.ErrorContains(t, err, `restoring "secret" from the build context cache`)
+
+	_, err = cache.Missing([]string{"../../etc/passwd"})
+	assert.Error(t, err)
+}
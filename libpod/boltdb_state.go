@@ -74,6 +74,12 @@ type BoltState struct {
 
 // NewBoltState creates a new bolt-backed state database
 func NewBoltState(path string, runtime *Runtime) (State, error) {
+	return newBoltState(path, runtime, false)
+}
+
+// newBoltState creates a new bolt-backed state database. New databases are
+// only created if allowCreate is set, e.g. when migrating to BoltDB.
+func newBoltState(path string, runtime *Runtime, allowCreate bool) (State, error) {
 	logrus.Info("Using boltdb as database backend")
 	state := new(BoltState)
 	state.dbPath = path
@@ -86,11 +92,14 @@ func NewBoltState(path string, runtime *Runtime) (State, error) {
 	// If the DB does not already exist, error out.
 	// To continue testing in CI, allow creation iff an undocumented env
 	// var is set.
-	if os.Getenv("CI_DESIRED_DATABASE") != "boltdb" {
+	switch {
+	case allowCreate:
+		logrus.Debugf("Allowing creation of deprecated database backend for migration.")
+	case os.Getenv("CI_DESIRED_DATABASE") != "boltdb":
 		if err := fileutils.Exists(path); err != nil && errors.Is(err, fs.ErrNotExist) {
 			return nil, fmt.Errorf("the BoltDB backend has been deprecated, no new BoltDB databases can be created: %w", define.ErrInvalidArg)
 		}
-	} else {
+	default:
 		logrus.Debugf("Allowing deprecated database backend due to CI_DESIRED_DATABASE.")
 	}
 
@@ -125,6 +134,7 @@ func NewBoltState(path string, runtime *Runtime) (State, error) {
 		exitCodeBkt,
 		exitCodeTimeStampBkt,
 		volCtrsBkt,
+		idempotencyKeysBkt,
 	}
 
 	// Does the DB need an update?
@@ -1560,6 +1570,134 @@ func (s *BoltState) PruneContainerExitCodes() error {
 	return nil
 }
 
+// AddIdempotencyKey adds the idempotency key of an API request to the
+// database, unless an unexpired key with the same value exists, which is
+// returned instead.  Expired keys are removed.
+func (s *BoltState) AddIdempotencyKey(key *IdempotencyKey) (*IdempotencyKey, error) {
+	if len(key.Key) == 0 {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling idempotency key %q: %w", key.Key, err)
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return nil, err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	var existing *IdempotencyKey
+	err = db.Update(func(tx *bolt.Tx) error {
+		keysBucket, err := getIdempotencyKeysBucket(tx)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var expired [][]byte
+		err = keysBucket.ForEach(func(rawKey, rawJSON []byte) error {
+			stored := new(IdempotencyKey)
+			if err := json.Unmarshal(rawJSON, stored); err != nil {
+				return fmt.Errorf("unmarshalling idempotency key %q: %w", string(rawKey), err)
+			}
+			if stored.expired(now) {
+				expired = append(expired, rawKey)
+			} else if stored.Key == key.Key {
+				existing = stored
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, rawKey := range expired {
+			if err := keysBucket.Delete(rawKey); err != nil {
+				return fmt.Errorf("removing expired idempotency key %q: %w", string(rawKey), err)
+			}
+		}
+
+		if existing != nil {
+			return nil
+		}
+		if err := keysBucket.Put([]byte(key.Key), keyJSON); err != nil {
+			return fmt.Errorf("adding idempotency key %q to DB: %w", key.Key, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return existing, nil
+}
+
+// UpdateIdempotencyKey updates the response saved with the idempotency key.
+func (s *BoltState) UpdateIdempotencyKey(key *IdempotencyKey) error {
+	if len(key.Key) == 0 {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("marshalling idempotency key %q: %w", key.Key, err)
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		keysBucket, err := getIdempotencyKeysBucket(tx)
+		if err != nil {
+			return err
+		}
+		if err := keysBucket.Put([]byte(key.Key), keyJSON); err != nil {
+			return fmt.Errorf("updating idempotency key %q in DB: %w", key.Key, err)
+		}
+		return nil
+	})
+}
+
+// RemoveIdempotencyKey removes the idempotency key from the database.
+func (s *BoltState) RemoveIdempotencyKey(key string) error {
+	if len(key) == 0 {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	db, err := s.getDBCon()
+	if err != nil {
+		return err
+	}
+	defer s.deferredCloseDBCon(db)
+
+	return db.Update(func(tx *bolt.Tx) error {
+		keysBucket, err := getIdempotencyKeysBucket(tx)
+		if err != nil {
+			return err
+		}
+		if err := keysBucket.Delete([]byte(key)); err != nil {
+			return fmt.Errorf("removing idempotency key %q from DB: %w", key, err)
+		}
+		return nil
+	})
+}
+
 // AddExecSession adds an exec session to the state.
 func (s *BoltState) AddExecSession(ctr *Container, session *ExecSession) error {
 	if !s.valid {
@@ -0,0 +1,137 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	buildahDefine "github.com/containers/buildah/define"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libimage"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/oci/layout"
+	"go.podman.io/image/v5/types"
+)
+
+const (
+	// buildCacheRepository is the repository of the cache images which
+	// buildah pushes and pulls, tagged with the cache keys of the build
+	// steps.  The images are stored in the shared build cache instead.
+	buildCacheRepository = "localhost/podman-build-cache"
+	// buildCacheDir is the directory of the shared build cache in a graph
+	// root on shared storage.
+	buildCacheDir = "build-cache"
+	// buildCacheBlobsDir holds the blobs of all the images of the shared
+	// build cache.
+	buildCacheBlobsDir = "blobs"
+)
+
+// SetupSharedBuildCache sets up the build to export the images of its steps
+// to the shared build cache and to import them from it.  The setting is
+// either the directory of the cache, define.BuildSharedCacheNone, or
+// define.BuildSharedCacheAuto, which uses the build-cache directory of the
+// graph root when it is on shared storage.
+//
+// The images are stored in OCI layouts named after the cache keys of the
+// steps, which buildah computes from the base image and the instruction of
+// the step, so that the steps executed on one node are found on the other
+// nodes sharing the storage.  The layouts share a blob directory: blobs are
+// written once, under their digest.
+func (r *Runtime) SetupSharedBuildCache(options *buildahDefine.BuildOptions, setting string) error {
+	var dir string
+	switch setting {
+	case "", define.BuildSharedCacheNone:
+		return nil
+	case define.BuildSharedCacheAuto:
+		if !options.Layers {
+			return nil
+		}
+		shared, err := isPathOnSharedStorage(r.store.GraphRoot())
+		if err != nil {
+			logrus.Debugf("Failed to check if graph root is on shared storage: %v", err)
+		}
+		if !shared {
+			return nil
+		}
+		dir = filepath.Join(r.store.GraphRoot(), buildCacheDir)
+	default:
+		if !filepath.IsAbs(setting) {
+			return fmt.Errorf("shared build cache %q must be %q, %q or an absolute path: %w", setting, define.BuildSharedCacheAuto, define.BuildSharedCacheNone, define.ErrInvalidArg)
+		}
+		if !options.Layers {
+			return fmt.Errorf("the shared build cache requires the intermediate images of --layers: %w", define.ErrInvalidArg)
+		}
+		dir = setting
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, buildCacheBlobsDir), 0o755); err != nil {
+		return fmt.Errorf("creating shared build cache: %w", err)
+	}
+	logrus.Debugf("Using shared build cache %s", dir)
+
+	repo, err := reference.ParseNormalizedNamed(buildCacheRepository)
+	if err != nil {
+		return err
+	}
+	options.CacheFrom = append(options.CacheFrom, repo)
+	options.CacheTo = append(options.CacheTo, repo)
+	options.CachePullSourceLookupReferenceFunc = sharedBuildCacheLookup(dir, options.CachePullSourceLookupReferenceFunc)
+	options.CachePushDestinationLookupReferenceFunc = sharedBuildCacheLookup(dir, options.CachePushDestinationLookupReferenceFunc)
+	return nil
+}
+
+// sharedBuildCacheLookup returns a function replacing the references to
+// the cache images by their layouts in the shared build cache in dir.  The
+// other references are passed to next, if set.
+func sharedBuildCacheLookup(dir string, next libimage.LookupReferenceFunc) libimage.LookupReferenceFunc {
+	return func(ref types.ImageReference) (types.ImageReference, error) {
+		tagged, ok := ref.DockerReference().(reference.NamedTagged)
+		if !ok || tagged.Name() != buildCacheRepository {
+			if next != nil {
+				return next(ref)
+			}
+			return ref, nil
+		}
+		layoutRef, err := layout.NewReference(filepath.Join(dir, tagged.Tag()), "")
+		if err != nil {
+			return nil, err
+		}
+		return sharedBlobDirReference{ImageReference: layoutRef, blobDir: filepath.Join(dir, buildCacheBlobsDir)}, nil
+	}
+}
+
+// sharedBlobDirReference is a reference to an OCI layout storing its blobs
+// in a shared blob directory.
+type sharedBlobDirReference struct {
+	types.ImageReference
+	blobDir string
+}
+
+func (ref sharedBlobDirReference) systemContext(sys *types.SystemContext) *types.SystemContext {
+	ctx := types.SystemContext{}
+	if sys != nil {
+		ctx = *sys
+	}
+	ctx.OCISharedBlobDirPath = ref.blobDir
+	return &ctx
+}
+
+func (ref sharedBlobDirReference) NewImage(ctx context.Context, sys *types.SystemContext) (types.ImageCloser, error) {
+	return ref.ImageReference.NewImage(ctx, ref.systemContext(sys))
+}
+
+func (ref sharedBlobDirReference) NewImageSource(ctx context.Context, sys *types.SystemContext) (types.ImageSource, error) {
+	return ref.ImageReference.NewImageSource(ctx, ref.systemContext(sys))
+}
+
+func (ref sharedBlobDirReference) NewImageDestination(ctx context.Context, sys *types.SystemContext) (types.ImageDestination, error) {
+	return ref.ImageReference.NewImageDestination(ctx, ref.systemContext(sys))
+}
+
+func (ref sharedBlobDirReference) DeleteImage(ctx context.Context, sys *types.SystemContext) error {
+	return ref.ImageReference.DeleteImage(ctx, ref.systemContext(sys))
+}
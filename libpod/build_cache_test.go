@@ -0,0 +1,58 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"path/filepath"
+	"testing"
+
+	buildahDefine "github.com/containers/buildah/define"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/common/libimage"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/oci/layout"
+)
+
+func TestSetupSharedBuildCache(t *testing.T) {
+	r := &Runtime{}
+	options := &buildahDefine.BuildOptions{Layers: true}
+	require.NoError(t, r.SetupSharedBuildCache(options, define.BuildSharedCacheNone))
+	assert.Empty(t, options.CacheTo)
+
+	err := r.SetupSharedBuildCache(options, "relative/dir")
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+
+	err = r.SetupSharedBuildCache(&buildahDefine.BuildOptions{}, t.TempDir())
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+
+	dir := t.TempDir()
+	require.NoError(t, r.SetupSharedBuildCache(options, dir))
+	assert.DirExists(t, filepath.Join(dir, buildCacheBlobsDir))
+	require.Len(t, options.CacheFrom, 1)
+	require.Len(t, options.CacheTo, 1)
+	assert.Equal(t, buildCacheRepository, options.CacheTo[0].Name())
+
+	// The cache images are stored in the layouts of the cache.
+	named, err := reference.ParseNormalizedNamed(buildCacheRepository + ":0123abcd")
+	require.NoError(t, err)
+	cacheRef, err := docker.NewReference(named)
+	require.NoError(t, err)
+	for _, lookup := range []libimage.LookupReferenceFunc{options.CachePullSourceLookupReferenceFunc, options.CachePushDestinationLookupReferenceFunc} {
+		ref, err := lookup(cacheRef)
+		require.NoError(t, err)
+		assert.Equal(t, layout.Transport.Name(), ref.Transport().Name())
+		assert.Equal(t, filepath.Join(dir, "0123abcd")+":", ref.StringWithinTransport())
+	}
+
+	// Other references are left as they are.
+	other, err := reference.ParseNormalizedNamed("quay.io/test/cache:0123abcd")
+	require.NoError(t, err)
+	otherRef, err := docker.NewReference(other)
+	require.NoError(t, err)
+	ref, err := options.CachePullSourceLookupReferenceFunc(otherRef)
+	require.NoError(t, err)
+	assert.Equal(t, otherRef, ref)
+}
@@ -158,6 +158,12 @@ type ContainerState struct {
 	// OOMKilled indicates that the container was killed as it ran out of
 	// memory
 	OOMKilled bool `json:"oomKilled,omitempty"`
+	// OOMReport describes the memory usage of the container when it was
+	// OOM killed.
+	OOMReport *define.OOMReport `json:"oomReport,omitempty"`
+	// CgroupPath is the cgroup of the container when it was started,
+	// the cgroup cannot be looked up anymore once the container exited.
+	CgroupPath string `json:"cgroupPath,omitempty"`
 	// Checkpointed indicates that the container was stopped by a checkpoint
 	// operation.
 	Checkpointed bool `json:"checkpointed,omitempty"`
@@ -211,6 +217,10 @@ type ContainerState struct {
 	// HCUnitName records the name of the healthcheck unit.
 	// Automatically generated when the healthcheck is started.
 	HCUnitName string `json:"hcUnitName,omitempty"`
+	// FrozenUntil is the time the container, paused with a freeze timeout,
+	// is unpaused automatically.  It is zero if the container was paused
+	// without a timeout.
+	FrozenUntil time.Time `json:"frozenUntil"`
 
 	// ExtensionStageHooks holds hooks which will be executed by libpod
 	// and not delegated to the OCI runtime.
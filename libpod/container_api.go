@@ -9,6 +9,7 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"slices"
 	"time"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
@@ -492,23 +493,7 @@ func (c *Container) Unmount(force bool) error {
 
 // Pause pauses a container
 func (c *Container) Pause() error {
-	if !c.batched {
-		c.lock.Lock()
-		defer c.lock.Unlock()
-
-		if err := c.syncContainer(); err != nil {
-			return err
-		}
-	}
-
-	if c.state.State == define.ContainerStatePaused {
-		return fmt.Errorf("%q is already paused: %w", c.ID(), define.ErrCtrStateInvalid)
-	}
-	if c.state.State != define.ContainerStateRunning {
-		return fmt.Errorf("%q is not running, can't pause: %w", c.state.State, define.ErrCtrStateInvalid)
-	}
-	defer c.newContainerEvent(events.Pause)
-	return c.pause()
+	return c.Freeze(0)
 }
 
 // Unpause unpauses a container
@@ -710,6 +695,9 @@ type waitResult struct {
 
 func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeout time.Duration, conditions ...string) (int32, error) {
 	if !c.valid {
+		if slices.Contains(conditions, define.ContainerConditionRemoved) {
+			return -1, nil
+		}
 		return -1, define.ErrCtrRemoved
 	}
 
@@ -724,9 +712,12 @@ func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeou
 	waitForExit := false
 	wantedStates := make(map[define.ContainerStatus]bool, len(conditions))
 	wantedHealthStates := make(map[string]bool)
+	waitForRemoval := false
 
 	for _, rawCondition := range conditions {
 		switch rawCondition {
+		case define.ContainerConditionRemoved:
+			waitForRemoval = true
 		case define.HealthCheckHealthy, define.HealthCheckUnhealthy:
 			if !c.HasHealthCheck() {
 				return -1, fmt.Errorf("cannot use condition %q: container %s has no healthcheck", rawCondition, c.ID())
@@ -760,16 +751,16 @@ func (c *Container) WaitForConditionWithInterval(ctx context.Context, waitTimeou
 		}()
 	}
 
-	if len(wantedStates) > 0 || len(wantedHealthStates) > 0 {
+	if len(wantedStates) > 0 || len(wantedHealthStates) > 0 || waitForRemoval {
 		go func() {
 			stoppedCount := 0
 			for {
-				if len(wantedStates) > 0 {
+				if len(wantedStates) > 0 || waitForRemoval {
 					state, err := c.State()
 					if err != nil {
 						// If the we wait for removing and the container is removed do not return this as error.
 						// This allows callers to actually wait for the ctr to be removed.
-						if wantedStates[define.ContainerStateRemoving] &&
+						if (wantedStates[define.ContainerStateRemoving] || waitForRemoval) &&
 							(errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved)) {
 							// check if the exit code was recorded in the db to return it
 							exitCode, err := c.runtime.state.GetContainerExitCode(c.ID())
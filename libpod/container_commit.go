@@ -6,14 +6,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"slices"
 	"strings"
 
 	"github.com/containers/buildah"
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/libpod/events"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/libimage"
+	ociarchive "go.podman.io/image/v5/oci/archive"
 	is "go.podman.io/image/v5/storage"
 	"go.podman.io/image/v5/types"
 )
@@ -35,8 +40,76 @@ type ContainerCommitOptions struct {
 // Commit commits the changes between a container and its image, creating a new
 // image
 func (c *Container) Commit(ctx context.Context, destImage string, options ContainerCommitOptions) (*libimage.Image, error) {
+	var commitRef types.ImageReference
+	if destImage != "" {
+		// Now resolve the name.
+		resolvedImageName, err := c.runtime.LibimageRuntime().ResolveName(destImage)
+		if err != nil {
+			return nil, err
+		}
+
+		imageRef, err := is.Transport.ParseStoreReference(c.runtime.store, resolvedImageName)
+		if err != nil {
+			return nil, fmt.Errorf("parsing target image name %q: %w", destImage, err)
+		}
+		commitRef = imageRef
+	}
+	id, err := c.commit(ctx, commitRef, options)
+	if err != nil {
+		return nil, err
+	}
+	defer c.newContainerEvent(events.Commit)
+	img, _, err := c.runtime.libimageRuntime.LookupImage(id, nil)
+	if err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// ExportImage writes the container as an OCI archive to out, with its
+// changes squashed into a single layer on top of nothing.  Unlike Export,
+// the archive keeps the configuration of the container (entrypoint,
+// command, environment, labels, user, working directory and exposed ports),
+// so that importing it gives an image the container can be recreated from.
+func (c *Container) ExportImage(ctx context.Context, out io.Writer, options ContainerCommitOptions) error {
+	tmpDir, err := os.MkdirTemp("", "podman-export-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(tmpDir); err != nil {
+			logrus.Errorf("Removing %s: %v", tmpDir, err)
+		}
+	}()
+
+	archivePath := filepath.Join(tmpDir, "archive.tar")
+	ref, err := ociarchive.NewReference(archivePath, "")
+	if err != nil {
+		return err
+	}
+	options.Squash = true
+	options.PreferredManifestType = v1.MediaTypeImageManifest
+	if _, err := c.commit(ctx, ref, options); err != nil {
+		return err
+	}
+
+	archive, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer archive.Close()
+	if _, err := io.Copy(out, archive); err != nil {
+		return fmt.Errorf("writing OCI archive of container %s: %w", c.ID(), err)
+	}
+	c.newContainerEvent(events.Export)
+	return nil
+}
+
+// commit commits the container to commitRef, or to an unnamed image in the
+// storage when it is nil, and returns the ID of the image.
+func (c *Container) commit(ctx context.Context, commitRef types.ImageReference, options ContainerCommitOptions) (string, error) {
 	if c.config.Rootfs != "" {
-		return nil, errors.New("cannot commit a container that uses an exploded rootfs")
+		return "", errors.New("cannot commit a container that uses an exploded rootfs")
 	}
 
 	if !c.batched {
@@ -44,13 +117,13 @@ func (c *Container) Commit(ctx context.Context, destImage string, options Contai
 		defer c.lock.Unlock()
 
 		if err := c.syncContainer(); err != nil {
-			return nil, err
+			return "", err
 		}
 	}
 
 	if c.state.State == define.ContainerStateRunning && options.Pause {
 		if err := c.pause(); err != nil {
-			return nil, fmt.Errorf("pausing container %q to commit: %w", c.ID(), err)
+			return "", fmt.Errorf("pausing container %q to commit: %w", c.ID(), err)
 		}
 		defer func() {
 			if err := c.unpause(); err != nil {
@@ -74,7 +147,7 @@ func (c *Container) Commit(ctx context.Context, destImage string, options Contai
 	}
 	importBuilder, err := buildah.ImportBuilder(ctx, c.runtime.store, builderOptions)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
 	importBuilder.Format = options.PreferredManifestType
 	if options.Author != "" {
@@ -134,7 +207,7 @@ func (c *Container) Commit(ctx context.Context, destImage string, options Contai
 			if slices.Contains(c.config.UserVolumes, v.Dest) {
 				vol, err := c.runtime.GetVolume(v.Name)
 				if err != nil {
-					return nil, fmt.Errorf("volume %s used in container %s has been removed: %w", v.Name, c.ID(), err)
+					return "", fmt.Errorf("volume %s used in container %s has been removed: %w", v.Name, c.ID(), err)
 				}
 				if vol.Anonymous() {
 					importBuilder.AddVolume(v.Dest)
@@ -145,28 +218,9 @@ func (c *Container) Commit(ctx context.Context, destImage string, options Contai
 	// Workdir
 	importBuilder.SetWorkDir(c.config.Spec.Process.Cwd)
 
-	var commitRef types.ImageReference
-	if destImage != "" {
-		// Now resolve the name.
-		resolvedImageName, err := c.runtime.LibimageRuntime().ResolveName(destImage)
-		if err != nil {
-			return nil, err
-		}
-
-		imageRef, err := is.Transport.ParseStoreReference(c.runtime.store, resolvedImageName)
-		if err != nil {
-			return nil, fmt.Errorf("parsing target image name %q: %w", destImage, err)
-		}
-		commitRef = imageRef
-	}
 	id, _, _, err := importBuilder.Commit(ctx, commitRef, commitOptions)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
-	defer c.newContainerEvent(events.Commit)
-	img, _, err := c.runtime.libimageRuntime.LookupImage(id, nil)
-	if err != nil {
-		return nil, err
-	}
-	return img, nil
+	return id, nil
 }
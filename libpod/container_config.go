@@ -177,6 +177,11 @@ type ContainerRootFSConfig struct {
 	// Volatile specifies whether the container storage can be optimized
 	// at the cost of not syncing all the dirty files in memory.
 	Volatile bool `json:"volatile,omitempty"`
+	// StorageTransient indicates that the bundle and the logs of the
+	// container are kept under the run root, on tmpfs, and that the
+	// container is removed when the system reboots.  The writable layer
+	// of the container is volatile.
+	StorageTransient bool `json:"storageTransient,omitempty"`
 	// Passwd allows to user to override podman's passwd/group file setup
 	Passwd *bool `json:"passwd,omitempty"`
 	// ChrootDirs is an additional set of directories that need to be
@@ -328,6 +333,13 @@ type ContainerNetworkConfig struct {
 	NetMode namespaces.NetworkMode `json:"networkMode,omitempty"`
 	// NetworkOptions are additional options for each network
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// TrackFlows enables the accounting of the connection tracking of
+	// the kernel for the connections of the container, which are shown
+	// by inspect.
+	TrackFlows bool `json:"trackFlows,omitempty"`
+	// NetworkPolicy restricts the traffic of the network namespace of
+	// the container with firewall rules in the namespace.
+	NetworkPolicy *define.NetworkPolicy `json:"networkPolicy,omitempty"`
 }
 
 // ContainerImageConfig is an embedded sub-config providing image configuration
@@ -408,6 +420,15 @@ type ContainerMiscConfig struct {
 	PostConfigureNetNS bool `json:"postConfigureNetNS"`
 	// OCIRuntime used to create the container
 	OCIRuntime string `json:"runtime,omitempty"`
+	// RuntimeClass is the runtime class the OCI runtime of the container
+	// was selected with.
+	RuntimeClass string `json:"runtimeClass,omitempty"`
+	// Monitor is the container monitor selected for the container, conmon
+	// when empty.
+	Monitor string `json:"monitor,omitempty"`
+	// MonitorPath is the path of the container monitor binary, the conmon
+	// of the OCI runtime is used when empty.
+	MonitorPath string `json:"monitorPath,omitempty"`
 	// IsInfra is a bool indicating whether this container is an infra container used for
 	// sharing kernel namespaces in a pod
 	IsInfra bool `json:"pause"`
@@ -442,6 +463,10 @@ type ContainerMiscConfig struct {
 	// healthcheck for the container. This will run before the regular HC
 	// runs, and when it passes the regular HC will be activated.
 	StartupHealthCheckConfig *define.StartupHealthCheck `json:"startupHealthCheck,omitempty"`
+	// StatsHistoryInterval is the interval at which samples of the resource
+	// usage of the container are recorded in its stats history while it
+	// runs.  Zero disables the recording.
+	StatsHistoryInterval time.Duration `json:"statsHistoryInterval,omitempty"`
 	// PreserveFDs is a number of additional file descriptors (in addition
 	// to 0, 1, 2) that will be passed to the executed process. The total FDs
 	// passed will be 3 + PreserveFDs.
@@ -449,11 +474,21 @@ type ContainerMiscConfig struct {
 	// PreserveFD is a list of additional file descriptors (in addition
 	// to 0, 1, 2) that will be passed to the executed process.
 	PreserveFD []uint `json:"preserveFd,omitempty"`
+	// SocketActivation passes the preserved file descriptors to the
+	// container as sockets of systemd socket activation.
+	SocketActivation bool `json:"socketActivation,omitempty"`
 	// Timezone is the timezone inside the container.
 	// Local means it has the same timezone as the host machine
 	Timezone string `json:"timezone,omitempty"`
+	// Locale is the locale of the container.  It is generated from the
+	// definition in the image when the image does not provide it compiled.
+	Locale string `json:"locale,omitempty"`
 	// Umask is the umask inside the container.
 	Umask string `json:"umask,omitempty"`
+	// NUMAPolicy is the NUMA memory policy (set_mempolicy(2)) that is
+	// applied to the container processes. The memory nodes are taken from
+	// the cpuset mems of the container.
+	NUMAPolicy string `json:"numaPolicy,omitempty"`
 	// PidFile is the file that saves the pid of the container process
 	PidFile string `json:"pid_file,omitempty"`
 	// CDIDevices contains devices that use the CDI
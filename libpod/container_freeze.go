@@ -0,0 +1,104 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/libpod/events"
+	"github.com/sirupsen/logrus"
+)
+
+// Freeze pauses the container like Pause.  If the timeout is not zero, the
+// container is unpaused automatically by a systemd timer once the timeout
+// expired, unless it was unpaused before.  A freeze_failed event is written
+// if the container cannot be paused.
+func (c *Container) Freeze(timeout time.Duration) error {
+	if timeout < 0 {
+		return fmt.Errorf("freeze timeout must not be negative: %w", define.ErrInvalidArg)
+	}
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.state.State == define.ContainerStatePaused {
+		return fmt.Errorf("%q is already paused: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+	if c.state.State != define.ContainerStateRunning {
+		return fmt.Errorf("%q is not running, can't pause: %w", c.state.State, define.ErrCtrStateInvalid)
+	}
+
+	c.state.FrozenUntil = time.Time{}
+	if timeout > 0 {
+		// The deadline is computed before the timer is created, the
+		// timer cannot fire before it.
+		deadline := time.Now().Add(timeout)
+		if err := c.createThawTimer(timeout); err != nil {
+			err = fmt.Errorf("scheduling the automatic thaw of container %s: %w", c.ID(), err)
+			c.newContainerFreezeFailedEvent(err)
+			return err
+		}
+		c.state.FrozenUntil = deadline
+	}
+
+	if err := c.pause(); err != nil {
+		if !c.state.FrozenUntil.IsZero() {
+			c.state.FrozenUntil = time.Time{}
+			if err := c.removeThawTimer(context.Background()); err != nil {
+				logrus.Errorf("Removing thaw timer of container %s: %v", c.ID(), err)
+			}
+		}
+		c.newContainerFreezeFailedEvent(err)
+		return err
+	}
+	c.newContainerEvent(events.Pause)
+	return nil
+}
+
+// ThawExpired unpauses the container if it was paused with a freeze timeout
+// which expired.  It is run by the systemd timer of the freeze timeout, and
+// does nothing if the container was unpaused or paused again since.
+func (c *Container) ThawExpired() error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if c.state.State != define.ContainerStatePaused || c.state.FrozenUntil.IsZero() || time.Now().Before(c.state.FrozenUntil) {
+		return nil
+	}
+
+	// Clear the deadline first, the timer running this must not be
+	// stopped by unpause.
+	c.state.FrozenUntil = time.Time{}
+	if err := c.unpause(); err != nil {
+		return fmt.Errorf("thawing container %s after its freeze timeout: %w", c.ID(), err)
+	}
+	c.newContainerEvent(events.Thaw)
+	return nil
+}
+
+// clearFreezeTimeout removes the thaw timer of a container paused with a
+// freeze timeout.  The caller saves the state.
+func (c *Container) clearFreezeTimeout() {
+	if c.state.FrozenUntil.IsZero() {
+		return
+	}
+	c.state.FrozenUntil = time.Time{}
+	if err := c.removeThawTimer(context.Background()); err != nil {
+		logrus.Errorf("Removing thaw timer of container %s: %v", c.ID(), err)
+	}
+}
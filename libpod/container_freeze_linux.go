@@ -0,0 +1,101 @@
+//go:build !remote && systemd
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/dmikushin/podman-shared/pkg/errorhandling"
+	"github.com/dmikushin/podman-shared/pkg/rootless"
+	"github.com/dmikushin/podman-shared/pkg/systemd"
+	"github.com/sirupsen/logrus"
+	systemdCommon "go.podman.io/common/pkg/systemd"
+)
+
+// thawUnitName is the name of the systemd units unpausing the container once
+// its freeze timeout expired.
+func (c *Container) thawUnitName() string {
+	return c.ID() + "-thaw"
+}
+
+// createThawTimer creates and starts a systemd timer unpausing the container
+// after the timeout.  The units are unloaded once the timer fired.
+func (c *Container) createThawTimer(timeout time.Duration) error {
+	if !systemdCommon.RunsOnSystemd() {
+		return errors.New("a freeze timeout requires systemd")
+	}
+	// Remove the units of a previous freeze the thaw did not remove.
+	if err := c.removeThawTimer(context.Background()); err != nil {
+		logrus.Debugf("Removing thaw timer of container %s: %v", c.ID(), err)
+	}
+
+	podman, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get path for podman for a thaw timer: %w", err)
+	}
+
+	var cmd = []string{"--property", "LogLevelMax=notice", "--property", "CollectMode=inactive-or-failed"}
+	if rootless.IsRootless() {
+		cmd = append(cmd, "--user")
+	}
+	path := os.Getenv("PATH")
+	if path != "" {
+		cmd = append(cmd, "--setenv=PATH="+path)
+	}
+
+	cmd = append(cmd, "--unit", c.thawUnitName(), "--on-active="+timeout.String(), "--timer-property=AccuracySec=1s", "--timer-property=RemainAfterElapse=no", podman)
+
+	if logrus.IsLevelEnabled(logrus.DebugLevel) {
+		cmd = append(cmd, "--log-level=debug", "--syslog")
+	}
+
+	cmd = append(cmd, "container", "thaw-expired", c.ID())
+
+	logrus.Debugf("creating systemd-transient files: %s %s", "systemd-run", cmd)
+	systemdRun := exec.Command("systemd-run", cmd...)
+	if output, err := systemdRun.CombinedOutput(); err != nil {
+		exitError := &exec.ExitError{}
+		if errors.As(err, &exitError) {
+			return fmt.Errorf("systemd-run failed: %w: output: %s", err, strings.TrimSpace(string(output)))
+		}
+		return fmt.Errorf("failed to execute systemd-run: %w", err)
+	}
+	return nil
+}
+
+// removeThawTimer stops and removes the systemd timer unpausing the
+// container.
+func (c *Container) removeThawTimer(ctx context.Context) error {
+	if !systemdCommon.RunsOnSystemd() {
+		return nil
+	}
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		return fmt.Errorf("unable to get systemd connection to remove thaw timer: %w", err)
+	}
+	defer conn.Close()
+
+	stopErrors := []error{}
+	unitName := c.thawUnitName()
+	for _, unit := range []string{unitName + ".timer", unitName + ".service"} {
+		stopChan := make(chan string)
+		if _, err := conn.StopUnitContext(ctx, unit, "ignore-dependencies", stopChan); err != nil {
+			if !strings.HasSuffix(err.Error(), " not loaded.") {
+				stopErrors = append(stopErrors, fmt.Errorf("removing thaw unit %q: %w", unit, err))
+			}
+		} else if err := systemdOpSuccessful(stopChan); err != nil {
+			stopErrors = append(stopErrors, fmt.Errorf("stopping systemd thaw unit %q: %w", unit, err))
+		}
+	}
+	if err := conn.ResetFailedUnitContext(ctx, unitName+".service"); err != nil {
+		logrus.Debugf("Failed to reset unit file: %q", err)
+	}
+
+	return errorhandling.JoinErrors(stopErrors)
+}
@@ -0,0 +1,21 @@
+//go:build !remote && (!systemd || !linux)
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// createThawTimer creates and starts a systemd timer unpausing the container
+// after the timeout.
+func (c *Container) createThawTimer(_ time.Duration) error {
+	return errors.New("a freeze timeout requires systemd")
+}
+
+// removeThawTimer stops and removes the systemd timer unpausing the
+// container.
+func (c *Container) removeThawTimer(_ context.Context) error {
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/parallel"
 	"github.com/dmikushin/podman-shared/pkg/syncmap"
 	"github.com/sirupsen/logrus"
+	"golang.org/x/sync/semaphore"
 )
 
 type containerNode struct {
@@ -237,56 +238,55 @@ func startNode(ctx context.Context, node *containerNode, setError bool, ctrError
 	ctrsVisited[node.id] = true
 
 	ctrErrored := false
+	if err := node.container.startInGraph(ctx, restart); err != nil {
+		ctrErrored = true
+		ctrErrors[node.id] = err
+	}
 
+	// Recurse to anyone who depends on us and start them
+	for _, successor := range node.dependedOn {
+		startNode(ctx, successor, ctrErrored, ctrErrors, ctrsVisited, restart)
+	}
+}
+
+// startInGraph starts the container, or restarts it if restart is set, once
+// its dependencies in the graph have been started.  Init containers are not
+// started.
+func (c *Container) startInGraph(ctx context.Context, restart bool) error {
 	// Check if dependencies are running
 	// Graph traversal means we should have started them
 	// But they could have died before we got here
 	// Does not require that the container be locked, we only need to lock
 	// the dependencies
-	depsStopped, err := node.container.checkDependenciesRunning()
+	depsStopped, err := c.checkDependenciesRunning()
 	if err != nil {
-		ctrErrors[node.id] = err
-		ctrErrored = true
+		return err
 	} else if len(depsStopped) > 0 {
 		// Our dependencies are not running
 		depsList := strings.Join(depsStopped, ",")
-		ctrErrors[node.id] = fmt.Errorf("the following dependencies of container %s are not running: %s: %w", node.id, depsList, define.ErrCtrStateInvalid)
-		ctrErrored = true
+		return fmt.Errorf("the following dependencies of container %s are not running: %s: %w", c.ID(), depsList, define.ErrCtrStateInvalid)
 	}
 
 	// Lock before we start
-	node.container.lock.Lock()
+	c.lock.Lock()
+	defer c.lock.Unlock()
 
 	// Sync the container to pick up current state
-	if !ctrErrored {
-		if err := node.container.syncContainer(); err != nil {
-			ctrErrored = true
-			ctrErrors[node.id] = err
-		}
+	if err := c.syncContainer(); err != nil {
+		return err
 	}
 
 	// Start the container (only if it is not running)
-	if !ctrErrored && len(node.container.config.InitContainerType) < 1 {
-		if !restart && node.container.state.State != define.ContainerStateRunning {
-			if err := node.container.initAndStart(ctx); err != nil {
-				ctrErrored = true
-				ctrErrors[node.id] = err
-			}
-		}
-		if restart && node.container.state.State != define.ContainerStatePaused && node.container.state.State != define.ContainerStateUnknown {
-			if err := node.container.restartWithTimeout(ctx, node.container.config.StopTimeout); err != nil {
-				ctrErrored = true
-				ctrErrors[node.id] = err
-			}
-		}
+	if len(c.config.InitContainerType) > 0 {
+		return nil
 	}
-
-	node.container.lock.Unlock()
-
-	// Recurse to anyone who depends on us and start them
-	for _, successor := range node.dependedOn {
-		startNode(ctx, successor, ctrErrored, ctrErrors, ctrsVisited, restart)
+	if !restart && c.state.State != define.ContainerStateRunning {
+		return c.initAndStart(ctx)
+	}
+	if restart && c.state.State != define.ContainerStatePaused && c.state.State != define.ContainerStateUnknown {
+		return c.restartWithTimeout(ctx, c.config.StopTimeout)
 	}
+	return nil
 }
 
 // Contains all details required for traversing the container graph.
@@ -381,20 +381,24 @@ func traverseNodeInwards(node *containerNode, nodeDetails *nodeTraversal, setErr
 }
 
 // Stop all containers in the given graph, assumed to be a graph of pod.
-// Pod is mandatory and should be locked.
-func stopContainerGraph(ctx context.Context, graph *ContainerGraph, pod *Pod, timeout *uint, cleanup bool) (map[string]error, error) {
+// Pod is mandatory and should be locked.  At most jobs containers are stopped
+// at once, zero uses the number of parallel jobs of Podman.
+func stopContainerGraph(ctx context.Context, graph *ContainerGraph, pod *Pod, timeout *uint, cleanup bool, jobs uint) (map[string]error, error) {
 	// Are there actually any containers in the graph?
 	// If not, return immediately.
 	if len(graph.nodes) == 0 {
 		return map[string]error{}, nil
 	}
 
-	nodeDetails := new(nodeTraversal)
-	nodeDetails.pod = pod
-	nodeDetails.ctrErrors = syncmap.New[string, error]()
-	nodeDetails.ctrsVisited = syncmap.New[string, bool]()
+	if len(graph.notDependedOnNodes) == 0 {
+		return nil, fmt.Errorf("no containers in pod %s are not dependencies of other containers, unable to stop", pod.ID())
+	}
 
-	traversalFunc := func(ctr *Container, _ *Pod) error {
+	ctrs := make([]*Container, 0, len(graph.nodes))
+	for _, node := range graph.nodes {
+		ctrs = append(ctrs, node.container)
+	}
+	errMap, err := ContainerOpOrdered(ctx, ctrs, jobs, true, func(ctr *Container) error {
 		ctr.lock.Lock()
 		defer ctr.lock.Unlock()
 
@@ -416,30 +420,18 @@ func stopContainerGraph(ctx context.Context, graph *ContainerGraph, pod *Pod, ti
 		}
 
 		return nil
-	}
-	nodeDetails.actionFunc = traversalFunc
-
-	doneChans := make([]<-chan error, 0, len(graph.notDependedOnNodes))
-
-	// Parallel enqueue jobs for all our starting nodes.
-	if len(graph.notDependedOnNodes) == 0 {
-		return nil, fmt.Errorf("no containers in pod %s are not dependencies of other containers, unable to stop", pod.ID())
-	}
-	for _, node := range graph.notDependedOnNodes {
-		doneChan := parallel.Enqueue(ctx, func() error {
-			traverseNodeInwards(node, nodeDetails, false)
-			return nil
-		})
-		doneChans = append(doneChans, doneChan)
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	// We don't care about the returns values, these functions always return nil
-	// But we do need all of the parallel jobs to terminate.
-	for _, doneChan := range doneChans {
-		<-doneChan
+	ctrErrors := make(map[string]error)
+	for ctr, err := range errMap {
+		if err != nil {
+			ctrErrors[ctr.ID()] = err
+		}
 	}
-
-	return nodeDetails.ctrErrors.Underlying(), nil
+	return ctrErrors, nil
 }
 
 // Remove all containers in the given graph
@@ -514,3 +506,128 @@ func removeContainerGraph(ctx context.Context, graph *ContainerGraph, pod *Pod,
 	// Safe to use Underlying as the SyncMap passes out of scope as we return
 	return ctrNamedVolumes.Underlying(), nodeDetails.ctrsVisited.Underlying(), nodeDetails.ctrErrors.Underlying(), nil
 }
+
+// ContainerOpOrdered performs the given function on the given containers in
+// the order of the dependencies between them, using a number of parallel
+// threads.  The function is only performed on a container once it has been
+// performed on all containers in ctrs it depends on, or with reverse set, on
+// all containers in ctrs that depend on it.  Dependencies on containers not in
+// ctrs are ignored.
+// At most jobs functions are performed at once; zero jobs uses the number of
+// parallel jobs of Podman.
+// If the function fails for a container, it is not performed on the
+// containers that must come after it, which fail with ErrCtrStateInvalid.
+// Each container in ctrs has an entry in the resulting map, set to nil if the
+// function succeeded.  An error is returned without performing the function
+// if the dependencies have a cycle.
+func ContainerOpOrdered(ctx context.Context, ctrs []*Container, jobs uint, reverse bool, applyFunc func(*Container) error) (map[*Container]error, error) {
+	nodes := make(map[string]*Container, len(ctrs))
+	for _, ctr := range ctrs {
+		nodes[ctr.ID()] = ctr
+	}
+
+	// The containers each container must wait for.
+	waitFor := make(map[string][]string, len(nodes))
+	for id, ctr := range nodes {
+		for _, dep := range ctr.Dependencies() {
+			if _, ok := nodes[dep]; !ok || dep == id {
+				continue
+			}
+			if reverse {
+				waitFor[dep] = append(waitFor[dep], id)
+			} else {
+				waitFor[id] = append(waitFor[id], dep)
+			}
+		}
+	}
+	if err := checkOrderCycles(nodes, waitFor); err != nil {
+		return nil, err
+	}
+
+	var sem *semaphore.Weighted
+	if jobs > 0 {
+		sem = semaphore.NewWeighted(int64(jobs))
+	}
+	run := func(ctr *Container) error {
+		if sem == nil {
+			return <-parallel.Enqueue(ctx, func() error {
+				return applyFunc(ctr)
+			})
+		}
+		if err := sem.Acquire(ctx, 1); err != nil {
+			return fmt.Errorf("acquiring job control semaphore: %w", err)
+		}
+		defer sem.Release(1)
+		return applyFunc(ctr)
+	}
+
+	var errLock sync.Mutex
+	ctrErrors := make(map[*Container]error, len(nodes))
+	done := make(map[string]chan struct{}, len(nodes))
+	for id := range nodes {
+		done[id] = make(chan struct{})
+	}
+	for id, ctr := range nodes {
+		go func() {
+			defer close(done[id])
+			var err error
+			for _, before := range waitFor[id] {
+				<-done[before]
+				errLock.Lock()
+				failed := ctrErrors[nodes[before]] != nil
+				errLock.Unlock()
+				if failed && err == nil {
+					if reverse {
+						err = fmt.Errorf("a container that depends on container %s failed: %w", id, define.ErrCtrStateInvalid)
+					} else {
+						err = fmt.Errorf("a dependency of container %s failed: %w", id, define.ErrCtrStateInvalid)
+					}
+				}
+			}
+			if err == nil {
+				logrus.Debugf("Starting ordered parallel job on container %s", id)
+				err = run(ctr)
+			}
+			errLock.Lock()
+			ctrErrors[ctr] = err
+			errLock.Unlock()
+		}()
+	}
+	for _, c := range done {
+		<-c
+	}
+	return ctrErrors, nil
+}
+
+// checkOrderCycles returns an error if the order of the containers has a
+// cycle, which would make ContainerOpOrdered wait forever.
+func checkOrderCycles(nodes map[string]*Container, waitFor map[string][]string) error {
+	pending := make(map[string]int, len(nodes))
+	next := make(map[string][]string, len(nodes))
+	ready := []string{}
+	for id := range nodes {
+		pending[id] = len(waitFor[id])
+		for _, before := range waitFor[id] {
+			next[before] = append(next[before], id)
+		}
+		if pending[id] == 0 {
+			ready = append(ready, id)
+		}
+	}
+	ordered := 0
+	for len(ready) > 0 {
+		id := ready[len(ready)-1]
+		ready = ready[:len(ready)-1]
+		ordered++
+		for _, after := range next[id] {
+			pending[after]--
+			if pending[after] == 0 {
+				ready = append(ready, after)
+			}
+		}
+	}
+	if ordered != len(nodes) {
+		return fmt.Errorf("cycle found in container dependency graph: %w", define.ErrInternal)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// initStatsFile is the file in the run directory of the container mounted at
+// define.ContainerInitStatsPath.
+const initStatsFile = "init.stats"
+
+// hasInit reports whether the container runs the init of --init.
+func (c *Container) hasInit() bool {
+	return c.config.Spec != nil && c.config.Spec.Annotations[define.InspectAnnotationInit] == define.InspectResponseTrue
+}
+
+// initStats returns the statistics the init of the container reported
+// during its last run, as "key value" lines like cgroup files.  Catatonit
+// and most other inits do not report any, so nil is returned.
+func (c *Container) initStats() *define.InspectInitStats {
+	if !c.hasInit() || c.state.RunDir == "" {
+		return nil
+	}
+	content, err := os.ReadFile(filepath.Join(c.state.RunDir, initStatsFile))
+	if err != nil || len(content) == 0 {
+		return nil
+	}
+	values, err := parseKeyedValues(content)
+	if err != nil {
+		logrus.Debugf("Parsing the init statistics of container %s: %v", c.ID(), err)
+		return nil
+	}
+	return &define.InspectInitStats{
+		Reaped:           values["reaped"],
+		SignalsForwarded: values["forwarded"],
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"slices"
 	"strings"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
@@ -128,6 +129,8 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 			Running:        runtimeInfo.State == define.ContainerStateRunning,
 			Paused:         runtimeInfo.State == define.ContainerStatePaused,
 			OOMKilled:      runtimeInfo.OOMKilled,
+			OOMReport:      runtimeInfo.OOMReport,
+			InitStats:      c.initStats(),
 			Dead:           runtimeInfo.State.String() == "bad state",
 			Pid:            runtimeInfo.PID,
 			ConmonPid:      runtimeInfo.ConmonPID,
@@ -155,6 +158,8 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 		HostsPath:               hostsPath,
 		StaticDir:               config.StaticDir,
 		OCIRuntime:              config.OCIRuntime,
+		RuntimeClass:            config.RuntimeClass,
+		Monitor:                 c.inspectMonitor(),
 		ConmonPidFile:           config.ConmonPidFile,
 		PidFile:                 config.PidFile,
 		Name:                    config.Name,
@@ -192,6 +197,11 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 		data.OCIConfigPath = c.state.ConfigPath
 	}
 
+	if runtimeInfo.State == define.ContainerStatePaused && !runtimeInfo.FrozenUntil.IsZero() {
+		frozenUntil := runtimeInfo.FrozenUntil
+		data.State.FrozenUntil = &frozenUntil
+	}
+
 	// Check if healthcheck is not nil and --no-healthcheck option is not set.
 	// If --no-healthcheck is set Test will be always set to `[NONE]`, so the
 	// inspect status should be set to nil.
@@ -213,6 +223,12 @@ func (c *Container) getContainerInspectData(size bool, driverData *define.Driver
 		return nil, err
 	}
 	data.NetworkSettings = networkConfig
+	if c.config.TrackFlows && c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		data.NetworkFlows, err = c.runtime.networkFlows(networkConfig)
+		if err != nil {
+			logrus.Warnf("Listing the network flows of container %s: %v", c.ID(), err)
+		}
+	}
 	// Ports in NetworkSettings includes exposed ports for network modes that are not host,
 	// and not container.
 	if c.config.NetNsCtr == "" && c.NetworkMode() != "host" {
@@ -440,6 +456,7 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) *define.Insp
 	ctrConfig.CreateCommand = c.config.CreateCommand
 
 	ctrConfig.Timezone = c.config.Timezone
+	ctrConfig.Locale = c.config.Locale
 	for _, secret := range c.config.Secrets {
 		newSec := define.InspectSecret{}
 		newSec.Name = secret.Name
@@ -463,6 +480,7 @@ func (c *Container) generateInspectContainerConfig(spec *spec.Spec) *define.Insp
 
 	ctrConfig.SdNotifyMode = c.config.SdNotifyMode
 	ctrConfig.SdNotifySocket = c.config.SdNotifySocket
+	ctrConfig.StorageTransient = c.config.StorageTransient
 
 	// Exosed ports consists of all exposed ports and all port mappings for
 	// this container. It does *NOT* follow to another container if we share
@@ -529,6 +547,7 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 	hostConfig.GroupAdd = append(hostConfig.GroupAdd, c.config.Groups...)
 
 	hostConfig.HostsFile = c.config.BaseHostsFile
+	hostConfig.NUMAPolicy = c.config.NUMAPolicy
 
 	if ctrSpec.Process != nil {
 		if ctrSpec.Process.OOMScoreAdj != nil {
@@ -563,6 +582,11 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 		}
 		if ctrSpec.Annotations[define.InspectAnnotationInit] == define.InspectResponseTrue {
 			hostConfig.Init = true
+			for _, m := range ctrSpec.Mounts {
+				if m.Destination == define.ContainerInitPath {
+					hostConfig.InitPath = m.Source
+				}
+			}
 		}
 		if ctrSpec.Annotations[define.InspectAnnotationPublishAll] == define.InspectResponseTrue {
 			hostConfig.PublishAllPorts = true
@@ -609,6 +633,20 @@ func (c *Container) generateInspectContainerHostConfig(ctrSpec *spec.Spec, named
 	hostConfig.Binds = binds
 	hostConfig.Tmpfs = tmpfs
 
+	// The tmpfs mounted by --read-only-tmpfs are not user volumes.
+	if c.IsReadOnly() && c.config.ReadWriteTmpfs {
+		hostConfig.ReadOnlyTmpfs = make(map[string]string)
+		for _, mount := range ctrSpec.Mounts {
+			if mount.Type != define.TypeTmpfs || !slices.Contains([]string{"/run", "/tmp", "/var/run", "/var/tmp"}, mount.Destination) {
+				continue
+			}
+			if _, ok := tmpfs[mount.Destination]; ok {
+				continue
+			}
+			hostConfig.ReadOnlyTmpfs[mount.Destination] = strings.Join(mount.Options, ",")
+		}
+	}
+
 	// Network mode parsing.
 	networkMode := c.NetworkMode()
 	hostConfig.NetworkMode = networkMode
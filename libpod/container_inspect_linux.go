@@ -12,6 +12,7 @@ import (
 	"github.com/moby/sys/capability"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/cgroups"
 	"go.podman.io/common/pkg/config"
 	"go.podman.io/storage/types"
 )
@@ -129,6 +130,10 @@ func (c *Container) platformInspectContainerHostConfig(ctrSpec *spec.Spec, hostC
 					return err
 				}
 				hostConfig.BlkioDeviceWriteIOps = writeIops
+
+				if unified, _ := cgroups.IsCgroup2UnifiedMode(); unified {
+					hostConfig.IOMax = ioMaxDevices(readBps, writeBps, readIops, writeIops)
+				}
 			}
 		}
 	}
@@ -299,6 +304,22 @@ func (c *Container) platformInspectContainerHostConfig(ctrSpec *spec.Spec, hostC
 	if c.config.IDMappings.UIDMap != nil && c.config.IDMappings.GIDMap != nil {
 		hostConfig.IDMappings = generateIDMappings(c.config.IDMappings)
 	}
+	if c.config.IDMappings.AutoUserNs {
+		autoUserNs := &define.InspectAutoUserNs{
+			RequestedSize: c.config.IDMappings.AutoUserNsOpts.Size,
+		}
+		autoUserNs.User, _ = c.runtime.autoUserNsUser()
+		if autoUserNs.RequestedSize == 0 {
+			autoUserNs.MinSize = max(c.runtime.autoUserNsMinSize(), c.autoUserNsInitialSize())
+		}
+		for _, uid := range c.config.IDMappings.UIDMap {
+			autoUserNs.UIDs += uid.Size
+		}
+		for _, gid := range c.config.IDMappings.GIDMap {
+			autoUserNs.GIDs += gid.Size
+		}
+		hostConfig.AutoUserNs = autoUserNs
+	}
 	// Devices
 	// Do not include if privileged - assumed that all devices will be
 	// included.
@@ -344,3 +365,31 @@ func (c *Container) inHostPidNS() (bool, error) {
 	}
 	return true, nil
 }
+
+// ioMaxDevices combines the read and write limits of the devices into the
+// per device limits of the io.max cgroup file
+func ioMaxDevices(readBps, writeBps, readIops, writeIops []define.InspectBlkioThrottleDevice) []define.InspectIOMaxDevice {
+	var devices []define.InspectIOMaxDevice
+	device := func(path string) *define.InspectIOMaxDevice {
+		for i := range devices {
+			if devices[i].Path == path {
+				return &devices[i]
+			}
+		}
+		devices = append(devices, define.InspectIOMaxDevice{Path: path})
+		return &devices[len(devices)-1]
+	}
+	for _, dev := range readBps {
+		device(dev.Path).Rbps = dev.Rate
+	}
+	for _, dev := range writeBps {
+		device(dev.Path).Wbps = dev.Rate
+	}
+	for _, dev := range readIops {
+		device(dev.Path).Riops = dev.Rate
+	}
+	for _, dev := range writeIops {
+		device(dev.Path).Wiops = dev.Rate
+	}
+	return devices
+}
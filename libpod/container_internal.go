@@ -63,6 +63,8 @@ const (
 	artifactsDir      = "artifacts"
 	execDirPermission = 0755
 	preCheckpointDir  = "pre-checkpoint"
+	// rwSizeCacheFile holds the cached size of the writeable layer
+	rwSizeCacheFile = "rw-size"
 )
 
 // rootFsSize gets the size of the container, which can be divided notionally
@@ -91,7 +93,7 @@ func (c *Container) rootFsSize() (int64, error) {
 		}
 	}
 
-	layerSize, err := c.runtime.store.ContainerSize(c.ID())
+	layerSize, err := c.layerSize(container)
 
 	return size + layerSize, err
 }
@@ -104,18 +106,74 @@ func (c *Container) rwSize() (int64, error) {
 		return int64(size), err
 	}
 
-	layerSize, err := c.runtime.store.ContainerSize(c.ID())
+	container, err := c.runtime.store.Container(c.ID())
 	if err != nil {
 		return 0, err
 	}
+	return c.layerSize(container)
+}
 
-	return layerSize, nil
+// layerSize gets the size of the writeable layer of the container, its big
+// data and its directories, like the store's ContainerSize does.  Walking a
+// large layer is slow: the size is cached in the static directory of the
+// container while its layer is not mounted, since the layer cannot change
+// then.  The cache is dropped when the container is mounted or unmounted.
+// The store's read locks are held only, so that the sizes of several
+// containers can be computed in parallel.
+func (c *Container) layerSize(container *storage.Container) (int64, error) {
+	cachePath := filepath.Join(c.config.StaticDir, rwSizeCacheFile)
+	if data, err := os.ReadFile(cachePath); err == nil {
+		if size, err := strconv.ParseInt(string(data), 10, 64); err == nil && !c.layerMounted(container) {
+			return size, nil
+		}
+	}
+
+	size, err := c.runtime.store.DiffSize("", container.LayerID)
+	if err != nil {
+		return 0, fmt.Errorf("determining size of layer with ID %q: %w", container.LayerID, err)
+	}
+	for _, n := range container.BigDataSizes {
+		size += n
+	}
+	for _, dir := range []func(string) (string, error){c.runtime.store.ContainerDirectory, c.runtime.store.ContainerRunDirectory} {
+		path, err := dir(c.ID())
+		if err != nil {
+			return 0, err
+		}
+		n, err := util.SizeOfPath(path)
+		if err != nil {
+			return 0, err
+		}
+		size += int64(n)
+	}
+
+	if !c.layerMounted(container) {
+		if err := os.WriteFile(cachePath, []byte(strconv.FormatInt(size, 10)), 0o600); err != nil {
+			logrus.Debugf("Caching size of container %s: %v", c.ID(), err)
+		}
+	}
+	return size, nil
+}
+
+// layerMounted reports whether the writeable layer of the container is
+// mounted, by Podman or by another user of the store.
+func (c *Container) layerMounted(container *storage.Container) bool {
+	n, err := c.runtime.store.Mounted(container.LayerID)
+	return err != nil || n > 0
+}
+
+// dropSizeCache removes the cached size of the writeable layer of the
+// container with the given static directory.
+func dropSizeCache(dir string) {
+	if err := os.Remove(filepath.Join(dir, rwSizeCacheFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		logrus.Debugf("Removing cached container size: %v", err)
+	}
 }
 
 // bundlePath returns the path to the container's root filesystem - where the OCI spec will be
 // placed, amongst other things
 func (c *Container) bundlePath() string {
-	if c.runtime.storageConfig.TransientStore {
+	if c.runtime.storageConfig.TransientStore || c.config.StorageTransient {
 		return c.state.RunDir
 	}
 	return c.config.StaticDir
@@ -214,8 +272,10 @@ func (c *Container) handleExitFile(exitFile string, fi os.FileInfo) error {
 	if err != nil {
 		return err
 	}
-	if err = fileutils.Exists(oomFilePath); err == nil {
+	if oomInfo, err := os.Stat(oomFilePath); err == nil {
 		c.state.OOMKilled = true
+		c.state.OOMReport = c.oomReport(oomInfo.ModTime())
+		c.newContainerOOMEvent()
 	}
 
 	c.state.Exited = true
@@ -406,17 +466,7 @@ func (c *Container) setupStorageMapping(dest, from *storage.IDMappingOptions) {
 		overrides := c.getUserOverrides()
 		dest.AutoUserNsOpts.PasswdFile = overrides.ContainerEtcPasswdPath
 		dest.AutoUserNsOpts.GroupFile = overrides.ContainerEtcGroupPath
-		if c.config.User != "" {
-			initialSize := uint32(0)
-			parts := strings.SplitSeq(c.config.User, ":")
-			for p := range parts {
-				s, err := strconv.ParseUint(p, 10, 32)
-				if err == nil && uint32(s) > initialSize {
-					initialSize = uint32(s)
-				}
-			}
-			dest.AutoUserNsOpts.InitialSize = initialSize + 1
-		}
+		dest.AutoUserNsOpts.InitialSize = c.autoUserNsInitialSize()
 	} else if c.config.Spec.Linux != nil {
 		dest.UIDMap = nil
 		for _, r := range c.config.Spec.Linux.UIDMappings {
@@ -441,20 +491,27 @@ func (c *Container) setupStorageMapping(dest, from *storage.IDMappingOptions) {
 	}
 }
 
-// Create container root filesystem for use
-func (c *Container) setupStorage(ctx context.Context) error {
-	if !c.valid {
-		return fmt.Errorf("container %s is not valid: %w", c.ID(), define.ErrCtrRemoved)
+// autoUserNsInitialSize returns the minimum size of the user namespace
+// allocated with --userns=auto for the UID and GID the container runs as to
+// be mapped, or 0 if it runs as the user of the image.
+func (c *Container) autoUserNsInitialSize() uint32 {
+	if c.config.User == "" {
+		return 0
 	}
-
-	if c.state.State != define.ContainerStateConfigured {
-		return fmt.Errorf("container %s must be in Configured state to have storage set up: %w", c.ID(), define.ErrCtrStateInvalid)
+	initialSize := uint32(0)
+	parts := strings.SplitSeq(c.config.User, ":")
+	for p := range parts {
+		s, err := strconv.ParseUint(p, 10, 32)
+		if err == nil && uint32(s) > initialSize {
+			initialSize = uint32(s)
+		}
 	}
+	return initialSize + 1
+}
 
-	// Need both an image ID and image name, plus a bool telling us whether to use the image configuration
-	if c.config.Rootfs == "" && (c.config.RootfsImageID == "" || c.config.RootfsImageName == "") {
-		return fmt.Errorf("must provide image ID and image name to use an image: %w", define.ErrInvalidArg)
-	}
+// storageContainerOptions returns the options used to create the storage
+// container holding the root filesystem of the container
+func (c *Container) storageContainerOptions() (storage.ContainerOptions, error) {
 	options := storage.ContainerOptions{
 		IDMappingOptions: storage.IDMappingOptions{
 			HostUIDMapping: true,
@@ -484,7 +541,7 @@ func (c *Container) setupStorage(ctx context.Context) error {
 
 		defOptions, err := storage.GetMountOptions(c.runtime.store.GraphDriverName(), c.runtime.store.GraphOptions())
 		if err != nil {
-			return fmt.Errorf("getting default mount options: %w", err)
+			return options, fmt.Errorf("getting default mount options: %w", err)
 		}
 		var newOptions []string
 		for _, opt := range defOptions {
@@ -499,6 +556,28 @@ func (c *Container) setupStorage(ctx context.Context) error {
 
 	c.setupStorageMapping(&options.IDMappingOptions, &c.config.IDMappings)
 
+	return options, nil
+}
+
+// Create container root filesystem for use
+func (c *Container) setupStorage(ctx context.Context) error {
+	if !c.valid {
+		return fmt.Errorf("container %s is not valid: %w", c.ID(), define.ErrCtrRemoved)
+	}
+
+	if c.state.State != define.ContainerStateConfigured {
+		return fmt.Errorf("container %s must be in Configured state to have storage set up: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+
+	// Need both an image ID and image name, plus a bool telling us whether to use the image configuration
+	if c.config.Rootfs == "" && (c.config.RootfsImageID == "" || c.config.RootfsImageName == "") {
+		return fmt.Errorf("must provide image ID and image name to use an image: %w", define.ErrInvalidArg)
+	}
+	options, err := c.storageContainerOptions()
+	if err != nil {
+		return err
+	}
+
 	// Unless the user has specified a name, use a randomly generated one.
 	// Note that name conflicts may occur (see #11735), so we need to loop.
 	generateName := c.config.Name == ""
@@ -524,6 +603,9 @@ func (c *Container) setupStorage(ctx context.Context) error {
 				return fmt.Errorf("creating container storage: %w by an external entity", containerInfoErr)
 			}
 		}
+		if options.AutoUserNs {
+			containerInfoErr = c.runtime.explainAutoUserNsError(containerInfoErr, &options.AutoUserNsOpts)
+		}
 		return fmt.Errorf("creating container storage: %w", containerInfoErr)
 	}
 
@@ -709,7 +791,7 @@ func (c *Container) refresh() error {
 	// If a rewrite must happen the config.rewrite field is set to true.
 	if c.config.rewrite {
 		// SafeRewriteContainerConfig must be used with care. Make sure to not change config fields by accident.
-		if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config); err != nil {
+		if err := c.safeRewriteConfig("", ""); err != nil {
 			return fmt.Errorf("failed to rewrite the config for container %s: %w", c.config.ID, err)
 		}
 		c.config.rewrite = false
@@ -1161,6 +1243,7 @@ func (c *Container) cleanupRuntime(ctx context.Context) error {
 	// If we were Stopped, we are now Exited, as we've removed ourself
 	// from the runtime.
 	// If we were Created, we are now Configured.
+	wasStopped := c.state.State == define.ContainerStateStopped
 	switch c.state.State {
 	case define.ContainerStateStopped:
 		c.state.State = define.ContainerStateExited
@@ -1174,6 +1257,14 @@ func (c *Container) cleanupRuntime(ctx context.Context) error {
 		}
 	}
 
+	// The container ran, run its post-stop hook.  A failing hook does not
+	// fail the cleanup.
+	if wasStopped {
+		if err := c.runLifecycleHook(lifecycleHookPostStop); err != nil {
+			logrus.Error(err)
+		}
+	}
+
 	logrus.Debugf("Successfully cleaned up container %s", c.ID())
 
 	return nil
@@ -1289,12 +1380,20 @@ func (c *Container) start() error {
 		logrus.Debugf("Starting container %s with command %v", c.ID(), c.config.Spec.Process.Args)
 	}
 
+	if err := c.runLifecycleHook(lifecycleHookPreStart); err != nil {
+		return err
+	}
+
 	if err := c.ociRuntime.StartContainer(c); err != nil {
 		return err
 	}
 	logrus.Debugf("Started container %s", c.ID())
 
 	c.state.State = define.ContainerStateRunning
+	c.state.CgroupPath = ""
+	if cgroupPath, err := c.cGroupPath(); err == nil {
+		c.state.CgroupPath = cgroupPath
+	}
 
 	// Unless being ignored, set the MAINPID to conmon.
 	if c.config.SdNotifyMode != define.SdNotifyModeIgnore {
@@ -1323,6 +1422,10 @@ func (c *Container) start() error {
 		}
 	}
 
+	if err := c.createStatsHistoryTimer(); err != nil {
+		return fmt.Errorf("start stats history: %w", err)
+	}
+
 	c.newContainerEvent(events.Start)
 
 	return c.save()
@@ -1600,6 +1703,7 @@ func (c *Container) unpause() error {
 		// TODO when using docker-py there is some sort of race/incompatibility here
 		return err
 	}
+	c.clearFreezeTimeout()
 
 	isStartupHealthCheck := c.config.StartupHealthCheckConfig != nil && !c.state.StartupHCPassed
 	isHealthCheckEnabled := c.config.HealthCheckConfig != nil &&
@@ -1880,7 +1984,12 @@ func (c *Container) mountStorage() (_ string, deferredErr error) {
 	}
 
 	tz := c.Timezone()
-	localTimePath, err := timezone.ConfigureContainerTimeZone(tz, c.state.RunDir, mountPoint, etcInTheContainerPath, c.ID())
+	var localTimePath string
+	if tz == define.TimezoneHost {
+		err = c.configureHostTimezone(etcInTheContainerFd)
+	} else {
+		localTimePath, err = timezone.ConfigureContainerTimeZone(tz, c.state.RunDir, mountPoint, etcInTheContainerPath, c.ID())
+	}
 	if err != nil {
 		return "", fmt.Errorf("configuring timezone for container %s: %w", c.ID(), err)
 	}
@@ -1894,6 +2003,10 @@ func (c *Container) mountStorage() (_ string, deferredErr error) {
 		c.state.BindMounts["/etc/localtime"] = localTimePath
 	}
 
+	if err := c.configureLocale(mountPoint); err != nil {
+		return "", fmt.Errorf("configuring locale for container %s: %w", c.ID(), err)
+	}
+
 	// Request a mount of all named volumes
 	for _, v := range c.config.NamedVolumes {
 		vol, err := c.mountNamedVolume(v, mountPoint)
@@ -2241,6 +2354,11 @@ func (c *Container) cleanup(ctx context.Context) error {
 		}
 	}
 
+	if err := c.removeStatsHistoryTimer(ctx); err != nil {
+		logrus.Errorf("Removing timer for container %s stats history: %v", c.ID(), err)
+	}
+	c.clearFreezeTimeout()
+
 	// Clean up network namespace, if present
 	if err := c.cleanupNetwork(); err != nil {
 		lastError = fmt.Errorf("removing container %s network: %w", c.ID(), err)
@@ -2455,6 +2573,21 @@ func (c *Container) postDeleteHooks(ctx context.Context) error {
 	return nil
 }
 
+// socketActivationFDs returns the number of preserved file descriptors the
+// container gets as sockets of systemd socket activation, starting at 3.
+func (c *Container) socketActivationFDs() uint {
+	if !c.config.SocketActivation {
+		return 0
+	}
+	fds := c.config.PreserveFDs
+	for _, fd := range c.config.PreserveFD {
+		if fd > 2 {
+			fds = max(fds, fd-2)
+		}
+	}
+	return fds
+}
+
 // writeStringToRundir writes the given string to a file with the given name in
 // the container's temporary files directory. The file will be chown'd to the
 // container's root user and have an appropriate SELinux label set.
@@ -2532,7 +2665,7 @@ func (c *Container) setupOCIHooks(ctx context.Context, config *spec.Spec) (map[s
 			return nil, nil
 		}
 		for _, hDir := range []string{hooks.DefaultDir, hooks.OverrideDir} {
-			manager, err := hooks.New(ctx, []string{hDir}, []string{"precreate", "poststop"})
+			manager, err := c.runtime.newHooksManager(ctx, []string{hDir})
 			if err != nil {
 				if os.IsNotExist(err) {
 					continue
@@ -2549,7 +2682,7 @@ func (c *Container) setupOCIHooks(ctx context.Context, config *spec.Spec) (map[s
 			maps.Copy(allHooks, ociHooks)
 		}
 	} else {
-		manager, err := hooks.New(ctx, c.runtime.config.Engine.HooksDir.Get(), []string{"precreate", "poststop"})
+		manager, err := c.runtime.newHooksManager(ctx, c.runtime.config.Engine.HooksDir.Get())
 		if err != nil {
 			return nil, err
 		}
@@ -2655,6 +2788,7 @@ func (c *Container) mount() (string, error) {
 		return "", fmt.Errorf("cannot mount container %s as it is being removed: %w", c.ID(), define.ErrCtrStateInvalid)
 	}
 
+	dropSizeCache(c.config.StaticDir)
 	mountPoint, err := c.runtime.storageService.MountContainerImage(c.ID())
 	if err != nil {
 		return "", fmt.Errorf("mounting storage for container %s: %w", c.ID(), err)
@@ -2675,6 +2809,7 @@ func (c *Container) unmount(force bool) error {
 	if _, err := c.runtime.storageService.UnmountContainerImage(c.ID(), force); err != nil {
 		return fmt.Errorf("unmounting container %s root filesystem: %w", c.ID(), err)
 	}
+	dropSizeCache(c.config.StaticDir)
 
 	return nil
 }
@@ -2860,12 +2995,20 @@ func (c *Container) extractSecretToCtrStorage(secr *ContainerSecret) error {
 // Update a container's resources or restart policy after creation.
 // At least one of resources or restartPolicy must not be nil.
 func (c *Container) update(updateOptions *entities.ContainerUpdateOptions) error {
-	if updateOptions.Resources == nil && updateOptions.RestartPolicy == nil {
-		return fmt.Errorf("must provide at least one of resources and restartPolicy to update a container: %w", define.ErrInvalidArg)
+	if updateOptions.Resources == nil && updateOptions.RestartPolicy == nil && len(updateOptions.NetworkOptions) == 0 {
+		return fmt.Errorf("must provide at least one of resources, restartPolicy and network options to update a container: %w", define.ErrInvalidArg)
 	}
 	if updateOptions.RestartRetries != nil && updateOptions.RestartPolicy == nil {
 		return fmt.Errorf("must provide restart policy if updating restart retries: %w", define.ErrInvalidArg)
 	}
+	if updateOptions.MemoryReclaim != nil {
+		if *updateOptions.MemoryReclaim <= 0 {
+			return fmt.Errorf("memory to reclaim must be positive: %w", define.ErrInvalidArg)
+		}
+		if !c.ensureState(define.ContainerStateRunning) {
+			return fmt.Errorf("can only reclaim memory of running containers: %w", define.ErrCtrStateInvalid)
+		}
+	}
 
 	oldResources := new(spec.LinuxResources)
 	if c.config.Spec.Linux.Resources != nil {
@@ -2875,6 +3018,10 @@ func (c *Container) update(updateOptions *entities.ContainerUpdateOptions) error
 	}
 	oldRestart := c.config.RestartPolicy
 	oldRetries := c.config.RestartRetries
+	var oldDevices []spec.LinuxDevice
+	if c.config.Spec.Linux != nil {
+		oldDevices = slices.Clone(c.config.Spec.Linux.Devices)
+	}
 
 	if updateOptions.RestartPolicy != nil {
 		if err := define.ValidateRestartPolicy(*updateOptions.RestartPolicy); err != nil {
@@ -2910,6 +3057,21 @@ func (c *Container) update(updateOptions *entities.ContainerUpdateOptions) error
 		updateOptions.Resources = c.config.Spec.Linux.Resources
 	}
 
+	var addedDevices, removedDevices []spec.LinuxDevice
+	if updateOptions.Devices != nil && (len(updateOptions.Devices.AddDevices) != 0 || len(updateOptions.Devices.RemoveDevices) != 0 || len(updateOptions.Devices.AddDeviceCgroupRules) != 0) {
+		var err error
+		addedDevices, removedDevices, err = c.updateDevices(updateOptions.Devices)
+		if err != nil {
+			if c.config.Spec.Linux != nil {
+				c.config.Spec.Linux.Resources = oldResources
+				c.config.Spec.Linux.Devices = oldDevices
+			}
+			return err
+		}
+		// the device cgroup is updated together with the other resources
+		updateOptions.Resources = c.config.Spec.Linux.Resources
+	}
+
 	if len(updateOptions.Env) != 0 {
 		c.config.Spec.Process.Env = envLib.Slice(envLib.Join(envLib.Map(c.config.Spec.Process.Env), envLib.Map(updateOptions.Env)))
 	}
@@ -2922,9 +3084,10 @@ func (c *Container) update(updateOptions *entities.ContainerUpdateOptions) error
 		c.config.Spec.Process.Env = envLib.Slice(envMap)
 	}
 
-	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config); err != nil {
+	if err := c.safeRewriteConfig("", ""); err != nil {
 		// Assume DB write failed, revert to old resources block
 		c.config.Spec.Linux.Resources = oldResources
+		c.config.Spec.Linux.Devices = oldDevices
 		c.config.RestartPolicy = oldRestart
 		c.config.RestartRetries = oldRetries
 		return err
@@ -2944,6 +3107,9 @@ func (c *Container) update(updateOptions *entities.ContainerUpdateOptions) error
 			}
 			onDiskSpec.Linux.Resources = updateOptions.Resources
 		}
+		if len(addedDevices) != 0 || len(removedDevices) != 0 {
+			onDiskSpec.Linux.Devices = c.config.Spec.Linux.Devices
+		}
 		if len(updateOptions.Env) != 0 || len(updateOptions.UnsetEnv) != 0 {
 			onDiskSpec.Process.Env = c.config.Spec.Process.Env
 		}
@@ -2954,6 +3120,24 @@ func (c *Container) update(updateOptions *entities.ContainerUpdateOptions) error
 		if err := c.ociRuntime.UpdateContainer(c, updateOptions.Resources); err != nil {
 			return err
 		}
+
+		if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+			if err := c.updateDeviceNodes(addedDevices, removedDevices); err != nil {
+				return err
+			}
+		}
+	}
+
+	for network, options := range updateOptions.NetworkOptions {
+		if err := c.updateBandwidth(network, options); err != nil {
+			return err
+		}
+	}
+
+	if updateOptions.MemoryReclaim != nil {
+		if err := c.reclaimMemory(*updateOptions.MemoryReclaim); err != nil {
+			return err
+		}
 	}
 
 	logrus.Debugf("updated container %s", c.ID())
@@ -3013,7 +3197,7 @@ func (c *Container) updateHealthCheck(newHealthCheckConfig IHealthCheckConfig, c
 
 	newHealthCheckConfig.SetTo(c.config)
 
-	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config); err != nil {
+	if err := c.safeRewriteConfig("", ""); err != nil {
 		// Assume DB write failed, revert to old resources block
 		oldHealthCheckConfig.SetTo(c.config)
 		return err
@@ -3069,7 +3253,7 @@ func (c *Container) updateGlobalHealthCheckConfiguration(globalOptions define.Gl
 		c.config.HealthLogDestination = &dest
 	}
 
-	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config); err != nil {
+	if err := c.safeRewriteConfig("", ""); err != nil {
 		// Assume DB write failed, revert to old resources block
 		c.config.HealthCheckOnFailureAction = oldHealthCheckOnFailureAction
 		c.config.HealthLogDestination = oldHealthLogDestination
@@ -297,6 +297,13 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 	}
 
 	// Add named volumes
+	var volumeRules []*VolumeOptionsRule
+	if len(c.config.NamedVolumes) > 0 {
+		volumeRules, err = volumeOptionsRules()
+		if err != nil {
+			return nil, nil, err
+		}
+	}
 	for _, namedVol := range c.config.NamedVolumes {
 		volume, err := c.runtime.GetVolume(namedVol.Name)
 		if err != nil {
@@ -367,7 +374,7 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 				Type:        define.TypeBind,
 				Source:      mountPoint,
 				Destination: namedVol.Dest,
-				Options:     namedVol.Options,
+				Options:     applyVolumeOptionsRules(volumeRules, volume.Name(), volume.Driver(), namedVol.Options),
 			}
 			g.AddMount(volMount)
 		}
@@ -397,6 +404,15 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 				if err != nil {
 					return nil, nil, err
 				}
+				// Rootless users cannot idmap most host file systems,
+				// change the ownership of the files instead.
+				if rootless.IsRootless() && (len(m.UIDMappings) > 0 || len(m.GIDMappings) > 0) &&
+					!idmappedMountSupported(m.Source, m.UIDMappings, m.GIDMappings) {
+					if err := c.chownIDMappedMount(m.Source, m.UIDMappings, m.GIDMappings); err != nil {
+						return nil, nil, err
+					}
+					m.UIDMappings, m.GIDMappings = nil, nil
+				}
 				continue
 			}
 			switch o {
@@ -461,6 +477,40 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		}
 	}
 
+	// The timezone database of the host, /etc/localtime links into it
+	if c.config.Timezone == define.TimezoneHost {
+		zoneinfo := hostZoneinfoDir()
+		source, err := filepath.EvalSymlinks(zoneinfo)
+		if err != nil {
+			return nil, nil, fmt.Errorf("finding the timezone database of the host: %w", err)
+		}
+		if !MountExists(g.Mounts(), zoneinfo) {
+			g.AddMount(spec.Mount{
+				Type:        define.TypeBind,
+				Source:      source,
+				Destination: zoneinfo,
+				Options:     append(bindOptions, "ro", "nosuid", "noexec", "nodev"),
+			})
+		}
+	}
+
+	// The init of the container may report its statistics there, it is
+	// writable even in read-only containers
+	if c.hasInit() {
+		statsPath, err := c.writeStringToRundir(initStatsFile, "")
+		if err != nil {
+			return nil, nil, err
+		}
+		if !MountExists(g.Mounts(), define.ContainerInitStatsPath) {
+			g.AddMount(spec.Mount{
+				Type:        define.TypeBind,
+				Source:      statsPath,
+				Destination: define.ContainerInitStatsPath,
+				Options:     append(bindOptions, "nosuid", "noexec", "nodev"),
+			})
+		}
+	}
+
 	// Add overlay volumes
 	for _, overlayVol := range c.config.OverlayVolumes {
 		upperDir, workDir, err := getOverlayUpperAndWorkDir(overlayVol.Options)
@@ -502,7 +552,7 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 	// Add image volumes as overlay mounts
 	for _, volume := range c.config.ImageVolumes {
 		// Mount the specified image.
-		img, _, err := c.runtime.LibimageRuntime().LookupImage(volume.Source, nil)
+		img, err := c.runtime.lookupImage(ctx, volume.Source)
 		if err != nil {
 			return nil, nil, fmt.Errorf("creating image volume %q:%q: %w", volume.Source, volume.Dest, err)
 		}
@@ -763,9 +813,23 @@ func (c *Container) generateSpec(ctx context.Context) (s *spec.Spec, cleanupFunc
 		}
 	}
 
-	// Pass down the LISTEN_* environment (see #10443).
+	// Pass down the LISTEN_* environment (see #10443).  With socket
+	// activation, the preserved file descriptors are the sockets.
+	listenEnv := make(map[string]string)
 	for _, key := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
 		if val, ok := os.LookupEnv(key); ok {
+			listenEnv[key] = val
+		}
+	}
+	if fds := c.socketActivationFDs(); fds > 0 {
+		listenEnv["LISTEN_PID"] = "1"
+		listenEnv["LISTEN_FDS"] = strconv.FormatUint(uint64(fds), 10)
+		if names, ok := listenEnv["LISTEN_FDNAMES"]; ok && uint(len(strings.Split(names, ":"))) != fds {
+			delete(listenEnv, "LISTEN_FDNAMES")
+		}
+	}
+	for _, key := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
+		if val, ok := listenEnv[key]; ok {
 			// Force the PID to `1` since we cannot rely on (all
 			// versions of) all runtimes to do it for us.
 			if key == "LISTEN_PID" {
@@ -2418,6 +2482,14 @@ func (c *Container) addHosts() error {
 	if err != nil {
 		return fmt.Errorf("failed to get container ip host entries: %w", err)
 	}
+	if c.config.NetMode.IsBridge() {
+		// the containers on the WireGuard networks on the other hosts
+		wireGuardEntries, err := c.getWireGuardHostsEntries()
+		if err != nil {
+			return fmt.Errorf("failed to get WireGuard peer host entries: %w", err)
+		}
+		containerIPsEntries = append(containerIPsEntries, wireGuardEntries...)
+	}
 
 	// Consider container level BaseHostsFile configuration first.
 	// If it is empty, fallback to containers.conf level configuration.
@@ -4,6 +4,7 @@ package libpod
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,8 +13,9 @@ import (
 	"syscall"
 	"time"
 
-	"github.com/dmikushin/podman-shared/pkg/rootless"
 	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/rootless"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/sirupsen/logrus"
@@ -25,6 +27,15 @@ var (
 	bindOptions = []string{}
 )
 
+// isPathOnSharedStorage checks if the given path is on an NFS mount
+func isPathOnSharedStorage(path string) (bool, error) {
+	var stat unix.Statfs_t
+	if err := unix.Statfs(path, &stat); err != nil {
+		return false, fmt.Errorf("failed to get filesystem info for %s: %w", path, err)
+	}
+	return unix.ByteSliceToString(stat.Fstypename[:]) == "nfs", nil
+}
+
 func (c *Container) mountSHM(_ string) error {
 	return nil
 }
@@ -435,3 +446,15 @@ func containerPathIsFile(unsafeRoot string, containerPath string) (bool, error)
 	}
 	return false, err
 }
+
+func (c *Container) updateDevices(_ *define.UpdateContainerDevices) (added, removed []spec.LinuxDevice, _ error) {
+	return nil, nil, fmt.Errorf("updating devices: %w", define.ErrNotImplemented)
+}
+
+func (c *Container) updateDeviceNodes(_, _ []spec.LinuxDevice) error {
+	return nil
+}
+
+func (c *Container) reclaimMemory(_ int64) error {
+	return errors.New("reclaiming memory is not supported on FreeBSD")
+}
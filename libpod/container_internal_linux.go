@@ -10,15 +10,19 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
 	"syscall"
 	"time"
 
+	securejoin "github.com/cyphar/filepath-securejoin"
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/libpod/shutdown"
 	"github.com/dmikushin/podman-shared/pkg/rootless"
-	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/moby/sys/capability"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
@@ -29,6 +33,7 @@ import (
 	"go.podman.io/common/pkg/cgroups"
 	"go.podman.io/common/pkg/config"
 	graphdriver "go.podman.io/storage/drivers"
+	"go.podman.io/storage/pkg/fileutils"
 	"go.podman.io/storage/pkg/idtools"
 	"golang.org/x/sys/unix"
 )
@@ -37,17 +42,48 @@ var (
 	bindOptions = []string{define.TypeBind, "rprivate"}
 )
 
-// isPathOnNFS checks if the given path is on an NFS mount
-func isPathOnNFS(path string) (bool, error) {
+// isPathOnSharedStorage checks if the given path is on an NFS mount, or on
+// a directory of the host shared with a VM through virtiofs or 9p, as done
+// by podman machine
+func isPathOnSharedStorage(path string) (bool, error) {
 	// Get the mount info for the path
 	var stat syscall.Statfs_t
 	if err := syscall.Statfs(path, &stat); err != nil {
 		return false, fmt.Errorf("failed to get filesystem info for %s: %w", path, err)
 	}
 
-	// NFS magic number is 0x6969
-	const nfsMagic = 0x6969
-	return stat.Type == nfsMagic, nil
+	switch stat.Type {
+	case unix.NFS_SUPER_MAGIC, unix.V9FS_MAGIC:
+		return true, nil
+	case unix.FUSE_SUPER_MAGIC:
+		// virtiofs reports the magic number of FUSE
+		return isVirtiofs(path)
+	}
+	return false, nil
+}
+
+// isVirtiofs checks if the given path is on a virtiofs mount
+func isVirtiofs(path string) (bool, error) {
+	mounts, err := os.ReadFile("/proc/self/mounts")
+	if err != nil {
+		return false, fmt.Errorf("failed to read mounts: %w", err)
+	}
+	resolvedPath, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return false, err
+	}
+	// The mount point of the path is the longest one containing it
+	fsType, mountPoint := "", ""
+	for _, line := range strings.Split(string(mounts), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		if (resolvedPath == fields[1] || fields[1] == "/" || strings.HasPrefix(resolvedPath, fields[1]+"/")) && len(fields[1]) >= len(mountPoint) {
+			fsType, mountPoint = fields[2], fields[1]
+		}
+	}
+	return fsType == "virtiofs", nil
 }
 
 // isImageStorageOnSharedStorage checks if container image storage is on NFS or other shared storage
@@ -56,21 +92,52 @@ func (c *Container) isImageStorageOnSharedStorage() (bool, error) {
 		return false, nil
 	}
 
-	// Get the image store's root directory from runtime config
-	graphRoot := c.runtime.storageConfig.GraphRoot
-	if graphRoot == "" {
+	// Get the image store's root directory from the storage
+	imageRoot := c.runtime.imageStorageRoot()
+	if imageRoot == "" {
 		return false, nil
 	}
 
-	// Check if the storage root is on NFS
-	isNFS, err := isPathOnNFS(graphRoot)
+	// Check if the storage root is on shared storage
+	isShared, err := isPathOnSharedStorage(imageRoot)
 	if err != nil {
-		logrus.Debugf("Failed to check if image storage is on NFS: %v", err)
+		logrus.Debugf("Failed to check if image storage is on shared storage: %v", err)
 		return false, nil // Don't fail container creation for this
 	}
 
-	logrus.Debugf("Image storage at %s is on NFS: %v", graphRoot, isNFS)
-	return isNFS, nil
+	logrus.Debugf("Image storage at %s is on shared storage: %v", imageRoot, isShared)
+	if !isShared {
+		// The image may have been pulled with --to-shared into an
+		// additional image store on shared storage
+		isShared = c.isImageInSharedImageStore()
+	}
+	return isShared, nil
+}
+
+// isImageInSharedImageStore checks if the image of the container is in an
+// additional image store on shared storage
+func (c *Container) isImageInSharedImageStore() bool {
+	img, err := c.runtime.store.Image(c.config.RootfsImageID)
+	if err != nil || !img.ReadOnly {
+		return false
+	}
+	driver, err := c.runtime.store.GraphDriver()
+	if err != nil {
+		return false
+	}
+	for _, store := range driver.AdditionalImageStores() {
+		if err := fileutils.Exists(filepath.Join(store, driver.String()+"-images", img.ID)); err != nil {
+			continue
+		}
+		isShared, err := isPathOnSharedStorage(store)
+		if err != nil {
+			logrus.Debugf("Failed to check if image store %s is on shared storage: %v", store, err)
+			return false
+		}
+		logrus.Debugf("Image %s is in image store %s, on shared storage: %v", img.ID, store, isShared)
+		return isShared
+	}
+	return false
 }
 
 // getBaseImageID determines the base image ID for shared base layers
@@ -153,6 +220,14 @@ func (c *Container) mountSharedBaseLayers() (string, error) {
 	if err != nil {
 		return "", fmt.Errorf("failed to get image layer path: %w", err)
 	}
+	if c.runtime.sharedLayerCache != nil {
+		cachedPath, err := c.runtime.sharedLayerCache.lookup(img.TopLayer, sharedLayerPath)
+		if err != nil {
+			logrus.Warnf("Failed to use the shared layer cache for container %s: %v", c.ID(), err)
+		} else {
+			sharedLayerPath = cachedPath
+		}
+	}
 
 	logrus.Debugf("Using shared base layers from: %s", sharedLayerPath)
 
@@ -1287,3 +1362,181 @@ func containerPathIsFile(unsafeRoot string, containerPath string) (bool, error)
 	}
 	return false, err
 }
+
+// updateDevices adds devices to and removes devices from the configuration
+// of the container, together with the device cgroup rules allowing access to
+// them. The devices added and removed are returned.
+func (c *Container) updateDevices(updates *define.UpdateContainerDevices) (added, removed []spec.LinuxDevice, _ error) {
+	if rootless.IsRootless() && c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return nil, nil, fmt.Errorf("cannot update devices of running container %s as rootless: %w", c.ID(), define.ErrCtrStateInvalid)
+	}
+
+	if c.config.Spec.Linux == nil {
+		c.config.Spec.Linux = new(spec.Linux)
+	}
+	if c.config.Spec.Linux.Resources == nil {
+		c.config.Spec.Linux.Resources = new(spec.LinuxResources)
+	}
+	linux := c.config.Spec.Linux
+
+	for _, path := range updates.RemoveDevices {
+		i := slices.IndexFunc(linux.Devices, func(dev spec.LinuxDevice) bool {
+			return dev.Path == path
+		})
+		if i < 0 {
+			return nil, nil, fmt.Errorf("container %s has no device %s: %w", c.ID(), path, define.ErrInvalidArg)
+		}
+		dev := linux.Devices[i]
+		linux.Devices = slices.Delete(linux.Devices, i, i+1)
+		linux.Resources.Devices = setDeviceCgroupRule(linux.Resources.Devices, dev, false, "rwm")
+		removed = append(removed, dev)
+	}
+
+	for _, device := range updates.AddDevices {
+		src, dst, permissions, err := util.ParseDevice(device)
+		if err != nil {
+			return nil, nil, err
+		}
+		dev, err := util.DeviceFromPath(src)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s is not a valid device: %w", src, err)
+		}
+		if slices.ContainsFunc(linux.Devices, func(d spec.LinuxDevice) bool { return d.Path == dst }) {
+			return nil, nil, fmt.Errorf("container %s already has a device %s: %w", c.ID(), dst, define.ErrInvalidArg)
+		}
+		dev.Path = dst
+		linux.Devices = append(linux.Devices, *dev)
+		linux.Resources.Devices = setDeviceCgroupRule(linux.Resources.Devices, *dev, true, permissions)
+		added = append(added, *dev)
+	}
+
+	if len(updates.AddDeviceCgroupRules) != 0 && rootless.IsRootless() {
+		return nil, nil, fmt.Errorf("device cgroup rules are not supported in rootless mode: %w", define.ErrInvalidArg)
+	}
+	for _, rule := range updates.AddDeviceCgroupRules {
+		var rules []spec.LinuxDeviceCgroup
+		var err error
+		if util.IsDeviceCgroupPathRule(rule) {
+			rules, err = util.DeviceCgroupRulesFromPaths(rule)
+		} else {
+			rules, err = util.ParseDeviceCgroupRule(rule)
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, r := range rules {
+			if !slices.ContainsFunc(linux.Resources.Devices, func(existing spec.LinuxDeviceCgroup) bool {
+				return reflect.DeepEqual(existing, r)
+			}) {
+				linux.Resources.Devices = append(linux.Resources.Devices, r)
+			}
+		}
+	}
+
+	return added, removed, nil
+}
+
+// setDeviceCgroupRule replaces the cgroup rules for the given device with a
+// single rule allowing or denying the given access
+func setDeviceCgroupRule(rules []spec.LinuxDeviceCgroup, dev spec.LinuxDevice, allow bool, access string) []spec.LinuxDeviceCgroup {
+	rules = slices.DeleteFunc(rules, func(rule spec.LinuxDeviceCgroup) bool {
+		return rule.Type == dev.Type && rule.Major != nil && *rule.Major == dev.Major && rule.Minor != nil && *rule.Minor == dev.Minor
+	})
+	major, minor := dev.Major, dev.Minor
+	return append(rules, spec.LinuxDeviceCgroup{
+		Allow:  allow,
+		Type:   dev.Type,
+		Major:  &major,
+		Minor:  &minor,
+		Access: access,
+	})
+}
+
+// updateDeviceNodes creates the device nodes of added devices in, and
+// removes the device nodes of removed devices from, the root filesystem of
+// the running container
+func (c *Container) updateDeviceNodes(added, removed []spec.LinuxDevice) error {
+	if len(added) == 0 && len(removed) == 0 {
+		return nil
+	}
+	root := fmt.Sprintf("/proc/%d/root", c.state.PID)
+
+	for _, dev := range removed {
+		path, err := securejoin.SecureJoin(root, dev.Path)
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("removing device %s from container %s: %w", dev.Path, c.ID(), err)
+		}
+	}
+
+	mappings := idtools.NewIDMappingsFromMaps(c.config.IDMappings.UIDMap, c.config.IDMappings.GIDMap)
+	for _, dev := range added {
+		path, err := securejoin.SecureJoin(root, dev.Path)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+
+		var mode uint32
+		switch dev.Type {
+		case "b":
+			mode = unix.S_IFBLK
+		case "c":
+			mode = unix.S_IFCHR
+		case "p":
+			mode = unix.S_IFIFO
+		}
+		if dev.FileMode != nil {
+			mode |= uint32(dev.FileMode.Perm())
+		}
+		if err := unix.Mknod(path, mode, int(unix.Mkdev(uint32(dev.Major), uint32(dev.Minor)))); err != nil {
+			return fmt.Errorf("creating device %s in container %s: %w", dev.Path, c.ID(), err)
+		}
+
+		var owner idtools.IDPair
+		if dev.UID != nil {
+			owner.UID = int(*dev.UID)
+		}
+		if dev.GID != nil {
+			owner.GID = int(*dev.GID)
+		}
+		hostOwner, err := mappings.ToHost(owner)
+		if err != nil {
+			return fmt.Errorf("mapping owner of device %s: %w", dev.Path, err)
+		}
+		if err := os.Lchown(path, hostOwner.UID, hostOwner.GID); err != nil {
+			return fmt.Errorf("changing owner of device %s in container %s: %w", dev.Path, c.ID(), err)
+		}
+	}
+	return nil
+}
+
+// reclaimMemory asks the kernel to reclaim the given amount of memory, in
+// bytes, from the cgroup of the running container.
+func (c *Container) reclaimMemory(size int64) error {
+	unified, err := cgroups.IsCgroup2UnifiedMode()
+	if err != nil {
+		return err
+	}
+	if !unified {
+		return errors.New("reclaiming memory requires cgroup v2")
+	}
+	cgroupPath, err := c.cGroupPath()
+	if err != nil {
+		return err
+	}
+	reclaimFile := filepath.Join("/sys/fs/cgroup", cgroupPath, "memory.reclaim")
+	if err := os.WriteFile(reclaimFile, []byte(strconv.FormatInt(size, 10)), 0); err != nil {
+		if errors.Is(err, unix.EAGAIN) {
+			// the kernel reclaimed less memory than requested
+			logrus.Warnf("Could not reclaim %d bytes of memory from container %s", size, c.ID())
+			return nil
+		}
+		return fmt.Errorf("reclaiming memory of container %s: %w", c.ID(), err)
+	}
+	return nil
+}
@@ -60,3 +60,37 @@ func TestGenerateUserGroupEntry(t *testing.T) {
 	}
 	assert.Equal(t, group, "0:x:0:567890\n")
 }
+
+func TestSetDeviceCgroupRule(t *testing.T) {
+	major, minor := int64(1), int64(5)
+	rules := []spec.LinuxDeviceCgroup{
+		{Allow: false, Access: "rwm"},
+		{Allow: true, Type: "c", Major: &major, Minor: &minor, Access: "rwm"},
+	}
+	dev := spec.LinuxDevice{Type: "c", Major: major, Minor: minor}
+
+	rules = setDeviceCgroupRule(rules, dev, false, "rwm")
+	assert.Len(t, rules, 2)
+	assert.False(t, rules[1].Allow)
+
+	rules = setDeviceCgroupRule(rules, dev, true, "r")
+	assert.Len(t, rules, 2)
+	assert.True(t, rules[1].Allow)
+	assert.Equal(t, "r", rules[1].Access)
+}
+
+func TestMapFileSystemID(t *testing.T) {
+	mappings := []spec.LinuxIDMapping{
+		{ContainerID: 0, HostID: 1, Size: 10},
+		{ContainerID: 10, HostID: 11000, Size: 10},
+	}
+	id, ok := mapFileSystemID(mappings, 0)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1), id)
+	id, ok = mapFileSystemID(mappings, 15)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(11005), id)
+	id, ok = mapFileSystemID(mappings, 20)
+	assert.False(t, ok)
+	assert.Equal(t, uint32(20), id)
+}
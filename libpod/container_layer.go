@@ -0,0 +1,82 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+)
+
+// writableLayerID returns the ID of the writable layer of the container in
+// c/storage.
+func (c *Container) writableLayerID() (string, error) {
+	if c.config.Rootfs != "" {
+		return "", fmt.Errorf("container %s was created with --rootfs and has no writable layer: %w", c.ID(), define.ErrInvalidArg)
+	}
+	ctr, err := c.runtime.store.Container(c.ID())
+	if err != nil {
+		return "", fmt.Errorf("looking up container %s in storage: %w", c.ID(), err)
+	}
+	return ctr.LayerID, nil
+}
+
+// ExportLayer writes the changes of the writable layer of the container to
+// the writer as an uncompressed tar archive, with whiteouts for the removed
+// files.
+func (c *Container) ExportLayer(out io.Writer) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	layerID, err := c.writableLayerID()
+	if err != nil {
+		return err
+	}
+	layer, err := c.runtime.store.Layer(layerID)
+	if err != nil {
+		return fmt.Errorf("looking up writable layer of container %s: %w", c.ID(), err)
+	}
+	diff, err := c.runtime.LayerDiff(layer)
+	if err != nil {
+		return fmt.Errorf("reading writable layer of container %s: %w", c.ID(), err)
+	}
+	defer diff.Close()
+
+	if _, err := io.Copy(out, diff); err != nil {
+		return fmt.Errorf("writing writable layer of container %s: %w", c.ID(), err)
+	}
+	return nil
+}
+
+// ImportLayer applies the changes of the tar archive, as written by
+// ExportLayer, to the writable layer of the container.  The container must
+// not be running.
+func (c *Container) ImportLayer(in io.Reader) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if !c.ensureState(define.ContainerStateConfigured, define.ContainerStateCreated, define.ContainerStateStopped, define.ContainerStateExited) {
+		return fmt.Errorf("cannot import the writable layer of container %s as it is %s: %w", c.ID(), c.state.State, define.ErrCtrStateInvalid)
+	}
+	layerID, err := c.writableLayerID()
+	if err != nil {
+		return err
+	}
+	if _, err := c.runtime.store.ApplyDiff(layerID, in); err != nil {
+		return fmt.Errorf("applying changes to the writable layer of container %s: %w", c.ID(), err)
+	}
+	return nil
+}
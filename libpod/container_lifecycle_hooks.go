@@ -0,0 +1,146 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/specgenutil"
+	"github.com/sirupsen/logrus"
+)
+
+// Lifecycle hook stages, the suffixes of the hook labels and annotations.
+const (
+	lifecycleHookPreStart = "pre-start"
+	lifecycleHookPostStop = "post-stop"
+)
+
+// lifecycleHookSetting returns the value of the lifecycle hook label or
+// annotation.  Annotations take precedence over labels.
+func (c *Container) lifecycleHookSetting(key string) string {
+	if c.config.Spec != nil {
+		if value, ok := c.config.Spec.Annotations[key]; ok {
+			return value
+		}
+	}
+	return c.config.Labels[key]
+}
+
+// validateLifecycleHooks checks the lifecycle hook labels and annotations of
+// the container.
+func (c *Container) validateLifecycleHooks() error {
+	settings := []map[string]string{c.config.Labels}
+	if c.config.Spec != nil {
+		settings = append(settings, c.config.Spec.Annotations)
+	}
+	for _, setting := range settings {
+		for key := range setting {
+			if strings.HasPrefix(key, define.LifecycleHookPrefix) && !define.IsLifecycleHookKey(key) {
+				return fmt.Errorf("unknown lifecycle hook %q: %w", key, define.ErrInvalidArg)
+			}
+		}
+	}
+	if _, err := c.lifecycleHookTimeout(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// lifecycleHookTimeout returns the timeout of the lifecycle hooks.
+func (c *Container) lifecycleHookTimeout() (time.Duration, error) {
+	value := c.lifecycleHookSetting(define.LifecycleHookTimeout)
+	if value == "" {
+		return define.DefaultLifecycleHookTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid lifecycle hook timeout %q, must be a positive duration: %w", value, define.ErrInvalidArg)
+	}
+	return timeout, nil
+}
+
+// lifecycleHookCommand returns the command line running the command of the
+// hook, with /bin/sh on the host or in a helper container of the hook image.
+// The helper container is run by this podman binary, with the global options
+// of the cleanup process of the container.
+func (c *Container) lifecycleHookCommand(stage, command string, env []string) ([]string, error) {
+	image := c.lifecycleHookSetting(define.LifecycleHookImage)
+	if image == "" {
+		return []string{"/bin/sh", "-c", command}, nil
+	}
+	args, err := specgenutil.CreateGlobalCommandArgs(c.runtime.storageConfig, c.runtime.config, false)
+	if err != nil {
+		return nil, fmt.Errorf("creating the command of the %s hook: %w", stage, err)
+	}
+	// Replace the helper container of a hook which timed out.
+	args = append(args, "run", "--rm", "--replace", "--name", c.Name()+"-"+stage)
+	for _, e := range env {
+		args = append(args, "--env", e)
+	}
+	return append(args, image, "/bin/sh", "-c", command), nil
+}
+
+// runLifecycleHook runs the command of the lifecycle hook of the stage, if
+// the container has one, and writes a hook event with its result.  The hook
+// is killed after the timeout of the hooks.  The container lock is held
+// while the hook runs, the hook cannot run podman commands on the container.
+func (c *Container) runLifecycleHook(stage string) error {
+	command := c.lifecycleHookSetting(define.LifecycleHookPrefix + stage)
+	if command == "" {
+		return nil
+	}
+	timeout, err := c.lifecycleHookTimeout()
+	if err != nil {
+		return err
+	}
+
+	env := []string{
+		"PODMAN_HOOK_STAGE=" + stage,
+		"PODMAN_CONTAINER_ID=" + c.ID(),
+		"PODMAN_CONTAINER_NAME=" + c.Name(),
+	}
+	if c.state.PID > 0 {
+		env = append(env, "PODMAN_CONTAINER_PID="+strconv.Itoa(c.state.PID))
+	}
+	args, err := c.lifecycleHookCommand(stage, command, env)
+	if err != nil {
+		c.newContainerHookEvent(stage, -1, err)
+		return err
+	}
+
+	logrus.Debugf("Running %s hook of container %s: %v", stage, c.ID(), args)
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Env = append(os.Environ(), env...)
+	// Do not wait for processes of the hook keeping its output open.
+	cmd.WaitDelay = time.Second
+	output, err := cmd.CombinedOutput()
+	exitCode := 0
+	if err != nil {
+		exitCode = -1
+		exitErr := &exec.ExitError{}
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("timed out after %s", timeout)
+		}
+		if out := strings.TrimSpace(string(output)); out != "" {
+			err = fmt.Errorf("%w: %s", err, out)
+		}
+		err = fmt.Errorf("%s hook of container %s failed: %w", stage, c.ID(), err)
+	} else if len(output) > 0 {
+		logrus.Debugf("Output of %s hook of container %s: %s", stage, c.ID(), strings.TrimSpace(string(output)))
+	}
+	c.newContainerHookEvent(stage, exitCode, err)
+	return err
+}
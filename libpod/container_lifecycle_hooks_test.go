@@ -0,0 +1,84 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/libpod/events"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingEventer keeps the events written to it.
+type recordingEventer struct {
+	events []events.Event
+}
+
+func (e *recordingEventer) Write(event events.Event) error {
+	e.events = append(e.events, event)
+	return nil
+}
+
+func (e *recordingEventer) Read(_ context.Context, _ events.ReadOptions) error {
+	return nil
+}
+
+func (e *recordingEventer) String() string {
+	return "recording"
+}
+
+func TestRunLifecycleHook(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "out")
+	eventer := &recordingEventer{}
+	c := &Container{
+		config: &ContainerConfig{
+			ID:   "8843e4430a3f",
+			Name: "web",
+			Spec: &spec.Spec{Annotations: map[string]string{
+				define.LifecycleHookPreStart: "echo $PODMAN_HOOK_STAGE $PODMAN_CONTAINER_NAME $PODMAN_CONTAINER_PID > " + out,
+			}},
+			ContainerMiscConfig: ContainerMiscConfig{Labels: map[string]string{
+				// The annotation takes precedence.
+				define.LifecycleHookPreStart: "exit 1",
+				define.LifecycleHookPostStop: "echo failing; exit 3",
+				define.LifecycleHookTimeout:  "2s",
+			}},
+		},
+		state:   &ContainerState{PID: 1234},
+		runtime: &Runtime{eventer: eventer},
+	}
+	require.NoError(t, c.validateLifecycleHooks())
+
+	require.NoError(t, c.runLifecycleHook(lifecycleHookPreStart))
+	content, err := os.ReadFile(out)
+	require.NoError(t, err)
+	assert.Equal(t, "pre-start web 1234\n", string(content))
+
+	err = c.runLifecycleHook(lifecycleHookPostStop)
+	assert.EqualError(t, err, "post-stop hook of container 8843e4430a3f failed: exit status 3: failing")
+
+	require.Len(t, eventer.events, 2)
+	assert.Equal(t, events.Hook, eventer.events[0].Status)
+	assert.Equal(t, 0, *eventer.events[0].ContainerExitCode)
+	assert.Equal(t, "pre-start", eventer.events[0].Attributes["hook"])
+	assert.NotContains(t, eventer.events[0].Attributes, "hook_error")
+	assert.Equal(t, 3, *eventer.events[1].ContainerExitCode)
+	assert.Equal(t, err.Error(), eventer.events[1].Attributes["hook_error"])
+
+	c.config.Labels[define.LifecycleHookPostStop] = "sleep 10"
+	c.config.Labels[define.LifecycleHookTimeout] = "100ms"
+	err = c.runLifecycleHook(lifecycleHookPostStop)
+	assert.EqualError(t, err, "post-stop hook of container 8843e4430a3f failed: timed out after 100ms")
+
+	c.config.Labels[define.LifecycleHookTimeout] = "soon"
+	assert.ErrorContains(t, c.validateLifecycleHooks(), `invalid lifecycle hook timeout "soon"`)
+	c.config.Labels[define.LifecycleHookTimeout] = "1s"
+	c.config.Labels[define.LifecycleHookPrefix+"pre-stop"] = "true"
+	assert.ErrorContains(t, c.validateLifecycleHooks(), `unknown lifecycle hook "io.podman.hook.pre-stop"`)
+}
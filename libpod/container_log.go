@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/libpod/events"
 	"github.com/dmikushin/podman-shared/libpod/logs"
 	systemdDefine "github.com/dmikushin/podman-shared/pkg/systemd/define"
 	"github.com/nxadm/tail"
@@ -34,6 +35,104 @@ func (r *Runtime) Log(ctx context.Context, containers []*Container, options *log
 	return nil
 }
 
+// PodLog reads the logs of all containers of the pod and returns the log
+// lines over the channel.  When following, the logs of the containers started
+// in the pod later on are read as well, until no container of the pod is
+// running anymore.
+func (r *Runtime) PodLog(ctx context.Context, pod *Pod, options *logs.LogOptions, logChannel chan *logs.LogLine) error {
+	var eventChannel chan events.ReadResult
+	watchCtx, cancel := context.WithCancel(ctx)
+	if options.Follow {
+		// Watch the events before listing the containers to not miss
+		// the ones started in the meantime.
+		eventChannel = make(chan events.ReadResult)
+		readOpts := events.ReadOptions{
+			EventChannel: eventChannel,
+			Filters:      []string{"type=container", "event=start", "event=died", "event=remove"},
+			Stream:       true,
+		}
+		if err := r.Events(watchCtx, readOpts); err != nil {
+			cancel()
+			return err
+		}
+	}
+
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		cancel()
+		return err
+	}
+	followed := make(map[string]bool, len(ctrs))
+	for _, ctr := range ctrs {
+		if err := ctr.ReadLog(ctx, options, logChannel, int64(len(followed))); err != nil {
+			cancel()
+			return err
+		}
+		followed[ctr.ID()] = true
+	}
+	if !options.Follow {
+		cancel()
+		return nil
+	}
+
+	options.WaitGroup.Add(1)
+	go func() {
+		defer options.WaitGroup.Done()
+		defer func() {
+			cancel()
+			for range eventChannel {
+			}
+		}()
+		if !podHasRunningContainer(pod) {
+			return
+		}
+		for evt := range eventChannel {
+			if evt.Error != nil {
+				logrus.Debugf("Reading events of pod %s: %v", pod.ID(), evt.Error)
+				continue
+			}
+			if evt.Event.PodID != pod.ID() {
+				continue
+			}
+			if evt.Event.Status != events.Start {
+				if !podHasRunningContainer(pod) {
+					return
+				}
+				continue
+			}
+			if followed[evt.Event.ID] {
+				continue
+			}
+			ctr, err := r.LookupContainer(evt.Event.ID)
+			if err != nil {
+				logrus.Debugf("Looking up container %s of pod %s: %v", evt.Event.ID, pod.ID(), err)
+				continue
+			}
+			if err := ctr.ReadLog(ctx, options, logChannel, int64(len(followed))); err != nil {
+				logrus.Errorf("Reading logs of container %s: %v", ctr.ID(), err)
+			}
+			followed[ctr.ID()] = true
+		}
+	}()
+	return nil
+}
+
+// podHasRunningContainer returns whether a container of the pod is running or
+// paused.
+func podHasRunningContainer(pod *Pod) bool {
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		return false
+	}
+	for _, ctr := range ctrs {
+		state, err := ctr.State()
+		if err == nil && (state == define.ContainerStateRunning || state == define.ContainerStatePaused) {
+			return true
+		}
+	}
+	return false
+}
+
 // ReadLog reads a container's log based on the input options and returns log lines over a channel.
 func (c *Container) ReadLog(ctx context.Context, options *logs.LogOptions, logChannel chan *logs.LogLine, colorID int64) error {
 	switch c.LogDriver() {
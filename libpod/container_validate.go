@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
+	systemdDefine "github.com/dmikushin/podman-shared/pkg/systemd/define"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"go.podman.io/image/v5/docker"
 	"go.podman.io/image/v5/pkg/shortnames"
@@ -148,6 +149,16 @@ func (c *Container) validate() error {
 		return fmt.Errorf("cannot set on-failure action to %s without a health check", c.config.HealthCheckOnFailureAction.String())
 	}
 
+	if c.config.HealthCheckOnFailureAction == define.HealthCheckOnFailureActionRestartUnit {
+		if _, exists := c.config.Labels[systemdDefine.EnvVariable]; !exists {
+			return fmt.Errorf("cannot set on-failure action to %s without a systemd unit: the %s label is not set", c.config.HealthCheckOnFailureAction.String(), systemdDefine.EnvVariable)
+		}
+	}
+
+	if err := c.validateLifecycleHooks(); err != nil {
+		return err
+	}
+
 	if value, exists := c.config.Labels[define.AutoUpdateLabel]; exists {
 		// TODO: we cannot reference pkg/autoupdate here due to
 		// circular dependencies.  It's worth considering moving the
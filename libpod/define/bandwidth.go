@@ -0,0 +1,145 @@
+package define
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/docker/go-units"
+)
+
+// Per-network options of a container limiting its bandwidth on the network.
+// The rate and burst options apply to both directions, the ingress and
+// egress options override them for one direction.
+const (
+	BandwidthRate         = "rate"
+	BandwidthBurst        = "burst"
+	BandwidthIngressRate  = "ingress_rate"
+	BandwidthIngressBurst = "ingress_burst"
+	BandwidthEgressRate   = "egress_rate"
+	BandwidthEgressBurst  = "egress_burst"
+)
+
+// minBandwidthBurst is the smallest default burst, enough for a few full
+// sized packets.
+const minBandwidthBurst = 32 * 1024
+
+// Bandwidth holds the bandwidth limits of a container on a network.  Rates
+// are in bits per second, bursts in bytes, zero means unlimited.
+type Bandwidth struct {
+	IngressRate  uint64 `json:"IngressRate,omitempty"`
+	IngressBurst uint64 `json:"IngressBurst,omitempty"`
+	EgressRate   uint64 `json:"EgressRate,omitempty"`
+	EgressBurst  uint64 `json:"EgressBurst,omitempty"`
+}
+
+// IsBandwidthOption returns true if the per-network option is a bandwidth
+// limit handled by Podman instead of the network backend.
+func IsBandwidthOption(key string) bool {
+	switch key {
+	case BandwidthRate, BandwidthBurst, BandwidthIngressRate, BandwidthIngressBurst, BandwidthEgressRate, BandwidthEgressBurst:
+		return true
+	}
+	return false
+}
+
+// ParseBandwidth parses the bandwidth options of the per-network options of
+// a container.  It returns nil if no limit is set.
+func ParseBandwidth(options map[string]string) (*Bandwidth, error) {
+	var err error
+	get := func(key, fallback string, parse func(string) (uint64, error)) uint64 {
+		value, ok := options[key]
+		if !ok {
+			key = fallback
+			value, ok = options[key]
+		}
+		if !ok || err != nil {
+			return 0
+		}
+		var parsed uint64
+		if parsed, err = parse(value); err != nil {
+			err = fmt.Errorf("invalid network option %s=%s: %w", key, value, err)
+		}
+		return parsed
+	}
+	bw := &Bandwidth{
+		IngressRate:  get(BandwidthIngressRate, BandwidthRate, ParseRate),
+		IngressBurst: get(BandwidthIngressBurst, BandwidthBurst, parseBurst),
+		EgressRate:   get(BandwidthEgressRate, BandwidthRate, ParseRate),
+		EgressBurst:  get(BandwidthEgressBurst, BandwidthBurst, parseBurst),
+	}
+	if err != nil {
+		return nil, err
+	}
+	if bw.IngressRate == 0 && bw.EgressRate == 0 {
+		if bw.IngressBurst != 0 || bw.EgressBurst != 0 {
+			return nil, fmt.Errorf("a bandwidth burst requires a rate: %w", ErrInvalidArg)
+		}
+		return nil, nil
+	}
+	if bw.IngressRate == 0 {
+		bw.IngressBurst = 0
+	} else if bw.IngressBurst == 0 {
+		bw.IngressBurst = defaultBurst(bw.IngressRate)
+	}
+	if bw.EgressRate == 0 {
+		bw.EgressBurst = 0
+	} else if bw.EgressBurst == 0 {
+		bw.EgressBurst = defaultBurst(bw.EgressRate)
+	}
+	return bw, nil
+}
+
+// defaultBurst returns the burst of a rate without one, the bytes sent in
+// 10ms.
+func defaultBurst(rate uint64) uint64 {
+	return max(rate/8/100, minBandwidthBurst)
+}
+
+// rateUnits are the units of tc(8) rates in bits per second.
+var rateUnits = []struct {
+	suffix string
+	factor uint64
+}{
+	{"tbit", 1000 * 1000 * 1000 * 1000},
+	{"gbit", 1000 * 1000 * 1000},
+	{"mbit", 1000 * 1000},
+	{"kbit", 1000},
+	{"tbps", 8 * 1000 * 1000 * 1000 * 1000},
+	{"gbps", 8 * 1000 * 1000 * 1000},
+	{"mbps", 8 * 1000 * 1000},
+	{"kbps", 8 * 1000},
+	{"bit", 1},
+	{"bps", 8},
+}
+
+// ParseRate parses a rate in the format of tc(8), e.g. 10mbit or 1gbps, a
+// bare number is in bits per second.  It returns the rate in bits per second.
+func ParseRate(rate string) (uint64, error) {
+	value := strings.ToLower(strings.TrimSpace(rate))
+	factor := uint64(1)
+	for _, unit := range rateUnits {
+		if number, found := strings.CutSuffix(value, unit.suffix); found {
+			value, factor = number, unit.factor
+			break
+		}
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("rate %q must be a positive number with an optional unit like kbit, mbit, gbit, kbps or mbps: %w", rate, ErrInvalidArg)
+	}
+	bits := number * float64(factor)
+	if bits >= math.MaxUint64 {
+		return 0, fmt.Errorf("rate %q is too large: %w", rate, ErrInvalidArg)
+	}
+	return uint64(bits), nil
+}
+
+func parseBurst(burst string) (uint64, error) {
+	size, err := units.RAMInBytes(burst)
+	if err != nil || size <= 0 {
+		return 0, fmt.Errorf("burst %q must be a positive size: %w", burst, ErrInvalidArg)
+	}
+	return uint64(size), nil
+}
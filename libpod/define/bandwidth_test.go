@@ -0,0 +1,53 @@
+package define
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRate(t *testing.T) {
+	tests := []struct {
+		rate string
+		bits uint64
+	}{
+		{"1000", 1000},
+		{"10mbit", 10_000_000},
+		{"1.5gbit", 1_500_000_000},
+		{"100kbps", 800_000},
+		{"2MBps", 16_000_000},
+	}
+	for _, tt := range tests {
+		bits, err := ParseRate(tt.rate)
+		require.NoError(t, err, tt.rate)
+		assert.Equal(t, tt.bits, bits, tt.rate)
+	}
+	for _, rate := range []string{"", "0", "-1mbit", "fast", "10mb"} {
+		_, err := ParseRate(rate)
+		assert.ErrorIs(t, err, ErrInvalidArg, rate)
+	}
+}
+
+func TestParseBandwidth(t *testing.T) {
+	bw, err := ParseBandwidth(map[string]string{"isolate": "true"})
+	require.NoError(t, err)
+	assert.Nil(t, bw)
+
+	bw, err = ParseBandwidth(map[string]string{BandwidthRate: "10mbit", BandwidthBurst: "64k", BandwidthIngressRate: "1gbit"})
+	require.NoError(t, err)
+	assert.Equal(t, &Bandwidth{IngressRate: 1_000_000_000, IngressBurst: 65536, EgressRate: 10_000_000, EgressBurst: 65536}, bw)
+
+	// default burst is 10ms at the rate, at least minBandwidthBurst
+	bw, err = ParseBandwidth(map[string]string{BandwidthEgressRate: "1gbit"})
+	require.NoError(t, err)
+	assert.Equal(t, &Bandwidth{EgressRate: 1_000_000_000, EgressBurst: 1_250_000}, bw)
+	bw, err = ParseBandwidth(map[string]string{BandwidthIngressRate: "1mbit"})
+	require.NoError(t, err)
+	assert.Equal(t, &Bandwidth{IngressRate: 1_000_000, IngressBurst: minBandwidthBurst}, bw)
+
+	_, err = ParseBandwidth(map[string]string{BandwidthBurst: "64k"})
+	assert.ErrorIs(t, err, ErrInvalidArg)
+	_, err = ParseBandwidth(map[string]string{BandwidthRate: "10mbit", BandwidthEgressBurst: "none"})
+	assert.ErrorContains(t, err, "invalid network option egress_burst=none")
+}
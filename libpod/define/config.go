@@ -25,6 +25,11 @@ const (
 	// DefaultTransport is a prefix that we apply to an image name
 	// to check docker hub first for the image
 	DefaultTransport = "docker://"
+
+	// BuildSecretPrefix is a prefix of the source of a build secret which
+	// refers to a secret of the secrets store instead of a file or an
+	// environment variable
+	BuildSecretPrefix = "podman-secret:"
 )
 
 // InfoData holds the info type, i.e store, host etc and the data for each type
@@ -46,6 +51,9 @@ const (
 	OCIArchive      = "oci-archive"
 	V2s2ManifestDir = "docker-dir"
 	V2s2Archive     = "docker-archive"
+	// OCIArtifactArchive is an OCI archive holding images and the
+	// artifacts referring to them, like signatures and SBOMs.
+	OCIArtifactArchive = "oci-artifact"
 )
 
 // AttachStreams contains streams that will be attached to the container
@@ -90,3 +98,12 @@ const RLimitDefaultValue = uint64(1048576)
 
 // BindMountPrefix distinguishes its annotations from others
 const BindMountPrefix = "bind-mount-options"
+
+// Values of the shared build cache setting besides the path of a directory.
+const (
+	// BuildSharedCacheAuto uses the build-cache directory of the graph
+	// root when the graph root is on shared storage.
+	BuildSharedCacheAuto = "auto"
+	// BuildSharedCacheNone disables the shared build cache.
+	BuildSharedCacheNone = "none"
+)
@@ -24,6 +24,10 @@ const (
 	RestartPolicyUnlessStopped = "unless-stopped"
 )
 
+// TimezoneHost is the timezone of containers following the timezone of the
+// host, which get the timezone database of the host mounted.
+const TimezoneHost = "host"
+
 // RestartPolicyMap maps between restart-policy valid values to restart policy types
 var RestartPolicyMap = map[string]string{
 	"none":                     RestartPolicyNone,
@@ -53,6 +57,9 @@ const (
 	OneShotInitContainer = "once"
 	// ContainerInitPath is the default path of the mounted container init.
 	ContainerInitPath = "/run/podman-init"
+	// ContainerInitStatsPath is where the container init may report its
+	// statistics.
+	ContainerInitStatsPath = "/run/podman-init.stats"
 )
 
 // Kubernetes Kinds
@@ -67,6 +74,29 @@ const (
 	K8sKindJob = "job"
 )
 
+// Kubernetes kinds routing HTTP traffic to a service
+const (
+	// An Ingress of the networking.k8s.io API
+	K8sKindIngress = "ingress"
+	// An HTTPRoute of the Gateway API
+	K8sKindHTTPRoute = "httproute"
+)
+
+// Labels of containers and pods read by kube generate to route HTTP traffic
+// to the generated service.
+const (
+	// KubeIngressHostLabel is the host name the requests are routed for.
+	KubeIngressHostLabel = "io.podman.kube.ingress.host"
+	// KubeIngressPathLabel is the prefix of the paths routed, / by default.
+	KubeIngressPathLabel = "io.podman.kube.ingress.path"
+	// KubeIngressPortLabel is the number or name of the service port the
+	// requests are routed to, the first TCP port by default.
+	KubeIngressPortLabel = "io.podman.kube.ingress.port"
+	// KubeIngressGatewayLabel is the Gateway an HTTPRoute is attached to,
+	// as NAME or NAMESPACE/NAME.
+	KubeIngressGatewayLabel = "io.podman.kube.ingress.gateway"
+)
+
 type WeightDevice struct {
 	Path   string
 	Weight uint16
@@ -95,6 +125,19 @@ type UpdateContainerDevicesLimits struct {
 	DeviceWriteIOPs []ThrottleDevice `json:",omitempty"`
 }
 
+// UpdateContainerDevices describes the devices added to and removed from a
+// container by an update
+type UpdateContainerDevices struct {
+	// Host devices to add, in the form of the --device option:
+	// ```/dev/sda[:/dev/xvda[:rwm]]```
+	AddDevices []string `json:",omitempty"`
+	// Devices to remove, given by their path in the container
+	RemoveDevices []string `json:",omitempty"`
+	// Device cgroup rules to add, in the form of the --device-cgroup-rule
+	// option: ```c 188:0-15 rwm``` or ```/dev/ttyUSB* rwm```
+	AddDeviceCgroupRules []string `json:",omitempty"`
+}
+
 func (d *WeightDevice) addToLinuxWeightDevice(wd map[string]specs.LinuxWeightDevice) {
 	wd[d.Path] = specs.LinuxWeightDevice{
 		Weight:     &d.Weight,
@@ -15,6 +15,22 @@ type InspectIDMappings struct {
 	GIDMap []string `json:"GidMap"`
 }
 
+// InspectAutoUserNs describes the user namespace allocated to a container
+// created with --userns=auto.
+type InspectAutoUserNs struct {
+	// User whose subordinate IDs the user namespace was allocated from.
+	User string `json:"User"`
+	// RequestedSize is the size set with --userns=auto:size=, the size is
+	// computed from the image otherwise.
+	RequestedSize uint32 `json:"RequestedSize,omitempty"`
+	// MinSize is the minimum size of a computed size, from the UID and GID
+	// the container runs as.
+	MinSize uint32 `json:"MinSize,omitempty"`
+	// UIDs and GIDs are the number of IDs mapped into the user namespace.
+	UIDs int `json:"UIDs"`
+	GIDs int `json:"GIDs"`
+}
+
 // InspectContainerConfig holds further data about how a container was initially
 // configured.
 type InspectContainerConfig struct {
@@ -77,6 +93,8 @@ type InspectContainerConfig struct {
 	// Timezone is the timezone inside the container.
 	// Local means it has the same timezone as the host machine
 	Timezone string `json:"Timezone,omitempty"`
+	// Locale is the locale of the container.
+	Locale string `json:"Locale,omitempty"`
 	// SystemdMode is whether the container is running in systemd mode. In
 	// systemd mode, the container configuration is customized to optimize
 	// running systemd in the container.
@@ -101,6 +119,9 @@ type InspectContainerConfig struct {
 	SdNotifySocket string `json:"sdNotifySocket,omitempty"`
 	// ExposedPorts includes ports the container has exposed.
 	ExposedPorts map[string]struct{} `json:"ExposedPorts,omitempty"`
+	// StorageTransient indicates that the container is removed when the
+	// system reboots.
+	StorageTransient bool `json:"StorageTransient,omitempty"`
 
 	// V4PodmanCompatMarshal indicates that the json marshaller should
 	// use the old v4 inspect format to keep API compatibility.
@@ -225,6 +246,21 @@ type InspectBlkioThrottleDevice struct {
 	Rate uint64 `json:"Rate"`
 }
 
+// InspectIOMaxDevice holds the cgroup v2 io.max limits of a single device.
+// Limits that are not set are 0.
+type InspectIOMaxDevice struct {
+	// Path is the path to the device this applies to.
+	Path string `json:"Path"`
+	// Rbps is the maximum read rate in bytes per second.
+	Rbps uint64 `json:"Rbps,omitempty"`
+	// Wbps is the maximum write rate in bytes per second.
+	Wbps uint64 `json:"Wbps,omitempty"`
+	// Riops is the maximum read rate in IO operations per second.
+	Riops uint64 `json:"Riops,omitempty"`
+	// Wiops is the maximum write rate in IO operations per second.
+	Wiops uint64 `json:"Wiops,omitempty"`
+}
+
 // InspectUlimit is a ulimit that will be applied to the container.
 type InspectUlimit struct {
 	// Name is the name (type) of the ulimit.
@@ -290,6 +326,17 @@ type InspectMount struct {
 	SubPath string `json:"SubPath,omitempty"`
 }
 
+// InspectInitStats are the statistics reported by the init of a container,
+// which are only available if its init binary writes them to
+// ContainerInitStatsPath.
+type InspectInitStats struct {
+	// Reaped is the number of zombie processes the init reaped.
+	Reaped uint64 `json:"Reaped"`
+	// SignalsForwarded is the number of signals the init forwarded to the
+	// process of the container.
+	SignalsForwarded uint64 `json:"SignalsForwarded"`
+}
+
 // InspectContainerState provides a detailed record of a container's current
 // state. It is returned as part of InspectContainerData.
 // As with InspectContainerData, many portions of this struct are matched to
@@ -302,6 +349,8 @@ type InspectContainerState struct {
 	Paused         bool                `json:"Paused"`
 	Restarting     bool                `json:"Restarting"` // TODO
 	OOMKilled      bool                `json:"OOMKilled"`
+	OOMReport      *OOMReport          `json:"OOMReport,omitempty"`
+	InitStats      *InspectInitStats   `json:"InitStats,omitempty"`
 	Dead           bool                `json:"Dead"`
 	Pid            int                 `json:"Pid"`
 	ConmonPid      int                 `json:"ConmonPid,omitempty"`
@@ -319,6 +368,9 @@ type InspectContainerState struct {
 	RestoreLog     string              `json:"RestoreLog,omitempty"`
 	Restored       bool                `json:"Restored,omitempty"`
 	StoppedByUser  bool                `json:"StoppedByUser,omitempty"`
+	// FrozenUntil is the time a container paused with a freeze timeout is
+	// unpaused automatically.
+	FrozenUntil *time.Time `json:"FrozenUntil,omitempty"`
 }
 
 // Healthcheck returns the HealthCheckResults. This is used for old podman compat
@@ -494,6 +546,11 @@ type InspectContainerHostConfig struct {
 	// container.
 	// It is a map of destination path to options for the mount.
 	Tmpfs map[string]string `json:"Tmpfs"`
+	// ReadOnlyTmpfs are the read-write tmpfs mounted on /run, /tmp and
+	// /var/tmp of read-only containers, unless disabled with
+	// --read-only-tmpfs=false.
+	// It is a map of destination path to options for the mount.
+	ReadOnlyTmpfs map[string]string `json:"ReadOnlyTmpfs,omitempty"`
 	// UTSMode represents the configuration of the container's UID
 	// namespace.
 	// Populated as follows:
@@ -516,6 +573,9 @@ type InspectContainerHostConfig struct {
 	UsernsMode string `json:"UsernsMode"`
 	// IDMappings is the UIDMapping and GIDMapping used within the container
 	IDMappings *InspectIDMappings `json:"IDMappings,omitempty"`
+	// AutoUserNs describes how the size of the user namespace was
+	// negotiated, for containers created with --userns=auto.
+	AutoUserNs *InspectAutoUserNs `json:"AutoUserNs,omitempty"`
 	// ShmSize is the size of the container's SHM device.
 
 	ShmSize int64 `json:"ShmSize"`
@@ -599,6 +659,10 @@ type InspectContainerHostConfig struct {
 	// don't guarantee the path will be identical to the original (though
 	// the node will be).
 	BlkioDeviceWriteIOps []InspectBlkioThrottleDevice `json:"BlkioDeviceWriteIOps"`
+	// IOMax combines the BlkioDevice limits per device, as they are
+	// written to the io.max file of the container's cgroup.
+	// Only set on cgroup v2 systems.
+	IOMax []InspectIOMaxDevice `json:"IOMax,omitempty"`
 	// CpuPeriod is the length of a CPU period in microseconds.
 	// It relates directly to CpuQuota.
 	CpuPeriod uint64 `json:"CpuPeriod"`
@@ -618,6 +682,9 @@ type InspectContainerHostConfig struct {
 	// CpusetMems is the set of memory nodes the container will use.
 	// Formatted as `0-3` or `0,2`. Default (if unset) is all memory nodes.
 	CpusetMems string `json:"CpusetMems"`
+	// NUMAPolicy is the NUMA memory policy applied to the processes of
+	// the container. Empty if the default policy of the host is used.
+	NUMAPolicy string `json:"NumaPolicy,omitempty"`
 	// Devices is a list of device nodes that will be added to the
 	// container.
 	// These are stored in the OCI spec only as type, major, minor while we
@@ -650,6 +717,9 @@ type InspectContainerHostConfig struct {
 	OomKillDisable bool `json:"OomKillDisable"`
 	// Init indicates whether the container has an init mounted into it.
 	Init bool `json:"Init,omitempty"`
+	// InitPath is the path of the init binary on the host, if the
+	// container has an init.
+	InitPath string `json:"InitPath,omitempty"`
 	// PidsLimit is the maximum number of PIDs that may be created within
 	// the container. 0, the default, indicates no limit.
 	PidsLimit int64 `json:"PidsLimit"`
@@ -728,6 +798,33 @@ type InspectAdditionalNetwork struct {
 	Links []string `json:"Links"`
 	// Aliases are any network aliases the container has in this network.
 	Aliases []string `json:"Aliases,omitempty"`
+	// Bandwidth are the bandwidth limits of the container in this
+	// network.
+	Bandwidth *Bandwidth `json:"Bandwidth,omitempty"`
+}
+
+// InspectNetworkFlow is a connection of a container tracked by the
+// connection tracking of the kernel, seen from the container.
+type InspectNetworkFlow struct {
+	// Protocol of the connection, e.g. tcp or udp.
+	Protocol string `json:"Protocol"`
+	// Direction is outgoing for connections opened by the container and
+	// incoming for connections to a published port of the container.
+	Direction string `json:"Direction"`
+	// LocalAddress and LocalPort are the address and port of the container.
+	LocalAddress string `json:"LocalAddress"`
+	LocalPort    uint16 `json:"LocalPort,omitempty"`
+	// RemoteAddress and RemotePort are the address and port of the peer.
+	RemoteAddress string `json:"RemoteAddress"`
+	RemotePort    uint16 `json:"RemotePort,omitempty"`
+	// State is the state of TCP connections.
+	State string `json:"State,omitempty"`
+	// BytesSent and BytesReceived are only counted when the connection
+	// tracking accounting was enabled before the connection was opened.
+	BytesSent       uint64 `json:"BytesSent"`
+	BytesReceived   uint64 `json:"BytesReceived"`
+	PacketsSent     uint64 `json:"PacketsSent"`
+	PacketsReceived uint64 `json:"PacketsReceived"`
 }
 
 // InspectNetworkSettings holds information about the network settings of the
@@ -750,6 +847,15 @@ type InspectNetworkSettings struct {
 	Networks map[string]*InspectAdditionalNetwork `json:"Networks,omitempty"`
 }
 
+// InspectMonitor describes the container monitor managing a container.
+type InspectMonitor struct {
+	// Name is the monitor selected for the container: conmon, conmon-rs or
+	// the path of a monitor binary.
+	Name string `json:"Name"`
+	// Path is the path of the monitor binary.
+	Path string `json:"Path"`
+}
+
 // InspectContainerData provides a detailed record of a container's configuration
 // and state as viewed by Libpod.
 // Large portions of this structure are defined such that the output is
@@ -772,6 +878,9 @@ type InspectContainerData struct {
 	StaticDir               string                      `json:"StaticDir"`
 	OCIConfigPath           string                      `json:"OCIConfigPath,omitempty"`
 	OCIRuntime              string                      `json:"OCIRuntime,omitempty"`
+	RuntimeClass            string                      `json:"RuntimeClass,omitempty"`
+	Monitor                 *InspectMonitor             `json:"Monitor,omitempty"`
+	NetworkFlows            []InspectNetworkFlow        `json:"NetworkFlows,omitempty"`
 	ConmonPidFile           string                      `json:"ConmonPidFile"`
 	PidFile                 string                      `json:"PidFile"`
 	Name                    string                      `json:"Name"`
@@ -36,6 +36,11 @@ const (
 	ContainerStateStopping ContainerStatus = iota
 )
 
+// ContainerConditionRemoved is a wait condition which is met once the
+// container has been removed.  Unlike ContainerStateRemoving it is not met
+// while the removal is still in progress.
+const ContainerConditionRemoved = "removed"
+
 // ContainerStatus returns a string representation for users of a container
 // state. All results should match Docker's versions (from `docker ps`) as
 // closely as possible, given the different set of states we support.
@@ -141,6 +146,17 @@ type ContainerStats struct {
 	MemUsage      uint64
 	MemLimit      uint64
 	MemPerc       float64
+	// Breakdown of the memory of the container, from the memory.stat
+	// file of its cgroup.  MemAnon is the anonymous memory, MemFile the
+	// page cache and MemSlab the kernel slab memory.  MemSlab is only
+	// set on cgroup v2.
+	MemAnon uint64
+	MemFile uint64
+	MemSlab uint64
+	// MemWorkingSet estimates the memory the container needs to run: its
+	// anonymous memory and its active page cache.  The inactive page
+	// cache is reclaimed first when memory is short.
+	MemWorkingSet uint64
 	// Map of interface name to network statistics for that interface.
 	Network     map[string]ContainerNetworkStats
 	BlockInput  uint64
@@ -161,3 +177,22 @@ type ContainerNetworkStats struct {
 	TxErrors  uint64
 	TxPackets uint64
 }
+
+// ContainerStatsSample is a sample of the resource usage of a container
+// recorded in its stats history.
+type ContainerStatsSample struct {
+	// Time the sample was recorded.
+	Time time.Time
+	// CPU usage in percent since the previous sample.
+	CPU float64
+	// Total CPU time of the container in nanoseconds.
+	CPUNano     uint64
+	MemUsage    uint64
+	MemLimit    uint64
+	MemPerc     float64
+	NetInput    uint64
+	NetOutput   uint64
+	BlockInput  uint64
+	BlockOutput uint64
+	PIDs        uint64
+}
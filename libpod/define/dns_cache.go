@@ -0,0 +1,25 @@
+package define
+
+import (
+	"fmt"
+	"strconv"
+)
+
+const (
+	// DNSCacheLabel is the label of a network enabling the DNS cache of
+	// aardvark-dns for the containers on the network.
+	DNSCacheLabel = "io.podman.network.dns_cache"
+	// DNSCacheOption is the per-network option of a container overriding
+	// the DNSCacheLabel of the network.  Podman passes it to netavark
+	// with the effective setting, for aardvark-dns.
+	DNSCacheOption = "dns_cache"
+)
+
+// ParseDNSCache parses the value of DNSCacheLabel or DNSCacheOption.
+func ParseDNSCache(value string) (bool, error) {
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid DNS cache setting %q, must be true or false: %w", value, ErrInvalidArg)
+	}
+	return enabled, nil
+}
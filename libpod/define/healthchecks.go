@@ -112,6 +112,8 @@ const (
 	HealthCheckOnFailureActionRestart = iota
 	// HealthCheckOnFailureActionNonce instructs Podman to stop the container on an unhealthy status.
 	HealthCheckOnFailureActionStop = iota
+	// HealthCheckOnFailureActionRestartUnit instructs Podman to restart the systemd unit running the container on an unhealthy status.
+	HealthCheckOnFailureActionRestartUnit = iota
 )
 
 // String representations for on-failure actions.
@@ -121,6 +123,8 @@ const (
 	strHealthCheckOnFailureActionKill    = "kill"
 	strHealthCheckOnFailureActionRestart = "restart"
 	strHealthCheckOnFailureActionStop    = "stop"
+
+	strHealthCheckOnFailureActionRestartUnit = "restart-unit"
 )
 
 // SupportedHealthCheckOnFailureActions lists all supported healthcheck restart policies.
@@ -129,6 +133,7 @@ var SupportedHealthCheckOnFailureActions = []string{
 	strHealthCheckOnFailureActionKill,
 	strHealthCheckOnFailureActionRestart,
 	strHealthCheckOnFailureActionStop,
+	strHealthCheckOnFailureActionRestartUnit,
 }
 
 // String returns the string representation of the HealthCheckOnFailureAction.
@@ -142,6 +147,8 @@ func (h HealthCheckOnFailureAction) String() string {
 		return strHealthCheckOnFailureActionRestart
 	case HealthCheckOnFailureActionStop:
 		return strHealthCheckOnFailureActionStop
+	case HealthCheckOnFailureActionRestartUnit:
+		return strHealthCheckOnFailureActionRestartUnit
 	default:
 		return strHealthCheckOnFailureActionInvalid
 	}
@@ -159,6 +166,8 @@ func ParseHealthCheckOnFailureAction(s string) (HealthCheckOnFailureAction, erro
 		return HealthCheckOnFailureActionRestart, nil
 	case strHealthCheckOnFailureActionStop:
 		return HealthCheckOnFailureActionStop, nil
+	case strHealthCheckOnFailureActionRestartUnit:
+		return HealthCheckOnFailureActionRestartUnit, nil
 	default:
 		err := fmt.Errorf("invalid on-failure action %q for health check: supported actions are %s", s, strings.Join(SupportedHealthCheckOnFailureActions, ","))
 		return HealthCheckOnFailureActionInvalid, err
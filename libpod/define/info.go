@@ -48,6 +48,7 @@ type HostInfo struct {
 	MemTotal           int64             `json:"memTotal"`
 	NetworkBackend     string            `json:"networkBackend"`
 	NetworkBackendInfo types.NetworkInfo `json:"networkBackendInfo"`
+	NUMA               []NUMANode        `json:"numa,omitempty"`
 	OCIRuntime         *OCIRuntimeInfo   `json:"ociRuntime"`
 	OS                 string            `json:"os"`
 	// RemoteSocket returns the UNIX domain socket the Podman service is listening on
@@ -66,10 +67,35 @@ type HostInfo struct {
 	Uptime    string `json:"uptime"`
 	Variant   string `json:"variant"`
 	Linkmode  string `json:"linkmode"`
+	// Wasm describes the OCI runtime running WebAssembly images
+	Wasm *WasmInfo `json:"wasm,omitempty"`
 
 	EmulatedArchitectures []string `json:"emulatedArchitectures,omitempty"`
 }
 
+// WasmInfo describes the OCI runtime running WebAssembly (wasi/wasm) images
+type WasmInfo struct {
+	// Available is true when an OCI runtime can run WebAssembly images
+	Available bool `json:"available"`
+	// Runtime is the name of the OCI runtime
+	Runtime string `json:"runtime,omitempty"`
+	// Path is the path of the OCI runtime
+	Path string `json:"path,omitempty"`
+	// Handlers are the wasm handlers of the runtime, e.g. wasmedge
+	Handlers []string `json:"handlers,omitempty"`
+	// Annotation is true when the wasm handler of the runtime is selected
+	// with an annotation, as for the default runtime
+	Annotation bool `json:"annotation,omitempty"`
+}
+
+// NUMANode describes a NUMA node of the host
+type NUMANode struct {
+	ID       int    `json:"id"`
+	CPUs     string `json:"cpus"`
+	MemFree  int64  `json:"memFree"`
+	MemTotal int64  `json:"memTotal"`
+}
+
 // RemoteSocket describes information about the API socket
 type RemoteSocket struct {
 	Path   string `json:"path,omitempty"`
@@ -118,6 +144,9 @@ type OCIRuntimeInfo struct {
 	Package string `json:"package"`
 	Path    string `json:"path"`
 	Version string `json:"version"`
+	// Features lists the optional features the runtime was built with,
+	// e.g. "wasm:wasmedge" or "criu"
+	Features []string `json:"features,omitempty"`
 }
 
 // StoreInfo describes the container storage and its
@@ -138,6 +167,31 @@ type StoreInfo struct {
 	RunRoot         string            `json:"runRoot"`
 	VolumePath      string            `json:"volumePath"`
 	TransientStore  bool              `json:"transientStore"`
+	// SharedStorage is true when the graph root, or the image store when
+	// set, is on shared storage, so that containers created with
+	// --shared-base-layers can mount their base layers from it
+	SharedStorage bool `json:"sharedStorage"`
+	// ReadOnlyGraphRoot is the graph root used as a read-only image store
+	// when the graph root is the writable root set with --root-rw
+	ReadOnlyGraphRoot string `json:"readOnlyGraphRoot,omitempty"`
+	// SharedLayerCache describes the local cache of the shared base
+	// layers, set with --shared-layer-cache
+	SharedLayerCache *SharedLayerCacheInfo `json:"sharedLayerCache,omitempty"`
+}
+
+// SharedLayerCacheInfo describes the local cache of the shared base layers
+type SharedLayerCacheInfo struct {
+	Path string `json:"path"`
+	// Size is the size of the cached layers in bytes
+	Size int64 `json:"size"`
+	// MaxSize is the size the cache is kept under in bytes
+	MaxSize int64 `json:"maxSize"`
+	// Layers is the number of cached layers
+	Layers int `json:"layers"`
+	// Hits is the number of mounts of a cached layer
+	Hits uint64 `json:"hits"`
+	// Misses is the number of mounts of a layer from the shared storage
+	Misses uint64 `json:"misses"`
 }
 
 // ImageStore describes the image store.  Right now only the number
@@ -161,6 +215,15 @@ type Plugins struct {
 	Log     []string `json:"log"`
 	// Authorization is provided for compatibility, will always be nil as Podman has no daemon
 	Authorization []string `json:"authorization"`
+	// Hooks lists the OCI hooks found in the hooks directories
+	Hooks []HookInfo `json:"hooks,omitempty"`
+}
+
+// HookInfo describes an installed OCI hook
+type HookInfo struct {
+	Name   string   `json:"name"`
+	Path   string   `json:"path"`
+	Stages []string `json:"stages"`
 }
 
 type CPUUsage struct {
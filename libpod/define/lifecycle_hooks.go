@@ -0,0 +1,39 @@
+package define
+
+import "time"
+
+const (
+	// LifecycleHookPrefix is the prefix of the container labels and
+	// annotations declaring lifecycle hooks.  They can only be set by the
+	// user, labels of the image with this prefix are ignored.
+	LifecycleHookPrefix = "io.podman.hook."
+	// LifecycleHookPreStart is the label or annotation with the command
+	// run on the host before the container is started.  The start fails if
+	// the command fails.
+	LifecycleHookPreStart = LifecycleHookPrefix + "pre-start"
+	// LifecycleHookPostStop is the label or annotation with the command
+	// run on the host after the container stopped and was removed from the
+	// OCI runtime.
+	LifecycleHookPostStop = LifecycleHookPrefix + "post-stop"
+	// LifecycleHookTimeout is the label or annotation with the duration
+	// after which a lifecycle hook is killed.
+	LifecycleHookTimeout = LifecycleHookPrefix + "timeout"
+	// LifecycleHookImage is the label or annotation with the image of a
+	// helper container the lifecycle hooks are run in, instead of the host.
+	LifecycleHookImage = LifecycleHookPrefix + "image"
+
+	// DefaultLifecycleHookTimeout is the timeout of lifecycle hooks when
+	// LifecycleHookTimeout is not set.
+	DefaultLifecycleHookTimeout = 30 * time.Second
+)
+
+// IsLifecycleHookKey returns true if the label or annotation is one of the
+// supported lifecycle hook keys.
+func IsLifecycleHookKey(key string) bool {
+	switch key {
+	case LifecycleHookPreStart, LifecycleHookPostStop, LifecycleHookTimeout, LifecycleHookImage:
+		return true
+	default:
+		return false
+	}
+}
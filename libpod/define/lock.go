@@ -0,0 +1,22 @@
+package define
+
+// LockAllocation describes the lock allocated to a container, pod or volume.
+type LockAllocation struct {
+	// LockID is the number of the lock.
+	LockID uint32
+	// Type is the type of the object, "container", "pod" or "volume".
+	Type string
+	// ID is the ID of the object, the name for volumes.
+	ID string
+	// Name is the name of the object.
+	Name string
+}
+
+// LockRenumbering describes the lock of a container, pod or volume before
+// and after the locks are renumbered.
+type LockRenumbering struct {
+	LockAllocation
+	// NewLockID is the number of the lock allocated to the object by the
+	// renumbering, nil if no lock is left for it.
+	NewLockID *uint32
+}
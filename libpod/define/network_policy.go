@@ -0,0 +1,70 @@
+package define
+
+import "slices"
+
+// NetworkPolicy restricts the traffic of the network namespace of a container.
+// It is translated from the Kubernetes NetworkPolicy objects selecting a pod
+// played with kube play --network-policy.
+type NetworkPolicy struct {
+	// Policies are the names of the NetworkPolicy objects translated.
+	Policies []string `json:"policies,omitempty"`
+	// IsolateIngress drops the incoming connections not allowed by the
+	// Ingress rules.
+	IsolateIngress bool `json:"isolateIngress,omitempty"`
+	// Ingress are the rules allowing incoming connections.
+	Ingress []NetworkPolicyRule `json:"ingress,omitempty"`
+	// IsolateEgress drops the outgoing connections not allowed by the
+	// Egress rules.
+	IsolateEgress bool `json:"isolateEgress,omitempty"`
+	// Egress are the rules allowing outgoing connections.
+	Egress []NetworkPolicyRule `json:"egress,omitempty"`
+}
+
+// NetworkPolicyRule allows the connections with the peers on the ports.
+type NetworkPolicyRule struct {
+	// AllPeers allows the connections with any peer, Pods and IPBlocks
+	// are ignored.
+	AllPeers bool `json:"allPeers,omitempty"`
+	// Pods are the names of the peer pods.  Their addresses are resolved
+	// when the rules are applied.
+	Pods []string `json:"pods,omitempty"`
+	// IPBlocks are the peer IP ranges.
+	IPBlocks []NetworkPolicyIPBlock `json:"ipBlocks,omitempty"`
+	// Ports are the ports of the connections allowed, all ports when
+	// empty.
+	Ports []NetworkPolicyPort `json:"ports,omitempty"`
+}
+
+// NetworkPolicyIPBlock is an IP range in CIDR notation without the ranges in
+// Except.
+type NetworkPolicyIPBlock struct {
+	CIDR   string   `json:"cidr"`
+	Except []string `json:"except,omitempty"`
+}
+
+// NetworkPolicyPort is a port, or a range of ports up to EndPort, of a
+// protocol.  All ports of the protocol match when Port is 0.
+type NetworkPolicyPort struct {
+	// Protocol is tcp, udp or sctp.
+	Protocol string `json:"protocol"`
+	Port     uint16 `json:"port,omitempty"`
+	EndPort  uint16 `json:"endPort,omitempty"`
+}
+
+// PeerPods returns the pods named as peers by the rules of the policy.
+func (p *NetworkPolicy) PeerPods() []string {
+	var pods []string
+	for _, rules := range [][]NetworkPolicyRule{p.Ingress, p.Egress} {
+		for _, rule := range rules {
+			if rule.AllPeers {
+				continue
+			}
+			for _, pod := range rule.Pods {
+				if !slices.Contains(pods, pod) {
+					pods = append(pods, pod)
+				}
+			}
+		}
+	}
+	return pods
+}
@@ -0,0 +1,33 @@
+package define
+
+import "fmt"
+
+// Strings used for the --numa-policy option to podman.
+// They map to the memory policies of set_mempolicy(2).
+const (
+	NUMAPolicyDefault    = "default"
+	NUMAPolicyBind       = "bind"
+	NUMAPolicyInterleave = "interleave"
+	NUMAPolicyLocal      = "local"
+	NUMAPolicyPreferred  = "preferred"
+)
+
+// ValidateNUMAPolicy validates the specified NUMA memory policy.
+func ValidateNUMAPolicy(policy string) error {
+	switch policy {
+	case "", NUMAPolicyDefault, NUMAPolicyBind, NUMAPolicyInterleave, NUMAPolicyLocal, NUMAPolicyPreferred:
+		return nil
+	default:
+		return fmt.Errorf("%w: invalid NUMA policy %q: must be %s, %s, %s, %s or %s", ErrInvalidArg, policy, NUMAPolicyDefault, NUMAPolicyBind, NUMAPolicyInterleave, NUMAPolicyLocal, NUMAPolicyPreferred)
+	}
+}
+
+// NUMAPolicyNeedsNodes returns true if the NUMA memory policy requires a
+// set of memory nodes (--cpuset-mems) to be specified.
+func NUMAPolicyNeedsNodes(policy string) bool {
+	switch policy {
+	case NUMAPolicyBind, NUMAPolicyInterleave, NUMAPolicyPreferred:
+		return true
+	}
+	return false
+}
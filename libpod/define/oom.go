@@ -0,0 +1,34 @@
+package define
+
+import "time"
+
+// OOMReport is a snapshot of the memory usage of a container taken when the
+// OOM killer killed one of its processes.
+type OOMReport struct {
+	// Time is when the OOM kill was noticed.
+	Time time.Time `json:"Time"`
+	// MemoryLimit is the memory limit of the container in bytes, 0 if it
+	// has none.
+	MemoryLimit uint64 `json:"MemoryLimit"`
+	// MemoryPeak is the highest memory usage of the container in bytes.
+	MemoryPeak uint64 `json:"MemoryPeak,omitempty"`
+	// SwapPeak is the highest swap usage of the container in bytes.
+	SwapPeak uint64 `json:"SwapPeak,omitempty"`
+	// MemoryEvents are the counters of the memory.events file of the
+	// cgroup of the container, such as oom_kill.
+	MemoryEvents map[string]uint64 `json:"MemoryEvents,omitempty"`
+	// MemoryStat is the breakdown of the memory used by the container
+	// in bytes, from the memory.stat file of its cgroup.
+	MemoryStat map[string]uint64 `json:"MemoryStat,omitempty"`
+	// Processes are the processes of the container still running when
+	// the report was taken, by decreasing resident memory.
+	Processes []OOMProcess `json:"Processes,omitempty"`
+}
+
+// OOMProcess is a process of an OOMReport.
+type OOMProcess struct {
+	PID     int    `json:"Pid"`
+	Command string `json:"Command"`
+	// RSS is the resident memory of the process in bytes.
+	RSS uint64 `json:"RSS"`
+}
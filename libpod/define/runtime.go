@@ -26,3 +26,52 @@ const (
 	// 4 minutes versus 1 minute makes a real difference.
 	ContainerCreateTimeout = 240 * time.Second
 )
+
+// DBQueryProfile is the time spent running a query of the database.
+type DBQueryProfile struct {
+	// Query is the SQL statement of the query.
+	Query string `json:"query"`
+	// Count is the number of times the query ran.
+	Count uint64 `json:"count"`
+	// Total is the total time spent running the query.
+	Total time.Duration `json:"total"`
+	// Max is the longest time spent running the query once.
+	Max time.Duration `json:"max"`
+}
+
+// RegistryCacheStats describes the use of the registry cache of the API
+// service.
+type RegistryCacheStats struct {
+	// ManifestHits is the number of manifests served from the cache.
+	ManifestHits uint64 `json:"manifestHits"`
+	// ManifestMisses is the number of manifests fetched from the
+	// upstream registries.
+	ManifestMisses uint64 `json:"manifestMisses"`
+	// BlobHits is the number of blobs served from the cache.
+	BlobHits uint64 `json:"blobHits"`
+	// BlobMisses is the number of blobs fetched from the upstream
+	// registries.
+	BlobMisses uint64 `json:"blobMisses"`
+	// UpstreamErrors is the number of failed requests to the upstream
+	// registries.
+	UpstreamErrors uint64 `json:"upstreamErrors"`
+	// BytesServed is the number of bytes of manifests and blobs served.
+	BytesServed uint64 `json:"bytesServed"`
+	// BytesFetched is the number of bytes of manifests and blobs fetched
+	// from the upstream registries.
+	BytesFetched uint64 `json:"bytesFetched"`
+	// Tags is the number of tags cached.
+	Tags int `json:"tags"`
+	// Blobs is the number of manifests and blobs cached.
+	Blobs int `json:"blobs"`
+	// Size is the size of the manifests and blobs cached.
+	Size int64 `json:"size"`
+	// MaxSize is the size the cache is kept under, 0 for no limit.
+	MaxSize int64 `json:"maxSize"`
+	// GCRemovedBlobs is the number of blobs removed to keep the cache
+	// under its maximum size.
+	GCRemovedBlobs uint64 `json:"gcRemovedBlobs"`
+	// GCFreedBytes is the size of the blobs removed to keep the cache
+	// under its maximum size.
+	GCFreedBytes uint64 `json:"gcFreedBytes"`
+}
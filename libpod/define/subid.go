@@ -0,0 +1,30 @@
+package define
+
+import "github.com/dmikushin/podman-shared/pkg/subid"
+
+// SubIDPool is a pool of subordinate IDs that --userns=auto allocates user
+// namespaces from.
+type SubIDPool struct {
+	// Ranges of the pool, as listed in /etc/subuid or /etc/subgid.
+	Ranges []subid.Range `json:"ranges"`
+	// Size is the number of IDs of the pool.
+	Size int `json:"size"`
+	// Used is the number of IDs mapped into containers.
+	Used int `json:"used"`
+	// Free is the number of IDs left for new containers.
+	Free int `json:"free"`
+}
+
+// SubIDStatus describes the subordinate ID pools of the user that
+// --userns=auto allocates user namespaces from.
+type SubIDStatus struct {
+	User string    `json:"user"`
+	UIDs SubIDPool `json:"uids"`
+	GIDs SubIDPool `json:"gids"`
+	// Containers is the number of containers using IDs of the pools.
+	Containers int `json:"containers"`
+	// AutoUserNsMinSize and AutoUserNsMaxSize bound the size of the user
+	// namespaces whose size is computed from the image.
+	AutoUserNsMinSize uint32 `json:"autoUserNsMinSize"`
+	AutoUserNsMaxSize uint32 `json:"autoUserNsMaxSize"`
+}
@@ -21,6 +21,21 @@ var (
 	buildOrigin string
 )
 
+// FeatureSharedBaseLayers is advertised by services whose graph root, or
+// image store, is on shared storage, so that containers created with
+// --shared-base-layers mount their base layers from it
+const FeatureSharedBaseLayers = "shared-base-layers"
+
+// ServiceFeatures lists the optional features usable on the host described
+// by info
+func ServiceFeatures(info *Info) []string {
+	var features []string
+	if info.Store != nil && info.Store.SharedStorage {
+		features = append(features, FeatureSharedBaseLayers)
+	}
+	return features
+}
+
 // Version is an output struct for API
 type Version struct {
 	APIVersion  string
@@ -32,6 +47,7 @@ type Version struct {
 	BuildOrigin string `json:",omitempty" yaml:",omitempty"`
 	OsArch      string
 	Os          string
+	Features    []string `json:",omitempty" yaml:",omitempty"`
 }
 
 // GetVersion returns a VersionOutput struct for API and podman
@@ -0,0 +1,13 @@
+package define
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceFeatures(t *testing.T) {
+	assert.Empty(t, ServiceFeatures(&Info{}))
+	assert.Empty(t, ServiceFeatures(&Info{Store: &StoreInfo{}}))
+	assert.Equal(t, []string{FeatureSharedBaseLayers}, ServiceFeatures(&Info{Store: &StoreInfo{SharedStorage: true}}))
+}
@@ -68,5 +68,16 @@ type InspectVolumeData struct {
 type VolumeReload struct {
 	Added   []string
 	Removed []string
-	Errors  []error
+	// Conflicts are the volumes which were not reloaded because their
+	// name is used by several volume drivers.
+	Conflicts []VolumeReloadConflict
+	Errors    []error
+}
+
+// VolumeReloadConflict is a volume name used by several volume drivers.
+type VolumeReloadConflict struct {
+	Name string
+	// Drivers are the drivers using the name, starting with the driver
+	// of the existing volume if any.
+	Drivers []string
 }
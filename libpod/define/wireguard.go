@@ -0,0 +1,28 @@
+package define
+
+const (
+	// WireGuardDriver is the driver of WireGuard overlay networks,
+	// implemented by the netavark plugin of the same name.
+	WireGuardDriver = "wireguard"
+	// WireGuardConfigDirOption is the option of a WireGuard network set by
+	// Podman to the directory of the private key and the peers of this
+	// host, for the plugin.
+	WireGuardConfigDirOption = "config_dir"
+)
+
+// WireGuardPeer is a host of a WireGuard network, as the other hosts of the
+// network see it.
+type WireGuardPeer struct {
+	// Host is the host name of the host
+	Host string `json:"host"`
+	// PublicKey is the WireGuard public key of the host, base64 encoded
+	PublicKey string `json:"public_key"`
+	// Endpoint is the address the other hosts reach the host at, host:port
+	Endpoint string `json:"endpoint"`
+	// AllowedIPs are the subnets of the network on the host
+	AllowedIPs []string `json:"allowed_ips"`
+	// Containers are the addresses of the containers on the network on
+	// the host by name, added to /etc/hosts of the containers on the
+	// network on the other hosts
+	Containers map[string][]string `json:"containers,omitempty"`
+}
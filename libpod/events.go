@@ -6,10 +6,12 @@ import (
 	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/libpod/events"
 	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libnetwork/types"
 )
 
 // newEventer returns an eventer that can be used to read/write events
@@ -59,6 +61,9 @@ func (c *Container) newContainerEventWithInspectData(status events.Status, healt
 		}
 	}
 	e.HealthFailingStreak = healthCheckResult.FailingStreak
+	if status == events.Create || status == events.Start {
+		e.PublishedPorts = formatPublishedPorts(c.config.PortMappings)
+	}
 
 	e.Details = events.Details{
 		PodID:      c.PodID(),
@@ -94,6 +99,52 @@ func (c *Container) newContainerEventWithInspectData(status events.Status, healt
 	return c.runtime.eventer.Write(e)
 }
 
+// formatPublishedPorts formats the port mappings of a container for events
+// as a comma separated list of hostIP:hostPort->containerPort/protocol.
+func formatPublishedPorts(ports []types.PortMapping) string {
+	mappings := make([]string, 0, len(ports))
+	for _, port := range ports {
+		hostIP := port.HostIP
+		if hostIP == "" {
+			hostIP = "0.0.0.0"
+		}
+		if port.Range > 1 {
+			mappings = append(mappings, fmt.Sprintf("%s:%d-%d->%d-%d/%s", hostIP, port.HostPort, port.HostPort+port.Range-1,
+				port.ContainerPort, port.ContainerPort+port.Range-1, port.Protocol))
+		} else {
+			mappings = append(mappings, fmt.Sprintf("%s:%d->%d/%s", hostIP, port.HostPort, port.ContainerPort, port.Protocol))
+		}
+	}
+	return strings.Join(mappings, ",")
+}
+
+// newContainerOOMEvent creates a new event for a container killed by the
+// OOM killer, with the OOM report of the container.
+func (c *Container) newContainerOOMEvent() {
+	e := events.NewEvent(events.OOM)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+	e.PodID = c.PodID()
+	if c.state.OOMReport != nil {
+		report, err := json.Marshal(c.state.OOMReport)
+		if err != nil {
+			logrus.Errorf("Encoding OOM report of container %s: %v", c.ID(), err)
+		} else {
+			e.OOMReport = string(report)
+		}
+	}
+
+	e.Details = events.Details{
+		Attributes: c.Labels(),
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write container OOM event: %q", err)
+	}
+}
+
 // newContainerExitedEvent creates a new event for a container's death
 func (c *Container) newContainerExitedEvent(exitCode int32) {
 	e := events.NewEvent(events.Exited)
@@ -114,6 +165,54 @@ func (c *Container) newContainerExitedEvent(exitCode int32) {
 	}
 }
 
+// newContainerHookEvent creates a new event for a lifecycle hook of the
+// container, with the exit code of the hook and the error if it failed.
+func (c *Container) newContainerHookEvent(stage string, exitCode int, hookErr error) {
+	e := events.NewEvent(events.Hook)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+	e.PodID = c.PodID()
+	if exitCode >= 0 {
+		e.ContainerExitCode = &exitCode
+	}
+
+	attributes := c.Labels()
+	attributes["hook"] = stage
+	if hookErr != nil {
+		attributes["hook_error"] = hookErr.Error()
+	}
+	e.Details = events.Details{
+		Attributes: attributes,
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write container hook event: %q", err)
+	}
+}
+
+// newContainerFreezeFailedEvent creates a new event for a container which
+// could not be paused, with the error.
+func (c *Container) newContainerFreezeFailedEvent(freezeErr error) {
+	e := events.NewEvent(events.FreezeFailed)
+	e.ID = c.ID()
+	e.Name = c.Name()
+	e.Image = c.config.RootfsImageName
+	e.Type = events.Container
+	e.PodID = c.PodID()
+
+	attributes := c.Labels()
+	attributes["freeze_error"] = freezeErr.Error()
+	e.Details = events.Details{
+		Attributes: attributes,
+	}
+
+	if err := c.runtime.eventer.Write(e); err != nil {
+		logrus.Errorf("Unable to write container freeze_failed event: %q", err)
+	}
+}
+
 // newExecDiedEvent creates a new event for an exec session's death
 func (c *Container) newExecDiedEvent(sessionID string, exitCode int) {
 	e := events.NewEvent(events.ExecDied)
@@ -0,0 +1,63 @@
+package events
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	// CloudEventsSpecVersion is the version of the CloudEvents specification
+	// events are converted to.
+	CloudEventsSpecVersion = "1.0"
+	// CloudEventsFormat is the name of the stream format of events in the
+	// CloudEvents JSON format.
+	CloudEventsFormat = "cloudevents"
+	// CloudEventsContentType is the media type of events in the CloudEvents
+	// JSON format.
+	CloudEventsContentType = "application/cloudevents+json"
+	// cloudEventsTypePrefix prefixes the type of the events, followed by the
+	// type and status of the Podman event, e.g. io.podman.container.start.
+	cloudEventsTypePrefix = "io.podman."
+)
+
+// CloudEvent is an event in the JSON format of the CloudEvents specification.
+// The data of the event is the Podman event.
+type CloudEvent struct {
+	SpecVersion     string `json:"specversion"`
+	ID              string `json:"id"`
+	Source          string `json:"source"`
+	Type            string `json:"type"`
+	Subject         string `json:"subject,omitempty"`
+	Time            string `json:"time"`
+	DataContentType string `json:"datacontenttype"`
+	Data            *Event `json:"data"`
+}
+
+// CloudEventSource returns the source of the events of Podman on the host in
+// CloudEvents, podman://<hostname>.
+func CloudEventSource(hostname string) string {
+	return "podman://" + hostname
+}
+
+// ToCloudEvent converts the event to a CloudEvent of the source.  The ID of
+// the CloudEvent is derived from the event, so the same event always has the
+// same ID and consumers can deduplicate events read several times.
+func (e *Event) ToCloudEvent(source string) *CloudEvent {
+	subject := e.ID
+	if subject == "" {
+		subject = e.Name
+	}
+	id := digest.FromString(fmt.Sprintf("%s/%s/%s/%s/%d", e.Type, e.Status, e.ID, e.Name, e.Time.UnixNano()))
+	return &CloudEvent{
+		SpecVersion:     CloudEventsSpecVersion,
+		ID:              id.Encoded(),
+		Source:          source,
+		Type:            cloudEventsTypePrefix + e.Type.String() + "." + e.Status.String(),
+		Subject:         subject,
+		Time:            e.Time.UTC().Format(time.RFC3339Nano),
+		DataContentType: "application/json",
+		Data:            e,
+	}
+}
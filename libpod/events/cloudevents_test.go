@@ -0,0 +1,45 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToCloudEvent(t *testing.T) {
+	e := NewEvent(Start)
+	e.Type = Container
+	e.ID = "abc"
+	e.Name = "ctr"
+	e.Time = time.Date(2024, 5, 2, 10, 15, 3, 218453711, time.FixedZone("CEST", 2*60*60))
+
+	ce := e.ToCloudEvent(CloudEventSource("host"))
+	assert.Equal(t, "1.0", ce.SpecVersion)
+	assert.Equal(t, "podman://host", ce.Source)
+	assert.Equal(t, "io.podman.container.start", ce.Type)
+	assert.Equal(t, "abc", ce.Subject)
+	assert.Equal(t, "2024-05-02T08:15:03.218453711Z", ce.Time)
+	assert.Len(t, ce.ID, 64)
+
+	// the same event has the same ID, others have different IDs
+	assert.Equal(t, ce.ID, e.ToCloudEvent("other").ID)
+	other := e
+	other.Time = other.Time.Add(time.Nanosecond)
+	assert.NotEqual(t, ce.ID, other.ToCloudEvent(ce.Source).ID)
+
+	b, err := json.Marshal(ce)
+	require.NoError(t, err)
+	var decoded map[string]any
+	require.NoError(t, json.Unmarshal(b, &decoded))
+	assert.Equal(t, "application/json", decoded["datacontenttype"])
+	assert.Equal(t, "ctr", decoded["data"].(map[string]any)["Name"])
+
+	// events without ID, e.g. of networks, use the name as subject
+	network := NewEvent(Create)
+	network.Type = Network
+	network.Name = "net"
+	assert.Equal(t, "net", network.ToCloudEvent("source").Subject)
+}
@@ -46,6 +46,13 @@ type Event struct {
 	HealthFailingStreak int `json:"health_failing_streak,omitempty"`
 	// Error code for certain events involving errors.
 	Error string `json:"error,omitempty"`
+	// PublishedPorts lists the published ports of a container in the
+	// form hostIP:hostPort->containerPort/protocol, including host ports
+	// that were allocated dynamically. Only set for create and start events.
+	PublishedPorts string `json:",omitempty"`
+	// OOMReport is the JSON encoded OOM report of a container, only set
+	// for oom events.
+	OOMReport string `json:",omitempty"`
 
 	Details
 }
@@ -158,10 +165,14 @@ const (
 	Exited Status = "died"
 	// Export ...
 	Export Status = "export"
+	// FreezeFailed indicates that a container could not be paused.
+	FreezeFailed Status = "freeze_failed"
 	// HealthStatus ...
 	HealthStatus Status = "health_status"
 	// History ...
 	History Status = "history"
+	// Hook indicates that a lifecycle hook of a container was run.
+	Hook Status = "hook"
 	// Import ...
 	Import Status = "import"
 	// Init ...
@@ -176,6 +187,9 @@ const (
 	NetworkConnect Status = "connect"
 	// NetworkDisconnect
 	NetworkDisconnect Status = "disconnect"
+	// OOM indicates that a process of a container was killed by the OOM
+	// killer.
+	OOM Status = "oom"
 	// Pause ...
 	Pause Status = "pause"
 	// Prune ...
@@ -212,6 +226,9 @@ const (
 	Sync Status = "sync"
 	// Tag ...
 	Tag Status = "tag"
+	// Thaw indicates that a paused container was unpaused as its freeze
+	// timeout expired.
+	Thaw Status = "thaw"
 	// Unmount ...
 	Unmount Status = "unmount"
 	// Unpause ...
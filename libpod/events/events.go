@@ -64,6 +64,12 @@ func (e *Event) ToHumanReadable(truncate bool) string {
 			humanFormat += fmt.Sprintf(", health_failing_streak=%d", e.HealthFailingStreak)
 			humanFormat += fmt.Sprintf(", health_log=%s", e.HealthLog)
 		}
+		if e.PublishedPorts != "" {
+			humanFormat += fmt.Sprintf(", published_ports=%s", e.PublishedPorts)
+		}
+		if e.OOMReport != "" {
+			humanFormat += fmt.Sprintf(", oom_report=%s", e.OOMReport)
+		}
 		// check if the container has labels and add it to the output
 		if len(e.Attributes) > 0 {
 			for k, v := range e.Attributes {
@@ -158,10 +164,14 @@ func StringToStatus(name string) (Status, error) {
 		return Exited, nil
 	case Export.String():
 		return Export, nil
+	case FreezeFailed.String():
+		return FreezeFailed, nil
 	case HealthStatus.String():
 		return HealthStatus, nil
 	case History.String():
 		return History, nil
+	case Hook.String():
+		return Hook, nil
 	case Import.String():
 		return Import, nil
 	case Init.String():
@@ -176,6 +186,8 @@ func StringToStatus(name string) (Status, error) {
 		return NetworkConnect, nil
 	case NetworkDisconnect.String():
 		return NetworkDisconnect, nil
+	case OOM.String():
+		return OOM, nil
 	case Pause.String():
 		return Pause, nil
 	case Prune.String():
@@ -210,6 +222,8 @@ func StringToStatus(name string) (Status, error) {
 		return Sync, nil
 	case Tag.String():
 		return Tag, nil
+	case Thaw.String():
+		return Thaw, nil
 	case Unmount.String():
 		return Unmount, nil
 	case Unpause.String():
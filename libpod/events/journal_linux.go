@@ -66,6 +66,12 @@ func (e EventJournalD) Write(ee Event) error {
 		if len(ee.Details.ContainerInspectData) > 0 {
 			m["PODMAN_CONTAINER_INSPECT_DATA"] = ee.Details.ContainerInspectData
 		}
+		if ee.PublishedPorts != "" {
+			m["PODMAN_PUBLISHED_PORTS"] = ee.PublishedPorts
+		}
+		if ee.OOMReport != "" {
+			m["PODMAN_OOM_REPORT"] = ee.OOMReport
+		}
 	case Network:
 		m["PODMAN_ID"] = ee.ID
 		m["PODMAN_NETWORK_NAME"] = ee.Network
@@ -264,6 +270,8 @@ func newEventFromJournalEntry(entry *sdjournal.JournalEntry) (*Event, error) {
 			}
 		}
 		newEvent.Details.ContainerInspectData = entry.Fields["PODMAN_CONTAINER_INSPECT_DATA"]
+		newEvent.PublishedPorts = entry.Fields["PODMAN_PUBLISHED_PORTS"]
+		newEvent.OOMReport = entry.Fields["PODMAN_OOM_REPORT"]
 	case Network:
 		newEvent.ID = entry.Fields["PODMAN_ID"]
 		newEvent.Network = entry.Fields["PODMAN_NETWORK_NAME"]
@@ -205,6 +205,13 @@ func (c *Container) processHealthCheckStatus(status string) error {
 			return fmt.Errorf("stopping container after health-check turned unhealthy: %w", err)
 		}
 
+	case define.HealthCheckOnFailureActionRestartUnit:
+		// The restart of the unit stops the container, and with it
+		// the health check running this, so it is not waited for.
+		if err := c.restartSystemdUnit(); err != nil {
+			return fmt.Errorf("restarting systemd unit after health-check turned unhealthy: %w", err)
+		}
+
 	default: // Should not happen but better be safe than sorry
 		return fmt.Errorf("unsupported on-failure action %d", c.config.HealthCheckOnFailureAction)
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/errorhandling"
 	"github.com/dmikushin/podman-shared/pkg/rootless"
 	"github.com/dmikushin/podman-shared/pkg/systemd"
+	systemdDefine "github.com/dmikushin/podman-shared/pkg/systemd/define"
 	"github.com/sirupsen/logrus"
 	systemdCommon "go.podman.io/common/pkg/systemd"
 )
@@ -111,6 +112,28 @@ func (c *Container) startTimer(isStartup bool) error {
 	return nil
 }
 
+// restartSystemdUnit queues a restart of the systemd unit running the
+// container, as given by its PODMAN_SYSTEMD_UNIT label, without waiting for
+// it.
+func (c *Container) restartSystemdUnit() error {
+	unit, exists := c.config.Labels[systemdDefine.EnvVariable]
+	if !exists {
+		return fmt.Errorf("container %s is not running in a systemd unit: the %s label is not set", c.ID(), systemdDefine.EnvVariable)
+	}
+
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		return fmt.Errorf("unable to get systemd connection to restart unit %q: %w", unit, err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.RestartUnitContext(context.Background(), unit, "replace", nil); err != nil {
+		return fmt.Errorf("restarting systemd unit %q: %w", unit, err)
+	}
+	logrus.Infof("Restarting systemd unit %q of unhealthy container %s", unit, c.ID())
+	return nil
+}
+
 // removeTransientFiles removes the systemd timer and unit files
 // for the container
 func (c *Container) removeTransientFiles(ctx context.Context, isStartup bool, unitName string) error {
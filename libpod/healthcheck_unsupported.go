@@ -4,6 +4,9 @@ package libpod
 
 import (
 	"context"
+	"fmt"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
 )
 
 // createTimer systemd timers for healthchecks of a container
@@ -16,6 +19,11 @@ func (c *Container) startTimer(_ bool) error {
 	return nil
 }
 
+// restartSystemdUnit restarts the systemd unit running the container
+func (c *Container) restartSystemdUnit() error {
+	return fmt.Errorf("restarting the systemd unit of a container is not supported on this platform: %w", define.ErrNotImplemented)
+}
+
 // removeTransientFiles removes the systemd timer and unit files
 // for the container
 func (c *Container) removeTransientFiles(_ context.Context, _ bool, _ string) error {
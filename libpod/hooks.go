@@ -0,0 +1,47 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/dmikushin/podman-shared/pkg/rootless"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/common/pkg/hooks"
+)
+
+// HooksDirs returns the directories OCI hooks are read from when a container
+// starts, in order of precedence.  Without configured directories, root
+// falls back to the implicit default directories.
+func (r *Runtime) HooksDirs() []string {
+	if dirs := r.config.Engine.HooksDir.Get(); len(dirs) > 0 {
+		return dirs
+	}
+	if rootless.IsRootless() {
+		return nil
+	}
+	return []string{hooks.DefaultDir, hooks.OverrideDir}
+}
+
+// ReloadHooksDirs reads the hooks directories from containers.conf again,
+// unless they were set with WithHooksDir.  The hooks themselves are read
+// from the directories every time a container starts, so they do not need
+// to be reloaded.
+func (r *Runtime) ReloadHooksDirs() error {
+	if r.hooksDirsFromOptions {
+		logrus.Debugf("Not reloading hooks directories set by option: %v", r.config.Engine.HooksDir.Get())
+		return nil
+	}
+	conf, err := config.New(nil)
+	if err != nil {
+		return fmt.Errorf("reading hooks directories: %w", err)
+	}
+	dirs := conf.Engine.HooksDir.Get()
+	if !slices.Equal(dirs, r.config.Engine.HooksDir.Get()) {
+		logrus.Infof("Reloaded hooks directories: %v", dirs)
+	}
+	r.config.Engine.HooksDir.Set(dirs)
+	return nil
+}
@@ -0,0 +1,87 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+)
+
+const (
+	// IdempotencyKeyTTL is how long the response to a request with an
+	// idempotency key is returned to requests reusing the key.
+	IdempotencyKeyTTL = 24 * time.Hour
+	// idempotencyPendingTimeout is how long a request with an idempotency
+	// key may be processed before its key is considered abandoned, e.g.
+	// because the API service was killed while processing it.
+	idempotencyPendingTimeout = 30 * time.Minute
+)
+
+// IdempotencyKey is the key of the Idempotency-Key header of an API request
+// creating an object, with the response to the first request using the key.
+// Requests reusing the key get the same response instead of creating another
+// object.
+type IdempotencyKey struct {
+	// Key is the value of the header.
+	Key string `json:"key"`
+	// Operation is the operation of the request, e.g. "container create".
+	Operation string `json:"operation"`
+	// RequestHash is a hash of the request, requests reusing the key must
+	// have the same hash.
+	RequestHash string `json:"requestHash"`
+	// Created is the time the first request using the key was received.
+	Created time.Time `json:"created"`
+	// Expires is the time the key is removed.
+	Expires time.Time `json:"expires"`
+	// StatusCode is the status code of the response, 0 while the first
+	// request is processed.
+	StatusCode int `json:"statusCode,omitempty"`
+	// ContentType is the content type of the response.
+	ContentType string `json:"contentType,omitempty"`
+	// Body is the body of the response.
+	Body []byte `json:"body,omitempty"`
+}
+
+// Pending returns true if the first request using the key is processed.
+func (k *IdempotencyKey) Pending() bool {
+	return k.StatusCode == 0 && time.Since(k.Created) < idempotencyPendingTimeout
+}
+
+// expired returns true if the key must be removed at time now.
+func (k *IdempotencyKey) expired(now time.Time) bool {
+	return !now.Before(k.Expires) || (k.StatusCode == 0 && now.Sub(k.Created) >= idempotencyPendingTimeout)
+}
+
+// ReserveIdempotencyKey reserves the idempotency key of a request.  If the
+// key is already in use, the key and the response of the first request using
+// it are returned and the key is not reserved.  Otherwise nil is returned and
+// the response to the request must be saved with SaveIdempotencyKey, or the
+// key released with RemoveIdempotencyKey if the request failed.
+func (r *Runtime) ReserveIdempotencyKey(key *IdempotencyKey) (*IdempotencyKey, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	key.Created = time.Now()
+	key.Expires = key.Created.Add(IdempotencyKeyTTL)
+	key.StatusCode = 0
+	return r.state.AddIdempotencyKey(key)
+}
+
+// SaveIdempotencyKey saves the response to the request which reserved the
+// idempotency key.
+func (r *Runtime) SaveIdempotencyKey(key *IdempotencyKey) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+	return r.state.UpdateIdempotencyKey(key)
+}
+
+// RemoveIdempotencyKey releases the idempotency key of a failed request, so
+// the request can be retried with the same key.
+func (r *Runtime) RemoveIdempotencyKey(key string) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+	return r.state.RemoveIdempotencyKey(key)
+}
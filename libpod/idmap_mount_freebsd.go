@@ -0,0 +1,15 @@
+//go:build !remote
+
+package libpod
+
+import (
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+)
+
+func idmappedMountSupported(source string, uidMappings, gidMappings []spec.LinuxIDMapping) bool {
+	return false
+}
+
+func (c *Container) chownIDMappedMount(source string, uidMappings, gidMappings []spec.LinuxIDMapping) error {
+	return nil
+}
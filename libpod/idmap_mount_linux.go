@@ -0,0 +1,105 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/util"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/storage/pkg/idmap"
+	"golang.org/x/sys/unix"
+)
+
+// idmappedMountSupported reports whether the kernel lets the current user
+// create an idmapped mount of source with the given mappings.  Rootless
+// users can only idmap mounts of file systems mounted in their user
+// namespace, and not every file system supports idmapped mounts.
+func idmappedMountSupported(source string, uidMappings, gidMappings []spec.LinuxIDMapping) bool {
+	pid, cleanup, err := idmap.CreateUsernsProcess(util.RuntimeSpecToIDtools(uidMappings), util.RuntimeSpecToIDtools(gidMappings))
+	if err != nil {
+		logrus.Debugf("Creating a user namespace to probe idmapped mounts: %v", err)
+		return false
+	}
+	defer cleanup()
+
+	userNsFile, err := os.Open(fmt.Sprintf("/proc/%d/ns/user", pid))
+	if err != nil {
+		logrus.Debugf("Opening the user namespace to probe idmapped mounts: %v", err)
+		return false
+	}
+	defer userNsFile.Close()
+
+	// the detached mount is never attached, closing it is enough to drop it
+	treeFd, err := unix.OpenTree(unix.AT_FDCWD, source, unix.OPEN_TREE_CLONE|unix.OPEN_TREE_CLOEXEC)
+	if err != nil {
+		logrus.Debugf("Cloning %s to probe idmapped mounts: %v", source, err)
+		return false
+	}
+	defer unix.Close(treeFd)
+
+	if err := unix.MountSetattr(treeFd, "", unix.AT_EMPTY_PATH, &unix.MountAttr{
+		Attr_set:  unix.MOUNT_ATTR_IDMAP,
+		Userns_fd: uint64(userNsFile.Fd()),
+	}); err != nil {
+		logrus.Debugf("Idmapped mounts of %s are not supported: %v", source, err)
+		return false
+	}
+	return true
+}
+
+// mapFileSystemID returns the ID an idmapped mount with the given mappings
+// shows for the file system ID id, and false if the mappings do not cover
+// it.
+func mapFileSystemID(mappings []spec.LinuxIDMapping, id uint32) (uint32, bool) {
+	for _, m := range mappings {
+		if id >= m.ContainerID && id-m.ContainerID < m.Size {
+			return m.HostID + id - m.ContainerID, true
+		}
+	}
+	return id, false
+}
+
+// chownIDMappedMount changes the owner of the files under source to the
+// IDs an idmapped mount with the given mappings would show, so that the
+// container sees the same ownership without the mount.  It is the
+// fallback for kernels and file systems not supporting idmapped mounts,
+// and it is only done the first time the container starts.
+func (c *Container) chownIDMappedMount(source string, uidMappings, gidMappings []spec.LinuxIDMapping) error {
+	if !c.ensureState(define.ContainerStateConfigured, define.ContainerStateUnknown) {
+		return nil
+	}
+	logrus.Warnf("Idmapped mounts of %s are not supported, changing the ownership of its files instead", source)
+	return filepath.WalkDir(source, func(path string, _ fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		info, err := os.Lstat(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		st, ok := info.Sys().(*syscall.Stat_t)
+		if !ok {
+			return nil
+		}
+		uid, uidMapped := mapFileSystemID(uidMappings, st.Uid)
+		gid, gidMapped := mapFileSystemID(gidMappings, st.Gid)
+		if !uidMapped && !gidMapped {
+			return nil
+		}
+		if err := os.Lchown(path, int(uid), int(gid)); err != nil {
+			return fmt.Errorf("changing the ownership of %s for the idmapped mount: %w", path, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,128 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libimage"
+	"go.podman.io/storage"
+)
+
+// imageLayerUsage describes the disk usage of a layer of an image.
+type imageLayerUsage struct {
+	layer *storage.Layer
+	// size of the layer on disk
+	size int64
+	// number of other images using the layer
+	sharedWith int
+	// names of the images the layer is the top layer of
+	topLayerOf []string
+}
+
+// ImageDiskUsageTree generates a tree of the layers of the image showing
+// how much disk space each layer uses, how many other images share the
+// layer and whether it is on shared storage.  Layers shared with other
+// images are stored once, so only the size of the layers not shared is
+// freed when removing the image.
+func (r *Runtime) ImageDiskUsageTree(img *libimage.Image) (string, error) {
+	layers, err := r.store.Layers()
+	if err != nil {
+		return "", err
+	}
+	images, err := r.store.Images()
+	if err != nil {
+		return "", err
+	}
+
+	layersByID := make(map[string]*storage.Layer, len(layers))
+	for i := range layers {
+		layersByID[layers[i].ID] = &layers[i]
+	}
+
+	// Count the images using each layer and note the images each layer
+	// is the top layer of.
+	users := make(map[string]int)
+	topLayerOf := make(map[string][]string)
+	for _, storageImage := range images {
+		if storageImage.TopLayer != "" {
+			topLayerOf[storageImage.TopLayer] = append(topLayerOf[storageImage.TopLayer], storageImage.Names...)
+		}
+		if storageImage.ID == img.ID() {
+			continue
+		}
+		for layer := layersByID[storageImage.TopLayer]; layer != nil; layer = layersByID[layer.Parent] {
+			users[layer.ID]++
+		}
+	}
+
+	var usages []imageLayerUsage
+	var uniqueSize, sharedSize int64
+	for layer := layersByID[img.TopLayer()]; layer != nil; layer = layersByID[layer.Parent] {
+		size, err := r.LayerSize(layer)
+		if err != nil {
+			return "", err
+		}
+		usage := imageLayerUsage{
+			layer:      layer,
+			size:       size,
+			sharedWith: users[layer.ID],
+			topLayerOf: topLayerOf[layer.ID],
+		}
+		if usage.sharedWith > 0 {
+			sharedSize += usage.size
+		} else {
+			uniqueSize += usage.size
+		}
+		usages = append(usages, usage)
+	}
+
+	imageRootShared, err := isPathOnSharedStorage(r.imageStorageRoot())
+	if err != nil {
+		logrus.Debugf("Failed to check if image storage is on shared storage: %v", err)
+	}
+
+	repoTags, err := img.RepoTags()
+	if err != nil {
+		return "", err
+	}
+
+	sb := &strings.Builder{}
+	fmt.Fprintf(sb, "Image ID:    %s\n", img.ID()[:12])
+	fmt.Fprintf(sb, "Tags:        %s\n", repoTags)
+	fmt.Fprintf(sb, "Size:        %v\n", units.HumanSizeWithPrecision(float64(uniqueSize+sharedSize), 4))
+	fmt.Fprintf(sb, "Unique Size: %v\n", units.HumanSizeWithPrecision(float64(uniqueSize), 4))
+	fmt.Fprintf(sb, "Shared Size: %v\n", units.HumanSizeWithPrecision(float64(sharedSize), 4))
+	if len(usages) == 0 {
+		sb.WriteString("No Image Layers")
+		return sb.String(), nil
+	}
+	sb.WriteString("Image Layers\n")
+
+	// As with `podman image tree`, the base layer is printed first.
+	for i := len(usages) - 1; i >= 0; i-- {
+		usage := usages[i]
+		prefix := "├── "
+		if i == 0 {
+			prefix = "└── "
+		}
+		shared := "unique"
+		if usage.sharedWith > 0 {
+			shared = fmt.Sprintf("shared with %d other image(s)", usage.sharedWith)
+		}
+		fmt.Fprintf(sb, "%sID: %s Size: %7v %s", prefix, usage.layer.ID[:12], units.HumanSizeWithPrecision(float64(usage.size), 4), shared)
+		if usage.layer.ReadOnly || imageRootShared {
+			sb.WriteString(" on shared storage")
+		}
+		if len(usage.topLayerOf) > 0 {
+			fmt.Fprintf(sb, " Top Layer of: %s", usage.topLayerOf)
+		}
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String(), nil
+}
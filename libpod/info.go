@@ -7,9 +7,12 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -20,6 +23,8 @@ import (
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/libpod/linkmode"
 	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/hooks"
+	hooksv1 "go.podman.io/common/pkg/hooks/1.0.0"
 	"go.podman.io/common/pkg/version"
 	"go.podman.io/image/v5/pkg/sysregistriesv2"
 	"go.podman.io/storage"
@@ -73,6 +78,7 @@ func (r *Runtime) info() (*define.Info, error) {
 	info.Plugins.Volume = volumePlugins
 	info.Plugins.Network = r.network.Drivers()
 	info.Plugins.Log = logDrivers
+	info.Plugins.Hooks = r.hooksInfo()
 
 	info.Registries = registries
 	return &info, nil
@@ -146,6 +152,7 @@ func (r *Runtime) hostInfo() (*define.HostInfo, error) {
 		info.Conmon = conmonInfo
 		info.OCIRuntime = ociruntimeInfo
 	}
+	info.Wasm, _ = r.WasmRuntime()
 
 	duration, err := util.ReadUptime()
 	if err != nil {
@@ -246,6 +253,21 @@ func (r *Runtime) storeInfo() (*define.StoreInfo, error) {
 		VolumePath:         r.config.Engine.VolumePath,
 		ConfigFile:         configFile,
 		TransientStore:     r.store.TransientStore(),
+		ReadOnlyGraphRoot:  r.readOnlyGraphRoot,
+	}
+
+	sharedStorage, err := isPathOnSharedStorage(r.imageStorageRoot())
+	if err != nil {
+		logrus.Debugf("Failed to check if image storage is on shared storage: %v", err)
+	}
+	info.SharedStorage = sharedStorage
+
+	if r.sharedLayerCache != nil {
+		cacheInfo, err := r.sharedLayerCache.info()
+		if err != nil {
+			logrus.Warnf("Failed to read the shared layer cache: %v", err)
+		}
+		info.SharedLayerCache = cacheInfo
 	}
 
 	graphOptions := map[string]any{}
@@ -290,6 +312,32 @@ func (r *Runtime) storeInfo() (*define.StoreInfo, error) {
 	return &info, nil
 }
 
+// hooksInfo returns the OCI hooks found in the hooks directories. As when
+// running containers, a hook overrides hooks of the same name in earlier
+// directories.
+func (r *Runtime) hooksInfo() []define.HookInfo {
+	found := make(map[string]define.HookInfo)
+	for _, dir := range r.HooksDirs() {
+		dirHooks := make(map[string]*hooksv1.Hook)
+		if err := hooks.ReadDir(dir, []string{"precreate", "poststop"}, dirHooks); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logrus.Warnf("Reading hooks from %s: %v", dir, err)
+		}
+		for name, hook := range dirHooks {
+			found[name] = define.HookInfo{
+				Name:   name,
+				Path:   filepath.Join(dir, name),
+				Stages: hook.Stages,
+			}
+		}
+	}
+
+	hooksInfo := make([]define.HookInfo, 0, len(found))
+	for _, name := range slices.Sorted(maps.Keys(found)) {
+		hooksInfo = append(hooksInfo, found[name])
+	}
+	return hooksInfo
+}
+
 // GetHostDistributionInfo returns a map containing the host's distribution and version
 func (r *Runtime) GetHostDistributionInfo() define.DistributionInfo {
 	// Populate values in case we cannot find the values
@@ -4,9 +4,13 @@ package libpod
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"os"
+	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -21,6 +25,7 @@ import (
 	"go.podman.io/common/pkg/cgroups"
 	"go.podman.io/common/pkg/seccomp"
 	"go.podman.io/common/pkg/version"
+	"go.podman.io/storage/pkg/parsers"
 	"go.podman.io/storage/pkg/unshare"
 )
 
@@ -92,6 +97,12 @@ func (r *Runtime) setPlatformHostInfo(info *define.HostInfo) error {
 		info.Pasta = program
 	}
 
+	numaNodes, err := getNUMANodes(sysfsNodeDir)
+	if err != nil {
+		logrus.Warnf("Failed to retrieve NUMA topology: %v", err)
+	}
+	info.NUMA = numaNodes
+
 	if rootless.IsRootless() {
 		uidmappings, gidmappings, err := unshare.GetHostIDMappings("")
 		if err != nil {
@@ -107,6 +118,77 @@ func (r *Runtime) setPlatformHostInfo(info *define.HostInfo) error {
 	return nil
 }
 
+const sysfsNodeDir = "/sys/devices/system/node"
+
+// getNUMANodes returns the online NUMA nodes found in the given sysfs node
+// directory, with their CPUs and memory.
+func getNUMANodes(nodeDir string) ([]define.NUMANode, error) {
+	online, err := os.ReadFile(filepath.Join(nodeDir, "online"))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// Kernel built without NUMA support
+			return nil, nil
+		}
+		return nil, err
+	}
+	ids, err := parsers.ParseUintList(strings.TrimSpace(string(online)))
+	if err != nil {
+		return nil, fmt.Errorf("parsing online NUMA nodes: %w", err)
+	}
+	nodes := make([]define.NUMANode, 0, len(ids))
+	for _, id := range slices.Sorted(maps.Keys(ids)) {
+		node := define.NUMANode{ID: id}
+		path := filepath.Join(nodeDir, fmt.Sprintf("node%d", id))
+		cpus, err := os.ReadFile(filepath.Join(path, "cpulist"))
+		if err != nil {
+			return nil, err
+		}
+		node.CPUs = strings.TrimSpace(string(cpus))
+		node.MemTotal, node.MemFree, err = readNUMANodeMeminfo(filepath.Join(path, "meminfo"))
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes, nil
+}
+
+// readNUMANodeMeminfo returns the total and free memory in bytes of a NUMA node.
+// Lines in the file are formatted as "Node 0 MemTotal:       16318932 kB".
+func readNUMANodeMeminfo(path string) (int64, int64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+	var memTotal, memFree int64
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		var dest *int64
+		switch fields[2] {
+		case "MemTotal:":
+			dest = &memTotal
+		case "MemFree:":
+			dest = &memFree
+		default:
+			continue
+		}
+		value, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("unable to parse %s value %q: %w", fields[2], fields[3], err)
+		}
+		if len(fields) > 4 && fields[4] == "kB" {
+			value *= 1024
+		}
+		*dest = value
+	}
+	return memTotal, memFree, scanner.Err()
+}
+
 func statToPercent(stats []string) (*define.CPUUsage, error) {
 	userTotal, err := strconv.ParseFloat(stats[1], 64)
 	if err != nil {
@@ -4,6 +4,8 @@ package libpod
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
@@ -59,3 +61,50 @@ func Test_statToPercent(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseOCIRuntimeFeatures(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		want    []string
+	}{
+		{
+			name:    "crun",
+			version: "crun version 1.14\ncommit: 667e6ebd4e2442d39512e36215bc5c2e18a6ae34\nrundir: /run/user/1000/crun\nspec: 1.0.0\n+SYSTEMD +SELINUX +APPARMOR +CAP +SECCOMP +EBPF +CRIU +WASM:wasmedge +YAJL",
+			want:    []string{"systemd", "selinux", "apparmor", "cap", "seccomp", "ebpf", "criu", "wasm:wasmedge", "yajl"},
+		},
+		{
+			name:    "runc",
+			version: "runc version 1.1.12\ncommit: v1.1.12-0-g51d5e946\nspec: 1.0.2-dev\ngo: go1.21.6\nlibseccomp: 2.5.5",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, parseOCIRuntimeFeatures(tt.version))
+		})
+	}
+}
+
+func Test_getNUMANodes(t *testing.T) {
+	dir := t.TempDir()
+	nodes, err := getNUMANodes(dir)
+	assert.NoError(t, err)
+	assert.Nil(t, nodes)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "online"), []byte("0-1\n"), 0o644))
+	for i, cpus := range []string{"0-3", "4-7"} {
+		nodeDir := filepath.Join(dir, fmt.Sprintf("node%d", i))
+		assert.NoError(t, os.Mkdir(nodeDir, 0o755))
+		assert.NoError(t, os.WriteFile(filepath.Join(nodeDir, "cpulist"), []byte(cpus+"\n"), 0o644))
+		meminfo := fmt.Sprintf("Node %d MemTotal:       16384 kB\nNode %d MemFree:        8192 kB\nNode %d MemUsed:        8192 kB\n", i, i, i)
+		assert.NoError(t, os.WriteFile(filepath.Join(nodeDir, "meminfo"), []byte(meminfo), 0o644))
+	}
+
+	nodes, err = getNUMANodes(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, []define.NUMANode{
+		{ID: 0, CPUs: "0-3", MemTotal: 16384 * 1024, MemFree: 8192 * 1024},
+		{ID: 1, CPUs: "4-7", MemTotal: 16384 * 1024, MemFree: 8192 * 1024},
+	}, nodes)
+}
@@ -0,0 +1,196 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	v1 "github.com/dmikushin/podman-shared/pkg/k8s.io/api/core/v1"
+	v12 "github.com/dmikushin/podman-shared/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// YAMLIngress represents a k8s API networking/v1 Ingress with the fields
+// generated by podman.
+type YAMLIngress struct {
+	v12.TypeMeta   `json:",inline"`
+	v12.ObjectMeta `json:"metadata,omitempty"`
+	Spec           YAMLIngressSpec `json:"spec"`
+}
+
+// YAMLIngressSpec is the spec of an Ingress.
+type YAMLIngressSpec struct {
+	Rules []YAMLIngressRule `json:"rules"`
+}
+
+// YAMLIngressRule routes the HTTP requests for a host.
+type YAMLIngressRule struct {
+	Host string                   `json:"host,omitempty"`
+	HTTP YAMLHTTPIngressRuleValue `json:"http"`
+}
+
+// YAMLHTTPIngressRuleValue lists the paths routed by an Ingress rule.
+type YAMLHTTPIngressRuleValue struct {
+	Paths []YAMLHTTPIngressPath `json:"paths"`
+}
+
+// YAMLHTTPIngressPath routes the requests for a path to a service.
+type YAMLHTTPIngressPath struct {
+	Path     string             `json:"path"`
+	PathType string             `json:"pathType"`
+	Backend  YAMLIngressBackend `json:"backend"`
+}
+
+// YAMLIngressBackend is the service the requests are routed to.
+type YAMLIngressBackend struct {
+	Service YAMLIngressServiceBackend `json:"service"`
+}
+
+// YAMLIngressServiceBackend refers to a port of a service.
+type YAMLIngressServiceBackend struct {
+	Name string                 `json:"name"`
+	Port YAMLServiceBackendPort `json:"port"`
+}
+
+// YAMLServiceBackendPort is the number of a service port.
+type YAMLServiceBackendPort struct {
+	Number int32 `json:"number"`
+}
+
+// YAMLHTTPRoute represents a Gateway API HTTPRoute with the fields generated
+// by podman.
+type YAMLHTTPRoute struct {
+	v12.TypeMeta   `json:",inline"`
+	v12.ObjectMeta `json:"metadata,omitempty"`
+	Spec           YAMLHTTPRouteSpec `json:"spec"`
+}
+
+// YAMLHTTPRouteSpec is the spec of an HTTPRoute.
+type YAMLHTTPRouteSpec struct {
+	ParentRefs []YAMLParentReference `json:"parentRefs"`
+	Hostnames  []string              `json:"hostnames,omitempty"`
+	Rules      []YAMLHTTPRouteRule   `json:"rules"`
+}
+
+// YAMLParentReference is the Gateway an HTTPRoute is attached to.
+type YAMLParentReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// YAMLHTTPRouteRule routes the requests matched to services.
+type YAMLHTTPRouteRule struct {
+	Matches     []YAMLHTTPRouteMatch `json:"matches"`
+	BackendRefs []YAMLHTTPBackendRef `json:"backendRefs"`
+}
+
+// YAMLHTTPRouteMatch matches the requests on their path.
+type YAMLHTTPRouteMatch struct {
+	Path YAMLHTTPPathMatch `json:"path"`
+}
+
+// YAMLHTTPPathMatch matches the prefix of the path of the requests.
+type YAMLHTTPPathMatch struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// YAMLHTTPBackendRef refers to a port of a service.
+type YAMLHTTPBackendRef struct {
+	Name string `json:"name"`
+	Port int32  `json:"port"`
+}
+
+// GenerateKubeIngress creates an Ingress, or an HTTPRoute when kind is
+// define.K8sKindHTTPRoute, routing HTTP requests to a port of the service.
+// The host, path, port and gateway are read from the
+// io.podman.kube.ingress.* labels of the pod or containers.
+func GenerateKubeIngress(service YAMLService, labels map[string]string, kind string) (any, error) {
+	port, err := ingressServicePort(service.Spec.Ports, labels[define.KubeIngressPortLabel])
+	if err != nil {
+		return nil, err
+	}
+	host := labels[define.KubeIngressHostLabel]
+	path := labels[define.KubeIngressPathLabel]
+	if path == "" {
+		path = "/"
+	}
+	if !strings.HasPrefix(path, "/") {
+		return nil, fmt.Errorf("invalid %s label %q: the path must be absolute", define.KubeIngressPathLabel, path)
+	}
+	meta := v12.ObjectMeta{
+		Name:              service.Name,
+		Labels:            service.Labels,
+		CreationTimestamp: service.CreationTimestamp,
+	}
+
+	switch kind {
+	case define.K8sKindIngress:
+		return YAMLIngress{
+			TypeMeta:   v12.TypeMeta{Kind: "Ingress", APIVersion: "networking.k8s.io/v1"},
+			ObjectMeta: meta,
+			Spec: YAMLIngressSpec{
+				Rules: []YAMLIngressRule{{
+					Host: host,
+					HTTP: YAMLHTTPIngressRuleValue{
+						Paths: []YAMLHTTPIngressPath{{
+							Path:     path,
+							PathType: "Prefix",
+							Backend: YAMLIngressBackend{
+								Service: YAMLIngressServiceBackend{
+									Name: service.Name,
+									Port: YAMLServiceBackendPort{Number: port},
+								},
+							},
+						}},
+					},
+				}},
+			},
+		}, nil
+	case define.K8sKindHTTPRoute:
+		gateway := labels[define.KubeIngressGatewayLabel]
+		if gateway == "" {
+			return nil, fmt.Errorf("an HTTPRoute requires the %s label naming the Gateway to attach it to", define.KubeIngressGatewayLabel)
+		}
+		parent := YAMLParentReference{Name: gateway}
+		if namespace, name, ok := strings.Cut(gateway, "/"); ok {
+			parent = YAMLParentReference{Name: name, Namespace: namespace}
+		}
+		route := YAMLHTTPRoute{
+			TypeMeta:   v12.TypeMeta{Kind: "HTTPRoute", APIVersion: "gateway.networking.k8s.io/v1"},
+			ObjectMeta: meta,
+			Spec: YAMLHTTPRouteSpec{
+				ParentRefs: []YAMLParentReference{parent},
+				Rules: []YAMLHTTPRouteRule{{
+					Matches:     []YAMLHTTPRouteMatch{{Path: YAMLHTTPPathMatch{Type: "PathPrefix", Value: path}}},
+					BackendRefs: []YAMLHTTPBackendRef{{Name: service.Name, Port: port}},
+				}},
+			},
+		}
+		if host != "" {
+			route.Spec.Hostnames = []string{host}
+		}
+		return route, nil
+	default:
+		return nil, fmt.Errorf("invalid ingress kind %q - only %s and %s are supported", kind, define.K8sKindIngress, define.K8sKindHTTPRoute)
+	}
+}
+
+// ingressServicePort returns the service port with the given number or name,
+// or the first TCP port of the service if none is given.
+func ingressServicePort(ports []v1.ServicePort, want string) (int32, error) {
+	for _, p := range ports {
+		if p.Protocol != "" && p.Protocol != v1.ProtocolTCP {
+			continue
+		}
+		if want == "" || want == p.Name || want == strconv.Itoa(int(p.Port)) {
+			return p.Port, nil
+		}
+	}
+	if want != "" {
+		return 0, fmt.Errorf("invalid %s label: %q is not a published TCP port", define.KubeIngressPortLabel, want)
+	}
+	return 0, fmt.Errorf("no published TCP port to route HTTP requests to")
+}
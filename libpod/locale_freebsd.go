@@ -0,0 +1,9 @@
+//go:build !remote
+
+package libpod
+
+// configureLocale does nothing on FreeBSD, the locale of the container is
+// only set in its environment.
+func (c *Container) configureLocale(mountPoint string) error {
+	return nil
+}
@@ -0,0 +1,218 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	securejoin "github.com/cyphar/filepath-securejoin"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/storage/pkg/fileutils"
+)
+
+const (
+	// localeDir is where glibc looks for compiled locales.
+	localeDir = "/usr/lib/locale"
+	// localeDefinitionsDir holds the locale definitions and charmaps
+	// compiled locales are generated from.
+	localeDefinitionsDir = "/usr/share/i18n"
+	// localeArchiveMagic starts a glibc locale-archive.
+	localeArchiveMagic = 0xde020109
+)
+
+// splitLocale splits a locale of the form language[_territory][.codeset][@modifier].
+func splitLocale(locale string) (name, codeset, modifier string) {
+	name, modifier, _ = strings.Cut(locale, "@")
+	name, codeset, _ = strings.Cut(name, ".")
+	return name, codeset, modifier
+}
+
+// normalizeCodeset normalizes a codeset like glibc does for the names of
+// compiled locales, "UTF-8" becomes "utf8" and "8859-1" becomes "iso88591".
+func normalizeCodeset(codeset string) string {
+	var b strings.Builder
+	onlyDigits := true
+	for _, r := range strings.ToLower(codeset) {
+		switch {
+		case r >= 'a' && r <= 'z':
+			onlyDigits = false
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		}
+	}
+	if onlyDigits && b.Len() > 0 {
+		return "iso" + b.String()
+	}
+	return b.String()
+}
+
+// compiledLocaleName returns the name glibc looks up a locale with in
+// /usr/lib/locale, with its codeset normalized.
+func compiledLocaleName(locale string) string {
+	name, codeset, modifier := splitLocale(locale)
+	if codeset != "" {
+		name += "." + normalizeCodeset(codeset)
+	}
+	if modifier != "" {
+		name += "@" + modifier
+	}
+	return name
+}
+
+// readLocaleArchive returns the names of the locales of a glibc
+// locale-archive.
+func readLocaleArchive(r io.ReaderAt) ([]string, error) {
+	// struct locarhead of glibc, only the fields up to the string table
+	var header struct {
+		Magic         uint32
+		Serial        uint32
+		NamehashOff   uint32
+		NamehashUsed  uint32
+		NamehashSize  uint32
+		StringOffset  uint32
+		StringUsed    uint32
+		StringSize    uint32
+		LocrectabOff  uint32
+		LocrectabUsed uint32
+		LocrectabSize uint32
+		SumhashOff    uint32
+		SumhashUsed   uint32
+		SumhashSize   uint32
+	}
+	if err := binary.Read(io.NewSectionReader(r, 0, int64(binary.Size(header))), binary.NativeEndian, &header); err != nil {
+		return nil, fmt.Errorf("reading locale archive header: %w", err)
+	}
+	if header.Magic != localeArchiveMagic {
+		return nil, errors.New("not a locale archive")
+	}
+	strs := make([]byte, header.StringUsed)
+	if _, err := r.ReadAt(strs, int64(header.StringOffset)); err != nil {
+		return nil, fmt.Errorf("reading locale archive names: %w", err)
+	}
+	var names []string
+	for name := range bytes.SplitSeq(strs, []byte{0}) {
+		if len(name) > 0 {
+			names = append(names, string(name))
+		}
+	}
+	return names, nil
+}
+
+// imageHasLocale reports whether the image at mountPoint provides the
+// compiled locale, as directory or in its locale-archive.
+func imageHasLocale(mountPoint, locale string) (bool, error) {
+	names := []string{locale, compiledLocaleName(locale)}
+	for _, name := range names {
+		path, err := securejoin.SecureJoin(mountPoint, filepath.Join(localeDir, name))
+		if err != nil {
+			return false, err
+		}
+		if err := fileutils.Exists(path); err == nil {
+			return true, nil
+		}
+	}
+	path, err := securejoin.SecureJoin(mountPoint, filepath.Join(localeDir, "locale-archive"))
+	if err != nil {
+		return false, err
+	}
+	archive, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer archive.Close()
+	archived, err := readLocaleArchive(archive)
+	if err != nil {
+		logrus.Debugf("Reading locale archive %s: %v", path, err)
+		return false, nil
+	}
+	return slices.ContainsFunc(names, func(name string) bool {
+		return slices.Contains(archived, name)
+	}), nil
+}
+
+// configureLocale makes the locale of the container available when the
+// image only has its definition, compiling it with the localedef of the host
+// into the run directory of the container.  The compiled locale is mounted
+// where glibc looks for it, the image is left unchanged.
+func (c *Container) configureLocale(mountPoint string) error {
+	locale := c.config.Locale
+	name, codeset, modifier := splitLocale(locale)
+	if locale == "" || name == "C" || name == "POSIX" {
+		return nil
+	}
+	dest := filepath.Join(localeDir, compiledLocaleName(locale))
+	if path, ok := c.state.BindMounts[dest]; ok && fileutils.Exists(path) == nil {
+		return nil
+	}
+	available, err := imageHasLocale(mountPoint, locale)
+	if err != nil {
+		return err
+	}
+	if available {
+		return nil
+	}
+
+	source := name
+	if modifier != "" {
+		source += "@" + modifier
+	}
+	definitions, err := securejoin.SecureJoin(mountPoint, localeDefinitionsDir)
+	if err != nil {
+		return err
+	}
+	sourcePath, err := securejoin.SecureJoin(mountPoint, filepath.Join(localeDefinitionsDir, "locales", source))
+	if err != nil {
+		return err
+	}
+	if fileutils.Exists(definitions) != nil {
+		// images without glibc, locales are up to their libc
+		logrus.Debugf("Image of container %s has no locale definitions, not generating locale %s", c.ID(), locale)
+		return nil
+	}
+	if codeset == "" || fileutils.Exists(sourcePath) != nil {
+		logrus.Warnf("Locale %s is not available in container %s and cannot be generated from the image", locale, c.ID())
+		return nil
+	}
+	localedef, err := exec.LookPath("localedef")
+	if err != nil {
+		logrus.Warnf("Locale %s is not available in container %s and cannot be generated: %v", locale, c.ID(), err)
+		return nil
+	}
+
+	output := filepath.Join(c.state.RunDir, "locale", compiledLocaleName(locale))
+	if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		return err
+	}
+	logrus.Debugf("Generating locale %s for container %s", locale, c.ID())
+	// --force writes the locale despite warnings, exiting with 1
+	cmd := exec.Command(localedef, "--no-archive", "--force", "-i", sourcePath, "-f", codeset, output)
+	cmd.Env = append(os.Environ(), "I18NPATH="+definitions)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 || fileutils.Exists(filepath.Join(output, "LC_CTYPE")) != nil {
+			logrus.Warnf("Generating locale %s for container %s: %v: %s", locale, c.ID(), err, strings.TrimSpace(string(out)))
+			return nil
+		}
+	}
+	if err := c.relabel(output, c.config.MountLabel, true); err != nil {
+		return err
+	}
+	if c.state.BindMounts == nil {
+		c.state.BindMounts = make(map[string]string)
+	}
+	c.state.BindMounts[dest] = output
+	return nil
+}
@@ -0,0 +1,47 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompiledLocaleName(t *testing.T) {
+	assert.Equal(t, "de_DE.utf8", compiledLocaleName("de_DE.UTF-8"))
+	assert.Equal(t, "de_DE.iso88591@euro", compiledLocaleName("de_DE.8859-1@euro"))
+	assert.Equal(t, "en_US", compiledLocaleName("en_US"))
+}
+
+func TestImageHasLocale(t *testing.T) {
+	rootfs := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(rootfs, localeDir, "C.utf8"), 0o755))
+
+	// a locale-archive with the header and the names of two locales
+	names := []byte("en_US.utf8\x00fr_FR.utf8\x00")
+	header := make([]uint32, 14)
+	header[0] = localeArchiveMagic
+	header[5] = 56
+	header[6] = uint32(len(names))
+	var archive bytes.Buffer
+	require.NoError(t, binary.Write(&archive, binary.NativeEndian, header))
+	archive.Write(names)
+	require.NoError(t, os.WriteFile(filepath.Join(rootfs, localeDir, "locale-archive"), archive.Bytes(), 0o644))
+
+	for locale, expected := range map[string]bool{
+		"C.UTF-8":     true,
+		"fr_FR.UTF-8": true,
+		"en_US.utf8":  true,
+		"de_DE.UTF-8": false,
+	} {
+		available, err := imageHasLocale(rootfs, locale)
+		require.NoError(t, err)
+		assert.Equal(t, expected, available, locale)
+	}
+}
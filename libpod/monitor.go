@@ -0,0 +1,99 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// MonitorConmon selects the conmon of the runtime as container monitor.
+	MonitorConmon = "conmon"
+	// MonitorConmonRs selects the conmon-rs binary of containers.conf as
+	// container monitor.
+	MonitorConmonRs = "conmon-rs"
+)
+
+// monitorOptions are options of the conmon command line Podman starts every
+// container monitor with.
+var monitorOptions = []string{"--api-version", "--exit-dir", "--persist-dir", "--full-attach", "--socket-dir-path", "--exit-command"}
+
+// monitorProbes caches the result of probing the container monitors by path.
+var monitorProbes sync.Map
+
+// resolveMonitor returns the path of the container monitor selected by
+// monitor, which is conmon, conmon-rs or the absolute path of a monitor
+// binary.  An empty path is returned for the conmon of the runtime.
+func (r *Runtime) resolveMonitor(monitor string) (string, error) {
+	var path string
+	switch {
+	case monitor == "" || monitor == MonitorConmon:
+		return "", nil
+	case monitor == MonitorConmonRs:
+		found, err := r.config.FindConmonRs()
+		if err != nil {
+			return "", fmt.Errorf("container monitor %s: %w", monitor, err)
+		}
+		path = found
+	case filepath.IsAbs(monitor):
+		path = monitor
+	default:
+		return "", fmt.Errorf("invalid container monitor %q, must be %s, %s or an absolute path: %w", monitor, MonitorConmon, MonitorConmonRs, define.ErrInvalidArg)
+	}
+	if err := probeMonitor(path); err != nil {
+		return "", fmt.Errorf("container monitor %s: %w", monitor, err)
+	}
+	return path, nil
+}
+
+// probeMonitor verifies that the monitor binary at path supports the conmon
+// command line options Podman starts monitors with.
+func probeMonitor(path string) error {
+	if probe, ok := monitorProbes.Load(path); ok {
+		err, _ := probe.(error)
+		return err
+	}
+	err := probeMonitorHelp(path)
+	monitorProbes.Store(path, err)
+	return err
+}
+
+func probeMonitorHelp(path string) error {
+	help, err := utils.ExecCmd(path, "--help")
+	if err != nil {
+		return fmt.Errorf("probing %s: %w", path, err)
+	}
+	var missing []string
+	for _, option := range monitorOptions {
+		if !strings.Contains(help, option) {
+			missing = append(missing, option)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s does not support the conmon command line options %s: %w", path, strings.Join(missing, ", "), define.ErrInvalidArg)
+	}
+	logrus.Debugf("Container monitor %s supports the conmon command line", path)
+	return nil
+}
+
+// inspectMonitor describes the container monitor of the container.
+func (c *Container) inspectMonitor() *define.InspectMonitor {
+	monitor := &define.InspectMonitor{
+		Name: c.config.Monitor,
+		Path: c.config.MonitorPath,
+	}
+	if monitor.Name == "" {
+		monitor.Name = MonitorConmon
+	}
+	if monitor.Path == "" {
+		monitor.Path = c.runtime.conmonPath
+	}
+	return monitor
+}
@@ -0,0 +1,148 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"go.podman.io/common/libnetwork/types"
+	"golang.org/x/sys/unix"
+)
+
+// bandwidthLatency is the longest time a packet waits in the egress queue
+// before it is dropped.
+const bandwidthLatency = 0.025
+
+// applyBandwidth limits the bandwidth of the interfaces of the container in
+// the network namespace to the bandwidth options of their networks.  The
+// limits are applied in the namespace of the container, so they work for
+// rootless containers as well: egress traffic is shaped with a token bucket
+// filter, ingress traffic is policed.  Limits of networks without bandwidth
+// options are removed.
+func applyBandwidth(netNSPath string, networks map[string]types.PerNetworkOptions) error {
+	limits := make(map[string]*define.Bandwidth, len(networks))
+	for name, opts := range networks {
+		bw, err := define.ParseBandwidth(opts.Options)
+		if err != nil {
+			return fmt.Errorf("network %s: %w", name, err)
+		}
+		if opts.InterfaceName != "" {
+			limits[opts.InterfaceName] = bw
+		}
+	}
+	return ns.WithNetNSPath(netNSPath, func(_ ns.NetNS) error {
+		for ifname, bw := range limits {
+			link, err := netlink.LinkByName(ifname)
+			if err != nil {
+				var notFound netlink.LinkNotFoundError
+				if errors.As(err, &notFound) {
+					continue
+				}
+				return fmt.Errorf("looking up interface %s: %w", ifname, err)
+			}
+			if err := setBandwidth(link, bw); err != nil {
+				return fmt.Errorf("limiting bandwidth of interface %s: %w", ifname, err)
+			}
+		}
+		return nil
+	})
+}
+
+// setBandwidth replaces the bandwidth limits of the link, bw nil removes them.
+func setBandwidth(link netlink.Link, bw *define.Bandwidth) error {
+	qdiscs, err := netlink.QdiscList(link)
+	if err != nil {
+		return err
+	}
+	for _, qdisc := range qdiscs {
+		switch q := qdisc.(type) {
+		case *netlink.Tbf:
+			if q.Parent != netlink.HANDLE_ROOT {
+				continue
+			}
+		case *netlink.Ingress:
+		default:
+			continue
+		}
+		if err := netlink.QdiscDel(qdisc); err != nil {
+			return fmt.Errorf("removing %s qdisc: %w", qdisc.Type(), err)
+		}
+	}
+	if bw == nil {
+		return nil
+	}
+
+	if bw.EgressRate > 0 {
+		rate := bw.EgressRate / 8
+		burst := min(bw.EgressBurst, math.MaxUint32)
+		limit := min(uint64(float64(rate)*bandwidthLatency)+burst, math.MaxUint32)
+		tbf := &netlink.Tbf{
+			QdiscAttrs: netlink.QdiscAttrs{
+				LinkIndex: link.Attrs().Index,
+				Handle:    netlink.MakeHandle(1, 0),
+				Parent:    netlink.HANDLE_ROOT,
+			},
+			Rate:   rate,
+			Limit:  uint32(limit),
+			Buffer: netlink.Xmittime(rate, uint32(burst)),
+		}
+		if err := netlink.QdiscAdd(tbf); err != nil {
+			return fmt.Errorf("adding egress qdisc: %w", err)
+		}
+	}
+
+	if bw.IngressRate > 0 {
+		rate := bw.IngressRate / 8
+		if rate > math.MaxUint32 {
+			return fmt.Errorf("ingress rate %d bit/s is too large to be policed: %w", bw.IngressRate, define.ErrInvalidArg)
+		}
+		ingress := &netlink.Ingress{
+			QdiscAttrs: netlink.QdiscAttrs{
+				LinkIndex: link.Attrs().Index,
+				Handle:    netlink.MakeHandle(0xffff, 0),
+				Parent:    netlink.HANDLE_INGRESS,
+			},
+		}
+		if err := netlink.QdiscAdd(ingress); err != nil {
+			return fmt.Errorf("adding ingress qdisc: %w", err)
+		}
+		police := netlink.NewPoliceAction()
+		police.Rate = uint32(rate)
+		police.Burst = uint32(min(bw.IngressBurst, math.MaxUint32))
+		police.Mtu = uint32(max(link.Attrs().MTU, 1500)) + 14
+		police.ExceedAction = netlink.TC_POLICE_SHOT
+		// u32 is more widely available than matchall, its empty
+		// selector key matches all packets
+		filter := &netlink.U32{
+			FilterAttrs: netlink.FilterAttrs{
+				LinkIndex: link.Attrs().Index,
+				Parent:    ingress.Handle,
+				Priority:  1,
+				Protocol:  unix.ETH_P_ALL,
+			},
+			Sel: &netlink.TcU32Sel{
+				Flags: netlink.TC_U32_TERMINAL,
+				Nkeys: 1,
+				Keys:  []netlink.TcU32Key{{Mask: 0, Val: 0}},
+			},
+			Actions: []netlink.Action{police},
+		}
+		if err := netlink.FilterAdd(filter); err != nil {
+			if delErr := netlink.QdiscDel(ingress); delErr != nil {
+				logrus.Debugf("Removing ingress qdisc of interface %s: %v", link.Attrs().Name, delErr)
+			}
+			if errors.Is(err, unix.ENOENT) {
+				return fmt.Errorf("adding ingress policing filter, the kernel may lack the u32 classifier or the police action: %w", err)
+			}
+			return fmt.Errorf("adding ingress policing filter: %w", err)
+		}
+	}
+	logrus.Debugf("Limited bandwidth of interface %s: %+v", link.Attrs().Name, *bw)
+	return nil
+}
@@ -5,10 +5,12 @@ package libpod
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"regexp"
 	"slices"
 	"sort"
+	"strconv"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/libpod/events"
@@ -69,9 +71,130 @@ func (c *Container) getNetworkOptions(networkOpts map[string]types.PerNetworkOpt
 	} else {
 		opts.Networks = networkOpts
 	}
+	opts.Networks = c.runtime.withDNSCacheOptions(withoutBandwidthOptions(opts.Networks))
 	return opts
 }
 
+// withDNSCacheOptions sets the DNS cache option of the per-network options
+// to the effective setting, the option of the container or else the label
+// of the network, for aardvark-dns.  The option is removed for networks
+// without DNS and left unset for networks without a DNS cache setting.
+func (r *Runtime) withDNSCacheOptions(networks map[string]types.PerNetworkOptions) map[string]types.PerNetworkOptions {
+	for name, opts := range networks {
+		netConfig, err := r.network.NetworkInspect(name)
+		if err != nil {
+			// the setup of the network fails with the error
+			continue
+		}
+		value, ok := opts.Options[define.DNSCacheOption]
+		if !ok {
+			value, ok = netConfig.Labels[define.DNSCacheLabel]
+		}
+		if !ok {
+			continue
+		}
+		options := make(map[string]string, len(opts.Options)+1)
+		maps.Copy(options, opts.Options)
+		delete(options, define.DNSCacheOption)
+		if netConfig.DNSEnabled {
+			enabled, err := define.ParseDNSCache(value)
+			if err != nil {
+				logrus.Warnf("Ignoring the DNS cache setting of network %s: %v", name, err)
+			} else {
+				options[define.DNSCacheOption] = strconv.FormatBool(enabled)
+			}
+		}
+		if len(options) == 0 {
+			options = nil
+		}
+		opts.Options = options
+		networks[name] = opts
+	}
+	return networks
+}
+
+// withoutBandwidthOptions returns the per-network options without the
+// bandwidth options, which are applied by Podman and unknown to the network
+// backend.
+func withoutBandwidthOptions(networks map[string]types.PerNetworkOptions) map[string]types.PerNetworkOptions {
+	stripped := make(map[string]types.PerNetworkOptions, len(networks))
+	for name, opts := range networks {
+		if len(opts.Options) > 0 {
+			options := make(map[string]string, len(opts.Options))
+			for key, value := range opts.Options {
+				if !define.IsBandwidthOption(key) {
+					options[key] = value
+				}
+			}
+			if len(options) == 0 {
+				options = nil
+			}
+			opts.Options = options
+		}
+		stripped[name] = opts
+	}
+	return stripped
+}
+
+// updateBandwidth merges the bandwidth options into the per-network options
+// of the networks of the container, all networks when network is empty.  An
+// option with an empty value is removed.  The limits of a running container
+// are changed immediately.
+func (c *Container) updateBandwidth(network string, options map[string]string) error {
+	for key := range options {
+		if !define.IsBandwidthOption(key) {
+			return fmt.Errorf("network option %s cannot be updated, only bandwidth options can: %w", key, define.ErrInvalidArg)
+		}
+	}
+	networks, err := c.networks()
+	if err != nil {
+		return err
+	}
+	if network != "" {
+		name, _, err := c.runtime.normalizeNetworkName(network)
+		if err != nil {
+			return err
+		}
+		opts, ok := networks[name]
+		if !ok {
+			return fmt.Errorf("container %s is not connected to network %s: %w", c.ID(), name, define.ErrNoSuchNetwork)
+		}
+		networks = map[string]types.PerNetworkOptions{name: opts}
+	}
+	if len(networks) == 0 {
+		return fmt.Errorf("container %s is not connected to a network, bandwidth can only be limited on networks: %w", c.ID(), define.ErrInvalidArg)
+	}
+
+	for name, opts := range networks {
+		merged := maps.Clone(opts.Options)
+		if merged == nil {
+			merged = make(map[string]string, len(options))
+		}
+		for key, value := range options {
+			if value == "" {
+				delete(merged, key)
+			} else {
+				merged[key] = value
+			}
+		}
+		if _, err := define.ParseBandwidth(merged); err != nil {
+			return fmt.Errorf("network %s: %w", name, err)
+		}
+		opts.Options = merged
+		networks[name] = opts
+	}
+	for name, opts := range networks {
+		if err := c.runtime.state.NetworkModify(c, name, opts); err != nil {
+			return err
+		}
+	}
+
+	if c.state.NetNS != "" && c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return applyBandwidth(c.state.NetNS, networks)
+	}
+	return nil
+}
+
 // setUpNetwork will set up the networks, on error it will also tear down the cni
 // networks. If rootless it will join/create the rootless network namespace.
 func (r *Runtime) setUpNetwork(ns string, opts types.NetworkOptions) (map[string]types.StatusBlock, error) {
@@ -158,6 +281,12 @@ func (r *Runtime) reloadContainerNetwork(ctr *Container) (map[string]types.Statu
 		}
 	}
 
+	return r.configureNetNSKeepAddresses(ctr)
+}
+
+// configureNetNSKeepAddresses configures the network namespace of a container
+// again after a teardown, with the same MAC and IP addresses as before.
+func (r *Runtime) configureNetNSKeepAddresses(ctr *Container) (map[string]types.StatusBlock, error) {
 	networkOpts, err := ctr.networks()
 	if err != nil {
 		return nil, err
@@ -183,6 +312,71 @@ func (r *Runtime) reloadContainerNetwork(ctr *Container) (map[string]types.Statu
 	return r.configureNetNS(ctr, ctr.state.NetNS)
 }
 
+// updatePortMappings replaces the ports published by the container.  When the
+// network of the container is configured, the port forwarding is set up again
+// with the new ports, keeping the addresses of the container.
+func (c *Container) updatePortMappings(ports []types.PortMapping) error {
+	if !c.config.NetMode.IsBridge() && !c.config.NetMode.IsSlirp4netns() && !c.config.NetMode.IsPasta() {
+		return fmt.Errorf("publishing ports requires bridge, slirp4netns or pasta networking, got %q: %w", c.config.NetMode, define.ErrNetworkModeInvalid)
+	}
+
+	live := c.state.NetNS != ""
+	if live {
+		if !c.config.NetMode.IsBridge() || rootless.IsRootless() {
+			return fmt.Errorf("changing the published ports of running container %s requires rootful bridge networking, stop it first: %w", c.ID(), define.ErrCtrStateInvalid)
+		}
+		if err := c.runtime.teardownNetwork(c); err != nil {
+			return fmt.Errorf("removing the port forwarding of container %s: %w", c.ID(), err)
+		}
+		if err := c.runtime.unexposeMachinePorts(c.config.PortMappings); err != nil {
+			logrus.Errorf("Failed to free gvproxy machine ports: %v", err)
+		}
+	}
+
+	oldPorts := c.config.PortMappings
+	c.config.PortMappings = ports
+	// SafeRewriteContainerConfig must be used with care. Make sure to not change config fields by accident.
+	rewriteErr := c.safeRewriteConfig("", "")
+	if rewriteErr != nil {
+		// Set the old ports up again.
+		c.config.PortMappings = oldPorts
+	}
+	if !live {
+		return rewriteErr
+	}
+
+	result, err := c.runtime.configureNetNSKeepAddresses(c)
+	if err != nil && rewriteErr == nil {
+		// Set the old ports up again.
+		err = fmt.Errorf("setting up the port forwarding of container %s: %w", c.ID(), err)
+		c.config.PortMappings = oldPorts
+		if rewriteErr = c.safeRewriteConfig("", ""); rewriteErr == nil {
+			result, rewriteErr = c.runtime.configureNetNSKeepAddresses(c)
+		}
+		if rewriteErr != nil {
+			return errors.Join(err, fmt.Errorf("restoring the published ports: %w", rewriteErr))
+		}
+		c.state.NetworkStatus = result
+		return errors.Join(err, c.save())
+	}
+	if err != nil {
+		return errors.Join(rewriteErr, fmt.Errorf("setting up the port forwarding of container %s: %w", c.ID(), err))
+	}
+	c.state.NetworkStatus = result
+	return errors.Join(rewriteErr, c.save())
+}
+
+// ReloadNetworkFirewall gracefully recreates the firewall rules of all
+// containers with bridge networking. The rules are replaced in place without
+// tearing down the container networks, so established connections survive.
+// This is mainly useful after the host firewall daemon has been restarted.
+func (r *Runtime) ReloadNetworkFirewall() error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+	return r.reloadNetworkFirewall()
+}
+
 // Produce an InspectNetworkSettings containing information on the container
 // network.
 func (c *Container) getContainerNetworkInfo() (*define.InspectNetworkSettings, error) {
@@ -254,6 +448,7 @@ func (c *Container) getContainerNetworkInfo() (*define.InspectNetworkSettings, e
 				cniNet := new(define.InspectAdditionalNetwork)
 				cniNet.NetworkID = getNetworkID(net)
 				cniNet.Aliases = opts.Aliases
+				cniNet.Bandwidth, _ = define.ParseBandwidth(opts.Options)
 				settings.Networks[net] = cniNet
 			}
 		} else {
@@ -286,6 +481,7 @@ func (c *Container) getContainerNetworkInfo() (*define.InspectNetworkSettings, e
 			addedNet.NetworkID = getNetworkID(name)
 			addedNet.Aliases = opts.Aliases
 			addedNet.InspectBasicNetworkConfig = resultToBasicNetworkConfig(result)
+			addedNet.Bandwidth, _ = define.ParseBandwidth(opts.Options)
 
 			settings.Networks[name] = addedNet
 		}
@@ -392,12 +588,15 @@ func (c *Container) NetworkDisconnect(nameOrID, netName string, _ bool) error {
 	if err := c.runtime.state.NetworkDisconnect(c, netName); err != nil {
 		return err
 	}
+	c.refreshConfigBackup()
 
 	// Since we removed the new network from the container db we must have to add it back during partial setup errors
 	addContainerNetworkToDB := func() {
 		if err := c.runtime.state.NetworkConnect(c, netName, netOpts); err != nil {
 			logrus.Errorf("Failed to add network %s for container %s to DB after failed network disconnect", netName, nameOrID)
+			return
 		}
+		c.refreshConfigBackup()
 	}
 
 	c.newNetworkEvent(events.NetworkDisconnect, netName)
@@ -533,12 +732,15 @@ func (c *Container) NetworkConnect(nameOrID, netName string, netOpts types.PerNe
 
 		return err
 	}
+	c.refreshConfigBackup()
 
 	// Since we added the new network to the container db we must have to remove it from that during partial setup errors
 	removeContainerNetworkFromDB := func() {
 		if err := c.runtime.state.NetworkDisconnect(c, netName); err != nil {
 			logrus.Errorf("Failed to remove network %s for container %s from DB after failed network connect", netName, nameOrID)
+			return
 		}
+		c.refreshConfigBackup()
 	}
 
 	c.newNetworkEvent(events.NetworkConnect, netName)
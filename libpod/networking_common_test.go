@@ -0,0 +1,52 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"go.podman.io/common/libnetwork/types"
+)
+
+// inspectOnlyNetwork is a network backend which can only inspect networks.
+type inspectOnlyNetwork struct {
+	types.ContainerNetwork
+	networks map[string]types.Network
+}
+
+func (n inspectOnlyNetwork) NetworkInspect(name string) (types.Network, error) {
+	network, ok := n.networks[name]
+	if !ok {
+		return types.Network{}, define.ErrNoSuchNetwork
+	}
+	return network, nil
+}
+
+func Test_withDNSCacheOptions(t *testing.T) {
+	r := &Runtime{network: inspectOnlyNetwork{networks: map[string]types.Network{
+		"cached":   {Name: "cached", DNSEnabled: true, Labels: map[string]string{define.DNSCacheLabel: "true"}},
+		"uncached": {Name: "uncached", DNSEnabled: true},
+		"nodns":    {Name: "nodns", Labels: map[string]string{define.DNSCacheLabel: "true"}},
+	}}}
+
+	networks := r.withDNSCacheOptions(map[string]types.PerNetworkOptions{
+		"cached":   {InterfaceName: "eth0"},
+		"uncached": {InterfaceName: "eth1"},
+		"nodns":    {InterfaceName: "eth2", Options: map[string]string{define.DNSCacheOption: "true"}},
+		"missing":  {InterfaceName: "eth3"},
+	})
+	assert.Equal(t, map[string]string{define.DNSCacheOption: "true"}, networks["cached"].Options)
+	assert.Nil(t, networks["uncached"].Options)
+	assert.Nil(t, networks["nodns"].Options, "option removed without DNS")
+	assert.Nil(t, networks["missing"].Options)
+
+	// The option of the container overrides the label of the network.
+	networks = r.withDNSCacheOptions(map[string]types.PerNetworkOptions{
+		"cached":   {Options: map[string]string{define.DNSCacheOption: "0", "mtu": "1400"}},
+		"uncached": {Options: map[string]string{define.DNSCacheOption: "1"}},
+	})
+	assert.Equal(t, map[string]string{define.DNSCacheOption: "false", "mtu": "1400"}, networks["cached"].Options)
+	assert.Equal(t, map[string]string{define.DNSCacheOption: "true"}, networks["uncached"].Options)
+}
@@ -0,0 +1,168 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/rootless"
+	"github.com/sirupsen/logrus"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+// conntrackAcctPath is the sysctl enabling the byte and packet counters of
+// the connection tracking in the network namespace of the caller.
+const conntrackAcctPath = "/proc/sys/net/netfilter/nf_conntrack_acct"
+
+// tcpStates are the names of the TCP connection tracking states.
+var tcpStates = []string{"NONE", "SYN_SENT", "SYN_RECV", "ESTABLISHED", "FIN_WAIT", "CLOSE_WAIT", "LAST_ACK", "TIME_WAIT", "CLOSE", "SYN_SENT2"}
+
+// inConntrackNetns runs toRun in the network namespace tracking the
+// connections of containers on bridge networks, the one of the host or the
+// rootless network namespace.
+func (r *Runtime) inConntrackNetns(toRun func() error) error {
+	if rootless.IsRootless() {
+		return r.network.RunInRootlessNetns(toRun)
+	}
+	return toRun()
+}
+
+// enableFlowAccounting enables the byte and packet counters of the
+// connection tracking, the counters apply to connections opened afterwards.
+func (r *Runtime) enableFlowAccounting() error {
+	return r.inConntrackNetns(func() error {
+		content, err := os.ReadFile(conntrackAcctPath)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				logrus.Debugf("Connection tracking is not enabled, not enabling its accounting")
+				return nil
+			}
+			return err
+		}
+		if len(content) > 0 && content[0] == '1' {
+			return nil
+		}
+		logrus.Debugf("Enabling the accounting of the connection tracking")
+		return os.WriteFile(conntrackAcctPath, []byte("1"), 0o644)
+	})
+}
+
+// networkFlows lists the connections of the container with the addresses of
+// the network settings from the connection tracking of the kernel.
+func (r *Runtime) networkFlows(settings *define.InspectNetworkSettings) ([]define.InspectNetworkFlow, error) {
+	addrs := flowAddresses(settings)
+	if len(addrs) == 0 {
+		return nil, nil
+	}
+	if err := r.enableFlowAccounting(); err != nil {
+		logrus.Debugf("Enabling the accounting of the connection tracking: %v", err)
+	}
+	var ctFlows []*netlink.ConntrackFlow
+	err := r.inConntrackNetns(func() error {
+		for _, family := range []netlink.InetFamily{unix.AF_INET, unix.AF_INET6} {
+			list, err := netlink.ConntrackTableList(netlink.ConntrackTable, family)
+			if err != nil {
+				return fmt.Errorf("listing connection tracking table: %w", err)
+			}
+			ctFlows = append(ctFlows, list...)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var flows []define.InspectNetworkFlow
+	for _, ctFlow := range ctFlows {
+		if flow := conntrackToFlow(ctFlow, addrs); flow != nil {
+			flows = append(flows, *flow)
+		}
+	}
+	return flows, nil
+}
+
+// flowAddresses returns the addresses of the container on its networks.
+func flowAddresses(settings *define.InspectNetworkSettings) map[string]bool {
+	addrs := make(map[string]bool)
+	add := func(config *define.InspectBasicNetworkConfig) {
+		for _, addr := range []string{config.IPAddress, config.GlobalIPv6Address} {
+			if addr != "" {
+				addrs[addr] = true
+			}
+		}
+		for _, addr := range slices.Concat(config.SecondaryIPAddresses, config.SecondaryIPv6Addresses) {
+			addrs[addr.Addr] = true
+		}
+	}
+	if settings == nil {
+		return addrs
+	}
+	add(&settings.InspectBasicNetworkConfig)
+	for _, network := range settings.Networks {
+		add(&network.InspectBasicNetworkConfig)
+	}
+	return addrs
+}
+
+// conntrackToFlow converts a tracked connection of one of the addresses to a
+// flow seen from the container, or returns nil for other connections.
+func conntrackToFlow(ctFlow *netlink.ConntrackFlow, addrs map[string]bool) *define.InspectNetworkFlow {
+	var flow *define.InspectNetworkFlow
+	switch {
+	case addrs[ctFlow.Forward.SrcIP.String()]:
+		flow = &define.InspectNetworkFlow{
+			Direction:       "outgoing",
+			LocalAddress:    ctFlow.Forward.SrcIP.String(),
+			LocalPort:       ctFlow.Forward.SrcPort,
+			RemoteAddress:   ctFlow.Forward.DstIP.String(),
+			RemotePort:      ctFlow.Forward.DstPort,
+			BytesSent:       ctFlow.Forward.Bytes,
+			PacketsSent:     ctFlow.Forward.Packets,
+			BytesReceived:   ctFlow.Reverse.Bytes,
+			PacketsReceived: ctFlow.Reverse.Packets,
+		}
+	case addrs[ctFlow.Reverse.SrcIP.String()]:
+		// connections to published ports are destination NATed to
+		// the container, which answers from its own address
+		flow = &define.InspectNetworkFlow{
+			Direction:       "incoming",
+			LocalAddress:    ctFlow.Reverse.SrcIP.String(),
+			LocalPort:       ctFlow.Reverse.SrcPort,
+			RemoteAddress:   ctFlow.Forward.SrcIP.String(),
+			RemotePort:      ctFlow.Forward.SrcPort,
+			BytesSent:       ctFlow.Reverse.Bytes,
+			PacketsSent:     ctFlow.Reverse.Packets,
+			BytesReceived:   ctFlow.Forward.Bytes,
+			PacketsReceived: ctFlow.Forward.Packets,
+		}
+	default:
+		return nil
+	}
+	flow.Protocol = protocolName(ctFlow.Forward.Protocol)
+	if tcp, ok := ctFlow.ProtoInfo.(*netlink.ProtoInfoTCP); ok && int(tcp.State) < len(tcpStates) {
+		flow.State = tcpStates[tcp.State]
+	}
+	return flow
+}
+
+func protocolName(protocol uint8) string {
+	switch protocol {
+	case unix.IPPROTO_ICMP:
+		return "icmp"
+	case unix.IPPROTO_TCP:
+		return "tcp"
+	case unix.IPPROTO_UDP:
+		return "udp"
+	case unix.IPPROTO_ICMPV6:
+		return "icmpv6"
+	case unix.IPPROTO_SCTP:
+		return "sctp"
+	default:
+		return strconv.Itoa(int(protocol))
+	}
+}
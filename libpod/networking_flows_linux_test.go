@@ -0,0 +1,101 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"net"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+)
+
+func Test_flowAddresses(t *testing.T) {
+	assert.Empty(t, flowAddresses(nil))
+
+	settings := &define.InspectNetworkSettings{
+		Networks: map[string]*define.InspectAdditionalNetwork{
+			"podman": {
+				InspectBasicNetworkConfig: define.InspectBasicNetworkConfig{
+					IPAddress:            "10.88.0.2",
+					GlobalIPv6Address:    "fd00::2",
+					SecondaryIPAddresses: []define.Address{{Addr: "10.88.0.3", PrefixLength: 16}},
+				},
+			},
+			"other": {
+				InspectBasicNetworkConfig: define.InspectBasicNetworkConfig{
+					IPAddress: "10.89.0.2",
+				},
+			},
+		},
+	}
+	assert.Equal(t, map[string]bool{
+		"10.88.0.2": true,
+		"fd00::2":   true,
+		"10.88.0.3": true,
+		"10.89.0.2": true,
+	}, flowAddresses(settings))
+}
+
+func Test_conntrackToFlow(t *testing.T) {
+	addrs := map[string]bool{"10.88.0.2": true}
+
+	// the container connects to a remote server
+	outgoing := &netlink.ConntrackFlow{
+		Forward: netlink.IPTuple{
+			SrcIP: net.ParseIP("10.88.0.2"), SrcPort: 41000,
+			DstIP: net.ParseIP("192.0.2.1"), DstPort: 443,
+			Protocol: unix.IPPROTO_TCP, Bytes: 100, Packets: 2,
+		},
+		Reverse: netlink.IPTuple{
+			SrcIP: net.ParseIP("192.0.2.1"), SrcPort: 443,
+			DstIP: net.ParseIP("192.168.1.10"), DstPort: 41000,
+			Protocol: unix.IPPROTO_TCP, Bytes: 2000, Packets: 3,
+		},
+		ProtoInfo: &netlink.ProtoInfoTCP{State: 3},
+	}
+	assert.Equal(t, &define.InspectNetworkFlow{
+		Protocol:        "tcp",
+		Direction:       "outgoing",
+		LocalAddress:    "10.88.0.2",
+		LocalPort:       41000,
+		RemoteAddress:   "192.0.2.1",
+		RemotePort:      443,
+		State:           "ESTABLISHED",
+		BytesSent:       100,
+		BytesReceived:   2000,
+		PacketsSent:     2,
+		PacketsReceived: 3,
+	}, conntrackToFlow(outgoing, addrs))
+
+	// a client connects to a published port of the container
+	incoming := &netlink.ConntrackFlow{
+		Forward: netlink.IPTuple{
+			SrcIP: net.ParseIP("198.51.100.7"), SrcPort: 52000,
+			DstIP: net.ParseIP("192.168.1.10"), DstPort: 8080,
+			Protocol: unix.IPPROTO_UDP, Bytes: 60, Packets: 1,
+		},
+		Reverse: netlink.IPTuple{
+			SrcIP: net.ParseIP("10.88.0.2"), SrcPort: 80,
+			DstIP: net.ParseIP("198.51.100.7"), DstPort: 52000,
+			Protocol: unix.IPPROTO_UDP, Bytes: 80, Packets: 1,
+		},
+	}
+	assert.Equal(t, &define.InspectNetworkFlow{
+		Protocol:        "udp",
+		Direction:       "incoming",
+		LocalAddress:    "10.88.0.2",
+		LocalPort:       80,
+		RemoteAddress:   "198.51.100.7",
+		RemotePort:      52000,
+		BytesSent:       80,
+		BytesReceived:   60,
+		PacketsSent:     1,
+		PacketsReceived: 1,
+	}, conntrackToFlow(incoming, addrs))
+
+	// connections of other containers are skipped
+	assert.Nil(t, conntrackToFlow(outgoing, map[string]bool{"10.88.0.9": true}))
+}
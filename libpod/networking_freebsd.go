@@ -229,3 +229,20 @@ func (c *Container) inspectJoinedNetworkNS(_ string) (q types.StatusBlock, retEr
 func (c *Container) reloadRootlessRLKPortMapping() error {
 	return errors.New("unsupported (*Container).reloadRootlessRLKPortMapping")
 }
+
+func (r *Runtime) reloadNetworkFirewall() error {
+	return fmt.Errorf("graceful network reload is not supported on FreeBSD: %w", define.ErrNotImplemented)
+}
+
+func applyBandwidth(_ string, networks map[string]types.PerNetworkOptions) error {
+	for _, opts := range networks {
+		if bw, err := define.ParseBandwidth(opts.Options); err != nil || bw != nil {
+			return fmt.Errorf("bandwidth limits are not supported on FreeBSD: %w", define.ErrNotImplemented)
+		}
+	}
+	return nil
+}
+
+func (r *Runtime) networkFlows(_ *define.InspectNetworkSettings) ([]define.InspectNetworkFlow, error) {
+	return nil, fmt.Errorf("network flows are not supported on FreeBSD: %w", define.ErrNotImplemented)
+}
@@ -0,0 +1,64 @@
+//go:build !remote
+
+package libpod
+
+import (
+	nettypes "go.podman.io/common/libnetwork/types"
+)
+
+// lazyNetworkError is the network backend of a runtime created with
+// WithLazyStorage whose deferred setup failed.  It returns the error of the
+// setup from every call, so that it reaches the caller instead of exiting the
+// process or handing out a nil backend.
+type lazyNetworkError struct {
+	err            error
+	defaultNetwork string
+}
+
+func (n *lazyNetworkError) NetworkCreate(nettypes.Network, *nettypes.NetworkCreateOptions) (nettypes.Network, error) {
+	return nettypes.Network{}, n.err
+}
+
+func (n *lazyNetworkError) NetworkUpdate(string, nettypes.NetworkUpdateOptions) error {
+	return n.err
+}
+
+func (n *lazyNetworkError) NetworkRemove(string) error {
+	return n.err
+}
+
+func (n *lazyNetworkError) NetworkList(...nettypes.FilterFunc) ([]nettypes.Network, error) {
+	return nil, n.err
+}
+
+func (n *lazyNetworkError) NetworkInspect(string) (nettypes.Network, error) {
+	return nettypes.Network{}, n.err
+}
+
+func (n *lazyNetworkError) Setup(string, nettypes.SetupOptions) (map[string]nettypes.StatusBlock, error) {
+	return nil, n.err
+}
+
+func (n *lazyNetworkError) Teardown(string, nettypes.TeardownOptions) error {
+	return n.err
+}
+
+func (n *lazyNetworkError) RunInRootlessNetns(func() error) error {
+	return n.err
+}
+
+func (n *lazyNetworkError) RootlessNetnsInfo() (*nettypes.RootlessNetnsInfo, error) {
+	return nil, n.err
+}
+
+func (n *lazyNetworkError) Drivers() []string {
+	return nil
+}
+
+func (n *lazyNetworkError) DefaultNetworkName() string {
+	return n.defaultNetwork
+}
+
+func (n *lazyNetworkError) NetworkInfo() nettypes.NetworkInfo {
+	return nettypes.NetworkInfo{}
+}
@@ -5,6 +5,11 @@ package libpod
 import (
 	"fmt"
 	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/containernetworking/plugins/pkg/ns"
 	"github.com/dmikushin/podman-shared/libpod/define"
@@ -59,6 +64,20 @@ func (r *Runtime) configureNetNS(ctr *Container, ctrNS string) (status map[strin
 		}
 	}()
 
+	if err := applyBandwidth(ctrNS, networks); err != nil {
+		return nil, err
+	}
+
+	if err := r.applyNetworkPolicies(ctr, ctrNS, netStatus); err != nil {
+		return nil, err
+	}
+
+	if ctr.config.TrackFlows {
+		if err := r.enableFlowAccounting(); err != nil {
+			logrus.Warnf("Enabling the accounting of the connection tracking for container %s: %v", ctr.ID(), err)
+		}
+	}
+
 	// set up rootless port forwarder when rootless with ports and the network status is empty,
 	// if this is called from network reload the network status will not be empty and we should
 	// not set up port because they are still active
@@ -120,6 +139,8 @@ func (r *Runtime) teardownNetNS(ctr *Container) error {
 		logrus.Errorf("failed to free gvproxy machine ports: %v", err)
 	}
 
+	r.removeNetworkPolicies(ctr)
+
 	// Do not check the error here, we want to always umount the netns
 	// This will ensure that the container interface will be deleted
 	// even when there is a CNI or netavark bug.
@@ -276,3 +297,44 @@ func (c *Container) inspectJoinedNetworkNS(networkns string) (q types.StatusBloc
 	})
 	return result, err
 }
+
+// reloadNetworkFirewall asks netavark to reapply the firewall rules of all
+// configured container networks in place. Unlike reloadContainerNetwork no
+// network is torn down first, netavark replaces its rule set atomically so
+// established connections are not dropped.
+func (r *Runtime) reloadNetworkFirewall() error {
+	info := r.network.NetworkInfo()
+	if info.Backend != types.Netavark {
+		return fmt.Errorf("graceful network reload requires the netavark network backend, got %q: %w", info.Backend, define.ErrNotImplemented)
+	}
+
+	isRootless := rootless.IsRootless()
+	// must match the run directory used by the libnetwork netavark backend
+	runDir := "/run/containers/networks"
+	if isRootless {
+		runDir = filepath.Join(r.store.RunRoot(), "networks")
+	}
+	args := []string{"--config", runDir, "--rootless=" + strconv.FormatBool(isRootless), "firewalld-reload"}
+
+	env := os.Environ()
+	if path := os.Getenv("PATH"); !strings.Contains(path, "/usr/sbin") {
+		env = append(env, "PATH="+path+":/usr/sbin")
+	}
+	if r.config.Network.FirewallDriver != "" {
+		env = append(env, "NETAVARK_FW="+r.config.Network.FirewallDriver)
+	}
+
+	reload := func() error {
+		cmd := exec.Command(info.Path, args...)
+		cmd.Env = env
+		logrus.Debugf("Reloading firewall rules: %s %s", info.Path, strings.Join(args, " "))
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("reloading firewall rules: %s: %w", strings.TrimSpace(string(out)), err)
+		}
+		return nil
+	}
+	if isRootless {
+		return r.network.RunInRootlessNetns(reload)
+	}
+	return reload()
+}
@@ -0,0 +1,362 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libnetwork/types"
+	"go.podman.io/storage/pkg/ioutils"
+)
+
+// networkPolicyTable is the nftables table holding the rules of the network
+// policy in the network namespace of a container.
+const networkPolicyTable = "podman-network-policy"
+
+// networkPolicyPeersDir indexes the running containers with a network
+// policy, in the temporary directory of the runtime. It holds a file per
+// container listing the pods named as peers by its policy, so that the
+// policies to update when the addresses of a pod change are found without
+// loading every container.
+const networkPolicyPeersDir = "network-policy"
+
+// applyNetworkPolicies applies the network policy of the container to its
+// network namespace ctrNS, whose network status is not stored yet, and the
+// network policies of the running containers naming the pod of the container
+// as peer, since the addresses of the pod may have changed.
+func (r *Runtime) applyNetworkPolicies(ctr *Container, ctrNS string, status map[string]types.StatusBlock) error {
+	if ctr.config.NetworkPolicy != nil {
+		if err := r.indexNetworkPolicy(ctr); err != nil {
+			return err
+		}
+	}
+	podName, peers, err := r.networkPolicyPeerContainers(ctr)
+	if err != nil {
+		return err
+	}
+	if ctr.config.NetworkPolicy != nil {
+		addrs := r.networkPolicyAddresses(ctr.config.NetworkPolicy, podName, status)
+		if err := r.applyNetworkPolicy(ctrNS, ctr.config.NetworkPolicy, addrs); err != nil {
+			return fmt.Errorf("applying network policy of container %s: %w", ctr.ID(), err)
+		}
+	}
+	r.updateNetworkPolicyPeers(peers, podName, status)
+	return nil
+}
+
+// removeNetworkPolicies is called when the network of the container is torn
+// down. The container is removed from the index of the policies, and the
+// addresses of its pod are removed from the policies of the running
+// containers naming the pod as peer, so that they are not allowed once
+// reused by another pod.
+func (r *Runtime) removeNetworkPolicies(ctr *Container) {
+	if ctr.config.NetworkPolicy != nil {
+		if err := os.Remove(filepath.Join(r.config.Engine.TmpDir, networkPolicyPeersDir, ctr.ID())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logrus.Warnf("Removing network policy of container %s from the index: %v", ctr.ID(), err)
+		}
+	}
+	podName, peers, err := r.networkPolicyPeerContainers(ctr)
+	if err != nil {
+		logrus.Warnf("Looking up the network policies naming container %s: %v", ctr.ID(), err)
+		return
+	}
+	r.updateNetworkPolicyPeers(peers, podName, nil)
+}
+
+// indexNetworkPolicy adds the container to the index of the policies.
+func (r *Runtime) indexNetworkPolicy(ctr *Container) error {
+	dir := filepath.Join(r.config.Engine.TmpDir, networkPolicyPeersDir)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return fmt.Errorf("creating network policy index: %w", err)
+	}
+	data, err := json.Marshal(ctr.config.NetworkPolicy.PeerPods())
+	if err != nil {
+		return err
+	}
+	if err := ioutils.AtomicWriteFile(filepath.Join(dir, ctr.ID()), data, 0o600); err != nil {
+		return fmt.Errorf("adding network policy of container %s to the index: %w", ctr.ID(), err)
+	}
+	return nil
+}
+
+// networkPolicyPeerContainers returns the name of the pod of the infra
+// container ctr and the other running containers whose policy names the pod
+// as peer. The index of the policies is read; when no container has a
+// policy, nothing is loaded from the database.
+func (r *Runtime) networkPolicyPeerContainers(ctr *Container) (string, []*Container, error) {
+	if !ctr.IsInfra() {
+		return "", nil, nil
+	}
+	dir := filepath.Join(r.config.Engine.TmpDir, networkPolicyPeersDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+	if len(entries) == 0 || (len(entries) == 1 && entries[0].Name() == ctr.ID()) {
+		return "", nil, nil
+	}
+
+	pod, err := r.state.Pod(ctr.PodID())
+	if err != nil {
+		return "", nil, err
+	}
+	var peers []*Container
+	for _, entry := range entries {
+		id := entry.Name()
+		if id == ctr.ID() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, id))
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				logrus.Debugf("Reading network policy index of container %s: %v", id, err)
+			}
+			continue
+		}
+		var pods []string
+		if err := json.Unmarshal(data, &pods); err != nil {
+			logrus.Debugf("Parsing network policy index of container %s: %v", id, err)
+			continue
+		}
+		if !slices.Contains(pods, pod.Name()) {
+			continue
+		}
+		peer, err := r.state.Container(id)
+		if err != nil {
+			logrus.Debugf("Looking up container %s with a network policy: %v", id, err)
+			continue
+		}
+		if err := r.state.UpdateContainer(peer); err != nil {
+			logrus.Debugf("Updating state of container %s with a network policy: %v", id, err)
+			continue
+		}
+		if peer.config.NetworkPolicy != nil && peer.state.NetNS != "" {
+			peers = append(peers, peer)
+		}
+	}
+	return pod.Name(), peers, nil
+}
+
+// updateNetworkPolicyPeers applies the policies of the peers again with the
+// addresses of the pod podName given by status, none if nil.
+func (r *Runtime) updateNetworkPolicyPeers(peers []*Container, podName string, status map[string]types.StatusBlock) {
+	for _, c := range peers {
+		addrs := r.networkPolicyAddresses(c.config.NetworkPolicy, podName, status)
+		if err := r.applyNetworkPolicy(c.state.NetNS, c.config.NetworkPolicy, addrs); err != nil {
+			logrus.Warnf("Updating network policy of container %s with the addresses of pod %s: %v", c.ID(), podName, err)
+		}
+	}
+}
+
+// networkPolicyAddresses returns the IP addresses of the pods named by the
+// policy, read from the network status of their infra containers. The
+// status of the pod podName is given, as it is not stored yet or is being
+// torn down.
+func (r *Runtime) networkPolicyAddresses(policy *define.NetworkPolicy, podName string, status map[string]types.StatusBlock) map[string][]net.IP {
+	addrs := make(map[string][]net.IP)
+	for _, name := range policy.PeerPods() {
+		podStatus := status
+		if name != podName {
+			podStatus = r.podNetworkStatus(name)
+		}
+		for _, netStatus := range podStatus {
+			for _, iface := range netStatus.Interfaces {
+				for _, subnet := range iface.Subnets {
+					addrs[name] = append(addrs[name], subnet.IPNet.IP)
+				}
+			}
+		}
+	}
+	return addrs
+}
+
+// podNetworkStatus returns the network status of the infra container of the
+// pod, nil if its network is not set up.
+func (r *Runtime) podNetworkStatus(name string) map[string]types.StatusBlock {
+	pod, err := r.state.LookupPod(name)
+	if err != nil {
+		logrus.Debugf("Looking up pod %s for its addresses: %v", name, err)
+		return nil
+	}
+	if err := r.state.UpdatePod(pod); err != nil {
+		logrus.Debugf("Updating state of pod %s for its addresses: %v", name, err)
+		return nil
+	}
+	if pod.state.InfraContainerID == "" {
+		return nil
+	}
+	infra, err := r.state.Container(pod.state.InfraContainerID)
+	if err != nil {
+		logrus.Debugf("Looking up infra container of pod %s for its addresses: %v", name, err)
+		return nil
+	}
+	if err := r.state.UpdateContainer(infra); err != nil {
+		logrus.Debugf("Updating state of infra container of pod %s for its addresses: %v", name, err)
+		return nil
+	}
+	if infra.state.NetNS == "" {
+		return nil
+	}
+	return infra.state.NetworkStatus
+}
+
+// applyNetworkPolicy replaces the nftables rules of the network policy in the
+// network namespace at nsPath.
+func (r *Runtime) applyNetworkPolicy(nsPath string, policy *define.NetworkPolicy, addrs map[string][]net.IP) error {
+	ruleset, err := networkPolicyRuleset(policy, addrs)
+	if err != nil {
+		return err
+	}
+	nft, err := r.config.FindHelperBinary("nft", true)
+	if err != nil {
+		return fmt.Errorf("network policies are enforced with nftables: %w", err)
+	}
+	return ns.WithNetNSPath(nsPath, func(_ ns.NetNS) error {
+		cmd := exec.Command(nft, "-f", "-")
+		cmd.Stdin = strings.NewReader(ruleset)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("running nft: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		logrus.Debugf("Applied network policy %v in network namespace %s", policy.Policies, nsPath)
+		return nil
+	})
+}
+
+// networkPolicyRuleset returns the nftables ruleset of the network policy.
+// It replaces the table of the policy atomically.  The established
+// connections, the loopback interface and the neighbor discovery of IPv6 are
+// always allowed; in an isolated direction, the connections not allowed by a
+// rule are dropped.  The pods named by the rules are resolved to their
+// addresses in addrs, a rule with no address left allows nothing.
+func networkPolicyRuleset(policy *define.NetworkPolicy, addrs map[string][]net.IP) (string, error) {
+	var b strings.Builder
+	fmt.Fprintf(&b, "table inet %s\ndelete table inet %s\n", networkPolicyTable, networkPolicyTable)
+	fmt.Fprintf(&b, "table inet %s {\n", networkPolicyTable)
+	for _, dir := range []struct {
+		chain, hook, iface, peer string
+		isolate                  bool
+		rules                    []define.NetworkPolicyRule
+	}{
+		{"ingress", "input", "iif", "saddr", policy.IsolateIngress, policy.Ingress},
+		{"egress", "output", "oif", "daddr", policy.IsolateEgress, policy.Egress},
+	} {
+		if !dir.isolate {
+			continue
+		}
+		fmt.Fprintf(&b, "\tchain %s {\n\t\ttype filter hook %s priority filter; policy drop;\n", dir.chain, dir.hook)
+		b.WriteString("\t\tct state established,related accept\n")
+		fmt.Fprintf(&b, "\t\t%s lo accept\n", dir.iface)
+		b.WriteString("\t\ticmpv6 type { nd-neighbor-solicit, nd-neighbor-advert, nd-router-solicit, nd-router-advert } accept\n")
+		for _, rule := range dir.rules {
+			peers, err := networkPolicyPeers(rule, dir.peer, addrs)
+			if err != nil {
+				return "", err
+			}
+			if !rule.AllPeers && len(peers) == 0 {
+				continue
+			}
+			ports, err := networkPolicyPorts(rule.Ports)
+			if err != nil {
+				return "", err
+			}
+			for _, peer := range peers {
+				for _, port := range ports {
+					fmt.Fprintf(&b, "\t\t%s accept\n", strings.TrimSpace(peer+" "+port))
+				}
+			}
+		}
+		b.WriteString("\t}\n")
+	}
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// networkPolicyPeers returns the nftables matches of the peers of the rule
+// in the direction, saddr or daddr.  It is a single empty match for all
+// peers.
+func networkPolicyPeers(rule define.NetworkPolicyRule, dir string, addrs map[string][]net.IP) ([]string, error) {
+	if rule.AllPeers {
+		return []string{""}, nil
+	}
+	var v4, v6 []string
+	for _, pod := range rule.Pods {
+		for _, ip := range addrs[pod] {
+			if ip.To4() != nil {
+				v4 = append(v4, ip.String())
+			} else {
+				v6 = append(v6, ip.String())
+			}
+		}
+	}
+	var matches []string
+	if len(v4) > 0 {
+		matches = append(matches, fmt.Sprintf("ip %s { %s }", dir, strings.Join(v4, ", ")))
+	}
+	if len(v6) > 0 {
+		matches = append(matches, fmt.Sprintf("ip6 %s { %s }", dir, strings.Join(v6, ", ")))
+	}
+	for _, block := range rule.IPBlocks {
+		_, cidr, err := net.ParseCIDR(block.CIDR)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ipBlock: %w", err)
+		}
+		family := "ip"
+		if cidr.IP.To4() == nil {
+			family = "ip6"
+		}
+		match := fmt.Sprintf("%s %s %s", family, dir, cidr)
+		if len(block.Except) > 0 {
+			except := make([]string, 0, len(block.Except))
+			for _, e := range block.Except {
+				_, ecidr, err := net.ParseCIDR(e)
+				if err != nil {
+					return nil, fmt.Errorf("invalid ipBlock except: %w", err)
+				}
+				except = append(except, ecidr.String())
+			}
+			match += fmt.Sprintf(" %s %s != { %s }", family, dir, strings.Join(except, ", "))
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// networkPolicyPorts returns the nftables matches of the destination ports.
+// It is a single empty match for all ports.
+func networkPolicyPorts(ports []define.NetworkPolicyPort) ([]string, error) {
+	if len(ports) == 0 {
+		return []string{""}, nil
+	}
+	matches := make([]string, 0, len(ports))
+	for _, port := range ports {
+		protocol := strings.ToLower(port.Protocol)
+		if !slices.Contains([]string{"tcp", "udp", "sctp"}, protocol) {
+			return nil, fmt.Errorf("invalid protocol %q: %w", port.Protocol, define.ErrInvalidArg)
+		}
+		switch {
+		case port.Port == 0:
+			matches = append(matches, "meta l4proto "+protocol)
+		case port.EndPort > port.Port:
+			matches = append(matches, fmt.Sprintf("%s dport %d-%d", protocol, port.Port, port.EndPort))
+		default:
+			matches = append(matches, fmt.Sprintf("%s dport %d", protocol, port.Port))
+		}
+	}
+	return matches, nil
+}
@@ -0,0 +1,107 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/common/pkg/config"
+)
+
+func Test_networkPolicyRuleset(t *testing.T) {
+	policy := &define.NetworkPolicy{
+		IsolateIngress: true,
+		Ingress: []define.NetworkPolicyRule{
+			{
+				Pods:     []string{"client", "stopped"},
+				IPBlocks: []define.NetworkPolicyIPBlock{{CIDR: "192.168.0.0/16", Except: []string{"192.168.1.0/24"}}},
+				Ports: []define.NetworkPolicyPort{
+					{Protocol: "tcp", Port: 8080},
+					{Protocol: "udp", Port: 5000, EndPort: 5010},
+				},
+			},
+			// The pod is not running, the rule allows nothing.
+			{Pods: []string{"stopped"}},
+		},
+		IsolateEgress: true,
+		Egress: []define.NetworkPolicyRule{
+			{AllPeers: true, Ports: []define.NetworkPolicyPort{{Protocol: "udp", Port: 53}, {Protocol: "sctp"}}},
+		},
+	}
+	addrs := map[string][]net.IP{
+		"client": {net.ParseIP("10.89.0.3"), net.ParseIP("fd00::3")},
+	}
+
+	ruleset, err := networkPolicyRuleset(policy, addrs)
+	require.NoError(t, err)
+	assert.Equal(t, `table inet podman-network-policy
+delete table inet podman-network-policy
+table inet podman-network-policy {
+	chain ingress {
+		type filter hook input priority filter; policy drop;
+		ct state established,related accept
+		iif lo accept
+		icmpv6 type { nd-neighbor-solicit, nd-neighbor-advert, nd-router-solicit, nd-router-advert } accept
+		ip saddr { 10.89.0.3 } tcp dport 8080 accept
+		ip saddr { 10.89.0.3 } udp dport 5000-5010 accept
+		ip6 saddr { fd00::3 } tcp dport 8080 accept
+		ip6 saddr { fd00::3 } udp dport 5000-5010 accept
+		ip saddr 192.168.0.0/16 ip saddr != { 192.168.1.0/24 } tcp dport 8080 accept
+		ip saddr 192.168.0.0/16 ip saddr != { 192.168.1.0/24 } udp dport 5000-5010 accept
+	}
+	chain egress {
+		type filter hook output priority filter; policy drop;
+		ct state established,related accept
+		oif lo accept
+		icmpv6 type { nd-neighbor-solicit, nd-neighbor-advert, nd-router-solicit, nd-router-advert } accept
+		udp dport 53 accept
+		meta l4proto sctp accept
+	}
+}
+`, ruleset)
+
+	// Without isolation, the table is emptied.
+	ruleset, err = networkPolicyRuleset(&define.NetworkPolicy{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "table inet podman-network-policy\ndelete table inet podman-network-policy\ntable inet podman-network-policy {\n}\n", ruleset)
+
+	_, err = networkPolicyRuleset(&define.NetworkPolicy{
+		IsolateIngress: true,
+		Ingress:        []define.NetworkPolicyRule{{AllPeers: true, Ports: []define.NetworkPolicyPort{{Protocol: "icmp"}}}},
+	}, nil)
+	assert.ErrorContains(t, err, `invalid protocol "icmp"`)
+}
+
+func Test_networkPolicyPeerContainersWithoutPolicies(t *testing.T) {
+	// The runtime has no state: without indexed policies, nothing is
+	// looked up in the database when the network of a pod is set up.
+	r := &Runtime{config: &config.Config{}}
+	r.config.Engine.TmpDir = t.TempDir()
+	infra := &Container{config: &ContainerConfig{ID: "infra"}}
+	infra.config.IsInfra = true
+
+	podName, peers, err := r.networkPolicyPeerContainers(infra)
+	require.NoError(t, err)
+	assert.Empty(t, podName)
+	assert.Empty(t, peers)
+
+	// The only indexed policy is the one of the container itself.
+	infra.config.NetworkPolicy = &define.NetworkPolicy{
+		IsolateIngress: true,
+		Ingress:        []define.NetworkPolicyRule{{Pods: []string{"web", "db"}}, {AllPeers: true}},
+		Egress:         []define.NetworkPolicyRule{{Pods: []string{"db"}}},
+	}
+	assert.Equal(t, []string{"web", "db"}, infra.config.NetworkPolicy.PeerPods())
+	require.NoError(t, r.indexNetworkPolicy(infra))
+	_, peers, err = r.networkPolicyPeerContainers(infra)
+	require.NoError(t, err)
+	assert.Empty(t, peers)
+
+	r.removeNetworkPolicies(infra)
+	assert.NoFileExists(t, filepath.Join(r.config.Engine.TmpDir, networkPolicyPeersDir, "infra"))
+}
@@ -0,0 +1,171 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libnetwork/etchosts"
+	"go.podman.io/common/libnetwork/types"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/storage/pkg/ioutils"
+	"go.podman.io/storage/pkg/lockfile"
+)
+
+// wireGuardPeersFile is the file of the WireGuard configuration directory of
+// a network holding the peers of this host, read by the plugin.
+const wireGuardPeersFile = "peers.json"
+
+// wireGuardPeersPath returns the path of the peers file of the WireGuard
+// network.
+func wireGuardPeersPath(network *types.Network) (string, error) {
+	dir, ok := network.Options[define.WireGuardConfigDirOption]
+	if network.Driver != define.WireGuardDriver || !ok {
+		return "", fmt.Errorf("network %s is not a %s network: %w", network.Name, define.WireGuardDriver, define.ErrInvalidArg)
+	}
+	return filepath.Join(dir, wireGuardPeersFile), nil
+}
+
+// WireGuardPeers returns the peers of this host in the WireGuard network.
+func (r *Runtime) WireGuardPeers(network *types.Network) ([]define.WireGuardPeer, error) {
+	path, err := wireGuardPeersPath(network)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var peers []define.WireGuardPeer
+	if err := json.Unmarshal(content, &peers); err != nil {
+		return nil, fmt.Errorf("parsing the WireGuard peers of network %s: %w", network.Name, err)
+	}
+	return peers, nil
+}
+
+// SetWireGuardPeers replaces the peers of this host in the WireGuard network.
+// The containers of the peers replace the ones of the previous peers in
+// /etc/hosts of the running containers on the network, containers started
+// later get them when their network is set up.
+func (r *Runtime) SetWireGuardPeers(network *types.Network, peers []define.WireGuardPeer) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+	oldPeers, err := r.WireGuardPeers(network)
+	if err != nil {
+		return err
+	}
+	path, err := wireGuardPeersPath(network)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(peers, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := ioutils.AtomicWriteFile(path, content, 0o600); err != nil {
+		return err
+	}
+
+	ctrs, err := r.GetRunningContainers()
+	if err != nil {
+		return err
+	}
+	oldEntries := wireGuardHostEntries(oldPeers)
+	newEntries := wireGuardHostEntries(peers)
+	for _, ctr := range ctrs {
+		if err := ctr.updateWireGuardHosts(network.Name, oldEntries, newEntries); err != nil {
+			logrus.Errorf("Updating /etc/hosts of container %s with the WireGuard peers of network %s: %v", ctr.ID(), network.Name, err)
+		}
+	}
+	return nil
+}
+
+// wireGuardHostEntries returns the /etc/hosts entries of the containers of
+// the peers.
+func wireGuardHostEntries(peers []define.WireGuardPeer) etchosts.HostEntries {
+	var entries etchosts.HostEntries
+	for _, peer := range peers {
+		for _, name := range slices.Sorted(maps.Keys(peer.Containers)) {
+			for _, ip := range peer.Containers[name] {
+				entries = append(entries, etchosts.HostEntry{IP: ip, Names: []string{name}})
+			}
+		}
+	}
+	return entries
+}
+
+// getWireGuardHostsEntries returns the /etc/hosts entries of the containers
+// of the peers of the WireGuard networks of the container.
+func (c *Container) getWireGuardHostsEntries() (etchosts.HostEntries, error) {
+	var entries etchosts.HostEntries
+	for _, name := range slices.Sorted(maps.Keys(c.state.NetworkStatus)) {
+		network, err := c.runtime.network.NetworkInspect(name)
+		if err != nil {
+			return nil, err
+		}
+		if network.Driver != define.WireGuardDriver {
+			continue
+		}
+		peers, err := c.runtime.WireGuardPeers(&network)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, wireGuardHostEntries(peers)...)
+	}
+	return entries, nil
+}
+
+// updateWireGuardHosts replaces the entries of the containers of the old
+// peers of the WireGuard network with the ones of the new peers in the
+// /etc/hosts file of the container, if it is running on the network.
+func (c *Container) updateWireGuardHosts(network string, oldEntries, newEntries etchosts.HostEntries) error {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+	if c.state.State != define.ContainerStateRunning {
+		return nil
+	}
+	if _, ok := c.state.NetworkStatus[network]; !ok {
+		return nil
+	}
+	file, ok := c.state.BindMounts[config.DefaultHostsFile]
+	if !ok {
+		return nil
+	}
+
+	// make sure to lock this file to prevent concurrent writes when
+	// this is used a net dependency container
+	lock, err := lockfile.GetLockFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to lock hosts file: %w", err)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if len(oldEntries) > 0 {
+		logrus.Debugf("Remove /etc/hosts entries %v", oldEntries)
+		if err := etchosts.Remove(file, oldEntries); err != nil {
+			return err
+		}
+	}
+	if len(newEntries) > 0 {
+		logrus.Debugf("Add /etc/hosts entries %v", newEntries)
+		return etchosts.Add(file, newEntries)
+	}
+	return nil
+}
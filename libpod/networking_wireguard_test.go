@@ -0,0 +1,44 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/common/libnetwork/etchosts"
+	"go.podman.io/common/libnetwork/types"
+)
+
+func TestWireGuardPeers(t *testing.T) {
+	r := &Runtime{}
+	dir := t.TempDir()
+	network := &types.Network{
+		Name:    "overlay",
+		Driver:  define.WireGuardDriver,
+		Options: map[string]string{define.WireGuardConfigDirOption: dir},
+	}
+
+	// no peers set yet
+	peers, err := r.WireGuardPeers(network)
+	require.NoError(t, err)
+	assert.Empty(t, peers)
+
+	content := `[{"host": "host2", "public_key": "key", "endpoint": "host2:51820", "allowed_ips": ["10.90.2.0/24"], "containers": {"web": ["10.90.2.2"], "db": ["10.90.2.3"]}}]`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, wireGuardPeersFile), []byte(content), 0o600))
+	peers, err = r.WireGuardPeers(network)
+	require.NoError(t, err)
+	require.Len(t, peers, 1)
+	assert.Equal(t, "host2", peers[0].Host)
+	assert.Equal(t, etchosts.HostEntries{
+		{IP: "10.90.2.3", Names: []string{"db"}},
+		{IP: "10.90.2.2", Names: []string{"web"}},
+	}, wireGuardHostEntries(peers))
+
+	_, err = r.WireGuardPeers(&types.Network{Name: "bridge", Driver: types.BridgeNetworkDriver})
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+}
@@ -885,14 +885,29 @@ func (r *ConmonOCIRuntime) RuntimeInfo() (*define.ConmonInfo, *define.OCIRuntime
 		Version: conmonVersion,
 	}
 	ocirt := define.OCIRuntimeInfo{
-		Name:    r.name,
-		Path:    r.path,
-		Package: runtimePackage,
-		Version: runtimeVersion,
+		Name:     r.name,
+		Path:     r.path,
+		Package:  runtimePackage,
+		Version:  runtimeVersion,
+		Features: parseOCIRuntimeFeatures(runtimeVersion),
 	}
 	return &conmon, &ocirt, nil
 }
 
+// parseOCIRuntimeFeatures returns the features listed in the version output
+// of the OCI runtime. crun lists the features it was built with as
+// "+SYSTEMD +SELINUX ... +WASM:wasmedge", these are returned lowercased
+// and without the plus sign.
+func parseOCIRuntimeFeatures(runtimeVersion string) []string {
+	var features []string
+	for _, field := range strings.Fields(runtimeVersion) {
+		if feature, ok := strings.CutPrefix(field, "+"); ok && feature != "" {
+			features = append(features, strings.ToLower(feature))
+		}
+	}
+	return features
+}
+
 // Wait for a container which has been sent a signal to stop
 func waitContainerStop(ctr *Container, timeout time.Duration) error {
 	return waitPidStop(ctr.state.PID, timeout)
@@ -1058,7 +1073,9 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 	// Pass down the LISTEN_* environment (see #10443).
 	if val := os.Getenv("LISTEN_FDS"); val != "" {
 		if preserveFDs > 0 || len(ctr.config.PreserveFD) > 0 {
-			logrus.Warnf("Ignoring LISTEN_FDS to preserve custom user-specified FDs")
+			if !ctr.config.SocketActivation {
+				logrus.Warnf("Ignoring LISTEN_FDS to preserve custom user-specified FDs")
+			}
 		} else {
 			fds, err := strconv.Atoi(val)
 			if err != nil {
@@ -1115,9 +1132,9 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 
 	logrus.WithFields(logrus.Fields{
 		"args": args,
-	}).Debugf("running conmon: %s", r.conmonPath)
+	}).Debugf("running conmon: %s", r.monitorPath(ctr))
 
-	cmd := exec.Command(r.conmonPath, args...)
+	cmd := exec.Command(r.monitorPath(ctr), args...)
 	cmd.SysProcAttr = &syscall.SysProcAttr{
 		Setpgid: true,
 	}
@@ -1194,7 +1211,7 @@ func (r *ConmonOCIRuntime) createOCIContainer(ctr *Container, restoreOptions *Co
 	if restoreOptions != nil {
 		runtimeRestoreStarted = time.Now()
 	}
-	err = cmd.Start()
+	err = r.withNUMAPolicy(ctr, cmd.Start)
 
 	// regardless of whether we errored or not, we no longer need the children pipes
 	childSyncPipe.Close()
@@ -1341,7 +1358,7 @@ func (r *ConmonOCIRuntime) sharedConmonArgs(ctr *Container, cuuid, bundlePath, p
 	logLevel := logrus.GetLevel()
 	args = append(args, "--log-level", logLevel.String())
 
-	logrus.Debugf("%s messages will be logged to syslog", r.conmonPath)
+	logrus.Debugf("%s messages will be logged to syslog", r.monitorPath(ctr))
 	args = append(args, "--syslog")
 
 	size := ctr.LogSizeMax()
@@ -1475,6 +1492,15 @@ func formatRuntimeOpts(opts ...string) []string {
 	return args
 }
 
+// monitorPath returns the path of the container monitor of ctr, the conmon
+// of the runtime unless another monitor was selected for the container.
+func (r *ConmonOCIRuntime) monitorPath(ctr *Container) string {
+	if ctr.config.MonitorPath != "" {
+		return ctr.config.MonitorPath
+	}
+	return r.conmonPath
+}
+
 // getConmonVersion returns a string representation of the conmon version.
 func (r *ConmonOCIRuntime) getConmonVersion() (string, error) {
 	output, err := utils.ExecCmd(r.conmonPath, "--version")
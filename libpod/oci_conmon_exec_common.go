@@ -438,8 +438,8 @@ func (r *ConmonOCIRuntime) startExec(c *Container, sessionID string, options *Ex
 
 	logrus.WithFields(logrus.Fields{
 		"args": args,
-	}).Debugf("running conmon: %s", r.conmonPath)
-	execCmd := exec.Command(r.conmonPath, args...)
+	}).Debugf("running conmon: %s", r.monitorPath(c))
+	execCmd := exec.Command(r.monitorPath(c), args...)
 
 	// TODO: This is commented because it doesn't make much sense in HTTP
 	// attach, and I'm not certain it does for non-HTTP attach as well.
@@ -18,6 +18,12 @@ func (r *ConmonOCIRuntime) withContainerSocketLabel(_ *Container, closure func()
 	return closure()
 }
 
+// Run the closure with the container's NUMA memory policy set
+func (r *ConmonOCIRuntime) withNUMAPolicy(_ *Container, closure func() error) error {
+	// No NUMA policy support yet
+	return closure()
+}
+
 // moveConmonToCgroupAndSignal gets a container's cgroupParent and moves the conmon process to that cgroup
 // it then signals for conmon to start by sending nonce data down the start fd
 func (r *ConmonOCIRuntime) moveConmonToCgroupAndSignal(_ *Container, _ *exec.Cmd, startFd *os.File) error {
@@ -12,7 +12,9 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"unsafe"
 
+	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/errorhandling"
 	"github.com/dmikushin/podman-shared/pkg/rootless"
 	runcconfig "github.com/opencontainers/cgroups"
@@ -24,6 +26,7 @@ import (
 	"go.podman.io/common/pkg/config"
 	"go.podman.io/common/pkg/systemd"
 	pmount "go.podman.io/storage/pkg/mount"
+	"go.podman.io/storage/pkg/parsers"
 	"golang.org/x/sys/unix"
 )
 
@@ -165,6 +168,99 @@ func (r *ConmonOCIRuntime) withContainerSocketLabel(ctr *Container, closure func
 	return err
 }
 
+// Memory policy modes for set_mempolicy(2).
+const (
+	mpolDefault = iota
+	mpolPreferred
+	mpolBind
+	mpolInterleave
+	mpolLocal
+)
+
+// numaNodeMask converts a list of NUMA nodes (e.g. 0-1,3) to the node mask
+// expected by set_mempolicy(2).
+func numaNodeMask(nodes string) ([]uint64, error) {
+	parsed, err := parsers.ParseUintList(nodes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid NUMA nodes %q: %w", nodes, err)
+	}
+	var mask []uint64
+	for node := range parsed {
+		for len(mask) <= node/64 {
+			mask = append(mask, 0)
+		}
+		mask[node/64] |= 1 << (node % 64)
+	}
+	return mask, nil
+}
+
+func setMemPolicy(mode int, mask []uint64) error {
+	var maskPtr unsafe.Pointer
+	maxNode := 0
+	if len(mask) > 0 {
+		maskPtr = unsafe.Pointer(&mask[0])
+		// The kernel ignores the last bit of the mask.
+		maxNode = len(mask)*64 + 1
+	}
+	if _, _, errno := unix.Syscall(unix.SYS_SET_MEMPOLICY, uintptr(mode), uintptr(maskPtr), uintptr(maxNode)); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// withNUMAPolicy runs the closure with the NUMA memory policy of the container
+// set on the current thread.  The policy is inherited by conmon, the OCI runtime
+// and the container processes.
+func (r *ConmonOCIRuntime) withNUMAPolicy(ctr *Container, closure func() error) error {
+	var mode int
+	switch ctr.config.NUMAPolicy {
+	case "", define.NUMAPolicyDefault:
+		return closure()
+	case define.NUMAPolicyBind:
+		mode = mpolBind
+	case define.NUMAPolicyInterleave:
+		mode = mpolInterleave
+	case define.NUMAPolicyLocal:
+		mode = mpolLocal
+	case define.NUMAPolicyPreferred:
+		mode = mpolPreferred
+	default:
+		return fmt.Errorf("unknown NUMA policy %q: %w", ctr.config.NUMAPolicy, define.ErrInvalidArg)
+	}
+
+	var mask []uint64
+	if define.NUMAPolicyNeedsNodes(ctr.config.NUMAPolicy) {
+		mems := ""
+		if ctr.config.Spec.Linux != nil && ctr.config.Spec.Linux.Resources != nil && ctr.config.Spec.Linux.Resources.CPU != nil {
+			mems = ctr.config.Spec.Linux.Resources.CPU.Mems
+		}
+		if mems == "" {
+			return fmt.Errorf("NUMA policy %q requires memory nodes to be set with --cpuset-mems: %w", ctr.config.NUMAPolicy, define.ErrInvalidArg)
+		}
+		var err error
+		mask, err = numaNodeMask(mems)
+		if err != nil {
+			return err
+		}
+	}
+
+	runtime.LockOSThread()
+	if err := setMemPolicy(mode, mask); err != nil {
+		runtime.UnlockOSThread()
+		return fmt.Errorf("setting NUMA policy %q: %w", ctr.config.NUMAPolicy, err)
+	}
+	err := closure()
+	if policyErr := setMemPolicy(mpolDefault, nil); policyErr == nil {
+		// Unlock the thread only if the memory policy could be restored
+		// successfully.  Otherwise leave the thread locked and the Go runtime
+		// will terminate it once it returns to the threads pool.
+		runtime.UnlockOSThread()
+	} else {
+		logrus.Errorf("Unable to reset NUMA policy: %q", policyErr)
+	}
+	return err
+}
+
 // Create systemd unit name for cgroup scopes.
 func createUnitName(prefix string, name string) string {
 	return fmt.Sprintf("%s-%s.scope", prefix, name)
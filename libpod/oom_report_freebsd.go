@@ -0,0 +1,15 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+)
+
+// oomReport returns a snapshot of the memory usage of the container, which
+// was OOM killed at killedAt.
+func (c *Container) oomReport(killedAt time.Time) *define.OOMReport {
+	return &define.OOMReport{Time: killedAt}
+}
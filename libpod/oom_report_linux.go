@@ -0,0 +1,149 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/cgroups"
+)
+
+// oomReportProcesses is the maximum number of processes of an OOM report.
+const oomReportProcesses = 10
+
+// oomReportStats are the memory.stat entries kept in an OOM report.
+var oomReportStats = []string{"anon", "file", "kernel", "kernel_stack", "pagetables", "percpu", "sock", "shmem", "slab", "file_mapped", "file_dirty", "file_writeback"}
+
+// oomReport returns a snapshot of the memory usage of the container, which
+// was OOM killed at killedAt.  The details read from the cgroup of the
+// container are only available with cgroups v2, as long as the cgroup was
+// not removed yet.
+func (c *Container) oomReport(killedAt time.Time) *define.OOMReport {
+	report := &define.OOMReport{Time: killedAt}
+	if spec := c.config.Spec; spec != nil && spec.Linux != nil && spec.Linux.Resources != nil &&
+		spec.Linux.Resources.Memory != nil && spec.Linux.Resources.Memory.Limit != nil && *spec.Linux.Resources.Memory.Limit > 0 {
+		report.MemoryLimit = uint64(*spec.Linux.Resources.Memory.Limit)
+	}
+	if c.state.CgroupPath == "" {
+		return report
+	}
+	if unified, err := cgroups.IsCgroup2UnifiedMode(); err != nil || !unified {
+		return report
+	}
+	dir := filepath.Join("/sys/fs/cgroup", c.state.CgroupPath)
+	if _, err := os.Stat(dir); err != nil {
+		logrus.Debugf("Cgroup of OOM killed container %s is gone: %v", c.ID(), err)
+		return report
+	}
+
+	report.MemoryPeak, _ = readCgroupValue(filepath.Join(dir, "memory.peak"))
+	report.SwapPeak, _ = readCgroupValue(filepath.Join(dir, "memory.swap.peak"))
+	if limit, err := readCgroupValue(filepath.Join(dir, "memory.max")); err == nil && limit > 0 {
+		// the limit may have been changed by podman update
+		report.MemoryLimit = limit
+	}
+	if events, err := readCgroupKeyedValues(filepath.Join(dir, "memory.events")); err == nil {
+		report.MemoryEvents = events
+	}
+	if stat, err := readCgroupKeyedValues(filepath.Join(dir, "memory.stat")); err == nil {
+		report.MemoryStat = make(map[string]uint64)
+		for _, key := range oomReportStats {
+			if value, ok := stat[key]; ok {
+				report.MemoryStat[key] = value
+			}
+		}
+	}
+	report.Processes = cgroupProcesses(dir)
+	return report
+}
+
+// readCgroupValue reads a cgroup file holding a single value, "max" is
+// returned as 0.
+func readCgroupValue(path string) (uint64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	value := strings.TrimSpace(string(content))
+	if value == "max" {
+		return 0, nil
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// readCgroupKeyedValues reads a cgroup file of "key value" lines.
+func readCgroupKeyedValues(path string) (map[string]uint64, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseKeyedValues(content)
+}
+
+// cgroupProcesses returns the processes of the cgroup at dir and of its
+// children using the most resident memory.
+func cgroupProcesses(dir string) []define.OOMProcess {
+	var processes []define.OOMProcess
+	_ = filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return nil
+		}
+		content, err := os.ReadFile(filepath.Join(path, "cgroup.procs"))
+		if err != nil {
+			return nil
+		}
+		for _, line := range strings.Fields(string(content)) {
+			pid, err := strconv.Atoi(line)
+			if err != nil {
+				continue
+			}
+			if process, err := readProcess(pid); err == nil {
+				processes = append(processes, process)
+			}
+		}
+		return nil
+	})
+	slices.SortFunc(processes, func(a, b define.OOMProcess) int {
+		return cmp.Compare(b.RSS, a.RSS)
+	})
+	if len(processes) > oomReportProcesses {
+		processes = processes[:oomReportProcesses]
+	}
+	return processes
+}
+
+// readProcess reads the name and the resident memory of a process from
+// /proc/PID/status.
+func readProcess(pid int) (define.OOMProcess, error) {
+	process := define.OOMProcess{PID: pid}
+	content, err := os.ReadFile(fmt.Sprintf("/proc/%d/status", pid))
+	if err != nil {
+		return process, err
+	}
+	for line := range strings.SplitSeq(string(content), "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Name":
+			process.Command = value
+		case "VmRSS":
+			kb, err := strconv.ParseUint(strings.TrimSuffix(value, " kB"), 10, 64)
+			if err == nil {
+				process.RSS = kb * 1024
+			}
+		}
+	}
+	return process, nil
+}
@@ -0,0 +1,28 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyedValues(t *testing.T) {
+	values, err := parseKeyedValues([]byte("low 0\nhigh 3\nmax 12\noom 1\noom_kill 1\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]uint64{"low": 0, "high": 3, "max": 12, "oom": 1, "oom_kill": 1}, values)
+
+	_, err = parseKeyedValues([]byte("anon -1\n"))
+	assert.Error(t, err)
+}
+
+func TestReadProcess(t *testing.T) {
+	process, err := readProcess(os.Getpid())
+	require.NoError(t, err)
+	assert.Equal(t, os.Getpid(), process.PID)
+	assert.NotEmpty(t, process.Command)
+	assert.NotZero(t, process.RSS)
+}
@@ -122,6 +122,66 @@ func WithTransientStore(transientStore bool) RuntimeOption {
 	}
 }
 
+// WithReadWriteRoot makes the configured graph root a read-only image store,
+// for graph roots on read-only media, and stores the images pulled, the
+// containers and the state of libpod in the given writable graph root.
+func WithReadWriteRoot(rwRoot string) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		if rwRoot == "" {
+			return fmt.Errorf("must provide a valid path: %w", define.ErrInvalidArg)
+		}
+
+		rt.readWriteRoot = rwRoot
+
+		return nil
+	}
+}
+
+// WithSharedLayerCache copies the shared base layers mounted often to the
+// given local directory and mounts the copies instead, removing the least
+// recently used copies to keep the directory under maxSize bytes.
+func WithSharedLayerCache(dir string, maxSize int64) RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		if dir == "" {
+			return fmt.Errorf("must provide a valid path: %w", define.ErrInvalidArg)
+		}
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("resolving shared layer cache %s: %w", dir, err)
+		}
+		if maxSize <= 0 {
+			return fmt.Errorf("shared layer cache size must be positive: %w", define.ErrInvalidArg)
+		}
+
+		rt.sharedLayerCacheDir = absDir
+		rt.sharedLayerCacheSize = maxSize
+
+		return nil
+	}
+}
+
+// WithSharedImageStorePull sets up the runtime to pull images into the
+// additional image store on shared storage with PullImageToSharedStore.
+func WithSharedImageStorePull() RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		rt.sharedImageStorePull = true
+
+		return nil
+	}
+}
+
 func WithImageStore(imageStore string) RuntimeOption {
 	return func(rt *Runtime) error {
 		if rt.valid {
@@ -162,6 +222,38 @@ func WithCtrOCIRuntime(runtime string) CtrCreateOption {
 	}
 }
 
+// WithRuntimeClass runs the container with the OCI runtime of the runtime
+// class.  The annotations of the class must be added to the spec of the
+// container by the caller.
+func WithRuntimeClass(class *RuntimeClass) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.RuntimeClass = class.Name
+		ctr.config.OCIRuntime = class.Runtime
+		return nil
+	}
+}
+
+// WithMonitor selects the container monitor of the container: conmon,
+// conmon-rs or the absolute path of a monitor binary.  The monitor is probed
+// for the conmon command line options Podman needs.
+func WithMonitor(monitor string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		path, err := ctr.runtime.resolveMonitor(monitor)
+		if err != nil {
+			return err
+		}
+		ctr.config.Monitor = monitor
+		ctr.config.MonitorPath = path
+		return nil
+	}
+}
+
 // WithConmonPath specifies the path to the conmon binary which manages the
 // runtime.
 func WithConmonPath(path string) RuntimeOption {
@@ -260,6 +352,23 @@ func WithRegistriesConf(path string) RuntimeOption {
 	}
 }
 
+// WithShortNameAliasConf configures the runtime to record the short-name
+// aliases of pulled images in the specified file instead of the per-user
+// cache.
+func WithShortNameAliasConf(path string) RuntimeOption {
+	logrus.Debugf("Setting custom short-name alias cache: %q", path)
+	return func(rt *Runtime) error {
+		if rt.imageContext == nil {
+			rt.imageContext = &types.SystemContext{
+				BigFilesTemporaryDir: parse.GetTempDir(),
+			}
+		}
+
+		rt.imageContext.UserShortNameAliasConfPath = path
+		return nil
+	}
+}
+
 // WithDatabaseBackend configures the runtime's database backend.
 func WithDatabaseBackend(value string) RuntimeOption {
 	logrus.Debugf("Setting custom database backend: %q", value)
@@ -282,6 +391,7 @@ func WithHooksDir(hooksDirs ...string) RuntimeOption {
 		}
 
 		rt.config.Engine.HooksDir.Set(hooksDirs)
+		rt.hooksDirsFromOptions = true
 		return nil
 	}
 }
@@ -430,6 +540,22 @@ func WithRenumber() RuntimeOption {
 	}
 }
 
+// WithLazyStorage tells Libpod to defer setting up the container storage, and
+// the network backend depending on it, until they are first used through the
+// accessors of the runtime.  It is meant for commands that do not use the
+// storage at all, for which setting it up is costly on slow graph roots.
+func WithLazyStorage() RuntimeOption {
+	return func(rt *Runtime) error {
+		if rt.valid {
+			return define.ErrRuntimeFinalized
+		}
+
+		rt.lazyStorage = true
+
+		return nil
+	}
+}
+
 // WithEventsLogger sets the events backend to use.
 // Currently supported values are "file" for file backend and "journald" for
 // journald backend.
@@ -975,6 +1101,16 @@ func WithNetNS(portMappings []nettypes.PortMapping, postConfigureNetNS bool, net
 		if !ctr.config.NetMode.IsBridge() && len(networks) > 0 {
 			return errors.New("cannot use networks when network mode is not bridge")
 		}
+		for name, opts := range networks {
+			if _, err := define.ParseBandwidth(opts.Options); err != nil {
+				return fmt.Errorf("network %s: %w", name, err)
+			}
+			if value, ok := opts.Options[define.DNSCacheOption]; ok {
+				if _, err := define.ParseDNSCache(value); err != nil {
+					return fmt.Errorf("network %s: %w", name, err)
+				}
+			}
+		}
 		ctr.config.Networks = networks
 
 		return nil
@@ -1008,6 +1144,46 @@ func WithNetworkOptions(options map[string][]string) CtrCreateOption {
 	}
 }
 
+// WithTrackFlows enables the accounting of the connection tracking of the
+// kernel for the connections of the container, which are shown by inspect.
+func WithTrackFlows() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.TrackFlows = true
+		return nil
+	}
+}
+
+// WithNetworkPolicy restricts the traffic of the network namespace of the
+// container to the connections allowed by the policy.  The policy is only
+// applied to network namespaces connected to networks by the network backend.
+func WithNetworkPolicy(policy *define.NetworkPolicy) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.NetworkPolicy = policy
+		return nil
+	}
+}
+
+// WithStatsHistory records samples of the resource usage of the container in
+// its stats history at the interval while it runs.
+func WithStatsHistory(interval time.Duration) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if interval < time.Second {
+			return fmt.Errorf("stats history interval must be at least 1s, not %s: %w", interval, define.ErrInvalidArg)
+		}
+		ctr.config.StatsHistoryInterval = interval
+		return nil
+	}
+}
+
 // WithLogDriver sets the log driver for the container
 func WithLogDriver(driver string) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -1499,6 +1675,19 @@ func WithPreserveFD(fds []uint) CtrCreateOption {
 	}
 }
 
+// WithSocketActivation passes the file descriptors preserved with
+// WithPreserveFDs or WithPreserveFD to the container as sockets of systemd
+// socket activation.
+func WithSocketActivation() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		ctr.config.SocketActivation = true
+		return nil
+	}
+}
+
 // WithCreateCommand adds the full command plus arguments of the current
 // process to the container config.
 func WithCreateCommand(cmd []string) CtrCreateOption {
@@ -1764,13 +1953,28 @@ func WithVolumeDriverTimeout(timeout uint) VolumeCreateOption {
 	}
 }
 
+// WithLocale sets the locale of the container, which is generated when the
+// image provides its definition only.
+func WithLocale(locale string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if locale == "" || strings.ContainsAny(locale, "/ ") || strings.HasPrefix(locale, ".") {
+			return fmt.Errorf("invalid locale %q: %w", locale, define.ErrInvalidArg)
+		}
+		ctr.config.Locale = locale
+		return nil
+	}
+}
+
 // WithTimezone sets the timezone in the container
 func WithTimezone(path string) CtrCreateOption {
 	return func(ctr *Container) error {
 		if ctr.valid {
 			return define.ErrCtrFinalized
 		}
-		if path != "local" {
+		if path != "local" && path != define.TimezoneHost {
 			// validate the format of the timezone specified if it's not "local"
 			_, err := time.LoadLocation(path)
 			if err != nil {
@@ -1797,6 +2001,20 @@ func WithUmask(umask string) CtrCreateOption {
 	}
 }
 
+// WithNUMAPolicy sets the NUMA memory policy of the container
+func WithNUMAPolicy(policy string) CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+		if err := define.ValidateNUMAPolicy(policy); err != nil {
+			return err
+		}
+		ctr.config.NUMAPolicy = policy
+		return nil
+	}
+}
+
 // WithSecrets adds secrets to the container
 func WithSecrets(containerSecrets []*ContainerSecret) CtrCreateOption {
 	return func(ctr *Container) error {
@@ -2192,6 +2410,22 @@ func WithPodResources(resources specs.LinuxResources) PodCreateOption {
 	}
 }
 
+// WithStorageTransient makes the container transient: its bundle and logs are
+// kept under the run root, its writable layer is volatile, and it is removed
+// when the system reboots.
+func WithStorageTransient() CtrCreateOption {
+	return func(ctr *Container) error {
+		if ctr.valid {
+			return define.ErrCtrFinalized
+		}
+
+		ctr.config.StorageTransient = true
+		ctr.config.Volatile = true
+
+		return nil
+	}
+}
+
 // WithVolatile sets the volatile flag for the container storage.
 // The option can potentially cause data loss when used on a container that must survive a machine reboot.
 func WithVolatile() CtrCreateOption {
@@ -13,6 +13,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/rootless"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libnetwork/types"
 	"go.podman.io/common/pkg/cgroups"
 )
 
@@ -72,6 +73,14 @@ func (p *Pod) startInitContainers(ctx context.Context) error {
 // set to ErrPodPartialFail.
 // If both error and the map are nil, all containers were started successfully.
 func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
+	return p.StartWithJobs(ctx, 0)
+}
+
+// StartWithJobs starts all containers within a pod like Start, starting at
+// most jobs containers at once.  Containers are started once the containers
+// they depend on are started.  Zero jobs uses the number of parallel jobs of
+// Podman.
+func (p *Pod) StartWithJobs(ctx context.Context, jobs uint) (map[string]error, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -83,6 +92,10 @@ func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
 		return nil, err
 	}
 
+	// The containers of the pod share the image lookups and hooks
+	// needed to generate their specs.
+	ctx = WithStartBatch(ctx)
+
 	// Before "regular" containers start in the pod, all init containers
 	// must have run and exited successfully.
 	if err := p.startInitContainers(ctx); err != nil {
@@ -103,12 +116,18 @@ func (p *Pod) Start(ctx context.Context) (map[string]error, error) {
 		return nil, fmt.Errorf("no containers in pod %s have no dependencies, cannot start pod: %w", p.ID(), define.ErrNoSuchCtr)
 	}
 
+	// Start the containers beginning with the ones with no dependencies
+	errMap, err := ContainerOpOrdered(ctx, allCtrs, jobs, false, func(ctr *Container) error {
+		return ctr.startInGraph(ctx, false)
+	})
+	if err != nil {
+		return nil, err
+	}
 	ctrErrors := make(map[string]error)
-	ctrsVisited := make(map[string]bool)
-
-	// Traverse the graph beginning at nodes with no dependencies
-	for _, node := range graph.noDepNodes {
-		startNode(ctx, node, false, ctrErrors, ctrsVisited, false)
+	for ctr, err := range errMap {
+		if err != nil {
+			ctrErrors[ctr.ID()] = err
+		}
 	}
 
 	if len(ctrErrors) > 0 {
@@ -140,13 +159,21 @@ func (p *Pod) Stop(ctx context.Context, cleanup bool) (map[string]error, error)
 // set to ErrPodPartialFail.
 // If both error and the map are nil, all containers were stopped without error.
 func (p *Pod) StopWithTimeout(ctx context.Context, cleanup bool, timeout int) (map[string]error, error) {
+	return p.StopWithJobs(ctx, cleanup, timeout, 0)
+}
+
+// StopWithJobs stops all containers within a pod like StopWithTimeout,
+// stopping at most jobs containers at once.  Containers are stopped once the
+// containers that depend on them are stopped.  Zero jobs uses the number of
+// parallel jobs of Podman.
+func (p *Pod) StopWithJobs(ctx context.Context, cleanup bool, timeout int, jobs uint) (map[string]error, error) {
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
-	return p.stopWithTimeout(ctx, cleanup, timeout)
+	return p.stopWithTimeout(ctx, cleanup, timeout, jobs)
 }
 
-func (p *Pod) stopWithTimeout(ctx context.Context, cleanup bool, timeout int) (map[string]error, error) {
+func (p *Pod) stopWithTimeout(ctx context.Context, cleanup bool, timeout int, jobs uint) (map[string]error, error) {
 	if !p.valid {
 		return nil, define.ErrPodRemoved
 	}
@@ -190,7 +217,7 @@ func (p *Pod) stopWithTimeout(ctx context.Context, cleanup bool, timeout int) (m
 			realTimeout = &innerTimeout
 		}
 
-		ctrErrors, err = stopContainerGraph(ctx, graph, p, realTimeout, cleanup)
+		ctrErrors, err = stopContainerGraph(ctx, graph, p, realTimeout, cleanup, jobs)
 		if err != nil {
 			return nil, err
 		}
@@ -255,7 +282,7 @@ func (p *Pod) stopIfOnlyInfraRemains(ctx context.Context, ignoreID string) error
 		}
 	}
 
-	errs, err := p.stopWithTimeout(ctx, true, -1)
+	errs, err := p.stopWithTimeout(ctx, true, -1, 0)
 	for ctr, e := range errs {
 		logrus.Errorf("Failed to stop container %s: %v", ctr, e)
 	}
@@ -468,6 +495,8 @@ func (p *Pod) Restart(ctx context.Context) (map[string]error, error) {
 		return nil, err
 	}
 
+	ctx = WithStartBatch(ctx)
+
 	allCtrs, err := p.runtime.state.PodContainers(p)
 	if err != nil {
 		return nil, err
@@ -601,6 +630,35 @@ func containerStatusFromContainers(allCtrs []*Container) (map[string]define.Cont
 	return status, nil
 }
 
+// UpdatePortMappings replaces the ports published by the infra container of
+// the pod.  When the pod is running, the port forwarding of its network
+// namespace is reconfigured in place, which requires rootful bridge
+// networking.
+func (p *Pod) UpdatePortMappings(ports []types.PortMapping) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if !p.valid {
+		return define.ErrPodRemoved
+	}
+	infra, err := p.infraContainer()
+	if err != nil {
+		return err
+	}
+
+	infra.lock.Lock()
+	defer infra.lock.Unlock()
+	if err := infra.syncContainer(); err != nil {
+		return err
+	}
+	if err := infra.updatePortMappings(ports); err != nil {
+		return err
+	}
+
+	p.newPodEvent(events.Update)
+	return nil
+}
+
 // Inspect returns a PodInspect struct to describe the pod.
 func (p *Pod) Inspect() (*define.InspectPodData, error) {
 	p.lock.Lock()
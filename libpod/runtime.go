@@ -25,6 +25,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities/reports"
 	artStore "github.com/dmikushin/podman-shared/pkg/libartifact/store"
+	"github.com/dmikushin/podman-shared/pkg/registrycache"
 	"github.com/dmikushin/podman-shared/pkg/rootless"
 	"github.com/dmikushin/podman-shared/pkg/systemd"
 	"github.com/dmikushin/podman-shared/pkg/util"
@@ -110,6 +111,34 @@ type Runtime struct {
 	// errors related to lock initialization so a renumber can be performed
 	// if something has gone wrong.
 	doRenumber bool
+	// lazyStorage indicates that the container storage and the network
+	// backend depending on it are only set up when first used.
+	lazyStorage     bool
+	lazyStorageOnce sync.Once
+	// lazyStorageErr is the error of the deferred setup, returned by the
+	// accessors instead of the unset store or network backend.
+	lazyStorageErr error
+	// readWriteRoot is the writable graph root set with WithReadWriteRoot,
+	// the configured graph root is then only read.
+	readWriteRoot string
+	// readOnlyGraphRoot is the configured graph root, used as a read-only
+	// image store of readWriteRoot.
+	readOnlyGraphRoot string
+	// sharedLayerCacheDir and sharedLayerCacheSize are set with
+	// WithSharedLayerCache, sharedLayerCache is then the local cache of
+	// the shared base layers.
+	sharedLayerCacheDir  string
+	sharedLayerCacheSize int64
+	sharedLayerCache     *sharedLayerCache
+	// sharedImageStorePull is set with WithSharedImageStorePull,
+	// sharedImageStore is then the additional image store on shared
+	// storage images are pulled into, empty if the image storage is on
+	// shared storage.
+	sharedImageStorePull bool
+	sharedImageStore     string
+	// hooksDirsFromOptions indicates that the hooks directories were set
+	// with WithHooksDir and must not be reloaded from containers.conf.
+	hooksDirsFromOptions bool
 
 	// valid indicates whether the runtime is ready to use.
 	// valid is set to true when a runtime is returned from GetRuntime(),
@@ -122,6 +151,10 @@ type Runtime struct {
 
 	// secretsManager manages secrets
 	secretsManager *secrets.SecretsManager
+
+	// registryCache is the registry cache served by the API service, if
+	// enabled.
+	registryCache *registrycache.Cache
 }
 
 // SetXdgDirs ensures the XDG_RUNTIME_DIR env and XDG_CONFIG_HOME variables are set.
@@ -285,6 +318,15 @@ func getLockManager(runtime *Runtime) (lock.Manager, error) {
 	return manager, nil
 }
 
+// boltStatePath returns the path of the BoltDB database
+func boltStatePath(runtime *Runtime) string {
+	baseDir := runtime.config.Engine.StaticDir
+	if runtime.storageConfig.TransientStore {
+		baseDir = runtime.config.Engine.TmpDir
+	}
+	return filepath.Join(baseDir, "bolt_state.db")
+}
+
 func getDBState(runtime *Runtime) (State, error) {
 	// TODO - if we further break out the state implementation into
 	// libpod/state, the config could take care of the code below.  It
@@ -296,11 +338,7 @@ func getDBState(runtime *Runtime) (State, error) {
 	}
 
 	// get default boltdb path
-	baseDir := runtime.config.Engine.StaticDir
-	if runtime.storageConfig.TransientStore {
-		baseDir = runtime.config.Engine.TmpDir
-	}
-	boltDBPath := filepath.Join(baseDir, "bolt_state.db")
+	boltDBPath := boltStatePath(runtime)
 
 	switch backend {
 	case config.DBBackendDefault:
@@ -337,6 +375,26 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 	}
 	runtime.conmonPath = cPath
 
+	if runtime.readWriteRoot != "" {
+		if err := runtime.setupReadOnlyGraphRoot(); err != nil {
+			return err
+		}
+	}
+
+	if runtime.sharedLayerCacheDir != "" {
+		cache, err := newSharedLayerCache(runtime.sharedLayerCacheDir, runtime.sharedLayerCacheSize)
+		if err != nil {
+			return err
+		}
+		runtime.sharedLayerCache = cache
+	}
+
+	if runtime.sharedImageStorePull {
+		if err := runtime.setupSharedImageStorePull(); err != nil {
+			return err
+		}
+	}
+
 	if runtime.config.Engine.StaticDir == "" {
 		runtime.config.Engine.StaticDir = filepath.Join(runtime.storageConfig.GraphRoot, "libpod")
 		runtime.storageSet.StaticDirSet = true
@@ -434,6 +492,9 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 	var store storage.Store
 	if needsUserns {
 		logrus.Debug("Not configuring container store")
+		runtime.lazyStorage = false
+	} else if runtime.lazyStorage {
+		logrus.Debug("Deferring container store setup until first use")
 	} else if err := runtime.configureStore(); err != nil {
 		// Make a best-effort attempt to clean up if performing a
 		// storage reset.
@@ -522,12 +583,11 @@ func makeRuntime(ctx context.Context, runtime *Runtime) (retErr error) {
 
 	// the store is only set up when we are in the userns so we do the same for the network interface
 	if !needsUserns {
-		netBackend, netInterface, err := network.NetworkBackend(runtime.store, runtime.config, runtime.syslog)
-		if err != nil {
-			return err
+		if !runtime.lazyStorage {
+			if err := runtime.configureNetwork(); err != nil {
+				return err
+			}
 		}
-		runtime.config.Network.NetworkBackend = string(netBackend)
-		runtime.network = netInterface
 
 		// Using sync once value to only init the store exactly once and only when it will be actually be used.
 		runtime.ArtifactStore = sync.OnceValues(func() (*artStore.ArtifactStore, error) {
@@ -859,7 +919,7 @@ func (r *Runtime) refresh(ctx context.Context, alivePath string) error {
 		}
 		// This is the only place it's safe to use ctr.state.State unlocked
 		// We're holding the alive lock, guaranteed to be the only Libpod on the system right now.
-		if (ctr.AutoRemove() && ctr.state.State == define.ContainerStateExited) || ctr.state.State == define.ContainerStateRemoving {
+		if (ctr.AutoRemove() && ctr.state.State == define.ContainerStateExited) || ctr.state.State == define.ContainerStateRemoving || ctr.config.StorageTransient {
 			opts := ctrRmOpts{
 				// Don't force-remove, we're supposed to be fresh off a reboot
 				// If we have to force something is seriously wrong
@@ -867,7 +927,7 @@ func (r *Runtime) refresh(ctx context.Context, alivePath string) error {
 				RemoveVolume: true,
 			}
 			// This container should have autoremoved before the
-			// reboot but did not.
+			// reboot but did not, or is transient.
 			// Get rid of it.
 			if _, _, err := r.removeContainer(ctx, ctr, opts); err != nil {
 				logrus.Errorf("Unable to remove container %s which should have autoremoved: %v", ctr.ID(), err)
@@ -899,6 +959,9 @@ func (r *Runtime) refresh(ctx context.Context, alivePath string) error {
 
 // Info returns the store and host information
 func (r *Runtime) Info() (*define.Info, error) {
+	if err := r.setupLazyStorage(); err != nil {
+		return nil, err
+	}
 	return r.info()
 }
 
@@ -951,13 +1014,56 @@ func (r *Runtime) configureStore() error {
 	return nil
 }
 
+// configureNetwork sets up the network backend, which requires the store.
+func (r *Runtime) configureNetwork() error {
+	netBackend, netInterface, err := network.NetworkBackend(r.store, r.config, r.syslog)
+	if err != nil {
+		return err
+	}
+	r.config.Network.NetworkBackend = string(netBackend)
+	r.network = netInterface
+	return nil
+}
+
+// setupLazyStorage sets up the store and the network backend on first use if
+// the runtime was created with WithLazyStorage.  The error of the setup is
+// kept and returned on every later call.
+func (r *Runtime) setupLazyStorage() error {
+	if !r.lazyStorage {
+		return nil
+	}
+	r.lazyStorageOnce.Do(func() {
+		logrus.Debug("Setting up deferred container store")
+		if r.store == nil {
+			if err := r.configureStore(); err != nil {
+				r.lazyStorageErr = fmt.Errorf("configuring storage: %w", err)
+				return
+			}
+		}
+		if err := r.configureNetwork(); err != nil {
+			r.lazyStorageErr = fmt.Errorf("configuring network backend: %w", err)
+		}
+	})
+	return r.lazyStorageErr
+}
+
 // LibimageRuntime ... to allow for a step-by-step migration to libimage.
+// It is nil if the deferred setup of a runtime created with WithLazyStorage
+// failed, the error is logged and returned by Info and GarbageCollect.
 func (r *Runtime) LibimageRuntime() *libimage.Runtime {
+	if err := r.setupLazyStorage(); err != nil {
+		logrus.Errorf("Setting up deferred container store: %v", err)
+	}
 	return r.libimageRuntime
 }
 
 // SystemContext returns the imagecontext
 func (r *Runtime) SystemContext() *types.SystemContext {
+	if err := r.setupLazyStorage(); err != nil {
+		// The context does not depend on the store, hand out the
+		// configured one.
+		return r.imageContext
+	}
 	// Return the context from the libimage runtime.  libimage is sensitive
 	// to a number of env vars.
 	return r.libimageRuntime.SystemContext()
@@ -979,11 +1085,15 @@ func (r *Runtime) StorageConfig() storage.StoreOptions {
 }
 
 func (r *Runtime) GarbageCollect() error {
+	if err := r.setupLazyStorage(); err != nil {
+		return err
+	}
 	return r.store.GarbageCollect()
 }
 
 // RunRoot retrieves the current c/storage temporary directory in use by Libpod.
 func (r *Runtime) RunRoot() string {
+	_ = r.setupLazyStorage()
 	if r.store == nil {
 		return ""
 	}
@@ -992,12 +1102,22 @@ func (r *Runtime) RunRoot() string {
 
 // GraphRoot retrieves the current c/storage directory in use by Libpod.
 func (r *Runtime) GraphRoot() string {
+	_ = r.setupLazyStorage()
 	if r.store == nil {
 		return ""
 	}
 	return r.store.GraphRoot()
 }
 
+// imageStorageRoot returns the root directory of the image layers, the
+// image store when the images are stored apart from the graph root
+func (r *Runtime) imageStorageRoot() string {
+	if imageStore := r.store.ImageStore(); imageStore != "" {
+		return imageStore
+	}
+	return r.store.GraphRoot()
+}
+
 // GetPodName retrieves the pod name associated with a given full ID.
 // If the given ID does not correspond to any existing Pod or Container,
 // ErrNoSuchPod is returned.
@@ -1096,6 +1216,9 @@ func (r *Runtime) reloadContainersConf() error {
 	if err != nil {
 		return err
 	}
+	if r.hooksDirsFromOptions {
+		config.Engine.HooksDir.Set(r.config.Engine.HooksDir.Get())
+	}
 	r.config = config
 	logrus.Infof("Applied new containers configuration: %v", config)
 	return nil
@@ -1134,7 +1257,7 @@ func (r *Runtime) getVolumePlugin(volConfig *VolumeConfig) (*plugin.VolumePlugin
 
 // GetSecretsStorageDir returns the directory that the secrets manager should take
 func (r *Runtime) GetSecretsStorageDir() string {
-	return filepath.Join(r.store.GraphRoot(), "secrets")
+	return filepath.Join(r.GraphRoot(), "secrets")
 }
 
 // SecretsManager returns the directory that the secrets manager should take
@@ -1182,6 +1305,9 @@ func (r *Runtime) getRuntimeAliveLock() (*lockfile.LockFile, error) {
 
 // Network returns the network interface which is used by the runtime
 func (r *Runtime) Network() nettypes.ContainerNetwork {
+	if err := r.setupLazyStorage(); err != nil {
+		return &lazyNetworkError{err: err, defaultNetwork: r.config.Network.DefaultNetwork}
+	}
 	return r.network
 }
 
@@ -1200,44 +1326,68 @@ func (r *Runtime) SetRemoteURI(uri string) {
 	r.config.Engine.RemoteURI = uri
 }
 
-// Get information on potential lock conflicts.
-// Returns a map of lock number to object(s) using the lock, formatted as
-// "container <id>" or "volume <id>" or "pod <id>", and an array of locks that
-// are currently being held, formatted as []uint32.
-// If the map returned is not empty, you should immediately renumber locks on
-// the runtime, because you have a deadlock waiting to happen.
-func (r *Runtime) LockConflicts() (map[uint32][]string, []uint32, error) {
-	// Make an internal map to store what lock is associated with what
-	locksInUse := make(map[uint32][]string)
+// LockAllocations returns the locks allocated to all containers, pods and
+// volumes in the state, in this order.
+func (r *Runtime) LockAllocations() ([]define.LockAllocation, error) {
+	var allocations []define.LockAllocation
 
 	ctrs, err := r.state.AllContainers(false)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	for _, ctr := range ctrs {
-		lockNum := ctr.lock.ID()
-		ctrString := fmt.Sprintf("container %s", ctr.ID())
-		locksInUse[lockNum] = append(locksInUse[lockNum], ctrString)
+		allocations = append(allocations, define.LockAllocation{LockID: ctr.lock.ID(), Type: "container", ID: ctr.ID(), Name: ctr.Name()})
 	}
 
 	pods, err := r.state.AllPods()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	for _, pod := range pods {
-		lockNum := pod.lock.ID()
-		podString := fmt.Sprintf("pod %s", pod.ID())
-		locksInUse[lockNum] = append(locksInUse[lockNum], podString)
+		allocations = append(allocations, define.LockAllocation{LockID: pod.lock.ID(), Type: "pod", ID: pod.ID(), Name: pod.Name()})
 	}
 
 	volumes, err := r.state.AllVolumes()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	for _, vol := range volumes {
-		lockNum := vol.lock.ID()
-		volString := fmt.Sprintf("volume %s", vol.Name())
-		locksInUse[lockNum] = append(locksInUse[lockNum], volString)
+		allocations = append(allocations, define.LockAllocation{LockID: vol.lock.ID(), Type: "volume", ID: vol.Name(), Name: vol.Name()})
+	}
+
+	return allocations, nil
+}
+
+// LockUsage returns the number of locks of the lock manager and the number
+// of them which are not allocated.  Both are 0 if the lock manager, like the
+// file lock manager, has no limit.
+func (r *Runtime) LockUsage() (uint32, uint32, error) {
+	free, err := r.lockManager.AvailableLocks()
+	if err != nil {
+		return 0, 0, err
+	}
+	if free == nil {
+		return 0, 0, nil
+	}
+	return r.config.Engine.NumLocks, *free, nil
+}
+
+// Get information on potential lock conflicts.
+// Returns a map of lock number to object(s) using the lock, formatted as
+// "container <id>" or "volume <id>" or "pod <id>", and an array of locks that
+// are currently being held, formatted as []uint32.
+// If the map returned is not empty, you should immediately renumber locks on
+// the runtime, because you have a deadlock waiting to happen.
+func (r *Runtime) LockConflicts() (map[uint32][]string, []uint32, error) {
+	// Make an internal map to store what lock is associated with what
+	locksInUse := make(map[uint32][]string)
+
+	allocations, err := r.LockAllocations()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, allocation := range allocations {
+		locksInUse[allocation.LockID] = append(locksInUse[allocation.LockID], fmt.Sprintf("%s %s", allocation.Type, allocation.ID))
 	}
 
 	// Now go through and find any entries with >1 item associated
@@ -1265,26 +1415,39 @@ func (r *Runtime) LockConflicts() (map[uint32][]string, []uint32, error) {
 	return toReturn, locksHeld, nil
 }
 
-// PruneBuildContainers removes any build containers that were created during the build,
-// but were not removed because the build was unexpectedly terminated.
-//
-// Note: This is not safe operation and should be executed only when no builds are in progress. It can interfere with builds in progress.
-func (r *Runtime) PruneBuildContainers() ([]*reports.PruneReport, error) {
-	stageContainersPruneReports := []*reports.PruneReport{}
-
+// BuildContainers returns the storage containers created by builds, which
+// are removed by PruneBuildContainers.
+func (r *Runtime) BuildContainers() ([]storage.Container, error) {
 	containers, err := r.store.Containers()
 	if err != nil {
-		return stageContainersPruneReports, err
+		return nil, err
 	}
+	var buildContainers []storage.Container
 	for _, container := range containers {
 		path, err := r.store.ContainerDirectory(container.ID)
 		if err != nil {
-			return stageContainersPruneReports, err
+			return nil, err
 		}
 		if err := fileutils.Exists(filepath.Join(path, "buildah.json")); err != nil {
 			continue
 		}
+		buildContainers = append(buildContainers, container)
+	}
+	return buildContainers, nil
+}
+
+// PruneBuildContainers removes any build containers that were created during the build,
+// but were not removed because the build was unexpectedly terminated.
+//
+// Note: This is not safe operation and should be executed only when no builds are in progress. It can interfere with builds in progress.
+func (r *Runtime) PruneBuildContainers() ([]*reports.PruneReport, error) {
+	stageContainersPruneReports := []*reports.PruneReport{}
 
+	containers, err := r.BuildContainers()
+	if err != nil {
+		return stageContainersPruneReports, err
+	}
+	for _, container := range containers {
 		report := &reports.PruneReport{
 			Id: container.ID,
 		}
@@ -1304,7 +1467,15 @@ func (r *Runtime) PruneBuildContainers() ([]*reports.PruneReport, error) {
 
 // SystemCheck checks our storage for consistency, and depending on the options
 // specified, will attempt to remove anything which fails consistency checks.
-func (r *Runtime) SystemCheck(_ context.Context, options entities.SystemCheckOptions) (entities.SystemCheckReport, error) {
+func (r *Runtime) SystemCheck(ctx context.Context, options entities.SystemCheckOptions) (entities.SystemCheckReport, error) {
+	damagedContainers, repairedContainers, err := r.checkContainers(ctx, options.RepairContainers)
+	if err != nil {
+		return entities.SystemCheckReport{}, err
+	}
+	if len(repairedContainers) == 0 {
+		repairedContainers = nil
+	}
+
 	what := storage.CheckEverything()
 	if options.Quick {
 		// Turn off checking layer digests and layer contents to do quick check.
@@ -1332,9 +1503,10 @@ func (r *Runtime) SystemCheck(_ context.Context, options entities.SystemCheckOpt
 		len(storageReport.Layers) == 0 &&
 		len(storageReport.ROLayers) == 0 &&
 		len(storageReport.Images) == 0 &&
-		len(storageReport.ROImages) == 0 {
+		len(storageReport.ROImages) == 0 &&
+		len(damagedContainers) == 0 {
 		// no errors detected
-		return entities.SystemCheckReport{}, nil
+		return entities.SystemCheckReport{RepairedContainers: repairedContainers}, nil
 	}
 	mapErrorSlicesToStringSlices := func(m map[string][]error) map[string][]string {
 		if len(m) == 0 {
@@ -1358,6 +1530,14 @@ func (r *Runtime) SystemCheck(_ context.Context, options entities.SystemCheckOpt
 		Images:     mapErrorSlicesToStringSlices(storageReport.Images),
 		ROImages:   mapErrorSlicesToStringSlices(storageReport.ROImages),
 		Containers: mapErrorSlicesToStringSlices(storageReport.Containers),
+
+		RepairedContainers: repairedContainers,
+	}
+	for containerID, errs := range damagedContainers {
+		if report.Containers == nil {
+			report.Containers = make(map[string][]string)
+		}
+		report.Containers[containerID] = append(report.Containers[containerID], errs...)
 	}
 	if !options.Repair && report.Errors {
 		// errors detected, no corrective measures to be taken
@@ -1446,3 +1626,52 @@ func (r *Runtime) SystemCheck(_ context.Context, options entities.SystemCheckOpt
 func (r *Runtime) GetContainerExitCode(id string) (int32, error) {
 	return r.state.GetContainerExitCode(id)
 }
+
+// EnableDBProfile starts recording the time spent running the queries of the
+// database.  Profiling is only supported by the sqlite database backend.
+func (r *Runtime) EnableDBProfile() error {
+	state, ok := r.state.(*SQLiteState)
+	if !ok {
+		return fmt.Errorf("database profiling requires the sqlite database backend: %w", define.ErrNotImplemented)
+	}
+	state.profile.enabled.Store(true)
+	return nil
+}
+
+// DBProfile returns the time spent running each query of the database since
+// EnableDBProfile was called, the most time consuming queries first.
+func (r *Runtime) DBProfile() ([]define.DBQueryProfile, error) {
+	state, ok := r.state.(*SQLiteState)
+	if !ok || !state.profile.enabled.Load() {
+		return nil, fmt.Errorf("database profiling is not enabled: %w", define.ErrInvalidArg)
+	}
+	return state.profile.profiles(), nil
+}
+
+// SetRegistryCache records the registry cache served by the API service.
+func (r *Runtime) SetRegistryCache(cache *registrycache.Cache) {
+	r.registryCache = cache
+}
+
+// RegistryCacheStats returns the statistics of the use of the registry cache
+// served by the API service.
+func (r *Runtime) RegistryCacheStats() (*define.RegistryCacheStats, error) {
+	if r.registryCache == nil {
+		return nil, fmt.Errorf("the registry cache is not enabled: %w", define.ErrInvalidArg)
+	}
+	stats := r.registryCache.Stats()
+	return &stats, nil
+}
+
+// RegistryCacheGC removes the least recently used content of the registry
+// cache served by the API service until it is under its maximum size.
+func (r *Runtime) RegistryCacheGC() (*define.RegistryCacheStats, error) {
+	if r.registryCache == nil {
+		return nil, fmt.Errorf("the registry cache is not enabled: %w", define.ErrInvalidArg)
+	}
+	if err := r.registryCache.GC(); err != nil {
+		return nil, err
+	}
+	stats := r.registryCache.Stats()
+	return &stats, nil
+}
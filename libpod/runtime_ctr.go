@@ -146,7 +146,7 @@ func (r *Runtime) RenameContainer(_ context.Context, ctr *Container, newName str
 	ctr.config.Name = newName
 
 	// Step 2: rewrite the old container's config in the DB.
-	if err := r.state.SafeRewriteContainerConfig(ctr, oldName, ctr.config.Name, ctr.config); err != nil {
+	if err := ctr.safeRewriteConfig(oldName, ctr.config.Name); err != nil {
 		// Assume the rename failed.
 		// Set config back to the old name so reflect what is actually
 		// present in the DB.
@@ -573,7 +573,11 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		break
 	default:
 		if ctr.config.LogPath == "" {
-			ctr.config.LogPath = filepath.Join(ctr.config.StaticDir, "ctr.log")
+			logDir := ctr.config.StaticDir
+			if ctr.config.StorageTransient {
+				logDir = ctr.bundlePath()
+			}
+			ctr.config.LogPath = filepath.Join(logDir, "ctr.log")
 		}
 	}
 
@@ -602,6 +606,8 @@ func (r *Runtime) setupContainer(ctx context.Context, ctr *Container) (_ *Contai
 		return nil, err
 	}
 
+	ctr.refreshConfigBackup()
+
 	if ctr.runtime.config.Engine.EventsContainerCreateInspectData {
 		if err := ctr.newContainerEventWithInspectData(events.Create, define.HealthCheckResults{}, true); err != nil {
 			return nil, err
@@ -1357,10 +1363,9 @@ func (r *Runtime) GetExecSessionContainer(id string) (*Container, error) {
 	return r.state.Container(ctrID)
 }
 
-// PruneContainers removes stopped and exited containers from localstorage.  A set of optional filters
-// can be provided to be more granular.
-func (r *Runtime) PruneContainers(filterFuncs []ContainerFilter) ([]*reports.PruneReport, error) {
-	preports := make([]*reports.PruneReport, 0)
+// PrunableContainers returns the stopped and exited containers which are
+// removed by PruneContainers with the given filters.
+func (r *Runtime) PrunableContainers(filterFuncs []ContainerFilter) ([]*Container, error) {
 	// We add getting the exited and stopped containers via a filter
 	containerStateFilter := func(c *Container) bool {
 		if c.PodID() != "" {
@@ -1378,7 +1383,14 @@ func (r *Runtime) PruneContainers(filterFuncs []ContainerFilter) ([]*reports.Pru
 		return false
 	}
 	filterFuncs = append(filterFuncs, containerStateFilter)
-	delContainers, err := r.GetContainers(false, filterFuncs...)
+	return r.GetContainers(false, filterFuncs...)
+}
+
+// PruneContainers removes stopped and exited containers from localstorage.  A set of optional filters
+// can be provided to be more granular.
+func (r *Runtime) PruneContainers(filterFuncs []ContainerFilter) ([]*reports.PruneReport, error) {
+	preports := make([]*reports.PruneReport, 0)
+	delContainers, err := r.PrunableContainers(filterFuncs)
 	if err != nil {
 		return nil, err
 	}
@@ -1414,6 +1426,7 @@ func (r *Runtime) MountStorageContainer(id string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	r.dropStorageContainerSizeCache(container.ID)
 	mountPoint, err := r.store.Mount(container.ID, "")
 	if err != nil {
 		return "", fmt.Errorf("mounting storage for container %s: %w", id, err)
@@ -1430,9 +1443,22 @@ func (r *Runtime) UnmountStorageContainer(id string, force bool) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	defer r.dropStorageContainerSizeCache(container.ID)
 	return r.store.Unmount(container.ID, force)
 }
 
+// dropStorageContainerSizeCache removes the cached size of the writeable
+// layer of a container, which can be a libpod container also when it is
+// referred to by name.
+func (r *Runtime) dropStorageContainerSizeCache(id string) {
+	dir, err := r.store.ContainerDirectory(id)
+	if err != nil {
+		logrus.Debugf("Getting directory of container %s: %v", id, err)
+		return
+	}
+	dropSizeCache(dir)
+}
+
 // MountedStorageContainer returns whether a storage container is mounted
 // along with the mount path
 func (r *Runtime) IsStorageContainerMounted(id string) (bool, string, error) {
@@ -1481,6 +1507,15 @@ func (r *Runtime) StorageContainers() ([]storage.Container, error) {
 	return retCtrs, nil
 }
 
+// StorageContainerSize returns the size of the storage container with the
+// given ID
+func (r *Runtime) StorageContainerSize(id string) (int64, error) {
+	if r.store == nil {
+		return -1, define.ErrStoreNotInitialized
+	}
+	return r.store.ContainerSize(id)
+}
+
 func (r *Runtime) IsBuildahContainer(id string) (bool, error) {
 	return buildah.IsContainer(id, r.store)
 }
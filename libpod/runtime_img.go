@@ -6,6 +6,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
 	buildahDefine "github.com/containers/buildah/define"
 	"github.com/containers/buildah/imagebuildah"
@@ -13,6 +16,7 @@ import (
 	"github.com/dmikushin/podman-shared/libpod/events"
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/libimage"
+	"go.podman.io/common/pkg/config"
 	"go.podman.io/image/v5/docker/reference"
 )
 
@@ -109,6 +113,64 @@ func (r *Runtime) IsExternalContainerCallback(_ context.Context) libimage.IsExte
 	}
 }
 
+// imagePullCheckedKey is the big data item of an image holding when the
+// registry was last checked for a newer version of the image.
+const imagePullCheckedKey = "podman-pull-checked"
+
+// PullImage pulls name like libimage.Runtime.Pull.  With PullPolicyNewer and
+// a positive maxAge, the registry is not checked again for a local image
+// which was pulled, or checked for a newer version, less than maxAge ago.
+func (r *Runtime) PullImage(ctx context.Context, name string, policy config.PullPolicy, maxAge time.Duration, options *libimage.PullOptions) ([]*libimage.Image, error) {
+	if options == nil {
+		options = &libimage.PullOptions{}
+	}
+	if policy == config.PullPolicyNewer && maxAge > 0 && !options.AllTags {
+		lookupOptions := &libimage.LookupImageOptions{
+			Architecture: options.Architecture,
+			OS:           options.OS,
+			Variant:      options.Variant,
+		}
+		if img, _, err := r.LibimageRuntime().LookupImage(name, lookupOptions); err == nil {
+			if checked := r.imagePullChecked(img.ID()); time.Since(checked) < maxAge {
+				logrus.Debugf("Image %s was checked at %s, not checking the registry again", name, checked)
+				policy = config.PullPolicyMissing
+			}
+		}
+	}
+
+	images, err := r.LibimageRuntime().Pull(ctx, name, policy, options)
+	if err != nil {
+		return nil, err
+	}
+	if policy == config.PullPolicyAlways || policy == config.PullPolicyNewer {
+		now, err := time.Now().UTC().MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		for _, img := range images {
+			if err := r.store.SetImageBigData(img.ID(), imagePullCheckedKey, now, nil); err != nil {
+				logrus.Warnf("Unable to record the pull of image %s: %v", img.ID(), err)
+			}
+		}
+	}
+	return images, nil
+}
+
+// imagePullChecked returns when the registry was last checked for a newer
+// version of the image, the zero time if unknown.
+func (r *Runtime) imagePullChecked(id string) time.Time {
+	var checked time.Time
+	data, err := r.store.ImageBigData(id, imagePullCheckedKey)
+	if err != nil {
+		return checked
+	}
+	if err := checked.UnmarshalText(data); err != nil {
+		logrus.Debugf("Parsing the last pull of image %s: %v", id, err)
+		return time.Time{}
+	}
+	return checked
+}
+
 // newImageBuildCompleteEvent creates a new event based on completion of a built image
 func (r *Runtime) newImageBuildCompleteEvent(idOrName string) {
 	e := events.NewEvent(events.Build)
@@ -128,8 +190,104 @@ func (r *Runtime) Build(ctx context.Context, options buildahDefine.BuildOptions,
 
 	// share the network interface between podman and buildah
 	options.NetworkInterface = r.network
+
+	if options.CommonBuildOpts != nil {
+		secrets, cleanup, err := r.resolveBuildSecrets(options.CommonBuildOpts.Secrets)
+		if err != nil {
+			return "", nil, err
+		}
+		defer cleanup()
+		commonOpts := *options.CommonBuildOpts
+		commonOpts.Secrets = secrets
+		options.CommonBuildOpts = &commonOpts
+	}
+
 	id, ref, err := imagebuildah.BuildDockerfiles(ctx, r.store, options, dockerfiles...)
 	// Write event for build completion
 	r.newImageBuildCompleteEvent(id)
 	return id, ref, err
 }
+
+// resolveBuildSecrets replaces the sources of build secrets which refer to the
+// secrets store with temporary files holding the secret data.  The files are
+// created in the tmp dir of the runtime, outside of the build context, and are
+// removed by the returned cleanup function.
+func (r *Runtime) resolveBuildSecrets(secrets []string) ([]string, func(), error) {
+	var dir string
+	cleanup := func() {
+		if dir == "" {
+			return
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			logrus.Errorf("Removing build secrets %s: %v", dir, err)
+		}
+	}
+
+	resolved := make([]string, 0, len(secrets))
+	for _, secret := range secrets {
+		tokens := strings.Split(secret, ",")
+		var name, typ string
+		for _, token := range tokens {
+			key, val, _ := strings.Cut(token, "=")
+			switch key {
+			case "src":
+				if n, ok := strings.CutPrefix(val, define.BuildSecretPrefix); ok {
+					name = n
+				}
+			case "type", "env":
+				typ = key + "=" + val
+			}
+		}
+		if name == "" {
+			resolved = append(resolved, secret)
+			continue
+		}
+		if typ != "" && typ != "type=file" {
+			cleanup()
+			return nil, nil, fmt.Errorf("build secret %q from the secrets store must be of type file", secret)
+		}
+
+		manager, err := r.SecretsManager()
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		_, data, err := manager.LookupSecretData(name)
+		if err != nil {
+			cleanup()
+			return nil, nil, fmt.Errorf("looking up build secret %q: %w", name, err)
+		}
+		if dir == "" {
+			dir, err = os.MkdirTemp(r.config.Engine.TmpDir, "build-secrets-")
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+		f, err := os.CreateTemp(dir, "secret-")
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+		_, err = f.Write(data)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+
+		for i, token := range tokens {
+			if strings.HasPrefix(token, "src=") {
+				tokens[i] = "src=" + f.Name()
+			}
+		}
+		if typ == "" {
+			// Do not let buildah pick an environment variable named
+			// like the secret id.
+			tokens = append(tokens, "type=file")
+		}
+		resolved = append(resolved, strings.Join(tokens, ","))
+	}
+	return resolved, cleanup, nil
+}
@@ -0,0 +1,145 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libimage"
+	"go.podman.io/storage"
+	"go.podman.io/storage/pkg/archive"
+	"go.podman.io/storage/pkg/mount"
+)
+
+// ImageLayerMount is a layer of an image mounted by MountImageLayers.
+type ImageLayerMount struct {
+	Layer *storage.Layer
+	// Path the layer is mounted on read-only
+	Path string
+}
+
+// ImageLayers returns the layers of the image from the base layer up.
+func (r *Runtime) ImageLayers(img *libimage.Image) ([]*storage.Layer, error) {
+	var layers []*storage.Layer
+	for id := img.TopLayer(); id != ""; {
+		layer, err := r.store.Layer(id)
+		if err != nil {
+			return nil, err
+		}
+		layers = append(layers, layer)
+		id = layer.Parent
+	}
+	slices.Reverse(layers)
+	return layers, nil
+}
+
+// LayerSize returns the uncompressed size of the changes of the layer.
+func (r *Runtime) LayerSize(layer *storage.Layer) (int64, error) {
+	if layer.UncompressedSize > 0 {
+		return layer.UncompressedSize, nil
+	}
+	size, err := r.store.DiffSize(layer.Parent, layer.ID)
+	if err != nil {
+		return 0, fmt.Errorf("computing size of layer %s: %w", layer.ID, err)
+	}
+	return size, nil
+}
+
+// LayerDiff returns the changes of the layer as an uncompressed tar stream.
+func (r *Runtime) LayerDiff(layer *storage.Layer) (io.ReadCloser, error) {
+	uncompressed := archive.Uncompressed
+	return r.store.Diff(layer.Parent, layer.ID, &storage.DiffOptions{Compression: &uncompressed})
+}
+
+// layerMountPath returns the directory under target the layer with the given
+// index is mounted on.
+func layerMountPath(target string, index int) string {
+	return filepath.Join(target, fmt.Sprintf("%03d", index))
+}
+
+// MountImageLayers mounts each layer of the image read-only in a numbered
+// directory under target, starting with 000 for the base layer.  Each mount
+// shows the root filesystem of the image up to and including the layer, so
+// comparing the directories of adjacent layers shows the changes of a layer.
+func (r *Runtime) MountImageLayers(img *libimage.Image, target string) (_ []ImageLayerMount, retErr error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	layers, err := r.ImageLayers(img)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(target, 0o700); err != nil {
+		return nil, err
+	}
+
+	mounts := make([]ImageLayerMount, 0, len(layers))
+	defer func() {
+		if retErr != nil {
+			for _, m := range mounts {
+				if err := r.unmountImageLayer(m.Layer.ID, m.Path); err != nil {
+					logrus.Errorf("Unmounting layer %s: %v", m.Layer.ID, err)
+				}
+			}
+		}
+	}()
+	for i, layer := range layers {
+		path := layerMountPath(target, i)
+		if err := os.Mkdir(path, 0o700); err != nil {
+			return nil, fmt.Errorf("creating mount point of layer %s: %w", layer.ID, err)
+		}
+		source, err := r.store.Mount(layer.ID, "")
+		if err != nil {
+			return nil, fmt.Errorf("mounting layer %s: %w", layer.ID, err)
+		}
+		if err := mount.Mount(source, path, "bind", "bind,ro"); err != nil {
+			if _, err := r.store.Unmount(layer.ID, false); err != nil {
+				logrus.Errorf("Unmounting layer %s: %v", layer.ID, err)
+			}
+			return nil, fmt.Errorf("mounting layer %s read-only on %s: %w", layer.ID, path, err)
+		}
+		mounts = append(mounts, ImageLayerMount{Layer: layer, Path: path})
+	}
+	return mounts, nil
+}
+
+// UnmountImageLayers unmounts the layers of the image mounted under target by
+// MountImageLayers.
+func (r *Runtime) UnmountImageLayers(img *libimage.Image, target string) error {
+	if !r.valid {
+		return define.ErrRuntimeStopped
+	}
+	layers, err := r.ImageLayers(img)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for i, layer := range layers {
+		path := layerMountPath(target, i)
+		if err := r.unmountImageLayer(layer.ID, path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs = append(errs, fmt.Errorf("unmounting layer %s from %s: %w", layer.ID, path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *Runtime) unmountImageLayer(layerID, path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+	if err := mount.Unmount(path); err != nil {
+		return err
+	}
+	if _, err := r.store.Unmount(layerID, false); err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
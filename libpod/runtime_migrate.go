@@ -78,7 +78,7 @@ func (r *Runtime) Migrate(newRuntime string) error {
 		}
 
 		if needsWrite {
-			if err := r.state.RewriteContainerConfig(ctr, ctr.config); err != nil {
+			if err := ctr.rewriteConfig(); err != nil {
 				return fmt.Errorf("rewriting config for container %s: %w", ctr.ID(), err)
 			}
 		}
@@ -0,0 +1,325 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/config"
+)
+
+// MigrateDBBackend converts the database to the given backend. The current
+// database is copied into a new database of the requested backend, the copy
+// is verified and the current database is then renamed to a backup file, so
+// that the new database is used from now on. All containers are stopped
+// first. The path of the backup is returned.
+func (r *Runtime) MigrateDBBackend(backend string) (string, error) {
+	target, err := config.ParseDBBackend(backend)
+	if err != nil {
+		return "", err
+	}
+	if target == config.DBBackendDefault {
+		return "", fmt.Errorf("a database backend to migrate to must be given: %w", define.ErrInvalidArg)
+	}
+
+	// Acquire the alive lock and hold it.
+	// Ensures that we don't let other Podman commands run while we are
+	// replacing the DB.
+	aliveLock, err := r.getRuntimeAliveLock()
+	if err != nil {
+		return "", fmt.Errorf("retrieving alive lock: %w", err)
+	}
+	aliveLock.Lock()
+	defer aliveLock.Unlock()
+
+	if !r.valid {
+		return "", define.ErrRuntimeStopped
+	}
+
+	// Once database_backend in containers.conf is changed to the target,
+	// the runtime opens the target database, the current one has to be
+	// selected with the global --db-backend option.
+	if r.config.Engine.DBBackend == target.String() {
+		return "", fmt.Errorf("database backend is already %s, select the database to migrate with %q: %w", target.String(), migrateDBBackendCommand(otherDBBackend(target), target), define.ErrInvalidArg)
+	}
+
+	// The next Podman command would otherwise open a new, empty database
+	// of the pinned backend once the current one is moved to a backup.
+	if defaultConfig, err := config.Default(); err == nil {
+		if err := checkDBBackendPin(defaultConfig.Engine.DBBackend, r.config.Engine.DBBackend, target); err != nil {
+			return "", err
+		}
+	}
+
+	sourcePath := boltStatePath(r)
+	targetPath := filepath.Join(sqliteStateDir(r), sqliteStateFile)
+	if target == config.DBBackendBoltDB {
+		sourcePath, targetPath = targetPath, sourcePath
+	}
+
+	runningContainers, err := r.GetRunningContainers()
+	if err != nil {
+		return "", err
+	}
+	logrus.Infof("Stopping all containers")
+	for _, ctr := range runningContainers {
+		logrus.Infof("Stopping container %s", ctr.ID())
+		if err := ctr.Stop(); err != nil {
+			return "", fmt.Errorf("cannot stop container %s: %w", ctr.ID(), err)
+		}
+	}
+
+	// A leftover database of the target backend is moved out of the way,
+	// it would otherwise be merged with the migrated data.
+	if _, err := os.Stat(targetPath); err == nil {
+		backup := dbBackupPath(targetPath)
+		logrus.Warnf("Moving existing %s database %s to %s", target.String(), targetPath, backup)
+		if err := os.Rename(targetPath, backup); err != nil {
+			return "", fmt.Errorf("moving existing database out of the way: %w", err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+
+	var newState State
+	switch target {
+	case config.DBBackendBoltDB:
+		newState, err = newBoltState(targetPath, r, true)
+	case config.DBBackendSQLite:
+		newState, err = NewSqliteState(r)
+	}
+	if err != nil {
+		return "", fmt.Errorf("creating %s database: %w", target.String(), err)
+	}
+
+	if err := r.copyState(newState); err != nil {
+		if closeErr := newState.Close(); closeErr != nil {
+			logrus.Errorf("Closing %s database: %v", target.String(), closeErr)
+		}
+		if rmErr := os.Remove(targetPath); rmErr != nil {
+			logrus.Errorf("Removing incomplete %s database: %v", target.String(), rmErr)
+		}
+		return "", fmt.Errorf("migrating database to %s: %w", target.String(), err)
+	}
+
+	if err := r.state.Close(); err != nil {
+		return "", fmt.Errorf("closing %s database: %w", r.config.Engine.DBBackend, err)
+	}
+	backup := dbBackupPath(sourcePath)
+	if err := os.Rename(sourcePath, backup); err != nil {
+		return "", fmt.Errorf("moving %s database to backup: %w", r.config.Engine.DBBackend, err)
+	}
+	logrus.Infof("Backup of the %s database saved to %s", r.config.Engine.DBBackend, backup)
+
+	r.state = newState
+	r.config.Engine.DBBackend = target.String()
+
+	return backup, nil
+}
+
+// checkDBBackendPin returns an error if containers.conf pins the database
+// backend to another one than target
+func checkDBBackendPin(pinned, current string, target config.DBBackend) error {
+	if pinned == "" || pinned == target.String() {
+		return nil
+	}
+	return fmt.Errorf("containers.conf sets database_backend to %q, change it to %q and migrate the database with %q: %w", pinned, target.String(), migrateDBBackendCommand(current, target), define.ErrInvalidArg)
+}
+
+// otherDBBackend returns the backend which is not backend
+func otherDBBackend(backend config.DBBackend) string {
+	if backend == config.DBBackendSQLite {
+		return config.DBBackendBoltDB.String()
+	}
+	return config.DBBackendSQLite.String()
+}
+
+// migrateDBBackendCommand returns the command migrating the database of the
+// current backend to target, whatever containers.conf selects
+func migrateDBBackendCommand(current string, target config.DBBackend) string {
+	return fmt.Sprintf("podman --db-backend %s system migrate --db-backend %s", current, target.String())
+}
+
+// dbBackupPath returns the path a database file is renamed to when it is
+// replaced
+func dbBackupPath(path string) string {
+	return fmt.Sprintf("%s.%s.bak", path, time.Now().Format("20060102150405"))
+}
+
+// copyState copies all pods, volumes and containers of the current state
+// into dest and verifies that dest contains all of them afterwards.
+func (r *Runtime) copyState(dest State) error {
+	if err := dest.ValidateDBConfig(r); err != nil {
+		return err
+	}
+
+	volumes, err := r.state.AllVolumes()
+	if err != nil {
+		return err
+	}
+	for _, vol := range volumes {
+		if err := r.state.UpdateVolume(vol); err != nil {
+			return err
+		}
+		if err := dest.AddVolume(vol); err != nil {
+			return fmt.Errorf("copying volume %s: %w", vol.Name(), err)
+		}
+	}
+
+	pods, err := r.state.AllPods()
+	if err != nil {
+		return err
+	}
+	for _, pod := range pods {
+		if err := r.state.UpdatePod(pod); err != nil {
+			return err
+		}
+		// the infra container is set once it has been copied
+		infraID := pod.state.InfraContainerID
+		pod.state.InfraContainerID = ""
+		err := dest.AddPod(pod)
+		pod.state.InfraContainerID = infraID
+		if err != nil {
+			return fmt.Errorf("copying pod %s: %w", pod.ID(), err)
+		}
+	}
+
+	ctrs, err := r.state.AllContainers(true)
+	if err != nil {
+		return err
+	}
+	ordered, err := orderContainersByDependencies(ctrs)
+	if err != nil {
+		return err
+	}
+	podsByID := make(map[string]*Pod, len(pods))
+	for _, pod := range pods {
+		podsByID[pod.ID()] = pod
+	}
+	for _, ctr := range ordered {
+		networks, err := r.state.GetNetworks(ctr)
+		if err != nil {
+			return fmt.Errorf("retrieving networks of container %s: %w", ctr.ID(), err)
+		}
+		ctr.config.Networks = networks
+
+		if ctr.config.Pod != "" {
+			pod, ok := podsByID[ctr.config.Pod]
+			if !ok {
+				return fmt.Errorf("pod %s of container %s: %w", ctr.config.Pod, ctr.ID(), define.ErrNoSuchPod)
+			}
+			err = dest.AddContainerToPod(pod, ctr)
+		} else {
+			err = dest.AddContainer(ctr)
+		}
+		if err != nil {
+			return fmt.Errorf("copying container %s: %w", ctr.ID(), err)
+		}
+
+		exitCode, err := r.state.GetContainerExitCode(ctr.ID())
+		if err == nil {
+			if err := dest.AddContainerExitCode(ctr.ID(), exitCode); err != nil {
+				return fmt.Errorf("copying exit code of container %s: %w", ctr.ID(), err)
+			}
+		} else if !errors.Is(err, define.ErrNoSuchExitCode) {
+			return err
+		}
+	}
+
+	for _, pod := range pods {
+		if pod.state.InfraContainerID == "" {
+			continue
+		}
+		if err := dest.SavePod(pod); err != nil {
+			return fmt.Errorf("copying state of pod %s: %w", pod.ID(), err)
+		}
+	}
+
+	return verifyStateCopy(dest, ctrs, pods, volumes)
+}
+
+// orderContainersByDependencies orders the containers so that every
+// container comes after the containers it depends on
+func orderContainersByDependencies(ctrs []*Container) ([]*Container, error) {
+	ordered := make([]*Container, 0, len(ctrs))
+	added := make(map[string]bool, len(ctrs))
+	for len(ordered) < len(ctrs) {
+		progress := false
+		for _, ctr := range ctrs {
+			if added[ctr.ID()] {
+				continue
+			}
+			ready := true
+			for _, dep := range ctr.Dependencies() {
+				if !added[dep] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				ordered = append(ordered, ctr)
+				added[ctr.ID()] = true
+				progress = true
+			}
+		}
+		if !progress {
+			return nil, fmt.Errorf("containers have missing or circular dependencies: %w", define.ErrInternal)
+		}
+	}
+	return ordered, nil
+}
+
+// verifyStateCopy checks that the state contains the given containers, pods
+// and volumes
+func verifyStateCopy(state State, ctrs []*Container, pods []*Pod, volumes []*Volume) error {
+	for _, ctr := range ctrs {
+		copied, err := state.Container(ctr.ID())
+		if err != nil {
+			return fmt.Errorf("verifying container %s: %w", ctr.ID(), err)
+		}
+		if err := state.UpdateContainer(copied); err != nil {
+			return fmt.Errorf("verifying container %s: %w", ctr.ID(), err)
+		}
+		if copied.Name() != ctr.Name() || copied.config.Pod != ctr.config.Pod || copied.state.State != ctr.state.State {
+			return fmt.Errorf("verifying container %s: copy does not match", ctr.ID())
+		}
+	}
+	for _, pod := range pods {
+		copied, err := state.Pod(pod.ID())
+		if err != nil {
+			return fmt.Errorf("verifying pod %s: %w", pod.ID(), err)
+		}
+		if copied.Name() != pod.Name() {
+			return fmt.Errorf("verifying pod %s: copy does not match", pod.ID())
+		}
+	}
+	for _, vol := range volumes {
+		if _, err := state.Volume(vol.Name()); err != nil {
+			return fmt.Errorf("verifying volume %s: %w", vol.Name(), err)
+		}
+	}
+
+	allCtrs, err := state.AllContainers(false)
+	if err != nil {
+		return err
+	}
+	allPods, err := state.AllPods()
+	if err != nil {
+		return err
+	}
+	allVolumes, err := state.AllVolumes()
+	if err != nil {
+		return err
+	}
+	if len(allCtrs) != len(ctrs) || len(allPods) != len(pods) || len(allVolumes) != len(volumes) {
+		return fmt.Errorf("verifying copy: expected %d containers, %d pods and %d volumes, found %d, %d and %d",
+			len(ctrs), len(pods), len(volumes), len(allCtrs), len(allPods), len(allVolumes))
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/lock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/common/pkg/config"
+)
+
+func TestCopyState(t *testing.T) {
+	source, tmpDir, manager, err := getEmptyBoltState()
+	require.NoError(t, err)
+	defer os.RemoveAll(tmpDir)
+	defer source.Close()
+
+	runtime := new(Runtime)
+	runtime.config = source.(*BoltState).runtime.config
+	runtime.lockManager = manager
+	runtime.state = source
+
+	pod, err := getTestPodN("3", manager)
+	require.NoError(t, err)
+	require.NoError(t, source.AddPod(pod))
+
+	ctr1, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	require.NoError(t, source.AddContainer(ctr1))
+
+	ctr2, err := getTestCtr2(manager)
+	require.NoError(t, err)
+	ctr2.config.Pod = pod.ID()
+	require.NoError(t, source.AddContainerToPod(pod, ctr2))
+	require.NoError(t, source.AddContainerExitCode(ctr1.ID(), 42))
+
+	dest, err := newBoltState(filepath.Join(tmpDir, "dest.db"), runtime, true)
+	require.NoError(t, err)
+	defer dest.Close()
+
+	require.NoError(t, runtime.copyState(dest))
+
+	copied, err := dest.Container(ctr1.ID())
+	require.NoError(t, err)
+	testContainersEqual(t, copied, ctr1, true)
+
+	inPod, err := dest.PodHasContainer(pod, ctr2.ID())
+	require.NoError(t, err)
+	assert.True(t, inPod)
+
+	exitCode, err := dest.GetContainerExitCode(ctr1.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int32(42), exitCode)
+}
+
+func TestOrderContainersByDependencies(t *testing.T) {
+	manager, err := lock.NewInMemoryManager(16)
+	require.NoError(t, err)
+
+	ctr1, err := getTestCtr1(manager)
+	require.NoError(t, err)
+	ctr2, err := getTestCtr2(manager)
+	require.NoError(t, err)
+	ctr1.config.Dependencies = []string{ctr2.ID()}
+
+	ordered, err := orderContainersByDependencies([]*Container{ctr1, ctr2})
+	require.NoError(t, err)
+	assert.Equal(t, []*Container{ctr2, ctr1}, ordered)
+
+	ctr2.config.Dependencies = []string{ctr1.ID()}
+	_, err = orderContainersByDependencies([]*Container{ctr1, ctr2})
+	assert.Error(t, err)
+}
+
+func TestCheckDBBackendPin(t *testing.T) {
+	assert.NoError(t, checkDBBackendPin("", "boltdb", config.DBBackendSQLite))
+	assert.NoError(t, checkDBBackendPin("sqlite", "boltdb", config.DBBackendSQLite))
+	assert.ErrorContains(t, checkDBBackendPin("boltdb", "boltdb", config.DBBackendSQLite), `change it to "sqlite" and migrate the database with "podman --db-backend boltdb system migrate --db-backend sqlite"`)
+}
@@ -160,15 +160,21 @@ func (r *Runtime) GetRunningPods() ([]*Pod, error) {
 	return runningPods, nil
 }
 
-// PrunePods removes unused pods and their containers from local storage.
-func (r *Runtime) PrunePods(_ context.Context) (map[string]error, error) {
-	response := make(map[string]error)
+// PrunablePods returns the stopped and exited pods which are removed by
+// PrunePods.
+func (r *Runtime) PrunablePods() ([]*Pod, error) {
 	states := []string{define.PodStateStopped, define.PodStateExited}
 	filterFunc := func(p *Pod) bool {
 		state, _ := p.GetPodStatus()
 		return slices.Contains(states, state)
 	}
-	pods, err := r.Pods(filterFunc)
+	return r.Pods(filterFunc)
+}
+
+// PrunePods removes unused pods and their containers from local storage.
+func (r *Runtime) PrunePods(_ context.Context) (map[string]error, error) {
+	response := make(map[string]error)
+	pods, err := r.PrunablePods()
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,68 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// setupReadOnlyGraphRoot validates the configured graph root and the
+// writable graph root of WithReadWriteRoot, then makes the former a read-only
+// image store of the latter.  Only the overlay and vfs drivers support image
+// stores.
+func (r *Runtime) setupReadOnlyGraphRoot() error {
+	roRoot := filepath.Clean(r.storageConfig.GraphRoot)
+	rwRoot, err := filepath.Abs(r.readWriteRoot)
+	if err != nil {
+		return fmt.Errorf("resolving read-write root %s: %w", r.readWriteRoot, err)
+	}
+
+	driver := r.storageConfig.GraphDriverName
+	if driver != "overlay" && driver != "vfs" {
+		return fmt.Errorf("a read-write root requires the overlay or vfs storage driver, not %q: %w", driver, define.ErrInvalidArg)
+	}
+	if rwRoot == roRoot || strings.HasPrefix(rwRoot, roRoot+string(filepath.Separator)) {
+		return fmt.Errorf("read-write root %s must not be inside the graph root %s: %w", rwRoot, roRoot, define.ErrInvalidArg)
+	}
+
+	// The images of the read-only graph root are in the directory of the
+	// driver, the store is unusable without it.
+	imagesDir := filepath.Join(roRoot, driver+"-images")
+	if st, err := os.Stat(imagesDir); err != nil {
+		return fmt.Errorf("graph root %s cannot be used read-only, it has no %s images: %w", roRoot, driver, err)
+	} else if !st.IsDir() {
+		return fmt.Errorf("graph root %s cannot be used read-only, %s is not a directory: %w", roRoot, imagesDir, define.ErrInvalidArg)
+	}
+	if err := unix.Access(roRoot, unix.W_OK); err == nil {
+		logrus.Debugf("Graph root %s is writable, it is still only read", roRoot)
+	} else if !errors.Is(err, unix.EROFS) && !errors.Is(err, unix.EACCES) {
+		return fmt.Errorf("checking graph root %s: %w", roRoot, err)
+	}
+
+	if err := os.MkdirAll(rwRoot, 0o700); err != nil {
+		return fmt.Errorf("creating read-write root: %w", err)
+	}
+	probe, err := os.CreateTemp(rwRoot, ".podman-rw-check")
+	if err != nil {
+		return fmt.Errorf("read-write root %s is not writable: %w", rwRoot, err)
+	}
+	probe.Close()
+	if err := os.Remove(probe.Name()); err != nil {
+		return err
+	}
+
+	logrus.Debugf("Using graph root %s read-only, writing to %s", roRoot, rwRoot)
+	r.readOnlyGraphRoot = roRoot
+	r.storageConfig.GraphRoot = rwRoot
+	r.storageConfig.GraphDriverOptions = append(slices.Clone(r.storageConfig.GraphDriverOptions), driver+".imagestore="+roRoot)
+	return nil
+}
@@ -0,0 +1,43 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	stypes "go.podman.io/storage/types"
+)
+
+func TestSetupReadOnlyGraphRoot(t *testing.T) {
+	dir := t.TempDir()
+	roRoot := filepath.Join(dir, "ro")
+	rwRoot := filepath.Join(dir, "rw")
+	require.NoError(t, os.MkdirAll(filepath.Join(roRoot, "overlay-images"), 0o755))
+
+	r := &Runtime{
+		storageConfig: stypes.StoreOptions{
+			GraphRoot:          roRoot,
+			GraphDriverName:    "overlay",
+			GraphDriverOptions: []string{"overlay.mountopt=nodev"},
+		},
+		readWriteRoot: rwRoot,
+	}
+	require.NoError(t, r.setupReadOnlyGraphRoot())
+	assert.Equal(t, rwRoot, r.storageConfig.GraphRoot)
+	assert.Equal(t, roRoot, r.readOnlyGraphRoot)
+	assert.Equal(t, []string{"overlay.mountopt=nodev", "overlay.imagestore=" + roRoot}, r.storageConfig.GraphDriverOptions)
+	assert.DirExists(t, rwRoot)
+
+	r = &Runtime{storageConfig: stypes.StoreOptions{GraphRoot: roRoot, GraphDriverName: "vfs"}, readWriteRoot: rwRoot}
+	assert.ErrorContains(t, r.setupReadOnlyGraphRoot(), "has no vfs images")
+
+	r = &Runtime{storageConfig: stypes.StoreOptions{GraphRoot: roRoot, GraphDriverName: "btrfs"}, readWriteRoot: rwRoot}
+	assert.ErrorContains(t, r.setupReadOnlyGraphRoot(), `requires the overlay or vfs storage driver, not "btrfs"`)
+
+	r = &Runtime{storageConfig: stypes.StoreOptions{GraphRoot: roRoot, GraphDriverName: "overlay"}, readWriteRoot: filepath.Join(roRoot, "rw")}
+	assert.ErrorContains(t, r.setupReadOnlyGraphRoot(), "must not be inside the graph root")
+}
@@ -51,7 +51,7 @@ func (r *Runtime) RenumberLocks() error {
 		ctr.config.LockID = lock.ID()
 
 		// Write the new lock ID
-		if err := r.state.RewriteContainerConfig(ctr, ctr.config); err != nil {
+		if err := ctr.rewriteConfig(); err != nil {
 			return err
 		}
 	}
@@ -94,3 +94,34 @@ func (r *Runtime) RenumberLocks() error {
 
 	return r.Shutdown(false)
 }
+
+// RenumberLocksPreview returns the locks of all containers, pods and volumes
+// in the state with the locks RenumberLocks would allocate to them, without
+// changing anything.  The locks are allocated in order, starting from 0, as
+// all of them are freed first.  If the lock manager has a limit, NewLockID is
+// nil for the objects no lock would be left for.
+func (r *Runtime) RenumberLocksPreview() ([]define.LockRenumbering, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+
+	allocations, err := r.LockAllocations()
+	if err != nil {
+		return nil, err
+	}
+	numLocks, _, err := r.LockUsage()
+	if err != nil {
+		return nil, err
+	}
+
+	preview := make([]define.LockRenumbering, 0, len(allocations))
+	for i, allocation := range allocations {
+		renumbering := define.LockRenumbering{LockAllocation: allocation}
+		if numLocks == 0 || uint32(i) < numLocks {
+			newLockID := uint32(i)
+			renumbering.NewLockID = &newLockID
+		}
+		preview = append(preview, renumbering)
+	}
+	return preview, nil
+}
@@ -0,0 +1,329 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	metadata "github.com/checkpoint-restore/checkpointctl/lib"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/storage"
+	"go.podman.io/storage/pkg/fileutils"
+)
+
+// configBackupFile is a copy of the container configuration kept in the
+// storage directory of the container. It allows the database entry of the
+// container to be regenerated if it is lost.
+const configBackupFile = "libpod-config.json"
+
+// writeConfigBackup writes a copy of the container configuration to the
+// storage directory of the container. The networks of the container are
+// kept apart from its configuration in the database, they are taken from
+// there.
+func (c *Container) writeConfigBackup() error {
+	if c.config.StaticDir == "" {
+		return nil
+	}
+	config := *c.config
+	networks, err := c.runtime.state.GetNetworks(c)
+	if err != nil {
+		return fmt.Errorf("writing configuration backup of container %s: retrieving networks: %w", c.ID(), err)
+	}
+	config.Networks = networks
+	if _, err := metadata.WriteJSONFile(&config, c.config.StaticDir, configBackupFile); err != nil {
+		return fmt.Errorf("writing configuration backup of container %s: %w", c.ID(), err)
+	}
+	return nil
+}
+
+// refreshConfigBackup rewrites the configuration backup after the
+// configuration of the container changed in the database. A stale backup
+// would restore an outdated configuration, but the change itself is not
+// failed for it.
+func (c *Container) refreshConfigBackup() {
+	if err := c.writeConfigBackup(); err != nil {
+		logrus.Warn(err)
+	}
+}
+
+// rewriteConfig rewrites the configuration of the container in the database
+// with RewriteContainerConfig, and refreshes its configuration backup.
+func (c *Container) rewriteConfig() error {
+	if err := c.runtime.state.RewriteContainerConfig(c, c.config); err != nil {
+		return err
+	}
+	c.refreshConfigBackup()
+	return nil
+}
+
+// safeRewriteConfig rewrites the configuration of the container in the
+// database with SafeRewriteContainerConfig, renaming it from oldName to
+// newName if they are set, and refreshes its configuration backup.
+func (c *Container) safeRewriteConfig(oldName, newName string) error {
+	if err := c.runtime.state.SafeRewriteContainerConfig(c, oldName, newName, c.config); err != nil {
+		return err
+	}
+	c.refreshConfigBackup()
+	return nil
+}
+
+// checkContainers compares the containers in the database with the
+// containers in storage. Containers whose storage container is missing, and
+// storage containers with a configuration backup but without a database
+// entry, are reported as damaged. If repair is set, the missing storage is
+// recreated, missing database entries are regenerated from the
+// configuration backups and missing configuration backups, of containers
+// created before they were written, are written. The repairs performed are
+// returned per container.
+func (r *Runtime) checkContainers(ctx context.Context, repair bool) (damaged map[string][]string, repaired map[string][]string, _ error) {
+	damaged = make(map[string][]string)
+	repaired = make(map[string][]string)
+
+	ctrs, err := r.state.AllContainers(false)
+	if err != nil {
+		return nil, nil, fmt.Errorf("retrieving containers from the database: %w", err)
+	}
+	known := make(map[string]bool, len(ctrs))
+	for _, ctr := range ctrs {
+		known[ctr.ID()] = true
+
+		// containers using a rootfs directory have no storage container
+		if ctr.config.Rootfs != "" || ctr.config.RootfsImageID == "" {
+			continue
+		}
+		if _, err := r.store.Container(ctr.ID()); err == nil {
+			r.checkConfigBackup(ctr, repair, repaired)
+			continue
+		} else if !errors.Is(err, storage.ErrContainerUnknown) {
+			return nil, nil, fmt.Errorf("looking up storage for container %s: %w", ctr.ID(), err)
+		}
+
+		if !repair {
+			damaged[ctr.ID()] = append(damaged[ctr.ID()], "storage container is missing")
+			continue
+		}
+		if err := ctr.recreateStorage(ctx); err != nil {
+			damaged[ctr.ID()] = append(damaged[ctr.ID()], fmt.Sprintf("recreating missing storage container: %v", err))
+			continue
+		}
+		repaired[ctr.ID()] = append(repaired[ctr.ID()], "recreated missing storage container")
+	}
+
+	storageCtrs, err := r.store.Containers()
+	if err != nil {
+		return nil, nil, fmt.Errorf("retrieving containers from storage: %w", err)
+	}
+	var backups []*ContainerConfig
+	for _, storageCtr := range storageCtrs {
+		if known[storageCtr.ID] {
+			continue
+		}
+		dir, err := r.store.ContainerDirectory(storageCtr.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("looking up storage directory of container %s: %w", storageCtr.ID, err)
+		}
+		config, err := readConfigBackup(filepath.Join(dir, configBackupFile))
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				// not created by us, e.g. a Buildah container
+				continue
+			}
+			damaged[storageCtr.ID] = append(damaged[storageCtr.ID], err.Error())
+			continue
+		}
+		if config.ID != storageCtr.ID {
+			damaged[storageCtr.ID] = append(damaged[storageCtr.ID], fmt.Sprintf("configuration backup belongs to container %s", config.ID))
+			continue
+		}
+		if !repair {
+			damaged[storageCtr.ID] = append(damaged[storageCtr.ID], "database entry is missing")
+			continue
+		}
+		backups = append(backups, config)
+	}
+
+	// restore in creation order, so that dependencies are restored before
+	// the containers depending on them
+	slices.SortFunc(backups, func(a, b *ContainerConfig) int {
+		return a.CreatedTime.Compare(b.CreatedTime)
+	})
+	for _, config := range backups {
+		if err := r.restoreContainerConfig(config); err != nil {
+			damaged[config.ID] = append(damaged[config.ID], fmt.Sprintf("regenerating missing database entry: %v", err))
+			continue
+		}
+		repaired[config.ID] = append(repaired[config.ID], "regenerated missing database entry from configuration backup")
+	}
+
+	return damaged, repaired, nil
+}
+
+// checkConfigBackup looks for the configuration backup of a container with
+// a storage container. Without it, the database entry of the container
+// cannot be regenerated if it is lost. If repair is set, the missing backup
+// is written and reported in repaired, otherwise a warning is logged.
+func (r *Runtime) checkConfigBackup(ctr *Container, repair bool, repaired map[string][]string) {
+	if ctr.config.StaticDir == "" {
+		return
+	}
+	if err := fileutils.Exists(filepath.Join(ctr.config.StaticDir, configBackupFile)); err == nil || !errors.Is(err, fs.ErrNotExist) {
+		return
+	}
+	if !repair {
+		logrus.Warnf("Container %s has no configuration backup, its database entry cannot be regenerated if lost; run with --repair-containers to write it", ctr.ID())
+		return
+	}
+	if err := ctr.writeConfigBackup(); err != nil {
+		logrus.Warn(err)
+		return
+	}
+	repaired[ctr.ID()] = append(repaired[ctr.ID()], "wrote missing configuration backup")
+}
+
+func readConfigBackup(path string) (*ContainerConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := new(ContainerConfig)
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("parsing configuration backup %q: %w", path, err)
+	}
+	return config, nil
+}
+
+// recreateStorage creates a new storage container for a container whose
+// storage container was lost. The root filesystem is recreated from the
+// image of the container; changes made to it are lost.
+func (c *Container) recreateStorage(ctx context.Context) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if err := c.syncContainer(); err != nil {
+		return err
+	}
+
+	if c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return fmt.Errorf("container %s is %s: %w", c.ID(), c.state.State.String(), define.ErrCtrStateInvalid)
+	}
+	if _, err := c.runtime.store.Image(c.config.RootfsImageID); err != nil {
+		return fmt.Errorf("looking up image %s: %w", c.config.RootfsImageID, err)
+	}
+
+	// the container in the OCI runtime references the lost root filesystem
+	if err := c.cleanupRuntime(ctx); err != nil {
+		return err
+	}
+
+	options, err := c.storageContainerOptions()
+	if err != nil {
+		return err
+	}
+	// keep the SELinux labels the container was created with
+	if c.config.ProcessLabel != "" && c.config.MountLabel != "" {
+		if options.Flags == nil {
+			options.Flags = make(map[string]any)
+		}
+		options.Flags["ProcessLabel"] = c.config.ProcessLabel
+		options.Flags["MountLabel"] = c.config.MountLabel
+	}
+
+	containerInfo, err := c.runtime.storageService.CreateContainerStorage(ctx, c.runtime.imageContext, c.config.RootfsImageName, c.config.RootfsImageID, c.config.Name, c.config.ID, options)
+	if err != nil {
+		return fmt.Errorf("creating container storage: %w", err)
+	}
+
+	c.config.IDMappings.UIDMap = containerInfo.UIDMap
+	c.config.IDMappings.GIDMap = containerInfo.GIDMap
+	c.config.MountLabel = containerInfo.MountLabel
+	c.config.StaticDir = containerInfo.Dir
+	c.state.RunDir = containerInfo.RunDir
+	c.state.Mounted = false
+	c.state.Mountpoint = ""
+
+	artifacts := filepath.Join(c.config.StaticDir, artifactsDir)
+	if err := os.MkdirAll(artifacts, 0755); err != nil {
+		return fmt.Errorf("creating artifacts directory: %w", err)
+	}
+	if c.config.SecretsPath != "" {
+		if err := os.MkdirAll(c.config.SecretsPath, 0755); err != nil {
+			return err
+		}
+		for _, secr := range c.config.Secrets {
+			if err := c.extractSecretToCtrStorage(secr); err != nil {
+				return err
+			}
+		}
+	}
+	if c.config.ShmDir != "" && strings.HasPrefix(c.config.ShmDir, c.config.StaticDir) {
+		if err := os.MkdirAll(c.config.ShmDir, 0700); err != nil {
+			return fmt.Errorf("unable to create shm dir: %w", err)
+		}
+	}
+
+	if err := c.runtime.state.SafeRewriteContainerConfig(c, "", "", c.config); err != nil {
+		return err
+	}
+	if err := c.save(); err != nil {
+		return err
+	}
+	return c.writeConfigBackup()
+}
+
+// restoreContainerConfig adds a container to the database from its
+// configuration backup. The container is added in Configured state.
+func (r *Runtime) restoreContainerConfig(config *ContainerConfig) (retErr error) {
+	ctr := new(Container)
+	ctr.config = config
+	ctr.state = new(ContainerState)
+	ctr.state.State = define.ContainerStateConfigured
+	ctr.state.BindMounts = make(map[string]string)
+	ctr.runtime = r
+
+	runDir, err := r.store.ContainerRunDirectory(config.ID)
+	if err != nil {
+		return err
+	}
+	ctr.state.RunDir = runDir
+
+	lock, err := r.lockManager.AllocateLock()
+	if err != nil {
+		return fmt.Errorf("allocating lock for container: %w", err)
+	}
+	ctr.lock = lock
+	ctr.config.LockID = lock.ID()
+	defer func() {
+		if retErr != nil {
+			if err := ctr.lock.Free(); err != nil {
+				logrus.Errorf("Freeing lock for container after restore failed: %v", err)
+			}
+		}
+	}()
+
+	ctr.valid = true
+
+	if ctr.config.Pod != "" {
+		pod, err := r.state.Pod(ctr.config.Pod)
+		if err != nil {
+			return fmt.Errorf("looking up pod %s: %w", ctr.config.Pod, err)
+		}
+		pod.lock.Lock()
+		defer pod.lock.Unlock()
+
+		if err := r.state.AddContainerToPod(pod, ctr); err != nil {
+			return err
+		}
+	} else if err := r.state.AddContainer(ctr); err != nil {
+		return err
+	}
+
+	return ctr.writeConfigBackup()
+}
@@ -4,9 +4,13 @@ package libpod
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/image/v5/types"
+	"go.podman.io/storage"
 )
 
 func Test_generateName(t *testing.T) {
@@ -25,3 +29,31 @@ func Test_generateName(t *testing.T) {
 	n2, _ := r.generateName()
 	assert.NotEqual(t, n1, n2)
 }
+
+func Test_setupLazyStorageError(t *testing.T) {
+	// An unknown graph driver makes the deferred store setup fail.
+	tmp := t.TempDir()
+	imageContext := &types.SystemContext{}
+	r := &Runtime{
+		config:       &config.Config{},
+		imageContext: imageContext,
+		lazyStorage:  true,
+		storageConfig: storage.StoreOptions{
+			GraphRoot:       filepath.Join(tmp, "root"),
+			RunRoot:         filepath.Join(tmp, "runroot"),
+			GraphDriverName: "no-such-driver",
+		},
+	}
+
+	err := r.GarbageCollect()
+	assert.ErrorContains(t, err, "configuring storage")
+	// The error is kept for later accessors.
+	_, err = r.Info()
+	assert.ErrorContains(t, err, "configuring storage")
+	assert.Empty(t, r.GraphRoot())
+	// Accessors without an error return do not exit the process.
+	_, err = r.Network().NetworkList()
+	assert.ErrorContains(t, err, "configuring storage")
+	assert.Same(t, imageContext, r.SystemContext())
+	assert.Nil(t, r.LibimageRuntime())
+}
@@ -6,8 +6,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -243,22 +245,38 @@ func (r *Runtime) newVolume(ctx context.Context, noCreatePluginVolume bool, opti
 	return volume, nil
 }
 
-// UpdateVolumePlugins reads all volumes from all configured volume plugins and
-// imports them into the libpod db. It also checks if existing libpod volumes
-// are removed in the plugin, in this case we try to remove it from libpod.
+// UpdateVolumePlugins reads all volumes from the given configured volume
+// plugins, or from all of them if none is given, and imports them into the
+// libpod db. It also checks if existing libpod volumes are removed in their
+// plugin, in this case we try to remove it from libpod. A volume name listed
+// by several plugins, or used by a volume of another driver, is reported as
+// a conflict and left as it is. The volumes of plugins which cannot be listed
+// are left as they are too.
 // On errors we continue and try to do as much as possible. all errors are
 // returned as array in the returned struct.
 // This function has many race conditions, it is best effort but cannot guarantee
 // a perfect state since plugins can be modified from the outside at any time.
-func (r *Runtime) UpdateVolumePlugins(ctx context.Context) *define.VolumeReload {
+func (r *Runtime) UpdateVolumePlugins(ctx context.Context, plugins []string) *define.VolumeReload {
 	var (
-		added            []string
-		removed          []string
-		errs             []error
-		allPluginVolumes = map[string]struct{}{}
+		added     []string
+		removed   []string
+		conflicts []define.VolumeReloadConflict
+		errs      []error
+		// listed maps the volumes listed by the plugins to the
+		// plugins listing them.
+		listed        = map[string][]string{}
+		listedPlugins = map[string]struct{}{}
 	)
 
-	for driverName, socket := range r.config.Engine.VolumePlugins {
+	if len(plugins) == 0 {
+		plugins = slices.Sorted(maps.Keys(r.config.Engine.VolumePlugins))
+	}
+	for _, driverName := range plugins {
+		socket, ok := r.config.Engine.VolumePlugins[driverName]
+		if !ok {
+			errs = append(errs, fmt.Errorf("volume plugin %q is not configured: %w", driverName, define.ErrMissingPlugin))
+			continue
+		}
 		driver, err := volplugin.GetVolumePlugin(driverName, socket, nil, r.config)
 		if err != nil {
 			errs = append(errs, err)
@@ -269,19 +287,9 @@ func (r *Runtime) UpdateVolumePlugins(ctx context.Context) *define.VolumeReload
 			errs = append(errs, fmt.Errorf("failed to read volumes from plugin %q: %w", driverName, err))
 			continue
 		}
+		listedPlugins[driverName] = struct{}{}
 		for _, vol := range vols {
-			allPluginVolumes[vol.Name] = struct{}{}
-			if _, err := r.newVolume(ctx, true, WithVolumeName(vol.Name), WithVolumeDriver(driverName)); err != nil {
-				// If the volume exists this is not an error, just ignore it and log. It is very likely
-				// that the volume from the plugin was already in our db.
-				if !errors.Is(err, define.ErrVolumeExists) {
-					errs = append(errs, err)
-					continue
-				}
-				logrus.Infof("Volume %q already exists: %v", vol.Name, err)
-				continue
-			}
-			added = append(added, vol.Name)
+			listed[vol.Name] = append(listed[vol.Name], driverName)
 		}
 	}
 
@@ -289,36 +297,69 @@ func (r *Runtime) UpdateVolumePlugins(ctx context.Context) *define.VolumeReload
 	if err != nil {
 		errs = append(errs, fmt.Errorf("cannot delete dangling plugin volumes: failed to read libpod volumes: %w", err))
 	}
+	existing := make(map[string]string, len(libpodVolumes))
 	for _, vol := range libpodVolumes {
-		if vol.UsesVolumeDriver() {
-			if _, ok := allPluginVolumes[vol.Name()]; !ok {
-				// The volume is no longer in the plugin. Let's remove it from the libpod db.
-				if err := r.removeVolume(ctx, vol, false, nil, true); err != nil {
-					if errors.Is(err, define.ErrVolumeBeingUsed) {
-						// Volume is still used by at least one container. This is very bad,
-						// the plugin no longer has this but we still need it.
-						errs = append(errs, fmt.Errorf("volume was removed from the plugin %q but containers still require it: %w", vol.config.Driver, err))
-						continue
-					}
-					if errors.Is(err, define.ErrNoSuchVolume) || errors.Is(err, define.ErrVolumeRemoved) || errors.Is(err, define.ErrMissingPlugin) {
-						// Volume was already removed, no problem just ignore it and continue.
-						continue
-					}
+		existing[vol.Name()] = vol.Driver()
+		if _, ok := listedPlugins[vol.Driver()]; !ok || !vol.UsesVolumeDriver() {
+			continue
+		}
+		if slices.Contains(listed[vol.Name()], vol.Driver()) {
+			continue
+		}
+		// The volume is no longer in the plugin. Let's remove it from the libpod db.
+		if err := r.removeVolume(ctx, vol, false, nil, true); err != nil {
+			if errors.Is(err, define.ErrVolumeBeingUsed) {
+				// Volume is still used by at least one container. This is very bad,
+				// the plugin no longer has this but we still need it.
+				errs = append(errs, fmt.Errorf("volume was removed from the plugin %q but containers still require it: %w", vol.config.Driver, err))
+				continue
+			}
+			if errors.Is(err, define.ErrNoSuchVolume) || errors.Is(err, define.ErrVolumeRemoved) || errors.Is(err, define.ErrMissingPlugin) {
+				// Volume was already removed, no problem just ignore it and continue.
+				delete(existing, vol.Name())
+				continue
+			}
 
-					// some other error
-					errs = append(errs, err)
-					continue
-				}
-				// Volume was successfully removed
-				removed = append(removed, vol.Name())
+			// some other error
+			errs = append(errs, err)
+			continue
+		}
+		// Volume was successfully removed
+		delete(existing, vol.Name())
+		removed = append(removed, vol.Name())
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(listed)) {
+		drivers := listed[name]
+		if driver, ok := existing[name]; ok {
+			if len(drivers) == 1 && drivers[0] == driver {
+				// The volume from the plugin is already in our db.
+				continue
 			}
+			drivers = append([]string{driver}, slices.DeleteFunc(drivers, func(d string) bool { return d == driver })...)
+		}
+		if len(drivers) > 1 {
+			conflicts = append(conflicts, define.VolumeReloadConflict{Name: name, Drivers: drivers})
+			continue
+		}
+		if _, err := r.newVolume(ctx, true, WithVolumeName(name), WithVolumeDriver(drivers[0])); err != nil {
+			// If the volume exists this is not an error, just ignore it and log. It is very likely
+			// that the volume was created in the meantime.
+			if !errors.Is(err, define.ErrVolumeExists) {
+				errs = append(errs, err)
+				continue
+			}
+			logrus.Infof("Volume %q already exists: %v", name, err)
+			continue
 		}
+		added = append(added, name)
 	}
 
 	return &define.VolumeReload{
-		Added:   added,
-		Removed: removed,
-		Errors:  errs,
+		Added:     added,
+		Removed:   removed,
+		Conflicts: conflicts,
+		Errors:    errs,
 	}
 }
 
@@ -0,0 +1,113 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/rootless"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/storage/pkg/homedir"
+)
+
+const (
+	// RuntimeClassDefaultDir is the directory runtime classes of packages
+	// are installed to.
+	RuntimeClassDefaultDir = "/usr/share/containers/runtime-classes.d"
+	// RuntimeClassOverrideDir is the directory of runtime classes defined
+	// by the administrator, which override the ones of packages.
+	RuntimeClassOverrideDir = "/etc/containers/runtime-classes.d"
+)
+
+// runtimeClassName is the format of runtime class names, the DNS labels
+// Kubernetes accepts as runtimeClassName.
+var runtimeClassName = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// RuntimeClass is a pre-configured OCI runtime together with the annotations
+// it needs to run a container, e.g. the ones selecting the confidential VM
+// of Kata Containers.
+type RuntimeClass struct {
+	// Name of the runtime class.
+	Name string `json:"-"`
+	// Runtime is the name of the OCI runtime of containers.conf running
+	// the containers of the class.
+	Runtime string `json:"runtime"`
+	// Annotations are added to the containers of the class.
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// runtimeClassDirs returns the directories runtime classes are read from,
+// later directories override earlier ones.
+func runtimeClassDirs() []string {
+	return configDropInDirs(RuntimeClassDefaultDir, RuntimeClassOverrideDir)
+}
+
+// configDropInDirs returns the directories of packages and of the
+// administrator, followed by the one of the user when running rootless,
+// which has the name of the administrator's one.
+func configDropInDirs(defaultDir, overrideDir string) []string {
+	dirs := []string{defaultDir, overrideDir}
+	if rootless.IsRootless() {
+		configHome, err := homedir.GetConfigHome()
+		if err != nil {
+			logrus.Warnf("Looking up the configuration directory of the user: %v", err)
+			return dirs
+		}
+		dirs = append(dirs, filepath.Join(configHome, "containers", filepath.Base(overrideDir)))
+	}
+	return dirs
+}
+
+// readRuntimeClass reads the runtime class name from the <name>.json files
+// of dirs.  It returns nil when none of the directories defines the class.
+func readRuntimeClass(dirs []string, name string) (*RuntimeClass, error) {
+	var class *RuntimeClass
+	for _, dir := range dirs {
+		path := filepath.Join(dir, name+".json")
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("reading runtime class %s: %w", name, err)
+		}
+		class = &RuntimeClass{Name: name}
+		if err := json.Unmarshal(content, class); err != nil {
+			return nil, fmt.Errorf("parsing runtime class %s: %w", path, err)
+		}
+		if class.Runtime == "" {
+			return nil, fmt.Errorf("runtime class %s does not set a runtime: %w", path, define.ErrInvalidArg)
+		}
+	}
+	return class, nil
+}
+
+// RuntimeClass looks up the runtime class name.  Runtime classes are defined
+// by the <name>.json files of the runtime class directories, an OCI runtime
+// of containers.conf is a runtime class without annotations.  The OCI
+// runtime of the class must be installed.
+func (r *Runtime) RuntimeClass(name string) (*RuntimeClass, error) {
+	if !runtimeClassName.MatchString(name) {
+		return nil, fmt.Errorf("invalid runtime class name %q: %w", name, define.ErrInvalidArg)
+	}
+	class, err := readRuntimeClass(runtimeClassDirs(), name)
+	if err != nil {
+		return nil, err
+	}
+	if class == nil {
+		if _, ok := r.config.Engine.OCIRuntimes[name]; !ok {
+			return nil, fmt.Errorf("runtime class %s is not defined in %s: %w", name, strings.Join(runtimeClassDirs(), ", "), define.ErrInvalidArg)
+		}
+		class = &RuntimeClass{Name: name, Runtime: name}
+	}
+	if _, ok := r.ociRuntimes[class.Runtime]; !ok {
+		return nil, fmt.Errorf("OCI runtime %s of runtime class %s is not installed: %w", class.Runtime, name, define.ErrOCIRuntimeUnavailable)
+	}
+	return class, nil
+}
@@ -0,0 +1,43 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readRuntimeClass(t *testing.T) {
+	vendorDir := t.TempDir()
+	adminDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(vendorDir, "kata-cc.json"), []byte(`{"runtime": "kata", "annotations": {"io.katacontainers.config.hypervisor.confidential_guest": "true"}}`), 0o644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(vendorDir, "broken.json"), []byte(`{"annotations": {"a": "b"}}`), 0o644)
+	require.NoError(t, err)
+
+	class, err := readRuntimeClass([]string{vendorDir, adminDir}, "kata-cc")
+	require.NoError(t, err)
+	assert.Equal(t, &RuntimeClass{
+		Name:        "kata-cc",
+		Runtime:     "kata",
+		Annotations: map[string]string{"io.katacontainers.config.hypervisor.confidential_guest": "true"},
+	}, class)
+
+	// the class of a later directory overrides the one of an earlier
+	err = os.WriteFile(filepath.Join(adminDir, "kata-cc.json"), []byte(`{"runtime": "kata-qemu"}`), 0o644)
+	require.NoError(t, err)
+	class, err = readRuntimeClass([]string{vendorDir, adminDir}, "kata-cc")
+	require.NoError(t, err)
+	assert.Equal(t, &RuntimeClass{Name: "kata-cc", Runtime: "kata-qemu"}, class)
+
+	class, err = readRuntimeClass([]string{vendorDir, adminDir}, "missing")
+	require.NoError(t, err)
+	assert.Nil(t, class)
+
+	_, err = readRuntimeClass([]string{vendorDir, adminDir}, "broken")
+	assert.ErrorContains(t, err, "does not set a runtime")
+}
@@ -0,0 +1,163 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/libpod/events"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libimage"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/storage"
+)
+
+// isImageStoreOption reports whether the storage driver option lists
+// additional image stores, like "overlay.imagestore=/mnt/shared".
+func isImageStoreOption(option string) bool {
+	key, _, ok := strings.Cut(option, "=")
+	if !ok {
+		return false
+	}
+	key = strings.ToLower(key)
+	if i := strings.LastIndex(key, "."); i >= 0 {
+		key = key[i+1:]
+	}
+	return key == "imagestore" || key == "additionalimagestore"
+}
+
+// splitSharedImageStore returns the first additional image store of the
+// storage driver options which is on shared storage, and the options
+// without it.  The store is empty if none is on shared storage.
+func splitSharedImageStore(options []string, isShared func(path string) (bool, error)) (string, []string) {
+	sharedStore := ""
+	rest := make([]string, 0, len(options))
+	for _, option := range options {
+		if sharedStore != "" || !isImageStoreOption(option) {
+			rest = append(rest, option)
+			continue
+		}
+		key, value, _ := strings.Cut(option, "=")
+		var stores []string
+		for _, store := range strings.Split(value, ",") {
+			if sharedStore == "" {
+				shared, err := isShared(store)
+				if err != nil {
+					logrus.Debugf("Checking if image store %s is on shared storage: %v", store, err)
+				}
+				if shared {
+					sharedStore = store
+					continue
+				}
+			}
+			stores = append(stores, store)
+		}
+		if len(stores) > 0 {
+			rest = append(rest, key+"="+strings.Join(stores, ","))
+		}
+	}
+	return sharedStore, rest
+}
+
+// setupSharedImageStorePull finds the shared image store the images are
+// pulled into with PullImageToSharedStore.  The store is removed from the
+// additional image stores of the runtime, which would otherwise hold it
+// read-only.
+func (r *Runtime) setupSharedImageStorePull() error {
+	store, options := splitSharedImageStore(r.storageConfig.GraphDriverOptions, isPathOnSharedStorage)
+	if store != "" {
+		r.sharedImageStore = store
+		r.storageConfig.GraphDriverOptions = options
+		logrus.Debugf("Pulling images into the shared image store %s", store)
+		return nil
+	}
+
+	imageRoot := r.storageConfig.ImageStore
+	if imageRoot == "" {
+		imageRoot = r.storageConfig.GraphRoot
+	}
+	shared, err := isPathOnSharedStorage(imageRoot)
+	if err != nil {
+		logrus.Debugf("Checking if image storage %s is on shared storage: %v", imageRoot, err)
+	}
+	if !shared {
+		return fmt.Errorf("no additional image store on shared storage is set, see additionalimagestores in containers-storage.conf(5): %w", define.ErrInvalidArg)
+	}
+	// The images are stored on shared storage already, as on podman
+	// machines with a shared layer store.
+	logrus.Debugf("Image storage %s is on shared storage, pulling images there", imageRoot)
+	return nil
+}
+
+// PullImageToSharedStore pulls name into the shared image store, the
+// additional image store on shared storage, instead of the graph root.  The
+// layers are written only to the shared image store, where all the nodes
+// using the store find the image, and only the pull is recorded locally.  The
+// runtime must be created with WithSharedImageStorePull.  It returns the IDs
+// of the pulled images.
+func (r *Runtime) PullImageToSharedStore(ctx context.Context, name string, policy config.PullPolicy, options *libimage.PullOptions) ([]string, error) {
+	if !r.sharedImageStorePull {
+		return nil, fmt.Errorf("runtime is not set up to pull into the shared image store: %w", define.ErrInvalidArg)
+	}
+
+	var images []*libimage.Image
+	if r.sharedImageStore == "" {
+		var err error
+		images, err = r.LibimageRuntime().Pull(ctx, name, policy, options)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		storeOptions := r.storageConfig
+		storeOptions.GraphRoot = r.sharedImageStore
+		storeOptions.RunRoot = filepath.Join(r.storageConfig.RunRoot, "shared-image-store")
+		storeOptions.ImageStore = ""
+		storeOptions.GraphDriverName = r.store.GraphDriverName()
+		// Layers are only reused from the shared image store, so that
+		// the parents of the pulled layers are found by all nodes.
+		storeOptions.GraphDriverOptions = nil
+		for _, option := range r.storageConfig.GraphDriverOptions {
+			if !isImageStoreOption(option) {
+				storeOptions.GraphDriverOptions = append(storeOptions.GraphDriverOptions, option)
+			}
+		}
+		store, err := storage.GetStore(storeOptions)
+		if err != nil {
+			return nil, fmt.Errorf("opening shared image store %s: %w", r.sharedImageStore, err)
+		}
+		defer func() {
+			if _, err := store.Shutdown(false); err != nil {
+				logrus.Errorf("Shutting down shared image store %s: %v", r.sharedImageStore, err)
+			}
+		}()
+		sharedRuntime, err := libimage.RuntimeFromStore(store, &libimage.RuntimeOptions{SystemContext: r.imageContext})
+		if err != nil {
+			return nil, err
+		}
+		images, err = sharedRuntime.Pull(ctx, name, policy, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]string, 0, len(images))
+	for _, img := range images {
+		ids = append(ids, img.ID())
+		if r.sharedImageStore == "" {
+			// The pull event was written by the libimage runtime.
+			continue
+		}
+		e := events.NewEvent(events.Pull)
+		e.Type = events.Image
+		e.ID = img.ID()
+		e.Name = name
+		if err := r.eventer.Write(e); err != nil {
+			logrus.Errorf("Unable to write pull event: %q", err)
+		}
+	}
+	return ids, nil
+}
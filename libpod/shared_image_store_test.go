@@ -0,0 +1,57 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitSharedImageStore(t *testing.T) {
+	isShared := func(path string) (bool, error) {
+		return path == "/mnt/shared" || path == "/mnt/other-shared", nil
+	}
+
+	for _, tc := range []struct {
+		name    string
+		options []string
+		store   string
+		rest    []string
+	}{
+		{
+			name:    "no image store",
+			options: []string{"overlay.mountopt=nodev"},
+			rest:    []string{"overlay.mountopt=nodev"},
+		},
+		{
+			name:    "local image store",
+			options: []string{"overlay.imagestore=/var/lib/images"},
+			rest:    []string{"overlay.imagestore=/var/lib/images"},
+		},
+		{
+			name:    "shared image store",
+			options: []string{"overlay.mountopt=nodev", "overlay.imagestore=/mnt/shared"},
+			store:   "/mnt/shared",
+			rest:    []string{"overlay.mountopt=nodev"},
+		},
+		{
+			name:    "first shared image store",
+			options: []string{"vfs.imagestore=/var/lib/images,/mnt/shared,/mnt/other-shared"},
+			store:   "/mnt/shared",
+			rest:    []string{"vfs.imagestore=/var/lib/images,/mnt/other-shared"},
+		},
+		{
+			name:    "additionalimagestore",
+			options: []string{".additionalimagestore=/mnt/other-shared", "overlay.imagestore=/mnt/shared"},
+			store:   "/mnt/other-shared",
+			rest:    []string{"overlay.imagestore=/mnt/shared"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			store, rest := splitSharedImageStore(tc.options, isShared)
+			assert.Equal(t, tc.store, store)
+			assert.Equal(t, tc.rest, rest)
+		})
+	}
+}
@@ -0,0 +1,395 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/storage/pkg/archive"
+	"go.podman.io/storage/pkg/directory"
+	"go.podman.io/storage/pkg/ioutils"
+	"go.podman.io/storage/pkg/lockfile"
+)
+
+const (
+	sharedLayerCacheStateFile = "cache.json"
+	sharedLayerCacheLockFile  = "cache.lock"
+	sharedLayerCacheLayersDir = "layers"
+	// sharedLayerCacheHotUses is the number of mounts of a shared base
+	// layer after which it is copied to the cache.  A layer mounted once
+	// is read from the shared storage.
+	sharedLayerCacheHotUses = 2
+)
+
+// sharedLayerCacheEntry is what is known of a shared base layer mounted
+// while the cache was enabled.
+type sharedLayerCacheEntry struct {
+	// Uses is the number of times the layer was mounted.
+	Uses     int       `json:"uses"`
+	LastUsed time.Time `json:"lastUsed"`
+	// Cached is true once the layer is copied to the cache.
+	Cached bool  `json:"cached,omitempty"`
+	Size   int64 `json:"size,omitempty"`
+}
+
+// sharedLayerCacheState is saved in the cache directory, shared by the
+// podman processes using the cache.
+type sharedLayerCacheState struct {
+	Hits   uint64                            `json:"hits"`
+	Misses uint64                            `json:"misses"`
+	Layers map[string]*sharedLayerCacheEntry `json:"layers"`
+}
+
+// sharedLayerCache keeps local copies of the shared base layers which are
+// mounted often, so that containers read them from local disk instead of
+// the slow shared storage.  The least recently used copies are removed to
+// keep the cache under its maximum size.
+type sharedLayerCache struct {
+	dir     string
+	maxSize int64
+	lock    *lockfile.LockFile
+	// inUse reports whether a copy is the lower directory of a mount, it
+	// must not be removed then.
+	inUse func(path string) bool
+}
+
+// newSharedLayerCache returns the cache stored in dir.
+func newSharedLayerCache(dir string, maxSize int64) (*sharedLayerCache, error) {
+	if err := os.MkdirAll(filepath.Join(dir, sharedLayerCacheLayersDir), 0o700); err != nil {
+		return nil, fmt.Errorf("creating shared layer cache: %w", err)
+	}
+	lock, err := lockfile.GetLockFile(filepath.Join(dir, sharedLayerCacheLockFile))
+	if err != nil {
+		return nil, fmt.Errorf("creating shared layer cache lock: %w", err)
+	}
+	return &sharedLayerCache{
+		dir:     dir,
+		maxSize: maxSize,
+		lock:    lock,
+		inUse:   isMountedLowerDir,
+	}, nil
+}
+
+// PurgeSharedLayerCache removes the copies of the shared base layers from
+// the local cache, except the ones in use, and returns the removed layers and
+// the size they freed.
+func (r *Runtime) PurgeSharedLayerCache() ([]string, int64, error) {
+	if r.sharedLayerCache == nil {
+		return nil, 0, fmt.Errorf("no shared layer cache is set, use --shared-layer-cache: %w", define.ErrInvalidArg)
+	}
+	return r.sharedLayerCache.purge()
+}
+
+func (c *sharedLayerCache) layerPath(layerID string) string {
+	return filepath.Join(c.dir, sharedLayerCacheLayersDir, layerID)
+}
+
+// loadState must be called with c.lock held.
+func (c *sharedLayerCache) loadState() (*sharedLayerCacheState, error) {
+	state := &sharedLayerCacheState{Layers: make(map[string]*sharedLayerCacheEntry)}
+	data, err := os.ReadFile(filepath.Join(c.dir, sharedLayerCacheStateFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return state, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("parsing shared layer cache state: %w", err)
+	}
+	if state.Layers == nil {
+		state.Layers = make(map[string]*sharedLayerCacheEntry)
+	}
+	return state, nil
+}
+
+// saveState must be called with c.lock held.
+func (c *sharedLayerCache) saveState(state *sharedLayerCacheState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(filepath.Join(c.dir, sharedLayerCacheStateFile), data, 0o600)
+}
+
+// lookup returns the directory to mount for the shared base layer found at
+// layerPath: its copy in the cache, made once the layer is hot, or
+// layerPath.  The layer is copied without holding the lock of the cache, so
+// that the other users of the cache are not blocked while it is copied.
+func (c *sharedLayerCache) lookup(layerID, layerPath string) (string, error) {
+	cachePath, hot, err := c.recordUse(layerID)
+	if err != nil {
+		return "", err
+	}
+	if cachePath != "" {
+		return cachePath, nil
+	}
+	if !hot {
+		return layerPath, nil
+	}
+	cached, err := c.add(layerID, layerPath)
+	if err != nil {
+		logrus.Warnf("Copying shared base layer %s to the local cache: %v", layerID, err)
+		return layerPath, nil
+	}
+	if cached {
+		return c.layerPath(layerID), nil
+	}
+	return layerPath, nil
+}
+
+// recordUse counts a mount of the layer.  It returns the path of the copy
+// of the layer if it is cached, or whether the layer is hot and should be
+// copied.
+func (c *sharedLayerCache) recordUse(layerID string) (string, bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	state, err := c.loadState()
+	if err != nil {
+		return "", false, err
+	}
+	entry, ok := state.Layers[layerID]
+	if !ok {
+		entry = &sharedLayerCacheEntry{}
+		state.Layers[layerID] = entry
+	}
+	entry.Uses++
+	entry.LastUsed = time.Now()
+
+	cachePath := c.layerPath(layerID)
+	if entry.Cached {
+		if _, err := os.Stat(cachePath); err == nil {
+			state.Hits++
+			return cachePath, false, c.saveState(state)
+		}
+		// The copy was removed behind our back.
+		entry.Cached = false
+		entry.Size = 0
+	}
+	state.Misses++
+	return "", entry.Uses >= sharedLayerCacheHotUses, c.saveState(state)
+}
+
+// add copies the layer to the cache, after removing the least recently used
+// copies to make room for it.  It returns false if the layer does not fit in
+// the cache, or if another process is copying it.
+func (c *sharedLayerCache) add(layerID, layerPath string) (bool, error) {
+	// Only one process copies a layer, the others mount the shared layer
+	// meanwhile.
+	copyLock, err := lockfile.GetLockFile(filepath.Join(c.dir, sharedLayerCacheLayersDir, ".lock-"+layerID))
+	if err != nil {
+		return false, err
+	}
+	if err := copyLock.TryLock(); err != nil {
+		logrus.Debugf("Shared base layer %s is being copied to the local cache", layerID)
+		return false, nil
+	}
+	defer copyLock.Unlock()
+
+	size, err := directory.Size(layerPath)
+	if err != nil {
+		return false, err
+	}
+	if size > c.maxSize {
+		logrus.Debugf("Shared base layer %s of %d bytes is larger than the local cache", layerID, size)
+		return false, nil
+	}
+	if fits, err := c.makeRoom(layerID, size); err != nil || !fits {
+		return false, err
+	}
+
+	tmpPath, err := os.MkdirTemp(filepath.Join(c.dir, sharedLayerCacheLayersDir), ".tmp-")
+	if err != nil {
+		return false, err
+	}
+	defer os.RemoveAll(tmpPath)
+	if err := archive.NewDefaultArchiver().CopyWithTar(layerPath, tmpPath); err != nil {
+		return false, err
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	state, err := c.loadState()
+	if err != nil {
+		return false, err
+	}
+	// Other layers may have been copied while this one was.
+	if _, err := c.evict(state, c.maxSize-size); err != nil {
+		return false, err
+	}
+	if cacheSize(state) > c.maxSize-size {
+		logrus.Debugf("No room left in the local cache for shared base layer %s", layerID)
+		return false, c.saveState(state)
+	}
+	if err := os.RemoveAll(c.layerPath(layerID)); err != nil {
+		return false, err
+	}
+	if err := os.Rename(tmpPath, c.layerPath(layerID)); err != nil {
+		return false, err
+	}
+	entry, ok := state.Layers[layerID]
+	if !ok {
+		entry = &sharedLayerCacheEntry{LastUsed: time.Now()}
+		state.Layers[layerID] = entry
+	}
+	entry.Cached = true
+	entry.Size = size
+	logrus.Debugf("Copied shared base layer %s of %d bytes to the local cache", layerID, size)
+	return true, c.saveState(state)
+}
+
+// makeRoom removes the least recently used copies which are not in use to
+// make room for a layer of the given size.  It returns false if there is not
+// enough room, the cached layers being in use.
+func (c *sharedLayerCache) makeRoom(layerID string, size int64) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	state, err := c.loadState()
+	if err != nil {
+		return false, err
+	}
+	_, err = c.evict(state, c.maxSize-size)
+	if saveErr := c.saveState(state); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	if err != nil {
+		return false, err
+	}
+	if cacheSize(state) > c.maxSize-size {
+		logrus.Debugf("No room in the local cache for shared base layer %s, the cached layers are in use", layerID)
+		return false, nil
+	}
+	return true, nil
+}
+
+// evict removes the least recently used copies which are not in use until
+// the cache is not larger than maxSize.  It returns the removed layers.
+func (c *sharedLayerCache) evict(state *sharedLayerCacheState, maxSize int64) ([]string, error) {
+	size := cacheSize(state)
+	cached := make([]string, 0, len(state.Layers))
+	for id, entry := range state.Layers {
+		if entry.Cached {
+			cached = append(cached, id)
+		}
+	}
+	sort.Slice(cached, func(i, j int) bool {
+		return state.Layers[cached[i]].LastUsed.Before(state.Layers[cached[j]].LastUsed)
+	})
+	var removed []string
+	for _, id := range cached {
+		if size <= maxSize {
+			break
+		}
+		path := c.layerPath(id)
+		if c.inUse(path) {
+			continue
+		}
+		if err := os.RemoveAll(path); err != nil {
+			return removed, err
+		}
+		entry := state.Layers[id]
+		size -= entry.Size
+		delete(state.Layers, id)
+		removed = append(removed, id)
+		logrus.Debugf("Removed shared base layer %s from the local cache", id)
+	}
+	return removed, nil
+}
+
+// purge removes the copies which are not in use and returns the removed
+// layers and the size they freed.
+func (c *sharedLayerCache) purge() ([]string, int64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	state, err := c.loadState()
+	if err != nil {
+		return nil, 0, err
+	}
+	size := cacheSize(state)
+	removed, err := c.evict(state, 0)
+	freed := size - cacheSize(state)
+	if saveErr := c.saveState(state); saveErr != nil && err == nil {
+		err = saveErr
+	}
+	return removed, freed, err
+}
+
+// info returns the statistics of the cache.
+func (c *sharedLayerCache) info() (*define.SharedLayerCacheInfo, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	state, err := c.loadState()
+	if err != nil {
+		return nil, err
+	}
+	info := &define.SharedLayerCacheInfo{
+		Path:    c.dir,
+		Size:    cacheSize(state),
+		MaxSize: c.maxSize,
+		Hits:    state.Hits,
+		Misses:  state.Misses,
+	}
+	for _, entry := range state.Layers {
+		if entry.Cached {
+			info.Layers++
+		}
+	}
+	return info, nil
+}
+
+func cacheSize(state *sharedLayerCacheState) int64 {
+	var size int64
+	for _, entry := range state.Layers {
+		if entry.Cached {
+			size += entry.Size
+		}
+	}
+	return size
+}
+
+// isMountedLowerDir reports whether path is a lower directory of an overlay
+// mount.
+func isMountedLowerDir(path string) bool {
+	f, err := os.Open("/proc/self/mountinfo")
+	if err != nil {
+		logrus.Debugf("Failed to read mounts: %v", err)
+		// Assume the worst, the copy is kept.
+		return true
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		// The mount options of the filesystem come last.
+		for _, opt := range strings.Split(fields[len(fields)-1], ",") {
+			lowerDirs, ok := strings.CutPrefix(opt, "lowerdir=")
+			if !ok {
+				continue
+			}
+			for _, dir := range strings.Split(lowerDirs, ":") {
+				if dir == path {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,117 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/storage/pkg/lockfile"
+)
+
+func newTestLayer(t *testing.T, size int) string {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "file"), []byte(strings.Repeat("x", size)), 0o644))
+	return dir
+}
+
+func TestSharedLayerCache(t *testing.T) {
+	cache, err := newSharedLayerCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+	inUse := map[string]bool{}
+	cache.inUse = func(path string) bool { return inUse[path] }
+
+	layerA := newTestLayer(t, 400<<10)
+	layerB := newTestLayer(t, 400<<10)
+	layerC := newTestLayer(t, 400<<10)
+
+	// A layer is copied once it is mounted again.
+	path, err := cache.lookup("a", layerA)
+	require.NoError(t, err)
+	assert.Equal(t, layerA, path)
+	path, err = cache.lookup("a", layerA)
+	require.NoError(t, err)
+	assert.Equal(t, cache.layerPath("a"), path)
+	assert.FileExists(t, filepath.Join(path, "file"))
+	path, err = cache.lookup("a", layerA)
+	require.NoError(t, err)
+	assert.Equal(t, cache.layerPath("a"), path)
+
+	for range 2 {
+		_, err = cache.lookup("b", layerB)
+		require.NoError(t, err)
+	}
+	info, err := cache.info()
+	require.NoError(t, err)
+	assert.Equal(t, 2, info.Layers)
+	assert.Equal(t, uint64(1), info.Hits)
+	assert.Equal(t, uint64(4), info.Misses)
+
+	// The least recently used layer makes room for the new one.
+	for range 2 {
+		_, err = cache.lookup("c", layerC)
+		require.NoError(t, err)
+	}
+	assert.NoDirExists(t, cache.layerPath("a"))
+	assert.DirExists(t, cache.layerPath("b"))
+	assert.DirExists(t, cache.layerPath("c"))
+	info, err = cache.info()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, info.Size, info.MaxSize)
+
+	// Layers in use are kept, the new layer is then not cached.
+	inUse[cache.layerPath("b")] = true
+	inUse[cache.layerPath("c")] = true
+	for range 2 {
+		path, err = cache.lookup("a", layerA)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, layerA, path)
+
+	// Layers larger than the cache are never cached.
+	large, err := newSharedLayerCache(t.TempDir(), 1<<10)
+	require.NoError(t, err)
+	for range 2 {
+		path, err = large.lookup("a", layerA)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, layerA, path)
+
+	inUse[cache.layerPath("c")] = false
+	removed, freed, err := cache.purge()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"c"}, removed)
+	assert.Positive(t, freed)
+	assert.DirExists(t, cache.layerPath("b"))
+	assert.NoDirExists(t, cache.layerPath("c"))
+}
+
+func TestSharedLayerCacheCopyInProgress(t *testing.T) {
+	cache, err := newSharedLayerCache(t.TempDir(), 1<<20)
+	require.NoError(t, err)
+	cache.inUse = func(string) bool { return false }
+	layer := newTestLayer(t, 4<<10)
+
+	// Another process copying the layer does not block the mount, the
+	// shared layer is used meanwhile.
+	copyLock, err := lockfile.GetLockFile(filepath.Join(cache.dir, sharedLayerCacheLayersDir, ".lock-a"))
+	require.NoError(t, err)
+	copyLock.Lock()
+	for range 2 {
+		path, err := cache.lookup("a", layer)
+		require.NoError(t, err)
+		assert.Equal(t, layer, path)
+	}
+	copyLock.Unlock()
+
+	path, err := cache.lookup("a", layer)
+	require.NoError(t, err)
+	assert.Equal(t, cache.layerPath("a"), path)
+	tmpDirs, err := filepath.Glob(filepath.Join(cache.dir, sharedLayerCacheLayersDir, ".tmp-*"))
+	require.NoError(t, err)
+	assert.Empty(t, tmpDirs)
+}
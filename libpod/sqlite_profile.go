@@ -0,0 +1,101 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"database/sql/driver"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/mattn/go-sqlite3"
+)
+
+// sqliteProfile records the time spent running the queries of a SQLite
+// state once enabled.
+type sqliteProfile struct {
+	enabled atomic.Bool
+	lock    sync.Mutex
+	queries map[string]*define.DBQueryProfile
+}
+
+// record adds a run of the query started at start to the profile.
+func (p *sqliteProfile) record(query string, start time.Time) {
+	if !p.enabled.Load() {
+		return
+	}
+	duration := time.Since(start)
+	query = strings.Join(strings.Fields(query), " ")
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.queries == nil {
+		p.queries = make(map[string]*define.DBQueryProfile)
+	}
+	profile, ok := p.queries[query]
+	if !ok {
+		profile = &define.DBQueryProfile{Query: query}
+		p.queries[query] = profile
+	}
+	profile.Count++
+	profile.Total += duration
+	profile.Max = max(profile.Max, duration)
+}
+
+// profiles returns the profiles of the queries, the most time consuming
+// first.
+func (p *sqliteProfile) profiles() []define.DBQueryProfile {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	profiles := make([]define.DBQueryProfile, 0, len(p.queries))
+	for _, profile := range p.queries {
+		profiles = append(profiles, *profile)
+	}
+	sort.Slice(profiles, func(i, j int) bool {
+		return profiles[i].Total > profiles[j].Total
+	})
+	return profiles
+}
+
+// sqliteProfileConnector opens SQLite connections recording the time spent
+// running queries in the profile.
+type sqliteProfileConnector struct {
+	dsn     string
+	driver  *sqlite3.SQLiteDriver
+	profile *sqliteProfile
+}
+
+func newSQLiteProfileConnector(dsn string, profile *sqliteProfile) *sqliteProfileConnector {
+	return &sqliteProfileConnector{dsn: dsn, driver: &sqlite3.SQLiteDriver{}, profile: profile}
+}
+
+func (c *sqliteProfileConnector) Connect(_ context.Context) (driver.Conn, error) {
+	conn, err := c.driver.Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteProfileConn{SQLiteConn: conn.(*sqlite3.SQLiteConn), profile: c.profile}, nil
+}
+
+func (c *sqliteProfileConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+type sqliteProfileConn struct {
+	*sqlite3.SQLiteConn
+	profile *sqliteProfile
+}
+
+func (c *sqliteProfileConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	defer c.profile.record(query, time.Now())
+	return c.SQLiteConn.QueryContext(ctx, query, args)
+}
+
+func (c *sqliteProfileConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	defer c.profile.record(query, time.Now())
+	return c.SQLiteConn.ExecContext(ctx, query, args)
+}
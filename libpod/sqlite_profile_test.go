@@ -0,0 +1,34 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLiteProfile(t *testing.T) {
+	var profile sqliteProfile
+
+	// Nothing is recorded until profiling is enabled.
+	profile.record("SELECT 1;", time.Now())
+	assert.Empty(t, profile.profiles())
+
+	profile.enabled.Store(true)
+	start := time.Now()
+	profile.record("SELECT JSON\n\tFROM ContainerConfig;", start.Add(-time.Second))
+	profile.record("SELECT JSON FROM ContainerConfig;", start.Add(-2*time.Second))
+	profile.record("SELECT 1;", start)
+
+	profiles := profile.profiles()
+	require.Len(t, profiles, 2)
+	assert.Equal(t, "SELECT JSON FROM ContainerConfig;", profiles[0].Query)
+	assert.Equal(t, uint64(2), profiles[0].Count)
+	assert.GreaterOrEqual(t, profiles[0].Max, 2*time.Second)
+	assert.GreaterOrEqual(t, profiles[0].Total, 3*time.Second)
+	assert.Equal(t, "SELECT 1;", profiles[1].Query)
+	assert.Equal(t, uint64(1), profiles[1].Count)
+}
@@ -16,18 +16,20 @@ import (
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/libnetwork/types"
 	"go.podman.io/storage"
-
-	// SQLite backend for database/sql
-	_ "github.com/mattn/go-sqlite3"
 )
 
-const schemaVersion = 1
+const schemaVersion = 2
 
 // SQLiteState is a state implementation backed by a SQLite database
 type SQLiteState struct {
-	valid   bool
-	conn    *sql.DB
-	runtime *Runtime
+	valid bool
+	conn  *sql.DB
+	// readConn is a read-only connection used by list operations.  As
+	// the database uses write-ahead logging, it reads a snapshot of the
+	// database without waiting for the writers.
+	readConn *sql.DB
+	profile  sqliteProfile
+	runtime  *Runtime
 }
 
 const (
@@ -41,27 +43,49 @@ const (
 	sqliteOptionTXLock = "&_txlock=exclusive"
 	// Enforce case sensitivity for LIKE
 	sqliteOptionCaseSensitiveLike = "&_cslike=TRUE"
+	// Use write-ahead logging so that readers do not wait for writers
+	// (https://www.sqlite.org/wal.html).
+	sqliteOptionJournalMode = "&_journal_mode=WAL"
+	// Open the database read-only.
+	sqliteOptionReadOnly = "&mode=ro"
 
 	// Assembled sqlite options used when opening the database.
-	sqliteOptions = "db.sql?" +
+	sqliteOptions = sqliteStateFile + "?" +
 		sqliteOptionLocation +
 		sqliteOptionSynchronous +
 		sqliteOptionForeignKeys +
 		sqliteOptionTXLock +
-		sqliteOptionCaseSensitiveLike
+		sqliteOptionCaseSensitiveLike +
+		sqliteOptionJournalMode
+
+	// Assembled sqlite options used when opening the read-only connection
+	// to the database.
+	sqliteReadOptions = sqliteStateFile + "?" +
+		sqliteOptionLocation +
+		sqliteOptionCaseSensitiveLike +
+		sqliteOptionReadOnly
 )
 
+// sqliteStateFile is the name of the SQLite database file
+const sqliteStateFile = "db.sql"
+
+// sqliteStateDir returns the directory holding the SQLite database
+func sqliteStateDir(runtime *Runtime) string {
+	if runtime.storageConfig.TransientStore {
+		return runtime.storageConfig.RunRoot
+	}
+	if !runtime.storageSet.StaticDirSet {
+		return runtime.config.Engine.StaticDir
+	}
+	return runtime.storageConfig.GraphRoot
+}
+
 // NewSqliteState creates a new SQLite-backed state database.
 func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
 	logrus.Info("Using sqlite as database backend")
 	state := new(SQLiteState)
 
-	basePath := runtime.storageConfig.GraphRoot
-	if runtime.storageConfig.TransientStore {
-		basePath = runtime.storageConfig.RunRoot
-	} else if !runtime.storageSet.StaticDirSet {
-		basePath = runtime.config.Engine.StaticDir
-	}
+	basePath := sqliteStateDir(runtime)
 
 	// c/storage is set up *after* the DB - so even though we use the c/s
 	// root (or, for transient, runroot) dir, we need to make the dir
@@ -81,10 +105,7 @@ func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
 	}
 	sqliteOptionBusyTimeout := "&_busy_timeout=" + busyTimeout
 
-	conn, err := sql.Open("sqlite3", filepath.Join(basePath, sqliteOptions+sqliteOptionBusyTimeout))
-	if err != nil {
-		return nil, fmt.Errorf("initializing sqlite database: %w", err)
-	}
+	conn := sql.OpenDB(newSQLiteProfileConnector(filepath.Join(basePath, sqliteOptions+sqliteOptionBusyTimeout), &state.profile))
 	defer func() {
 		if defErr != nil {
 			if err := conn.Close(); err != nil {
@@ -97,7 +118,12 @@ func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
 		return nil, err
 	}
 
+	// The read-only connection needs a URI filename for the mode to be
+	// passed to SQLite.
+	readConn := sql.OpenDB(newSQLiteProfileConnector("file:"+filepath.Join(basePath, sqliteReadOptions+sqliteOptionBusyTimeout), &state.profile))
+
 	state.conn = conn
+	state.readConn = readConn
 	state.valid = true
 	state.runtime = runtime
 
@@ -106,6 +132,9 @@ func NewSqliteState(runtime *Runtime) (_ State, defErr error) {
 
 // Close closes the state and prevents further use
 func (s *SQLiteState) Close() error {
+	if err := s.readConn.Close(); err != nil {
+		return err
+	}
 	if err := s.conn.Close(); err != nil {
 		return err
 	}
@@ -828,7 +857,7 @@ func (s *SQLiteState) AllContainers(loadState bool) ([]*Container, error) {
 	ctrs := []*Container{}
 
 	if loadState {
-		rows, err := s.conn.Query("SELECT ContainerConfig.JSON, ContainerState.JSON AS StateJSON FROM ContainerConfig INNER JOIN ContainerState ON ContainerConfig.ID = ContainerState.ID;")
+		rows, err := s.readConn.Query("SELECT ContainerConfig.JSON, ContainerState.JSON AS StateJSON FROM ContainerConfig INNER JOIN ContainerState ON ContainerConfig.ID = ContainerState.ID;")
 		if err != nil {
 			return nil, fmt.Errorf("retrieving all containers from database: %w", err)
 		}
@@ -858,7 +887,7 @@ func (s *SQLiteState) AllContainers(loadState bool) ([]*Container, error) {
 			return nil, err
 		}
 	} else {
-		rows, err := s.conn.Query("SELECT JSON FROM ContainerConfig;")
+		rows, err := s.readConn.Query("SELECT JSON FROM ContainerConfig;")
 		if err != nil {
 			return nil, fmt.Errorf("retrieving all containers from database: %w", err)
 		}
@@ -1064,6 +1093,109 @@ func (s *SQLiteState) PruneContainerExitCodes() (defErr error) {
 	return nil
 }
 
+// AddIdempotencyKey adds the idempotency key of an API request to the
+// database, unless an unexpired key with the same value exists, which is
+// returned instead.  Expired keys are removed.
+func (s *SQLiteState) AddIdempotencyKey(key *IdempotencyKey) (_ *IdempotencyKey, defErr error) {
+	if len(key.Key) == 0 {
+		return nil, define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return nil, define.ErrDBClosed
+	}
+
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return nil, fmt.Errorf("marshalling idempotency key %q: %w", key.Key, err)
+	}
+
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("beginning transaction to add idempotency key: %w", err)
+	}
+	defer func() {
+		if defErr != nil {
+			if err := tx.Rollback(); err != nil {
+				logrus.Errorf("Rolling back transaction to add idempotency key: %v", err)
+			}
+		}
+	}()
+
+	now := time.Now()
+	if _, err := tx.Exec("DELETE FROM IdempotencyKey WHERE Expires <= ?;", now.Unix()); err != nil {
+		return nil, fmt.Errorf("removing expired idempotency keys: %w", err)
+	}
+
+	var existingJSON string
+	row := tx.QueryRow("SELECT JSON FROM IdempotencyKey WHERE Key=?;", key.Key)
+	switch err := row.Scan(&existingJSON); {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return nil, fmt.Errorf("retrieving idempotency key %q from DB: %w", key.Key, err)
+	default:
+		existing := new(IdempotencyKey)
+		if err := json.Unmarshal([]byte(existingJSON), existing); err != nil {
+			return nil, fmt.Errorf("unmarshalling idempotency key %q: %w", key.Key, err)
+		}
+		if !existing.expired(now) {
+			if err := tx.Commit(); err != nil {
+				return nil, fmt.Errorf("committing transaction to add idempotency key: %w", err)
+			}
+			return existing, nil
+		}
+	}
+
+	if _, err := tx.Exec("INSERT OR REPLACE INTO IdempotencyKey VALUES (?, ?, ?);", key.Key, key.Expires.Unix(), string(keyJSON)); err != nil {
+		return nil, fmt.Errorf("adding idempotency key %q: %w", key.Key, err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("committing transaction to add idempotency key: %w", err)
+	}
+
+	return nil, nil
+}
+
+// UpdateIdempotencyKey updates the response saved with the idempotency key.
+func (s *SQLiteState) UpdateIdempotencyKey(key *IdempotencyKey) error {
+	if len(key.Key) == 0 {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("marshalling idempotency key %q: %w", key.Key, err)
+	}
+
+	if _, err := s.conn.Exec("INSERT OR REPLACE INTO IdempotencyKey VALUES (?, ?, ?);", key.Key, key.Expires.Unix(), string(keyJSON)); err != nil {
+		return fmt.Errorf("updating idempotency key %q: %w", key.Key, err)
+	}
+
+	return nil
+}
+
+// RemoveIdempotencyKey removes the idempotency key from the database.
+func (s *SQLiteState) RemoveIdempotencyKey(key string) error {
+	if len(key) == 0 {
+		return define.ErrEmptyID
+	}
+
+	if !s.valid {
+		return define.ErrDBClosed
+	}
+
+	if _, err := s.conn.Exec("DELETE FROM IdempotencyKey WHERE Key=?;", key); err != nil {
+		return fmt.Errorf("removing idempotency key %q: %w", key, err)
+	}
+
+	return nil
+}
+
 // AddExecSession adds an exec session to the state.
 func (s *SQLiteState) AddExecSession(ctr *Container, session *ExecSession) (defErr error) {
 	if !s.valid {
@@ -1533,7 +1665,7 @@ func (s *SQLiteState) PodContainers(pod *Pod) ([]*Container, error) {
 		return nil, define.ErrPodRemoved
 	}
 
-	rows, err := s.conn.Query("SELECT JSON FROM ContainerConfig WHERE PodID=?;", pod.ID())
+	rows, err := s.readConn.Query("SELECT JSON FROM ContainerConfig WHERE PodID=?;", pod.ID())
 	if err != nil {
 		return nil, fmt.Errorf("retrieving containers of pod %s from database: %w", pod.ID(), err)
 	}
@@ -1893,7 +2025,7 @@ func (s *SQLiteState) AllPods() ([]*Pod, error) {
 	}
 
 	pods := []*Pod{}
-	rows, err := s.conn.Query("SELECT JSON FROM PodConfig;")
+	rows, err := s.readConn.Query("SELECT JSON FROM PodConfig;")
 	if err != nil {
 		return nil, fmt.Errorf("retrieving all pods from database: %w", err)
 	}
@@ -2131,7 +2263,7 @@ func (s *SQLiteState) AllVolumes() ([]*Volume, error) {
 		return nil, define.ErrDBClosed
 	}
 
-	rows, err := s.conn.Query("SELECT JSON FROM VolumeConfig;")
+	rows, err := s.readConn.Query("SELECT JSON FROM VolumeConfig;")
 	if err != nil {
 		return nil, fmt.Errorf("querying database for all volumes: %w", err)
 	}
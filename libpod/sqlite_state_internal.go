@@ -17,6 +17,15 @@ import (
 	_ "github.com/mattn/go-sqlite3"
 )
 
+// idempotencyKeyTable holds the idempotency keys of API requests, the table
+// was added in schema version 2.
+const idempotencyKeyTable = `
+        CREATE TABLE IF NOT EXISTS IdempotencyKey(
+                Key     TEXT    PRIMARY KEY NOT NULL,
+                Expires INTEGER NOT NULL,
+                JSON    TEXT    NOT NULL
+        );`
+
 func initSQLiteDB(conn *sql.DB) (defErr error) {
 	// Start with a transaction to avoid "database locked" errors.
 	// See https://github.com/mattn/go-sqlite3/issues/274#issuecomment-1429054597
@@ -90,8 +99,18 @@ func migrateSchemaIfNecessary(tx *sql.Tx) (bool, error) {
 	}
 
 	// Perform schema migration here, one version at a time.
+	if schemaVer < 2 {
+		if _, err := tx.Exec(idempotencyKeyTable); err != nil {
+			return false, fmt.Errorf("migrating database schema to version 2: creating table IdempotencyKey: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec("UPDATE DBConfig SET SchemaVersion=?;", schemaVersion); err != nil {
+		return false, fmt.Errorf("updating database schema version to %d: %w", schemaVersion, err)
+	}
+	logrus.Infof("Migrated database schema from version %d to %d", schemaVer, schemaVersion)
 
-	return false, nil
+	return true, nil
 }
 
 // Initialize all required tables for the SQLite state
@@ -219,6 +238,7 @@ func createSQLiteTables(tx *sql.Tx) error {
 		"PodState":             podState,
 		"VolumeConfig":         volumeConfig,
 		"VolumeState":          volumeState,
+		"IdempotencyKey":       idempotencyKeyTable,
 	}
 
 	for tblName, cmd := range tables {
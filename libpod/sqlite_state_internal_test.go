@@ -0,0 +1,40 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateSchemaToVersion2(t *testing.T) {
+	conn, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "db.sql"))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// A database of schema version 1 has no IdempotencyKey table.
+	tx, err := conn.Begin()
+	require.NoError(t, err)
+	require.NoError(t, createSQLiteTables(tx))
+	_, err = tx.Exec("DROP TABLE IdempotencyKey;")
+	require.NoError(t, err)
+	_, err = tx.Exec("INSERT INTO DBConfig VALUES (1, 1, 'linux', '/static', '/tmp', '/root', '/run', 'overlay', '/volumes');")
+	require.NoError(t, err)
+	require.NoError(t, tx.Commit())
+
+	require.NoError(t, initSQLiteDB(conn))
+
+	var version int
+	require.NoError(t, conn.QueryRow("SELECT SchemaVersion FROM DBConfig;").Scan(&version))
+	assert.Equal(t, schemaVersion, version)
+	var count int
+	require.NoError(t, conn.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type='table' AND name='IdempotencyKey';").Scan(&count))
+	assert.Equal(t, 1, count)
+
+	// Opening the migrated database again changes nothing.
+	require.NoError(t, initSQLiteDB(conn))
+}
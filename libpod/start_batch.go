@@ -0,0 +1,89 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.podman.io/common/libimage"
+	"go.podman.io/common/pkg/hooks"
+)
+
+// startBatchKey is the key of the start batch in a context.
+type startBatchKey struct{}
+
+// startBatch holds the state shared by containers started together.  The
+// image store lookups and the loading of the OCI hooks done while generating
+// the specs of the containers are done once for the whole batch instead of
+// once per container.
+type startBatch struct {
+	lock   sync.Mutex
+	images map[string]func() (*libimage.Image, error)
+	hooks  map[string]func() (*hooks.Manager, error)
+}
+
+// WithStartBatch returns a copy of ctx under which the containers started
+// share the image lookups and OCI hooks loaded for any of them.  It is meant
+// for starting many containers at once, e.g. all containers of a pod; images
+// removed or hooks changed while the containers are started are not noticed.
+func WithStartBatch(ctx context.Context) context.Context {
+	if startBatchFromContext(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, startBatchKey{}, &startBatch{
+		images: make(map[string]func() (*libimage.Image, error)),
+		hooks:  make(map[string]func() (*hooks.Manager, error)),
+	})
+}
+
+// startBatchFromContext returns the start batch of ctx, nil if there is none.
+func startBatchFromContext(ctx context.Context) *startBatch {
+	b, _ := ctx.Value(startBatchKey{}).(*startBatch)
+	return b
+}
+
+// lookupImage looks up the image by name or ID in the local storage.  Under
+// a start batch, the image is looked up once for all containers.
+func (r *Runtime) lookupImage(ctx context.Context, name string) (*libimage.Image, error) {
+	lookup := func() (*libimage.Image, error) {
+		img, _, err := r.libimageRuntime.LookupImage(name, nil)
+		return img, err
+	}
+	b := startBatchFromContext(ctx)
+	if b == nil {
+		return lookup()
+	}
+
+	b.lock.Lock()
+	cached, ok := b.images[name]
+	if !ok {
+		cached = sync.OnceValues(lookup)
+		b.images[name] = cached
+	}
+	b.lock.Unlock()
+	return cached()
+}
+
+// newHooksManager loads the OCI hooks of the directories.  Under a start
+// batch, the hooks are loaded once for all containers.
+func (r *Runtime) newHooksManager(ctx context.Context, directories []string) (*hooks.Manager, error) {
+	load := func() (*hooks.Manager, error) {
+		return hooks.New(ctx, directories, []string{"precreate", "poststop"})
+	}
+	b := startBatchFromContext(ctx)
+	if b == nil {
+		return load()
+	}
+
+	key := strings.Join(directories, "\x00")
+	b.lock.Lock()
+	cached, ok := b.hooks[key]
+	if !ok {
+		cached = sync.OnceValues(load)
+		b.hooks[key] = cached
+	}
+	b.lock.Unlock()
+	return cached()
+}
@@ -0,0 +1,44 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithStartBatch(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, startBatchFromContext(ctx))
+
+	batchCtx := WithStartBatch(ctx)
+	batch := startBatchFromContext(batchCtx)
+	require.NotNil(t, batch)
+	// Nested batches are the same batch.
+	assert.Same(t, batch, startBatchFromContext(WithStartBatch(batchCtx)))
+}
+
+func TestStartBatchHooks(t *testing.T) {
+	r := &Runtime{}
+	dirs := []string{t.TempDir()}
+
+	ctx := context.Background()
+	m1, err := r.newHooksManager(ctx, dirs)
+	require.NoError(t, err)
+	m2, err := r.newHooksManager(ctx, dirs)
+	require.NoError(t, err)
+	assert.NotSame(t, m1, m2)
+
+	batchCtx := WithStartBatch(ctx)
+	m1, err = r.newHooksManager(batchCtx, dirs)
+	require.NoError(t, err)
+	m2, err = r.newHooksManager(batchCtx, dirs)
+	require.NoError(t, err)
+	assert.Same(t, m1, m2)
+	m3, err := r.newHooksManager(batchCtx, []string{t.TempDir()})
+	require.NoError(t, err)
+	assert.NotSame(t, m1, m3)
+}
@@ -116,6 +116,15 @@ type State interface { //nolint:interfacebloat
 	// Remove exit codes older than 5 minutes.
 	PruneContainerExitCodes() error
 
+	// Add the idempotency key of an API request to the database, unless
+	// an unexpired key with the same value exists.  The existing key is
+	// returned in that case, nil otherwise.  Expired keys are removed.
+	AddIdempotencyKey(key *IdempotencyKey) (*IdempotencyKey, error)
+	// Update the response saved with the idempotency key.
+	UpdateIdempotencyKey(key *IdempotencyKey) error
+	// Remove the idempotency key from the database.
+	RemoveIdempotencyKey(key string) error
+
 	// Add creates a reference to an exec session in the database.
 	// The container the exec session is attached to will be recorded.
 	// The container state will not be modified.
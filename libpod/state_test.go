@@ -2631,3 +2631,72 @@ func TestConvertPortMapping(t *testing.T) {
 		testContainersEqual(t, retrievedCtr, testCtr, true)
 	})
 }
+
+func testIdempotencyKeys(t *testing.T, state State) {
+	now := time.Now()
+	key := &IdempotencyKey{
+		Key:         "retry-1",
+		Operation:   "volume create",
+		RequestHash: "hash",
+		Created:     now,
+		Expires:     now.Add(time.Hour),
+	}
+	existing, err := state.AddIdempotencyKey(key)
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// the key is reserved while the first request is processed
+	existing, err = state.AddIdempotencyKey(&IdempotencyKey{Key: "retry-1", Created: now, Expires: now.Add(time.Hour)})
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.True(t, existing.Pending())
+	assert.Equal(t, "hash", existing.RequestHash)
+
+	key.StatusCode = 201
+	key.ContentType = "application/json"
+	key.Body = []byte(`{"Name":"vol"}`)
+	require.NoError(t, state.UpdateIdempotencyKey(key))
+	existing, err = state.AddIdempotencyKey(&IdempotencyKey{Key: "retry-1", Created: now, Expires: now.Add(time.Hour)})
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.False(t, existing.Pending())
+	assert.Equal(t, 201, existing.StatusCode)
+	assert.Equal(t, key.Body, existing.Body)
+
+	// expired keys and keys of abandoned requests are replaced
+	expired := &IdempotencyKey{Key: "expired", Created: now.Add(-2 * time.Hour), Expires: now.Add(-time.Hour), StatusCode: 201}
+	require.NoError(t, state.UpdateIdempotencyKey(expired))
+	existing, err = state.AddIdempotencyKey(&IdempotencyKey{Key: "expired", Created: now, Expires: now.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+	abandoned := &IdempotencyKey{Key: "abandoned", Created: now.Add(-time.Hour), Expires: now.Add(time.Hour)}
+	require.NoError(t, state.UpdateIdempotencyKey(abandoned))
+	existing, err = state.AddIdempotencyKey(&IdempotencyKey{Key: "abandoned", Created: now, Expires: now.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// removed keys can be reused
+	require.NoError(t, state.RemoveIdempotencyKey("retry-1"))
+	existing, err = state.AddIdempotencyKey(&IdempotencyKey{Key: "retry-1", Created: now, Expires: now.Add(time.Hour)})
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	_, err = state.AddIdempotencyKey(&IdempotencyKey{})
+	assert.ErrorIs(t, err, define.ErrEmptyID)
+}
+
+func TestIdempotencyKeys(t *testing.T) {
+	runForAllStates(t, func(t *testing.T, state State, _ lock.Manager) {
+		testIdempotencyKeys(t, state)
+	})
+
+	t.Run("sqlite", func(t *testing.T) {
+		runtime := new(Runtime)
+		runtime.config = new(config.Config)
+		runtime.config.Engine.StaticDir = t.TempDir()
+		state, err := NewSqliteState(runtime)
+		require.NoError(t, err)
+		defer state.Close()
+		testIdempotencyKeys(t, state)
+	})
+}
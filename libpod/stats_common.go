@@ -12,10 +12,6 @@ import (
 // The previousStats is used to correctly calculate cpu percentages. You
 // should pass nil if there is no previous stat for this container.
 func (c *Container) GetContainerStats(previousStats *define.ContainerStats) (*define.ContainerStats, error) {
-	stats := new(define.ContainerStats)
-	stats.ContainerID = c.ID()
-	stats.Name = c.Name()
-
 	if c.config.NoCgroups {
 		return nil, fmt.Errorf("cannot run top on container %s as it did not create a cgroup: %w", c.ID(), define.ErrNoCgroups)
 	}
@@ -24,10 +20,23 @@ func (c *Container) GetContainerStats(previousStats *define.ContainerStats) (*de
 		c.lock.Lock()
 		defer c.lock.Unlock()
 		if err := c.syncContainer(); err != nil {
+			stats := new(define.ContainerStats)
+			stats.ContainerID = c.ID()
+			stats.Name = c.Name()
 			return stats, err
 		}
 	}
 
+	return c.getContainerStats(previousStats)
+}
+
+// getContainerStats gets the running stats of the container.
+// Must be called with the container lock held.
+func (c *Container) getContainerStats(previousStats *define.ContainerStats) (*define.ContainerStats, error) {
+	stats := new(define.ContainerStats)
+	stats.ContainerID = c.ID()
+	stats.Name = c.Name()
+
 	// returns stats with the fields' default values respective of their type
 	if c.state.State != define.ContainerStateRunning && c.state.State != define.ContainerStatePaused {
 		return stats, nil
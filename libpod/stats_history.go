@@ -0,0 +1,300 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+)
+
+const (
+	// statsHistoryFile is the name of the file in the static directory of
+	// the container storing its stats history.
+	statsHistoryFile = "stats-history"
+	// statsHistoryMagic identifies the format of the stats history file.
+	statsHistoryMagic = "PSH1"
+	// statsHistoryPeriod is the period of time the stats history keeps
+	// samples for.
+	statsHistoryPeriod = 24 * time.Hour
+	// statsHistoryMinCapacity and statsHistoryMaxCapacity bound the number
+	// of samples kept in the stats history.
+	statsHistoryMinCapacity = 60
+	statsHistoryMaxCapacity = 8640
+)
+
+// statsHistoryHeader is the header of the stats history file, which is
+// followed by a ring buffer of Capacity records.
+type statsHistoryHeader struct {
+	Magic [4]byte
+	// Capacity is the number of records of the ring buffer.
+	Capacity uint32
+	// Next is the index of the record written next.
+	Next uint32
+	// Count is the number of valid records.
+	Count uint32
+}
+
+// statsHistoryRecord is a sample of the stats history as stored on disk.
+type statsHistoryRecord struct {
+	Time        int64
+	CPUNano     uint64
+	CPU         float64
+	MemUsage    uint64
+	MemLimit    uint64
+	NetInput    uint64
+	NetOutput   uint64
+	BlockInput  uint64
+	BlockOutput uint64
+	PIDs        uint64
+}
+
+var (
+	statsHistoryHeaderSize = int64(binary.Size(statsHistoryHeader{}))
+	statsHistoryRecordSize = int64(binary.Size(statsHistoryRecord{}))
+)
+
+// statsHistory is a stats history file with a fixed number of records.  Once
+// the file is full, the oldest records are overwritten.
+type statsHistory struct {
+	file   *os.File
+	header statsHistoryHeader
+}
+
+// statsHistoryCapacity returns the number of samples to keep in the stats
+// history to cover statsHistoryPeriod when recording at the interval.
+func statsHistoryCapacity(interval time.Duration) uint32 {
+	if interval <= 0 {
+		return statsHistoryMaxCapacity / 6
+	}
+	return uint32(min(max(int64(statsHistoryPeriod/interval), statsHistoryMinCapacity), statsHistoryMaxCapacity))
+}
+
+// createStatsHistory opens the stats history file at the path, creating it
+// with the capacity if it does not exist.
+func createStatsHistory(path string, capacity uint32) (*statsHistory, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	h := &statsHistory{file: f}
+	err = binary.Read(io.NewSectionReader(f, 0, statsHistoryHeaderSize), binary.LittleEndian, &h.header)
+	switch {
+	case errors.Is(err, io.EOF):
+		copy(h.header.Magic[:], statsHistoryMagic)
+		h.header.Capacity = capacity
+		err = h.writeHeader()
+	case err == nil:
+		err = h.checkHeader()
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("stats history %s: %w", path, err)
+	}
+	return h, nil
+}
+
+// openStatsHistory opens the stats history file at the path for reading.  If
+// the file does not exist, an error wrapping fs.ErrNotExist is returned.
+func openStatsHistory(path string) (*statsHistory, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	h := &statsHistory{file: f}
+	err = binary.Read(io.NewSectionReader(f, 0, statsHistoryHeaderSize), binary.LittleEndian, &h.header)
+	if err == nil {
+		err = h.checkHeader()
+	}
+	if err != nil {
+		f.Close()
+		return nil, fmt.Errorf("stats history %s: %w", path, err)
+	}
+	return h, nil
+}
+
+func (h *statsHistory) checkHeader() error {
+	if string(h.header.Magic[:]) != statsHistoryMagic || h.header.Capacity == 0 ||
+		h.header.Next >= h.header.Capacity || h.header.Count > h.header.Capacity {
+		return errors.New("invalid stats history file")
+	}
+	return nil
+}
+
+func (h *statsHistory) writeHeader() error {
+	return binary.Write(io.NewOffsetWriter(h.file, 0), binary.LittleEndian, &h.header)
+}
+
+func (h *statsHistory) recordOffset(index uint32) int64 {
+	return statsHistoryHeaderSize + int64(index)*statsHistoryRecordSize
+}
+
+// Close closes the stats history file.
+func (h *statsHistory) Close() error {
+	return h.file.Close()
+}
+
+// append adds the record to the stats history, overwriting the oldest record
+// if the stats history is full.
+func (h *statsHistory) append(record *statsHistoryRecord) error {
+	if err := binary.Write(io.NewOffsetWriter(h.file, h.recordOffset(h.header.Next)), binary.LittleEndian, record); err != nil {
+		return err
+	}
+	h.header.Next = (h.header.Next + 1) % h.header.Capacity
+	if h.header.Count < h.header.Capacity {
+		h.header.Count++
+	}
+	return h.writeHeader()
+}
+
+// records returns the records of the stats history from the oldest to the
+// newest.
+func (h *statsHistory) records() ([]statsHistoryRecord, error) {
+	records := make([]statsHistoryRecord, h.header.Count)
+	first := (h.header.Next + h.header.Capacity - h.header.Count) % h.header.Capacity
+	for i := range records {
+		index := (first + uint32(i)) % h.header.Capacity
+		r := io.NewSectionReader(h.file, h.recordOffset(index), statsHistoryRecordSize)
+		if err := binary.Read(r, binary.LittleEndian, &records[i]); err != nil {
+			return nil, err
+		}
+	}
+	return records, nil
+}
+
+// last returns the newest record of the stats history, or nil if it is empty.
+func (h *statsHistory) last() (*statsHistoryRecord, error) {
+	if h.header.Count == 0 {
+		return nil, nil
+	}
+	record := new(statsHistoryRecord)
+	index := (h.header.Next + h.header.Capacity - 1) % h.header.Capacity
+	r := io.NewSectionReader(h.file, h.recordOffset(index), statsHistoryRecordSize)
+	if err := binary.Read(r, binary.LittleEndian, record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func (c *Container) statsHistoryPath() string {
+	return filepath.Join(c.config.StaticDir, statsHistoryFile)
+}
+
+// RecordStats records a sample of the resource usage of the container in its
+// stats history.  Nothing is recorded if the container is not running.
+func (c *Container) RecordStats() error {
+	if c.config.NoCgroups {
+		return fmt.Errorf("cannot record stats of container %s as it did not create a cgroup: %w", c.ID(), define.ErrNoCgroups)
+	}
+
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return err
+		}
+	}
+
+	if !c.ensureState(define.ContainerStateRunning, define.ContainerStatePaused) {
+		return nil
+	}
+
+	history, err := createStatsHistory(c.statsHistoryPath(), statsHistoryCapacity(c.config.StatsHistoryInterval))
+	if err != nil {
+		return err
+	}
+	defer history.Close()
+
+	// The CPU usage is computed since the previous sample, unless it was
+	// recorded in a previous run of the container.
+	var previousStats *define.ContainerStats
+	last, err := history.last()
+	if err != nil {
+		return err
+	}
+	if last != nil && last.Time > c.state.StartedTime.UnixNano() {
+		previousStats = &define.ContainerStats{
+			CPUNano:    last.CPUNano,
+			SystemNano: uint64(last.Time),
+			Duration:   last.CPUNano,
+		}
+	}
+
+	stats, err := c.getContainerStats(previousStats)
+	if err != nil {
+		return err
+	}
+	record := &statsHistoryRecord{
+		Time:        int64(stats.SystemNano),
+		CPUNano:     stats.CPUNano,
+		CPU:         stats.CPU,
+		MemUsage:    stats.MemUsage,
+		MemLimit:    stats.MemLimit,
+		BlockInput:  stats.BlockInput,
+		BlockOutput: stats.BlockOutput,
+		PIDs:        stats.PIDs,
+	}
+	for _, net := range stats.Network {
+		record.NetInput += net.RxBytes
+		record.NetOutput += net.TxBytes
+	}
+	return history.append(record)
+}
+
+// StatsHistory returns the samples of the stats history of the container
+// recorded between since and until.  A zero since or until does not bound the
+// samples.
+func (c *Container) StatsHistory(since, until time.Time) ([]define.ContainerStatsSample, error) {
+	if !c.batched {
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		if err := c.syncContainer(); err != nil {
+			return nil, err
+		}
+	}
+
+	samples := []define.ContainerStatsSample{}
+	history, err := openStatsHistory(c.statsHistoryPath())
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return samples, nil
+		}
+		return nil, err
+	}
+	defer history.Close()
+
+	records, err := history.records()
+	if err != nil {
+		return nil, fmt.Errorf("reading stats history of container %s: %w", c.ID(), err)
+	}
+	for _, record := range records {
+		t := time.Unix(0, record.Time)
+		if (!since.IsZero() && t.Before(since)) || (!until.IsZero() && t.After(until)) {
+			continue
+		}
+		sample := define.ContainerStatsSample{
+			Time:        t,
+			CPU:         record.CPU,
+			CPUNano:     record.CPUNano,
+			MemUsage:    record.MemUsage,
+			MemLimit:    record.MemLimit,
+			NetInput:    record.NetInput,
+			NetOutput:   record.NetOutput,
+			BlockInput:  record.BlockInput,
+			BlockOutput: record.BlockOutput,
+			PIDs:        record.PIDs,
+		}
+		if record.MemLimit > 0 {
+			sample.MemPerc = float64(record.MemUsage) / float64(record.MemLimit) * 100
+		}
+		samples = append(samples, sample)
+	}
+	return samples, nil
+}
@@ -0,0 +1,105 @@
+//go:build !remote && systemd
+
+package libpod
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/pkg/errorhandling"
+	"github.com/dmikushin/podman-shared/pkg/rootless"
+	"github.com/dmikushin/podman-shared/pkg/systemd"
+	"github.com/sirupsen/logrus"
+	systemdCommon "go.podman.io/common/pkg/systemd"
+)
+
+// statsHistoryUnitName is the name of the systemd units recording the stats
+// history of the container.
+func (c *Container) statsHistoryUnitName() string {
+	return c.ID() + "-stats"
+}
+
+func (c *Container) disableStatsHistorySystemd() bool {
+	return c.config.StatsHistoryInterval == 0 || !systemdCommon.RunsOnSystemd()
+}
+
+// createStatsHistoryTimer creates and starts a systemd timer recording the
+// stats history of the container at its interval.
+func (c *Container) createStatsHistoryTimer() error {
+	if c.disableStatsHistorySystemd() {
+		return nil
+	}
+	// Remove the units of a previous run the cleanup did not remove.
+	if err := c.removeStatsHistoryTimer(context.Background()); err != nil {
+		logrus.Debugf("Removing stats history timer of container %s: %v", c.ID(), err)
+	}
+
+	podman, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to get path for podman for a stats history timer: %w", err)
+	}
+
+	var cmd = []string{"--property", "LogLevelMax=notice"}
+	if rootless.IsRootless() {
+		cmd = append(cmd, "--user")
+	}
+	path := os.Getenv("PATH")
+	if path != "" {
+		cmd = append(cmd, "--setenv=PATH="+path)
+	}
+
+	interval := c.config.StatsHistoryInterval.String()
+	cmd = append(cmd, "--unit", c.statsHistoryUnitName(), "--on-active="+interval, "--on-unit-inactive="+interval, "--timer-property=AccuracySec=1s", podman)
+
+	if logrus.IsLevelEnabled(logrus.DebugLevel) {
+		cmd = append(cmd, "--log-level=debug", "--syslog")
+	}
+
+	cmd = append(cmd, "container", "record-stats", c.ID())
+
+	logrus.Debugf("creating systemd-transient files: %s %s", "systemd-run", cmd)
+	systemdRun := exec.Command("systemd-run", cmd...)
+	if output, err := systemdRun.CombinedOutput(); err != nil {
+		exitError := &exec.ExitError{}
+		if errors.As(err, &exitError) {
+			return fmt.Errorf("systemd-run failed: %w: output: %s", err, strings.TrimSpace(string(output)))
+		}
+		return fmt.Errorf("failed to execute systemd-run: %w", err)
+	}
+	return nil
+}
+
+// removeStatsHistoryTimer stops and removes the systemd timer recording the
+// stats history of the container.
+func (c *Container) removeStatsHistoryTimer(ctx context.Context) error {
+	if c.disableStatsHistorySystemd() {
+		return nil
+	}
+	conn, err := systemd.ConnectToDBUS()
+	if err != nil {
+		return fmt.Errorf("unable to get systemd connection to remove stats history timer: %w", err)
+	}
+	defer conn.Close()
+
+	stopErrors := []error{}
+	unitName := c.statsHistoryUnitName()
+	for _, unit := range []string{unitName + ".timer", unitName + ".service"} {
+		stopChan := make(chan string)
+		if _, err := conn.StopUnitContext(ctx, unit, "ignore-dependencies", stopChan); err != nil {
+			if !strings.HasSuffix(err.Error(), " not loaded.") {
+				stopErrors = append(stopErrors, fmt.Errorf("removing stats history unit %q: %w", unit, err))
+			}
+		} else if err := systemdOpSuccessful(stopChan); err != nil {
+			stopErrors = append(stopErrors, fmt.Errorf("stopping systemd stats history unit %q: %w", unit, err))
+		}
+	}
+	if err := conn.ResetFailedUnitContext(ctx, unitName+".service"); err != nil {
+		logrus.Debugf("Failed to reset unit file: %q", err)
+	}
+
+	return errorhandling.JoinErrors(stopErrors)
+}
@@ -0,0 +1,70 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsHistoryCapacity(t *testing.T) {
+	assert.Equal(t, uint32(1440), statsHistoryCapacity(0))
+	assert.Equal(t, uint32(8640), statsHistoryCapacity(time.Second))
+	assert.Equal(t, uint32(1440), statsHistoryCapacity(time.Minute))
+	assert.Equal(t, uint32(60), statsHistoryCapacity(time.Hour))
+}
+
+func TestStatsHistoryRing(t *testing.T) {
+	path := filepath.Join(t.TempDir(), statsHistoryFile)
+
+	_, err := openStatsHistory(path)
+	assert.ErrorIs(t, err, fs.ErrNotExist)
+
+	history, err := createStatsHistory(path, 3)
+	require.NoError(t, err)
+	last, err := history.last()
+	require.NoError(t, err)
+	assert.Nil(t, last)
+	for i := range int64(5) {
+		require.NoError(t, history.append(&statsHistoryRecord{Time: i, PIDs: uint64(i)}))
+	}
+	require.NoError(t, history.Close())
+
+	// The oldest records are overwritten and the capacity of an existing
+	// history is kept.
+	history, err = createStatsHistory(path, 10)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), history.header.Capacity)
+	require.NoError(t, history.append(&statsHistoryRecord{Time: 5, PIDs: 5}))
+	require.NoError(t, history.Close())
+
+	history, err = openStatsHistory(path)
+	require.NoError(t, err)
+	defer history.Close()
+	records, err := history.records()
+	require.NoError(t, err)
+	times := []int64{}
+	for _, record := range records {
+		times = append(times, record.Time)
+	}
+	assert.Equal(t, []int64{3, 4, 5}, times)
+	last, err = history.last()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), last.PIDs)
+}
+
+func TestStatsHistoryInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), statsHistoryFile)
+	require.NoError(t, os.WriteFile(path, []byte("not a stats history file"), 0o600))
+
+	_, err := openStatsHistory(path)
+	assert.ErrorContains(t, err, "invalid stats history file")
+	_, err = createStatsHistory(path, 3)
+	assert.ErrorContains(t, err, "invalid stats history file")
+}
@@ -0,0 +1,19 @@
+//go:build !remote && (!systemd || !linux)
+
+package libpod
+
+import (
+	"context"
+)
+
+// createStatsHistoryTimer creates and starts a systemd timer recording the
+// stats history of the container at its interval.
+func (c *Container) createStatsHistoryTimer() error {
+	return nil
+}
+
+// removeStatsHistoryTimer stops and removes the systemd timer recording the
+// stats history of the container.
+func (c *Container) removeStatsHistoryTimer(_ context.Context) error {
+	return nil
+}
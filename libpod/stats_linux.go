@@ -5,12 +5,16 @@ package libpod
 import (
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
 	runccgroup "github.com/opencontainers/cgroups"
+	"github.com/sirupsen/logrus"
 	"go.podman.io/common/pkg/cgroups"
 	"golang.org/x/sys/unix"
 )
@@ -61,6 +65,13 @@ func (c *Container) getPlatformContainerStats(stats *define.ContainerStats, prev
 	stats.MemUsage = cgroupStats.MemoryStats.Usage.Usage
 	stats.MemLimit = c.getMemLimit(cgroupStats.MemoryStats.Usage.Limit)
 	stats.MemPerc = (float64(stats.MemUsage) / float64(stats.MemLimit)) * 100
+	memStat, err := readMemoryStat(cgroupPath)
+	if err != nil {
+		// The breakdown is informative, do not fail the stats for it.
+		logrus.Debugf("Reading memory.stat of container %s: %v", c.ID(), err)
+	} else {
+		setMemoryBreakdown(stats, memStat)
+	}
 	stats.PIDs = 0
 	if conState == define.ContainerStateRunning || conState == define.ContainerStatePaused {
 		stats.PIDs = cgroupStats.PidsStats.Current
@@ -74,6 +85,55 @@ func (c *Container) getPlatformContainerStats(stats *define.ContainerStats, prev
 	return nil
 }
 
+// readMemoryStat reads the memory.stat file of the memory cgroup at path.
+func readMemoryStat(path string) (map[string]uint64, error) {
+	cgroupv2, _ := cgroups.IsCgroup2UnifiedMode()
+	statPath := filepath.Join("/sys/fs/cgroup/memory", path, "memory.stat")
+	if cgroupv2 {
+		statPath = filepath.Join("/sys/fs/cgroup", path, "memory.stat")
+	}
+	content, err := os.ReadFile(statPath)
+	if err != nil {
+		return nil, err
+	}
+	return parseMemoryStat(string(content)), nil
+}
+
+// parseMemoryStat parses the "key value" lines of a memory.stat file.
+func parseMemoryStat(content string) map[string]uint64 {
+	memStat := make(map[string]uint64)
+	for line := range strings.Lines(content) {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), " ")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.ParseUint(value, 10, 64); err == nil {
+			memStat[key] = n
+		}
+	}
+	return memStat
+}
+
+// setMemoryBreakdown sets the memory breakdown of stats from the memory.stat
+// entries of the container cgroup.  The cgroup v1 entries of the whole
+// hierarchy are prefixed with total_.
+func setMemoryBreakdown(stats *define.ContainerStats, memStat map[string]uint64) {
+	if anon, ok := memStat["anon"]; ok {
+		stats.MemAnon = anon
+		stats.MemFile = memStat["file"]
+		stats.MemSlab = memStat["slab"]
+		if stats.MemSlab == 0 {
+			// The slab entry only exists since Linux 5.9.
+			stats.MemSlab = memStat["slab_reclaimable"] + memStat["slab_unreclaimable"]
+		}
+		stats.MemWorkingSet = anon + memStat["active_file"]
+		return
+	}
+	stats.MemAnon = memStat["total_rss"]
+	stats.MemFile = memStat["total_cache"]
+	stats.MemWorkingSet = stats.MemAnon + memStat["total_active_file"]
+}
+
 // getMemLimit returns the memory limit for a container
 func (c *Container) getMemLimit(memLimit uint64) uint64 {
 	si := &syscall.Sysinfo_t{}
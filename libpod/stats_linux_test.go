@@ -0,0 +1,38 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMemoryBreakdown(t *testing.T) {
+	stats := &define.ContainerStats{}
+	setMemoryBreakdown(stats, parseMemoryStat(`anon 4096000
+file 8192000
+kernel 512000
+slab 204800
+active_file 1024000
+inactive_file 7168000
+`))
+	assert.Equal(t, uint64(4096000), stats.MemAnon)
+	assert.Equal(t, uint64(8192000), stats.MemFile)
+	assert.Equal(t, uint64(204800), stats.MemSlab)
+	assert.Equal(t, uint64(5120000), stats.MemWorkingSet)
+
+	// Before Linux 5.9
+	stats = &define.ContainerStats{}
+	setMemoryBreakdown(stats, parseMemoryStat("anon 100\nslab_reclaimable 20\nslab_unreclaimable 30\n"))
+	assert.Equal(t, uint64(50), stats.MemSlab)
+
+	// cgroup v1
+	stats = &define.ContainerStats{}
+	setMemoryBreakdown(stats, parseMemoryStat("cache 1\nrss 2\ntotal_cache 300\ntotal_rss 200\ntotal_active_file 100\n"))
+	assert.Equal(t, uint64(200), stats.MemAnon)
+	assert.Equal(t, uint64(300), stats.MemFile)
+	assert.Equal(t, uint64(0), stats.MemSlab)
+	assert.Equal(t, uint64(300), stats.MemWorkingSet)
+}
@@ -0,0 +1,19 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"go.podman.io/storage/types"
+)
+
+// SubIDStatus is not supported on FreeBSD, which has no user namespaces.
+func (r *Runtime) SubIDStatus() (*define.SubIDStatus, error) {
+	return nil, errors.New("subordinate IDs are not supported on FreeBSD")
+}
+
+func (r *Runtime) explainAutoUserNsError(err error, _ *types.AutoUserNsOptions) error {
+	return err
+}
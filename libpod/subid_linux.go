@@ -0,0 +1,152 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/rootless"
+	"github.com/dmikushin/podman-shared/pkg/subid"
+	"go.podman.io/storage"
+	"go.podman.io/storage/pkg/idtools"
+	"go.podman.io/storage/types"
+)
+
+// autoUserNsUser returns the name and numeric ID of the user whose
+// subordinate IDs --userns=auto allocates user namespaces from, like
+// c/storage looks it up.
+func (r *Runtime) autoUserNsUser() (string, string) {
+	if rootless.IsRootless() {
+		id := strconv.Itoa(rootless.GetRootlessUID())
+		name := os.Getenv("USER")
+		if name == "" {
+			if u, err := user.LookupId(id); err == nil {
+				name = u.Username
+			}
+		}
+		return name, id
+	}
+	name := r.storageConfig.RootAutoNsUser
+	if name == "" {
+		name = storage.RootAutoUserNsUser
+	}
+	var id string
+	if u, err := user.Lookup(name); err == nil {
+		id = u.Uid
+	}
+	return name, id
+}
+
+func (r *Runtime) autoUserNsMinSize() uint32 {
+	if r.storageConfig.AutoNsMinSize > 0 {
+		return r.storageConfig.AutoNsMinSize
+	}
+	return storage.AutoUserNsMinSize
+}
+
+func (r *Runtime) autoUserNsMaxSize() uint32 {
+	if r.storageConfig.AutoNsMaxSize > 0 {
+		return r.storageConfig.AutoNsMaxSize
+	}
+	return storage.AutoUserNsMaxSize
+}
+
+// subIDPool fills the size and usage of the pool from the ID mappings of the
+// containers in storage, and returns whether any of them uses the pool.
+func subIDPool(pool *define.SubIDPool, mappings [][]idtools.IDMap) []bool {
+	pool.Size = subid.Count(pool.Ranges)
+	// rootless, c/storage allocates from the IDs of the rootless user
+	// namespace, where the pool is mapped from 1
+	storeRanges := pool.Ranges
+	if rootless.IsRootless() {
+		storeRanges = []subid.Range{{Start: 1, Size: pool.Size}}
+	}
+	var used []subid.Range
+	users := make([]bool, len(mappings))
+	for i, idMap := range mappings {
+		var ranges []subid.Range
+		for _, m := range idMap {
+			ranges = append(ranges, subid.Range{Start: m.HostID, Size: m.Size})
+		}
+		inPool := subid.Intersect(storeRanges, ranges)
+		users[i] = len(inPool) > 0
+		used = append(used, inPool...)
+	}
+	pool.Used = subid.Count(used)
+	pool.Free = pool.Size - pool.Used
+	return users
+}
+
+// SubIDStatus returns the subordinate ID pools --userns=auto allocates user
+// namespaces from, and how much of them the containers use.
+func (r *Runtime) SubIDStatus() (*define.SubIDStatus, error) {
+	if !r.valid {
+		return nil, define.ErrRuntimeStopped
+	}
+	name, id := r.autoUserNsUser()
+	status := &define.SubIDStatus{
+		User:              name,
+		AutoUserNsMinSize: r.autoUserNsMinSize(),
+		AutoUserNsMaxSize: r.autoUserNsMaxSize(),
+	}
+	uidEntries, err := subid.ReadFile(subid.UIDFile)
+	if err != nil {
+		return nil, err
+	}
+	gidEntries, err := subid.ReadFile(subid.GIDFile)
+	if err != nil {
+		return nil, err
+	}
+	status.UIDs.Ranges = subid.Ranges(uidEntries, name, id)
+	status.GIDs.Ranges = subid.Ranges(gidEntries, name, id)
+
+	containers, err := r.store.Containers()
+	if err != nil {
+		return nil, err
+	}
+	uidMaps := make([][]idtools.IDMap, 0, len(containers))
+	gidMaps := make([][]idtools.IDMap, 0, len(containers))
+	for _, ctr := range containers {
+		uidMaps = append(uidMaps, ctr.UIDMap)
+		gidMaps = append(gidMaps, ctr.GIDMap)
+	}
+	uidUsers := subIDPool(&status.UIDs, uidMaps)
+	gidUsers := subIDPool(&status.GIDs, gidMaps)
+	for i := range containers {
+		if uidUsers[i] || gidUsers[i] {
+			status.Containers++
+		}
+	}
+	return status, nil
+}
+
+// explainAutoUserNsError adds to an error of c/storage allocating a user
+// namespace with --userns=auto why the namespace could not be allocated.
+func (r *Runtime) explainAutoUserNsError(err error, opts *types.AutoUserNsOptions) error {
+	if strings.Contains(err.Error(), "bigger than the maximum value allowed with userns=auto") {
+		return fmt.Errorf("%w: raise auto_userns_max_size in storage.conf or set the size with --userns=auto:size=SIZE", err)
+	}
+	if !errors.Is(err, types.ErrNoAvailableIDs) {
+		return err
+	}
+	status, statusErr := r.SubIDStatus()
+	if statusErr != nil {
+		return fmt.Errorf("%w (reading the subordinate ID pools: %v)", err, statusErr)
+	}
+	if status.UIDs.Size == 0 || status.GIDs.Size == 0 {
+		return fmt.Errorf("%w: user %q has no subordinate UIDs in %s or GIDs in %s, add some with podman system subid expand", err, status.User, subid.UIDFile, subid.GIDFile)
+	}
+
+	needed := fmt.Sprintf("%d IDs", opts.Size)
+	if opts.Size == 0 {
+		needed = fmt.Sprintf("at least %d IDs, or as many as the highest UID or GID of the image requires", max(status.AutoUserNsMinSize, opts.InitialSize))
+	}
+	return fmt.Errorf("%w: --userns=auto needs %s, but only %d of the %d subordinate UIDs and %d of the %d subordinate GIDs of user %q are free, %d containers use the others: remove unused containers or grow the pools with podman system subid expand",
+		err, needed, status.UIDs.Free, status.UIDs.Size, status.GIDs.Free, status.GIDs.Size, status.User, status.Containers)
+}
@@ -0,0 +1,62 @@
+//go:build !remote && (linux || freebsd)
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// hostZoneinfoDir returns the timezone database of the host, which glibc
+// lets TZDIR override.
+func hostZoneinfoDir() string {
+	if dir := os.Getenv("TZDIR"); dir != "" {
+		return dir
+	}
+	return "/usr/share/zoneinfo"
+}
+
+// hostTimezone returns the timezone of the host, relative to its timezone
+// database.
+func hostTimezone(zoneinfo string) (string, error) {
+	zoneinfo, err := filepath.EvalSymlinks(zoneinfo)
+	if err != nil {
+		return "", fmt.Errorf("finding the timezone database of the host: %w", err)
+	}
+	zone, err := filepath.EvalSymlinks("/etc/localtime")
+	if err != nil {
+		return "", fmt.Errorf("finding the timezone of the host: %w", err)
+	}
+	rel, err := filepath.Rel(zoneinfo, zone)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("timezone %s of the host is not in its timezone database %s", zone, zoneinfo)
+	}
+	return rel, nil
+}
+
+// configureHostTimezone links /etc/localtime of the container to the
+// timezone of the host, in the timezone database of the host which
+// generateSpec mounts over the one of the image.  The timezone of the host is
+// looked up again every time the container starts.
+func (c *Container) configureHostTimezone(etcFd int) error {
+	zoneinfo := hostZoneinfoDir()
+	zone, err := hostTimezone(zoneinfo)
+	if err != nil {
+		return err
+	}
+	if err := unix.Unlinkat(etcFd, "localtime", 0); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("removing /etc/localtime: %w", err)
+	}
+	logrus.Debugf("Linking /etc/localtime of container %s to the timezone %s of the host", c.ID(), zone)
+	if err := unix.Symlinkat(".."+filepath.Join(zoneinfo, zone), etcFd, "localtime"); err != nil {
+		return fmt.Errorf("creating /etc/localtime symlink: %w", err)
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ package libpod
 
 import (
 	"bufio"
+	"bytes"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -18,12 +19,12 @@ import (
 
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils/apiutil"
+	"github.com/dmikushin/podman-shared/pkg/seccomp"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/selinux/go-selinux/label"
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/libnetwork/types"
 	"go.podman.io/common/pkg/config"
-	"go.podman.io/storage/pkg/fileutils"
 	"golang.org/x/sys/unix"
 )
 
@@ -80,24 +81,7 @@ func DefaultSeccompPath() (string, error) {
 	if err != nil {
 		return "", err
 	}
-	if def.Containers.SeccompProfile != "" {
-		return def.Containers.SeccompProfile, nil
-	}
-
-	err = fileutils.Exists(config.SeccompOverridePath)
-	if err == nil {
-		return config.SeccompOverridePath, nil
-	}
-	if !os.IsNotExist(err) {
-		return "", err
-	}
-	if err := fileutils.Exists(config.SeccompDefaultPath); err != nil {
-		if !os.IsNotExist(err) {
-			return "", err
-		}
-		return "", nil
-	}
-	return config.SeccompDefaultPath, nil
+	return seccomp.DefaultProfilePath(def.Containers.SeccompProfile)
 }
 
 // CheckDependencyContainer verifies the given container can be used as a
@@ -292,3 +276,21 @@ func isDirectory(path string) bool {
 	}
 	return info.IsDir()
 }
+
+// parseKeyedValues parses "key value" lines, like the ones of cgroup files.
+func parseKeyedValues(content []byte) (map[string]uint64, error) {
+	values := make(map[string]uint64)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing %q: %w", scanner.Text(), err)
+		}
+		values[key] = v
+	}
+	return values, scanner.Err()
+}
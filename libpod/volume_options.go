@@ -0,0 +1,149 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+)
+
+const (
+	// VolumeOptionsDefaultDir is the directory the volume mount option
+	// rules of packages are installed to.
+	VolumeOptionsDefaultDir = "/usr/share/containers/volume-options.d"
+	// VolumeOptionsOverrideDir is the directory of the volume mount
+	// option rules defined by the administrator, which override the ones
+	// of packages with the same file name.
+	VolumeOptionsOverrideDir = "/etc/containers/volume-options.d"
+)
+
+// volumeOptionClasses maps the mount options a volume options rule can set
+// to their class: an option of a class overrides the other options of the
+// class.  Named volumes are always mounted nodev and nosuid unless the
+// container sets dev or suid, so rules cannot set those.
+var volumeOptionClasses = map[string]string{
+	"ro":     "rw",
+	"rw":     "rw",
+	"exec":   "exec",
+	"noexec": "exec",
+	"dev":    "dev",
+	"nodev":  "dev",
+	"suid":   "suid",
+	"nosuid": "suid",
+	"z":      "z",
+	"Z":      "z",
+}
+
+// VolumeOptionsRule sets the default mount options of the named volumes of
+// a driver, or whose name starts with a prefix, when they are mounted into
+// containers.  The options of the containers override the ones of the rules.
+type VolumeOptionsRule struct {
+	// Name of the rule, the name of its file without the .json suffix.
+	Name string `json:"-"`
+	// Driver is the volume driver the rule applies to, all drivers if
+	// empty.
+	Driver string `json:"driver,omitempty"`
+	// NamePrefix is the prefix of the names of the volumes the rule
+	// applies to, all volumes if empty.
+	NamePrefix string `json:"namePrefix,omitempty"`
+	// Options are the mount options: ro, rw, exec, noexec, nodev, nosuid,
+	// z and Z.
+	Options []string `json:"options"`
+}
+
+// matches reports whether the rule applies to the volume name of driver.
+func (rule *VolumeOptionsRule) matches(name, driver string) bool {
+	return (rule.Driver == "" || rule.Driver == driver) && strings.HasPrefix(name, rule.NamePrefix)
+}
+
+// readVolumeOptionsRules reads the volume options rules of the .json files
+// of dirs, in the order of their file names.  A file overrides the file with
+// the same name of an earlier directory.
+func readVolumeOptionsRules(dirs []string) ([]*VolumeOptionsRule, error) {
+	paths := make(map[string]string)
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue
+			}
+			return nil, fmt.Errorf("reading volume options rules: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || filepath.Ext(entry.Name()) != ".json" {
+				continue
+			}
+			paths[entry.Name()] = filepath.Join(dir, entry.Name())
+		}
+	}
+
+	rules := make([]*VolumeOptionsRule, 0, len(paths))
+	for _, file := range slices.Sorted(maps.Keys(paths)) {
+		content, err := os.ReadFile(paths[file])
+		if err != nil {
+			return nil, fmt.Errorf("reading volume options rule: %w", err)
+		}
+		rule := &VolumeOptionsRule{Name: strings.TrimSuffix(file, ".json")}
+		if err := json.Unmarshal(content, rule); err != nil {
+			return nil, fmt.Errorf("parsing volume options rule %s: %w", paths[file], err)
+		}
+		for _, o := range rule.Options {
+			if _, ok := volumeOptionClasses[o]; !ok || o == "dev" || o == "suid" {
+				return nil, fmt.Errorf("volume options rule %s: unsupported mount option %q: %w", paths[file], o, define.ErrInvalidArg)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// applyVolumeOptionsRules returns the mount options of the named volume name
+// of driver: options, the mount options of the container, followed by the
+// options of the rules applying to the volume for the classes options does
+// not set.  The options of later rules override the ones of earlier rules.
+func applyVolumeOptionsRules(rules []*VolumeOptionsRule, name, driver string, options []string) []string {
+	defaults := make(map[string]string)
+	var classes []string
+	for _, rule := range rules {
+		if !rule.matches(name, driver) {
+			continue
+		}
+		for _, o := range rule.Options {
+			class := volumeOptionClasses[o]
+			if _, ok := defaults[class]; !ok {
+				classes = append(classes, class)
+			}
+			defaults[class] = o
+		}
+	}
+	if len(classes) == 0 {
+		return options
+	}
+
+	set := make(map[string]bool)
+	for _, o := range options {
+		if class, ok := volumeOptionClasses[o]; ok {
+			set[class] = true
+		}
+	}
+	merged := slices.Clone(options)
+	for _, class := range classes {
+		if !set[class] {
+			merged = append(merged, defaults[class])
+		}
+	}
+	return merged
+}
+
+// volumeOptionsRules returns the volume options rules of the volume options
+// directories.
+func volumeOptionsRules() ([]*VolumeOptionsRule, error) {
+	return readVolumeOptionsRules(configDropInDirs(VolumeOptionsDefaultDir, VolumeOptionsOverrideDir))
+}
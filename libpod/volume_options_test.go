@@ -0,0 +1,71 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_readVolumeOptionsRules(t *testing.T) {
+	vendorDir := t.TempDir()
+	adminDir := t.TempDir()
+	err := os.WriteFile(filepath.Join(vendorDir, "10-local.json"), []byte(`{"driver": "local", "options": ["noexec", "z"]}`), 0o644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(vendorDir, "20-data.json"), []byte(`{"namePrefix": "data-", "options": ["ro"]}`), 0o644)
+	require.NoError(t, err)
+	err = os.WriteFile(filepath.Join(vendorDir, "README"), []byte(`not a rule`), 0o644)
+	require.NoError(t, err)
+	// the rule of a later directory overrides the one of an earlier one
+	err = os.WriteFile(filepath.Join(adminDir, "20-data.json"), []byte(`{"namePrefix": "data-", "options": ["ro", "Z"]}`), 0o644)
+	require.NoError(t, err)
+
+	rules, err := readVolumeOptionsRules([]string{vendorDir, adminDir, filepath.Join(adminDir, "missing")})
+	require.NoError(t, err)
+	assert.Equal(t, []*VolumeOptionsRule{
+		{Name: "10-local", Driver: "local", Options: []string{"noexec", "z"}},
+		{Name: "20-data", NamePrefix: "data-", Options: []string{"ro", "Z"}},
+	}, rules)
+
+	err = os.WriteFile(filepath.Join(adminDir, "30-dev.json"), []byte(`{"options": ["dev"]}`), 0o644)
+	require.NoError(t, err)
+	_, err = readVolumeOptionsRules([]string{vendorDir, adminDir})
+	assert.ErrorIs(t, err, define.ErrInvalidArg)
+
+	err = os.WriteFile(filepath.Join(adminDir, "30-dev.json"), []byte(`{"options": "noexec"}`), 0o644)
+	require.NoError(t, err)
+	_, err = readVolumeOptionsRules([]string{vendorDir, adminDir})
+	assert.ErrorContains(t, err, "parsing volume options rule")
+}
+
+func Test_applyVolumeOptionsRules(t *testing.T) {
+	rules := []*VolumeOptionsRule{
+		{Name: "10-local", Driver: "local", Options: []string{"noexec", "nodev", "z"}},
+		{Name: "20-data", NamePrefix: "data-", Options: []string{"ro", "Z"}},
+		{Name: "30-plugin", Driver: "csi", Options: []string{"nosuid"}},
+	}
+	processed := []string{"rprivate", "nosuid", "nodev", "rbind"}
+
+	tests := []struct {
+		name, volume, driver string
+		options, expected    []string
+	}{
+		{"no matching rule", "vol", "other", processed, processed},
+		{"driver rule", "vol", "local", processed, append(processed, "noexec", "z")},
+		{"later rule overrides", "data-1", "local", processed, append(processed, "noexec", "Z", "ro")},
+		{"prefix rule of any driver", "data-1", "csi", processed, append(processed, "ro", "Z")},
+		{"container options override", "data-1", "local", []string{"rw", "exec", "z", "dev"}, []string{"rw", "exec", "z", "dev"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, applyVolumeOptionsRules(rules, tt.volume, tt.driver, tt.options))
+		})
+	}
+	// the options of the container are not modified
+	assert.Equal(t, []string{"rprivate", "nosuid", "nodev", "rbind"}, processed)
+}
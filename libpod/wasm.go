@@ -0,0 +1,77 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+)
+
+// WasmVariantAnnotation makes crun run the entrypoint of a container with its
+// wasm handler.
+const WasmVariantAnnotation = "module.wasm.image/variant"
+
+// IsWasmPlatform reports whether images of the platform are WebAssembly
+// modules, e.g. wasi/wasm.
+func IsWasmPlatform(os, arch string) bool {
+	return os == "wasi" || strings.HasPrefix(arch, "wasm")
+}
+
+// wasmHandlers returns the wasm handlers of the features of an OCI runtime,
+// crun lists them as "wasm:wasmedge".
+func wasmHandlers(features []string) []string {
+	var handlers []string
+	for _, feature := range features {
+		if handler, ok := strings.CutPrefix(feature, "wasm:"); ok {
+			handlers = append(handlers, strings.Split(handler, ",")...)
+		}
+	}
+	return handlers
+}
+
+// WasmRuntime returns the OCI runtime running WebAssembly images: the runtime
+// configured for the wasi platforms in platform_to_oci_runtime when it is
+// installed, or else the default runtime when it was built with a wasm
+// handler.  An error is returned along with an unavailable runtime when
+// neither is the case.
+func (r *Runtime) WasmRuntime() (*define.WasmInfo, error) {
+	var names []string
+	for platform, name := range r.config.Engine.PlatformToOCIRuntime {
+		os, arch, _ := strings.Cut(platform, "/")
+		if IsWasmPlatform(os, arch) && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		ociRuntime, ok := r.ociRuntimes[name]
+		if !ok {
+			logrus.Debugf("Wasm OCI runtime %s is not installed", name)
+			continue
+		}
+		info := &define.WasmInfo{Available: true, Runtime: name, Path: ociRuntime.Path()}
+		if _, runtimeInfo, err := ociRuntime.RuntimeInfo(); err == nil {
+			info.Handlers = wasmHandlers(runtimeInfo.Features)
+		}
+		return info, nil
+	}
+
+	_, runtimeInfo, err := r.defaultOCIRuntime.RuntimeInfo()
+	if err != nil {
+		logrus.Debugf("Getting info on OCI runtime %s: %v", r.defaultOCIRuntime.Name(), err)
+	} else if handlers := wasmHandlers(runtimeInfo.Features); len(handlers) > 0 {
+		return &define.WasmInfo{
+			Available:  true,
+			Runtime:    r.defaultOCIRuntime.Name(),
+			Path:       r.defaultOCIRuntime.Path(),
+			Handlers:   handlers,
+			Annotation: true,
+		}, nil
+	}
+	return &define.WasmInfo{}, fmt.Errorf("no OCI runtime can run WebAssembly images, install %s or build %s with a wasm handler: %w",
+		strings.Join(names, " or "), r.defaultOCIRuntime.Name(), define.ErrOCIRuntimeUnavailable)
+}
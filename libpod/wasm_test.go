@@ -0,0 +1,22 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_wasmHandlers(t *testing.T) {
+	assert.Equal(t, []string{"wasmedge"}, wasmHandlers([]string{"systemd", "criu", "wasm:wasmedge", "yajl"}))
+	assert.Equal(t, []string{"wasmtime", "wasmer"}, wasmHandlers([]string{"wasm:wasmtime,wasmer"}))
+	assert.Nil(t, wasmHandlers([]string{"systemd", "seccomp"}))
+}
+
+func TestIsWasmPlatform(t *testing.T) {
+	assert.True(t, IsWasmPlatform("wasi", "wasm"))
+	assert.True(t, IsWasmPlatform("wasi", "wasm32"))
+	assert.True(t, IsWasmPlatform("wasip1", "wasm"))
+	assert.False(t, IsWasmPlatform("linux", "amd64"))
+}
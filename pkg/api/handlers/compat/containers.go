@@ -23,6 +23,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/domain/infra/abi"
 	"github.com/dmikushin/podman-shared/pkg/ps"
 	"github.com/dmikushin/podman-shared/pkg/signal"
+	"github.com/dmikushin/podman-shared/pkg/specgenutil"
 	"github.com/dmikushin/podman-shared/pkg/util"
 	dockerBackend "github.com/docker/docker/api/types/backend"
 	"github.com/docker/docker/api/types/container"
@@ -713,37 +714,55 @@ func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 
 	options := new(container.UpdateConfig)
 	if err := json.NewDecoder(r.Body).Decode(options); err != nil {
-		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("decoding request body: %w", err))
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("decoding request body: %w", err))
 		return
 	}
 
-	// Only handle the bits of update that Docker uses as examples.
-	// For example, the update API claims to be able to update devices for
-	// existing containers... Which I am very dubious about.
-	// Ignore bits like that unless someone asks us for them.
+	updateOptions, warnings, err := updateConfigToUpdateOptions(options)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
 
-	// We're going to be editing this, so we have to deep-copy to not affect
-	// the container's own resources
-	resources := new(spec.LinuxResources)
-	oldResources := ctr.LinuxResources()
-	if oldResources != nil {
-		if err := libpod.JSONDeepCopy(oldResources, resources); err != nil {
-			utils.Error(w, http.StatusInternalServerError, fmt.Errorf("copying old resource limits: %w", err))
+	if err := ctr.Update(updateOptions); err != nil {
+		if errors.Is(err, define.ErrInvalidArg) {
+			utils.Error(w, http.StatusBadRequest, fmt.Errorf("updating container: %w", err))
 			return
 		}
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("updating container: %w", err))
+		return
 	}
 
+	responseStruct := container.UpdateResponse{Warnings: warnings}
+	utils.WriteResponse(w, http.StatusOK, responseStruct)
+}
+
+// updateConfigToUpdateOptions translates the Docker update config to the
+// options of a container update.  Only the resources set in the config are
+// updated, the other ones are kept.  Resources which cannot be updated are
+// reported as warnings.
+func updateConfigToUpdateOptions(options *container.UpdateConfig) (*entities.ContainerUpdateOptions, []string, error) {
+	resources := new(spec.LinuxResources)
+	warnings := []string{}
+
 	// CPU limits
-	cpu := resources.CPU
-	if cpu == nil {
-		cpu = new(spec.LinuxCPU)
+	if options.NanoCPUs != 0 && (options.CPUPeriod != 0 || options.CPUQuota != 0) {
+		return nil, nil, errors.New("conflicting options: NanoCpus conflicts with CpuPeriod and CpuQuota")
 	}
+	cpu := new(spec.LinuxCPU)
 	useCPU := false
 	if options.CPUShares != 0 {
 		shares := uint64(options.CPUShares)
 		cpu.Shares = &shares
 		useCPU = true
 	}
+	if options.NanoCPUs != 0 {
+		period := uint64(100000)
+		quota := options.NanoCPUs / 10000
+		cpu.Period = &period
+		cpu.Quota = &quota
+		useCPU = true
+	}
 	if options.CPUPeriod != 0 {
 		period := uint64(options.CPUPeriod)
 		cpu.Period = &period
@@ -775,10 +794,7 @@ func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Memory limits
-	mem := resources.Memory
-	if mem == nil {
-		mem = new(spec.LinuxMemory)
-	}
+	mem := new(spec.LinuxMemory)
 	useMem := false
 	if options.Memory != 0 {
 		mem.Limit = &options.Memory
@@ -792,48 +808,115 @@ func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 		mem.Reservation = &options.MemoryReservation
 		useMem = true
 	}
+	// Docker uses -1 for the default swappiness
+	if options.MemorySwappiness != nil && *options.MemorySwappiness != -1 {
+		if *options.MemorySwappiness < 0 || *options.MemorySwappiness > 100 {
+			return nil, nil, fmt.Errorf("invalid MemorySwappiness %d: valid memory swappiness range is 0-100", *options.MemorySwappiness)
+		}
+		swappiness := uint64(*options.MemorySwappiness)
+		mem.Swappiness = &swappiness
+		useMem = true
+	}
+	if options.KernelMemoryTCP != 0 {
+		mem.KernelTCP = &options.KernelMemoryTCP
+		useMem = true
+	}
+	if options.OomKillDisable != nil {
+		mem.DisableOOMKiller = options.OomKillDisable
+		useMem = true
+	}
 	if useMem {
 		resources.Memory = mem
 	}
 
-	// PIDs limit
+	// PIDs limit, Docker uses 0 and -1 for unlimited
 	if options.PidsLimit != nil {
-		if resources.Pids == nil {
-			resources.Pids = new(spec.LinuxPids)
+		limit := *options.PidsLimit
+		if limit <= 0 {
+			limit = -1
 		}
-		resources.Pids.Limit = *options.PidsLimit
+		resources.Pids = &spec.LinuxPids{Limit: limit}
 	}
 
-	// Blkio Weight
+	// Block IO limits
 	if options.BlkioWeight != 0 {
-		if resources.BlockIO == nil {
-			resources.BlockIO = new(spec.LinuxBlockIO)
-		}
-		resources.BlockIO.Weight = &options.BlkioWeight
+		resources.BlockIO = &spec.LinuxBlockIO{Weight: &options.BlkioWeight}
+	}
+	devicesLimits := define.UpdateContainerDevicesLimits{}
+	for _, d := range options.BlkioWeightDevice {
+		devicesLimits.BlkIOWeightDevice = append(devicesLimits.BlkIOWeightDevice, define.WeightDevice{Path: d.Path, Weight: d.Weight})
+	}
+	for _, d := range options.BlkioDeviceReadBps {
+		devicesLimits.DeviceReadBPs = append(devicesLimits.DeviceReadBPs, define.ThrottleDevice{Path: d.Path, Rate: d.Rate})
+	}
+	for _, d := range options.BlkioDeviceWriteBps {
+		devicesLimits.DeviceWriteBPs = append(devicesLimits.DeviceWriteBPs, define.ThrottleDevice{Path: d.Path, Rate: d.Rate})
+	}
+	for _, d := range options.BlkioDeviceReadIOps {
+		devicesLimits.DeviceReadIOPs = append(devicesLimits.DeviceReadIOPs, define.ThrottleDevice{Path: d.Path, Rate: d.Rate})
+	}
+	for _, d := range options.BlkioDeviceWriteIOps {
+		devicesLimits.DeviceWriteIOPs = append(devicesLimits.DeviceWriteIOPs, define.ThrottleDevice{Path: d.Path, Rate: d.Rate})
+	}
+	resources, err := specgenutil.UpdateMajorAndMinorNumbers(resources, &devicesLimits)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Restart policy
-	localPolicy := string(options.RestartPolicy.Name)
-	restartPolicy := &localPolicy
-
-	var restartRetries *uint
-	if options.RestartPolicy.MaximumRetryCount != 0 {
-		localRetries := uint(options.RestartPolicy.MaximumRetryCount)
-		restartRetries = &localRetries
+	// Devices
+	devices := &define.UpdateContainerDevices{}
+	for _, d := range options.Devices {
+		device := d.PathOnHost
+		if d.PathInContainer != "" {
+			device += ":" + d.PathInContainer
+			if d.CgroupPermissions != "" {
+				device += ":" + d.CgroupPermissions
+			}
+		} else if d.CgroupPermissions != "" {
+			device += ":" + d.PathOnHost + ":" + d.CgroupPermissions
+		}
+		devices.AddDevices = append(devices.AddDevices, device)
+	}
+
+	for field, set := range map[string]bool{
+		"CgroupParent":       options.CgroupParent != "",
+		"DeviceCgroupRules":  len(options.DeviceCgroupRules) > 0,
+		"DeviceRequests":     len(options.DeviceRequests) > 0,
+		"KernelMemory":       options.KernelMemory != 0,
+		"Ulimits":            len(options.Ulimits) > 0,
+		"CpuCount":           options.CPUCount != 0,
+		"CpuPercent":         options.CPUPercent != 0,
+		"IOMaximumIOps":      options.IOMaximumIOps != 0,
+		"IOMaximumBandwidth": options.IOMaximumBandwidth != 0,
+	} {
+		if set {
+			warnings = append(warnings, fmt.Sprintf("%s cannot be updated and was ignored", field))
+		}
 	}
+	sort.Strings(warnings)
 
 	updateOptions := &entities.ContainerUpdateOptions{
 		Resources:                       resources,
 		ChangedHealthCheckConfiguration: &define.UpdateHealthCheckConfig{},
-		RestartPolicy:                   restartPolicy,
-		RestartRetries:                  restartRetries,
+		Devices:                         devices,
 	}
 
-	if err := ctr.Update(updateOptions); err != nil {
-		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("updating container: %w", err))
-		return
+	// Restart policy, Docker does not change it if no name is given
+	if options.RestartPolicy.Name != "" {
+		policy := string(options.RestartPolicy.Name)
+		updateOptions.RestartPolicy = &policy
+		if options.RestartPolicy.MaximumRetryCount < 0 {
+			return nil, nil, fmt.Errorf("invalid maximum retry count %d: must not be negative", options.RestartPolicy.MaximumRetryCount)
+		}
+		if options.RestartPolicy.MaximumRetryCount != 0 {
+			if policy != define.RestartPolicyOnFailure {
+				return nil, nil, fmt.Errorf("maximum retry count cannot be used with restart policy %q", policy)
+			}
+			retries := uint(options.RestartPolicy.MaximumRetryCount)
+			updateOptions.RestartRetries = &retries
+		}
+	} else if options.RestartPolicy.MaximumRetryCount != 0 {
+		return nil, nil, errors.New("maximum retry count cannot be used without restart policy")
 	}
-
-	responseStruct := container.UpdateResponse{}
-	utils.WriteResponse(w, http.StatusOK, responseStruct)
+	return updateOptions, warnings, nil
 }
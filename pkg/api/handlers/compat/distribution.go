@@ -0,0 +1,143 @@
+//go:build !remote
+
+package compat
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/dmikushin/podman-shared/libpod"
+	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
+	api "github.com/dmikushin/podman-shared/pkg/api/types"
+	"github.com/dmikushin/podman-shared/pkg/auth"
+	"github.com/docker/distribution/registry/api/errcode"
+	dockerRegistry "github.com/docker/docker/api/types/registry"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/image"
+	"go.podman.io/image/v5/manifest"
+	"go.podman.io/image/v5/types"
+)
+
+// InspectDistribution returns the descriptor of the manifest of an image in
+// its registry and the platforms it is available for.  As with Docker, short
+// names are resolved to Docker Hub and the tag defaults to latest.
+func InspectDistribution(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := utils.GetDecoder(r)
+	query := struct {
+		TLSVerify bool `schema:"tlsVerify"`
+	}{
+		// This is where you can override the golang default value for one of fields
+		TLSVerify: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	name := utils.GetName(r)
+	named, err := reference.ParseNormalizedNamed(name)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("parsing image name %q: %w", name, err))
+		return
+	}
+	ref, err := docker.NewReference(reference.TagNameOnly(named))
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	authConf, authfile, err := auth.GetCredentials(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	defer auth.RemoveAuthfile(authfile)
+
+	sys := *runtime.SystemContext()
+	sys.AuthFilePath = authfile
+	if _, found := r.URL.Query()["tlsVerify"]; found {
+		sys.DockerInsecureSkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
+	}
+	if authConf != nil {
+		sys.DockerAuthConfig = &types.DockerAuthConfig{
+			Username:      authConf.Username,
+			Password:      authConf.Password,
+			IdentityToken: authConf.IdentityToken,
+		}
+	}
+
+	inspect, err := inspectDistribution(r, &sys, ref)
+	if err != nil {
+		var unauthorized docker.ErrUnauthorizedForCredentials
+		var coder errcode.ErrorCoder
+		switch {
+		case errors.As(err, &unauthorized):
+			utils.Error(w, http.StatusUnauthorized, err)
+		case errors.As(err, &coder) && coder.ErrorCode().Descriptor().HTTPStatusCode == http.StatusUnauthorized:
+			utils.Error(w, http.StatusUnauthorized, err)
+		case errors.As(err, &coder) && coder.ErrorCode().Descriptor().HTTPStatusCode == http.StatusNotFound:
+			utils.Error(w, http.StatusNotFound, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, inspect)
+}
+
+func inspectDistribution(r *http.Request, sys *types.SystemContext, ref types.ImageReference) (*dockerRegistry.DistributionInspect, error) {
+	src, err := ref.NewImageSource(r.Context(), sys)
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	rawManifest, mimeType, err := src.GetManifest(r.Context(), nil)
+	if err != nil {
+		return nil, err
+	}
+	manifestDigest, err := manifest.Digest(rawManifest)
+	if err != nil {
+		return nil, err
+	}
+	inspect := &dockerRegistry.DistributionInspect{
+		Descriptor: ocispec.Descriptor{
+			MediaType: mimeType,
+			Digest:    manifestDigest,
+			Size:      int64(len(rawManifest)),
+		},
+		Platforms: []ocispec.Platform{},
+	}
+
+	if manifest.MIMETypeIsMultiImage(mimeType) {
+		list, err := manifest.ListFromBlob(rawManifest, mimeType)
+		if err != nil {
+			return nil, err
+		}
+		for _, instanceDigest := range list.Instances() {
+			instance, err := list.Instance(instanceDigest)
+			if err != nil {
+				return nil, err
+			}
+			if instance.ReadOnly.Platform != nil {
+				inspect.Platforms = append(inspect.Platforms, *instance.ReadOnly.Platform)
+			}
+		}
+		return inspect, nil
+	}
+
+	img, err := image.FromUnparsedImage(r.Context(), sys, image.UnparsedInstance(src, nil))
+	if err != nil {
+		return nil, err
+	}
+	config, err := img.OCIConfig(r.Context())
+	if err != nil {
+		return nil, err
+	}
+	inspect.Platforms = append(inspect.Platforms, config.Platform)
+	return inspect, nil
+}
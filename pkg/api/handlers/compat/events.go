@@ -5,6 +5,7 @@ package compat
 import (
 	"fmt"
 	"net/http"
+	"os"
 
 	"github.com/dmikushin/podman-shared/libpod"
 	"github.com/dmikushin/podman-shared/libpod/events"
@@ -28,9 +29,10 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 	// NOTE: the "filters" parameter is extracted separately for backwards
 	// compat via `filterFromRequest()`.
 	query := struct {
-		Since  string `schema:"since"`
-		Until  string `schema:"until"`
-		Stream bool   `schema:"stream"`
+		Since        string `schema:"since"`
+		Until        string `schema:"until"`
+		Stream       bool   `schema:"stream"`
+		StreamFormat string `schema:"streamFormat"`
 	}{
 		Stream: true,
 	}
@@ -43,6 +45,21 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 		fromStart = true
 	}
 
+	var cloudEventSource string
+	switch query.StreamFormat {
+	case "", "json":
+	case events.CloudEventsFormat:
+		hostname, err := os.Hostname()
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		cloudEventSource = events.CloudEventSource(hostname)
+	default:
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("invalid stream format %q, must be json or %s", query.StreamFormat, events.CloudEventsFormat))
+		return
+	}
+
 	libpodFilters, err := util.FiltersFromRequest(r)
 	if err != nil {
 		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse filters for %s: %w", r.URL.String(), err))
@@ -69,7 +86,11 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 		flush = flusher.Flush
 	}
 
-	w.Header().Set("Content-Type", "application/json")
+	if cloudEventSource != "" {
+		w.Header().Set("Content-Type", events.CloudEventsContentType)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+	}
 	w.WriteHeader(http.StatusOK)
 	flush()
 
@@ -92,6 +113,14 @@ func GetEvents(w http.ResponseWriter, r *http.Request) {
 				continue
 			}
 
+			if cloudEventSource != "" {
+				if err := coder.Encode(evt.Event.ToCloudEvent(cloudEventSource)); err != nil {
+					logrus.Errorf("Unable to write json: %q", err)
+				}
+				flush()
+				continue
+			}
+
 			e := entities.ConvertToEntitiesEvent(*evt.Event)
 			// Some events differ between Libpod and Docker endpoints.
 			// Handle these differences for Docker-compat.
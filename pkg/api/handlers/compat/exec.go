@@ -143,7 +143,19 @@ func ExecStartHandler(w http.ResponseWriter, r *http.Request) {
 	// TODO: We should read/support Tty from here.
 	bodyParams := new(handlers.ExecStartConfig)
 
-	if err := json.NewDecoder(r.Body).Decode(&bodyParams); err != nil {
+	if r.Method == http.MethodGet {
+		// WebSocket requests have no body, the parameters are in the query
+		query := struct {
+			Tty    bool   `schema:"tty"`
+			Height uint16 `schema:"h"`
+			Width  uint16 `schema:"w"`
+		}{}
+		if err := utils.GetDecoder(r).Decode(&query, r.URL.Query()); err != nil {
+			utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+			return
+		}
+		bodyParams.Tty, bodyParams.Height, bodyParams.Width = query.Tty, query.Height, query.Width
+	} else if err := json.NewDecoder(r.Body).Decode(&bodyParams); err != nil {
 		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to decode parameters for %s: %w", r.URL.String(), err))
 		return
 	}
@@ -319,7 +319,7 @@ func CreateImageFromImage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	utils.CompatPull(r.Context(), w, runtime, possiblyNormalizedName, config.PullPolicyAlways, pullOptions)
+	utils.CompatPull(r.Context(), w, runtime, possiblyNormalizedName, config.PullPolicyAlways, 0, pullOptions)
 }
 
 func GetImage(w http.ResponseWriter, r *http.Request) {
@@ -427,8 +427,11 @@ func GetImages(w http.ResponseWriter, r *http.Request) {
 	query := struct {
 		All        bool
 		Digests    bool
-		Filter     string // Docker 1.24 compatibility
-		SharedSize bool   `schema:"shared-size"` // Docker 1.42 compatibility
+		Fields     []string `schema:"fields"` // libpod only
+		Filter     string   // Docker 1.24 compatibility
+		Limit      int      `schema:"limit"`       // libpod only
+		Offset     int      `schema:"offset"`      // libpod only
+		SharedSize bool     `schema:"shared-size"` // Docker 1.42 compatibility
 	}{
 		// This is where you can override the golang default value for one of fields
 	}
@@ -468,6 +471,16 @@ func GetImages(w http.ResponseWriter, r *http.Request) {
 	imageEngine := abi.ImageEngine{Libpod: runtime}
 
 	listOptions := entities.ImageListOptions{All: query.All, Filter: filterList, ExtendedAttributes: utils.IsLibpodRequest(r)}
+	if utils.IsLibpodRequest(r) {
+		if query.Limit < 0 || query.Offset < 0 {
+			utils.Error(w, http.StatusBadRequest, errors.New("limit and offset must not be negative"))
+			return
+		}
+		listOptions.Limit = query.Limit
+		listOptions.Offset = query.Offset
+	} else {
+		query.Fields = nil
+	}
 	summaries, err := imageEngine.List(r.Context(), listOptions)
 	if err != nil {
 		utils.Error(w, http.StatusInternalServerError, err)
@@ -500,7 +513,7 @@ func GetImages(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 	}
-	utils.WriteResponse(w, http.StatusOK, summaries)
+	utils.WriteListResponse(w, http.StatusOK, summaries, query.Fields)
 }
 
 func LoadImages(w http.ResponseWriter, r *http.Request) {
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"mime"
 	"net/http"
 	"net/url"
@@ -23,11 +24,14 @@ import (
 	buildahDefine "github.com/containers/buildah/define"
 	"github.com/containers/buildah/pkg/parse"
 	"github.com/dmikushin/podman-shared/internal/localapi"
+	"github.com/dmikushin/podman-shared/internal/remote_build_helpers"
 	"github.com/dmikushin/podman-shared/libpod"
+	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
 	api "github.com/dmikushin/podman-shared/pkg/api/types"
 	"github.com/dmikushin/podman-shared/pkg/auth"
 	"github.com/dmikushin/podman-shared/pkg/channel"
+	entitiesTypes "github.com/dmikushin/podman-shared/pkg/domain/entities/types"
 	"github.com/dmikushin/podman-shared/pkg/rootless"
 	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/opencontainers/runtime-spec/specs-go"
@@ -101,6 +105,7 @@ type BuildQuery struct {
 	OSVersion               string             `schema:"osversion"`
 	OutputFormat            string             `schema:"outputformat"`
 	Platform                []string           `schema:"platform"`
+	Progress                bool               `schema:"progress"`
 	Pull                    bool               `schema:"pull"`
 	PullPolicy              string             `schema:"pullpolicy"`
 	Quiet                   bool               `schema:"q"`
@@ -114,6 +119,7 @@ type BuildQuery struct {
 	Seccomp                 string             `schema:"seccomp"`
 	Secrets                 string             `schema:"secrets"`
 	SecurityOpt             string             `schema:"securityopt"`
+	SharedCache             string             `schema:"sharedcache"`
 	ShmSize                 int                `schema:"shmsize"`
 	SkipUnusedStages        bool               `schema:"skipunusedstages"`
 	SourceDateEpoch         int64              `schema:"sourcedateepoch"`
@@ -142,6 +148,10 @@ type BuildContext struct {
 	AdditionalBuildContexts map[string]*buildahDefine.AdditionalBuildContext
 	ContainerFiles          []string
 	IgnoreFile              string
+	// Secrets and SSHSources sent with the request, stored outside of the
+	// build contexts.
+	Secrets    []string
+	SSHSources []string
 }
 
 func (b *BuildContext) validateLocalAPIPaths() error {
@@ -233,13 +243,14 @@ func validateContentType(r *http.Request) (bool, error) {
 // parseBuildQuery parses HTTP query parameters into a BuildQuery struct with defaults.
 func parseBuildQuery(r *http.Request, conf *config.Config, queryValues url.Values) (*BuildQuery, error) {
 	query := &BuildQuery{
-		Dockerfile: "Dockerfile",
-		Registry:   "docker.io",
-		Rm:         true,
-		ShmSize:    64 * 1024 * 1024,
-		TLSVerify:  true,
-		Retry:      int(conf.Engine.Retry),
-		RetryDelay: conf.Engine.RetryDelay,
+		Dockerfile:  "Dockerfile",
+		Registry:    "docker.io",
+		Rm:          true,
+		ShmSize:     64 * 1024 * 1024,
+		TLSVerify:   true,
+		Retry:       int(conf.Engine.Retry),
+		RetryDelay:  conf.Engine.RetryDelay,
+		SharedCache: define.BuildSharedCacheAuto,
 	}
 
 	decoder := utils.GetDecoder(r)
@@ -337,7 +348,7 @@ func processSecrets(query *BuildQuery, contextDirectory string, queryValues url.
 			for _, token := range secretOpt {
 				key, val, hasVal := strings.Cut(token, "=")
 				if hasVal {
-					if key == "src" {
+					if key == "src" && !strings.HasPrefix(val, define.BuildSecretPrefix) {
 						/* move secret away from contextDir */
 						/* to make sure we dont accidentally commit temporary secrets to image*/
 						builderDirectory, _ := filepath.Split(contextDirectory)
@@ -402,6 +413,7 @@ func createBuildOptions(query *BuildQuery, buildCtx *BuildContext, queryValues u
 	if err != nil {
 		return nil, nil, utils.GetBadRequestError("secrets", query.Secrets, err)
 	}
+	secrets = append(secrets, buildCtx.Secrets...)
 
 	addhosts, err := utils.ParseJSONOptionalSlice(query.AddHosts, queryValues, "extrahosts")
 	if err != nil {
@@ -729,6 +741,7 @@ func createBuildOptions(query *BuildQuery, buildCtx *BuildContext, queryValues u
 			ShmSize:            strconv.Itoa(query.ShmSize),
 			Ulimit:             ulimits,
 			Secrets:            secrets,
+			SSHSources:         buildCtx.SSHSources,
 			Volumes:            query.Volumes,
 		},
 		CompatVolumes:                  compatVolumes,
@@ -853,13 +866,26 @@ func executeBuild(runtime *libpod.Runtime, w http.ResponseWriter, r *http.Reques
 
 	sender := utils.NewBuildResponseSender(w)
 	var stepErrors []string
+	// total number of steps of each build stage reported with progress frames
+	stages := make(map[string]int64)
+	sendProgress := func(output string) {
+		if !query.Progress {
+			return
+		}
+		for _, frame := range utils.BuildProgressFrames(output) {
+			stages[frame.ID] = frame.Total
+			sender.SendBuildProgress(frame)
+		}
+	}
 
 	for {
 		select {
 		case e := <-stdout.Chan():
 			sender.SendBuildStream(string(e))
+			sendProgress(string(e))
 		case e := <-reporter.Chan():
 			sender.SendBuildStream(string(e))
+			sendProgress(string(e))
 		case e := <-auxout.Chan():
 			if !query.Quiet {
 				sender.SendBuildStream(string(e))
@@ -877,6 +903,9 @@ func executeBuild(runtime *libpod.Runtime, w http.ResponseWriter, r *http.Reques
 			return
 		case <-runCtx.Done():
 			if success {
+				for _, id := range slices.Sorted(maps.Keys(stages)) {
+					sender.SendBuildProgress(entitiesTypes.NewProgressFrame(id, entitiesTypes.ProgressStatusDone, stages[id], stages[id]))
+				}
 				if !utils.IsLibpodRequest(r) && !query.Quiet {
 					sender.SendBuildAux(fmt.Appendf(nil, `{"ID":"sha256:%s"}`, imageID))
 					sender.SendBuildStream(fmt.Sprintf("Successfully built %12.12s\n", imageID))
@@ -1051,6 +1080,10 @@ func buildImage(w http.ResponseWriter, r *http.Request, getBuildContextFunc getB
 		utils.ProcessBuildError(w, err)
 		return
 	}
+	if err := processBuildSecretHeaders(r, anchorDir, buildContext); err != nil {
+		utils.ProcessBuildError(w, utils.GetGenericBadRequestError(err))
+		return
+	}
 
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	conf, err := runtime.GetConfigNoCopy()
@@ -1074,6 +1107,10 @@ func buildImage(w http.ResponseWriter, r *http.Request, getBuildContextFunc getB
 		utils.ProcessBuildError(w, err)
 		return
 	}
+	if err := runtime.SetupSharedBuildCache(buildOptions, query.SharedCache); err != nil {
+		utils.ProcessBuildError(w, utils.GetBadRequestError("sharedcache", query.SharedCache, err))
+		return
+	}
 
 	// Execute build
 	executeBuild(runtime, w, r, buildOptions, buildContext.ContainerFiles, query)
@@ -1147,7 +1184,9 @@ func handleBuildContexts(r *http.Request, query url.Values, anchorDir string, mu
 
 	if !multipart {
 		logrus.Debug("No multipart needed")
-		out.ContextDirectory, err = extractTarFile(anchorDir, r.Body)
+		body := contextReader(r, query, r.Body)
+		defer body.Close()
+		out.ContextDirectory, err = extractTarFile(anchorDir, body)
 		if err != nil {
 			return nil, err
 		}
@@ -1173,7 +1212,9 @@ func handleBuildContexts(r *http.Request, query url.Values, anchorDir string, mu
 		fieldName := part.FormName()
 
 		if fieldName == "MainContext" {
-			mainDir, err := extractTarFile(anchorDir, part)
+			body := contextReader(r, query, part)
+			defer body.Close()
+			mainDir, err := extractTarFile(anchorDir, body)
 			if err != nil {
 				return nil, fmt.Errorf("extracting main context in multipart: %w", err)
 			}
@@ -1228,6 +1269,14 @@ func handleBuildContexts(r *http.Request, query url.Values, anchorDir string, mu
 				IsImage: false,
 				Value:   additionalAnchor,
 			}
+		} else if id, ok := strings.CutPrefix(fieldName, buildSecretPartPrefix); ok {
+			if err := out.addBuildSecret(anchorDir, id, part); err != nil {
+				return nil, fmt.Errorf("adding build secret in multipart: %w", err)
+			}
+		} else if id, ok := strings.CutPrefix(fieldName, buildSSHPartPrefix); ok {
+			if err := out.addBuildSSH(anchorDir, id, part); err != nil {
+				return nil, fmt.Errorf("adding SSH key in multipart: %w", err)
+			}
 		} else {
 			logrus.Debugf("Ignoring unknown multipart field: %s", fieldName)
 		}
@@ -1236,6 +1285,18 @@ func handleBuildContexts(r *http.Request, query url.Values, anchorDir string, mu
 	return out, nil
 }
 
+// contextReader returns the reader of the main build context.  Clients which
+// use the build context cache only send the content of the files which
+// changed since a previous build, the others are restored from the cache.
+func contextReader(r *http.Request, query url.Values, body io.ReadCloser) io.ReadCloser {
+	if contextCache, _ := strconv.ParseBool(query.Get("contextcache")); !contextCache {
+		return body
+	}
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	cache := remote_build_helpers.NewContextCache(remote_build_helpers.ContextCacheDir(runtime.StorageConfig().GraphRoot))
+	return cache.Expand(body)
+}
+
 func parseNetworkConfigurationPolicy(network string) buildah.NetworkConfigurationPolicy {
 	if val, err := strconv.Atoi(network); err == nil {
 		return buildah.NetworkConfigurationPolicy(val)
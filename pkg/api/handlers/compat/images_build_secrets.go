@@ -0,0 +1,125 @@
+//go:build !remote
+
+package compat
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// buildSecretsHeader carries build secrets as a base64url-encoded JSON
+	// object mapping secret IDs to their base64-encoded content.
+	buildSecretsHeader = "X-Build-Secrets"
+	// buildSSHHeader carries SSH private keys to forward to the build as a
+	// base64url-encoded JSON object mapping SSH IDs to their base64-encoded
+	// keys.
+	buildSSHHeader = "X-Build-SSH"
+
+	// buildSecretPartPrefix and buildSSHPartPrefix prefix the names of the
+	// multipart fields carrying a build secret or an SSH key, followed by
+	// the secret or SSH ID.
+	buildSecretPartPrefix = "secret-"
+	buildSSHPartPrefix    = "ssh-"
+)
+
+// buildSecretsDir returns the directory storing the secrets and SSH keys of a
+// build, which is outside of the build contexts so they never end up in the
+// image.
+func buildSecretsDir(anchorDir string) (string, error) {
+	dir := filepath.Join(anchorDir, "secrets")
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// writeBuildSecret stores the content of the secret or SSH key with the ID in
+// the secrets directory of the build and returns the path to the file.
+func writeBuildSecret(anchorDir, id string, content io.Reader) (string, error) {
+	if id == "" || strings.ContainsAny(id, ",=") {
+		return "", fmt.Errorf("invalid secret ID %q", id)
+	}
+	dir, err := buildSecretsDir(anchorDir)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(dir, "secret-*")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := io.Copy(f, content); err != nil {
+		return "", fmt.Errorf("writing secret %q: %w", id, err)
+	}
+	return f.Name(), nil
+}
+
+// addBuildSecret stores the content of the secret with the ID and adds it to
+// the secrets of the build.
+func (b *BuildContext) addBuildSecret(anchorDir, id string, content io.Reader) error {
+	path, err := writeBuildSecret(anchorDir, id, content)
+	if err != nil {
+		return err
+	}
+	b.Secrets = append(b.Secrets, fmt.Sprintf("id=%s,src=%s", id, path))
+	return nil
+}
+
+// addBuildSSH stores the SSH key with the ID and forwards it to the build.
+func (b *BuildContext) addBuildSSH(anchorDir, id string, content io.Reader) error {
+	path, err := writeBuildSecret(anchorDir, id, content)
+	if err != nil {
+		return err
+	}
+	b.SSHSources = append(b.SSHSources, fmt.Sprintf("%s=%s", id, path))
+	return nil
+}
+
+// parseBuildSecretsHeader decodes a header in the format of buildSecretsHeader.
+func parseBuildSecretsHeader(r *http.Request, header string) (map[string][]byte, error) {
+	value := r.Header.Get(header)
+	if value == "" {
+		return nil, nil
+	}
+	secrets := make(map[string][]byte)
+	decoder := json.NewDecoder(base64.NewDecoder(base64.URLEncoding, strings.NewReader(value)))
+	if err := decoder.Decode(&secrets); err != nil {
+		return nil, fmt.Errorf("parsing %s header: %w", header, err)
+	}
+	return secrets, nil
+}
+
+// processBuildSecretHeaders adds the secrets and SSH keys sent in the headers
+// of the request to the build.
+func processBuildSecretHeaders(r *http.Request, anchorDir string, buildContext *BuildContext) error {
+	secrets, err := parseBuildSecretsHeader(r, buildSecretsHeader)
+	if err != nil {
+		return err
+	}
+	for _, id := range slices.Sorted(maps.Keys(secrets)) {
+		if err := buildContext.addBuildSecret(anchorDir, id, bytes.NewReader(secrets[id])); err != nil {
+			return err
+		}
+	}
+
+	keys, err := parseBuildSecretsHeader(r, buildSSHHeader)
+	if err != nil {
+		return err
+	}
+	for _, id := range slices.Sorted(maps.Keys(keys)) {
+		if err := buildContext.addBuildSSH(anchorDir, id, bytes.NewReader(keys[id])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	goRuntime "runtime"
+	"strings"
 	"time"
 
 	"github.com/dmikushin/podman-shared/libpod"
@@ -45,6 +46,7 @@ func VersionHandler(w http.ResponseWriter, r *http.Request) {
 			"KernelVersion": info.Host.Kernel,
 			"MinAPIVersion": version.APIVersion[version.Libpod][version.MinimalAPI].String(),
 			"Os":            goRuntime.GOOS,
+			"Features":      strings.Join(define.ServiceFeatures(info), ","),
 		},
 	}, {
 		Name:    "Conmon",
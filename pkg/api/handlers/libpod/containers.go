@@ -6,9 +6,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/dmikushin/podman-shared/libpod"
 	"github.com/dmikushin/podman-shared/libpod/define"
@@ -63,16 +65,51 @@ func ContainerExists(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// ContainersExist checks whether each of the containers in the JSON list of
+// names or IDs in the body exists.
+func ContainersExist(w http.ResponseWriter, r *http.Request) {
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+
+	query := struct {
+		External bool `schema:"external"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	var names []string
+	if err := json.NewDecoder(r.Body).Decode(&names); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("decoding request body: %w", err))
+		return
+	}
+
+	options := entities.ContainerExistsOptions{
+		External: query.External,
+	}
+	reports, err := containerEngine.ContainersExist(r.Context(), names, options)
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
+
 func ListContainers(w http.ResponseWriter, r *http.Request) {
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 	query := struct {
-		All       bool `schema:"all"`
-		External  bool `schema:"external"`
-		Last      int  `schema:"last"` // alias for limit
-		Limit     int  `schema:"limit"`
-		Namespace bool `schema:"namespace"`
-		Size      bool `schema:"size"`
-		Sync      bool `schema:"sync"`
+		All       bool     `schema:"all"`
+		External  bool     `schema:"external"`
+		Fields    []string `schema:"fields"`
+		Last      int      `schema:"last"` // alias for limit
+		Limit     int      `schema:"limit"`
+		Namespace bool     `schema:"namespace"`
+		Offset    int      `schema:"offset"`
+		Size      bool     `schema:"size"`
+		Sort      string   `schema:"sort"`
+		Sync      bool     `schema:"sync"`
 	}{
 		// override any golang type defaults
 	}
@@ -97,6 +134,16 @@ func ListContainers(w http.ResponseWriter, r *http.Request) {
 		logrus.Info("List containers: received `last` parameter - overwriting `limit`")
 		limit = query.Last
 	}
+	if query.Offset < 0 {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("invalid offset %d: must not be negative", query.Offset))
+		return
+	}
+	if query.Sort != "" {
+		if err := entities.ValidatePsSort(query.Sort); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
 
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	// Now use the ABI implementation to prevent us from having duplicate
@@ -108,10 +155,12 @@ func ListContainers(w http.ResponseWriter, r *http.Request) {
 		Filters:   *filterMap,
 		Last:      limit,
 		Namespace: query.Namespace,
+		Offset:    query.Offset,
 		// Always return Pod, should not be part of the API.
 		// https://github.com/containers/podman/pull/7223
 		Pod:  true,
 		Size: query.Size,
+		Sort: query.Sort,
 		Sync: query.Sync,
 	}
 	pss, err := containerEngine.ContainerList(r.Context(), opts)
@@ -119,7 +168,7 @@ func ListContainers(w http.ResponseWriter, r *http.Request) {
 		utils.InternalServerError(w, err)
 		return
 	}
-	utils.WriteResponse(w, http.StatusOK, pss)
+	utils.WriteListResponse(w, http.StatusOK, pss, query.Fields)
 }
 
 func GetContainer(w http.ResponseWriter, r *http.Request) {
@@ -158,6 +207,69 @@ func WaitContainer(w http.ResponseWriter, r *http.Request) {
 	utils.WaitContainerLibpod(w, r)
 }
 
+// WaitContainers waits for multiple conditions of multiple containers and
+// streams a report whenever a container meets one of them.
+func WaitContainers(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Containers []string `schema:"containers"`
+		Conditions []string `schema:"condition"`
+		Ignore     bool     `schema:"ignore"`
+		Interval   string   `schema:"interval"`
+	}{
+		Interval: "250ms",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if len(query.Containers) == 0 {
+		utils.Error(w, http.StatusBadRequest, errors.New("at least one container must be specified"))
+		return
+	}
+	interval, err := time.ParseDuration(query.Interval)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("invalid interval: %w", err))
+		return
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	conditionChan := make(chan entities.WaitConditionReport)
+	options := entities.WaitOptions{
+		Conditions:    query.Conditions,
+		Interval:      interval,
+		Ignore:        query.Ignore,
+		ConditionChan: conditionChan,
+	}
+	if _, err := containerEngine.ContainerWait(r.Context(), query.Containers, options); err != nil {
+		if errors.Is(err, define.ErrNoSuchCtr) {
+			utils.ContainerNotFound(w, "", err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	coder := json.NewEncoder(w)
+	coder.SetEscapeHTML(true)
+	for report := range conditionChan {
+		if err := coder.Encode(report); err != nil {
+			logrus.Errorf("Unable to encode wait report: %v", err)
+			return
+		}
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+}
+
 func UnmountContainer(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	name := utils.GetName(r)
@@ -213,6 +325,44 @@ func ShowMountedContainers(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, response)
 }
 
+// ExportContainer exports the root file system of a container as a tarball,
+// or the container with its configuration as an OCI archive.
+func ExportContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Format string `schema:"format"`
+	}{
+		Format: entities.ExportFormatTar,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	switch query.Format {
+	case "", entities.ExportFormatTar:
+		compat.ExportContainer(w, r)
+		return
+	case entities.ExportFormatOCIArchive:
+	default:
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("unsupported export format %q", query.Format))
+		return
+	}
+
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-tar")
+	if err := ctr.ExportImage(r.Context(), w, libpod.ContainerCommitOptions{}); err != nil {
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to export container: %w", err))
+		return
+	}
+}
+
 func Checkpoint(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	containerEngine := abi.ContainerEngine{Libpod: runtime}
@@ -388,6 +538,103 @@ func Restore(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, reports[0])
 }
 
+func BackupContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		IgnoreVolumes bool `schema:"ignoreVolumes"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	name := utils.GetName(r)
+	if _, err := runtime.LookupContainer(name); err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+
+	f, err := os.CreateTemp("", "backup")
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+	if err := containerEngine.ContainerBackup(r.Context(), name, entities.ContainerBackupOptions{Output: f, IgnoreVolumes: query.IgnoreVolumes}); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, f)
+}
+
+func RestoreBackupContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Name          string `schema:"name"`
+		IgnoreVolumes bool   `schema:"ignoreVolumes"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	report, err := containerEngine.ContainerRestoreBackup(r.Context(), entities.ContainerRestoreBackupOptions{
+		Input:         r.Body,
+		Name:          query.Name,
+		IgnoreVolumes: query.IgnoreVolumes,
+	})
+	if err != nil {
+		httpCode := http.StatusInternalServerError
+		if errors.Is(err, define.ErrCtrExists) || errors.Is(err, define.ErrVolumeExists) {
+			httpCode = http.StatusConflict
+		}
+		utils.Error(w, httpCode, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func PauseContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		FreezeTimeout uint `schema:"freezeTimeout"`
+	}{
+		// override any golang type defaults
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	name := utils.GetName(r)
+	ctr, err := runtime.LookupContainer(name)
+	if err != nil {
+		utils.ContainerNotFound(w, name, err)
+		return
+	}
+	if err := ctr.Freeze(time.Duration(query.FreezeTimeout) * time.Second); err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, nil)
+}
+
 func InitContainer(w http.ResponseWriter, r *http.Request) {
 	name := utils.GetName(r)
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
@@ -460,10 +707,15 @@ func UpdateContainer(w http.ResponseWriter, r *http.Request) {
 	updateOptions := &entities.ContainerUpdateOptions{
 		Resources:                       resourceLimits,
 		ChangedHealthCheckConfiguration: &options.UpdateHealthCheckConfig,
+		Devices:                         &options.UpdateContainerDevices,
 		RestartPolicy:                   restartPolicy,
 		RestartRetries:                  restartRetries,
 		Env:                             options.Env,
 		UnsetEnv:                        options.UnsetEnv,
+		NetworkOptions:                  options.NetworkOptions,
+	}
+	if options.MemoryReclaim != 0 {
+		updateOptions.MemoryReclaim = &options.MemoryReclaim
 	}
 
 	err = ctr.Update(updateOptions)
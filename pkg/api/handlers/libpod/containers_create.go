@@ -129,7 +129,13 @@ func CreateContainer(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response := entities.ContainerCreateResponse{ID: ctr.ID(), Warnings: warn}
+	ports, err := ctr.PortMappings()
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+
+	response := entities.ContainerCreateResponse{ID: ctr.ID(), Warnings: warn, PublishedPorts: ports}
 	utils.WriteJSON(w, http.StatusCreated, response)
 }
 
@@ -0,0 +1,130 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/dmikushin/podman-shared/libpod"
+	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
+	api "github.com/dmikushin/podman-shared/pkg/api/types"
+	"github.com/dmikushin/podman-shared/pkg/auth"
+	"github.com/dmikushin/podman-shared/pkg/channel"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/domain/infra/abi"
+	"github.com/gorilla/schema"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/image/v5/types"
+)
+
+// ContainerRunlabel executes the command described by an image label on the
+// server.  The output of the pull and the command is streamed to the client.
+func ContainerRunlabel(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Args      []string `schema:"args"`
+		Display   bool     `schema:"display"`
+		Image     string   `schema:"image"`
+		Label     string   `schema:"label"`
+		Name      string   `schema:"name"`
+		Opt1      string   `schema:"opt1"`
+		Opt2      string   `schema:"opt2"`
+		Opt3      string   `schema:"opt3"`
+		Pull      bool     `schema:"pull"`
+		Quiet     bool     `schema:"quiet"`
+		Replace   bool     `schema:"replace"`
+		TLSVerify bool     `schema:"tlsVerify"`
+	}{
+		Pull:      true,
+		TLSVerify: true,
+	}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Label == "" || query.Image == "" {
+		utils.Error(w, http.StatusBadRequest, errors.New("label and image parameters must be set"))
+		return
+	}
+
+	authConf, authfile, err := auth.GetCredentials(r)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	defer auth.RemoveAuthfile(authfile)
+
+	writer := channel.NewWriter(make(chan []byte))
+	defer writer.Close()
+
+	options := entities.ContainerRunlabelOptions{
+		Authfile:  authfile,
+		Display:   query.Display,
+		Name:      query.Name,
+		Optional1: query.Opt1,
+		Optional2: query.Opt2,
+		Optional3: query.Opt3,
+		Pull:      query.Pull,
+		Quiet:     query.Quiet,
+		Replace:   query.Replace,
+		Writer:    writer,
+	}
+	if _, found := r.URL.Query()["tlsVerify"]; found {
+		options.SkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
+	}
+	if authConf != nil {
+		options.Username = authConf.Username
+		options.Password = authConf.Password
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	var runlabelError error
+	runCtx, cancel := context.WithCancel(r.Context())
+	go func() {
+		defer cancel()
+		runlabelError = containerEngine.ContainerRunlabel(runCtx, query.Label, query.Image, query.Args, options)
+	}()
+
+	flush := func() {
+		if flusher, ok := w.(http.Flusher); ok {
+			flusher.Flush()
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flush()
+
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(true)
+	for {
+		var report entities.ContainerRunlabelReport
+		select {
+		case s := <-writer.Chan():
+			report.Stream = string(s)
+			if err := enc.Encode(report); err != nil {
+				logrus.Warnf("Failed to encode json: %v", err)
+			}
+			flush()
+		case <-runCtx.Done():
+			if runlabelError == nil {
+				return
+			}
+			report.Error = runlabelError.Error()
+			if err := enc.Encode(report); err != nil {
+				logrus.Warnf("Failed to encode json: %v", err)
+			}
+			flush()
+			return
+		case <-r.Context().Done():
+			// Client has closed connection
+			return
+		}
+	}
+}
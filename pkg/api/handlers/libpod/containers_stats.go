@@ -9,11 +9,13 @@ import (
 	"net/http"
 
 	"github.com/dmikushin/podman-shared/libpod"
+	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
 	api "github.com/dmikushin/podman-shared/pkg/api/types"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/dmikushin/podman-shared/pkg/domain/infra/abi"
 	"github.com/dmikushin/podman-shared/pkg/rootless"
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/gorilla/schema"
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/pkg/cgroups"
@@ -93,3 +95,49 @@ func StatsContainer(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+// StatsHistoryContainer returns the stats history of one or more containers.
+func StatsHistoryContainer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+
+	query := struct {
+		Containers []string `schema:"containers"`
+		All        bool     `schema:"all"`
+		Since      string   `schema:"since"`
+		Until      string   `schema:"until"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Since != "" {
+		if _, err := util.ParseInputTime(query.Since, true); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	if query.Until != "" {
+		if _, err := util.ParseInputTime(query.Until, false); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	historyOptions := entities.ContainerStatsHistoryOptions{
+		All:   query.All,
+		Since: query.Since,
+		Until: query.Until,
+	}
+	reports, err := containerEngine.ContainerStatsHistory(r.Context(), query.Containers, historyOptions)
+	if err != nil {
+		if errors.Is(err, define.ErrNoSuchCtr) {
+			utils.ContainerNotFound(w, "", err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
@@ -93,6 +93,29 @@ func GenerateSystemd(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, report.Units)
 }
 
+func GenerateQuadlet(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		NoHeader bool `schema:"noHeader"`
+	}{}
+
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	options := entities.GenerateQuadletOptions{NoHeader: query.NoHeader}
+	report, err := containerEngine.GenerateQuadlet(r.Context(), utils.GetName(r), options)
+	if err != nil {
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("generating Quadlet units: %w", err))
+		return
+	}
+
+	utils.WriteResponse(w, http.StatusOK, report.Units)
+}
+
 func GenerateKube(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
@@ -100,6 +123,7 @@ func GenerateKube(w http.ResponseWriter, r *http.Request) {
 		PodmanOnly bool     `schema:"podmanOnly"`
 		Names      []string `schema:"names"`
 		Service    bool     `schema:"service"`
+		Ingress    string   `schema:"ingress"`
 		Type       string   `schema:"type"`
 		Replicas   int32    `schema:"replicas"`
 		NoTrunc    bool     `schema:"noTrunc"`
@@ -128,6 +152,7 @@ func GenerateKube(w http.ResponseWriter, r *http.Request) {
 	options := entities.GenerateKubeOptions{
 		PodmanOnly:         query.PodmanOnly,
 		Service:            query.Service,
+		Ingress:            query.Ingress,
 		Type:               generateType,
 		Replicas:           query.Replicas,
 		UseLongAnnotations: query.NoTrunc,
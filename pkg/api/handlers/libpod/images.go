@@ -16,11 +16,13 @@ import (
 	"strings"
 
 	"github.com/containers/buildah"
+	"github.com/dmikushin/podman-shared/internal/remote_build_helpers"
 	"github.com/dmikushin/podman-shared/libpod"
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/api/handlers"
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
 	api "github.com/dmikushin/podman-shared/pkg/api/types"
+	"github.com/dmikushin/podman-shared/pkg/auth"
 	"github.com/dmikushin/podman-shared/pkg/bindings/images"
 	"github.com/dmikushin/podman-shared/pkg/channel"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
@@ -28,6 +30,8 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/domain/infra/abi"
 	domainUtils "github.com/dmikushin/podman-shared/pkg/domain/utils"
 	"github.com/dmikushin/podman-shared/pkg/errorhandling"
+	"github.com/dmikushin/podman-shared/pkg/sbom"
+	"github.com/dmikushin/podman-shared/pkg/scan"
 	"github.com/dmikushin/podman-shared/pkg/util"
 	utils2 "github.com/dmikushin/podman-shared/utils"
 	"github.com/docker/docker/pkg/jsonmessage"
@@ -79,6 +83,7 @@ func ImageTree(w http.ResponseWriter, r *http.Request) {
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 	query := struct {
 		WhatRequires bool `schema:"whatrequires"`
+		DiskUsage    bool `schema:"du"`
 	}{
 		WhatRequires: false,
 	}
@@ -87,19 +92,157 @@ func ImageTree(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	ir := abi.ImageEngine{Libpod: runtime}
-	options := entities.ImageTreeOptions{WhatRequires: query.WhatRequires}
+	options := entities.ImageTreeOptions{WhatRequires: query.WhatRequires, DiskUsage: query.DiskUsage}
 	report, err := ir.Tree(r.Context(), name, options)
 	if err != nil {
 		if errors.Is(err, storage.ErrImageUnknown) {
 			utils.Error(w, http.StatusNotFound, fmt.Errorf("failed to find image %s: %w", name, err))
 			return
 		}
+		if errors.Is(err, define.ErrInvalidArg) {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
 		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to generate image tree for %s: %w", name, err))
 		return
 	}
 	utils.WriteResponse(w, http.StatusOK, report)
 }
 
+func ImageSBOM(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Format    string `schema:"format"`
+		Attach    string `schema:"attach"`
+		TLSVerify bool   `schema:"tlsVerify"`
+	}{
+		TLSVerify: true,
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	if query.Format != "" {
+		if _, err := sbom.MediaType(query.Format); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	options := entities.ImageSBOMOptions{
+		Format: query.Format,
+		Attach: query.Attach,
+	}
+	if query.Attach != "" {
+		authconf, authfile, err := auth.GetCredentials(r)
+		if err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		defer auth.RemoveAuthfile(authfile)
+		options.Authfile = authfile
+		if authconf != nil {
+			options.Username = authconf.Username
+			options.Password = authconf.Password
+		}
+		if _, found := r.URL.Query()["tlsVerify"]; found {
+			options.SkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
+		}
+	}
+
+	ir := abi.ImageEngine{Libpod: runtime}
+	report, err := ir.SBOM(r.Context(), name, options)
+	if err != nil {
+		if errors.Is(err, storage.ErrImageUnknown) {
+			utils.Error(w, http.StatusNotFound, fmt.Errorf("failed to find image %s: %w", name, err))
+			return
+		}
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to generate the SBOM of %s: %w", name, err))
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func ImageScan(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Scanner   string `schema:"scanner"`
+		Threshold string `schema:"threshold"`
+		RunPolicy bool   `schema:"runPolicy"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Threshold != "" {
+		if _, err := scan.ParseSeverity(query.Threshold); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	ir := abi.ImageEngine{Libpod: runtime}
+	options := entities.ImageScanOptions{
+		Scanner:   query.Scanner,
+		Threshold: query.Threshold,
+		RunPolicy: query.RunPolicy,
+	}
+	report, err := ir.Scan(r.Context(), name, options)
+	if err != nil {
+		if errors.Is(err, storage.ErrImageUnknown) {
+			utils.Error(w, http.StatusNotFound, fmt.Errorf("failed to find image %s: %w", name, err))
+			return
+		}
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to scan image %s: %w", name, err))
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
+func ImageOptimize(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	name := utils.GetName(r)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Target           string `schema:"target"`
+		MergeThreshold   int64  `schema:"mergethreshold"`
+		CompressionLevel *int   `schema:"compressionlevel"`
+	}{}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Target == "" {
+		utils.Error(w, http.StatusBadRequest, errors.New("the name of the optimized image must be set with target"))
+		return
+	}
+	if _, err := libimage.NormalizeName(query.Target); err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ir := abi.ImageEngine{Libpod: runtime}
+	options := entities.ImageOptimizeOptions{
+		MergeThreshold:   query.MergeThreshold,
+		CompressionLevel: query.CompressionLevel,
+	}
+	report, err := ir.Optimize(r.Context(), name, query.Target, options)
+	if err != nil {
+		if errors.Is(err, storage.ErrImageUnknown) {
+			utils.Error(w, http.StatusNotFound, fmt.Errorf("failed to find image %s: %w", name, err))
+			return
+		}
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to optimize image %s: %w", name, err))
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
+
 func GetImage(w http.ResponseWriter, r *http.Request) {
 	name := utils.GetName(r)
 	newImage, err := utils.GetImage(r, name)
@@ -198,7 +341,7 @@ func ExportImage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	switch query.Format {
-	case define.OCIArchive, define.V2s2Archive:
+	case define.OCIArchive, define.V2s2Archive, define.OCIArtifactArchive:
 		tmpfile, err := os.CreateTemp("", "api.tar")
 		if err != nil {
 			utils.Error(w, http.StatusInternalServerError, fmt.Errorf("unable to create tempfile: %w", err))
@@ -278,14 +421,14 @@ func ExportImages(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Format is mandatory! Currently, we only support multi-image docker
-	// archives.
-	if len(query.References) > 1 && query.Format != define.V2s2Archive {
-		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("multi-image archives must use format of %s", define.V2s2Archive))
+	// archives and archives of images with their referrers.
+	if len(query.References) > 1 && query.Format != define.V2s2Archive && query.Format != define.OCIArtifactArchive {
+		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("multi-image archives must use format of %s or %s", define.V2s2Archive, define.OCIArtifactArchive))
 		return
 	}
 
 	switch query.Format {
-	case define.V2s2Archive, define.OCIArchive:
+	case define.V2s2Archive, define.OCIArchive, define.OCIArtifactArchive:
 		tmpfile, err := os.CreateTemp("", "api.tar")
 		if err != nil {
 			utils.Error(w, http.StatusInternalServerError, fmt.Errorf("unable to create tempfile: %w", err))
@@ -326,7 +469,7 @@ func ExportImages(w http.ResponseWriter, r *http.Request) {
 
 	// If we already produced a tar archive, let's stream that directly.
 	switch query.Format {
-	case define.V2s2Archive, define.OCIArchive:
+	case define.V2s2Archive, define.OCIArchive, define.OCIArtifactArchive:
 		rdr, err := os.Open(output)
 		if err != nil {
 			utils.Error(w, http.StatusInternalServerError, fmt.Errorf("failed to read the exported tarfile: %w", err))
@@ -810,3 +953,24 @@ func ImageResolve(w http.ResponseWriter, r *http.Request) {
 	report := handlers.LibpodImagesResolveReport{Names: names}
 	utils.WriteResponse(w, http.StatusOK, report)
 }
+
+// BuildContextMissing returns the digests of build context files which are not
+// in the build context cache.  Remote clients only send the content of those
+// files, the others are restored from the cache.
+func BuildContextMissing(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+
+	var digests []string
+	if err := json.NewDecoder(r.Body).Decode(&digests); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("decode(): %w", err))
+		return
+	}
+
+	cache := remote_build_helpers.NewContextCache(remote_build_helpers.ContextCacheDir(runtime.StorageConfig().GraphRoot))
+	missing, err := cache.Missing(digests)
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, missing)
+}
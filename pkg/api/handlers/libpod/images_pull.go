@@ -16,10 +16,10 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/auth"
 	"github.com/dmikushin/podman-shared/pkg/channel"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/gorilla/schema"
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/libimage"
-	"go.podman.io/common/pkg/config"
 	"go.podman.io/image/v5/types"
 )
 
@@ -33,7 +33,9 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 	query := struct {
 		AllTags    bool   `schema:"allTags"`
 		CompatMode bool   `schema:"compatMode"`
+		MaxAge     string `schema:"maxage"`
 		PullPolicy string `schema:"policy"`
+		Progress   bool   `schema:"progress"`
 		Quiet      bool   `schema:"quiet"`
 		Reference  string `schema:"reference"`
 		Retry      uint   `schema:"retry"`
@@ -94,11 +96,22 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 		pullOptions.IdentityToken = authConf.IdentityToken
 	}
 
-	pullPolicy, err := config.ParsePullPolicy(query.PullPolicy)
+	pullPolicy, withMaxAge, err := util.ParsePullPolicy(query.PullPolicy)
 	if err != nil {
 		utils.Error(w, http.StatusBadRequest, err)
 		return
 	}
+	var maxAge time.Duration
+	if withMaxAge {
+		if query.MaxAge == "" {
+			query.MaxAge = util.DefaultPullMaxAge
+		}
+		maxAge, err = util.ParsePullMaxAge(query.MaxAge)
+		if err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+	}
 
 	if _, found := r.URL.Query()["retry"]; found {
 		pullOptions.MaxRetries = &query.Retry
@@ -115,7 +128,7 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 
 	// Let's keep thing simple when running in quiet mode and pull directly.
 	if query.Quiet {
-		images, err := runtime.LibimageRuntime().Pull(r.Context(), query.Reference, pullPolicy, pullOptions)
+		images, err := runtime.PullImage(r.Context(), query.Reference, pullPolicy, maxAge, pullOptions)
 		var report entities.ImagePullReport
 		if err != nil {
 			report.Error = err.Error()
@@ -130,7 +143,7 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if query.CompatMode {
-		utils.CompatPull(r.Context(), w, runtime, query.Reference, pullPolicy, pullOptions)
+		utils.CompatPull(r.Context(), w, runtime, query.Reference, pullPolicy, maxAge, pullOptions)
 		return
 	}
 
@@ -138,12 +151,18 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 	defer writer.Close()
 	pullOptions.Writer = writer
 
+	var progress chan types.ProgressProperties
+	if query.Progress {
+		progress = make(chan types.ProgressProperties)
+		pullOptions.Progress = progress
+	}
+
 	var pulledImages []*libimage.Image
 	var pullError error
 	runCtx, cancel := context.WithCancel(r.Context())
 	go func() {
 		defer cancel()
-		pulledImages, pullError = runtime.LibimageRuntime().Pull(runCtx, query.Reference, pullPolicy, pullOptions)
+		pulledImages, pullError = runtime.PullImage(runCtx, query.Reference, pullPolicy, maxAge, pullOptions)
 	}()
 
 	flush := func() {
@@ -167,6 +186,12 @@ func ImagesPull(w http.ResponseWriter, r *http.Request) {
 				logrus.Warnf("Failed to encode json: %v", err)
 			}
 			flush()
+		case e := <-progress:
+			report.Progress = utils.CopyProgressFrame(e)
+			if err := enc.Encode(report); err != nil {
+				logrus.Warnf("Failed to encode json: %v", err)
+			}
+			flush()
 		case <-runCtx.Done():
 			for _, image := range pulledImages {
 				report.Images = append(report.Images, image.ID())
@@ -33,6 +33,7 @@ func PushImage(w http.ResponseWriter, r *http.Request) {
 		ForceCompressionFormat bool   `schema:"forceCompressionFormat"`
 		Destination            string `schema:"destination"`
 		Format                 string `schema:"format"`
+		Progress               bool   `schema:"progress"`
 		RemoveSignatures       bool   `schema:"removeSignatures"`
 		Retry                  uint   `schema:"retry"`
 		RetryDelay             string `schema:"retryDelay"`
@@ -107,6 +108,12 @@ func PushImage(w http.ResponseWriter, r *http.Request) {
 		options.SkipTLSVerify = types.NewOptionalBool(!query.TLSVerify)
 	}
 
+	// Structured progress is streamed, so it implies quiet=false unless
+	// quiet was requested explicitly.
+	if _, found := r.URL.Query()["quiet"]; !found && query.Progress {
+		query.Quiet = false
+	}
+
 	imageEngine := abi.ImageEngine{Libpod: runtime}
 
 	// Let's keep thing simple when running in quiet mode and push directly.
@@ -123,6 +130,9 @@ func PushImage(w http.ResponseWriter, r *http.Request) {
 	writer := channel.NewWriter(make(chan []byte))
 	defer writer.Close()
 	options.Writer = writer
+	if query.Progress {
+		options.Progress = make(chan types.ProgressProperties)
+	}
 
 	pushCtx, pushCancel := context.WithCancel(r.Context())
 	var pushError error
@@ -153,6 +163,12 @@ func PushImage(w http.ResponseWriter, r *http.Request) {
 				logrus.Warnf("Failed to encode json: %v", err)
 			}
 			flush()
+		case e := <-options.Progress:
+			stream.Progress = utils.CopyProgressFrame(e)
+			if err := enc.Encode(stream); err != nil {
+				logrus.Warnf("Failed to encode json: %v", err)
+			}
+			flush()
 		case <-pushCtx.Done():
 			if pushReport != nil {
 				stream.ManifestDigest = pushReport.ManifestDigest
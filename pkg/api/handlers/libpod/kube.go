@@ -109,6 +109,7 @@ func KubePlay(w http.ResponseWriter, r *http.Request) {
 		LogDriver        string            `schema:"logDriver"`
 		LogOptions       []string          `schema:"logOptions"`
 		Network          []string          `schema:"network"`
+		NetworkPolicy    bool              `schema:"networkPolicy"`
 		NoHostname       bool              `schema:"noHostname"`
 		NoHosts          bool              `schema:"noHosts"`
 		NoTrunc          bool              `schema:"noTrunc"`
@@ -183,6 +184,7 @@ func KubePlay(w http.ResponseWriter, r *http.Request) {
 		LogDriver:          logDriver,
 		LogOptions:         query.LogOptions,
 		Networks:           query.Network,
+		NetworkPolicy:      query.NetworkPolicy,
 		NoHostname:         query.NoHostname,
 		NoHosts:            query.NoHosts,
 		Password:           password,
@@ -220,9 +222,14 @@ func KubePlayDown(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 	query := struct {
-		Force bool `schema:"force"`
+		Force      bool  `schema:"force"`
+		Volumes    bool  `schema:"volumes"`
+		ConfigMaps bool  `schema:"configmaps"`
+		Secrets    bool  `schema:"secrets"`
+		Timeout    *uint `schema:"timeout"`
 	}{
-		Force: false,
+		Force:   false,
+		Secrets: true,
 	}
 
 	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
@@ -231,7 +238,14 @@ func KubePlayDown(w http.ResponseWriter, r *http.Request) {
 	}
 
 	containerEngine := abi.ContainerEngine{Libpod: runtime}
-	report, err := containerEngine.PlayKubeDown(r.Context(), r.Body, entities.PlayKubeDownOptions{Force: query.Force})
+	options := entities.PlayKubeDownOptions{
+		Force:       query.Force,
+		Volumes:     query.Volumes,
+		ConfigMaps:  query.ConfigMaps,
+		KeepSecrets: !query.Secrets,
+		Timeout:     query.Timeout,
+	}
+	report, err := containerEngine.PlayKubeDown(r.Context(), r.Body, options)
 	if err != nil {
 		utils.Error(w, http.StatusInternalServerError, fmt.Errorf("tearing down YAML file: %w", err))
 		return
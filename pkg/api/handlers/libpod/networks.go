@@ -11,6 +11,7 @@ import (
 
 	"github.com/dmikushin/podman-shared/libpod"
 	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/api/handlers"
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
 	api "github.com/dmikushin/podman-shared/pkg/api/types"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
@@ -76,6 +77,48 @@ func UpdateNetwork(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusNoContent, nil)
 }
 
+func WireGuardPeer(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	ic := abi.ContainerEngine{Libpod: runtime}
+
+	peer, err := ic.NetworkWireGuardPeer(r.Context(), utils.GetName(r))
+	if err != nil {
+		switch {
+		case errors.Is(err, define.ErrNoSuchNetwork):
+			utils.Error(w, http.StatusNotFound, err)
+		case errors.Is(err, define.ErrInvalidArg):
+			utils.Error(w, http.StatusBadRequest, err)
+		default:
+			utils.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, peer)
+}
+
+func SetWireGuardPeers(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	ic := abi.ContainerEngine{Libpod: runtime}
+
+	var peers []entities.NetworkWireGuardPeer
+	if err := json.NewDecoder(r.Body).Decode(&peers); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to decode request JSON payload: %w", err))
+		return
+	}
+	if err := ic.NetworkWireGuardSetPeers(r.Context(), utils.GetName(r), peers); err != nil {
+		switch {
+		case errors.Is(err, define.ErrNoSuchNetwork):
+			utils.Error(w, http.StatusNotFound, err)
+		case errors.Is(err, define.ErrInvalidArg):
+			utils.Error(w, http.StatusBadRequest, err)
+		default:
+			utils.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusNoContent, nil)
+}
+
 func ListNetworks(w http.ResponseWriter, r *http.Request) {
 	if v, err := utils.SupportedVersion(r, ">=4.0.0"); err != nil {
 		utils.BadRequest(w, "version", v.String(), err)
@@ -249,3 +292,50 @@ func Prune(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.WriteResponse(w, http.StatusOK, pruneReports)
 }
+
+// Reload recreates the firewall rules of container networks.
+func Reload(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	query := struct {
+		All        bool     `schema:"all"`
+		Graceful   bool     `schema:"graceful"`
+		Containers []string `schema:"containers"`
+	}{}
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if !query.All && len(query.Containers) == 0 {
+		utils.Error(w, http.StatusBadRequest, errors.New("either all or at least one container must be given"))
+		return
+	}
+
+	ic := abi.ContainerEngine{Libpod: runtime}
+	options := entities.NetworkReloadOptions{
+		All:      query.All,
+		Graceful: query.Graceful,
+	}
+	reloadReports, err := ic.NetworkReload(r.Context(), query.Containers, options)
+	if err != nil {
+		switch {
+		case errors.Is(err, define.ErrNoSuchCtr):
+			utils.Error(w, http.StatusNotFound, err)
+		case errors.Is(err, define.ErrInvalidArg):
+			utils.Error(w, http.StatusBadRequest, err)
+		default:
+			utils.Error(w, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	reports := make([]handlers.LibpodNetworkReloadReport, 0, len(reloadReports))
+	for _, report := range reloadReports {
+		rr := handlers.LibpodNetworkReloadReport{ID: report.Id}
+		if report.Err != nil {
+			rr.ReloadError = report.Err.Error()
+		}
+		reports = append(reports, rr)
+	}
+	utils.WriteResponse(w, http.StatusOK, reports)
+}
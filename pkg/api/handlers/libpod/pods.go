@@ -7,11 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/dmikushin/podman-shared/libpod"
 	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/libpod/logs"
 	"github.com/dmikushin/podman-shared/pkg/api/handlers"
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
 	api "github.com/dmikushin/podman-shared/pkg/api/types"
@@ -88,23 +91,39 @@ func PodCreate(w http.ResponseWriter, r *http.Request) {
 
 func Pods(w http.ResponseWriter, r *http.Request) {
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+	query := struct {
+		Fields []string `schema:"fields"`
+		Limit  int      `schema:"limit"`
+		Offset int      `schema:"offset"`
+	}{}
 
 	filterMap, err := util.PrepareFilters(r)
 	if err != nil {
 		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
 		return
 	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+	if query.Limit < 0 || query.Offset < 0 {
+		utils.Error(w, http.StatusBadRequest, errors.New("limit and offset must not be negative"))
+		return
+	}
 
 	containerEngine := abi.ContainerEngine{Libpod: runtime}
 	podPSOptions := entities.PodPSOptions{
 		Filters: *filterMap,
+		Limit:   query.Limit,
+		Offset:  query.Offset,
 	}
 	pods, err := containerEngine.PodPs(r.Context(), podPSOptions)
 	if err != nil {
 		utils.Error(w, http.StatusInternalServerError, err)
 		return
 	}
-	utils.WriteResponse(w, http.StatusOK, pods)
+	utils.WriteListResponse(w, http.StatusOK, pods, query.Fields)
 }
 
 func PodInspect(w http.ResponseWriter, r *http.Request) {
@@ -623,3 +642,128 @@ func PodStats(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 }
+
+// PodLogs streams the log lines of the containers of a pod as JSON objects.
+func PodLogs(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
+
+	query := struct {
+		Container string `schema:"container"`
+		Follow    bool   `schema:"follow"`
+		Since     string `schema:"since"`
+		Until     string `schema:"until"`
+		Tail      string `schema:"tail"`
+	}{
+		Tail: "all",
+	}
+	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("failed to parse parameters for %s: %w", r.URL.String(), err))
+		return
+	}
+
+	name := utils.GetName(r)
+	pod, err := runtime.LookupPod(name)
+	if err != nil {
+		utils.PodNotFound(w, name, err)
+		return
+	}
+
+	options := &logs.LogOptions{
+		Follow: query.Follow,
+		Tail:   -1,
+		Multi:  true,
+	}
+	if query.Tail != "all" {
+		options.Tail, err = strconv.ParseInt(query.Tail, 0, 64)
+		if err != nil {
+			utils.BadRequest(w, "tail", query.Tail, err)
+			return
+		}
+	}
+	if query.Since != "" {
+		options.Since, err = util.ParseInputTime(query.Since, true)
+		if err != nil {
+			utils.BadRequest(w, "since", query.Since, err)
+			return
+		}
+	}
+	if query.Until != "" && query.Until != "0" {
+		options.Until, err = util.ParseInputTime(query.Until, false)
+		if err != nil {
+			utils.BadRequest(w, "until", query.Until, err)
+			return
+		}
+	}
+
+	var wg sync.WaitGroup
+	options.WaitGroup = &wg
+	logChannel := make(chan *logs.LogLine, 1)
+	if query.Container != "" {
+		ctr, err := runtime.LookupContainer(query.Container)
+		if err != nil {
+			utils.ContainerNotFound(w, query.Container, err)
+			return
+		}
+		if ctr.PodID() != pod.ID() {
+			utils.Error(w, http.StatusNotFound, fmt.Errorf("container %s is not in pod %s: %w", query.Container, name, define.ErrNoSuchCtr))
+			return
+		}
+		err = runtime.Log(r.Context(), []*libpod.Container{ctr}, options, logChannel)
+	} else {
+		err = runtime.PodLog(r.Context(), pod, options, logChannel)
+	}
+	if err != nil {
+		utils.InternalServerError(w, fmt.Errorf("failed to obtain logs for pod %s: %w", name, err))
+		return
+	}
+	go func() {
+		wg.Wait()
+		close(logChannel)
+	}()
+
+	flush := func() {}
+	if flusher, ok := w.(http.Flusher); ok {
+		flush = flusher.Flush
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	flush()
+
+	coder := json.NewEncoder(w)
+	for line := range logChannel {
+		if err := coder.Encode(line); err != nil {
+			logrus.Infof("Error from %s %q : %v", r.Method, r.URL, err)
+		}
+		flush()
+	}
+}
+
+// PodUpdate changes the ports published by a pod.
+func PodUpdate(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+
+	options := new(entities.PodUpdateOptions)
+	if err := json.NewDecoder(r.Body).Decode(options); err != nil {
+		utils.Error(w, http.StatusBadRequest, fmt.Errorf("decoding request body: %w", err))
+		return
+	}
+	options.NameOrID = utils.GetName(r)
+
+	containerEngine := abi.ContainerEngine{Libpod: runtime}
+	report, err := containerEngine.PodUpdate(r.Context(), options)
+	if err != nil {
+		switch {
+		case errors.Is(err, define.ErrNoSuchPod):
+			utils.PodNotFound(w, options.NameOrID, err)
+		case errors.Is(err, define.ErrInvalidArg), errors.Is(err, define.ErrNetworkModeInvalid):
+			utils.Error(w, http.StatusBadRequest, err)
+		case errors.Is(err, define.ErrCtrStateInvalid):
+			utils.Error(w, http.StatusConflict, err)
+		default:
+			utils.InternalServerError(w, err)
+		}
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, report)
+}
@@ -7,19 +7,30 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
+	"github.com/dmikushin/podman-shared/version"
+	"github.com/gorilla/mux"
 	"go.podman.io/storage/pkg/fileutils"
+	"sigs.k8s.io/yaml"
 )
 
 // DefaultPodmanSwaggerSpec provides the default path to the podman swagger spec file
 const DefaultPodmanSwaggerSpec = "/usr/share/containers/podman/swagger.yaml"
 
-func ServeSwagger(w http.ResponseWriter, r *http.Request) {
-	path := DefaultPodmanSwaggerSpec
+// swaggerSpecPath returns the path of the swagger spec file.
+func swaggerSpecPath() string {
 	if p, found := os.LookupEnv("PODMAN_SWAGGER_SPEC"); found {
-		path = p
+		return p
 	}
+	return DefaultPodmanSwaggerSpec
+}
+
+func ServeSwagger(w http.ResponseWriter, r *http.Request) {
+	path := swaggerSpecPath()
 	if err := fileutils.Exists(path); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			utils.InternalServerError(w, fmt.Errorf("swagger spec %q does not exist", path))
@@ -31,3 +42,150 @@ func ServeSwagger(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/yaml")
 	http.ServeFile(w, r, path)
 }
+
+// ServeSwaggerJSON returns a handler serving the swagger spec as JSON for the
+// service: its version is the API version of the service, and it only
+// documents the operations of the routes of the router, so it reflects the
+// features of the service.  Without spec file, the spec only lists the
+// operations of the routes.
+func ServeSwaggerJSON(router *mux.Router) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		operations, err := routeOperations(router)
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+
+		spec, err := readSwaggerSpec(swaggerSpecPath())
+		switch {
+		case errors.Is(err, os.ErrNotExist):
+			spec = operations.spec()
+		case err != nil:
+			utils.InternalServerError(w, err)
+			return
+		default:
+			operations.filter(spec)
+		}
+
+		info, _ := spec["info"].(map[string]any)
+		if info == nil {
+			info = make(map[string]any)
+			spec["info"] = info
+		}
+		info["version"] = version.APIVersion[version.Libpod][version.CurrentAPI].String()
+		info["x-podman-version"] = version.Version.String()
+		info["x-compat-api-version"] = version.APIVersion[version.Compat][version.CurrentAPI].String()
+		utils.WriteResponse(w, http.StatusOK, spec)
+	}
+}
+
+// readSwaggerSpec reads the YAML swagger spec file at path.
+func readSwaggerSpec(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	spec := make(map[string]any)
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("parsing swagger spec %q: %w", path, err)
+	}
+	return spec, nil
+}
+
+// swaggerMethods are the keys of the operations of the path items of a spec.
+var swaggerMethods = []string{"get", "put", "post", "delete", "options", "head", "patch"}
+
+var (
+	// versionPrefixRegex matches the version prefix of versioned routes.
+	versionPrefixRegex = regexp.MustCompile(`^/v\{version:[^}]*\}`)
+	// pathParamRegex matches the parameters of route and spec paths.
+	pathParamRegex = regexp.MustCompile(`\{([^}:]*)(:[^}]*)?\}`)
+)
+
+// operationKey returns the key of the operation with the method on the path
+// of a route or the spec, ignoring the names of path parameters.
+func operationKey(method, path string) string {
+	return strings.ToUpper(method) + " " + pathParamRegex.ReplaceAllString(path, "{}")
+}
+
+// swaggerOperations are the operations of the routes of a router by their
+// keys, with the paths of the routes without patterns of path parameters.
+type swaggerOperations map[string]swaggerOperation
+
+type swaggerOperation struct {
+	method string
+	path   string
+}
+
+// routeOperations returns the operations of the routes of the router.
+func routeOperations(router *mux.Router) (swaggerOperations, error) {
+	operations := make(swaggerOperations)
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+		path = versionPrefixRegex.ReplaceAllString(path, "")
+		path = pathParamRegex.ReplaceAllString(path, "{$1}")
+		for _, method := range methods {
+			operations[operationKey(method, path)] = swaggerOperation{method: strings.ToLower(method), path: path}
+		}
+		return nil
+	})
+	return operations, err
+}
+
+// filter removes the operations of the spec without route.
+func (o swaggerOperations) filter(spec map[string]any) {
+	paths, _ := spec["paths"].(map[string]any)
+	for path, item := range paths {
+		item, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		operations := 0
+		for method := range item {
+			if !slices.Contains(swaggerMethods, method) {
+				continue
+			}
+			if _, found := o[operationKey(method, path)]; found {
+				operations++
+			} else {
+				delete(item, method)
+			}
+		}
+		if operations == 0 {
+			delete(paths, path)
+		}
+	}
+}
+
+// spec returns a spec documenting the operations without details.
+func (o swaggerOperations) spec() map[string]any {
+	paths := make(map[string]any)
+	for _, operation := range o {
+		item, ok := paths[operation.path].(map[string]any)
+		if !ok {
+			item = make(map[string]any)
+			paths[operation.path] = item
+		}
+		item[operation.method] = map[string]any{
+			"responses": map[string]any{
+				"default": map[string]any{"description": "See the API documentation of the Podman version."},
+			},
+		}
+	}
+	return map[string]any{
+		"swagger":  "2.0",
+		"basePath": "/",
+		"info": map[string]any{
+			"title":   "Podman API",
+			"license": map[string]any{"name": "Apache-2.0"},
+		},
+		"paths": paths,
+	}
+}
@@ -0,0 +1,88 @@
+//go:build !remote
+
+package libpod
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/version"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSwaggerSpec = `swagger: "2.0"
+info:
+  title: Test
+  version: 1.0.0
+paths:
+  /libpod/containers/{name}/json:
+    get:
+      operationId: ContainerInspectLibpod
+    parameters:
+      - name: name
+        in: path
+  /libpod/containers/{name}/start:
+    post:
+      operationId: ContainerStartLibpod
+    delete:
+      operationId: Removed
+  /libpod/disabled:
+    get:
+      operationId: Disabled
+`
+
+func newTestSwaggerRouter() *mux.Router {
+	router := mux.NewRouter()
+	handler := func(http.ResponseWriter, *http.Request) {}
+	router.HandleFunc("/v{version:[0-9][0-9A-Za-z.-]*}/libpod/containers/{id:.*}/json", handler).Methods(http.MethodGet)
+	router.HandleFunc("/v{version:[0-9][0-9A-Za-z.-]*}/libpod/containers/{name}/start", handler).Methods(http.MethodPost)
+	router.HandleFunc("/libpod/_ping", handler).Methods(http.MethodGet, http.MethodHead)
+	return router
+}
+
+func getSwaggerJSON(t *testing.T, router *mux.Router) map[string]any {
+	w := httptest.NewRecorder()
+	ServeSwaggerJSON(router)(w, httptest.NewRequest(http.MethodGet, "/libpod/swagger.json", nil))
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	spec := make(map[string]any)
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	info := spec["info"].(map[string]any)
+	assert.Equal(t, version.APIVersion[version.Libpod][version.CurrentAPI].String(), info["version"])
+	assert.Equal(t, version.Version.String(), info["x-podman-version"])
+	return spec
+}
+
+func TestServeSwaggerJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "swagger.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testSwaggerSpec), 0o644))
+	t.Setenv("PODMAN_SWAGGER_SPEC", path)
+
+	spec := getSwaggerJSON(t, newTestSwaggerRouter())
+	assert.Equal(t, "Test", spec["info"].(map[string]any)["title"])
+	paths := spec["paths"].(map[string]any)
+	assert.Len(t, paths, 2)
+	inspect := paths["/libpod/containers/{name}/json"].(map[string]any)
+	assert.Contains(t, inspect, "get")
+	assert.Contains(t, inspect, "parameters")
+	start := paths["/libpod/containers/{name}/start"].(map[string]any)
+	assert.Contains(t, start, "post")
+	assert.NotContains(t, start, "delete")
+}
+
+func TestServeSwaggerJSONWithoutSpec(t *testing.T) {
+	t.Setenv("PODMAN_SWAGGER_SPEC", filepath.Join(t.TempDir(), "missing.yaml"))
+
+	spec := getSwaggerJSON(t, newTestSwaggerRouter())
+	assert.Equal(t, "2.0", spec["swagger"])
+	paths := spec["paths"].(map[string]any)
+	assert.Len(t, paths, 3)
+	assert.Contains(t, paths["/libpod/containers/{id}/json"], "get")
+	assert.Contains(t, paths["/libpod/_ping"], "get")
+	assert.Contains(t, paths["/libpod/_ping"], "head")
+}
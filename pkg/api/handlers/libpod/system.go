@@ -3,11 +3,13 @@
 package libpod
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/dmikushin/podman-shared/libpod"
+	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
 	api "github.com/dmikushin/podman-shared/pkg/api/types"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
@@ -26,6 +28,7 @@ func SystemPrune(w http.ResponseWriter, r *http.Request) {
 		Volumes  bool `schema:"volumes"`
 		External bool `schema:"external"`
 		Build    bool `schema:"build"`
+		DryRun   bool `schema:"dry_run"`
 	}{}
 
 	if err := decoder.Decode(&query, r.URL.Query()); err != nil {
@@ -48,6 +51,7 @@ func SystemPrune(w http.ResponseWriter, r *http.Request) {
 		Filters:  *filterMap,
 		External: query.External,
 		Build:    query.Build,
+		DryRun:   query.DryRun,
 	}
 	report, err := containerEngine.SystemPrune(r.Context(), pruneOptions)
 	if err != nil {
@@ -71,6 +75,55 @@ func DiskUsage(w http.ResponseWriter, r *http.Request) {
 	utils.WriteResponse(w, http.StatusOK, response)
 }
 
+// Devices lists the CDI and GPU devices of the host
+func Devices(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	ic := abi.ContainerEngine{Libpod: runtime}
+	response, err := ic.DeviceList(r.Context())
+	if err != nil {
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, response)
+}
+
+// DBProfile returns the time spent running the queries of the database
+func DBProfile(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	response, err := runtime.DBProfile()
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, response)
+}
+
+// RegistryCacheStats returns the statistics of the registry cache
+func RegistryCacheStats(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	response, err := runtime.RegistryCacheStats()
+	if err != nil {
+		utils.Error(w, http.StatusBadRequest, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, response)
+}
+
+// RegistryCacheGC shrinks the registry cache to its maximum size
+func RegistryCacheGC(w http.ResponseWriter, r *http.Request) {
+	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+	response, err := runtime.RegistryCacheGC()
+	if err != nil {
+		if errors.Is(err, define.ErrInvalidArg) {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		utils.InternalServerError(w, err)
+		return
+	}
+	utils.WriteResponse(w, http.StatusOK, response)
+}
+
 func SystemCheck(w http.ResponseWriter, r *http.Request) {
 	decoder := r.Context().Value(api.DecoderKey).(*schema.Decoder)
 	runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
@@ -79,6 +132,7 @@ func SystemCheck(w http.ResponseWriter, r *http.Request) {
 		Quick                       bool   `schema:"quick"`
 		Repair                      bool   `schema:"repair"`
 		RepairLossy                 bool   `schema:"repair_lossy"`
+		RepairContainers            bool   `schema:"repair_containers"`
 		UnreferencedLayerMaximumAge string `schema:"unreferenced_layer_max_age"`
 	}{}
 
@@ -103,6 +157,7 @@ func SystemCheck(w http.ResponseWriter, r *http.Request) {
 		Quick:                       query.Quick,
 		Repair:                      query.Repair,
 		RepairLossy:                 query.RepairLossy,
+		RepairContainers:            query.RepairContainers,
 		UnreferencedLayerMaximumAge: unreferencedLayerMaximumAge,
 	}
 	report, err := containerEngine.SystemCheck(r.Context(), checkOptions)
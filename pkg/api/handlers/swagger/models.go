@@ -52,6 +52,10 @@ type networkConnectRequestLibpod entities.NetworkConnectOptions
 // swagger:model
 type networkUpdateRequestLibpod entities.NetworkUpdateOptions
 
+// WireGuard network peers
+// swagger:model
+type networkWireGuardPeersRequestLibpod []entities.NetworkWireGuardPeer
+
 // Container update
 // swagger:model
 type containerUpdateRequest struct {
@@ -12,6 +12,7 @@ import (
 	"github.com/docker/docker/api/types/container"
 	dockerImage "github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
+	dockerRegistry "github.com/docker/docker/api/types/registry"
 	"github.com/docker/docker/api/types/volume"
 	"go.podman.io/common/libnetwork/types"
 	"go.podman.io/image/v5/manifest"
@@ -24,6 +25,27 @@ type treeResponse struct {
 	Body entities.ImageTreeReport
 }
 
+// Image SBOM
+// swagger:response
+type imageSBOMResponse struct {
+	// in:body
+	Body entities.ImageSBOMReport
+}
+
+// Image vulnerability scan
+// swagger:response
+type imageScanResponse struct {
+	// in:body
+	Body entities.ImageScanReport
+}
+
+// Image optimize
+// swagger:response
+type imageOptimizeResponse struct {
+	// in:body
+	Body entities.ImageOptimizeReport
+}
+
 // Image History
 // swagger:response
 type history struct {
@@ -114,6 +136,13 @@ type registrySearchResponse struct {
 	}
 }
 
+// Distribution Inspect
+// swagger:response
+type distributionInspectResponse struct {
+	// in:body
+	Body dockerRegistry.DistributionInspect
+}
+
 // Inspect Image
 // swagger:response
 type inspectImageResponseLibpod struct {
@@ -310,6 +339,13 @@ type containerStats struct {
 	Body define.ContainerStats
 }
 
+// Stats history of one or more containers
+// swagger:response
+type containerStatsHistory struct {
+	// in:body
+	Body []entities.ContainerStatsHistoryReport
+}
+
 // Volume Prune
 // swagger:response
 type volumePruneLibpod struct {
@@ -324,6 +360,20 @@ type containerCreateResponse struct {
 	Body entities.ContainerCreateResponse
 }
 
+// Wait condition met
+// swagger:response
+type containerWaitConditionResponse struct {
+	// in:body
+	Body entities.WaitConditionReport
+}
+
+// Runlabel output
+// swagger:response
+type containerRunlabelResponse struct {
+	// in:body
+	Body entities.ContainerRunlabelReport
+}
+
 // Update container
 // swagger:response
 type containerUpdateResponse struct {
@@ -333,6 +383,13 @@ type containerUpdateResponse struct {
 	}
 }
 
+// Update container
+// swagger:response
+type containerUpdateCompatResponse struct {
+	// in:body
+	Body container.UpdateResponse
+}
+
 // Wait container
 // swagger:response
 type containerWaitResponse struct {
@@ -409,6 +466,13 @@ type podRestartResponse struct {
 	Body entities.PodRestartReport
 }
 
+// Update pod
+// swagger:response
+type podUpdateResponse struct {
+	// in:body
+	Body entities.PodUpdateReport
+}
+
 // Start pod
 // swagger:response
 type podStartResponse struct {
@@ -451,6 +515,13 @@ type networkInspectResponse struct {
 	Body entities.NetworkInspectReport
 }
 
+// WireGuard network peer
+// swagger:response
+type networkWireGuardPeerResponse struct {
+	// in:body
+	Body entities.NetworkWireGuardPeer
+}
+
 // Network list
 // swagger:response
 type networkListLibpod struct {
@@ -479,6 +550,13 @@ type networkPruneResponse struct {
 	Body []entities.NetworkPruneReport
 }
 
+// Network reload
+// swagger:response
+type networkReloadResponse struct {
+	// in:body
+	Body []handlers.LibpodNetworkReloadReport
+}
+
 // Inspect Artifact
 // swagger:response
 type inspectArtifactResponse struct {
@@ -521,6 +599,41 @@ type artifactPushResponse struct {
 	Body entities.ArtifactPushReport
 }
 
+// System devices
+// swagger:response
+type systemDevicesResponse struct {
+	// in:body
+	Body []entities.DeviceListReport
+}
+
+// Database query timing
+// swagger:response
+type systemDBProfileResponse struct {
+	// in:body
+	Body []define.DBQueryProfile
+}
+
+// Registry cache statistics
+// swagger:response
+type systemRegistryCacheResponse struct {
+	// in:body
+	Body define.RegistryCacheStats
+}
+
+// Hooks list
+// swagger:response
+type hooksListResponse struct {
+	// in:body
+	Body []entities.HookListReport
+}
+
+// Hooks test
+// swagger:response
+type hooksTestResponse struct {
+	// in:body
+	Body []entities.HookTestReport
+}
+
 // Quadlet list
 // swagger:response
 type quadletListResponse struct {
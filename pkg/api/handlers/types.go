@@ -78,14 +78,32 @@ type LibpodContainersRmReport struct {
 	RmError string `json:"Err,omitempty"`
 }
 
+type LibpodNetworkReloadReport struct {
+	ID string `json:"Id"`
+	// Error which occurred while reloading the container network (if any).
+	// This field is optional and may be omitted if no error occurred.
+	//
+	// Extensions:
+	// x-omitempty: true
+	// x-nullable: true
+	ReloadError string `json:"Err,omitempty"`
+}
+
 // UpdateEntities used to wrap the oci resource spec in a swagger model
 // swagger:model
 type UpdateEntities struct {
 	specs.LinuxResources
 	define.UpdateHealthCheckConfig
 	define.UpdateContainerDevicesLimits
+	define.UpdateContainerDevices
 	Env      []string
 	UnsetEnv []string
+	// MemoryReclaim is the amount of memory, in bytes, to reclaim from
+	// the running container.
+	MemoryReclaim int64 `json:",omitempty"`
+	// NetworkOptions are the bandwidth options to set per network, the
+	// empty network name sets them on all networks of the container.
+	NetworkOptions map[string]map[string]string `json:",omitempty"`
 }
 
 type Info struct {
@@ -147,11 +147,20 @@ func WaitContainerLibpod(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		InternalServerError(w, err)
+		return
 	}
 	if len(reports) != 1 {
 		Error(w, http.StatusInternalServerError, fmt.Errorf("the ContainerWait() function returned unexpected count of reports: %d", len(reports)))
 		return
 	}
+	if err := reports[0].Error; err != nil {
+		if errors.Is(err, define.ErrNoSuchCtr) || errors.Is(err, define.ErrCtrRemoved) {
+			ContainerNotFound(w, name, err)
+			return
+		}
+		InternalServerError(w, err)
+		return
+	}
 
 	WriteResponse(w, http.StatusOK, strconv.Itoa(int(reports[0].ExitCode)))
 }
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 	"unsafe"
 
 	"github.com/blang/semver/v4"
@@ -22,6 +23,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils/apiutil"
 	api "github.com/dmikushin/podman-shared/pkg/api/types"
 	"github.com/dmikushin/podman-shared/pkg/bindings/images"
+	entitiesTypes "github.com/dmikushin/podman-shared/pkg/domain/entities/types"
 )
 
 // IsLibpodRequest returns true if the request related to a libpod endpoint
@@ -80,6 +82,44 @@ func WriteResponse(w http.ResponseWriter, code int, value any) {
 	}
 }
 
+// WriteListResponse encodes the list value as JSON like WriteResponse, with
+// the objects of the list reduced to the given fields.  Fields are the JSON
+// keys of the objects, matched case-insensitively, and may be given as comma
+// separated lists.  Without fields the full objects are written.
+func WriteListResponse(w http.ResponseWriter, code int, value any, fields []string) {
+	selected := make(map[string]bool)
+	for _, field := range fields {
+		for name := range strings.SplitSeq(field, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				selected[strings.ToLower(name)] = true
+			}
+		}
+	}
+	if len(selected) == 0 {
+		WriteResponse(w, code, value)
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		InternalServerError(w, err)
+		return
+	}
+	var objects []map[string]jsoniter.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		InternalServerError(w, fmt.Errorf("selecting fields of list: %w", err))
+		return
+	}
+	for _, object := range objects {
+		for key := range object {
+			if !selected[strings.ToLower(key)] {
+				delete(object, key)
+			}
+		}
+	}
+	WriteJSON(w, code, objects)
+}
+
 func init() {
 	jsoniter.RegisterTypeEncoderFunc("error", MarshalErrorJSON, MarshalErrorJSONIsEmpty)
 	jsoniter.RegisterTypeEncoderFunc("[]error", MarshalErrorSliceJSON, MarshalErrorSliceJSONIsEmpty)
@@ -247,6 +287,11 @@ func (b *ResponseSender) SendBuildError(message string) {
 	b.Send(response)
 }
 
+// SendBuildProgress sends a structured progress report as build response.
+func (b *ResponseSender) SendBuildProgress(frame *entitiesTypes.ProgressFrame) {
+	b.Send(images.BuildResponse{Progress: frame})
+}
+
 // SendBuildAux sends auxiliary data as part of a build response.
 func (b *ResponseSender) SendBuildAux(aux []byte) {
 	b.Send(images.BuildResponse{Aux: aux})
@@ -113,6 +113,30 @@ func TestWriteJSONNoHTMLEscape(t *testing.T) {
 	}
 }
 
+func TestWriteListResponse(t *testing.T) {
+	items := []struct {
+		ID     string `json:"Id"`
+		Names  []string
+		Status string `json:",omitempty"`
+	}{
+		{ID: "a", Names: []string{"first"}, Status: "running"},
+		{ID: "b", Names: []string{"second"}},
+	}
+
+	recorder := httptest.NewRecorder()
+	WriteListResponse(recorder, 200, items, []string{"id,status", " names "})
+	assert.JSONEq(t, `[{"Id":"a","Names":["first"],"Status":"running"},{"Id":"b","Names":["second"]}]`, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	WriteListResponse(recorder, 200, items, []string{"Id"})
+	assert.JSONEq(t, `[{"Id":"a"},{"Id":"b"}]`, recorder.Body.String())
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	recorder = httptest.NewRecorder()
+	WriteListResponse(recorder, 200, items, nil)
+	assert.JSONEq(t, `[{"Id":"a","Names":["first"],"Status":"running"},{"Id":"b","Names":["second"]}]`, recorder.Body.String())
+}
+
 func TestParseOptionalJSONField(t *testing.T) {
 	t.Run("field exists with valid JSON", func(t *testing.T) {
 		jsonStr := `["item1", "item2"]`
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/dmikushin/podman-shared/libpod"
 	api "github.com/dmikushin/podman-shared/pkg/api/types"
@@ -124,13 +125,13 @@ type pullResult struct {
 	err    error
 }
 
-func CompatPull(ctx context.Context, w http.ResponseWriter, runtime *libpod.Runtime, reference string, pullPolicy config.PullPolicy, pullOptions *libimage.PullOptions) {
+func CompatPull(ctx context.Context, w http.ResponseWriter, runtime *libpod.Runtime, reference string, pullPolicy config.PullPolicy, maxAge time.Duration, pullOptions *libimage.PullOptions) {
 	progress := make(chan types.ProgressProperties)
 	pullOptions.Progress = progress
 
 	pullResChan := make(chan pullResult)
 	go func() {
-		pulledImages, err := runtime.LibimageRuntime().Pull(ctx, reference, pullPolicy, pullOptions)
+		pulledImages, err := runtime.PullImage(ctx, reference, pullPolicy, maxAge, pullOptions)
 		pullResChan <- pullResult{images: pulledImages, err: err}
 	}()
 
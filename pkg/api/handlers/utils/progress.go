@@ -0,0 +1,60 @@
+//go:build !remote
+
+package utils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	entitiesTypes "github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+	"go.podman.io/image/v5/types"
+)
+
+// buildStepRegex matches the step lines of buildah, e.g. "[1/2] STEP 3/5: RUN make".
+var buildStepRegex = regexp.MustCompile(`^(?:\[(\d+)/(\d+)\] )?STEP (\d+)/(\d+): `)
+
+// CopyProgressFrame converts a progress notification of c/image into a
+// progress frame.  The digest of the blob is used as ID.
+func CopyProgressFrame(e types.ProgressProperties) *entitiesTypes.ProgressFrame {
+	id := e.Artifact.Digest.String()
+	size := e.Artifact.Size
+	switch e.Event {
+	case types.ProgressEventNewArtifact:
+		return entitiesTypes.NewProgressFrame(id, entitiesTypes.ProgressStatusStarted, 0, size)
+	case types.ProgressEventSkipped:
+		return entitiesTypes.NewProgressFrame(id, entitiesTypes.ProgressStatusSkipped, size, size)
+	case types.ProgressEventDone:
+		return entitiesTypes.NewProgressFrame(id, entitiesTypes.ProgressStatusDone, size, size)
+	default:
+		return entitiesTypes.NewProgressFrame(id, entitiesTypes.ProgressStatusProgress, int64(e.Offset), size)
+	}
+}
+
+// BuildProgressFrames returns a progress frame for every step line in the
+// build output.  Each stage of a multi-stage build is reported with its own
+// ID, "stage-N", single-stage builds use "build".
+func BuildProgressFrames(output string) []*entitiesTypes.ProgressFrame {
+	var frames []*entitiesTypes.ProgressFrame
+	for line := range strings.SplitSeq(output, "\n") {
+		match := buildStepRegex.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		id := "build"
+		if match[1] != "" {
+			id = "stage-" + match[1]
+		}
+		// the regex only matches digits, errors are limited to overflows
+		step, _ := strconv.ParseInt(match[3], 10, 64)
+		steps, _ := strconv.ParseInt(match[4], 10, 64)
+		status := entitiesTypes.ProgressStatusProgress
+		if step == 1 {
+			status = entitiesTypes.ProgressStatusStarted
+		}
+		// a step is reported when it starts, so it is not done yet
+		frame := entitiesTypes.NewProgressFrame(id, status, step-1, steps)
+		frames = append(frames, frame)
+	}
+	return frames
+}
@@ -0,0 +1,57 @@
+//go:build !remote
+
+package utils
+
+import (
+	"testing"
+
+	entitiesTypes "github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+	"github.com/opencontainers/go-digest"
+	"github.com/stretchr/testify/assert"
+	"go.podman.io/image/v5/types"
+)
+
+func TestCopyProgressFrame(t *testing.T) {
+	blob := types.BlobInfo{Digest: digest.FromString("layer"), Size: 200}
+
+	tests := []struct {
+		event   types.ProgressEvent
+		offset  uint64
+		status  string
+		current int64
+		percent int
+	}{
+		{types.ProgressEventNewArtifact, 0, entitiesTypes.ProgressStatusStarted, 0, 0},
+		{types.ProgressEventRead, 50, entitiesTypes.ProgressStatusProgress, 50, 25},
+		{types.ProgressEventDone, 200, entitiesTypes.ProgressStatusDone, 200, 100},
+		{types.ProgressEventSkipped, 0, entitiesTypes.ProgressStatusSkipped, 200, 100},
+	}
+	for _, tt := range tests {
+		frame := CopyProgressFrame(types.ProgressProperties{Event: tt.event, Artifact: blob, Offset: tt.offset})
+		assert.Equal(t, blob.Digest.String(), frame.ID)
+		assert.Equal(t, tt.status, frame.Status)
+		assert.Equal(t, tt.current, frame.Current)
+		assert.Equal(t, int64(200), frame.Total)
+		assert.Equal(t, tt.percent, frame.Percent)
+	}
+
+	// the size of a blob is not always known
+	frame := CopyProgressFrame(types.ProgressProperties{Event: types.ProgressEventRead, Artifact: types.BlobInfo{Size: -1}, Offset: 10})
+	assert.Equal(t, -1, frame.Percent)
+}
+
+func TestBuildProgressFrames(t *testing.T) {
+	output := "STEP 1/4: FROM alpine\nSTEP 2/4: RUN true\n--> 1234\n"
+	frames := BuildProgressFrames(output)
+	assert.Equal(t, []*entitiesTypes.ProgressFrame{
+		{ID: "build", Status: entitiesTypes.ProgressStatusStarted, Current: 0, Total: 4, Percent: 0},
+		{ID: "build", Status: entitiesTypes.ProgressStatusProgress, Current: 1, Total: 4, Percent: 25},
+	}, frames)
+
+	frames = BuildProgressFrames("[2/2] STEP 3/3: COPY --from=builder /out /\n")
+	assert.Equal(t, []*entitiesTypes.ProgressFrame{
+		{ID: "stage-2", Status: entitiesTypes.ProgressStatusProgress, Current: 2, Total: 3, Percent: 66},
+	}, frames)
+
+	assert.Empty(t, BuildProgressFrames("COMMIT test\n"))
+}
@@ -51,11 +51,7 @@ func (s *APIServer) apiWrapper(h http.HandlerFunc, w http.ResponseWriter, r *htt
 	w.Header().Set("Libpod-API-Version", lv)
 	w.Header().Set("Server", "Libpod/"+lv+" ("+runtime.GOOS+")")
 
-	if s.CorsHeaders != "" {
-		w.Header().Set("Access-Control-Allow-Origin", s.CorsHeaders)
-		w.Header().Set("Access-Control-Allow-Headers", "Origin, X-Requested-With, Content-Type, Accept, X-Registry-Auth, Connection, Upgrade, X-Registry-Config")
-		w.Header().Set("Access-Control-Allow-Methods", "HEAD, GET, POST, DELETE, PUT, OPTIONS")
-	}
+	s.cors.setHeaders(w, r)
 
 	if buffer {
 		bw := newBufferedResponseWriter(w)
@@ -0,0 +1,140 @@
+//go:build !remote
+
+package server
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	corsAllowHeaders = "Origin, X-Requested-With, Content-Type, Accept, X-Registry-Auth, Connection, Upgrade, X-Registry-Config"
+	corsAllowMethods = "HEAD, GET, POST, DELETE, PUT, OPTIONS"
+	// corsMaxAge is the number of seconds browsers may cache the answer to
+	// a preflight request.
+	corsMaxAge = "600"
+)
+
+// corsRouteGroup is the format of route groups of --cors, the first segment
+// of the paths of the routes after the version and the libpod prefix.
+var corsRouteGroup = regexp.MustCompile(`^[a-z_]+$`)
+
+// corsConfig holds the origins allowed to make cross-origin requests to the
+// API, for all routes and per route group.
+type corsConfig struct {
+	// origins allowed for routes of groups without their own origins
+	origins []string
+	// groups maps route groups to their allowed origins
+	groups map[string][]string
+}
+
+// parseCors parses the --cors options of the service.  An option is either
+// a list of origins allowed for all routes, or GROUP=ORIGINS allowing the
+// origins for the routes of a group only.  An origin of "*" allows all
+// origins.  It returns nil if no option is set, empty options are ignored.
+func parseCors(options []string) (*corsConfig, error) {
+	cors := &corsConfig{groups: make(map[string][]string)}
+	for _, option := range options {
+		if option == "" {
+			continue
+		}
+		group, origins, hasGroup := strings.Cut(option, "=")
+		if !hasGroup {
+			origins = option
+		}
+		var list []string
+		for origin := range strings.SplitSeq(origins, ",") {
+			if origin = strings.TrimSpace(origin); origin != "" {
+				list = append(list, strings.TrimSuffix(origin, "/"))
+			}
+		}
+		if len(list) == 0 {
+			return nil, fmt.Errorf("CORS option %q does not allow any origin", option)
+		}
+		if !hasGroup {
+			cors.origins = append(cors.origins, list...)
+			continue
+		}
+		if !corsRouteGroup.MatchString(group) {
+			return nil, fmt.Errorf("invalid route group %q of CORS option %q", group, option)
+		}
+		cors.groups[group] = append(cors.groups[group], list...)
+	}
+	if len(cors.origins) == 0 && len(cors.groups) == 0 {
+		return nil, nil
+	}
+	return cors, nil
+}
+
+// routeGroup returns the route group of the URL path, e.g. "containers" for
+// /v5.0.0/libpod/containers/json.
+func routeGroup(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 1 && strings.HasPrefix(segments[0], "v") && strings.Trim(segments[0][1:], "0123456789.") == "" {
+		segments = segments[1:]
+	}
+	if len(segments) > 1 && segments[0] == "libpod" {
+		segments = segments[1:]
+	}
+	return segments[0]
+}
+
+// allowedOrigin returns the value of the Access-Control-Allow-Origin header
+// of the response to r, or "" if its origin may not access the route.
+func (c *corsConfig) allowedOrigin(r *http.Request) string {
+	if c == nil {
+		return ""
+	}
+	origins, ok := c.groups[routeGroup(r.URL.Path)]
+	if !ok {
+		origins = c.origins
+	}
+	if slices.Contains(origins, "*") {
+		return "*"
+	}
+	origin := r.Header.Get("Origin")
+	if origin != "" && slices.Contains(origins, origin) {
+		return origin
+	}
+	return ""
+}
+
+// setHeaders sets the CORS headers of the response to r.
+func (c *corsConfig) setHeaders(w http.ResponseWriter, r *http.Request) {
+	if c == nil {
+		return
+	}
+	// the response depends on the origin unless all origins are allowed
+	w.Header().Add("Vary", "Origin")
+	origin := c.allowedOrigin(r)
+	if origin == "" {
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Origin", origin)
+	w.Header().Set("Access-Control-Allow-Headers", corsAllowHeaders)
+	w.Header().Set("Access-Control-Allow-Methods", corsAllowMethods)
+}
+
+// corsHandler answers the preflight requests browsers send before
+// cross-origin requests, which do not match any route of the API.
+func (s *APIServer) corsHandler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.cors == nil || r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+			h.ServeHTTP(w, r)
+			return
+		}
+		s.cors.setHeaders(w, r)
+		if w.Header().Get("Access-Control-Allow-Origin") == "" {
+			logrus.Infof("Rejected CORS preflight request from origin %q for %s", r.Header.Get("Origin"), r.URL.Path)
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
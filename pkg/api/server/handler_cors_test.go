@@ -0,0 +1,70 @@
+//go:build !remote
+
+package server
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRouteGroup(t *testing.T) {
+	for path, group := range map[string]string{
+		"/v5.0.0/libpod/containers/json": "containers",
+		"/v1.41/containers/json":         "containers",
+		"/containers/abc/attach/ws":      "containers",
+		"/libpod/images/json":            "images",
+		"/events":                        "events",
+		"/version":                       "version",
+		"/v4.0.0/libpod/_ping":           "_ping",
+	} {
+		assert.Equal(t, group, routeGroup(path), path)
+	}
+}
+
+func TestParseCors(t *testing.T) {
+	cors, err := parseCors(nil)
+	require.NoError(t, err)
+	assert.Nil(t, cors)
+	cors, err = parseCors([]string{""})
+	require.NoError(t, err)
+	assert.Nil(t, cors)
+
+	_, err = parseCors([]string{"containers="})
+	assert.ErrorContains(t, err, "does not allow any origin")
+	_, err = parseCors([]string{"Containers/x=https://a.example"})
+	assert.ErrorContains(t, err, "invalid route group")
+
+	cors, err = parseCors([]string{"https://a.example/", "events=*", "images=https://b.example, https://c.example"})
+	require.NoError(t, err)
+	assert.Equal(t, &corsConfig{
+		origins: []string{"https://a.example"},
+		groups: map[string][]string{
+			"events": {"*"},
+			"images": {"https://b.example", "https://c.example"},
+		},
+	}, cors)
+
+	for _, tt := range []struct {
+		path, origin, allowed string
+	}{
+		{"/v5.0.0/libpod/containers/json", "https://a.example", "https://a.example"},
+		{"/v5.0.0/libpod/containers/json", "https://b.example", ""},
+		{"/v5.0.0/libpod/containers/json", "", ""},
+		{"/images/json", "https://c.example", "https://c.example"},
+		{"/images/json", "https://a.example", ""},
+		{"/events", "https://z.example", "*"},
+		{"/events", "", "*"},
+	} {
+		req := httptest.NewRequest("GET", tt.path, nil)
+		if tt.origin != "" {
+			req.Header.Set("Origin", tt.origin)
+		}
+		assert.Equal(t, tt.allowed, cors.allowedOrigin(req), "%s from %s", tt.path, tt.origin)
+	}
+
+	var disabled *corsConfig
+	assert.Empty(t, disabled.allowedOrigin(httptest.NewRequest("GET", "/events", nil)))
+}
@@ -0,0 +1,150 @@
+//go:build !remote
+
+package server
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"unicode"
+
+	"github.com/dmikushin/podman-shared/libpod"
+	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
+	api "github.com/dmikushin/podman-shared/pkg/api/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// idempotencyKeyHeader is the header of requests which must not
+	// create another object when they are retried.
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotentReplayedHeader marks responses of earlier requests returned
+	// to requests reusing their idempotency key.
+	idempotentReplayedHeader = "Idempotent-Replayed"
+	// maxIdempotencyKeyLength is the length limit of idempotency keys.
+	maxIdempotencyKeyLength = 255
+)
+
+// IdempotentHandler makes the handler h of the API requests creating objects
+// of the operation, e.g. "container create", idempotent for requests with an
+// Idempotency-Key header.  The response to the first request with a key is
+// saved, requests reusing the key get the saved response instead of creating
+// another object.  Keys of failed requests are released so the request can be
+// retried.
+func (s *APIServer) IdempotentHandler(h http.HandlerFunc, operation string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		keyValue := r.Header.Get(idempotencyKeyHeader)
+		if keyValue == "" {
+			h(w, r)
+			return
+		}
+		if err := validateIdempotencyKey(keyValue); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			utils.Error(w, http.StatusBadRequest, fmt.Errorf("reading request body: %w", err))
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		hash := sha256.New()
+		fmt.Fprintf(hash, "%s\n%s\n%s\n", r.Method, r.URL.RawQuery, r.Header.Get("Content-Type"))
+		hash.Write(body)
+
+		runtime := r.Context().Value(api.RuntimeKey).(*libpod.Runtime)
+		key := &libpod.IdempotencyKey{
+			Key:         keyValue,
+			Operation:   operation,
+			RequestHash: hex.EncodeToString(hash.Sum(nil)),
+		}
+		existing, err := runtime.ReserveIdempotencyKey(key)
+		if err != nil {
+			utils.InternalServerError(w, err)
+			return
+		}
+		if existing != nil {
+			replayIdempotentResponse(w, key, existing)
+			return
+		}
+
+		rec := &recordingResponseWriter{ResponseWriter: w}
+		h(rec, r)
+
+		if rec.statusCode < http.StatusOK || rec.statusCode >= http.StatusMultipleChoices {
+			if err := runtime.RemoveIdempotencyKey(keyValue); err != nil {
+				logrus.Errorf("Releasing idempotency key %q: %v", keyValue, err)
+			}
+			return
+		}
+		key.StatusCode = rec.statusCode
+		key.ContentType = rec.Header().Get("Content-Type")
+		key.Body = rec.body.Bytes()
+		if err := runtime.SaveIdempotencyKey(key); err != nil {
+			logrus.Errorf("Saving response of idempotency key %q: %v", keyValue, err)
+		}
+	}
+}
+
+// validateIdempotencyKey verifies the key is not too long and printable.
+func validateIdempotencyKey(key string) error {
+	if len(key) > maxIdempotencyKeyLength {
+		return fmt.Errorf("%s header must not be longer than %d characters", idempotencyKeyHeader, maxIdempotencyKeyLength)
+	}
+	for _, c := range key {
+		if c > unicode.MaxASCII || !unicode.IsPrint(c) {
+			return fmt.Errorf("%s header must only contain printable ASCII characters", idempotencyKeyHeader)
+		}
+	}
+	return nil
+}
+
+// replayIdempotentResponse answers a request reusing the idempotency key of
+// the existing request.
+func replayIdempotentResponse(w http.ResponseWriter, key, existing *libpod.IdempotencyKey) {
+	switch {
+	case existing.Operation != key.Operation || existing.RequestHash != key.RequestHash:
+		utils.Error(w, http.StatusUnprocessableEntity,
+			fmt.Errorf("%s %q was already used for another request", idempotencyKeyHeader, key.Key))
+	case existing.Pending():
+		utils.Error(w, http.StatusConflict,
+			fmt.Errorf("request with %s %q is being processed", idempotencyKeyHeader, key.Key))
+	default:
+		logrus.Debugf("Replaying response of %s request with %s %q", existing.Operation, idempotencyKeyHeader, key.Key)
+		if existing.ContentType != "" {
+			w.Header().Set("Content-Type", existing.ContentType)
+		}
+		w.Header().Set(idempotentReplayedHeader, "true")
+		w.WriteHeader(existing.StatusCode)
+		if _, err := w.Write(existing.Body); err != nil {
+			logrus.Errorf("Writing response of %s %q: %v", idempotencyKeyHeader, key.Key, err)
+		}
+	}
+}
+
+// recordingResponseWriter records the status code and the body of the
+// response.
+type recordingResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *recordingResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *recordingResponseWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
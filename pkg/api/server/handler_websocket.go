@@ -0,0 +1,196 @@
+//go:build !remote
+
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+
+	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
+	"github.com/dmikushin/podman-shared/pkg/api/server/idle"
+	"github.com/dmikushin/podman-shared/pkg/api/server/websocket"
+	api "github.com/dmikushin/podman-shared/pkg/api/types"
+	"github.com/sirupsen/logrus"
+)
+
+// WebSocketHandler serves the WebSocket upgrade requests of the streaming
+// handler h, which is already wrapped by an APIHandler.  Browsers cannot
+// hijack HTTP connections, the stream is sent to them as WebSocket messages
+// of messageType instead.  Requests without upgrade are passed to h as is.
+func (s *APIServer) WebSocketHandler(h http.HandlerFunc, messageType byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !websocket.IsUpgrade(r) {
+			h(w, r)
+			return
+		}
+		if err := websocket.CheckHandshake(r); err != nil {
+			utils.Error(w, http.StatusBadRequest, err)
+			return
+		}
+		// Browsers do not apply the same-origin policy to WebSockets,
+		// reject the origins CORS does not allow.
+		if origin := r.Header.Get("Origin"); origin != "" && s.cors.allowedOrigin(r) == "" {
+			utils.Error(w, http.StatusForbidden, fmt.Errorf("WebSocket connections from origin %q are not allowed", origin))
+			return
+		}
+
+		ctx, cancel := context.WithCancel(r.Context())
+		defer cancel()
+		ww := &webSocketResponseWriter{ResponseWriter: w, r: r, messageType: messageType, cancel: cancel}
+		h(ww, r.WithContext(ctx))
+
+		// Connections hijacked by the handler are closed by the handler,
+		// streams of other handlers end with the handler.
+		if ww.conn != nil && !ww.hijacked {
+			ww.Flush()
+			if err := ww.conn.Close(); err != nil && !errors.Is(err, net.ErrClosed) {
+				logrus.Debugf("Closing WebSocket connection: %v", err)
+			}
+			r.Context().Value(api.IdleTrackerKey).(*idle.Tracker).Close()
+		}
+	}
+}
+
+// webSocketResponseWriter upgrades the connection of a streaming handler to
+// the WebSocket protocol once the handler starts streaming.  Responses of
+// handlers failing before they start are sent as plain HTTP responses.
+type webSocketResponseWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	messageType byte
+	// cancel cancels the context of the request once the client closes
+	// the connection
+	cancel context.CancelFunc
+
+	conn *websocket.Conn
+	// message holds the output of the handler up to its next flush
+	message bytes.Buffer
+	// plain is set when the handler responded with an error
+	plain bool
+	// hijacked is set when the handler hijacked the connection
+	hijacked bool
+}
+
+func (w *webSocketResponseWriter) WriteHeader(statusCode int) {
+	switch {
+	case w.plain || w.conn != nil:
+	case statusCode >= http.StatusMultipleChoices:
+		w.plain = true
+		w.ResponseWriter.WriteHeader(statusCode)
+	default:
+		if err := w.startStream(); err != nil {
+			logrus.Errorf("Upgrading connection to WebSocket: %v", err)
+		}
+	}
+}
+
+func (w *webSocketResponseWriter) Write(b []byte) (int, error) {
+	if w.plain {
+		return w.ResponseWriter.Write(b)
+	}
+	if w.conn == nil {
+		if err := w.startStream(); err != nil {
+			return 0, err
+		}
+	}
+	return w.message.Write(b)
+}
+
+// Flush sends the output of the handler since the last flush as one message.
+func (w *webSocketResponseWriter) Flush() {
+	if w.plain {
+		if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+			flusher.Flush()
+		}
+		return
+	}
+	if w.conn == nil || w.message.Len() == 0 {
+		return
+	}
+	if _, err := w.conn.Write(w.message.Bytes()); err != nil {
+		logrus.Debugf("Writing WebSocket message: %v", err)
+	}
+	w.message.Reset()
+}
+
+// Hijack upgrades the connection and returns it to handlers streaming on the
+// hijacked connection.  The HTTP response header the handler writes first is
+// replaced by the WebSocket handshake.
+func (w *webSocketResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	if w.conn != nil || w.plain {
+		return nil, nil, errors.New("response already started, cannot hijack connection")
+	}
+	if err := w.upgrade(); err != nil {
+		return nil, nil, err
+	}
+	w.hijacked = true
+	conn := &hijackedWebSocketConn{Conn: w.conn}
+	return conn, bufio.NewReadWriter(bufio.NewReader(conn), bufio.NewWriter(conn)), nil
+}
+
+// startStream upgrades the connection of a handler streaming through the
+// response writer.  The handler does not read from the connection, the
+// request is canceled once the client closes it.
+func (w *webSocketResponseWriter) startStream() error {
+	if err := w.upgrade(); err != nil {
+		return err
+	}
+	go func() {
+		if _, err := io.Copy(io.Discard, w.conn); err != nil {
+			logrus.Debugf("Reading WebSocket connection: %v", err)
+		}
+		w.cancel()
+	}()
+	return nil
+}
+
+// upgrade hijacks the connection and answers the handshake.
+func (w *webSocketResponseWriter) upgrade() error {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return errors.New("unable to hijack connection")
+	}
+	netConn, rw, err := hijacker.Hijack()
+	if err != nil {
+		return fmt.Errorf("hijacking connection: %w", err)
+	}
+	if err := websocket.WriteHandshake(netConn, w.r); err != nil {
+		netConn.Close()
+		return fmt.Errorf("writing WebSocket handshake: %w", err)
+	}
+	w.conn = websocket.NewConn(netConn, rw.Reader, w.messageType)
+	logrus.Debugf("Upgraded connection of %s to WebSocket", w.r.URL.Path)
+	return nil
+}
+
+// hijackedWebSocketConn is a WebSocket connection dropping the HTTP response
+// header written by the handler which hijacked it.
+type hijackedWebSocketConn struct {
+	*websocket.Conn
+	header     []byte
+	headerDone bool
+}
+
+func (c *hijackedWebSocketConn) Write(b []byte) (int, error) {
+	if c.headerDone {
+		return c.Conn.Write(b)
+	}
+	c.header = append(c.header, b...)
+	end := bytes.Index(c.header, []byte("\r\n\r\n"))
+	if end < 0 {
+		return len(b), nil
+	}
+	c.headerDone = true
+	rest := c.header[end+4:]
+	c.header = nil
+	if _, err := c.Conn.Write(rest); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
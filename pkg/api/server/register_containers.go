@@ -7,6 +7,7 @@ import (
 
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/compat"
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/libpod"
+	"github.com/dmikushin/podman-shared/pkg/api/server/websocket"
 	"github.com/gorilla/mux"
 )
 
@@ -19,6 +20,12 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   produces:
 	//   - application/json
 	//   parameters:
+	//    - in: header
+	//      name: Idempotency-Key
+	//      type: string
+	//      description: |
+	//        Create the container only once for all requests with this key. Repeated requests get the response
+	//        of the first request with the Idempotent-Replayed header set.
 	//    - in: query
 	//      name: name
 	//      type: string
@@ -40,9 +47,9 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//       $ref: "#/responses/conflictError"
 	//     500:
 	//       $ref: "#/responses/internalError"
-	r.HandleFunc(VersionedPath("/containers/create"), s.APIHandler(compat.CreateContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/containers/create"), s.IdempotentHandler(s.APIHandler(compat.CreateContainer), "container create")).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
-	r.HandleFunc("/containers/create", s.APIHandler(compat.CreateContainer)).Methods(http.MethodPost)
+	r.HandleFunc("/containers/create", s.IdempotentHandler(s.APIHandler(compat.CreateContainer), "container create")).Methods(http.MethodPost)
 	// swagger:operation GET /containers/json compat ContainerList
 	// ---
 	// tags:
@@ -585,6 +592,65 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	r.HandleFunc(VersionedPath("/containers/{name}/attach"), s.APIHandler(compat.AttachContainer)).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
 	r.HandleFunc("/containers/{name}/attach", s.APIHandler(compat.AttachContainer)).Methods(http.MethodPost)
+	// swagger:operation GET /containers/{name}/attach/ws compat ContainerAttachWebSocket
+	// ---
+	// tags:
+	//  - containers (compat)
+	// summary: Attach to a container with a WebSocket
+	// description: |
+	//   Attach to a container like the POST endpoint, over a connection upgraded to the WebSocket protocol for clients which cannot hijack HTTP connections, e.g. web browsers.
+	//   The streams are sent as binary messages in the format of the POST endpoint, the messages of the client are written to the STDIN of the container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: detachKeys
+	//    required: false
+	//    type: string
+	//    description: keys to use for detaching from the container
+	//  - in: query
+	//    name: logs
+	//    required: false
+	//    type: boolean
+	//    description: Stream all logs from the container across the connection. Happens before streaming attach (if requested). At least one of logs or stream must be set
+	//  - in: query
+	//    name: stream
+	//    required: false
+	//    type: boolean
+	//    default: true
+	//    description: Attach to the container. If unset, and logs is set, only the container's logs will be sent. At least one of stream or logs must be set
+	//  - in: query
+	//    name: stdout
+	//    required: false
+	//    type: boolean
+	//    description: Attach to container STDOUT
+	//  - in: query
+	//    name: stderr
+	//    required: false
+	//    type: boolean
+	//    description: Attach to container STDERR
+	//  - in: query
+	//    name: stdin
+	//    required: false
+	//    type: boolean
+	//    description: Attach to container STDIN
+	// responses:
+	//   101:
+	//     description: No error, connection has been upgraded to the WebSocket protocol for transporting streams.
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   403:
+	//     description: origin of the request not allowed by CORS
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/containers/{name}/attach/ws"), s.WebSocketHandler(s.APIHandler(compat.AttachContainer), websocket.BinaryMessage)).Methods(http.MethodGet)
+	// Added non version path to URI to support docker non versioned paths
+	r.HandleFunc("/containers/{name}/attach/ws", s.WebSocketHandler(s.APIHandler(compat.AttachContainer), websocket.BinaryMessage)).Methods(http.MethodGet)
 	// swagger:operation POST /containers/{name}/resize compat ContainerResize
 	// ---
 	// tags:
@@ -682,13 +748,16 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// tags:
 	//   - containers (compat)
 	// summary: Update configuration of an existing container
-	// description: Change configuration settings for an existing container without requiring recreation.
+	// description: |
+	//   Change the resource limits and the restart policy of an existing container without requiring recreation.
+	//   Only the resources set in the request are changed, and the restart policy is only changed if a name is given.
+	//   Resources which cannot be updated are ignored and reported as warnings.
 	// parameters:
 	//  - in: path
 	//    name: name
 	//    type: string
 	//    required: true
-	//    description: Full or partial ID or full name of the container to rename
+	//    description: Full or partial ID or full name of the container to update
 	//  - in: body
 	//    name: resources
 	//    required: false
@@ -699,7 +768,9 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// - application/json
 	// responses:
 	//   200:
-	//     description: no error
+	//     $ref: "#/responses/containerUpdateCompatResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
 	//   404:
 	//     $ref: "#/responses/containerNotFound"
 	//   500:
@@ -719,9 +790,15 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   produces:
 	//   - application/json
 	//   parameters:
+	//    - in: header
+	//      name: Idempotency-Key
+	//      type: string
+	//      description: |
+	//        Create the container only once for all requests with this key. Repeated requests get the response
+	//        of the first request with the Idempotent-Replayed header set.
 	//    - in: body
 	//      name: create
-	//      description: attributes for creating a container
+	//      description: attributes for creating a container, as written by podman generate spec (As of version 5.7.0)
 	//      schema:
 	//        $ref: "#/definitions/SpecGenerator"
 	//      required: true
@@ -736,7 +813,87 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//       $ref: "#/responses/conflictError"
 	//     500:
 	//       $ref: "#/responses/internalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/create"), s.APIHandler(libpod.CreateContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/libpod/containers/create"), s.IdempotentHandler(s.APIHandler(libpod.CreateContainer), "container create")).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/runlabel libpod ContainerRunlabelLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Execute a runlabel
+	// description: |
+	//   Pull the image if needed and execute the command described by the image label on the server.
+	//   The output of the pull and of the command is streamed as JSON objects.
+	// parameters:
+	//  - in: query
+	//    name: label
+	//    type: string
+	//    required: true
+	//    description: Name of the label, e.g. install or run.
+	//  - in: query
+	//    name: image
+	//    type: string
+	//    required: true
+	//    description: Name or ID of the image.
+	//  - in: query
+	//    name: args
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: Arguments appended to the command.
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    description: Name of the container replacing NAME in the command.
+	//  - in: query
+	//    name: display
+	//    type: boolean
+	//    default: false
+	//    description: Only print the command that the label would run.
+	//  - in: query
+	//    name: replace
+	//    type: boolean
+	//    default: false
+	//    description: Replace an existing container with the same name.
+	//  - in: query
+	//    name: opt1
+	//    type: string
+	//    description: Value of the OPT1 variable of the command.
+	//  - in: query
+	//    name: opt2
+	//    type: string
+	//    description: Value of the OPT2 variable of the command.
+	//  - in: query
+	//    name: opt3
+	//    type: string
+	//    description: Value of the OPT3 variable of the command.
+	//  - in: query
+	//    name: pull
+	//    type: boolean
+	//    default: true
+	//    description: Pull the image if it does not exist locally.
+	//  - in: query
+	//    name: quiet
+	//    type: boolean
+	//    default: false
+	//    description: Suppress the output of the pull and the command.
+	//  - in: query
+	//    name: tlsVerify
+	//    type: boolean
+	//    default: true
+	//    description: Require TLS verification.
+	//  - in: header
+	//    name: X-Registry-Auth
+	//    type: string
+	//    description: "base-64 encoded auth config. Must include the following four values: username, password, email and server address OR simply just an identity token."
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/containerRunlabelResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/runlabel"), s.APIHandler(libpod.ContainerRunlabel)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/containers/json libpod ContainerListLibpod
 	// ---
 	// tags:
@@ -754,6 +911,16 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    description: Return this number of most recently created containers, including non-running ones.
 	//    type: integer
 	//  - in: query
+	//    name: offset
+	//    description: Skip this number of most recently created containers, to page through the list together with limit.
+	//    type: integer
+	//  - in: query
+	//    name: fields
+	//    description: Only return these fields, e.g. `Id,Names,State`, of the containers.
+	//    type: array
+	//    items:
+	//      type: string
+	//  - in: query
 	//    name: namespace
 	//    type: boolean
 	//    description: Include namespace information
@@ -769,6 +936,12 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    default: false
 	//    description: Return the size of container as fields SizeRw and SizeRootFs.
 	//  - in: query
+	//    name: sort
+	//    type: string
+	//    description: |
+	//      Sort the containers by `command`, `created`, `id`, `image`, `memory`, `names`, `pod`, `runningfor`, `size` or `status`.
+	//      Limit and offset page through the sorted containers.
+	//  - in: query
 	//    name: sync
 	//    type: boolean
 	//    default: false
@@ -782,6 +955,9 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//        - `before`=(`<container id>` or `<container name>`)
 	//        - `expose`=(`<port>[/<proto>]` or `<startport-endport>/[<proto>]`)
 	//        - `exited=<int>` containers with exit code of `<int>`
+	//        - `exited-range=<min>-<max>` containers with exit code between `<min>` and `<max>`
+	//        - `created-since=<timestamp>` containers created after the timestamp or duration
+	//        - `image-digest=<digest>` containers created from the image with this digest
 	//        - `health`=(`starting`, `healthy`, `unhealthy` or `none`)
 	//        - `id=<ID>` a container's ID
 	//        - `is-task`=(`true` or `false`)
@@ -1062,6 +1238,11 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//    type: string
 	//    required: true
 	//    description: the name or ID of the container
+	//  - in: query
+	//    name: freezeTimeout
+	//    type: integer
+	//    default: 0
+	//    description: number of seconds after which the container is unpaused automatically, 0 to keep it paused
 	// produces:
 	// - application/json
 	// responses:
@@ -1071,7 +1252,7 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/containerNotFound"
 	//   500:
 	//     $ref: "#/responses/internalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/pause"), s.APIHandler(compat.PauseContainer)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/pause"), s.APIHandler(libpod.PauseContainer)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/containers/{name}/restart libpod ContainerRestartLibpod
 	// ---
 	// tags:
@@ -1188,6 +1369,46 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/stats"), s.APIHandler(libpod.StatsContainer)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/containers/stats/history libpod ContainersStatsHistoryLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Get the stats history of one or more containers
+	// description: |
+	//   Return the samples of resource usage recorded in the stats history of one or more containers. Samples are recorded for containers created with a stats history interval. If no container is specified, the stats history of all containers is returned.
+	//   (As of version 5.7.0)
+	// parameters:
+	//  - in: query
+	//    name: containers
+	//    description: names or IDs of containers
+	//    type: array
+	//    items:
+	//       type: string
+	//  - in: query
+	//    name: all
+	//    type: boolean
+	//    default: false
+	//    description: Return the stats history of all containers
+	//  - in: query
+	//    name: since
+	//    type: string
+	//    description: Only return samples recorded at or after this time, as a timestamp or a duration relative to now
+	//  - in: query
+	//    name: until
+	//    type: string
+	//    description: Only return samples recorded at or before this time, as a timestamp or a duration relative to now
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/containerStatsHistory"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/stats/history"), s.APIHandler(libpod.StatsHistoryContainer)).Methods(http.MethodGet)
 
 	// swagger:operation GET /libpod/containers/{name}/top libpod ContainerTopLibpod
 	// ---
@@ -1272,6 +1493,7 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//       - healthy
 	//       - initialized
 	//       - paused
+	//       - removed
 	//       - removing
 	//       - running
 	//       - stopped
@@ -1299,6 +1521,64 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/wait"), s.APIHandler(libpod.WaitContainer)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/containers/wait libpod ContainerWaitMultipleLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Wait on multiple containers
+	// description: |
+	//   Wait on multiple containers to meet multiple conditions.  Every condition is awaited separately for every
+	//   container and a report is streamed as soon as a container meets one of them.  The stream ends once all
+	//   conditions have been met or failed.
+	// parameters:
+	//  - in: query
+	//    name: containers
+	//    type: array
+	//    items:
+	//      type: string
+	//    required: true
+	//    description: the names or IDs of the containers
+	//  - in: query
+	//    name: condition
+	//    type: array
+	//    items:
+	//      type: string
+	//      enum:
+	//       - configured
+	//       - created
+	//       - exited
+	//       - healthy
+	//       - initialized
+	//       - paused
+	//       - removed
+	//       - removing
+	//       - running
+	//       - stopped
+	//       - stopping
+	//       - unhealthy
+	//    description: "Conditions to wait for. If no condition provided the 'exited' condition is assumed."
+	//  - in: query
+	//    name: ignore
+	//    type: boolean
+	//    default: false
+	//    description: Ignore containers which do not exist.
+	//  - in: query
+	//    name: interval
+	//    type: string
+	//    default: "250ms"
+	//    description: Time Interval to wait before polling for completion.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/containerWaitConditionResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/wait"), s.APIHandler(libpod.WaitContainers)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/containers/{name}/exists libpod ContainerExistsLibpod
 	// ---
 	// tags:
@@ -1321,6 +1601,42 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/exists"), s.APIHandler(libpod.ContainerExists)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/containers/exists libpod ContainersExistLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Check if containers exist
+	// description: |
+	//   Check with a single request whether each container of a list of names or IDs exists.
+	//   (As of version 5.7.0)
+	// parameters:
+	//  - in: query
+	//    name: external
+	//    type: boolean
+	//    default: false
+	//    description: Check containers created outside of Podman, such as by Buildah, as well
+	//  - in: body
+	//    name: names
+	//    description: the names or IDs of the containers
+	//    required: true
+	//    schema:
+	//      type: array
+	//      items:
+	//        type: string
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: whether each container exists, keyed by the given name or ID
+	//     schema:
+	//       type: object
+	//       additionalProperties:
+	//         type: boolean
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/exists"), s.APIHandler(libpod.ContainersExist)).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/containers/{name}/stop libpod ContainerStopLibpod
 	// ---
 	// tags:
@@ -1496,6 +1812,63 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/attach"), s.APIHandler(compat.AttachContainer)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/containers/{name}/attach/ws libpod ContainerAttachWebSocketLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Attach to a container with a WebSocket
+	// description: |
+	//   Attach to a container like the POST endpoint, over a connection upgraded to the WebSocket protocol for clients which cannot hijack HTTP connections, e.g. web browsers.
+	//   The streams are sent as binary messages in the format of the POST endpoint, the messages of the client are written to the STDIN of the container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: detachKeys
+	//    required: false
+	//    type: string
+	//    description: keys to use for detaching from the container
+	//  - in: query
+	//    name: logs
+	//    required: false
+	//    type: boolean
+	//    description: Stream all logs from the container across the connection. Happens before streaming attach (if requested). At least one of logs or stream must be set
+	//  - in: query
+	//    name: stream
+	//    required: false
+	//    type: boolean
+	//    default: true
+	//    description: Attach to the container. If unset, and logs is set, only the container's logs will be sent. At least one of stream or logs must be set
+	//  - in: query
+	//    name: stdout
+	//    required: false
+	//    type: boolean
+	//    description: Attach to container STDOUT
+	//  - in: query
+	//    name: stderr
+	//    required: false
+	//    type: boolean
+	//    description: Attach to container STDERR
+	//  - in: query
+	//    name: stdin
+	//    required: false
+	//    type: boolean
+	//    description: Attach to container STDIN
+	// responses:
+	//   101:
+	//     description: No error, connection has been upgraded to the WebSocket protocol for transporting streams.
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   403:
+	//     description: origin of the request not allowed by CORS
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/attach/ws"), s.WebSocketHandler(s.APIHandler(compat.AttachContainer), websocket.BinaryMessage)).Methods(http.MethodGet)
 	// swagger:operation POST /libpod/containers/{name}/resize libpod ContainerResizeLibpod
 	// ---
 	// tags:
@@ -1535,23 +1908,34 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	// tags:
 	//   - containers
 	// summary: Export a container
-	// description: Export the contents of a container as a tarball.
+	// description: Export the contents of a container as a tarball, or the container with its configuration as an OCI archive.
 	// parameters:
 	//  - in: path
 	//    name: name
 	//    type: string
 	//    required: true
 	//    description: the name or ID of the container
+	//  - in: query
+	//    name: format
+	//    type: string
+	//    default: tar
+	//    enum: ["tar", "oci-archive"]
+	//    description: |
+	//      tar exports the root file system of the container, oci-archive exports
+	//      an OCI archive of a squashed image keeping the entrypoint, command,
+	//      environment, labels, user, working directory and exposed ports of the container
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
 	//     description: tarball is returned in body
+	//   400:
+	//     $ref: "#/responses/badParamError"
 	//   404:
 	//     $ref: "#/responses/containerNotFound"
 	//   500:
 	//     $ref: "#/responses/internalError"
-	r.HandleFunc(VersionedPath("/libpod/containers/{name}/export"), s.APIHandler(compat.ExportContainer)).Methods(http.MethodGet)
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/export"), s.APIHandler(libpod.ExportContainer)).Methods(http.MethodGet)
 	// swagger:operation POST /libpod/containers/{name}/checkpoint libpod ContainerCheckpointLibpod
 	// ---
 	// tags:
@@ -1683,6 +2067,80 @@ func (s *APIServer) registerContainersHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/containers/{name}/restore"), s.APIHandler(libpod.Restore)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/containers/{name}/backup libpod ContainerBackupLibpod
+	// ---
+	// tags:
+	//   - containers
+	// summary: Back up a container
+	// description: |
+	//   Back up the configuration of a container, the changes of its writable layer and
+	//   the content of its named volumes as a tarball.  Bind mounts and devices are not
+	//   part of the backup.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	//  - in: query
+	//    name: ignoreVolumes
+	//    type: boolean
+	//    description: do not include the named volumes of the container
+	// produces:
+	// - application/x-tar
+	// responses:
+	//   200:
+	//     description: tarball is returned in body
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/{name}/backup"), s.APIHandler(libpod.BackupContainer)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/containers/restore-backup libpod ContainerRestoreBackupLibpod
+	// ---
+	// tags:
+	//   - containers
+	// summary: Restore a container from a backup
+	// description: |
+	//   Create a container, with the changes of its writable layer and its named volumes,
+	//   from the backup tarball in the request body.  The image of the container is
+	//   pulled if it is missing.
+	// parameters:
+	//  - in: query
+	//    name: name
+	//    type: string
+	//    description: the name of the restored container, instead of the name in the backup
+	//  - in: query
+	//    name: ignoreVolumes
+	//    type: boolean
+	//    description: do not restore the named volumes of the backup
+	//  - in: body
+	//    name: request
+	//    description: tarball written by a backup of a container
+	//    schema:
+	//      type: string
+	//      format: binary
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: restored container
+	//     schema:
+	//       type: object
+	//       properties:
+	//         Id:
+	//           type: string
+	//         Name:
+	//           type: string
+	//         Volumes:
+	//           type: array
+	//           items:
+	//             type: string
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/containers/restore-backup"), s.APIHandler(libpod.RestoreBackupContainer)).Methods(http.MethodPost)
 	// swagger:operation GET /containers/{name}/changes compat ContainerChanges
 	// swagger:operation GET /libpod/containers/{name}/changes libpod ContainerChangesLibpod
 	// ---
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/compat"
+	"github.com/dmikushin/podman-shared/pkg/api/server/websocket"
 	"github.com/gorilla/mux"
 )
 
@@ -15,9 +16,12 @@ func (s *APIServer) registerEventsHandlers(r *mux.Router) error {
 	// tags:
 	//   - system (compat)
 	// summary: Get events
-	// description: Returns events filtered on query parameters
+	// description: |
+	//   Returns events filtered on query parameters.
+	//   Requests upgrading the connection to the WebSocket protocol receive each event as a text message.
 	// produces:
 	// - application/json
+	// - application/cloudevents+json
 	// parameters:
 	// - name: since
 	//   type: string
@@ -31,22 +35,37 @@ func (s *APIServer) registerEventsHandlers(r *mux.Router) error {
 	//   type: string
 	//   in: query
 	//   description: JSON encoded map[string][]string of constraints
+	// - name: streamFormat
+	//   type: string
+	//   in: query
+	//   enum: ["json", "cloudevents"]
+	//   default: json
+	//   description: format of the events, cloudevents for events in the CloudEvents v1.0 JSON format with the Podman event as data
 	// responses:
+	//   101:
+	//     description: connection upgraded to the WebSocket protocol
 	//   200:
 	//     description: returns a string of json data describing an event
+	//   400:
+	//     "$ref": "#/responses/badParamError"
+	//   403:
+	//     description: origin of the WebSocket request not allowed by CORS
 	//   500:
 	//     "$ref": "#/responses/internalError"
-	r.Handle(VersionedPath("/events"), s.StreamBufferedAPIHandler(compat.GetEvents)).Methods(http.MethodGet)
+	r.Handle(VersionedPath("/events"), s.WebSocketHandler(s.StreamBufferedAPIHandler(compat.GetEvents), websocket.TextMessage)).Methods(http.MethodGet)
 	// Added non version path to URI to support docker non versioned paths
-	r.Handle("/events", s.StreamBufferedAPIHandler(compat.GetEvents)).Methods(http.MethodGet)
+	r.Handle("/events", s.WebSocketHandler(s.StreamBufferedAPIHandler(compat.GetEvents), websocket.TextMessage)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/events system SystemEventsLibpod
 	// ---
 	// tags:
 	//   - system
 	// summary: Get events
-	// description: Returns events filtered on query parameters
+	// description: |
+	//   Returns events filtered on query parameters.
+	//   Requests upgrading the connection to the WebSocket protocol receive each event as a text message.
 	// produces:
 	// - application/json
+	// - application/cloudevents+json
 	// parameters:
 	// - name: since
 	//   type: string
@@ -65,11 +84,23 @@ func (s *APIServer) registerEventsHandlers(r *mux.Router) error {
 	//   in: query
 	//   default: true
 	//   description: when false, do not follow events
+	// - name: streamFormat
+	//   type: string
+	//   in: query
+	//   enum: ["json", "cloudevents"]
+	//   default: json
+	//   description: format of the events, cloudevents for events in the CloudEvents v1.0 JSON format with the Podman event as data
 	// responses:
+	//   101:
+	//     description: connection upgraded to the WebSocket protocol
 	//   200:
 	//     description: returns a string of json data describing an event
+	//   400:
+	//     "$ref": "#/responses/badParamError"
+	//   403:
+	//     description: origin of the WebSocket request not allowed by CORS
 	//   500:
 	//     "$ref": "#/responses/internalError"
-	r.Handle(VersionedPath("/libpod/events"), s.APIHandler(compat.GetEvents)).Methods(http.MethodGet)
+	r.Handle(VersionedPath("/libpod/events"), s.WebSocketHandler(s.APIHandler(compat.GetEvents), websocket.TextMessage)).Methods(http.MethodGet)
 	return nil
 }
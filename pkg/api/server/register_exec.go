@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/compat"
+	"github.com/dmikushin/podman-shared/pkg/api/server/websocket"
 	"github.com/gorilla/mux"
 )
 
@@ -117,6 +118,46 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	r.Handle(VersionedPath("/exec/{id}/start"), s.APIHandler(compat.ExecStartHandler)).Methods(http.MethodPost)
 	// Added non version path to URI to support docker non versioned paths
 	r.Handle("/exec/{id}/start", s.APIHandler(compat.ExecStartHandler)).Methods(http.MethodPost)
+	// swagger:operation GET /exec/{id}/start/ws compat ExecStartWebSocket
+	// ---
+	// tags:
+	//   - exec (compat)
+	// summary: Start an exec instance with a WebSocket
+	// description: |
+	//   Starts a previously set up exec instance like the POST endpoint, over a connection upgraded to the WebSocket protocol for clients which cannot hijack HTTP connections, e.g. web browsers.
+	//   The streams are sent as binary messages in the format of the attach endpoint, the messages of the client are written to the STDIN of the command.
+	// parameters:
+	//  - in: path
+	//    name: id
+	//    type: string
+	//    required: true
+	//    description: Exec instance ID
+	//  - in: query
+	//    name: tty
+	//    type: boolean
+	//    description: Allocate a pseudo-TTY.
+	//  - in: query
+	//    name: h
+	//    type: integer
+	//    description: Height of the TTY session in characters. tty must be set to true to use it.
+	//  - in: query
+	//    name: w
+	//    type: integer
+	//    description: Width of the TTY session in characters. tty must be set to true to use it.
+	// responses:
+	//   101:
+	//     description: No error, connection has been upgraded to the WebSocket protocol for transporting streams.
+	//   403:
+	//     description: origin of the request not allowed by CORS
+	//   404:
+	//     $ref: "#/responses/execSessionNotFound"
+	//   409:
+	//     description: container is not running
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/exec/{id}/start/ws"), s.WebSocketHandler(s.APIHandler(compat.ExecStartHandler), websocket.BinaryMessage)).Methods(http.MethodGet)
+	// Added non version path to URI to support docker non versioned paths
+	r.Handle("/exec/{id}/start/ws", s.WebSocketHandler(s.APIHandler(compat.ExecStartHandler), websocket.BinaryMessage)).Methods(http.MethodGet)
 	// swagger:operation POST /exec/{id}/resize compat ExecResize
 	// ---
 	// tags:
@@ -295,6 +336,44 @@ func (s *APIServer) registerExecHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/exec/{id}/start"), s.APIHandler(compat.ExecStartHandler)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/exec/{id}/start/ws libpod ExecStartWebSocketLibpod
+	// ---
+	// tags:
+	//   - exec
+	// summary: Start an exec instance with a WebSocket
+	// description: |
+	//   Starts a previously set up exec instance like the POST endpoint, over a connection upgraded to the WebSocket protocol for clients which cannot hijack HTTP connections, e.g. web browsers.
+	//   The streams are sent as binary messages in the format of the attach endpoint, the messages of the client are written to the STDIN of the command.
+	// parameters:
+	//  - in: path
+	//    name: id
+	//    type: string
+	//    required: true
+	//    description: Exec instance ID
+	//  - in: query
+	//    name: tty
+	//    type: boolean
+	//    description: Allocate a pseudo-TTY.
+	//  - in: query
+	//    name: h
+	//    type: integer
+	//    description: Height of the TTY session in characters. tty must be set to true to use it.
+	//  - in: query
+	//    name: w
+	//    type: integer
+	//    description: Width of the TTY session in characters. tty must be set to true to use it.
+	// responses:
+	//   101:
+	//     description: No error, connection has been upgraded to the WebSocket protocol for transporting streams.
+	//   403:
+	//     description: origin of the request not allowed by CORS
+	//   404:
+	//     $ref: "#/responses/execSessionNotFound"
+	//   409:
+	//     description: container is not running
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/exec/{id}/start/ws"), s.WebSocketHandler(s.APIHandler(compat.ExecStartHandler), websocket.BinaryMessage)).Methods(http.MethodGet)
 	// swagger:operation POST /libpod/exec/{id}/resize libpod ExecResizeLibpod
 	// ---
 	// tags:
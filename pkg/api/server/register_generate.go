@@ -115,6 +115,37 @@ func (s *APIServer) registerGenerateHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/generate/{name:.*}/systemd"), s.APIHandler(libpod.GenerateSystemd)).Methods(http.MethodGet)
 
+	// swagger:operation GET /libpod/generate/{name}/quadlet libpod GenerateQuadletLibpod
+	// ---
+	// tags:
+	//  - containers
+	//  - pods
+	// summary: Generate Quadlet Units
+	// description: Generate Quadlet unit files recreating a container or a pod and the named volumes and networks it uses.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: Name or ID of the container or pod.
+	//  - in: query
+	//    name: noHeader
+	//    type: boolean
+	//    default: false
+	//    description: Do not generate the header including the Podman version.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: no error, the unit files keyed by file name
+	//     schema:
+	//       type: object
+	//       additionalProperties:
+	//         type: string
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/generate/{name:.*}/quadlet"), s.APIHandler(libpod.GenerateQuadlet)).Methods(http.MethodGet)
+
 	// swagger:operation GET /libpod/generate/kube libpod GenerateKubeLibpod
 	// ---
 	// tags:
@@ -135,6 +166,14 @@ func (s *APIServer) registerGenerateHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    default: false
 	//    description: Generate YAML for a Kubernetes service object.
+	//  - in: query
+	//    name: ingress
+	//    type: string
+	//    enum: ["ingress", "httproute"]
+	//    description: |
+	//      Generate YAML for an Ingress or a Gateway API HTTPRoute routing HTTP traffic to the service, implies service.
+	//      The host, path, port and gateway are read from the io.podman.kube.ingress.* labels of the pod or containers.
+	//      (As of version 5.7.0)
 	// produces:
 	// - text/vnd.yaml
 	// - application/json
@@ -0,0 +1,69 @@
+//go:build !remote
+
+package server
+
+import (
+	"net/http"
+
+	"github.com/dmikushin/podman-shared/pkg/api/handlers/libpod"
+	"github.com/gorilla/mux"
+)
+
+func (s *APIServer) registerHooksHandlers(r *mux.Router) error {
+	// swagger:operation GET /libpod/hooks/json libpod HooksListLibpod
+	// ---
+	// tags:
+	//  - system
+	// summary: List OCI hooks
+	// description: |
+	//   List the OCI hooks of the hooks directories. Invalid hooks, which are
+	//   ignored when containers start, are listed with their error.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/hooksListResponse"
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/hooks/json"), s.APIHandler(libpod.ListHooks)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/hooks/reload libpod HooksReloadLibpod
+	// ---
+	// tags:
+	//  - system
+	// summary: Reload the OCI hooks directories
+	// description: |
+	//   Read the hooks directories from containers.conf again, unless they
+	//   were set on the command line of the service, and list the hooks found
+	//   in them.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/hooksListResponse"
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/hooks/reload"), s.APIHandler(libpod.ReloadHooks)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/containers/{name}/hooks libpod ContainerHooksLibpod
+	// ---
+	// tags:
+	//  - containers
+	// summary: Test OCI hooks against a container
+	// description: Report which OCI hooks of the hooks directories match the configuration of the container.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the container
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/hooksTestResponse"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/containers/{name:.*}/hooks"), s.APIHandler(libpod.TestHooks)).Methods(http.MethodGet)
+	return nil
+}
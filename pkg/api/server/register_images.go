@@ -499,10 +499,27 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//    name: Content-Type
 	//    type: string
 	//    default: application/x-tar
-	//    enum: ["application/x-tar"]
+	//    enum: ["application/x-tar", "multipart/form-data"]
 	//  - in: header
 	//    name: X-Registry-Config
 	//    type: string
+	//  - in: header
+	//    name: X-Build-Secrets
+	//    type: string
+	//    description: |
+	//      Build secrets as a base64url-encoded JSON object mapping secret IDs to their base64-encoded content,
+	//      available to RUN instructions with --mount=type=secret,id=<id>.
+	//      Secrets can also be sent in multipart/form-data fields named "secret-<id>".
+	//      They are stored outside of the build context and never end up in the image.
+	//      (As of version 5.7.0)
+	//  - in: header
+	//    name: X-Build-SSH
+	//    type: string
+	//    description: |
+	//      SSH private keys to forward to the build as a base64url-encoded JSON object mapping SSH IDs to their
+	//      base64-encoded keys, available to RUN instructions with --mount=type=ssh,id=<id>.
+	//      Keys can also be sent in multipart/form-data fields named "ssh-<id>".
+	//      (As of version 5.7.0)
 	//  - in: query
 	//    name: dockerfile
 	//    type: string
@@ -774,6 +791,11 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    default: true
 	//  - in: query
+	//    name: progress
+	//    description: Send structured progress frames, in the "progress" field, with the blob digest as ID. Implies quiet=false unless quiet is set.
+	//    type: boolean
+	//    default: false
+	//  - in: query
 	//    name: quiet
 	//    description: Silences extra stream data on push.
 	//    type: boolean
@@ -853,16 +875,145 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//    name: whatrequires
 	//    type: boolean
 	//    description: show all child images and layers of the specified image
+	//  - in: query
+	//    name: du
+	//    type: boolean
+	//    description: show the disk usage of the layers, whether they are shared with other images and whether they are on shared storage (As of version 5.7.0)
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
 	//     $ref: "#/responses/treeResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
 	//   404:
 	//     $ref: '#/responses/imageNotFound'
 	//   500:
 	//     $ref: '#/responses/internalError'
 	r.Handle(VersionedPath("/libpod/images/{name:.*}/tree"), s.APIHandler(libpod.ImageTree)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/images/{name}/sbom libpod ImageSBOMLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Generate an SBOM
+	// description: |
+	//   Generate a software bill of materials of the image from the package databases found in its layers.
+	//   Optionally attach the SBOM to the image in a registry, as an artifact referring to the image.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the image
+	//  - in: query
+	//    name: format
+	//    type: string
+	//    default: spdx
+	//    description: format of the SBOM, spdx or cyclonedx
+	//  - in: query
+	//    name: attach
+	//    type: string
+	//    description: push the SBOM as a referrer of this image reference in a registry
+	//  - in: query
+	//    name: tlsVerify
+	//    type: boolean
+	//    default: true
+	//    description: Require TLS verification when attaching the SBOM.
+	//  - in: header
+	//    name: X-Registry-Auth
+	//    type: string
+	//    description: "base-64 encoded auth config. Must include the following four values: username, password, email and server address OR simply just an identity token."
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/imageSBOMResponse"
+	//   404:
+	//     $ref: '#/responses/imageNotFound'
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/images/{name:.*}/sbom"), s.APIHandler(libpod.ImageSBOM)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/images/{name}/scan libpod ImageScanLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Scan an image for vulnerabilities
+	// description: |
+	//   Scan the mounted root filesystem of the image with a vulnerability scanner configured on the server
+	//   and return the vulnerabilities in a common schema.
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the image
+	//  - in: query
+	//    name: scanner
+	//    type: string
+	//    description: name of the scanner, defaults to the scanner of the scan configuration
+	//  - in: query
+	//    name: threshold
+	//    type: string
+	//    description: block the image if it has vulnerabilities of this severity or higher (unknown, low, medium, high or critical)
+	//  - in: query
+	//    name: runPolicy
+	//    type: boolean
+	//    default: false
+	//    description: evaluate the vulnerabilities against the run threshold of the scan configuration instead, the image is only scanned if one is configured
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/imageScanResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: '#/responses/imageNotFound'
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/images/{name:.*}/scan"), s.APIHandler(libpod.ImageScan)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/images/{name}/optimize libpod ImageOptimizeLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Optimize an image
+	// description: |
+	//   Write a copy of the image with its layers compressed with zstd, the whiteouts hiding nothing removed
+	//   and the layers smaller than the merge threshold merged with the following layers.
+	//   The size of the layers before and after is reported. (As of version 5.7.0)
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the image
+	//  - in: query
+	//    name: target
+	//    type: string
+	//    required: true
+	//    description: name of the optimized image
+	//  - in: query
+	//    name: mergethreshold
+	//    type: integer
+	//    format: int64
+	//    default: 0
+	//    description: size in bytes under which consecutive layers are merged, 0 to not merge layers
+	//  - in: query
+	//    name: compressionlevel
+	//    type: integer
+	//    description: level of the zstd compression
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: "#/responses/imageOptimizeResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: '#/responses/imageNotFound'
+	//   500:
+	//     $ref: '#/responses/internalError'
+	r.Handle(VersionedPath("/libpod/images/{name:.*}/optimize"), s.APIHandler(libpod.ImageOptimize)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/images/{name}/history libpod ImageHistoryLibpod
 	// ---
 	// tags:
@@ -908,11 +1059,27 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//        - `id`=(`<image-id>`)
 	//        - `since`=(`<image-name>[:<tag>]`,  `<image id>` or `<image@digest>`)
 	//     type: string
+	//   - name: limit
+	//     in: query
+	//     description: Return this number of most recently created images.
+	//     type: integer
+	//   - name: offset
+	//     in: query
+	//     description: Skip this number of most recently created images, to page through the list together with limit.
+	//     type: integer
+	//   - name: fields
+	//     in: query
+	//     description: Only return these fields, e.g. `Id,Names,Size`, of the images.
+	//     type: array
+	//     items:
+	//       type: string
 	// produces:
 	// - application/json
 	// responses:
 	//   200:
 	//     $ref: "#/responses/imageListLibpod"
+	//   400:
+	//     $ref: "#/responses/badParamError"
 	//   500:
 	//     $ref: '#/responses/internalError'
 	r.Handle(VersionedPath("/libpod/images/json"), s.APIHandler(compat.GetImages)).Methods(http.MethodGet)
@@ -1096,6 +1263,11 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//     description: "Mandatory reference to the image (e.g., quay.io/image/name:tag)"
 	//     type: string
 	//   - in: query
+	//     name: progress
+	//     description: "Send structured progress frames, in the \"progress\" field, with the blob digest as ID. Ignored when quiet is set."
+	//     type: boolean
+	//     default: false
+	//   - in: query
 	//     name: quiet
 	//     description: "silences extra stream data on pull"
 	//     type: boolean
@@ -1119,7 +1291,14 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//     type: string
 	//   - in: query
 	//     name: policy
-	//     description: Pull policy, "always" (default), "missing", "newer", "never".
+	//     description: Pull policy, "always" (default), "missing", "newer", "newer-with-max-age", "never".
+	//     type: string
+	//   - in: query
+	//     name: maxage
+	//     description: |
+	//       Maximum age of the local image with the "newer-with-max-age" policy, like "24h" (default).
+	//       The registry is only checked for a newer image when the local image was pulled, or checked, longer ago.
+	//       (As of version 5.7.0)
 	//     type: string
 	//   - in: query
 	//     name: tlsVerify
@@ -1244,7 +1423,9 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//  - in: query
 	//    name: format
 	//    type: string
-	//    description: format for exported image
+	//    description: |
+	//      format for exported image.
+	//      (As of version 5.7.0) oci-artifact exports the image with the artifacts referring to it.
 	//  - in: query
 	//    name: compress
 	//    type: boolean
@@ -1267,12 +1448,14 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	// tags:
 	//  - images
 	// summary: Export multiple images
-	// description: Export multiple images into a single object. Only `docker-archive` is currently supported.
+	// description: Export multiple images into a single object. Only `docker-archive` and `oci-artifact` are currently supported.
 	// parameters:
 	//  - in: query
 	//    name: format
 	//    type: string
-	//    description: format for exported image (only docker-archive is supported)
+	//    description: |
+	//      format for exported image (only docker-archive and oci-artifact are supported).
+	//      (As of version 5.7.0) oci-artifact exports the images with the artifacts referring to them.
 	//  - in: query
 	//    name: references
 	//    description: references to images to export
@@ -1505,6 +1688,23 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//  - in: header
 	//    name: X-Registry-Config
 	//    type: string
+	//  - in: header
+	//    name: X-Build-Secrets
+	//    type: string
+	//    description: |
+	//      Build secrets as a base64url-encoded JSON object mapping secret IDs to their base64-encoded content,
+	//      available to RUN instructions with --mount=type=secret,id=<id>.
+	//      Secrets can also be sent in multipart/form-data fields named "secret-<id>".
+	//      They are stored outside of the build context and never end up in the image.
+	//      (As of version 5.7.0)
+	//  - in: header
+	//    name: X-Build-SSH
+	//    type: string
+	//    description: |
+	//      SSH private keys to forward to the build as a base64url-encoded JSON object mapping SSH IDs to their
+	//      base64-encoded keys, available to RUN instructions with --mount=type=ssh,id=<id>.
+	//      Keys can also be sent in multipart/form-data fields named "ssh-<id>".
+	//      (As of version 5.7.0)
 	//  - in: query
 	//    name: dockerfile
 	//    type: string
@@ -1644,6 +1844,19 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//      JSON array of images used to build cache resolution
 	//      (As of version 1.xx)
 	//  - in: query
+	//    name: contextcache
+	//    type: boolean
+	//    default: false
+	//    description: |
+	//      The build context is a delta against the build context cache, see ImageBuildContextMissingLibpod.
+	//  - in: query
+	//    name: progress
+	//    type: boolean
+	//    default: false
+	//    description: |
+	//      Send structured progress frames, in the "progress" field, for every build step
+	//      with the stage as ID, the step as current and the number of steps as total.
+	//  - in: query
 	//    name: pull
 	//    type: boolean
 	//    default: false
@@ -1724,6 +1937,15 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//      Default is 64MB
 	//      (As of version 1.xx)
 	//  - in: query
+	//    name: sharedcache
+	//    type: string
+	//    default: auto
+	//    description: |
+	//      Directory of a shared build cache to export the intermediate images to and import them from,
+	//      keyed by the cache keys of the build steps. "auto" uses the build-cache directory of the
+	//      graph root when it is on shared storage, "none" disables the shared build cache.
+	//      (As of version 5.7.0)
+	//  - in: query
 	//    name: squash
 	//    type: boolean
 	//    default: false
@@ -1838,6 +2060,38 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/build"), s.APIHandler(compat.BuildImage)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/build/context/missing libpod ImageBuildContextMissingLibpod
+	// ---
+	// tags:
+	//  - images
+	// summary: Look up cached build context files
+	// description: |
+	//   Return the digests of build context files which are not in the build context cache of the server.
+	//   A build request with contextcache=true only needs to include the content of those files, the
+	//   entries of the other files carry the digest in the PODMAN.context.digest PAX record instead.
+	//   Entries with the PODMAN.context.cache PAX record are added to the cache.
+	// parameters:
+	//  - in: body
+	//    name: digests
+	//    description: Digests of the build context files
+	//    schema:
+	//      type: array
+	//      items:
+	//        type: string
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: Digests missing from the cache
+	//     schema:
+	//       type: array
+	//       items:
+	//         type: string
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/build/context/missing"), s.APIHandler(libpod.BuildContextMissing)).Methods(http.MethodPost)
 
 	// swagger:operation POST /libpod/local/build libpod LocalBuildLibpod
 	// ---
@@ -2072,6 +2326,15 @@ func (s *APIServer) registerImagesHandlers(r *mux.Router) error {
 	//      Default is 64MB
 	//      (As of version 1.xx)
 	//  - in: query
+	//    name: sharedcache
+	//    type: string
+	//    default: auto
+	//    description: |
+	//      Directory of a shared build cache to export the intermediate images to and import them from,
+	//      keyed by the cache keys of the build steps. "auto" uses the build-cache directory of the
+	//      graph root when it is on shared storage, "none" disables the shared build cache.
+	//      (As of version 5.7.0)
+	//  - in: query
 	//    name: squash
 	//    type: boolean
 	//    default: false
@@ -99,6 +99,13 @@ func (s *APIServer) registerKubeHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    description: Whether to publish all ports defined in the K8S YAML file (containerPort, hostPort), if false only hostPort will be published
 	//  - in: query
+	//    name: networkPolicy
+	//    type: boolean
+	//    default: false
+	//    description: |
+	//      Enforce the NetworkPolicy objects of the K8S YAML file with firewall rules in the network namespaces of the selected pods.
+	//      (As of version 5.7.0)
+	//  - in: query
 	//    name: replace
 	//    type: boolean
 	//    default: false
@@ -170,6 +177,25 @@ func (s *APIServer) registerKubeHandlers(r *mux.Router) error {
 	//    type: boolean
 	//    default: false
 	//    description: Remove volumes.
+	//  - in: query
+	//    name: volumes
+	//    type: boolean
+	//    default: false
+	//    description: Remove the volumes of PersistentVolumeClaims (As of version 5.7.0).
+	//  - in: query
+	//    name: configmaps
+	//    type: boolean
+	//    default: false
+	//    description: Remove the volumes created for ConfigMap and Secret volumes (As of version 5.7.0).
+	//  - in: query
+	//    name: secrets
+	//    type: boolean
+	//    default: true
+	//    description: Remove the secrets created from Secrets (As of version 5.7.0).
+	//  - in: query
+	//    name: timeout
+	//    type: integer
+	//    description: Seconds to wait for each pod to stop before killing its containers, the stop timeout of the containers by default (As of version 5.7.0).
 	// produces:
 	// - application/json
 	// responses:
@@ -200,6 +226,14 @@ func (s *APIServer) registerKubeHandlers(r *mux.Router) error {
 	//    default: false
 	//    description: Generate YAML for a Kubernetes service object.
 	//  - in: query
+	//    name: ingress
+	//    type: string
+	//    enum: ["ingress", "httproute"]
+	//    description: |
+	//      Generate YAML for an Ingress or a Gateway API HTTPRoute routing HTTP traffic to the service, implies service.
+	//      The host, path, port and gateway are read from the io.podman.kube.ingress.* labels of the pod or containers.
+	//      (As of version 5.7.0)
+	//  - in: query
 	//    name: type
 	//    type: string
 	//    default: pod
@@ -101,6 +101,12 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	// produces:
 	// - application/json
 	// parameters:
+	//  - in: header
+	//    name: Idempotency-Key
+	//    type: string
+	//    description: |
+	//      Create the network only once for all requests with this key. Repeated requests get the response
+	//      of the first request with the Idempotent-Replayed header set.
 	//  - in: body
 	//    name: create
 	//    description: attributes for creating a network
@@ -120,8 +126,8 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/badParamError"
 	//   500:
 	//     $ref: "#/responses/internalError"
-	r.HandleFunc(VersionedPath("/networks/create"), s.APIHandler(compat.CreateNetwork)).Methods(http.MethodPost)
-	r.HandleFunc("/networks/create", s.APIHandler(compat.CreateNetwork)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/networks/create"), s.IdempotentHandler(s.APIHandler(compat.CreateNetwork), "network create")).Methods(http.MethodPost)
+	r.HandleFunc("/networks/create", s.IdempotentHandler(s.APIHandler(compat.CreateNetwork), "network create")).Methods(http.MethodPost)
 	// swagger:operation POST /networks/{name}/connect compat NetworkConnect
 	// ---
 	// tags:
@@ -265,6 +271,59 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/networks/{name}/update"), s.APIHandler(libpod.UpdateNetwork)).Methods(http.MethodPost)
+	// swagger:operation GET /libpod/networks/{name}/wireguard/peer libpod NetworkWireGuardPeerLibpod
+	// ---
+	// tags:
+	//  - networks
+	// summary: WireGuard network peer
+	// description: Get the host of the service as a peer of a WireGuard network, with its public key, endpoint and subnets
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the network
+	// responses:
+	//   200:
+	//     $ref: "#/responses/networkWireGuardPeerResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/networkNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/networks/{name}/wireguard/peer"), s.APIHandler(libpod.WireGuardPeer)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/networks/{name}/wireguard/peers libpod NetworkWireGuardSetPeersLibpod
+	// ---
+	// tags:
+	//  - networks
+	// summary: Set WireGuard network peers
+	// description: Replace the peers of the host of the service in a WireGuard network
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the network
+	//  - in: body
+	//    name: peers
+	//    description: the hosts of the network, the host of the service is skipped
+	//    schema:
+	//      $ref: "#/definitions/networkWireGuardPeersRequestLibpod"
+	// responses:
+	//   204:
+	//     description: no error
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/networkNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/networks/{name}/wireguard/peers"), s.APIHandler(libpod.SetWireGuardPeers)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/networks/{name}/exists libpod NetworkExistsLibpod
 	// ---
 	// tags:
@@ -347,6 +406,12 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	// produces:
 	// - application/json
 	// parameters:
+	//  - in: header
+	//    name: Idempotency-Key
+	//    type: string
+	//    description: |
+	//      Create the network only once for all requests with this key. Repeated requests get the response
+	//      of the first request with the Idempotent-Replayed header set.
 	//  - in: body
 	//    name: create
 	//    description: attributes for creating a network
@@ -361,7 +426,7 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/conflictError"
 	//   500:
 	//     $ref: "#/responses/internalError"
-	r.HandleFunc(VersionedPath("/libpod/networks/create"), s.APIHandler(libpod.CreateNetwork)).Methods(http.MethodPost)
+	r.HandleFunc(VersionedPath("/libpod/networks/create"), s.IdempotentHandler(s.APIHandler(libpod.CreateNetwork), "network create")).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/networks/{name}/connect libpod NetworkConnectLibpod
 	// ---
 	// tags:
@@ -439,5 +504,43 @@ func (s *APIServer) registerNetworkHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.HandleFunc(VersionedPath("/libpod/networks/prune"), s.APIHandler(libpod.Prune)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/networks/reload libpod NetworkReloadLibpod
+	// ---
+	// tags:
+	//  - networks
+	// summary: Reload container networks
+	// description: |
+	//   Recreate the firewall rules of container networks, for example after the host firewall daemon was restarted.
+	//   By default the networks of the given containers are torn down and set up again.
+	//   With graceful the firewall rules of all containers are replaced in place, established connections are not dropped.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: query
+	//    name: all
+	//    type: boolean
+	//    default: false
+	//    description: reload the networks of all containers
+	//  - in: query
+	//    name: graceful
+	//    type: boolean
+	//    default: false
+	//    description: replace the firewall rules in place without tearing down the networks, requires all
+	//  - in: query
+	//    name: containers
+	//    type: array
+	//    items:
+	//      type: string
+	//    description: names or IDs of the containers to reload
+	// responses:
+	//   200:
+	//     $ref: "#/responses/networkReloadResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/containerNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/networks/reload"), s.APIHandler(libpod.Reload)).Methods(http.MethodPost)
 	return nil
 }
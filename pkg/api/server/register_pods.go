@@ -31,6 +31,20 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//        - `ctr-ids=<pod-ctr-ids>` Container ID within the pod.
 	//        - `ctr-status=<pod-ctr-status>` Container status within the pod.
 	//        - `ctr-number=<pod-ctr-number>` Number of containers in the pod.
+	// - in: query
+	//   name: limit
+	//   type: integer
+	//   description: Return this number of most recently created pods.
+	// - in: query
+	//   name: offset
+	//   type: integer
+	//   description: Skip this number of most recently created pods, to page through the list together with limit.
+	// - in: query
+	//   name: fields
+	//   type: array
+	//   items:
+	//     type: string
+	//   description: Only return these fields, e.g. `Id,Name,Status`, of the pods.
 	// responses:
 	//   200:
 	//     $ref: "#/responses/podsListResponse"
@@ -45,6 +59,12 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	// produces:
 	// - application/json
 	// parameters:
+	// - in: header
+	//   name: Idempotency-Key
+	//   type: string
+	//   description: |
+	//     Create the pod only once for all requests with this key. Repeated requests get the response
+	//     of the first request with the Idempotent-Replayed header set.
 	// - in: body
 	//   name: create
 	//   description: attributes for creating a pod
@@ -63,7 +83,7 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//       description: message describing error
 	//   500:
 	//     $ref: "#/responses/internalError"
-	r.Handle(VersionedPath("/libpod/pods/create"), s.APIHandler(libpod.PodCreate)).Methods(http.MethodPost)
+	r.Handle(VersionedPath("/libpod/pods/create"), s.IdempotentHandler(s.APIHandler(libpod.PodCreate), "pod create")).Methods(http.MethodPost)
 	// swagger:operation POST /libpod/pods/prune pods PodPruneLibpod
 	// ---
 	// summary: Prune unused pods
@@ -287,6 +307,50 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/pods/{name}/unpause"), s.APIHandler(libpod.PodUnpause)).Methods(http.MethodPost)
+	// swagger:operation POST /libpod/pods/{name}/update pods PodUpdateLibpod
+	// ---
+	// summary: Update a pod
+	// description: |
+	//   Change the ports published by a pod (As of version 5.7.0).  When the pod is
+	//   running, the port forwarding of the infra container is reconfigured in
+	//   place, which requires rootful bridge networking.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: body
+	//    name: options
+	//    description: |
+	//      PublishAdd are the port mappings to publish in addition, PublishRemove the
+	//      published ports to remove, matching on the container port and protocol and,
+	//      when set, on the host port and IP.
+	//    schema:
+	//      type: object
+	//      properties:
+	//        PublishAdd:
+	//          type: array
+	//          items:
+	//            $ref: "#/definitions/PortMapping"
+	//        PublishRemove:
+	//          type: array
+	//          items:
+	//            $ref: "#/definitions/PortMapping"
+	// responses:
+	//   200:
+	//     $ref: "#/responses/podUpdateResponse"
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   404:
+	//     $ref: "#/responses/podNotFound"
+	//   409:
+	//     $ref: "#/responses/conflictError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/update"), s.APIHandler(libpod.PodUpdate)).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/pods/{name}/top pods PodTopLibpod
 	// ---
 	// summary: List processes
@@ -322,6 +386,52 @@ func (s *APIServer) registerPodsHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/pods/{name}/top"), s.APIHandler(libpod.PodTop)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/pods/{name}/logs pods PodLogsLibpod
+	// ---
+	// summary: Get pod logs
+	// description: |
+	//   Get the interleaved logs of the containers of a pod (As of version 5.7.0).
+	//   When following, the logs of the containers started in the pod afterwards
+	//   are streamed as well, until no container of the pod is running anymore.
+	// produces:
+	// - application/json
+	// parameters:
+	//  - in: path
+	//    name: name
+	//    type: string
+	//    required: true
+	//    description: the name or ID of the pod
+	//  - in: query
+	//    name: container
+	//    type: string
+	//    description: only return the logs of this container of the pod
+	//  - in: query
+	//    name: follow
+	//    type: boolean
+	//    description: Keep connection after returning logs.
+	//  - in: query
+	//    name: since
+	//    type:  string
+	//    description: Only return logs since this time, as a UNIX timestamp
+	//  - in: query
+	//    name: until
+	//    type:  string
+	//    description: Only return logs before this time, as a UNIX timestamp
+	//  - in: query
+	//    name: tail
+	//    type: string
+	//    description: Only return this number of log lines from the end of the logs of each container
+	//    default: all
+	// responses:
+	//   200:
+	//     description: |
+	//       stream of JSON objects, one per log line, with the device (stdout or stderr),
+	//       the time, the message, the ID and name of the container and its color index.
+	//   404:
+	//     $ref: "#/responses/podNotFound"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/pods/{name}/logs"), s.APIHandler(libpod.PodLogs)).Methods(http.MethodGet)
 	// swagger:operation GET /libpod/pods/stats pods PodStatsAllLibpod
 	// ---
 	// tags:
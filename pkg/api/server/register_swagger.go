@@ -13,5 +13,22 @@ import (
 func (s *APIServer) registerSwaggerHandlers(r *mux.Router) error {
 	// This handler does _*NOT*_ provide an UI rather just a swagger spec that an UI could render
 	r.HandleFunc(VersionedPath("/libpod/swagger"), s.APIHandler(libpod.ServeSwagger)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/swagger.json libpod SystemSwaggerLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: Get the API specification
+	// description: |
+	//   Returns the swagger specification of the API as JSON. The version of the specification is the
+	//   API version of the service, and it only contains the operations supported by the service.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     description: swagger specification of the API
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.HandleFunc(VersionedPath("/libpod/swagger.json"), s.APIHandler(libpod.ServeSwaggerJSON(r))).Methods(http.MethodGet)
+	r.HandleFunc("/libpod/swagger.json", s.APIHandler(libpod.ServeSwaggerJSON(r))).Methods(http.MethodGet)
 	return nil
 }
@@ -4,14 +4,24 @@ package server
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/dmikushin/podman-shared/pkg/api/handlers/utils"
 	"github.com/gorilla/mux"
-	"github.com/sirupsen/logrus"
 )
 
+// errNoSwarm starts with the message of Docker when swarm mode is inactive,
+// which Docker clients look for to handle it.
+var errNoSwarm = errors.New("This node is not a swarm manager: Podman does not support swarm mode")
+
 func (s *APIServer) registerSwarmHandlers(r *mux.Router) error {
+	for _, endpoint := range []string{"configs", "nodes", "services", "swarm", "tasks"} {
+		r.HandleFunc(VersionedPath("/"+endpoint), noSwarm)
+		// Added non version path to URI to support docker non versioned paths
+		r.HandleFunc("/"+endpoint, noSwarm)
+	}
+
 	r.PathPrefix("/v{version:[0-9.]+}/configs/").HandlerFunc(noSwarm)
 	r.PathPrefix("/v{version:[0-9.]+}/nodes/").HandlerFunc(noSwarm)
 	r.PathPrefix("/v{version:[0-9.]+}/secrets/").HandlerFunc(noSwarm)
@@ -30,8 +40,7 @@ func (s *APIServer) registerSwarmHandlers(r *mux.Router) error {
 }
 
 // noSwarm returns http.StatusServiceUnavailable rather than something like http.StatusInternalServerError,
-// this allows the client to decide if they still can talk to us
+// like Docker does when swarm mode is inactive. This allows the client to decide if they still can talk to us
 func noSwarm(w http.ResponseWriter, r *http.Request) {
-	logrus.Errorf("%s is not a podman supported service", r.URL.String())
-	utils.Error(w, http.StatusServiceUnavailable, errors.New("Podman does not support service: "+r.URL.String()))
+	utils.Error(w, http.StatusServiceUnavailable, fmt.Errorf("%w: %s %s", errNoSwarm, r.Method, r.URL.Path))
 }
@@ -46,6 +46,10 @@ func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
 	//     type: boolean
 	//     description: Remove inconsistent containers and images
 	//   - in: query
+	//     name: repair_containers
+	//     type: boolean
+	//     description: Recreate missing container storage and regenerate missing container database entries
+	//   - in: query
 	//     name: unreferenced_layer_max_age
 	//     type: string
 	//     description: Maximum allowed age of unreferenced layers
@@ -65,6 +69,32 @@ func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
 	// tags:
 	//   - system
 	// summary: Prune unused data
+	// parameters:
+	//   - in: query
+	//     name: all
+	//     type: boolean
+	//     description: Remove all unused images, not just dangling ones
+	//   - in: query
+	//     name: volumes
+	//     type: boolean
+	//     description: Prune volumes
+	//   - in: query
+	//     name: external
+	//     type: boolean
+	//     description: Remove container data in storage not controlled by podman
+	//   - in: query
+	//     name: build
+	//     type: boolean
+	//     description: Remove build containers
+	//   - in: query
+	//     name: dry_run
+	//     type: boolean
+	//     description: Report what would be removed, with sizes, without removing anything
+	//   - in: query
+	//     name: filters
+	//     type: string
+	//     description: |
+	//       JSON encoded value of filters (a map[string][]string) to process on the prune list.
 	// produces:
 	// - application/json
 	// responses:
@@ -89,5 +119,82 @@ func (s *APIServer) registerSystemHandlers(r *mux.Router) error {
 	//   500:
 	//     $ref: "#/responses/internalError"
 	r.Handle(VersionedPath("/libpod/system/df"), s.APIHandler(libpod.DiskUsage)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/system/devices libpod SystemDevicesLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: List devices
+	// description: |
+	//   Return the devices defined in the CDI specifications of the host and
+	//   the NVIDIA, AMD and Intel GPU device nodes found on the host.
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/systemDevicesResponse'
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/system/devices"), s.APIHandler(libpod.Devices)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/system/dbprofile libpod SystemDBProfileLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: Show database query timing
+	// description: |
+	//   Return the number of runs and the time spent running each query of
+	//   the database, the most time consuming queries first.  Only available
+	//   when the service was started with --db-profile and uses the sqlite
+	//   database backend.
+	//   (As of version 5.7.0)
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/systemDBProfileResponse'
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/system/dbprofile"), s.APIHandler(libpod.DBProfile)).Methods(http.MethodGet)
+	// swagger:operation GET /libpod/system/registrycache libpod SystemRegistryCacheLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: Show registry cache statistics
+	// description: |
+	//   Return the hits and misses of the registry cache and the size of
+	//   its content.  Only available when the service was started with
+	//   --registry-cache.
+	//   (As of version 5.7.0)
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/systemRegistryCacheResponse'
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/system/registrycache"), s.APIHandler(libpod.RegistryCacheStats)).Methods(http.MethodGet)
+	// swagger:operation POST /libpod/system/registrycache/gc libpod SystemRegistryCacheGCLibpod
+	// ---
+	// tags:
+	//   - system
+	// summary: Shrink the registry cache
+	// description: |
+	//   Remove the least recently used manifests and blobs of the registry
+	//   cache until it is under its maximum size and return its statistics.
+	//   Only available when the service was started with --registry-cache.
+	//   (As of version 5.7.0)
+	// produces:
+	// - application/json
+	// responses:
+	//   200:
+	//     $ref: '#/responses/systemRegistryCacheResponse'
+	//   400:
+	//     $ref: "#/responses/badParamError"
+	//   500:
+	//     $ref: "#/responses/internalError"
+	r.Handle(VersionedPath("/libpod/system/registrycache/gc"), s.APIHandler(libpod.RegistryCacheGC)).Methods(http.MethodPost)
 	return nil
 }
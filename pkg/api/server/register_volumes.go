@@ -17,6 +17,12 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	//  - volumes
 	// summary: Create a volume
 	// parameters:
+	//  - in: header
+	//    name: Idempotency-Key
+	//    type: string
+	//    description: |
+	//      Create the volume only once for all requests with this key. Repeated requests get the response
+	//      of the first request with the Idempotent-Replayed header set.
 	//  - in: body
 	//    name: create
 	//    description: attributes for creating a volume
@@ -29,7 +35,7 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	//     $ref: "#/responses/volumeCreateResponse"
 	//   '500':
 	//      "$ref": "#/responses/internalError"
-	r.Handle(VersionedPath("/libpod/volumes/create"), s.APIHandler(libpod.CreateVolume)).Methods(http.MethodPost)
+	r.Handle(VersionedPath("/libpod/volumes/create"), s.IdempotentHandler(s.APIHandler(libpod.CreateVolume), "volume create")).Methods(http.MethodPost)
 	// swagger:operation GET /libpod/volumes/{name}/exists libpod VolumeExistsLibpod
 	// ---
 	// tags:
@@ -241,6 +247,12 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	//  - volumes (compat)
 	// summary: Create a volume
 	// parameters:
+	//  - in: header
+	//    name: Idempotency-Key
+	//    type: string
+	//    description: |
+	//      Create the volume only once for all requests with this key. Repeated requests get the response
+	//      of the first request with the Idempotent-Replayed header set.
 	//  - in: body
 	//    name: create
 	//    description: |
@@ -255,8 +267,8 @@ func (s *APIServer) registerVolumeHandlers(r *mux.Router) error {
 	//     "$ref": "#/responses/volumeInspect"
 	//   '500':
 	//     "$ref": "#/responses/internalError"
-	r.Handle(VersionedPath("/volumes/create"), s.APIHandler(compat.CreateVolume)).Methods(http.MethodPost)
-	r.Handle("/volumes/create", s.APIHandler(compat.CreateVolume)).Methods(http.MethodPost)
+	r.Handle(VersionedPath("/volumes/create"), s.IdempotentHandler(s.APIHandler(compat.CreateVolume), "volume create")).Methods(http.MethodPost)
+	r.Handle("/volumes/create", s.IdempotentHandler(s.APIHandler(compat.CreateVolume), "volume create")).Methods(http.MethodPost)
 
 	// swagger:operation GET /volumes/{name} compat VolumeInspect
 	// ---
@@ -37,8 +37,8 @@ type APIServer struct {
 	*schema.Decoder                  // Decoder for Query parameters to structs
 	context.CancelFunc               // Stop APIServer
 	context.Context                  // Context to carry objects to handlers
-	CorsHeaders        string        // Inject Cross-Origin Resource Sharing (CORS) headers
 	PProfAddr          string        // Binding network address for pprof profiles
+	cors               *corsConfig   // Origins allowed to make Cross-Origin Resource Sharing (CORS) requests
 	idleTracker        *idle.Tracker // Track connections to support idle shutdown
 	tlsCertFile        string        // TLS serving certificate PEM file
 	tlsKeyFile         string        // TLS serving certificate private key PEM file
@@ -62,7 +62,11 @@ func NewServerWithSettings(runtime *libpod.Runtime, listener net.Listener, opts
 
 func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.ServiceOptions) (*APIServer, error) {
 	logrus.Infof("API service listening on %q. URI: %q", listener.Addr(), runtime.RemoteURI())
-	if opts.CorsHeaders == "" {
+	cors, err := parseCors(opts.CorsHeaders)
+	if err != nil {
+		return nil, err
+	}
+	if cors == nil {
 		logrus.Debug("CORS Headers were not set")
 	} else {
 		logrus.Debugf("CORS Headers were set to %q", opts.CorsHeaders)
@@ -78,17 +82,17 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 			},
 			ConnState:   tracker.ConnState,
 			ErrorLog:    log.New(logrus.StandardLogger().Out, "", 0),
-			Handler:     router,
 			IdleTimeout: opts.Timeout * 2,
 		},
-		CorsHeaders:     opts.CorsHeaders,
 		Listener:        listener,
 		PProfAddr:       opts.PProfAddr,
 		idleTracker:     tracker,
 		tlsCertFile:     opts.TLSCertFile,
 		tlsKeyFile:      opts.TLSKeyFile,
 		tlsClientCAFile: opts.TLSClientCAFile,
+		cors:            cors,
 	}
+	server.Handler = server.corsHandler(router)
 
 	server.BaseContext = func(_ net.Listener) context.Context {
 		ctx := context.WithValue(context.Background(), types.DecoderKey, handlers.NewAPIDecoder())
@@ -139,6 +143,7 @@ func newServer(runtime *libpod.Runtime, listener net.Listener, opts entities.Ser
 		server.registerExecHandlers,
 		server.registerGenerateHandlers,
 		server.registerHealthCheckHandlers,
+		server.registerHooksHandlers,
 		server.registerImagesHandlers,
 		server.registerInfoHandlers,
 		server.registerManifestHandlers,
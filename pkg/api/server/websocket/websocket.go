@@ -0,0 +1,252 @@
+//go:build !remote
+
+// Package websocket implements the server side of the WebSocket protocol
+// (RFC 6455) the API service uses to stream attach, exec and event sessions
+// to clients which cannot hijack HTTP connections, e.g. web browsers.
+package websocket
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Opcodes of WebSocket frames.
+const (
+	continuationFrame = 0x0
+	TextMessage       = 0x1
+	BinaryMessage     = 0x2
+	closeFrame        = 0x8
+	pingFrame         = 0x9
+	pongFrame         = 0xa
+)
+
+// acceptGUID is appended to the key of the client to compute the accept key
+// of the handshake.
+const acceptGUID = "258EAFA5-E914-47DA-95CA-C5AB0DC85B11"
+
+// maxControlPayload is the largest payload of a control frame.
+const maxControlPayload = 125
+
+// closeNormal is the status code of a normal closure.
+const closeNormal = 1000
+
+// IsUpgrade returns true if the request asks to upgrade the connection to
+// the WebSocket protocol.
+func IsUpgrade(r *http.Request) bool {
+	if !strings.EqualFold(r.Header.Get("Upgrade"), "websocket") {
+		return false
+	}
+	for _, value := range r.Header.Values("Connection") {
+		for token := range strings.SplitSeq(value, ",") {
+			if strings.EqualFold(strings.TrimSpace(token), "upgrade") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AcceptKey returns the Sec-WebSocket-Accept header of the handshake
+// response to the Sec-WebSocket-Key header of the request.
+func AcceptKey(key string) string {
+	sum := sha1.Sum([]byte(key + acceptGUID))
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// CheckHandshake verifies the request is a valid opening handshake.
+func CheckHandshake(r *http.Request) error {
+	if r.Method != http.MethodGet {
+		return fmt.Errorf("WebSocket upgrade requires method GET, not %s", r.Method)
+	}
+	if !IsUpgrade(r) {
+		return errors.New("request is not a WebSocket upgrade")
+	}
+	if version := r.Header.Get("Sec-WebSocket-Version"); version != "13" {
+		return fmt.Errorf("unsupported WebSocket version %q, only 13 is supported", version)
+	}
+	if key, err := base64.StdEncoding.DecodeString(r.Header.Get("Sec-WebSocket-Key")); err != nil || len(key) != 16 {
+		return errors.New("invalid Sec-WebSocket-Key header")
+	}
+	return nil
+}
+
+// WriteHandshake writes the response to the opening handshake r, which must
+// have been checked with CheckHandshake.
+func WriteHandshake(w io.Writer, r *http.Request) error {
+	_, err := fmt.Fprintf(w, "HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\nSec-WebSocket-Accept: %s\r\n\r\n",
+		AcceptKey(r.Header.Get("Sec-WebSocket-Key")))
+	return err
+}
+
+// Conn is a WebSocket connection after the handshake.  Writes are sent as
+// messages of one frame of the message type of the connection, reads return
+// the payloads of the data frames received.  Control frames are answered
+// while reading.
+type Conn struct {
+	net.Conn
+	reader      *bufio.Reader
+	messageType byte
+
+	writeLock sync.Mutex
+	closed    bool
+
+	// payload of the data frame being read
+	remaining uint64
+	mask      [4]byte
+	maskPos   int
+}
+
+// NewConn returns the WebSocket connection of conn, reading from reader
+// which may hold data buffered from conn.
+func NewConn(conn net.Conn, reader *bufio.Reader, messageType byte) *Conn {
+	if reader == nil {
+		reader = bufio.NewReader(conn)
+	}
+	return &Conn{Conn: conn, reader: reader, messageType: messageType}
+}
+
+// Write sends b as one message.
+func (c *Conn) Write(b []byte) (int, error) {
+	if len(b) == 0 {
+		return 0, nil
+	}
+	if err := c.writeFrame(c.messageType, b); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// writeFrame writes a final, unmasked frame as servers send them.
+func (c *Conn) writeFrame(opcode byte, payload []byte) error {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	if c.closed {
+		return net.ErrClosed
+	}
+	header := make([]byte, 2, 10)
+	header[0] = 0x80 | opcode
+	switch length := len(payload); {
+	case length < 126:
+		header[1] = byte(length)
+	case length <= 0xffff:
+		header[1] = 126
+		header = binary.BigEndian.AppendUint16(header, uint16(length))
+	default:
+		header[1] = 127
+		header = binary.BigEndian.AppendUint64(header, uint64(length))
+	}
+	if _, err := c.Conn.Write(append(header, payload...)); err != nil {
+		return err
+	}
+	if opcode == closeFrame {
+		c.closed = true
+	}
+	return nil
+}
+
+// Read reads the payload of data frames.  It returns io.EOF once the client
+// closed the connection.
+func (c *Conn) Read(b []byte) (int, error) {
+	for c.remaining == 0 {
+		if err := c.nextDataFrame(); err != nil {
+			return 0, err
+		}
+	}
+	if uint64(len(b)) > c.remaining {
+		b = b[:c.remaining]
+	}
+	n, err := c.reader.Read(b)
+	for i := range n {
+		b[i] ^= c.mask[c.maskPos]
+		c.maskPos = (c.maskPos + 1) % 4
+	}
+	c.remaining -= uint64(n)
+	return n, err
+}
+
+// nextDataFrame reads frame headers up to the next data frame, handling the
+// control frames in between.
+func (c *Conn) nextDataFrame() error {
+	for {
+		var header [2]byte
+		if _, err := io.ReadFull(c.reader, header[:]); err != nil {
+			return err
+		}
+		opcode := header[0] & 0x0f
+		if header[1]&0x80 == 0 {
+			return c.fail("client frames must be masked")
+		}
+		length := uint64(header[1] & 0x7f)
+		switch length {
+		case 126:
+			var ext [2]byte
+			if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+				return err
+			}
+			length = uint64(binary.BigEndian.Uint16(ext[:]))
+		case 127:
+			var ext [8]byte
+			if _, err := io.ReadFull(c.reader, ext[:]); err != nil {
+				return err
+			}
+			length = binary.BigEndian.Uint64(ext[:])
+		}
+		if _, err := io.ReadFull(c.reader, c.mask[:]); err != nil {
+			return err
+		}
+		c.maskPos = 0
+
+		switch opcode {
+		case continuationFrame, TextMessage, BinaryMessage:
+			c.remaining = length
+			return nil
+		case closeFrame, pingFrame, pongFrame:
+			if length > maxControlPayload {
+				return c.fail("control frame too large")
+			}
+			payload := make([]byte, length)
+			if _, err := io.ReadFull(c.reader, payload); err != nil {
+				return err
+			}
+			for i := range payload {
+				payload[i] ^= c.mask[i%4]
+			}
+			switch opcode {
+			case closeFrame:
+				// echo the status code of the client
+				if len(payload) > 2 {
+					payload = payload[:2]
+				}
+				_ = c.writeFrame(closeFrame, payload)
+				return io.EOF
+			case pingFrame:
+				if err := c.writeFrame(pongFrame, payload); err != nil {
+					return err
+				}
+			}
+		default:
+			return c.fail(fmt.Sprintf("unknown opcode %d", opcode))
+		}
+	}
+}
+
+// fail closes the connection after a protocol error.
+func (c *Conn) fail(reason string) error {
+	_ = c.writeFrame(closeFrame, binary.BigEndian.AppendUint16(nil, 1002))
+	return fmt.Errorf("WebSocket protocol error: %s", reason)
+}
+
+// Close sends a close frame and closes the connection.
+func (c *Conn) Close() error {
+	_ = c.writeFrame(closeFrame, binary.BigEndian.AppendUint16(nil, closeNormal))
+	return c.Conn.Close()
+}
@@ -0,0 +1,139 @@
+//go:build !remote
+
+package websocket
+
+import (
+	"bufio"
+	"encoding/binary"
+	"io"
+	"net"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clientFrame returns a masked frame as clients send them.
+func clientFrame(opcode byte, payload []byte) []byte {
+	mask := [4]byte{1, 2, 3, 4}
+	frame := []byte{0x80 | opcode, 0x80 | byte(len(payload))}
+	frame = append(frame, mask[:]...)
+	for i, b := range payload {
+		frame = append(frame, b^mask[i%4])
+	}
+	return frame
+}
+
+// readFrame reads an unmasked frame as servers send them.
+func readFrame(t *testing.T, r io.Reader) (byte, []byte) {
+	header := make([]byte, 2)
+	_, err := io.ReadFull(r, header)
+	require.NoError(t, err)
+	length := int(header[1] & 0x7f)
+	if length == 126 {
+		ext := make([]byte, 2)
+		_, err := io.ReadFull(r, ext)
+		require.NoError(t, err)
+		length = int(binary.BigEndian.Uint16(ext))
+	}
+	payload := make([]byte, length)
+	_, err = io.ReadFull(r, payload)
+	require.NoError(t, err)
+	return header[0] & 0x0f, payload
+}
+
+func TestAcceptKey(t *testing.T) {
+	// example of RFC 6455
+	assert.Equal(t, "s3pPLMBiTxaQ9kYGzzhZRbK+xOo=", AcceptKey("dGhlIHNhbXBsZSBub25jZQ=="))
+}
+
+func TestCheckHandshake(t *testing.T) {
+	req := httptest.NewRequest("GET", "/events", nil)
+	assert.False(t, IsUpgrade(req))
+	assert.Error(t, CheckHandshake(req))
+
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Connection", "keep-alive, Upgrade")
+	assert.True(t, IsUpgrade(req))
+	assert.ErrorContains(t, CheckHandshake(req), "unsupported WebSocket version")
+
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "short")
+	assert.ErrorContains(t, CheckHandshake(req), "invalid Sec-WebSocket-Key")
+
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	assert.NoError(t, CheckHandshake(req))
+
+	req.Method = "POST"
+	assert.ErrorContains(t, CheckHandshake(req), "requires method GET")
+}
+
+func TestConn(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	conn := NewConn(server, nil, BinaryMessage)
+
+	// writes are sent as one message
+	go func() {
+		_, _ = conn.Write([]byte("hello"))
+		_, _ = conn.Write(make([]byte, 300))
+	}()
+	opcode, payload := readFrame(t, client)
+	assert.Equal(t, byte(BinaryMessage), opcode)
+	assert.Equal(t, []byte("hello"), payload)
+	_, payload = readFrame(t, client)
+	assert.Len(t, payload, 300)
+
+	// pings are answered while reading data frames
+	go func() {
+		_, _ = client.Write(clientFrame(pingFrame, []byte("ping")))
+		_, _ = client.Write(clientFrame(TextMessage, []byte("data")))
+	}()
+	reader := bufio.NewReader(client)
+	done := make(chan []byte)
+	go func() {
+		buf := make([]byte, 10)
+		n, _ := conn.Read(buf)
+		done <- buf[:n]
+	}()
+	opcode, payload = readFrame(t, reader)
+	assert.Equal(t, byte(pongFrame), opcode)
+	assert.Equal(t, []byte("ping"), payload)
+	assert.Equal(t, []byte("data"), <-done)
+
+	// a close frame is echoed and ends reading
+	go func() {
+		_, _ = client.Write(clientFrame(closeFrame, binary.BigEndian.AppendUint16(nil, 1001)))
+	}()
+	go func() {
+		_, err := conn.Read(make([]byte, 10))
+		done <- []byte(err.Error())
+	}()
+	opcode, payload = readFrame(t, reader)
+	assert.Equal(t, byte(closeFrame), opcode)
+	assert.Equal(t, binary.BigEndian.AppendUint16(nil, 1001), payload)
+	assert.Equal(t, []byte(io.EOF.Error()), <-done)
+
+	_, err := conn.Write([]byte("late"))
+	assert.ErrorIs(t, err, net.ErrClosed)
+}
+
+func TestConnUnmaskedFrame(t *testing.T) {
+	server, client := net.Pipe()
+	defer client.Close()
+	conn := NewConn(server, nil, TextMessage)
+
+	go func() {
+		_, _ = client.Write([]byte{0x81, 0x01, 'x'})
+	}()
+	errChan := make(chan error)
+	go func() {
+		_, err := conn.Read(make([]byte, 10))
+		errChan <- err
+	}()
+	opcode, payload := readFrame(t, client)
+	assert.Equal(t, byte(closeFrame), opcode)
+	assert.Equal(t, binary.BigEndian.AppendUint16(nil, 1002), payload)
+	assert.ErrorContains(t, <-errChan, "client frames must be masked")
+}
@@ -0,0 +1,226 @@
+package auth
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"github.com/docker/docker-credential-helpers/client"
+	"github.com/docker/docker-credential-helpers/credentials"
+	commonAuth "go.podman.io/common/pkg/auth"
+	"go.podman.io/image/v5/pkg/sysregistriesv2"
+	"go.podman.io/image/v5/types"
+	"go.podman.io/storage/pkg/homedir"
+)
+
+// AuthFileHelper is the built-in credential helper which stores the
+// credentials in the auth file itself.
+const AuthFileHelper = sysregistriesv2.AuthenticationFileHelper
+
+// CredentialHelper describes a credential helper which supplies registry
+// credentials.
+type CredentialHelper struct {
+	// Name of the helper, e.g. "secretservice" for the
+	// docker-credential-secretservice binary.
+	Name string
+	// Registries the helper is selected for in the credHelpers of the auth
+	// file.  Empty if the helper is configured for all registries in
+	// registries.conf.
+	Registries []string
+	// Path of the helper binary or, for the built-in helper, of the auth
+	// file.  Empty if the binary is not installed.
+	Path string
+}
+
+// DefaultAuthFile returns the auth file used when none is specified.
+//
+// Keep this in sync with the default logic of containers/image.
+func DefaultAuthFile() string {
+	if authfile := commonAuth.GetDefaultAuthFile(); authfile != "" {
+		return authfile
+	}
+	if runtime.GOOS != "linux" {
+		return filepath.Join(homedir.Get(), ".config", "containers", "auth.json")
+	}
+	if runtimeDir := os.Getenv("XDG_RUNTIME_DIR"); runtimeDir != "" {
+		return filepath.Join(runtimeDir, "containers", "auth.json")
+	}
+	return fmt.Sprintf("/run/containers/%d/auth.json", os.Getuid())
+}
+
+// readAuthFile returns the top-level fields of the auth file, or an empty
+// map if the file does not exist.
+func readAuthFile(authfile string) (map[string]json.RawMessage, error) {
+	fields := make(map[string]json.RawMessage)
+	data, err := os.ReadFile(authfile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fields, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, fmt.Errorf("unmarshaling JSON at %q: %w", authfile, err)
+	}
+	return fields, nil
+}
+
+// credHelpers returns the credential helpers selected per registry in the
+// auth file.
+func credHelpers(fields map[string]json.RawMessage) (map[string]string, error) {
+	helpers := make(map[string]string)
+	if raw, ok := fields["credHelpers"]; ok {
+		if err := json.Unmarshal(raw, &helpers); err != nil {
+			return nil, fmt.Errorf("unmarshaling credHelpers: %w", err)
+		}
+	}
+	return helpers, nil
+}
+
+// SetCredentialHelper selects the credential helper for the registry in the
+// credHelpers of the auth file, so that its credentials are stored in and
+// looked up from that helper.  Selecting AuthFileHelper removes the selection.
+func SetCredentialHelper(authfile, registry, helper string) error {
+	if strings.Contains(registry, "/") {
+		return fmt.Errorf("credential helpers can only be selected for a registry, not for %q", registry)
+	}
+	fields, err := readAuthFile(authfile)
+	if err != nil {
+		return err
+	}
+	helpers, err := credHelpers(fields)
+	if err != nil {
+		return err
+	}
+	if helper == AuthFileHelper {
+		delete(helpers, registry)
+	} else {
+		helpers[registry] = helper
+	}
+	raw, err := json.Marshal(helpers)
+	if err != nil {
+		return err
+	}
+	fields["credHelpers"] = raw
+	if _, ok := fields["auths"]; !ok {
+		fields["auths"] = json.RawMessage("{}")
+	}
+	data, err := json.MarshalIndent(fields, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(authfile), 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(authfile), ".auth.json.")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), authfile)
+}
+
+// CredentialHelpers returns the credential helpers configured for all
+// registries in registries.conf followed by the ones selected per registry
+// in the auth file.
+func CredentialHelpers(sys *types.SystemContext, authfile string) ([]CredentialHelper, error) {
+	names, err := sysregistriesv2.CredentialHelpers(sys)
+	if err != nil {
+		return nil, err
+	}
+	helpers := make([]CredentialHelper, 0, len(names))
+	for _, name := range names {
+		helpers = append(helpers, CredentialHelper{Name: name, Path: helperPath(name, authfile)})
+	}
+
+	fields, err := readAuthFile(authfile)
+	if err != nil {
+		return nil, err
+	}
+	selected, err := credHelpers(fields)
+	if err != nil {
+		return nil, err
+	}
+	perRegistry := make(map[string][]string)
+	for registry, name := range selected {
+		perRegistry[name] = append(perRegistry[name], registry)
+	}
+	for _, name := range slices.Sorted(maps.Keys(perRegistry)) {
+		registries := perRegistry[name]
+		slices.Sort(registries)
+		helpers = append(helpers, CredentialHelper{Name: name, Registries: registries, Path: helperPath(name, authfile)})
+	}
+	return helpers, nil
+}
+
+func helperPath(name, authfile string) string {
+	if name == AuthFileHelper {
+		return authfile
+	}
+	path, err := exec.LookPath("docker-credential-" + name)
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// HelperUsername returns the user name of the credentials the helper supplies
+// for the registry, or an empty string if it has none.
+func HelperUsername(authfile, helper, registry string) (string, error) {
+	if helper != AuthFileHelper {
+		creds, err := client.Get(client.NewShellProgramFunc("docker-credential-"+helper), registry)
+		if err != nil {
+			if credentials.IsErrCredentialsNotFound(err) {
+				return "", nil
+			}
+			return "", err
+		}
+		return creds.Username, nil
+	}
+
+	fields, err := readAuthFile(authfile)
+	if err != nil {
+		return "", err
+	}
+	selected, err := credHelpers(fields)
+	if err != nil {
+		return "", err
+	}
+	if name, ok := selected[registry]; ok {
+		return HelperUsername(authfile, name, registry)
+	}
+	auths := make(map[string]struct {
+		Auth string `json:"auth"`
+	})
+	if raw, ok := fields["auths"]; ok {
+		if err := json.Unmarshal(raw, &auths); err != nil {
+			return "", fmt.Errorf("unmarshaling auths: %w", err)
+		}
+	}
+	entry, ok := auths[registry]
+	if !ok || entry.Auth == "" {
+		return "", nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(entry.Auth)
+	if err != nil {
+		return "", err
+	}
+	username, _, _ := strings.Cut(string(decoded), ":")
+	return username, nil
+}
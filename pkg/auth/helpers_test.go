@@ -0,0 +1,78 @@
+//go:build !windows
+
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/types"
+)
+
+func TestSetCredentialHelper(t *testing.T) {
+	authfile := filepath.Join(t.TempDir(), "containers", "auth.json")
+
+	// The auth file is created if it does not exist.
+	err := SetCredentialHelper(authfile, "quay.io", "secretservice")
+	require.NoError(t, err)
+	err = SetCredentialHelper(authfile, "example.com", "pass")
+	require.NoError(t, err)
+	data, err := os.ReadFile(authfile)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"auths":{},"credHelpers":{"quay.io":"secretservice","example.com":"pass"}}`, string(data))
+
+	// The credentials of other registries are kept.
+	err = os.WriteFile(authfile, []byte(`{"auths":{"docker.io":{"auth":"dXNlcjpwYXNz"}},"credHelpers":{"quay.io":"secretservice"}}`), 0o600)
+	require.NoError(t, err)
+	err = SetCredentialHelper(authfile, "quay.io", AuthFileHelper)
+	require.NoError(t, err)
+	data, err = os.ReadFile(authfile)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"auths":{"docker.io":{"auth":"dXNlcjpwYXNz"}},"credHelpers":{}}`, string(data))
+
+	err = SetCredentialHelper(authfile, "quay.io/libpod", "secretservice")
+	assert.ErrorContains(t, err, "can only be selected for a registry")
+}
+
+func TestCredentialHelpers(t *testing.T) {
+	dir := t.TempDir()
+	registriesConf := filepath.Join(dir, "registries.conf")
+	err := os.WriteFile(registriesConf, []byte(`credential-helpers = ["containers-auth.json", "nosuchhelper"]`), 0o600)
+	require.NoError(t, err)
+	authfile := filepath.Join(dir, "auth.json")
+	err = os.WriteFile(authfile, []byte(`{"auths":{},"credHelpers":{"quay.io":"nosuchhelper1","example.com":"nosuchhelper1","docker.io":"nosuchhelper2"}}`), 0o600)
+	require.NoError(t, err)
+
+	sys := &types.SystemContext{
+		SystemRegistriesConfPath:    registriesConf,
+		SystemRegistriesConfDirPath: filepath.Join(dir, "registries.conf.d"),
+	}
+	helpers, err := CredentialHelpers(sys, authfile)
+	require.NoError(t, err)
+	assert.Equal(t, []CredentialHelper{
+		{Name: AuthFileHelper, Path: authfile},
+		{Name: "nosuchhelper"},
+		{Name: "nosuchhelper1", Registries: []string{"example.com", "quay.io"}},
+		{Name: "nosuchhelper2", Registries: []string{"docker.io"}},
+	}, helpers)
+}
+
+func TestHelperUsername(t *testing.T) {
+	authfile := filepath.Join(t.TempDir(), "auth.json")
+	err := os.WriteFile(authfile, []byte(`{"auths":{"quay.io":{"auth":"cXVheTp0b3A="}}}`), 0o600)
+	require.NoError(t, err)
+
+	username, err := HelperUsername(authfile, AuthFileHelper, "quay.io")
+	require.NoError(t, err)
+	assert.Equal(t, "quay", username)
+
+	username, err = HelperUsername(authfile, AuthFileHelper, "docker.io")
+	require.NoError(t, err)
+	assert.Empty(t, username)
+
+	_, err = HelperUsername(authfile, "nosuchhelper", "quay.io")
+	assert.Error(t, err)
+}
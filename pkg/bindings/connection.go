@@ -36,6 +36,11 @@ type Connection struct {
 	URI    *url.URL
 	Client *http.Client
 	tls    bool
+	// retry is the retry policy of requests, the defaults if nil
+	retry *RetryPolicy
+	// timeout is the time to wait for responses of the service, no limit
+	// if 0
+	timeout time.Duration
 }
 
 type valueKey string
@@ -114,6 +119,15 @@ type Options struct {
 	TLSKeyFile  string
 	TLSCAFile   string
 	Machine     bool
+	// RetryPolicy is the retry policy of the requests of the connection,
+	// the defaults of RetryPolicy if nil.  It can be changed for single
+	// requests with WithRetryPolicy.
+	RetryPolicy *RetryPolicy
+	// RequestTimeout is the time to wait for the responses of the
+	// service, no limit if 0.  Reading the response body, e.g. of streamed
+	// logs, is not limited.  It can be changed for single requests with
+	// WithRequestTimeout.
+	RequestTimeout time.Duration
 }
 
 func orEnv(s string, env string) string {
@@ -163,6 +177,8 @@ func NewConnectionWithOptions(ctx context.Context, opts Options) (context.Contex
 	default:
 		return nil, fmt.Errorf("unable to create connection. %q is not a supported schema", _url.Scheme)
 	}
+	connection.retry = opts.RetryPolicy
+	connection.timeout = opts.RequestTimeout
 
 	ctx = context.WithValue(ctx, clientKey, &connection)
 	serviceVersion, err := pingNewConnection(ctx)
@@ -478,14 +494,8 @@ func (c *Connection) DoRequest(ctx context.Context, httpBody io.Reader, httpMeth
 		}
 	}
 
-	// Give the Do three chances in the case of a comm/service hiccup
-	for i := 1; i <= 3; i++ {
-		response, err = c.Client.Do(req) //nolint:bodyclose // The caller has to close the body.
-		if err == nil {
-			break
-		}
-		time.Sleep(time.Duration(i*100) * time.Millisecond)
-	}
+	// Retry in the case of a comm/service hiccup
+	response, err = c.do(req) //nolint:bodyclose // The caller has to close the body.
 	return &APIResponse{response, req}, err
 }
 
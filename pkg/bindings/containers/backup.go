@@ -0,0 +1,60 @@
+package containers
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/dmikushin/podman-shared/pkg/bindings"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+)
+
+// Backup writes a backup of the container, with the changes of its writable
+// layer and its named volumes, to the writer as a tar archive.
+func Backup(ctx context.Context, nameOrID string, w io.Writer, options *BackupOptions) error {
+	if options == nil {
+		options = new(BackupOptions)
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/backup", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.IsSuccess() {
+		_, err = io.Copy(w, response.Body)
+		return err
+	}
+	return response.Process(nil)
+}
+
+// RestoreBackup recreates a container from the backup read from the reader.
+func RestoreBackup(ctx context.Context, r io.Reader, options *RestoreBackupOptions) (*types.ContainerRestoreBackupReport, error) {
+	var report types.ContainerRestoreBackupReport
+	if options == nil {
+		options = new(RestoreBackupOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, r, http.MethodPost, "/containers/restore-backup", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/bindings"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities/reports"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+	jsoniter "github.com/json-iterator/go"
 )
 
 var (
@@ -156,12 +157,15 @@ func Pause(ctx context.Context, nameOrID string, options *PauseOptions) error {
 	if options == nil {
 		options = new(PauseOptions)
 	}
-	_ = options
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
 		return err
 	}
-	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/containers/%s/pause", nil, nil, nameOrID)
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/containers/%s/pause", params, nil, nameOrID)
 	if err != nil {
 		return err
 	}
@@ -278,6 +282,36 @@ func Stats(ctx context.Context, containers []string, options *StatsOptions) (cha
 	return statsChan, nil
 }
 
+// StatsHistory returns the samples of the stats history of the containers.
+// Without containers, the stats history of all containers is returned.
+func StatsHistory(ctx context.Context, containers []string, options *StatsHistoryOptions) ([]*types.ContainerStatsHistoryReport, error) {
+	if options == nil {
+		options = new(StatsHistoryOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range containers {
+		params.Add("containers", c)
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/stats/history", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var reports []*types.ContainerStatsHistoryReport
+	if err := response.Process(&reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
 // Top gathers statistics about the running processes in a container. The nameOrID can be a container name
 // or a partial/full ID.  The descriptors allow for specifying which data to collect from the process.
 func Top(ctx context.Context, nameOrID string, options *TopOptions) ([]string, error) {
@@ -365,6 +399,56 @@ func Wait(ctx context.Context, nameOrID string, options *WaitOptions) (int32, er
 	return exitCode, response.Process(&exitCode)
 }
 
+// WaitConditions waits on multiple containers to meet multiple conditions.
+// Each condition is awaited separately for each container and a report is
+// sent on the returned channel as soon as one is met.  The channel is closed
+// once all conditions are met or failed.
+func WaitConditions(ctx context.Context, namesOrIDs []string, options *WaitConditionsOptions) (chan types.WaitConditionReport, error) {
+	if options == nil {
+		options = new(WaitConditionsOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range namesOrIDs {
+		params.Add("containers", n)
+	}
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/containers/wait", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !response.IsSuccess() {
+		defer response.Body.Close()
+		return nil, response.Process(nil)
+	}
+
+	reportChan := make(chan types.WaitConditionReport)
+	go func() {
+		defer close(reportChan)
+		defer response.Body.Close()
+
+		dec := json.NewDecoder(response.Body)
+		for {
+			var report types.WaitConditionReport
+			if err := dec.Decode(&report); err != nil {
+				if !errors.Is(err, io.EOF) {
+					reportChan <- types.WaitConditionReport{Error: fmt.Sprintf("failed to decode message from stream: %v", err)}
+				}
+				return
+			}
+			reportChan <- report
+		}
+	}()
+
+	return reportChan, nil
+}
+
 // Exists is a quick, light-weight way to determine if a given container
 // exists in local storage.  The nameOrID can be a container name
 // or a partial/full ID.
@@ -386,6 +470,39 @@ func Exists(ctx context.Context, nameOrID string, options *ExistsOptions) (bool,
 	return response.IsSuccess(), nil
 }
 
+// ExistsMany checks whether each of the containers exists in local storage
+// with a single request.  The result is keyed by the given names or IDs.
+func ExistsMany(ctx context.Context, namesOrIDs []string, options *ExistsOptions) (map[string]bool, error) {
+	if options == nil {
+		options = new(ExistsOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	body, err := jsoniter.MarshalToString(namesOrIDs)
+	if err != nil {
+		return nil, err
+	}
+	header := http.Header{}
+	header.Set("Content-Type", "application/json")
+	response, err := conn.DoRequest(ctx, strings.NewReader(body), http.MethodPost, "/containers/exists", params, header)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	reports := make(map[string]bool, len(namesOrIDs))
+	if err := response.Process(&reports); err != nil {
+		return nil, err
+	}
+	return reports, nil
+}
+
 // Stop stops a running container.  The timeout is optional. The nameOrID can be a container name
 // or a partial/full ID
 func Stop(ctx context.Context, nameOrID string, options *StopOptions) error {
@@ -418,8 +535,10 @@ func Export(ctx context.Context, nameOrID string, w io.Writer, options *ExportOp
 	if options == nil {
 		options = new(ExportOptions)
 	}
-	_ = options
-	params := url.Values{}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
 	conn, err := bindings.GetClient(ctx)
 	if err != nil {
 		return err
@@ -0,0 +1,26 @@
+package containers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dmikushin/podman-shared/pkg/bindings"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+)
+
+// Hooks reports which OCI hooks of the hooks directories of the service
+// match the configuration of a container.
+func Hooks(ctx context.Context, nameOrID string, _ *HooksOptions) ([]*types.HookTestReport, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/containers/%s/hooks", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var reports []*types.HookTestReport
+	return reports, response.Process(&reports)
+}
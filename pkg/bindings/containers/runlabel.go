@@ -0,0 +1,78 @@
+package containers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/dmikushin/podman-shared/pkg/auth"
+	"github.com/dmikushin/podman-shared/pkg/bindings"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+	imageTypes "go.podman.io/image/v5/types"
+)
+
+// Runlabel executes the command described by the label of the image on the
+// server.  The output of the pull and of the command is written to the writer
+// of the options.
+func Runlabel(ctx context.Context, label, image string, args []string, options *RunlabelOptions) error {
+	if options == nil {
+		options = new(RunlabelOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	params.Set("label", label)
+	params.Set("image", image)
+	for _, arg := range args {
+		params.Add("args", arg)
+	}
+	// SkipTLSVerify is special.  It's not being serialized by ToParams()
+	// because we need to flip the boolean.
+	if options.SkipTLSVerify != nil {
+		params.Set("tlsVerify", strconv.FormatBool(!options.GetSkipTLSVerify()))
+	}
+
+	header, err := auth.MakeXRegistryAuthHeader(&imageTypes.SystemContext{AuthFilePath: options.GetAuthfile()}, options.GetUsername(), options.GetPassword())
+	if err != nil {
+		return err
+	}
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/containers/runlabel", params, header)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if !response.IsSuccess() {
+		return response.Process(nil)
+	}
+
+	writer := options.GetWriter()
+	if writer == nil {
+		writer = os.Stdout
+	}
+
+	dec := json.NewDecoder(response.Body)
+	for {
+		var report types.ContainerRunlabelReport
+		if err := dec.Decode(&report); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to decode message from stream: %w", err)
+		}
+		if report.Error != "" {
+			return errors.New(report.Error)
+		}
+		fmt.Fprint(writer, report.Stream)
+	}
+}
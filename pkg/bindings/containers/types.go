@@ -39,6 +39,37 @@ type CommitOptions struct {
 	Tag     *string
 }
 
+// RunlabelOptions are optional options for executing the command described
+// by an image label
+//
+//go:generate go run ../generator/generator.go RunlabelOptions
+type RunlabelOptions struct {
+	// Authfile - path to the authentication file for the image pull
+	Authfile *string `schema:"-"`
+	// Display - only print the command that the label would run
+	Display *bool
+	// Name - name of the container replacing NAME in the command
+	Name *string
+	// Opt1, Opt2, Opt3 - values of the OPT1, OPT2 and OPT3 variables
+	Opt1 *string
+	Opt2 *string
+	Opt3 *string
+	// Password for authenticating against the registry
+	Password *string `schema:"-"`
+	// Pull - pull the image if it does not exist on the server
+	Pull *bool
+	// Quiet - suppress the output of the pull and the command
+	Quiet *bool
+	// Replace - replace an existing container with the same name
+	Replace *bool
+	// SkipTLSVerify to skip HTTPS and certificate verification
+	SkipTLSVerify *bool `schema:"-"`
+	// Username for authenticating against the registry
+	Username *string `schema:"-"`
+	// Writer receives the output, defaults to os.Stdout
+	Writer *io.Writer `schema:"-"`
+}
+
 // AttachOptions are optional options for attaching to containers
 //
 //go:generate go run ../generator/generator.go AttachOptions
@@ -146,13 +177,21 @@ type MountedContainerPathsOptions struct{}
 //
 //go:generate go run ../generator/generator.go ListOptions
 type ListOptions struct {
-	All       *bool
-	External  *bool
+	All      *bool
+	External *bool
+	// Fields selects the fields of the containers returned, all fields
+	// by default
+	Fields    []string
 	Filters   map[string][]string
 	Last      *int
 	Namespace *bool
-	Size      *bool
-	Sync      *bool
+	// Offset skips this number of most recently created containers
+	Offset *int
+	Size   *bool
+	// Sort sorts the containers by command, created, id, image, memory,
+	// names, pod, runningfor, size or status
+	Sort *string
+	Sync *bool
 }
 
 // PruneOptions are optional options for pruning containers
@@ -190,7 +229,9 @@ type KillOptions struct {
 // PauseOptions are optional options for pausing containers
 //
 //go:generate go run ../generator/generator.go PauseOptions
-type PauseOptions struct{}
+type PauseOptions struct {
+	FreezeTimeout *uint
+}
 
 // RestartOptions are optional options for restarting containers
 //
@@ -216,6 +257,16 @@ type StatsOptions struct {
 	Interval *int
 }
 
+// StatsHistoryOptions are optional options for getting the stats history of
+// containers
+//
+//go:generate go run ../generator/generator.go StatsHistoryOptions
+type StatsHistoryOptions struct {
+	All   *bool
+	Since *string
+	Until *string
+}
+
 // TopOptions are optional options for getting running
 // processes in containers
 //
@@ -243,6 +294,19 @@ type WaitOptions struct {
 	Condition []define.ContainerStatus
 }
 
+// WaitConditionsOptions are optional options for waiting on multiple
+// conditions of multiple containers
+//
+//go:generate go run ../generator/generator.go WaitConditionsOptions
+type WaitConditionsOptions struct {
+	// Conditions to wait on.  Each condition is awaited separately.
+	Conditions []string `schema:"condition"`
+	// Ignore containers which do not exist.
+	Ignore *bool
+	// Time interval to wait before polling for completion.
+	Interval *string
+}
+
 // StopOptions are optional options for stopping containers
 //
 //go:generate go run ../generator/generator.go StopOptions
@@ -254,7 +318,29 @@ type StopOptions struct {
 // ExportOptions are optional options for exporting containers
 //
 //go:generate go run ../generator/generator.go ExportOptions
-type ExportOptions struct{}
+type ExportOptions struct {
+	// Format is "tar", the default, or "oci-archive"
+	Format *string
+}
+
+// BackupOptions are optional options for backing up containers
+//
+//go:generate go run ../generator/generator.go BackupOptions
+type BackupOptions struct {
+	// IgnoreVolumes leaves the named volumes out of the backup
+	IgnoreVolumes *bool
+}
+
+// RestoreBackupOptions are optional options for restoring containers from
+// a backup
+//
+//go:generate go run ../generator/generator.go RestoreBackupOptions
+type RestoreBackupOptions struct {
+	// Name of the restored container, instead of the name in the backup
+	Name *string
+	// IgnoreVolumes does not restore the named volumes of the backup
+	IgnoreVolumes *bool
+}
 
 // InitOptions are optional options for initing containers
 //
@@ -343,3 +429,10 @@ type CopyOptions struct {
 type ExecRemoveOptions struct {
 	Force *bool
 }
+
+// HooksOptions are optional options for testing the OCI hooks against a
+// container
+//
+//go:generate go run ../generator/generator.go HooksOptions
+type HooksOptions struct {
+}
@@ -47,6 +47,21 @@ func (o *ListOptions) GetExternal() bool {
 	return *o.External
 }
 
+// WithFields set field Fields to given value
+func (o *ListOptions) WithFields(value []string) *ListOptions {
+	o.Fields = value
+	return o
+}
+
+// GetFields returns value of field Fields
+func (o *ListOptions) GetFields() []string {
+	if o.Fields == nil {
+		var z []string
+		return z
+	}
+	return o.Fields
+}
+
 // WithFilters set field Filters to given value
 func (o *ListOptions) WithFilters(value map[string][]string) *ListOptions {
 	o.Filters = value
@@ -92,6 +107,21 @@ func (o *ListOptions) GetNamespace() bool {
 	return *o.Namespace
 }
 
+// WithOffset set field Offset to given value
+func (o *ListOptions) WithOffset(value int) *ListOptions {
+	o.Offset = &value
+	return o
+}
+
+// GetOffset returns value of field Offset
+func (o *ListOptions) GetOffset() int {
+	if o.Offset == nil {
+		var z int
+		return z
+	}
+	return *o.Offset
+}
+
 // WithSize set field Size to given value
 func (o *ListOptions) WithSize(value bool) *ListOptions {
 	o.Size = &value
@@ -107,6 +137,21 @@ func (o *ListOptions) GetSize() bool {
 	return *o.Size
 }
 
+// WithSort set field Sort to given value
+func (o *ListOptions) WithSort(value string) *ListOptions {
+	o.Sort = &value
+	return o
+}
+
+// GetSort returns value of field Sort
+func (o *ListOptions) GetSort() string {
+	if o.Sort == nil {
+		var z string
+		return z
+	}
+	return *o.Sort
+}
+
 // WithSync set field Sync to given value
 func (o *ListOptions) WithSync(value bool) *ListOptions {
 	o.Sync = &value
@@ -16,3 +16,18 @@ func (o *PauseOptions) Changed(fieldName string) bool {
 func (o *PauseOptions) ToParams() (url.Values, error) {
 	return util.ToParams(o)
 }
+
+// WithFreezeTimeout set field FreezeTimeout to given value
+func (o *PauseOptions) WithFreezeTimeout(value uint) *PauseOptions {
+	o.FreezeTimeout = &value
+	return o
+}
+
+// GetFreezeTimeout returns value of field FreezeTimeout
+func (o *PauseOptions) GetFreezeTimeout() uint {
+	if o.FreezeTimeout == nil {
+		var z uint
+		return z
+	}
+	return *o.FreezeTimeout
+}
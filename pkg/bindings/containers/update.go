@@ -28,8 +28,9 @@ func Update(ctx context.Context, options *types.ContainerUpdateOptions) (string,
 	}
 
 	updateEntities := &handlers.UpdateEntities{
-		Env:      options.Env,
-		UnsetEnv: options.UnsetEnv,
+		Env:            options.Env,
+		UnsetEnv:       options.UnsetEnv,
+		NetworkOptions: options.NetworkOptions,
 	}
 	if options.Resources != nil {
 		updateEntities.LinuxResources = *options.Resources
@@ -40,6 +41,12 @@ func Update(ctx context.Context, options *types.ContainerUpdateOptions) (string,
 	if options.DevicesLimits != nil {
 		updateEntities.UpdateContainerDevicesLimits = *options.DevicesLimits
 	}
+	if options.Devices != nil {
+		updateEntities.UpdateContainerDevices = *options.Devices
+	}
+	if options.MemoryReclaim != nil {
+		updateEntities.MemoryReclaim = *options.MemoryReclaim
+	}
 
 	requestData, err := jsoniter.MarshalToString(updateEntities)
 	if err != nil {
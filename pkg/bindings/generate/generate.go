@@ -33,6 +33,30 @@ func Systemd(ctx context.Context, nameOrID string, options *SystemdOptions) (*ty
 	return report, response.Process(&report.Units)
 }
 
+// Quadlet generates Quadlet unit files recreating a container or pod
+func Quadlet(ctx context.Context, nameOrID string, options *QuadletOptions) (*types.GenerateQuadletReport, error) {
+	if options == nil {
+		options = new(QuadletOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/generate/%s/quadlet", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	report := &types.GenerateQuadletReport{}
+	return report, response.Process(&report.Units)
+}
+
 // Kube generate Kubernetes YAML (v1 specification)
 //
 // Note: Caller is responsible for closing returned reader
@@ -8,6 +8,8 @@ type KubeOptions struct {
 	PodmanOnly *bool
 	// Service - generate YAML for a Kubernetes _service_ object.
 	Service *bool
+	// Ingress - the kind of object routing HTTP traffic to the service, ingress or httproute.
+	Ingress *string
 	// Type - the k8s kind to be generated i.e Pod or Deployment
 	Type *string
 	// Replicas - the value to set in the replicas field for a Deployment
@@ -51,3 +53,11 @@ type SystemdOptions struct {
 	// AdditionalEnvVariables - Sets environment variables to a systemd unit file
 	AdditionalEnvVariables *[]string
 }
+
+// QuadletOptions are optional options for generating Quadlet files
+//
+//go:generate go run ../generator/generator.go QuadletOptions
+type QuadletOptions struct {
+	// NoHeader - Removes the Podman version comment if set to true
+	NoHeader *bool
+}
@@ -47,6 +47,21 @@ func (o *KubeOptions) GetService() bool {
 	return *o.Service
 }
 
+// WithIngress set field Ingress to given value
+func (o *KubeOptions) WithIngress(value string) *KubeOptions {
+	o.Ingress = &value
+	return o
+}
+
+// GetIngress returns value of field Ingress
+func (o *KubeOptions) GetIngress() string {
+	if o.Ingress == nil {
+		var z string
+		return z
+	}
+	return *o.Ingress
+}
+
 // WithType set field Type to given value
 func (o *KubeOptions) WithType(value string) *KubeOptions {
 	o.Type = &value
@@ -2,18 +2,21 @@ package images
 
 import (
 	"archive/tar"
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"maps"
 	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -31,6 +34,7 @@ import (
 	"github.com/hashicorp/go-multierror"
 	jsoniter "github.com/json-iterator/go"
 	gzip "github.com/klauspost/pgzip"
+	"github.com/opencontainers/go-digest"
 	"github.com/sirupsen/logrus"
 	imageTypes "go.podman.io/image/v5/types"
 	"go.podman.io/storage/pkg/archive"
@@ -52,6 +56,8 @@ type BuildResponse struct {
 	// NOTE: `error` is being deprecated check https://github.com/moby/moby/blob/master/pkg/jsonmessage/jsonmessage.go#L148
 	ErrorMessage string          `json:"error,omitempty"` // deprecate this slowly
 	Aux          json.RawMessage `json:"aux,omitempty"`
+	// Progress is a structured progress report, only sent on request
+	Progress *types.ProgressFrame `json:"progress,omitempty"`
 }
 
 // BuildFilePaths contains the file paths and exclusion patterns for the build context.
@@ -335,6 +341,9 @@ func prepareParams(options types.BuildOptions) (url.Values, error) {
 	if len(options.Manifest) > 0 {
 		params.Set("manifest", options.Manifest)
 	}
+	if options.SharedCache != "" {
+		params.Set("sharedcache", options.SharedCache)
+	}
 	if options.CacheFrom != nil {
 		cacheFrom := []string{}
 		for _, cacheSrc := range options.CacheFrom {
@@ -610,7 +619,8 @@ func prepareContainerFiles(containerFiles []string, contextDir string, stdinDest
 
 // prepareSecrets processes build secrets by creating temporary files for them.
 // It moves secrets to the context directory and modifies the secret configuration
-// to use relative paths suitable for remote builds.
+// to use relative paths suitable for remote builds.  Secrets of the secrets
+// store are looked up by the server and passed on unchanged.
 // WARNING: Caller must ensure tempManager.Cleanup() is called to remove any temporary files created.
 func prepareSecrets(secrets []string, contextDir string, tempManager *remote_build_helpers.TempFileManager) ([]string, []string, error) {
 	if len(secrets) == 0 {
@@ -626,7 +636,7 @@ func prepareSecrets(secrets []string, contextDir string, tempManager *remote_bui
 		for _, token := range secretOpt {
 			opt, val, hasVal := strings.Cut(token, "=")
 			if hasVal {
-				if opt == "src" {
+				if opt == "src" && !strings.HasPrefix(val, ldefine.BuildSecretPrefix) {
 					// read specified secret into a tmp file
 					// move tmp file to tar and change secret source to relative tmp file
 					tmpSecretFilePath, err := tempManager.CreateTempSecret(val, contextDir)
@@ -655,7 +665,12 @@ func prepareSecrets(secrets []string, contextDir string, tempManager *remote_bui
 // additional build contexts, supporting URLs, images, and local directories.
 // WARNING: Caller must close request body.
 func prepareRemoteRequestBody(ctx context.Context, requestParts *RequestParts, buildFilePaths *BuildFilePaths, options types.BuildOptions) (*RequestParts, error) {
-	tarfile, err := nTar(append(buildFilePaths.excludes, buildFilePaths.dontexcludes...), buildFilePaths.tarContent...)
+	excludes := append(buildFilePaths.excludes, buildFilePaths.dontexcludes...)
+	files, err := cachedContextFiles(ctx, requestParts.Params, excludes, buildFilePaths.tarContent)
+	if err != nil {
+		return nil, fmt.Errorf("looking up cached build context files: %w", err)
+	}
+	tarfile, err := nTar(excludes, files, buildFilePaths.tarContent...)
 	if err != nil {
 		logrus.Errorf("Cannot tar container entries %v error: %v", buildFilePaths.tarContent, err)
 		return nil, err
@@ -813,7 +828,7 @@ func prepareRemoteRequestBody(ctx context.Context, requestParts *RequestParts, b
 				}
 				file.Close()
 			} else {
-				tarContent, err := nTar(nil, context.Value)
+				tarContent, err := nTar(nil, nil, context.Value)
 				if err != nil {
 					pw.CloseWithError(fmt.Errorf("creating tar content %q: %w", name, err))
 					return
@@ -907,6 +922,8 @@ func processBuildResponse(response *bindings.APIResponse, stdout io.Writer, save
 			// If there's an error, return directly.  The stream
 			// will be closed on return.
 			return &types.BuildReport{ID: id, SaveFormat: saveFormat}, errors.New(s.Error.Message)
+		case s.Progress != nil:
+			// structured progress is not requested by the client
 		default:
 			return &types.BuildReport{ID: id, SaveFormat: saveFormat}, errors.New("failed to parse build results stream, unexpected input")
 		}
@@ -1069,7 +1086,127 @@ func build(ctx context.Context, containerFiles []string, options types.BuildOpti
 	return processBuildResponse(response, stdout, saveFormat)
 }
 
-func nTar(excludes []string, sources ...string) (io.ReadCloser, error) {
+// contextFile describes how a file of the main build context is sent to a
+// server which caches build contexts.
+type contextFile struct {
+	digest digest.Digest
+	// cached is set if the server has the content, it is not sent then.
+	cached bool
+}
+
+// contextDigests returns the digests of the regular files in the main build
+// context, the first source, keyed by their name in the context tar.  Excluded
+// files and the other sources, e.g. build secrets copied into the context,
+// are skipped.
+func contextDigests(excludes []string, sources []string) (map[string]digest.Digest, error) {
+	pm, err := fileutils.NewPatternMatcher(excludes)
+	if err != nil {
+		return nil, fmt.Errorf("processing excludes list %v: %w", excludes, err)
+	}
+	source, err := filepath.Abs(sources[0])
+	if err != nil {
+		return nil, err
+	}
+	skip := make(map[string]struct{}, len(sources)-1)
+	for _, src := range sources[1:] {
+		path, err := filepath.Abs(src)
+		if err != nil {
+			return nil, err
+		}
+		skip[path] = struct{}{}
+	}
+
+	digests := make(map[string]digest.Digest)
+	err = filepath.WalkDir(source, func(path string, dentry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == source || !dentry.Type().IsRegular() {
+			return nil
+		}
+		if _, ok := skip[path]; ok {
+			return nil
+		}
+		name := filepath.ToSlash(strings.TrimPrefix(path, source+string(filepath.Separator)))
+		excluded, err := pm.Matches(name) //nolint:staticcheck
+		if err != nil {
+			return fmt.Errorf("checking if %q is excluded: %w", name, err)
+		}
+		if excluded {
+			return nil
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		d, err := digest.Canonical.FromReader(f)
+		if err != nil {
+			return err
+		}
+		digests[name] = d
+		return nil
+	})
+	return digests, err
+}
+
+// cachedContextFiles asks the server which files of the main build context it
+// cached during previous builds.  Those files are sent without their content,
+// the others are added to the cache.  Nil is returned if the server does not
+// cache build contexts.
+func cachedContextFiles(ctx context.Context, params url.Values, excludes []string, sources []string) (map[string]contextFile, error) {
+	digests, err := contextDigests(excludes, sources)
+	if err != nil {
+		return nil, err
+	}
+	unique := make(map[digest.Digest]struct{}, len(digests))
+	for _, d := range digests {
+		unique[d] = struct{}{}
+	}
+	body, err := json.Marshal(slices.Collect(maps.Keys(unique)))
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, bytes.NewReader(body), http.MethodPost, "/build/context/missing", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotFound {
+		logrus.Debug("The server does not cache build contexts, sending the whole context")
+		return nil, nil
+	}
+	var missing []digest.Digest
+	if err := response.Process(&missing); err != nil {
+		return nil, err
+	}
+	for _, d := range missing {
+		delete(unique, d)
+	}
+
+	files := make(map[string]contextFile, len(digests))
+	numCached := 0
+	for name, d := range digests {
+		_, cached := unique[d]
+		if cached {
+			numCached++
+		}
+		files[name] = contextFile{digest: d, cached: cached}
+	}
+	logrus.Debugf("Sending %d of %d build context files, the others are cached by the server", len(files)-numCached, len(files))
+	params.Set("contextcache", "true")
+	return files, nil
+}
+
+// nTar creates a compressed tar stream of the sources.  Files of the main
+// context which are in files are marked for the build context cache of the
+// server, the content of cached ones is not included.
+func nTar(excludes []string, files map[string]contextFile, sources ...string) (io.ReadCloser, error) {
 	pm, err := fileutils.NewPatternMatcher(excludes)
 	if err != nil {
 		return nil, fmt.Errorf("processing excludes list %v: %w", excludes, err)
@@ -1166,6 +1303,21 @@ func nTar(excludes []string, sources ...string) (io.ReadCloser, error) {
 						hdr.Name = name
 						return tw.WriteHeader(hdr)
 					}
+					if file, ok := files[name]; ok && i == 0 {
+						if file.cached {
+							hdr.Name = name
+							hdr.Size = 0
+							hdr.PAXRecords = map[string]string{remote_build_helpers.ContextDigestRecord: file.digest.String()}
+							if err := tw.WriteHeader(hdr); err != nil {
+								return err
+							}
+							if isHardLink {
+								seen[di] = name
+							}
+							return nil
+						}
+						hdr.PAXRecords = map[string]string{remote_build_helpers.ContextCacheRecord: file.digest.String()}
+					}
 					f, err := os.Open(path)
 					if err != nil {
 						return err
@@ -1,10 +1,18 @@
 package images
 
 import (
+	"archive/tar"
+	"io"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/containers/buildah/define"
+	"github.com/dmikushin/podman-shared/internal/remote_build_helpers"
+	"github.com/opencontainers/go-digest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/storage/pkg/archive"
 )
 
 func TestBuildMatchIID(t *testing.T) {
@@ -58,3 +66,56 @@ func TestConvertAdditionalBuildContexts(t *testing.T) {
 		assert.Equal(t, expectedGuestValues[key], value.Value)
 	}
 }
+
+func TestNTarContextCache(t *testing.T) {
+	contextDir := t.TempDir()
+	for name, content := range map[string]string{
+		"Containerfile":          "FROM scratch",
+		"cached.txt":             "cached",
+		"changed.txt":            "changed",
+		"ignored.log":            "ignored",
+		"podman-build-secret-42": "secret",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(contextDir, name), []byte(content), 0o644))
+	}
+	sources := []string{contextDir, filepath.Join(contextDir, "podman-build-secret-42")}
+	excludes := []string{"*.log"}
+
+	digests, err := contextDigests(excludes, sources)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]digest.Digest{
+		"Containerfile": digest.FromString("FROM scratch"),
+		"cached.txt":    digest.FromString("cached"),
+		"changed.txt":   digest.FromString("changed"),
+	}, digests)
+
+	files := map[string]contextFile{
+		"cached.txt":  {digest: digests["cached.txt"], cached: true},
+		"changed.txt": {digest: digests["changed.txt"]},
+	}
+	tarfile, err := nTar(excludes, files, contextDir)
+	require.NoError(t, err)
+	defer tarfile.Close()
+	decompressed, err := archive.DecompressStream(tarfile)
+	require.NoError(t, err)
+	defer decompressed.Close()
+
+	records := make(map[string]map[string]string)
+	sizes := make(map[string]int64)
+	tr := tar.NewReader(decompressed)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		records[hdr.Name] = hdr.PAXRecords
+		sizes[hdr.Name] = hdr.Size
+	}
+	assert.NotContains(t, records, "ignored.log")
+	assert.Equal(t, digests["cached.txt"].String(), records["cached.txt"][remote_build_helpers.ContextDigestRecord])
+	assert.Zero(t, sizes["cached.txt"])
+	assert.Equal(t, digests["changed.txt"].String(), records["changed.txt"][remote_build_helpers.ContextCacheRecord])
+	assert.Equal(t, int64(len("changed")), sizes["changed.txt"])
+	assert.Empty(t, records["podman-build-secret-42"])
+}
@@ -104,6 +104,90 @@ func Tree(ctx context.Context, nameOrID string, options *TreeOptions) (*types.Im
 	return &report, response.Process(&report)
 }
 
+// SBOM generates the software bill of materials of an image on the server
+// and optionally attaches it to the image in a registry.
+func SBOM(ctx context.Context, nameOrID string, options *SBOMOptions) (*types.ImageSBOMReport, error) {
+	if options == nil {
+		options = new(SBOMOptions)
+	}
+	var report types.ImageSBOMReport
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	// SkipTLSVerify is special.  It's not being serialized by ToParams()
+	// because we need to flip the boolean.
+	if options.SkipTLSVerify != nil {
+		params.Set("tlsVerify", strconv.FormatBool(!options.GetSkipTLSVerify()))
+	}
+	var header http.Header
+	if options.GetAttach() != "" {
+		header, err = auth.MakeXRegistryAuthHeader(&imageTypes.SystemContext{AuthFilePath: options.GetAuthfile()}, options.GetUsername(), options.GetPassword())
+		if err != nil {
+			return nil, err
+		}
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/images/%s/sbom", params, header, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
+// Scan scans an image for vulnerabilities with a scanner configured on the
+// server.
+func Scan(ctx context.Context, nameOrID string, options *ScanOptions) (*types.ImageScanReport, error) {
+	if options == nil {
+		options = new(ScanOptions)
+	}
+	var report types.ImageScanReport
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/images/%s/scan", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
+// Optimize writes an optimized copy of an image named target on the server.
+func Optimize(ctx context.Context, nameOrID, target string, options *OptimizeOptions) (*types.ImageOptimizeReport, error) {
+	if options == nil {
+		options = new(OptimizeOptions)
+	}
+	var report types.ImageOptimizeReport
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	params.Set("target", target)
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/images/%s/optimize", params, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
+
 // History returns the parent layers of an image.
 func History(ctx context.Context, nameOrID string, options *HistoryOptions) ([]*handlersTypes.HistoryResponse, error) {
 	if options == nil {
@@ -35,6 +35,12 @@ func Pull(ctx context.Context, rawImage string, options *PullOptions) ([]string,
 	}
 	params.Set("reference", rawImage)
 
+	progress := options.GetProgress()
+	if progress != nil {
+		defer close(progress)
+		params.Set("progress", "true")
+	}
+
 	// SkipTLSVerify is special.  It's not being serialized by ToParams()
 	// because we need to flip the boolean.
 	if options.SkipTLSVerify != nil {
@@ -94,6 +100,10 @@ LOOP:
 		}
 
 		switch {
+		case report.Progress != nil:
+			if progress != nil {
+				progress <- *report.Progress
+			}
 		case report.Stream != "":
 			fmt.Fprint(writer, report.Stream)
 		case report.Error != "":
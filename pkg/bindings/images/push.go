@@ -45,6 +45,12 @@ func Push(ctx context.Context, source string, destination string, options *PushO
 	}
 	params.Set("destination", destination)
 
+	progress := options.GetProgress()
+	if progress != nil {
+		defer close(progress)
+		params.Set("progress", "true")
+	}
+
 	path := fmt.Sprintf("/images/%s/push", source)
 	response, err := conn.DoRequest(ctx, nil, http.MethodPost, path, params, header)
 	if err != nil {
@@ -85,6 +91,10 @@ LOOP:
 		}
 
 		switch {
+		case report.Progress != nil:
+			if progress != nil {
+				progress <- *report.Progress
+			}
 		case report.Stream != "":
 			fmt.Fprint(writer, report.Stream)
 		case report.ManifestDigest != "":
@@ -38,8 +38,15 @@ type DiffOptions struct {
 type ListOptions struct {
 	// All lists all image in the image store including dangling images
 	All *bool
+	// Fields selects the fields of the images returned, all fields by
+	// default
+	Fields []string
 	// filters that can be used to get a more specific list of images
 	Filters map[string][]string
+	// Limit returns this number of most recently created images
+	Limit *int
+	// Offset skips this number of most recently created images
+	Offset *int
 }
 
 // GetOptions are optional options for inspecting an image
@@ -57,6 +64,51 @@ type GetOptions struct {
 type TreeOptions struct {
 	// WhatRequires ...
 	WhatRequires *bool
+	// DiskUsage shows the disk usage and sharing of the layers of the image
+	DiskUsage *bool `schema:"du"`
+}
+
+// SBOMOptions are optional options for generating the SBOM of an image
+//
+//go:generate go run ../generator/generator.go SBOMOptions
+type SBOMOptions struct {
+	// Format of the SBOM, "spdx" or "cyclonedx"
+	Format *string
+	// Attach the SBOM as a referrer artifact to this image in a registry
+	Attach *string
+	// Authfile is the path to the authentication file. Ignored for remote
+	// calls.
+	Authfile *string `schema:"-"`
+	// Username for authenticating against the registry.
+	Username *string `schema:"-"`
+	// Password for authenticating against the registry.
+	Password *string `schema:"-"`
+	// SkipTLSVerify to skip HTTPS and certificate verification.
+	SkipTLSVerify *bool `schema:"-"`
+}
+
+// ScanOptions are optional options for scanning an image for vulnerabilities
+//
+//go:generate go run ../generator/generator.go ScanOptions
+type ScanOptions struct {
+	// Scanner to use instead of the default scanner of the server
+	Scanner *string
+	// Threshold severity the vulnerabilities are evaluated against
+	Threshold *string
+	// RunPolicy evaluates the vulnerabilities against the run threshold
+	// of the server
+	RunPolicy *bool
+}
+
+// OptimizeOptions are optional options for optimizing an image
+//
+//go:generate go run ../generator/generator.go OptimizeOptions
+type OptimizeOptions struct {
+	// MergeThreshold is the size in bytes under which consecutive layers
+	// are merged
+	MergeThreshold *int64
+	// CompressionLevel of the zstd compression
+	CompressionLevel *int
 }
 
 // HistoryOptions are optional options image history
@@ -156,6 +208,9 @@ type PushOptions struct {
 	Format *string
 	// Password for authenticating against the registry.
 	Password *string `schema:"-"`
+	// Progress receives structured progress frames of the push.  It is
+	// closed when images.Push returns and must be drained by the caller.
+	Progress *chan types.ProgressFrame `schema:"-"`
 	// ProgressWriter is a writer where push progress are sent.
 	// Since API handler for image push is quiet by default, WithQuiet(false) is necessary for
 	// the writer to receive progress messages.
@@ -210,14 +265,21 @@ type PullOptions struct {
 	// Authfile is the path to the authentication file. Ignored for remote
 	// calls.
 	Authfile *string
+	// MaxAge is the maximum age of the images checked in the registry
+	// with the "newer-with-max-age" policy, like "24h".
+	MaxAge *string
 	// OS will overwrite the local operating system (OS) for image
 	// pulls.
 	OS *string
 	// Policy is the pull policy. Supported values are "missing", "never",
-	// "newer", "always". An empty string defaults to "always".
+	// "newer", "newer-with-max-age", "always". An empty string defaults to
+	// "always".
 	Policy *string
 	// Password for authenticating against the registry.
 	Password *string `schema:"-"`
+	// Progress receives structured progress frames of the pull.  It is
+	// closed when images.Pull returns and must be drained by the caller.
+	Progress *chan types.ProgressFrame `schema:"-"`
 	// ProgressWriter is a writer where pull progress are sent.
 	ProgressWriter *io.Writer `schema:"-"`
 	// Quiet can be specified to suppress pull progress when pulling.  Ignored
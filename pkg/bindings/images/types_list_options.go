@@ -32,6 +32,21 @@ func (o *ListOptions) GetAll() bool {
 	return *o.All
 }
 
+// WithFields set field Fields to given value
+func (o *ListOptions) WithFields(value []string) *ListOptions {
+	o.Fields = value
+	return o
+}
+
+// GetFields returns value of field Fields
+func (o *ListOptions) GetFields() []string {
+	if o.Fields == nil {
+		var z []string
+		return z
+	}
+	return o.Fields
+}
+
 // WithFilters set field Filters to given value
 func (o *ListOptions) WithFilters(value map[string][]string) *ListOptions {
 	o.Filters = value
@@ -46,3 +61,33 @@ func (o *ListOptions) GetFilters() map[string][]string {
 	}
 	return o.Filters
 }
+
+// WithLimit set field Limit to given value
+func (o *ListOptions) WithLimit(value int) *ListOptions {
+	o.Limit = &value
+	return o
+}
+
+// GetLimit returns value of field Limit
+func (o *ListOptions) GetLimit() int {
+	if o.Limit == nil {
+		var z int
+		return z
+	}
+	return *o.Limit
+}
+
+// WithOffset set field Offset to given value
+func (o *ListOptions) WithOffset(value int) *ListOptions {
+	o.Offset = &value
+	return o
+}
+
+// GetOffset returns value of field Offset
+func (o *ListOptions) GetOffset() int {
+	if o.Offset == nil {
+		var z int
+		return z
+	}
+	return *o.Offset
+}
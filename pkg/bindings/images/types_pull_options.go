@@ -6,6 +6,7 @@ import (
 	"net/url"
 
 	"github.com/dmikushin/podman-shared/pkg/bindings/internal/util"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
 )
 
 // Changed returns true if named field has been set
@@ -63,6 +64,21 @@ func (o *PullOptions) GetAuthfile() string {
 	return *o.Authfile
 }
 
+// WithMaxAge set field MaxAge to given value
+func (o *PullOptions) WithMaxAge(value string) *PullOptions {
+	o.MaxAge = &value
+	return o
+}
+
+// GetMaxAge returns value of field MaxAge
+func (o *PullOptions) GetMaxAge() string {
+	if o.MaxAge == nil {
+		var z string
+		return z
+	}
+	return *o.MaxAge
+}
+
 // WithOS set field OS to given value
 func (o *PullOptions) WithOS(value string) *PullOptions {
 	o.OS = &value
@@ -108,6 +124,21 @@ func (o *PullOptions) GetPassword() string {
 	return *o.Password
 }
 
+// WithProgress set field Progress to given value
+func (o *PullOptions) WithProgress(value chan types.ProgressFrame) *PullOptions {
+	o.Progress = &value
+	return o
+}
+
+// GetProgress returns value of field Progress
+func (o *PullOptions) GetProgress() chan types.ProgressFrame {
+	if o.Progress == nil {
+		var z chan types.ProgressFrame
+		return z
+	}
+	return *o.Progress
+}
+
 // WithProgressWriter set field ProgressWriter to given value
 func (o *PullOptions) WithProgressWriter(value io.Writer) *PullOptions {
 	o.ProgressWriter = &value
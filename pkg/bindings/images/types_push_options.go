@@ -6,6 +6,7 @@ import (
 	"net/url"
 
 	"github.com/dmikushin/podman-shared/pkg/bindings/internal/util"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
 )
 
 // Changed returns true if named field has been set
@@ -153,6 +154,21 @@ func (o *PushOptions) GetPassword() string {
 	return *o.Password
 }
 
+// WithProgress set field Progress to given value
+func (o *PushOptions) WithProgress(value chan types.ProgressFrame) *PushOptions {
+	o.Progress = &value
+	return o
+}
+
+// GetProgress returns value of field Progress
+func (o *PushOptions) GetProgress() chan types.ProgressFrame {
+	if o.Progress == nil {
+		var z chan types.ProgressFrame
+		return z
+	}
+	return *o.Progress
+}
+
 // WithProgressWriter set field ProgressWriter to given value
 func (o *PushOptions) WithProgressWriter(value io.Writer) *PushOptions {
 	o.ProgressWriter = &value
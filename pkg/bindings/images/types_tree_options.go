@@ -31,3 +31,18 @@ func (o *TreeOptions) GetWhatRequires() bool {
 	}
 	return *o.WhatRequires
 }
+
+// WithDiskUsage set field DiskUsage to given value
+func (o *TreeOptions) WithDiskUsage(value bool) *TreeOptions {
+	o.DiskUsage = &value
+	return o
+}
+
+// GetDiskUsage returns value of field DiskUsage
+func (o *TreeOptions) GetDiskUsage() bool {
+	if o.DiskUsage == nil {
+		var z bool
+		return z
+	}
+	return *o.DiskUsage
+}
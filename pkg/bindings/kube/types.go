@@ -60,6 +60,9 @@ type PlayOptions struct {
 	// PublishAllPorts - whether to publish all ports defined in the K8S YAML file
 	// (containerPort, hostPort) otherwise only hostPort will be published
 	PublishAllPorts *bool
+	// NetworkPolicy - enforce the NetworkPolicy objects of the K8S YAML file
+	// with firewall rules in the network namespaces of the selected pods
+	NetworkPolicy *bool
 	// Wait - indicates whether to return after having created the pods
 	Wait             *bool
 	ServiceContainer *bool
@@ -87,4 +90,13 @@ type ApplyOptions struct {
 type DownOptions struct {
 	// Force - remove volumes on --down
 	Force *bool
+	// Volumes - remove the volumes of PersistentVolumeClaims
+	Volumes *bool
+	// ConfigMaps - remove the volumes created for ConfigMap and Secret volumes
+	ConfigMaps *bool
+	// Secrets - remove the secrets created from Secrets, true by default
+	Secrets *bool
+	// Timeout - seconds to wait for each pod to stop before killing its
+	// containers
+	Timeout *uint
 }
@@ -31,3 +31,63 @@ func (o *DownOptions) GetForce() bool {
 	}
 	return *o.Force
 }
+
+// WithVolumes set field Volumes to given value
+func (o *DownOptions) WithVolumes(value bool) *DownOptions {
+	o.Volumes = &value
+	return o
+}
+
+// GetVolumes returns value of field Volumes
+func (o *DownOptions) GetVolumes() bool {
+	if o.Volumes == nil {
+		var z bool
+		return z
+	}
+	return *o.Volumes
+}
+
+// WithConfigMaps set field ConfigMaps to given value
+func (o *DownOptions) WithConfigMaps(value bool) *DownOptions {
+	o.ConfigMaps = &value
+	return o
+}
+
+// GetConfigMaps returns value of field ConfigMaps
+func (o *DownOptions) GetConfigMaps() bool {
+	if o.ConfigMaps == nil {
+		var z bool
+		return z
+	}
+	return *o.ConfigMaps
+}
+
+// WithSecrets set field Secrets to given value
+func (o *DownOptions) WithSecrets(value bool) *DownOptions {
+	o.Secrets = &value
+	return o
+}
+
+// GetSecrets returns value of field Secrets
+func (o *DownOptions) GetSecrets() bool {
+	if o.Secrets == nil {
+		var z bool
+		return z
+	}
+	return *o.Secrets
+}
+
+// WithTimeout set field Timeout to given value
+func (o *DownOptions) WithTimeout(value uint) *DownOptions {
+	o.Timeout = &value
+	return o
+}
+
+// GetTimeout returns value of field Timeout
+func (o *DownOptions) GetTimeout() uint {
+	if o.Timeout == nil {
+		var z uint
+		return z
+	}
+	return *o.Timeout
+}
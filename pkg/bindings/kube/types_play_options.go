@@ -378,6 +378,21 @@ func (o *PlayOptions) GetPublishAllPorts() bool {
 	return *o.PublishAllPorts
 }
 
+// WithNetworkPolicy set field NetworkPolicy to given value
+func (o *PlayOptions) WithNetworkPolicy(value bool) *PlayOptions {
+	o.NetworkPolicy = &value
+	return o
+}
+
+// GetNetworkPolicy returns value of field NetworkPolicy
+func (o *PlayOptions) GetNetworkPolicy() bool {
+	if o.NetworkPolicy == nil {
+		var z bool
+		return z
+	}
+	return *o.NetworkPolicy
+}
+
 // WithWait set field Wait to given value
 func (o *PlayOptions) WithWait(value bool) *PlayOptions {
 	o.Wait = &value
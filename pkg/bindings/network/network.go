@@ -2,10 +2,12 @@ package network
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"strings"
 
+	"github.com/dmikushin/podman-shared/pkg/api/handlers"
 	"github.com/dmikushin/podman-shared/pkg/bindings"
 	entitiesTypes "github.com/dmikushin/podman-shared/pkg/domain/entities/types"
 	jsoniter "github.com/json-iterator/go"
@@ -236,3 +238,76 @@ func Prune(ctx context.Context, options *PruneOptions) ([]*entitiesTypes.Network
 
 	return prunedNetworks, response.Process(&prunedNetworks)
 }
+
+// Reload recreates the firewall rules of container networks.
+func Reload(ctx context.Context, options *ReloadOptions) ([]*entitiesTypes.NetworkReloadReport, error) {
+	if options == nil {
+		options = new(ReloadOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodPost, "/networks/reload", params, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var reloadReports []handlers.LibpodNetworkReloadReport
+	if err := response.Process(&reloadReports); err != nil {
+		return nil, err
+	}
+	reports := make([]*entitiesTypes.NetworkReloadReport, 0, len(reloadReports))
+	for _, r := range reloadReports {
+		report := &entitiesTypes.NetworkReloadReport{Id: r.ID}
+		if r.ReloadError != "" {
+			report.Err = errors.New(r.ReloadError)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// WireGuardPeer returns the host of the service as a peer of a WireGuard
+// network
+func WireGuardPeer(ctx context.Context, nameOrID string) (*entitiesTypes.NetworkWireGuardPeer, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/networks/%s/wireguard/peer", nil, nil, nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var peer entitiesTypes.NetworkWireGuardPeer
+	if err := response.Process(&peer); err != nil {
+		return nil, err
+	}
+	return &peer, nil
+}
+
+// SetWireGuardPeers replaces the peers of the host of the service in a
+// WireGuard network
+func SetWireGuardPeers(ctx context.Context, nameOrID string, peers []entitiesTypes.NetworkWireGuardPeer) error {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	body, err := jsoniter.MarshalToString(peers)
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, strings.NewReader(body), http.MethodPost, "/networks/%s/wireguard/peers", nil, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	return response.Process(nil)
+}
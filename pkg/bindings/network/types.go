@@ -93,6 +93,20 @@ type PruneOptions struct {
 	Filters map[string][]string
 }
 
+// ReloadOptions are optional options for reloading
+// container networks
+//
+//go:generate go run ../generator/generator.go ReloadOptions
+type ReloadOptions struct {
+	// All reloads the networks of all containers
+	All *bool
+	// Graceful replaces the firewall rules in place
+	// without tearing down the container networks
+	Graceful *bool
+	// Containers to reload
+	Containers []string
+}
+
 // ExtraCreateOptions are optional additional configuration flags for creating Networks
 // that are not part of the network configuration
 //
@@ -0,0 +1,51 @@
+package pods
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/dmikushin/podman-shared/libpod/logs"
+	"github.com/dmikushin/podman-shared/pkg/bindings"
+)
+
+// Logs obtains the logs of the containers of a pod given the options
+// provided.  The log lines of the containers are interleaved and sent to the
+// channel, when following they include the logs of the containers started in
+// the pod afterwards.
+func Logs(ctx context.Context, nameOrID string, options *LogsOptions, lineChan chan *logs.LogLine) error {
+	if options == nil {
+		options = new(LogsOptions)
+	}
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return err
+	}
+	params, err := options.ToParams()
+	if err != nil {
+		return err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/pods/%s/logs", params, nil, nameOrID)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if !response.IsSuccess() {
+		return response.Process(nil)
+	}
+
+	dec := json.NewDecoder(response.Body)
+	for {
+		var line logs.LogLine
+		if err := dec.Decode(&line); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		lineChan <- &line
+	}
+}
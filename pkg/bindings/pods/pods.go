@@ -340,3 +340,23 @@ func Stats(ctx context.Context, namesOrIDs []string, options *StatsOptions) ([]*
 
 	return reports, response.Process(&reports)
 }
+
+// Update changes the ports published by a pod.
+func Update(ctx context.Context, options *entitiesTypes.PodUpdateOptions) (*entitiesTypes.PodUpdateReport, error) {
+	var report entitiesTypes.PodUpdateReport
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	body, err := jsoniter.MarshalToString(options)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, strings.NewReader(body), http.MethodPost, "/pods/%s/update", nil, nil, options.NameOrID)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	return &report, response.Process(&report)
+}
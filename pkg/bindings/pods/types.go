@@ -35,7 +35,14 @@ type PruneOptions struct {
 //
 //go:generate go run ../generator/generator.go ListOptions
 type ListOptions struct {
+	// Fields selects the fields of the pods returned, all fields by
+	// default
+	Fields  []string
 	Filters map[string][]string
+	// Limit returns this number of most recently created pods
+	Limit *int
+	// Offset skips this number of most recently created pods
+	Offset *int
 }
 
 // RestartOptions are optional options for restarting pods
@@ -90,3 +97,14 @@ type RemoveOptions struct {
 //go:generate go run ../generator/generator.go ExistsOptions
 type ExistsOptions struct {
 }
+
+// LogsOptions are optional options for getting the logs of a pod
+//
+//go:generate go run ../generator/generator.go LogsOptions
+type LogsOptions struct {
+	Container *string
+	Follow    *bool
+	Since     *string
+	Tail      *string
+	Until     *string
+}
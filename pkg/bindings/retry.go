@@ -0,0 +1,234 @@
+package bindings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	retryPolicyKey    = valueKey("RetryPolicy")
+	requestTimeoutKey = valueKey("RequestTimeout")
+
+	// DefaultMaxAttempts is the default number of attempts of a request.
+	DefaultMaxAttempts = 3
+	// DefaultBackoff is the default delay before the first retry of a
+	// request.
+	DefaultBackoff = 100 * time.Millisecond
+)
+
+// idempotencyKeyHeader is the header making create requests of the service
+// idempotent, requests with it are retried like idempotent ones.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// RetryPolicy controls how requests to the service are retried.  Requests are
+// retried when the connection to the service cannot be established.  Requests
+// with idempotent methods and requests with an Idempotency-Key header are
+// also retried when the connection fails after the request was sent, or when
+// the service responds with one of the RetryStatusCodes.  The zero value
+// retries with the defaults.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of a request including the
+	// first one, DefaultMaxAttempts if 0.  1 disables retries.
+	MaxAttempts int
+	// Backoff is the delay before the first retry, DefaultBackoff if 0.
+	// The delay is doubled for each further retry.
+	Backoff time.Duration
+	// MaxBackoff limits the delay between retries, no limit if 0.
+	MaxBackoff time.Duration
+	// RetryStatusCodes are the status codes of responses to retry, e.g.
+	// http.StatusServiceUnavailable.  No responses are retried by default.
+	RetryStatusCodes []int
+	// IdempotentMethods are the methods of requests which are safe to
+	// send again, GET, HEAD, OPTIONS, PUT and DELETE if empty.
+	IdempotentMethods []string
+}
+
+var defaultIdempotentMethods = []string{http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete}
+
+// WithRetryPolicy returns a copy of the connection context ctx retrying
+// requests with the policy instead of the policy of the connection.
+func WithRetryPolicy(ctx context.Context, policy RetryPolicy) context.Context {
+	return context.WithValue(ctx, retryPolicyKey, &policy)
+}
+
+// WithRequestTimeout returns a copy of the connection context ctx waiting
+// at most timeout for the responses of the service instead of the request
+// timeout of the connection.  0 disables the timeout.
+func WithRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutKey, timeout)
+}
+
+// retryPolicy returns the retry policy of requests with the context ctx.
+func (c *Connection) retryPolicy(ctx context.Context) *RetryPolicy {
+	if policy, ok := ctx.Value(retryPolicyKey).(*RetryPolicy); ok {
+		return policy
+	}
+	if c.retry != nil {
+		return c.retry
+	}
+	return &RetryPolicy{}
+}
+
+// requestTimeout returns the request timeout of requests with the context
+// ctx.
+func (c *Connection) requestTimeout(ctx context.Context) time.Duration {
+	if timeout, ok := ctx.Value(requestTimeoutKey).(time.Duration); ok {
+		return timeout
+	}
+	return c.timeout
+}
+
+func (p *RetryPolicy) maxAttempts() int {
+	if p.MaxAttempts <= 0 {
+		return DefaultMaxAttempts
+	}
+	return p.MaxAttempts
+}
+
+// delay returns the delay before the retry following the attempt.
+func (p *RetryPolicy) delay(attempt int) time.Duration {
+	delay := p.Backoff
+	if delay <= 0 {
+		delay = DefaultBackoff
+	}
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+// idempotent returns true if the request can be sent again.
+func (p *RetryPolicy) idempotent(req *http.Request) bool {
+	if req.Header.Get(idempotencyKeyHeader) != "" {
+		return true
+	}
+	methods := p.IdempotentMethods
+	if len(methods) == 0 {
+		methods = defaultIdempotentMethods
+	}
+	return slices.Contains(methods, req.Method)
+}
+
+// shouldRetry returns true if the request with the response or the error of
+// its last attempt must be retried.  Requests whose body cannot be sent again
+// are only retried if they were not sent.
+func (p *RetryPolicy) shouldRetry(req *http.Request, response *http.Response, err error) bool {
+	if err != nil {
+		if req.Context().Err() != nil {
+			return false
+		}
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return p.idempotent(req) && (req.Body == nil || req.GetBody != nil)
+	}
+	return slices.Contains(p.RetryStatusCodes, response.StatusCode) &&
+		p.idempotent(req) && (req.Body == nil || req.GetBody != nil)
+}
+
+// do sends the request with the retry policy and request timeout of its
+// context.
+func (c *Connection) do(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	policy := c.retryPolicy(ctx)
+	timeout := c.requestTimeout(ctx)
+	for attempt := 1; ; attempt++ {
+		response, err := c.doAttempt(req, timeout)
+		if attempt >= policy.maxAttempts() || !policy.shouldRetry(req, response, err) {
+			return response, err
+		}
+
+		reason := ""
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = response.Status
+			_, _ = io.Copy(io.Discard, response.Body)
+			response.Body.Close()
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+		delay := policy.delay(attempt)
+		logrus.Debugf("Retrying %s %s in %s after attempt %d: %s", req.Method, req.URL, delay, attempt, reason)
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// doAttempt sends the request once.  If the timeout is not 0, the request
+// fails if the service does not respond in time.  The response body, which
+// may be streamed for a long time, is not limited by the timeout.
+func (c *Connection) doAttempt(req *http.Request, timeout time.Duration) (*http.Response, error) {
+	if timeout <= 0 {
+		return c.Client.Do(req) //nolint:bodyclose // The caller has to close the body.
+	}
+	ctx, cancel := context.WithCancelCause(req.Context())
+	timer := time.AfterFunc(timeout, func() {
+		cancel(fmt.Errorf("no response from the Podman service within %s: %w", timeout, context.DeadlineExceeded))
+	})
+	response, err := c.Client.Do(req.WithContext(ctx)) //nolint:bodyclose // The caller has to close the body.
+	timer.Stop()
+	if err != nil {
+		if cause := context.Cause(ctx); cause != nil && req.Context().Err() == nil {
+			err = cause
+		}
+		cancel(nil)
+		return nil, err
+	}
+	// The context must stay alive while the caller reads the body.
+	if rwc, ok := response.Body.(io.ReadWriteCloser); ok {
+		response.Body = &cancelReadWriteCloser{ReadWriteCloser: rwc, cancel: cancel}
+	} else {
+		response.Body = &cancelReadCloser{ReadCloser: response.Body, cancel: cancel}
+	}
+	return response, nil
+}
+
+// cancelReadCloser cancels the context of a request once its response body
+// is closed.
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelCauseFunc
+}
+
+func (r *cancelReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.cancel(nil)
+	return err
+}
+
+// cancelReadWriteCloser is a cancelReadCloser for the bodies of upgraded
+// connections.
+type cancelReadWriteCloser struct {
+	io.ReadWriteCloser
+	cancel context.CancelCauseFunc
+}
+
+func (r *cancelReadWriteCloser) Close() error {
+	err := r.ReadWriteCloser.Close()
+	r.cancel(nil)
+	return err
+}
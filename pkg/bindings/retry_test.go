@@ -0,0 +1,115 @@
+package bindings
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestConnection returns a connection to a service answering requests
+// with handler.
+func newTestConnection(t *testing.T, handler http.HandlerFunc) *Connection {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	uri, err := url.Parse(strings.Replace(server.URL, "http://", "tcp://", 1))
+	require.NoError(t, err)
+	return &Connection{URI: uri, Client: server.Client(), retry: &RetryPolicy{Backoff: time.Millisecond}}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := RetryPolicy{}
+	assert.Equal(t, DefaultBackoff, policy.delay(1))
+	assert.Equal(t, 2*DefaultBackoff, policy.delay(2))
+
+	policy = RetryPolicy{Backoff: time.Second, MaxBackoff: 3 * time.Second}
+	assert.Equal(t, time.Second, policy.delay(1))
+	assert.Equal(t, 2*time.Second, policy.delay(2))
+	assert.Equal(t, 3*time.Second, policy.delay(3))
+	assert.Equal(t, 3*time.Second, policy.delay(100))
+}
+
+func TestDoRequestRetry(t *testing.T) {
+	var attempts atomic.Int32
+	conn := newTestConnection(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write(body)
+	})
+	conn.retry.RetryStatusCodes = []int{http.StatusServiceUnavailable}
+
+	// idempotent requests are sent again with their body
+	response, err := conn.DoRequest(context.Background(), strings.NewReader("data"), http.MethodPut, "/test", nil, nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(body))
+	assert.Equal(t, int32(3), attempts.Load())
+
+	// other requests only with an idempotency key
+	attempts.Store(0)
+	response, err = conn.DoRequest(context.Background(), nil, http.MethodPost, "/test", nil, nil)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	assert.Equal(t, int32(1), attempts.Load())
+
+	attempts.Store(0)
+	response, err = conn.DoRequest(context.Background(), nil, http.MethodPost, "/test", nil, http.Header{"Idempotency-Key": {"key"}})
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, int32(3), attempts.Load())
+
+	// the policy of the context replaces the one of the connection
+	attempts.Store(0)
+	ctx := WithRetryPolicy(context.Background(), RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond, RetryStatusCodes: []int{http.StatusServiceUnavailable}})
+	response, err = conn.DoRequest(ctx, nil, http.MethodGet, "/test", nil, nil)
+	require.NoError(t, err)
+	response.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode)
+	assert.Equal(t, int32(2), attempts.Load())
+}
+
+func TestDoRequestTimeout(t *testing.T) {
+	conn := newTestConnection(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("slow") != "" {
+			time.Sleep(time.Second)
+		}
+		w.(http.Flusher).Flush()
+		// the body is not limited by the timeout
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte("done"))
+	})
+	conn.timeout = 50 * time.Millisecond
+	conn.retry.MaxAttempts = 1
+
+	_, err := conn.DoRequest(context.Background(), nil, http.MethodGet, "/test", url.Values{"slow": {"1"}}, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "no response from the Podman service within 50ms")
+
+	response, err := conn.DoRequest(context.Background(), nil, http.MethodGet, "/test", nil, nil)
+	require.NoError(t, err)
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "done", string(body))
+
+	ctx := WithRequestTimeout(context.Background(), 0)
+	response, err = conn.DoRequest(ctx, nil, http.MethodGet, "/test", url.Values{"slow": {"1"}}, nil)
+	require.NoError(t, err)
+	response.Body.Close()
+}
@@ -0,0 +1,25 @@
+package system
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dmikushin/podman-shared/pkg/bindings"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+)
+
+// Devices lists the CDI and GPU devices available on the host of the service
+func Devices(ctx context.Context, _ *DevicesOptions) ([]*types.DeviceListReport, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/system/devices", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var devices []*types.DeviceListReport
+	return devices, response.Process(&devices)
+}
@@ -0,0 +1,35 @@
+package system
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/dmikushin/podman-shared/pkg/bindings"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+)
+
+// Hooks lists the OCI hooks of the hooks directories of the service
+func Hooks(ctx context.Context, _ *HooksOptions) ([]*types.HookListReport, error) {
+	return hooks(ctx, http.MethodGet, "/hooks/json")
+}
+
+// ReloadHooks reloads the hooks directories of the service from
+// containers.conf and lists the OCI hooks found in them
+func ReloadHooks(ctx context.Context, _ *ReloadHooksOptions) ([]*types.HookListReport, error) {
+	return hooks(ctx, http.MethodPost, "/hooks/reload")
+}
+
+func hooks(ctx context.Context, method, endpoint string) ([]*types.HookListReport, error) {
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	response, err := conn.DoRequest(ctx, nil, method, endpoint, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	var reports []*types.HookListReport
+	return reports, response.Process(&reports)
+}
@@ -3,13 +3,18 @@ package system
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/bindings"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+	"github.com/dmikushin/podman-shared/pkg/errorhandling"
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/sirupsen/logrus"
 )
 
@@ -59,6 +64,166 @@ func Events(ctx context.Context, eventChan chan types.Event, cancelChan chan boo
 	return nil
 }
 
+// StreamEvents streams the events of the service like Events, but
+// transparently reconnects when the connection to the service is lost, e.g.
+// because the service was restarted.  On reconnection, the events are
+// requested again since the time of the last received event, so no events
+// are missed and none is received twice.  Both channels are closed once ctx
+// is done, the Until time of the options passed, or the service rejected the
+// request.  In the latter case, the error is sent on the error channel
+// before.
+func StreamEvents(ctx context.Context, options *EventsOptions) (<-chan types.Event, <-chan error) {
+	eventChan := make(chan types.Event)
+	errChan := make(chan error, 1)
+	if options == nil {
+		options = new(EventsOptions)
+	}
+	opts := *options
+	opts.Stream = &[]bool{true}[0]
+
+	conn, err := bindings.GetClient(ctx)
+	if err != nil {
+		errChan <- err
+		close(errChan)
+		close(eventChan)
+		return eventChan, errChan
+	}
+
+	go func() {
+		defer close(errChan)
+		defer close(eventChan)
+		s := eventStream{events: eventChan}
+		for attempt := 1; ; attempt++ {
+			received, err := s.read(ctx, conn, &opts)
+			if ctx.Err() != nil {
+				return
+			}
+			var errModel *errorhandling.ErrorModel
+			if errors.As(err, &errModel) && errModel.Code() < http.StatusInternalServerError {
+				errChan <- err
+				return
+			}
+			if opts.Until != nil && *opts.Until != "" {
+				until, err := util.ParseInputTime(*opts.Until, false)
+				if err == nil && time.Now().After(until) {
+					return
+				}
+			}
+			if received {
+				attempt = 1
+			}
+			delay := streamEventsDelay(attempt)
+			if err != nil {
+				logrus.Debugf("Reconnecting to the event stream in %s: %v", delay, err)
+			} else {
+				logrus.Debugf("Reconnecting to the event stream in %s", delay)
+			}
+			select {
+			case <-time.After(delay):
+			case <-ctx.Done():
+				return
+			}
+			s.resume(&opts)
+		}
+	}()
+	return eventChan, errChan
+}
+
+// streamEventsMaxDelay limits the delay between reconnections of
+// StreamEvents.
+const streamEventsMaxDelay = 5 * time.Second
+
+// streamEventsDelay returns the delay before the reconnection following the
+// attempt.
+func streamEventsDelay(attempt int) time.Duration {
+	delay := bindings.DefaultBackoff
+	for i := 1; i < attempt && delay < streamEventsMaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, streamEventsMaxDelay)
+}
+
+// eventStream keeps track of the events received by StreamEvents.
+type eventStream struct {
+	events chan<- types.Event
+	// last is the time of the last received event in nanoseconds.
+	last int64
+	// seen holds the received events with the time last, they are sent
+	// again by the service when resuming since last.
+	seen map[string]struct{}
+}
+
+// read reads the events of the service until the connection is closed.  It
+// returns true if any event was received.
+func (s *eventStream) read(ctx context.Context, conn *bindings.Connection, options *EventsOptions) (bool, error) {
+	params, err := options.ToParams()
+	if err != nil {
+		return false, err
+	}
+	response, err := conn.DoRequest(ctx, nil, http.MethodGet, "/events", params, nil)
+	if err != nil {
+		return false, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return false, response.Process(nil)
+	}
+
+	received := false
+	dec := json.NewDecoder(response.Body)
+	for {
+		var e types.Event
+		if err := dec.Decode(&e); err != nil {
+			if errors.Is(err, io.EOF) {
+				return received, nil
+			}
+			return received, err
+		}
+		received = true
+		if !s.add(&e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		case <-ctx.Done():
+			return received, ctx.Err()
+		}
+	}
+}
+
+// add records the event and returns false if it was received before.
+func (s *eventStream) add(e *types.Event) bool {
+	key, err := json.Marshal(e)
+	if err != nil {
+		return true
+	}
+	switch {
+	case e.TimeNano < s.last:
+		return false
+	case e.TimeNano > s.last:
+		s.last = e.TimeNano
+		s.seen = make(map[string]struct{})
+	case s.seen == nil:
+		s.seen = make(map[string]struct{})
+	}
+	if _, ok := s.seen[string(key)]; ok {
+		return false
+	}
+	s.seen[string(key)] = struct{}{}
+	return true
+}
+
+// resume updates the options to request the events since the last received
+// one.  The service only sends events after the since time, so events with
+// the time of the last one are requested again and skipped by add.
+func (s *eventStream) resume(options *EventsOptions) {
+	if s.last == 0 {
+		return
+	}
+	since := time.Unix(0, s.last-1).UTC().Format(time.RFC3339Nano)
+	options.Since = &since
+}
+
 // Prune removes all unused system data.
 func Prune(ctx context.Context, options *PruneOptions) (*types.SystemPruneReport, error) {
 	var (
@@ -145,6 +310,9 @@ func Version(ctx context.Context, options *VersionOptions) (*types.SystemVersion
 	for _, c := range component.Components {
 		if c.Name == "Podman Engine" {
 			report.Server.APIVersion = c.Details["APIVersion"]
+			if features := c.Details["Features"]; features != "" {
+				report.Server.Features = strings.Split(features, ",")
+			}
 		}
 	}
 	return &report, err
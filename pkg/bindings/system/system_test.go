@@ -0,0 +1,104 @@
+package system
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dmikushin/podman-shared/pkg/bindings"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+	dockerEvents "github.com/docker/docker/api/types/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEvent(id string, timeNano int64) types.Event {
+	return types.Event{Message: dockerEvents.Message{
+		Type:     dockerEvents.ContainerEventType,
+		Action:   "start",
+		Actor:    dockerEvents.Actor{ID: id},
+		Time:     timeNano / int64(time.Second),
+		TimeNano: timeNano,
+	}}
+}
+
+// newTestContext returns a connection context to a service answering event
+// requests with handler.
+func newTestContext(t *testing.T, handler http.HandlerFunc) context.Context {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/_ping") {
+			return
+		}
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+	ctx, err := bindings.NewConnection(context.Background(), strings.Replace(server.URL, "http://", "tcp://", 1))
+	require.NoError(t, err)
+	return ctx
+}
+
+func TestStreamEventsReconnect(t *testing.T) {
+	first := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).UnixNano()
+	var requests atomic.Int32
+	var since atomic.Value
+	ctx := newTestContext(t, func(w http.ResponseWriter, r *http.Request) {
+		enc := json.NewEncoder(w)
+		switch requests.Add(1) {
+		case 1:
+			assert.Equal(t, "true", r.URL.Query().Get("stream"))
+			_ = enc.Encode(newTestEvent("a", first))
+			_ = enc.Encode(newTestEvent("b", first+1))
+			_ = enc.Encode(newTestEvent("c", first+1))
+		case 2:
+			// the service restarts
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			since.Store(r.URL.Query().Get("since"))
+			_ = enc.Encode(newTestEvent("b", first+1))
+			_ = enc.Encode(newTestEvent("c", first+1))
+			_ = enc.Encode(newTestEvent("d", first+2))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	})
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	eventChan, errChan := StreamEvents(ctx, nil)
+	var ids []string
+	for len(ids) < 4 {
+		select {
+		case e := <-eventChan:
+			ids = append(ids, e.Actor.ID)
+		case <-time.After(10 * time.Second):
+			t.Fatal("timed out waiting for events")
+		}
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ids)
+	assert.Equal(t, int32(3), requests.Load())
+	assert.Equal(t, time.Unix(0, first).UTC().Format(time.RFC3339Nano), since.Load())
+
+	cancel()
+	_, ok := <-eventChan
+	assert.False(t, ok)
+	assert.NoError(t, <-errChan)
+}
+
+func TestStreamEventsError(t *testing.T) {
+	ctx := newTestContext(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"message":"invalid filter","response":400}`))
+	})
+
+	eventChan, errChan := StreamEvents(ctx, &EventsOptions{Filters: map[string][]string{"foo": {"bar"}}})
+	err := <-errChan
+	assert.ErrorContains(t, err, "invalid filter")
+	_, ok := <-eventChan
+	assert.False(t, ok)
+}
@@ -19,6 +19,7 @@ type PruneOptions struct {
 	Volumes  *bool
 	External *bool
 	Build    *bool
+	DryRun   *bool `schema:"dry_run"`
 }
 
 // VersionOptions are optional options for getting version info
@@ -40,6 +41,25 @@ type DiskOptions struct {
 type InfoOptions struct {
 }
 
+// DevicesOptions are optional options for listing devices
+//
+//go:generate go run ../generator/generator.go DevicesOptions
+type DevicesOptions struct {
+}
+
+// HooksOptions are optional options for listing OCI hooks
+//
+//go:generate go run ../generator/generator.go HooksOptions
+type HooksOptions struct {
+}
+
+// ReloadHooksOptions are optional options for reloading the OCI hooks
+// directories
+//
+//go:generate go run ../generator/generator.go ReloadHooksOptions
+type ReloadHooksOptions struct {
+}
+
 // CheckOptions are optional options for storage consistency check/repair
 //
 //go:generate go run ../generator/generator.go CheckOptions
@@ -47,5 +67,6 @@ type CheckOptions struct {
 	Quick                       *bool   `schema:"quick"`
 	Repair                      *bool   `schema:"repair"`
 	RepairLossy                 *bool   `schema:"repair_lossy"`
+	RepairContainers            *bool   `schema:"repair_containers"`
 	UnreferencedLayerMaximumAge *string `schema:"unreferenced_layer_max_age"`
 }
@@ -62,6 +62,21 @@ func (o *CheckOptions) GetRepairLossy() bool {
 	return *o.RepairLossy
 }
 
+// WithRepairContainers set field RepairContainers to given value
+func (o *CheckOptions) WithRepairContainers(value bool) *CheckOptions {
+	o.RepairContainers = &value
+	return o
+}
+
+// GetRepairContainers returns value of field RepairContainers
+func (o *CheckOptions) GetRepairContainers() bool {
+	if o.RepairContainers == nil {
+		var z bool
+		return z
+	}
+	return *o.RepairContainers
+}
+
 // WithUnreferencedLayerMaximumAge set field UnreferencedLayerMaximumAge to given value
 func (o *CheckOptions) WithUnreferencedLayerMaximumAge(value string) *CheckOptions {
 	o.UnreferencedLayerMaximumAge = &value
@@ -0,0 +1,267 @@
+// Package client provides high-level helpers to control Podman from Go
+// programs.  A Client works on top of the container and image engines of
+// Podman, so it behaves the same whether it runs containers in-process with
+// a libpod runtime or remotely via the API service.
+//
+// Programs copying files with the client must call reexec.Init() of
+// go.podman.io/storage/pkg/reexec at the start of their main function.
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	buildahCopiah "github.com/containers/buildah/copier"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/bindings"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/domain/infra/tunnel"
+	"github.com/dmikushin/podman-shared/pkg/errorhandling"
+	"github.com/dmikushin/podman-shared/pkg/specgen"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/storage/pkg/idtools"
+)
+
+// DefaultHealthInterval is the default interval of WaitHealthy to poll the
+// health of a container.
+const DefaultHealthInterval = 250 * time.Millisecond
+
+// Client controls containers and images of Podman.
+type Client struct {
+	containers entities.ContainerEngine
+	images     entities.ImageEngine
+}
+
+// New returns a client using the container and image engines.
+func New(containers entities.ContainerEngine, images entities.ImageEngine) *Client {
+	return &Client{containers: containers, images: images}
+}
+
+// NewFromConnection returns a client using the connection to the API service
+// of the context created by the bindings.NewConnection functions.
+func NewFromConnection(conn context.Context) *Client {
+	return New(&tunnel.ContainerEngine{ClientCtx: conn}, &tunnel.ImageEngine{ClientCtx: conn})
+}
+
+// Connect connects to the API service with the URI, e.g.
+// unix:///run/podman/podman.sock, and returns a client using the connection.
+func Connect(ctx context.Context, uri string) (*Client, error) {
+	conn, err := bindings.NewConnection(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	return NewFromConnection(conn), nil
+}
+
+// ContainerEngine returns the container engine of the client for operations
+// without helper.
+func (c *Client) ContainerEngine() entities.ContainerEngine {
+	return c.containers
+}
+
+// ImageEngine returns the image engine of the client for operations without
+// helper.
+func (c *Client) ImageEngine() entities.ImageEngine {
+	return c.images
+}
+
+// EnsureImage pulls the image unless it is already present and returns its
+// ID.
+func (c *Client) EnsureImage(ctx context.Context, name string) (string, error) {
+	report, err := c.images.Pull(ctx, name, entities.ImagePullOptions{
+		PullPolicy: config.PullPolicyMissing,
+		Quiet:      true,
+		Writer:     io.Discard,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(report.Images) == 0 {
+		return "", fmt.Errorf("pulling image %q: no image reported", name)
+	}
+	return report.Images[0], nil
+}
+
+// Run creates a container with the spec and starts it in the background.  The
+// image of the spec is pulled if it is not present.  It returns the ID of the
+// container, also if the container was created but could not be started.
+func (c *Client) Run(ctx context.Context, s *specgen.SpecGenerator) (string, error) {
+	if s.Rootfs == "" {
+		if _, err := c.EnsureImage(ctx, s.Image); err != nil {
+			return "", err
+		}
+	}
+	created, err := c.containers.ContainerCreate(ctx, s)
+	if err != nil {
+		return "", err
+	}
+	reports, err := c.containers.ContainerStart(ctx, []string{created.Id}, entities.ContainerStartOptions{})
+	if err != nil {
+		return created.Id, err
+	}
+	for _, report := range reports {
+		if report.Err != nil {
+			return created.Id, report.Err
+		}
+	}
+	return created.Id, nil
+}
+
+// WaitHealthy waits until the healthcheck of the container reports it
+// healthy, polling its health with the interval, DefaultHealthInterval if 0.
+// It fails if the container turns unhealthy, stops, or has no healthcheck.
+func (c *Client) WaitHealthy(ctx context.Context, nameOrID string, interval time.Duration) error {
+	if interval <= 0 {
+		interval = DefaultHealthInterval
+	}
+	// The remote engine does not stop waiting with ctx.
+	errChan := make(chan error, 1)
+	go func() {
+		reports, err := c.containers.ContainerWait(ctx, []string{nameOrID}, entities.WaitOptions{
+			Conditions: []string{define.HealthCheckHealthy, define.HealthCheckUnhealthy},
+			Interval:   interval,
+		})
+		if err == nil && len(reports) > 0 {
+			err = reports[0].Error
+		}
+		errChan <- err
+	}()
+	select {
+	case err := <-errChan:
+		if err != nil {
+			return fmt.Errorf("waiting for container %s to be healthy: %w", nameOrID, err)
+		}
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	inspect, errs, err := c.containers.ContainerInspect(ctx, []string{nameOrID}, entities.InspectOptions{})
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errorhandling.JoinErrors(errs)
+	}
+	if len(inspect) == 0 || inspect[0].State == nil || inspect[0].State.Health == nil {
+		return fmt.Errorf("container %s has no health status", nameOrID)
+	}
+	if status := inspect[0].State.Health.Status; status != define.HealthCheckHealthy {
+		return fmt.Errorf("container %s is %s", nameOrID, status)
+	}
+	return nil
+}
+
+// CopyTo copies the file or directory at hostPath on the host into the
+// directory containerDir of the container, keeping its name.
+func (c *Client) CopyTo(ctx context.Context, nameOrID, hostPath, containerDir string) error {
+	hostPath, err := filepath.Abs(hostPath)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(hostPath); err != nil {
+		return err
+	}
+
+	reader, writer := io.Pipe()
+	hostCopy := func() error {
+		// On Windows, the root path needs to be <drive>:\, while otherwise
+		// it needs to be /.
+		root := filepath.VolumeName(hostPath) + string(os.PathSeparator)
+		err := buildahCopiah.Get(root, "", buildahCopiah.GetOptions{KeepDirectoryNames: true}, []string{hostPath}, writer)
+		writer.CloseWithError(err)
+		if err != nil {
+			return fmt.Errorf("copying from host: %w", err)
+		}
+		return nil
+	}
+	containerCopy := func() error {
+		copyFunc, err := c.containers.ContainerCopyFromArchive(ctx, nameOrID, containerDir, reader, entities.CopyOptions{Chown: true})
+		if err == nil {
+			err = copyFunc()
+		}
+		reader.CloseWithError(err)
+		if err != nil {
+			return fmt.Errorf("copying to container: %w", err)
+		}
+		return nil
+	}
+	return doCopy(hostCopy, containerCopy)
+}
+
+// CopyFrom copies the file or directory at containerPath in the container
+// into the directory hostDir on the host, keeping its name.  The copied files
+// are owned by the current user.
+func (c *Client) CopyFrom(ctx context.Context, nameOrID, containerPath, hostDir string) error {
+	info, err := os.Stat(hostDir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%q is not a directory", hostDir)
+	}
+
+	reader, writer := io.Pipe()
+	containerCopy := func() error {
+		copyFunc, err := c.containers.ContainerCopyToArchive(ctx, nameOrID, path.Clean(containerPath), writer)
+		if err == nil {
+			err = copyFunc()
+		}
+		writer.CloseWithError(err)
+		if err != nil {
+			return fmt.Errorf("copying from container: %w", err)
+		}
+		return nil
+	}
+	hostCopy := func() error {
+		idPair := currentIDPair()
+		err := buildahCopiah.Put(hostDir, "", buildahCopiah.PutOptions{
+			ChownDirs:     &idPair,
+			ChownFiles:    &idPair,
+			IgnoreDevices: true,
+		}, reader)
+		reader.CloseWithError(err)
+		if err != nil {
+			return fmt.Errorf("copying to host: %w", err)
+		}
+		return nil
+	}
+	return doCopy(containerCopy, hostCopy)
+}
+
+// currentIDPair returns the IDs of the current user.
+func currentIDPair() idtools.IDPair {
+	idPair := idtools.IDPair{}
+	current, err := user.Current()
+	if err != nil {
+		logrus.Debugf("Error looking up the current user: %v", err)
+		return idPair
+	}
+	if i, err := strconv.Atoi(current.Uid); err == nil {
+		idPair.UID = i
+	}
+	if i, err := strconv.Atoi(current.Gid); err == nil {
+		idPair.GID = i
+	}
+	return idPair
+}
+
+// doCopy executes the two functions in parallel to copy data from A to B and
+// joins the errors if any.
+func doCopy(funcA func() error, funcB func() error) error {
+	errChan := make(chan error)
+	go func() {
+		errChan <- funcA()
+	}()
+	var copyErrors []error
+	copyErrors = append(copyErrors, funcB())
+	copyErrors = append(copyErrors, <-errChan)
+	return errorhandling.JoinErrors(copyErrors)
+}
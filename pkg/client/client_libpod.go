@@ -0,0 +1,14 @@
+//go:build !remote
+
+package client
+
+import (
+	"github.com/dmikushin/podman-shared/libpod"
+	"github.com/dmikushin/podman-shared/pkg/domain/infra/abi"
+)
+
+// NewFromRuntime returns a client running containers in-process with the
+// libpod runtime.
+func NewFromRuntime(r *libpod.Runtime) *Client {
+	return New(&abi.ContainerEngine{Libpod: r}, &abi.ImageEngine{Libpod: r})
+}
@@ -0,0 +1,140 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/specgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/storage/pkg/reexec"
+)
+
+func TestMain(m *testing.M) {
+	if reexec.Init() {
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// fakeContainerEngine implements the container engine methods used by the
+// client, all others panic.
+type fakeContainerEngine struct {
+	entities.ContainerEngine
+	created  []*specgen.SpecGenerator
+	started  []string
+	startErr error
+	health   string
+	waitErr  error
+	archive  bytes.Buffer
+}
+
+func (f *fakeContainerEngine) ContainerCreate(_ context.Context, s *specgen.SpecGenerator) (*entities.ContainerCreateReport, error) {
+	f.created = append(f.created, s)
+	return &entities.ContainerCreateReport{Id: "ctr"}, nil
+}
+
+func (f *fakeContainerEngine) ContainerStart(_ context.Context, namesOrIds []string, _ entities.ContainerStartOptions) ([]*entities.ContainerStartReport, error) {
+	f.started = append(f.started, namesOrIds...)
+	return []*entities.ContainerStartReport{{Id: namesOrIds[0], Err: f.startErr}}, nil
+}
+
+func (f *fakeContainerEngine) ContainerWait(_ context.Context, _ []string, options entities.WaitOptions) ([]entities.WaitReport, error) {
+	if len(options.Conditions) != 2 {
+		return nil, errors.New("unexpected conditions")
+	}
+	return []entities.WaitReport{{Error: f.waitErr, ExitCode: -1}}, nil
+}
+
+func (f *fakeContainerEngine) ContainerInspect(_ context.Context, _ []string, _ entities.InspectOptions) ([]*entities.ContainerInspectReport, []error, error) {
+	data := &define.InspectContainerData{State: &define.InspectContainerState{Health: &define.HealthCheckResults{Status: f.health}}}
+	return []*entities.ContainerInspectReport{{InspectContainerData: data}}, nil, nil
+}
+
+func (f *fakeContainerEngine) ContainerCopyFromArchive(_ context.Context, _, _ string, reader io.Reader, _ entities.CopyOptions) (entities.ContainerCopyFunc, error) {
+	return func() error {
+		_, err := io.Copy(&f.archive, reader)
+		return err
+	}, nil
+}
+
+func (f *fakeContainerEngine) ContainerCopyToArchive(_ context.Context, _, _ string, writer io.Writer) (entities.ContainerCopyFunc, error) {
+	return func() error {
+		_, err := io.Copy(writer, &f.archive)
+		return err
+	}, nil
+}
+
+// fakeImageEngine implements the image engine methods used by the client,
+// all others panic.
+type fakeImageEngine struct {
+	entities.ImageEngine
+	pulled []string
+}
+
+func (f *fakeImageEngine) Pull(_ context.Context, rawImage string, _ entities.ImagePullOptions) (*entities.ImagePullReport, error) {
+	f.pulled = append(f.pulled, rawImage)
+	return &entities.ImagePullReport{Images: []string{"image"}}, nil
+}
+
+func TestRun(t *testing.T) {
+	containers := &fakeContainerEngine{}
+	images := &fakeImageEngine{}
+	client := New(containers, images)
+
+	id, err := client.Run(context.Background(), specgen.NewSpecGenerator("alpine", false))
+	require.NoError(t, err)
+	assert.Equal(t, "ctr", id)
+	assert.Equal(t, []string{"alpine"}, images.pulled)
+	assert.Len(t, containers.created, 1)
+	assert.Equal(t, []string{"ctr"}, containers.started)
+
+	// containers with a rootfs do not need an image
+	_, err = client.Run(context.Background(), specgen.NewSpecGenerator("/rootfs", true))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alpine"}, images.pulled)
+
+	containers.startErr = errors.New("start failed")
+	id, err = client.Run(context.Background(), specgen.NewSpecGenerator("alpine", false))
+	assert.ErrorContains(t, err, "start failed")
+	assert.Equal(t, "ctr", id)
+}
+
+func TestWaitHealthy(t *testing.T) {
+	containers := &fakeContainerEngine{health: define.HealthCheckHealthy}
+	client := New(containers, &fakeImageEngine{})
+	assert.NoError(t, client.WaitHealthy(context.Background(), "ctr", 0))
+
+	containers.health = define.HealthCheckUnhealthy
+	assert.EqualError(t, client.WaitHealthy(context.Background(), "ctr", 0), "container ctr is unhealthy")
+
+	containers.waitErr = define.ErrCtrStopped
+	assert.ErrorIs(t, client.WaitHealthy(context.Background(), "ctr", 0), define.ErrCtrStopped)
+}
+
+func TestCopy(t *testing.T) {
+	containers := &fakeContainerEngine{}
+	client := New(containers, &fakeImageEngine{})
+
+	src := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(src, "data", "sub"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(src, "data", "sub", "file"), []byte("content"), 0o644))
+	require.NoError(t, client.CopyTo(context.Background(), "ctr", filepath.Join(src, "data"), "/"))
+	assert.NotZero(t, containers.archive.Len())
+
+	dest := t.TempDir()
+	require.NoError(t, client.CopyFrom(context.Background(), "ctr", "/data", dest))
+	content, err := os.ReadFile(filepath.Join(dest, "data", "sub", "file"))
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+
+	assert.Error(t, client.CopyFrom(context.Background(), "ctr", "/data", filepath.Join(dest, "data", "sub", "file")))
+	assert.Error(t, client.CopyTo(context.Background(), "ctr", filepath.Join(src, "missing"), "/"))
+}
@@ -5,6 +5,7 @@ import (
 
 	encconfig "github.com/containers/ocicrypt/config"
 	entitiesTypes "github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"go.podman.io/image/v5/types"
 )
 
@@ -14,6 +15,8 @@ type ArtifactAddOptions struct {
 	Append           bool
 	FileMIMEType     string
 	Replace          bool
+	// Subject is the manifest the artifact refers to. Local only.
+	Subject *imgspecv1.Descriptor
 }
 
 type ArtifactAddReport = entitiesTypes.ArtifactAddReport
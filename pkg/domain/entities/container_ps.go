@@ -75,6 +75,12 @@ func (a psSortedSize) Less(i, j int) bool {
 	return a.SortListContainers[i].Size.RootFsSize < a.SortListContainers[j].Size.RootFsSize
 }
 
+type psSortedMemory struct{ SortListContainers }
+
+func (a psSortedMemory) Less(i, j int) bool {
+	return a.SortListContainers[i].MemUsage < a.SortListContainers[j].MemUsage
+}
+
 type PsSortedCreateTime struct{ SortListContainers }
 
 func (a PsSortedCreateTime) Less(i, j int) bool {
@@ -101,8 +107,21 @@ func SortPsOutput(sortBy string, psOutput SortListContainers) (SortListContainer
 		sort.Sort(PsSortedCreateTime{psOutput})
 	case "pod":
 		sort.Sort(psSortedPod{psOutput})
+	case "memory":
+		sort.Sort(psSortedMemory{psOutput})
 	default:
-		return nil, errors.New("invalid option for --sort, options are: command, created, id, image, names, runningfor, size, or status")
+		return nil, errPsSort
 	}
 	return psOutput, nil
 }
+
+var errPsSort = errors.New("invalid option for --sort, options are: command, created, id, image, memory, names, runningfor, size, or status")
+
+// ValidatePsSort returns an error if containers cannot be sorted by sortBy.
+func ValidatePsSort(sortBy string) error {
+	switch sortBy {
+	case "command", "created", "id", "image", "memory", "names", "pod", "runningfor", "size", "status":
+		return nil
+	}
+	return errPsSort
+}
@@ -45,10 +45,18 @@ type ContainerRunlabelOptions struct {
 	// SkipTLSVerify - skip HTTPS and certificate verifications when
 	// contacting registries.
 	SkipTLSVerify imageTypes.OptionalBool
+	// Username - username to use when pulling an image.
+	Username string
+	// Password - password to use when pulling an image.
+	Password string
+	// Writer - if set, the pull progress and the output of the command are
+	// written to it instead of the standard streams and no input is
+	// connected to the command.
+	Writer io.Writer
 }
 
 // ContainerRunlabelReport contains the results from executing container-runlabel.
-type ContainerRunlabelReport struct{}
+type ContainerRunlabelReport = types.ContainerRunlabelReport
 
 // WaitOptions are arguments for waiting for a container.
 type WaitOptions struct {
@@ -64,8 +72,17 @@ type WaitOptions struct {
 	Latest bool
 	// Wait for exit of first container which matches conditions, ignore other ones.
 	ExitFirstMatch bool
+	// ConditionChan, if set, receives a report whenever a container meets
+	// one of the conditions.  Each condition is awaited separately for
+	// each container and the channel is closed once all of them are met
+	// or failed.  ContainerWait returns without WaitReports in this mode
+	// once the containers have been looked up.
+	ConditionChan chan WaitConditionReport
 }
 
+// WaitConditionReport notifies that a container met a wait condition.
+type WaitConditionReport = types.WaitConditionReport
+
 // WaitReport is the result of waiting a container.
 type WaitReport struct {
 	// Error while waiting.
@@ -87,6 +104,9 @@ type PauseUnPauseOptions struct {
 	Filters map[string][]string
 	All     bool
 	Latest  bool
+	// FreezeTimeout is the number of seconds after which paused
+	// containers are unpaused automatically.  Zero disables it.
+	FreezeTimeout uint
 }
 
 type PauseUnpauseReport struct {
@@ -99,6 +119,7 @@ type StopOptions struct {
 	Filters map[string][]string
 	All     bool
 	Ignore  bool
+	Jobs    uint
 	Latest  bool
 	Timeout *uint
 }
@@ -192,10 +213,41 @@ type CommitReport struct {
 	Id string
 }
 
+const (
+	// ExportFormatTar exports the root file system of a container.
+	ExportFormatTar = "tar"
+	// ExportFormatOCIArchive exports a container as an OCI archive keeping
+	// its configuration.
+	ExportFormatOCIArchive = "oci-archive"
+)
+
 type ContainerExportOptions struct {
+	// Format is ExportFormatTar, the default, or ExportFormatOCIArchive.
+	Format string
 	Output io.Writer
 }
 
+// ContainerBackupOptions describes the options to back up a container.
+type ContainerBackupOptions struct {
+	Output io.Writer
+	// IgnoreVolumes leaves the named volumes of the container out of the
+	// backup.
+	IgnoreVolumes bool
+}
+
+// ContainerRestoreBackupOptions describes the options to recreate a
+// container from a backup.
+type ContainerRestoreBackupOptions struct {
+	Input io.Reader
+	// Name overrides the name of the container in the backup.
+	Name string
+	// IgnoreVolumes does not create the named volumes of the backup, the
+	// container uses the existing volumes.
+	IgnoreVolumes bool
+}
+
+type ContainerRestoreBackupReport = types.ContainerRestoreBackupReport
+
 type CheckpointOptions struct {
 	All            bool
 	Export         string
@@ -239,6 +291,9 @@ type RestoreReport = types.RestoreReport
 
 type ContainerCreateReport struct {
 	Id string
+	// PublishedPorts are the port mappings of the container, including
+	// the host ports that were allocated dynamically
+	PublishedPorts []nettypes.PortMapping
 }
 
 // AttachOptions describes the cli and other values
@@ -308,6 +363,7 @@ type ContainerStartOptions struct {
 	Attach      bool
 	DetachKeys  string
 	Interactive bool
+	Jobs        uint
 	Latest      bool
 	SigProxy    bool
 	Stdout      *os.File
@@ -333,6 +389,7 @@ type ContainerListOptions struct {
 	Last      int
 	Latest    bool
 	Namespace bool
+	Offset    int
 	Pod       bool
 	Quiet     bool
 	Size      bool
@@ -479,6 +536,22 @@ type ContainerStatsOptions struct {
 
 type ContainerStatsReport = types.ContainerStatsReport
 
+// ContainerStatsHistoryOptions describes input options for getting the stats
+// history of containers.
+type ContainerStatsHistoryOptions struct {
+	// Get the stats history of all containers.
+	All bool
+	// Operate on the latest known container.  Only supported for local
+	// clients.
+	Latest bool
+	// Only return samples recorded at or after this time.
+	Since string
+	// Only return samples recorded at or before this time.
+	Until string
+}
+
+type ContainerStatsHistoryReport = types.ContainerStatsHistoryReport
+
 // ContainerRenameOptions describes input options for renaming a container.
 type ContainerRenameOptions struct {
 	// NewName is the new name that will be given to the container.
@@ -0,0 +1,8 @@
+package entities
+
+import (
+	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+)
+
+// DeviceListReport describes a device available to containers
+type DeviceListReport = types.DeviceListReport
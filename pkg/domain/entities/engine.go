@@ -42,9 +42,11 @@ type PodmanConfig struct {
 	TLSCAFile                string   // tls certificate authority to verify server connection
 	IsRenumber               bool     // Is this a system renumber command? If so, a number of checks will be relaxed
 	IsReset                  bool     // Is this a system reset command? If so, a number of checks will be skipped/omitted
+	LazyStorage              bool     // Defer setting up the container storage until it is used
 	MaxWorks                 int      // maximum number of parallel threads
 	MemoryProfile            string   // Hidden: Should memory profile be taken
 	RegistriesConf           string   // allows for specifying a custom registries.conf
+	ShortNameAliasConf       string   // short-name aliases recorded with a project or connection registries.conf
 	Remote                   bool     // Connection to Podman API Service will use RESTful API
 	RuntimePath              string   // --runtime flag will set Engine.RuntimePath
 	RuntimeFlags             []string // global flags for the container runtime
@@ -53,6 +55,7 @@ type PodmanConfig struct {
 	URI                      string   // URI to RESTful API Service
 	FarmNodeName             string   // Name of farm node
 	ConnectionError          error    // Error when looking up the connection in setupRemoteConnection()
+	ConnectionName           string   // Name of the active connection, empty if given by URI
 
 	Runroot        string
 	ImageStore     string
@@ -62,5 +65,9 @@ type PodmanConfig struct {
 	MachineMode    bool
 	TransientStore bool
 	GraphRoot      string
+	ReadWriteRoot  string
 	PullOptions    []string
+
+	SharedLayerCache     string
+	SharedLayerCacheSize string
 }
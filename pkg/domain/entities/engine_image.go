@@ -27,10 +27,16 @@ type ImageEngine interface { //nolint:interfacebloat
 	List(ctx context.Context, opts ImageListOptions) ([]*ImageSummary, error)
 	Load(ctx context.Context, opts ImageLoadOptions) (*ImageLoadReport, error)
 	Mount(ctx context.Context, images []string, options ImageMountOptions) ([]*ImageMountReport, error)
+	Optimize(ctx context.Context, nameOrID, target string, opts ImageOptimizeOptions) (*ImageOptimizeReport, error)
 	Prune(ctx context.Context, opts ImagePruneOptions) ([]*reports.PruneReport, error)
 	Pull(ctx context.Context, rawImage string, opts ImagePullOptions) (*ImagePullReport, error)
 	Push(ctx context.Context, source string, destination string, opts ImagePushOptions) (*ImagePushReport, error)
 	Remove(ctx context.Context, images []string, opts ImageRemoveOptions) (*ImageRemoveReport, []error)
+	RootfsList(ctx context.Context) ([]*RootfsListReport, error)
+	RootfsPrepare(ctx context.Context, nameOrID string, opts RootfsPrepareOptions) (*RootfsPrepareReport, error)
+	RootfsRm(ctx context.Context, names []string, opts RootfsRmOptions) ([]*RootfsRmReport, error)
+	SBOM(ctx context.Context, nameOrID string, opts ImageSBOMOptions) (*ImageSBOMReport, error)
+	Scan(ctx context.Context, nameOrID string, opts ImageScanOptions) (*ImageScanReport, error)
 	Save(ctx context.Context, nameOrID string, tags []string, options ImageSaveOptions) error
 	Scp(ctx context.Context, src, dst string, opts ImageScpOptions) (*ImageScpReport, error)
 	Search(ctx context.Context, term string, opts ImageSearchOptions) ([]ImageSearchReport, error)
@@ -34,6 +34,8 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 	network := e.Actor.Attributes["network"]
 	podID := e.Actor.Attributes["podId"]
 	errorString := e.Actor.Attributes["error"]
+	publishedPorts := e.Actor.Attributes["publishedPorts"]
+	oomReport := e.Actor.Attributes["oomReport"]
 	details := e.Actor.Attributes
 	delete(details, "image")
 	delete(details, "name")
@@ -41,6 +43,8 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 	delete(details, "podId")
 	delete(details, "error")
 	delete(details, "containerExitCode")
+	delete(details, "publishedPorts")
+	delete(details, "oomReport")
 	return &libpodEvents.Event{
 		ContainerExitCode: &exitCode,
 		ID:                e.Actor.ID,
@@ -52,6 +56,8 @@ func ConvertToLibpodEvent(e Event) *libpodEvents.Event {
 		Type:              t,
 		HealthStatus:      e.HealthStatus,
 		Error:             errorString,
+		PublishedPorts:    publishedPorts,
+		OOMReport:         oomReport,
 		Details: libpodEvents.Details{
 			PodID:      podID,
 			Attributes: details,
@@ -77,6 +83,12 @@ func ConvertToEntitiesEvent(e libpodEvents.Event) *types.Event {
 	if e.Error != "" {
 		attributes["error"] = e.Error
 	}
+	if e.PublishedPorts != "" {
+		attributes["publishedPorts"] = e.PublishedPorts
+	}
+	if e.OOMReport != "" {
+		attributes["oomReport"] = e.OOMReport
+	}
 	message := dockerEvents.Message{
 		// Compatibility with clients that still look for deprecated API elements
 		Status: e.Status.String(),
@@ -26,12 +26,24 @@ type GenerateSystemdOptions struct {
 // GenerateSystemdReport
 type GenerateSystemdReport = types.GenerateSystemdReport
 
+// GenerateQuadletOptions control the generation of Quadlet unit files.
+type GenerateQuadletOptions struct {
+	// NoHeader - do not add the Podman version to the generated units.
+	NoHeader bool
+}
+
+// GenerateQuadletReport
+type GenerateQuadletReport = types.GenerateQuadletReport
+
 // GenerateKubeOptions control the generation of Kubernetes YAML files.
 type GenerateKubeOptions struct {
 	// PodmanOnly - add podman-only reserved annotations in the generated YAML file (Cannot be used by Kubernetes)
 	PodmanOnly bool
 	// Service - generate YAML for a Kubernetes _service_ object.
 	Service bool
+	// Ingress - the kind of object routing HTTP traffic to the service, ingress or httproute.
+	// Implies Service.
+	Ingress string
 	// Type - the k8s kind to be generated i.e Pod or Deployment
 	Type string
 	// Replicas - the value to set in the replicas field for a Deployment
@@ -52,4 +64,7 @@ type GenerateSpecOptions struct {
 	FileName string
 	Compact  bool
 	Name     bool
+	// StripHostSpecific - remove the host paths, host IDs and addresses
+	// from the spec to create the container or pod on another host
+	StripHostSpecific bool
 }
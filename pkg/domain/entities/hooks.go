@@ -0,0 +1,11 @@
+package entities
+
+import (
+	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+)
+
+// HookListReport describes an OCI hook of the hooks directories
+type HookListReport = types.HookListReport
+
+// HookTestReport describes whether an OCI hook matches a container
+type HookTestReport = types.HookTestReport
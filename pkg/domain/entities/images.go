@@ -3,6 +3,7 @@ package entities
 import (
 	"io"
 	"net/url"
+	"time"
 
 	encconfig "github.com/containers/ocicrypt/config"
 	entitiesTypes "github.com/dmikushin/podman-shared/pkg/domain/entities/types"
@@ -74,6 +75,12 @@ type ImagePullOptions struct {
 	SkipTLSVerify types.OptionalBool
 	// PullPolicy whether to pull new image
 	PullPolicy config.PullPolicy
+	// PullMaxAge, with PullPolicyNewer, skips checking the registry for
+	// local images pulled or checked less than PullMaxAge ago.
+	PullMaxAge time.Duration
+	// ToShared pulls the image into the additional image store on shared
+	// storage instead of the local graph root.  Local only.
+	ToShared bool
 	// Writer is used to display copy information including progress bars.
 	Writer io.Writer
 	// OciDecryptConfig contains the config that can be used to decrypt an image if it is
@@ -209,6 +216,10 @@ type ImageListOptions struct {
 	// that the compat endpoint does not
 	ExtendedAttributes bool
 	Filter             []string
+	// Limit and Offset select a page of the images sorted by creation
+	// time, newest first
+	Limit  int
+	Offset int
 }
 
 type ImagePruneOptions struct {
@@ -291,11 +302,55 @@ type ImageScpConnections struct {
 // ImageTreeOptions provides options for ImageEngine.Tree()
 type ImageTreeOptions struct {
 	WhatRequires bool // Show all child images and layers of the specified image
+	DiskUsage    bool // Show the disk usage and sharing of the layers of the image
 }
 
 // ImageTreeReport provides results from ImageEngine.Tree()
 type ImageTreeReport = entitiesTypes.ImageTreeReport
 
+// ImageSBOMOptions provides options for ImageEngine.SBOM()
+type ImageSBOMOptions struct {
+	// Format of the SBOM, "spdx" or "cyclonedx"
+	Format string
+	// Attach the SBOM as a referrer artifact to this image in a registry
+	Attach string
+	// Authfile, credentials and TLS verification used to attach the SBOM
+	Authfile      string
+	Username      string
+	Password      string
+	SkipTLSVerify types.OptionalBool
+}
+
+// ImageSBOMReport provides results from ImageEngine.SBOM()
+type ImageSBOMReport = entitiesTypes.ImageSBOMReport
+
+// ImageScanOptions provides options for ImageEngine.Scan()
+type ImageScanOptions struct {
+	// Scanner to use, defaults to the scanner of the scan configuration
+	Scanner string
+	// Threshold evaluates the vulnerabilities against this severity
+	Threshold string
+	// RunPolicy evaluates the vulnerabilities against the run threshold of
+	// the scan configuration instead, the image is not scanned if there is
+	// none
+	RunPolicy bool
+}
+
+// ImageScanReport provides results from ImageEngine.Scan()
+type ImageScanReport = entitiesTypes.ImageScanReport
+
+// ImageOptimizeOptions provides options for ImageEngine.Optimize()
+type ImageOptimizeOptions struct {
+	// MergeThreshold is the size in bytes under which consecutive layers
+	// are merged, 0 to not merge layers
+	MergeThreshold int64
+	// CompressionLevel of the zstd compression, nil for the default level
+	CompressionLevel *int
+}
+
+// ImageOptimizeReport provides results from ImageEngine.Optimize()
+type ImageOptimizeReport = entitiesTypes.ImageOptimizeReport
+
 // ShowTrustOptions are the cli options for showing trust
 type ShowTrustOptions struct {
 	JSON         bool
@@ -331,14 +386,25 @@ type SignReport struct{}
 type ImageMountOptions struct {
 	All    bool
 	Format string
+	// AllLayers mounts each layer of the image separately and read-only
+	// in a numbered directory under Target.
+	AllLayers bool
+	Target    string
 }
 
 // ImageUnmountOptions are the options from the cli for unmounting
 type ImageUnmountOptions struct {
 	All   bool
 	Force bool
+	// AllLayers unmounts the layers of the image mounted under Target
+	// with ImageMountOptions.AllLayers.
+	AllLayers bool
+	Target    string
 }
 
+// ImageLayerMount describes a layer mounted by image mount --all-layers
+type ImageLayerMount = entitiesTypes.ImageLayerMount
+
 // ImageMountReport describes the response from image mount
 type ImageMountReport = entitiesTypes.ImageMountReport
 
@@ -16,8 +16,9 @@ type NetworkListOptions struct {
 // NetworkReloadOptions describes options for reloading container network
 // configuration.
 type NetworkReloadOptions struct {
-	All    bool
-	Latest bool
+	All      bool
+	Latest   bool
+	Graceful bool
 }
 
 // NetworkReloadReport describes the results of reloading a container network.
@@ -35,6 +36,7 @@ type NetworkRmReport = entitiesTypes.NetworkRmReport
 // NetworkCreateOptions describes options to create a network
 type NetworkCreateOptions struct {
 	DisableDNS        bool
+	DNSCache          bool
 	Driver            string
 	Gateways          []net.IP
 	Internal          bool
@@ -84,3 +86,5 @@ type NetworkPruneOptions struct {
 
 type NetworkInspectReport = entitiesTypes.NetworkInspectReport
 type NetworkContainerInfo = entitiesTypes.NetworkContainerInfo
+
+type NetworkWireGuardPeer = entitiesTypes.NetworkWireGuardPeer
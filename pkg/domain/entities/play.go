@@ -77,6 +77,9 @@ type PlayKubeOptions struct {
 	// PublishAllPorts - whether to publish all ports defined in the K8S YAML file
 	// (containerPort, hostPort) otherwise only hostPort will be published
 	PublishAllPorts bool
+	// NetworkPolicy - enforce the NetworkPolicy objects of the K8S YAML file
+	// with firewall rules in the network namespaces of the selected pods
+	NetworkPolicy bool
 	// Wait - indicates whether to return after having created the pods
 	Wait bool
 	// SystemContext - used when building the image
@@ -97,6 +100,16 @@ type KubePlayReport = entitiesTypes.KubePlayReport
 type PlayKubeDownOptions struct {
 	// Force - remove volumes if passed
 	Force bool
+	// Volumes - remove the volumes of PersistentVolumeClaims
+	Volumes bool
+	// ConfigMaps - remove the volumes created for ConfigMap and Secret
+	// volumes
+	ConfigMaps bool
+	// KeepSecrets - do not remove the secrets created from Secrets
+	KeepSecrets bool
+	// Timeout - seconds to wait for each pod to stop before killing its
+	// containers, the stop timeout of the containers if nil
+	Timeout *uint
 }
 
 // PlayKubeDownReport contains the results of tearing down play kube
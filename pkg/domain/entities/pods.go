@@ -41,6 +41,7 @@ type PodUnpauseReport = types.PodUnpauseReport
 type PodStopOptions struct {
 	All     bool
 	Ignore  bool
+	Jobs    uint
 	Latest  bool
 	Timeout int
 }
@@ -55,6 +56,7 @@ type PodRestartOptions struct {
 type PodRestartReport = types.PodRestartReport
 type PodStartOptions struct {
 	All    bool
+	Jobs   uint
 	Latest bool
 }
 
@@ -115,6 +117,11 @@ type PodLogsOptions struct {
 	Color bool
 }
 
+// PodUpdateOptions contains the changes to make to an existing pod
+type PodUpdateOptions = types.PodUpdateOptions
+
+type PodUpdateReport = types.PodUpdateReport
+
 // PodCloneOptions contains options for cloning an existing pod
 type PodCloneOptions struct {
 	ID                  string
@@ -187,17 +194,20 @@ type ContainerCreateOptions struct {
 	InitPath             string
 	IntelRdtClosID       string
 	Interactive          bool
+	IOMax                []string
 	IPC                  string
 	Label                []string
 	LabelFile            []string
 	LogDriver            string
 	LogOptions           []string
 	Memory               string
+	MemoryHigh           string
 	MemoryReservation    string
 	MemorySwap           string
 	MemorySwappiness     int64
 	Name                 string `json:"container_name"`
 	NoHealthCheck        bool
+	NUMAPolicy           string
 	OOMKillDisable       bool
 	OOMScoreAdj          *int
 	Arch                 string
@@ -211,12 +221,16 @@ type ContainerCreateOptions struct {
 	Personality          string
 	PreserveFDs          uint
 	PreserveFD           []uint
+	SocketActivation     bool
 	Privileged           bool
 	PublishAll           bool
 	Pull                 string
+	PullMaxAge           string
 	Quiet                bool
 	ReadOnly             bool
 	ReadWriteTmpFS       bool
+	ReadWriteTmpFSSize   string
+	ReadWriteTmpFSOpts   []string
 	Restart              string
 	Replace              bool
 	Requires             []string
@@ -247,6 +261,12 @@ type ContainerCreateOptions struct {
 	TmpFS                []string
 	TTY                  bool
 	Timezone             string
+	TZFromHost           bool
+	Locale               string
+	Monitor              string
+	RuntimeClass         string
+	TrackFlows           bool
+	StatsHistory         string
 	Umask                string
 	EnvMerge             []string
 	UnsetEnv             []string
@@ -278,6 +298,13 @@ type ContainerCreateOptions struct {
 	// SharedBaseLayers instructs Podman to skip copying base layers for this container
 	// launch, using them directly from shared storage (like NFS)
 	SharedBaseLayers bool
+
+	// StorageTransient keeps the container state under the run root and
+	// removes the container when the system reboots
+	StorageTransient bool
+
+	// PodmanInPodman sets the container up to run Podman in it
+	PodmanInPodman bool
 }
 
 func NewInfraContainerCreateOptions() ContainerCreateOptions {
@@ -421,7 +448,9 @@ type PodPSOptions struct {
 	Filters   map[string][]string
 	Format    string
 	Latest    bool
+	Limit     int
 	Namespace bool
+	Offset    int
 	Quiet     bool
 	Sort      string
 }
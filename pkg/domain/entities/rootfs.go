@@ -0,0 +1,43 @@
+package entities
+
+import "time"
+
+// RootfsPrepareOptions are the options for preparing a root file system
+// directory from an image.
+type RootfsPrepareOptions struct {
+	// Name of the root file system, derived from the image by default.
+	Name string
+	// Link uses the mounted image instead of a copy of it.
+	Link bool
+	// Replace an existing root file system with the same name.
+	Replace bool
+}
+
+// RootfsPrepareReport describes a prepared root file system.
+type RootfsPrepareReport struct {
+	Name string
+	// Path is the directory to pass to --rootfs.
+	Path string
+}
+
+// RootfsListReport describes a root file system prepared from an image.
+type RootfsListReport struct {
+	Name    string
+	Image   string
+	ImageID string
+	Path    string
+	Linked  bool
+	Created time.Time
+}
+
+// RootfsRmOptions are the options for removing prepared root file systems.
+type RootfsRmOptions struct {
+	All    bool
+	Ignore bool
+}
+
+// RootfsRmReport is the result of removing a prepared root file system.
+type RootfsRmReport struct {
+	Name string
+	Err  error
+}
@@ -9,6 +9,10 @@ type ServiceOptions = types.ServiceOptions
 type SystemPruneOptions = types.SystemPruneOptions
 type SystemPruneReport = types.SystemPruneReport
 type SystemMigrateOptions = types.SystemMigrateOptions
+type SystemMigrateReport = types.SystemMigrateReport
+type SystemRenumberOptions = types.SystemRenumberOptions
+type SystemRenumberReport = types.SystemRenumberReport
+type SystemSharedLayerCachePurgeReport = types.SystemSharedLayerCachePurgeReport
 type SystemCheckOptions = types.SystemCheckOptions
 type SystemCheckReport = types.SystemCheckReport
 type SystemDfOptions = types.SystemDfOptions
@@ -18,6 +22,9 @@ type SystemDfContainerReport = types.SystemDfContainerReport
 type SystemDfVolumeReport = types.SystemDfVolumeReport
 type SystemVersionReport = types.SystemVersionReport
 type SystemUnshareOptions = types.SystemUnshareOptions
+type SystemSubIDStatusReport = types.SystemSubIDStatusReport
+type SystemSubIDExpandOptions = types.SystemSubIDExpandOptions
+type SystemSubIDExpandReport = types.SystemSubIDExpandReport
 type ComponentVersion = types.SystemComponentVersion
 type ListRegistriesReport = types.ListRegistriesReport
 
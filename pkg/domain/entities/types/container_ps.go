@@ -57,6 +57,9 @@ type ListContainer struct {
 	// If the container is part of Pod, the Pod name. Requires the pod
 	// boolean to be set
 	PodName string
+	// MemUsage is the memory usage of the running container in bytes.
+	// Only set when sorting by memory
+	MemUsage uint64 `json:",omitempty"`
 	// Port mappings
 	Ports []netTypes.PortMapping
 	// Restarts is how many times the container was restarted by its
@@ -71,6 +74,9 @@ type ListContainer struct {
 	State string
 	// Status is a human-readable approximation of a duration for json output
 	Status string
+	// StorageTransient is true when the container is removed when the
+	// system reboots
+	StorageTransient bool
 }
 
 // ListContainerNamespaces contains the identifiers of the container's Linux namespaces
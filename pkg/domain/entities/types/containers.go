@@ -8,6 +8,30 @@ import (
 
 type ContainerCopyFunc func() error
 
+// WaitConditionReport is sent whenever a container meets a condition while
+// waiting for multiple conditions.
+type WaitConditionReport struct {
+	// ID of the container.
+	ID string `json:"Id"`
+	// Name of the container.
+	Name string
+	// Condition which was met.
+	Condition string
+	// ExitCode of the container if the condition is exited or stopped.
+	ExitCode int32
+	// Error while waiting for the condition.
+	Error string `json:"Err,omitempty"`
+}
+
+// ContainerRunlabelReport is streamed by the remote API while executing
+// container-runlabel.
+type ContainerRunlabelReport struct {
+	// Stream - output of the pull and of the executed command
+	Stream string `json:"stream,omitempty"`
+	// Error - error pulling the image or executing the command
+	Error string `json:"error,omitempty"`
+}
+
 type ContainerStatReport struct {
 	define.FileInfo
 }
@@ -28,6 +52,14 @@ type RestoreReport struct {
 	CRIUStatistics  *define.CRIUCheckpointRestoreStatistics `json:"criu_statistics"`
 }
 
+// ContainerRestoreBackupReport describes a container recreated from a backup.
+type ContainerRestoreBackupReport struct {
+	Id   string `json:"Id"`
+	Name string `json:"Name"`
+	// Volumes are the named volumes created from the backup.
+	Volumes []string `json:"Volumes,omitempty"`
+}
+
 // ContainerStatsReport is used for streaming container stats.
 type ContainerStatsReport struct {
 	// Error from reading stats.
@@ -36,6 +68,13 @@ type ContainerStatsReport struct {
 	Stats []define.ContainerStats
 }
 
+// ContainerStatsHistoryReport is the stats history of a container.
+type ContainerStatsHistoryReport struct {
+	Id      string
+	Name    string
+	Samples []define.ContainerStatsSample
+}
+
 type ContainerUpdateOptions struct {
 	NameOrID string
 	// This individual items of Specgen are used to update container configuration:
@@ -49,18 +88,25 @@ type ContainerUpdateOptions struct {
 	// To change configuration use other fields in ContainerUpdateOptions struct:
 	// - Resources to change resource configuration
 	// - DevicesLimits to Limit device
+	// - Devices to add and remove devices
 	// - RestartPolicy to change restart policy
 	// - RestartRetries to change restart retries
 	// - Env to change the environment variables.
 	// - UntsetEnv to unset the environment variables.
+	// - MemoryReclaim to reclaim memory from the running container.
+	// - NetworkOptions to change the bandwidth limits per network, the
+	//   empty network name changes them on all networks.
 	Specgen                         *specgen.SpecGenerator
 	Resources                       *specs.LinuxResources
 	DevicesLimits                   *define.UpdateContainerDevicesLimits
+	Devices                         *define.UpdateContainerDevices
 	ChangedHealthCheckConfiguration *define.UpdateHealthCheckConfig
 	RestartPolicy                   *string
 	RestartRetries                  *uint
 	Env                             []string
 	UnsetEnv                        []string
+	MemoryReclaim                   *int64
+	NetworkOptions                  map[string]map[string]string
 	Latest                          bool
 }
 
@@ -0,0 +1,18 @@
+package types
+
+// DeviceListReport describes a device that can be passed to a container with
+// --device.
+type DeviceListReport struct {
+	// Name is the fully qualified CDI device name (vendor.com/class=name)
+	// or the path of the device node on the host.
+	Name string
+	// Source is "cdi" for devices defined in a CDI specification and
+	// "host" for device nodes discovered on the host.
+	Source string
+	// Vendor of the device, e.g. nvidia.com.
+	Vendor string
+	// Class of the device, e.g. gpu.
+	Class string
+	// Spec is the path of the CDI specification defining the device.
+	Spec string `json:",omitempty"`
+}
@@ -9,6 +9,11 @@ type GenerateSystemdReport struct {
 	Units map[string]string
 }
 
+type GenerateQuadletReport struct {
+	// Units of the generate process. key = file name -> value = unit content
+	Units map[string]string
+}
+
 type GenerateKubeReport struct {
 	// FIXME: Podman4.0 should change io.Reader to io.ReaderCloser
 	// Reader - the io.Reader to reader the generated YAML file.
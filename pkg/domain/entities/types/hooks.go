@@ -0,0 +1,23 @@
+package types
+
+// HookListReport describes an OCI hook of the hooks directories.
+type HookListReport struct {
+	// Name of the hook file, hooks override the ones of the same name in
+	// earlier directories.
+	Name string
+	// Path of the hook file.
+	Path string
+	// Stages the hook runs at.
+	Stages []string
+	// When summarizes the conditions the hook is added to a container on.
+	When string
+	// Error is set when the hook is invalid and ignored.
+	Error string `json:",omitempty"`
+}
+
+// HookTestReport describes whether an OCI hook matches a container.
+type HookTestReport struct {
+	HookListReport
+	// Matched is true when the hook is added to the container.
+	Matched bool
+}
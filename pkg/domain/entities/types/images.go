@@ -1,9 +1,11 @@
 package types
 
 import (
+	"encoding/json"
 	"time"
 
 	"github.com/dmikushin/podman-shared/pkg/inspect"
+	"github.com/dmikushin/podman-shared/pkg/scan"
 	"github.com/dmikushin/podman-shared/pkg/trust"
 )
 
@@ -54,8 +56,44 @@ type ImageTreeReport struct {
 	Tree string // TODO: Refactor move presentation work out of server
 }
 
+// ImageSBOMReport is the software bill of materials of an image
+type ImageSBOMReport struct {
+	Format    string
+	MediaType string
+	SBOM      json.RawMessage
+	// Referrer is the artifact the SBOM was attached to the image with
+	Referrer string `json:",omitempty"`
+}
+
+// ImageScanReport is the normalized vulnerability report of an image
+type ImageScanReport = scan.Report
+
+// ImageOptimizeReport describes the image written by optimizing an image
+type ImageOptimizeReport struct {
+	// ID and Name of the optimized image
+	ID   string
+	Name string
+	// Number of layers of the image and of the optimized image
+	LayersBefore int
+	LayersAfter  int
+	// WhiteoutsRemoved is the number of whiteouts dropped because they
+	// did not hide anything
+	WhiteoutsRemoved int
+	// Uncompressed size of the layers of the image and of the optimized
+	// image
+	SizeBefore int64
+	SizeAfter  int64
+	// Compressed size of the layers of the image, as pulled, and of the
+	// optimized image compressed with zstd
+	CompressedSizeBefore int64
+	CompressedSizeAfter  int64
+}
+
 type ImageLoadReport struct {
 	Names []string
+	// Artifacts are the names of the artifacts referring to the images,
+	// loaded along with them.
+	Artifacts []string `json:",omitempty"`
 }
 
 type ImageImportReport struct {
@@ -94,6 +132,19 @@ type ImageMountReport struct {
 	Name         string
 	Repositories []string
 	Path         string
+	// Layers mounted separately with --all-layers, from the base layer up
+	Layers []ImageLayerMount `json:",omitempty"`
+}
+
+// ImageLayerMount describes a layer of an image mounted read-only with
+// image mount --all-layers.  The mount shows the root filesystem of the
+// image up to and including the layer.
+type ImageLayerMount struct {
+	Index  int
+	ID     string
+	DiffID string
+	Size   int64
+	Path   string
 }
 
 // ImageUnmountReport describes the response from umounting an image
@@ -145,6 +196,8 @@ type ImagePullReport struct {
 	Images []string `json:"images,omitempty"`
 	// ID contains image id (retained for backwards compatibility)
 	ID string `json:"id,omitempty"`
+	// Progress is a structured progress report, only sent on request
+	Progress *ProgressFrame `json:"progress,omitempty"`
 }
 
 type ImagePushStream struct {
@@ -154,4 +207,6 @@ type ImagePushStream struct {
 	Stream string `json:"stream,omitempty"`
 	// Error contains text of errors from pushing
 	Error string `json:"error,omitempty"`
+	// Progress is a structured progress report, only sent on request
+	Progress *ProgressFrame `json:"progress,omitempty"`
 }
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/dmikushin/podman-shared/libpod/define"
 	commonTypes "go.podman.io/common/libnetwork/types"
 )
 
@@ -41,6 +42,10 @@ type NetworkInspectReport struct {
 	Containers map[string]NetworkContainerInfo `json:"containers"`
 }
 
+// NetworkWireGuardPeer describes a host of a WireGuard network, as the
+// other hosts of the network see it.
+type NetworkWireGuardPeer = define.WireGuardPeer
+
 type NetworkContainerInfo struct {
 	// Name of the container
 	Name string `json:"name"`
@@ -40,7 +40,9 @@ type PlayKubeTeardown struct {
 	StopReport     []*PodStopReport
 	RmReport       []*PodRmReport
 	VolumeRmReport []*VolumeRmReport
-	SecretRmReport []*SecretRmReport
+	// ConfigMapRmReport - volumes created for ConfigMap and Secret volumes
+	ConfigMapRmReport []*VolumeRmReport `json:",omitempty"`
+	SecretRmReport    []*SecretRmReport
 }
 
 type PlaySecret struct {
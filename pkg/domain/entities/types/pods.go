@@ -5,6 +5,7 @@ import (
 
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/specgen"
+	"go.podman.io/common/libnetwork/types"
 )
 
 type PodPruneReport struct {
@@ -52,6 +53,22 @@ type PodCloneReport struct {
 	Id string
 }
 
+// PodUpdateOptions describes the changes to make to an existing pod.
+type PodUpdateOptions struct {
+	NameOrID string
+	// PublishAdd are the ports to publish in addition to the current ones.
+	PublishAdd []types.PortMapping
+	// PublishRemove are the published ports to remove.  They match on the
+	// container port and protocol and, when set, on the host port and IP.
+	PublishRemove []types.PortMapping
+}
+
+type PodUpdateReport struct {
+	Id string
+	// Ports are the ports published by the pod after the update.
+	Ports []types.PortMapping
+}
+
 // PodStatsReport includes pod-resource statistics data.
 type PodStatsReport struct {
 	// Percentage of CPU utilized by pod
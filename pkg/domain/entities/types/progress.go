@@ -0,0 +1,48 @@
+package types
+
+const (
+	// ProgressStatusStarted is reported when processing of an item begins.
+	ProgressStatusStarted = "started"
+	// ProgressStatusProgress is reported periodically while an item is processed.
+	ProgressStatusProgress = "progress"
+	// ProgressStatusSkipped is reported when an item did not need processing,
+	// e.g. a blob which already exists at the destination.
+	ProgressStatusSkipped = "skipped"
+	// ProgressStatusDone is reported when processing of an item has finished.
+	ProgressStatusDone = "done"
+)
+
+// ProgressFrame is a machine-readable progress report of a long running
+// operation such as pulling, pushing or building an image.  All frames of the
+// same item, e.g. an image layer or a build stage, carry the same ID.
+type ProgressFrame struct {
+	// ID identifies the item and is stable for the whole operation.
+	ID string `json:"id"`
+	// Status is one of started, progress, skipped or done.
+	Status string `json:"status"`
+	// Current is the amount of work done, bytes for transfers and steps for
+	// builds.
+	Current int64 `json:"current,omitempty"`
+	// Total is the total amount of work, zero if unknown.
+	Total int64 `json:"total,omitempty"`
+	// Percent is the completion in percent, -1 if the total is unknown.
+	Percent int `json:"percent"`
+}
+
+// NewProgressFrame returns a progress frame and computes its percentage.
+func NewProgressFrame(id, status string, current, total int64) *ProgressFrame {
+	frame := &ProgressFrame{
+		ID:      id,
+		Status:  status,
+		Current: current,
+		Total:   total,
+		Percent: -1,
+	}
+	switch {
+	case status == ProgressStatusDone || status == ProgressStatusSkipped:
+		frame.Percent = 100
+	case total > 0:
+		frame.Percent = int(min(current*100/total, 100))
+	}
+	return frame
+}
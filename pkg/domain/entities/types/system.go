@@ -5,17 +5,24 @@ import (
 
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities/reports"
+	"github.com/dmikushin/podman-shared/pkg/subid"
 )
 
 // ServiceOptions provides the input for starting an API and sidecar pprof services
 type ServiceOptions struct {
-	CorsHeaders     string        // Cross-Origin Resource Sharing (CORS) headers
-	PProfAddr       string        // Network address to bind pprof profiles service
-	Timeout         time.Duration // Duration of inactivity the service should wait before shutting down
-	URI             string        // Path to unix domain socket service should listen on
-	TLSCertFile     string        // Path to serving certificate PEM file
-	TLSKeyFile      string        // Path to serving certificate key PEM file
-	TLSClientCAFile string        // Path to client certificate authority
+	CorsHeaders                   []string      // Origins allowed to make Cross-Origin Resource Sharing (CORS) requests
+	DBProfile                     bool          // Record the time spent running database queries
+	PProfAddr                     string        // Network address to bind pprof profiles service
+	RegistryCacheAddr             string        // Network address to serve the registry cache on, no cache if empty
+	RegistryCacheUpstream         string        // Registry of the repositories whose name does not start with a registry
+	RegistryCacheAllowedUpstreams []string      // Other registries the registry cache fetches repositories from
+	RegistryCacheMaxSize          int64         // Size the registry cache is kept under, 0 for no limit
+	RegistryCacheTagTTL           time.Duration // Duration a tag is served from the registry cache before checking it again
+	Timeout                       time.Duration // Duration of inactivity the service should wait before shutting down
+	URI                           string        // Path to unix domain socket service should listen on
+	TLSCertFile                   string        // Path to serving certificate PEM file
+	TLSKeyFile                    string        // Path to serving certificate key PEM file
+	TLSClientCAFile               string        // Path to client certificate authority
 }
 
 // SystemCheckOptions provides options for checking storage consistency.
@@ -23,21 +30,23 @@ type SystemCheckOptions struct {
 	Quick                       bool           // skip the most time-intensive checks
 	Repair                      bool           // remove damaged images
 	RepairLossy                 bool           // remove damaged containers
+	RepairContainers            bool           // recreate missing container storage and database entries
 	UnreferencedLayerMaximumAge *time.Duration // maximum allowed age for unreferenced layers
 }
 
 // SystemCheckReport provides a report of what a storage consistency check
 // found, and if we removed anything that was damaged, what we removed.
 type SystemCheckReport struct {
-	Errors            bool                // any errors were detected
-	Layers            map[string][]string // layer ID → what was detected
-	ROLayers          map[string][]string // layer ID → what was detected
-	RemovedLayers     []string            // layer ID
-	Images            map[string][]string // image ID → what was detected
-	ROImages          map[string][]string // image ID → what was detected
-	RemovedImages     map[string][]string // image ID → names
-	Containers        map[string][]string // container ID → what was detected
-	RemovedContainers map[string]string   // container ID → name
+	Errors             bool                // any errors were detected
+	Layers             map[string][]string // layer ID → what was detected
+	ROLayers           map[string][]string // layer ID → what was detected
+	RemovedLayers      []string            // layer ID
+	Images             map[string][]string // image ID → what was detected
+	ROImages           map[string][]string // image ID → what was detected
+	RemovedImages      map[string][]string // image ID → names
+	Containers         map[string][]string // container ID → what was detected
+	RemovedContainers  map[string]string   // container ID → name
+	RepairedContainers map[string][]string // container ID → what was repaired
 }
 
 // SystemPruneOptions provides options to prune system.
@@ -47,6 +56,7 @@ type SystemPruneOptions struct {
 	Filters  map[string][]string `json:"filters" schema:"filters"`
 	External bool
 	Build    bool
+	DryRun   bool
 }
 
 // SystemPruneReport provides report after system prune is executed.
@@ -63,6 +73,16 @@ type SystemPruneReport struct {
 // cli to migrate runtimes of containers
 type SystemMigrateOptions struct {
 	NewRuntime string
+	DBBackend  string
+}
+
+// SystemMigrateReport describes the database conversion of a system migrate
+type SystemMigrateReport struct {
+	// DBBackend is the backend of the database the containers were
+	// migrated from
+	DBBackend string
+	// DBBackup is the path the database was moved to
+	DBBackup string
 }
 
 // SystemDfOptions describes the options for getting df information
@@ -123,6 +143,30 @@ type SystemVersionReport struct {
 // SystemUnshareOptions describes the options for the unshare command
 type SystemUnshareOptions struct {
 	RootlessNetNS bool
+	// Container is the container whose user, mount and network
+	// namespaces are joined.
+	Container string
+	// KeepNet keeps the network namespace when joining the namespaces of
+	// Container.
+	KeepNet bool
+}
+
+// SystemSubIDStatusReport describes the subordinate ID pools used by
+// --userns=auto
+type SystemSubIDStatusReport = define.SubIDStatus
+
+// SystemSubIDExpandOptions describes the options for growing the subordinate
+// ID pools of a user
+type SystemSubIDExpandOptions struct {
+	User string // user to add IDs to, the user of --userns=auto if empty
+	Size int    // number of UIDs and GIDs to add
+}
+
+// SystemSubIDExpandReport lists the subordinate ID ranges added to a user
+type SystemSubIDExpandReport struct {
+	User string      `json:"user"`
+	UIDs subid.Range `json:"uids"`
+	GIDs subid.Range `json:"gids"`
 }
 
 // ListRegistriesReport is the report when querying for a sorted list of
@@ -142,4 +186,38 @@ type AuthReport struct {
 type LocksReport struct {
 	LockConflicts map[uint32][]string
 	LocksHeld     []uint32
+	// LockType is the lock backend, "shm" or "file".
+	LockType string
+	// NumLocks is the number of locks of the backend, 0 if it has no
+	// limit.
+	NumLocks uint32
+	// LocksFree is the number of locks which are not allocated, 0 if
+	// the backend has no limit.
+	LocksFree uint32
+	// Allocations are the locks allocated to all containers, pods and
+	// volumes.
+	Allocations []define.LockAllocation
+}
+
+// SystemRenumberOptions are the options of system renumber.
+type SystemRenumberOptions struct {
+	// DryRun previews the renumbering without changing the locks.
+	DryRun bool
+}
+
+// SystemSharedLayerCachePurgeReport describes the shared base layers removed
+// from the local cache.
+type SystemSharedLayerCachePurgeReport struct {
+	Layers []string
+	// Size is the size freed in bytes.
+	Size int64
+}
+
+// SystemRenumberReport describes the locks before and after a renumbering,
+// only returned by a dry run.
+type SystemRenumberReport struct {
+	// NumLocks is the number of locks of the backend, 0 if it has no
+	// limit.
+	NumLocks uint32
+	Locks    []define.LockRenumbering
 }
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	buildahDefine "github.com/containers/buildah/define"
+	nettypes "go.podman.io/common/libnetwork/types"
 )
 
 // ComponentVersion describes the version information for a specific component.
@@ -47,6 +48,9 @@ type ContainerCreateResponse struct {
 	// Warnings during container creation
 	// required: true
 	Warnings []string `json:"Warnings"`
+	// PublishedPorts are the port mappings of the container, including
+	// the host ports that were allocated dynamically
+	PublishedPorts []nettypes.PortMapping `json:"PublishedPorts,omitempty"`
 }
 
 // FarmBuildOptions describes the options for building container images on farm nodes
@@ -68,6 +72,12 @@ type BuildOptions struct {
 	// so need to pass this to the main build functions
 	LogFileToClose *os.File
 	TmpDirToClose  string
+	// ArchiveToStdout is the OCI archive written by the build which is
+	// copied to stdout and removed afterwards
+	ArchiveToStdout string
+	// SharedCache is the directory of the shared build cache, "auto" or
+	// "none"
+	SharedCache string
 }
 
 // BuildReport is the image-build report.
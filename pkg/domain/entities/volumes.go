@@ -35,6 +35,14 @@ type VolumeListOptions struct {
 
 type VolumeListReport = types.VolumeListReport
 
+// VolumeReloadOptions describes the options to reload volume plugins
+type VolumeReloadOptions struct {
+	// All reloads all configured volume plugins, like an empty Plugins.
+	All bool
+	// Plugins are the names of the volume plugins to reload.
+	Plugins []string
+}
+
 // VolumeReloadReport describes the response from reload volume plugins
 type VolumeReloadReport = types.VolumeReloadReport
 
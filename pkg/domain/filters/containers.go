@@ -3,6 +3,7 @@
 package filters
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"slices"
@@ -13,6 +14,7 @@ import (
 	"github.com/dmikushin/podman-shared/libpod"
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities/types"
+	"go.podman.io/common/libimage"
 	"go.podman.io/common/pkg/filters"
 	"go.podman.io/common/pkg/util"
 	"go.podman.io/storage"
@@ -61,6 +63,32 @@ func GenerateContainerFilterFuncs(filter string, filterValues []string, r *libpo
 			}
 			return false
 		}, nil
+	case "exited-range":
+		ranges, err := parseExitedRanges(filterValues)
+		if err != nil {
+			return nil, err
+		}
+		return func(c *libpod.Container) bool {
+			ec, exited, err := c.ExitCode()
+			return err == nil && exited && exitCodeInRanges(ec, ranges)
+		}, nil
+	case "created-since":
+		since, err := computeCreatedSince(filterValues)
+		if err != nil {
+			return nil, err
+		}
+		return func(c *libpod.Container) bool {
+			return c.CreatedTime().After(since)
+		}, nil
+	case "image-digest":
+		imageIDs, err := imageIDsWithDigests(filterValues, r)
+		if err != nil {
+			return nil, err
+		}
+		return func(c *libpod.Container) bool {
+			imageID, _ := c.Image()
+			return slices.Contains(imageIDs, imageID)
+		}, nil
 	case "status":
 		for _, filterValue := range filterValues {
 			if _, err := define.StringToContainerStatus(filterValue); err != nil {
@@ -469,6 +497,30 @@ func GenerateExternalContainerFilterFuncs(filter string, filterValues []string,
 			}
 			return false
 		}, nil
+	case "exited-range":
+		ranges, err := parseExitedRanges(filterValues)
+		if err != nil {
+			return nil, err
+		}
+		return func(listContainer *types.ListContainer) bool {
+			return listContainer.Exited && exitCodeInRanges(listContainer.ExitCode, ranges)
+		}, nil
+	case "created-since":
+		since, err := computeCreatedSince(filterValues)
+		if err != nil {
+			return nil, err
+		}
+		return func(listContainer *types.ListContainer) bool {
+			return listContainer.Created.After(since)
+		}, nil
+	case "image-digest":
+		imageIDs, err := imageIDsWithDigests(filterValues, r)
+		if err != nil {
+			return nil, err
+		}
+		return func(listContainer *types.ListContainer) bool {
+			return slices.Contains(imageIDs, listContainer.ImageID)
+		}, nil
 	case "label":
 		return func(listContainer *types.ListContainer) bool {
 			return !filters.MatchLabelFilters(filterValues, listContainer.Labels)
@@ -529,3 +581,73 @@ func GenerateExternalContainerFilterFuncs(filter string, filterValues []string,
 
 	return nil, fmt.Errorf("%s is an invalid filter", filter)
 }
+
+// parseExitedRanges parses the MIN-MAX exit code ranges of the exited-range
+// filter.
+func parseExitedRanges(filterValues []string) ([][2]int32, error) {
+	ranges := make([][2]int32, 0, len(filterValues))
+	for _, filterValue := range filterValues {
+		minCode, maxCode, ok := strings.Cut(filterValue, "-")
+		if !ok {
+			return nil, fmt.Errorf("invalid exited-range %q: must be MIN-MAX", filterValue)
+		}
+		low, err := strconv.ParseInt(minCode, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exited-range %q: %w", filterValue, err)
+		}
+		high, err := strconv.ParseInt(maxCode, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exited-range %q: %w", filterValue, err)
+		}
+		if low > high {
+			return nil, fmt.Errorf("invalid exited-range %q: MIN must not be greater than MAX", filterValue)
+		}
+		ranges = append(ranges, [2]int32{int32(low), int32(high)})
+	}
+	return ranges, nil
+}
+
+// exitCodeInRanges returns true if the exit code is in one of the ranges.
+func exitCodeInRanges(exitCode int32, ranges [][2]int32) bool {
+	for _, r := range ranges {
+		if exitCode >= r[0] && exitCode <= r[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// computeCreatedSince returns the earliest time of the timestamps or
+// durations of the created-since filter.
+func computeCreatedSince(filterValues []string) (time.Time, error) {
+	var since time.Time
+	for _, filterValue := range filterValues {
+		ts, err := filters.ComputeUntilTimestamp([]string{filterValue})
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid created-since %q: %w", filterValue, err)
+		}
+		if since.IsZero() || ts.Before(since) {
+			since = ts
+		}
+	}
+	return since, nil
+}
+
+// imageIDsWithDigests returns the IDs of the images with the digests of the
+// image-digest filter.
+func imageIDsWithDigests(filterValues []string, r *libpod.Runtime) ([]string, error) {
+	var imageIDs []string
+	for _, filterValue := range filterValues {
+		if !strings.HasPrefix(filterValue, "sha256:") {
+			filterValue = "sha256:" + filterValue
+		}
+		images, err := r.LibimageRuntime().ListImages(context.Background(), &libimage.ListImagesOptions{Filters: []string{"digest=" + filterValue}})
+		if err != nil {
+			return nil, err
+		}
+		for _, img := range images {
+			imageIDs = append(imageIDs, img.ID())
+		}
+	}
+	return imageIDs, nil
+}
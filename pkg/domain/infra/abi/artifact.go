@@ -222,6 +222,7 @@ func (ir *ImageEngine) ArtifactAdd(ctx context.Context, name string, artifactBlo
 		Append:           opts.Append,
 		FileMIMEType:     opts.FileMIMEType,
 		Replace:          opts.Replace,
+		Subject:          opts.Subject,
 	}
 
 	artifactDigest, err := artStore.Add(ctx, name, artifactBlobs, &addOptions)
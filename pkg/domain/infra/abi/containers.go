@@ -179,6 +179,20 @@ func (ic *ContainerEngine) ContainerExists(_ context.Context, nameOrID string, o
 	return &entities.BoolReport{Value: err == nil}, nil
 }
 
+// ContainersExist returns whether each of the containers exists in container
+// storage, keyed by the given name or ID.
+func (ic *ContainerEngine) ContainersExist(ctx context.Context, namesOrIds []string, options entities.ContainerExistsOptions) (map[string]bool, error) {
+	reports := make(map[string]bool, len(namesOrIds))
+	for _, nameOrID := range namesOrIds {
+		report, err := ic.ContainerExists(ctx, nameOrID, options)
+		if err != nil {
+			return nil, err
+		}
+		reports[nameOrID] = report.Value
+	}
+	return reports, nil
+}
+
 func (ic *ContainerEngine) ContainerWait(ctx context.Context, namesOrIds []string, options entities.WaitOptions) ([]entities.WaitReport, error) {
 	responses := make([]entities.WaitReport, 0, len(namesOrIds))
 	containers, err := getContainers(ic.Libpod, getContainersOptions{latest: options.Latest, ignore: options.Ignore, names: namesOrIds})
@@ -186,6 +200,11 @@ func (ic *ContainerEngine) ContainerWait(ctx context.Context, namesOrIds []strin
 		return nil, err
 	}
 
+	if options.ConditionChan != nil {
+		go waitConditions(ctx, containers, options)
+		return nil, nil
+	}
+
 	if options.ExitFirstMatch {
 		response := waitExitOnFirst(ctx, containers, options)
 		responses = append(responses, response)
@@ -253,6 +272,42 @@ func waitExitOnFirst(ctx context.Context, containers []containerWrapper, options
 	return response
 }
 
+// waitConditions waits for every condition of every container in parallel and
+// sends a report to the condition channel as soon as one is met.
+func waitConditions(ctx context.Context, containers []containerWrapper, options entities.WaitOptions) {
+	defer close(options.ConditionChan)
+
+	conditions := options.Conditions
+	if len(conditions) == 0 {
+		conditions = []string{define.ContainerStateExited.String()}
+	}
+
+	var wg sync.WaitGroup
+	for _, c := range containers {
+		if c.doesNotExist { // Only set when `options.Ignore == true`
+			continue
+		}
+		id, name := c.ID(), c.Name()
+		for _, condition := range conditions {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				report := entities.WaitConditionReport{ID: id, Name: name, Condition: condition}
+				exitCode, err := c.WaitForConditionWithInterval(ctx, options.Interval, condition)
+				if err != nil {
+					report.Error = err.Error()
+				}
+				report.ExitCode = exitCode
+				select {
+				case options.ConditionChan <- report:
+				case <-ctx.Done():
+				}
+			}()
+		}
+	}
+	wg.Wait()
+}
+
 func (ic *ContainerEngine) ContainerPause(_ context.Context, namesOrIds []string, options entities.PauseUnPauseOptions) ([]*entities.PauseUnpauseReport, error) {
 	containers, err := getContainers(ic.Libpod, getContainersOptions{all: options.All, latest: options.Latest, names: namesOrIds, filters: options.Filters})
 	if err != nil {
@@ -260,7 +315,7 @@ func (ic *ContainerEngine) ContainerPause(_ context.Context, namesOrIds []string
 	}
 	reports := make([]*entities.PauseUnpauseReport, 0, len(containers))
 	for _, c := range containers {
-		err := c.Pause()
+		err := c.Freeze(time.Duration(options.FreezeTimeout) * time.Second)
 		if err != nil && options.All && errors.Is(err, define.ErrCtrStateInvalid) {
 			logrus.Debugf("Container %s is not running", c.ID())
 			continue
@@ -317,7 +372,7 @@ func (ic *ContainerEngine) ContainerStop(ctx context.Context, namesOrIds []strin
 		libpodContainers = append(libpodContainers, containers[i].Container)
 	}
 
-	errMap, err := parallelctr.ContainerOp(ctx, libpodContainers, func(c *libpod.Container) error {
+	errMap, err := libpod.ContainerOpOrdered(ctx, libpodContainers, options.Jobs, true, func(c *libpod.Container) error {
 		var err error
 		if options.Timeout != nil {
 			err = c.StopWithTimeout(*options.Timeout)
@@ -684,12 +739,19 @@ func (ic *ContainerEngine) ContainerCommit(ctx context.Context, nameOrID string,
 	return &entities.CommitReport{Id: newImage.ID()}, nil
 }
 
-func (ic *ContainerEngine) ContainerExport(_ context.Context, nameOrID string, options entities.ContainerExportOptions) error {
+func (ic *ContainerEngine) ContainerExport(ctx context.Context, nameOrID string, options entities.ContainerExportOptions) error {
 	ctr, err := ic.Libpod.LookupContainer(nameOrID)
 	if err != nil {
 		return err
 	}
-	return ctr.Export(options.Output)
+	switch options.Format {
+	case "", entities.ExportFormatTar:
+		return ctr.Export(options.Output)
+	case entities.ExportFormatOCIArchive:
+		return ctr.ExportImage(ctx, options.Output, libpod.ContainerCommitOptions{})
+	default:
+		return fmt.Errorf("unsupported export format %q", options.Format)
+	}
 }
 
 func (ic *ContainerEngine) ContainerCheckpoint(ctx context.Context, namesOrIds []string, options entities.CheckpointOptions) ([]*entities.CheckpointReport, error) {
@@ -851,7 +913,11 @@ func (ic *ContainerEngine) ContainerCreate(ctx context.Context, s *specgen.SpecG
 	if err != nil {
 		return nil, err
 	}
-	return &entities.ContainerCreateReport{Id: ctr.ID()}, nil
+	ports, err := ctr.PortMappings()
+	if err != nil {
+		return nil, err
+	}
+	return &entities.ContainerCreateReport{Id: ctr.ID(), PublishedPorts: ports}, nil
 }
 
 func (ic *ContainerEngine) ContainerAttach(ctx context.Context, nameOrID string, options entities.AttachOptions) error {
@@ -999,16 +1065,16 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 		return nil, err
 	}
 	// There can only be one container if attach was used
-	for i := range containers {
-		ctr := containers[i]
+	if options.Attach {
+		for i := range containers {
+			ctr := containers[i]
 
-		removeContainer := func() {
-			if _, _, err := ic.removeContainer(ctx, ctr.Container, entities.RmOptions{}); err != nil {
-				logrus.Errorf("Removing container %s: %v", ctr.ID(), err)
+			removeContainer := func() {
+				if _, _, err := ic.removeContainer(ctx, ctr.Container, entities.RmOptions{}); err != nil {
+					logrus.Errorf("Removing container %s: %v", ctr.ID(), err)
+				}
 			}
-		}
 
-		if options.Attach {
 			err = terminal.StartAttachCtr(ctx, ctr.Container, options.Stdout, options.Stderr, options.Stdin, options.DetachKeys, options.SigProxy, true)
 			if errors.Is(err, define.ErrDetach) {
 				// User manually detached
@@ -1060,42 +1126,68 @@ func (ic *ContainerEngine) ContainerStart(ctx context.Context, namesOrIds []stri
 				ExitCode: exitCode,
 			})
 			return reports, nil
-		} // end attach
+		}
+	}
 
-		// Handle non-attach start
+	// Handle non-attach start, the containers are started in the order of
+	// their dependencies.
+	ctrs := make([]*libpod.Container, 0, len(containers))
+	for _, ctr := range containers {
+		ctrs = append(ctrs, ctr.Container)
+	}
+	var (
+		runningLock sync.Mutex
+		running     = make(map[string]bool)
+	)
+	batchCtx := libpod.WithStartBatch(ctx)
+	errMap, err := libpod.ContainerOpOrdered(ctx, ctrs, options.Jobs, false, func(c *libpod.Container) error {
 		// If the container is in a pod, also set to recursively start dependencies
+		err := c.Start(batchCtx, true)
+		// Already running is no error for the start command as it is idempotent.
+		if errors.Is(err, define.ErrCtrStateRunning) {
+			runningLock.Lock()
+			running[c.ID()] = true
+			runningLock.Unlock()
+			return nil
+		}
+		if err != nil && !errors.Is(err, define.ErrWillDeadlock) && c.AutoRemove() {
+			if _, _, err := ic.removeContainer(ctx, c, entities.RmOptions{}); err != nil {
+				logrus.Errorf("Removing container %s: %v", c.ID(), err)
+			}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	ctrErrs := make(map[string]error, len(errMap))
+	for c, err := range errMap {
+		ctrErrs[c.ID()] = err
+	}
+
+	for _, ctr := range containers {
 		report := &entities.ContainerStartReport{
 			Id:       ctr.ID(),
 			RawInput: ctr.rawInput,
 			ExitCode: 125,
 		}
-		if err := ctr.Start(ctx, true); err != nil {
-			// Already running is no error for the start command as it is idempotent.
-			if errors.Is(err, define.ErrCtrStateRunning) {
-				// If all is set we only want to output the actual started containers
-				// so do not include the entry in the result.
-				if !options.All {
-					report.ExitCode = 0
-					reports = append(reports, report)
-				}
-				continue
-			}
-
-			report.Err = err
-			if errors.Is(err, define.ErrWillDeadlock) {
-				report.Err = fmt.Errorf("please run 'podman system renumber' to resolve deadlocks: %w", err)
-				reports = append(reports, report)
+		err := ctrErrs[ctr.ID()]
+		switch {
+		case err == nil && running[ctr.ID()]:
+			// If all is set we only want to output the actual started containers
+			// so do not include the entry in the result.
+			if options.All {
 				continue
 			}
+			report.ExitCode = 0
+		case err == nil:
+			// no error set exit code to 0
+			report.ExitCode = 0
+		case errors.Is(err, define.ErrWillDeadlock):
+			report.Err = fmt.Errorf("please run 'podman system renumber' to resolve deadlocks: %w", err)
+		default:
 			report.Err = fmt.Errorf("unable to start container %q: %w", ctr.ID(), err)
-			if ctr.AutoRemove() {
-				removeContainer()
-			}
-			reports = append(reports, report)
-			continue
 		}
-		// no error set exit code to 0
-		report.ExitCode = 0
 		reports = append(reports, report)
 	}
 	return reports, nil
@@ -1715,6 +1807,72 @@ func (ic *ContainerEngine) ContainerStats(ctx context.Context, namesOrIds []stri
 	return statsChan, nil
 }
 
+// ContainerStatsHistory returns the samples of the stats history of the
+// containers.  Without names, the stats history of all containers is returned.
+func (ic *ContainerEngine) ContainerStatsHistory(_ context.Context, namesOrIds []string, options entities.ContainerStatsHistoryOptions) ([]*entities.ContainerStatsHistoryReport, error) {
+	var since, until time.Time
+	if options.Since != "" {
+		t, err := util.ParseInputTime(options.Since, true)
+		if err != nil {
+			return nil, err
+		}
+		since = t
+	}
+	if options.Until != "" {
+		t, err := util.ParseInputTime(options.Until, false)
+		if err != nil {
+			return nil, err
+		}
+		until = t
+	}
+
+	ctrs, err := getContainers(ic.Libpod, getContainersOptions{
+		all:    options.All || (len(namesOrIds) == 0 && !options.Latest),
+		latest: options.Latest,
+		names:  namesOrIds,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*entities.ContainerStatsHistoryReport, 0, len(ctrs))
+	for _, ctr := range ctrs {
+		samples, err := ctr.StatsHistory(since, until)
+		if err != nil {
+			if len(namesOrIds) == 0 && (errors.Is(err, define.ErrCtrRemoved) || errors.Is(err, define.ErrNoSuchCtr)) {
+				continue
+			}
+			return nil, err
+		}
+		reports = append(reports, &entities.ContainerStatsHistoryReport{
+			Id:      ctr.ID(),
+			Name:    ctr.Name(),
+			Samples: samples,
+		})
+	}
+	return reports, nil
+}
+
+// ContainerStatsRecord records a sample of the resource usage of the container
+// in its stats history.
+func (ic *ContainerEngine) ContainerStatsRecord(_ context.Context, nameOrID string) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ctr.RecordStats()
+}
+
+// ContainerThawExpired unpauses the container if its freeze timeout
+// expired.
+func (ic *ContainerEngine) ContainerThawExpired(_ context.Context, nameOrID string) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+	return ctr.ThawExpired()
+}
+
 // ContainerRename renames the given container.
 func (ic *ContainerEngine) ContainerRename(ctx context.Context, nameOrID string, opts entities.ContainerRenameOptions) error {
 	ctr, err := ic.Libpod.LookupContainer(nameOrID)
@@ -0,0 +1,293 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/specgen"
+	generateUtils "github.com/dmikushin/podman-shared/pkg/specgen/generate"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/storage/pkg/archive"
+)
+
+// A backup of a container is a tar archive with the files below.  The
+// metadata and the spec are read first on restore.
+const (
+	// backupMetadataFile is the backupMetadata of the backup.
+	backupMetadataFile = "backup.json"
+	// backupSpecFile is the portable spec of the container.
+	backupSpecFile = "spec.json"
+	// backupLayerFile is the tar archive of the changes of the writable
+	// layer of the container.
+	backupLayerFile = "layer.tar"
+	// backupVolumesDir holds a tar archive of the content of each named
+	// volume, named after the volume.
+	backupVolumesDir = "volumes"
+
+	// backupVersion is the version of the backup format.
+	backupVersion = 1
+)
+
+// backupMetadata describes a backup of a container.
+type backupMetadata struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	ID      string    `json:"id"`
+	Name    string    `json:"name"`
+	Image   string    `json:"image"`
+	// Volumes are the named volumes used by the container.
+	Volumes []backupVolume `json:"volumes,omitempty"`
+}
+
+// backupVolume is the configuration of a named volume in a backup.
+type backupVolume struct {
+	Name    string            `json:"name"`
+	Driver  string            `json:"driver"`
+	Labels  map[string]string `json:"labels,omitempty"`
+	Options map[string]string `json:"options,omitempty"`
+}
+
+func writeBackupJSON(dir, name string, v any) error {
+	data, err := json.MarshalIndent(v, "", " ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name), data, 0o600)
+}
+
+func readBackupJSON(dir, name string, v any) error {
+	data, err := os.ReadFile(filepath.Join(dir, name))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("invalid container backup, %s is missing", name)
+		}
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("invalid container backup, decoding %s: %w", name, err)
+	}
+	return nil
+}
+
+// writeBackupFile writes the file of the backup with the content written by
+// the function.
+func writeBackupFile(path string, write func(io.Writer) error) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ContainerBackup writes a tar archive with the portable configuration of the
+// container, the changes of its writable layer and the content of its named
+// volumes.  Bind mounts, devices and other host specific settings are not
+// part of the backup.
+func (ic *ContainerEngine) ContainerBackup(_ context.Context, nameOrID string, options entities.ContainerBackupOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return err
+	}
+
+	s := &specgen.SpecGenerator{}
+	if _, _, err := generateUtils.ConfigToSpec(ic.Libpod, s, ctr.ID()); err != nil {
+		return err
+	}
+	stripHostSpecificSpec(s, ctr.ID(), ctr.RawImageName())
+
+	dir, err := os.MkdirTemp("", "podman-backup")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	metadata := backupMetadata{
+		Version: backupVersion,
+		Created: time.Now(),
+		ID:      ctr.ID(),
+		Name:    ctr.Name(),
+		Image:   s.Image,
+	}
+	if err := os.Mkdir(filepath.Join(dir, backupVolumesDir), 0o700); err != nil {
+		return err
+	}
+	for _, namedVolume := range s.Volumes {
+		if options.IgnoreVolumes {
+			break
+		}
+		vol, err := ic.Libpod.LookupVolume(namedVolume.Name)
+		if err != nil {
+			return err
+		}
+		metadata.Volumes = append(metadata.Volumes, backupVolume{
+			Name:    vol.Name(),
+			Driver:  vol.Driver(),
+			Labels:  vol.Labels(),
+			Options: vol.Options(),
+		})
+		if err := writeBackupFile(filepath.Join(dir, backupVolumesDir, vol.Name()+".tar"), func(w io.Writer) error {
+			contents, err := vol.Export()
+			if err != nil {
+				return err
+			}
+			defer contents.Close()
+			if _, err := io.Copy(w, contents); err != nil {
+				return fmt.Errorf("writing volume %s contents: %w", vol.Name(), err)
+			}
+			return nil
+		}); err != nil {
+			return err
+		}
+	}
+
+	if err := writeBackupFile(filepath.Join(dir, backupLayerFile), ctr.ExportLayer); err != nil {
+		return err
+	}
+	if err := writeBackupJSON(dir, backupSpecFile, s); err != nil {
+		return err
+	}
+	if err := writeBackupJSON(dir, backupMetadataFile, metadata); err != nil {
+		return err
+	}
+
+	input, err := archive.TarWithOptions(dir, &archive.TarOptions{
+		Compression: archive.Uncompressed,
+		IncludeFiles: []string{
+			backupMetadataFile,
+			backupSpecFile,
+			backupVolumesDir,
+			backupLayerFile,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("creating backup of container %s: %w", ctr.ID(), err)
+	}
+	defer input.Close()
+	if _, err := io.Copy(options.Output, input); err != nil {
+		return fmt.Errorf("writing backup of container %s: %w", ctr.ID(), err)
+	}
+	return nil
+}
+
+// ContainerRestoreBackup recreates a container, with the changes of its
+// writable layer and its named volumes, from a backup written by
+// ContainerBackup.  The image of the container is pulled if it is missing.
+func (ic *ContainerEngine) ContainerRestoreBackup(ctx context.Context, options entities.ContainerRestoreBackupOptions) (_ *entities.ContainerRestoreBackupReport, retErr error) {
+	dir, err := os.MkdirTemp("", "podman-restore-backup")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	if err := archive.Untar(options.Input, dir, nil); err != nil {
+		return nil, fmt.Errorf("unpacking container backup: %w", err)
+	}
+	var metadata backupMetadata
+	if err := readBackupJSON(dir, backupMetadataFile, &metadata); err != nil {
+		return nil, err
+	}
+	if metadata.Version != backupVersion {
+		return nil, fmt.Errorf("unsupported container backup version %d", metadata.Version)
+	}
+	s := &specgen.SpecGenerator{}
+	if err := readBackupJSON(dir, backupSpecFile, s); err != nil {
+		return nil, err
+	}
+	if options.Name != "" {
+		s.Name = options.Name
+	}
+	if ctr, err := ic.Libpod.LookupContainer(s.Name); err == nil && ctr.Name() == s.Name {
+		return nil, fmt.Errorf("container %s of the backup already exists, remove it or use --name: %w", s.Name, define.ErrCtrExists)
+	}
+
+	report := &entities.ContainerRestoreBackupReport{}
+	if !options.IgnoreVolumes {
+		for _, volume := range metadata.Volumes {
+			if _, err := ic.Libpod.LookupVolume(volume.Name); err == nil {
+				return nil, fmt.Errorf("volume %s of the backup already exists, remove it or use --ignore-volumes: %w", volume.Name, define.ErrVolumeExists)
+			}
+		}
+		defer func() {
+			if retErr == nil {
+				return
+			}
+			for _, name := range report.Volumes {
+				vol, err := ic.Libpod.LookupVolume(name)
+				if err == nil {
+					err = ic.Libpod.RemoveVolume(ctx, vol, true, nil)
+				}
+				if err != nil {
+					logrus.Errorf("Removing volume %s after failed restore: %v", name, err)
+				}
+			}
+		}()
+		for _, volume := range metadata.Volumes {
+			if _, err := ic.VolumeCreate(ctx, entities.VolumeCreateOptions{
+				Name:    volume.Name,
+				Driver:  volume.Driver,
+				Label:   maps.Clone(volume.Labels),
+				Options: maps.Clone(volume.Options),
+			}); err != nil {
+				return nil, err
+			}
+			report.Volumes = append(report.Volumes, volume.Name)
+			f, err := os.Open(filepath.Join(dir, backupVolumesDir, volume.Name+".tar"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid container backup: %w", err)
+			}
+			err = ic.VolumeImport(ctx, volume.Name, entities.VolumeImportOptions{Input: f})
+			f.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if _, err := ic.Libpod.LibimageRuntime().Pull(ctx, s.Image, config.PullPolicyMissing, nil); err != nil {
+		return nil, fmt.Errorf("pulling image %s of the container backup: %w", s.Image, err)
+	}
+	createReport, err := ic.ContainerCreate(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+	ctr, err := ic.Libpod.LookupContainer(createReport.Id)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if retErr != nil {
+			if err := ic.Libpod.RemoveContainer(ctx, ctr, true, false, nil); err != nil {
+				logrus.Errorf("Removing container %s after failed restore: %v", ctr.ID(), err)
+			}
+		}
+	}()
+
+	layer, err := os.Open(filepath.Join(dir, backupLayerFile))
+	if err != nil {
+		return nil, fmt.Errorf("invalid container backup: %w", err)
+	}
+	defer layer.Close()
+	if err := ctr.ImportLayer(layer); err != nil {
+		return nil, err
+	}
+
+	report.Id = ctr.ID()
+	report.Name = ctr.Name()
+	return report, nil
+}
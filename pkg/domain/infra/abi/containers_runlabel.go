@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
@@ -27,6 +28,8 @@ func (ic *ContainerEngine) ContainerRunlabel(ctx context.Context, label string,
 	pullOptions.AuthFilePath = options.Authfile
 	pullOptions.CertDirPath = options.CertDir
 	pullOptions.Credentials = options.Credentials
+	pullOptions.Username = options.Username
+	pullOptions.Password = options.Password
 	pullOptions.SignaturePolicyPath = options.SignaturePolicy
 	pullOptions.InsecureSkipTLSVerify = options.SkipTLSVerify
 
@@ -34,8 +37,16 @@ func (ic *ContainerEngine) ContainerRunlabel(ctx context.Context, label string,
 	if options.Pull {
 		pullPolicy = config.PullPolicyMissing
 	}
+	stdErr := io.Writer(os.Stderr)
+	stdOut := io.Writer(os.Stdout)
+	stdIn := io.Reader(os.Stdin)
+	if options.Writer != nil {
+		stdErr = options.Writer
+		stdOut = options.Writer
+		stdIn = nil
+	}
 	if !options.Quiet {
-		pullOptions.Writer = os.Stderr
+		pullOptions.Writer = stdErr
 	}
 
 	pulledImages, err := ic.Libpod.LibimageRuntime().Pull(ctx, imageRef, pullPolicy, pullOptions)
@@ -70,13 +81,10 @@ func (ic *ContainerEngine) ContainerRunlabel(ctx context.Context, label string,
 	}
 
 	if options.Display {
-		fmt.Printf("command: %s\n", strings.Join(append([]string{os.Args[0]}, cmd[1:]...), " "))
+		fmt.Fprintf(stdOut, "command: %s\n", strings.Join(append([]string{os.Args[0]}, cmd[1:]...), " "))
 		return nil
 	}
 
-	stdErr := os.Stderr
-	stdOut := os.Stdout
-	stdIn := os.Stdin
 	if options.Quiet {
 		stdErr = nil
 		stdOut = nil
@@ -0,0 +1,112 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+)
+
+const (
+	deviceSourceCDI  = "cdi"
+	deviceSourceHost = "host"
+)
+
+// pciVendors maps the PCI vendor IDs of GPU vendors to the vendor names used
+// in their CDI specifications.
+var pciVendors = map[string]string{
+	"0x1002": "amd.com",
+	"0x10de": "nvidia.com",
+	"0x8086": "intel.com",
+}
+
+// DeviceList lists the devices defined in the CDI specifications and the GPU
+// device nodes found on the host.
+func (ic *ContainerEngine) DeviceList(_ context.Context) ([]*entities.DeviceListReport, error) {
+	rtc, err := ic.Libpod.GetConfigNoCopy()
+	if err != nil {
+		return nil, err
+	}
+	registry, err := cdi.NewCache(
+		cdi.WithSpecDirs(rtc.Engine.CdiSpecDirs.Get()...),
+		cdi.WithAutoRefresh(false),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating CDI registry: %w", err)
+	}
+	if err := registry.Refresh(); err != nil {
+		// Invalid specifications are skipped, the valid ones are still listed.
+		logrus.Warnf("Refreshing the CDI registry: %v", err)
+	}
+
+	reports := []*entities.DeviceListReport{}
+	for _, name := range registry.ListDevices() {
+		vendor, class, _, err := parser.ParseQualifiedName(name)
+		if err != nil {
+			logrus.Warnf("Skipping CDI device %q: %v", name, err)
+			continue
+		}
+		report := &entities.DeviceListReport{
+			Name:   name,
+			Source: deviceSourceCDI,
+			Vendor: vendor,
+			Class:  class,
+		}
+		if device := registry.GetDevice(name); device != nil {
+			report.Spec = device.GetSpec().GetPath()
+		}
+		reports = append(reports, report)
+	}
+
+	return append(reports, discoverGPUDevices("/dev", "/sys/class/drm")...), nil
+}
+
+// discoverGPUDevices returns the NVIDIA, AMD and Intel GPU device nodes found
+// in devDir. The vendor of DRM devices is looked up in drmDir.
+func discoverGPUDevices(devDir, drmDir string) []*entities.DeviceListReport {
+	var reports []*entities.DeviceListReport
+	add := func(path, vendor string) {
+		reports = append(reports, &entities.DeviceListReport{
+			Name:   path,
+			Source: deviceSourceHost,
+			Vendor: vendor,
+			Class:  "gpu",
+		})
+	}
+
+	nvidia, _ := filepath.Glob(filepath.Join(devDir, "nvidia[0-9]*"))
+	for _, path := range nvidia {
+		add(path, "nvidia.com")
+	}
+	// The AMD kernel fusion driver, used by ROCm for GPU compute.
+	if _, err := os.Stat(filepath.Join(devDir, "kfd")); err == nil {
+		add(filepath.Join(devDir, "kfd"), "amd.com")
+	}
+	for _, pattern := range []string{"card[0-9]*", "renderD[0-9]*"} {
+		drm, _ := filepath.Glob(filepath.Join(devDir, "dri", pattern))
+		for _, path := range drm {
+			vendor := ""
+			if data, err := os.ReadFile(filepath.Join(drmDir, filepath.Base(path), "device", "vendor")); err == nil {
+				vendor = strings.TrimSpace(string(data))
+				if name, ok := pciVendors[vendor]; ok {
+					vendor = name
+				}
+			}
+			add(path, vendor)
+		}
+	}
+
+	sort.Slice(reports, func(i, j int) bool {
+		return reports[i].Name < reports[j].Name
+	})
+	return reports
+}
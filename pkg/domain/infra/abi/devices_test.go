@@ -0,0 +1,40 @@
+//go:build !remote
+
+package abi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverGPUDevices(t *testing.T) {
+	devDir := t.TempDir()
+	drmDir := t.TempDir()
+
+	assert.Empty(t, discoverGPUDevices(devDir, drmDir))
+
+	require.NoError(t, os.Mkdir(filepath.Join(devDir, "dri"), 0o755))
+	for _, name := range []string{"nvidia0", "nvidiactl", "kfd", "dri/card0", "dri/renderD128", "dri/card1"} {
+		require.NoError(t, os.WriteFile(filepath.Join(devDir, name), nil, 0o644))
+	}
+	for name, vendor := range map[string]string{"card0": "0x8086", "renderD128": "0x8086", "card1": "0x1234"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(drmDir, name, "device"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(drmDir, name, "device", "vendor"), []byte(vendor+"\n"), 0o644))
+	}
+
+	gpu := func(path, vendor string) *entities.DeviceListReport {
+		return &entities.DeviceListReport{Name: filepath.Join(devDir, path), Source: deviceSourceHost, Vendor: vendor, Class: "gpu"}
+	}
+	assert.Equal(t, []*entities.DeviceListReport{
+		gpu("dri/card0", "intel.com"),
+		gpu("dri/card1", "0x1234"),
+		gpu("dri/renderD128", "intel.com"),
+		gpu("kfd", "amd.com"),
+		gpu("nvidia0", "nvidia.com"),
+	}, discoverGPUDevices(devDir, drmDir))
+}
@@ -7,6 +7,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/dmikushin/podman-shared/libpod"
@@ -16,6 +18,9 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/specgen"
 	generateUtils "github.com/dmikushin/podman-shared/pkg/specgen/generate"
 	"github.com/dmikushin/podman-shared/pkg/systemd/generate"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libnetwork/types"
 	"sigs.k8s.io/yaml"
 )
 
@@ -46,16 +51,44 @@ func (ic *ContainerEngine) GenerateSystemd(_ context.Context, nameOrID string, o
 	return &entities.GenerateSystemdReport{Units: units}, nil
 }
 
+func (ic *ContainerEngine) GenerateQuadlet(ctx context.Context, nameOrID string, options entities.GenerateQuadletOptions) (*entities.GenerateQuadletReport, error) {
+	// First assume it's a container.
+	ctr, ctrErr := ic.Libpod.LookupContainer(nameOrID)
+	if ctrErr == nil {
+		units, err := generate.ContainerQuadlets(ctx, ctr, options)
+		if err != nil {
+			return nil, err
+		}
+		return &entities.GenerateQuadletReport{Units: units}, nil
+	}
+
+	// If it's not a container, we either have a pod or garbage.
+	pod, err := ic.Libpod.LookupPod(nameOrID)
+	if err != nil {
+		err = fmt.Errorf("%v: %w", err.Error(), ctrErr)
+		return nil, fmt.Errorf("%s does not refer to a container or pod: %w", nameOrID, err)
+	}
+
+	units, err := generate.PodQuadlets(ctx, pod, options)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.GenerateQuadletReport{Units: units}, nil
+}
+
 func (ic *ContainerEngine) GenerateSpec(_ context.Context, opts *entities.GenerateSpecOptions) (*entities.GenerateSpecReport, error) {
 	var spec *specgen.SpecGenerator
 	var pspec *specgen.PodSpecGenerator
 	var err error
 	if _, err := ic.Libpod.LookupContainer(opts.ID); err == nil {
 		spec = &specgen.SpecGenerator{}
-		_, _, err = generateUtils.ConfigToSpec(ic.Libpod, spec, opts.ID)
+		ctr, _, err := generateUtils.ConfigToSpec(ic.Libpod, spec, opts.ID)
 		if err != nil {
 			return nil, err
 		}
+		if opts.StripHostSpecific {
+			stripHostSpecificSpec(spec, ctr.ID(), ctr.RawImageName())
+		}
 	} else if p, err := ic.Libpod.LookupPod(opts.ID); err == nil {
 		pspec = &specgen.PodSpecGenerator{}
 		pspec.Name = p.Name()
@@ -68,6 +101,13 @@ func (ic *ContainerEngine) GenerateSpec(_ context.Context, opts *entities.Genera
 		if err != nil {
 			return nil, err
 		}
+		if opts.StripHostSpecific {
+			infraID, infraImage := "", ""
+			if infra, err := p.InfraContainer(); err == nil {
+				infraID, infraImage = infra.ID(), infra.RawImageName()
+			}
+			stripHostSpecificPodSpec(pspec, infraID, infraImage)
+		}
 	}
 
 	if pspec == nil && spec == nil {
@@ -108,6 +148,101 @@ func (ic *ContainerEngine) GenerateSpec(_ context.Context, opts *entities.Genera
 	return &entities.GenerateSpecReport{Data: j}, nil // regular output
 }
 
+// stripHostSpecificSpec removes the values of the spec of the container
+// which only make sense on this host: host paths, host IDs, addresses and
+// the IDs of other containers.  The image is given by the name it was
+// created with rather than by its ID.
+func stripHostSpecificSpec(spec *specgen.SpecGenerator, ctrID, rawImageName string) {
+	if rawImageName != "" {
+		spec.Image = rawImageName
+	}
+	if hostname, ok := spec.Env["HOSTNAME"]; ok && strings.HasPrefix(ctrID, hostname) {
+		delete(spec.Env, "HOSTNAME")
+	}
+	spec.ContainerCreateCommand = nil
+	spec.Pod = ""
+	spec.DependencyContainers = nil
+	spec.ConmonPidFile = ""
+	spec.CgroupParent = ""
+	spec.HostUsers = nil
+	spec.IDMappings = nil
+	if filepath.IsAbs(spec.BaseHostsFile) {
+		spec.BaseHostsFile = ""
+	}
+	spec.Mounts = stripBindMounts(spec.Mounts)
+	spec.OverlayVolumes = stripOverlayVolumes(spec.OverlayVolumes)
+	for _, device := range slices.Concat(spec.Devices, spec.HostDeviceList) {
+		logrus.Warnf("Dropping host device %s from the portable spec", device.Path)
+	}
+	spec.Devices = nil
+	spec.HostDeviceList = nil
+	if spec.ResourceLimits != nil {
+		spec.ResourceLimits.Devices = nil
+	}
+	stripHostSpecificNetworks(spec.Networks, ctrID)
+}
+
+// stripHostSpecificPodSpec removes the values of the spec of the pod which
+// only make sense on this host, see stripHostSpecificSpec.
+func stripHostSpecificPodSpec(pspec *specgen.PodSpecGenerator, infraID, infraImageName string) {
+	if infraImageName != "" {
+		pspec.InfraImage = infraImageName
+	}
+	pspec.PodCreateCommand = nil
+	pspec.InfraConmonPidFile = ""
+	pspec.CgroupParent = ""
+	pspec.ServiceContainerID = ""
+	pspec.IDMappings = nil
+	if filepath.IsAbs(pspec.HostsFile) {
+		pspec.HostsFile = ""
+	}
+	pspec.Mounts = stripBindMounts(pspec.Mounts)
+	pspec.OverlayVolumes = stripOverlayVolumes(pspec.OverlayVolumes)
+	for _, device := range pspec.Devices {
+		logrus.Warnf("Dropping host device %s from the portable spec", device)
+	}
+	pspec.Devices = nil
+	if pspec.ResourceLimits != nil {
+		pspec.ResourceLimits.Devices = nil
+	}
+	stripHostSpecificNetworks(pspec.Networks, infraID)
+}
+
+// stripBindMounts returns the mounts without the bind mounts of host paths.
+func stripBindMounts(mounts []spec.Mount) []spec.Mount {
+	var kept []spec.Mount
+	for _, mount := range mounts {
+		if mount.Type == define.TypeBind {
+			logrus.Warnf("Dropping bind mount of host path %s from the portable spec", mount.Source)
+			continue
+		}
+		kept = append(kept, mount)
+	}
+	return kept
+}
+
+// stripOverlayVolumes drops the overlay volumes, their lower directories are
+// host paths.
+func stripOverlayVolumes(volumes []*specgen.OverlayVolume) []*specgen.OverlayVolume {
+	for _, volume := range volumes {
+		logrus.Warnf("Dropping overlay volume of host path %s from the portable spec", volume.Source)
+	}
+	return nil
+}
+
+// stripHostSpecificNetworks removes the static addresses and the alias of
+// the short container ID from the network options.
+func stripHostSpecificNetworks(networks map[string]types.PerNetworkOptions, ctrID string) {
+	for name, opts := range networks {
+		opts.StaticIPs = nil
+		opts.StaticMAC = nil
+		opts.Aliases = slices.DeleteFunc(opts.Aliases, func(alias string) bool {
+			return ctrID != "" && strings.HasPrefix(ctrID, alias)
+		})
+		networks[name] = opts
+	}
+}
+
 func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string, options entities.GenerateKubeOptions) (*entities.GenerateKubeReport, error) {
 	var (
 		pods        []*libpod.Pod
@@ -123,6 +258,14 @@ func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string,
 	if options.Replicas < 1 {
 		return nil, fmt.Errorf("--replicas has to be greater than or equal to 1. By default, --replicas is set to 1")
 	}
+	switch options.Ingress {
+	case "":
+	case define.K8sKindIngress, define.K8sKindHTTPRoute:
+		// The Ingress or HTTPRoute routes the traffic to the service.
+		options.Service = true
+	default:
+		return nil, fmt.Errorf("invalid ingress kind %q - only %s and %s are supported", options.Ingress, define.K8sKindIngress, define.K8sKindHTTPRoute)
+	}
 
 	defaultKubeNS := true
 	// Lookup for podman objects.
@@ -279,6 +422,22 @@ func (ic *ContainerEngine) GenerateKube(ctx context.Context, nameOrIDs []string,
 				return nil, err
 			}
 			content = append(content, b)
+
+			if options.Ingress != "" {
+				labels := make(map[string]string)
+				for _, ctr := range ctrs {
+					for k, v := range ctr.Labels() {
+						if _, ok := labels[k]; !ok {
+							labels[k] = v
+						}
+					}
+				}
+				b, err := getKubeIngress(svc, labels, options.Ingress)
+				if err != nil {
+					return nil, err
+				}
+				content = append(content, b)
+			}
 		}
 	}
 
@@ -356,12 +515,30 @@ func getKubePods(ctx context.Context, pods []*libpod.Pod, options entities.Gener
 				return nil, nil, err
 			}
 			svcs = append(svcs, b)
+
+			if options.Ingress != "" {
+				b, err := getKubeIngress(svc, p.Labels(), options.Ingress)
+				if err != nil {
+					return nil, nil, fmt.Errorf("pod %s: %w", p.Name(), err)
+				}
+				svcs = append(svcs, b)
+			}
 		}
 	}
 
 	return out, svcs, nil
 }
 
+// getKubeIngress returns the kube Ingress or HTTPRoute YAML file routing HTTP
+// traffic to the service.
+func getKubeIngress(svc libpod.YAMLService, labels map[string]string, kind string) ([]byte, error) {
+	ingress, err := libpod.GenerateKubeIngress(svc, labels, kind)
+	if err != nil {
+		return nil, err
+	}
+	return generateKubeYAML(ingress)
+}
+
 // getKubePVCs returns kube persistent volume claim YAML files from podman volumes.
 func getKubePVCs(volumes []*libpod.Volume) ([][]byte, error) {
 	pvs := [][]byte{}
@@ -0,0 +1,95 @@
+//go:build !remote
+
+package abi
+
+import (
+	"net"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/pkg/specgen"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"go.podman.io/common/libnetwork/types"
+	"go.podman.io/storage/pkg/idtools"
+	storageTypes "go.podman.io/storage/types"
+)
+
+func TestStripHostSpecificSpec(t *testing.T) {
+	ctrID := "8843e4430a3f59a2b1d6ed2e3b1a6cbf1f1b8b1b6a3c2e9d4f5a6b7c8d9e0f1a"
+	mac, err := net.ParseMAC("92:d0:c6:0a:29:33")
+	assert.NoError(t, err)
+
+	s := &specgen.SpecGenerator{}
+	s.Name = "web"
+	s.Image = "a64f2071e611ea5d764d3c28fe7504b9b6d3631d99061ab2e62fbe99285a0331"
+	s.Env = map[string]string{"HOSTNAME": ctrID[:12], "FOO": "bar"}
+	s.ContainerCreateCommand = []string{"podman", "create", "-v", "/srv/data:/data", "alpine"}
+	s.Pod = "1a67d32538ab"
+	s.ConmonPidFile = "/run/web.pid"
+	s.CgroupParent = "/machine.slice"
+	s.IDMappings = &storageTypes.IDMappingOptions{UIDMap: []idtools.IDMap{{ContainerID: 0, HostID: 100000, Size: 5000}}}
+	s.BaseHostsFile = "/etc/hosts.web"
+	s.Mounts = []spec.Mount{
+		{Destination: "/data", Type: "bind", Source: "/srv/data"},
+		{Destination: "/tmp", Type: "tmpfs", Source: "tmpfs"},
+	}
+	s.Volumes = []*specgen.NamedVolume{{Name: "webdata", Dest: "/var/lib/web"}}
+	s.OverlayVolumes = []*specgen.OverlayVolume{{Source: "/srv/src", Destination: "/src"}}
+	s.HostDeviceList = []spec.LinuxDevice{{Path: "/dev/fuse"}}
+	s.ResourceLimits = &spec.LinuxResources{Devices: []spec.LinuxDeviceCgroup{{Allow: true, Type: "c"}}}
+	s.Networks = map[string]types.PerNetworkOptions{
+		"podman": {
+			StaticIPs: []net.IP{net.ParseIP("10.88.0.50")},
+			StaticMAC: types.HardwareAddr(mac),
+			Aliases:   []string{ctrID[:12], "web"},
+		},
+	}
+
+	stripHostSpecificSpec(s, ctrID, "docker.io/library/alpine:latest")
+
+	assert.Equal(t, "web", s.Name)
+	assert.Equal(t, "docker.io/library/alpine:latest", s.Image)
+	assert.Equal(t, map[string]string{"FOO": "bar"}, s.Env)
+	assert.Nil(t, s.ContainerCreateCommand)
+	assert.Empty(t, s.Pod)
+	assert.Empty(t, s.ConmonPidFile)
+	assert.Empty(t, s.CgroupParent)
+	assert.Nil(t, s.IDMappings)
+	assert.Empty(t, s.BaseHostsFile)
+	assert.Equal(t, []spec.Mount{{Destination: "/tmp", Type: "tmpfs", Source: "tmpfs"}}, s.Mounts)
+	assert.Equal(t, []*specgen.NamedVolume{{Name: "webdata", Dest: "/var/lib/web"}}, s.Volumes)
+	assert.Nil(t, s.OverlayVolumes)
+	assert.Nil(t, s.HostDeviceList)
+	assert.Nil(t, s.ResourceLimits.Devices)
+	assert.Equal(t, map[string]types.PerNetworkOptions{"podman": {Aliases: []string{"web"}}}, s.Networks)
+
+	// A hostname set by the user and a base hosts file of the image are kept.
+	s = &specgen.SpecGenerator{}
+	s.Env = map[string]string{"HOSTNAME": "myhost"}
+	s.BaseHostsFile = "image"
+	stripHostSpecificSpec(s, ctrID, "")
+	assert.Equal(t, map[string]string{"HOSTNAME": "myhost"}, s.Env)
+	assert.Equal(t, "image", s.BaseHostsFile)
+}
+
+func TestStripHostSpecificPodSpec(t *testing.T) {
+	infraID := "1a67d32538ab0e5a3f8a9c6b2d1e4f7a8b9c0d1e2f3a4b5c6d7e8f9a0b1c2d3e"
+
+	p := &specgen.PodSpecGenerator{}
+	p.Name = "web"
+	p.InfraImage = "a64f2071e611ea5d764d3c28fe7504b9b6d3631d99061ab2e62fbe99285a0331"
+	p.PodCreateCommand = []string{"podman", "pod", "create", "web"}
+	p.PortMappings = []types.PortMapping{{ContainerPort: 80, HostPort: 8080}}
+	p.Mounts = []spec.Mount{{Destination: "/data", Type: "bind", Source: "/srv/data"}}
+	p.Networks = map[string]types.PerNetworkOptions{
+		"podman": {StaticIPs: []net.IP{net.ParseIP("10.88.0.50")}, Aliases: []string{infraID[:12], "web"}},
+	}
+
+	stripHostSpecificPodSpec(p, infraID, "localhost/podman-pause:5.7.0")
+
+	assert.Equal(t, "localhost/podman-pause:5.7.0", p.InfraImage)
+	assert.Nil(t, p.PodCreateCommand)
+	assert.Equal(t, []types.PortMapping{{ContainerPort: 80, HostPort: 8080}}, p.PortMappings)
+	assert.Nil(t, p.Mounts)
+	assert.Equal(t, map[string]types.PerNetworkOptions{"podman": {Aliases: []string{"web"}}}, p.Networks)
+}
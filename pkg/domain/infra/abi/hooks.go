@@ -0,0 +1,142 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/pkg/hooks"
+	hooksv1 "go.podman.io/common/pkg/hooks/1.0.0"
+)
+
+// hookExtensionStages are the stages libpod runs hooks at itself, in
+// addition to the stages of the OCI runtime.
+var hookExtensionStages = []string{"precreate", "poststop"}
+
+// hook is a hook file of the hooks directories.
+type hook struct {
+	report *entities.HookListReport
+	hook   *hooksv1.Hook
+}
+
+// readHooks reads the hooks of the hooks directories like libpod when a
+// container starts, a hook overrides the ones of the same name in earlier
+// directories.  Unlike libpod, invalid hooks are returned with their error.
+func (ic *ContainerEngine) readHooks() []hook {
+	found := make(map[string]hook)
+	for _, dir := range ic.Libpod.HooksDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			if !errors.Is(err, os.ErrNotExist) {
+				logrus.Warnf("Reading hooks from %s: %v", dir, err)
+			}
+			continue
+		}
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			h, err := hooks.Read(path, hookExtensionStages)
+			if errors.Is(err, hooks.ErrNoJSONSuffix) {
+				continue
+			}
+			report := &entities.HookListReport{
+				Name: entry.Name(),
+				Path: path,
+			}
+			if h != nil {
+				report.Stages = h.Stages
+				report.When = describeWhen(&h.When)
+			}
+			if err != nil {
+				report.Error = err.Error()
+				h = nil
+			}
+			found[entry.Name()] = hook{report: report, hook: h}
+		}
+	}
+
+	// libpod runs matching hooks in the order of their names
+	names := slices.SortedFunc(maps.Keys(found), func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	sorted := make([]hook, 0, len(names))
+	for _, name := range names {
+		sorted = append(sorted, found[name])
+	}
+	return sorted
+}
+
+// describeWhen summarizes the conditions of a hook.
+func describeWhen(when *hooksv1.When) string {
+	var conditions []string
+	if when.Always != nil {
+		conditions = append(conditions, fmt.Sprintf("always=%t", *when.Always))
+	}
+	for _, key := range slices.Sorted(maps.Keys(when.Annotations)) {
+		conditions = append(conditions, fmt.Sprintf("annotation %s=%s", key, when.Annotations[key]))
+	}
+	for _, command := range when.Commands {
+		conditions = append(conditions, "command "+command)
+	}
+	if when.HasBindMounts != nil {
+		conditions = append(conditions, fmt.Sprintf("hasBindMounts=%t", *when.HasBindMounts))
+	}
+	separator := " and "
+	if when.Or {
+		separator = " or "
+	}
+	return strings.Join(conditions, separator)
+}
+
+// HookList lists the OCI hooks of the hooks directories.
+func (ic *ContainerEngine) HookList(_ context.Context) ([]*entities.HookListReport, error) {
+	found := ic.readHooks()
+	reports := make([]*entities.HookListReport, 0, len(found))
+	for _, h := range found {
+		reports = append(reports, h.report)
+	}
+	return reports, nil
+}
+
+// HookReload reads the hooks directories from containers.conf again and
+// lists the hooks found in them.
+func (ic *ContainerEngine) HookReload(ctx context.Context) ([]*entities.HookListReport, error) {
+	if err := ic.Libpod.ReloadHooksDirs(); err != nil {
+		return nil, err
+	}
+	return ic.HookList(ctx)
+}
+
+// HookTest reports which OCI hooks match the configuration of a container,
+// the matching hooks are added to the container when it starts.
+func (ic *ContainerEngine) HookTest(_ context.Context, nameOrID string) ([]*entities.HookTestReport, error) {
+	ctr, err := ic.Libpod.LookupContainer(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	config := ctr.Config()
+	if config.Spec == nil {
+		return nil, fmt.Errorf("container %s has no OCI spec", ctr.ID())
+	}
+	found := ic.readHooks()
+	reports := make([]*entities.HookTestReport, 0, len(found))
+	for _, h := range found {
+		report := &entities.HookTestReport{HookListReport: *h.report}
+		if h.hook != nil {
+			report.Matched, err = h.hook.When.Match(config.Spec, config.Spec.Annotations, len(config.UserVolumes) > 0)
+			if err != nil {
+				report.Error = err.Error()
+			}
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
@@ -0,0 +1,26 @@
+//go:build !remote
+
+package abi
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	hooksv1 "go.podman.io/common/pkg/hooks/1.0.0"
+)
+
+func TestDescribeWhen(t *testing.T) {
+	always := true
+	tests := []struct {
+		when     hooksv1.When
+		expected string
+	}{
+		{hooksv1.When{Always: &always}, "always=true"},
+		{hooksv1.When{Annotations: map[string]string{"b": "2", "a": "1"}}, "annotation a=1 and annotation b=2"},
+		{hooksv1.When{Commands: []string{".*/init$"}, HasBindMounts: &always}, "command .*/init$ and hasBindMounts=true"},
+		{hooksv1.When{Commands: []string{"sh"}, HasBindMounts: &always, Or: true}, "command sh or hasBindMounts=true"},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.expected, describeWhen(&test.when))
+	}
+}
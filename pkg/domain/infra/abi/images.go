@@ -3,6 +3,7 @@
 package abi
 
 import (
+	"archive/tar"
 	"context"
 	"errors"
 	"fmt"
@@ -21,6 +22,7 @@ import (
 
 	bdefine "github.com/containers/buildah/define"
 	"github.com/containers/buildah/pkg/volumes"
+	"github.com/dmikushin/podman-shared/internal/remote_build_helpers"
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities/reports"
@@ -37,6 +39,7 @@ import (
 	"go.podman.io/image/v5/docker/reference"
 	"go.podman.io/image/v5/image"
 	"go.podman.io/image/v5/manifest"
+	ociarchive "go.podman.io/image/v5/oci/archive"
 	"go.podman.io/image/v5/pkg/compression"
 	"go.podman.io/image/v5/signature"
 	"go.podman.io/image/v5/transports"
@@ -115,6 +118,10 @@ func (ir *ImageEngine) Prune(ctx context.Context, opts entities.ImagePruneOption
 		if err := volumes.CleanCacheMount(); err != nil {
 			return nil, err
 		}
+		// and the build context cache of remote builds
+		if err := os.RemoveAll(remote_build_helpers.ContextCacheDir(ir.Libpod.StorageConfig().GraphRoot)); err != nil {
+			return nil, err
+		}
 	}
 
 	return pruneReports, nil
@@ -166,6 +173,11 @@ func (ir *ImageEngine) Mount(ctx context.Context, nameOrIDs []string, opts entit
 	}
 
 	if os.Geteuid() != 0 || !hasCapSysAdmin {
+		if opts.AllLayers {
+			// The layer mounts would vanish with the user namespace
+			// created for this command.
+			return nil, errors.New("in rootless mode --all-layers can only be used inside podman unshare")
+		}
 		if driver := ir.Libpod.StorageConfig().GraphDriverName; driver != "vfs" {
 			// Do not allow to mount a graphdriver that is not vfs if we are creating the userns as part
 			// of the mount command.
@@ -202,6 +214,15 @@ func (ir *ImageEngine) Mount(ctx context.Context, nameOrIDs []string, opts entit
 		}
 	}
 
+	if opts.AllLayers {
+		if len(nameOrIDs) != 1 {
+			return nil, errors.New("--all-layers requires exactly one image")
+		}
+		if opts.Target == "" {
+			return nil, errors.New("--all-layers requires a --target directory")
+		}
+	}
+
 	mountReports := []*entities.ImageMountReport{}
 	for _, i := range images {
 		var mountPoint string
@@ -219,13 +240,31 @@ func (ir *ImageEngine) Mount(ctx context.Context, nameOrIDs []string, opts entit
 			if mountPoint == "" {
 				continue
 			}
-		} else {
+		} else if !opts.AllLayers {
 			mountPoint, err = i.Mount(ctx, nil, "")
 			if err != nil {
 				return nil, err
 			}
 		}
 
+		var layers []entities.ImageLayerMount
+		if opts.AllLayers {
+			mounts, err := ir.Libpod.MountImageLayers(i, opts.Target)
+			if err != nil {
+				return nil, err
+			}
+			for index, m := range mounts {
+				layers = append(layers, entities.ImageLayerMount{
+					Index:  index,
+					ID:     m.Layer.ID,
+					DiffID: m.Layer.UncompressedDigest.String(),
+					Size:   m.Layer.UncompressedSize,
+					Path:   m.Path,
+				})
+			}
+			mountPoint = opts.Target
+		}
+
 		tags, err := i.RepoTags()
 		if err != nil {
 			return nil, err
@@ -235,6 +274,7 @@ func (ir *ImageEngine) Mount(ctx context.Context, nameOrIDs []string, opts entit
 			Name:         string(i.Digest()),
 			Repositories: tags,
 			Path:         mountPoint,
+			Layers:       layers,
 		})
 	}
 	return mountReports, nil
@@ -263,6 +303,20 @@ func (ir *ImageEngine) Unmount(ctx context.Context, nameOrIDs []string, options
 	}
 
 	unmountReports := []*entities.ImageUnmountReport{}
+	if options.AllLayers {
+		if len(nameOrIDs) != 1 {
+			return nil, errors.New("--all-layers requires exactly one image")
+		}
+		if options.Target == "" {
+			return nil, errors.New("--all-layers requires a --target directory")
+		}
+		for _, image := range images {
+			r := &entities.ImageUnmountReport{Id: image.ID()}
+			r.Err = ir.Libpod.UnmountImageLayers(image, options.Target)
+			unmountReports = append(unmountReports, r)
+		}
+		return unmountReports, nil
+	}
 	for _, image := range images {
 		r := &entities.ImageUnmountReport{Id: image.ID()}
 		mountPoint, err := image.Mountpoint()
@@ -308,7 +362,15 @@ func (ir *ImageEngine) Pull(ctx context.Context, rawImage string, options entiti
 		pullOptions.Writer = os.Stderr
 	}
 
-	pulledImages, err := ir.Libpod.LibimageRuntime().Pull(ctx, rawImage, options.PullPolicy, pullOptions)
+	if options.ToShared {
+		pulledIDs, err := ir.Libpod.PullImageToSharedStore(ctx, rawImage, options.PullPolicy, pullOptions)
+		if err != nil {
+			return nil, err
+		}
+		return &entities.ImagePullReport{Images: pulledIDs}, nil
+	}
+
+	pulledImages, err := ir.Libpod.PullImage(ctx, rawImage, options.PullPolicy, options.PullMaxAge, pullOptions)
 	if err != nil {
 		return nil, err
 	}
@@ -379,6 +441,7 @@ func (ir *ImageEngine) Push(ctx context.Context, source string, destination stri
 	pushOptions.SignSigstorePrivateKeyPassphrase = options.SignSigstorePrivateKeyPassphrase
 	pushOptions.InsecureSkipTLSVerify = options.SkipTLSVerify
 	pushOptions.Writer = options.Writer
+	pushOptions.Progress = options.Progress
 	pushOptions.OciEncryptConfig = options.OciEncryptConfig
 	pushOptions.OciEncryptLayers = options.OciEncryptLayers
 	pushOptions.CompressionLevel = options.CompressionLevel
@@ -475,6 +538,12 @@ func (ir *ImageEngine) Untag(_ context.Context, nameOrID string, tags []string,
 }
 
 func (ir *ImageEngine) Load(ctx context.Context, options entities.ImageLoadOptions) (*entities.ImageLoadReport, error) {
+	// Images saved with the artifacts referring to them are in OCI
+	// archives holding several manifests.
+	if index, err := ociArchiveIndex(options.Input); err == nil && len(index.Manifests) > 1 {
+		return ir.loadWithReferrers(ctx, options)
+	}
+
 	loadOptions := &libimage.LoadOptions{}
 	loadOptions.SignaturePolicyPath = options.SignaturePolicy
 	if !options.Quiet {
@@ -503,6 +572,9 @@ func (ir *ImageEngine) Save(ctx context.Context, nameOrID string, tags []string,
 	}
 
 	names := []string{nameOrID}
+	if options.Format == define.OCIArtifactArchive {
+		return ir.saveWithReferrers(ctx, append(names, tags...), options)
+	}
 	if options.MultiImageArchive {
 		names = append(names, tags...)
 	} else {
@@ -512,6 +584,10 @@ func (ir *ImageEngine) Save(ctx context.Context, nameOrID string, tags []string,
 }
 
 func (ir *ImageEngine) Import(ctx context.Context, options entities.ImageImportOptions) (*entities.ImageImportReport, error) {
+	if !options.SourceIsURL && isOCIArchive(options.Source) {
+		return ir.importOCIArchive(ctx, options)
+	}
+
 	importOptions := &libimage.ImportOptions{}
 	importOptions.Changes = options.Changes
 	importOptions.CommitMessage = options.Message
@@ -533,6 +609,61 @@ func (ir *ImageEngine) Import(ctx context.Context, options entities.ImageImportO
 	return &entities.ImageImportReport{Id: imageID}, nil
 }
 
+// isOCIArchive reports whether the file at path is an OCI archive, as
+// written by podman export --format oci-archive, rather than the tarball of
+// a root file system.
+func isOCIArchive(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			return false
+		}
+		if filepath.Clean(hdr.Name) == imgspecv1.ImageLayoutFile {
+			return true
+		}
+	}
+}
+
+// importOCIArchive loads an OCI archive, keeping the configuration it holds
+// instead of creating a new one like for the tarball of a root file system.
+func (ir *ImageEngine) importOCIArchive(ctx context.Context, options entities.ImageImportOptions) (*entities.ImageImportReport, error) {
+	if len(options.Changes) > 0 || options.Message != "" || options.OS != "" || options.Architecture != "" || options.Variant != "" {
+		return nil, errors.New("--change, --message, --os, --arch and --variant cannot be used when importing an OCI archive, which keeps its own configuration")
+	}
+	ref, err := ociarchive.NewReference(options.Source, "")
+	if err != nil {
+		return nil, err
+	}
+	loadOptions := &libimage.LoadOptions{}
+	loadOptions.SignaturePolicyPath = options.SignaturePolicy
+	if !options.Quiet {
+		loadOptions.Writer = os.Stderr
+	}
+	names, err := ir.Libpod.LibimageRuntime().LoadReference(ctx, ref, loadOptions)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("no image found in OCI archive %s", options.Source)
+	}
+	img, _, err := ir.Libpod.LibimageRuntime().LookupImage(names[0], nil)
+	if err != nil {
+		return nil, err
+	}
+	if options.Reference != "" {
+		if err := img.Tag(options.Reference); err != nil {
+			return nil, err
+		}
+	}
+	return &entities.ImageImportReport{Id: img.ID()}, nil
+}
+
 // Search for images using term and filters
 func (ir *ImageEngine) Search(ctx context.Context, term string, opts entities.ImageSearchOptions) ([]entities.ImageSearchReport, error) {
 	filter, err := filter.ParseSearchFilter(opts.Filters)
@@ -581,6 +712,9 @@ func (ir *ImageEngine) Config(_ context.Context) (*config.Config, error) {
 }
 
 func (ir *ImageEngine) Build(ctx context.Context, containerFiles []string, opts entities.BuildOptions) (*entities.BuildReport, error) {
+	if err := ir.Libpod.SetupSharedBuildCache(&opts.BuildOptions, opts.SharedCache); err != nil {
+		return nil, err
+	}
 	id, _, err := ir.Libpod.Build(ctx, opts.BuildOptions, containerFiles...)
 	if err != nil {
 		return nil, err
@@ -597,6 +731,16 @@ func (ir *ImageEngine) Tree(ctx context.Context, nameOrID string, opts entities.
 	if err != nil {
 		return nil, err
 	}
+	if opts.DiskUsage {
+		if opts.WhatRequires {
+			return nil, fmt.Errorf("disk usage cannot be shown together with the child images: %w", define.ErrInvalidArg)
+		}
+		tree, err := ir.Libpod.ImageDiskUsageTree(image)
+		if err != nil {
+			return nil, err
+		}
+		return &entities.ImageTreeReport{Tree: tree}, nil
+	}
 	tree, err := image.Tree(ctx, opts.WhatRequires)
 	if err != nil {
 		return nil, err
@@ -6,9 +6,11 @@ import (
 	"context"
 	"fmt"
 	"slices"
+	"sort"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"go.podman.io/common/libimage"
 )
 
@@ -28,6 +30,14 @@ func (ir *ImageEngine) List(ctx context.Context, opts entities.ImageListOptions)
 	if err != nil {
 		return nil, err
 	}
+	if opts.Limit > 0 || opts.Offset > 0 {
+		// select the page before getting the expensive information
+		// on the images
+		sort.SliceStable(images, func(i, j int) bool {
+			return images[i].Created().After(images[j].Created())
+		})
+		images = util.Paginate(images, opts.Offset, opts.Limit)
+	}
 
 	summaries := []*entities.ImageSummary{}
 	for _, img := range images {
@@ -0,0 +1,264 @@
+//go:build !remote
+
+package abi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/layermerge"
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.podman.io/common/libimage"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/image/v5/manifest"
+	"go.podman.io/image/v5/oci/layout"
+	"go.podman.io/image/v5/pkg/blobinfocache/none"
+	"go.podman.io/image/v5/pkg/compression"
+	"go.podman.io/image/v5/types"
+)
+
+// Optimize writes the image as target with its layers compressed with zstd,
+// the whiteouts hiding nothing removed and the layers smaller than the merge
+// threshold merged with the following layers.
+func (ir *ImageEngine) Optimize(ctx context.Context, nameOrID, target string, opts entities.ImageOptimizeOptions) (*entities.ImageOptimizeReport, error) {
+	img, _, err := ir.Libpod.LibimageRuntime().LookupImage(nameOrID, nil)
+	if err != nil {
+		return nil, err
+	}
+	named, err := libimage.NormalizeName(target)
+	if err != nil {
+		return nil, err
+	}
+	layers, err := ir.Libpod.ImageLayers(img)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entities.ImageOptimizeReport{Name: named.String(), LayersBefore: len(layers)}
+	sizes := make([]int64, len(layers))
+	for i, layer := range layers {
+		if sizes[i], err = ir.Libpod.LayerSize(layer); err != nil {
+			return nil, err
+		}
+		report.SizeBefore += sizes[i]
+		if layer.CompressedSize > 0 {
+			report.CompressedSizeBefore += layer.CompressedSize
+		} else {
+			report.CompressedSizeBefore += sizes[i]
+		}
+	}
+
+	rawConfig, err := ir.imageConfigBlob(ctx, img)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := ir.Libpod.GetConfigNoCopy()
+	if err != nil {
+		return nil, err
+	}
+	tmpDir, err := cfg.ImageCopyTmpDir()
+	if err != nil {
+		return nil, err
+	}
+	layoutDir, err := os.MkdirTemp(tmpDir, "podman-optimize")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(layoutDir)
+	layoutRef, err := layout.NewReference(layoutDir, named.String())
+	if err != nil {
+		return nil, err
+	}
+	imageDest, err := layoutRef.NewImageDestination(ctx, ir.Libpod.SystemContext())
+	if err != nil {
+		return nil, err
+	}
+	defer imageDest.Close()
+
+	groups := layermerge.Groups(sizes, opts.MergeThreshold)
+	lower := layermerge.NewLower()
+	imageManifest := imgspecv1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: imgspecv1.MediaTypeImageManifest,
+	}
+	var diffIDs []digest.Digest
+	for _, group := range groups {
+		open := func(i int) (io.ReadCloser, error) {
+			return ir.Libpod.LayerDiff(layers[group[i]])
+		}
+		layerInfo, diffID, size, stats, err := putOptimizedLayer(ctx, imageDest, len(group), open, lower, opts.CompressionLevel)
+		if err != nil {
+			return nil, fmt.Errorf("writing optimized layer of layers %d-%d: %w", group[0]+1, group[len(group)-1]+1, err)
+		}
+		imageManifest.Layers = append(imageManifest.Layers, imgspecv1.Descriptor{
+			MediaType: imgspecv1.MediaTypeImageLayerZstd,
+			Digest:    layerInfo.Digest,
+			Size:      layerInfo.Size,
+		})
+		diffIDs = append(diffIDs, diffID)
+		report.SizeAfter += size
+		report.CompressedSizeAfter += layerInfo.Size
+		report.WhiteoutsRemoved += stats.WhiteoutsRemoved
+	}
+	report.LayersAfter = len(groups)
+
+	rawConfig, err = optimizedConfig(rawConfig, diffIDs, groups, len(layers))
+	if err != nil {
+		return nil, err
+	}
+	configInfo, err := imageDest.PutBlob(ctx, bytes.NewReader(rawConfig), types.BlobInfo{Size: -1}, none.NoCache, true)
+	if err != nil {
+		return nil, err
+	}
+	imageManifest.Config = imgspecv1.Descriptor{
+		MediaType: imgspecv1.MediaTypeImageConfig,
+		Digest:    configInfo.Digest,
+		Size:      configInfo.Size,
+	}
+	rawManifest, err := json.Marshal(imageManifest)
+	if err != nil {
+		return nil, err
+	}
+	if err := imageDest.PutManifest(ctx, rawManifest, nil); err != nil {
+		return nil, err
+	}
+	if err := imageDest.Commit(ctx, nil); err != nil {
+		return nil, err
+	}
+
+	pulled, err := ir.Libpod.LibimageRuntime().Pull(ctx, layout.Transport.Name()+":"+layoutDir+":"+named.String(), config.PullPolicyAlways, &libimage.PullOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("storing optimized image: %w", err)
+	}
+	report.ID = pulled[0].ID()
+	return report, nil
+}
+
+// imageConfigBlob returns the raw config of the image.
+func (ir *ImageEngine) imageConfigBlob(ctx context.Context, img *libimage.Image) ([]byte, error) {
+	ref, err := img.StorageReference()
+	if err != nil {
+		return nil, err
+	}
+	src, err := ref.NewImageSource(ctx, ir.Libpod.SystemContext())
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	rawManifest, manifestType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	m, err := manifest.FromBlob(rawManifest, manifestType)
+	if err != nil {
+		return nil, err
+	}
+	rc, _, err := src.GetBlob(ctx, m.ConfigInfo(), none.NoCache)
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
+
+// byteCounter counts the bytes written to it.
+type byteCounter int64
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	*c += byteCounter(len(p))
+	return len(p), nil
+}
+
+// putOptimizedLayer merges the n layers returned by open and writes the
+// result compressed with zstd to dest.  It returns the info of the blob
+// written, the digest and size of the uncompressed layer and what merging
+// removed.
+func putOptimizedLayer(ctx context.Context, dest types.ImageDestination, n int, open func(i int) (io.ReadCloser, error), lower *layermerge.Lower, level *int) (types.BlobInfo, digest.Digest, int64, layermerge.Stats, error) {
+	var (
+		stats    layermerge.Stats
+		size     byteCounter
+		digester = digest.Canonical.Digester()
+		done     = make(chan error, 1)
+	)
+	pr, pw := io.Pipe()
+	go func() {
+		zw, err := compression.CompressStream(pw, compression.Zstd, level)
+		if err == nil {
+			stats, err = layermerge.Merge(io.MultiWriter(zw, digester.Hash(), &size), n, open, lower)
+			if closeErr := zw.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		pw.CloseWithError(err)
+		done <- err
+	}()
+	info, err := dest.PutBlob(ctx, pr, types.BlobInfo{Size: -1}, none.NoCache, false)
+	// Unblock the merge if writing the blob failed.
+	pr.Close()
+	mergeErr := <-done
+	if err != nil {
+		return types.BlobInfo{}, "", 0, stats, err
+	}
+	if mergeErr != nil {
+		return types.BlobInfo{}, "", 0, stats, mergeErr
+	}
+	return info, digester.Digest(), int64(size), stats, nil
+}
+
+// optimizedConfig returns the config of the image with the new layers.  The
+// history entries of the layers merged into the layer of the last one of
+// their group are marked as empty, other fields are preserved as they are.
+func optimizedConfig(rawConfig []byte, diffIDs []digest.Digest, groups [][]int, layers int) ([]byte, error) {
+	var imageConfig map[string]json.RawMessage
+	if err := json.Unmarshal(rawConfig, &imageConfig); err != nil {
+		return nil, fmt.Errorf("parsing image config: %w", err)
+	}
+	var history []imgspecv1.History
+	if raw, ok := imageConfig["history"]; ok {
+		if err := json.Unmarshal(raw, &history); err != nil {
+			return nil, fmt.Errorf("parsing image history: %w", err)
+		}
+	}
+
+	var layerHistory []int
+	for i, h := range history {
+		if !h.EmptyLayer {
+			layerHistory = append(layerHistory, i)
+		}
+	}
+	if len(layerHistory) == layers {
+		for _, group := range groups {
+			for _, layer := range group[:len(group)-1] {
+				history[layerHistory[layer]].EmptyLayer = true
+			}
+		}
+	} else {
+		// The history does not match the layers, it only describes
+		// the new layers then.
+		for i := range history {
+			history[i].EmptyLayer = true
+		}
+		now := time.Now().UTC()
+		for range groups {
+			history = append(history, imgspecv1.History{Created: &now, CreatedBy: "podman image optimize"})
+		}
+	}
+
+	var err error
+	if imageConfig["history"], err = json.Marshal(history); err != nil {
+		return nil, err
+	}
+	if imageConfig["rootfs"], err = json.Marshal(imgspecv1.RootFS{Type: "layers", DiffIDs: diffIDs}); err != nil {
+		return nil, err
+	}
+	return json.Marshal(imageConfig)
+}
@@ -0,0 +1,259 @@
+//go:build !remote
+
+package abi
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libimage"
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/image/v5/manifest"
+	"go.podman.io/image/v5/oci/layout"
+	"go.podman.io/storage/pkg/archive"
+)
+
+// saveWithReferrers writes the images and the artifacts referring to them,
+// like signatures, SBOMs and attestations, to an OCI archive.  The layers of
+// the images are compressed again when saving, which changes the digests of
+// their manifests: the subjects of the artifacts are updated to the saved
+// manifests so that they still refer to the images once loaded.
+func (ir *ImageEngine) saveWithReferrers(ctx context.Context, names []string, options entities.ImageSaveOptions) error {
+	artStore, err := ir.Libpod.ArtifactStore()
+	if err != nil {
+		return err
+	}
+	artifacts, err := artStore.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := ir.Libpod.GetConfigNoCopy()
+	if err != nil {
+		return err
+	}
+	tmpDir, err := cfg.ImageCopyTmpDir()
+	if err != nil {
+		return err
+	}
+	layoutDir, err := os.MkdirTemp(tmpDir, "podman-save")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(layoutDir)
+
+	for _, name := range names {
+		img, resolvedName, err := ir.Libpod.LibimageRuntime().LookupImage(name, nil)
+		if err != nil {
+			return err
+		}
+		refName := resolvedName
+		if strings.HasPrefix(img.ID(), resolvedName) {
+			// The image is referred to by ID, save it under its
+			// first name.
+			if len(img.Names()) == 0 {
+				return fmt.Errorf("image %s has no name to save it under", name)
+			}
+			refName = img.Names()[0]
+		} else if named, err := libimage.NormalizeName(resolvedName); err == nil {
+			refName = named.String()
+		}
+
+		pushOptions := &libimage.PushOptions{}
+		pushOptions.SignaturePolicyPath = options.SignaturePolicy
+		pushOptions.OciAcceptUncompressedLayers = options.OciAcceptUncompressedLayers
+		pushOptions.RemoveSignatures = true
+		if !options.Quiet {
+			pushOptions.Writer = os.Stderr
+		}
+		rawManifest, err := ir.Libpod.LibimageRuntime().Push(ctx, img.ID(), layout.Transport.Name()+":"+layoutDir+":"+refName, pushOptions)
+		if err != nil {
+			return err
+		}
+		subject := &imgspecv1.Descriptor{
+			MediaType: manifest.GuessMIMEType(rawManifest),
+			Digest:    digest.FromBytes(rawManifest),
+			Size:      int64(len(rawManifest)),
+		}
+
+		digests := img.Digests()
+		for _, artifact := range artifacts {
+			if artifact.Name == "" || artifact.Manifest.Subject == nil || !slices.Contains(digests, artifact.Manifest.Subject.Digest) {
+				continue
+			}
+			if !options.Quiet {
+				fmt.Fprintf(os.Stderr, "Saving artifact %s\n", artifact.Name)
+			}
+			if err := artStore.Export(ctx, artifact.Name, layoutDir, subject); err != nil {
+				return fmt.Errorf("saving artifact %s: %w", artifact.Name, err)
+			}
+		}
+	}
+
+	return writeLayoutArchive(layoutDir, options.Output)
+}
+
+// writeLayoutArchive writes the OCI layout in dir to an archive at path.
+func writeLayoutArchive(dir, path string) (retErr error) {
+	tarStream, err := archive.TarWithOptions(dir, &archive.TarOptions{Compression: archive.Uncompressed})
+	if err != nil {
+		return err
+	}
+	defer tarStream.Close()
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := f.Close(); err != nil && retErr == nil {
+			retErr = err
+		}
+	}()
+	_, err = io.Copy(f, tarStream)
+	return err
+}
+
+// ociArchiveIndex returns the index of the OCI archive at path.
+func ociArchiveIndex(path string) (*imgspecv1.Index, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	tr := tar.NewReader(f)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, fmt.Errorf("%s has no %s", path, imgspecv1.ImageIndexFile)
+			}
+			return nil, err
+		}
+		if filepath.Clean(hdr.Name) != imgspecv1.ImageIndexFile {
+			continue
+		}
+		var index imgspecv1.Index
+		if err := json.NewDecoder(tr).Decode(&index); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", imgspecv1.ImageIndexFile, err)
+		}
+		return &index, nil
+	}
+}
+
+// loadWithReferrers loads the images of an OCI archive holding several
+// manifests, as written by podman save --format oci-artifact.  The manifests
+// with a subject are loaded as artifacts in the artifact store and the
+// others as images.
+func (ir *ImageEngine) loadWithReferrers(ctx context.Context, options entities.ImageLoadOptions) (*entities.ImageLoadReport, error) {
+	cfg, err := ir.Libpod.GetConfigNoCopy()
+	if err != nil {
+		return nil, err
+	}
+	tmpDir, err := cfg.ImageCopyTmpDir()
+	if err != nil {
+		return nil, err
+	}
+	layoutDir, err := os.MkdirTemp(tmpDir, "podman-load")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(layoutDir)
+
+	f, err := os.Open(options.Input)
+	if err != nil {
+		return nil, err
+	}
+	err = archive.NewDefaultArchiver().Untar(f, layoutDir, &archive.TarOptions{NoLchown: true})
+	f.Close()
+	if err != nil {
+		return nil, fmt.Errorf("extracting %s: %w", options.Input, err)
+	}
+
+	entries, err := layout.List(layoutDir)
+	if err != nil {
+		return nil, err
+	}
+
+	copyOptions := libimage.CopyOptions{SignaturePolicyPath: options.SignaturePolicy}
+	if !options.Quiet {
+		copyOptions.Writer = os.Stderr
+	}
+	report := &entities.ImageLoadReport{}
+	var referrers []layout.ListResult
+	for _, entry := range entries {
+		hasSubject, err := ir.manifestHasSubject(ctx, entry)
+		if err != nil {
+			return nil, err
+		}
+		if hasSubject {
+			// Load the artifacts once the images they refer to
+			// are.
+			referrers = append(referrers, entry)
+			continue
+		}
+		pullOptions := &libimage.PullOptions{CopyOptions: copyOptions}
+		pulled, err := ir.Libpod.LibimageRuntime().Pull(ctx, layout.Transport.Name()+":"+entry.Reference.StringWithinTransport(), config.PullPolicyAlways, pullOptions)
+		if err != nil {
+			return nil, err
+		}
+		if name := entry.ManifestDescriptor.Annotations[imgspecv1.AnnotationRefName]; name != "" {
+			report.Names = append(report.Names, name)
+		} else {
+			report.Names = append(report.Names, pulled[0].ID())
+		}
+	}
+
+	if len(referrers) == 0 {
+		return report, nil
+	}
+	artStore, err := ir.Libpod.ArtifactStore()
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range referrers {
+		name := entry.ManifestDescriptor.Annotations[imgspecv1.AnnotationRefName]
+		if name == "" {
+			logrus.Warnf("Skipping artifact %s without a name", entry.ManifestDescriptor.Digest)
+			continue
+		}
+		if _, err := artStore.Import(ctx, name, entry.Reference, copyOptions); err != nil {
+			return nil, fmt.Errorf("loading artifact %s: %w", name, err)
+		}
+		report.Artifacts = append(report.Artifacts, name)
+	}
+	return report, nil
+}
+
+// manifestHasSubject reports whether the manifest of the entry of an OCI
+// layout refers to another manifest.
+func (ir *ImageEngine) manifestHasSubject(ctx context.Context, entry layout.ListResult) (bool, error) {
+	if entry.ManifestDescriptor.MediaType != imgspecv1.MediaTypeImageManifest {
+		return false, nil
+	}
+	src, err := entry.Reference.NewImageSource(ctx, ir.Libpod.SystemContext())
+	if err != nil {
+		return false, err
+	}
+	defer src.Close()
+	rawManifest, _, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	var m imgspecv1.Manifest
+	if err := json.Unmarshal(rawManifest, &m); err != nil {
+		return false, err
+	}
+	return m.Subject != nil, nil
+}
@@ -0,0 +1,170 @@
+//go:build !remote
+
+package abi
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/sbom"
+	"github.com/opencontainers/go-digest"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.podman.io/common/libimage"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/manifest"
+	"go.podman.io/image/v5/pkg/blobinfocache/none"
+	"go.podman.io/image/v5/types"
+)
+
+func (ir *ImageEngine) SBOM(ctx context.Context, nameOrID string, opts entities.ImageSBOMOptions) (*entities.ImageSBOMReport, error) {
+	if opts.Format == "" {
+		opts.Format = sbom.FormatSPDX
+	}
+	mediaType, err := sbom.MediaType(opts.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	img, _, err := ir.Libpod.LibimageRuntime().LookupImage(nameOrID, nil)
+	if err != nil {
+		return nil, err
+	}
+	inv, err := ir.scanImage(ctx, img)
+	if err != nil {
+		return nil, fmt.Errorf("scanning the layers of image %s: %w", nameOrID, err)
+	}
+
+	name := nameOrID
+	if names := img.Names(); len(names) > 0 {
+		name = names[0]
+	}
+	content, err := sbom.Generate(opts.Format, sbom.Image{Name: name, ID: img.ID(), Digest: img.Digest().String()}, inv, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	report := &entities.ImageSBOMReport{
+		Format:    opts.Format,
+		MediaType: mediaType,
+		SBOM:      content,
+	}
+
+	if opts.Attach != "" {
+		report.Referrer, err = ir.attachSBOM(ctx, opts, mediaType, content)
+		if err != nil {
+			return nil, fmt.Errorf("attaching the SBOM to %s: %w", opts.Attach, err)
+		}
+	}
+	return report, nil
+}
+
+// scanImage returns the packages installed in the image, as found in the
+// package databases of its layers.
+func (ir *ImageEngine) scanImage(ctx context.Context, img *libimage.Image) (*sbom.Inventory, error) {
+	ref, err := img.StorageReference()
+	if err != nil {
+		return nil, err
+	}
+	src, err := ref.NewImageSource(ctx, ir.Libpod.SystemContext())
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+	rawManifest, manifestType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	m, err := manifest.FromBlob(rawManifest, manifestType)
+	if err != nil {
+		return nil, err
+	}
+
+	scanner := sbom.NewScanner()
+	for _, layer := range m.LayerInfos() {
+		if layer.EmptyLayer {
+			continue
+		}
+		rc, _, err := src.GetBlob(ctx, layer.BlobInfo, none.NoCache)
+		if err != nil {
+			return nil, err
+		}
+		err = scanner.AddLayer(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("reading layer %s: %w", layer.Digest, err)
+		}
+	}
+	return scanner.Inventory()
+}
+
+// attachSBOM pushes the SBOM as an artifact whose subject is the image in the
+// registry, so that registries supporting the OCI referrers API list it as a
+// referrer of the image.  The artifact is tagged after the digest of the
+// image, like in the referrers tag schema, and kept in the local artifact
+// store.
+func (ir *ImageEngine) attachSBOM(ctx context.Context, opts entities.ImageSBOMOptions, mediaType string, content []byte) (string, error) {
+	named, err := reference.ParseNormalizedNamed(opts.Attach)
+	if err != nil {
+		return "", err
+	}
+	named = reference.TagNameOnly(named)
+	ref, err := docker.NewReference(named)
+	if err != nil {
+		return "", err
+	}
+
+	sys := *ir.Libpod.SystemContext()
+	if opts.Authfile != "" {
+		sys.AuthFilePath = opts.Authfile
+	}
+	if opts.Username != "" || opts.Password != "" {
+		sys.DockerAuthConfig = &types.DockerAuthConfig{Username: opts.Username, Password: opts.Password}
+	}
+	if opts.SkipTLSVerify != types.OptionalBoolUndefined {
+		sys.DockerInsecureSkipTLSVerify = opts.SkipTLSVerify
+	}
+	src, err := ref.NewImageSource(ctx, &sys)
+	if err != nil {
+		return "", err
+	}
+	rawManifest, manifestType, err := src.GetManifest(ctx, nil)
+	src.Close()
+	if err != nil {
+		return "", err
+	}
+	subject := imgspecv1.Descriptor{
+		MediaType: manifestType,
+		Digest:    digest.FromBytes(rawManifest),
+		Size:      int64(len(rawManifest)),
+	}
+
+	artifactName := fmt.Sprintf("%s:%s-%s.%s", named.Name(), subject.Digest.Algorithm(), subject.Digest.Encoded(), opts.Format)
+	blob := entities.ArtifactBlob{
+		BlobReader: bytes.NewReader(content),
+		FileName:   "sbom." + opts.Format + ".json",
+	}
+	addOpts := entities.ArtifactAddOptions{
+		ArtifactMIMEType: mediaType,
+		FileMIMEType:     mediaType,
+		Replace:          true,
+		Subject:          &subject,
+	}
+	if _, err := ir.ArtifactAdd(ctx, artifactName, []entities.ArtifactBlob{blob}, addOpts); err != nil {
+		return "", err
+	}
+
+	pushOpts := entities.ArtifactPushOptions{ImagePushOptions: entities.ImagePushOptions{
+		Authfile:      opts.Authfile,
+		Username:      opts.Username,
+		Password:      opts.Password,
+		SkipTLSVerify: opts.SkipTLSVerify,
+		Quiet:         true,
+	}}
+	if _, err := ir.ArtifactPush(ctx, artifactName, pushOpts); err != nil {
+		return "", err
+	}
+	return artifactName, nil
+}
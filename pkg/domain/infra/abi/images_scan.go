@@ -0,0 +1,63 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/scan"
+	"github.com/sirupsen/logrus"
+)
+
+func (ir *ImageEngine) Scan(ctx context.Context, nameOrID string, opts entities.ImageScanOptions) (*entities.ImageScanReport, error) {
+	cfg, err := scan.ReadConfig()
+	if err != nil {
+		return nil, err
+	}
+	threshold := opts.Threshold
+	if opts.RunPolicy {
+		threshold = cfg.RunThreshold
+		if threshold == "" {
+			// nothing to enforce, do not pay for a scan
+			return &entities.ImageScanReport{Image: nameOrID}, nil
+		}
+	}
+	var severity scan.Severity
+	if threshold != "" {
+		if severity, err = scan.ParseSeverity(threshold); err != nil {
+			return nil, err
+		}
+	}
+
+	img, _, err := ir.Libpod.LibimageRuntime().LookupImage(nameOrID, nil)
+	if err != nil {
+		return nil, err
+	}
+	scanner, err := cfg.LookupScanner(opts.Scanner)
+	if err != nil {
+		return nil, err
+	}
+
+	name := nameOrID
+	if names := img.Names(); len(names) > 0 {
+		name = names[0]
+	}
+	mountPoint, err := img.Mount(ctx, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("mounting image %s: %w", name, err)
+	}
+	defer func() {
+		if err := img.Unmount(false); err != nil {
+			logrus.Errorf("Unmounting image %s: %v", name, err)
+		}
+	}()
+
+	report, err := scanner.Scan(ctx, scan.Target{Image: name, ImageID: img.ID(), Rootfs: mountPoint})
+	if err != nil {
+		return nil, err
+	}
+	report.Evaluate(severity, cfg.Ignore)
+	return report, nil
+}
@@ -6,18 +6,33 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"slices"
 	"strconv"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/libpod/events"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
 	"go.podman.io/common/libnetwork/pasta"
 	"go.podman.io/common/libnetwork/slirp4netns"
 	"go.podman.io/common/libnetwork/types"
 	netutil "go.podman.io/common/libnetwork/util"
 )
 
+const (
+	// sriovVFOption selects the SR-IOV virtual function of the parent
+	// interface of a macvlan or ipvlan network, either by number or "auto".
+	sriovVFOption = "sriov_vf"
+	// sriovMACOption sets the MAC address of the selected virtual function.
+	sriovMACOption = "sriov_mac"
+
+	// labels recording the virtual function assigned to a network
+	sriovPFLabel  = "io.podman.network.sriov.pf"
+	sriovVFLabel  = "io.podman.network.sriov.vf"
+	sriovMACLabel = "io.podman.network.sriov.mac"
+)
+
 func (ic *ContainerEngine) NetworkUpdate(_ context.Context, netName string, options entities.NetworkUpdateOptions) error {
 	var networkUpdateOptions types.NetworkUpdateOptions
 	networkUpdateOptions.AddDNSServers = options.AddDNSServers
@@ -105,11 +120,31 @@ func (ic *ContainerEngine) NetworkInspect(_ context.Context, namesOrIds []string
 }
 
 func (ic *ContainerEngine) NetworkReload(_ context.Context, names []string, options entities.NetworkReloadOptions) ([]*entities.NetworkReloadReport, error) {
+	if options.Graceful && !options.All {
+		return nil, fmt.Errorf("graceful network reload can only be used with all containers: %w", define.ErrInvalidArg)
+	}
 	containers, err := getContainers(ic.Libpod, getContainersOptions{all: options.All, latest: options.Latest, names: names})
 	if err != nil {
 		return nil, err
 	}
 
+	if options.Graceful {
+		// The firewall rules of all containers are replaced in one go,
+		// report every running container with bridge networking.
+		if err := ic.Libpod.ReloadNetworkFirewall(); err != nil {
+			return nil, err
+		}
+		reports := make([]*entities.NetworkReloadReport, 0, len(containers))
+		for _, ctr := range containers {
+			state, err := ctr.State()
+			if err != nil || state != define.ContainerStateRunning || !ctr.Config().NetMode.IsBridge() {
+				continue
+			}
+			reports = append(reports, &entities.NetworkReloadReport{Id: ctr.ID()})
+		}
+		return reports, nil
+	}
+
 	reports := make([]*entities.NetworkReloadReport, 0, len(containers))
 	for _, ctr := range containers {
 		report := new(entities.NetworkReloadReport)
@@ -168,7 +203,7 @@ func (ic *ContainerEngine) NetworkRm(ctx context.Context, namesOrIds []string, o
 		if err != nil && !errors.Is(err, define.ErrNoSuchNetwork) {
 			return reports, err
 		}
-		if err := ic.Libpod.Network().NetworkRemove(name); err != nil {
+		if err := ic.removeNetwork(name, net.Driver); err != nil {
 			report.Err = err
 		}
 		if len(net.Name) != 0 {
@@ -183,8 +218,29 @@ func (ic *ContainerEngine) NetworkCreate(_ context.Context, network types.Networ
 	if slices.Contains([]string{"none", "host", "bridge", "private", slirp4netns.BinaryName, pasta.BinaryName, "container", "ns", "default"}, network.Name) {
 		return nil, fmt.Errorf("cannot create network with name %q because it conflicts with a valid network mode", network.Name)
 	}
+	// do not program another virtual function or generate another key
+	// when an existing network is reused
+	reuse := false
+	if createOptions != nil && createOptions.IgnoreIfExists && network.Name != "" {
+		_, err := ic.Libpod.Network().NetworkInspect(network.Name)
+		reuse = err == nil
+	}
+	removeWireGuardKey := func() {}
+	if !reuse {
+		if err := ic.assignSRIOVVirtualFunction(&network); err != nil {
+			return nil, err
+		}
+		if network.Driver == define.WireGuardDriver {
+			cleanup, err := ic.prepareWireGuardNetwork(&network)
+			if err != nil {
+				return nil, err
+			}
+			removeWireGuardKey = cleanup
+		}
+	}
 	network, err := ic.Libpod.Network().NetworkCreate(network, createOptions)
 	if err != nil {
+		removeWireGuardKey()
 		return nil, err
 	}
 	ic.Libpod.NewNetworkEvent(events.Create, network.Name, network.ID, network.Driver)
@@ -236,12 +292,30 @@ func (ic *ContainerEngine) NetworkPrune(_ context.Context, options entities.Netw
 	for _, net := range nets {
 		pruneReport = append(pruneReport, &entities.NetworkPruneReport{
 			Name:  net.Name,
-			Error: ic.Libpod.Network().NetworkRemove(net.Name),
+			Error: ic.removeNetwork(net.Name, net.Driver),
 		})
 	}
 	return pruneReport, nil
 }
 
+// removeNetwork removes the network, and the WireGuard keys of this host for
+// WireGuard networks.
+func (ic *ContainerEngine) removeNetwork(name, driver string) error {
+	if err := ic.Libpod.Network().NetworkRemove(name); err != nil {
+		return err
+	}
+	if driver == define.WireGuardDriver {
+		dir, err := ic.wireGuardConfigDir(name)
+		if err == nil {
+			err = os.RemoveAll(dir)
+		}
+		if err != nil {
+			logrus.Warnf("Removing the WireGuard keys of network %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
 // danglingFilter function is special and not implemented in libnetwork filters
 func (ic *ContainerEngine) createDanglingFilterFunc(wantDangling bool) (types.FilterFunc, error) {
 	cons, err := ic.Libpod.GetAllContainers()
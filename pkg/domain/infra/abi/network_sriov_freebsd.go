@@ -0,0 +1,18 @@
+//go:build !remote
+
+package abi
+
+import (
+	"fmt"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"go.podman.io/common/libnetwork/types"
+)
+
+// assignSRIOVVirtualFunction is not supported on FreeBSD.
+func (ic *ContainerEngine) assignSRIOVVirtualFunction(network *types.Network) error {
+	if _, ok := network.Options[sriovVFOption]; ok {
+		return fmt.Errorf("SR-IOV virtual functions are not supported on FreeBSD: %w", define.ErrNotImplemented)
+	}
+	return nil
+}
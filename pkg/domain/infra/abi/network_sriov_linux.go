@@ -0,0 +1,169 @@
+//go:build !remote
+
+package abi
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/rootless"
+	"github.com/vishvananda/netlink"
+	"go.podman.io/common/libnetwork/types"
+	"go.podman.io/storage/pkg/fileutils"
+)
+
+const sysClassNet = "/sys/class/net"
+
+// assignSRIOVVirtualFunction binds a macvlan or ipvlan network to a virtual
+// function of the SR-IOV physical function set as network interface. The VF
+// is either picked by index or the first one not used by another network,
+// its MAC address is programmed on the physical function and the network
+// interface is replaced with the VF netdev.
+func (ic *ContainerEngine) assignSRIOVVirtualFunction(network *types.Network) error {
+	vfOpt, ok := network.Options[sriovVFOption]
+	if !ok {
+		if _, ok := network.Options[sriovMACOption]; ok {
+			return fmt.Errorf("option %s requires option %s: %w", sriovMACOption, sriovVFOption, define.ErrInvalidArg)
+		}
+		return nil
+	}
+	if network.Driver != types.MacVLANNetworkDriver && network.Driver != types.IPVLANNetworkDriver {
+		return fmt.Errorf("option %s is only supported with the macvlan and ipvlan drivers: %w", sriovVFOption, define.ErrInvalidArg)
+	}
+	pf := network.NetworkInterface
+	if pf == "" {
+		return fmt.Errorf("option %s requires the SR-IOV physical function to be set with --interface-name: %w", sriovVFOption, define.ErrInvalidArg)
+	}
+	if rootless.IsRootless() {
+		return errors.New("assigning SR-IOV virtual functions requires root privileges")
+	}
+
+	mac, err := parseSRIOVMAC(network.Options[sriovMACOption])
+	if err != nil {
+		return err
+	}
+
+	vfs, err := sriovVirtualFunctions(sysClassNet, pf)
+	if err != nil {
+		return err
+	}
+	nets, err := ic.Libpod.Network().NetworkList()
+	if err != nil {
+		return err
+	}
+	used := make([]string, 0, len(nets))
+	for _, n := range nets {
+		used = append(used, n.NetworkInterface)
+	}
+	vf, err := selectVirtualFunction(vfs, vfOpt, used)
+	if err != nil {
+		return fmt.Errorf("physical function %s: %w", pf, err)
+	}
+
+	pfLink, err := netlink.LinkByName(pf)
+	if err != nil {
+		return fmt.Errorf("looking up physical function %s: %w", pf, err)
+	}
+	if err := netlink.LinkSetVfHardwareAddr(pfLink, vf, mac); err != nil {
+		return fmt.Errorf("setting MAC address %s on virtual function %d of %s: %w", mac, vf, pf, err)
+	}
+
+	network.NetworkInterface = vfs[vf]
+	delete(network.Options, sriovVFOption)
+	delete(network.Options, sriovMACOption)
+	if network.Labels == nil {
+		network.Labels = make(map[string]string)
+	}
+	network.Labels[sriovPFLabel] = pf
+	network.Labels[sriovVFLabel] = strconv.Itoa(vf)
+	network.Labels[sriovMACLabel] = mac.String()
+	return nil
+}
+
+// sriovVirtualFunctions returns the netdev names of the virtual functions of
+// the given physical function indexed by their VF number.
+func sriovVirtualFunctions(sysNet, pf string) (map[int]string, error) {
+	device := filepath.Join(sysNet, pf, "device")
+	if err := fileutils.Exists(filepath.Join(device, "sriov_totalvfs")); err != nil {
+		return nil, fmt.Errorf("%s is not an SR-IOV physical function: %w", pf, define.ErrInvalidArg)
+	}
+	matches, err := filepath.Glob(filepath.Join(device, "virtfn*"))
+	if err != nil {
+		return nil, err
+	}
+	vfs := make(map[int]string, len(matches))
+	for _, m := range matches {
+		index, err := strconv.Atoi(strings.TrimPrefix(filepath.Base(m), "virtfn"))
+		if err != nil {
+			continue
+		}
+		entries, err := os.ReadDir(filepath.Join(m, "net"))
+		if err != nil || len(entries) == 0 {
+			// the VF is bound to a driver without netdev, e.g. vfio-pci
+			continue
+		}
+		vfs[index] = entries[0].Name()
+	}
+	if len(vfs) == 0 {
+		return nil, fmt.Errorf("%s has no virtual functions with a network device, enable them with `echo N > %s`: %w",
+			pf, filepath.Join(device, "sriov_numvfs"), define.ErrInvalidArg)
+	}
+	return vfs, nil
+}
+
+// selectVirtualFunction returns the requested VF number or, when vf is
+// "auto", the lowest one whose netdev is not in use by another network.
+func selectVirtualFunction(vfs map[int]string, vf string, used []string) (int, error) {
+	if vf != "auto" {
+		index, err := strconv.Atoi(vf)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s value %q, must be a VF number or auto: %w", sriovVFOption, vf, define.ErrInvalidArg)
+		}
+		netdev, ok := vfs[index]
+		if !ok {
+			return 0, fmt.Errorf("virtual function %d does not exist: %w", index, define.ErrInvalidArg)
+		}
+		if slices.Contains(used, netdev) {
+			return 0, fmt.Errorf("virtual function %d (%s) is already used by another network: %w", index, netdev, define.ErrInvalidArg)
+		}
+		return index, nil
+	}
+
+	indexes := make([]int, 0, len(vfs))
+	for index := range vfs {
+		indexes = append(indexes, index)
+	}
+	slices.Sort(indexes)
+	for _, index := range indexes {
+		if !slices.Contains(used, vfs[index]) {
+			return index, nil
+		}
+	}
+	return 0, fmt.Errorf("all %d virtual functions are already used by other networks: %w", len(vfs), define.ErrInvalidArg)
+}
+
+// parseSRIOVMAC parses the requested VF MAC address or generates a random
+// locally administered unicast one.
+func parseSRIOVMAC(value string) (net.HardwareAddr, error) {
+	if value != "" {
+		mac, err := net.ParseMAC(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s value: %w", sriovMACOption, err)
+		}
+		return mac, nil
+	}
+	mac := make(net.HardwareAddr, 6)
+	if _, err := rand.Read(mac); err != nil {
+		return nil, err
+	}
+	mac[0] = (mac[0] | 0x02) & 0xfe
+	return mac, nil
+}
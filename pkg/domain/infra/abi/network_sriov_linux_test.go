@@ -0,0 +1,80 @@
+//go:build !remote
+
+package abi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSRIOVVirtualFunctions(t *testing.T) {
+	sysNet := t.TempDir()
+	device := filepath.Join(sysNet, "enp1s0f0", "device")
+	require.NoError(t, os.MkdirAll(device, 0o755))
+
+	_, err := sriovVirtualFunctions(sysNet, "enp1s0f0")
+	assert.ErrorContains(t, err, "not an SR-IOV physical function")
+
+	require.NoError(t, os.WriteFile(filepath.Join(device, "sriov_totalvfs"), []byte("4\n"), 0o644))
+	_, err = sriovVirtualFunctions(sysNet, "enp1s0f0")
+	assert.ErrorContains(t, err, "has no virtual functions")
+
+	for vf, netdev := range map[string]string{"virtfn0": "enp1s0f0v0", "virtfn1": "enp1s0f0v1", "virtfn10": "enp1s0f0v10"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(device, vf, "net", netdev), 0o755))
+	}
+	// bound to vfio-pci, no netdev
+	require.NoError(t, os.MkdirAll(filepath.Join(device, "virtfn2"), 0o755))
+
+	vfs, err := sriovVirtualFunctions(sysNet, "enp1s0f0")
+	require.NoError(t, err)
+	assert.Equal(t, map[int]string{0: "enp1s0f0v0", 1: "enp1s0f0v1", 10: "enp1s0f0v10"}, vfs)
+}
+
+func TestSelectVirtualFunction(t *testing.T) {
+	vfs := map[int]string{0: "vf0", 1: "vf1", 10: "vf10"}
+
+	tests := []struct {
+		name    string
+		vf      string
+		used    []string
+		want    int
+		wantErr string
+	}{
+		{name: "auto picks lowest", vf: "auto", want: 0},
+		{name: "auto skips used", vf: "auto", used: []string{"vf0", "vf1"}, want: 10},
+		{name: "auto all used", vf: "auto", used: []string{"vf0", "vf1", "vf10"}, wantErr: "all 3 virtual functions are already used"},
+		{name: "by index", vf: "1", want: 1},
+		{name: "index in use", vf: "1", used: []string{"vf1"}, wantErr: "already used by another network"},
+		{name: "missing index", vf: "5", wantErr: "does not exist"},
+		{name: "invalid", vf: "first", wantErr: "must be a VF number or auto"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selectVirtualFunction(vfs, tt.vf, tt.used)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseSRIOVMAC(t *testing.T) {
+	mac, err := parseSRIOVMAC("02:11:22:33:44:55")
+	require.NoError(t, err)
+	assert.Equal(t, "02:11:22:33:44:55", mac.String())
+
+	_, err = parseSRIOVMAC("nomac")
+	assert.Error(t, err)
+
+	mac, err = parseSRIOVMAC("")
+	require.NoError(t, err)
+	assert.Len(t, mac, 6)
+	assert.Equal(t, byte(0x02), mac[0]&0x03, "random MAC must be locally administered unicast")
+}
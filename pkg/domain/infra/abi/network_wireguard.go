@@ -0,0 +1,235 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"go.podman.io/common/libnetwork/types"
+	"go.podman.io/storage/pkg/ioutils"
+	"golang.org/x/crypto/curve25519"
+)
+
+const (
+	// wireGuardEndpointOption is the address, host:port, the other hosts
+	// reach this host at.  The plugin listens on its port.
+	wireGuardEndpointOption = "endpoint"
+
+	// label recording the public key of this host
+	wireGuardPublicKeyLabel = "io.podman.network.wireguard.public_key"
+
+	// file of the WireGuard configuration directory of a network holding
+	// the private key of this host
+	wireGuardPrivateKeyFile = "private.key"
+)
+
+// wireGuardConfigDir returns the directory holding the private key and the
+// peers of the WireGuard network.  The name is checked first, it must not
+// lead out of the directory of the WireGuard networks.
+func (ic *ContainerEngine) wireGuardConfigDir(network string) (string, error) {
+	if !types.NameRegex.MatchString(network) {
+		return "", fmt.Errorf("network name %s invalid: %w", network, types.RegexError)
+	}
+	return filepath.Join(ic.Libpod.StorageConfig().GraphRoot, "wireguard", network), nil
+}
+
+// prepareWireGuardNetwork generates the key pair of this host for a new
+// WireGuard network, stores the private key for the plugin and records the
+// public key as a label.  It returns a function removing the key again.
+func (ic *ContainerEngine) prepareWireGuardNetwork(network *types.Network) (func(), error) {
+	if network.Name == "" {
+		return nil, fmt.Errorf("%s networks must be named: %w", define.WireGuardDriver, define.ErrInvalidArg)
+	}
+	endpoint, ok := network.Options[wireGuardEndpointOption]
+	if !ok {
+		return nil, fmt.Errorf("%s networks require the %s option, the address other hosts reach this host at: %w", define.WireGuardDriver, wireGuardEndpointOption, define.ErrInvalidArg)
+	}
+	if _, _, err := net.SplitHostPort(endpoint); err != nil {
+		return nil, fmt.Errorf("invalid %s option %q: %w", wireGuardEndpointOption, endpoint, err)
+	}
+	if _, ok := network.Options[define.WireGuardConfigDirOption]; ok {
+		return nil, fmt.Errorf("option %s is set by Podman: %w", define.WireGuardConfigDirOption, define.ErrInvalidArg)
+	}
+	dir, err := ic.wireGuardConfigDir(network.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	privateKey, publicKey, err := generateWireGuardKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(dir), 0o700); err != nil {
+		return nil, err
+	}
+	// Only remove what is created here, a directory left behind by a
+	// network of the same name is kept.
+	keyPath := filepath.Join(dir, wireGuardPrivateKeyFile)
+	cleanup := func() { _ = os.Remove(keyPath) }
+	if err := os.Mkdir(dir, 0o700); err == nil {
+		cleanup = func() { _ = os.RemoveAll(dir) }
+	} else if !errors.Is(err, os.ErrExist) {
+		return nil, err
+	}
+	if err := ioutils.AtomicWriteFile(keyPath, []byte(privateKey+"\n"), 0o600); err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	if network.Options == nil {
+		network.Options = make(map[string]string)
+	}
+	network.Options[define.WireGuardConfigDirOption] = dir
+	if network.Labels == nil {
+		network.Labels = make(map[string]string)
+	}
+	network.Labels[wireGuardPublicKeyLabel] = publicKey
+	return cleanup, nil
+}
+
+// generateWireGuardKey returns a new private key and its public key, base64
+// encoded like the keys of wg(8).
+func generateWireGuardKey() (string, string, error) {
+	var privateKey [curve25519.ScalarSize]byte
+	if _, err := rand.Read(privateKey[:]); err != nil {
+		return "", "", err
+	}
+	// clamp the key as wg genkey does
+	privateKey[0] &= 248
+	privateKey[31] = (privateKey[31] & 127) | 64
+	publicKey, err := curve25519.X25519(privateKey[:], curve25519.Basepoint)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(privateKey[:]), base64.StdEncoding.EncodeToString(publicKey), nil
+}
+
+// wireGuardNetwork returns the WireGuard network with the given name.
+func (ic *ContainerEngine) wireGuardNetwork(name string) (types.Network, error) {
+	network, err := ic.Libpod.Network().NetworkInspect(name)
+	if err != nil {
+		return network, err
+	}
+	if network.Driver != define.WireGuardDriver {
+		return network, fmt.Errorf("network %s uses the %s driver, not %s: %w", network.Name, network.Driver, define.WireGuardDriver, define.ErrInvalidArg)
+	}
+	return network, nil
+}
+
+// NetworkWireGuardPeer returns this host as a peer of the WireGuard network,
+// with the containers on the network on this host.
+func (ic *ContainerEngine) NetworkWireGuardPeer(_ context.Context, name string) (*entities.NetworkWireGuardPeer, error) {
+	network, err := ic.wireGuardNetwork(name)
+	if err != nil {
+		return nil, err
+	}
+	statuses, err := ic.GetContainerNetStatuses()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get network status for containers: %w", err)
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	peer := &entities.NetworkWireGuardPeer{
+		Host:      hostname,
+		PublicKey: network.Labels[wireGuardPublicKeyLabel],
+		Endpoint:  network.Options[wireGuardEndpointOption],
+	}
+	for _, subnet := range network.Subnets {
+		peer.AllowedIPs = append(peer.AllowedIPs, subnet.Subnet.String())
+	}
+	for _, st := range statuses {
+		sb, ok := st.Status[network.Name]
+		if !ok {
+			continue
+		}
+		var ips []string
+		for _, netInt := range sb.Interfaces {
+			for _, netAddress := range netInt.Subnets {
+				ips = append(ips, netAddress.IPNet.IP.String())
+			}
+		}
+		if len(ips) == 0 {
+			continue
+		}
+		if peer.Containers == nil {
+			peer.Containers = make(map[string][]string)
+		}
+		peer.Containers[st.Name] = ips
+	}
+	return peer, nil
+}
+
+// NetworkWireGuardSetPeers replaces the peers of this host in the WireGuard
+// network.  This host is skipped if it is one of the peers.  The plugin
+// applies them when it sets up the network of a container, or with
+// podman network reload, the containers of the peers are added to
+// /etc/hosts of the containers on the network right away.
+func (ic *ContainerEngine) NetworkWireGuardSetPeers(_ context.Context, name string, peers []entities.NetworkWireGuardPeer) error {
+	network, err := ic.wireGuardNetwork(name)
+	if err != nil {
+		return err
+	}
+	publicKey := network.Labels[wireGuardPublicKeyLabel]
+	others := make([]entities.NetworkWireGuardPeer, 0, len(peers))
+	for _, peer := range peers {
+		if peer.PublicKey == publicKey {
+			continue
+		}
+		if err := validateWireGuardPeer(&peer, network.Subnets); err != nil {
+			return fmt.Errorf("peer %s: %w", peer.Host, err)
+		}
+		others = append(others, peer)
+	}
+	return ic.Libpod.SetWireGuardPeers(&network, others)
+}
+
+// validateWireGuardPeer checks a peer of a network with the given subnets on
+// this host, its allowed IPs must not overlap with them.
+func validateWireGuardPeer(peer *entities.NetworkWireGuardPeer, subnets []types.Subnet) error {
+	key, err := base64.StdEncoding.DecodeString(peer.PublicKey)
+	if err != nil || len(key) != curve25519.PointSize {
+		return fmt.Errorf("invalid public key %q: %w", peer.PublicKey, define.ErrInvalidArg)
+	}
+	if _, _, err := net.SplitHostPort(peer.Endpoint); err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", peer.Endpoint, err)
+	}
+	allowedNets := make([]*net.IPNet, 0, len(peer.AllowedIPs))
+	for _, allowed := range peer.AllowedIPs {
+		_, allowedNet, err := net.ParseCIDR(allowed)
+		if err != nil {
+			return fmt.Errorf("invalid allowed IPs %q: %w", allowed, err)
+		}
+		for _, subnet := range subnets {
+			if allowedNet.Contains(subnet.Subnet.IP) || subnet.Subnet.Contains(allowedNet.IP) {
+				return fmt.Errorf("allowed IPs %s overlap with the subnet %s of this host, the hosts of a WireGuard network need distinct subnets: %w", allowed, subnet.Subnet.String(), define.ErrInvalidArg)
+			}
+		}
+		allowedNets = append(allowedNets, allowedNet)
+	}
+	// the containers end up in /etc/hosts, they must be reached through
+	// the peer
+	for name, ips := range peer.Containers {
+		if !define.NameRegex.MatchString(name) {
+			return fmt.Errorf("invalid container name %q: %w", name, define.RegexError)
+		}
+		for _, ip := range ips {
+			addr := net.ParseIP(ip)
+			if addr == nil || !slices.ContainsFunc(allowedNets, func(allowedNet *net.IPNet) bool { return allowedNet.Contains(addr) }) {
+				return fmt.Errorf("address %q of container %s is not in the allowed IPs of the peer: %w", ip, name, define.ErrInvalidArg)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+//go:build !remote
+
+package abi
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/common/libnetwork/types"
+	"golang.org/x/crypto/curve25519"
+)
+
+func TestGenerateWireGuardKey(t *testing.T) {
+	privateKey, publicKey, err := generateWireGuardKey()
+	require.NoError(t, err)
+
+	private, err := base64.StdEncoding.DecodeString(privateKey)
+	require.NoError(t, err)
+	require.Len(t, private, curve25519.ScalarSize)
+	// clamped like the keys of wg genkey
+	assert.Zero(t, private[0]&7)
+	assert.Equal(t, byte(64), private[31]&192)
+
+	public, err := curve25519.X25519(private, curve25519.Basepoint)
+	require.NoError(t, err)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(public), publicKey)
+
+	otherKey, _, err := generateWireGuardKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, privateKey, otherKey)
+}
+
+func TestValidateWireGuardPeer(t *testing.T) {
+	_, publicKey, err := generateWireGuardKey()
+	require.NoError(t, err)
+	subnet, err := types.ParseCIDR("10.90.1.0/24")
+	require.NoError(t, err)
+	subnets := []types.Subnet{{Subnet: subnet}}
+
+	tests := []struct {
+		name string
+		peer entities.NetworkWireGuardPeer
+		err  string
+	}{
+		{
+			name: "valid",
+			peer: entities.NetworkWireGuardPeer{PublicKey: publicKey, Endpoint: "host2:51820", AllowedIPs: []string{"10.90.2.0/24"}},
+		},
+		{
+			name: "invalid public key",
+			peer: entities.NetworkWireGuardPeer{PublicKey: "AAAA", Endpoint: "host2:51820"},
+			err:  "invalid public key",
+		},
+		{
+			name: "endpoint without port",
+			peer: entities.NetworkWireGuardPeer{PublicKey: publicKey, Endpoint: "host2"},
+			err:  "invalid endpoint",
+		},
+		{
+			name: "invalid allowed IPs",
+			peer: entities.NetworkWireGuardPeer{PublicKey: publicKey, Endpoint: "host2:51820", AllowedIPs: []string{"10.90.2.0"}},
+			err:  "invalid allowed IPs",
+		},
+		{
+			name: "overlapping subnet",
+			peer: entities.NetworkWireGuardPeer{PublicKey: publicKey, Endpoint: "host2:51820", AllowedIPs: []string{"10.90.0.0/16"}},
+			err:  "overlap with the subnet 10.90.1.0/24",
+		},
+		{
+			name: "containers",
+			peer: entities.NetworkWireGuardPeer{PublicKey: publicKey, Endpoint: "host2:51820", AllowedIPs: []string{"10.90.2.0/24"}, Containers: map[string][]string{"web": {"10.90.2.2"}}},
+		},
+		{
+			name: "invalid container name",
+			peer: entities.NetworkWireGuardPeer{PublicKey: publicKey, Endpoint: "host2:51820", AllowedIPs: []string{"10.90.2.0/24"}, Containers: map[string][]string{"web host": {"10.90.2.2"}}},
+			err:  `invalid container name "web host"`,
+		},
+		{
+			name: "container address outside of the allowed IPs",
+			peer: entities.NetworkWireGuardPeer{PublicKey: publicKey, Endpoint: "host2:51820", AllowedIPs: []string{"10.90.2.0/24"}, Containers: map[string][]string{"web": {"10.90.1.2"}}},
+			err:  `address "10.90.1.2" of container web is not in the allowed IPs`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateWireGuardPeer(&tt.peer, subnets)
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestWireGuardConfigDirInvalidName(t *testing.T) {
+	ic := &ContainerEngine{}
+	for _, name := range []string{"../..", "..", "a/../..", "/etc"} {
+		_, err := ic.wireGuardConfigDir(name)
+		assert.ErrorIs(t, err, types.ErrInvalidName, name)
+	}
+}
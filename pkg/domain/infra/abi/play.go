@@ -273,6 +273,14 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 		return nil, fmt.Errorf("unable to sort kube kinds: %w", err)
 	}
 
+	var networkPolicies map[string]*define.NetworkPolicy
+	if options.NetworkPolicy {
+		networkPolicies, err = getKubeNetworkPolicies(documentList)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	ipIndex := 0
 
 	var configMaps []v1.ConfigMap
@@ -372,7 +380,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, err
 			}
 
-			r, proxies, err := ic.playKubePod(ctx, podTemplateSpec.ObjectMeta.Name, &podTemplateSpec, options, &ipIndex, podYAML.Annotations, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubePod(ctx, podTemplateSpec.ObjectMeta.Name, &podTemplateSpec, options, &ipIndex, podYAML.Annotations, configMaps, networkPolicies, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -388,7 +396,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube DaemonSet: %w", err)
 			}
 
-			r, proxies, err := ic.playKubeDaemonSet(ctx, &daemonSetYAML, options, &ipIndex, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubeDaemonSet(ctx, &daemonSetYAML, options, &ipIndex, configMaps, networkPolicies, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -404,7 +412,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube Deployment: %w", err)
 			}
 
-			r, proxies, err := ic.playKubeDeployment(ctx, &deploymentYAML, options, &ipIndex, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubeDeployment(ctx, &deploymentYAML, options, &ipIndex, configMaps, networkPolicies, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -420,7 +428,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 				return nil, fmt.Errorf("unable to read YAML as Kube Job: %w", err)
 			}
 
-			r, proxies, err := ic.playKubeJob(ctx, &jobYAML, options, &ipIndex, configMaps, serviceContainer)
+			r, proxies, err := ic.playKubeJob(ctx, &jobYAML, options, &ipIndex, configMaps, networkPolicies, serviceContainer)
 			if err != nil {
 				return nil, err
 			}
@@ -476,6 +484,11 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 			}
 			report.Secrets = append(report.Secrets, entities.PlaySecret{CreateReport: r})
 			validKinds++
+		case "NetworkPolicy":
+			// Translated into the network policies of the pods above.
+			if !options.NetworkPolicy {
+				logrus.Warnf("Kube kind NetworkPolicy is only enforced with --network-policy")
+			}
 		default:
 			logrus.Infof("Kube kind %s not supported", kind)
 			continue
@@ -540,7 +553,7 @@ func (ic *ContainerEngine) PlayKube(ctx context.Context, body io.Reader, options
 	return report, nil
 }
 
-func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML *v1apps.DaemonSet, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML *v1apps.DaemonSet, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, networkPolicies map[string]*define.NetworkPolicy, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		daemonSetName string
 		podSpec       v1.PodTemplateSpec
@@ -554,7 +567,7 @@ func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML
 	podSpec = daemonSetYAML.Spec.Template
 
 	podName := fmt.Sprintf("%s-pod", daemonSetName)
-	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, daemonSetYAML.Annotations, configMaps, serviceContainer)
+	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, daemonSetYAML.Annotations, configMaps, networkPolicies, serviceContainer)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
 	}
@@ -563,7 +576,7 @@ func (ic *ContainerEngine) playKubeDaemonSet(ctx context.Context, daemonSetYAML
 	return &report, proxies, nil
 }
 
-func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAML *v1apps.Deployment, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAML *v1apps.Deployment, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, networkPolicies map[string]*define.NetworkPolicy, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		deploymentName string
 		podSpec        v1.PodTemplateSpec
@@ -585,7 +598,7 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 	podSpec = deploymentYAML.Spec.Template
 
 	podName := fmt.Sprintf("%s-pod", deploymentName)
-	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, deploymentYAML.Annotations, configMaps, serviceContainer)
+	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, deploymentYAML.Annotations, configMaps, networkPolicies, serviceContainer)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
 	}
@@ -594,7 +607,7 @@ func (ic *ContainerEngine) playKubeDeployment(ctx context.Context, deploymentYAM
 	return &report, proxies, nil
 }
 
-func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1.Job, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1.Job, options entities.PlayKubeOptions, ipIndex *int, configMaps []v1.ConfigMap, networkPolicies map[string]*define.NetworkPolicy, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	var (
 		jobName string
 		podSpec v1.PodTemplateSpec
@@ -608,7 +621,7 @@ func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1.Job, opt
 	podSpec = jobYAML.Spec.Template
 
 	podName := fmt.Sprintf("%s-pod", jobName)
-	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, jobYAML.Annotations, configMaps, serviceContainer)
+	podReport, proxies, err := ic.playKubePod(ctx, podName, &podSpec, options, ipIndex, jobYAML.Annotations, configMaps, networkPolicies, serviceContainer)
 	if err != nil {
 		return nil, nil, fmt.Errorf("encountered while bringing up pod %s: %w", podName, err)
 	}
@@ -617,7 +630,7 @@ func (ic *ContainerEngine) playKubeJob(ctx context.Context, jobYAML *v1.Job, opt
 	return &report, proxies, nil
 }
 
-func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, options entities.PlayKubeOptions, ipIndex *int, annotations map[string]string, configMaps []v1.ConfigMap, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
+func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podYAML *v1.PodTemplateSpec, options entities.PlayKubeOptions, ipIndex *int, annotations map[string]string, configMaps []v1.ConfigMap, networkPolicies map[string]*define.NetworkPolicy, serviceContainer *libpod.Container) (*entities.PlayKubeReport, []*notifyproxy.NotifyProxy, error) {
 	cfg, err := ic.Libpod.GetConfigNoCopy()
 	if err != nil {
 		return nil, nil, err
@@ -778,6 +791,11 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		return nil, nil, err
 	}
 
+	var runtimeClass string
+	if podYAML.Spec.RuntimeClassName != nil {
+		runtimeClass = *podYAML.Spec.RuntimeClassName
+	}
+
 	volumes, err := kube.InitializeVolumes(podYAML.Spec.Volumes, configMaps, secretsManager, mountLabel)
 	if err != nil {
 		return nil, nil, err
@@ -884,6 +902,13 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 		}
 	}
 
+	if policy := networkPolicies[podName]; policy != nil {
+		if podSpec.PodSpecGen.InfraContainerSpec == nil || podOpt.Net.Network.NSMode != specgen.Bridge {
+			return nil, nil, fmt.Errorf("NetworkPolicy %s: only pods with an infra container on bridge networks can be isolated, pod %s cannot", strings.Join(policy.Policies, ", "), podName)
+		}
+		podSpec.PodSpecGen.InfraContainerSpec.NetworkPolicy = policy
+	}
+
 	// Add the original container names from the kube yaml as aliases for it. This will allow network to work with
 	// both just containerName as well as containerName-podName.
 	// In the future, we want to extend this to the CLI as well, where the name of the container created will not have
@@ -990,6 +1015,7 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 			PodInfraID:         podInfraID,
 			PodName:            podName,
 			PodSecurityContext: podYAML.Spec.SecurityContext,
+			RuntimeClass:       runtimeClass,
 			ReadOnly:           readOnly,
 			RestartPolicy:      define.RestartPolicyNo,
 			SeccompPaths:       seccompPaths,
@@ -1080,6 +1106,7 @@ func (ic *ContainerEngine) playKubePod(ctx context.Context, podName string, podY
 			PodInfraID:         podInfraID,
 			PodName:            podName,
 			PodSecurityContext: podYAML.Spec.SecurityContext,
+			RuntimeClass:       runtimeClass,
 			RestartPolicy:      podSpec.PodSpecGen.RestartPolicy, // pass the restart policy to the container (https://github.com/containers/podman/issues/20903)
 			ReadOnly:           readOnly,
 			SeccompPaths:       seccompPaths,
@@ -1676,9 +1703,10 @@ func getBuildFile(imageName string, cwd string) (string, error) {
 
 func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, options entities.PlayKubeDownOptions) (*entities.PlayKubeReport, error) {
 	var (
-		podNames    []string
-		volumeNames []string
-		secretNames []string
+		podNames        []string
+		volumeNames     []string
+		dataVolumeNames []string
+		secretNames     []string
 	)
 	reports := new(entities.PlayKubeReport)
 
@@ -1719,9 +1747,9 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 				case vs.PersistentVolumeClaim != nil:
 					volumeNames = append(volumeNames, vs.PersistentVolumeClaim.ClaimName)
 				case vs.ConfigMap != nil:
-					volumeNames = append(volumeNames, vs.ConfigMap.Name)
+					dataVolumeNames = append(dataVolumeNames, vs.ConfigMap.Name)
 				case vs.Secret != nil:
-					volumeNames = append(volumeNames, vs.Secret.SecretName)
+					dataVolumeNames = append(dataVolumeNames, vs.Secret.SecretName)
 				}
 			}
 		case "DaemonSet":
@@ -1796,9 +1824,13 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 	}
 
 	// Add the reports
+	stopTimeout := -1
+	if options.Timeout != nil {
+		stopTimeout = int(*options.Timeout)
+	}
 	reports.StopReport, err = ic.PodStop(ctx, podNames, entities.PodStopOptions{
 		Ignore:  true,
-		Timeout: -1,
+		Timeout: stopTimeout,
 	})
 	if err != nil {
 		return nil, err
@@ -1809,18 +1841,27 @@ func (ic *ContainerEngine) PlayKubeDown(ctx context.Context, body io.Reader, opt
 		return nil, err
 	}
 
-	reports.SecretRmReport, err = ic.SecretRm(ctx, secretNames, entities.SecretRmOptions{Ignore: true})
-	if err != nil {
-		return nil, err
+	if !options.KeepSecrets {
+		reports.SecretRmReport, err = ic.SecretRm(ctx, secretNames, entities.SecretRmOptions{Ignore: true})
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if options.Force {
+	if options.Force || options.Volumes {
 		reports.VolumeRmReport, err = ic.VolumeRm(ctx, volumeNames, entities.VolumeRmOptions{Ignore: true})
 		if err != nil {
 			return nil, err
 		}
 	}
 
+	if options.Force || options.ConfigMaps {
+		reports.ConfigMapRmReport, err = ic.VolumeRm(ctx, dataVolumeNames, entities.VolumeRmOptions{Ignore: true})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Remove the service container to ensure it is removed before we return for the remote case
 	// Needed for the clean up with podman kube play --wait in the remote case
 	if len(serviceCtrIDs) > 0 {
@@ -0,0 +1,260 @@
+//go:build !remote
+
+package abi
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	v1apps "github.com/dmikushin/podman-shared/pkg/k8s.io/api/apps/v1"
+	v1 "github.com/dmikushin/podman-shared/pkg/k8s.io/api/core/v1"
+	networkingv1 "github.com/dmikushin/podman-shared/pkg/k8s.io/api/networking/v1"
+	metav1 "github.com/dmikushin/podman-shared/pkg/k8s.io/apimachinery/pkg/apis/meta/v1"
+	"github.com/dmikushin/podman-shared/pkg/k8s.io/apimachinery/pkg/util/intstr"
+	"github.com/sirupsen/logrus"
+	"sigs.k8s.io/yaml"
+)
+
+// kubePodTemplate is a pod created by kube play, with the name it is created
+// under.
+type kubePodTemplate struct {
+	name     string
+	template v1.PodTemplateSpec
+}
+
+// getKubeNetworkPolicies translates the NetworkPolicy objects of the kube
+// YAML documents into the network policies of the pods they select, by pod
+// name.  Podman has no namespaces: a namespaceSelector selects the pods of
+// the documents.
+func getKubeNetworkPolicies(documentList [][]byte) (map[string]*define.NetworkPolicy, error) {
+	var (
+		pods     []kubePodTemplate
+		policies []networkingv1.NetworkPolicy
+	)
+	for _, document := range documentList {
+		kind, err := getKubeKind(document)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read kube YAML: %w", err)
+		}
+		switch kind {
+		case "Pod":
+			var podYAML v1.Pod
+			if err := yaml.Unmarshal(document, &podYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Pod: %w", err)
+			}
+			pods = append(pods, kubePodTemplate{podYAML.Name, v1.PodTemplateSpec{ObjectMeta: podYAML.ObjectMeta, Spec: podYAML.Spec}})
+		case "DaemonSet":
+			var daemonSetYAML v1apps.DaemonSet
+			if err := yaml.Unmarshal(document, &daemonSetYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube DaemonSet: %w", err)
+			}
+			pods = append(pods, kubePodTemplate{daemonSetYAML.Name + "-pod", daemonSetYAML.Spec.Template})
+		case "Deployment":
+			var deploymentYAML v1apps.Deployment
+			if err := yaml.Unmarshal(document, &deploymentYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Deployment: %w", err)
+			}
+			pods = append(pods, kubePodTemplate{deploymentYAML.Name + "-pod", deploymentYAML.Spec.Template})
+		case "Job":
+			var jobYAML v1.Job
+			if err := yaml.Unmarshal(document, &jobYAML); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube Job: %w", err)
+			}
+			pods = append(pods, kubePodTemplate{jobYAML.Name + "-pod", jobYAML.Spec.Template})
+		case "NetworkPolicy":
+			var policy networkingv1.NetworkPolicy
+			if err := yaml.Unmarshal(document, &policy); err != nil {
+				return nil, fmt.Errorf("unable to read YAML as Kube NetworkPolicy: %w", err)
+			}
+			policies = append(policies, policy)
+		}
+	}
+
+	result := make(map[string]*define.NetworkPolicy)
+	for _, policy := range policies {
+		ingress, egress, err := networkPolicyRules(policy, pods)
+		if err != nil {
+			return nil, fmt.Errorf("NetworkPolicy %s: %w", policy.Name, err)
+		}
+		isolateIngress, isolateEgress := len(policy.Spec.PolicyTypes) == 0, len(policy.Spec.Egress) > 0
+		for _, policyType := range policy.Spec.PolicyTypes {
+			switch policyType {
+			case networkingv1.PolicyTypeIngress:
+				isolateIngress = true
+			case networkingv1.PolicyTypeEgress:
+				isolateEgress = true
+			default:
+				return nil, fmt.Errorf("NetworkPolicy %s: invalid policy type %q", policy.Name, policyType)
+			}
+		}
+
+		selected := 0
+		for _, pod := range pods {
+			matches, err := labelSelectorMatches(&policy.Spec.PodSelector, pod.template.Labels)
+			if err != nil {
+				return nil, fmt.Errorf("NetworkPolicy %s: %w", policy.Name, err)
+			}
+			if !matches {
+				continue
+			}
+			selected++
+			p, ok := result[pod.name]
+			if !ok {
+				p = &define.NetworkPolicy{}
+				result[pod.name] = p
+			}
+			p.Policies = append(p.Policies, policy.Name)
+			if isolateIngress {
+				p.IsolateIngress = true
+				p.Ingress = append(p.Ingress, ingress...)
+			}
+			if isolateEgress {
+				p.IsolateEgress = true
+				p.Egress = append(p.Egress, egress...)
+			}
+		}
+		if selected == 0 {
+			logrus.Warnf("NetworkPolicy %s does not select any pod", policy.Name)
+		}
+	}
+	return result, nil
+}
+
+// networkPolicyRules translates the ingress and egress rules of the policy.
+func networkPolicyRules(policy networkingv1.NetworkPolicy, pods []kubePodTemplate) ([]define.NetworkPolicyRule, []define.NetworkPolicyRule, error) {
+	ingress := make([]define.NetworkPolicyRule, 0, len(policy.Spec.Ingress))
+	for _, rule := range policy.Spec.Ingress {
+		r, err := networkPolicyRule(rule.From, rule.Ports, pods)
+		if err != nil {
+			return nil, nil, err
+		}
+		ingress = append(ingress, r)
+	}
+	egress := make([]define.NetworkPolicyRule, 0, len(policy.Spec.Egress))
+	for _, rule := range policy.Spec.Egress {
+		r, err := networkPolicyRule(rule.To, rule.Ports, pods)
+		if err != nil {
+			return nil, nil, err
+		}
+		egress = append(egress, r)
+	}
+	return ingress, egress, nil
+}
+
+// networkPolicyRule translates the peers and ports of a rule.  The pod
+// selectors are resolved to the names of the pods, named ports to the
+// container ports of the pods with that name.
+func networkPolicyRule(peers []networkingv1.NetworkPolicyPeer, ports []networkingv1.NetworkPolicyPort, pods []kubePodTemplate) (define.NetworkPolicyRule, error) {
+	rule := define.NetworkPolicyRule{AllPeers: len(peers) == 0}
+	for _, peer := range peers {
+		if peer.IPBlock != nil {
+			if peer.PodSelector != nil || peer.NamespaceSelector != nil {
+				return rule, fmt.Errorf("a peer with an ipBlock cannot have a podSelector or namespaceSelector")
+			}
+			if _, _, err := net.ParseCIDR(peer.IPBlock.CIDR); err != nil {
+				return rule, fmt.Errorf("invalid ipBlock: %w", err)
+			}
+			for _, except := range peer.IPBlock.Except {
+				if _, _, err := net.ParseCIDR(except); err != nil {
+					return rule, fmt.Errorf("invalid ipBlock except: %w", err)
+				}
+			}
+			rule.IPBlocks = append(rule.IPBlocks, define.NetworkPolicyIPBlock{CIDR: peer.IPBlock.CIDR, Except: peer.IPBlock.Except})
+			continue
+		}
+		for _, pod := range pods {
+			// Without a podSelector, the namespaceSelector selects all
+			// pods.
+			matches, err := labelSelectorMatches(peer.PodSelector, pod.template.Labels)
+			if err != nil {
+				return rule, err
+			}
+			if matches && !slices.Contains(rule.Pods, pod.name) {
+				rule.Pods = append(rule.Pods, pod.name)
+			}
+		}
+	}
+
+	for _, port := range ports {
+		protocol := v1.ProtocolTCP
+		if port.Protocol != nil {
+			protocol = *port.Protocol
+		}
+		switch {
+		case port.Port == nil:
+			rule.Ports = append(rule.Ports, define.NetworkPolicyPort{Protocol: strings.ToLower(string(protocol))})
+		case port.Port.Type == intstr.String:
+			found := false
+			for _, pod := range pods {
+				for _, ctr := range pod.template.Spec.Containers {
+					for _, p := range ctr.Ports {
+						ctrProtocol := p.Protocol
+						if ctrProtocol == "" {
+							ctrProtocol = v1.ProtocolTCP
+						}
+						if p.Name == port.Port.StrVal && ctrProtocol == protocol {
+							rule.Ports = append(rule.Ports, define.NetworkPolicyPort{Protocol: strings.ToLower(string(protocol)), Port: uint16(p.ContainerPort)})
+							found = true
+						}
+					}
+				}
+			}
+			if !found {
+				return rule, fmt.Errorf("no container port named %q", port.Port.StrVal)
+			}
+		default:
+			if port.Port.IntVal < 1 || port.Port.IntVal > 65535 {
+				return rule, fmt.Errorf("invalid port %d", port.Port.IntVal)
+			}
+			p := define.NetworkPolicyPort{Protocol: strings.ToLower(string(protocol)), Port: uint16(port.Port.IntVal)}
+			if port.EndPort != nil {
+				if *port.EndPort < port.Port.IntVal || *port.EndPort > 65535 {
+					return rule, fmt.Errorf("invalid endPort %d", *port.EndPort)
+				}
+				p.EndPort = uint16(*port.EndPort)
+			}
+			rule.Ports = append(rule.Ports, p)
+		}
+	}
+	return rule, nil
+}
+
+// labelSelectorMatches reports whether the labels match the selector.  A nil
+// or empty selector matches all labels.
+func labelSelectorMatches(selector *metav1.LabelSelector, labels map[string]string) (bool, error) {
+	if selector == nil {
+		return true, nil
+	}
+	for key, value := range selector.MatchLabels {
+		if v, ok := labels[key]; !ok || v != value {
+			return false, nil
+		}
+	}
+	for _, requirement := range selector.MatchExpressions {
+		value, ok := labels[requirement.Key]
+		switch requirement.Operator {
+		case metav1.LabelSelectorOpIn:
+			if !ok || !slices.Contains(requirement.Values, value) {
+				return false, nil
+			}
+		case metav1.LabelSelectorOpNotIn:
+			if ok && slices.Contains(requirement.Values, value) {
+				return false, nil
+			}
+		case metav1.LabelSelectorOpExists:
+			if !ok {
+				return false, nil
+			}
+		case metav1.LabelSelectorOpDoesNotExist:
+			if ok {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("invalid label selector operator %q", requirement.Operator)
+		}
+	}
+	return true, nil
+}
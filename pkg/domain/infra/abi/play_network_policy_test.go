@@ -0,0 +1,134 @@
+//go:build !remote
+
+package abi
+
+import (
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const networkPolicyYAML = `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  containers:
+  - name: web
+    image: nginx
+    ports:
+    - name: http
+      containerPort: 8080
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: client
+spec:
+  template:
+    metadata:
+      labels:
+        app: client
+    spec:
+      containers:
+      - name: client
+        image: alpine
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: web-ingress
+spec:
+  podSelector:
+    matchLabels:
+      app: web
+  ingress:
+  - from:
+    - podSelector:
+        matchExpressions:
+        - key: app
+          operator: In
+          values: [client]
+    - ipBlock:
+        cidr: 192.168.0.0/16
+        except: [192.168.1.0/24]
+    ports:
+    - port: http
+    - protocol: UDP
+      port: 5000
+      endPort: 5010
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: deny-egress
+spec:
+  podSelector: {}
+  policyTypes: [Egress]
+`
+
+func TestGetKubeNetworkPolicies(t *testing.T) {
+	documents, err := splitMultiDocYAML([]byte(networkPolicyYAML))
+	require.NoError(t, err)
+	policies, err := getKubeNetworkPolicies(documents)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]*define.NetworkPolicy{
+		"web": {
+			Policies:       []string{"web-ingress", "deny-egress"},
+			IsolateIngress: true,
+			Ingress: []define.NetworkPolicyRule{{
+				Pods:     []string{"client-pod"},
+				IPBlocks: []define.NetworkPolicyIPBlock{{CIDR: "192.168.0.0/16", Except: []string{"192.168.1.0/24"}}},
+				Ports: []define.NetworkPolicyPort{
+					{Protocol: "tcp", Port: 8080},
+					{Protocol: "udp", Port: 5000, EndPort: 5010},
+				},
+			}},
+			IsolateEgress: true,
+		},
+		"client-pod": {
+			Policies:      []string{"deny-egress"},
+			IsolateEgress: true,
+		},
+	}, policies)
+}
+
+func TestGetKubeNetworkPoliciesErrors(t *testing.T) {
+	for _, tt := range []struct {
+		name, spec, err string
+	}{
+		{
+			"unknown named port",
+			"ingress:\n  - ports:\n    - port: https",
+			`NetworkPolicy bad: no container port named "https"`,
+		},
+		{
+			"invalid cidr",
+			"ingress:\n  - from:\n    - ipBlock:\n        cidr: 10.0.0.1",
+			"NetworkPolicy bad: invalid ipBlock: invalid CIDR address: 10.0.0.1",
+		},
+		{
+			"invalid operator",
+			"podSelector:\n    matchExpressions:\n    - key: app\n      operator: Is",
+			`NetworkPolicy bad: invalid label selector operator "Is"`,
+		},
+		{
+			"invalid policy type",
+			"policyTypes: [Both]",
+			`NetworkPolicy bad: invalid policy type "Both"`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			documents, err := splitMultiDocYAML([]byte(networkPolicyYAML + "---\nkind: NetworkPolicy\nmetadata:\n  name: bad\nspec:\n  " + tt.spec + "\n"))
+			require.NoError(t, err)
+			_, err = getKubeNetworkPolicies(documents)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -6,16 +6,20 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/dmikushin/podman-shared/libpod"
 	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/libpod/logs"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	dfilters "github.com/dmikushin/podman-shared/pkg/domain/filters"
 	"github.com/dmikushin/podman-shared/pkg/signal"
 	"github.com/dmikushin/podman-shared/pkg/specgen"
 	"github.com/dmikushin/podman-shared/pkg/specgen/generate"
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/sirupsen/logrus"
 )
 
@@ -102,25 +106,19 @@ func (ic *ContainerEngine) PodLogs(ctx context.Context, nameOrID string, options
 		return err
 	}
 
-	ctrNames := []string{}
 	// Check if `kubectl pod logs -c ctrname <podname>` alike command is used
-	if options.ContainerName != "" {
-		ctrFound := false
-		for _, ctr := range podCtrs {
-			if ctr.ID() == options.ContainerName || ctr.Name() == options.ContainerName {
-				ctrNames = append(ctrNames, options.ContainerName)
-				ctrFound = true
-			}
-		}
-		if !ctrFound {
-			return fmt.Errorf("container %s is not in pod %s: %w", options.ContainerName, nameOrID, define.ErrNoSuchCtr)
-		}
-	} else {
-		// No container name specified select all containers
-		for _, ctr := range podCtrs {
-			ctrNames = append(ctrNames, ctr.Name())
+	if options.ContainerName == "" {
+		return ic.podLogs(ctx, pod[0], options)
+	}
+	ctrNames := []string{}
+	for _, ctr := range podCtrs {
+		if ctr.ID() == options.ContainerName || ctr.Name() == options.ContainerName {
+			ctrNames = append(ctrNames, options.ContainerName)
 		}
 	}
+	if len(ctrNames) == 0 {
+		return fmt.Errorf("container %s is not in pod %s: %w", options.ContainerName, nameOrID, define.ErrNoSuchCtr)
+	}
 
 	// PodLogsOptions are similar but contains few extra fields like ctrName
 	// So cast other values as is so we can reuse the code
@@ -129,6 +127,44 @@ func (ic *ContainerEngine) PodLogs(ctx context.Context, nameOrID string, options
 	return ic.ContainerLogs(ctx, ctrNames, containerLogsOpts)
 }
 
+// podLogs writes the interleaved logs of all containers of the pod, following
+// the containers joining the pod when following.
+func (ic *ContainerEngine) podLogs(ctx context.Context, pod *libpod.Pod, options entities.PodLogsOptions) error {
+	if options.StdoutWriter == nil && options.StderrWriter == nil {
+		return errors.New("no io.Writer set for pod logs")
+	}
+
+	var wg sync.WaitGroup
+	logOpts := &logs.LogOptions{
+		Multi:      true,
+		Details:    options.Details,
+		Follow:     options.Follow,
+		Since:      options.Since,
+		Until:      options.Until,
+		Tail:       options.Tail,
+		Timestamps: options.Timestamps,
+		Colors:     options.Colors,
+		UseName:    options.Names,
+		WaitGroup:  &wg,
+	}
+
+	logChannel := make(chan *logs.LogLine, 1)
+	if err := ic.Libpod.PodLog(ctx, pod, logOpts, logChannel); err != nil {
+		return err
+	}
+
+	go func() {
+		wg.Wait()
+		close(logChannel)
+	}()
+
+	for line := range logChannel {
+		line.Write(options.StdoutWriter, options.StderrWriter, logOpts)
+	}
+
+	return nil
+}
+
 func (ic *ContainerEngine) PodPause(ctx context.Context, namesOrIds []string, options entities.PodPauseOptions) ([]*entities.PodPauseReport, error) {
 	reports := []*entities.PodPauseReport{}
 	pods, err := getPodsByContext(options.All, options.Latest, namesOrIds, ic.Libpod)
@@ -200,7 +236,7 @@ func (ic *ContainerEngine) PodStop(ctx context.Context, namesOrIds []string, opt
 			Id:       p.ID(),
 			RawInput: p.Name(),
 		}
-		errs, err := p.StopWithTimeout(ctx, true, options.Timeout)
+		errs, err := p.StopWithJobs(ctx, true, options.Timeout, options.Jobs)
 		if err != nil && !errors.Is(err, define.ErrPodPartialFail) {
 			report.Errs = []error{err}
 			reports = append(reports, &report)
@@ -256,7 +292,7 @@ func (ic *ContainerEngine) PodStart(ctx context.Context, namesOrIds []string, op
 			Id:       p.ID(),
 			RawInput: p.Name(),
 		}
-		errs, err := p.Start(ctx)
+		errs, err := p.StartWithJobs(ctx, options.Jobs)
 		if err != nil && !errors.Is(err, define.ErrPodPartialFail) {
 			report.Errs = []error{err}
 			reports = append(reports, &report)
@@ -507,6 +543,14 @@ func (ic *ContainerEngine) PodPs(_ context.Context, options entities.PodPSOption
 			return nil, err
 		}
 	}
+	if options.Limit > 0 || options.Offset > 0 {
+		// select the page before getting the expensive information
+		// on the pods
+		sort.SliceStable(pds, func(i, j int) bool {
+			return pds[i].CreatedTime().After(pds[j].CreatedTime())
+		})
+		pds = util.Paginate(pds, options.Offset, options.Limit)
+	}
 
 	reports := make([]*entities.ListPodsReport, 0, len(pds))
 	for _, p := range pds {
@@ -568,3 +612,36 @@ func (ic *ContainerEngine) PodInspect(_ context.Context, nameOrIDs []string, opt
 	}
 	return podReport, errs, nil
 }
+
+func (ic *ContainerEngine) PodUpdate(_ context.Context, options *entities.PodUpdateOptions) (*entities.PodUpdateReport, error) {
+	if len(options.PublishAdd) == 0 && len(options.PublishRemove) == 0 {
+		return nil, fmt.Errorf("must provide at least one port to publish or remove to update a pod: %w", define.ErrInvalidArg)
+	}
+	pod, err := ic.Libpod.LookupPod(options.NameOrID)
+	if err != nil {
+		return nil, err
+	}
+	infra, err := pod.InfraContainer()
+	if err != nil {
+		return nil, fmt.Errorf("publishing ports of pod %s: %w", pod.Name(), err)
+	}
+	ports, err := infra.PortMappings()
+	if err != nil {
+		return nil, err
+	}
+	ports, err = generate.RemovePortMappings(ports, options.PublishRemove)
+	if err != nil {
+		return nil, err
+	}
+	ports, err = generate.ParsePortMapping(append(ports, options.PublishAdd...), nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := generate.CheckHostPortConflicts(ports); err != nil {
+		return nil, err
+	}
+	if err := pod.UpdatePortMappings(ports); err != nil {
+		return nil, err
+	}
+	return &entities.PodUpdateReport{Id: pod.ID(), Ports: ports}, nil
+}
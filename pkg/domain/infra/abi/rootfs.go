@@ -0,0 +1,267 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/common/libimage"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/storage/pkg/chrootarchive"
+	"go.podman.io/storage/pkg/ioutils"
+)
+
+const (
+	// rootfsDir is the directory of the prepared root file systems in the
+	// static directory.
+	rootfsDir = "rootfs"
+	// rootfsConfigFile describes a prepared root file system.
+	rootfsConfigFile = "rootfs.json"
+)
+
+// rootfsConfig is stored next to a prepared root file system.
+type rootfsConfig struct {
+	Image   string    `json:"image"`
+	ImageID string    `json:"imageID"`
+	Linked  bool      `json:"linked,omitempty"`
+	Created time.Time `json:"created"`
+}
+
+func (ir *ImageEngine) rootfsDir() (string, error) {
+	rtc, err := ir.Libpod.GetConfigNoCopy()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(rtc.Engine.StaticDir, rootfsDir), nil
+}
+
+// rootfsName returns the default name of a root file system prepared from
+// img, the last component of its repository or its short ID.
+func rootfsName(img *libimage.Image) string {
+	for _, name := range img.Names() {
+		named, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			continue
+		}
+		return path.Base(reference.Path(named))
+	}
+	return img.ID()[:12]
+}
+
+// RootfsPrepare creates a directory with the file system of an image, to be
+// used with --rootfs.  The image is copied, or only mounted with
+// options.Link, which avoids the copy of large images on shared storage but
+// keeps the image mounted until the root file system is removed.
+func (ir *ImageEngine) RootfsPrepare(ctx context.Context, nameOrID string, options entities.RootfsPrepareOptions) (*entities.RootfsPrepareReport, error) {
+	img, _, err := ir.Libpod.LibimageRuntime().LookupImage(nameOrID, nil)
+	if err != nil {
+		return nil, err
+	}
+	name := options.Name
+	if name == "" {
+		name = rootfsName(img)
+	}
+	if !define.NameRegex.MatchString(name) {
+		return nil, fmt.Errorf("invalid root file system name %q: %w", name, define.RegexError)
+	}
+	dir, err := ir.rootfsDir()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	rootfsPath := filepath.Join(dir, name)
+	if err := os.Mkdir(rootfsPath, 0o700); err != nil {
+		if !errors.Is(err, os.ErrExist) {
+			return nil, err
+		}
+		if !options.Replace {
+			return nil, fmt.Errorf("root file system %q already exists, use --replace to prepare it again", name)
+		}
+		if err := ir.removeRootfs(ctx, name); err != nil {
+			return nil, err
+		}
+		if err := os.Mkdir(rootfsPath, 0o700); err != nil {
+			return nil, err
+		}
+	}
+	report, err := ir.prepareRootfs(ctx, img, name, rootfsPath, options.Link)
+	if err != nil {
+		if err := os.RemoveAll(rootfsPath); err != nil {
+			logrus.Errorf("Removing root file system %q after prepare error: %v", name, err)
+		}
+		return nil, err
+	}
+	return report, nil
+}
+
+func (ir *ImageEngine) prepareRootfs(ctx context.Context, img *libimage.Image, name, rootfsPath string, link bool) (*entities.RootfsPrepareReport, error) {
+	mountPoint, err := img.Mount(ctx, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("mounting image %s: %w", img.ID(), err)
+	}
+	target := filepath.Join(rootfsPath, rootfsDir)
+	if link {
+		if err := os.Symlink(mountPoint, target); err != nil {
+			if err := img.Unmount(false); err != nil {
+				logrus.Errorf("Unmounting image %s: %v", img.ID(), err)
+			}
+			return nil, err
+		}
+	} else {
+		logrus.Debugf("Copying image %s to root file system %q", img.ID(), name)
+		err := chrootarchive.NewArchiver(nil).CopyWithTar(mountPoint, target)
+		if unmountErr := img.Unmount(false); unmountErr != nil {
+			logrus.Errorf("Unmounting image %s: %v", img.ID(), unmountErr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("copying image %s: %w", img.ID(), err)
+		}
+	}
+
+	config := rootfsConfig{
+		Image:   img.ID(),
+		ImageID: img.ID(),
+		Linked:  link,
+		Created: time.Now(),
+	}
+	if names := img.Names(); len(names) > 0 {
+		config.Image = names[0]
+	}
+	content, err := json.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	if err := ioutils.AtomicWriteFile(filepath.Join(rootfsPath, rootfsConfigFile), content, 0o600); err != nil {
+		return nil, err
+	}
+	return &entities.RootfsPrepareReport{Name: name, Path: target}, nil
+}
+
+func readRootfsConfig(rootfsPath string) (*rootfsConfig, error) {
+	content, err := os.ReadFile(filepath.Join(rootfsPath, rootfsConfigFile))
+	if err != nil {
+		return nil, err
+	}
+	config := new(rootfsConfig)
+	if err := json.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", filepath.Join(rootfsPath, rootfsConfigFile), err)
+	}
+	return config, nil
+}
+
+// RootfsList lists the root file systems prepared from images.
+func (ir *ImageEngine) RootfsList(_ context.Context) ([]*entities.RootfsListReport, error) {
+	dir, err := ir.rootfsDir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	reports := []*entities.RootfsListReport{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		rootfsPath := filepath.Join(dir, entry.Name())
+		config, err := readRootfsConfig(rootfsPath)
+		if err != nil {
+			// still being prepared, or a leftover of a failed prepare
+			logrus.Debugf("Skipping root file system %q: %v", entry.Name(), err)
+			continue
+		}
+		reports = append(reports, &entities.RootfsListReport{
+			Name:    entry.Name(),
+			Image:   config.Image,
+			ImageID: config.ImageID,
+			Path:    filepath.Join(rootfsPath, rootfsDir),
+			Linked:  config.Linked,
+			Created: config.Created,
+		})
+	}
+	return reports, nil
+}
+
+// RootfsRm removes prepared root file systems, which must not be used by a
+// container anymore.
+func (ir *ImageEngine) RootfsRm(ctx context.Context, names []string, options entities.RootfsRmOptions) ([]*entities.RootfsRmReport, error) {
+	if options.All {
+		listed, err := ir.RootfsList(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names = make([]string, 0, len(listed))
+		for _, rootfs := range listed {
+			names = append(names, rootfs.Name)
+		}
+	}
+	reports := make([]*entities.RootfsRmReport, 0, len(names))
+	for _, name := range names {
+		err := ir.removeRootfs(ctx, name)
+		if err != nil && options.Ignore && errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		reports = append(reports, &entities.RootfsRmReport{Name: name, Err: err})
+	}
+	return reports, nil
+}
+
+func (ir *ImageEngine) removeRootfs(_ context.Context, name string) error {
+	if !define.NameRegex.MatchString(name) {
+		return fmt.Errorf("invalid root file system name %q: %w", name, define.RegexError)
+	}
+	dir, err := ir.rootfsDir()
+	if err != nil {
+		return err
+	}
+	rootfsPath := filepath.Join(dir, name)
+	if _, err := os.Stat(rootfsPath); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("no root file system %q: %w", name, os.ErrNotExist)
+		}
+		return err
+	}
+
+	target := filepath.Join(rootfsPath, rootfsDir)
+	resolved, err := filepath.EvalSymlinks(target)
+	if err != nil {
+		resolved = target
+	}
+	ctrs, err := ir.Libpod.GetAllContainers()
+	if err != nil {
+		return err
+	}
+	for _, ctr := range ctrs {
+		if rootfs := ctr.Config().Rootfs; rootfs != "" && (filepath.Clean(rootfs) == target || filepath.Clean(rootfs) == resolved) {
+			return fmt.Errorf("root file system %q is used by container %s", name, ctr.ID())
+		}
+	}
+
+	config, err := readRootfsConfig(rootfsPath)
+	if err != nil {
+		logrus.Debugf("Reading the configuration of root file system %q: %v", name, err)
+	} else if config.Linked {
+		img, _, err := ir.Libpod.LibimageRuntime().LookupImage(config.ImageID, nil)
+		if err == nil {
+			err = img.Unmount(false)
+		}
+		if err != nil {
+			logrus.Warnf("Unmounting image %s of root file system %q: %v", config.ImageID, name, err)
+		}
+	}
+	return os.RemoveAll(rootfsPath)
+}
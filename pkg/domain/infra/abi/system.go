@@ -10,13 +10,20 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/dmikushin/podman-shared/libpod"
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities/reports"
+	"github.com/dmikushin/podman-shared/pkg/domain/filters"
 	"github.com/dmikushin/podman-shared/pkg/emulation"
+	"github.com/dmikushin/podman-shared/pkg/rootless"
 	"github.com/dmikushin/podman-shared/pkg/util"
+	"go.podman.io/common/libimage"
+	nettypes "go.podman.io/common/libnetwork/types"
+	netutil "go.podman.io/common/libnetwork/util"
 	"go.podman.io/storage"
 	"go.podman.io/storage/pkg/directory"
 	"go.podman.io/storage/pkg/fileutils"
@@ -69,7 +76,7 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 	var systemPruneReport = new(entities.SystemPruneReport)
 
 	if options.External {
-		if options.All || options.Volume || len(options.Filters) > 0 || options.Build {
+		if options.All || options.Volume || len(options.Filters) > 0 || options.Build || options.DryRun {
 			return nil, fmt.Errorf("system prune --external cannot be combined with other options")
 		}
 
@@ -79,6 +86,10 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 		return systemPruneReport, nil
 	}
 
+	if options.DryRun {
+		return ic.systemPruneDryRun(ctx, options)
+	}
+
 	filters := []string{}
 	for k, v := range options.Filters {
 		filters = append(filters, fmt.Sprintf("%s=%s", k, v[0]))
@@ -178,6 +189,171 @@ func (ic *ContainerEngine) SystemPrune(ctx context.Context, options entities.Sys
 	return systemPruneReport, nil
 }
 
+// systemPruneDryRun reports what SystemPrune removes with the given options
+// without removing anything. Images, networks and volumes which are only
+// used by containers that would be removed are included in the report.
+func (ic *ContainerEngine) systemPruneDryRun(ctx context.Context, options entities.SystemPruneOptions) (*entities.SystemPruneReport, error) {
+	systemPruneReport := new(entities.SystemPruneReport)
+	// IDs of all containers which would be removed
+	removed := make(map[string]bool)
+
+	if options.Build {
+		buildContainers, err := ic.Libpod.BuildContainers()
+		if err != nil {
+			return nil, err
+		}
+		for _, container := range buildContainers {
+			report := &reports.PruneReport{Id: container.ID}
+			size, err := ic.Libpod.StorageContainerSize(container.ID)
+			if err != nil {
+				report.Err = err
+			} else {
+				report.Size = uint64(size)
+			}
+			systemPruneReport.ContainerPruneReports = append(systemPruneReport.ContainerPruneReports, report)
+			removed[container.ID] = true
+		}
+	}
+
+	pods, err := ic.Libpod.PrunablePods()
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods {
+		podCtrs, err := pod.AllContainers()
+		if err != nil {
+			return nil, err
+		}
+		for _, ctr := range podCtrs {
+			removed[ctr.ID()] = true
+		}
+		systemPruneReport.PodPruneReport = append(systemPruneReport.PodPruneReport, &entities.PodPruneReport{Id: pod.ID()})
+	}
+
+	ctrFilterFuncs := make([]libpod.ContainerFilter, 0, len(options.Filters))
+	for k, v := range options.Filters {
+		generatedFunc, err := filters.GeneratePruneContainerFilterFuncs(k, v, ic.Libpod)
+		if err != nil {
+			return nil, err
+		}
+		ctrFilterFuncs = append(ctrFilterFuncs, generatedFunc)
+	}
+	ctrs, err := ic.Libpod.PrunableContainers(ctrFilterFuncs)
+	if err != nil {
+		return nil, err
+	}
+	for _, ctr := range ctrs {
+		report := &reports.PruneReport{Id: ctr.ID()}
+		size, err := ctr.RWSize()
+		if err != nil {
+			report.Err = err
+		} else {
+			report.Size = uint64(size)
+		}
+		systemPruneReport.ContainerPruneReports = append(systemPruneReport.ContainerPruneReports, report)
+		removed[ctr.ID()] = true
+	}
+
+	// everything used by the remaining containers is kept
+	allCtrs, err := ic.Libpod.GetAllContainers()
+	if err != nil {
+		return nil, err
+	}
+	networksToKeep := map[string]bool{ic.Libpod.GetDefaultNetworkName(): true}
+	volumesToKeep := make(map[string]bool)
+	for _, ctr := range allCtrs {
+		if removed[ctr.ID()] {
+			continue
+		}
+		nets, err := ctr.Networks()
+		if err != nil {
+			return nil, err
+		}
+		for _, net := range nets {
+			networksToKeep[net] = true
+		}
+		for _, vol := range ctr.NamedVolumes() {
+			volumesToKeep[vol.Name] = true
+		}
+	}
+
+	imageFilters := []string{"readonly=false"}
+	for k, v := range options.Filters {
+		imageFilters = append(imageFilters, fmt.Sprintf("%s=%s", k, v[0]))
+	}
+	if !options.All && !slices.ContainsFunc(imageFilters, func(filter string) bool {
+		return strings.HasPrefix(filter, "dangling=")
+	}) {
+		imageFilters = append(imageFilters, "dangling=true")
+	}
+	images, err := ic.Libpod.LibimageRuntime().ListImages(ctx, &libimage.ListImagesOptions{Filters: imageFilters})
+	if err != nil {
+		return nil, err
+	}
+	for _, image := range images {
+		imageCtrs, err := image.Containers()
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(imageCtrs, func(id string) bool { return !removed[id] }) {
+			continue
+		}
+		report := &reports.PruneReport{Id: image.ID()}
+		size, err := image.Size()
+		if err != nil {
+			report.Err = err
+		} else {
+			report.Size = uint64(size)
+		}
+		systemPruneReport.ImagePruneReports = append(systemPruneReport.ImagePruneReports, report)
+	}
+
+	netFilters, err := netutil.GenerateNetworkPruneFilters(options.Filters)
+	if err != nil {
+		return nil, err
+	}
+	netFilters = append(netFilters, func(net nettypes.Network) bool {
+		return !networksToKeep[net.Name]
+	})
+	nets, err := ic.Libpod.Network().NetworkList(netFilters...)
+	if err != nil {
+		return nil, err
+	}
+	for _, net := range nets {
+		systemPruneReport.NetworkPruneReports = append(systemPruneReport.NetworkPruneReports, &entities.NetworkPruneReport{Name: net.Name})
+	}
+
+	if options.Volume {
+		volFilterFuncs := []libpod.VolumeFilter{}
+		for filter, filterValues := range options.Filters {
+			filterFunc, err := filters.GenerateVolumeFilters(filter, filterValues, ic.Libpod)
+			if err != nil {
+				return nil, err
+			}
+			volFilterFuncs = append(volFilterFuncs, filterFunc)
+		}
+		vols, err := ic.Libpod.Volumes(volFilterFuncs...)
+		if err != nil {
+			return nil, err
+		}
+		for _, vol := range vols {
+			if volumesToKeep[vol.Name()] {
+				continue
+			}
+			report := &reports.PruneReport{Id: vol.Name()}
+			if size, err := vol.Size(); err == nil {
+				report.Size = size
+			}
+			systemPruneReport.VolumePruneReports = append(systemPruneReport.VolumePruneReports, report)
+		}
+	}
+
+	systemPruneReport.ReclaimedSpace = reports.PruneReportsSize(systemPruneReport.ContainerPruneReports) +
+		reports.PruneReportsSize(systemPruneReport.ImagePruneReports) +
+		reports.PruneReportsSize(systemPruneReport.VolumePruneReports)
+	return systemPruneReport, nil
+}
+
 func (ic *ContainerEngine) SystemDf(ctx context.Context, _ entities.SystemDfOptions) (*entities.SystemDfReport, error) {
 	var (
 		dfImages = []*entities.SystemDfImageReport{}
@@ -302,12 +478,44 @@ func (ic *ContainerEngine) Reset(ctx context.Context) error {
 	return ic.Libpod.Reset(ctx)
 }
 
-func (ic *ContainerEngine) Renumber(_ context.Context) error {
-	return ic.Libpod.RenumberLocks()
+func (ic *ContainerEngine) Renumber(_ context.Context, options entities.SystemRenumberOptions) (*entities.SystemRenumberReport, error) {
+	if !options.DryRun {
+		return nil, ic.Libpod.RenumberLocks()
+	}
+	preview, err := ic.Libpod.RenumberLocksPreview()
+	if err != nil {
+		return nil, err
+	}
+	numLocks, _, err := ic.Libpod.LockUsage()
+	if err != nil {
+		return nil, err
+	}
+	return &entities.SystemRenumberReport{NumLocks: numLocks, Locks: preview}, nil
 }
 
-func (ic *ContainerEngine) Migrate(_ context.Context, options entities.SystemMigrateOptions) error {
-	return ic.Libpod.Migrate(options.NewRuntime)
+func (ic *ContainerEngine) SharedLayerCachePurge(_ context.Context) (*entities.SystemSharedLayerCachePurgeReport, error) {
+	layers, size, err := ic.Libpod.PurgeSharedLayerCache()
+	if err != nil {
+		return nil, err
+	}
+	return &entities.SystemSharedLayerCachePurgeReport{Layers: layers, Size: size}, nil
+}
+
+func (ic *ContainerEngine) Migrate(_ context.Context, options entities.SystemMigrateOptions) (*entities.SystemMigrateReport, error) {
+	report := new(entities.SystemMigrateReport)
+	if options.DBBackend != "" {
+		rtc, err := ic.Libpod.GetConfigNoCopy()
+		if err != nil {
+			return nil, err
+		}
+		report.DBBackend = rtc.Engine.DBBackend
+		backup, err := ic.Libpod.MigrateDBBackend(options.DBBackend)
+		if err != nil {
+			return nil, err
+		}
+		report.DBBackup = backup
+	}
+	return report, ic.Libpod.Migrate(options.NewRuntime)
 }
 
 func unshareEnv(graphroot, runroot string) []string {
@@ -326,12 +534,50 @@ func (ic *ContainerEngine) Unshare(_ context.Context, args []string, options ent
 		return cmd.Run()
 	}
 
+	if options.Container != "" {
+		return ic.unshareContainer(args, options)
+	}
 	if options.RootlessNetNS {
 		return ic.Libpod.Network().RunInRootlessNetns(unshare)
 	}
 	return unshare()
 }
 
+// unshareContainer runs args in the user, mount and network namespaces of
+// the container of options.
+func (ic *ContainerEngine) unshareContainer(args []string, options entities.SystemUnshareOptions) error {
+	ctr, err := ic.Libpod.LookupContainer(options.Container)
+	if err != nil {
+		return err
+	}
+	state, err := ctr.State()
+	if err != nil {
+		return err
+	}
+	if state != define.ContainerStateRunning && state != define.ContainerStatePaused {
+		return fmt.Errorf("container %s is not running, its namespaces cannot be joined: %w", ctr.Name(), define.ErrCtrStateInvalid)
+	}
+	pid, err := ctr.PID()
+	if err != nil {
+		return err
+	}
+	namespaces := []string{"user"}
+	if !options.KeepNet {
+		namespaces = append(namespaces, "net")
+	}
+	// The mount namespace last, /proc is the one of the container once
+	// it is joined.
+	namespaces = append(namespaces, "mnt")
+	cmd, err := rootless.JoinNamespacesCommand(pid, namespaces, args)
+	if err != nil {
+		return err
+	}
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 func (ic *ContainerEngine) Version(_ context.Context) (*entities.SystemVersionReport, error) {
 	var report entities.SystemVersionReport
 	v, err := define.GetVersion()
@@ -350,6 +596,22 @@ func (ic *ContainerEngine) Locks(_ context.Context) (*entities.LocksReport, erro
 	}
 	report.LockConflicts = conflicts
 	report.LocksHeld = held
+	rtc, err := ic.Libpod.GetConfigNoCopy()
+	if err != nil {
+		return nil, err
+	}
+	report.LockType = rtc.Engine.LockType
+	if report.LockType == "" {
+		report.LockType = "shm"
+	}
+	report.NumLocks, report.LocksFree, err = ic.Libpod.LockUsage()
+	if err != nil {
+		return nil, err
+	}
+	report.Allocations, err = ic.Libpod.LockAllocations()
+	if err != nil {
+		return nil, err
+	}
 	return &report, nil
 }
 
@@ -0,0 +1,54 @@
+//go:build !remote
+
+package abi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/rootless"
+	"github.com/dmikushin/podman-shared/pkg/subid"
+)
+
+func (ic *ContainerEngine) SubIDStatus(_ context.Context) (*entities.SystemSubIDStatusReport, error) {
+	return ic.Libpod.SubIDStatus()
+}
+
+// SubIDExpand adds a range of subordinate UIDs and GIDs to the user, above
+// all the ranges assigned to any user so that they do not overlap.
+func (ic *ContainerEngine) SubIDExpand(_ context.Context, options entities.SystemSubIDExpandOptions) (*entities.SystemSubIDExpandReport, error) {
+	if rootless.IsRootless() {
+		return nil, errors.New("growing the subordinate ID pools requires root, use sudo podman system subid expand --user $USER")
+	}
+	report := &entities.SystemSubIDExpandReport{User: options.User}
+	if report.User == "" {
+		status, err := ic.Libpod.SubIDStatus()
+		if err != nil {
+			return nil, err
+		}
+		report.User = status.User
+	}
+
+	uidEntries, err := subid.ReadFile(subid.UIDFile)
+	if err != nil {
+		return nil, err
+	}
+	gidEntries, err := subid.ReadFile(subid.GIDFile)
+	if err != nil {
+		return nil, err
+	}
+	// use the same range for UIDs and GIDs, like useradd does
+	r, err := subid.NextRange(options.Size, uidEntries, gidEntries)
+	if err != nil {
+		return nil, err
+	}
+	if err := subid.Append(subid.UIDFile, report.User, r); err != nil {
+		return nil, err
+	}
+	if err := subid.Append(subid.GIDFile, report.User, r); err != nil {
+		return nil, err
+	}
+	report.UIDs, report.GIDs = r, r
+	return report, nil
+}
@@ -243,8 +243,12 @@ func (ic *ContainerEngine) VolumeUnmount(_ context.Context, nameOrIDs []string)
 	return reports, nil
 }
 
-func (ic *ContainerEngine) VolumeReload(ctx context.Context) (*entities.VolumeReloadReport, error) {
-	report := ic.Libpod.UpdateVolumePlugins(ctx)
+func (ic *ContainerEngine) VolumeReload(ctx context.Context, options entities.VolumeReloadOptions) (*entities.VolumeReloadReport, error) {
+	var plugins []string
+	if !options.All {
+		plugins = options.Plugins
+	}
+	report := ic.Libpod.UpdateVolumePlugins(ctx, plugins)
 	return &entities.VolumeReloadReport{VolumeReload: *report}, nil
 }
 
@@ -18,6 +18,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/namespaces"
 	"github.com/dmikushin/podman-shared/pkg/rootless"
 	"github.com/dmikushin/podman-shared/pkg/util"
+	"github.com/docker/go-units"
 	"github.com/sirupsen/logrus"
 	flag "github.com/spf13/pflag"
 	"go.podman.io/common/pkg/cgroups"
@@ -34,20 +35,22 @@ var (
 )
 
 type engineOpts struct {
-	withFDS  bool
-	reset    bool
-	renumber bool
-	config   *entities.PodmanConfig
+	withFDS     bool
+	reset       bool
+	renumber    bool
+	lazyStorage bool
+	config      *entities.PodmanConfig
 }
 
 // GetRuntime generates a new libpod runtime configured by command line options
 func GetRuntime(ctx context.Context, flags *flag.FlagSet, cfg *entities.PodmanConfig) (*libpod.Runtime, error) {
 	runtimeSync.Do(func() {
 		runtimeLib, runtimeErr = getRuntime(ctx, flags, &engineOpts{
-			withFDS:  true,
-			reset:    cfg.IsReset,
-			renumber: cfg.IsRenumber,
-			config:   cfg,
+			withFDS:     true,
+			reset:       cfg.IsReset,
+			renumber:    cfg.IsRenumber,
+			lazyStorage: cfg.LazyStorage,
+			config:      cfg,
 		})
 	})
 	return runtimeLib, runtimeErr
@@ -86,6 +89,9 @@ func getRuntime(ctx context.Context, fs *flag.FlagSet, opts *engineOpts) (*libpo
 		storageOpts.GraphRoot = cfg.GraphRoot
 		storageOpts.GraphDriverOptions = []string{}
 	}
+	if fs.Changed("root-rw") {
+		options = append(options, libpod.WithReadWriteRoot(cfg.ReadWriteRoot))
+	}
 	if fs.Changed("runroot") {
 		storageSet = true
 		storageOpts.RunRoot = cfg.Runroot
@@ -124,6 +130,17 @@ func getRuntime(ctx context.Context, fs *flag.FlagSet, opts *engineOpts) (*libpo
 			storageOpts.GraphDriverOptions = cfg.StorageOpts
 		}
 	}
+	if fs.Changed("shared-layer-cache") {
+		size, err := units.FromHumanSize(cfg.SharedLayerCacheSize)
+		if err != nil {
+			return nil, fmt.Errorf("invalid shared layer cache size %q: %w", cfg.SharedLayerCacheSize, err)
+		}
+		options = append(options, libpod.WithSharedLayerCache(cfg.SharedLayerCache, size))
+	}
+	// podman pull --to-shared writes to the shared image store.
+	if toShared, _ := fs.GetBool("to-shared"); toShared {
+		options = append(options, libpod.WithSharedImageStorePull())
+	}
 	if fs.Changed("transient-store") {
 		options = append(options, libpod.WithTransientStore(cfg.TransientStore))
 	}
@@ -134,6 +151,9 @@ func getRuntime(ctx context.Context, fs *flag.FlagSet, opts *engineOpts) (*libpo
 	if opts.renumber {
 		options = append(options, libpod.WithRenumber())
 	}
+	if opts.lazyStorage {
+		options = append(options, libpod.WithLazyStorage())
+	}
 
 	if len(cfg.RuntimeFlags) > 0 {
 		runtimeFlags := []string{}
@@ -207,6 +227,9 @@ func getRuntime(ctx context.Context, fs *flag.FlagSet, opts *engineOpts) (*libpo
 	if fs.Changed("registries-conf") {
 		options = append(options, libpod.WithRegistriesConf(cfg.RegistriesConf))
 	}
+	if cfg.ShortNameAliasConf != "" {
+		options = append(options, libpod.WithShortNameAliasConf(cfg.ShortNameAliasConf))
+	}
 
 	if fs.Changed("db-backend") {
 		options = append(options, libpod.WithDatabaseBackend(cfg.ContainersConf.Engine.DBBackend))
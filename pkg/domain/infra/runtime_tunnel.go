@@ -14,7 +14,8 @@ import (
 
 var (
 	connectionMutex = &sync.Mutex{}
-	connection      *context.Context
+	// connections are shared by the engines of the same URI
+	connections = map[string]context.Context{}
 )
 
 func newConnection(uri string, identity, tlsCertFile, tlsKeyFile, tlsCAFile, farmNodeName string, machine bool) (context.Context, error) {
@@ -22,21 +23,22 @@ func newConnection(uri string, identity, tlsCertFile, tlsKeyFile, tlsCAFile, far
 	defer connectionMutex.Unlock()
 
 	// if farmNodeName given, then create a connection with the node so that we can send builds there
-	if connection == nil || farmNodeName != "" {
-		ctx, err := bindings.NewConnectionWithOptions(context.Background(), bindings.Options{
-			URI:         uri,
-			Identity:    identity,
-			TLSCertFile: tlsCertFile,
-			TLSKeyFile:  tlsKeyFile,
-			TLSCAFile:   tlsCAFile,
-			Machine:     machine,
-		})
-		if err != nil {
-			return ctx, err
-		}
-		connection = &ctx
+	if connection, ok := connections[uri]; ok && farmNodeName == "" {
+		return connection, nil
 	}
-	return *connection, nil
+	ctx, err := bindings.NewConnectionWithOptions(context.Background(), bindings.Options{
+		URI:         uri,
+		Identity:    identity,
+		TLSCertFile: tlsCertFile,
+		TLSKeyFile:  tlsKeyFile,
+		TLSCAFile:   tlsCAFile,
+		Machine:     machine,
+	})
+	if err != nil {
+		return ctx, err
+	}
+	connections[uri] = ctx
+	return ctx, nil
 }
 
 func NewContainerEngine(facts *entities.PodmanConfig) (entities.ContainerEngine, error) {
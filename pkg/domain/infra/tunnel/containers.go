@@ -25,11 +25,29 @@ import (
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/pkg/config"
 	"go.podman.io/image/v5/docker/reference"
+	imageTypes "go.podman.io/image/v5/types"
 	"go.podman.io/storage/types"
 )
 
-func (ic *ContainerEngine) ContainerRunlabel(_ context.Context, _ string, _ string, _ []string, _ entities.ContainerRunlabelOptions) error {
-	return errors.New("not implemented")
+func (ic *ContainerEngine) ContainerRunlabel(_ context.Context, label string, image string, args []string, opts entities.ContainerRunlabelOptions) error {
+	options := new(containers.RunlabelOptions).WithAuthfile(opts.Authfile).WithDisplay(opts.Display).WithName(opts.Name)
+	options.WithOpt1(opts.Optional1).WithOpt2(opts.Optional2).WithOpt3(opts.Optional3)
+	options.WithPull(opts.Pull).WithQuiet(opts.Quiet).WithReplace(opts.Replace)
+	options.WithUsername(opts.Username).WithPassword(opts.Password)
+	if opts.Credentials != "" {
+		creds, err := util.ParseRegistryCreds(opts.Credentials)
+		if err != nil {
+			return err
+		}
+		options.WithUsername(creds.Username).WithPassword(creds.Password)
+	}
+	if s := opts.SkipTLSVerify; s != imageTypes.OptionalBoolUndefined {
+		options.WithSkipTLSVerify(s == imageTypes.OptionalBoolTrue)
+	}
+	if opts.Writer != nil {
+		options.WithWriter(opts.Writer)
+	}
+	return containers.Runlabel(ic.ClientCtx, label, image, args, options)
 }
 
 func (ic *ContainerEngine) ContainerExists(_ context.Context, nameOrID string, options entities.ContainerExistsOptions) (*entities.BoolReport, error) {
@@ -37,10 +55,29 @@ func (ic *ContainerEngine) ContainerExists(_ context.Context, nameOrID string, o
 	return &entities.BoolReport{Value: exists}, err
 }
 
+func (ic *ContainerEngine) ContainersExist(_ context.Context, namesOrIds []string, options entities.ContainerExistsOptions) (map[string]bool, error) {
+	return containers.ExistsMany(ic.ClientCtx, namesOrIds, new(containers.ExistsOptions).WithExternal(options.External))
+}
+
 func (ic *ContainerEngine) ContainerWait(ctx context.Context, namesOrIds []string, opts entities.WaitOptions) ([]entities.WaitReport, error) {
 	responses := make([]entities.WaitReport, 0, len(namesOrIds))
 	options := new(containers.WaitOptions).WithConditions(opts.Conditions).WithInterval(opts.Interval.String())
 
+	if opts.ConditionChan != nil {
+		conditionsOptions := new(containers.WaitConditionsOptions).WithConditions(opts.Conditions).WithInterval(opts.Interval.String()).WithIgnore(opts.Ignore)
+		reportChan, err := containers.WaitConditions(ic.ClientCtx, namesOrIds, conditionsOptions)
+		if err != nil {
+			return nil, err
+		}
+		go func() {
+			defer close(opts.ConditionChan)
+			for report := range reportChan {
+				opts.ConditionChan <- report
+			}
+		}()
+		return nil, nil
+	}
+
 	if opts.ExitFirstMatch {
 		var waitChannel = make(chan entities.WaitReport, 1)
 		var waitFunction = func(ctx context.Context, nameOrId string, options *containers.WaitOptions, waitChannel chan<- entities.WaitReport) {
@@ -101,7 +138,7 @@ func (ic *ContainerEngine) ContainerPause(_ context.Context, namesOrIds []string
 	}
 	reports := make([]*entities.PauseUnpauseReport, 0, len(ctrs))
 	for _, c := range ctrs {
-		err := containers.Pause(ic.ClientCtx, c.ID, nil)
+		err := containers.Pause(ic.ClientCtx, c.ID, new(containers.PauseOptions).WithFreezeTimeout(options.FreezeTimeout))
 		if err != nil && options.All && strings.Contains(err.Error(), define.ErrCtrStateInvalid.Error()) {
 			logrus.Debugf("Container %s is not running", c.ID)
 			continue
@@ -397,7 +434,15 @@ func (ic *ContainerEngine) ContainerCommit(_ context.Context, nameOrID string, o
 }
 
 func (ic *ContainerEngine) ContainerExport(_ context.Context, nameOrID string, options entities.ContainerExportOptions) error {
-	return containers.Export(ic.ClientCtx, nameOrID, options.Output, nil)
+	return containers.Export(ic.ClientCtx, nameOrID, options.Output, new(containers.ExportOptions).WithFormat(options.Format))
+}
+
+func (ic *ContainerEngine) ContainerBackup(_ context.Context, nameOrID string, options entities.ContainerBackupOptions) error {
+	return containers.Backup(ic.ClientCtx, nameOrID, options.Output, new(containers.BackupOptions).WithIgnoreVolumes(options.IgnoreVolumes))
+}
+
+func (ic *ContainerEngine) ContainerRestoreBackup(_ context.Context, options entities.ContainerRestoreBackupOptions) (*entities.ContainerRestoreBackupReport, error) {
+	return containers.RestoreBackup(ic.ClientCtx, options.Input, new(containers.RestoreBackupOptions).WithName(options.Name).WithIgnoreVolumes(options.IgnoreVolumes))
 }
 
 func (ic *ContainerEngine) ContainerCheckpoint(_ context.Context, namesOrIds []string, opts entities.CheckpointOptions) ([]*entities.CheckpointReport, error) {
@@ -542,7 +587,7 @@ func (ic *ContainerEngine) ContainerCreate(_ context.Context, s *specgen.SpecGen
 	for _, w := range response.Warnings {
 		fmt.Fprintf(os.Stderr, "%s\n", w)
 	}
-	return &entities.ContainerCreateReport{Id: response.ID}, nil
+	return &entities.ContainerCreateReport{Id: response.ID, PublishedPorts: response.PublishedPorts}, nil
 }
 
 func (ic *ContainerEngine) ContainerLogs(_ context.Context, nameOrIDs []string, opts entities.ContainerLogsOptions) error {
@@ -864,6 +909,12 @@ func (ic *ContainerEngine) ContainerStart(_ context.Context, namesOrIds []string
 func (ic *ContainerEngine) ContainerList(_ context.Context, opts entities.ContainerListOptions) ([]entities.ListContainer, error) {
 	options := new(containers.ListOptions).WithFilters(opts.Filters).WithAll(opts.All).WithLast(opts.Last)
 	options.WithNamespace(opts.Namespace).WithSize(opts.Size).WithSync(opts.Sync).WithExternal(opts.External)
+	if opts.Offset > 0 {
+		options.WithOffset(opts.Offset)
+	}
+	if opts.Sort != "" {
+		options.WithSort(opts.Sort)
+	}
 	return containers.List(ic.ClientCtx, options)
 }
 
@@ -1078,6 +1129,21 @@ func (ic *ContainerEngine) ContainerStats(_ context.Context, namesOrIds []string
 	return containers.Stats(ic.ClientCtx, namesOrIds, new(containers.StatsOptions).WithStream(options.Stream).WithInterval(options.Interval).WithAll(options.All))
 }
 
+func (ic *ContainerEngine) ContainerStatsHistory(_ context.Context, namesOrIds []string, options entities.ContainerStatsHistoryOptions) ([]*entities.ContainerStatsHistoryReport, error) {
+	if options.Latest {
+		return nil, errors.New("latest is not supported for the remote client")
+	}
+	return containers.StatsHistory(ic.ClientCtx, namesOrIds, new(containers.StatsHistoryOptions).WithAll(options.All).WithSince(options.Since).WithUntil(options.Until))
+}
+
+func (ic *ContainerEngine) ContainerStatsRecord(_ context.Context, _ string) error {
+	return errors.New("recording stats is not supported on the remote client")
+}
+
+func (ic *ContainerEngine) ContainerThawExpired(_ context.Context, _ string) error {
+	return errors.New("thawing expired containers is not supported on the remote client")
+}
+
 // ContainerRename renames the given container.
 func (ic *ContainerEngine) ContainerRename(_ context.Context, nameOrID string, opts entities.ContainerRenameOptions) error {
 	return containers.Rename(ic.ClientCtx, nameOrID, new(containers.RenameOptions).WithName(opts.NewName))
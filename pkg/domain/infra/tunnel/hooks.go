@@ -0,0 +1,24 @@
+package tunnel
+
+import (
+	"context"
+
+	"github.com/dmikushin/podman-shared/pkg/bindings/containers"
+	"github.com/dmikushin/podman-shared/pkg/bindings/system"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+)
+
+// HookList lists the OCI hooks of the hooks directories of the service.
+func (ic *ContainerEngine) HookList(_ context.Context) ([]*entities.HookListReport, error) {
+	return system.Hooks(ic.ClientCtx, nil)
+}
+
+// HookReload reloads the hooks directories of the service.
+func (ic *ContainerEngine) HookReload(_ context.Context) ([]*entities.HookListReport, error) {
+	return system.ReloadHooks(ic.ClientCtx, nil)
+}
+
+// HookTest reports which OCI hooks of the service match a container.
+func (ic *ContainerEngine) HookTest(_ context.Context, nameOrID string) ([]*entities.HookTestReport, error) {
+	return containers.Hooks(ic.ClientCtx, nameOrID, nil)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/domain/entities/reports"
 	"github.com/dmikushin/podman-shared/pkg/domain/utils"
 	"github.com/dmikushin/podman-shared/pkg/errorhandling"
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/sirupsen/logrus"
 	"go.podman.io/common/libimage/filter"
 	"go.podman.io/common/pkg/config"
@@ -47,6 +48,12 @@ func (ir *ImageEngine) List(_ context.Context, opts entities.ImageListOptions) (
 		}
 	}
 	options := new(images.ListOptions).WithAll(opts.All).WithFilters(filters)
+	if opts.Limit > 0 {
+		options.WithLimit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		options.WithOffset(opts.Offset)
+	}
 	psImages, err := images.List(ir.ClientCtx, options)
 	if err != nil {
 		return nil, err
@@ -71,6 +78,40 @@ func (ir *ImageEngine) Unmount(_ context.Context, _ []string, _ entities.ImageUn
 	return nil, errors.New("unmounting images is not supported for remote clients")
 }
 
+func (ir *ImageEngine) RootfsList(_ context.Context) ([]*entities.RootfsListReport, error) {
+	return nil, errors.New("preparing root file systems is not supported for remote clients")
+}
+
+func (ir *ImageEngine) RootfsPrepare(_ context.Context, _ string, _ entities.RootfsPrepareOptions) (*entities.RootfsPrepareReport, error) {
+	return nil, errors.New("preparing root file systems is not supported for remote clients")
+}
+
+func (ir *ImageEngine) RootfsRm(_ context.Context, _ []string, _ entities.RootfsRmOptions) ([]*entities.RootfsRmReport, error) {
+	return nil, errors.New("preparing root file systems is not supported for remote clients")
+}
+
+func (ir *ImageEngine) SBOM(_ context.Context, nameOrID string, opts entities.ImageSBOMOptions) (*entities.ImageSBOMReport, error) {
+	options := new(images.SBOMOptions).WithFormat(opts.Format).WithAttach(opts.Attach)
+	options.WithAuthfile(opts.Authfile).WithUsername(opts.Username).WithPassword(opts.Password)
+	if s := opts.SkipTLSVerify; s != types.OptionalBoolUndefined {
+		options.WithSkipTLSVerify(s == types.OptionalBoolTrue)
+	}
+	return images.SBOM(ir.ClientCtx, nameOrID, options)
+}
+
+func (ir *ImageEngine) Optimize(_ context.Context, nameOrID, target string, opts entities.ImageOptimizeOptions) (*entities.ImageOptimizeReport, error) {
+	options := new(images.OptimizeOptions).WithMergeThreshold(opts.MergeThreshold)
+	if opts.CompressionLevel != nil {
+		options.WithCompressionLevel(*opts.CompressionLevel)
+	}
+	return images.Optimize(ir.ClientCtx, nameOrID, target, options)
+}
+
+func (ir *ImageEngine) Scan(_ context.Context, nameOrID string, opts entities.ImageScanOptions) (*entities.ImageScanReport, error) {
+	options := new(images.ScanOptions).WithScanner(opts.Scanner).WithThreshold(opts.Threshold).WithRunPolicy(opts.RunPolicy)
+	return images.Scan(ir.ClientCtx, nameOrID, options)
+}
+
 func (ir *ImageEngine) History(_ context.Context, nameOrID string, _ entities.ImageHistoryOptions) (*entities.ImageHistoryReport, error) {
 	options := new(images.HistoryOptions)
 	results, err := images.History(ir.ClientCtx, nameOrID, options)
@@ -122,6 +163,9 @@ func (ir *ImageEngine) Pull(_ context.Context, rawImage string, opts entities.Im
 	options.WithVariant(opts.Variant).WithPassword(opts.Password)
 	options.WithQuiet(opts.Quiet).WithUsername(opts.Username).WithPolicy(opts.PullPolicy.String())
 	options.WithProgressWriter(opts.Writer)
+	if opts.PullPolicy == config.PullPolicyNewer && opts.PullMaxAge > 0 {
+		options.WithPolicy(util.PullPolicyNewerWithMaxAge).WithMaxAge(opts.PullMaxAge.String())
+	}
 	if s := opts.SkipTLSVerify; s != types.OptionalBoolUndefined {
 		if s == types.OptionalBoolTrue {
 			options.WithSkipTLSVerify(true)
@@ -451,7 +495,7 @@ func (ir *ImageEngine) Build(_ context.Context, containerFiles []string, opts en
 }
 
 func (ir *ImageEngine) Tree(_ context.Context, nameOrID string, opts entities.ImageTreeOptions) (*entities.ImageTreeReport, error) {
-	options := new(images.TreeOptions).WithWhatRequires(opts.WhatRequires)
+	options := new(images.TreeOptions).WithWhatRequires(opts.WhatRequires).WithDiskUsage(opts.DiskUsage)
 	return images.Tree(ir.ClientCtx, nameOrID, options)
 }
 
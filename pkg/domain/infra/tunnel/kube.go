@@ -42,11 +42,16 @@ func (ic *ContainerEngine) GenerateSystemd(_ context.Context, nameOrID string, o
 	return generate.Systemd(ic.ClientCtx, nameOrID, options)
 }
 
+func (ic *ContainerEngine) GenerateQuadlet(_ context.Context, nameOrID string, opts entities.GenerateQuadletOptions) (*entities.GenerateQuadletReport, error) {
+	options := new(generate.QuadletOptions).WithNoHeader(opts.NoHeader)
+	return generate.Quadlet(ic.ClientCtx, nameOrID, options)
+}
+
 // GenerateKube Kubernetes YAML (v1 specification) for nameOrIDs
 //
 // Note: Caller is responsible for closing returned Reader
 func (ic *ContainerEngine) GenerateKube(_ context.Context, nameOrIDs []string, opts entities.GenerateKubeOptions) (*entities.GenerateKubeReport, error) {
-	options := new(generate.KubeOptions).WithService(opts.Service).WithType(opts.Type).WithReplicas(opts.Replicas).WithNoTrunc(opts.UseLongAnnotations).WithPodmanOnly(opts.PodmanOnly)
+	options := new(generate.KubeOptions).WithService(opts.Service).WithIngress(opts.Ingress).WithType(opts.Type).WithReplicas(opts.Replicas).WithNoTrunc(opts.UseLongAnnotations).WithPodmanOnly(opts.PodmanOnly)
 	return generate.Kube(ic.ClientCtx, nameOrIDs, options)
 }
 
@@ -74,12 +79,17 @@ func (ic *ContainerEngine) PlayKube(_ context.Context, body io.Reader, opts enti
 	}
 	options.WithPublishPorts(opts.PublishPorts)
 	options.WithPublishAllPorts(opts.PublishAllPorts)
+	options.WithNetworkPolicy(opts.NetworkPolicy)
 	options.WithNoTrunc(opts.UseLongAnnotations)
 	return play.KubeWithBody(ic.ClientCtx, body, options)
 }
 
 func (ic *ContainerEngine) PlayKubeDown(_ context.Context, body io.Reader, options entities.PlayKubeDownOptions) (*entities.PlayKubeReport, error) {
-	return play.DownWithBody(ic.ClientCtx, body, kube.DownOptions{Force: &options.Force})
+	opts := new(kube.DownOptions).WithForce(options.Force).WithVolumes(options.Volumes).WithConfigMaps(options.ConfigMaps).WithSecrets(!options.KeepSecrets)
+	if options.Timeout != nil {
+		opts.WithTimeout(*options.Timeout)
+	}
+	return play.DownWithBody(ic.ClientCtx, body, *opts)
 }
 
 func (ic *ContainerEngine) KubeApply(_ context.Context, body io.Reader, opts entities.ApplyOptions) error {
@@ -2,7 +2,6 @@ package tunnel
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
@@ -17,6 +16,14 @@ func (ic *ContainerEngine) NetworkUpdate(_ context.Context, netName string, opts
 	return network.Update(ic.ClientCtx, netName, options)
 }
 
+func (ic *ContainerEngine) NetworkWireGuardPeer(_ context.Context, netName string) (*entities.NetworkWireGuardPeer, error) {
+	return network.WireGuardPeer(ic.ClientCtx, netName)
+}
+
+func (ic *ContainerEngine) NetworkWireGuardSetPeers(_ context.Context, netName string, peers []entities.NetworkWireGuardPeer) error {
+	return network.SetWireGuardPeers(ic.ClientCtx, netName, peers)
+}
+
 func (ic *ContainerEngine) NetworkList(_ context.Context, opts entities.NetworkListOptions) ([]types.Network, error) {
 	options := new(network.ListOptions).WithFilters(opts.Filters)
 	return network.List(ic.ClientCtx, options)
@@ -46,8 +53,9 @@ func (ic *ContainerEngine) NetworkInspect(_ context.Context, namesOrIds []string
 	return reports, errs, nil
 }
 
-func (ic *ContainerEngine) NetworkReload(_ context.Context, _ []string, _ entities.NetworkReloadOptions) ([]*entities.NetworkReloadReport, error) {
-	return nil, errors.New("not implemented")
+func (ic *ContainerEngine) NetworkReload(_ context.Context, names []string, opts entities.NetworkReloadOptions) ([]*entities.NetworkReloadReport, error) {
+	options := new(network.ReloadOptions).WithAll(opts.All).WithGraceful(opts.Graceful).WithContainers(names)
+	return network.Reload(ic.ClientCtx, options)
 }
 
 func (ic *ContainerEngine) NetworkRm(_ context.Context, namesOrIds []string, opts entities.NetworkRmOptions) ([]*entities.NetworkRmReport, error) {
@@ -4,8 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/libpod/logs"
 	"github.com/dmikushin/podman-shared/pkg/bindings/pods"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/dmikushin/podman-shared/pkg/errorhandling"
@@ -44,14 +47,40 @@ func (ic *ContainerEngine) PodKill(_ context.Context, namesOrIds []string, opts
 	return reports, nil
 }
 
-func (ic *ContainerEngine) PodLogs(ctx context.Context, _ string, options entities.PodLogsOptions) error {
-	// PodLogsOptions are similar but contains few extra fields like ctrName
-	// So cast other values as is so we can reuse the code
-	containerLogsOpts := entities.PodLogsOptionsToContainerLogsOptions(options)
+func (ic *ContainerEngine) PodLogs(_ context.Context, nameOrID string, options entities.PodLogsOptions) error {
+	logsOptions := new(pods.LogsOptions).WithFollow(options.Follow).WithTail(strconv.FormatInt(options.Tail, 10))
+	if options.ContainerName != "" {
+		logsOptions.WithContainer(options.ContainerName)
+	}
+	if !options.Since.IsZero() {
+		logsOptions.WithSince(options.Since.Format(time.RFC3339Nano))
+	}
+	if !options.Until.IsZero() {
+		logsOptions.WithUntil(options.Until.Format(time.RFC3339Nano))
+	}
+	logOpts := &logs.LogOptions{
+		Multi:      options.ContainerName == "",
+		Timestamps: options.Timestamps,
+		Colors:     options.Colors,
+		UseName:    options.Names,
+	}
+
+	var err error
+	lineCh := make(chan *logs.LogLine)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		err = pods.Logs(ic.ClientCtx, nameOrID, logsOptions, lineCh)
+		cancel()
+	}()
 
-	// interface only accepts slice, keep everything consistent
-	name := []string{options.ContainerName}
-	return ic.ContainerLogs(ctx, name, containerLogsOpts)
+	for {
+		select {
+		case <-ctx.Done():
+			return err
+		case line := <-lineCh:
+			line.Write(options.StdoutWriter, options.StderrWriter, logOpts)
+		}
+	}
 }
 
 func (ic *ContainerEngine) PodPause(_ context.Context, namesOrIds []string, options entities.PodPauseOptions) ([]*entities.PodPauseReport, error) {
@@ -224,6 +253,12 @@ func (ic *ContainerEngine) PodTop(_ context.Context, opts entities.PodTopOptions
 
 func (ic *ContainerEngine) PodPs(_ context.Context, opts entities.PodPSOptions) ([]*entities.ListPodsReport, error) {
 	options := new(pods.ListOptions).WithFilters(opts.Filters)
+	if opts.Limit > 0 {
+		options.WithLimit(opts.Limit)
+	}
+	if opts.Offset > 0 {
+		options.WithOffset(opts.Offset)
+	}
 	return pods.List(ic.ClientCtx, options)
 }
 
@@ -252,3 +287,7 @@ func (ic *ContainerEngine) PodStats(_ context.Context, namesOrIds []string, opts
 	options := new(pods.StatsOptions).WithAll(opts.All)
 	return pods.Stats(ic.ClientCtx, namesOrIds, options)
 }
+
+func (ic *ContainerEngine) PodUpdate(_ context.Context, options *entities.PodUpdateOptions) (*entities.PodUpdateReport, error) {
+	return pods.Update(ic.ClientCtx, options)
+}
@@ -19,12 +19,12 @@ func (ic *ContainerEngine) SetupRootless(_ context.Context, _ bool, _ string) er
 
 // SystemPrune prunes unused data from the system.
 func (ic *ContainerEngine) SystemPrune(_ context.Context, opts entities.SystemPruneOptions) (*entities.SystemPruneReport, error) {
-	options := new(system.PruneOptions).WithAll(opts.All).WithVolumes(opts.Volume).WithFilters(opts.Filters).WithExternal(opts.External).WithBuild(opts.Build)
+	options := new(system.PruneOptions).WithAll(opts.All).WithVolumes(opts.Volume).WithFilters(opts.Filters).WithExternal(opts.External).WithBuild(opts.Build).WithDryRun(opts.DryRun)
 	return system.Prune(ic.ClientCtx, options)
 }
 
 func (ic *ContainerEngine) SystemCheck(_ context.Context, opts entities.SystemCheckOptions) (*entities.SystemCheckReport, error) {
-	options := new(system.CheckOptions).WithQuick(opts.Quick).WithRepair(opts.Repair).WithRepairLossy(opts.RepairLossy)
+	options := new(system.CheckOptions).WithQuick(opts.Quick).WithRepair(opts.Repair).WithRepairLossy(opts.RepairLossy).WithRepairContainers(opts.RepairContainers)
 	if opts.UnreferencedLayerMaximumAge != nil {
 		duration := *opts.UnreferencedLayerMaximumAge
 		options = options.WithUnreferencedLayerMaximumAge(duration.String())
@@ -32,12 +32,16 @@ func (ic *ContainerEngine) SystemCheck(_ context.Context, opts entities.SystemCh
 	return system.Check(ic.ClientCtx, options)
 }
 
-func (ic *ContainerEngine) Migrate(_ context.Context, _ entities.SystemMigrateOptions) error {
-	return errors.New("runtime migration is not supported on remote clients")
+func (ic *ContainerEngine) Migrate(_ context.Context, _ entities.SystemMigrateOptions) (*entities.SystemMigrateReport, error) {
+	return nil, errors.New("runtime migration is not supported on remote clients")
 }
 
-func (ic *ContainerEngine) Renumber(_ context.Context) error {
-	return errors.New("lock renumbering is not supported on remote clients")
+func (ic *ContainerEngine) Renumber(_ context.Context, _ entities.SystemRenumberOptions) (*entities.SystemRenumberReport, error) {
+	return nil, errors.New("lock renumbering is not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SharedLayerCachePurge(_ context.Context) (*entities.SystemSharedLayerCachePurgeReport, error) {
+	return nil, errors.New("purging the shared layer cache is not supported on remote clients")
 }
 
 func (ic *ContainerEngine) Reset(_ context.Context) error {
@@ -48,6 +52,14 @@ func (ic *ContainerEngine) SystemDf(_ context.Context, _ entities.SystemDfOption
 	return system.DiskUsage(ic.ClientCtx, nil)
 }
 
+func (ic *ContainerEngine) SubIDStatus(_ context.Context) (*entities.SystemSubIDStatusReport, error) {
+	return nil, errors.New("subordinate ID pools are not supported on remote clients")
+}
+
+func (ic *ContainerEngine) SubIDExpand(_ context.Context, _ entities.SystemSubIDExpandOptions) (*entities.SystemSubIDExpandReport, error) {
+	return nil, errors.New("subordinate ID pools are not supported on remote clients")
+}
+
 func (ic *ContainerEngine) Unshare(_ context.Context, _ []string, _ entities.SystemUnshareOptions) error {
 	return errors.New("unshare is not supported on remote clients")
 }
@@ -56,6 +68,11 @@ func (ic *ContainerEngine) Version(_ context.Context) (*entities.SystemVersionRe
 	return system.Version(ic.ClientCtx, nil)
 }
 
+// DeviceList lists the CDI and GPU devices of the host of the service.
+func (ic *ContainerEngine) DeviceList(_ context.Context) ([]*entities.DeviceListReport, error) {
+	return system.Devices(ic.ClientCtx, nil)
+}
+
 func (ic *ContainerEngine) Locks(_ context.Context) (*entities.LocksReport, error) {
 	return nil, errors.New("locks is not supported on remote clients")
 }
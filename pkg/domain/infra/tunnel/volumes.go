@@ -110,7 +110,7 @@ func (ic *ContainerEngine) VolumeUnmount(_ context.Context, _ []string) ([]*enti
 	return nil, errors.New("unmounting volumes is not supported for remote clients")
 }
 
-func (ic *ContainerEngine) VolumeReload(_ context.Context) (*entities.VolumeReloadReport, error) {
+func (ic *ContainerEngine) VolumeReload(_ context.Context, _ entities.VolumeReloadOptions) (*entities.VolumeReloadReport, error) {
 	return nil, errors.New("volume reload is not supported for remote clients")
 }
 
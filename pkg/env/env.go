@@ -5,13 +5,31 @@ package env
 
 import (
 	"bufio"
+	"bytes"
 	"fmt"
+	"io"
 	"maps"
+	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+)
+
+const (
+	whiteSpaces = " \t"
+
+	// downloadTimeout is the timeout for downloading env files from URLs.
+	downloadTimeout = 30 * time.Second
+	// maxDownloadSize is the maximum size of env files downloaded from URLs.
+	maxDownloadSize = 1 << 20
 )
 
-const whiteSpaces = " \t"
+// httpClient is used to download env files from URLs.
+var httpClient = &http.Client{Timeout: downloadTimeout}
 
 // DefaultEnvVariables returns a default environment, with $PATH and $TERM set.
 func DefaultEnvVariables() map[string]string {
@@ -61,7 +79,6 @@ func Join(base map[string]string, override map[string]string) map[string]string
 // ParseFile parses the specified path for environment variables and returns them
 // as a map.
 func ParseFile(path string) (_ map[string]string, err error) {
-	env := make(map[string]string)
 	defer func() {
 		if err != nil {
 			err = fmt.Errorf("parsing file %q: %w", path, err)
@@ -74,12 +91,98 @@ func ParseFile(path string) (_ map[string]string, err error) {
 	}
 	defer fh.Close()
 
-	scanner := bufio.NewScanner(fh)
+	return parseReader(fh)
+}
+
+// ParseSource parses environment variables from an env file, a directory of env
+// files or an https:// URL and returns them as a map.
+// Files of a directory are parsed in lexical order, hidden files and
+// subdirectories are skipped.  A URL may pin the checksum of the file in its
+// fragment, e.g. https://example.com/app.env#sha256:<hex>.
+func ParseSource(source string) (map[string]string, error) {
+	if strings.HasPrefix(source, "https://") {
+		return parseURL(source)
+	}
+	st, err := os.Stat(source)
+	if err != nil || !st.IsDir() {
+		return ParseFile(source)
+	}
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return nil, fmt.Errorf("reading env file directory %q: %w", source, err)
+	}
+	env := make(map[string]string)
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		fileEnv, err := ParseFile(filepath.Join(source, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		maps.Copy(env, fileEnv)
+	}
+	return env, nil
+}
+
+// parseURL downloads the env file at rawURL, verifies its checksum if one is
+// set in the fragment of the URL and parses it.
+func parseURL(rawURL string) (_ map[string]string, err error) {
+	defer func() {
+		if err != nil {
+			err = fmt.Errorf("parsing env file %q: %w", rawURL, err)
+		}
+	}()
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	var expected digest.Digest
+	if u.Fragment != "" {
+		expected, err = digest.Parse(u.Fragment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid checksum %q: %w", u.Fragment, err)
+		}
+		u.Fragment = ""
+	}
+
+	resp, err := httpClient.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading file: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxDownloadSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxDownloadSize {
+		return nil, fmt.Errorf("file is larger than %d bytes", maxDownloadSize)
+	}
+	if expected != "" {
+		if actual := expected.Algorithm().FromBytes(data); actual != expected {
+			return nil, fmt.Errorf("checksum mismatch: expected %s, got %s", expected, actual)
+		}
+	}
+	return parseReader(bytes.NewReader(data))
+}
+
+// parseReader parses env file lines from r.  ${NAME} references in values are
+// expanded with expand.
+func parseReader(r io.Reader) (map[string]string, error) {
+	env := make(map[string]string)
+	scanner := bufio.NewScanner(r)
 	for scanner.Scan() {
 		// trim the line from all leading whitespace first
 		line := strings.TrimLeft(scanner.Text(), whiteSpaces)
 		// line is not empty, and not starting with '#'
 		if len(line) > 0 && !strings.HasPrefix(line, "#") {
+			if key, val, hasVal := strings.Cut(line, "="); hasVal {
+				line = key + "=" + expand(val, env)
+			}
 			if err := parseEnv(env, line); err != nil {
 				return nil, err
 			}
@@ -88,6 +191,51 @@ func ParseFile(path string) (_ map[string]string, err error) {
 	return env, scanner.Err()
 }
 
+// expand replaces ${NAME}, ${NAME:-default} and ${NAME-default} in value with
+// the value of NAME, looked up first in env and then in the environment of the
+// process.  ${NAME:-default} uses default if NAME is unset or empty,
+// ${NAME-default} only if NAME is unset.  $${ is kept as a literal ${.
+func expand(value string, env map[string]string) string {
+	var sb strings.Builder
+	for {
+		i := strings.Index(value, "${")
+		if i < 0 {
+			break
+		}
+		if i > 0 && value[i-1] == '$' {
+			sb.WriteString(value[:i-1])
+			sb.WriteString("${")
+			value = value[i+2:]
+			continue
+		}
+		end := strings.IndexByte(value[i+2:], '}')
+		if end < 0 {
+			break
+		}
+		sb.WriteString(value[:i])
+		sb.WriteString(expandVariable(value[i+2:i+2+end], env))
+		value = value[i+3+end:]
+	}
+	sb.WriteString(value)
+	return sb.String()
+}
+
+func expandVariable(expr string, env map[string]string) string {
+	name, def, hasDefault := strings.Cut(expr, "-")
+	emptyIsUnset := false
+	if hasDefault {
+		name, emptyIsUnset = strings.CutSuffix(name, ":")
+	}
+	val, ok := env[name]
+	if !ok {
+		val, ok = os.LookupEnv(name)
+	}
+	if hasDefault && (!ok || (emptyIsUnset && val == "")) {
+		return def
+	}
+	return val
+}
+
 func parseEnv(env map[string]string, line string) error {
 	key, val, hasVal := strings.Cut(line, "=")
 
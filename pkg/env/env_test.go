@@ -1,9 +1,15 @@
 package env
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/opencontainers/go-digest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSlice(t *testing.T) {
@@ -184,3 +190,73 @@ func TestMap(t *testing.T) {
 		})
 	}
 }
+
+func TestExpand(t *testing.T) {
+	t.Setenv("PODMAN_TEST_HOST", "host")
+	t.Setenv("PODMAN_TEST_EMPTY", "")
+	env := map[string]string{"FILE": "file"}
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"plain", "plain"},
+		{"${FILE}", "file"},
+		{"a-${FILE}-${PODMAN_TEST_HOST}-b", "a-file-host-b"},
+		{"${PODMAN_TEST_UNSET}", ""},
+		{"${PODMAN_TEST_UNSET:-default}", "default"},
+		{"${PODMAN_TEST_UNSET-default}", "default"},
+		{"${PODMAN_TEST_EMPTY:-default}", "default"},
+		{"${PODMAN_TEST_EMPTY-default}", ""},
+		{"${FILE:-default}", "file"},
+		{"$${FILE}", "${FILE}"},
+		{"$FILE", "$FILE"},
+		{"${FILE", "${FILE"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, expand(tt.value, env), "expand(%q)", tt.value)
+	}
+}
+
+func TestParseSource(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "01-base"), []byte("a=1\nb=${a}2\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "02-override"), []byte("a=3\n"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".hidden"), []byte("hidden=1\n"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0o755))
+
+	env, err := ParseSource(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "3", "b": "12"}, env)
+
+	env, err = ParseSource(filepath.Join(dir, "01-base"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "12"}, env)
+
+	_, err = ParseSource(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestParseSourceURL(t *testing.T) {
+	content := []byte("a=1\nb=${a}2\n")
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	defer server.Close()
+	defaultClient := httpClient
+	httpClient = server.Client()
+	defer func() { httpClient = defaultClient }()
+
+	env, err := ParseSource(server.URL + "/app.env")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "12"}, env)
+
+	env, err = ParseSource(server.URL + "/app.env#" + digest.FromBytes(content).String())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"a": "1", "b": "12"}, env)
+
+	_, err = ParseSource(server.URL + "/app.env#" + digest.FromString("other").String())
+	assert.ErrorContains(t, err, "checksum mismatch")
+
+	_, err = ParseSource(server.URL + "/app.env#sha256:invalid")
+	assert.ErrorContains(t, err, "invalid checksum")
+}
@@ -0,0 +1,373 @@
+// Package layermerge merges consecutive image layers into single layers and
+// drops the whiteouts which do not hide anything.
+package layermerge
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"go.podman.io/storage/pkg/archive"
+)
+
+// Groups splits layers of the given sizes into groups of consecutive layers
+// to be merged together.  Layers are added to a group until its size reaches
+// threshold, a last group smaller than threshold is merged into the previous
+// one.  A threshold of 0 or less disables merging.
+func Groups(sizes []int64, threshold int64) [][]int {
+	var groups [][]int
+	var current []int
+	var currentSize int64
+	for i, size := range sizes {
+		current = append(current, i)
+		currentSize += size
+		if currentSize >= threshold {
+			groups = append(groups, current)
+			current, currentSize = nil, 0
+		}
+	}
+	if len(current) > 0 {
+		if len(groups) > 0 {
+			groups[len(groups)-1] = append(groups[len(groups)-1], current...)
+		} else {
+			groups = append(groups, current)
+		}
+	}
+	return groups
+}
+
+// Lower tracks the paths present in the layers below the layer being
+// merged.
+type Lower struct {
+	// paths maps the paths to whether they are directories.
+	paths map[string]bool
+}
+
+// NewLower returns a Lower for merging the first layer of an image.
+func NewLower() *Lower {
+	return &Lower{paths: make(map[string]bool)}
+}
+
+func (l *Lower) exists(p string) bool {
+	_, ok := l.paths[p]
+	return ok
+}
+
+func (l *Lower) hasChildren(dir string) bool {
+	prefix := childPrefix(dir)
+	for p := range l.paths {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Lower) add(p string, isDir bool) {
+	l.paths[p] = isDir
+	for dir := path.Dir(p); dir != "." && dir != "/"; dir = path.Dir(dir) {
+		l.paths[dir] = true
+	}
+}
+
+func (l *Lower) removeChildren(dir string) {
+	prefix := childPrefix(dir)
+	for p := range l.paths {
+		if strings.HasPrefix(p, prefix) {
+			delete(l.paths, p)
+		}
+	}
+}
+
+func (l *Lower) remove(p string) {
+	delete(l.paths, p)
+	l.removeChildren(p)
+}
+
+// Stats reports what merging changed besides the number of layers.
+type Stats struct {
+	// WhiteoutsRemoved is the number of whiteouts dropped because they
+	// did not hide anything in the lower layers.
+	WhiteoutsRemoved int
+}
+
+type entry struct {
+	layer int
+	isDir bool
+}
+
+type hardlink struct {
+	layer  int
+	name   string
+	target string
+}
+
+// mergePlan is what is kept of the layers being merged, computed from their
+// headers.
+type mergePlan struct {
+	// entries maps the paths to the layer adding their final version.
+	entries map[string]entry
+	// whiteouts maps the whited out paths to the layer removing them.
+	whiteouts map[string]int
+	// opaques maps the opaque directories to the layer making them
+	// opaque.
+	opaques map[string]int
+	// carriers maps a hardlink target superseded by a later layer to the
+	// hardlink, from the same layer, which takes over its content.
+	carriers map[int]map[string]string
+	// toOpaque lists the whited out directories which are added again by
+	// a later layer and hide the content of the lower layers.
+	toOpaque map[string]bool
+}
+
+// Merge writes to w a single layer with the changes of the n layers, from
+// bottom to top, returned by open, as applied on top of lower.  Whiteouts
+// hiding nothing in lower are dropped.  The paths of the merged layer are
+// then added to lower for merging the layers above it.  Each layer is read
+// twice.
+func Merge(w io.Writer, n int, open func(i int) (io.ReadCloser, error), lower *Lower) (Stats, error) {
+	plan, err := planMerge(n, open)
+	if err != nil {
+		return Stats{}, err
+	}
+
+	var stats Stats
+	keepWhiteout := make(map[string]bool)
+	for p := range plan.whiteouts {
+		_, added := plan.entries[p]
+		switch {
+		case plan.toOpaque[p]:
+			if !lower.hasChildren(p) {
+				stats.WhiteoutsRemoved++
+			}
+		case added:
+			// The path added again is replaced in the lower
+			// layers.
+			stats.WhiteoutsRemoved++
+		case lower.exists(p):
+			keepWhiteout[p] = true
+		default:
+			stats.WhiteoutsRemoved++
+		}
+	}
+	keepOpaque := make(map[string]bool)
+	for dir := range plan.opaques {
+		if lower.hasChildren(dir) {
+			keepOpaque[dir] = true
+		} else {
+			stats.WhiteoutsRemoved++
+		}
+	}
+	for dir := range plan.toOpaque {
+		if lower.hasChildren(dir) {
+			keepOpaque[dir] = true
+		}
+	}
+
+	tw := tar.NewWriter(w)
+	written := make(map[string]bool)
+	for i := range n {
+		if err := writeLayer(tw, i, open, plan, keepWhiteout, keepOpaque, written); err != nil {
+			return Stats{}, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return Stats{}, err
+	}
+
+	for dir := range keepOpaque {
+		lower.removeChildren(dir)
+	}
+	for p := range keepWhiteout {
+		lower.remove(p)
+	}
+	for p, e := range plan.entries {
+		if p != "" {
+			lower.add(p, e.isDir)
+		}
+	}
+	return stats, nil
+}
+
+func planMerge(n int, open func(i int) (io.ReadCloser, error)) (*mergePlan, error) {
+	plan := &mergePlan{
+		entries:   make(map[string]entry),
+		whiteouts: make(map[string]int),
+		opaques:   make(map[string]int),
+		carriers:  make(map[int]map[string]string),
+		toOpaque:  make(map[string]bool),
+	}
+	var links []hardlink
+	for i := range n {
+		layerPaths := make(map[string]bool)
+		err := readLayer(i, open, func(hdr *tar.Header, p string, _ io.Reader) error {
+			dir, base := path.Split(p)
+			dir = path.Clean("/" + dir)[1:]
+			switch {
+			case base == archive.WhiteoutOpaqueDir:
+				plan.removeBelow(dir, i, false)
+				plan.opaques[dir] = i
+			case strings.HasPrefix(base, archive.WhiteoutPrefix):
+				target := path.Join(dir, strings.TrimPrefix(base, archive.WhiteoutPrefix))
+				plan.removeBelow(target, i, true)
+				plan.whiteouts[target] = i
+			default:
+				plan.entries[p] = entry{layer: i, isDir: hdr.Typeflag == tar.TypeDir}
+				layerPaths[p] = true
+				if target := cleanPath(hdr.Linkname); hdr.Typeflag == tar.TypeLink && layerPaths[target] {
+					links = append(links, hardlink{layer: i, name: p, target: target})
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for p, layer := range plan.whiteouts {
+		if e, ok := plan.entries[p]; ok && e.layer > layer && e.isDir {
+			plan.toOpaque[p] = true
+		}
+	}
+
+	// A hardlink to a path of its layer replaced by a later layer must
+	// keep the content of the path: the first hardlink kept gets the
+	// content and the others are linked to it.
+	for _, link := range links {
+		if e, ok := plan.entries[link.name]; !ok || e.layer != link.layer {
+			continue
+		}
+		if e, ok := plan.entries[link.target]; ok && e.layer == link.layer {
+			continue
+		}
+		if plan.carriers[link.layer] == nil {
+			plan.carriers[link.layer] = make(map[string]string)
+		}
+		if _, ok := plan.carriers[link.layer][link.target]; !ok {
+			plan.carriers[link.layer][link.target] = link.name
+		}
+	}
+	return plan, nil
+}
+
+// removeBelow drops what layers below layer added at p, or only below p
+// unless self is set.
+func (plan *mergePlan) removeBelow(p string, layer int, self bool) {
+	prefix := childPrefix(p)
+	for q, e := range plan.entries {
+		if e.layer < layer && ((self && q == p) || strings.HasPrefix(q, prefix)) {
+			delete(plan.entries, q)
+		}
+	}
+	for q, l := range plan.whiteouts {
+		if l < layer && strings.HasPrefix(q, prefix) {
+			delete(plan.whiteouts, q)
+		}
+	}
+	for q, l := range plan.opaques {
+		if l < layer && ((self && q == p) || strings.HasPrefix(q, prefix)) {
+			delete(plan.opaques, q)
+		}
+	}
+}
+
+func writeLayer(tw *tar.Writer, i int, open func(i int) (io.ReadCloser, error), plan *mergePlan, keepWhiteout, keepOpaque, written map[string]bool) error {
+	carriers := plan.carriers[i]
+	return readLayer(i, open, func(hdr *tar.Header, p string, content io.Reader) error {
+		dir, base := path.Split(p)
+		dir = path.Clean("/" + dir)[1:]
+		switch {
+		case base == archive.WhiteoutOpaqueDir:
+			if plan.opaques[dir] != i || !keepOpaque[dir] || written[p] {
+				return nil
+			}
+		case strings.HasPrefix(base, archive.WhiteoutPrefix):
+			target := path.Join(dir, strings.TrimPrefix(base, archive.WhiteoutPrefix))
+			if plan.whiteouts[target] != i {
+				return nil
+			}
+			if plan.toOpaque[target] {
+				// The directory added again later hides the
+				// content of the lower layers.
+				opaque := path.Join(target, archive.WhiteoutOpaqueDir)
+				if !keepOpaque[target] || written[opaque] {
+					return nil
+				}
+				hdr.Name = opaque
+				p = opaque
+				break
+			}
+			if !keepWhiteout[target] {
+				return nil
+			}
+		default:
+			if carrier, ok := carriers[p]; ok {
+				// The content of the replaced hardlink target
+				// is written under the hardlink.
+				hdr.Name = carrier
+				p = carrier
+				break
+			}
+			if e, ok := plan.entries[p]; !ok || e.layer != i || written[p] {
+				return nil
+			}
+			if hdr.Typeflag == tar.TypeLink {
+				if carrier, ok := carriers[cleanPath(hdr.Linkname)]; ok {
+					if carrier == p {
+						return nil
+					}
+					hdr.Linkname = carrier
+				}
+			}
+		}
+		written[p] = true
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if hdr.Size > 0 && content != nil {
+			if _, err := io.Copy(tw, content); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// readLayer calls fn with each entry of layer i, its path and its content.
+func readLayer(i int, open func(i int) (io.ReadCloser, error), fn func(hdr *tar.Header, p string, content io.Reader) error) error {
+	rc, err := open(i)
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	tr := tar.NewReader(rc)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading layer %d: %w", i, err)
+		}
+		if err := fn(hdr, cleanPath(hdr.Name), tr); err != nil {
+			return err
+		}
+	}
+}
+
+// cleanPath returns the path of a tar entry relative to the root of the
+// layer, "" for the root.
+func cleanPath(name string) string {
+	return path.Clean("/" + name)[1:]
+}
+
+func childPrefix(dir string) string {
+	if dir == "" {
+		return ""
+	}
+	return dir + "/"
+}
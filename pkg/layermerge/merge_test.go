@@ -0,0 +1,164 @@
+package layermerge
+
+import (
+	"archive/tar"
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testEntry is an entry of a test layer: a directory if name ends with
+// "/", a hardlink if link is set and a file otherwise.
+type testEntry struct {
+	name    string
+	content string
+	link    string
+}
+
+func makeLayer(t *testing.T, entries ...testEntry) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, e := range entries {
+		hdr := &tar.Header{Name: e.name, Mode: 0o644, Typeflag: tar.TypeReg, Size: int64(len(e.content))}
+		switch {
+		case e.name[len(e.name)-1] == '/':
+			hdr.Typeflag, hdr.Mode = tar.TypeDir, 0o755
+		case e.link != "":
+			hdr.Typeflag, hdr.Linkname, hdr.Size = tar.TypeLink, e.link, 0
+		}
+		require.NoError(t, tw.WriteHeader(hdr))
+		if hdr.Size > 0 {
+			_, err := tw.Write([]byte(e.content))
+			require.NoError(t, err)
+		}
+	}
+	require.NoError(t, tw.Close())
+	return buf.Bytes()
+}
+
+// readEntries returns the entries of a layer.
+func readEntries(t *testing.T, layer []byte) []testEntry {
+	t.Helper()
+	var entries []testEntry
+	tr := tar.NewReader(bytes.NewReader(layer))
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return entries
+		}
+		require.NoError(t, err)
+		content, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries = append(entries, testEntry{name: hdr.Name, content: string(content), link: hdr.Linkname})
+	}
+}
+
+func merge(t *testing.T, lower *Lower, layers ...[]byte) ([]testEntry, Stats) {
+	t.Helper()
+	var buf bytes.Buffer
+	stats, err := Merge(&buf, len(layers), func(i int) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(layers[i])), nil
+	}, lower)
+	require.NoError(t, err)
+	return readEntries(t, buf.Bytes()), stats
+}
+
+func TestGroups(t *testing.T) {
+	tests := []struct {
+		sizes     []int64
+		threshold int64
+		groups    [][]int
+	}{
+		{[]int64{10, 20, 30}, 0, [][]int{{0}, {1}, {2}}},
+		{[]int64{100, 1, 50, 2}, 10, [][]int{{0}, {1, 2, 3}}},
+		{[]int64{1, 2, 3}, 10, [][]int{{0, 1, 2}}},
+		{[]int64{5, 5, 5, 5}, 10, [][]int{{0, 1}, {2, 3}}},
+		{nil, 10, nil},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.groups, Groups(tt.sizes, tt.threshold), "sizes %v, threshold %d", tt.sizes, tt.threshold)
+	}
+}
+
+func TestMergeOverride(t *testing.T) {
+	entries, stats := merge(t, NewLower(),
+		makeLayer(t, testEntry{name: "etc/"}, testEntry{name: "etc/a", content: "one"}, testEntry{name: "etc/b", content: "b"}),
+		makeLayer(t, testEntry{name: "etc/a", content: "two"}),
+	)
+	assert.Equal(t, []testEntry{
+		{name: "etc/"},
+		{name: "etc/b", content: "b"},
+		{name: "etc/a", content: "two"},
+	}, entries)
+	assert.Zero(t, stats.WhiteoutsRemoved)
+}
+
+func TestMergeWhiteouts(t *testing.T) {
+	lower := NewLower()
+	merge(t, lower, makeLayer(t, testEntry{name: "base", content: "base"}, testEntry{name: "dir/file", content: "f"}))
+
+	entries, stats := merge(t, lower,
+		makeLayer(t, testEntry{name: "tmp", content: "tmp"}, testEntry{name: "new/"}, testEntry{name: "new/x", content: "x"}),
+		makeLayer(t,
+			// Hides a path added by the merged layers only.
+			testEntry{name: ".wh.tmp"},
+			// Hides a path of the lower layers.
+			testEntry{name: ".wh.base"},
+			// Hides nothing.
+			testEntry{name: ".wh.missing"},
+			// Hides content of the merged layers only.
+			testEntry{name: "new/.wh..wh..opq"},
+			// Hides content of the lower layers.
+			testEntry{name: "dir/.wh..wh..opq"},
+		),
+	)
+	assert.Equal(t, []testEntry{
+		{name: "new/"},
+		{name: ".wh.base"},
+		{name: "dir/.wh..wh..opq"},
+	}, entries)
+	assert.Equal(t, 3, stats.WhiteoutsRemoved)
+
+	// The lower layers now lack the whited out paths.
+	entries, stats = merge(t, lower, makeLayer(t, testEntry{name: ".wh.base"}, testEntry{name: ".wh.dir"}))
+	assert.Equal(t, []testEntry{{name: ".wh.dir"}}, entries)
+	assert.Equal(t, 1, stats.WhiteoutsRemoved)
+}
+
+func TestMergeDirectoryReplaced(t *testing.T) {
+	lower := NewLower()
+	merge(t, lower, makeLayer(t, testEntry{name: "data/"}, testEntry{name: "data/old", content: "old"}))
+
+	// A directory removed and added again hides the lower content.
+	entries, _ := merge(t, lower,
+		makeLayer(t, testEntry{name: ".wh.data"}),
+		makeLayer(t, testEntry{name: "data/"}, testEntry{name: "data/new", content: "new"}),
+	)
+	assert.Equal(t, []testEntry{
+		{name: "data/.wh..wh..opq"},
+		{name: "data/"},
+		{name: "data/new", content: "new"},
+	}, entries)
+}
+
+func TestMergeHardlinks(t *testing.T) {
+	entries, _ := merge(t, NewLower(),
+		makeLayer(t,
+			testEntry{name: "a", content: "one"},
+			testEntry{name: "b", link: "a"},
+			testEntry{name: "c", link: "a"},
+		),
+		makeLayer(t, testEntry{name: "a", content: "two"}),
+	)
+	// The links keep the content of the replaced file.
+	assert.Equal(t, []testEntry{
+		{name: "b", content: "one"},
+		{name: "c", link: "b"},
+		{name: "a", content: "two"},
+	}, entries)
+}
@@ -210,6 +210,99 @@ func (as ArtifactStore) Push(ctx context.Context, src, dest string, opts libimag
 	return artifactDigest, nil
 }
 
+// Export writes the artifact to the OCI layout in dir under its name, with
+// its subject replaced by subject.  Saving the image an artifact refers to
+// can change the digest of its manifest, the artifact is then written with
+// the digest of the saved manifest as subject.
+func (as ArtifactStore) Export(ctx context.Context, nameOrDigest, dir string, subject *specV1.Descriptor) error {
+	if len(nameOrDigest) == 0 {
+		return ErrEmptyArtifactName
+	}
+
+	as.lock.RLock()
+	defer as.lock.Unlock()
+
+	artifacts, err := as.getArtifacts(ctx, nil)
+	if err != nil {
+		return err
+	}
+	arty, _, err := artifacts.GetByNameOrDigest(nameOrDigest)
+	if err != nil {
+		return err
+	}
+	srcRef, err := layout.NewReference(as.storePath, arty.Name)
+	if err != nil {
+		return err
+	}
+	imgSrc, err := srcRef.NewImageSource(ctx, as.SystemContext)
+	if err != nil {
+		return err
+	}
+	defer imgSrc.Close()
+
+	destRef, err := layout.NewReference(dir, arty.Name)
+	if err != nil {
+		return err
+	}
+	imageDest, err := destRef.NewImageDestination(ctx, as.SystemContext)
+	if err != nil {
+		return err
+	}
+	defer imageDest.Close()
+
+	artifactManifest := arty.Manifest.Manifest
+	blobs := append([]specV1.Descriptor{artifactManifest.Config}, artifactManifest.Layers...)
+	for i, blob := range blobs {
+		rc, size, err := imgSrc.GetBlob(ctx, types.BlobInfo{Digest: blob.Digest, Size: blob.Size}, none.NoCache)
+		if err != nil {
+			return fmt.Errorf("reading blob %s of artifact %s: %w", blob.Digest, arty.Name, err)
+		}
+		_, err = imageDest.PutBlob(ctx, rc, types.BlobInfo{Digest: blob.Digest, Size: size}, none.NoCache, i == 0)
+		rc.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	artifactManifest.Subject = subject
+	rawData, err := json.Marshal(artifactManifest)
+	if err != nil {
+		return err
+	}
+	if err := imageDest.PutManifest(ctx, rawData, nil); err != nil {
+		return err
+	}
+	return imageDest.Commit(ctx, newUnparsedArtifactImage(destRef, artifactManifest))
+}
+
+// Import copies the artifact at src, an image reference of any transport,
+// to the local store under name.
+func (as ArtifactStore) Import(ctx context.Context, name string, src types.ImageReference, opts libimage.CopyOptions) (digest.Digest, error) {
+	if len(name) == 0 {
+		return "", ErrEmptyArtifactName
+	}
+
+	as.lock.Lock()
+	defer as.lock.Unlock()
+
+	destRef, err := layout.NewReference(as.storePath, name)
+	if err != nil {
+		return "", err
+	}
+	copyer, err := libimage.NewCopier(&opts, as.SystemContext)
+	if err != nil {
+		return "", err
+	}
+	artifactBytes, err := copyer.Copy(ctx, src, destRef)
+	if err != nil {
+		return "", err
+	}
+	if err := copyer.Close(); err != nil {
+		return "", err
+	}
+	return digest.FromBytes(artifactBytes), nil
+}
+
 // Add takes one or more artifact blobs and add them to the local artifact store.  The empty
 // string input is for possible custom artifact types.
 func (as ArtifactStore) Add(ctx context.Context, dest string, artifactBlobs []entities.ArtifactBlob, options *libartTypes.AddOptions) (*digest.Digest, error) {
@@ -220,6 +313,9 @@ func (as ArtifactStore) Add(ctx context.Context, dest string, artifactBlobs []en
 	if options.Append && len(options.ArtifactMIMEType) > 0 {
 		return nil, errors.New("append option is not compatible with type option")
 	}
+	if options.Append && options.Subject != nil {
+		return nil, errors.New("append option is not compatible with subject option")
+	}
 
 	// currently we don't allow override of the filename ; if a user requirement emerges,
 	// we could seemingly accommodate but broadens possibilities of something bad happening
@@ -268,6 +364,7 @@ func (as ArtifactStore) Add(ctx context.Context, dest string, artifactBlobs []en
 			// TODO This should probably be configurable once the CLI is capable
 			Config:      specV1.DescriptorEmptyJSON,
 			Layers:      make([]specV1.Descriptor, 0),
+			Subject:     options.Subject,
 			Annotations: annotations,
 		}
 	} else {
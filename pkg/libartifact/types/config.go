@@ -1,5 +1,7 @@
 package types
 
+import specV1 "github.com/opencontainers/image-spec/specs-go/v1"
+
 // GetArtifactOptions is a struct containing options that for obtaining artifacts.
 // It is meant for future growth or changes required without wacking the API
 type GetArtifactOptions struct{}
@@ -15,6 +17,9 @@ type AddOptions struct {
 	FileMIMEType string `json:",omitempty"`
 	// Replace option removes existing artifact before adding new one
 	Replace bool `json:",omitempty"`
+	// Subject is the manifest the artifact refers to, e.g. the image an
+	// SBOM describes.  Not compatible with the append option.
+	Subject *specV1.Descriptor `json:",omitempty"`
 }
 
 // FilterBlobOptions options used to filter for a single blob in an artifact
@@ -6,6 +6,9 @@ const (
 	UserCertsTargetPath = "/etc/containers/certs.d"
 	DefaultIdentityName = "machine"
 	DefaultMachineName  = "podman-machine-default"
+	// SharedLayerStoreTarget is where the shared layer store of the host
+	// is mounted in the VM.
+	SharedLayerStoreTarget = "/var/lib/containers/shared-layers"
 )
 
 // MountTag is an identifier to mount a VirtioFS file system tag on a mount point in the VM.
@@ -21,6 +24,11 @@ type CreateVMOpts struct {
 	Dirs               *MachineDirs
 	ReExec             bool
 	UserModeNetworking bool
+	NetworkMode        NetworkMode
+	// WSL only
+	DisableSystemdUserServices bool
+	CgroupsV2                  bool
+	Sysctls                    map[string]string
 }
 
 type MachineDirs struct {
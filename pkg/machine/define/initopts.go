@@ -26,4 +26,12 @@ type InitOptions struct {
 	UserModeNetworking *bool  // nil = use backend/system default, false = disable, true = enable
 	USBs               []string
 	SkipTlsVerify      types.OptionalBool
+	NetworkMode        NetworkMode // empty = use backend default
+	// SharedLayerStore is the host directory mounted in the VM to store
+	// the images shared by the machines
+	SharedLayerStore string
+	// WSL only
+	SystemdUserServices *bool // nil = enabled
+	CgroupsV2           bool
+	Sysctls             map[string]string
 }
@@ -0,0 +1,30 @@
+package define
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NetworkMode describes how a machine is attached to the network of the host
+type NetworkMode string
+
+const (
+	// NetworkModeDefault lets the provider pick its standard network setup
+	NetworkModeDefault NetworkMode = ""
+	// NetworkModeSwitch attaches the machine to the hypervisor default switch
+	// (Hyper-V only). gvproxy is still used for API and ssh forwarding.
+	NetworkModeSwitch NetworkMode = "switch"
+	// NetworkModeUser routes all machine traffic through gvproxy user-mode
+	// networking and does not require any hypervisor network device
+	NetworkModeUser NetworkMode = "user"
+)
+
+// ParseNetworkMode converts the value given to `podman machine init --network`
+// into a NetworkMode
+func ParseNetworkMode(input string) (NetworkMode, error) {
+	switch mode := NetworkMode(strings.TrimSpace(strings.ToLower(input))); mode {
+	case NetworkModeDefault, NetworkModeSwitch, NetworkModeUser:
+		return mode, nil
+	}
+	return NetworkModeDefault, fmt.Errorf("invalid machine network mode %q: must be %q or %q", input, NetworkModeSwitch, NetworkModeUser)
+}
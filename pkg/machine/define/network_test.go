@@ -0,0 +1,46 @@
+package define
+
+import "testing"
+
+func TestParseNetworkMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    NetworkMode
+		wantErr bool
+	}{
+		{
+			name:  "empty input",
+			input: "",
+			want:  NetworkModeDefault,
+		},
+		{
+			name:  "user input",
+			input: "user",
+			want:  NetworkModeUser,
+		},
+		{
+			name:  "switch input with case and spaces",
+			input: " Switch ",
+			want:  NetworkModeSwitch,
+		},
+		{
+			name:    "invalid input",
+			input:   "bridge",
+			want:    NetworkModeDefault,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseNetworkMode(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseNetworkMode(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseNetworkMode(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
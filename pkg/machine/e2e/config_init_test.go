@@ -22,6 +22,7 @@ type initMachine struct {
 	      --playbook string        Run an ansible playbook after first boot
 	      --tls-verify             Require HTTPS and verify certificates when contacting registries
 	      --timezone string        Set timezone (default "local")
+	      --shared-layer-store string  Store the images in the host directory, shared with other machines
 	  -v, --volume stringArray     Volumes to mount, source:target
 	      --volume-driver string   Optional volume driver
 	*/
@@ -39,6 +40,7 @@ type initMachine struct {
 	volumes            []string
 	userModeNetworking bool
 	tlsVerify          *bool
+	sharedLayerStore   string
 
 	cmd []string
 }
@@ -89,6 +91,9 @@ func (i *initMachine) buildCmd(m *machineTestBuilder) []string {
 	if i.tlsVerify != nil {
 		cmd = append(cmd, "--tls-verify="+strconv.FormatBool(*i.tlsVerify))
 	}
+	if l := len(i.sharedLayerStore); l > 0 {
+		cmd = append(cmd, "--shared-layer-store", i.sharedLayerStore)
+	}
 	name := m.name
 	cmd = append(cmd, name)
 
@@ -181,6 +186,11 @@ func (i *initMachine) withTlsVerify(tlsVerify *bool) *initMachine {
 	return i
 }
 
+func (i *initMachine) withSharedLayerStore(dir string) *initMachine {
+	i.sharedLayerStore = dir
+	return i
+}
+
 func (i *initMachine) withUserModeNetworking(r bool) *initMachine { //nolint:unused,nolintlint
 	i.userModeNetworking = r
 	return i
@@ -351,6 +351,36 @@ var _ = Describe("podman machine init", func() {
 		Expect(sshSession.outputToString()).To(ContainSubstring("example"))
 	})
 
+	It("machine init with shared layer store", func() {
+		skipIfWSL("WSL does not use volume mounts")
+
+		store := GinkgoT().TempDir()
+		name := randomString()
+		i := new(initMachine)
+		session, err := mb.setName(name).setCmd(i.withImage(mb.imagePath).withSharedLayerStore(store).withNow()).run()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(session).To(Exit(0))
+
+		bm := basicMachine{}
+		infoSession, err := mb.setCmd(bm.withPodmanCommand([]string{"info", "--format", "{{.Store.SharedStorage}}"})).run()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(infoSession).To(Exit(0))
+		Expect(infoSession.outputToString()).To(Equal("true"))
+
+		// The images pulled in the machine are stored on the host.
+		pullSession, err := mb.setCmd(bm.withPodmanCommand([]string{"pull", TESTIMAGE})).run()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(pullSession).To(Exit(0))
+		stores, err := filepath.Glob(filepath.Join(store, "*", "overlay-images"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(stores).To(HaveLen(1))
+
+		runSession, err := mb.setCmd(bm.withPodmanCommand([]string{"run", "--rm", TESTIMAGE, "cat", "/etc/os-release"})).run()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(runSession).To(Exit(0))
+		Expect(runSession.outputToString()).To(ContainSubstring("Alpine Linux"))
+	})
+
 	It("machine init with ignition path", func() {
 		skipIfWSL("Ignition is not compatible with WSL machines since they are not based on Fedora CoreOS")
 
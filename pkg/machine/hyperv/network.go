@@ -0,0 +1,51 @@
+//go:build windows
+
+package hyperv
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/containers/libhvee/pkg/hypervctl"
+	"github.com/dmikushin/podman-shared/pkg/machine/define"
+	"github.com/sirupsen/logrus"
+)
+
+// resolveNetworkMode determines the network mode a new machine is created
+// with. Hyper-V machines always use gvproxy over vsock; attaching to the
+// default switch is optional and is skipped, with a warning, when the switch
+// is not available on the host (e.g. it was removed by a corporate policy).
+func resolveNetworkMode(requested define.NetworkMode) define.NetworkMode {
+	switch requested {
+	case define.NetworkModeSwitch:
+		if defaultSwitchAvailable() {
+			return define.NetworkModeSwitch
+		}
+		logrus.Warn("Hyper-V default switch is not available, falling back to user-mode networking")
+		return define.NetworkModeUser
+	default:
+		return define.NetworkModeUser
+	}
+}
+
+// defaultSwitchAvailable reports whether the Hyper-V default switch exists
+func defaultSwitchAvailable() bool {
+	query := exec.Command("powershell", []string{"-command", fmt.Sprintf("Get-VMSwitch -Id %s -ErrorAction Stop | Out-Null", hypervctl.DefaultSwitchId)}...)
+	logrus.Debug(query.Args)
+	if out, err := query.CombinedOutput(); err != nil {
+		logrus.Debugf("Unable to find Hyper-V default switch: %v: %s", err, out)
+		return false
+	}
+	return true
+}
+
+// vsockNMConnection returns the NetworkManager profile of the gvproxy vsock
+// interface. When the machine is also attached to the default switch, the
+// default route is left to the switch interface.
+func vsockNMConnection(mode define.NetworkMode) string {
+	ipv4 := "method=auto\n"
+	if mode == define.NetworkModeSwitch {
+		ipv4 += "never-default=true\n"
+	}
+	return fmt.Sprintf(hyperVVsockNMConnection, ipv4)
+}
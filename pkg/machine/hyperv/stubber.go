@@ -28,8 +28,8 @@ type HyperVStubber struct {
 	vmconfigs.HyperVConfig
 }
 
-func (h HyperVStubber) UserModeNetworkEnabled(_ *vmconfigs.MachineConfig) bool {
-	return true
+func (h HyperVStubber) UserModeNetworkEnabled(mc *vmconfigs.MachineConfig) bool {
+	return mc.HyperVHypervisor == nil || mc.HyperVHypervisor.NetworkMode != define.NetworkModeSwitch
 }
 
 func (h HyperVStubber) UseProviderNetworkSetup() bool {
@@ -40,7 +40,7 @@ func (h HyperVStubber) RequireExclusiveActive() bool {
 	return true
 }
 
-func (h HyperVStubber) CreateVM(_ define.CreateVMOpts, mc *vmconfigs.MachineConfig, builder *ignition.IgnitionBuilder) error {
+func (h HyperVStubber) CreateVM(opts define.CreateVMOpts, mc *vmconfigs.MachineConfig, builder *ignition.IgnitionBuilder) error {
 	var (
 		err error
 	)
@@ -48,11 +48,14 @@ func (h HyperVStubber) CreateVM(_ define.CreateVMOpts, mc *vmconfigs.MachineConf
 	defer callbackFuncs.CleanIfErr(&err)
 	go callbackFuncs.CleanOnSignal()
 
+	mc.HyperVHypervisor.NetworkMode = resolveNetworkMode(opts.NetworkMode)
+
 	hwConfig := hypervctl.HardwareConfig{
 		CPUs:     uint16(mc.Resources.CPUs),
 		DiskPath: mc.ImagePath.GetPath(),
 		DiskSize: uint64(mc.Resources.DiskSize),
 		Memory:   uint64(mc.Resources.Memory),
+		Network:  mc.HyperVHypervisor.NetworkMode == define.NetworkModeSwitch,
 	}
 
 	networkHVSock, err := vsock.NewHVSockRegistryEntry(mc.Name, vsock.Network)
@@ -97,7 +100,7 @@ func (h HyperVStubber) CreateVM(_ define.CreateVMOpts, mc *vmconfigs.MachineConf
 		FileEmbedded1: ignition.FileEmbedded1{
 			Append: nil,
 			Contents: ignition.Resource{
-				Source: ignition.EncodeDataURLPtr(hyperVVsockNMConnection),
+				Source: ignition.EncodeDataURLPtr(vsockNMConnection(mc.HyperVHypervisor.NetworkMode)),
 			},
 			Mode: ignition.IntToPtr(0600),
 		},
@@ -533,8 +536,7 @@ mode=2
 cloned-mac-address=5A:94:EF:E4:0C:EE
 
 [ipv4]
-method=auto
-
+%s
 [proxy]
 `
 
@@ -664,6 +664,51 @@ func (i *IgnitionBuilder) AddPlaybook(contents string, destPath string, username
 	return nil
 }
 
+// AddSharedLayerStore configures the container storage of root and of the
+// user to store the images in the shared layer store mounted at target, so
+// that containers can use their layers with --shared-base-layers.  Root and
+// the user have their own image store as the layers of rootless images are
+// owned by the subordinate IDs of the user.
+func (i *IgnitionBuilder) AddSharedLayerStore(target string, username string) {
+	rootConf := fmt.Sprintf(`[storage]
+driver = "overlay"
+runroot = "/run/containers/storage"
+graphroot = "/var/lib/containers/storage"
+imagestore = "%s"
+`, path.Join(target, "root"))
+	userConf := fmt.Sprintf(`[storage]
+driver = "overlay"
+imagestore = "%s"
+`, path.Join(target, username))
+
+	i.WithFile(File{
+		Node: Node{
+			Group:     GetNodeGrp("root"),
+			Path:      "/etc/containers/storage.conf",
+			User:      GetNodeUsr("root"),
+			Overwrite: BoolToPtr(true),
+		},
+		FileEmbedded1: FileEmbedded1{
+			Contents: Resource{
+				Source: EncodeDataURLPtr(rootConf),
+			},
+			Mode: IntToPtr(0644),
+		},
+	}, File{
+		Node: Node{
+			Group: GetNodeGrp(username),
+			Path:  "/home/" + username + "/.config/containers/storage.conf",
+			User:  GetNodeUsr(username),
+		},
+		FileEmbedded1: FileEmbedded1{
+			Contents: Resource{
+				Source: EncodeDataURLPtr(userConf),
+			},
+			Mode: IntToPtr(0644),
+		},
+	})
+}
+
 func DefaultReadyUnitFile() parser.UnitFile {
 	u := parser.NewUnitFile()
 	u.Add("Unit", "After", "sshd.socket sshd.service")
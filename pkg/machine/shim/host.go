@@ -10,6 +10,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
@@ -115,18 +116,31 @@ func Init(opts machineDefine.InitOptions, mp vmconfigs.VMProvider) error {
 	mc.Version = vmconfigs.MachineConfigVersion
 
 	createOpts := machineDefine.CreateVMOpts{
-		Name:   opts.Name,
-		Dirs:   dirs,
-		ReExec: opts.ReExec,
+		Name:        opts.Name,
+		Dirs:        dirs,
+		ReExec:      opts.ReExec,
+		NetworkMode: opts.NetworkMode,
+		CgroupsV2:   opts.CgroupsV2,
+		Sysctls:     opts.Sysctls,
 	}
 
 	if umn := opts.UserModeNetworking; umn != nil {
 		createOpts.UserModeNetworking = *umn
 	}
 
+	if sus := opts.SystemdUserServices; sus != nil {
+		createOpts.DisableSystemdUserServices = !*sus
+	}
+
 	// Mounts
 	if mp.VMType() != machineDefine.WSLVirt {
-		mc.Mounts = CmdLineVolumesToMounts(opts.Volumes, mp.MountType())
+		volumes := opts.Volumes
+		if opts.SharedLayerStore != "" {
+			volumes = append(slices.Clone(volumes), opts.SharedLayerStore+":"+machineDefine.SharedLayerStoreTarget)
+		}
+		mc.Mounts = CmdLineVolumesToMounts(volumes, mp.MountType())
+	} else if opts.SharedLayerStore != "" {
+		return fmt.Errorf("a shared layer store is not supported by the %s provider", mp.VMType().String())
 	}
 
 	// Issue #18230 ... do not mount over important directories at the / level (subdirs are fine)
@@ -226,6 +240,10 @@ func Init(opts machineDefine.InitOptions, mp vmconfigs.VMProvider) error {
 		}
 	}
 
+	if opts.SharedLayerStore != "" {
+		ignBuilder.AddSharedLayerStore(machineDefine.SharedLayerStoreTarget, userName)
+	}
+
 	if len(opts.PlaybookPath) > 0 {
 		f, err := os.Open(opts.PlaybookPath)
 		if err != nil {
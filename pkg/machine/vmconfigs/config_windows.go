@@ -12,11 +12,22 @@ type HyperVConfig struct {
 	ReadyVsock vsock.HVSockRegistryEntry
 	// NetworkVSock is for the user networking
 	NetworkVSock vsock.HVSockRegistryEntry
+	// NetworkMode is the network mode the machine was created with. An
+	// empty value means user-mode networking only.
+	NetworkMode define.NetworkMode `json:",omitempty"`
 }
 
 type WSLConfig struct {
 	// Uses usermode networking
 	UserModeNetworking bool
+	// DisableSystemdUserServices turns off lingering and the systemd
+	// user instance of the remote user
+	DisableSystemdUserServices bool `json:",omitempty"`
+	// CgroupsV2 boots systemd in the distribution on the unified
+	// cgroup v2 hierarchy
+	CgroupsV2 bool `json:",omitempty"`
+	// Sysctls are kernel parameters applied when the distribution boots
+	Sysctls map[string]string `json:",omitempty"`
 }
 
 type QEMUConfig struct {
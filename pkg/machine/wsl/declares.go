@@ -48,10 +48,19 @@ const sudoers = `%wheel        ALL=(ALL)       NOPASSWD: ALL
 
 const bootstrap = `#!/bin/bash
 ps -ef | grep -v grep | grep -q systemd && exit 0
-nohup unshare --kill-child --fork --pid --mount --mount-proc --propagation shared /lib/systemd/systemd >/dev/null 2>&1 &
+nohup unshare --kill-child --fork --pid --mount --mount-proc --propagation shared /lib/systemd/systemd%s >/dev/null 2>&1 &
 sleep 0.1
 `
 
+const enableUserServices = `rm -f /etc/systemd/system/user@.service
+mkdir -p /var/lib/systemd/linger
+touch /var/lib/systemd/linger/[USER]
+`
+
+const disableUserServices = `rm -f /var/lib/systemd/linger/[USER]
+ln -fs /dev/null /etc/systemd/system/user@.service
+`
+
 const wslmotd = `
 You will be automatically entered into a nested process namespace where
 systemd is running. If you need to access the parent namespace, hit ctrl-d
@@ -185,10 +185,6 @@ func configureSystem(mc *vmconfigs.MachineConfig, dist string, ansibleConfig *vm
 		}
 	}
 
-	if err := enableUserLinger(mc, dist); err != nil {
-		return err
-	}
-
 	if err := wslPipe(containersConf, dist, "sh", "-c", "cat > /etc/containers/containers.conf"); err != nil {
 		return fmt.Errorf("could not create containers.conf for guest OS: %w", err)
 	}
@@ -264,15 +260,6 @@ func setupPodmanDockerSock(dist string, rootful bool) error {
 	return nil
 }
 
-func enableUserLinger(mc *vmconfigs.MachineConfig, dist string) error {
-	lingerCmd := "mkdir -p /var/lib/systemd/linger; touch /var/lib/systemd/linger/" + mc.SSH.RemoteUsername
-	if err := wslInvoke(dist, "sh", "-c", lingerCmd); err != nil {
-		return fmt.Errorf("could not enable linger for remote user on guest OS: %w", err)
-	}
-
-	return nil
-}
-
 func configureRegistries(dist string) error {
 	cmd := "cat > /etc/containers/registries.conf.d/999-podman-machine.conf"
 	if err := wslPipe(registriesConf, dist, "sh", "-c", cmd); err != nil {
@@ -297,11 +284,6 @@ func installScripts(dist string) error {
 		return fmt.Errorf("could not create a WSL MOTD for guest OS: %w", err)
 	}
 
-	if err := wslPipe(bootstrap, dist, "sh", "-c",
-		"cat > /root/bootstrap; chmod 755 /root/bootstrap"); err != nil {
-		return fmt.Errorf("could not create bootstrap script for guest OS: %w", err)
-	}
-
 	return nil
 }
 
@@ -0,0 +1,86 @@
+//go:build windows
+
+package wsl
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/pkg/machine/vmconfigs"
+)
+
+const sysctlConfPath = "/etc/sysctl.d/99-podman-machine.conf"
+
+// applyProvisionSettings writes the distribution settings persisted in the
+// machine config. It is run on init and on every start so that the
+// distribution always reflects the machine config.
+func applyProvisionSettings(mc *vmconfigs.MachineConfig, dist string) error {
+	if err := writeBootstrap(dist, mc.WSLHypervisor.CgroupsV2); err != nil {
+		return err
+	}
+
+	if err := configureUserServices(dist, mc.SSH.RemoteUsername, !mc.WSLHypervisor.DisableSystemdUserServices); err != nil {
+		return err
+	}
+
+	return configureSysctls(dist, mc.WSLHypervisor.Sysctls)
+}
+
+func writeBootstrap(dist string, cgroupsV2 bool) error {
+	// systemd reads kernel command line parameters from its own arguments
+	// when it runs as PID 1 of a PID namespace
+	var systemdArgs string
+	if cgroupsV2 {
+		systemdArgs = " systemd.unified_cgroup_hierarchy=1"
+	}
+
+	if err := wslPipe(fmt.Sprintf(bootstrap, systemdArgs), dist, "sh", "-c",
+		"cat > /root/bootstrap; chmod 755 /root/bootstrap"); err != nil {
+		return fmt.Errorf("could not create bootstrap script for guest OS: %w", err)
+	}
+
+	return nil
+}
+
+func configureUserServices(dist string, user string, enable bool) error {
+	script := disableUserServices
+	if enable {
+		script = enableUserServices
+	}
+
+	if err := wslPipe(withUser(script, user), dist, "sh"); err != nil {
+		return fmt.Errorf("could not configure systemd user services for guest OS: %w", err)
+	}
+
+	return nil
+}
+
+func configureSysctls(dist string, sysctls map[string]string) error {
+	if len(sysctls) == 0 {
+		if err := wslInvoke(dist, "rm", "-f", sysctlConfPath); err != nil {
+			return fmt.Errorf("could not remove sysctl config for guest OS: %w", err)
+		}
+		return nil
+	}
+
+	if err := wslPipe(sysctlConf(sysctls), dist, "sh", "-c", "cat > "+sysctlConfPath); err != nil {
+		return fmt.Errorf("could not create sysctl config for guest OS: %w", err)
+	}
+
+	return nil
+}
+
+func sysctlConf(sysctls map[string]string) string {
+	keys := make([]string, 0, len(sysctls))
+	for key := range sysctls {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var sb strings.Builder
+	for _, key := range keys {
+		fmt.Fprintf(&sb, "%s = %s\n", key, sysctls[key])
+	}
+	return sb.String()
+}
@@ -32,7 +32,11 @@ func (w WSLStubber) CreateVM(opts define.CreateVMOpts, mc *vmconfigs.MachineConf
 	callbackFuncs := machine.CleanUp()
 	defer callbackFuncs.CleanIfErr(&err)
 	go callbackFuncs.CleanOnSignal()
-	mc.WSLHypervisor = new(vmconfigs.WSLConfig)
+	mc.WSLHypervisor = &vmconfigs.WSLConfig{
+		DisableSystemdUserServices: opts.DisableSystemdUserServices,
+		CgroupsV2:                  opts.CgroupsV2,
+		Sysctls:                    opts.Sysctls,
+	}
 
 	_ = setupWslProxyEnv()
 
@@ -79,6 +83,10 @@ func (w WSLStubber) CreateVM(opts define.CreateVMOpts, mc *vmconfigs.MachineConf
 		return err
 	}
 
+	if err = applyProvisionSettings(mc, dist); err != nil {
+		return err
+	}
+
 	if err = createKeys(mc, dist); err != nil {
 		return err
 	}
@@ -211,6 +219,10 @@ func (w WSLStubber) PostStartNetworking(mc *vmconfigs.MachineConfig, noInfo bool
 func (w WSLStubber) StartVM(mc *vmconfigs.MachineConfig) (func() error, func() error, error) {
 	dist := env.WithPodmanPrefix(mc.Name)
 
+	if err := applyProvisionSettings(mc, dist); err != nil {
+		return nil, nil, err
+	}
+
 	err := wslInvoke(dist, "/root/bootstrap")
 	if err != nil {
 		err = fmt.Errorf("the WSL bootstrap script failed: %w", err)
@@ -3,11 +3,13 @@
 package ps
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -17,7 +19,9 @@ import (
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
 	"github.com/dmikushin/podman-shared/pkg/domain/filters"
+	"github.com/dmikushin/podman-shared/pkg/parallel"
 	psdefine "github.com/dmikushin/podman-shared/pkg/ps/define"
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/sirupsen/logrus"
 	libnetworkTypes "go.podman.io/common/libnetwork/types"
 	"go.podman.io/storage"
@@ -36,6 +40,13 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 	filterFuncs := make([]libpod.ContainerFilter, 0, len(options.Filters))
 	filterExtFuncs := make([]entities.ExternalContainerFilter, 0, len(options.Filters))
 	all := options.All || options.Last > 0
+	if options.Sort != "" {
+		if err := entities.ValidatePsSort(options.Sort); err != nil {
+			return nil, err
+		}
+		// sizes must be known to sort by them
+		options.Size = options.Size || options.Sort == "size"
+	}
 	if len(options.Filters) > 0 {
 		for k, v := range options.Filters {
 			generatedFunc, err := filters.GenerateContainerFilterFuncs(k, v, runtime)
@@ -78,17 +89,43 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 	if err != nil {
 		return nil, err
 	}
-	if options.Last > 0 {
+	paginated := false
+	if (options.Last > 0 || options.Offset > 0) && options.Sort == "" {
 		// Sort the libpod containers
 		sort.Sort(SortCreateTime{SortContainers: cons})
 		// we should perform the lopping before we start getting
 		// the expensive information on containers
-		if options.Last < len(cons) {
-			cons = cons[:options.Last]
+		switch {
+		case !options.External:
+			cons = util.Paginate(cons, options.Offset, options.Last)
+			paginated = true
+		case options.Last > 0:
+			// external containers may be part of the page
+			cons = util.Paginate(cons, 0, options.Offset+options.Last)
 		}
 	}
-	for _, con := range cons {
-		listCon, err := ListContainerBatch(runtime, con, options)
+	listCons := make([]entities.ListContainer, len(cons))
+	listErrs := make([]error, len(cons))
+	if options.Size {
+		// Computing the sizes walks the writeable layers of the
+		// containers, list them in parallel.
+		errChans := make([]<-chan error, len(cons))
+		for i, con := range cons {
+			errChans[i] = parallel.Enqueue(context.Background(), func() error {
+				var err error
+				listCons[i], err = ListContainerBatch(runtime, con, options)
+				return err
+			})
+		}
+		for i, errChan := range errChans {
+			listErrs[i] = <-errChan
+		}
+	} else {
+		for i, con := range cons {
+			listCons[i], listErrs[i] = ListContainerBatch(runtime, con, options)
+		}
+	}
+	for i, err := range listErrs {
 		switch {
 		// ignore both no ctr and no such pod errors as it means the ctr is gone now
 		case errors.Is(err, define.ErrNoSuchCtr), errors.Is(err, define.ErrNoSuchPod):
@@ -96,7 +133,7 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 		case err != nil:
 			return nil, err
 		default:
-			pss = append(pss, listCon)
+			pss = append(pss, listCons[i])
 		}
 	}
 
@@ -111,11 +148,20 @@ func GetContainerLists(runtime *libpod.Runtime, options entities.ContainerListOp
 	// Sort the containers we got
 	sort.Sort(SortPSCreateTime{SortPSContainers: pss})
 
-	if options.Last > 0 {
-		// only return the "last" containers caller requested
-		if options.Last < len(pss) {
-			pss = pss[:options.Last]
+	if options.Sort != "" {
+		// page through the sorted containers
+		pss, err = entities.SortPsOutput(options.Sort, pss)
+		if err != nil {
+			return nil, err
 		}
+		return util.Paginate(pss, options.Offset, options.Last), nil
+	}
+	if !paginated && (options.Last > 0 || options.Offset > 0) {
+		// only return the "last" containers caller requested, skipping
+		// the offset most recently created ones
+		slices.Reverse(pss)
+		pss = util.Paginate(pss, options.Offset, options.Last)
+		slices.Reverse(pss)
 	}
 	return pss, nil
 }
@@ -188,6 +234,7 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 		healthStatus                            string
 		restartCount                            uint
 		podName                                 string
+		memUsage                                uint64
 	)
 
 	batchErr := ctr.Batch(func(c *libpod.Container) error {
@@ -267,6 +314,14 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 			size.RootFsSize = rootFsSize
 			size.RwSize = rwSize
 		}
+		if opts.Sort == "memory" && (conState == define.ContainerStateRunning || conState == define.ContainerStatePaused) {
+			stats, err := c.GetContainerStats(nil)
+			if err != nil {
+				logrus.Errorf("Getting memory usage of %q: %v", c.ID(), err)
+			} else {
+				memUsage = stats.MemUsage
+			}
+		}
 
 		if opts.Pod && len(conConfig.Pod) > 0 {
 			podName, err = rt.GetPodName(conConfig.Pod)
@@ -282,31 +337,33 @@ func ListContainerBatch(rt *libpod.Runtime, ctr *libpod.Container, opts entities
 	}
 
 	ps := entities.ListContainer{
-		AutoRemove:   ctr.AutoRemove(),
-		CIDFile:      conConfig.Spec.Annotations[define.InspectAnnotationCIDFile],
-		Command:      conConfig.Command,
-		Created:      conConfig.CreatedTime,
-		ExitCode:     exitCode,
-		Exited:       exited,
-		ExitedAt:     exitedTime.Unix(),
-		ExposedPorts: conConfig.ExposedPorts,
-		ID:           conConfig.ID,
-		Image:        conConfig.RootfsImageName,
-		ImageID:      conConfig.RootfsImageID,
-		IsInfra:      conConfig.IsInfra,
-		Labels:       conConfig.Labels,
-		Mounts:       ctr.UserVolumes(),
-		Names:        []string{conConfig.Name},
-		Networks:     networks,
-		Pid:          pid,
-		Pod:          conConfig.Pod,
-		PodName:      podName,
-		Ports:        portMappings,
-		Restarts:     restartCount,
-		Size:         size,
-		StartedAt:    startedTime.Unix(),
-		State:        conState.String(),
-		Status:       healthStatus,
+		AutoRemove:       ctr.AutoRemove(),
+		CIDFile:          conConfig.Spec.Annotations[define.InspectAnnotationCIDFile],
+		Command:          conConfig.Command,
+		Created:          conConfig.CreatedTime,
+		ExitCode:         exitCode,
+		Exited:           exited,
+		ExitedAt:         exitedTime.Unix(),
+		ExposedPorts:     conConfig.ExposedPorts,
+		ID:               conConfig.ID,
+		Image:            conConfig.RootfsImageName,
+		ImageID:          conConfig.RootfsImageID,
+		IsInfra:          conConfig.IsInfra,
+		Labels:           conConfig.Labels,
+		MemUsage:         memUsage,
+		Mounts:           ctr.UserVolumes(),
+		Names:            []string{conConfig.Name},
+		Networks:         networks,
+		Pid:              pid,
+		Pod:              conConfig.Pod,
+		PodName:          podName,
+		Ports:            portMappings,
+		Restarts:         restartCount,
+		Size:             size,
+		StartedAt:        startedTime.Unix(),
+		State:            conState.String(),
+		Status:           healthStatus,
+		StorageTransient: conConfig.StorageTransient,
 	}
 
 	if opts.Namespace {
@@ -0,0 +1,626 @@
+//go:build !remote
+
+// Package registrycache implements a pull-through cache of container
+// registries.  It serves the read-only part of the distribution API, so that
+// machines can use it as a registry mirror, and stores the manifests and
+// blobs fetched from the upstream registries as they are, keeping their
+// digests and the signatures of the images valid.
+//
+// The blobs are kept apart from containers-storage: it stores the layers
+// uncompressed, and the compressed blobs of the registries, whose digests
+// the manifests reference, cannot be recreated from them byte for byte.
+//
+// Only the configured upstream registries are fetched from, always
+// anonymously: the cache is shared by its clients, so the credentials of
+// the user running it must not give them access to private repositories.
+package registrycache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+	"go.podman.io/image/v5/docker"
+	"go.podman.io/image/v5/docker/reference"
+	"go.podman.io/image/v5/manifest"
+	"go.podman.io/image/v5/pkg/blobinfocache/none"
+	"go.podman.io/image/v5/types"
+	"go.podman.io/storage/pkg/ioutils"
+)
+
+const (
+	stateFile = "state.json"
+	blobsDir  = "blobs"
+	// sourceIdleTimeout is how long a connection to an upstream
+	// repository is kept open without being used.
+	sourceIdleTimeout = 5 * time.Minute
+)
+
+// Options are the settings of a Cache.
+type Options struct {
+	// Dir is the directory holding the cached manifests and blobs.
+	Dir string
+	// Upstream is the registry of the repositories whose name does not
+	// start with a registry, docker.io if empty.
+	Upstream string
+	// AllowedUpstreams are the other registries whose repositories can be
+	// fetched, by names starting with the registry.
+	AllowedUpstreams []string
+	// MaxSize is the size the cache is kept under by removing the least
+	// recently used blobs, 0 for no limit.
+	MaxSize int64
+	// TagTTL is how long a tag is served from the cache before checking
+	// the digest it points to in the upstream registry again.
+	TagTTL time.Duration
+	// SystemContext is used to access the upstream registries.  Its
+	// credentials are not used.
+	SystemContext *types.SystemContext
+}
+
+type tagEntry struct {
+	Digest  digest.Digest `json:"digest"`
+	Fetched time.Time     `json:"fetched"`
+}
+
+type blobEntry struct {
+	Size int64 `json:"size"`
+	// MediaType is the type of a manifest, empty for other blobs.
+	MediaType string    `json:"mediaType,omitempty"`
+	LastUsed  time.Time `json:"lastUsed"`
+}
+
+// state is what is known of the content of the cache, saved in the cache
+// directory.
+type state struct {
+	// Tags maps the tagged references, like docker.io/library/alpine:3,
+	// to the manifests they point to.
+	Tags map[string]tagEntry `json:"tags"`
+	// Repositories maps the repositories to the last manifest fetched
+	// from them, used to fetch the blobs of the repositories.
+	Repositories map[string]digest.Digest     `json:"repositories"`
+	Blobs        map[digest.Digest]*blobEntry `json:"blobs"`
+}
+
+type upstreamSource struct {
+	src      types.ImageSource
+	lastUsed time.Time
+	// users is the number of blobs being fetched with src.
+	users int
+}
+
+// Cache is a pull-through cache of container registries.
+type Cache struct {
+	opts Options
+	// upstreams are the domains of the registries which can be fetched
+	// from.
+	upstreams map[string]bool
+
+	mu    sync.Mutex
+	state state
+	stats define.RegistryCacheStats
+	// sources are the connections to the upstream repositories.
+	sources map[string]*upstreamSource
+	// fetching maps the blobs being fetched to a channel closed once
+	// they are.
+	fetching map[digest.Digest]chan struct{}
+}
+
+// New returns a cache storing its content in opts.Dir, with the content
+// cached by a previous instance.
+func New(opts Options) (*Cache, error) {
+	if opts.Upstream == "" {
+		opts.Upstream = "docker.io"
+	}
+	upstreams := make(map[string]bool, len(opts.AllowedUpstreams)+1)
+	for _, registry := range append([]string{opts.Upstream}, opts.AllowedUpstreams...) {
+		// Normalize the registry, e.g. docker.io/library.
+		named, err := reference.ParseNormalizedNamed(registry + "/x")
+		if err != nil {
+			return nil, fmt.Errorf("invalid upstream registry %q: %w", registry, err)
+		}
+		upstreams[reference.Domain(named)] = true
+	}
+	// Fetch anonymously, never with the credentials of the user.
+	sys := types.SystemContext{}
+	if opts.SystemContext != nil {
+		sys = *opts.SystemContext
+	}
+	sys.DockerAuthConfig = &types.DockerAuthConfig{}
+	sys.DockerBearerRegistryToken = ""
+	opts.SystemContext = &sys
+	if err := os.MkdirAll(filepath.Join(opts.Dir, blobsDir), 0o700); err != nil {
+		return nil, err
+	}
+	c := &Cache{
+		opts:      opts,
+		upstreams: upstreams,
+		state: state{
+			Tags:         make(map[string]tagEntry),
+			Repositories: make(map[string]digest.Digest),
+			Blobs:        make(map[digest.Digest]*blobEntry),
+		},
+		sources:  make(map[string]*upstreamSource),
+		fetching: make(map[digest.Digest]chan struct{}),
+	}
+	data, err := os.ReadFile(filepath.Join(opts.Dir, stateFile))
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &c.state); err != nil {
+			return nil, fmt.Errorf("parsing registry cache state: %w", err)
+		}
+	case !errors.Is(err, os.ErrNotExist):
+		return nil, err
+	}
+	// Drop what is no longer on disk.
+	for d := range c.state.Blobs {
+		if err := d.Validate(); err != nil {
+			delete(c.state.Blobs, d)
+			continue
+		}
+		if _, err := os.Stat(c.blobPath(d)); err != nil {
+			delete(c.state.Blobs, d)
+		}
+	}
+	c.stats.MaxSize = opts.MaxSize
+	return c, nil
+}
+
+// Close saves the state of the cache and closes the connections to the
+// upstream registries.
+func (c *Cache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for repo, s := range c.sources {
+		s.src.Close()
+		delete(c.sources, repo)
+	}
+	return c.saveState()
+}
+
+// Stats returns the statistics of the use of the cache.
+func (c *Cache) Stats() define.RegistryCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Tags = len(c.state.Tags)
+	stats.Blobs = len(c.state.Blobs)
+	stats.Size = c.size()
+	return stats
+}
+
+// GC removes the least recently used blobs until the cache is not larger
+// than its maximum size, if it has one.
+func (c *Cache) GC() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.gc(""); err != nil {
+		return err
+	}
+	return c.saveState()
+}
+
+func (c *Cache) blobPath(d digest.Digest) string {
+	return filepath.Join(c.opts.Dir, blobsDir, d.Algorithm().String(), d.Encoded())
+}
+
+func (c *Cache) size() int64 {
+	var size int64
+	for _, blob := range c.state.Blobs {
+		size += blob.Size
+	}
+	return size
+}
+
+// saveState must be called with c.mu held.
+func (c *Cache) saveState() error {
+	data, err := json.Marshal(c.state)
+	if err != nil {
+		return err
+	}
+	return ioutils.AtomicWriteFile(filepath.Join(c.opts.Dir, stateFile), data, 0o600)
+}
+
+// gc must be called with c.mu held.  The blob keep is never removed.
+func (c *Cache) gc(keep digest.Digest) error {
+	if c.opts.MaxSize <= 0 {
+		return nil
+	}
+	size := c.size()
+	if size <= c.opts.MaxSize {
+		return nil
+	}
+	digests := make([]digest.Digest, 0, len(c.state.Blobs))
+	for d := range c.state.Blobs {
+		if d != keep {
+			digests = append(digests, d)
+		}
+	}
+	sort.Slice(digests, func(i, j int) bool {
+		return c.state.Blobs[digests[i]].LastUsed.Before(c.state.Blobs[digests[j]].LastUsed)
+	})
+	for _, d := range digests {
+		if size <= c.opts.MaxSize {
+			break
+		}
+		if _, ok := c.fetching[d]; ok {
+			continue
+		}
+		if err := os.Remove(c.blobPath(d)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		blob := c.state.Blobs[d]
+		size -= blob.Size
+		c.stats.GCRemovedBlobs++
+		c.stats.GCFreedBytes += uint64(blob.Size)
+		delete(c.state.Blobs, d)
+		logrus.Debugf("Registry cache: removed blob %s", d)
+	}
+	for name, tag := range c.state.Tags {
+		if _, ok := c.state.Blobs[tag.Digest]; !ok {
+			delete(c.state.Tags, name)
+		}
+	}
+	for repo, d := range c.state.Repositories {
+		if _, ok := c.state.Blobs[d]; !ok {
+			delete(c.state.Repositories, repo)
+		}
+	}
+	return nil
+}
+
+// errUpstreamDenied is returned for the repositories of registries which
+// are not upstream registries of the cache.
+var errUpstreamDenied = errors.New("registry is not an upstream registry of the cache")
+
+// repository returns the upstream repository of a name of the distribution
+// API.  Names starting with a registry, like quay.io/podman/hello, are
+// looked up in that registry, which must be an upstream registry of the
+// cache, and the others in the default upstream registry.
+func (c *Cache) repository(name string) (reference.Named, error) {
+	if domain, _, ok := strings.Cut(name, "/"); ok && (domain == "localhost" || strings.ContainsAny(domain, ".:")) {
+		named, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			return nil, err
+		}
+		if !c.upstreams[reference.Domain(named)] {
+			return nil, fmt.Errorf("%s: %w", reference.Domain(named), errUpstreamDenied)
+		}
+		return named, nil
+	}
+	return reference.ParseNormalizedNamed(c.opts.Upstream + "/" + name)
+}
+
+// manifest returns the manifest of repo pointed to by tagOrDigest and its
+// media type, fetching it from the upstream registry unless cached.
+func (c *Cache) manifest(ctx context.Context, repo reference.Named, tagOrDigest string) ([]byte, string, digest.Digest, error) {
+	if d, err := digest.Parse(tagOrDigest); err == nil {
+		return c.manifestByDigest(ctx, repo, d)
+	}
+	tagged, err := reference.WithTag(repo, tagOrDigest)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	c.mu.Lock()
+	tag, cached := c.state.Tags[tagged.String()]
+	c.mu.Unlock()
+	if cached {
+		fresh := time.Since(tag.Fetched) < c.opts.TagTTL
+		if !fresh {
+			// Only ask the upstream registry for the digest of
+			// the tag, which is cheaper than fetching the
+			// manifest and not rate limited by some registries.
+			ref, err := docker.NewReference(tagged)
+			if err != nil {
+				return nil, "", "", err
+			}
+			d, err := docker.GetDigest(ctx, c.opts.SystemContext, ref)
+			switch {
+			case err != nil:
+				c.upstreamError()
+				logrus.Warnf("Registry cache: checking %s, serving the cached manifest: %v", tagged, err)
+				fresh = true
+			case d == tag.Digest:
+				c.mu.Lock()
+				tag.Fetched = time.Now()
+				c.state.Tags[tagged.String()] = tag
+				c.mu.Unlock()
+				fresh = true
+			}
+		}
+		if fresh {
+			if data, mediaType, err := c.cachedManifest(tag.Digest); err == nil {
+				return data, mediaType, tag.Digest, nil
+			}
+		}
+	}
+
+	data, mediaType, err := c.fetchManifest(ctx, repo, tagged)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return data, mediaType, digest.FromBytes(data), nil
+}
+
+func (c *Cache) manifestByDigest(ctx context.Context, repo reference.Named, d digest.Digest) ([]byte, string, digest.Digest, error) {
+	if data, mediaType, err := c.cachedManifest(d); err == nil {
+		return data, mediaType, d, nil
+	}
+	canonical, err := reference.WithDigest(repo, d)
+	if err != nil {
+		return nil, "", "", err
+	}
+	data, mediaType, err := c.fetchManifest(ctx, repo, canonical)
+	if err != nil {
+		return nil, "", "", err
+	}
+	if digest.FromBytes(data) != d {
+		return nil, "", "", fmt.Errorf("manifest of %s does not match its digest", canonical)
+	}
+	return data, mediaType, d, nil
+}
+
+// cachedManifest returns a manifest of the cache and its media type.
+func (c *Cache) cachedManifest(d digest.Digest) ([]byte, string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	blob, ok := c.state.Blobs[d]
+	if !ok || blob.MediaType == "" {
+		return nil, "", os.ErrNotExist
+	}
+	data, err := os.ReadFile(c.blobPath(d))
+	if err != nil {
+		return nil, "", err
+	}
+	blob.LastUsed = time.Now()
+	c.stats.ManifestHits++
+	return data, blob.MediaType, nil
+}
+
+// fetchManifest fetches the manifest of ref from the upstream registry and
+// stores it in the cache, as the manifest ref points to if ref is tagged.
+func (c *Cache) fetchManifest(ctx context.Context, repo, ref reference.Named) ([]byte, string, error) {
+	dockerRef, err := docker.NewReference(ref)
+	if err != nil {
+		return nil, "", err
+	}
+	src, err := dockerRef.NewImageSource(ctx, c.opts.SystemContext)
+	if err != nil {
+		c.upstreamError()
+		return nil, "", err
+	}
+	data, mediaType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		src.Close()
+		c.upstreamError()
+		return nil, "", err
+	}
+	if mediaType == "" {
+		mediaType = manifest.GuessMIMEType(data)
+	}
+	d := digest.FromBytes(data)
+	if err := writeBlob(c.blobPath(d), d, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	}); err != nil {
+		src.Close()
+		return nil, "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.ManifestMisses++
+	c.stats.BytesFetched += uint64(len(data))
+	c.state.Blobs[d] = &blobEntry{Size: int64(len(data)), MediaType: mediaType, LastUsed: time.Now()}
+	c.state.Repositories[repo.Name()] = d
+	if tagged, ok := ref.(reference.NamedTagged); ok {
+		c.state.Tags[tagged.String()] = tagEntry{Digest: d, Fetched: time.Now()}
+	}
+	// Keep the connection to fetch the blobs of the image, any
+	// connection to the repository can fetch them.
+	if _, ok := c.sources[repo.Name()]; ok {
+		src.Close()
+	} else {
+		c.sources[repo.Name()] = &upstreamSource{src: src, lastUsed: time.Now()}
+	}
+	if err := c.gc(d); err != nil {
+		logrus.Warnf("Registry cache: removing blobs: %v", err)
+	}
+	if err := c.saveState(); err != nil {
+		logrus.Warnf("Registry cache: saving state: %v", err)
+	}
+	return data, mediaType, nil
+}
+
+// blob returns the path of a blob of the cache, fetching it from repo
+// unless cached.
+func (c *Cache) blob(ctx context.Context, repo reference.Named, d digest.Digest) (string, error) {
+	for {
+		c.mu.Lock()
+		if blob, ok := c.state.Blobs[d]; ok {
+			blob.LastUsed = time.Now()
+			c.stats.BlobHits++
+			c.mu.Unlock()
+			return c.blobPath(d), nil
+		}
+		done, ok := c.fetching[d]
+		if !ok {
+			done = make(chan struct{})
+			c.fetching[d] = done
+			c.mu.Unlock()
+			break
+		}
+		c.mu.Unlock()
+		// Wait for the other request fetching the blob.
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+		c.mu.Lock()
+		_, ok = c.state.Blobs[d]
+		c.mu.Unlock()
+		if !ok {
+			return "", fmt.Errorf("fetching blob %s failed", d)
+		}
+	}
+
+	err := c.fetchBlob(ctx, repo, d)
+	c.mu.Lock()
+	close(c.fetching[d])
+	delete(c.fetching, d)
+	c.mu.Unlock()
+	if err != nil {
+		return "", err
+	}
+	return c.blobPath(d), nil
+}
+
+func (c *Cache) fetchBlob(ctx context.Context, repo reference.Named, d digest.Digest) error {
+	src, release, err := c.source(ctx, repo)
+	if err != nil {
+		c.upstreamError()
+		return err
+	}
+	defer release()
+	rc, _, err := src.GetBlob(ctx, types.BlobInfo{Digest: d, Size: -1}, none.NoCache)
+	if err != nil {
+		c.upstreamError()
+		return err
+	}
+	defer rc.Close()
+	var size int64
+	if err := writeBlob(c.blobPath(d), d, func(w io.Writer) error {
+		size, err = io.Copy(w, rc)
+		return err
+	}); err != nil {
+		c.upstreamError()
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stats.BlobMisses++
+	c.stats.BytesFetched += uint64(size)
+	c.state.Blobs[d] = &blobEntry{Size: size, LastUsed: time.Now()}
+	if err := c.gc(d); err != nil {
+		logrus.Warnf("Registry cache: removing blobs: %v", err)
+	}
+	if err := c.saveState(); err != nil {
+		logrus.Warnf("Registry cache: saving state: %v", err)
+	}
+	return nil
+}
+
+// source returns a connection to the upstream repository, opened for the
+// last manifest fetched from it, and a function to call once done with it.
+func (c *Cache) source(ctx context.Context, repo reference.Named) (types.ImageSource, func(), error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for name, s := range c.sources {
+		if s.users == 0 && time.Since(s.lastUsed) > sourceIdleTimeout {
+			s.src.Close()
+			delete(c.sources, name)
+		}
+	}
+	s, ok := c.sources[repo.Name()]
+	if !ok {
+		d, ok := c.state.Repositories[repo.Name()]
+		if !ok {
+			return nil, nil, fmt.Errorf("no manifest of %s was fetched to fetch its blobs", repo.Name())
+		}
+		canonical, err := reference.WithDigest(repo, d)
+		if err != nil {
+			return nil, nil, err
+		}
+		ref, err := docker.NewReference(canonical)
+		if err != nil {
+			return nil, nil, err
+		}
+		src, err := ref.NewImageSource(ctx, c.opts.SystemContext)
+		if err != nil {
+			return nil, nil, err
+		}
+		s = &upstreamSource{src: src}
+		c.sources[repo.Name()] = s
+	}
+	s.users++
+	s.lastUsed = time.Now()
+	release := func() {
+		c.mu.Lock()
+		s.users--
+		s.lastUsed = time.Now()
+		c.mu.Unlock()
+	}
+	return s.src, release, nil
+}
+
+// tags returns the cached tags of repo.
+func (c *Cache) tags(repo reference.Named) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	tags := []string{}
+	for name := range c.state.Tags {
+		ref, err := reference.ParseNormalizedNamed(name)
+		if err != nil {
+			continue
+		}
+		if tagged, ok := ref.(reference.NamedTagged); ok && ref.Name() == repo.Name() {
+			tags = append(tags, tagged.Tag())
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+func (c *Cache) upstreamError() {
+	c.mu.Lock()
+	c.stats.UpstreamErrors++
+	c.mu.Unlock()
+}
+
+func (c *Cache) served(n int64) {
+	c.mu.Lock()
+	c.stats.BytesServed += uint64(n)
+	c.mu.Unlock()
+}
+
+// writeBlob writes the blob with digest d to path with write, only if the
+// content written matches d.
+func writeBlob(path string, d digest.Digest, write func(w io.Writer) error) (retErr error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	verifier := d.Verifier()
+	err = write(io.MultiWriter(f, verifier))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	if !verifier.Verified() {
+		return fmt.Errorf("content of blob %s does not match its digest", d)
+	}
+	return os.Rename(f.Name(), path)
+}
@@ -0,0 +1,324 @@
+//go:build !remote
+
+package registrycache
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	imgspecv1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/image/v5/types"
+)
+
+// upstream is a registry serving one image, test/app:latest.
+type upstream struct {
+	*httptest.Server
+	manifest []byte
+	blobs    map[digest.Digest][]byte
+
+	mu       sync.Mutex
+	requests map[string]int
+	// requireAuth makes the registry ask for the credentials of the user.
+	requireAuth bool
+	// authorized counts the requests sent with the credentials of the
+	// user.
+	authorized int
+}
+
+// userAuth is the authorization of the user in the auth file of the cache.
+const userAuth = "Basic dXNlcjpwYXNz"
+
+func newUpstream(t *testing.T) *upstream {
+	t.Helper()
+	config := []byte(`{"architecture":"amd64","os":"linux"}`)
+	layer := []byte(strings.Repeat("layer", 1000))
+	m, err := json.Marshal(imgspecv1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: imgspecv1.MediaTypeImageManifest,
+		Config:    imgspecv1.Descriptor{MediaType: imgspecv1.MediaTypeImageConfig, Digest: digest.FromBytes(config), Size: int64(len(config))},
+		Layers:    []imgspecv1.Descriptor{{MediaType: imgspecv1.MediaTypeImageLayer, Digest: digest.FromBytes(layer), Size: int64(len(layer))}},
+	})
+	require.NoError(t, err)
+	u := &upstream{
+		manifest: m,
+		blobs: map[digest.Digest][]byte{
+			digest.FromBytes(config): config,
+			digest.FromBytes(layer):  layer,
+		},
+		requests: make(map[string]int),
+	}
+	u.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u.mu.Lock()
+		u.requests[r.Method+" "+r.URL.Path]++
+		authorized := r.Header.Get("Authorization") == userAuth
+		if authorized {
+			u.authorized++
+		}
+		requireAuth := u.requireAuth
+		u.mu.Unlock()
+		switch {
+		case requireAuth && !authorized:
+			w.Header().Set("WWW-Authenticate", `Basic realm="test"`)
+			w.WriteHeader(http.StatusUnauthorized)
+		case r.URL.Path == "/v2/":
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/v2/test/app/manifests/latest" || r.URL.Path == "/v2/test/app/manifests/"+digest.FromBytes(m).String():
+			w.Header().Set("Content-Type", imgspecv1.MediaTypeImageManifest)
+			w.Header().Set("Docker-Content-Digest", digest.FromBytes(m).String())
+			if r.Method != http.MethodHead {
+				_, _ = w.Write(m)
+			}
+		case strings.HasPrefix(r.URL.Path, "/v2/test/app/blobs/"):
+			blob, ok := u.blobs[digest.Digest(strings.TrimPrefix(r.URL.Path, "/v2/test/app/blobs/"))]
+			if !ok {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = w.Write(blob)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(u.Close)
+	return u
+}
+
+func (u *upstream) host() string {
+	return strings.TrimPrefix(u.URL, "http://")
+}
+
+func (u *upstream) count(request string) int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	return u.requests[request]
+}
+
+func newTestCache(t *testing.T, u *upstream, dir string, maxSize int64, allowedUpstreams ...string) *Cache {
+	t.Helper()
+	confDir := t.TempDir()
+	registriesConf := filepath.Join(confDir, "registries.conf")
+	require.NoError(t, os.WriteFile(registriesConf, nil, 0o600))
+	// Credentials of the user running the cache, which must not be used.
+	authFile := filepath.Join(confDir, "auth.json")
+	auth := `{"auths":{"` + u.host() + `":{"auth":"` + strings.TrimPrefix(userAuth, "Basic ") + `"}}}`
+	require.NoError(t, os.WriteFile(authFile, []byte(auth), 0o600))
+	c, err := New(Options{
+		Dir:              dir,
+		Upstream:         u.host(),
+		AllowedUpstreams: allowedUpstreams,
+		MaxSize:          maxSize,
+		TagTTL:           time.Hour,
+		SystemContext: &types.SystemContext{
+			DockerInsecureSkipTLSVerify: types.OptionalBoolTrue,
+			SystemRegistriesConfPath:    registriesConf,
+			SystemRegistriesConfDirPath: confDir,
+			AuthFilePath:                authFile,
+		},
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func get(t *testing.T, url string) (int, http.Header, []byte) {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, resp.Header, body
+}
+
+func TestPullThrough(t *testing.T) {
+	u := newUpstream(t)
+	c := newTestCache(t, u, t.TempDir(), 0)
+	defer c.Close()
+	front := httptest.NewServer(c)
+	defer front.Close()
+
+	status, _, _ := get(t, front.URL+"/v2/")
+	assert.Equal(t, http.StatusOK, status)
+
+	status, header, body := get(t, front.URL+"/v2/test/app/manifests/latest")
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Equal(t, u.manifest, body)
+	assert.Equal(t, digest.FromBytes(u.manifest).String(), header.Get("Docker-Content-Digest"))
+	assert.Equal(t, imgspecv1.MediaTypeImageManifest, header.Get("Content-Type"))
+
+	for d, blob := range u.blobs {
+		for range 2 {
+			status, _, body = get(t, front.URL+"/v2/test/app/blobs/"+d.String())
+			require.Equal(t, http.StatusOK, status, string(body))
+			assert.Equal(t, blob, body)
+		}
+		assert.Equal(t, 1, u.count("GET /v2/test/app/blobs/"+d.String()), "blob fetched once")
+	}
+
+	// The manifest is served from the cache, by tag and by digest, also
+	// with the name of the registry in the repository.
+	manifestGets := u.count("GET /v2/test/app/manifests/latest")
+	for _, path := range []string{"/v2/test/app/manifests/latest", "/v2/test/app/manifests/" + digest.FromBytes(u.manifest).String(), "/v2/" + u.host() + "/test/app/manifests/latest"} {
+		status, _, body = get(t, front.URL+path)
+		require.Equal(t, http.StatusOK, status, path)
+		assert.Equal(t, u.manifest, body, path)
+	}
+	assert.Equal(t, manifestGets, u.count("GET /v2/test/app/manifests/latest"))
+
+	status, _, body = get(t, front.URL+"/v2/test/app/tags/list")
+	require.Equal(t, http.StatusOK, status)
+	assert.JSONEq(t, `{"name":"test/app","tags":["latest"]}`, string(body))
+
+	status, _, body = get(t, front.URL+"/v2/test/missing/manifests/latest")
+	assert.Equal(t, http.StatusNotFound, status)
+	assert.Contains(t, string(body), errManifestUnknown)
+
+	resp, err := http.Post(front.URL+"/v2/test/app/blobs/uploads/", "", nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	stats := c.Stats()
+	assert.Equal(t, uint64(1), stats.ManifestMisses)
+	assert.Equal(t, uint64(3), stats.ManifestHits)
+	assert.Equal(t, uint64(2), stats.BlobMisses)
+	assert.Equal(t, uint64(2), stats.BlobHits)
+	assert.Equal(t, 3, stats.Blobs)
+	assert.Equal(t, 1, stats.Tags)
+	assert.Positive(t, stats.UpstreamErrors)
+}
+
+func TestCacheReopened(t *testing.T) {
+	u := newUpstream(t)
+	dir := t.TempDir()
+	c := newTestCache(t, u, dir, 0)
+	front := httptest.NewServer(c)
+	status, _, _ := get(t, front.URL+"/v2/test/app/manifests/latest")
+	require.Equal(t, http.StatusOK, status)
+	for d := range u.blobs {
+		status, _, _ = get(t, front.URL+"/v2/test/app/blobs/"+d.String())
+		require.Equal(t, http.StatusOK, status)
+	}
+	front.Close()
+	require.NoError(t, c.Close())
+
+	// The content is still served with the upstream registry down.
+	u.Close()
+	c = newTestCache(t, u, dir, 0)
+	defer c.Close()
+	front = httptest.NewServer(c)
+	defer front.Close()
+	status, _, body := get(t, front.URL+"/v2/test/app/manifests/latest")
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Equal(t, u.manifest, body)
+	for d, blob := range u.blobs {
+		status, _, body = get(t, front.URL+"/v2/test/app/blobs/"+d.String())
+		require.Equal(t, http.StatusOK, status)
+		assert.Equal(t, blob, body)
+	}
+}
+
+func TestGC(t *testing.T) {
+	u := newUpstream(t)
+	// Large enough for the manifest and the config only.
+	c := newTestCache(t, u, t.TempDir(), 1000)
+	defer c.Close()
+	front := httptest.NewServer(c)
+	defer front.Close()
+
+	status, _, _ := get(t, front.URL+"/v2/test/app/manifests/latest")
+	require.Equal(t, http.StatusOK, status)
+	var layer digest.Digest
+	for d, blob := range u.blobs {
+		if len(blob) > 1000 {
+			layer = d
+		}
+	}
+	// The layer is larger than the cache: it is served, removing the
+	// manifest, and removed with the next blob fetched.
+	status, _, _ = get(t, front.URL+"/v2/test/app/blobs/"+layer.String())
+	require.Equal(t, http.StatusOK, status)
+	for d := range u.blobs {
+		if d != layer {
+			status, _, _ = get(t, front.URL+"/v2/test/app/blobs/"+d.String())
+			require.Equal(t, http.StatusOK, status)
+		}
+	}
+
+	stats := c.Stats()
+	assert.LessOrEqual(t, stats.Size, int64(1000))
+	assert.Equal(t, uint64(2), stats.GCRemovedBlobs)
+	assert.Equal(t, uint64(len(u.blobs[layer])+len(u.manifest)), stats.GCFreedBytes)
+	assert.Zero(t, stats.Tags)
+	_, err := os.Stat(c.blobPath(layer))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestTagRevalidated(t *testing.T) {
+	u := newUpstream(t)
+	c := newTestCache(t, u, t.TempDir(), 0)
+	defer c.Close()
+	c.opts.TagTTL = 0
+	front := httptest.NewServer(c)
+	defer front.Close()
+
+	for range 2 {
+		status, _, body := get(t, front.URL+"/v2/test/app/manifests/latest")
+		require.Equal(t, http.StatusOK, status, string(body))
+		assert.Equal(t, u.manifest, body)
+	}
+	// The tag is checked again with a HEAD request, the manifest is not
+	// fetched again since it did not change.
+	assert.Equal(t, 1, u.count("HEAD /v2/test/app/manifests/latest"))
+	assert.Equal(t, uint64(1), c.Stats().ManifestHits)
+}
+
+func TestUpstreamNotAllowed(t *testing.T) {
+	u := newUpstream(t)
+	other := newUpstream(t)
+	allowed := newUpstream(t)
+	c := newTestCache(t, u, t.TempDir(), 0, allowed.host())
+	defer c.Close()
+	front := httptest.NewServer(c)
+	defer front.Close()
+
+	for _, path := range []string{"/manifests/latest", "/blobs/" + digest.FromBytes(other.manifest).String(), "/tags/list"} {
+		status, _, body := get(t, front.URL+"/v2/"+other.host()+"/test/app"+path)
+		assert.Equal(t, http.StatusForbidden, status, path)
+		assert.Contains(t, string(body), errDenied, path)
+	}
+	other.mu.Lock()
+	assert.Empty(t, other.requests, "registry not allowed contacted")
+	other.mu.Unlock()
+
+	status, _, body := get(t, front.URL+"/v2/"+allowed.host()+"/test/app/manifests/latest")
+	require.Equal(t, http.StatusOK, status, string(body))
+	assert.Equal(t, allowed.manifest, body)
+}
+
+func TestUpstreamCredentialsNotUsed(t *testing.T) {
+	u := newUpstream(t)
+	u.requireAuth = true
+	c := newTestCache(t, u, t.TempDir(), 0)
+	defer c.Close()
+	front := httptest.NewServer(c)
+	defer front.Close()
+
+	status, _, _ := get(t, front.URL+"/v2/test/app/manifests/latest")
+	assert.NotEqual(t, http.StatusOK, status)
+	assert.Positive(t, u.count("GET /v2/"), "registry not contacted")
+	u.mu.Lock()
+	assert.Zero(t, u.authorized, "credentials sent to the registry")
+	u.mu.Unlock()
+}
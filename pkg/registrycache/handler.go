@@ -0,0 +1,168 @@
+//go:build !remote
+
+package registrycache
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/opencontainers/go-digest"
+	"github.com/sirupsen/logrus"
+)
+
+// Error codes of the distribution API.
+const (
+	errNameInvalid      = "NAME_INVALID"
+	errManifestUnknown  = "MANIFEST_UNKNOWN"
+	errBlobUnknown      = "BLOB_UNKNOWN"
+	errDigestInvalid    = "DIGEST_INVALID"
+	errUnsupported      = "UNSUPPORTED"
+	errDenied           = "DENIED"
+	distributionVersion = "registry/2.0"
+)
+
+type apiError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+func writeError(w http.ResponseWriter, status int, code, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(struct {
+		Errors []apiError `json:"errors"`
+	}{[]apiError{{Code: code, Message: message}}}); err != nil {
+		logrus.Debugf("Registry cache: writing error: %v", err)
+	}
+}
+
+// writeRepositoryError writes the error of looking up the repository of a
+// name.
+func writeRepositoryError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errUpstreamDenied) {
+		writeError(w, http.StatusForbidden, errDenied, err.Error())
+		return
+	}
+	writeError(w, http.StatusBadRequest, errNameInvalid, err.Error())
+}
+
+// countingWriter counts the bytes written to the response.
+type countingWriter struct {
+	http.ResponseWriter
+	n int64
+}
+
+func (w *countingWriter) Write(p []byte) (int, error) {
+	n, err := w.ResponseWriter.Write(p)
+	w.n += int64(n)
+	return n, err
+}
+
+// ServeHTTP serves the pull part of the distribution API: the manifests,
+// blobs and cached tags of the repositories.
+func (c *Cache) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Docker-Distribution-API-Version", distributionVersion)
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		writeError(w, http.StatusMethodNotAllowed, errUnsupported, "the registry cache is read-only")
+		return
+	}
+	path, ok := strings.CutPrefix(r.URL.Path, "/v2")
+	if !ok || (path != "" && !strings.HasPrefix(path, "/")) {
+		writeError(w, http.StatusNotFound, errUnsupported, "not a distribution API path")
+		return
+	}
+	path = strings.TrimPrefix(path, "/")
+	if path == "" {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("{}"))
+		return
+	}
+
+	cw := &countingWriter{ResponseWriter: w}
+	switch {
+	case strings.HasSuffix(path, "/tags/list"):
+		c.serveTags(cw, strings.TrimSuffix(path, "/tags/list"))
+	case strings.Contains(path, "/manifests/"):
+		i := strings.LastIndex(path, "/manifests/")
+		c.serveManifest(cw, r, path[:i], path[i+len("/manifests/"):])
+	case strings.Contains(path, "/blobs/"):
+		i := strings.LastIndex(path, "/blobs/")
+		c.serveBlob(cw, r, path[:i], path[i+len("/blobs/"):])
+	default:
+		writeError(w, http.StatusNotFound, errUnsupported, "not a distribution API path")
+		return
+	}
+	c.served(cw.n)
+}
+
+func (c *Cache) serveTags(w http.ResponseWriter, name string) {
+	repo, err := c.repository(name)
+	if err != nil {
+		writeRepositoryError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}{name, c.tags(repo)}); err != nil {
+		logrus.Debugf("Registry cache: writing tags: %v", err)
+	}
+}
+
+func (c *Cache) serveManifest(w http.ResponseWriter, r *http.Request, name, tagOrDigest string) {
+	repo, err := c.repository(name)
+	if err != nil {
+		writeRepositoryError(w, err)
+		return
+	}
+	data, mediaType, d, err := c.manifest(r.Context(), repo, tagOrDigest)
+	if err != nil {
+		logrus.Debugf("Registry cache: manifest %s of %s: %v", tagOrDigest, repo, err)
+		writeError(w, http.StatusNotFound, errManifestUnknown, err.Error())
+		return
+	}
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Docker-Content-Digest", d.String())
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+	if r.Method == http.MethodHead {
+		return
+	}
+	_, _ = bytes.NewReader(data).WriteTo(w)
+}
+
+func (c *Cache) serveBlob(w http.ResponseWriter, r *http.Request, name, dgst string) {
+	repo, err := c.repository(name)
+	if err != nil {
+		writeRepositoryError(w, err)
+		return
+	}
+	d, err := digest.Parse(dgst)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, errDigestInvalid, err.Error())
+		return
+	}
+	path, err := c.blob(r.Context(), repo, d)
+	if err != nil {
+		logrus.Debugf("Registry cache: blob %s of %s: %v", d, repo, err)
+		writeError(w, http.StatusNotFound, errBlobUnknown, err.Error())
+		return
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		// Removed to keep the cache under its maximum size.
+		writeError(w, http.StatusNotFound, errBlobUnknown, err.Error())
+		return
+	}
+	defer f.Close()
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Docker-Content-Digest", d.String())
+	// Blobs never change, ServeContent handles range requests.
+	http.ServeContent(w, r, "", time.Time{}, f)
+}
@@ -4,6 +4,7 @@ package rootless
 
 import (
 	"errors"
+	"os/exec"
 
 	"go.podman.io/storage/pkg/idtools"
 )
@@ -57,3 +58,9 @@ func GetConfiguredMappings(_ bool) ([]idtools.IDMap, []idtools.IDMap, error) {
 func IsFdInherited(fd int) bool {
 	return int(C.is_fd_inherited(C.int(fd))) > 0
 }
+
+// JoinNamespacesCommand returns a command running args in the namespaces of
+// the process pid.  It is not supported on this OS.
+func JoinNamespacesCommand(_ int, _, _ []string) (*exec.Cmd, error) {
+	return nil, errors.New("this function is not supported on this os")
+}
@@ -17,6 +17,7 @@
 #include <sys/types.h>
 #include <sys/prctl.h>
 #include <dirent.h>
+#include <grp.h>
 #include <sys/select.h>
 #include <stdio.h>
 
@@ -428,10 +429,99 @@ is_fd_inherited(int fd)
   return FD_ISSET(fd % FD_SETSIZE, &(open_files_set[fd / FD_SETSIZE])) ? 1 : 0;
 }
 
+/* Join the namespaces of NSENTER, "PID:NS[,NS...]", e.g. "42:user,mnt", in
+   their order, become root in the user namespace and execute the command of
+   ARGV.  The namespaces the process is already in are skipped.  */
+static void
+do_nsenter (const char *nsenter, char **argv)
+{
+  cleanup_free char *value = NULL;
+  const char *names[8];
+  int fds[8];
+  char *ns, *saveptr = NULL, *end;
+  int n = 0, i;
+  long pid;
+
+  value = strdup (nsenter);
+  if (value == NULL)
+    {
+      fprintf (stderr, "cannot allocate memory\n");
+      _exit (EXIT_FAILURE);
+    }
+  unsetenv ("_PODMAN_NSENTER");
+
+  pid = strtol (value, &end, 10);
+  if (end == value || *end != ':' || pid <= 0 || argv[0] == NULL || argv[1] == NULL)
+    {
+      fprintf (stderr, "invalid namespaces to join: %s\n", value);
+      _exit (EXIT_FAILURE);
+    }
+
+  /* Open all the namespaces first, /proc is the one of the container once
+     its mount namespace is joined.  */
+  for (ns = strtok_r (end + 1, ",", &saveptr); ns; ns = strtok_r (NULL, ",", &saveptr))
+    {
+      char self_path[PATH_MAX];
+      struct stat target, self;
+      int fd;
+
+      if (n == sizeof (fds) / sizeof (fds[0]))
+        {
+          fprintf (stderr, "too many namespaces to join\n");
+          _exit (EXIT_FAILURE);
+        }
+      fd = open_namespace (pid, ns);
+      if (fd < 0)
+        {
+          fprintf (stderr, "cannot open %s namespace of process %ld: %m\n", ns, pid);
+          _exit (EXIT_FAILURE);
+        }
+      snprintf (self_path, sizeof (self_path), "/proc/self/ns/%s", ns);
+      if (fstat (fd, &target) == 0 && stat (self_path, &self) == 0
+          && target.st_dev == self.st_dev && target.st_ino == self.st_ino)
+        {
+          close (fd);
+          continue;
+        }
+      names[n] = ns;
+      fds[n++] = fd;
+    }
+
+  for (i = 0; i < n; i++)
+    {
+      if (setns (fds[i], 0) < 0)
+        {
+          fprintf (stderr, "cannot join %s namespace of process %ld: %m\n", names[i], pid);
+          _exit (EXIT_FAILURE);
+        }
+      close (fds[i]);
+      if (strcmp (names[i], "user") == 0)
+        {
+          /* setgroups is denied in the user namespaces whose gid mapping
+             was not set up by newgidmap.  */
+          if (setgroups (0, NULL) < 0 && errno != EPERM)
+            {
+              fprintf (stderr, "cannot setgroups: %m\n");
+              _exit (EXIT_FAILURE);
+            }
+          if (syscall_setresgid (0, 0, 0) < 0 || syscall_setresuid (0, 0, 0) < 0)
+            {
+              fprintf (stderr, "cannot become root in the user namespace of process %ld: %m\n", pid);
+              _exit (EXIT_FAILURE);
+            }
+        }
+    }
+
+  execvp (argv[1], argv + 1);
+  fprintf (stderr, "cannot execute %s: %m\n", argv[1]);
+  _exit (errno == ENOENT ? 127 : 126);
+}
+
 static void __attribute__((constructor)) init()
 {
   const char *xdg_runtime_dir;
   const char *pause;
+  const char *nsenter;
   const char *listen_pid;
   const char *listen_fds;
   const char *listen_fdnames;
@@ -447,6 +537,18 @@ static void __attribute__((constructor)) init()
       _exit (EXIT_FAILURE);
     }
 
+  nsenter = getenv ("_PODMAN_NSENTER");
+  if (nsenter && nsenter[0])
+    {
+      argv = get_cmd_line_args (&argc);
+      if (argv == NULL)
+        {
+          fprintf (stderr, "cannot retrieve cmd line\n");
+          _exit (EXIT_FAILURE);
+        }
+      do_nsenter (nsenter, argv);
+    }
+
   /* Store how many FDs were open before the Go runtime kicked in.  */
   d = opendir ("/proc/self/fd");
   if (d)
@@ -162,6 +162,20 @@ func joinUserAndMountNS(pid uint, pausePid string) (bool, int, error) {
 	return waitAndProxySignalsToChild(pidC)
 }
 
+// JoinNamespacesCommand returns a command running args in the namespaces of
+// the process pid, e.g. "user", "mnt" and "net", joined in this order, as
+// root of the user namespace.  The namespaces are joined by podman
+// re-executed, before the Go runtime starts.
+func JoinNamespacesCommand(pid int, namespaces, args []string) (*exec.Cmd, error) {
+	if len(args) == 0 {
+		return nil, errors.New("no command to run in the namespaces")
+	}
+	cmd := exec.Command("/proc/self/exe", args...)
+	cmd.Args[0] = os.Args[0]
+	cmd.Env = append(os.Environ(), fmt.Sprintf("_PODMAN_NSENTER=%d:%s", pid, strings.Join(namespaces, ",")))
+	return cmd, nil
+}
+
 // GetConfiguredMappings returns the additional IDs configured for the current user.
 func GetConfiguredMappings(quiet bool) ([]idtools.IDMap, []idtools.IDMap, error) {
 	var uids, gids []idtools.IDMap
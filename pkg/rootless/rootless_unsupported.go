@@ -5,6 +5,7 @@ package rootless
 import (
 	"errors"
 	"os"
+	"os/exec"
 
 	"go.podman.io/storage/pkg/idtools"
 )
@@ -60,3 +61,9 @@ func GetConfiguredMappings(_ bool) ([]idtools.IDMap, []idtools.IDMap, error) {
 func IsFdInherited(_ int) bool {
 	return false
 }
+
+// JoinNamespacesCommand returns a command running args in the namespaces of
+// the process pid.  It is not supported on this OS.
+func JoinNamespacesCommand(_ int, _, _ []string) (*exec.Cmd, error) {
+	return nil, errors.New("this function is not supported on this os")
+}
@@ -0,0 +1,251 @@
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/dmikushin/podman-shared/version"
+	"github.com/google/uuid"
+)
+
+// Supported SBOM formats.
+const (
+	FormatSPDX      = "spdx"
+	FormatCycloneDX = "cyclonedx"
+)
+
+// Media types of the SBOM formats, also used as artifact types when
+// attaching an SBOM to an image in a registry.
+const (
+	MediaTypeSPDX      = "application/spdx+json"
+	MediaTypeCycloneDX = "application/vnd.cyclonedx+json"
+)
+
+// Image identifies the image an SBOM describes.
+type Image struct {
+	Name   string
+	ID     string
+	Digest string
+}
+
+// MediaType returns the media type of the format.
+func MediaType(format string) (string, error) {
+	switch format {
+	case FormatSPDX:
+		return MediaTypeSPDX, nil
+	case FormatCycloneDX:
+		return MediaTypeCycloneDX, nil
+	}
+	return "", fmt.Errorf("unsupported SBOM format %q, must be %q or %q", format, FormatSPDX, FormatCycloneDX)
+}
+
+// Generate returns the SBOM of the image's inventory in the given format.
+func Generate(format string, img Image, inv *Inventory, created time.Time) ([]byte, error) {
+	var doc any
+	switch format {
+	case FormatSPDX:
+		doc = spdxDocument(img, inv, created)
+	case FormatCycloneDX:
+		doc = cycloneDXDocument(img, inv, created)
+	default:
+		_, err := MediaType(format)
+		return nil, err
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+func imageName(img Image) string {
+	if img.Name != "" {
+		return img.Name
+	}
+	return img.ID
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxPackage struct {
+	Name                  string            `json:"name"`
+	SPDXID                string            `json:"SPDXID"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	LicenseConcluded      string            `json:"licenseConcluded"`
+	LicenseDeclared       string            `json:"licenseDeclared"`
+	LicenseComments       string            `json:"licenseComments,omitempty"`
+	SourceInfo            string            `json:"sourceInfo,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose,omitempty"`
+	ExternalRefs          []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+type spdxDoc struct {
+	SPDXVersion       string `json:"spdxVersion"`
+	DataLicense       string `json:"dataLicense"`
+	SPDXID            string `json:"SPDXID"`
+	Name              string `json:"name"`
+	DocumentNamespace string `json:"documentNamespace"`
+	CreationInfo      struct {
+		Created  string   `json:"created"`
+		Creators []string `json:"creators"`
+	} `json:"creationInfo"`
+	Packages      []spdxPackage      `json:"packages"`
+	Relationships []spdxRelationship `json:"relationships"`
+}
+
+func spdxDocument(img Image, inv *Inventory, created time.Time) *spdxDoc {
+	const noAssertion = "NOASSERTION"
+	name := imageName(img)
+	doc := &spdxDoc{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              name,
+		DocumentNamespace: "https://podman.io/spdxdocs/" + url.PathEscape(name) + "-" + uuid.NewString(),
+	}
+	doc.CreationInfo.Created = created.UTC().Format(time.RFC3339)
+	doc.CreationInfo.Creators = []string{"Tool: podman-" + version.Version.String()}
+
+	doc.Packages = append(doc.Packages, spdxPackage{
+		Name:                  name,
+		SPDXID:                "SPDXRef-Image",
+		VersionInfo:           img.Digest,
+		DownloadLocation:      noAssertion,
+		LicenseConcluded:      noAssertion,
+		LicenseDeclared:       noAssertion,
+		PrimaryPackagePurpose: "CONTAINER",
+	})
+	doc.Relationships = append(doc.Relationships, spdxRelationship{
+		SPDXElementID:      doc.SPDXID,
+		RelationshipType:   "DESCRIBES",
+		RelatedSPDXElement: "SPDXRef-Image",
+	})
+	for i, pkg := range inv.Packages {
+		p := spdxPackage{
+			Name:             pkg.Name,
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%s-%d", pkg.Type, i),
+			VersionInfo:      pkg.Version,
+			DownloadLocation: noAssertion,
+			LicenseConcluded: noAssertion,
+			// the licenses recorded by package managers are not
+			// necessarily valid SPDX license expressions
+			LicenseDeclared: noAssertion,
+			ExternalRefs: []spdxExternalRef{{
+				ReferenceCategory: "PACKAGE-MANAGER",
+				ReferenceType:     "purl",
+				ReferenceLocator:  pkg.PURL(inv.OS),
+			}},
+		}
+		if pkg.License != "" {
+			p.LicenseComments = "Declared by the package: " + pkg.License
+		}
+		if pkg.Source != "" {
+			p.SourceInfo = "built from " + pkg.Source
+		}
+		doc.Packages = append(doc.Packages, p)
+		doc.Relationships = append(doc.Relationships, spdxRelationship{
+			SPDXElementID:      "SPDXRef-Image",
+			RelationshipType:   "CONTAINS",
+			RelatedSPDXElement: p.SPDXID,
+		})
+	}
+	return doc
+}
+
+type cdxLicense struct {
+	License struct {
+		Name string `json:"name"`
+	} `json:"license"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cdxComponent struct {
+	Type       string        `json:"type"`
+	BOMRef     string        `json:"bom-ref"`
+	Name       string        `json:"name"`
+	Version    string        `json:"version,omitempty"`
+	PURL       string        `json:"purl,omitempty"`
+	Licenses   []cdxLicense  `json:"licenses,omitempty"`
+	Properties []cdxProperty `json:"properties,omitempty"`
+}
+
+type cdxDoc struct {
+	BOMFormat    string `json:"bomFormat"`
+	SpecVersion  string `json:"specVersion"`
+	SerialNumber string `json:"serialNumber"`
+	Version      int    `json:"version"`
+	Metadata     struct {
+		Timestamp string `json:"timestamp"`
+		Tools     struct {
+			Components []cdxComponent `json:"components"`
+		} `json:"tools"`
+		Component cdxComponent `json:"component"`
+	} `json:"metadata"`
+	Components []cdxComponent `json:"components"`
+}
+
+func cycloneDXDocument(img Image, inv *Inventory, created time.Time) *cdxDoc {
+	doc := &cdxDoc{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: "urn:uuid:" + uuid.NewString(),
+		Version:      1,
+		Components:   []cdxComponent{},
+	}
+	doc.Metadata.Timestamp = created.UTC().Format(time.RFC3339)
+	doc.Metadata.Tools.Components = []cdxComponent{{
+		Type:    "application",
+		BOMRef:  "podman",
+		Name:    "podman",
+		Version: version.Version.String(),
+	}}
+	doc.Metadata.Component = cdxComponent{
+		Type:    "container",
+		BOMRef:  img.ID,
+		Name:    imageName(img),
+		Version: img.Digest,
+	}
+	if inv.OS.ID != "" {
+		doc.Components = append(doc.Components, cdxComponent{
+			Type:    "operating-system",
+			BOMRef:  "os:" + inv.OS.ID,
+			Name:    inv.OS.ID,
+			Version: inv.OS.VersionID,
+		})
+	}
+	for _, pkg := range inv.Packages {
+		purl := pkg.PURL(inv.OS)
+		c := cdxComponent{
+			Type:    "library",
+			BOMRef:  purl,
+			Name:    pkg.Name,
+			Version: pkg.Version,
+			PURL:    purl,
+		}
+		if pkg.License != "" {
+			var license cdxLicense
+			license.License.Name = pkg.License
+			c.Licenses = []cdxLicense{license}
+		}
+		c.Properties = append(c.Properties, cdxProperty{Name: "podman:package:type", Value: pkg.Type})
+		if pkg.Source != "" {
+			c.Properties = append(c.Properties, cdxProperty{Name: "podman:package:source", Value: pkg.Source})
+		}
+		doc.Components = append(doc.Components, c)
+	}
+	return doc
+}
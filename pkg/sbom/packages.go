@@ -0,0 +1,264 @@
+package sbom
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	dpkgStatus    = "var/lib/dpkg/status"
+	dpkgStatusDir = "var/lib/dpkg/status.d"
+	apkInstalled  = "lib/apk/db/installed"
+)
+
+// Package types, as used in package URLs.
+const (
+	PackageTypeDeb = "deb"
+	PackageTypeApk = "apk"
+	PackageTypeRpm = "rpm"
+)
+
+// OSRelease identifies the distribution of an image.
+type OSRelease struct {
+	ID        string
+	VersionID string
+	Name      string
+}
+
+// Package is a package installed in an image.
+type Package struct {
+	// Type is the package manager, one of the PackageType constants.
+	Type    string
+	Name    string
+	Version string
+	Arch    string
+	// License as declared by the package, if the database records it.
+	License string
+	// Source package or origin, if different from the package.
+	Source string
+}
+
+// Inventory lists the packages installed in an image.
+type Inventory struct {
+	OS       OSRelease
+	Packages []Package
+}
+
+// PURL returns the package URL of the package installed on the distribution.
+func (p Package) PURL(release OSRelease) string {
+	namespace := release.ID
+	if namespace == "" {
+		namespace = "unknown"
+	}
+	purl := fmt.Sprintf("pkg:%s/%s/%s@%s", p.Type, url.PathEscape(namespace), url.PathEscape(p.Name), url.PathEscape(p.Version))
+	query := url.Values{}
+	if p.Arch != "" {
+		query.Set("arch", p.Arch)
+	}
+	if release.ID != "" && release.VersionID != "" {
+		query.Set("distro", release.ID+"-"+release.VersionID)
+	}
+	if len(query) > 0 {
+		purl += "?" + query.Encode()
+	}
+	return purl
+}
+
+// Inventory returns the packages found in the package databases of the
+// layers added to the scanner, sorted by type and name.
+func (s *Scanner) Inventory() (*Inventory, error) {
+	inv := &Inventory{OS: s.osRelease()}
+
+	var statusFiles []string
+	if _, ok := s.files[dpkgStatus]; ok {
+		statusFiles = append(statusFiles, dpkgStatus)
+	}
+	// distroless images keep one status file per package
+	statusFiles = append(statusFiles, s.filesIn(dpkgStatusDir)...)
+	for _, name := range statusFiles {
+		inv.Packages = append(inv.Packages, parseDpkgStatus(s.files[name])...)
+	}
+	if content, ok := s.files[apkInstalled]; ok {
+		inv.Packages = append(inv.Packages, parseApkInstalled(content)...)
+	}
+	rpms, err := s.rpmPackages()
+	if err != nil {
+		return nil, err
+	}
+	inv.Packages = append(inv.Packages, rpms...)
+
+	slices.SortFunc(inv.Packages, func(a, b Package) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Name, b.Name), cmp.Compare(a.Arch, b.Arch))
+	})
+	inv.Packages = slices.Compact(inv.Packages)
+	return inv, nil
+}
+
+func (s *Scanner) osRelease() OSRelease {
+	content, ok := s.files["etc/os-release"]
+	if !ok {
+		content = s.files["usr/lib/os-release"]
+	}
+	var release OSRelease
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), "=")
+		if !ok {
+			continue
+		}
+		value = strings.Trim(value, `"'`)
+		switch key {
+		case "ID":
+			release.ID = value
+		case "VERSION_ID":
+			release.VersionID = value
+		case "PRETTY_NAME":
+			release.Name = value
+		}
+	}
+	return release
+}
+
+// parseDpkgStatus returns the installed packages of a dpkg status file.
+func parseDpkgStatus(content []byte) []Package {
+	var packages []Package
+	for _, paragraph := range strings.Split(string(content), "\n\n") {
+		var pkg Package
+		installed := true
+		for _, line := range strings.Split(paragraph, "\n") {
+			key, value, ok := strings.Cut(line, ":")
+			if !ok || strings.HasPrefix(line, " ") {
+				continue
+			}
+			value = strings.TrimSpace(value)
+			switch key {
+			case "Package":
+				pkg.Name = value
+			case "Version":
+				pkg.Version = value
+			case "Architecture":
+				pkg.Arch = value
+			case "Source":
+				// "Source: name (version)"
+				pkg.Source, _, _ = strings.Cut(value, " ")
+			case "Status":
+				installed = strings.HasSuffix(value, " installed")
+			}
+		}
+		if pkg.Name != "" && installed {
+			pkg.Type = PackageTypeDeb
+			packages = append(packages, pkg)
+		}
+	}
+	return packages
+}
+
+// parseApkInstalled returns the packages of an apk installed database.
+func parseApkInstalled(content []byte) []Package {
+	var packages []Package
+	for _, paragraph := range strings.Split(string(content), "\n\n") {
+		pkg := Package{Type: PackageTypeApk}
+		for _, line := range strings.Split(paragraph, "\n") {
+			key, value, ok := strings.Cut(line, ":")
+			if !ok {
+				continue
+			}
+			switch key {
+			case "P":
+				pkg.Name = value
+			case "V":
+				pkg.Version = value
+			case "A":
+				pkg.Arch = value
+			case "L":
+				pkg.License = value
+			case "o":
+				pkg.Source = value
+			}
+		}
+		if pkg.Name != "" {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages
+}
+
+// rpmPackages queries the rpm database of the image with the rpm binary of
+// the host, whose database formats are not parsed here.
+func (s *Scanner) rpmPackages() ([]Package, error) {
+	for _, dbDir := range []string{"usr/lib/sysimage/rpm", "var/lib/rpm"} {
+		names := s.filesIn(dbDir)
+		if len(names) == 0 {
+			continue
+		}
+		rpm, err := exec.LookPath("rpm")
+		if err != nil {
+			logrus.Warnf("The image has an rpm database but rpm is not installed, rpm packages are not listed")
+			return nil, nil
+		}
+
+		dir, err := os.MkdirTemp("", "sbom-rpmdb-")
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(dir)
+		for _, name := range names {
+			dest := filepath.Join(dir, strings.TrimPrefix(name, dbDir+"/"))
+			if err := os.MkdirAll(filepath.Dir(dest), 0o700); err != nil {
+				return nil, err
+			}
+			if err := os.WriteFile(dest, s.files[name], 0o600); err != nil {
+				return nil, err
+			}
+		}
+
+		var stderr bytes.Buffer
+		cmd := exec.Command(rpm, "--dbpath", dir, "-qa", "--qf", "%{NAME}\t%{EPOCHNUM}\t%{VERSION}-%{RELEASE}\t%{ARCH}\t%{LICENSE}\t%{SOURCERPM}\n")
+		cmd.Stderr = &stderr
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, fmt.Errorf("querying the rpm database: %w: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return parseRpmQuery(out)
+	}
+	return nil, nil
+}
+
+func parseRpmQuery(out []byte) ([]Package, error) {
+	var packages []Package
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line == "" {
+			continue
+		}
+		fields := strings.Split(line, "\t")
+		if len(fields) != 6 {
+			return nil, errors.New("unexpected output of rpm query: " + line)
+		}
+		pkg := Package{
+			Type:    PackageTypeRpm,
+			Name:    fields[0],
+			Version: fields[2],
+			Arch:    fields[3],
+			License: fields[4],
+		}
+		if fields[1] != "0" {
+			pkg.Version = fields[1] + ":" + pkg.Version
+		}
+		if fields[5] != "(none)" {
+			pkg.Source = fields[5]
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
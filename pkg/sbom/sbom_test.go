@@ -0,0 +1,139 @@
+package sbom
+
+import (
+	"archive/tar"
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const debianStatus = `Package: libc6
+Status: install ok installed
+Architecture: amd64
+Source: glibc (2.36-9)
+Version: 2.36-9+deb12u4
+Description: GNU C Library
+ Contains the standard libraries.
+
+Package: removed
+Status: deinstall ok config-files
+Architecture: amd64
+Version: 1.0
+
+Package: base-files
+Status: install ok installed
+Architecture: amd64
+Version: 12.4+deb12u5
+`
+
+const alpineInstalled = `C:Q1abc=
+P:musl
+V:1.2.4-r2
+A:x86_64
+L:MIT
+o:musl
+
+C:Q1def=
+P:busybox
+V:1.36.1-r15
+A:x86_64
+L:GPL-2.0-only
+o:busybox
+`
+
+func layer(t *testing.T, files map[string]string) *bytes.Buffer {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for name, content := range files {
+		err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))})
+		require.NoError(t, err)
+		_, err = tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	return &buf
+}
+
+func TestScannerInventory(t *testing.T) {
+	s := NewScanner()
+	err := s.AddLayer(layer(t, map[string]string{
+		"etc/os-release":       "ID=debian\nVERSION_ID=\"12\"\nPRETTY_NAME=\"Debian GNU/Linux 12 (bookworm)\"\n",
+		"./" + dpkgStatus:      debianStatus,
+		apkInstalled:           alpineInstalled,
+		"usr/bin/not-scanned":  "binary",
+		dpkgStatusDir + "/foo": "Package: foo\nVersion: 1\nArchitecture: all\n",
+	}))
+	require.NoError(t, err)
+	// the next layer removes the apk database and the status.d directory
+	err = s.AddLayer(layer(t, map[string]string{
+		"lib/apk/db/.wh.installed":      "",
+		dpkgStatusDir + "/.wh..wh..opq": "",
+	}))
+	require.NoError(t, err)
+
+	inv, err := s.Inventory()
+	require.NoError(t, err)
+	assert.Equal(t, OSRelease{ID: "debian", VersionID: "12", Name: "Debian GNU/Linux 12 (bookworm)"}, inv.OS)
+	assert.Equal(t, []Package{
+		{Type: PackageTypeDeb, Name: "base-files", Version: "12.4+deb12u5", Arch: "amd64"},
+		{Type: PackageTypeDeb, Name: "libc6", Version: "2.36-9+deb12u4", Arch: "amd64", Source: "glibc"},
+	}, inv.Packages)
+	assert.Equal(t, "pkg:deb/debian/libc6@2.36-9+deb12u4?arch=amd64&distro=debian-12", inv.Packages[1].PURL(inv.OS))
+}
+
+func TestParseApkInstalled(t *testing.T) {
+	assert.Equal(t, []Package{
+		{Type: PackageTypeApk, Name: "musl", Version: "1.2.4-r2", Arch: "x86_64", License: "MIT", Source: "musl"},
+		{Type: PackageTypeApk, Name: "busybox", Version: "1.36.1-r15", Arch: "x86_64", License: "GPL-2.0-only", Source: "busybox"},
+	}, parseApkInstalled([]byte(alpineInstalled)))
+}
+
+func TestParseRpmQuery(t *testing.T) {
+	packages, err := parseRpmQuery([]byte("bash\t0\t5.2.26-3.fc40\tx86_64\tGPL-3.0-or-later\tbash-5.2.26-3.fc40.src.rpm\ngpg-pubkey\t0\tabc-123\t(none)\tpubkey\t(none)\nopenssl\t1\t3.2.1-2.fc40\tx86_64\tApache-2.0\topenssl-3.2.1-2.fc40.src.rpm\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []Package{
+		{Type: PackageTypeRpm, Name: "bash", Version: "5.2.26-3.fc40", Arch: "x86_64", License: "GPL-3.0-or-later", Source: "bash-5.2.26-3.fc40.src.rpm"},
+		{Type: PackageTypeRpm, Name: "gpg-pubkey", Version: "abc-123", Arch: "(none)", License: "pubkey"},
+		{Type: PackageTypeRpm, Name: "openssl", Version: "1:3.2.1-2.fc40", Arch: "x86_64", License: "Apache-2.0", Source: "openssl-3.2.1-2.fc40.src.rpm"},
+	}, packages)
+
+	_, err = parseRpmQuery([]byte("garbage\n"))
+	assert.Error(t, err)
+}
+
+func TestGenerate(t *testing.T) {
+	img := Image{Name: "quay.io/libpod/alpine:latest", ID: "0123", Digest: "sha256:abcd"}
+	inv := &Inventory{
+		OS:       OSRelease{ID: "alpine", VersionID: "3.19.1"},
+		Packages: parseApkInstalled([]byte(alpineInstalled)),
+	}
+	created := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	out, err := Generate(FormatSPDX, img, inv, created)
+	require.NoError(t, err)
+	var spdx spdxDoc
+	require.NoError(t, json.Unmarshal(out, &spdx))
+	assert.Equal(t, "SPDX-2.3", spdx.SPDXVersion)
+	assert.Equal(t, "2024-05-01T12:00:00Z", spdx.CreationInfo.Created)
+	require.Len(t, spdx.Packages, 3)
+	assert.Equal(t, "CONTAINER", spdx.Packages[0].PrimaryPackagePurpose)
+	assert.Equal(t, "pkg:apk/alpine/musl@1.2.4-r2?arch=x86_64&distro=alpine-3.19.1", spdx.Packages[1].ExternalRefs[0].ReferenceLocator)
+	assert.Len(t, spdx.Relationships, 3)
+
+	out, err = Generate(FormatCycloneDX, img, inv, created)
+	require.NoError(t, err)
+	var cdx cdxDoc
+	require.NoError(t, json.Unmarshal(out, &cdx))
+	assert.Equal(t, "CycloneDX", cdx.BOMFormat)
+	assert.Equal(t, "container", cdx.Metadata.Component.Type)
+	require.Len(t, cdx.Components, 3)
+	assert.Equal(t, "operating-system", cdx.Components[0].Type)
+	assert.Equal(t, "MIT", cdx.Components[1].Licenses[0].License.Name)
+
+	_, err = Generate("text", img, inv, created)
+	assert.ErrorContains(t, err, `unsupported SBOM format "text"`)
+}
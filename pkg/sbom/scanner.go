@@ -0,0 +1,105 @@
+// Package sbom generates software bills of materials of container images
+// from the package databases found in their layers.
+package sbom
+
+import (
+	"archive/tar"
+	"errors"
+	"io"
+	"path"
+	"strings"
+
+	"go.podman.io/storage/pkg/archive"
+)
+
+const (
+	whiteoutPrefix = ".wh."
+	whiteoutOpaque = ".wh..wh..opq"
+)
+
+// scannedPaths are the files, or the directories whose files, the scanner
+// collects from the layers.
+var scannedPaths = []string{
+	"etc/os-release",
+	"usr/lib/os-release",
+	dpkgStatus,
+	dpkgStatusDir,
+	apkInstalled,
+	"var/lib/rpm",
+	"usr/lib/sysimage/rpm",
+}
+
+// Scanner collects the package databases of an image from its layers, so
+// that the image does not need to be mounted.
+type Scanner struct {
+	files map[string][]byte
+}
+
+// NewScanner returns a scanner without any layers.
+func NewScanner() *Scanner {
+	return &Scanner{files: make(map[string][]byte)}
+}
+
+func scanned(name string) bool {
+	for _, p := range scannedPaths {
+		if name == p || strings.HasPrefix(name, p+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// AddLayer adds the content of a, possibly compressed, layer tar stream.
+// Layers must be added in order, starting with the base layer.
+func (s *Scanner) AddLayer(r io.Reader) error {
+	decompressed, err := archive.DecompressStream(r)
+	if err != nil {
+		return err
+	}
+	defer decompressed.Close()
+
+	tr := tar.NewReader(decompressed)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		name := strings.TrimPrefix(path.Clean("/"+hdr.Name), "/")
+		dir, base := path.Split(name)
+		switch {
+		case base == whiteoutOpaque:
+			s.remove(strings.TrimSuffix(dir, "/"), false)
+		case strings.HasPrefix(base, whiteoutPrefix):
+			s.remove(dir+strings.TrimPrefix(base, whiteoutPrefix), true)
+		case hdr.Typeflag == tar.TypeReg && scanned(name):
+			content, err := io.ReadAll(tr)
+			if err != nil {
+				return err
+			}
+			s.files[name] = content
+		}
+	}
+}
+
+// remove removes the files below dir and, if self is set, the file dir.
+func (s *Scanner) remove(dir string, self bool) {
+	for name := range s.files {
+		if (self && name == dir) || strings.HasPrefix(name, dir+"/") {
+			delete(s.files, name)
+		}
+	}
+}
+
+// filesIn returns the names of the files collected in dir.
+func (s *Scanner) filesIn(dir string) []string {
+	var names []string
+	for name := range s.files {
+		if strings.HasPrefix(name, dir+"/") {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,173 @@
+package scan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.podman.io/storage/pkg/homedir"
+)
+
+const configFile = "podman-scan.json"
+
+// SystemConfigPath is the scan configuration of the system, the scan
+// configuration of the user overrides it.
+var SystemConfigPath = "/etc/containers/" + configFile
+
+// Output formats of scanners.
+const (
+	// FormatPodman is the normalized report schema, for scanners written
+	// for podman.
+	FormatPodman = "podman"
+	FormatTrivy  = "trivy"
+	FormatGrype  = "grype"
+)
+
+// ScannerConfig configures how a scanner is invoked, either by executing a
+// command or by sending a request to a socket.
+type ScannerConfig struct {
+	// Command and arguments executed to scan an image, "{rootfs}" and
+	// "{image}" are replaced with the mounted root filesystem and the name
+	// of the image.  The report is read from the standard output.
+	Command []string `json:"command,omitempty"`
+	// Socket is the path of a unix socket serving HTTP, the scan request
+	// is POSTed to /scan.
+	Socket string `json:"socket,omitempty"`
+	// Format of the report of the scanner, one of the Format constants.
+	Format string `json:"format"`
+}
+
+// Config is the scan configuration.
+type Config struct {
+	// Scanner used when none is selected, defaults to the first builtin
+	// scanner installed.
+	Scanner string `json:"scanner,omitempty"`
+	// Scanners configures scanners in addition to, or instead of, the
+	// builtin trivy and grype scanners.
+	Scanners map[string]ScannerConfig `json:"scanners,omitempty"`
+	// RunThreshold refuses to create containers of images with
+	// vulnerabilities of this severity or higher.
+	RunThreshold string `json:"run_threshold,omitempty"`
+	// Ignore lists the IDs of vulnerabilities left out of reports.
+	Ignore []string `json:"ignore,omitempty"`
+}
+
+// builtinScanners are available without configuration.
+var builtinScanners = map[string]ScannerConfig{
+	"trivy": {
+		Command: []string{"trivy", "rootfs", "--quiet", "--format", "json", "{rootfs}"},
+		Format:  FormatTrivy,
+	},
+	"grype": {
+		Command: []string{"grype", "dir:{rootfs}", "--quiet", "--output", "json"},
+		Format:  FormatGrype,
+	},
+}
+
+// ConfigPaths returns the scan configuration files, in the order they are
+// read.  PODMAN_SCAN_CONF replaces them with a single file.
+func ConfigPaths() ([]string, error) {
+	if path, found := os.LookupEnv("PODMAN_SCAN_CONF"); found {
+		return []string{path}, nil
+	}
+	configHome, err := homedir.GetConfigHome()
+	if err != nil {
+		return nil, err
+	}
+	return []string{SystemConfigPath, filepath.Join(configHome, "containers", configFile)}, nil
+}
+
+// ReadConfig reads the scan configuration, the values of later files
+// override the ones of earlier files.  Missing files are skipped.
+func ReadConfig() (*Config, error) {
+	paths, err := ConfigPaths()
+	if err != nil {
+		return nil, err
+	}
+	cfg := new(Config)
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		if err := json.Unmarshal(content, cfg); err != nil {
+			return nil, fmt.Errorf("parsing scan configuration %s: %w", path, err)
+		}
+	}
+	if cfg.RunThreshold != "" {
+		if _, err := ParseSeverity(cfg.RunThreshold); err != nil {
+			return nil, fmt.Errorf("run_threshold of the scan configuration: %w", err)
+		}
+	}
+	return cfg, nil
+}
+
+// LookupScanner returns the scanner named name, or the default scanner if name
+// is empty.
+func (c *Config) LookupScanner(name string) (Scanner, error) {
+	if name == "" {
+		name = c.Scanner
+	}
+	if name == "" {
+		for _, builtin := range []string{"trivy", "grype"} {
+			if _, err := exec.LookPath(builtin); err == nil {
+				name = builtin
+				break
+			}
+		}
+		if name == "" {
+			return nil, errors.New("no vulnerability scanner configured and neither trivy nor grype is installed")
+		}
+	}
+
+	sc, ok := c.Scanners[name]
+	if !ok {
+		sc, ok = builtinScanners[name]
+	}
+	if !ok {
+		return nil, fmt.Errorf("unknown vulnerability scanner %q", name)
+	}
+	if !slices.Contains([]string{FormatPodman, FormatTrivy, FormatGrype}, sc.Format) {
+		return nil, fmt.Errorf("scanner %q: invalid format %q, must be %s, %s or %s", name, sc.Format, FormatPodman, FormatTrivy, FormatGrype)
+	}
+	switch {
+	case len(sc.Command) > 0 && sc.Socket != "":
+		return nil, fmt.Errorf("scanner %q: command and socket are mutually exclusive", name)
+	case len(sc.Command) > 0:
+		return &execScanner{name: name, command: sc.Command, format: sc.Format}, nil
+	case sc.Socket != "":
+		return &socketScanner{name: name, socket: sc.Socket, format: sc.Format}, nil
+	}
+	return nil, fmt.Errorf("scanner %q: one of command or socket must be set", name)
+}
+
+// ScannerNames returns the names of the configured and builtin scanners.
+func (c *Config) ScannerNames() []string {
+	names := make([]string, 0, len(c.Scanners)+len(builtinScanners))
+	for name := range c.Scanners {
+		names = append(names, name)
+	}
+	for name := range builtinScanners {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func expandArgs(args []string, target Target) []string {
+	r := strings.NewReplacer("{rootfs}", target.Rootfs, "{image}", target.Image)
+	expanded := make([]string, len(args))
+	for i, arg := range args {
+		expanded[i] = r.Replace(arg)
+	}
+	return expanded
+}
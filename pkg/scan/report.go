@@ -0,0 +1,122 @@
+// Package scan runs external vulnerability scanners against the root
+// filesystem of an image and normalizes their results to a common schema.
+package scan
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Severity of a vulnerability.
+type Severity string
+
+const (
+	SeverityUnknown  Severity = "UNKNOWN"
+	SeverityLow      Severity = "LOW"
+	SeverityMedium   Severity = "MEDIUM"
+	SeverityHigh     Severity = "HIGH"
+	SeverityCritical Severity = "CRITICAL"
+)
+
+// severities in increasing order
+var severities = []Severity{SeverityUnknown, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// ParseSeverity returns the severity named by s, case-insensitive.
+func ParseSeverity(s string) (Severity, error) {
+	severity := Severity(strings.ToUpper(s))
+	if !slices.Contains(severities, severity) {
+		return "", fmt.Errorf("invalid severity %q, must be one of unknown, low, medium, high or critical", s)
+	}
+	return severity, nil
+}
+
+// normalizeSeverity maps the severities reported by scanners to ours,
+// severities we do not know are reported as UNKNOWN.
+func normalizeSeverity(s string) Severity {
+	s = strings.ToUpper(s)
+	if s == "NEGLIGIBLE" {
+		return SeverityLow
+	}
+	if severity, err := ParseSeverity(s); err == nil {
+		return severity
+	}
+	return SeverityUnknown
+}
+
+func (s Severity) rank() int {
+	return slices.Index(severities, s)
+}
+
+// AtLeast returns whether s is as severe as threshold or more.
+func (s Severity) AtLeast(threshold Severity) bool {
+	return s.rank() >= threshold.rank()
+}
+
+// Vulnerability is a vulnerability of a package installed in an image.
+type Vulnerability struct {
+	ID               string   `json:"id"`
+	Package          string   `json:"package"`
+	InstalledVersion string   `json:"installedVersion"`
+	FixedVersion     string   `json:"fixedVersion,omitempty"`
+	Severity         Severity `json:"severity"`
+	Title            string   `json:"title,omitempty"`
+	URL              string   `json:"url,omitempty"`
+}
+
+// Report is the normalized result of scanning an image.
+type Report struct {
+	Image   string `json:"image"`
+	ImageID string `json:"imageId"`
+	// Scanner that scanned the image, empty if the image was not scanned
+	// because no threshold applies.
+	Scanner         string           `json:"scanner"`
+	Vulnerabilities []Vulnerability  `json:"vulnerabilities"`
+	Summary         map[Severity]int `json:"summary"`
+	// Threshold the vulnerabilities were evaluated against, if any.
+	Threshold Severity `json:"threshold,omitempty"`
+	// Blocked is set if vulnerabilities reach the threshold.
+	Blocked bool `json:"blocked"`
+}
+
+// Evaluate drops the ignored vulnerabilities from the report, sorts the
+// others by decreasing severity, summarizes them and evaluates them against
+// the threshold.  An empty threshold never blocks.
+func (r *Report) Evaluate(threshold Severity, ignore []string) {
+	r.Vulnerabilities = slices.DeleteFunc(r.Vulnerabilities, func(v Vulnerability) bool {
+		return slices.Contains(ignore, v.ID)
+	})
+	if r.Vulnerabilities == nil {
+		r.Vulnerabilities = []Vulnerability{}
+	}
+	slices.SortStableFunc(r.Vulnerabilities, func(a, b Vulnerability) int {
+		if c := b.Severity.rank() - a.Severity.rank(); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+
+	r.Summary = make(map[Severity]int)
+	r.Threshold = threshold
+	r.Blocked = false
+	for _, v := range r.Vulnerabilities {
+		r.Summary[v.Severity]++
+		if threshold != "" && v.Severity.AtLeast(threshold) {
+			r.Blocked = true
+		}
+	}
+}
+
+// Exceeding returns the number of vulnerabilities reaching the threshold.
+func (r *Report) Exceeding() int {
+	n := 0
+	if r.Threshold == "" {
+		return n
+	}
+	for _, v := range r.Vulnerabilities {
+		if v.Severity.AtLeast(r.Threshold) {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,142 @@
+package scan
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const trivyOutput = `{
+  "SchemaVersion": 2,
+  "Results": [
+    {
+      "Target": "rootfs (alpine 3.19.1)",
+      "Vulnerabilities": [
+        {"VulnerabilityID": "CVE-2024-0001", "PkgName": "musl", "InstalledVersion": "1.2.4-r2", "FixedVersion": "1.2.4-r3", "Severity": "MEDIUM", "Title": "musl: bug", "PrimaryURL": "https://avd.aquasec.com/nvd/cve-2024-0001"},
+        {"VulnerabilityID": "CVE-2024-0002", "PkgName": "openssl", "InstalledVersion": "3.1.4-r5", "Severity": "CRITICAL"}
+      ]
+    },
+    {"Target": "usr/lib/python3/site-packages"}
+  ]
+}`
+
+const grypeOutput = `{
+  "matches": [
+    {
+      "vulnerability": {"id": "CVE-2024-0003", "dataSource": "https://nvd.nist.gov/vuln/detail/CVE-2024-0003", "severity": "Negligible", "fix": {"versions": ["2.0", "1.9.1"], "state": "fixed"}},
+      "artifact": {"name": "busybox", "version": "1.36.1-r15", "type": "apk"}
+    }
+  ],
+  "descriptor": {"name": "grype"}
+}`
+
+func TestNormalize(t *testing.T) {
+	vulns, err := normalize(FormatTrivy, []byte(trivyOutput))
+	require.NoError(t, err)
+	assert.Equal(t, []Vulnerability{
+		{ID: "CVE-2024-0001", Package: "musl", InstalledVersion: "1.2.4-r2", FixedVersion: "1.2.4-r3", Severity: SeverityMedium, Title: "musl: bug", URL: "https://avd.aquasec.com/nvd/cve-2024-0001"},
+		{ID: "CVE-2024-0002", Package: "openssl", InstalledVersion: "3.1.4-r5", Severity: SeverityCritical},
+	}, vulns)
+
+	vulns, err = normalize(FormatGrype, []byte(grypeOutput))
+	require.NoError(t, err)
+	assert.Equal(t, []Vulnerability{
+		{ID: "CVE-2024-0003", Package: "busybox", InstalledVersion: "1.36.1-r15", FixedVersion: "2.0, 1.9.1", Severity: SeverityLow, URL: "https://nvd.nist.gov/vuln/detail/CVE-2024-0003"},
+	}, vulns)
+
+	vulns, err = normalize(FormatPodman, []byte(`{"vulnerabilities": [{"id": "GHSA-1", "package": "foo", "installedVersion": "1", "severity": "high"}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, []Vulnerability{{ID: "GHSA-1", Package: "foo", InstalledVersion: "1", Severity: SeverityHigh}}, vulns)
+
+	_, err = normalize(FormatTrivy, []byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestEvaluate(t *testing.T) {
+	vulns, err := normalize(FormatTrivy, []byte(trivyOutput))
+	require.NoError(t, err)
+	vulns = append(vulns, Vulnerability{ID: "CVE-2024-0004", Severity: SeverityUnknown})
+
+	report := &Report{Vulnerabilities: vulns}
+	report.Evaluate(SeverityHigh, nil)
+	assert.True(t, report.Blocked)
+	assert.Equal(t, 1, report.Exceeding())
+	assert.Equal(t, map[Severity]int{SeverityCritical: 1, SeverityMedium: 1, SeverityUnknown: 1}, report.Summary)
+	assert.Equal(t, "CVE-2024-0002", report.Vulnerabilities[0].ID)
+
+	report.Evaluate(SeverityHigh, []string{"CVE-2024-0002"})
+	assert.False(t, report.Blocked)
+	assert.Len(t, report.Vulnerabilities, 2)
+
+	report.Evaluate("", nil)
+	assert.False(t, report.Blocked)
+	assert.Equal(t, 0, report.Exceeding())
+}
+
+func TestParseSeverity(t *testing.T) {
+	severity, err := ParseSeverity("High")
+	require.NoError(t, err)
+	assert.Equal(t, SeverityHigh, severity)
+	assert.True(t, SeverityCritical.AtLeast(severity))
+	assert.False(t, SeverityMedium.AtLeast(severity))
+
+	_, err = ParseSeverity("severe")
+	assert.ErrorContains(t, err, `invalid severity "severe"`)
+}
+
+func TestConfig(t *testing.T) {
+	dir := t.TempDir()
+	conf := filepath.Join(dir, "podman-scan.json")
+	t.Setenv("PODMAN_SCAN_CONF", conf)
+
+	// a missing configuration is empty
+	cfg, err := ReadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, &Config{}, cfg)
+
+	script := filepath.Join(dir, "scanner")
+	err = os.WriteFile(script, []byte("#!/bin/sh\necho '{\"vulnerabilities\": [{\"id\": \"CVE-1\", \"package\": \"'$2'\", \"severity\": \"critical\"}]}'\n"), 0o755)
+	require.NoError(t, err)
+	err = os.WriteFile(conf, []byte(`{
+  "scanner": "custom",
+  "run_threshold": "high",
+  "scanners": {
+    "custom": {"command": ["`+script+`", "{rootfs}", "{image}"], "format": "podman"},
+    "both": {"command": ["true"], "socket": "/run/scanner.sock", "format": "podman"},
+    "bad": {"command": ["true"], "format": "sarif"}
+  }
+}`), 0o644)
+	require.NoError(t, err)
+
+	cfg, err = ReadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "high", cfg.RunThreshold)
+	assert.Equal(t, []string{"bad", "both", "custom", "grype", "trivy"}, cfg.ScannerNames())
+
+	scanner, err := cfg.LookupScanner("")
+	require.NoError(t, err)
+	assert.Equal(t, "custom", scanner.Name())
+	report, err := scanner.Scan(context.Background(), Target{Image: "alpine", ImageID: "0123", Rootfs: dir})
+	require.NoError(t, err)
+	assert.Equal(t, &Report{Image: "alpine", ImageID: "0123", Scanner: "custom", Vulnerabilities: []Vulnerability{
+		{ID: "CVE-1", Package: "alpine", Severity: SeverityCritical},
+	}}, report)
+
+	_, err = cfg.LookupScanner("both")
+	assert.ErrorContains(t, err, "mutually exclusive")
+	_, err = cfg.LookupScanner("bad")
+	assert.ErrorContains(t, err, `invalid format "sarif"`)
+	_, err = cfg.LookupScanner("nonesuch")
+	assert.ErrorContains(t, err, `unknown vulnerability scanner "nonesuch"`)
+	scanner, err = cfg.LookupScanner("grype")
+	require.NoError(t, err)
+	assert.Equal(t, "grype", scanner.Name())
+
+	require.NoError(t, os.WriteFile(conf, []byte(`{"run_threshold": "severe"}`), 0o644))
+	_, err = ReadConfig()
+	assert.ErrorContains(t, err, "run_threshold")
+}
@@ -0,0 +1,194 @@
+package scan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os/exec"
+	"strings"
+)
+
+// Target is the image to scan.
+type Target struct {
+	Image   string `json:"image"`
+	ImageID string `json:"imageId"`
+	// Rootfs is the path of the mounted root filesystem of the image.
+	Rootfs string `json:"rootfs"`
+}
+
+// Scanner scans the root filesystem of an image for vulnerabilities.
+type Scanner interface {
+	// Name of the scanner in the configuration.
+	Name() string
+	// Scan returns the vulnerabilities found in target.
+	Scan(ctx context.Context, target Target) (*Report, error)
+}
+
+type execScanner struct {
+	name    string
+	command []string
+	format  string
+}
+
+func (s *execScanner) Name() string {
+	return s.name
+}
+
+func (s *execScanner) Scan(ctx context.Context, target Target) (*Report, error) {
+	args := expandArgs(s.command, target)
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("running scanner %s: %w: %s", s.name, err, strings.TrimSpace(stderr.String()))
+	}
+	return newReport(s.name, s.format, target, stdout.Bytes())
+}
+
+type socketScanner struct {
+	name   string
+	socket string
+	format string
+}
+
+func (s *socketScanner) Name() string {
+	return s.name
+}
+
+func (s *socketScanner) Scan(ctx context.Context, target Target) (*Report, error) {
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", s.socket)
+		},
+	}}
+	body, err := json.Marshal(target)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://d/scan", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("contacting scanner %s: %w", s.name, err)
+	}
+	defer resp.Body.Close()
+	output, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("scanner %s: %s: %s", s.name, resp.Status, strings.TrimSpace(string(output)))
+	}
+	return newReport(s.name, s.format, target, output)
+}
+
+func newReport(scanner, format string, target Target, output []byte) (*Report, error) {
+	vulns, err := normalize(format, output)
+	if err != nil {
+		return nil, fmt.Errorf("parsing the report of scanner %s: %w", scanner, err)
+	}
+	return &Report{Image: target.Image, ImageID: target.ImageID, Scanner: scanner, Vulnerabilities: vulns}, nil
+}
+
+// normalize returns the vulnerabilities of a report in the given format.
+func normalize(format string, output []byte) ([]Vulnerability, error) {
+	switch format {
+	case FormatTrivy:
+		return normalizeTrivy(output)
+	case FormatGrype:
+		return normalizeGrype(output)
+	case FormatPodman:
+		var report Report
+		if err := json.Unmarshal(output, &report); err != nil {
+			return nil, err
+		}
+		for i := range report.Vulnerabilities {
+			report.Vulnerabilities[i].Severity = normalizeSeverity(string(report.Vulnerabilities[i].Severity))
+		}
+		return report.Vulnerabilities, nil
+	}
+	return nil, fmt.Errorf("unknown report format %q", format)
+}
+
+type trivyReport struct {
+	Results []struct {
+		Vulnerabilities []struct {
+			VulnerabilityID  string
+			PkgName          string
+			InstalledVersion string
+			FixedVersion     string
+			Severity         string
+			Title            string
+			PrimaryURL       string
+		}
+	}
+}
+
+func normalizeTrivy(output []byte) ([]Vulnerability, error) {
+	var report trivyReport
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, err
+	}
+	var vulns []Vulnerability
+	for _, result := range report.Results {
+		for _, v := range result.Vulnerabilities {
+			vulns = append(vulns, Vulnerability{
+				ID:               v.VulnerabilityID,
+				Package:          v.PkgName,
+				InstalledVersion: v.InstalledVersion,
+				FixedVersion:     v.FixedVersion,
+				Severity:         normalizeSeverity(v.Severity),
+				Title:            v.Title,
+				URL:              v.PrimaryURL,
+			})
+		}
+	}
+	return vulns, nil
+}
+
+type grypeReport struct {
+	Matches []struct {
+		Vulnerability struct {
+			ID          string `json:"id"`
+			DataSource  string `json:"dataSource"`
+			Severity    string `json:"severity"`
+			Description string `json:"description"`
+			Fix         struct {
+				Versions []string `json:"versions"`
+			} `json:"fix"`
+		} `json:"vulnerability"`
+		Artifact struct {
+			Name    string `json:"name"`
+			Version string `json:"version"`
+		} `json:"artifact"`
+	} `json:"matches"`
+}
+
+func normalizeGrype(output []byte) ([]Vulnerability, error) {
+	var report grypeReport
+	if err := json.Unmarshal(output, &report); err != nil {
+		return nil, err
+	}
+	var vulns []Vulnerability
+	for _, m := range report.Matches {
+		vulns = append(vulns, Vulnerability{
+			ID:               m.Vulnerability.ID,
+			Package:          m.Artifact.Name,
+			InstalledVersion: m.Artifact.Version,
+			FixedVersion:     strings.Join(m.Vulnerability.Fix.Versions, ", "),
+			Severity:         normalizeSeverity(m.Vulnerability.Severity),
+			Title:            m.Vulnerability.Description,
+			URL:              m.Vulnerability.DataSource,
+		})
+	}
+	return vulns, nil
+}
@@ -0,0 +1,251 @@
+package seccomp
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"go.podman.io/common/pkg/config"
+	"go.podman.io/common/pkg/seccomp"
+	"go.podman.io/storage/pkg/fileutils"
+)
+
+// RuntimeSyscalls are the syscalls the OCI runtime may need between loading
+// the seccomp filter of a container and handing its notify file descriptor
+// over to the listener.  They are never sent to the listener, which cannot
+// answer them yet, and generated profiles always allow them.
+var RuntimeSyscalls = []string{"close", "exit", "exit_group", "futex", "rt_sigreturn", "sendmsg", "write"}
+
+// DefaultProfilePath returns the path of the profile used for containers
+// started without a seccomp security option, or "" when the profile built
+// into podman is used.  confProfile is the seccomp_profile of
+// containers.conf.
+func DefaultProfilePath(confProfile string) (string, error) {
+	if confProfile != "" {
+		return confProfile, nil
+	}
+	for _, path := range []string{config.SeccompOverridePath, config.SeccompDefaultPath} {
+		err := fileutils.Exists(path)
+		if err == nil {
+			return path, nil
+		}
+		if !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// ReadProfile reads the JSON seccomp profile at path.
+func ReadProfile(path string) (*seccomp.Seccomp, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	profile := new(seccomp.Seccomp)
+	if err := json.Unmarshal(content, profile); err != nil {
+		return nil, fmt.Errorf("decoding seccomp profile %s: %w", path, err)
+	}
+	return profile, nil
+}
+
+// Effect is what a profile does when a syscall is made.
+type Effect struct {
+	Action seccomp.Action `json:"action"`
+	// Conditional is set when the action only applies to some arguments,
+	// capabilities or architectures, the default action of the profile
+	// applies otherwise.
+	Conditional bool `json:"conditional,omitempty"`
+}
+
+func (e Effect) String() string {
+	if e.Conditional {
+		return string(e.Action) + " (conditional)"
+	}
+	return string(e.Action)
+}
+
+func ruleNames(rule *seccomp.Syscall) []string {
+	names := slices.Clone(rule.Names)
+	if rule.Name != "" {
+		names = append(names, rule.Name)
+	}
+	return names
+}
+
+func conditional(rule *seccomp.Syscall) bool {
+	return len(rule.Args) > 0 ||
+		len(rule.Includes.Caps) > 0 || len(rule.Includes.Arches) > 0 ||
+		len(rule.Excludes.Caps) > 0 || len(rule.Excludes.Arches) > 0
+}
+
+// Effects returns the effect of the syscalls with rules in the profile.  An
+// unconditional rule takes precedence over conditional ones, and a
+// conditional rule allowing the syscall over other conditional rules.
+func Effects(profile *seccomp.Seccomp) map[string]Effect {
+	effects := make(map[string]Effect)
+	for _, rule := range profile.Syscalls {
+		effect := Effect{Action: rule.Action, Conditional: conditional(rule)}
+		for _, name := range ruleNames(rule) {
+			if current, ok := effects[name]; ok && (!current.Conditional || (effect.Conditional && current.Action == seccomp.ActAllow)) {
+				continue
+			}
+			effects[name] = effect
+		}
+	}
+	return effects
+}
+
+// Change is a syscall a profile treats differently from the reference
+// profile.
+type Change struct {
+	Syscall   string `json:"syscall"`
+	Reference Effect `json:"reference"`
+	Profile   Effect `json:"profile"`
+}
+
+// Diff lists the differences of a profile from a reference profile.
+type Diff struct {
+	ReferenceDefaultAction seccomp.Action `json:"referenceDefaultAction"`
+	ProfileDefaultAction   seccomp.Action `json:"profileDefaultAction"`
+	Changes                []Change       `json:"changes"`
+}
+
+// Compare returns the differences of profile from reference, sorted by
+// syscall.
+func Compare(reference, profile *seccomp.Seccomp) *Diff {
+	diff := &Diff{
+		ReferenceDefaultAction: reference.DefaultAction,
+		ProfileDefaultAction:   profile.DefaultAction,
+		Changes:                []Change{},
+	}
+	referenceEffects, profileEffects := Effects(reference), Effects(profile)
+	names := make(map[string]struct{})
+	for name := range referenceEffects {
+		names[name] = struct{}{}
+	}
+	for name := range profileEffects {
+		names[name] = struct{}{}
+	}
+	for name := range names {
+		change := Change{
+			Syscall:   name,
+			Reference: effectOrDefault(referenceEffects, name, reference.DefaultAction),
+			Profile:   effectOrDefault(profileEffects, name, profile.DefaultAction),
+		}
+		if change.Reference != change.Profile {
+			diff.Changes = append(diff.Changes, change)
+		}
+	}
+	slices.SortFunc(diff.Changes, func(a, b Change) int {
+		return cmp.Compare(a.Syscall, b.Syscall)
+	})
+	return diff
+}
+
+func effectOrDefault(effects map[string]Effect, name string, defaultAction seccomp.Action) Effect {
+	if effect, ok := effects[name]; ok {
+		return effect
+	}
+	return Effect{Action: defaultAction}
+}
+
+// RecordingProfile returns a copy of base sending the syscalls it allows to
+// the seccomp notify listener at listenerPath, which lets them continue and
+// records them.
+func RecordingProfile(base *seccomp.Seccomp, listenerPath string) *seccomp.Seccomp {
+	profile := *base
+	profile.ListenerPath = listenerPath
+	profile.ListenerMetadata = ""
+	if profile.DefaultAction == seccomp.ActAllow || profile.DefaultAction == seccomp.ActLog {
+		profile.DefaultAction = seccomp.ActNotify
+	}
+	profile.Syscalls = nil
+	for _, rule := range base.Syscalls {
+		if rule.Action != seccomp.ActAllow && rule.Action != seccomp.ActLog {
+			profile.Syscalls = append(profile.Syscalls, rule)
+			continue
+		}
+		names := slices.DeleteFunc(ruleNames(rule), func(name string) bool {
+			return slices.Contains(RuntimeSyscalls, name)
+		})
+		if len(names) == 0 {
+			continue
+		}
+		notify := *rule
+		notify.Name = ""
+		notify.Names = names
+		notify.Action = seccomp.ActNotify
+		profile.Syscalls = append(profile.Syscalls, &notify)
+	}
+	profile.Syscalls = append(profile.Syscalls, &seccomp.Syscall{
+		Names:   slices.Clone(RuntimeSyscalls),
+		Action:  seccomp.ActAllow,
+		Comment: "used by the OCI runtime before the listener gets the notify file descriptor",
+	})
+	return &profile
+}
+
+// MinimalProfile returns a copy of base only allowing the recorded syscalls
+// and the RuntimeSyscalls.  The conditions base puts on the allowed syscalls
+// are kept, and the other syscalls get the default action of base, or fail
+// with EPERM if base allows them by default.
+func MinimalProfile(base *seccomp.Seccomp, recorded []string) *seccomp.Seccomp {
+	allowed := make(map[string]bool)
+	for _, name := range recorded {
+		allowed[name] = true
+	}
+	for _, name := range RuntimeSyscalls {
+		allowed[name] = true
+	}
+
+	profile := *base
+	profile.ListenerPath = ""
+	profile.ListenerMetadata = ""
+	switch profile.DefaultAction {
+	case seccomp.ActAllow, seccomp.ActLog, seccomp.ActNotify:
+		eperm := uint(1)
+		profile.DefaultAction = seccomp.ActErrno
+		profile.DefaultErrnoRet = &eperm
+		profile.DefaultErrno = ""
+	}
+	profile.Syscalls = nil
+	covered := make(map[string]bool)
+	for _, rule := range base.Syscalls {
+		if rule.Action != seccomp.ActAllow && rule.Action != seccomp.ActLog && rule.Action != seccomp.ActNotify {
+			profile.Syscalls = append(profile.Syscalls, rule)
+			continue
+		}
+		names := slices.DeleteFunc(ruleNames(rule), func(name string) bool {
+			return !allowed[name]
+		})
+		if len(names) == 0 {
+			continue
+		}
+		for _, name := range names {
+			covered[name] = true
+		}
+		allow := *rule
+		allow.Name = ""
+		allow.Names = names
+		allow.Action = seccomp.ActAllow
+		profile.Syscalls = append(profile.Syscalls, &allow)
+	}
+
+	var extra []string
+	for name := range allowed {
+		if !covered[name] {
+			extra = append(extra, name)
+		}
+	}
+	if len(extra) > 0 {
+		slices.Sort(extra)
+		profile.Syscalls = append(profile.Syscalls, &seccomp.Syscall{
+			Names:  extra,
+			Action: seccomp.ActAllow,
+		})
+	}
+	return &profile
+}
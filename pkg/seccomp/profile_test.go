@@ -0,0 +1,88 @@
+package seccomp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/common/pkg/seccomp"
+)
+
+func testProfile() *seccomp.Seccomp {
+	return &seccomp.Seccomp{
+		DefaultAction: seccomp.ActErrno,
+		Syscalls: []*seccomp.Syscall{
+			{Names: []string{"read", "write", "openat", "mkdir"}, Action: seccomp.ActAllow},
+			{Name: "clone", Action: seccomp.ActAllow, Args: []*seccomp.Arg{{Index: 0, Value: 1, Op: seccomp.OpMaskedEqual}}},
+			{Names: []string{"reboot"}, Action: seccomp.ActErrno, Includes: seccomp.Filter{Caps: []string{"CAP_SYS_BOOT"}}},
+			{Names: []string{"acct"}, Action: seccomp.ActAllow, Includes: seccomp.Filter{Caps: []string{"CAP_SYS_PACCT"}}},
+			{Names: []string{"acct"}, Action: seccomp.ActErrno, Excludes: seccomp.Filter{Caps: []string{"CAP_SYS_PACCT"}}},
+		},
+	}
+}
+
+func TestEffects(t *testing.T) {
+	effects := Effects(testProfile())
+	assert.Equal(t, Effect{Action: seccomp.ActAllow}, effects["read"])
+	assert.Equal(t, Effect{Action: seccomp.ActAllow, Conditional: true}, effects["clone"])
+	assert.Equal(t, "SCMP_ACT_ALLOW (conditional)", effects["clone"].String())
+	assert.Equal(t, Effect{Action: seccomp.ActAllow, Conditional: true}, effects["acct"])
+	assert.NotContains(t, effects, "mount")
+}
+
+func TestCompare(t *testing.T) {
+	profile := testProfile()
+	profile.Syscalls[0].Names = []string{"read", "write", "openat", "mount"}
+	profile.Syscalls[1].Args = nil
+
+	diff := Compare(testProfile(), profile)
+	assert.Equal(t, seccomp.ActErrno, diff.ProfileDefaultAction)
+	assert.Equal(t, []Change{
+		{Syscall: "clone", Reference: Effect{Action: seccomp.ActAllow, Conditional: true}, Profile: Effect{Action: seccomp.ActAllow}},
+		{Syscall: "mkdir", Reference: Effect{Action: seccomp.ActAllow}, Profile: Effect{Action: seccomp.ActErrno}},
+		{Syscall: "mount", Reference: Effect{Action: seccomp.ActErrno}, Profile: Effect{Action: seccomp.ActAllow}},
+	}, diff.Changes)
+
+	assert.Empty(t, Compare(testProfile(), testProfile()).Changes)
+}
+
+func TestRecordingProfile(t *testing.T) {
+	profile := RecordingProfile(testProfile(), "/run/listener.sock")
+	assert.Equal(t, "/run/listener.sock", profile.ListenerPath)
+	assert.Equal(t, seccomp.ActErrno, profile.DefaultAction)
+	require.Len(t, profile.Syscalls, 6)
+	assert.Equal(t, []string{"read", "openat", "mkdir"}, profile.Syscalls[0].Names)
+	assert.Equal(t, seccomp.ActNotify, profile.Syscalls[0].Action)
+	assert.Equal(t, seccomp.ActNotify, profile.Syscalls[1].Action)
+	assert.NotEmpty(t, profile.Syscalls[1].Args)
+	assert.Equal(t, seccomp.ActErrno, profile.Syscalls[2].Action)
+	assert.Equal(t, seccomp.ActNotify, profile.Syscalls[3].Action)
+	assert.Equal(t, RuntimeSyscalls, profile.Syscalls[5].Names)
+	assert.Equal(t, seccomp.ActAllow, profile.Syscalls[5].Action)
+
+	allowAll := &seccomp.Seccomp{DefaultAction: seccomp.ActAllow}
+	assert.Equal(t, seccomp.ActNotify, RecordingProfile(allowAll, "/run/listener.sock").DefaultAction)
+}
+
+func TestMinimalProfile(t *testing.T) {
+	base := testProfile()
+	profile := MinimalProfile(RecordingProfile(base, "/run/listener.sock"), []string{"clone", "openat"})
+	assert.Empty(t, profile.ListenerPath)
+	assert.Equal(t, seccomp.ActErrno, profile.DefaultAction)
+
+	effects := Effects(profile)
+	assert.Equal(t, Effect{Action: seccomp.ActAllow}, effects["openat"])
+	assert.Equal(t, Effect{Action: seccomp.ActAllow, Conditional: true}, effects["clone"])
+	assert.Equal(t, Effect{Action: seccomp.ActAllow}, effects["write"])
+	assert.Equal(t, Effect{Action: seccomp.ActErrno, Conditional: true}, effects["reboot"])
+	assert.NotContains(t, effects, "read")
+	assert.NotContains(t, effects, "mkdir")
+
+	profile = MinimalProfile(&seccomp.Seccomp{DefaultAction: seccomp.ActAllow}, []string{"getpid"})
+	assert.Equal(t, seccomp.ActErrno, profile.DefaultAction)
+	require.NotNil(t, profile.DefaultErrnoRet)
+	assert.Equal(t, uint(1), *profile.DefaultErrnoRet)
+	require.Len(t, profile.Syscalls, 1)
+	assert.Contains(t, profile.Syscalls[0].Names, "getpid")
+	assert.Contains(t, profile.Syscalls[0].Names, "futex")
+}
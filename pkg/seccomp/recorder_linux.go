@@ -0,0 +1,201 @@
+package seccomp
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"sync"
+	"unsafe"
+
+	"github.com/sirupsen/logrus"
+	"golang.org/x/sys/unix"
+)
+
+// seccompNotif is struct seccomp_notif of linux/seccomp.h.
+type seccompNotif struct {
+	id    uint64
+	pid   uint32
+	flags uint32
+	data  struct {
+		nr                 int32
+		arch               uint32
+		instructionPointer uint64
+		args               [6]uint64
+	}
+}
+
+// seccompNotifResp is struct seccomp_notif_resp of linux/seccomp.h.
+type seccompNotifResp struct {
+	id    uint64
+	val   int64
+	error int32
+	flags uint32
+}
+
+// pollTimeout is how long, in milliseconds, the recorder waits for a
+// notification before checking whether it is stopped.
+const pollTimeout = 100
+
+// Recorder is a seccomp notify listener recording the syscalls of the
+// containers whose profile has its socket as listener path.  The syscalls
+// are allowed to continue, as if the container had no seccomp filter.
+type Recorder struct {
+	listener *net.UnixListener
+	done     chan struct{}
+	wg       sync.WaitGroup
+
+	lock     sync.Mutex
+	syscalls map[int32]struct{}
+	errs     []error
+}
+
+// NewRecorder starts recording the syscalls of the containers connecting to
+// the unix socket created at socketPath.
+func NewRecorder(socketPath string) (*Recorder, error) {
+	listener, err := net.ListenUnix("unix", &net.UnixAddr{Name: socketPath, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	r := &Recorder{
+		listener: listener,
+		done:     make(chan struct{}),
+		syscalls: make(map[int32]struct{}),
+	}
+	r.wg.Add(1)
+	go r.accept()
+	return r, nil
+}
+
+func (r *Recorder) addError(err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.errs = append(r.errs, err)
+}
+
+func (r *Recorder) accept() {
+	defer r.wg.Done()
+	for {
+		conn, err := r.listener.AcceptUnix()
+		if err != nil {
+			select {
+			case <-r.done:
+			default:
+				r.addError(err)
+			}
+			return
+		}
+		fds, err := receiveFds(conn)
+		conn.Close()
+		if err != nil {
+			r.addError(fmt.Errorf("receiving the seccomp notify file descriptor: %w", err))
+			continue
+		}
+		for _, fd := range fds {
+			r.wg.Add(1)
+			go r.serve(fd)
+		}
+	}
+}
+
+// receiveFds returns the file descriptors the OCI runtime sends along with
+// the state of the container.
+func receiveFds(conn *net.UnixConn) ([]int, error) {
+	buf := make([]byte, 64*1024)
+	oob := make([]byte, unix.CmsgSpace(4*4))
+	_, oobn, _, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return nil, err
+	}
+	msgs, err := unix.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, err
+	}
+	var fds []int
+	for _, msg := range msgs {
+		rights, err := unix.ParseUnixRights(&msg)
+		if err != nil {
+			return nil, err
+		}
+		fds = append(fds, rights...)
+	}
+	if len(fds) == 0 {
+		return nil, errors.New("no file descriptor received")
+	}
+	return fds, nil
+}
+
+// serve records the notifications of the seccomp notify file descriptor
+// until the processes of the container exit or the recorder is stopped.
+func (r *Recorder) serve(fd int) {
+	defer r.wg.Done()
+	defer unix.Close(fd)
+	for {
+		select {
+		case <-r.done:
+			return
+		default:
+		}
+		fds := []unix.PollFd{{Fd: int32(fd), Events: unix.POLLIN}}
+		n, err := unix.Poll(fds, pollTimeout)
+		if err != nil {
+			if errors.Is(err, unix.EINTR) {
+				continue
+			}
+			r.addError(err)
+			return
+		}
+		if n == 0 {
+			continue
+		}
+		if fds[0].Revents&unix.POLLIN == 0 {
+			// POLLHUP: no process uses the filter anymore
+			return
+		}
+
+		var req seccompNotif
+		if err := ioctl(fd, unix.SECCOMP_IOCTL_NOTIF_RECV, unsafe.Pointer(&req)); err != nil {
+			if errors.Is(err, unix.EINTR) || errors.Is(err, unix.ENOENT) {
+				continue
+			}
+			r.addError(fmt.Errorf("receiving a seccomp notification: %w", err))
+			return
+		}
+		r.lock.Lock()
+		r.syscalls[req.data.nr] = struct{}{}
+		r.lock.Unlock()
+
+		resp := seccompNotifResp{id: req.id, flags: unix.SECCOMP_USER_NOTIF_FLAG_CONTINUE}
+		if err := ioctl(fd, unix.SECCOMP_IOCTL_NOTIF_SEND, unsafe.Pointer(&resp)); err != nil && !errors.Is(err, unix.ENOENT) {
+			// ENOENT: the process was killed while waiting for the answer
+			logrus.Debugf("Answering seccomp notification of syscall %d: %v", req.data.nr, err)
+		}
+	}
+}
+
+func ioctl(fd int, req uint, arg unsafe.Pointer) error {
+	_, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), uintptr(req), uintptr(arg))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// Stop stops recording and returns the names of the recorded syscalls,
+// sorted.
+func (r *Recorder) Stop() ([]string, error) {
+	close(r.done)
+	r.listener.Close()
+	r.wg.Wait()
+
+	names := make([]string, 0, len(r.syscalls))
+	for nr := range r.syscalls {
+		name, err := syscallName(nr)
+		if err != nil {
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names, errors.Join(r.errs...)
+}
@@ -0,0 +1,18 @@
+//go:build linux && seccomp
+
+package seccomp
+
+import (
+	"fmt"
+
+	libseccomp "github.com/seccomp/libseccomp-golang"
+)
+
+// syscallName returns the name of the syscall of the native architecture.
+func syscallName(nr int32) (string, error) {
+	name, err := libseccomp.ScmpSyscall(nr).GetName()
+	if err != nil {
+		return "", fmt.Errorf("looking up the name of syscall %d: %w", nr, err)
+	}
+	return name, nil
+}
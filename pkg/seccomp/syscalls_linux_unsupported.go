@@ -0,0 +1,9 @@
+//go:build linux && !seccomp
+
+package seccomp
+
+import "errors"
+
+func syscallName(int32) (string, error) {
+	return "", errors.New("seccomp not enabled in this build, syscall names cannot be looked up")
+}
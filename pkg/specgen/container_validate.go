@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
+	"go.podman.io/storage/pkg/parsers"
 )
 
 var (
@@ -91,6 +92,11 @@ func (s *SpecGenerator) Validate() error {
 	if s.UserNS.IsPrivate() && s.IDMappings == nil {
 		return fmt.Errorf("IDMappings are required when not creating a User namespace: %w", ErrInvalidSpecConfig)
 	}
+	if s.PodmanInPodman != nil && *s.PodmanInPodman {
+		if err := s.validatePodmanInPodman(); err != nil {
+			return err
+		}
+	}
 
 	//
 	// ContainerCgroupConfig
@@ -118,6 +124,13 @@ func (s *SpecGenerator) Validate() error {
 		return exclusiveOptions("UseImageHosts", "HostAdd")
 	}
 
+	//
+	// ContainerResourceConfig
+	//
+	if err := s.validateNUMAPolicy(); err != nil {
+		return err
+	}
+
 	// TODO the specgen does not appear to handle this?  Should it
 	// switch config.Cgroup.Cgroups {
 	// case "disabled":
@@ -157,3 +170,48 @@ func (s *SpecGenerator) Validate() error {
 
 	return nil
 }
+
+// validateNUMAPolicy verifies that the NUMA memory policy is known and that
+// the memory nodes it needs are set.
+func (s *SpecGenerator) validateNUMAPolicy() error {
+	if err := define.ValidateNUMAPolicy(s.NUMAPolicy); err != nil {
+		return err
+	}
+	if !define.NUMAPolicyNeedsNodes(s.NUMAPolicy) {
+		return nil
+	}
+	mems := ""
+	if s.ResourceLimits != nil && s.ResourceLimits.CPU != nil {
+		mems = s.ResourceLimits.CPU.Mems
+	}
+	if mems == "" {
+		return fmt.Errorf("NUMA policy %q requires memory nodes to be set with cpuset mems: %w", s.NUMAPolicy, ErrInvalidSpecConfig)
+	}
+	nodes, err := parsers.ParseUintList(mems)
+	if err != nil {
+		return fmt.Errorf("invalid value %s for cpuset mems: %w", mems, err)
+	}
+	if s.NUMAPolicy == define.NUMAPolicyPreferred && len(nodes) != 1 {
+		return fmt.Errorf("NUMA policy %q requires exactly one memory node, got %q: %w", s.NUMAPolicy, mems, ErrInvalidSpecConfig)
+	}
+	return nil
+}
+
+// validatePodmanInPodman verifies that the security options of the container
+// let Podman run in it as a rootless user.
+func (s *SpecGenerator) validatePodmanInPodman() error {
+	if s.IsPrivileged() {
+		return exclusiveOptions("PodmanInPodman", "Privileged")
+	}
+	// newuidmap and newgidmap are setuid binaries.
+	if s.NoNewPrivileges != nil && *s.NoNewPrivileges {
+		return exclusiveOptions("PodmanInPodman", "NoNewPrivileges")
+	}
+	for _, c := range s.CapDrop {
+		switch strings.TrimPrefix(strings.ToUpper(c), "CAP_") {
+		case "ALL", "SETUID", "SETGID":
+			return fmt.Errorf("cannot drop capability %s, nested Podman needs CAP_SETUID and CAP_SETGID: %w", c, ErrInvalidSpecConfig)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+//go:build !remote
+
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/sirupsen/logrus"
+	"tags.cncf.io/container-device-interface/pkg/cdi"
+	"tags.cncf.io/container-device-interface/pkg/parser"
+)
+
+// newCDIRegistry returns a CDI cache populated with the specs found in the
+// given directories.
+func newCDIRegistry(specDirs []string) (*cdi.Cache, error) {
+	registry, err := cdi.NewCache(
+		cdi.WithSpecDirs(specDirs...),
+		cdi.WithAutoRefresh(false),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating CDI registry: %w", err)
+	}
+	if err := registry.Refresh(); err != nil {
+		logrus.Debugf("The following error was triggered when refreshing the CDI registry: %v", err)
+	}
+	return registry, nil
+}
+
+// validateCDIDevices verifies that all the given CDI devices are defined by a
+// spec of the registry, so that a missing device is reported when the
+// container is created rather than when the OCI runtime starts it.
+func validateCDIDevices(registry *cdi.Cache, devices []string) error {
+	for _, device := range devices {
+		if registry.GetDevice(device) != nil {
+			continue
+		}
+		vendor, class, _, err := parser.ParseQualifiedName(device)
+		if err != nil {
+			return fmt.Errorf("invalid CDI device %q: %v: %w", device, err, define.ErrInvalidArg)
+		}
+		kind := parser.QualifiedName(vendor, class, "")
+		var available []string
+		for _, name := range registry.ListDevices() {
+			if strings.HasPrefix(name, kind) {
+				available = append(available, name)
+			}
+		}
+		if len(available) > 0 {
+			return fmt.Errorf("CDI device %q not found, available devices of kind %s/%s are %s: %w", device, vendor, class, strings.Join(available, ", "), define.ErrInvalidArg)
+		}
+
+		specErrors := []string{}
+		for path, errs := range registry.GetErrors() {
+			for _, err := range errs {
+				specErrors = append(specErrors, fmt.Sprintf("%s: %v", path, err))
+			}
+		}
+		if len(specErrors) > 0 {
+			return fmt.Errorf("CDI device %q not found, no valid CDI spec defines kind %s/%s, invalid specs: %s: %w", device, vendor, class, strings.Join(specErrors, "; "), define.ErrInvalidArg)
+		}
+		return fmt.Errorf("CDI device %q not found, no CDI spec for kind %s/%s in %s; generate one with the tools of the device vendor (e.g. \"nvidia-ctk cdi generate\") and check \"podman device list\": %w", device, vendor, class, strings.Join(registry.GetSpecDirectories(), ", "), define.ErrInvalidArg)
+	}
+	return nil
+}
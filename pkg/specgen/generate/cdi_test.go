@@ -0,0 +1,36 @@
+//go:build !remote
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateCDIDevices(t *testing.T) {
+	dir := t.TempDir()
+	spec := `{
+  "cdiVersion": "0.6.0",
+  "kind": "vendor.com/gpu",
+  "devices": [
+    {"name": "0", "containerEdits": {"env": ["GPU=0"]}},
+    {"name": "all", "containerEdits": {"env": ["GPU=all"]}}
+  ]
+}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "vendor.json"), []byte(spec), 0o644))
+
+	registry, err := newCDIRegistry([]string{dir})
+	require.NoError(t, err)
+
+	assert.NoError(t, validateCDIDevices(registry, []string{"vendor.com/gpu=0", "vendor.com/gpu=all"}))
+
+	err = validateCDIDevices(registry, []string{"vendor.com/gpu=1"})
+	assert.ErrorContains(t, err, `CDI device "vendor.com/gpu=1" not found, available devices of kind vendor.com/gpu are vendor.com/gpu=0, vendor.com/gpu=all`)
+
+	err = validateCDIDevices(registry, []string{"nvidia.com/gpu=all"})
+	assert.ErrorContains(t, err, "no CDI spec for kind nvidia.com/gpu in "+dir)
+}
@@ -9,25 +9,21 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/opencontainers/runtime-tools/generate"
-	"github.com/sirupsen/logrus"
 	"go.podman.io/common/pkg/config"
 	"golang.org/x/sys/unix"
-	"tags.cncf.io/container-device-interface/pkg/cdi"
 )
 
 // DevicesFromPath computes a list of devices
 func DevicesFromPath(g *generate.Generator, devicePath string, config *config.Config) error {
 	if isCDIDevice(devicePath) {
-		registry, err := cdi.NewCache(
-			cdi.WithSpecDirs(config.Engine.CdiSpecDirs.Get()...),
-			cdi.WithAutoRefresh(false),
-		)
+		registry, err := newCDIRegistry(config.Engine.CdiSpecDirs.Get())
 		if err != nil {
-			return fmt.Errorf("creating CDI registry: %w", err)
+			return err
 		}
-		if err := registry.Refresh(); err != nil {
-			logrus.Debugf("The following error was triggered when refreshing the CDI registry: %v", err)
+		if err := validateCDIDevices(registry, []string{devicePath}); err != nil {
+			return err
 		}
 		if _, err = registry.InjectDevices(g.Config, devicePath); err != nil {
 			return fmt.Errorf("setting up CDI devices: %w", err)
@@ -95,7 +91,7 @@ func DevicesFromPath(g *generate.Generator, devicePath string, config *config.Co
 }
 
 func addDevice(g *generate.Generator, device string) error {
-	src, dst, permissions, err := ParseDevice(device)
+	src, dst, permissions, err := util.ParseDevice(device)
 	if err != nil {
 		return err
 	}
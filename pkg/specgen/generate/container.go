@@ -226,6 +226,14 @@ func CompleteSpec(ctx context.Context, r *libpod.Runtime, s *specgen.SpecGenerat
 		}
 	}
 
+	// the locale replaces the one of the image, but not the one of --env
+	if s.Locale != "" {
+		defaultEnvs["LANG"] = s.Locale
+		if _, ok := defaultEnvs["LC_ALL"]; ok {
+			defaultEnvs["LC_ALL"] = s.Locale
+		}
+	}
+
 	s.Env = envLib.Join(defaultEnvs, s.Env)
 
 	// Labels and Annotations
@@ -240,6 +248,12 @@ func CompleteSpec(ctx context.Context, r *libpod.Runtime, s *specgen.SpecGenerat
 			s.Labels = make(map[string]string)
 		}
 		for k, v := range labels {
+			// Lifecycle hooks run commands on the host, do not let
+			// untrusted images set them.
+			if strings.HasPrefix(k, define.LifecycleHookPrefix) {
+				logrus.Warnf("Ignoring label %s of image %s: lifecycle hooks can only be set on the container", k, s.Image)
+				continue
+			}
 			if _, exists := s.Labels[k]; !exists {
 				s.Labels[k] = v
 			}
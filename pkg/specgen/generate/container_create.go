@@ -24,6 +24,7 @@ import (
 	"go.podman.io/common/libimage"
 	"go.podman.io/common/libnetwork/pasta"
 	"go.podman.io/common/libnetwork/slirp4netns"
+	"go.podman.io/common/pkg/config"
 	"tags.cncf.io/container-device-interface/pkg/parser"
 )
 
@@ -164,12 +165,27 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 		return nil, nil, nil, err
 	}
 
-	if imageData != nil {
-		ociRuntimeVariant := rtc.Engine.ImagePlatformToRuntime(imageData.Os, imageData.Architecture)
-		// Don't unnecessarily set and invoke additional libpod
-		// option if OCI runtime is still default.
-		if ociRuntimeVariant != rtc.Engine.OCIRuntime {
-			options = append(options, libpod.WithCtrOCIRuntime(ociRuntimeVariant))
+	switch {
+	case s.RuntimeClass != "":
+		classOptions, err := runtimeClassOptions(rt, s)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		options = append(options, classOptions...)
+	case imageData != nil:
+		if libpod.IsWasmPlatform(imageData.Os, imageData.Architecture) {
+			wasmOptions, err := wasmRuntimeOptions(rt, s)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			options = append(options, wasmOptions...)
+		} else {
+			ociRuntimeVariant := rtc.Engine.ImagePlatformToRuntime(imageData.Os, imageData.Architecture)
+			// Don't unnecessarily set and invoke additional libpod
+			// option if OCI runtime is still default.
+			if ociRuntimeVariant != rtc.Engine.OCIRuntime {
+				options = append(options, libpod.WithCtrOCIRuntime(ociRuntimeVariant))
+			}
 		}
 	}
 
@@ -221,6 +237,12 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 		return nil, nil, nil, fmt.Errorf("invalid config provided: %w", err)
 	}
 
+	if s.PodmanInPodman != nil && *s.PodmanInPodman {
+		if err := setupPodmanInPodman(s); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
 	finalMounts, finalVolumes, finalOverlays, err := finalizeMounts(ctx, s, rt, rtc, newImage)
 	if err != nil {
 		return nil, nil, nil, err
@@ -247,7 +269,10 @@ func MakeContainer(ctx context.Context, rt *libpod.Runtime, s *specgen.SpecGener
 		options = append(options, libpod.WithName(s.Name))
 	}
 	if len(s.Devices) > 0 {
-		opts = ExtractCDIDevices(s)
+		opts, err = ExtractCDIDevices(s, rtc)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 		options = append(options, opts...)
 	}
 	runtimeSpec, err := SpecGenToOCI(ctx, s, rt, rtc, newImage, finalMounts, pod, command, compatibleOptions)
@@ -322,9 +347,9 @@ func ExecuteCreate(ctx context.Context, rt *libpod.Runtime, runtimeSpec *specs.S
 }
 
 // ExtractCDIDevices process the list of Devices in the spec and determines if any of these are CDI devices.
-// The CDI devices are added to the list of CtrCreateOptions.
+// The CDI devices are validated against the CDI specs and added to the list of CtrCreateOptions.
 // Note that this may modify the device list associated with the spec, which should then only contain non-CDI devices.
-func ExtractCDIDevices(s *specgen.SpecGenerator) []libpod.CtrCreateOption {
+func ExtractCDIDevices(s *specgen.SpecGenerator, rtc *config.Config) ([]libpod.CtrCreateOption, error) {
 	devs := make([]specs.LinuxDevice, 0, len(s.Devices))
 	var cdiDevs []string
 	var options []libpod.CtrCreateOption
@@ -340,9 +365,16 @@ func ExtractCDIDevices(s *specgen.SpecGenerator) []libpod.CtrCreateOption {
 	}
 	s.Devices = devs
 	if len(cdiDevs) > 0 {
+		registry, err := newCDIRegistry(rtc.Engine.CdiSpecDirs.Get())
+		if err != nil {
+			return nil, err
+		}
+		if err := validateCDIDevices(registry, cdiDevs); err != nil {
+			return nil, err
+		}
 		options = append(options, libpod.WithCDI(cdiDevs))
 	}
-	return options
+	return options, nil
 }
 
 // isCDIDevice checks whether the specified device is a CDI device.
@@ -361,6 +393,9 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	if s.PreserveFD != nil {
 		options = append(options, libpod.WithPreserveFD(s.PreserveFD))
 	}
+	if s.SocketActivation {
+		options = append(options, libpod.WithSocketActivation())
+	}
 
 	if s.Stdin != nil && *s.Stdin {
 		options = append(options, libpod.WithStdin())
@@ -369,12 +404,33 @@ func createContainerOptions(rt *libpod.Runtime, s *specgen.SpecGenerator, pod *l
 	if s.Timezone != "" {
 		options = append(options, libpod.WithTimezone(s.Timezone))
 	}
+	if s.Locale != "" {
+		options = append(options, libpod.WithLocale(s.Locale))
+	}
+	if s.TrackFlows {
+		options = append(options, libpod.WithTrackFlows())
+	}
+	if s.NetworkPolicy != nil {
+		options = append(options, libpod.WithNetworkPolicy(s.NetworkPolicy))
+	}
+	if s.StatsHistoryInterval > 0 {
+		options = append(options, libpod.WithStatsHistory(s.StatsHistoryInterval))
+	}
+	if s.Monitor != "" {
+		options = append(options, libpod.WithMonitor(s.Monitor))
+	}
 	if s.Umask != "" {
 		options = append(options, libpod.WithUmask(s.Umask))
 	}
+	if s.NUMAPolicy != "" {
+		options = append(options, libpod.WithNUMAPolicy(s.NUMAPolicy))
+	}
 	if s.Volatile != nil && *s.Volatile {
 		options = append(options, libpod.WithVolatile())
 	}
+	if s.StorageTransient != nil && *s.StorageTransient {
+		options = append(options, libpod.WithStorageTransient())
+	}
 	if s.PasswdEntry != "" {
 		options = append(options, libpod.WithPasswdEntry(s.PasswdEntry))
 	}
@@ -185,6 +185,8 @@ type CtrSpecGenOptions struct {
 	PodSecurityContext *v1.PodSecurityContext
 	// TerminationGracePeriodSeconds is the grace period given to a container to stop before being forcefully killed
 	TerminationGracePeriodSeconds *int64
+	// RuntimeClass is the runtimeClassName of the pod
+	RuntimeClass string
 }
 
 func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGenerator, error) {
@@ -266,6 +268,7 @@ func ToSpecGen(ctx context.Context, opts *CtrSpecGenOptions) (*specgen.SpecGener
 	}
 
 	s.InitContainerType = opts.InitContainerType
+	s.RuntimeClass = opts.RuntimeClass
 
 	setupSecurityContext(s, opts.Container.SecurityContext, opts.PodSecurityContext)
 	err = setupLivenessProbe(s, opts.Container, opts.RestartPolicy)
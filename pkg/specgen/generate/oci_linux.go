@@ -13,6 +13,7 @@ import (
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/rootless"
 	"github.com/dmikushin/podman-shared/pkg/specgen"
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"github.com/docker/go-units"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
@@ -274,13 +275,22 @@ func SpecGenToOCI(_ context.Context, s *specgen.SpecGenerator, rt *libpod.Runtim
 	s.HostDeviceList = userDevices
 
 	// set the devices cgroup when not running in a user namespace
-	if isRootless && len(s.DeviceCgroupRule) > 0 {
+	if isRootless && (len(s.DeviceCgroupRule) > 0 || len(s.DeviceCgroupRulePaths) > 0) {
 		return nil, fmt.Errorf("device cgroup rules are not supported in rootless mode or in a user namespace")
 	}
 	if !isRootless && !s.IsPrivileged() {
 		for _, dev := range s.DeviceCgroupRule {
 			g.AddLinuxResourcesDevice(true, dev.Type, dev.Major, dev.Minor, dev.Access)
 		}
+		for _, rule := range s.DeviceCgroupRulePaths {
+			devs, err := util.DeviceCgroupRulesFromPaths(rule)
+			if err != nil {
+				return nil, err
+			}
+			for _, dev := range devs {
+				g.AddLinuxResourcesDevice(true, dev.Type, dev.Major, dev.Minor, dev.Access)
+			}
+		}
 	}
 
 	BlockAccessToKernelFilesystems(s.IsPrivileged(), s.PidNS.IsHost(), s.Mask, s.Unmask, &g)
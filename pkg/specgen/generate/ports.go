@@ -9,6 +9,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/specgen"
 	"github.com/dmikushin/podman-shared/pkg/specgenutil"
 	"github.com/dmikushin/podman-shared/utils"
@@ -406,6 +407,108 @@ func checkProtocol(protocol string) ([]string, error) {
 	return finalProto, nil
 }
 
+// splitPortMapping returns the single ports of a port mapping, one per port
+// of its range and per protocol.
+func splitPortMapping(port types.PortMapping) ([]types.PortMapping, error) {
+	protocols, err := checkProtocol(port.Protocol)
+	if err != nil {
+		return nil, err
+	}
+	portRange := max(port.Range, 1)
+	ports := make([]types.PortMapping, 0, len(protocols)*int(portRange))
+	for _, protocol := range protocols {
+		for i := range portRange {
+			single := types.PortMapping{
+				HostIP:        port.HostIP,
+				ContainerPort: port.ContainerPort + i,
+				Protocol:      protocol,
+				Range:         1,
+			}
+			if port.HostPort != 0 {
+				single.HostPort = port.HostPort + i
+			}
+			ports = append(ports, single)
+		}
+	}
+	return ports, nil
+}
+
+// RemovePortMappings removes ports from the published ports.  The ports to
+// remove are given like with --publish: the container port and protocol must
+// match, the host port and host IP only when they are set.  Every port to
+// remove must be published.  The remaining ports are split into single ports,
+// to be joined again by ParsePortMapping.
+func RemovePortMappings(ports []types.PortMapping, remove []types.PortMapping) ([]types.PortMapping, error) {
+	var published []types.PortMapping
+	for _, port := range ports {
+		single, err := splitPortMapping(port)
+		if err != nil {
+			return nil, err
+		}
+		published = append(published, single...)
+	}
+
+	removed := make([]bool, len(published))
+	for _, port := range remove {
+		toRemove, err := splitPortMapping(port)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range toRemove {
+			found := false
+			for i, p := range published {
+				if p.ContainerPort == r.ContainerPort && p.Protocol == r.Protocol &&
+					(r.HostPort == 0 || p.HostPort == r.HostPort) &&
+					(r.HostIP == "" || p.HostIP == r.HostIP) {
+					removed[i] = true
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("container port %d/%s is not published: %w", r.ContainerPort, r.Protocol, define.ErrInvalidArg)
+			}
+		}
+	}
+
+	kept := make([]types.PortMapping, 0, len(published))
+	for i, p := range published {
+		if !removed[i] {
+			kept = append(kept, p)
+		}
+	}
+	return kept, nil
+}
+
+// CheckHostPortConflicts returns an error when a host port is published more
+// than once for the same protocol on the same host IP, no host IP meaning all
+// of them.
+func CheckHostPortConflicts(ports []types.PortMapping) error {
+	type hostPort struct {
+		port     uint16
+		protocol string
+	}
+	usedIPs := make(map[hostPort][]string)
+	for _, port := range ports {
+		single, err := splitPortMapping(port)
+		if err != nil {
+			return err
+		}
+		for _, p := range single {
+			if p.HostPort == 0 {
+				continue
+			}
+			key := hostPort{p.HostPort, p.Protocol}
+			for _, ip := range usedIPs[key] {
+				if ip == "" || p.HostIP == "" || ip == p.HostIP {
+					return fmt.Errorf("host port %d/%s is published more than once: %w", p.HostPort, p.Protocol, define.ErrInvalidArg)
+				}
+			}
+			usedIPs[key] = append(usedIPs[key], p.HostIP)
+		}
+	}
+	return nil
+}
+
 func GenExposedPorts(exposedPorts map[string]struct{}) (map[uint16]string, error) {
 	expose := make([]string, 0, len(exposedPorts))
 	for e := range exposedPorts {
@@ -985,3 +985,94 @@ func TestParsePortMappingError(t *testing.T) {
 		})
 	}
 }
+
+func TestRemovePortMappings(t *testing.T) {
+	published := []types.PortMapping{
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 3},
+		{HostIP: "127.0.0.1", HostPort: 9090, ContainerPort: 90, Protocol: "udp", Range: 1},
+	}
+
+	kept, err := RemovePortMappings(published, []types.PortMapping{{HostPort: 8081, ContainerPort: 81, Range: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, []types.PortMapping{
+		{HostPort: 8080, ContainerPort: 80, Protocol: "tcp", Range: 1},
+		{HostPort: 8082, ContainerPort: 82, Protocol: "tcp", Range: 1},
+		{HostIP: "127.0.0.1", HostPort: 9090, ContainerPort: 90, Protocol: "udp", Range: 1},
+	}, kept)
+
+	// The host port and IP only match when given.
+	kept, err = RemovePortMappings(published, []types.PortMapping{{ContainerPort: 80, Protocol: "tcp", Range: 3}, {ContainerPort: 90, Protocol: "udp"}})
+	assert.NoError(t, err)
+	assert.Empty(t, kept)
+
+	_, err = RemovePortMappings(published, []types.PortMapping{{ContainerPort: 90, Range: 1}})
+	assert.ErrorContains(t, err, "container port 90/tcp is not published")
+	_, err = RemovePortMappings(published, []types.PortMapping{{HostPort: 9091, ContainerPort: 90, Protocol: "udp", Range: 1}})
+	assert.ErrorContains(t, err, "container port 90/udp is not published")
+	_, err = RemovePortMappings(published, []types.PortMapping{{HostIP: "127.0.0.2", ContainerPort: 90, Protocol: "udp", Range: 1}})
+	assert.ErrorContains(t, err, "container port 90/udp is not published")
+}
+
+func TestCheckHostPortConflicts(t *testing.T) {
+	tests := []struct {
+		name     string
+		ports    []types.PortMapping
+		conflict bool
+	}{
+		{
+			name: "different protocols",
+			ports: []types.PortMapping{
+				{HostPort: 8080, ContainerPort: 80, Protocol: "tcp"},
+				{HostPort: 8080, ContainerPort: 80, Protocol: "udp"},
+			},
+		},
+		{
+			name: "different host IPs",
+			ports: []types.PortMapping{
+				{HostIP: "127.0.0.1", HostPort: 8080, ContainerPort: 80},
+				{HostIP: "127.0.0.2", HostPort: 8080, ContainerPort: 81},
+			},
+		},
+		{
+			name: "random host ports",
+			ports: []types.PortMapping{
+				{ContainerPort: 80},
+				{ContainerPort: 81},
+			},
+		},
+		{
+			name: "same host port",
+			ports: []types.PortMapping{
+				{HostPort: 8080, ContainerPort: 80},
+				{HostPort: 8080, ContainerPort: 81},
+			},
+			conflict: true,
+		},
+		{
+			name: "all host IPs",
+			ports: []types.PortMapping{
+				{HostIP: "127.0.0.1", HostPort: 8080, ContainerPort: 80},
+				{HostPort: 8080, ContainerPort: 81},
+			},
+			conflict: true,
+		},
+		{
+			name: "overlapping ranges",
+			ports: []types.PortMapping{
+				{HostPort: 8080, ContainerPort: 80, Range: 3},
+				{HostPort: 8082, ContainerPort: 90, Range: 2},
+			},
+			conflict: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckHostPortConflicts(tt.ports)
+			if tt.conflict {
+				assert.ErrorContains(t, err, "is published more than once")
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
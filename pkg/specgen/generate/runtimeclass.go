@@ -0,0 +1,32 @@
+//go:build !remote
+
+package generate
+
+import (
+	"fmt"
+
+	"github.com/dmikushin/podman-shared/libpod"
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/specgen"
+)
+
+// runtimeClassOptions selects the OCI runtime of the runtime class of the
+// container and adds the annotations of the class.  An annotation the user
+// set to a different value than the class is an error, the runtime may not
+// run the container as intended without it.
+func runtimeClassOptions(rt *libpod.Runtime, s *specgen.SpecGenerator) ([]libpod.CtrCreateOption, error) {
+	class, err := rt.RuntimeClass(s.RuntimeClass)
+	if err != nil {
+		return nil, err
+	}
+	if len(class.Annotations) > 0 && s.Annotations == nil {
+		s.Annotations = make(map[string]string, len(class.Annotations))
+	}
+	for key, value := range class.Annotations {
+		if current, ok := s.Annotations[key]; ok && current != value {
+			return nil, fmt.Errorf("annotation %s=%s conflicts with %s=%s of runtime class %s: %w", key, current, key, value, class.Name, define.ErrInvalidArg)
+		}
+		s.Annotations[key] = value
+	}
+	return []libpod.CtrCreateOption{libpod.WithRuntimeClass(class)}, nil
+}
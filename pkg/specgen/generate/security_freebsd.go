@@ -3,6 +3,8 @@
 package generate
 
 import (
+	"errors"
+
 	"github.com/dmikushin/podman-shared/libpod"
 	"github.com/dmikushin/podman-shared/pkg/specgen"
 	"github.com/opencontainers/runtime-tools/generate"
@@ -35,3 +37,7 @@ func securityConfigureGenerator(s *specgen.SpecGenerator, g *generate.Generator,
 
 	return nil
 }
+
+func setupPodmanInPodman(_ *specgen.SpecGenerator) error {
+	return errors.New("podman in podman is not supported on FreeBSD")
+}
@@ -4,6 +4,7 @@ package generate
 
 import (
 	"fmt"
+	"os"
 	"slices"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/dmikushin/podman-shared/libpod/define"
 	"github.com/dmikushin/podman-shared/pkg/specgen"
 	"github.com/dmikushin/podman-shared/pkg/util"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/opencontainers/runtime-tools/generate"
 	"github.com/opencontainers/selinux/go-selinux"
 	"github.com/sirupsen/logrus"
@@ -271,3 +273,28 @@ func securityConfigureGenerator(s *specgen.SpecGenerator, g *generate.Generator,
 
 	return nil
 }
+
+// setupPodmanInPodman adds the devices and security options needed to run
+// Podman as a rootless user in the container: fuse-overlayfs needs
+// /dev/fuse, and the nested containers mount /proc and /sys, which the
+// kernel refuses while paths of them are masked.  The default seccomp
+// profile already allows the mount and unshare syscalls.
+func setupPodmanInPodman(s *specgen.SpecGenerator) error {
+	const fuse = "/dev/fuse"
+	if _, err := os.Stat(fuse); err != nil {
+		return fmt.Errorf("podman in podman needs %s on the host, load the fuse kernel module: %w", fuse, err)
+	}
+	if !slices.ContainsFunc(s.Devices, func(d spec.LinuxDevice) bool {
+		src, _, _ := strings.Cut(d.Path, ":")
+		return src == fuse
+	}) {
+		s.Devices = append(s.Devices, spec.LinuxDevice{Path: fuse})
+	}
+	if !slices.Contains(s.Unmask, "ALL") {
+		s.Unmask = append(s.Unmask, "ALL")
+	}
+	if len(s.SelinuxOpts) == 0 && (s.LabelNested == nil || !*s.LabelNested) {
+		s.SelinuxOpts = selinux.DisableSecOpt()
+	}
+	return nil
+}
@@ -10,6 +10,7 @@ import (
 	"maps"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/dmikushin/podman-shared/libpod"
@@ -195,7 +196,10 @@ func finalizeMounts(ctx context.Context, s *specgen.SpecGenerator, rt *libpod.Ru
 		if err != nil {
 			return nil, nil, nil, err
 		}
-		baseMounts = addReadWriteTmpfsMounts(baseMounts, s.Volumes, runPath)
+		baseMounts, err = addReadWriteTmpfsMounts(baseMounts, s.Volumes, runPath, s.ReadWriteTmpfsOptions)
+		if err != nil {
+			return nil, nil, nil, err
+		}
 	}
 
 	// Final step: maps to arrays
@@ -459,10 +463,16 @@ func InitFSMounts(mounts []spec.Mount) error {
 	return nil
 }
 
-func addReadWriteTmpfsMounts(mounts map[string]spec.Mount, volumes []*specgen.NamedVolume, runPath string) map[string]spec.Mount {
-	readonlyTmpfs := []string{"/tmp", "/var/tmp", runPath}
-	options := []string{"rw", "rprivate", "nosuid", "nodev", "tmpcopyup"}
-	for _, dest := range readonlyTmpfs {
+func addReadWriteTmpfsMounts(mounts map[string]spec.Mount, volumes []*specgen.NamedVolume, runPath string, tmpfsOptions map[string][]string) (map[string]spec.Mount, error) {
+	for key := range tmpfsOptions {
+		if key != "*" && !slices.Contains(specgen.ReadWriteTmpfsDestinations, key) {
+			return nil, fmt.Errorf("invalid read-write tmpfs %q, must be one of %s", key, strings.Join(specgen.ReadWriteTmpfsDestinations, ", "))
+		}
+	}
+	// destinations and the keys of their options
+	readonlyTmpfs := [][2]string{{"/tmp", "/tmp"}, {"/var/tmp", "/var/tmp"}, {runPath, "/run"}}
+	for _, tmpfs := range readonlyTmpfs {
+		dest := tmpfs[0]
 		if _, ok := mounts[dest]; ok {
 			continue
 		}
@@ -471,6 +481,10 @@ func addReadWriteTmpfsMounts(mounts map[string]spec.Mount, volumes []*specgen.Na
 				continue
 			}
 		}
+		options, enabled := mergeTmpfsOptions([]string{"rw", "rprivate", "nosuid", "nodev", "tmpcopyup"}, tmpfsOptions["*"], tmpfsOptions[tmpfs[1]])
+		if !enabled {
+			continue
+		}
 		mnt := spec.Mount{
 			Destination: dest,
 			Type:        define.TypeTmpfs,
@@ -479,5 +493,43 @@ func addReadWriteTmpfsMounts(mounts map[string]spec.Mount, volumes []*specgen.Na
 		}
 		mounts[dest] = mnt
 	}
-	return mounts
+	return mounts, nil
+}
+
+// tmpfsOptionGroups are the mutually exclusive tmpfs mount options
+var tmpfsOptionGroups = [][]string{
+	{"rw", "ro"},
+	{"suid", "nosuid"},
+	{"dev", "nodev"},
+	{"exec", "noexec"},
+	{"tmpcopyup", "notmpcopyup"},
+	{"private", "rprivate", "shared", "rshared", "slave", "rslave"},
+}
+
+// mergeTmpfsOptions returns the default options overridden by the user
+// options, in order, and whether the tmpfs is enabled.
+func mergeTmpfsOptions(defaults []string, userOptions ...[]string) ([]string, bool) {
+	options := slices.Clone(defaults)
+	for _, opts := range userOptions {
+		for _, opt := range opts {
+			if opt == "off" {
+				return nil, false
+			}
+			name, _, hasValue := strings.Cut(opt, "=")
+			options = slices.DeleteFunc(options, func(o string) bool {
+				if hasValue {
+					existing, _, _ := strings.Cut(o, "=")
+					return existing == name
+				}
+				for _, group := range tmpfsOptionGroups {
+					if slices.Contains(group, opt) && slices.Contains(group, o) {
+						return true
+					}
+				}
+				return o == opt
+			})
+			options = append(options, opt)
+		}
+	}
+	return options, true
 }
@@ -0,0 +1,48 @@
+//go:build !remote
+
+package generate
+
+import (
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/specgen"
+	spec "github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeTmpfsOptions(t *testing.T) {
+	defaults := []string{"rw", "rprivate", "nosuid", "nodev", "tmpcopyup"}
+
+	options, enabled := mergeTmpfsOptions(defaults, nil, nil)
+	assert.True(t, enabled)
+	assert.Equal(t, defaults, options)
+
+	options, enabled = mergeTmpfsOptions(defaults, []string{"size=64m"}, []string{"size=1g", "dev", "noexec", "mode=1777"})
+	assert.True(t, enabled)
+	assert.Equal(t, []string{"rw", "rprivate", "nosuid", "tmpcopyup", "size=1g", "dev", "noexec", "mode=1777"}, options)
+
+	_, enabled = mergeTmpfsOptions(defaults, []string{"size=64m"}, []string{"off"})
+	assert.False(t, enabled)
+}
+
+func TestAddReadWriteTmpfsMounts(t *testing.T) {
+	userTmp := spec.Mount{Destination: "/tmp", Type: define.TypeTmpfs, Source: define.TypeTmpfs, Options: []string{"size=1m"}}
+	mounts, err := addReadWriteTmpfsMounts(map[string]spec.Mount{"/tmp": userTmp}, nil, "/run", map[string][]string{
+		"*":        {"size=64m"},
+		"/tmp":     {"size=1g"},
+		"/var/tmp": {"off"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, mounts, 2)
+	assert.Equal(t, userTmp, mounts["/tmp"], "user mounts are left alone")
+	assert.Equal(t, []string{"rw", "rprivate", "nosuid", "nodev", "tmpcopyup", "size=64m"}, mounts["/run"].Options)
+
+	_, err = addReadWriteTmpfsMounts(map[string]spec.Mount{}, nil, "/run", map[string][]string{"/home": {"size=1m"}})
+	assert.ErrorContains(t, err, `invalid read-write tmpfs "/home"`)
+
+	mounts, err = addReadWriteTmpfsMounts(map[string]spec.Mount{}, []*specgen.NamedVolume{}, "/var/run", map[string][]string{"/run": {"noexec"}})
+	require.NoError(t, err)
+	assert.Contains(t, mounts["/var/run"].Options, "noexec", "/run stands for the run directory of the image")
+}
@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/dmikushin/podman-shared/pkg/rootless"
 	"github.com/dmikushin/podman-shared/pkg/specgen"
@@ -15,6 +17,7 @@ import (
 	"go.podman.io/common/pkg/cgroups"
 	"go.podman.io/common/pkg/sysinfo"
 	"go.podman.io/storage/pkg/fileutils"
+	"go.podman.io/storage/pkg/parsers"
 )
 
 // Verify resource limits are sanely set when running on cgroup v1.
@@ -37,7 +40,7 @@ func verifyContainerResourcesCgroupV1(s *specgen.SpecGenerator) ([]string, error
 	}
 
 	if s.ResourceLimits.Unified != nil {
-		return nil, errors.New("cannot use --cgroup-conf without cgroup v2")
+		return nil, errors.New("cannot use --cgroup-conf or --memory-high without cgroup v2")
 	}
 
 	// Memory checks
@@ -218,10 +221,80 @@ func verifyContainerResourcesCgroupV2(s *specgen.SpecGenerator) ([]string, error
 			warnings = append(warnings, "Realtime runtime not supported on cgroups V2 systems")
 			cpu.RealtimeRuntime = nil
 		}
+		if cpu.Mems != "" {
+			mems, err := onlineMemoryNodes()
+			if err != nil {
+				return warnings, err
+			}
+			memsAvailable, err := isNodeListAvailable(cpu.Mems, mems)
+			if err != nil {
+				return warnings, fmt.Errorf("invalid value %s for cpuset mems", cpu.Mems)
+			}
+			if !memsAvailable {
+				return warnings, fmt.Errorf("requested memory nodes are not available - requested %s, available: %s", cpu.Mems, mems)
+			}
+		}
+	}
+
+	// IO checks
+	// Device limits are still kept by device path in the specgen, they are
+	// only added to the block IO resources when the container is created.
+	hasDeviceLimits := len(s.WeightDevice)+len(s.ThrottleReadBpsDevice)+len(s.ThrottleWriteBpsDevice)+
+		len(s.ThrottleReadIOPSDevice)+len(s.ThrottleWriteIOPSDevice) > 0
+	if s.ResourceLimits.BlockIO != nil || hasDeviceLimits {
+		blkio := s.ResourceLimits.BlockIO
+		if blkio != nil && blkio.Weight != nil && (*blkio.Weight > 1000 || *blkio.Weight < 10) {
+			return warnings, errors.New("range of blkio weight is from 10 to 1000")
+		}
+		controllers, err := cgroups.AvailableControllers(nil, true)
+		if err != nil {
+			return warnings, err
+		}
+		if !slices.Contains(controllers, "io") {
+			warnings = append(warnings, "The io controller is not available in the cgroup. Block I/O weight and limits discarded.")
+			s.ResourceLimits.BlockIO = nil
+			s.WeightDevice = nil
+			s.ThrottleReadBpsDevice = nil
+			s.ThrottleWriteBpsDevice = nil
+			s.ThrottleReadIOPSDevice = nil
+			s.ThrottleWriteIOPSDevice = nil
+		}
 	}
 	return warnings, nil
 }
 
+// onlineMemoryNodes returns the list of NUMA nodes of the host that are online.
+func onlineMemoryNodes() (string, error) {
+	data, err := os.ReadFile("/sys/devices/system/node/online")
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			// Kernel built without NUMA support, there is a single node.
+			return "0", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// isNodeListAvailable returns true if all the nodes in provided are
+// part of available.
+func isNodeListAvailable(provided, available string) (bool, error) {
+	parsedProvided, err := parsers.ParseUintList(provided)
+	if err != nil {
+		return false, err
+	}
+	parsedAvailable, err := parsers.ParseUintList(available)
+	if err != nil {
+		return false, err
+	}
+	for k := range parsedProvided {
+		if !parsedAvailable[k] {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 // Verify resource limits are sanely set, removing any limits that are not
 // possible with the current cgroups config.
 func verifyContainerResources(s *specgen.SpecGenerator) ([]string, error) {
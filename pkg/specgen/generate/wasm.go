@@ -0,0 +1,29 @@
+//go:build !remote
+
+package generate
+
+import (
+	"github.com/dmikushin/podman-shared/libpod"
+	"github.com/dmikushin/podman-shared/pkg/specgen"
+	"github.com/sirupsen/logrus"
+)
+
+// wasmRuntimeOptions selects the OCI runtime running the WebAssembly image of
+// the container.  The wasm handler of the default runtime is enabled with an
+// annotation, unless the image or the user set it already.
+func wasmRuntimeOptions(rt *libpod.Runtime, s *specgen.SpecGenerator) ([]libpod.CtrCreateOption, error) {
+	wasm, err := rt.WasmRuntime()
+	if err != nil {
+		return nil, err
+	}
+	logrus.Debugf("Running WebAssembly image %s with OCI runtime %s", s.Image, wasm.Runtime)
+	if wasm.Annotation {
+		if s.Annotations == nil {
+			s.Annotations = make(map[string]string)
+		}
+		if _, ok := s.Annotations[libpod.WasmVariantAnnotation]; !ok {
+			s.Annotations[libpod.WasmVariantAnnotation] = "compat"
+		}
+	}
+	return []libpod.CtrCreateOption{libpod.WithCtrOCIRuntime(wasm.Runtime)}, nil
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
@@ -118,6 +119,17 @@ type ContainerBasicConfig struct {
 	// If not specified, the default will be used.
 	// Optional.
 	OCIRuntime string `json:"oci_runtime,omitempty"`
+	// RuntimeClass is the runtime class selecting the OCI runtime of the
+	// container and the annotations it needs, e.g. for Kata Containers.
+	// Takes precedence over the OCI runtime of the image platform.
+	// Optional.
+	RuntimeClass string `json:"runtime_class,omitempty"`
+	// Monitor is the container monitor managing the container: conmon,
+	// conmon-rs or the absolute path of a monitor binary supporting the
+	// conmon command line.
+	// If not specified, the conmon of the OCI runtime will be used.
+	// Optional.
+	Monitor string `json:"monitor,omitempty"`
 	// Systemd is whether the container will be started in systemd mode.
 	// Valid options are "true", "false", and "always".
 	// "true" enables this mode only if the binary run in the container is
@@ -183,10 +195,20 @@ type ContainerBasicConfig struct {
 	// set tags as `json:"-"` for not supported remote
 	// Optional.
 	PreserveFD []uint `json:"-"`
+	// SocketActivation passes the preserved file descriptors to the
+	// container as sockets of systemd socket activation, setting
+	// LISTEN_FDS and LISTEN_PID.
+	// Optional.
+	SocketActivation bool `json:"-"`
 	// Timezone is the timezone inside the container.
 	// Local means it has the same timezone as the host machine
 	// Optional.
 	Timezone string `json:"timezone,omitempty"`
+	// Locale is the locale of the container, set as LANG in its
+	// environment.  It is generated when the image has its definition but
+	// does not provide it compiled.
+	// Optional.
+	Locale string `json:"locale,omitempty"`
 	// DependencyContainers is an array of containers this container
 	// depends on. Dependency containers must be started before this
 	// container. Dependencies can be specified by name or full/partial ID.
@@ -313,6 +335,12 @@ type ContainerStorageConfig struct {
 	// DeviceCgroupRule are device cgroup rules that allow containers
 	// to use additional types of devices.
 	DeviceCgroupRule []spec.LinuxDeviceCgroup `json:"device_cgroup_rule,omitempty"`
+	// DeviceCgroupRulePaths are device cgroup rules giving the devices by
+	// a glob of their paths on the host, like "/dev/ttyUSB* rwm".  They
+	// allow access to the devices matching the glob when the container is
+	// created.
+	// Optional.
+	DeviceCgroupRulePaths []string `json:"device_cgroup_rule_paths,omitempty"`
 	// DevicesFrom specifies that this container will mount the device(s) from other container(s).
 	// Optional.
 	DevicesFrom []string `json:"devices_from,omitempty"`
@@ -362,6 +390,11 @@ type ContainerStorageConfig struct {
 	// container launch, using them directly from shared storage (like NFS).
 	// Optional.
 	SharedBaseLayers *bool `json:"shared_base_layers,omitempty"`
+	// StorageTransient keeps the bundle and the logs of the container
+	// under the run root, on tmpfs, makes its writable layer volatile and
+	// removes the container when the system reboots.
+	// Optional.
+	StorageTransient *bool `json:"storage_transient,omitempty"`
 }
 
 // ContainerSecurityConfig is a container's security features, including
@@ -435,6 +468,12 @@ type ContainerSecurityConfig struct {
 	// mount temporary file systems.
 	// Optional.
 	ReadWriteTmpfs *bool `json:"read_write_tmpfs,omitempty"`
+	// ReadWriteTmpfsOptions are mount options of the tmpfs mounted when
+	// ReadWriteTmpfs is set, keyed by their destination: /run, /tmp or
+	// /var/tmp.  The options of the "*" key apply to all of them.  The
+	// "off" option disables the tmpfs.
+	// Optional.
+	ReadWriteTmpfsOptions map[string][]string `json:"read_write_tmpfs_options,omitempty"`
 
 	// LabelNested indicates whether or not the container is allowed to
 	// run fully nested containers including SELinux labelling.
@@ -454,6 +493,12 @@ type ContainerSecurityConfig struct {
 	// that masking. If ALL is passed, all paths will be unmasked.
 	// Optional.
 	Unmask []string `json:"unmask,omitempty"`
+	// PodmanInPodman sets the container up to run Podman as a rootless
+	// user: /dev/fuse is added, the masked paths are unmasked so that the
+	// nested containers can mount /proc and /sys, and SELinux labeling
+	// is disabled unless SelinuxOpts are given.
+	// Optional.
+	PodmanInPodman *bool `json:"podman_in_podman,omitempty"`
 }
 
 // ContainerCgroupConfig contains configuration information about a container's
@@ -563,6 +608,15 @@ type ContainerNetworkConfig struct {
 	// NetworkOptions are additional options for each network
 	// Optional.
 	NetworkOptions map[string][]string `json:"network_options,omitempty"`
+	// TrackFlows enables the accounting of the connection tracking of the
+	// kernel for the connections of the container, which are shown by
+	// inspect.
+	// Optional.
+	TrackFlows bool `json:"track_flows,omitempty"`
+	// NetworkPolicy restricts the traffic of the network namespace of the
+	// container to the connections allowed by the policy.
+	// Optional.
+	NetworkPolicy *define.NetworkPolicy `json:"network_policy,omitempty"`
 }
 
 // ContainerResourceConfig contains information on container resource limits.
@@ -597,6 +651,16 @@ type ContainerResourceConfig struct {
 	// that are used to configure cgroup v2.
 	// Optional.
 	CgroupConf map[string]string `json:"unified,omitempty"`
+	// NUMAPolicy is the NUMA memory policy of the container processes.
+	// Must be one of default, local, bind, preferred or interleave. The
+	// bind, preferred and interleave policies use the memory nodes set in
+	// the cpuset mems of ResourceLimits.
+	// Optional.
+	NUMAPolicy string `json:"numa_policy,omitempty"`
+	// StatsHistoryInterval is the interval at which samples of the
+	// resource usage of the container are recorded in its stats history.
+	// Optional.
+	StatsHistoryInterval time.Duration `json:"stats_history_interval,omitempty"`
 }
 
 // ContainerHealthCheckConfig describes a container healthcheck with attributes
@@ -667,6 +731,10 @@ var (
 	ErrDuplicateDest = errors.New("duplicate mount destination")
 )
 
+// ReadWriteTmpfsDestinations are the destinations of the tmpfs mounted by
+// ReadWriteTmpfs, /run stands for the run directory of the image.
+var ReadWriteTmpfsDestinations = []string{"/run", "/tmp", "/var/tmp"}
+
 // NewSpecGenerator returns a SpecGenerator struct given one of two mandatory inputs
 func NewSpecGenerator(arg string, rootfs bool) *SpecGenerator {
 	csc := ContainerStorageConfig{}
@@ -3,6 +3,7 @@ package specgen
 import (
 	"testing"
 
+	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,3 +37,63 @@ func TestNewSpecGeneratorWithRootfs(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateNUMAPolicy(t *testing.T) {
+	tests := []struct {
+		policy  string
+		mems    string
+		wantErr bool
+	}{
+		{"", "", false},
+		{"default", "", false},
+		{"local", "", false},
+		{"bind", "0-1", false},
+		{"interleave", "0,2", false},
+		{"preferred", "1", false},
+		{"preferred", "0-1", true},
+		{"bind", "", true},
+		{"bind", "x", true},
+		{"unknown", "0", true},
+	}
+	for _, tt := range tests {
+		s := NewSpecGenerator("foo", false)
+		s.NUMAPolicy = tt.policy
+		if tt.mems != "" {
+			s.ResourceLimits = &spec.LinuxResources{CPU: &spec.LinuxCPU{Mems: tt.mems}}
+		}
+		err := s.validateNUMAPolicy()
+		if tt.wantErr {
+			assert.Error(t, err, "policy %q mems %q", tt.policy, tt.mems)
+		} else {
+			assert.NoError(t, err, "policy %q mems %q", tt.policy, tt.mems)
+		}
+	}
+}
+
+func TestValidatePodmanInPodman(t *testing.T) {
+	localTrue := true
+	tests := []struct {
+		name    string
+		setup   func(s *SpecGenerator)
+		wantErr string
+	}{
+		{"default", func(*SpecGenerator) {}, ""},
+		{"drop other capabilities", func(s *SpecGenerator) { s.CapDrop = []string{"NET_RAW", "CAP_MKNOD"} }, ""},
+		{"privileged", func(s *SpecGenerator) { s.Privileged = &localTrue }, "mutually exclusive"},
+		{"no new privileges", func(s *SpecGenerator) { s.NoNewPrivileges = &localTrue }, "mutually exclusive"},
+		{"drop all", func(s *SpecGenerator) { s.CapDrop = []string{"all"} }, "cannot drop capability all"},
+		{"drop setuid", func(s *SpecGenerator) { s.CapDrop = []string{"CAP_SETUID"} }, "cannot drop capability CAP_SETUID"},
+		{"drop setgid", func(s *SpecGenerator) { s.CapDrop = []string{"setgid"} }, "cannot drop capability setgid"},
+	}
+	for _, tt := range tests {
+		s := NewSpecGenerator("foo", false)
+		s.PodmanInPodman = &localTrue
+		tt.setup(s)
+		err := s.validatePodmanInPodman()
+		if tt.wantErr == "" {
+			assert.NoError(t, err, tt.name)
+		} else {
+			assert.ErrorContains(t, err, tt.wantErr, tt.name)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 
 	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/util"
 	"go.podman.io/common/pkg/config"
 )
 
@@ -15,9 +16,15 @@ func validate(c *entities.ContainerCreateOptions) error {
 		return errors.New(`the --rm option conflicts with --restart, when the restartPolicy is not "" and "no"`)
 	}
 
-	if _, err := config.ParsePullPolicy(c.Pull); err != nil {
+	_, withMaxAge, err := util.ParsePullPolicy(c.Pull)
+	if err != nil {
 		return err
 	}
+	if withMaxAge {
+		if _, err := util.ParsePullMaxAge(c.PullMaxAge); err != nil {
+			return err
+		}
+	}
 
 	return config.ValidateImageVolumeMode(c.ImageVolume)
 }
@@ -130,6 +130,13 @@ func getIOLimits(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions) (
 		hasLimits = true
 	}
 
+	if len(c.IOMax) > 0 {
+		if err := parseIOMaxDevices(s, c.IOMax); err != nil {
+			return nil, err
+		}
+		hasLimits = true
+	}
+
 	if !hasLimits {
 		return nil, nil
 	}
@@ -194,6 +201,19 @@ func getMemoryLimits(c *entities.ContainerCreateOptions) (*specs.LinuxMemory, er
 	return memory, nil
 }
 
+// parseMemoryHigh converts the value of --memory-high to the value written
+// to the memory.high cgroup v2 file, in bytes or "max".
+func parseMemoryHigh(value string) (string, error) {
+	if value == "max" {
+		return value, nil
+	}
+	high, err := units.RAMInBytes(value)
+	if err != nil || high <= 0 {
+		return "", fmt.Errorf("invalid value %q for memory-high, must be a positive size or max", value)
+	}
+	return strconv.FormatInt(high, 10), nil
+}
+
 func setNamespaces(rtc *config.Config, s *specgen.SpecGenerator, c *entities.ContainerCreateOptions) error {
 	var err error
 
@@ -322,6 +342,23 @@ func currentLabelOpts() ([]string, error) {
 	}, nil
 }
 
+// parseLocale validates the locale of --locale, resolving "host" to the
+// locale of the environment.
+func parseLocale(locale string) (string, error) {
+	if locale == "host" {
+		for _, name := range []string{"LC_ALL", "LANG"} {
+			if value := os.Getenv(name); value != "" {
+				return value, nil
+			}
+		}
+		return "", errors.New("--locale=host requires LC_ALL or LANG to be set")
+	}
+	if locale == "" || strings.ContainsAny(locale, "/ ") || strings.HasPrefix(locale, ".") {
+		return "", fmt.Errorf("invalid locale %q", locale)
+	}
+	return locale, nil
+}
+
 func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions, args []string) error {
 	rtc, err := config.Default()
 	if err != nil {
@@ -471,7 +508,7 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 
 	// env-file overrides any previous variables
 	for _, f := range c.EnvFile {
-		fileEnv, err := envLib.ParseFile(f)
+		fileEnv, err := envLib.ParseSource(f)
 		if err != nil {
 			return err
 		}
@@ -686,6 +723,16 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	// (user specifying --read-only-tmpfs=false.)
 	localRWTmpfs := c.ReadOnly && c.ReadWriteTmpFS
 	s.ReadWriteTmpfs = &localRWTmpfs
+	if c.ReadWriteTmpFSSize != "" || len(c.ReadWriteTmpFSOpts) > 0 {
+		if !localRWTmpfs {
+			return errors.New("--read-only-tmpfs-size and --read-only-tmpfs-opt require --read-only and --read-only-tmpfs")
+		}
+		rwTmpfsOptions, err := parseReadWriteTmpfsOptions(c.ReadWriteTmpFSSize, c.ReadWriteTmpFSOpts)
+		if err != nil {
+			return err
+		}
+		s.ReadWriteTmpfsOptions = rwTmpfsOptions
+	}
 
 	//  TODO convert to map?
 	// check if key=value and convert
@@ -824,11 +871,19 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	}
 
 	for _, rule := range c.DeviceCgroupRule {
-		dev, err := parseLinuxResourcesDeviceAccess(rule)
+		if util.IsDeviceCgroupPathRule(rule) {
+			// The devices are looked up on the host of the container.
+			if _, _, err := util.ParseDeviceCgroupPathRule(rule); err != nil {
+				return err
+			}
+			s.DeviceCgroupRulePaths = append(s.DeviceCgroupRulePaths, rule)
+			continue
+		}
+		rules, err := util.ParseDeviceCgroupRule(rule)
 		if err != nil {
 			return err
 		}
-		s.DeviceCgroupRule = append(s.DeviceCgroupRule, dev)
+		s.DeviceCgroupRule = append(s.DeviceCgroupRule, rules...)
 	}
 
 	if s.Init == nil {
@@ -840,6 +895,12 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	if s.SharedBaseLayers == nil {
 		s.SharedBaseLayers = &c.SharedBaseLayers
 	}
+	if s.StorageTransient == nil {
+		s.StorageTransient = &c.StorageTransient
+	}
+	if s.PodmanInPodman == nil {
+		s.PodmanInPodman = &c.PodmanInPodman
+	}
 	if s.Stdin == nil {
 		s.Stdin = &c.Interactive
 	}
@@ -892,6 +953,9 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	if s.PreserveFDs == 0 || c.PreserveFDs != 0 {
 		s.PreserveFDs = c.PreserveFDs
 	}
+	if c.SocketActivation {
+		s.SocketActivation = true
+	}
 	if s.PreserveFD == nil || c.PreserveFD != nil {
 		s.PreserveFD = c.PreserveFD
 	}
@@ -915,6 +979,10 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 		}
 	}
 
+	if c.NUMAPolicy != "" {
+		s.NUMAPolicy = c.NUMAPolicy
+	}
+
 	if c.Personality != "" {
 		s.Personality = &specs.LinuxPersonality{}
 		s.Personality.Domain = specs.LinuxPersonalityDomain(c.Personality)
@@ -932,6 +1000,34 @@ func FillOutSpecGen(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions
 	if len(s.Timezone) == 0 || len(c.Timezone) != 0 {
 		s.Timezone = c.Timezone
 	}
+	if c.TZFromHost {
+		if c.Timezone != "" {
+			return errors.New("--tz and --tz-from-host are mutually exclusive")
+		}
+		s.Timezone = define.TimezoneHost
+	}
+	if c.Monitor != "" {
+		s.Monitor = c.Monitor
+	}
+	if c.RuntimeClass != "" {
+		s.RuntimeClass = c.RuntimeClass
+	}
+	if c.TrackFlows {
+		s.TrackFlows = c.TrackFlows
+	}
+	if c.StatsHistory != "" {
+		interval, err := time.ParseDuration(c.StatsHistory)
+		if err != nil {
+			return fmt.Errorf("invalid stats history interval %q: %w", c.StatsHistory, err)
+		}
+		s.StatsHistoryInterval = interval
+	}
+	if c.Locale != "" {
+		s.Locale, err = parseLocale(c.Locale)
+		if err != nil {
+			return err
+		}
+	}
 	if len(s.Umask) == 0 || len(c.Umask) != 0 {
 		s.Umask = c.Umask
 	}
@@ -1114,6 +1210,65 @@ func parseThrottleIOPsDevices(iopsDevices []string) (map[string]specs.LinuxThrot
 	return td, nil
 }
 
+// parseIOMaxDevices parses limits in the format of the io.max cgroup v2
+// file, e.g. /dev/sda:rbps=1mb,wiops=100, into the throttle devices of the
+// spec. The limits override limits for the same device set with the
+// --device-{read,write}-{bps,iops} options. A limit of "max" removes it.
+func parseIOMaxDevices(s *specgen.SpecGenerator, ioMax []string) error {
+	for _, dev := range ioMax {
+		key, val, hasVal := strings.Cut(dev, ":")
+		if !hasVal || val == "" {
+			return fmt.Errorf("bad format: %s", dev)
+		}
+		if !strings.HasPrefix(key, "/dev/") {
+			return fmt.Errorf("bad format for device path: %s", dev)
+		}
+		for limit := range strings.SplitSeq(val, ",") {
+			name, value, hasValue := strings.Cut(limit, "=")
+			if !hasValue {
+				return fmt.Errorf("invalid limit %q for device %s, the correct format is <limit>=<rate>", limit, key)
+			}
+			var devices *map[string]specs.LinuxThrottleDevice
+			switch name {
+			case "rbps":
+				devices = &s.ThrottleReadBpsDevice
+			case "wbps":
+				devices = &s.ThrottleWriteBpsDevice
+			case "riops":
+				devices = &s.ThrottleReadIOPSDevice
+			case "wiops":
+				devices = &s.ThrottleWriteIOPSDevice
+			default:
+				return fmt.Errorf("unknown limit %q for device %s, supported limits are rbps, wbps, riops and wiops", name, key)
+			}
+			if value == "max" {
+				delete(*devices, key)
+				continue
+			}
+
+			var rate uint64
+			if strings.HasSuffix(name, "bps") {
+				bytes, err := units.RAMInBytes(value)
+				if err != nil || bytes < 0 {
+					return fmt.Errorf("invalid rate %q for %s of device %s. The correct format is <number>[<unit>]. Number must be a positive integer. Unit is optional and can be kb, mb, or gb", value, name, key)
+				}
+				rate = uint64(bytes)
+			} else {
+				iops, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid rate %q for %s of device %s. Number must be a positive integer", value, name, key)
+				}
+				rate = iops
+			}
+			if *devices == nil {
+				*devices = make(map[string]specs.LinuxThrottleDevice)
+			}
+			(*devices)[key] = specs.LinuxThrottleDevice{Rate: rate}
+		}
+	}
+	return nil
+}
+
 func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error) {
 	secretParseError := errors.New("parsing secret")
 	var mount []specgen.Secret
@@ -1221,65 +1376,6 @@ func parseSecrets(secrets []string) ([]specgen.Secret, map[string]string, error)
 	return mount, envs, nil
 }
 
-var cgroupDeviceType = map[string]bool{
-	"a": true, // all
-	"b": true, // block device
-	"c": true, // character device
-}
-
-var cgroupDeviceAccess = map[string]bool{
-	"r": true, // read
-	"w": true, // write
-	"m": true, // mknod
-}
-
-// parseLinuxResourcesDeviceAccess parses the raw string passed with the --device-access-add flag
-func parseLinuxResourcesDeviceAccess(device string) (specs.LinuxDeviceCgroup, error) {
-	var devType, access string
-	var major, minor *int64
-
-	value := strings.Split(device, " ")
-	if len(value) != 3 {
-		return specs.LinuxDeviceCgroup{}, fmt.Errorf("invalid device cgroup rule requires type, major:Minor, and access rules: %q", device)
-	}
-
-	devType = value[0]
-	if !cgroupDeviceType[devType] {
-		return specs.LinuxDeviceCgroup{}, fmt.Errorf("invalid device type in device-access-add: %s", devType)
-	}
-
-	majorNumber, minorNumber, hasMinor := strings.Cut(value[1], ":")
-	if majorNumber != "*" {
-		i, err := strconv.ParseUint(majorNumber, 10, 64)
-		if err != nil {
-			return specs.LinuxDeviceCgroup{}, err
-		}
-		m := int64(i)
-		major = &m
-	}
-	if hasMinor && minorNumber != "*" {
-		i, err := strconv.ParseUint(minorNumber, 10, 64)
-		if err != nil {
-			return specs.LinuxDeviceCgroup{}, err
-		}
-		m := int64(i)
-		minor = &m
-	}
-	access = value[2]
-	for c := range strings.SplitSeq(access, "") {
-		if !cgroupDeviceAccess[c] {
-			return specs.LinuxDeviceCgroup{}, fmt.Errorf("invalid device access in device-access-add: %s", c)
-		}
-	}
-	return specs.LinuxDeviceCgroup{
-		Allow:  true,
-		Type:   devType,
-		Major:  major,
-		Minor:  minor,
-		Access: access,
-	}, nil
-}
-
 func GetResources(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions) (*specs.LinuxResources, error) {
 	var err error
 	if s.ResourceLimits.Memory == nil || (len(c.Memory) != 0 || len(c.MemoryReservation) != 0 || len(c.MemorySwap) != 0 || c.MemorySwappiness != 0) {
@@ -1288,7 +1384,7 @@ func GetResources(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions)
 			return nil, err
 		}
 	}
-	if s.ResourceLimits.BlockIO == nil || (len(c.BlkIOWeight) != 0 || len(c.BlkIOWeightDevice) != 0 || len(c.DeviceReadBPs) != 0 || len(c.DeviceWriteBPs) != 0) {
+	if s.ResourceLimits.BlockIO == nil || (len(c.BlkIOWeight) != 0 || len(c.BlkIOWeightDevice) != 0 || len(c.DeviceReadBPs) != 0 || len(c.DeviceWriteBPs) != 0 || len(c.IOMax) != 0) {
 		s.ResourceLimits.BlockIO, err = getIOLimits(s, c)
 		if err != nil {
 			return nil, err
@@ -1314,6 +1410,13 @@ func GetResources(s *specgen.SpecGenerator, c *entities.ContainerCreateOptions)
 		}
 		unifieds[key] = val
 	}
+	if c.MemoryHigh != "" {
+		high, err := parseMemoryHigh(c.MemoryHigh)
+		if err != nil {
+			return nil, err
+		}
+		unifieds["memory.high"] = high
+	}
 	if len(unifieds) > 0 {
 		s.ResourceLimits.Unified = unifieds
 	}
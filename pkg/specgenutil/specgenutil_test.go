@@ -78,85 +78,6 @@ func TestWinPath(t *testing.T) {
 	}
 }
 
-func TestParseLinuxResourcesDeviceAccess(t *testing.T) {
-	d, err := parseLinuxResourcesDeviceAccess("a *:* rwm")
-	assert.NoError(t, err, "err is nil")
-	assert.True(t, d.Allow, "allow is true")
-	assert.Equal(t, d.Type, "a", "type is 'a'")
-	assert.Nil(t, d.Minor, "minor is nil")
-	assert.Nil(t, d.Major, "major is nil")
-
-	d, err = parseLinuxResourcesDeviceAccess("b 3:* rwm")
-	assert.NoError(t, err, "err is nil")
-	assert.True(t, d.Allow, "allow is true")
-	assert.Equal(t, d.Type, "b", "type is 'b'")
-	assert.Nil(t, d.Minor, "minor is nil")
-	assert.NotNil(t, d.Major, "major is not nil")
-	assert.Equal(t, *d.Major, int64(3), "major is 3")
-
-	d, err = parseLinuxResourcesDeviceAccess("a *:3 rwm")
-	assert.NoError(t, err, "err is nil")
-	assert.True(t, d.Allow, "allow is true")
-	assert.Equal(t, d.Type, "a", "type is 'a'")
-	assert.Nil(t, d.Major, "major is nil")
-	assert.NotNil(t, d.Minor, "minor is not nil")
-	assert.Equal(t, *d.Minor, int64(3), "minor is 3")
-
-	d, err = parseLinuxResourcesDeviceAccess("c 1:2 rwm")
-	assert.NoError(t, err, "err is nil")
-	assert.True(t, d.Allow, "allow is true")
-	assert.Equal(t, d.Type, "c", "type is 'c'")
-	assert.NotNil(t, d.Major, "minor is not nil")
-	assert.Equal(t, *d.Major, int64(1), "minor is 1")
-	assert.NotNil(t, d.Minor, "minor is not nil")
-	assert.Equal(t, *d.Minor, int64(2), "minor is 2")
-
-	_, err = parseLinuxResourcesDeviceAccess("q *:* rwm")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("a a:* rwm")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("a *:a rwm")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("a *:* abc")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("* *:* *")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("* *:a2 *")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("*")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("*:*")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("a *:*")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("a *:*")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("a 12a:* r")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("a a12:* r")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("a 0x1:* r")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("a -2:* r")
-	assert.NotNil(t, err, "err is not nil")
-
-	_, err = parseLinuxResourcesDeviceAccess("a *:-3 r")
-	assert.NotNil(t, err, "err is not nil")
-}
-
 func TestGenRlimits(t *testing.T) {
 	testLimits := map[string]string{
 		"core":       "1:2",
@@ -230,3 +151,49 @@ func TestFillOutSpecGenRecorsUserNs(t *testing.T) {
 	assert.True(t, ok, "UserNsAnnotation is set")
 	assert.Equal(t, "keep-id", v, "UserNsAnnotation is keep-id")
 }
+
+func TestParseIOMaxDevices(t *testing.T) {
+	s := specgen.NewSpecGenerator("", false)
+	err := parseIOMaxDevices(s, []string{"/dev/sda:rbps=1mb,wbps=max,riops=100", "/dev/sdb:wiops=50"})
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1024*1024), s.ThrottleReadBpsDevice["/dev/sda"].Rate)
+	assert.NotContains(t, s.ThrottleWriteBpsDevice, "/dev/sda")
+	assert.Equal(t, uint64(100), s.ThrottleReadIOPSDevice["/dev/sda"].Rate)
+	assert.Equal(t, uint64(50), s.ThrottleWriteIOPSDevice["/dev/sdb"].Rate)
+
+	for _, ioMax := range []string{"/dev/sda", "sda:rbps=1", "/dev/sda:rbps", "/dev/sda:foo=1", "/dev/sda:riops=1mb", "/dev/sda:rbps=-1"} {
+		err := parseIOMaxDevices(specgen.NewSpecGenerator("", false), []string{ioMax})
+		assert.Error(t, err, ioMax)
+	}
+}
+
+func TestParseMemoryHigh(t *testing.T) {
+	high, err := parseMemoryHigh("100m")
+	assert.NoError(t, err)
+	assert.Equal(t, "104857600", high)
+	high, err = parseMemoryHigh("max")
+	assert.NoError(t, err)
+	assert.Equal(t, "max", high)
+
+	for _, value := range []string{"", "0", "-1m", "lots"} {
+		_, err := parseMemoryHigh(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	locale, err := parseLocale("de_DE.UTF-8")
+	assert.NoError(t, err)
+	assert.Equal(t, "de_DE.UTF-8", locale)
+
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LANG", "fr_FR.UTF-8")
+	locale, err = parseLocale("host")
+	assert.NoError(t, err)
+	assert.Equal(t, "fr_FR.UTF-8", locale)
+
+	for _, value := range []string{"", "../etc", "de_DE/x", "de DE"} {
+		_, err := parseLocale(value)
+		assert.Error(t, err, value)
+	}
+}
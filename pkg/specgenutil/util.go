@@ -189,9 +189,10 @@ func parseSplitPort(hostIP, hostPort *string, ctrPort string, protocol *string)
 		}
 	}
 	if hostPort != nil {
-		if *hostPort == "" {
+		if *hostPort == "" || *hostPort == "0" {
 			// Set 0 as a placeholder. The server side of Specgen
 			// will find a random, open, unused port to use.
+			// An explicit 0 (-p 0:80) is accepted like Docker does.
 			newPort.HostPort = 0
 		} else {
 			hostStart, hostLen, err := parseAndValidateRange(*hostPort)
@@ -267,7 +268,36 @@ func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *conf
 	// user of the API.
 	// As such, provide a way to specify a path to Podman, so we can
 	// still invoke a cleanup process.
+	command, err := CreateGlobalCommandArgs(storageConfig, config, syslog)
+	if err != nil {
+		return nil, err
+	}
+
+	// --stopped-only is used to ensure we only cleanup stopped containers and do not race
+	// against other processes that did a cleanup() + init() again before we had the chance to run
+	command = append(command, []string{"container", "cleanup", "--stopped-only"}...)
+
+	if rm {
+		command = append(command, "--rm")
+	}
+
+	if rmi {
+		command = append(command, "--rmi")
+	}
+
+	// This has to be absolutely last, to ensure that the exec session ID
+	// will be added after it by Libpod.
+	if exec {
+		command = append(command, "--exec")
+	}
 
+	return command, nil
+}
+
+// CreateGlobalCommandArgs returns the path to this podman binary followed by
+// the global options which make another podman process use the same storage,
+// runtime and configuration as the current one.
+func CreateGlobalCommandArgs(storageConfig storageTypes.StoreOptions, config *config.Config, syslog bool) ([]string, error) {
 	podmanPath, err := os.Executable()
 	if err != nil {
 		return nil, err
@@ -314,23 +344,5 @@ func CreateExitCommandArgs(storageConfig storageTypes.StoreOptions, config *conf
 		command = append(command, "--module", module)
 	}
 
-	// --stopped-only is used to ensure we only cleanup stopped containers and do not race
-	// against other processes that did a cleanup() + init() again before we had the chance to run
-	command = append(command, []string{"container", "cleanup", "--stopped-only"}...)
-
-	if rm {
-		command = append(command, "--rm")
-	}
-
-	if rmi {
-		command = append(command, "--rmi")
-	}
-
-	// This has to be absolutely last, to ensure that the exec session ID
-	// will be added after it by Libpod.
-	if exec {
-		command = append(command, "--exec")
-	}
-
 	return command, nil
 }
@@ -3,6 +3,10 @@ package specgenutil
 import (
 	"reflect"
 	"testing"
+
+	"go.podman.io/common/libnetwork/types"
+	"go.podman.io/common/pkg/config"
+	storageTypes "go.podman.io/storage/types"
 )
 
 func TestCreateExpose(t *testing.T) {
@@ -144,3 +148,64 @@ func Test_parseAndValidatePort(t *testing.T) {
 		})
 	}
 }
+
+func TestCreatePortBindingsDynamicHostPort(t *testing.T) {
+	tests := []struct {
+		name    string
+		port    string
+		want    types.PortMapping
+		wantErr bool
+	}{
+		{
+			name: "empty host port",
+			port: "127.0.0.1::80",
+			want: types.PortMapping{HostIP: "127.0.0.1", ContainerPort: 80, Range: 1},
+		},
+		{
+			name: "zero host port",
+			port: "0:80",
+			want: types.PortMapping{ContainerPort: 80, Range: 1},
+		},
+		{
+			name: "zero host port with range",
+			port: "0:80-82/udp",
+			want: types.PortMapping{ContainerPort: 80, Range: 3, Protocol: "udp"},
+		},
+		{
+			name:    "zero as start of host range should fail",
+			port:    "0-2:80-82",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CreatePortBindings([]string{tt.port})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CreatePortBindings() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if len(got) != 1 || !reflect.DeepEqual(got[0], tt.want) {
+				t.Errorf("CreatePortBindings() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateExitCommandArgsGlobalPrefix(t *testing.T) {
+	storageConfig := storageTypes.StoreOptions{GraphRoot: "/graph", RunRoot: "/run/graph"}
+	conf := &config.Config{}
+	global, err := CreateGlobalCommandArgs(storageConfig, conf, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exit, err := CreateExitCommandArgs(storageConfig, conf, true, true, false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := append(global, "container", "cleanup", "--stopped-only", "--rm")
+	if !reflect.DeepEqual(exit, want) {
+		t.Errorf("CreateExitCommandArgs() got = %v, want %v", exit, want)
+	}
+}
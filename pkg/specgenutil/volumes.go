@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/dmikushin/podman-shared/libpod/define"
@@ -12,6 +13,7 @@ import (
 	"github.com/dmikushin/podman-shared/pkg/specgen"
 	"github.com/dmikushin/podman-shared/pkg/specgenutilexternal"
 	"github.com/dmikushin/podman-shared/pkg/util"
+	"github.com/docker/go-units"
 	spec "github.com/opencontainers/runtime-spec/specs-go"
 	"go.podman.io/common/pkg/config"
 	"go.podman.io/common/pkg/parse"
@@ -796,6 +798,31 @@ func getTmpfsMounts(tmpfsFlag []string) (map[string]spec.Mount, error) {
 	return m, nil
 }
 
+// parseReadWriteTmpfsOptions parses the --read-only-tmpfs-size and
+// --read-only-tmpfs-opt flags into the options of the tmpfs mounted by
+// --read-only-tmpfs
+func parseReadWriteTmpfsOptions(size string, opts []string) (map[string][]string, error) {
+	options := make(map[string][]string)
+	if size != "" {
+		if _, err := units.RAMInBytes(size); err != nil {
+			return nil, fmt.Errorf("invalid --read-only-tmpfs-size %q: %w", size, err)
+		}
+		options["*"] = []string{"size=" + size}
+	}
+	for _, opt := range opts {
+		dest, mountOpts, ok := strings.Cut(opt, ":")
+		if !ok || mountOpts == "" {
+			return nil, fmt.Errorf("invalid --read-only-tmpfs-opt %q: must be in the form PATH:OPTIONS", opt)
+		}
+		dest = unixPathClean(dest)
+		if !slices.Contains(specgen.ReadWriteTmpfsDestinations, dest) {
+			return nil, fmt.Errorf("invalid --read-only-tmpfs-opt %q: PATH must be one of %s", opt, strings.Join(specgen.ReadWriteTmpfsDestinations, ", "))
+		}
+		options[dest] = append(options[dest], strings.Split(mountOpts, ",")...)
+	}
+	return options, nil
+}
+
 // validChownFlag ensures that the U or chown flag is correctly used
 func validChownFlag(value string) (bool, error) {
 	// U=[true|false]
@@ -1,6 +1,9 @@
 package specgenutil
 
-import "testing"
+import (
+	"reflect"
+	"testing"
+)
 
 func Test_validChownFlag(t *testing.T) {
 	type args struct {
@@ -66,3 +69,32 @@ func Test_validChownFlag(t *testing.T) {
 		})
 	}
 }
+
+func Test_parseReadWriteTmpfsOptions(t *testing.T) {
+	options, err := parseReadWriteTmpfsOptions("64m", []string{"/tmp:size=1g,noexec", "/var/tmp/:off", "/tmp:mode=1777"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]string{
+		"*":        {"size=64m"},
+		"/tmp":     {"size=1g", "noexec", "mode=1777"},
+		"/var/tmp": {"off"},
+	}
+	if !reflect.DeepEqual(options, want) {
+		t.Errorf("parseReadWriteTmpfsOptions() = %v, want %v", options, want)
+	}
+
+	for _, tt := range []struct {
+		size string
+		opts []string
+	}{
+		{size: "lots"},
+		{opts: []string{"/tmp"}},
+		{opts: []string{"/tmp:"}},
+		{opts: []string{"/home:size=1m"}},
+	} {
+		if _, err := parseReadWriteTmpfsOptions(tt.size, tt.opts); err == nil {
+			t.Errorf("parseReadWriteTmpfsOptions(%q, %q) did not fail", tt.size, tt.opts)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+// Package subid reads and extends the /etc/subuid and /etc/subgid files
+// assigning subordinate ID ranges to users.
+package subid
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.podman.io/storage/pkg/ioutils"
+)
+
+const (
+	// UIDFile lists the subordinate UIDs of the users.
+	UIDFile = "/etc/subuid"
+	// GIDFile lists the subordinate GIDs of the users.
+	GIDFile = "/etc/subgid"
+
+	// firstID is the lowest ID new ranges are allocated from, like
+	// SUB_UID_MIN of login.defs.
+	firstID = 100000
+	// alignment of the new ranges.
+	alignment = 65536
+	maxID     = 1<<32 - 1
+)
+
+// Range is a range of subordinate IDs.
+type Range struct {
+	Start int `json:"start"`
+	Size  int `json:"size"`
+}
+
+// End returns the ID following the range.
+func (r Range) End() int {
+	return r.Start + r.Size
+}
+
+// Entry is a line of a subid file.
+type Entry struct {
+	// Name is the user or group name, or the numeric ID.
+	Name string
+	Range
+}
+
+// ReadFile returns the entries of the subid file at path, which may not
+// exist.
+func ReadFile(path string) ([]Entry, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parse(content, path)
+}
+
+func parse(content []byte, path string) ([]Entry, error) {
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Split(text, ":")
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected NAME:START:COUNT, got %q", path, line, text)
+		}
+		start, err := strconv.ParseUint(fields[1], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid start %q: %w", path, line, fields[1], err)
+		}
+		size, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid count %q: %w", path, line, fields[2], err)
+		}
+		entries = append(entries, Entry{Name: fields[0], Range: Range{Start: int(start), Size: int(size)}})
+	}
+	return entries, scanner.Err()
+}
+
+// Ranges returns the ranges of the entries of the user or group, given by
+// name and numeric ID.
+func Ranges(entries []Entry, name, id string) []Range {
+	ranges := []Range{}
+	for _, entry := range entries {
+		if entry.Name == name || (id != "" && entry.Name == id) {
+			ranges = append(ranges, entry.Range)
+		}
+	}
+	return ranges
+}
+
+// NextRange returns a range of size IDs following the ranges of all the
+// entries, so that it overlaps none of them.
+func NextRange(size int, entries ...[]Entry) (Range, error) {
+	if size <= 0 {
+		return Range{}, fmt.Errorf("invalid size %d, must be positive", size)
+	}
+	start := firstID
+	for _, list := range entries {
+		for _, entry := range list {
+			start = max(start, entry.End())
+		}
+	}
+	start = (start + alignment - 1) / alignment * alignment
+	if int64(start)+int64(size) > maxID {
+		return Range{}, fmt.Errorf("no range of %d IDs is left above %d", size, start)
+	}
+	return Range{Start: start, Size: size}, nil
+}
+
+// Append adds the range to the user or group name in the subid file at
+// path.
+func Append(path, name string, r Range) error {
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	perm := os.FileMode(0o644)
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if len(content) > 0 && !bytes.HasSuffix(content, []byte("\n")) {
+		content = append(content, '\n')
+	}
+	content = fmt.Appendf(content, "%s:%d:%d\n", name, r.Start, r.Size)
+	return ioutils.AtomicWriteFile(path, content, perm)
+}
+
+// Count returns the number of IDs of the ranges, counting the IDs of
+// overlapping ranges once.
+func Count(ranges []Range) int {
+	sorted := slices.Clone(ranges)
+	slices.SortFunc(sorted, func(a, b Range) int {
+		return a.Start - b.Start
+	})
+	count, end := 0, 0
+	for _, r := range sorted {
+		start := max(r.Start, end)
+		if r.End() > start {
+			count += r.End() - start
+			end = r.End()
+		}
+	}
+	return count
+}
+
+// Intersect returns the parts of the ranges that are in the pool.
+func Intersect(pool, ranges []Range) []Range {
+	var result []Range
+	for _, p := range pool {
+		for _, r := range ranges {
+			start, end := max(p.Start, r.Start), min(p.End(), r.End())
+			if end > start {
+				result = append(result, Range{Start: start, Size: end - start})
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package subid
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	entries, err := parse([]byte("# comment\nalice:100000:65536\n\n1001:165536:65536\ncontainers:2147483647:2147483648\n"), "subuid")
+	require.NoError(t, err)
+	assert.Equal(t, []Entry{
+		{Name: "alice", Range: Range{Start: 100000, Size: 65536}},
+		{Name: "1001", Range: Range{Start: 165536, Size: 65536}},
+		{Name: "containers", Range: Range{Start: 2147483647, Size: 2147483648}},
+	}, entries)
+	assert.Equal(t, []Range{{Start: 165536, Size: 65536}}, Ranges(entries, "bob", "1001"))
+
+	_, err = parse([]byte("alice:100000\n"), "subuid")
+	assert.ErrorContains(t, err, "subuid:1: expected NAME:START:COUNT")
+	_, err = parse([]byte("alice:-1:10\n"), "subuid")
+	assert.ErrorContains(t, err, "invalid start")
+}
+
+func TestNextRange(t *testing.T) {
+	r, err := NextRange(65536)
+	require.NoError(t, err)
+	assert.Equal(t, Range{Start: 131072, Size: 65536}, r)
+
+	uids := []Entry{{Name: "alice", Range: Range{Start: 100000, Size: 65536}}}
+	gids := []Entry{{Name: "alice", Range: Range{Start: 100000, Size: 300000}}}
+	r, err = NextRange(1000, uids, gids)
+	require.NoError(t, err)
+	assert.Equal(t, Range{Start: 458752, Size: 1000}, r)
+
+	_, err = NextRange(0)
+	assert.Error(t, err)
+	_, err = NextRange(65536, []Entry{{Name: "containers", Range: Range{Start: 1 << 31, Size: 1<<31 - 65536}}})
+	assert.ErrorContains(t, err, "no range of 65536 IDs is left")
+}
+
+func TestAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "subuid")
+	require.NoError(t, os.WriteFile(path, []byte("alice:100000:65536"), 0o600))
+	require.NoError(t, Append(path, "containers", Range{Start: 196608, Size: 1000}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "alice:100000:65536\ncontainers:196608:1000\n", string(content))
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	entries, err := ReadFile(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestCount(t *testing.T) {
+	pool := []Range{{Start: 100, Size: 100}, {Start: 1000, Size: 50}}
+	used := []Range{{Start: 150, Size: 100}, {Start: 120, Size: 40}, {Start: 0, Size: 10}, {Start: 1040, Size: 100}}
+	assert.Equal(t, 150, Count(pool))
+	// 120-199 and 1040-1049
+	assert.Equal(t, 90, Count(Intersect(pool, used)))
+}
@@ -0,0 +1,514 @@
+//go:build !remote
+
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/dmikushin/podman-shared/libpod"
+	libpodDefine "github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/dmikushin/podman-shared/pkg/systemd/parser"
+	"github.com/dmikushin/podman-shared/pkg/systemd/quadlet"
+	"github.com/dmikushin/podman-shared/version"
+	"go.podman.io/common/libnetwork/types"
+)
+
+// quadletImageConfig holds the image defaults of a container. Settings equal
+// to them are omitted from the generated .container unit.
+type quadletImageConfig struct {
+	Env        []string
+	Cmd        []string
+	Entrypoint []string
+	Labels     map[string]string
+	User       string
+	WorkingDir string
+}
+
+// quadletContainerInfo contains the data required for generating a
+// container's Quadlet unit file.
+type quadletContainerInfo struct {
+	Data  *libpodDefine.InspectContainerData
+	Image quadletImageConfig
+	// DefaultEnv is the environment added by containers.conf.
+	DefaultEnv []string
+	// DefaultNetwork is the name of the default network which does not
+	// need a .network unit.
+	DefaultNetwork string
+	// PodUnit is the .pod unit of the pod the container is part of.
+	PodUnit string
+	// AnonymousVolumes lists the names of anonymous volumes which are
+	// recreated without a .volume unit.
+	AnonymousVolumes []string
+}
+
+// ContainerQuadlets generates a Quadlet .container unit recreating the
+// specified container along with .volume and .network units for the named
+// volumes and networks it uses. The returned map is keyed by file name.
+func ContainerQuadlets(ctx context.Context, ctr *libpod.Container, options entities.GenerateQuadletOptions) (map[string]string, error) {
+	if ctr.IsInfra() {
+		return nil, fmt.Errorf("%s is an infra container, generate the units for its pod instead", ctr.ID())
+	}
+	if ctr.PodID() != "" {
+		return nil, fmt.Errorf("container %s is part of a pod, generate the units for its pod instead", ctr.ID())
+	}
+	units := make(map[string]string)
+	if err := addContainerQuadlets(ctx, ctr, "", units, options); err != nil {
+		return nil, err
+	}
+	return units, nil
+}
+
+// PodQuadlets generates a Quadlet .pod unit recreating the specified pod,
+// .container units for its containers and .volume and .network units for the
+// named volumes and networks they use. The returned map is keyed by file name.
+func PodQuadlets(ctx context.Context, pod *libpod.Pod, options entities.GenerateQuadletOptions) (map[string]string, error) {
+	ctrs, err := pod.AllContainers()
+	if err != nil {
+		return nil, err
+	}
+
+	podUnit := pod.Name() + ".pod"
+	var infraData *libpodDefine.InspectContainerData
+	defaultNetwork := ""
+	units := make(map[string]string)
+	for _, ctr := range ctrs {
+		if !ctr.IsInfra() {
+			if err := addContainerQuadlets(ctx, ctr, podUnit, units, options); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		infraData, err = ctr.Inspect(false)
+		if err != nil {
+			return nil, err
+		}
+		rtc, err := ctr.Runtime().GetConfigNoCopy()
+		if err != nil {
+			return nil, err
+		}
+		defaultNetwork = rtc.Network.DefaultNetwork
+		if err := addNetworkQuadlets(ctr.Runtime(), infraData, defaultNetwork, units, options); err != nil {
+			return nil, err
+		}
+	}
+
+	content, err := podQuadlet(pod.Name(), pod.Labels(), infraData, defaultNetwork, options)
+	if err != nil {
+		return nil, err
+	}
+	units[podUnit] = content
+	return units, nil
+}
+
+// addContainerQuadlets adds the units of the container and its volumes and
+// networks to units.
+func addContainerQuadlets(ctx context.Context, ctr *libpod.Container, podUnit string, units map[string]string, options entities.GenerateQuadletOptions) error {
+	data, err := ctr.Inspect(false)
+	if err != nil {
+		return err
+	}
+	runtime := ctr.Runtime()
+	rtc, err := runtime.GetConfigNoCopy()
+	if err != nil {
+		return err
+	}
+
+	info := quadletContainerInfo{
+		Data:           data,
+		DefaultEnv:     rtc.GetDefaultEnv(),
+		DefaultNetwork: rtc.Network.DefaultNetwork,
+		PodUnit:        podUnit,
+	}
+	if imageID, _ := ctr.Image(); imageID != "" {
+		img, _, err := runtime.LibimageRuntime().LookupImage(imageID, nil)
+		if err != nil {
+			return fmt.Errorf("looking up image of container %s: %w", ctr.ID(), err)
+		}
+		imgData, err := img.Inspect(ctx, nil)
+		if err != nil {
+			return err
+		}
+		if imgData.Config != nil {
+			info.Image = quadletImageConfig{
+				Env:        imgData.Config.Env,
+				Cmd:        imgData.Config.Cmd,
+				Entrypoint: imgData.Config.Entrypoint,
+				Labels:     imgData.Config.Labels,
+				User:       imgData.Config.User,
+				WorkingDir: imgData.Config.WorkingDir,
+			}
+		}
+	}
+
+	for _, mount := range data.Mounts {
+		if mount.Type != "volume" {
+			continue
+		}
+		vol, err := runtime.LookupVolume(mount.Name)
+		if err != nil {
+			return err
+		}
+		if vol.Anonymous() {
+			info.AnonymousVolumes = append(info.AnonymousVolumes, vol.Name())
+			continue
+		}
+		content, err := volumeQuadlet(vol.Name(), vol.Driver(), vol.Labels(), vol.Options(), options)
+		if err != nil {
+			return err
+		}
+		units[vol.Name()+".volume"] = content
+	}
+
+	if podUnit == "" {
+		if err := addNetworkQuadlets(runtime, data, info.DefaultNetwork, units, options); err != nil {
+			return err
+		}
+	}
+
+	content, err := containerQuadlet(&info, options)
+	if err != nil {
+		return err
+	}
+	units[data.Name+".container"] = content
+	return nil
+}
+
+// addNetworkQuadlets adds .network units for the non-default networks the
+// container is connected to.
+func addNetworkQuadlets(runtime *libpod.Runtime, data *libpodDefine.InspectContainerData, defaultNetwork string, units map[string]string, options entities.GenerateQuadletOptions) error {
+	for _, name := range quadletNetworks(data, defaultNetwork) {
+		network, err := runtime.Network().NetworkInspect(name)
+		if err != nil {
+			return err
+		}
+		content, err := networkQuadlet(&network, options)
+		if err != nil {
+			return err
+		}
+		units[name+".network"] = content
+	}
+	return nil
+}
+
+// quadletNetworks returns the sorted non-default networks of the container.
+func quadletNetworks(data *libpodDefine.InspectContainerData, defaultNetwork string) []string {
+	if data.NetworkSettings == nil || data.HostConfig == nil || data.HostConfig.NetworkMode != "bridge" {
+		return nil
+	}
+	networks := make([]string, 0, len(data.NetworkSettings.Networks))
+	for name := range data.NetworkSettings.Networks {
+		if name != defaultNetwork {
+			networks = append(networks, name)
+		}
+	}
+	sort.Strings(networks)
+	return networks
+}
+
+// newQuadletUnit returns a unit file starting with the header comment.
+func newQuadletUnit(fileName string, options entities.GenerateQuadletOptions) *parser.UnitFile {
+	unit := parser.NewUnitFile()
+	unit.AddComment("", fileName)
+	if !options.NoHeader {
+		unit.AddComment("", "autogenerated by Podman "+version.Version.String())
+	}
+	return unit
+}
+
+// containerQuadlet renders the .container unit of the container.
+func containerQuadlet(info *quadletContainerInfo, options entities.GenerateQuadletOptions) (string, error) {
+	data := info.Data
+	unit := newQuadletUnit(data.Name+".container", options)
+	unit.Add(quadlet.UnitGroup, "Description", "Podman container "+data.Name)
+
+	group := quadlet.ContainerGroup
+	unit.Add(group, quadlet.KeyContainerName, data.Name)
+	if data.ImageName != "" {
+		unit.Add(group, quadlet.KeyImage, data.ImageName)
+	} else {
+		unit.Add(group, quadlet.KeyRootfs, data.Rootfs)
+	}
+	if info.PodUnit != "" {
+		unit.Add(group, quadlet.KeyPod, info.PodUnit)
+	}
+
+	config := data.Config
+	if config != nil {
+		if !slices.Equal(config.Entrypoint, info.Image.Entrypoint) {
+			switch len(config.Entrypoint) {
+			case 0:
+				unit.Add(group, quadlet.KeyEntrypoint, `""`)
+			case 1:
+				unit.Add(group, quadlet.KeyEntrypoint, config.Entrypoint[0])
+			default:
+				b, err := json.Marshal(config.Entrypoint)
+				if err != nil {
+					return "", err
+				}
+				unit.Add(group, quadlet.KeyEntrypoint, string(b))
+			}
+		}
+		if len(config.Cmd) > 0 && (!slices.Equal(config.Cmd, info.Image.Cmd) || !slices.Equal(config.Entrypoint, info.Image.Entrypoint)) {
+			unit.AddCmdline(group, quadlet.KeyExec, config.Cmd)
+		}
+
+		env := make([]string, 0, len(config.Env))
+		for _, e := range config.Env {
+			if slices.Contains(info.Image.Env, e) || slices.Contains(info.DefaultEnv, e) || e == "container=podman" {
+				continue
+			}
+			env = append(env, e)
+		}
+		sort.Strings(env)
+		for _, e := range env {
+			unit.AddCmdline(group, quadlet.KeyEnvironment, []string{e})
+		}
+
+		for _, key := range slices.Sorted(maps.Keys(config.Labels)) {
+			value := config.Labels[key]
+			if imgValue, ok := info.Image.Labels[key]; ok && imgValue == value {
+				continue
+			}
+			unit.AddCmdline(group, quadlet.KeyLabel, []string{key + "=" + value})
+		}
+
+		if config.User != "" && config.User != info.Image.User {
+			unit.Add(group, quadlet.KeyUser, config.User)
+		}
+		if config.WorkingDir != "" && config.WorkingDir != info.Image.WorkingDir && (info.Image.WorkingDir != "" || config.WorkingDir != "/") {
+			unit.Add(group, quadlet.KeyWorkingDir, config.WorkingDir)
+		}
+		if info.PodUnit == "" && config.Hostname != "" && !strings.HasPrefix(data.ID, config.Hostname) {
+			unit.Add(group, quadlet.KeyHostName, config.Hostname)
+		}
+		if config.StopSignal != "" && config.StopSignal != "SIGTERM" && config.StopSignal != "15" {
+			unit.Add(group, quadlet.KeyStopSignal, config.StopSignal)
+		}
+		if err := addQuadletHealthCheck(unit, config); err != nil {
+			return "", err
+		}
+	}
+
+	for _, mount := range data.Mounts {
+		var volume string
+		switch mount.Type {
+		case "volume":
+			if slices.Contains(info.AnonymousVolumes, mount.Name) {
+				volume = mount.Destination
+			} else {
+				volume = mount.Name + ".volume:" + mount.Destination
+			}
+		case "bind":
+			volume = mount.Source + ":" + mount.Destination
+		default:
+			continue
+		}
+		if !mount.RW {
+			volume += ":ro"
+		}
+		unit.Add(group, quadlet.KeyVolume, volume)
+	}
+
+	if hostConfig := data.HostConfig; hostConfig != nil {
+		if info.PodUnit == "" {
+			addQuadletNetworks(unit, group, data, info.DefaultNetwork)
+			addQuadletPorts(unit, group, hostConfig.PortBindings)
+		}
+		for _, dest := range slices.Sorted(maps.Keys(hostConfig.Tmpfs)) {
+			tmpfs := dest
+			if opts := hostConfig.Tmpfs[dest]; opts != "" {
+				tmpfs += ":" + opts
+			}
+			unit.Add(group, quadlet.KeyTmpfs, tmpfs)
+		}
+		for _, device := range hostConfig.Devices {
+			dev := device.PathOnHost
+			if device.PathInContainer != device.PathOnHost || (device.CgroupPermissions != "" && device.CgroupPermissions != "rwm") {
+				dev += ":" + device.PathInContainer
+				if device.CgroupPermissions != "" && device.CgroupPermissions != "rwm" {
+					dev += ":" + device.CgroupPermissions
+				}
+			}
+			unit.Add(group, quadlet.KeyAddDevice, dev)
+		}
+		if len(hostConfig.CapAdd) > 0 {
+			unit.Add(group, quadlet.KeyAddCapability, strings.Join(hostConfig.CapAdd, " "))
+		}
+		if len(hostConfig.CapDrop) > 0 {
+			unit.Add(group, quadlet.KeyDropCapability, strings.Join(hostConfig.CapDrop, " "))
+		}
+		if hostConfig.ReadonlyRootfs {
+			unit.Add(group, quadlet.KeyReadOnly, "true")
+		}
+		if hostConfig.RestartPolicy != nil {
+			switch hostConfig.RestartPolicy.Name {
+			case "always", "unless-stopped":
+				unit.Add(quadlet.ServiceGroup, "Restart", "always")
+			case "on-failure":
+				unit.Add(quadlet.ServiceGroup, "Restart", "on-failure")
+			}
+		}
+	}
+
+	unit.Add(quadlet.InstallGroup, "WantedBy", "default.target")
+	return unit.ToString()
+}
+
+// addQuadletHealthCheck adds the health check settings of the container.
+func addQuadletHealthCheck(unit *parser.UnitFile, config *libpodDefine.InspectContainerConfig) error {
+	hc := config.Healthcheck
+	if hc == nil || len(hc.Test) == 0 || hc.Test[0] == "NONE" {
+		return nil
+	}
+	group := quadlet.ContainerGroup
+	switch hc.Test[0] {
+	case "CMD-SHELL":
+		unit.Add(group, quadlet.KeyHealthCmd, strings.Join(hc.Test[1:], " "))
+	case "CMD":
+		b, err := json.Marshal(hc.Test[1:])
+		if err != nil {
+			return err
+		}
+		unit.Add(group, quadlet.KeyHealthCmd, string(b))
+	default:
+		return nil
+	}
+	if hc.Interval > 0 {
+		unit.Add(group, quadlet.KeyHealthInterval, hc.Interval.String())
+	}
+	if hc.Timeout > 0 {
+		unit.Add(group, quadlet.KeyHealthTimeout, hc.Timeout.String())
+	}
+	if hc.StartPeriod > 0 {
+		unit.Add(group, quadlet.KeyHealthStartPeriod, hc.StartPeriod.String())
+	}
+	if hc.Retries > 0 {
+		unit.Add(group, quadlet.KeyHealthRetries, strconv.Itoa(hc.Retries))
+	}
+	if config.HealthcheckOnFailureAction != "" && config.HealthcheckOnFailureAction != "none" {
+		unit.Add(group, quadlet.KeyHealthOnFailure, config.HealthcheckOnFailureAction)
+	}
+	return nil
+}
+
+// addQuadletNetworks adds the network mode or the networks of the container.
+func addQuadletNetworks(unit *parser.UnitFile, group string, data *libpodDefine.InspectContainerData, defaultNetwork string) {
+	switch mode := data.HostConfig.NetworkMode; mode {
+	case "bridge", "":
+		for _, name := range quadletNetworks(data, defaultNetwork) {
+			unit.Add(group, quadlet.KeyNetwork, name+".network")
+		}
+	default:
+		unit.Add(group, quadlet.KeyNetwork, mode)
+	}
+}
+
+// addQuadletPorts adds the published ports in a deterministic order.
+func addQuadletPorts(unit *parser.UnitFile, group string, bindings map[string][]libpodDefine.InspectHostPort) {
+	for _, ctrPort := range slices.Sorted(maps.Keys(bindings)) {
+		port, proto, _ := strings.Cut(ctrPort, "/")
+		if proto != "" && proto != "tcp" {
+			port += "/" + proto
+		}
+		for _, binding := range bindings[ctrPort] {
+			publish := port
+			if binding.HostPort != "" {
+				publish = binding.HostPort + ":" + publish
+			} else {
+				publish = ":" + publish
+			}
+			if binding.HostIP != "" && binding.HostIP != "0.0.0.0" {
+				publish = binding.HostIP + ":" + publish
+			}
+			unit.Add(group, quadlet.KeyPublishPort, publish)
+		}
+	}
+}
+
+// podQuadlet renders the .pod unit of the pod. The published ports and
+// networks are taken from the infra container if there is one.
+func podQuadlet(name string, labels map[string]string, infraData *libpodDefine.InspectContainerData, defaultNetwork string, options entities.GenerateQuadletOptions) (string, error) {
+	unit := newQuadletUnit(name+".pod", options)
+	unit.Add(quadlet.UnitGroup, "Description", "Podman pod "+name)
+
+	group := quadlet.PodGroup
+	unit.Add(group, quadlet.KeyPodName, name)
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		unit.AddCmdline(group, quadlet.KeyLabel, []string{key + "=" + labels[key]})
+	}
+	if infraData != nil && infraData.HostConfig != nil {
+		addQuadletNetworks(unit, group, infraData, defaultNetwork)
+		addQuadletPorts(unit, group, infraData.HostConfig.PortBindings)
+	}
+
+	unit.Add(quadlet.InstallGroup, "WantedBy", "default.target")
+	return unit.ToString()
+}
+
+// volumeQuadlet renders the .volume unit of a named volume.
+func volumeQuadlet(name, driver string, labels, volOptions map[string]string, options entities.GenerateQuadletOptions) (string, error) {
+	unit := newQuadletUnit(name+".volume", options)
+	group := quadlet.VolumeGroup
+	unit.Add(group, quadlet.KeyVolumeName, name)
+	if driver != "" && driver != libpodDefine.VolumeDriverLocal {
+		unit.Add(group, quadlet.KeyDriver, driver)
+	}
+	for _, key := range slices.Sorted(maps.Keys(labels)) {
+		unit.AddCmdline(group, quadlet.KeyLabel, []string{key + "=" + labels[key]})
+	}
+	for _, key := range slices.Sorted(maps.Keys(volOptions)) {
+		value := volOptions[key]
+		switch key {
+		case "type":
+			unit.Add(group, quadlet.KeyType, value)
+		case "device":
+			unit.Add(group, quadlet.KeyDevice, value)
+		case "o":
+			unit.Add(group, quadlet.KeyOptions, value)
+		}
+	}
+	return unit.ToString()
+}
+
+// networkQuadlet renders the .network unit of a network.
+func networkQuadlet(network *types.Network, options entities.GenerateQuadletOptions) (string, error) {
+	unit := newQuadletUnit(network.Name+".network", options)
+	group := quadlet.NetworkGroup
+	unit.Add(group, quadlet.KeyNetworkName, network.Name)
+	if network.Driver != "" && network.Driver != types.BridgeNetworkDriver {
+		unit.Add(group, quadlet.KeyDriver, network.Driver)
+		if network.NetworkInterface != "" {
+			unit.Add(group, quadlet.KeyInterfaceName, network.NetworkInterface)
+		}
+	}
+	for _, subnet := range network.Subnets {
+		unit.Add(group, quadlet.KeySubnet, subnet.Subnet.String())
+		if subnet.Gateway != nil {
+			unit.Add(group, quadlet.KeyGateway, subnet.Gateway.String())
+		}
+	}
+	if network.IPv6Enabled {
+		unit.Add(group, quadlet.KeyIPv6, "true")
+	}
+	if network.Internal {
+		unit.Add(group, quadlet.KeyInternal, "true")
+	}
+	if !network.DNSEnabled && (network.Driver == "" || network.Driver == types.BridgeNetworkDriver) {
+		unit.Add(group, quadlet.KeyDisableDNS, "true")
+	}
+	for _, key := range slices.Sorted(maps.Keys(network.Labels)) {
+		unit.AddCmdline(group, quadlet.KeyLabel, []string{key + "=" + network.Labels[key]})
+	}
+	for _, key := range slices.Sorted(maps.Keys(network.Options)) {
+		unit.Add(group, quadlet.KeyOptions, key+"="+network.Options[key])
+	}
+	return unit.ToString()
+}
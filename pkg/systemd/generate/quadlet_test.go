@@ -0,0 +1,140 @@
+//go:build !remote
+
+package generate
+
+import (
+	"net"
+	"testing"
+
+	"github.com/dmikushin/podman-shared/libpod/define"
+	"github.com/dmikushin/podman-shared/pkg/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.podman.io/common/libnetwork/types"
+)
+
+func TestContainerQuadlet(t *testing.T) {
+	info := quadletContainerInfo{
+		Data: &define.InspectContainerData{
+			ID:        "639c53578af4d84b8800b4635fa4e680ee80fd67e0e6a2d4eea48d1e3230f401",
+			Name:      "web",
+			ImageName: "quay.io/libpod/alpine:latest",
+			Config: &define.InspectContainerConfig{
+				Hostname: "639c53578af4",
+				Env:      []string{"PATH=/usr/bin", "container=podman", "FOO=bar baz"},
+				Cmd:      []string{"sleep", "inf"},
+				Labels:   map[string]string{"app": "web", "image": "label"},
+			},
+			Mounts: []define.InspectMount{
+				{Type: "volume", Name: "data", Destination: "/data", RW: true},
+				{Type: "volume", Name: "0123abcd", Destination: "/cache", RW: true},
+				{Type: "bind", Source: "/etc/web", Destination: "/etc/web"},
+			},
+			HostConfig: &define.InspectContainerHostConfig{
+				NetworkMode: "bridge",
+				PortBindings: map[string][]define.InspectHostPort{
+					"80/tcp": {{HostPort: "8080"}},
+					"53/udp": {{HostIP: "127.0.0.1", HostPort: "5353"}},
+				},
+				RestartPolicy:  &define.InspectRestartPolicy{Name: "unless-stopped"},
+				ReadonlyRootfs: true,
+			},
+			NetworkSettings: &define.InspectNetworkSettings{
+				Networks: map[string]*define.InspectAdditionalNetwork{
+					"podman":  {},
+					"backend": {},
+				},
+			},
+		},
+		Image: quadletImageConfig{
+			Env:    []string{"PATH=/usr/bin"},
+			Cmd:    []string{"/bin/sh"},
+			Labels: map[string]string{"image": "label"},
+		},
+		DefaultNetwork:   "podman",
+		AnonymousVolumes: []string{"0123abcd"},
+	}
+
+	expected := `# web.container
+
+[Unit]
+Description=Podman container web
+
+[Container]
+ContainerName=web
+Image=quay.io/libpod/alpine:latest
+Exec=sleep inf
+Environment="FOO=bar\x20baz"
+Label=app=web
+Volume=data.volume:/data
+Volume=/cache
+Volume=/etc/web:/etc/web:ro
+Network=backend.network
+PublishPort=127.0.0.1:5353:53/udp
+PublishPort=8080:80
+ReadOnly=true
+
+[Service]
+Restart=always
+
+[Install]
+WantedBy=default.target
+`
+	content, err := containerQuadlet(&info, entities.GenerateQuadletOptions{NoHeader: true})
+	require.NoError(t, err)
+	assert.Equal(t, expected, content)
+
+	// Containers in a pod take the network settings from the pod.
+	info.PodUnit = "app.pod"
+	content, err = containerQuadlet(&info, entities.GenerateQuadletOptions{NoHeader: true})
+	require.NoError(t, err)
+	assert.Contains(t, content, "Pod=app.pod\n")
+	assert.NotContains(t, content, "Network=")
+	assert.NotContains(t, content, "PublishPort=")
+}
+
+func TestVolumeQuadlet(t *testing.T) {
+	expected := `# data.volume
+
+[Volume]
+VolumeName=data
+Label=app=web
+Device=/dev/sdb1
+Options=noatime
+Type=ext4
+`
+	content, err := volumeQuadlet("data", define.VolumeDriverLocal,
+		map[string]string{"app": "web"},
+		map[string]string{"device": "/dev/sdb1", "o": "noatime", "type": "ext4"},
+		entities.GenerateQuadletOptions{NoHeader: true})
+	require.NoError(t, err)
+	assert.Equal(t, expected, content)
+}
+
+func TestNetworkQuadlet(t *testing.T) {
+	subnet, err := types.ParseCIDR("10.89.1.0/24")
+	require.NoError(t, err)
+	network := types.Network{
+		Name:   "backend",
+		Driver: types.BridgeNetworkDriver,
+		Subnets: []types.Subnet{
+			{Subnet: subnet, Gateway: net.ParseIP("10.89.1.1")},
+		},
+		Internal: true,
+		Options:  map[string]string{"mtu": "1400"},
+	}
+
+	expected := `# backend.network
+
+[Network]
+NetworkName=backend
+Subnet=10.89.1.0/24
+Gateway=10.89.1.1
+Internal=true
+DisableDNS=true
+Options=mtu=1400
+`
+	content, err := networkQuadlet(&network, entities.GenerateQuadletOptions{NoHeader: true})
+	require.NoError(t, err)
+	assert.Equal(t, expected, content)
+}
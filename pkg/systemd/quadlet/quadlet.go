@@ -167,6 +167,7 @@ const (
 	KeyServiceName           = "ServiceName"
 	KeySetWorkingDirectory   = "SetWorkingDirectory"
 	KeyShmSize               = "ShmSize"
+	KeySocket                = "Socket"
 	KeyStartWithPod          = "StartWithPod"
 	KeyStopSignal            = "StopSignal"
 	KeyStopTimeout           = "StopTimeout"
@@ -319,6 +320,7 @@ var (
 				KeySecurityLabelType:     true,
 				KeyServiceName:           true,
 				KeyShmSize:               true,
+				KeySocket:                true,
 				KeyStopSignal:            true,
 				KeyStartWithPod:          true,
 				KeyStopTimeout:           true,
@@ -736,6 +738,20 @@ func ConvertContainer(container *parser.UnitFile, unitsInfoMap map[string]*UnitI
 		podman.add("-d")
 	}
 
+	sockets := container.LookupAll(ContainerGroup, KeySocket)
+	for _, socket := range sockets {
+		if !strings.HasSuffix(socket, ".socket") {
+			return nil, warnings, fmt.Errorf("invalid Socket %q, must be a socket unit", socket)
+		}
+		// systemd passes the sockets to podman, which passes them to the container
+		service.Add(ServiceGroup, "Sockets", socket)
+		service.Add(UnitGroup, "Requires", socket)
+		service.Add(UnitGroup, "After", socket)
+	}
+	if len(sockets) > 0 {
+		podman.add("--socket-activation")
+	}
+
 	if !container.HasKey(ServiceGroup, "SyslogIdentifier") {
 		service.Set(ServiceGroup, "SyslogIdentifier", "%N")
 	}
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"bufio"
+	"io"
 	"strings"
 	"unicode"
 )
@@ -32,3 +34,39 @@ func DecodeChanges(changes []string) []string {
 	}
 	return result
 }
+
+// ReadChanges reads changes from a file of Dockerfile-style instructions, one
+// per line.  Empty lines and comments are skipped, and lines ending with a
+// backslash are continued on the next line.
+func ReadChanges(r io.Reader) ([]string, error) {
+	var (
+		changes []string
+		current strings.Builder
+	)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		continued := strings.HasSuffix(line, "\\")
+		line = strings.TrimSpace(strings.TrimSuffix(line, "\\"))
+		if line != "" {
+			if current.Len() > 0 {
+				current.WriteString(" ")
+			}
+			current.WriteString(line)
+		}
+		if !continued && current.Len() > 0 {
+			changes = append(changes, current.String())
+			current.Reset()
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if current.Len() > 0 {
+		changes = append(changes, current.String())
+	}
+	return changes, nil
+}
@@ -1,6 +1,7 @@
 package util
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -50,3 +51,23 @@ func TestDecodeChanges(t *testing.T) {
 		})
 	}
 }
+
+func TestReadChanges(t *testing.T) {
+	changes, err := ReadChanges(strings.NewReader(`# appliance settings
+ENV PATH=/opt/app/bin:/usr/bin
+
+LABEL org.opencontainers.image.title=appliance \
+      org.opencontainers.image.version=1.0
+  # the port
+EXPOSE 8080
+CMD ["/opt/app/bin/serve", \
+     "--port", "8080"]
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"ENV PATH=/opt/app/bin:/usr/bin",
+		"LABEL org.opencontainers.image.title=appliance org.opencontainers.image.version=1.0",
+		"EXPOSE 8080",
+		`CMD ["/opt/app/bin/serve", "--port", "8080"]`,
+	}, changes)
+}
@@ -0,0 +1,43 @@
+package util
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.podman.io/common/pkg/config"
+)
+
+const (
+	// PullPolicyNewerWithMaxAge is the "newer" pull policy, only checking
+	// the registry for images pulled, or checked, longer than a maximum age
+	// ago.
+	PullPolicyNewerWithMaxAge = "newer-with-max-age"
+	// DefaultPullMaxAge is the default maximum age of the
+	// PullPolicyNewerWithMaxAge pull policy.
+	DefaultPullMaxAge = "24h"
+)
+
+// ParsePullPolicy parses the pull policies of config.ParsePullPolicy and
+// PullPolicyNewerWithMaxAge, which is returned as config.PullPolicyNewer with
+// withMaxAge set.
+func ParsePullPolicy(s string) (policy config.PullPolicy, withMaxAge bool, err error) {
+	if strings.EqualFold(s, PullPolicyNewerWithMaxAge) {
+		return config.PullPolicyNewer, true, nil
+	}
+	policy, err = config.ParsePullPolicy(s)
+	return policy, false, err
+}
+
+// ParsePullMaxAge parses the maximum age of the PullPolicyNewerWithMaxAge
+// pull policy.
+func ParsePullMaxAge(s string) (time.Duration, error) {
+	maxAge, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid pull max age %q: %w", s, err)
+	}
+	if maxAge <= 0 {
+		return 0, fmt.Errorf("invalid pull max age %q: must be positive", s)
+	}
+	return maxAge, nil
+}
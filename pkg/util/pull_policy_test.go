@@ -0,0 +1,48 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.podman.io/common/pkg/config"
+)
+
+func TestParsePullPolicy(t *testing.T) {
+	tests := []struct {
+		policy     string
+		want       config.PullPolicy
+		withMaxAge bool
+		wantErr    bool
+	}{
+		{"", config.PullPolicyMissing, false, false},
+		{"newer", config.PullPolicyNewer, false, false},
+		{"newer-with-max-age", config.PullPolicyNewer, true, false},
+		{"Newer-With-Max-Age", config.PullPolicyNewer, true, false},
+		{"newer-with-max-age=1h", config.PullPolicyUnsupported, false, true},
+		{"bogus", config.PullPolicyUnsupported, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			policy, withMaxAge, err := ParsePullPolicy(tt.policy)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, policy)
+			assert.Equal(t, tt.withMaxAge, withMaxAge)
+		})
+	}
+}
+
+func TestParsePullMaxAge(t *testing.T) {
+	maxAge, err := ParsePullMaxAge(DefaultPullMaxAge)
+	assert.NoError(t, err)
+	assert.Equal(t, 24*time.Hour, maxAge)
+
+	for _, s := range []string{"", "0", "-1h", "1d"} {
+		_, err := ParsePullMaxAge(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -1248,6 +1248,21 @@ func ConvertTimeout(timeout int) uint {
 	return uint(timeout)
 }
 
+// Paginate returns the page of items starting at offset with at most limit
+// items.  A limit of 0 or less returns all items after offset.
+func Paginate[T any](items []T, offset, limit int) []T {
+	if offset >= len(items) {
+		return items[:0]
+	}
+	if offset > 0 {
+		items = items[offset:]
+	}
+	if limit > 0 && limit < len(items) {
+		items = items[:limit]
+	}
+	return items
+}
+
 // ExecAddTERM when container does not have a TERM environment variable and
 // caller wants a tty, then leak the existing TERM environment into
 // the container.
@@ -1264,3 +1279,193 @@ func ExecAddTERM(existingEnv []string, execEnvs map[string]string) {
 
 	execEnvs["TERM"] = "xterm"
 }
+
+// ParseDevice parses device mapping string to a src, dest & permissions string
+func ParseDevice(device string) (string, string, string, error) {
+	var src string
+	var dst string
+	permissions := "rwm"
+	arr := strings.Split(device, ":")
+	switch len(arr) {
+	case 3:
+		if arr[2] == "" {
+			return "", "", "", fmt.Errorf("empty device mode in device specification: %s", device)
+		}
+		if !IsValidDeviceMode(arr[2]) {
+			return "", "", "", fmt.Errorf("invalid device mode %q in device %q", arr[2], device)
+		}
+		permissions = arr[2]
+		fallthrough
+	case 2:
+		if IsValidDeviceMode(arr[1]) {
+			permissions = arr[1]
+		} else {
+			if len(arr[1]) > 0 && arr[1][0] != '/' {
+				return "", "", "", fmt.Errorf("invalid device mode %q in device %q", arr[1], device)
+			}
+			dst = arr[1]
+		}
+		fallthrough
+	case 1:
+		src = arr[0]
+	default:
+		return "", "", "", fmt.Errorf("invalid device specification: %s", device)
+	}
+
+	if dst == "" {
+		dst = src
+	}
+	return src, dst, permissions, nil
+}
+
+// IsValidDeviceMode checks if the mode for device is valid or not.
+// IsValid mode is a composition of r (read), w (write), and m (mknod).
+func IsValidDeviceMode(mode string) bool {
+	var legalDeviceMode = map[rune]bool{
+		'r': true,
+		'w': true,
+		'm': true,
+	}
+	if mode == "" {
+		return false
+	}
+	for _, c := range mode {
+		if !legalDeviceMode[c] {
+			return false
+		}
+		legalDeviceMode[c] = false
+	}
+	return true
+}
+
+var cgroupDeviceType = map[string]bool{
+	"a": true, // all
+	"b": true, // block device
+	"c": true, // character device
+}
+
+var cgroupDeviceAccess = map[string]bool{
+	"r": true, // read
+	"w": true, // write
+	"m": true, // mknod
+}
+
+// maxDeviceCgroupRules limits the number of rules a device cgroup rule with
+// ranges expands to.
+const maxDeviceCgroupRules = 1024
+
+// IsDeviceCgroupPathRule reports whether the device cgroup rule gives the
+// devices by a glob of their paths, like "/dev/ttyUSB* rwm", instead of their
+// type and numbers.
+func IsDeviceCgroupPathRule(rule string) bool {
+	return strings.HasPrefix(rule, "/")
+}
+
+// ParseDeviceCgroupRule parses a device cgroup rule in the form
+// "type major:minor access", as given with --device-cgroup-rule.  The major
+// and minor numbers are a number, "*" for any number, or an inclusive range
+// like "0-15", which is expanded to a rule per number.
+func ParseDeviceCgroupRule(rule string) ([]specs.LinuxDeviceCgroup, error) {
+	value := strings.Split(rule, " ")
+	if len(value) != 3 {
+		return nil, fmt.Errorf("invalid device cgroup rule requires type, major:Minor, and access rules: %q", rule)
+	}
+
+	devType := value[0]
+	if !cgroupDeviceType[devType] {
+		return nil, fmt.Errorf("invalid device type in device-access-add: %s", devType)
+	}
+
+	majorNumber, minorNumber, hasMinor := strings.Cut(value[1], ":")
+	if !hasMinor {
+		minorNumber = "*"
+	}
+	majors, err := parseDeviceNumbers(majorNumber)
+	if err != nil {
+		return nil, err
+	}
+	minors, err := parseDeviceNumbers(minorNumber)
+	if err != nil {
+		return nil, err
+	}
+	if len(majors)*len(minors) > maxDeviceCgroupRules {
+		return nil, fmt.Errorf("device cgroup rule %q expands to more than %d rules", rule, maxDeviceCgroupRules)
+	}
+
+	access := value[2]
+	if err := validateDeviceCgroupAccess(access); err != nil {
+		return nil, err
+	}
+
+	rules := make([]specs.LinuxDeviceCgroup, 0, len(majors)*len(minors))
+	for _, major := range majors {
+		for _, minor := range minors {
+			rules = append(rules, specs.LinuxDeviceCgroup{
+				Allow:  true,
+				Type:   devType,
+				Major:  major,
+				Minor:  minor,
+				Access: access,
+			})
+		}
+	}
+	return rules, nil
+}
+
+// parseDeviceNumbers parses the major or minor number of a device cgroup
+// rule, a nil number stands for any number.
+func parseDeviceNumbers(s string) ([]*int64, error) {
+	if s == "*" {
+		return []*int64{nil}, nil
+	}
+	first, last, isRange := strings.Cut(s, "-")
+	start, err := strconv.ParseUint(first, 10, 32)
+	if err != nil {
+		return nil, err
+	}
+	end := start
+	if isRange {
+		end, err = strconv.ParseUint(last, 10, 32)
+		if err != nil {
+			return nil, err
+		}
+		if end < start {
+			return nil, fmt.Errorf("invalid device number range %q", s)
+		}
+		if end-start >= maxDeviceCgroupRules {
+			return nil, fmt.Errorf("device number range %q has more than %d numbers", s, maxDeviceCgroupRules)
+		}
+	}
+	numbers := make([]*int64, 0, end-start+1)
+	for i := start; i <= end; i++ {
+		n := int64(i)
+		numbers = append(numbers, &n)
+	}
+	return numbers, nil
+}
+
+// ParseDeviceCgroupPathRule parses a device cgroup rule in the form
+// "glob access", like "/dev/ttyUSB* rwm", into the glob of the device paths
+// and the access.
+func ParseDeviceCgroupPathRule(rule string) (string, string, error) {
+	glob, access, ok := strings.Cut(rule, " ")
+	if !ok || !IsDeviceCgroupPathRule(glob) {
+		return "", "", fmt.Errorf("invalid device cgroup rule requires a device path glob and access rules: %q", rule)
+	}
+	if _, err := filepath.Match(glob, ""); err != nil {
+		return "", "", fmt.Errorf("invalid device path glob in device cgroup rule %q: %w", rule, err)
+	}
+	if err := validateDeviceCgroupAccess(access); err != nil {
+		return "", "", err
+	}
+	return glob, access, nil
+}
+
+func validateDeviceCgroupAccess(access string) error {
+	for c := range strings.SplitSeq(access, "") {
+		if !cgroupDeviceAccess[c] {
+			return fmt.Errorf("invalid device access in device-access-add: %s", c)
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -265,3 +266,51 @@ func DeviceFromPath(path string) (*spec.LinuxDevice, error) {
 		Minor:    int64(unix.Minor(devNumber)),
 	}, nil
 }
+
+// DeviceCgroupRulesFromPaths returns the rules allowing access to the host
+// devices matching a device cgroup rule in the form "glob access", like
+// "/dev/ttyUSB* rwm".  Symbolic links, like the ones in /dev/serial/by-id,
+// are followed.
+func DeviceCgroupRulesFromPaths(rule string) ([]spec.LinuxDeviceCgroup, error) {
+	glob, access, err := ParseDeviceCgroupPathRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(glob)
+	if err != nil {
+		return nil, err
+	}
+	var rules []spec.LinuxDeviceCgroup
+	for _, path := range paths {
+		resolved, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			return nil, err
+		}
+		dev, err := DeviceFromPath(resolved)
+		if err != nil {
+			if errors.Is(err, errNotADevice) {
+				continue
+			}
+			return nil, err
+		}
+		if dev.Type != "b" && dev.Type != "c" {
+			continue
+		}
+		if slices.ContainsFunc(rules, func(r spec.LinuxDeviceCgroup) bool {
+			return r.Type == dev.Type && *r.Major == dev.Major && *r.Minor == dev.Minor
+		}) {
+			continue
+		}
+		rules = append(rules, spec.LinuxDeviceCgroup{
+			Allow:  true,
+			Type:   dev.Type,
+			Major:  &dev.Major,
+			Minor:  &dev.Minor,
+			Access: access,
+		})
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no device matches %s in device cgroup rule %q", glob, rule)
+	}
+	return rules, nil
+}
@@ -841,3 +841,190 @@ func TestGetRootlessPauseProcessPidPath(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEqual(t, dir, "libpod/tmp/pause.pid")
 }
+
+func TestParseDevice(t *testing.T) {
+	tests := []struct {
+		device string
+		src    string
+		dst    string
+		perm   string
+	}{
+		{"/dev/foo", "/dev/foo", "/dev/foo", "rwm"},
+		{"/dev/foo:/dev/bar", "/dev/foo", "/dev/bar", "rwm"},
+		{"/dev/foo:/dev/bar:rw", "/dev/foo", "/dev/bar", "rw"},
+		{"/dev/foo:rw", "/dev/foo", "/dev/foo", "rw"},
+		{"/dev/foo::rw", "/dev/foo", "/dev/foo", "rw"},
+		{"/dev/foo:", "/dev/foo", "/dev/foo", "rwm"},
+	}
+	for _, test := range tests {
+		src, dst, perm, err := ParseDevice(test.device)
+		assert.NoError(t, err)
+		assert.Equal(t, src, test.src)
+		assert.Equal(t, dst, test.dst)
+		assert.Equal(t, perm, test.perm)
+	}
+}
+
+func TestParseDeviceErrors(t *testing.T) {
+	errorTests := []struct {
+		device        string
+		expectedError string
+	}{
+		{"/dev/fuse::", "empty device mode in device specification: /dev/fuse::"},
+		{"/dev/fuse:invalid", `invalid device mode "invalid" in device "/dev/fuse:invalid"`},
+		{"/dev/fuse:/path:xyz", `invalid device mode "xyz" in device "/dev/fuse:/path:xyz"`},
+		{"/dev/fuse:/path:rw:extra", `invalid device specification: /dev/fuse:/path:rw:extra`},
+		{"/dev/fuse:/path:rw:extra:more", `invalid device specification: /dev/fuse:/path:rw:extra:more`},
+		{"/dev/fuse:notapath", `invalid device mode "notapath" in device "/dev/fuse:notapath"`},
+		{"/dev/fuse:x", `invalid device mode "x" in device "/dev/fuse:x"`},
+		{"/dev/fuse:rwx", `invalid device mode "rwx" in device "/dev/fuse:rwx"`},
+		{"/dev/fuse:rrw", `invalid device mode "rrw" in device "/dev/fuse:rrw"`},
+	}
+
+	for _, test := range errorTests {
+		_, _, _, err := ParseDevice(test.device)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), test.expectedError)
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+	for _, test := range []struct {
+		offset, limit int
+		expected      []int
+	}{
+		{0, 0, []int{1, 2, 3, 4, 5}},
+		{0, 2, []int{1, 2}},
+		{2, 0, []int{3, 4, 5}},
+		{2, 2, []int{3, 4}},
+		{4, 3, []int{5}},
+		{5, 1, []int{}},
+		{9, 0, []int{}},
+	} {
+		assert.Equal(t, test.expected, Paginate(items, test.offset, test.limit), "offset %d limit %d", test.offset, test.limit)
+	}
+}
+
+func TestParseDeviceCgroupRule(t *testing.T) {
+	rules, err := ParseDeviceCgroupRule("a *:* rwm")
+	assert.NoError(t, err, "err is nil")
+	assert.Len(t, rules, 1)
+	d := rules[0]
+	assert.True(t, d.Allow, "allow is true")
+	assert.Equal(t, d.Type, "a", "type is 'a'")
+	assert.Nil(t, d.Minor, "minor is nil")
+	assert.Nil(t, d.Major, "major is nil")
+
+	rules, err = ParseDeviceCgroupRule("b 3:* rwm")
+	assert.NoError(t, err, "err is nil")
+	assert.Len(t, rules, 1)
+	d = rules[0]
+	assert.True(t, d.Allow, "allow is true")
+	assert.Equal(t, d.Type, "b", "type is 'b'")
+	assert.Nil(t, d.Minor, "minor is nil")
+	assert.NotNil(t, d.Major, "major is not nil")
+	assert.Equal(t, *d.Major, int64(3), "major is 3")
+
+	rules, err = ParseDeviceCgroupRule("a *:3 rwm")
+	assert.NoError(t, err, "err is nil")
+	assert.Len(t, rules, 1)
+	d = rules[0]
+	assert.True(t, d.Allow, "allow is true")
+	assert.Equal(t, d.Type, "a", "type is 'a'")
+	assert.Nil(t, d.Major, "major is nil")
+	assert.NotNil(t, d.Minor, "minor is not nil")
+	assert.Equal(t, *d.Minor, int64(3), "minor is 3")
+
+	rules, err = ParseDeviceCgroupRule("c 1:2 rwm")
+	assert.NoError(t, err, "err is nil")
+	assert.Len(t, rules, 1)
+	d = rules[0]
+	assert.True(t, d.Allow, "allow is true")
+	assert.Equal(t, d.Type, "c", "type is 'c'")
+	assert.NotNil(t, d.Major, "minor is not nil")
+	assert.Equal(t, *d.Major, int64(1), "minor is 1")
+	assert.NotNil(t, d.Minor, "minor is not nil")
+	assert.Equal(t, *d.Minor, int64(2), "minor is 2")
+
+	_, err = ParseDeviceCgroupRule("q *:* rwm")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("a a:* rwm")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("a *:a rwm")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("a *:* abc")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("* *:* *")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("* *:a2 *")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("*")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("*:*")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("a *:*")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("a *:*")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("a 12a:* r")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("a a12:* r")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("a 0x1:* r")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("a -2:* r")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("a *:-3 r")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("c 188:3-1 rwm")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("c 0-4096:* rwm")
+	assert.NotNil(t, err, "err is not nil")
+
+	_, err = ParseDeviceCgroupRule("c 0-100:0-100 rwm")
+	assert.NotNil(t, err, "err is not nil")
+
+	rules, err = ParseDeviceCgroupRule("c 188:0-15 rw")
+	assert.NoError(t, err, "err is nil")
+	assert.Len(t, rules, 16)
+	for i, rule := range rules {
+		assert.Equal(t, int64(188), *rule.Major)
+		assert.Equal(t, int64(i), *rule.Minor)
+		assert.Equal(t, "rw", rule.Access)
+	}
+
+	rules, err = ParseDeviceCgroupRule("b 8-9:* r")
+	assert.NoError(t, err, "err is nil")
+	assert.Len(t, rules, 2)
+	assert.Equal(t, int64(9), *rules[1].Major)
+	assert.Nil(t, rules[1].Minor)
+}
+
+func TestParseDeviceCgroupPathRule(t *testing.T) {
+	glob, access, err := ParseDeviceCgroupPathRule("/dev/ttyUSB* rwm")
+	assert.NoError(t, err)
+	assert.Equal(t, "/dev/ttyUSB*", glob)
+	assert.Equal(t, "rwm", access)
+
+	for _, rule := range []string{"/dev/ttyUSB*", "/dev/ttyUSB[ rw", "/dev/ttyUSB* rwx", "c 1:2 rw"} {
+		_, _, err := ParseDeviceCgroupPathRule(rule)
+		assert.Error(t, err, rule)
+	}
+}
@@ -1,4 +1,7 @@
+import base64
+import io
 import json
+import tarfile
 import unittest
 from multiprocessing import Process
 
@@ -202,6 +205,55 @@ class ImageTestCase(APITestCase):
         tree = r.json()
         self.assertTrue(tree["Tree"].startswith("Image ID:"), r.text)
 
+    def test_build_secrets(self):
+        containerfile = b"""FROM alpine
+RUN --mount=type=secret,id=token echo "token=$(cat /run/secrets/token)"
+"""
+        context = io.BytesIO()
+        with tarfile.open(fileobj=context, mode="w") as tar:
+            info = tarfile.TarInfo("Dockerfile")
+            info.size = len(containerfile)
+            tar.addfile(info, io.BytesIO(containerfile))
+
+        def secrets_header(secrets):
+            encoded = {k: base64.b64encode(v).decode() for k, v in secrets.items()}
+            return base64.urlsafe_b64encode(json.dumps(encoded).encode()).decode()
+
+        # secrets in a header
+        r = requests.post(
+            self.podman_url + "/v1.40/build?nocache=true",
+            data=context.getvalue(),
+            headers={
+                "Content-Type": "application/x-tar",
+                "X-Build-Secrets": secrets_header({"token": b"fromheader"}),
+            },
+        )
+        self.assertEqual(r.status_code, 200, r.text)
+        self.assertIn("token=fromheader", r.text)
+        self.assertNotIn("errorDetail", r.text)
+
+        # secrets in a multipart field
+        r = requests.post(
+            self.podman_url + "/v1.40/build?nocache=true",
+            files={
+                "MainContext": ("context.tar", context.getvalue(), "application/x-tar"),
+                "secret-token": ("token", b"frommultipart"),
+            },
+        )
+        self.assertEqual(r.status_code, 200, r.text)
+        self.assertIn("token=frommultipart", r.text)
+
+        # invalid secret IDs are rejected
+        r = requests.post(
+            self.podman_url + "/v1.40/build",
+            data=context.getvalue(),
+            headers={
+                "Content-Type": "application/x-tar",
+                "X-Build-Secrets": secrets_header({"to,ken": b"x"}),
+            },
+        )
+        self.assertEqual(r.status_code, 400, r.text)
+
 
 if __name__ == "__main__":
     unittest.main()
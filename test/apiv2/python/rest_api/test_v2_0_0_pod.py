@@ -60,6 +60,35 @@ class TestApi(APITestCase):
         start = r.json()
         self.assertGreater(len(start["Errs"]), 0, r.text)
 
+    def test_pod_list_pages(self):
+        label = f"page_{random.getrandbits(64):x}"
+        names = [f"Pod_{random.getrandbits(160):x}" for _ in range(3)]
+        for name in names:
+            r = requests.post(self.uri("/pods/create"), json={"name": name, "labels": {label: ""}})
+            self.assertEqual(r.status_code, 201, r.text)
+
+        filters = '{"label":["%s"]}' % label
+        # pods are listed newest first
+        r = requests.get(self.uri("/pods/json"), params={"filters": filters, "limit": 2})
+        self.assertEqual(r.status_code, 200, r.text)
+        self.assertEqual([p["Name"] for p in r.json()], [names[2], names[1]])
+
+        r = requests.get(self.uri("/pods/json"), params={"filters": filters, "limit": 2, "offset": 2})
+        self.assertEqual(r.status_code, 200, r.text)
+        self.assertEqual([p["Name"] for p in r.json()], [names[0]])
+
+        r = requests.get(self.uri("/pods/json"), params={"filters": filters, "fields": "Id,Name"})
+        self.assertEqual(r.status_code, 200, r.text)
+        for pod in r.json():
+            self.assertEqual(sorted(pod.keys()), ["Id", "Name"])
+
+        r = requests.get(self.uri("/pods/json"), params={"offset": -1})
+        self.assertEqual(r.status_code, 400, r.text)
+
+        for name in names:
+            r = requests.delete(self.uri(f"/pods/{name}"))
+            self.assertEqual(r.status_code, 200, r.text)
+
 
 if __name__ == "__main__":
     unittest.main()
@@ -49,6 +49,23 @@ class SystemTestCase(APITestCase):
             if obj["Actor"].get("Attributes") and obj["Actor"]["Attributes"].get("image"):
                 self.assertEqual(obj["Actor"]["Attributes"]["image"], obj["from"])
 
+    def test_events_cloudevents(self):
+        r = requests.get(self.uri("/events?stream=false&streamFormat=cloudevents"))
+        self.assertEqual(r.status_code, 200, r.text)
+        self.assertEqual(r.headers["Content-Type"], "application/cloudevents+json")
+
+        report = r.text.splitlines()
+        self.assertGreater(len(report), 0, "No events found!")
+        for line in report:
+            obj = json.loads(line)
+            self.assertEqual(obj["specversion"], "1.0")
+            self.assertTrue(obj["source"].startswith("podman://"), obj)
+            self.assertEqual(obj["type"], "io.podman.{}.{}".format(obj["data"]["Type"], obj["data"]["Status"]))
+            self.assertTrue(obj["id"], obj)
+
+        r = requests.get(self.uri("/events?stream=false&streamFormat=xml"))
+        self.assertEqual(r.status_code, 400, r.text)
+
     def test_ping(self):
         required_headers = (
             "API-Version",
@@ -99,6 +116,19 @@ class SystemTestCase(APITestCase):
                 oci_name = n
         self.assertIsNotNone(oci_name, "OCI Runtime not found in version components.")
 
+    def test_swagger_json(self):
+        r = requests.get(self.uri("/swagger.json"))
+        self.assertEqual(r.status_code, 200, r.text)
+
+        spec = r.json()
+        version = requests.get(self.uri("/version")).json()
+        self.assertEqual(spec["info"]["x-podman-version"], version["Version"])
+        self.assertIn("get", spec["paths"]["/libpod/containers/json"])
+        self.assertIn("post", spec["paths"]["/containers/create"])
+
+        r = requests.get(self.podman_url + "/libpod/swagger.json")
+        self.assertEqual(r.status_code, 200, r.text)
+
     def test_df(self):
         r = requests.get(self.podman_url + "/v1.40/system/df")
         self.assertEqual(r.status_code, 200, r.text)
@@ -135,6 +135,34 @@ class VolumeTestCase(APITestCase):
         self.assertNotIn("Label", volume)
         self.assertDictEqual({"Database": "sqlserver"}, volume["Labels"])
 
+    def test_volume_idempotency_key(self):
+        key = f"key-{random.getrandbits(64):x}"
+        url = self.podman_url + "/v4.0.0/libpod/volumes/create"
+        labels = {"app": "idempotency"}
+
+        # volumes without name get a random one, a retry must not create another
+        create = requests.post(url, json={"labels": labels}, headers={"Idempotency-Key": key})
+        self.assertEqual(create.status_code, 201, create.text)
+        self.assertNotIn("Idempotent-Replayed", create.headers)
+
+        retry = requests.post(url, json={"labels": labels}, headers={"Idempotency-Key": key})
+        self.assertEqual(retry.status_code, 201, retry.text)
+        self.assertEqual(retry.headers.get("Idempotent-Replayed"), "true")
+        self.assertEqual(retry.json()["Name"], create.json()["Name"])
+
+        ls = requests.get(
+            self.podman_url + "/v4.0.0/libpod/volumes/json",
+            params={"filters": '{"label":["app=idempotency"]}'},
+        )
+        self.assertEqual(ls.status_code, 200, ls.text)
+        self.assertEqual(len(ls.json()), 1, ls.text)
+
+        other = requests.post(url, json={"labels": {"app": "other"}}, headers={"Idempotency-Key": key})
+        self.assertEqual(other.status_code, 422, other.text)
+
+        r = requests.delete(self.podman_url + f"/v4.0.0/libpod/volumes/{create.json()['Name']}")
+        self.assertEqual(r.status_code, 204, r.text)
+
 
 if __name__ == "__main__":
     unittest.main()
@@ -111,6 +111,76 @@ var _ = Describe("Podman build", func() {
 		Expect(session).Should(ExitCleanly())
 	})
 
+	It("podman build with a secret from the secrets store", func() {
+		secretFile := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFile, []byte("storedsecret"), 0o600)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("secret", "create", "buildsecret", secretFile)
+
+		session := podmanTest.PodmanExitCleanly("build", "-f", "build/Containerfile.with-secret", "-t", "secret-store-test", "--secret", "id=mysecret,src=podman-secret:buildsecret", "build/")
+		Expect(session.OutputToString()).To(ContainSubstring("storedsecret"))
+		podmanTest.PodmanExitCleanly("rmi", "secret-store-test")
+
+		session = podmanTest.Podman([]string{"build", "-f", "build/Containerfile.with-secret", "--secret", "id=mysecret,src=podman-secret:nosuchsecret", "build/"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `looking up build secret "nosuchsecret"`))
+
+		session = podmanTest.Podman([]string{"build", "-f", "build/Containerfile.with-secret", "--secret", "id=mysecret,src=podman-secret:buildsecret,type=env", "build/"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "from the secrets store must be of type file"))
+	})
+
+	It("podman build --output type=oci-dir and type=oci-archive", func() {
+		SkipIfRemote("--output is not supported in remote mode")
+		ociDir := filepath.Join(podmanTest.TempDir, "oci-dir")
+		podmanTest.PodmanExitCleanly("build", "--pull-never", "--output", "type=oci-dir,dest="+ociDir, "build/basicalpine")
+		Expect(filepath.Join(ociDir, "index.json")).To(BeARegularFile())
+		Expect(filepath.Join(ociDir, "oci-layout")).To(BeARegularFile())
+
+		// the build progress is written to stderr
+		session := podmanTest.Podman([]string{"build", "--pull-never", "--output", "type=oci-archive,dest=-", "build/basicalpine"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.ErrorToString()).To(ContainSubstring("STEP 1/"))
+		archive := filepath.Join(podmanTest.TempDir, "image.tar")
+		err := os.WriteFile(archive, session.Out.Contents(), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+		session = podmanTest.PodmanExitCleanly("load", "-q", "-i", archive)
+		Expect(session.OutputToString()).To(ContainSubstring("Loaded image"))
+
+		session = podmanTest.Podman([]string{"build", "--output", "type=oci-dir,dest=-", "build/basicalpine"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `"type=oci-dir" can not be used with "dest=-"`))
+
+		session = podmanTest.Podman([]string{"build", "-t", "oci-output", "--output", "type=oci-dir,dest=" + ociDir, "build/basicalpine"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "cannot be used with --tag or --manifest"))
+	})
+
+	It("podman build --platform with multiple platforms and --tag assembles a manifest list", func() {
+		build := []string{"build", "-f", "build/Containerfile.with-platform", "--platform", "linux/amd64,linux/arm64", "--jobs", "2", "-t", "localhost/multiarch:latest", "build/"}
+		session := podmanTest.Podman(build)
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.ErrorToString()).To(ContainSubstring("linux/amd64: sha256:"))
+		Expect(session.ErrorToString()).To(ContainSubstring("linux/arm64: sha256:"))
+
+		session = podmanTest.PodmanExitCleanly("manifest", "inspect", "localhost/multiarch:latest")
+		Expect(session.OutputToString()).To(ContainSubstring(`"architecture": "amd64"`))
+		Expect(session.OutputToString()).To(ContainSubstring(`"architecture": "arm64"`))
+
+		// a second build replaces the manifest list instead of adding to it
+		session = podmanTest.Podman(append([]string{"build", "-q"}, build[1:]...))
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.PodmanExitCleanly("manifest", "inspect", "localhost/multiarch:latest")
+		Expect(strings.Count(session.OutputToString(), `"architecture"`)).To(Equal(2))
+
+		session = podmanTest.Podman([]string{"build", "-f", "build/Containerfile.with-platform", "--platform", "linux/amd64,linux/arm64", "-t", "multiarch1", "-t", "multiarch2", "build/"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "only one --tag can be used when building for multiple platforms without --manifest"))
+	})
+
 	It("podman build with a secret from file and verify if secret file is not leaked into image", func() {
 		session := podmanTest.Podman([]string{"build", "-f", "build/secret-verify-leak/Containerfile.with-secret-verify-leak", "-t", "secret-test-leak", "--secret", "id=mysecret,src=build/secret.txt", "build/secret-verify-leak"})
 		session.WaitWithDefaultTimeout()
@@ -249,6 +319,36 @@ var _ = Describe("Podman build", func() {
 		Expect(strings.Fields(session.OutputToString())).To(HaveLen(1))
 	})
 
+	It("podman build --shared-cache", func() {
+		cacheDir := filepath.Join(podmanTest.TempDir, "build-cache")
+		containerfile := filepath.Join(podmanTest.TempDir, "Containerfile")
+		err := os.WriteFile(containerfile, fmt.Appendf(nil, "FROM %s\nRUN echo shared > /shared.txt\nLABEL test=shared-cache", CITEST_IMAGE), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"build", "--pull-never", "--layers", "--shared-cache", cacheDir, "-t", "test-shared-cache", "-f", containerfile, podmanTest.TempDir})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		imageID := session.OutputToStringArray()[len(session.OutputToStringArray())-1]
+		// One layout for each step, sharing the blobs directory
+		entries, err := os.ReadDir(cacheDir)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(len(entries)).To(BeNumerically(">", 1))
+		Expect(filepath.Join(cacheDir, "blobs", "sha256")).To(BeADirectory())
+
+		// Without the local intermediate images, the steps are
+		// imported from the shared build cache.
+		podmanTest.PodmanExitCleanly("rmi", "test-shared-cache")
+		session = podmanTest.Podman([]string{"build", "--pull-never", "--layers", "--shared-cache", cacheDir, "-t", "test-shared-cache", "-f", containerfile, podmanTest.TempDir})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(ContainSubstring("Cache pulled from remote"))
+		Expect(session.OutputToStringArray()).To(ContainElement(imageID))
+
+		session = podmanTest.Podman([]string{"build", "--layers=false", "--shared-cache", cacheDir, "-f", containerfile, podmanTest.TempDir})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "the shared build cache requires the intermediate images of --layers"))
+	})
+
 	It("podman build Containerfile locations", func() {
 		// Given
 		// Switch to temp dir and restore it afterwards
@@ -183,6 +183,40 @@ var _ = Describe("Podman commit", func() {
 		Expect(strings.Fields(session.OutputToString())).To(HaveLen(1))
 	})
 
+	It("podman commit container with --squash and --changes-from", func() {
+		test := podmanTest.Podman([]string{"run", "--name", "test1", "-d", ALPINE, "ls"})
+		test.WaitWithDefaultTimeout()
+		Expect(test).Should(ExitCleanly())
+
+		changesFile := filepath.Join(podmanTest.TempDir, "changes")
+		err := os.WriteFile(changesFile, []byte(`# appliance settings
+LABEL image=blue \
+      version=1.0
+ENV APPLIANCE=yes
+CMD ["/bin/sh", \
+     "-c", "echo $APPLIANCE"]
+`), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"commit", "-q", "--squash", "--changes-from", changesFile, "--change", "LABEL=image=green", "test1", "foobar.com/test1-image:latest"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		check := podmanTest.Podman([]string{"inspect", "foobar.com/test1-image:latest"})
+		check.WaitWithDefaultTimeout()
+		inspectResults := check.InspectImageJSON()
+		// --change is applied after --changes-from
+		Expect(inspectResults[0].Labels).To(HaveKeyWithValue("image", "green"))
+		Expect(inspectResults[0].Labels).To(HaveKeyWithValue("version", "1.0"))
+		Expect(inspectResults[0].Config.Env).To(ContainElement("APPLIANCE=yes"))
+		Expect(inspectResults[0].Config.Cmd).To(Equal([]string{"/bin/sh", "-c", "echo $APPLIANCE"}))
+		Expect(inspectResults[0].RootFS.Layers).To(HaveLen(1))
+
+		session = podmanTest.Podman([]string{"commit", "-q", "--changes-from", filepath.Join(podmanTest.TempDir, "missing"), "test1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "--changes-from: open "))
+	})
+
 	It("podman commit container with change flag and JSON entrypoint with =", func() {
 		test := podmanTest.Podman([]string{"run", "--name", "test1", "-d", ALPINE, "ls"})
 		test.WaitWithDefaultTimeout()
@@ -0,0 +1,61 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/dmikushin/podman-shared/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman container backup", func() {
+
+	It("podman container backup and restore-backup round-trip", func() {
+		podmanTest.PodmanExitCleanly("run", "--name", "backedup", "-v", "backupvol:/data", "--label", "backup=yes", ALPINE,
+			"sh", "-c", "echo volume > /data/file; echo layer > /layerfile")
+
+		outfile := filepath.Join(podmanTest.TempDir, "backup.tar")
+		podmanTest.PodmanExitCleanly("container", "backup", "-o", outfile, "backedup")
+
+		session := podmanTest.Podman([]string{"container", "restore-backup", outfile})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "container backedup of the backup already exists, remove it or use --name"))
+
+		podmanTest.PodmanExitCleanly("rm", "backedup")
+		session = podmanTest.Podman([]string{"container", "restore-backup", outfile})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "volume backupvol of the backup already exists, remove it or use --ignore-volumes"))
+
+		podmanTest.PodmanExitCleanly("volume", "rm", "backupvol")
+		session = podmanTest.PodmanExitCleanly("container", "restore-backup", outfile)
+		cid := session.OutputToString()
+
+		session = podmanTest.PodmanExitCleanly("inspect", "--format", "{{.Name}} {{.Config.Labels.backup}}", cid)
+		Expect(session.OutputToString()).To(Equal("backedup yes"))
+
+		// The files of the writable layer and of the volume are restored
+		// before the container runs again.
+		layerfile := filepath.Join(podmanTest.TempDir, "layerfile")
+		podmanTest.PodmanExitCleanly("cp", "backedup:/layerfile", layerfile)
+		content, err := os.ReadFile(layerfile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("layer\n"))
+		session = podmanTest.PodmanExitCleanly("run", "--rm", "-v", "backupvol:/data", ALPINE, "cat", "/data/file")
+		Expect(session.OutputToString()).To(Equal("volume"))
+
+		session = podmanTest.PodmanExitCleanly("container", "restore-backup", "--ignore-volumes", "--name", "backedup-copy", outfile)
+		session = podmanTest.PodmanExitCleanly("inspect", "--format", "{{.Name}}", session.OutputToString())
+		Expect(session.OutputToString()).To(Equal("backedup-copy"))
+	})
+
+	It("podman container backup bad filename", func() {
+		podmanTest.PodmanExitCleanly("create", "--name", "backedup", ALPINE)
+
+		session := podmanTest.Podman([]string{"container", "backup", "-o", filepath.Join(podmanTest.TempDir, "backup:with:colon.tar"), "backedup"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "invalid filename (should not contain ':')"))
+	})
+})
@@ -736,4 +736,76 @@ var _ = Describe("Podman create", func() {
 		Expect(inspect).Should(ExitCleanly())
 		Expect(inspect.OutputToString()).Should(Equal(ctr2Name))
 	})
+
+	It("podman create --monitor", func() {
+		session := podmanTest.PodmanExitCleanly("create", "--monitor", podmanTest.ConmonBinary, ALPINE, "top")
+		ctrID := session.OutputToString()
+		session = podmanTest.PodmanExitCleanly("inspect", "--format", "{{.Monitor.Name}} {{.Monitor.Path}}", ctrID)
+		Expect(session.OutputToString()).To(Equal(podmanTest.ConmonBinary + " " + podmanTest.ConmonBinary))
+		podmanTest.PodmanExitCleanly("start", ctrID)
+		podmanTest.PodmanExitCleanly("exec", ctrID, "true")
+
+		session = podmanTest.PodmanExitCleanly("create", ALPINE, "top")
+		session = podmanTest.PodmanExitCleanly("inspect", "--format", "{{.Monitor.Name}}", session.OutputToString())
+		Expect(session.OutputToString()).To(Equal("conmon"))
+
+		session = podmanTest.Podman([]string{"create", "--monitor", "foo", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `invalid container monitor "foo"`))
+
+		session = podmanTest.Podman([]string{"create", "--monitor", "/bin/true", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "/bin/true does not support the conmon command line options"))
+	})
+
+	It("podman create --runtime-class", func() {
+		runtime := filepath.Base(podmanTest.OCIRuntime)
+		session := podmanTest.PodmanExitCleanly("create", "--runtime-class", runtime, ALPINE, "top")
+		session = podmanTest.PodmanExitCleanly("inspect", "--format", "{{.RuntimeClass}} {{.OCIRuntime}}", session.OutputToString())
+		Expect(session.OutputToString()).To(Equal(runtime + " " + runtime))
+
+		session = podmanTest.Podman([]string{"create", "--runtime-class", "no-such-class", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "runtime class no-such-class is not defined"))
+
+		session = podmanTest.Podman([]string{"create", "--runtime-class", "Kata_CC", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `invalid runtime class name "Kata_CC"`))
+	})
+
+	It("podman create --spec", func() {
+		specFile := filepath.Join(podmanTest.TempDir, "spec.json")
+		spec := fmt.Sprintf(`{"name": "spec-ctr", "image": %q, "command": ["top"], "env": {"FOO": "bar"}, "labels": {"app": "web"}}`, ALPINE)
+		err := os.WriteFile(specFile, []byte(spec), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+
+		podmanTest.PodmanExitCleanly("create", "--spec", specFile)
+		session := podmanTest.PodmanExitCleanly("inspect", "--format", "{{.Config.Cmd}} {{.Config.Labels.app}}", "spec-ctr")
+		Expect(session.OutputToString()).To(Equal("[top] web"))
+		session = podmanTest.PodmanExitCleanly("inspect", "--format", "{{.Config.Env}}", "spec-ctr")
+		Expect(session.OutputToString()).To(ContainSubstring("FOO=bar"))
+
+		// The name can be overridden, and --replace replaces the container.
+		cidFile := filepath.Join(podmanTest.TempDir, "cid")
+		session = podmanTest.PodmanExitCleanly("create", "--spec", specFile, "--name", "spec-ctr2", "--cidfile", cidFile)
+		cid, err := os.ReadFile(cidFile)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(cid)).To(Equal(session.OutputToString()))
+		podmanTest.PodmanExitCleanly("create", "--spec", specFile, "--replace")
+
+		session = podmanTest.Podman([]string{"create", "--spec", specFile, "--env", "FOO=baz"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "--env cannot be set with --spec, set it in the spec file instead"))
+
+		session = podmanTest.Podman([]string{"create", "--spec", specFile, ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "the image and command cannot be set with --spec"))
+
+		if !IsRemote() {
+			podmanTest.PodmanExitCleanly("generate", "spec", "--filename", specFile, "spec-ctr")
+			podmanTest.PodmanExitCleanly("create", "--spec", specFile)
+			session = podmanTest.PodmanExitCleanly("inspect", "--format", "{{.Config.Cmd}} {{.Config.Labels.app}}", "spec-ctr-clone")
+			Expect(session.OutputToString()).To(Equal("[top] web"))
+		}
+	})
 })
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/dmikushin/podman-shared/cmd/podman/system"
+	"github.com/dmikushin/podman-shared/libpod/events"
 	. "github.com/dmikushin/podman-shared/test/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -170,6 +171,38 @@ var _ = Describe("Podman events", func() {
 		Expect(arr[0]).To(MatchRegexp("ID: [a-fA-F0-9]{64}"))
 	})
 
+	It("podman events --stream-format cloudevents", func() {
+		ctrName := "testCtr"
+		_, ec, cid := podmanTest.RunLsContainer(ctrName)
+		Expect(ec).To(Equal(0))
+
+		test := podmanTest.Podman([]string{"events", "--stream=false", "--filter", "container=" + ctrName, "--filter", "event=start", "--stream-format", "cloudevents"})
+		test.WaitWithDefaultTimeout()
+		Expect(test).To(ExitCleanly())
+		lines := test.OutputToStringArray()
+		Expect(lines).To(HaveLen(1))
+
+		event := events.CloudEvent{}
+		err := json.Unmarshal([]byte(lines[0]), &event)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(event.SpecVersion).To(Equal("1.0"))
+		Expect(event.Type).To(Equal("io.podman.container.start"))
+		Expect(event.Source).To(HavePrefix("podman://"))
+		Expect(event.Subject).To(Equal(cid))
+		Expect(event.ID).ToNot(BeEmpty())
+		Expect(event.Data.Name).To(Equal(ctrName))
+
+		// the same event has the same ID
+		test = podmanTest.Podman([]string{"events", "--stream=false", "--filter", "container=" + ctrName, "--filter", "event=start", "--stream-format", "cloudevents"})
+		test.WaitWithDefaultTimeout()
+		Expect(test).To(ExitCleanly())
+		Expect(test.OutputToStringArray()).To(Equal(lines))
+
+		test = podmanTest.Podman([]string{"events", "--stream=false", "--stream-format", "cloudevents", "--format", "json"})
+		test.WaitWithDefaultTimeout()
+		Expect(test).To(ExitWithError(125, "--stream-format and --format are mutually exclusive"))
+	})
+
 	It("podman events --until future", func() {
 		name1 := stringid.GenerateRandomID()
 		name2 := stringid.GenerateRandomID()
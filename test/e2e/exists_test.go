@@ -59,6 +59,16 @@ var _ = Describe("Podman image|container exists", func() {
 		session.WaitWithDefaultTimeout()
 		Expect(session).Should(ExitWithError(1, ""))
 	})
+	It("podman container exists with several containers", func() {
+		podmanTest.PodmanExitCleanly("create", "--name", "foo", ALPINE)
+		podmanTest.PodmanExitCleanly("create", "--name", "bar", ALPINE)
+
+		podmanTest.PodmanExitCleanly("container", "exists", "foo", "bar")
+
+		session := podmanTest.Podman([]string{"container", "exists", "foo", "foobar", "bar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(1, ""))
+	})
 
 	It("podman pod exists in local storage by name", func() {
 		setup, _, _ := podmanTest.CreatePod(map[string][]string{"--name": {"foobar"}})
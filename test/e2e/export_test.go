@@ -52,4 +52,37 @@ var _ = Describe("Podman export", func() {
 		result.WaitWithDefaultTimeout()
 		Expect(result).To(ExitWithError(125, "invalid filename (should not contain ':')"))
 	})
+	It("podman export --format oci-archive round-trip with import", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "exported", "-e", "EXPORTED=yes", "--label", "exported=label", "--workdir", "/tmp", "--entrypoint", "/bin/echo", ALPINE, "hello"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		outfile := filepath.Join(podmanTest.TempDir, "container-oci.tar")
+		result := podmanTest.Podman([]string{"export", "--format", "oci-archive", "-o", outfile, "exported"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+
+		result = podmanTest.Podman([]string{"import", "-q", outfile, "exported-image"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+
+		result = podmanTest.Podman([]string{"image", "inspect", "--format", "{{.Config.Env}} {{.Config.Entrypoint}} {{.Config.Cmd}} {{.Config.Labels.exported}} {{.Config.WorkingDir}} {{len .RootFS.Layers}}", "exported-image"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToString()).To(ContainSubstring("EXPORTED=yes"))
+		Expect(result.OutputToString()).To(HaveSuffix("[/bin/echo] [hello] label /tmp 1"))
+
+		result = podmanTest.Podman([]string{"run", "--rm", "exported-image"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToString()).To(Equal("hello"))
+
+		result = podmanTest.Podman([]string{"import", "--change", "CMD=/bin/sh", outfile})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError(125, "cannot be used when importing an OCI archive"))
+
+		result = podmanTest.Podman([]string{"export", "--format", "bogus", "exported"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError(125, `unsupported export format "bogus"`))
+	})
 })
@@ -169,6 +169,76 @@ var _ = Describe("Podman kube generate", func() {
 		Expect(pod.Spec.Containers[0].Ports[0].HostPort).To(Equal(int32(3890)))
 	})
 
+	It("ingress on pod", func() {
+		podName := "ingresspod"
+		session := podmanTest.Podman([]string{"pod", "create", "--name", podName, "-p", "8080:80", "-p", "8443:443",
+			"--label", define.KubeIngressHostLabel + "=example.com", "--label", define.KubeIngressPathLabel + "=/app",
+			"--label", define.KubeIngressPortLabel + "=443"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"create", "--pod", podName, CITEST_IMAGE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		kube := podmanTest.Podman([]string{"kube", "generate", "--ingress", "ingress", podName})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+
+		// The Service and Ingress come before the Pod
+		arr := strings.Split(string(kube.Out.Contents()), "---")
+		Expect(arr).To(HaveLen(3))
+
+		svc := new(v1.Service)
+		err := yaml.Unmarshal([]byte(arr[0]), svc)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(svc.Kind).To(Equal("Service"))
+		Expect(svc.Name).To(Equal(podName))
+
+		ingress := make(map[string]any)
+		err = yaml.Unmarshal([]byte(arr[1]), &ingress)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ingress).To(HaveKeyWithValue("apiVersion", "networking.k8s.io/v1"))
+		Expect(ingress).To(HaveKeyWithValue("kind", "Ingress"))
+		Expect(arr[1]).To(ContainSubstring("host: example.com"))
+		Expect(arr[1]).To(ContainSubstring("path: /app"))
+		Expect(arr[1]).To(ContainSubstring("name: " + podName))
+		Expect(arr[1]).To(ContainSubstring("number: 443"))
+
+		// An HTTPRoute needs a Gateway to attach to
+		kube = podmanTest.Podman([]string{"kube", "generate", "--ingress", "httproute", podName})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitWithError(125, "an HTTPRoute requires the "+define.KubeIngressGatewayLabel+" label"))
+
+		kube = podmanTest.Podman([]string{"kube", "generate", "--ingress", "foo", podName})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitWithError(125, `invalid ingress kind "foo"`))
+	})
+
+	It("httproute on container", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "test-ctr", "-p", "3890:3890",
+			"--label", define.KubeIngressGatewayLabel + "=infra/gateway", CITEST_IMAGE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		kube := podmanTest.Podman([]string{"kube", "generate", "--ingress", "httproute", "test-ctr"})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(ExitCleanly())
+
+		arr := strings.Split(string(kube.Out.Contents()), "---")
+		Expect(arr).To(HaveLen(3))
+
+		route := make(map[string]any)
+		err := yaml.Unmarshal([]byte(arr[1]), &route)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(route).To(HaveKeyWithValue("apiVersion", "gateway.networking.k8s.io/v1"))
+		Expect(route).To(HaveKeyWithValue("kind", "HTTPRoute"))
+		Expect(arr[1]).To(ContainSubstring("namespace: infra"))
+		Expect(arr[1]).To(ContainSubstring("name: gateway"))
+		Expect(arr[1]).To(ContainSubstring("value: /"))
+		Expect(arr[1]).To(ContainSubstring("port: 3890"))
+		Expect(arr[1]).ToNot(ContainSubstring("hostnames"))
+	})
+
 	It("on pod", func() {
 		_, rc, _ := podmanTest.CreatePod(map[string][]string{"--name": {"toppod"}})
 		Expect(rc).To(Equal(0))
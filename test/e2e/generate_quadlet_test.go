@@ -0,0 +1,81 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"encoding/json"
+	"os"
+
+	. "github.com/dmikushin/podman-shared/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman generate quadlet", func() {
+
+	It("podman generate quadlet on bogus container/pod", func() {
+		session := podmanTest.Podman([]string{"generate", "quadlet", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `foobar does not refer to a container or pod: no pod with name or ID foobar found: no such pod: no container with name or ID "foobar" found: no such container`))
+	})
+
+	It("podman generate quadlet container", func() {
+		podmanTest.PodmanExitCleanly("network", "create", "quadletnet")
+		podmanTest.PodmanExitCleanly("create", "--name", "web", "-v", "quadletvol:/data", "--network", "quadletnet",
+			"-p", "8080:80", "-e", "FOO=bar", "--label", "app=web", ALPINE, "top")
+
+		session := podmanTest.PodmanExitCleanly("generate", "quadlet", "web")
+		output := session.OutputToString()
+		Expect(output).To(ContainSubstring("# web.container"))
+		Expect(output).To(ContainSubstring("autogenerated by Podman"))
+		Expect(output).To(ContainSubstring("ContainerName=web"))
+		Expect(output).To(ContainSubstring("Image=" + ALPINE))
+		Expect(output).To(ContainSubstring("Exec=top"))
+		Expect(output).To(ContainSubstring("Environment=FOO=bar"))
+		Expect(output).To(ContainSubstring("Label=app=web"))
+		Expect(output).To(ContainSubstring("Volume=quadletvol.volume:/data"))
+		Expect(output).To(ContainSubstring("Network=quadletnet.network"))
+		Expect(output).To(ContainSubstring("PublishPort=8080:80"))
+		Expect(output).To(ContainSubstring("VolumeName=quadletvol"))
+		Expect(output).To(ContainSubstring("NetworkName=quadletnet"))
+		Expect(output).ToNot(ContainSubstring("container=podman"))
+
+		session = podmanTest.PodmanExitCleanly("generate", "quadlet", "--no-header", "--format", "json", "web")
+		units := make(map[string]string)
+		err := json.Unmarshal(session.Out.Contents(), &units)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(units).To(HaveLen(3))
+		Expect(units).To(HaveKey("web.container"))
+		Expect(units).To(HaveKey("quadletvol.volume"))
+		Expect(units).To(HaveKey("quadletnet.network"))
+		Expect(units["web.container"]).ToNot(ContainSubstring("autogenerated by"))
+	})
+
+	It("podman generate quadlet pod --files", func() {
+		podmanTest.PodmanExitCleanly("pod", "create", "--name", "app", "-p", "8080:80")
+		podmanTest.PodmanExitCleanly("create", "--pod", "app", "--name", "app-web", ALPINE, "top")
+
+		session := podmanTest.Podman([]string{"generate", "quadlet", "app-web"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "is part of a pod, generate the units for its pod instead"))
+
+		session = podmanTest.PodmanExitCleanly("generate", "quadlet", "--files", "app")
+		files := session.OutputToStringArray()
+		for _, file := range files {
+			defer os.Remove(file)
+		}
+		Expect(files).To(HaveLen(2))
+		Expect(files[0]).To(HaveSuffix("/app-web.container"))
+		Expect(files[1]).To(HaveSuffix("/app.pod"))
+
+		content, err := os.ReadFile(files[0])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("Pod=app.pod"))
+		Expect(string(content)).ToNot(ContainSubstring("PublishPort="))
+
+		content, err = os.ReadFile(files[1])
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(ContainSubstring("PodName=app"))
+		Expect(string(content)).To(ContainSubstring("PublishPort=8080:80"))
+	})
+})
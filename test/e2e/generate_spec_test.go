@@ -3,8 +3,11 @@
 package integration
 
 import (
+	"encoding/json"
+	"os"
 	"path/filepath"
 
+	"github.com/dmikushin/podman-shared/pkg/specgen"
 	. "github.com/dmikushin/podman-shared/test/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -68,4 +71,33 @@ var _ = Describe("Podman generate spec", func() {
 			Expect(session).Should(ExitCleanly())
 		}
 	})
+
+	It("generate spec --strip-host-specific", func() {
+		hostDir := filepath.Join(tempdir, "data")
+		err := os.Mkdir(hostDir, 0755)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("create", "--name", "portable", "-v", hostDir+":/data", "-v", "portablevol:/vol",
+			"--mac-address", "92:d0:c6:0a:29:33", "--uidmap", "0:100000:5000", "--gidmap", "0:100000:5000", ALPINE, "top")
+
+		session := podmanTest.PodmanExitCleanly("generate", "spec", "--compact", "portable")
+		Expect(session.OutputToString()).To(ContainSubstring(hostDir))
+
+		session = podmanTest.Podman([]string{"generate", "spec", "--compact", "--strip-host-specific", "portable"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.ErrorToString()).To(ContainSubstring("Dropping bind mount of host path " + hostDir + " from the portable spec"))
+
+		s := new(specgen.SpecGenerator)
+		err = json.Unmarshal(session.Out.Contents(), s)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(s.Image).To(Equal(ALPINE))
+		Expect(s.Mounts).To(BeEmpty())
+		Expect(s.Volumes).To(HaveLen(1))
+		Expect(s.Volumes[0].Name).To(Equal("portablevol"))
+		Expect(s.IDMappings).To(BeNil())
+		Expect(s.ContainerCreateCommand).To(BeNil())
+		for _, opts := range s.Networks {
+			Expect(opts.StaticMAC).To(BeEmpty())
+		}
+	})
 })
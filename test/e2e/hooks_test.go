@@ -0,0 +1,65 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/dmikushin/podman-shared/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman hooks", func() {
+	var hooksDir string
+
+	BeforeEach(func() {
+		SkipIfRemote("--hooks-dir does not work with remote")
+		hooksDir = filepath.Join(podmanTest.TempDir, "hooks")
+		err := os.Mkdir(hooksDir, 0755)
+		Expect(err).ToNot(HaveOccurred())
+		hooks := map[string]string{
+			"always.json": `{"version": "1.0.0", "hook": {"path": "/bin/true"}, "when": {"always": true}, "stages": ["poststop"]}`,
+			"debug.json":  `{"version": "1.0.0", "hook": {"path": "/bin/true"}, "when": {"annotations": {"^com.example.debug$": "^true$"}}, "stages": ["prestart"]}`,
+			"broken.json": `{"version": "1.0.0", "hook": {}, "stages": ["prestart"]}`,
+		}
+		for name, content := range hooks {
+			err := os.WriteFile(filepath.Join(hooksDir, name), []byte(content), 0644)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	It("podman hooks list and validate", func() {
+		session := podmanTest.PodmanExitCleanly("--hooks-dir", hooksDir, "hooks", "list", "--format", "{{.Name}} {{.Stages}} {{.When}}")
+		Expect(session.OutputToStringArray()).To(Equal([]string{
+			"always.json poststop always=true",
+			"broken.json prestart ",
+			"debug.json prestart annotation ^com.example.debug$=^true$",
+		}))
+
+		session = podmanTest.Podman([]string{"--hooks-dir", hooksDir, "hooks", "validate"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, filepath.Join(hooksDir, "broken.json")+": missing required property: hook.path"))
+		Expect(session.OutputToStringArray()).To(Equal([]string{filepath.Join(hooksDir, "always.json"), filepath.Join(hooksDir, "debug.json")}))
+
+		podmanTest.PodmanExitCleanly("hooks", "validate", filepath.Join(hooksDir, "debug.json"))
+
+		session = podmanTest.PodmanExitCleanly("--hooks-dir", hooksDir, "hooks", "reload", "--quiet")
+		Expect(session.OutputToStringArray()).To(Equal([]string{"always.json", "broken.json", "debug.json"}))
+	})
+
+	It("podman hooks test", func() {
+		podmanTest.PodmanExitCleanly("create", "--name", "plain", ALPINE, "true")
+		podmanTest.PodmanExitCleanly("create", "--name", "debug", "--annotation", "com.example.debug=true", ALPINE, "true")
+
+		session := podmanTest.PodmanExitCleanly("--hooks-dir", hooksDir, "hooks", "test", "--quiet", "plain")
+		Expect(session.OutputToStringArray()).To(Equal([]string{"always.json"}))
+
+		session = podmanTest.PodmanExitCleanly("--hooks-dir", hooksDir, "hooks", "test", "--quiet", "debug")
+		Expect(session.OutputToStringArray()).To(Equal([]string{"always.json", "debug.json"}))
+
+		session = podmanTest.PodmanExitCleanly("--hooks-dir", hooksDir, "hooks", "test", "--format", "{{.Name}} {{.Matched}}", "plain")
+		Expect(session.OutputToStringArray()).To(Equal([]string{"always.json true", "broken.json false", "debug.json false"}))
+	})
+})
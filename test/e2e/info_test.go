@@ -68,6 +68,36 @@ var _ = Describe("Podman Info", func() {
 		Expect(session.OutputToString()).To(ContainSubstring("bridge"))
 	})
 
+	It("podman info lists OCI hooks", func() {
+		SkipIfRemote("--hooks-dir does not work with remote")
+		hooksDir := filepath.Join(podmanTest.TempDir, "hooks")
+		err := os.Mkdir(hooksDir, 0755)
+		Expect(err).ToNot(HaveOccurred())
+		hookJSON := `{
+	"version": "1.0.0",
+	"hook": { "path": "/bin/true" },
+	"when": { "always": true },
+	"stages": [ "prestart" ]
+}
+`
+		err = os.WriteFile(filepath.Join(hooksDir, "infohook.json"), []byte(hookJSON), 0644)
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"--hooks-dir", hooksDir, "info", "--format", "{{range .Plugins.Hooks}}{{.Name}} {{.Path}} {{.Stages}}{{end}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("infohook.json " + filepath.Join(hooksDir, "infohook.json") + " [prestart]"))
+	})
+
+	It("podman info reports the wasm runtime", func() {
+		session := podmanTest.PodmanExitCleanly("info", "--format", "{{.Host.Wasm.Available}}")
+		Expect(session.OutputToString()).To(Or(Equal("true"), Equal("false")))
+		if session.OutputToString() == "true" {
+			session = podmanTest.PodmanExitCleanly("info", "--format", "{{.Host.Wasm.Runtime}}")
+			Expect(session.OutputToString()).ToNot(BeEmpty())
+		}
+	})
+
 	It("podman info rootless storage path", func() {
 		SkipIfNotRootless("test of rootless_storage_path is only meaningful as rootless")
 		SkipIfRemote("Only tests storage on local client")
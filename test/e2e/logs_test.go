@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/format"
+	. "github.com/onsi/gomega/gbytes"
 	. "github.com/onsi/gomega/gexec"
 	"go.podman.io/storage/pkg/stringid"
 )
@@ -617,7 +618,6 @@ var _ = Describe("Podman logs", func() {
 	})
 
 	It("podman pod logs with container names", func() {
-		SkipIfRemote("Remote can only process one container at a time")
 		podName := "testPod"
 		containerName1 := "container1"
 		containerName2 := "container2"
@@ -646,7 +646,6 @@ var _ = Describe("Podman logs", func() {
 		}).Should(Succeed())
 	})
 	It("podman pod logs with different colors", func() {
-		SkipIfRemote("Remote can only process one container at a time")
 		podName := "testPod"
 		containerName1 := "container1"
 		containerName2 := "container2"
@@ -670,6 +669,25 @@ var _ = Describe("Podman logs", func() {
 			g.Expect(output[1]).To(MatchRegexp(`\x1b\[3[0-9a-z ]+\x1b\[0m`))
 		}).Should(Succeed())
 	})
+
+	It("podman pod logs --follow with containers joining the pod", func() {
+		podName := "testPod"
+		podmanTest.PodmanExitCleanly("pod", "create", "--name", podName)
+		podmanTest.PodmanExitCleanly("run", "--name", "container1", "--pod", podName, BB, "echo", "log1")
+		podmanTest.PodmanExitCleanly("pod", "start", podName)
+
+		results := podmanTest.Podman([]string{"pod", "logs", "--follow", "--names", podName})
+		// Wait for the logs of the first container before adding one.
+		Eventually(results.Out).WithTimeout(30 * time.Second).Should(Say("container1 log1"))
+
+		podmanTest.PodmanExitCleanly("run", "--name", "container2", "--pod", podName, BB, "echo", "log2")
+		Eventually(results.Out).WithTimeout(30 * time.Second).Should(Say("container2 log2"))
+
+		// The logs end with the last running container of the pod.
+		podmanTest.PodmanExitCleanly("pod", "stop", "-t0", podName)
+		results.WaitWithDefaultTimeout()
+		Expect(results).To(ExitCleanly())
+	})
 })
 
 func setLangEnv(lang string) func() {
@@ -0,0 +1,26 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	. "github.com/dmikushin/podman-shared/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman container migrate", func() {
+
+	It("podman container migrate requires a destination", func() {
+		podmanTest.PodmanExitCleanly("create", "--name", "migrated", ALPINE, "top")
+
+		session := podmanTest.Podman([]string{"container", "migrate", "migrated"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `required flag(s) "destination" not set`))
+
+		session = podmanTest.Podman([]string{"container", "migrate", "--destination", "nonexistent", "migrated"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `connection "nonexistent" not found`))
+
+		podmanTest.PodmanExitCleanly("container", "exists", "migrated")
+	})
+})
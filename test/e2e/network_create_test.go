@@ -401,6 +401,37 @@ var _ = Describe("Podman network create", func() {
 		Expect(nc).To(ExitWithError(125, "invalid CIDR address: 10.11.12.0/17000"))
 	})
 
+	It("podman network create with invalid SR-IOV options", func() {
+		nc := podmanTest.Podman([]string{"network", "create", "-o", "sriov_vf=auto", stringid.GenerateRandomID()})
+		nc.WaitWithDefaultTimeout()
+		Expect(nc).To(ExitWithError(125, "option sriov_vf is only supported with the macvlan and ipvlan drivers"))
+
+		nc = podmanTest.Podman([]string{"network", "create", "-d", "macvlan", "-o", "sriov_mac=02:11:22:33:44:55", stringid.GenerateRandomID()})
+		nc.WaitWithDefaultTimeout()
+		Expect(nc).To(ExitWithError(125, "option sriov_mac requires option sriov_vf"))
+	})
+
+	It("podman network create with invalid WireGuard options", func() {
+		SkipIfCNI(podmanTest)
+		nc := podmanTest.Podman([]string{"network", "create", "-d", "wireguard", stringid.GenerateRandomID()})
+		nc.WaitWithDefaultTimeout()
+		Expect(nc).To(ExitWithError(125, "wireguard networks require the endpoint option"))
+
+		nc = podmanTest.Podman([]string{"network", "create", "-d", "wireguard", "-o", "endpoint=host1", stringid.GenerateRandomID()})
+		nc.WaitWithDefaultTimeout()
+		Expect(nc).To(ExitWithError(125, `invalid endpoint option "host1"`))
+
+		// the name is checked before the key is stored under the graph root
+		nc = podmanTest.Podman([]string{"network", "create", "-d", "wireguard", "-o", "endpoint=1.2.3.4:51820", "../.."})
+		nc.WaitWithDefaultTimeout()
+		Expect(nc).To(ExitWithError(125, "network name ../.. invalid"))
+		Expect(podmanTest.Root).To(BeADirectory())
+
+		nc = podmanTest.Podman([]string{"network", "peer", "podman"})
+		nc.WaitWithDefaultTimeout()
+		Expect(nc).To(ExitWithError(125, "at least one connection or --farm is required"))
+	})
+
 	It("podman network create with ipv4 subnet and ipv6 flag", func() {
 		name := stringid.GenerateRandomID()
 		nc := podmanTest.Podman([]string{"network", "create", "--subnet", "10.11.14.0/24", "--ipv6", name})
@@ -567,6 +598,28 @@ var _ = Describe("Podman network create", func() {
 		Expect(nc.OutputToString()).To(ContainSubstring(`"dns_enabled": true`))
 	})
 
+	It("podman network create with --dns-cache", func() {
+		SkipIfCNI(podmanTest)
+		net := "dns-cache-test" + stringid.GenerateRandomID()
+		nc := podmanTest.Podman([]string{"network", "create", "--dns-cache", net})
+		nc.WaitWithDefaultTimeout()
+		defer podmanTest.removeNetwork(net)
+		Expect(nc).Should(ExitCleanly())
+
+		inspect := podmanTest.Podman([]string{"network", "inspect", "--format", `{{index .Labels "io.podman.network.dns_cache"}}`, net})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("true"))
+
+		nc = podmanTest.Podman([]string{"network", "create", "--dns-cache", "--disable-dns", stringid.GenerateRandomID()})
+		nc.WaitWithDefaultTimeout()
+		Expect(nc).To(ExitWithError(125, "--dns-cache and --disable-dns cannot be used together"))
+
+		session := podmanTest.Podman([]string{"create", "--network", net + ":dns_cache=maybe", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `invalid DNS cache setting "maybe", must be true or false`))
+	})
+
 	It("podman network create with invalid name", func() {
 		for _, name := range []string{"none", "host", "bridge", "private", "slirp4netns", "pasta", "container", "ns", "default"} {
 			nc := podmanTest.Podman([]string{"network", "create", name})
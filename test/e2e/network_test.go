@@ -782,4 +782,21 @@ var _ = Describe("Podman network", func() {
 		Expect(listAgain.OutputToStringArray()).Should(ContainElement(net2))
 		Expect(listAgain.OutputToStringArray()).Should(ContainElement("podman"))
 	})
+
+	It("podman network connections", func() {
+		ctrName := "ctr-" + stringid.GenerateRandomID()
+		podmanTest.PodmanExitCleanly("create", "--name", ctrName, "--track-flows", ALPINE, "top")
+
+		// connections are only tracked while the container runs
+		session := podmanTest.PodmanExitCleanly("network", "connections", "--noheading", ctrName)
+		Expect(session.OutputToString()).To(BeEmpty())
+
+		podmanTest.PodmanExitCleanly("start", ctrName)
+		session = podmanTest.PodmanExitCleanly("network", "connections", "--format", "json", ctrName)
+		Expect(session.OutputToString()).To(BeValidJSON())
+
+		session = podmanTest.Podman([]string{"network", "connections", "no-such-ctr"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `no such container "no-such-ctr"`))
+	})
 })
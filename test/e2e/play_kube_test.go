@@ -4646,6 +4646,53 @@ invalid kube kind
 		Expect(exists).To(ExitWithError(1, ""))
 	})
 
+	It("teardown with --volumes --configmaps --secrets --force-timeout", func() {
+		pvcName := RandomString(12)
+		pvc := getPVC(withPVCName(pvcName))
+		pvcYaml, err := getKubeYaml("persistentVolumeClaim", pvc)
+		Expect(err).ToNot(HaveOccurred())
+
+		cmName := "cm-vol"
+		cm := getConfigMap(withConfigMapName(cmName), withConfigMapData("foo", "bar"))
+		cmYaml, err := getKubeYaml("configmap", cm)
+		Expect(err).ToNot(HaveOccurred())
+
+		ctr := getCtr(withVolumeMount("/test", "", false), withImage(CITEST_IMAGE))
+		pod := getPod(withVolume(getConfigMapVolume(cmName, nil, false, nil)), withCtr(ctr))
+		podYaml, err := getKubeYaml("pod", pod)
+		Expect(err).ToNot(HaveOccurred())
+
+		err = generateMultiDocKubeYaml([]string{pvcYaml, cmYaml, secretYaml, podYaml}, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		podmanTest.PodmanExitCleanly("kube", "play", kubeYaml)
+		teardown := podmanTest.PodmanExitCleanly("kube", "down", "--secrets=false", "--force-timeout", "0", kubeYaml)
+		Expect(teardown.OutputToString()).ToNot(ContainSubstring("ConfigMap and Secret volumes removed"))
+		exists := podmanTest.Podman([]string{"pod", "exists", pod.Name})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).To(ExitWithError(1, ""))
+		podmanTest.PodmanExitCleanly("secret", "exists", "newsecret")
+		podmanTest.PodmanExitCleanly("volume", "exists", pvcName)
+		podmanTest.PodmanExitCleanly("volume", "exists", cmName)
+
+		podmanTest.PodmanExitCleanly("kube", "play", kubeYaml)
+		teardown = podmanTest.PodmanExitCleanly("kube", "down", "--configmaps", kubeYaml)
+		Expect(teardown.OutputToString()).To(ContainSubstring("ConfigMap and Secret volumes removed: " + cmName))
+		exists = podmanTest.Podman([]string{"secret", "exists", "newsecret"})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).To(ExitWithError(1, ""))
+		exists = podmanTest.Podman([]string{"volume", "exists", cmName})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).To(ExitWithError(1, ""))
+		podmanTest.PodmanExitCleanly("volume", "exists", pvcName)
+
+		teardown = podmanTest.PodmanExitCleanly("kube", "down", "--volumes", kubeYaml)
+		Expect(teardown.OutputToString()).To(ContainSubstring("Volumes removed: " + pvcName))
+		exists = podmanTest.Podman([]string{"volume", "exists", pvcName})
+		exists.WaitWithDefaultTimeout()
+		Expect(exists).To(ExitWithError(1, ""))
+	})
+
 	It("after teardown with volume reuse", func() {
 
 		volName := RandomString(12)
@@ -6169,6 +6216,36 @@ EXPOSE 2004-2005/tcp`, CITEST_IMAGE)
 		Expect(inspect.OutputToString()).To(Equal("20"))
 	})
 
+	It("with runtimeClassName", func() {
+		runtime := filepath.Base(podmanTest.OCIRuntime)
+		classYaml := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: test-pod
+spec:
+  runtimeClassName: %s
+  containers:
+    - name: alpine
+      image: ` + CITEST_IMAGE + `
+      command:
+        - top
+`
+
+		err := writeYaml(fmt.Sprintf(classYaml, runtime), kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("kube", "play", "--start=false", kubeYaml)
+		inspect := podmanTest.PodmanExitCleanly("inspect", "test-pod-alpine", "--format", "{{.RuntimeClass}}")
+		Expect(inspect.OutputToString()).To(Equal(runtime))
+
+		podmanTest.PodmanExitCleanly("kube", "down", kubeYaml)
+		err = writeYaml(fmt.Sprintf(classYaml, "no-such-class"), kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).To(ExitWithError(125, "runtime class no-such-class is not defined"))
+	})
+
 	It("hostname should be node name when hostNetwork=true", func() {
 		netYaml := `
 apiVersion: v1
@@ -6420,4 +6497,115 @@ spec:
 
 		Expect(testfile).ToNot(BeAnExistingFile(), "file should never be created on the host")
 	})
+
+	It("NetworkPolicy with --network-policy", func() {
+		if _, err := exec.LookPath("nft"); err != nil {
+			Skip("network policies require nft")
+		}
+		policyYaml := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  containers:
+  - name: web
+    image: ` + CITEST_IMAGE + `
+    command: ["nc", "-lk", "-p", "8080", "-e", "/bin/cat"]
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: client
+  labels:
+    app: client
+spec:
+  containers:
+  - name: client
+    image: ` + CITEST_IMAGE + `
+    command: ["top"]
+---
+apiVersion: v1
+kind: Pod
+metadata:
+  name: other
+  labels:
+    app: other
+spec:
+  containers:
+  - name: other
+    image: ` + CITEST_IMAGE + `
+    command: ["top"]
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: web
+spec:
+  podSelector:
+    matchLabels:
+      app: web
+  ingress:
+  - from:
+    - podSelector:
+        matchLabels:
+          app: client
+    ports:
+    - port: 8080
+`
+		err := writeYaml(policyYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).Should(Exit(0))
+		if !IsRemote() {
+			Expect(kube.ErrorToString()).To(ContainSubstring("Kube kind NetworkPolicy is only enforced with --network-policy"))
+		}
+		podmanTest.PodmanExitCleanly("kube", "down", kubeYaml)
+
+		podmanTest.PodmanExitCleanly("kube", "play", "--network-policy", kubeYaml)
+		inspect := podmanTest.PodmanExitCleanly("inspect", "web-web", "--format", "{{range .NetworkSettings.Networks}}{{.IPAddress}}{{end}}")
+		ip := inspect.OutputToString()
+		Expect(ip).ToNot(BeEmpty())
+
+		allowed := podmanTest.PodmanExitCleanly("exec", "client-client", "sh", "-c", "echo hello | nc -w 2 "+ip+" 8080")
+		Expect(allowed.OutputToString()).To(Equal("hello"))
+
+		denied := podmanTest.Podman([]string{"exec", "other-other", "sh", "-c", "echo hello | nc -w 2 " + ip + " 8080"})
+		denied.WaitWithDefaultTimeout()
+		Expect(denied.OutputToString()).To(BeEmpty())
+	})
+
+	It("NetworkPolicy selecting a pod on the host network", func() {
+		policyYaml := `
+apiVersion: v1
+kind: Pod
+metadata:
+  name: web
+  labels:
+    app: web
+spec:
+  hostNetwork: true
+  containers:
+  - name: web
+    image: ` + CITEST_IMAGE + `
+    command: ["top"]
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: deny
+spec:
+  podSelector: {}
+`
+		err := writeYaml(policyYaml, kubeYaml)
+		Expect(err).ToNot(HaveOccurred())
+
+		kube := podmanTest.Podman([]string{"kube", "play", "--network-policy", kubeYaml})
+		kube.WaitWithDefaultTimeout()
+		Expect(kube).To(ExitWithError(125, "NetworkPolicy deny: only pods with an infra container on bridge networks can be isolated, pod web cannot"))
+	})
 })
@@ -136,6 +136,26 @@ var _ = Describe("Podman ps", func() {
 
 	})
 
+	It("podman pod ps json-stream format", func() {
+		podmanTest.PodmanExitCleanly("pod", "create", "--name", "pod1")
+		podmanTest.PodmanExitCleanly("pod", "create", "--name", "pod2")
+
+		result := podmanTest.PodmanExitCleanly("pod", "ps", "--format", "json-stream")
+		lines := result.OutputToStringArray()
+		Expect(lines).To(HaveLen(2))
+		for _, line := range lines {
+			Expect(line).To(BeValidJSON())
+		}
+
+		watch := podmanTest.Podman([]string{"pod", "ps", "--watch", "600", "--format", "json-stream"})
+		defer watch.Kill()
+		Eventually(watch.OutputToStringArray, "10s", "200ms").Should(HaveLen(2))
+
+		podmanTest.PodmanExitCleanly("pod", "rm", "pod1")
+		Eventually(watch.OutputToString, "20s", "200ms").Should(ContainSubstring(`"Removed":true`))
+		Expect(watch.OutputToString()).To(ContainSubstring(`"Name":"pod1"`))
+	})
+
 	It("podman pod ps --sort by name", func() {
 		_, ec, _ := podmanTest.CreatePod(nil)
 		Expect(ec).To(Equal(0))
@@ -0,0 +1,72 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	. "github.com/dmikushin/podman-shared/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman pod update", func() {
+
+	It("podman pod update bogus pod", func() {
+		session := podmanTest.Podman([]string{"pod", "update", "--publish-add", "8080:80", "foobar"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no pod with name or ID foobar found: no such pod"))
+	})
+
+	It("podman pod update --publish-add --publish-remove", func() {
+		port1, port2 := GetPort(), GetPort()
+		podmanTest.PodmanExitCleanly("pod", "create", "--name", "pubpod", "-p", fmt.Sprintf("%d:80", port1))
+
+		session := podmanTest.Podman([]string{"pod", "update", "pubpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "must provide at least one port to publish or remove to update a pod"))
+
+		session = podmanTest.Podman([]string{"pod", "update", "--publish-remove", "81", "pubpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "container port 81/tcp is not published"))
+
+		session = podmanTest.Podman([]string{"pod", "update", "--publish-add", fmt.Sprintf("%d:81", port1), "pubpod"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, fmt.Sprintf("host port %d/tcp is published more than once", port1)))
+
+		podmanTest.PodmanExitCleanly("pod", "update", "--publish-remove", fmt.Sprintf("%d:80", port1), "--publish-add", fmt.Sprintf("%d:80", port2), "--publish-add", "53/udp", "pubpod")
+		inspect := podmanTest.PodmanExitCleanly("pod", "inspect", "--format", "{{range $port, $hosts := .InfraConfig.PortBindings}}{{$port}}={{(index $hosts 0).HostPort}} {{end}}", "pubpod")
+		Expect(inspect.OutputToString()).To(ContainSubstring(fmt.Sprintf("80/tcp=%d", port2)))
+		Expect(inspect.OutputToString()).To(MatchRegexp(`53/udp=\d+`))
+		Expect(inspect.OutputToString()).ToNot(ContainSubstring(fmt.Sprintf("=%d ", port1)))
+	})
+
+	It("podman pod update --publish-add on a running pod", func() {
+		port1, port2 := GetPort(), GetPort()
+		podmanTest.PodmanExitCleanly("pod", "create", "--name", "pubpod", "-p", fmt.Sprintf("%d:80", port1))
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", "pubctr", "--pod", "pubpod", BB, "httpd", "-f", "-p", "80")
+
+		session := podmanTest.Podman([]string{"pod", "update", "--publish-remove", "80", "--publish-add", fmt.Sprintf("%d:80", port2), "pubpod"})
+		session.WaitWithDefaultTimeout()
+		if isRootless() {
+			Expect(session).Should(ExitWithError(125, "requires rootful bridge networking, stop it first"))
+			return
+		}
+		Expect(session).Should(ExitCleanly())
+
+		Eventually(func() error {
+			conn, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port2), time.Second)
+			if err == nil {
+				conn.Close()
+			}
+			return err
+		}).WithTimeout(10 * time.Second).Should(Succeed())
+		_, err := net.DialTimeout("tcp", fmt.Sprintf("127.0.0.1:%d", port1), time.Second)
+		Expect(err).To(HaveOccurred())
+
+		port := podmanTest.PodmanExitCleanly("port", "pubctr")
+		Expect(port.OutputToString()).To(Equal(fmt.Sprintf("80/tcp -> 0.0.0.0:%d", port2)))
+	})
+})
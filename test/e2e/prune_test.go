@@ -503,6 +503,24 @@ var _ = Describe("Podman prune", func() {
 		Expect(prune).Should(ExitWithError(125, "--external cannot be combined with other options"))
 	})
 
+	It("podman system prune --dry-run", func() {
+		session := podmanTest.Podman([]string{"create", ALPINE, "ls"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		cid := session.OutputToString()
+
+		prune := podmanTest.Podman([]string{"system", "prune", "--dry-run"})
+		prune.WaitWithDefaultTimeout()
+		Expect(prune).Should(ExitCleanly())
+		Expect(prune.OutputToString()).To(ContainSubstring("Containers that would be deleted"))
+		Expect(prune.OutputToString()).To(ContainSubstring(cid))
+		Expect(prune.OutputToString()).To(ContainSubstring("Total reclaimable space:"))
+
+		session = podmanTest.Podman([]string{"container", "exists", cid})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+	})
+
 	It("podman system prune --external leaves referenced containers", func() {
 		useCustomNetworkDir(podmanTest, tempdir)
 		containerStorageDir := filepath.Join(podmanTest.Root, podmanTest.ImageCacheFS+"-containers")
@@ -4,6 +4,8 @@ package integration
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"regexp"
 	"sort"
 	"strconv"
@@ -76,6 +78,36 @@ var _ = Describe("Podman ps", func() {
 		Expect(result.OutputToStringArray()).ShouldNot(BeEmpty())
 	})
 
+	It("podman ps size is updated when the container changes", func() {
+		session := podmanTest.Podman([]string{"create", "--name", "sizectr", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		rwSize := func() int {
+			result := podmanTest.Podman([]string{"container", "inspect", "--size", "--format", "{{.SizeRw}}", "sizectr"})
+			result.WaitWithDefaultTimeout()
+			Expect(result).Should(ExitCleanly())
+			size, err := strconv.Atoi(result.OutputToString())
+			Expect(err).ToNot(HaveOccurred())
+			return size
+		}
+		// The second time the size comes from the cache.
+		sizeBefore := rwSize()
+		Expect(rwSize()).To(Equal(sizeBefore))
+
+		file := filepath.Join(podmanTest.TempDir, "file")
+		Expect(os.WriteFile(file, make([]byte, 1000000), 0o644)).To(Succeed())
+		session = podmanTest.Podman([]string{"cp", file, "sizectr:/file"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(rwSize()).To(BeNumerically(">=", sizeBefore+1000000))
+
+		result := podmanTest.Podman([]string{"ps", "-a", "--size", "--format", "{{.Size}}"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToString()).To(MatchRegexp(`^1(\.\d+)?MB `))
+	})
+
 	It("podman ps quiet flag", func() {
 		_, ec, fullCid := podmanTest.RunLsContainer("")
 		Expect(ec).To(Equal(0))
@@ -284,6 +316,32 @@ var _ = Describe("Podman ps", func() {
 		Expect(StatusLine[0]).To(ContainSubstring("Exited"))
 	})
 
+	It("podman ps json-stream format", func() {
+		podmanTest.RunTopContainer("test1").WaitWithDefaultTimeout()
+		podmanTest.PodmanExitCleanly("create", "--name", "test2", ALPINE, "ls")
+
+		result := podmanTest.PodmanExitCleanly("ps", "-a", "--format", "json-stream")
+		lines := result.OutputToStringArray()
+		Expect(lines).To(HaveLen(2))
+		for _, line := range lines {
+			Expect(line).To(BeValidJSON())
+			Expect(line).To(ContainSubstring(`"Created":`))
+			Expect(line).ToNot(ContainSubstring(`"Removed"`))
+		}
+	})
+
+	It("podman ps --watch json-stream refreshes on events", func() {
+		podmanTest.RunTopContainer("test1").WaitWithDefaultTimeout()
+
+		// The interval is long enough for the refresh to come from the events.
+		watch := podmanTest.Podman([]string{"ps", "-a", "--watch", "600", "--format", "json-stream"})
+		defer watch.Kill()
+		Eventually(watch.OutputToStringArray, "10s", "200ms").Should(HaveLen(1))
+
+		podmanTest.PodmanExitCleanly("rm", "-f", "-t0", "test1")
+		Eventually(watch.OutputToString, "20s", "200ms").Should(ContainSubstring(`"Removed":true`))
+	})
+
 	It("podman ps namespace flag with go template format", func() {
 		_, ec, _ := podmanTest.RunLsContainer("test1")
 		Expect(ec).To(Equal(0))
@@ -403,6 +461,71 @@ var _ = Describe("Podman ps", func() {
 		Expect(psAll.OutputToString()).To(Equal(psFilter.OutputToString()))
 	})
 
+	It("podman ps filter by exited-range, created-since and image-digest", func() {
+		for _, code := range []int{0, 3, 130} {
+			session := podmanTest.Podman([]string{"run", "--name", fmt.Sprintf("exit%d", code), ALPINE, "sh", "-c", fmt.Sprintf("exit %d", code)})
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(Exit(code))
+		}
+
+		result := podmanTest.Podman([]string{"ps", "-a", "--format", "{{.Names}}", "--sort", "names", "--filter", "exited-range=1-127"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToStringArray()).To(Equal([]string{"exit3"}))
+
+		result = podmanTest.Podman([]string{"ps", "-a", "--format", "{{.Names}}", "--sort", "names", "--filter", "exited-range=1-127", "--filter", "exited-range=128-255"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToStringArray()).To(Equal([]string{"exit130", "exit3"}))
+
+		result = podmanTest.Podman([]string{"ps", "-a", "--filter", "exited-range=5"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitWithError(125, `invalid exited-range "5": must be MIN-MAX`))
+
+		result = podmanTest.Podman([]string{"ps", "-aq", "--filter", "created-since=1h"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToStringArray()).To(HaveLen(3))
+
+		result = podmanTest.Podman([]string{"ps", "-aq", "--filter", "created-since=2100-01-01"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToString()).To(BeEmpty())
+
+		inspect := podmanTest.Podman([]string{"image", "inspect", "--format", "{{.Digest}}", ALPINE})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		result = podmanTest.Podman([]string{"ps", "-aq", "--filter", "image-digest=" + inspect.OutputToString()})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToStringArray()).To(HaveLen(3))
+
+		result = podmanTest.Podman([]string{"ps", "-aq", "--filter", "image-digest=sha256:0000000000000000000000000000000000000000000000000000000000000000"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToString()).To(BeEmpty())
+	})
+
+	It("podman ps --sort memory", func() {
+		session := podmanTest.Podman([]string{"run", "-d", "--name", "small", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"create", "--name", "stopped", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		// stopped containers use no memory
+		result := podmanTest.Podman([]string{"ps", "-a", "--sort", "memory", "--format", "{{.Names}}"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToStringArray()).To(Equal([]string{"stopped", "small"}))
+
+		result = podmanTest.Podman([]string{"ps", "-a", "--sort", "memory", "--last", "1", "--format", "{{.Names}}"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToStringArray()).To(Equal([]string{"stopped"}))
+	})
+
 	It("podman filter without status does not find non-running", func() {
 		ctrName := "aContainerName"
 		ctr := podmanTest.Podman([]string{"create", "--name", ctrName, ALPINE, "ls", "/"})
@@ -457,6 +580,14 @@ var _ = Describe("Podman ps", func() {
 		session = podmanTest.Podman([]string{"ps", "-a", "--ns", "-s"})
 		session.WaitWithDefaultTimeout()
 		Expect(session).To(ExitWithError(125, "size and namespace options conflict"))
+
+		session = podmanTest.Podman([]string{"ps", "--watch", "1", "--latest"})
+		session.WaitWithDefaultTimeout()
+		if IsRemote() {
+			Expect(session).To(ExitWithError(125, "unknown flag: --latest"))
+		} else {
+			Expect(session).To(ExitWithError(125, "the watch and latest flags cannot be used together"))
+		}
 	})
 
 	It("podman --format by size", func() {
@@ -133,6 +133,14 @@ var _ = Describe("Podman pull", func() {
 		Expect(session.ErrorToString()).To(ContainSubstring("A driver was picked automatically."))
 	})
 
+	It("podman pull --to-shared without shared image store", func() {
+		SkipIfRemote("--to-shared is not supported on remote clients")
+		// The test storage is never on shared storage.
+		session := podmanTest.Podman([]string{"pull", "-q", "--to-shared", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "no additional image store on shared storage is set, see additionalimagestores in containers-storage.conf(5): invalid argument"))
+	})
+
 	It("podman pull by digest", func() {
 		session := podmanTest.Podman([]string{"pull", "-q", "quay.io/libpod/testdigest_v2s2@sha256:755f4d90b3716e2bf57060d249e2cd61c9ac089b1233465c5c2cb2d7ee550fdb"})
 		session.WaitWithDefaultTimeout()
@@ -944,6 +944,7 @@ BOGUS=foo
 		Entry("secrets.container", "secrets.container"),
 		Entry("selinux.container", "selinux.container"),
 		Entry("shmsize.container", "shmsize.container"),
+		Entry("socket.container", "socket.container"),
 		Entry("stopsigal.container", "stopsignal.container"),
 		Entry("stoptimeout.container", "stoptimeout.container"),
 		Entry("subidmapping.container", "subidmapping.container"),
@@ -1131,6 +1132,7 @@ BOGUS=foo
 		Entry("userns-with-remap.container", "userns-with-remap.container", "converting \"userns-with-remap.container\": deprecated Remap keys are set along with explicit mapping keys"),
 		Entry("reloadboth.container", "reloadboth.container", "converting \"reloadboth.container\": ReloadCmd and ReloadSignal are mutually exclusive but both are set"),
 		Entry("dependent.error.container", "dependent.error.container", "converting \"dependent.error.container\": unable to translate dependency for basic.container"),
+		Entry("socket.invalid.container", "socket.invalid.container", "converting \"socket.invalid.container\": invalid Socket \"web.service\", must be a socket unit"),
 
 		Entry("image-no-image.volume", "image-no-image.volume", "converting \"image-no-image.volume\": the key Image is mandatory when using the image driver"),
 		Entry("Volume - Quadlet image (.build) not found", "build-not-found.quadlet.volume", "converting \"build-not-found.quadlet.volume\": requested Quadlet image not-found.build was not found"),
@@ -0,0 +1,54 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"path/filepath"
+
+	. "github.com/dmikushin/podman-shared/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Podman rootfs", func() {
+
+	BeforeEach(func() {
+		SkipIfRemote("podman rootfs is not supported for remote clients")
+	})
+
+	It("podman rootfs prepare, list and rm", func() {
+		session := podmanTest.PodmanExitCleanly("rootfs", "prepare", ALPINE)
+		rootfs := session.OutputToString()
+		Expect(filepath.Base(filepath.Dir(rootfs))).To(Equal("alpine"))
+		Expect(filepath.Join(rootfs, "bin", "sh")).To(BeAnExistingFile())
+
+		session = podmanTest.Podman([]string{"rootfs", "prepare", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `root file system "alpine" already exists`))
+
+		session = podmanTest.PodmanExitCleanly("rootfs", "prepare", "--link", "--name", "linked", ALPINE)
+		linked := session.OutputToString()
+		Expect(filepath.Join(linked, "bin", "sh")).To(BeAnExistingFile())
+
+		session = podmanTest.PodmanExitCleanly("rootfs", "list", "--format", "{{.Name}} {{.Linked}} {{.Path}}")
+		Expect(session.OutputToStringArray()).To(ConsistOf("alpine false "+rootfs, "linked true "+linked))
+
+		session = podmanTest.PodmanExitCleanly("run", "--name", "onrootfs", "--security-opt", "label=disable", "--rootfs", rootfs+":O", "sh", "-c", "touch /changed && echo hello")
+		Expect(session.OutputToString()).To(Equal("hello"))
+		Expect(filepath.Join(rootfs, "changed")).ToNot(BeAnExistingFile())
+
+		session = podmanTest.Podman([]string{"rootfs", "rm", "alpine"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `root file system "alpine" is used by container`))
+
+		podmanTest.PodmanExitCleanly("rm", "onrootfs")
+		podmanTest.PodmanExitCleanly("rootfs", "rm", "--all")
+		session = podmanTest.PodmanExitCleanly("rootfs", "list", "--quiet")
+		Expect(session.OutputToString()).To(BeEmpty())
+
+		podmanTest.PodmanExitCleanly("rootfs", "rm", "--ignore", "alpine")
+		session = podmanTest.Podman([]string{"rootfs", "rm", "alpine"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `no root file system "alpine"`))
+	})
+})
@@ -108,6 +108,31 @@ var _ = Describe("Podman run cpu", func() {
 		Expect(result.OutputToString()).To(Equal("0"))
 	})
 
+	It("podman run numa-policy", func() {
+		result := podmanTest.Podman([]string{"run", "--name", "numa", "--numa-policy=bind", "--cpuset-mems=0", ALPINE, "true"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+
+		result = podmanTest.Podman([]string{"inspect", "numa", "--format", "{{.HostConfig.NumaPolicy}} {{.HostConfig.CpusetMems}}"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		Expect(result.OutputToString()).To(Equal("bind 0"))
+
+		result = podmanTest.Podman([]string{"run", "--rm", "--numa-policy=bind", ALPINE, "true"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError(125, `NUMA policy "bind" requires memory nodes to be set with cpuset mems`))
+
+		result = podmanTest.Podman([]string{"run", "--rm", "--numa-policy=foo", ALPINE, "true"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).To(ExitWithError(125, `invalid NUMA policy "foo"`))
+
+		if CGROUPSV2 {
+			result = podmanTest.Podman([]string{"run", "--rm", "--cpuset-mems=1023", ALPINE, "true"})
+			result.WaitWithDefaultTimeout()
+			Expect(result).To(ExitWithError(125, "requested memory nodes are not available - requested 1023"))
+		}
+	})
+
 	It("podman run cpus and cpu-period", func() {
 		result := podmanTest.Podman([]string{"run", "--rm", "--cpu-period=5000", "--cpus=0.5", ALPINE, "ls"})
 		result.WaitWithDefaultTimeout()
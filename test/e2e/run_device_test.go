@@ -91,6 +91,29 @@ var _ = Describe("Podman run device", func() {
 		Expect(session).Should(ExitWithError(125, "empty device mode in device specification: /dev/fuse::"))
 	})
 
+	It("podman run --podman-in-podman", func() {
+		if _, err := os.Lstat("/dev/fuse"); err != nil {
+			Skip(fmt.Sprintf("test requires stat /dev/fuse to work: %v", err))
+		}
+		session := podmanTest.Podman([]string{"run", "-q", "--podman-in-podman", ALPINE, "ls", "/dev/fuse"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		// The masked paths are unmasked.
+		session = podmanTest.Podman([]string{"run", "-q", "--podman-in-podman", ALPINE, "ls", "/sys/firmware"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Not(BeEmpty()))
+
+		session = podmanTest.Podman([]string{"create", "--podman-in-podman", "--privileged", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "PodmanInPodman and Privileged are mutually exclusive options"))
+
+		session = podmanTest.Podman([]string{"create", "--podman-in-podman", "--cap-drop", "setuid", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "cannot drop capability setuid, nested Podman needs CAP_SETUID and CAP_SETGID"))
+	})
+
 	It("podman run device host device and container device parameter are directories", func() {
 		SkipIfRootless("Cannot create devices in /dev in rootless mode")
 		// path must be unique to this test, not used anywhere else
@@ -160,6 +183,29 @@ var _ = Describe("Podman run device", func() {
 		Expect(session).Should(ExitCleanly())
 	})
 
+	It("podman device list and CDI device validation", func() {
+		SkipIfRemote("The --cdi-spec-dir only works locally.")
+		cdiDir := podmanTest.TempDir + "/cdi"
+		Expect(os.MkdirAll(cdiDir, os.ModePerm)).To(Succeed())
+
+		cmd := exec.Command("cp", "cdi/device.json", cdiDir)
+		err = cmd.Run()
+		Expect(err).ToNot(HaveOccurred())
+
+		session := podmanTest.Podman([]string{"--cdi-spec-dir", cdiDir, "device", "list", "--format", "{{.Name}} {{.Source}} {{.Vendor}} {{.Class}} {{.Spec}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToStringArray()).To(ContainElement("vendor.com/device=myKmsg cdi vendor.com device " + filepath.Join(cdiDir, "device.json")))
+
+		session = podmanTest.Podman([]string{"create", "--cdi-spec-dir", cdiDir, "--device", "vendor.com/device=missing", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `CDI device "vendor.com/device=missing" not found, available devices of kind vendor.com/device are vendor.com/device=myKmsg`))
+
+		session = podmanTest.Podman([]string{"create", "--cdi-spec-dir", cdiDir, "--device", "nvidia.com/gpu=all", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `CDI device "nvidia.com/gpu=all" not found, no CDI spec for kind nvidia.com/gpu`))
+	})
+
 	It("podman run cannot access non default devices", func() {
 		// Unlikely to happen but do not read any data from the device (thus the -n 0), because there is a rare
 		// race condition that happens so the test would fail for the rare race condition instead of a failure
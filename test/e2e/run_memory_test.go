@@ -90,6 +90,17 @@ var _ = Describe("Podman run memory", func() {
 		Expect(inspect).Should(ExitCleanly())
 		// Check oomkilled and exit code values
 		Expect(inspect.OutputToString()).Should(Equal("true 137"))
+
+		// the OOM report is kept in the state and attached to the oom event
+		inspect = podmanTest.Podman([]string{"inspect", "--format", "{{.State.OOMReport.MemoryLimit}}", ctrName})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).Should(Equal("20971520"))
+
+		events := podmanTest.Podman([]string{"events", "--stream=false", "--filter", "container=" + ctrName, "--filter", "event=oom", "--format", "{{.Status}} {{.Name}}"})
+		events.WaitWithDefaultTimeout()
+		Expect(events).Should(ExitCleanly())
+		Expect(events.OutputToString()).Should(Equal("oom " + ctrName))
 	})
 
 	It("podman run memory test on successfully exited container", func() {
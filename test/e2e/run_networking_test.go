@@ -532,6 +532,29 @@ EXPOSE 2004-2005/tcp`, ALPINE)
 		Expect(inspectOut[0].NetworkSettings.Ports["8181/tcp"][0]).To(HaveField("HostIP", "0.0.0.0"))
 	})
 
+	It("podman run -p 0:8282", func() {
+		name := "testctr"
+		session := podmanTest.Podman([]string{"create", "-t", "-p", "0:8282", "--name", name, ALPINE, "/bin/sh"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		inspectOut := podmanTest.InspectContainer(name)
+		Expect(inspectOut).To(HaveLen(1))
+		Expect(inspectOut[0].NetworkSettings.Ports["8282/tcp"]).To(HaveLen(1))
+		hostPort := inspectOut[0].NetworkSettings.Ports["8282/tcp"][0].HostPort
+		Expect(hostPort).To(Not(Equal("0")))
+		Expect(hostPort).To(Not(Equal("8282")))
+
+		session = podmanTest.Podman([]string{"port", name, "8282"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("0.0.0.0:" + hostPort))
+
+		session = podmanTest.Podman([]string{"events", "--stream=false", "--filter", "container=" + name, "--filter", "event=create", "--format", "{{.PublishedPorts}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("0.0.0.0:" + hostPort + "->8282/tcp"))
+	})
+
 	It("podman run -p xxx:8080 -p yyy:8080", func() {
 		name := "testctr"
 		session := podmanTest.Podman([]string{"create", "-t", "-p", "4444:8080", "-p", "5555:8080", "--name", name, ALPINE, "/bin/sh"})
@@ -1235,4 +1258,28 @@ EXPOSE 2004-2005/tcp`, ALPINE)
 options ndots:1
 `))
 	})
+
+	It("podman run --network with bandwidth limits", func() {
+		SkipIfRootless("the default network of rootless containers is pasta")
+		ctrName := "bwctr"
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", ctrName, "--network", "bridge:egress_rate=10mbit,egress_burst=64k", ALPINE, "top")
+		inspect := podmanTest.PodmanExitCleanly("inspect", "--format", `{{with index .NetworkSettings.Networks "podman"}}{{.Bandwidth.EgressRate}} {{.Bandwidth.EgressBurst}}{{end}}`, ctrName)
+		Expect(inspect.OutputToString()).To(Equal("10000000 65536"))
+
+		podmanTest.PodmanExitCleanly("update", "--network-opt", "podman:egress_rate=1mbit", "--network-opt", "egress_burst=", ctrName)
+		inspect = podmanTest.PodmanExitCleanly("inspect", "--format", `{{with index .NetworkSettings.Networks "podman"}}{{.Bandwidth.EgressRate}} {{.Bandwidth.EgressBurst}}{{end}}`, ctrName)
+		Expect(inspect.OutputToString()).To(Equal("1000000 32768"))
+
+		podmanTest.PodmanExitCleanly("update", "--network-opt", "egress_rate=", ctrName)
+		inspect = podmanTest.PodmanExitCleanly("inspect", "--format", `{{with index .NetworkSettings.Networks "podman"}}{{.Bandwidth}}{{end}}`, ctrName)
+		Expect(inspect.OutputToString()).To(Equal("<nil>"))
+
+		session := podmanTest.Podman([]string{"update", "--network-opt", "ip=10.88.0.99", ctrName})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "network option ip cannot be updated, only bandwidth options can"))
+
+		session = podmanTest.Podman([]string{"create", "--network", "bridge:rate=fast", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, `invalid network option rate=fast`))
+	})
 })
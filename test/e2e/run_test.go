@@ -372,6 +372,21 @@ var _ = Describe("Podman run", func() {
 		conData := result.InspectContainerToJSON()
 		Expect(conData[0]).To(HaveField("Path", define.ContainerInitPath))
 		Expect(conData[0].Config.Annotations).To(HaveKeyWithValue("io.podman.annotations.init", "TRUE"))
+		Expect(conData[0].HostConfig).To(HaveField("InitPath", "/usr/libexec/podman/catatonit"))
+		// catatonit does not report statistics
+		Expect(conData[0].State.InitStats).To(BeNil())
+	})
+
+	It("podman run a container with --init reporting statistics", func() {
+		// stand in for an init reporting its statistics
+		session := podmanTest.Podman([]string{"run", "--name", "test", "--init", "--read-only", ALPINE, "sh", "-c", "printf 'reaped 3\\nforwarded 2\\n' > " + define.ContainerInitStatsPath})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		result := podmanTest.Podman([]string{"inspect", "test"})
+		result.WaitWithDefaultTimeout()
+		Expect(result).Should(ExitCleanly())
+		conData := result.InspectContainerToJSON()
+		Expect(conData[0].State.InitStats).To(Equal(&define.InspectInitStats{Reaped: 3, SignalsForwarded: 2}))
 	})
 
 	It("podman run a container without --init", func() {
@@ -926,6 +941,32 @@ USER bin`, BB)
 		}
 	})
 
+	It("podman run io-max test", func() {
+		SkipIfRootless("Setting io-max not supported for rootless users")
+		SkipIfCgroupV1("io.max is only available on cgroup v2")
+		skipWithoutDevNullb0()
+
+		session := podmanTest.Podman([]string{"run", "-d", "--io-max=/dev/nullb0:rbps=1mb,wiops=100", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		cid := session.OutputToString()
+
+		session = podmanTest.Podman([]string{"exec", cid, "sh", "-c", "cat /sys/fs/cgroup/$(sed -e 's|0::||' < /proc/self/cgroup)/io.max"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(ContainSubstring("rbps=1048576"))
+		Expect(session.OutputToString()).To(ContainSubstring("wiops=100"))
+
+		session = podmanTest.Podman([]string{"inspect", cid, "--format", "{{range .HostConfig.IOMax}}{{.Path}} {{.Rbps}} {{.Wiops}}{{end}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("/dev/nullb0 1048576 100"))
+
+		session = podmanTest.Podman([]string{"run", "--rm", "--io-max=/dev/nullb0:foo=1", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `unknown limit "foo" for device /dev/nullb0`))
+	})
+
 	It("podman run notify_socket", func() {
 		SkipIfRemote("This can only be used for local tests")
 
@@ -1867,6 +1908,27 @@ VOLUME %s`, ALPINE, volPath, volPath)
 		Expect(session).Should(ExitCleanly())
 	})
 
+	It("podman run --device-cgroup-rule with a range and a glob", func() {
+		SkipIfRootless("rootless users are not allowed to mknod")
+		session := podmanTest.Podman([]string{"run", "--cap-add", "mknod", "--name", "test", "-d", "--device-cgroup-rule", "c 42:1-3 rwm", "--device-cgroup-rule", "/dev/nul* rwm", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"exec", "test", "mknod", "newDev", "c", "42", "3"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"exec", "test", "mknod", "otherDev", "c", "42", "4"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(1, "Operation not permitted"))
+		// /dev/null is 1:3
+		session = podmanTest.Podman([]string{"exec", "test", "mknod", "null", "c", "1", "3"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"run", "--device-cgroup-rule", "/dev/does-not-exist* rwm", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, `no device matches /dev/does-not-exist* in device cgroup rule "/dev/does-not-exist* rwm"`))
+	})
+
 	It("podman run --device and --privileged", func() {
 		session := podmanTest.Podman([]string{"run", "--device", "/dev/null:/dev/testdevice", "--privileged", ALPINE, "ls", "/dev"})
 		session.WaitWithDefaultTimeout()
@@ -1919,6 +1981,26 @@ VOLUME %s`, ALPINE, volPath, volPath)
 		Expect(session).To(ExitWithError(125, "file descriptor 3 is not available - the preserve-fds option requires that file descriptors must be passed"))
 	})
 
+	It("podman run --socket-activation", func() {
+		SkipIfRemote("socket activation is not supported remotely")
+		session := podmanTest.Podman([]string{"run", "--socket-activation", ALPINE, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "--socket-activation requires sockets passed in LISTEN_FDS or with --preserve-fds"))
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).ToNot(HaveOccurred())
+		defer listener.Close()
+		socket, err := listener.(*net.TCPListener).File()
+		Expect(err).ToNot(HaveOccurred())
+		defer socket.Close()
+		session = podmanTest.PodmanWithOptions(PodmanExecOptions{
+			ExtraFiles: []*os.File{socket},
+		}, "run", "--rm", "--preserve-fds", "1", "--socket-activation", ALPINE, "sh", "-c", "echo $LISTEN_PID $LISTEN_FDS; ls /proc/self/fd/3")
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToStringArray()).To(Equal([]string{"1 1", "/proc/self/fd/3"}))
+	})
+
 	It("podman run --privileged and --group-add", func() {
 		groupName := "mail"
 		session := podmanTest.Podman([]string{"run", "--group-add", groupName, "--privileged", fedoraMinimal, "groups"})
@@ -1962,6 +2044,47 @@ VOLUME %s`, ALPINE, volPath, volPath)
 
 	})
 
+	It("podman run --tz-from-host and --locale", func() {
+		session := podmanTest.Podman([]string{"run", "--tz", "local", "--tz-from-host", "--rm", ALPINE, "date"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "--tz and --tz-from-host are mutually exclusive"))
+
+		zone, err := filepath.EvalSymlinks("/etc/localtime")
+		Expect(err).ToNot(HaveOccurred())
+		zoneinfo, err := filepath.EvalSymlinks("/usr/share/zoneinfo")
+		Expect(err).ToNot(HaveOccurred())
+		if !strings.HasPrefix(zone, zoneinfo+"/") {
+			Skip("the timezone of the host is not in /usr/share/zoneinfo")
+		}
+		session = podmanTest.Podman([]string{"run", "--tz-from-host", "--rm", ALPINE, "readlink", "/etc/localtime"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("../usr/share/zoneinfo/" + strings.TrimPrefix(zone, zoneinfo+"/")))
+
+		session = podmanTest.Podman([]string{"run", "--tz-from-host", "--rm", ALPINE, "date", "+%Z"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		z, _ := time.Now().Zone()
+		Expect(session.OutputToString()).To(Equal(z))
+
+		session = podmanTest.Podman([]string{"create", "--locale", "de_DE.UTF-8", "--name", "locale", ALPINE, "printenv", "LANG"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		inspect := podmanTest.Podman([]string{"inspect", "--format", "{{.Config.Locale}}", "locale"})
+		inspect.WaitWithDefaultTimeout()
+		Expect(inspect).Should(ExitCleanly())
+		Expect(inspect.OutputToString()).To(Equal("de_DE.UTF-8"))
+		session = podmanTest.Podman([]string{"start", "--attach", "locale"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("de_DE.UTF-8"))
+
+		session = podmanTest.Podman([]string{"run", "--locale", "de_DE.UTF-8", "--env", "LANG=C", "--rm", ALPINE, "printenv", "LANG"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal("C"))
+	})
+
 	It("podman run verify pids-limit", func() {
 		SkipIfCgroupV1("pids-limit not supported on cgroup V1")
 		limit := "4321"
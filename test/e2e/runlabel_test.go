@@ -24,10 +24,6 @@ LABEL RUN podman run --name NAME IMAGE`, ALPINE)
 
 var _ = Describe("podman container runlabel", func() {
 
-	BeforeEach(func() {
-		SkipIfRemote("runlabel is not supported for remote connections")
-	})
-
 	It("podman container runlabel (podman --version)", func() {
 		image := "podman-runlabel-test:podman"
 		podmanTest.BuildImage(PodmanDockerfile, image, "false")
@@ -54,6 +50,7 @@ var _ = Describe("podman container runlabel", func() {
 		Expect(result).Should(ExitCleanly())
 	})
 	It("podman container runlabel --display", func() {
+		SkipIfRemote("the command is generated with the binary of the server")
 		image := "podman-runlabel-test:ls"
 		podmanTest.BuildImage(LsDockerfile, image, "false")
 
@@ -99,6 +96,7 @@ var _ = Describe("podman container runlabel", func() {
 	})
 
 	It("podman container runlabel name removes tag from image", func() {
+		SkipIfRemote("the command is generated with the binary of the server")
 		image := "podman-runlabel-name:sometag"
 		podmanTest.BuildImage(PodmanRunlabelNameDockerfile, image, "false")
 
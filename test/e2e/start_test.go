@@ -123,6 +123,39 @@ var _ = Describe("Podman start", func() {
 		Expect(session).Should(ExitWithError(125, `no container with name or ID "doesnotexist" found: no such container`))
 	})
 
+	It("podman start and stop --all --jobs in dependency order", func() {
+		SkipIfRemote("--jobs is not supported remotely")
+		session := podmanTest.Podman([]string{"create", "--name", "dep", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"create", "--name", "child", "--requires", "dep", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"start", "--all", "--jobs", "1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(2))
+
+		session = podmanTest.Podman([]string{"stop", "--all", "--jobs", "1", "-t", "0"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
+
+		// A failing dependency skips the containers depending on it.
+		session = podmanTest.Podman([]string{"create", "--name", "baddep", ALPINE, "foo"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		session = podmanTest.Podman([]string{"create", "--name", "badchild", "--requires", "baddep", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"start", "baddep", "badchild"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "a dependency of container"))
+		Expect(podmanTest.NumberOfContainersRunning()).To(Equal(0))
+	})
+
 	It("podman multiple containers -- attach should fail", func() {
 		session := podmanTest.Podman([]string{"create", "--name", "foobar1", ALPINE, "ls"})
 		session.WaitWithDefaultTimeout()
@@ -5,6 +5,7 @@ package integration
 import (
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	. "github.com/dmikushin/podman-shared/test/utils"
@@ -292,4 +293,62 @@ var _ = Describe("Podman stats", func() {
 		Expect(sessionAll).Should(ExitCleanly())
 		Expect(sessionAll.OutputToStringArray()).Should(HaveLen(2))
 	})
+
+	It("podman stats --history", func() {
+		SkipIfRemote("recording stats is not supported on the remote client")
+		ctr := "stats-history"
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", ctr, "--stats-history", "1h", ALPINE, "top")
+
+		session := podmanTest.PodmanExitCleanly("stats", "--history", "--format", "{{.ID}}", ctr)
+		Expect(session.OutputToString()).To(BeEmpty())
+
+		podmanTest.PodmanExitCleanly("container", "record-stats", ctr)
+		podmanTest.PodmanExitCleanly("container", "record-stats", ctr)
+
+		session = podmanTest.PodmanExitCleanly("stats", "--history", "--since", "1h", "--format", "{{.Name}} {{.PIDS}}", ctr)
+		Expect(session.OutputToStringArray()).To(Equal([]string{ctr + " 1", ctr + " 1"}))
+
+		session = podmanTest.PodmanExitCleanly("stats", "--history", "--format", "json", ctr)
+		Expect(session.OutputToString()).To(BeValidJSON())
+		Expect(session.OutputToString()).To(ContainSubstring(`"time":`))
+
+		session = podmanTest.PodmanExitCleanly("stats", "--history", "--until", "2000-01-01", "--format", "{{.ID}}", ctr)
+		Expect(session.OutputToString()).To(BeEmpty())
+
+		session = podmanTest.Podman([]string{"stats", "--no-stream", "--since", "1h", ctr})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "--since and --until can only be used with --history"))
+
+		session = podmanTest.Podman([]string{"create", "--stats-history", "100ms", ALPINE})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "stats history interval must be at least 1s, not 100ms"))
+	})
+
+	It("podman stats --memory-breakdown", func() {
+		ctr := "mem-breakdown"
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", ctr, "--memory", "50m", ALPINE, "top")
+
+		session := podmanTest.PodmanExitCleanly("stats", "--no-stream", "--memory-breakdown", ctr)
+		Expect(session.OutputToStringArray()).To(HaveLen(2))
+		Expect(session.OutputToStringArray()[0]).To(MatchRegexp(`^ID\s+NAME\s+MEM USAGE / LIMIT\s+WORKING SET\s+MEM ANON\s+MEM FILE\s+MEM SLAB\s+MEM %$`))
+		Expect(session.OutputToStringArray()[1]).To(ContainSubstring(ctr))
+
+		session = podmanTest.PodmanExitCleanly("stats", "--no-stream", "--format", "{{.ContainerStats.MemAnon}} {{.ContainerStats.MemWorkingSet}}", ctr)
+		values := strings.Fields(session.OutputToString())
+		Expect(values).To(HaveLen(2))
+		anon, err := strconv.ParseUint(values[0], 10, 64)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(anon).To(BeNumerically(">", 0))
+		workingSet, err := strconv.ParseUint(values[1], 10, 64)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(workingSet).To(BeNumerically(">=", anon))
+
+		session = podmanTest.PodmanExitCleanly("stats", "--no-stream", "--format", "json", ctr)
+		Expect(session.OutputToString()).To(BeValidJSON())
+		Expect(session.OutputToString()).To(ContainSubstring(`"mem_working_set":`))
+
+		session = podmanTest.Podman([]string{"stats", "--no-stream", "--memory-breakdown", "--format", "{{.ID}}", ctr})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "--memory-breakdown and --format cannot be used together"))
+	})
 })
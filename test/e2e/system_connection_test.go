@@ -137,6 +137,44 @@ QA-UDS1 unix:///run/user/podman/podman.sock  false true
 			Expect(session.OutputToString()).To(Equal("QA-TCP tcp://localhost:8888 true true"))
 		})
 
+		It("add with option defaults", func() {
+			cmd := []string{
+				"system", "connection", "add",
+				"--set", "shared-base-layers=true",
+				"--set", "tls-verify=false",
+				"QA-TCP",
+				"tcp://localhost:8888",
+			}
+			session := podmanTest.Podman(cmd)
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(ExitCleanly())
+
+			defaultsFile := filepath.Join(podmanTest.TempDir, "podman-connection-defaults.json")
+			content, err := os.ReadFile(defaultsFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring(`"QA-TCP":{"shared-base-layers":"true","tls-verify":"false"}`))
+
+			session = podmanTest.Podman([]string{"system", "connection", "rename", "QA-TCP", "QA"})
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(ExitCleanly())
+
+			content, err = os.ReadFile(defaultsFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(ContainSubstring(`"QA":{`))
+
+			session = podmanTest.Podman([]string{"system", "connection", "rm", "QA"})
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(ExitCleanly())
+
+			content, err = os.ReadFile(defaultsFile)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal("{}"))
+
+			session = podmanTest.Podman([]string{"system", "connection", "add", "--set", "bad key", "QA", "tcp://localhost:8888"})
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(ExitWithError(125, `invalid connection default "bad key": must be in the form option=value`))
+		})
+
 		It("add tcp w/ TLS", func() {
 			cmd := []string{
 				"system", "connection", "add",
@@ -389,6 +427,31 @@ qe ssh://root@podman.test:2222/run/podman/podman.sock ~/.ssh/id_rsa false true
 			Expect(session.Err).Should(Say("destination is not defined"))
 		})
 
+		It("failed test", func() {
+			cmd := []string{"system", "connection", "test", "devl"}
+			session := podmanTest.Podman(cmd)
+			session.WaitWithDefaultTimeout()
+			Expect(session).ShouldNot(ExitCleanly())
+			Expect(session.Err).Should(Say("destination is not defined"))
+		})
+
+		It("test unreachable destination", func() {
+			cmd := []string{
+				"system", "connection", "add",
+				"QA-UDS",
+				"unix://" + filepath.Join(podmanTest.TempDir, "missing.sock"),
+			}
+			session := podmanTest.Podman(cmd)
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(Exit(0))
+
+			session = podmanTest.Podman([]string{"system", "connection", "test", "--format", "{{.Name}} {{.Version}} {{.Error}}"})
+			session.WaitWithDefaultTimeout()
+			Expect(session).Should(ExitCleanly())
+			Expect(session.OutputToString()).To(HavePrefix("QA-UDS  "))
+			Expect(session.OutputToString()).To(ContainSubstring("missing.sock"))
+		})
+
 		It("empty list", func() {
 			cmd := []string{"system", "connection", "list"}
 			session := podmanTest.Podman(cmd)
@@ -0,0 +1,67 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/dmikushin/podman-shared/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gexec"
+)
+
+var _ = Describe("Podman system export", func() {
+
+	It("podman system export and import round-trip", func() {
+		useCustomNetworkDir(podmanTest, tempdir)
+
+		podmanTest.PodmanExitCleanly("network", "create", "exportnet")
+		podmanTest.PodmanExitCleanly("volume", "create", "--label", "exported=yes", "exportvol")
+		secretFile := filepath.Join(podmanTest.TempDir, "secret")
+		err := os.WriteFile(secretFile, []byte("secret"), 0o600)
+		Expect(err).ToNot(HaveOccurred())
+		podmanTest.PodmanExitCleanly("secret", "create", "exportsecret", secretFile)
+		podmanTest.PodmanExitCleanly("run", "--name", "exported", "--network", "exportnet", "-v", "exportvol:/data", ALPINE,
+			"sh", "-c", "echo volume > /data/file")
+
+		bundle := filepath.Join(podmanTest.TempDir, "bundle.tar")
+		podmanTest.PodmanExitCleanly("system", "export", "-o", bundle)
+
+		podmanTest.PodmanExitCleanly("rm", "exported")
+		podmanTest.PodmanExitCleanly("volume", "rm", "exportvol")
+		podmanTest.PodmanExitCleanly("network", "rm", "exportnet")
+		podmanTest.PodmanExitCleanly("secret", "rm", "exportsecret")
+
+		session := podmanTest.Podman([]string{"system", "import", bundle})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToString()).To(MatchRegexp(`^Imported 1 networks, \d+ images, 1 volumes and 1 containers$`))
+		Expect(session.ErrorToString()).To(ContainSubstring("Secret exportsecret (driver file) must be created again"))
+
+		session = podmanTest.PodmanExitCleanly("inspect", "--format", "{{range $name, $_ := .NetworkSettings.Networks}}{{$name}}{{end}}", "exported")
+		Expect(session.OutputToString()).To(Equal("exportnet"))
+		session = podmanTest.PodmanExitCleanly("volume", "inspect", "--format", "{{.Labels.exported}}", "exportvol")
+		Expect(session.OutputToString()).To(Equal("yes"))
+		session = podmanTest.PodmanExitCleanly("run", "--rm", "-v", "exportvol:/data", ALPINE, "cat", "/data/file")
+		Expect(session.OutputToString()).To(Equal("volume"))
+
+		// Existing objects are kept.
+		session = podmanTest.Podman([]string{"system", "import", bundle})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(Exit(0))
+		Expect(session.OutputToString()).To(MatchRegexp(`^Imported 0 networks, \d+ images, 0 volumes and 0 containers$`))
+		Expect(session.ErrorToString()).To(ContainSubstring("Container exported already exists, it is kept"))
+	})
+
+	It("podman system import invalid bundle", func() {
+		bundle := filepath.Join(podmanTest.TempDir, "bundle.tar")
+		podmanTest.PodmanExitCleanly("create", "--name", "backedup", ALPINE)
+		podmanTest.PodmanExitCleanly("container", "backup", "-o", bundle, "backedup")
+
+		session := podmanTest.Podman([]string{"system", "import", bundle})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "invalid bundle, bundle.json is missing"))
+	})
+})
@@ -0,0 +1,38 @@
+//go:build linux || freebsd
+
+package integration
+
+import (
+	. "github.com/dmikushin/podman-shared/test/utils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("podman system migrate", func() {
+
+	It("podman system migrate --db-backend", func() {
+		SkipIfRemote("system migrate not supported via remote")
+		current := podmanTest.DatabaseBackend
+		target := "sqlite"
+		if current == "sqlite" {
+			target = "boltdb"
+		}
+		podmanTest.PodmanExitCleanly("create", "--name", "migrated", ALPINE, "top")
+
+		session := podmanTest.Podman([]string{"system", "migrate", "--db-backend", current})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "database backend is already "+current))
+
+		// The database to migrate is selected with the global --db-backend
+		// option, as after changing database_backend in containers.conf.
+		session = podmanTest.PodmanExitCleanly("--db-backend", current, "system", "migrate", "--db-backend", target)
+		Expect(session.OutputToString()).To(ContainSubstring("Backup of the " + current + " database saved to "))
+
+		session = podmanTest.PodmanExitCleanly("--db-backend", target, "ps", "-a", "--format", "{{.Names}}")
+		Expect(session.OutputToString()).To(Equal("migrated"))
+
+		session = podmanTest.Podman([]string{"--db-backend", target, "system", "migrate", "--db-backend", target})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "podman --db-backend "+current+" system migrate --db-backend "+target))
+	})
+})
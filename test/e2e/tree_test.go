@@ -31,6 +31,19 @@ ENV foo=bar
 		session.WaitWithDefaultTimeout()
 		Expect(session).Should(ExitCleanly())
 
+		// The cirros layers are shared with the built image, the
+		// layers added by the build are not.
+		session = podmanTest.Podman([]string{"image", "tree", "--du", "test:latest"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(ContainSubstring("Unique Size:"))
+		Expect(session.OutputToString()).To(ContainSubstring(" shared with "))
+		Expect(session.OutputToString()).To(ContainSubstring("unique"))
+
+		session = podmanTest.Podman([]string{"image", "tree", "--du", "--whatrequires", "test:latest"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "[du whatrequires] were all set"))
+
 		session = podmanTest.Podman([]string{"rmi", "test:latest"})
 		session.WaitWithDefaultTimeout()
 		Expect(session).Should(ExitCleanly())
@@ -58,6 +58,37 @@ var _ = Describe("Podman unshare", func() {
 		Expect(session.OutputToString()).Should(Equal(""))
 	})
 
+	It("podman unshare --container", func() {
+		SkipIfRemote("podman-remote unshare is not supported")
+		session := podmanTest.PodmanExitCleanly("run", "-d", "--userns", "keep-id", ALPINE, "top")
+		cid := session.OutputToString()
+
+		for _, file := range []string{"/proc/self/uid_map", "/etc/alpine-release"} {
+			inCtr := podmanTest.PodmanExitCleanly("exec", cid, "cat", file)
+			session = podmanTest.PodmanExitCleanly("unshare", "--container", cid, "cat", file)
+			Expect(session.OutputToString()).To(Equal(inCtr.OutputToString()))
+		}
+
+		netNS := podmanTest.PodmanExitCleanly("exec", cid, "readlink", "/proc/self/ns/net").OutputToString()
+		session = podmanTest.PodmanExitCleanly("unshare", "--container", cid, "readlink", "/proc/self/ns/net")
+		Expect(session.OutputToString()).To(Equal(netNS))
+		session = podmanTest.PodmanExitCleanly("unshare", "--container", cid, "--keep-net", "readlink", "/proc/self/ns/net")
+		Expect(session.OutputToString()).ToNot(Equal(netNS))
+
+		session = podmanTest.Podman([]string{"unshare", "--container", cid, "bogus"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(127, "cannot execute bogus"))
+
+		session = podmanTest.Podman([]string{"unshare", "--keep-net", "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "--keep-net can only be used with --container"))
+
+		podmanTest.PodmanExitCleanly("stop", "-t0", cid)
+		session = podmanTest.Podman([]string{"unshare", "--container", cid, "true"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "is not running, its namespaces cannot be joined"))
+	})
+
 	It("podman unshare check remote error", func() {
 		SkipIfNotRemote("check for podman-remote unshare error")
 		session := podmanTest.Podman([]string{"unshare"})
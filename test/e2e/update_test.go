@@ -308,6 +308,91 @@ var _ = Describe("Podman update", func() {
 		Expect(env).To(ContainSubstring("PATH="))
 	})
 
+	It("podman update adds and removes devices of a running container", func() {
+		SkipIfRootless("adding devices to a running container requires root")
+		ctr := podmanTest.RunTopContainer("")
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr).Should(ExitCleanly())
+		cid := ctr.OutputToString()
+
+		update := podmanTest.Podman([]string{"update", "--device", "/dev/zero:/dev/testzero:r", cid})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(ExitCleanly())
+
+		session := podmanTest.Podman([]string{"exec", cid, "head", "-c", "1", "/dev/testzero"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"inspect", cid, "--format", "{{range .HostConfig.Devices}}{{.PathInContainer}} {{end}}"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToString()).To(ContainSubstring("/dev/testzero"))
+
+		update = podmanTest.Podman([]string{"update", "--device-rm", "/dev/testzero", cid})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"exec", cid, "ls", "/dev/testzero"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(1, "No such file or directory"))
+
+		update = podmanTest.Podman([]string{"update", "--device-rm", "/dev/testzero", cid})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(ExitWithError(125, "has no device /dev/testzero"))
+	})
+
+	It("podman update memory-high and memory-reclaim", func() {
+		SkipIfCgroupV1("memory.high and memory.reclaim are only available on cgroup v2")
+		SkipIfRootless("many of these handlers are not enabled while rootless in CI")
+		ctr := podmanTest.Podman([]string{"run", "-d", "--memory-high", "100m", ALPINE, "top"})
+		ctr.WaitWithDefaultTimeout()
+		Expect(ctr).Should(ExitCleanly())
+		cid := ctr.OutputToString()
+
+		podmanTest.CheckFileInContainer(cid, "/sys/fs/cgroup/memory.high", "104857600")
+
+		update := podmanTest.Podman([]string{"update", "--memory-high", "max", "--memory-reclaim", "1m", cid})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(Exit(0))
+
+		podmanTest.CheckFileInContainer(cid, "/sys/fs/cgroup/memory.high", "max")
+
+		update = podmanTest.Podman([]string{"update", "--memory-reclaim", "0", cid})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(ExitWithError(125, `invalid value "0" for memory-reclaim, must be a positive size`))
+
+		stop := podmanTest.Podman([]string{"stop", "-t0", cid})
+		stop.WaitWithDefaultTimeout()
+		Expect(stop).Should(ExitCleanly())
+
+		update = podmanTest.Podman([]string{"update", "--memory-reclaim", "1m", cid})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(ExitWithError(125, "can only reclaim memory of running containers"))
+	})
+
+	It("podman update adds device cgroup rules to a running container", func() {
+		SkipIfRootless("rootless users are not allowed to mknod")
+		session := podmanTest.Podman([]string{"run", "--cap-add", "mknod", "--name", "test", "-d", ALPINE, "top"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"exec", "test", "mknod", "newDev", "c", "42", "1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(1, "Operation not permitted"))
+
+		update := podmanTest.Podman([]string{"update", "--device-cgroup-rule", "c 42:0-7 rwm", "test"})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(ExitCleanly())
+
+		session = podmanTest.Podman([]string{"exec", "test", "mknod", "newDev", "c", "42", "1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+
+		update = podmanTest.Podman([]string{"update", "--device-cgroup-rule", "c 42:7-0 rwm", "test"})
+		update.WaitWithDefaultTimeout()
+		Expect(update).Should(ExitWithError(125, `invalid device number range "7-0"`))
+	})
+
 	It("podman update the latest container", func() {
 		SkipIfRemote("--latest is local-only")
 
@@ -3,6 +3,7 @@
 package integration
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -261,6 +262,102 @@ Removed:
 		Expect(session.ErrorToString()).To(Equal("")) // make no errors are shown
 	})
 
+	It("podman volume reload conflicts", func() {
+		podmanTest.AddImageToRWStore(volumeTest)
+
+		pluginNames := []string{"testvol7", "testvol8"}
+		confFile := filepath.Join(podmanTest.TempDir, "containers.conf")
+		err := os.WriteFile(confFile, fmt.Appendf(nil, `[engine]
+[engine.volume_plugins]
+%[1]s = "/run/docker/plugins/%[1]s.sock"
+%[2]s = "/run/docker/plugins/%[2]s.sock"
+notrunning = "/run/docker/plugins/notrunning.sock"`, pluginNames[0], pluginNames[1]), 0o644)
+		Expect(err).ToNot(HaveOccurred())
+		os.Setenv("CONTAINERS_CONF", confFile)
+
+		for _, pluginName := range pluginNames {
+			pluginStatePath := filepath.Join(podmanTest.TempDir, pluginName)
+			err = os.Mkdir(pluginStatePath, 0755)
+			Expect(err).ToNot(HaveOccurred())
+			plugin := podmanTest.Podman([]string{"run", "--name", pluginName, "--security-opt", "label=disable", "-v", "/run/docker/plugins:/run/docker/plugins",
+				"-v", fmt.Sprintf("%v:%v", pluginStatePath, pluginStatePath), "-d", volumeTest, "--sock-name", pluginName, "--path", pluginStatePath})
+			plugin.WaitWithDefaultTimeout()
+			Expect(plugin).Should(ExitCleanly())
+
+			// Make sure the socket is available (see #17956)
+			err = WaitForFile(fmt.Sprintf("/run/docker/plugins/%s.sock", pluginName))
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		localvol := "local-" + stringid.GenerateRandomID()
+		session := podmanTest.Podman([]string{"volume", "create", localvol})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitCleanly())
+
+		// Create the volumes in the plugins without podman, one of them
+		// in both plugins and one with the name of the local volume.
+		vol1 := "vol1-" + stringid.GenerateRandomID()
+		shared := "shared-" + stringid.GenerateRandomID()
+		for _, vol := range []struct{ plugin, name string }{
+			{pluginNames[0], vol1},
+			{pluginNames[0], shared},
+			{pluginNames[1], shared},
+			{pluginNames[1], localvol},
+		} {
+			plugin := podmanTest.Podman([]string{"exec", vol.plugin, "/usr/local/bin/testvol", "--sock-name", vol.plugin, "create", vol.name})
+			plugin.WaitWithDefaultTimeout()
+			Expect(plugin).Should(ExitCleanly())
+		}
+
+		session = podmanTest.Podman([]string{"volume", "reload", "--all", "--format", "json"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, ""))
+		var report struct {
+			Added     []string
+			Removed   []string
+			Conflicts []struct {
+				Name    string
+				Drivers []string
+			}
+			Errors []string
+		}
+		Expect(json.Unmarshal(session.Out.Contents(), &report)).To(Succeed())
+		Expect(report.Added).To(Equal([]string{vol1}))
+		Expect(report.Removed).To(BeEmpty())
+		Expect(report.Conflicts).To(ConsistOf(
+			HaveField("Name", localvol),
+			HaveField("Name", shared),
+		))
+		for _, conflict := range report.Conflicts {
+			if conflict.Name == localvol {
+				Expect(conflict.Drivers).To(Equal([]string{"local", pluginNames[1]}))
+			} else {
+				Expect(conflict.Drivers).To(Equal(pluginNames))
+			}
+		}
+		Expect(report.Errors).To(HaveExactElements(ContainSubstring("cannot access plugin notrunning socket")))
+
+		// Reloading a single plugin does not see the conflict between
+		// the plugins.
+		session = podmanTest.Podman([]string{"volume", "reload", pluginNames[1]})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitCleanly())
+		Expect(string(session.Out.Contents())).To(Equal(fmt.Sprintf(`Added:
+%s
+Conflicts:
+%s: local, %s
+`, shared, localvol, pluginNames[1])))
+
+		session = podmanTest.Podman([]string{"volume", "inspect", "--format", "{{.Driver}}", shared})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitCleanly())
+		Expect(session.OutputToString()).To(Equal(pluginNames[1]))
+
+		session = podmanTest.Podman([]string{"volume", "reload", "--all", pluginNames[0]})
+		session.WaitWithDefaultTimeout()
+		Expect(session).To(ExitWithError(125, "--all and plugin names cannot be used together"))
+	})
+
 	It("volume driver timeouts test", func() {
 		podmanTest.AddImageToRWStore(volumeTest)
 
@@ -3,6 +3,8 @@
 package integration
 
 import (
+	"time"
+
 	. "github.com/dmikushin/podman-shared/test/utils"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -135,4 +137,25 @@ var _ = Describe("Podman wait", func() {
 		waitSession.Wait(10)
 		Expect(waitSession.OutputToString()).To(Equal("2"))
 	})
+
+	It("podman wait --stream on multiple containers and conditions", func() {
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", "waitstream1", ALPINE, "sh", "-c", "sleep 2; exit 3")
+		podmanTest.PodmanExitCleanly("run", "-d", "--name", "waitstream2", ALPINE, "sleep", "100")
+
+		// every condition is reported on its own as soon as it is met
+		session := podmanTest.PodmanExitCleanly("wait", "--stream", "--condition", "running", "--condition", "exited", "waitstream1")
+		Expect(session.OutputToStringArray()).To(Equal([]string{"waitstream1 running", "waitstream1 exited 3"}))
+
+		session = podmanTest.Podman([]string{"wait", "--stream", "--condition", "removed", "waitstream1", "waitstream2"})
+		// give wait some time to look up the containers
+		time.Sleep(time.Second)
+		podmanTest.PodmanExitCleanly("rm", "-f", "-t0", "waitstream1", "waitstream2")
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitCleanly())
+		Expect(session.OutputToStringArray()).To(ConsistOf("waitstream1 removed", "waitstream2 removed"))
+
+		session = podmanTest.Podman([]string{"wait", "--stream", "--exit-first-match", "waitstream1"})
+		session.WaitWithDefaultTimeout()
+		Expect(session).Should(ExitWithError(125, "--stream and --exit-first-match cannot be used together"))
+	})
 })
@@ -415,6 +415,42 @@ EOF
     run_podman --root $imstore/root rmi --all
 }
 
+# bats test_tags=ci:parallel
+@test "podman --root-rw with a read-only graph root" {
+    skip_if_remote "only works on local"
+
+    # overlay or vfs
+    local storagedriver="$(podman_storage_driver)"
+
+    local roroot=$PODMAN_TMPDIR/roroot
+    local rwroot=$PODMAN_TMPDIR/rwroot
+    local opts="--root $roroot/root --runroot $PODMAN_TMPDIR/runroot --storage-driver $storagedriver"
+
+    _prefetch $IMAGE
+    skopeo copy containers-storage:$IMAGE \
+           containers-storage:\[${storagedriver}@${roroot}/root+${roroot}/runroot\]$IMAGE
+
+    run_podman $opts --root-rw $rwroot images -n --format "{{.Repository}}:{{.Tag}} {{.ReadOnly}}"
+    is "$output" "$IMAGE true" "image of the read-only graph root"
+
+    run_podman $opts --root-rw $rwroot info --format '{{.Store.GraphRoot}} {{.Store.ReadOnlyGraphRoot}}'
+    is "$output" "$rwroot $roroot/root" "graph roots in podman info"
+
+    local cname=c-$(safename)
+    run_podman $opts --root-rw $rwroot run --name $cname $IMAGE echo hello
+    is "$output" "hello" "container of an image of the read-only graph root"
+    run_podman $opts --root-rw $rwroot rm $cname
+    assert "$(ls $rwroot)" =~ "libpod" "database in the read-write root"
+
+    run_podman 125 $opts --root-rw $roroot/root/rw images
+    is "$output" "Error: read-write root $roroot/root/rw must not be inside the graph root $roroot/root: invalid argument"
+
+    run_podman 125 --root $PODMAN_TMPDIR/empty --storage-driver $storagedriver --root-rw $rwroot images
+    assert "$output" =~ "graph root $PODMAN_TMPDIR/empty cannot be used read-only, it has no $storagedriver images"
+
+    run_podman --root $roroot/root --runroot $roroot/runroot --storage-driver $storagedriver rmi --all
+}
+
 # bats test_tags=ci:parallel
 @test "podman images with concurrent removal" {
     skip_if_remote "following test is not supported for remote clients"
@@ -451,4 +487,118 @@ EOF
 }
 
 
+@test "podman image sbom" {
+    # The test image is based on alpine
+    run_podman image sbom $IMAGE
+    spdx="$output"
+    is "$(jq -r .spdxVersion <<<"$spdx")" "SPDX-2.3" "SPDX version"
+    is "$(jq -r '.packages[0].name' <<<"$spdx")" "$IMAGE" "first package is the image"
+    is "$(jq -r '.packages[] | select(.name == "busybox") | .externalRefs[0].referenceLocator' <<<"$spdx")" \
+       "pkg:apk/alpine/busybox@.*distro=alpine-.*" "busybox purl"
+
+    run_podman image sbom --format cyclonedx -o $PODMAN_TMPDIR/sbom.json $IMAGE
+    is "$output" "" "no output with --output"
+    is "$(jq -r .bomFormat $PODMAN_TMPDIR/sbom.json)" "CycloneDX" "CycloneDX written to file"
+    is "$(jq -r '.components[] | select(.name == "musl") | .properties[0].value' $PODMAN_TMPDIR/sbom.json)" \
+       "apk" "musl package type"
+
+    run_podman 125 image sbom --format text $IMAGE
+    is "$output" 'Error: .*unsupported SBOM format "text", must be "spdx" or "cyclonedx"'
+
+    run_podman 125 image sbom nonesuch-$(safename)
+    is "$output" "Error: .*nonesuch-$(safename).*: image not known"
+}
+
+
+@test "podman image scan" {
+    skip_if_remote "the scan configuration is read by the server"
+
+    # Fake scanner, checking that the image is mounted
+    cat >$PODMAN_TMPDIR/scanner <<EOF
+#!/bin/sh
+test -f "\$1/home/podman/testimage-id" || { echo "image not mounted at \$1" >&2; exit 1; }
+echo '{"vulnerabilities": [
+  {"id": "CVE-0000-0002", "package": "busybox", "installedVersion": "1.0", "fixedVersion": "1.1", "severity": "low"},
+  {"id": "CVE-0000-0001", "package": "musl", "installedVersion": "2.0", "severity": "Critical"},
+  {"id": "CVE-0000-0003", "package": "musl", "installedVersion": "2.0", "severity": "high"}
+]}'
+EOF
+    chmod 755 $PODMAN_TMPDIR/scanner
+    local conf=$PODMAN_TMPDIR/podman-scan.json
+    cat >$conf <<EOF
+{
+  "scanner": "fake",
+  "ignore": ["CVE-0000-0003"],
+  "scanners": {"fake": {"command": ["$PODMAN_TMPDIR/scanner", "{rootfs}"], "format": "podman"}}
+}
+EOF
+
+    PODMAN_SCAN_CONF=$conf run_podman image scan $IMAGE
+    assert "${lines[0]}" =~ "ID +SEVERITY +PACKAGE +INSTALLED +FIXED" "header"
+    assert "${lines[1]}" =~ "CVE-0000-0001 +CRITICAL +musl +2.0" "most severe first"
+    assert "${lines[2]}" =~ "CVE-0000-0002 +LOW +busybox +1.0 +1.1" "low"
+    assert "${#lines[*]}" = 3 "ignored vulnerability is not listed"
+
+    PODMAN_SCAN_CONF=$conf run_podman image scan --format json $IMAGE
+    assert "$(jq -r .scanner <<<"$output")" = "fake" "scanner"
+    assert "$(jq -r .summary.CRITICAL <<<"$output")" = "1" "summary"
+    assert "$(jq -r .blocked <<<"$output")" = "false" "not blocked without threshold"
+
+    PODMAN_SCAN_CONF=$conf run_podman 125 image scan --format '{{range .}}{{.ID}} {{end}}' --threshold critical $IMAGE
+    is "$output" "CVE-0000-0001 CVE-0000-0002 .*Error: image $IMAGE has 1 vulnerabilities of severity CRITICAL or higher" \
+       "--threshold fails after printing the vulnerabilities"
+
+    PODMAN_SCAN_CONF=$conf run_podman 125 image scan --threshold severe $IMAGE
+    is "$output" 'Error: invalid severity "severe".*'
+
+    PODMAN_SCAN_CONF=$conf run_podman 125 image scan --scanner nonesuch $IMAGE
+    is "$output" 'Error: unknown vulnerability scanner "nonesuch"'
+
+    # The run policy is only enforced when a run threshold is configured
+    PODMAN_SCAN_CONF=$conf run_podman run --rm $IMAGE true
+
+    sed -i -e 's/"scanner": "fake",/"scanner": "fake", "run_threshold": "critical",/' $conf
+    PODMAN_SCAN_CONF=$conf run_podman 125 run --rm $IMAGE true
+    is "$output" "Error: image .* has 1 vulnerabilities of severity CRITICAL or higher, refused by the scan policy.*"
+    PODMAN_SCAN_CONF=$conf run_podman 125 create $IMAGE true
+    is "$output" "Error: image .* refused by the scan policy.*"
+
+    sed -i -e 's/"CVE-0000-0003"/"CVE-0000-0003", "CVE-0000-0001"/' $conf
+    PODMAN_SCAN_CONF=$conf run_podman run --rm $IMAGE true
+}
+
+
+@test "podman image optimize" {
+    local src=src-$(safename)
+    local dst=dst-$(safename)
+    local ctxdir=$PODMAN_TMPDIR/optimize
+    mkdir -p $ctxdir
+    seq 1 20000 > $ctxdir/a
+    echo b > $ctxdir/b
+    cat >$ctxdir/Containerfile <<EOF
+FROM $IMAGE
+COPY a /a
+COPY b /b
+EOF
+    run_podman build -q -t $src $ctxdir
+    run_podman image inspect --format '{{len .RootFS.Layers}}' $IMAGE
+    local base_layers=$output
+
+    # Merge the added layers with the layers of the image
+    run_podman image optimize --merge-threshold 1GB $src $dst
+    assert "$output" =~ "Layers: +$((base_layers + 2)) -> 1" "all layers merged"
+    assert "$output" =~ "Compressed size: .* -> " "size reduction reported"
+
+    run_podman image inspect --format '{{len .RootFS.Layers}}' $dst
+    is "$output" "1" "optimized image has a single layer"
+    run_podman run --rm $dst cat /b
+    is "$output" "b" "content of the merged layers"
+
+    run_podman 125 image optimize --merge-threshold nonsense $src $dst
+    is "$output" 'Error: invalid merge threshold "nonsense": .*'
+
+    run_podman rmi $src $dst
+}
+
+
 # vim: filetype=sh
@@ -1310,6 +1310,33 @@ touch: /dev/shm/d: Read-only file system
 touch: /run/e: Read-only file system"
 }
 
+# bats test_tags=ci:parallel
+@test "podman run --read-only-tmpfs-size and --read-only-tmpfs-opt" {
+    ctrname="c-$(safename)"
+    run_podman create --name $ctrname --read-only --read-only-tmpfs-size=16m \
+               --read-only-tmpfs-opt=/tmp:size=8m,noexec --read-only-tmpfs-opt=/var/tmp:off \
+               $IMAGE sh -c 'df -k /run /tmp | tail -n 2; touch /var/tmp/x'
+    run_podman inspect --format '{{index .HostConfig.ReadOnlyTmpfs "/run"}}' $ctrname
+    assert "$output" =~ "size=16m" "/run has the size of all read-write tmpfs"
+    run_podman inspect --format '{{index .HostConfig.ReadOnlyTmpfs "/tmp"}}' $ctrname
+    assert "$output" =~ "size=8m" "/tmp has its own size"
+    assert "$output" =~ "noexec" "/tmp options"
+    assert "$output" !~ "size=16m" "/tmp size overrides the default one"
+    run_podman inspect --format '{{len .HostConfig.ReadOnlyTmpfs}}' $ctrname
+    assert "$output" = "2" "/var/tmp is disabled"
+
+    run_podman 1 start --attach $ctrname
+    assert "${lines[0]}" =~ "^tmpfs +16384 " "size of /run"
+    assert "${lines[1]}" =~ "^tmpfs +8192 " "size of /tmp"
+    assert "${lines[2]}" = "touch: /var/tmp/x: Read-only file system"
+    run_podman rm $ctrname
+
+    run_podman 125 create --read-only-tmpfs-size=16m $IMAGE
+    is "$output" "Error: --read-only-tmpfs-size and --read-only-tmpfs-opt require --read-only and --read-only-tmpfs"
+    run_podman 125 create --read-only --read-only-tmpfs-opt=/home:size=1m $IMAGE
+    is "$output" "Error: invalid --read-only-tmpfs-opt \"/home:size=1m\": PATH must be one of /run, /tmp, /var/tmp"
+}
+
 # bats test_tags=ci:parallel
 @test "podman run ulimit from containers.conf" {
     skip_if_remote "containers.conf has to be set on remote, only tested on E2E test"
@@ -1513,6 +1540,33 @@ EOF
     rm -rf $romount
 }
 
+# bats test_tags=ci:parallel
+@test "podman run - rootless idmapped volumes" {
+    skip_if_not_rootless "the chown fallback is only used by rootless users"
+    skip_if_remote "the ownership is checked on the server"
+
+    run_podman unshare cat /proc/self/uid_map
+    if [[ $(wc -l <<<"$output") -lt 2 ]]; then
+        skip "no subordinate IDs for $(id -un)"
+    fi
+
+    # the files of the user are owned by root in the rootless user namespace
+    mkdir $PODMAN_TMPDIR/vol $PODMAN_TMPDIR/custom
+    touch $PODMAN_TMPDIR/vol/file $PODMAN_TMPDIR/custom/file
+
+    run_podman run --rm --uidmap 0:1:1000 --gidmap 0:1:1000 \
+               -v $PODMAN_TMPDIR/vol:/vol:idmap,z $IMAGE stat -c %u:%g /vol /vol/file
+    assert "$output" = "0:0
+0:0" "files of the user are owned by root in the container"
+
+    run_podman run --rm --uidmap 0:1:1000 --gidmap 0:1:1000 \
+               -v "$PODMAN_TMPDIR/custom:/vol:idmap=uids=0-5-1;gids=0-6-1,z" $IMAGE stat -c %u:%g /vol/file
+    assert "$output" = "4:5" "custom mappings of the idmap option"
+
+    # files created by the container must be removed in the user namespace
+    run_podman unshare rm -rf $PODMAN_TMPDIR/vol $PODMAN_TMPDIR/custom
+}
+
 # bats test_tags=ci:parallel
 @test "podman run --restart=always/on-failure -- wait" {
     # regression test for #18572 to make sure Podman waits less than 20 seconds
@@ -1603,7 +1657,7 @@ EOF
     tests="
 auto-update          |                  | -
 build                | $PODMAN_TMPDIR   |
-container runlabel   | run $IMAGE       | -
+container runlabel   | run $IMAGE       |
 create               | $IMAGE argument  |
 image sign           | $IMAGE           | -
 kube play            | argument         |
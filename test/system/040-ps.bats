@@ -253,4 +253,28 @@ load helpers
     run_podman pod rm -t 0 -f $rand_value
 }
 
+# bats test_tags=ci:parallel
+@test "podman ps - transient containers" {
+    local cname=c-$(safename)
+    run_podman run --storage-transient --name $cname $IMAGE echo hello
+    is "$output" "hello" "output of a transient container"
+
+    run_podman ps -a --filter name=$cname --format '{{.Status}} {{.StorageTransient}}'
+    assert "$output" =~ "Exited \(0\) .* ago \(transient\) true" "transient container in podman ps"
+
+    run_podman info --format '{{.Store.RunRoot}}'
+    local runroot="$output"
+    run_podman inspect --format '{{.Config.StorageTransient}} {{.HostConfig.LogConfig.Type}} {{.HostConfig.LogConfig.Path}}' $cname
+    if [[ "$output" =~ "k8s-file" ]]; then
+        assert "$output" =~ "true k8s-file $runroot/" "log file of a transient container under the run root"
+    else
+        assert "$output" =~ "^true " "transient container in podman inspect"
+    fi
+
+    run_podman logs $cname
+    is "$output" "hello" "logs of a transient container"
+
+    run_podman rm $cname
+}
+
 # vim: filetype=sh
@@ -163,4 +163,35 @@ load helpers
     run_podman 1 container exists $cid
 }
 
+# bats test_tags=ci:parallel
+@test "podman start - lifecycle hooks" {
+    local cname=c-$(safename)
+    local hooklog=$PODMAN_TMPDIR/hooks.log
+
+    run_podman create --name $cname \
+               --label io.podman.hook.pre-start="echo \$PODMAN_HOOK_STAGE \$PODMAN_CONTAINER_NAME >> $hooklog" \
+               --annotation io.podman.hook.post-stop="echo \$PODMAN_HOOK_STAGE \$PODMAN_CONTAINER_NAME >> $hooklog" \
+               $IMAGE true
+    run_podman start -a $cname
+    run_podman wait $cname
+    # rm runs the cleanup of the container if the cleanup process did not yet
+    run_podman rm $cname
+    assert "$(< $hooklog)" == "pre-start $cname
+post-stop $cname" "hooks ran in order"
+
+    run_podman events --filter container=$cname --filter event=hook --stream=false \
+               --format "{{.Attributes.hook}} {{.ContainerExitCode}}"
+    assert "$output" == "pre-start 0
+post-stop 0" "hook events"
+
+    # A failing pre-start hook fails the start
+    run_podman 125 run --rm --label io.podman.hook.pre-start="echo no-go; exit 3" $IMAGE true
+    assert "$output" =~ "pre-start hook of container .* failed: exit status 3: no-go" "failing pre-start hook"
+    run_podman 125 run --rm --label io.podman.hook.pre-start="sleep 10" --label io.podman.hook.timeout=1s $IMAGE true
+    assert "$output" =~ "pre-start hook of container .* failed: timed out after 1s" "pre-start hook timeout"
+
+    run_podman 125 create --label io.podman.hook.pre-stop=true $IMAGE true
+    assert "$output" =~ 'unknown lifecycle hook "io.podman.hook.pre-stop"' "unknown hook"
+}
+
 # vim: filetype=sh
@@ -99,6 +99,51 @@ load helpers
     is "$output" "" "podman image mount, no args, after umount"
 }
 
+# bats test_tags=ci:parallel
+@test "podman image mount --all-layers" {
+    skip_if_remote "mounting remote is meaningless"
+    skip_if_rootless "too hard to test rootless"
+
+    # For parallel safety: create a temporary image with a layer of its own
+    local tmpctr="c-$(safename)"
+    local iname="i-$(safename)"
+    run_podman run --name $tmpctr $IMAGE touch /top-layer
+    run_podman commit -q $tmpctr $iname
+    run_podman rm $tmpctr
+
+    run_podman image inspect --format '{{len .RootFS.Layers}}' $iname
+    local nlayers="$output"
+
+    run_podman 125 image mount --all-layers $iname
+    is "$output" "Error: --all-layers requires a --target directory"
+
+    local target=$PODMAN_TMPDIR/layers
+    run_podman image mount --all-layers --target $target $iname
+    assert "${#lines[@]}" = "$nlayers" "one line per layer"
+    local top=$(printf "%s/%03d" $target $((nlayers - 1)))
+    local below=$(printf "%s/%03d" $target $((nlayers - 2)))
+    assert "${lines[-1]}" =~ "$top\$" "top layer mounted last"
+
+    # Each layer shows the filesystem up to and including the layer
+    test -e $top/top-layer
+    test ! -e $below/top-layer
+    test -e $below/home/podman/testimage-id
+
+    # The layers are read-only
+    run touch $top/read-only
+    assert "$status" -ne 0 "layers are mounted read-only"
+
+    run_podman image umount --all-layers --target $target $iname
+    test ! -e $top
+    test ! -e $below
+
+    run_podman image mount --all-layers --target $target --format json $iname
+    assert "$output" =~ "\"Path\": \"$top\"" "json lists the mount points"
+    run_podman image umount --all-layers --target $target $iname
+
+    run_podman rmi $iname
+}
+
 # bats test_tags=ci:parallel
 @test "podman run --mount ro=false " {
     local volpath=/path/in/container
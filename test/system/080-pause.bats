@@ -139,3 +139,43 @@ load helpers.systemd
     assert "$except_scope_mount" == "" "Healthcheck systemd unit cleanup: no units leaked"
 }
 # vim: filetype=sh
+
+# bats test_tags=ci:parallel
+@test "podman pause --freeze-timeout" {
+    if is_rootless && ! is_cgroupsv2; then
+        skip "'podman pause' (rootless) only works with cgroups v2"
+    fi
+
+    local ctrname="c-$(safename)"
+    run_podman run -d --name $ctrname $IMAGE /home/podman/pause
+    cid="$output"
+
+    run_podman --noout container freeze --freeze-timeout 2 $ctrname
+    assert "$output" == "" "output should be empty"
+    run_podman inspect --format '{{.State.Status}} {{if .State.FrozenUntil}}frozen{{end}}' $ctrname
+    is "$output" "paused frozen" "podman inspect .State.FrozenUntil"
+
+    # The timer unpauses the container once the timeout expired
+    for i in {1..20}; do
+        run_podman inspect --format '{{.State.Status}}' $ctrname
+        if [[ "$output" == "running" ]]; then
+            break
+        fi
+        sleep 0.5
+    done
+    is "$output" "running" "container is unpaused after its freeze timeout"
+    run_podman events --since 1m --stream=false --filter container=$cid --filter event=thaw --format '{{.Status}}'
+    is "$output" "thaw" "thaw event"
+
+    # Unpausing the container cancels its automatic unpause
+    run_podman --noout container freeze --freeze-timeout 60 $ctrname
+    run -0 systemctl status $cid-thaw.timer
+    assert "$output" =~ "active" "thaw timer should be running"
+    run_podman --noout container thaw $ctrname
+    run_podman inspect --format '{{.State.Status}} {{.State.FrozenUntil}}' $ctrname
+    is "$output" "running <nil>" "podman inspect after thaw"
+    run -0 systemctl list-units --quiet "$cid-thaw*"
+    assert "$output" == "" "thaw timer should be removed"
+
+    run_podman rm -t 0 -f $ctrname
+}
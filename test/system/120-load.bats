@@ -316,4 +316,51 @@ verify_iid_and_name() {
     is "$output" ".*POSIX tar archive" "layers are uncompressed"
 }
 
+@test "podman save --format oci-artifact" {
+    archive=$PODMAN_TMPDIR/myimage-$(random_string 8).tar
+    layout=$PODMAN_TMPDIR/mylayout-$(random_string 8)
+    artifact=localhost/sbom-$(random_string 8):latest
+    mkdir -p $layout
+
+    # Save the image and add an artifact referring to it to the archive.
+    run_podman save --format oci-artifact -o $archive $IMAGE
+    tar -C $layout -xf $archive
+    image_digest=$(jq -r '.manifests[0].digest' $layout/index.json)
+    image_size=$(jq -r '.manifests[0].size' $layout/index.json)
+
+    echo '{"spdxVersion": "SPDX-2.3"}' > $layout/sbom.json
+    sbom_digest=$(sha256sum < $layout/sbom.json | awk '{print $1}')
+    sbom_size=$(stat -c %s $layout/sbom.json)
+    mv $layout/sbom.json $layout/blobs/sha256/$sbom_digest
+    echo -n '{}' > $layout/blobs/sha256/44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a
+    cat > $layout/manifest.json <<EOF
+{"schemaVersion":2,"mediaType":"application/vnd.oci.image.manifest.v1+json","artifactType":"application/spdx+json",
+"config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a","size":2},
+"layers":[{"mediaType":"application/spdx+json","digest":"sha256:$sbom_digest","size":$sbom_size}],
+"subject":{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":"$image_digest","size":$image_size}}
+EOF
+    manifest_digest=$(sha256sum < $layout/manifest.json | awk '{print $1}')
+    manifest_size=$(stat -c %s $layout/manifest.json)
+    mv $layout/manifest.json $layout/blobs/sha256/$manifest_digest
+    jq --arg d "sha256:$manifest_digest" --argjson s $manifest_size --arg n "$artifact" \
+       '.manifests += [{"mediaType":"application/vnd.oci.image.manifest.v1+json","digest":$d,"size":$s,"annotations":{"org.opencontainers.image.ref.name":$n}}]' \
+       $layout/index.json > $layout/index.json.new
+    mv $layout/index.json.new $layout/index.json
+    tar -C $layout -cf $archive .
+
+    # Loading the archive loads the artifact with the image.
+    run_podman load -i $archive
+    assert "$output" =~ "Loaded image: $IMAGE" "image is loaded"
+    assert "$output" =~ "Loaded artifact: $artifact" "artifact is loaded"
+    run_podman artifact inspect $artifact
+    is "$(jq -r '.Manifest.subject.digest' <<<"$output")" "$image_digest" "artifact refers to the image"
+
+    # Saving the image again saves the artifact referring to it.
+    run_podman save --format oci-artifact -o $archive $IMAGE
+    run tar -xOf $archive index.json
+    assert "$output" =~ "$artifact" "artifact is saved with the image"
+
+    run_podman artifact rm $artifact
+}
+
 # vim: filetype=sh
@@ -322,6 +322,59 @@ function _test_skopeo_credential_sharing() {
 
 }
 
+@test "podman login --credential-helper" {
+    authfile=${PODMAN_LOGIN_WORKDIR}/auth-$(random_string 10).json
+    registry=localhost:${PODMAN_LOGIN_REGISTRY_PORT}
+
+    # Minimal credential helper keeping the credentials of one registry
+    store=$PODMAN_TMPDIR/helper-creds.json
+    mkdir -p $PODMAN_TMPDIR/bin
+    cat >$PODMAN_TMPDIR/bin/docker-credential-podmantest <<EOF
+#!/bin/sh
+case "\$1" in
+    store) cat >$store ;;
+    get)   if [ -s $store ]; then cat $store; else echo "credentials not found in native keychain"; exit 1; fi ;;
+    erase) rm -f $store ;;
+esac
+EOF
+    chmod +x $PODMAN_TMPDIR/bin/docker-credential-podmantest
+
+    PATH=$PODMAN_TMPDIR/bin:$PATH run_podman login --authfile=$authfile \
+        --tls-verify=false \
+        --credential-helper podmantest \
+        --username ${PODMAN_LOGIN_USER} \
+        --password ${PODMAN_LOGIN_PASS} \
+        $registry
+    is "$output" "Login Succeeded!" "output from podman login"
+
+    run jq -r ".credHelpers[\"$registry\"]" <$authfile
+    is "$output" "podmantest" "credential helper selected in $authfile"
+    run jq -r '.auths' <$authfile
+    is "$output" "{}" "no credentials stored in $authfile"
+    run jq -r '.Username' <$store
+    is "$output" "${PODMAN_LOGIN_USER}" "credentials stored in the helper"
+
+    PATH=$PODMAN_TMPDIR/bin:$PATH run_podman registry helpers ls --authfile=$authfile \
+        --format '{{.Name}} {{.Registries}} {{.Path}}'
+    assert "$output" =~ "podmantest $registry $PODMAN_TMPDIR/bin/docker-credential-podmantest" \
+           "helper selected for the registry is listed"
+
+    PATH=$PODMAN_TMPDIR/bin:$PATH run_podman registry helpers test --authfile=$authfile \
+        --format '{{.Helper}} {{.Status}} {{.Username}}' $registry
+    assert "$output" =~ "containers-auth.json ok ${PODMAN_LOGIN_USER}" \
+           "credentials supplied through the auth file"
+
+    run_podman 125 registry helpers test --authfile=$authfile --helper nosuchhelper $registry
+    assert "$output" =~ "Error: no credential helper supplies credentials for $registry"
+
+    PATH=$PODMAN_TMPDIR/bin:$PATH run_podman logout --authfile=$authfile $registry
+    test ! -e $store || die "podman logout did not erase the credentials of the helper"
+
+    run_podman 125 login --authfile=$authfile --credential-helper nosuchhelper $registry
+    assert "$output" =~ "Error: credential helper \"nosuchhelper\"" \
+           "login with a missing credential helper"
+}
+
 @test "podman pull images with retry" {
     run_podman pull -q --retry 4 --retry-delay "10s" $IMAGE
     run_podman 125 pull -q --retry 4 --retry-delay "bogus" $IMAGE
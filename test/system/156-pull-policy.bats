@@ -55,6 +55,52 @@ load helpers.registry
     run_podman image rm --ignore $image_for_test
 }
 
+@test "podman pull with policy newer-with-max-age" {
+    skip_if_remote "tests depend on start_registry which does not work with podman-remote"
+    start_registry
+
+    local registry=localhost:${PODMAN_LOGIN_REGISTRY_PORT}
+    local image_for_test=$registry/i-$(safename):$(random_string)
+    local authfile=$PODMAN_TMPDIR/authfile.json
+
+    run_podman login --authfile=$authfile \
+        --tls-verify=false \
+        --username ${PODMAN_LOGIN_USER} \
+        --password ${PODMAN_LOGIN_PASS} \
+        $registry
+
+    run_podman create -q $IMAGE true
+    local tmpcid=$output
+    run_podman commit -q $tmpcid $image_for_test
+    local image_id=$output
+    run_podman image push --tls-verify=false --authfile=$authfile $image_for_test
+
+    # Pulling records when the registry was checked
+    run_podman pull --tls-verify=false --authfile $authfile --policy always $image_for_test
+
+    # Replace the image in the registry
+    run_podman commit -q --change LABEL=newer=1 $tmpcid $image_for_test
+    local new_image_id=$output
+    run_podman rm $tmpcid
+    run_podman image push --tls-verify=false --authfile=$authfile $image_for_test
+    run_podman tag $image_id $image_for_test
+
+    run_podman 125 pull --tls-verify=false --authfile $authfile --policy newer-with-max-age --max-age 0 $image_for_test
+    assert "$output" = "Error: invalid pull max age \"0\": must be positive"
+
+    # The local image was checked less than an hour ago
+    run_podman pull --tls-verify=false --authfile $authfile --policy newer-with-max-age --max-age 1h $image_for_test
+    assert "$output" = $image_id "registry not checked"
+
+    sleep 2
+    run_podman pull --tls-verify=false --authfile $authfile --policy newer-with-max-age --max-age 1s $image_for_test
+    assert "$output" =~ "Writing manifest to image destination"
+    run_podman image inspect --format '{{.ID}}' $image_for_test
+    assert "$output" = $new_image_id "newer image pulled"
+
+    run_podman image rm --ignore $image_for_test $image_id
+}
+
 @test "podman pull with policy flag - remote" {
     # Make sure image is not pulled when policy is never
     run_podman 125 pull --policy never quay.io/libpod/i-do-not-exist
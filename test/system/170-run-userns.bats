@@ -121,6 +121,55 @@ EOF
     run_podman secret rm ${test_name}
 }
 
+# CANNOT BE PARALLELIZED: userns=auto, rootless, => not enough unused IDs in user namespace
+@test "podman system subid status and userns=auto size" {
+    skip_if_remote "podman system subid is not supported with podman-remote"
+    ns_user="containers"
+    if is_rootless; then
+        ns_user=$(id -un)
+    fi
+    grep -E -q "${ns_user}:" /etc/subuid || skip "no IDs allocated for user ${ns_user}"
+
+    run_podman system subid status --format '{{.User}} {{.UIDs.Size}} {{.UIDs.Free}}'
+    read user size free <<<"$output"
+    assert "$user" = "$ns_user" "user of the pools"
+    assert "$size" -gt 0 "the pool has IDs"
+
+    run_podman run -d --userns=auto:size=1000 $IMAGE top
+    cid="$output"
+    run_podman inspect --format '{{.HostConfig.AutoUserNs.RequestedSize}} {{.HostConfig.AutoUserNs.UIDs}} {{.HostConfig.AutoUserNs.User}}' $cid
+    is "$output" "1000 1000 $ns_user" "inspect shows the size of the user namespace"
+    run_podman system subid status --format '{{.UIDs.Free}}'
+    assert "$output" -le $((free - 1000)) "the container uses IDs of the pool"
+    run_podman rm -t 0 -f $cid
+
+    # a size bigger than the pool fails with the reason
+    run_podman 125 run --rm --userns=auto:size=$((size + 1)) $IMAGE true
+    assert "$output" =~ "--userns=auto needs $((size + 1)) IDs, but only [0-9]+ of the $size subordinate UIDs and [0-9]+ of the [0-9]+ subordinate GIDs of user \"$ns_user\" are free" "error explains the failure"
+    assert "$output" =~ "podman system subid expand" "error suggests growing the pools"
+}
+
+# CANNOT BE PARALLELIZED: modifies /etc/subuid and /etc/subgid
+@test "podman system subid expand" {
+    skip_if_remote "podman system subid is not supported with podman-remote"
+    skip_if_rootless "/etc/subuid can only be modified by root"
+
+    cp /etc/subuid $PODMAN_TMPDIR/subuid
+    cp /etc/subgid $PODMAN_TMPDIR/subgid
+    # do not leave the test user in the files if an assertion fails
+    defer-assertion-failures
+    user="user$(random_string 8)"
+    run_podman system subid expand --user $user --size 1000
+    assert "$output" =~ "Added UIDs and GIDs [0-9]+-[0-9]+ to user $user" "expand output"
+    assert "$(grep -c "^$user:[0-9]*:1000\$" /etc/subuid)" = "1" "range added to /etc/subuid"
+    assert "$(grep -c "^$user:[0-9]*:1000\$" /etc/subgid)" = "1" "range added to /etc/subgid"
+    cp $PODMAN_TMPDIR/subuid /etc/subuid
+    cp $PODMAN_TMPDIR/subgid /etc/subgid
+
+    run_podman 125 system subid expand --size 0
+    is "$output" "Error: invalid size 0, must be positive"
+}
+
 # bats test_tags=ci:parallel
 @test "podman userns=nomap" {
     if is_rootless; then
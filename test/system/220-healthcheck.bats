@@ -196,6 +196,9 @@ Log[-1].Output   | \"Uh-oh on stdout!\\\nUh-oh on stderr!\\\n\"
     run_podman 125 create --health-on-failure=kill $IMAGE
     is "$output" "Error: cannot set on-failure action to kill without a health check"
 
+    run_podman 125 create --health-cmd /home/podman/healthcheck --health-on-failure=restart-unit $IMAGE
+    is "$output" "Error: cannot set on-failure action to restart-unit without a systemd unit: the PODMAN_SYSTEMD_UNIT label is not set"
+
     ctr="c-h-$(safename)"
 
     for policy in none kill restart stop;do
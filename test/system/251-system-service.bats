@@ -343,3 +343,41 @@ function _podman_system_service {
   is "$output" ".* remote error: tls: certificate required"
   systemctl stop $SERVICE_NAME
 }
+
+@test "podman-system-service --cors per route group and WebSocket origins" {
+    unset REMOTESYSTEM_TRANSPORT
+
+    skip_if_remote "podman system service unavailable over remote"
+
+    port=$(random_free_port)
+    URL=http://127.0.0.1:$port
+
+    _podman_system_service tcp://127.0.0.1:$port --time=0 \
+      --cors https://a.example --cors 'events=*'
+    wait_for_port 127.0.0.1 $port
+
+    run curl -s -D - -o /dev/null -H "Origin: https://a.example" $URL/v5.0.0/libpod/containers/json
+    assert "$output" =~ "Access-Control-Allow-Origin: https://a.example" "allowed origin"
+    run curl -s -D - -o /dev/null -H "Origin: https://b.example" $URL/v5.0.0/libpod/containers/json
+    assert "$output" !~ "Access-Control-Allow-Origin" "origin not allowed"
+
+    # preflight requests
+    run curl -s -D - -o /dev/null -X OPTIONS -H "Origin: https://a.example" \
+        -H "Access-Control-Request-Method: POST" $URL/v5.0.0/libpod/containers/create
+    assert "$output" =~ "HTTP/1.1 204" "preflight of allowed origin"
+    run curl -s -D - -o /dev/null -X OPTIONS -H "Origin: https://b.example" \
+        -H "Access-Control-Request-Method: POST" $URL/v5.0.0/libpod/containers/create
+    assert "$output" =~ "HTTP/1.1 403" "preflight of origin not allowed"
+
+    # WebSocket upgrades are subject to the origins of the route group
+    ws_headers=(-H "Connection: Upgrade" -H "Upgrade: websocket"
+                -H "Sec-WebSocket-Version: 13" -H "Sec-WebSocket-Key: dGhlIHNhbXBsZSBub25jZQ==")
+    run curl -s -D - -o /dev/null -m 2 "${ws_headers[@]}" -H "Origin: https://b.example" $URL/v5.0.0/libpod/events
+    assert "$output" =~ "HTTP/1.1 101 Switching Protocols" "events upgraded to WebSocket"
+    assert "$output" =~ "Sec-WebSocket-Accept: s3pPLMBiTxaQ9kYGzzhZRbK\+xOo=" "WebSocket accept key"
+    run curl -s -D - -o /dev/null -m 2 "${ws_headers[@]}" -H "Origin: https://b.example" \
+        $URL/v5.0.0/libpod/containers/nonesuch/attach/ws
+    assert "$output" =~ "HTTP/1.1 403" "attach WebSocket of origin not allowed"
+
+    systemctl stop $SERVICE_NAME
+}
@@ -1214,6 +1214,49 @@ EOF
    done < <(parse_table "$exit_tests")
 }
 
+@test "quadlet - HealthOnFailure=restart-unit" {
+    local quadlet_file=$PODMAN_TMPDIR/health_restart_unit_$(safename).container
+    cat > $quadlet_file <<EOF
+[Container]
+Image=$IMAGE
+Exec=/home/podman/pause
+HealthCmd=/home/podman/healthcheck
+HealthInterval=disable
+HealthRetries=1
+HealthOnFailure=restart-unit
+Network=none
+EOF
+
+    run_quadlet "$quadlet_file"
+    assert "$QUADLET_SERVICE_CONTENT" =~ "--health-on-failure restart-unit" "quadlet passes the on-failure action"
+    service_setup $QUADLET_SERVICE_NAME
+
+    run_podman container inspect --format "{{.ID}} {{.Config.HealthcheckOnFailureAction}}" $QUADLET_CONTAINER_NAME
+    local cid=${output%% *}
+    assert "${output##* }" == "restart-unit" "on-failure action of the container"
+
+    # Make the health check fail, the unit must be restarted with a new container
+    run_podman exec $QUADLET_CONTAINER_NAME touch /uh-oh
+    run_podman 1 healthcheck run $QUADLET_CONTAINER_NAME
+    is "$output" "unhealthy" "output from 'podman healthcheck run'"
+
+    local new_cid=
+    for tries in $(seq 1 20); do
+        run_podman '?' container inspect --format "{{.ID}} {{.State.Status}}" $QUADLET_CONTAINER_NAME
+        if [[ $status -eq 0 ]] && [[ "$output" != "$cid "* ]] && [[ "$output" == *" running" ]]; then
+            new_cid=${output%% *}
+            break
+        fi
+        sleep 0.5
+    done
+    assert "$new_cid" != "" "the unit was restarted with a new container"
+
+    run systemctl show --value --property=ActiveState "$QUADLET_SERVICE_NAME"
+    is "$output" "active" "the unit is active again"
+
+    service_cleanup $QUADLET_SERVICE_NAME inactive
+}
+
 @test "quadlet kube - Working Directory" {
     yaml_source="$PODMAN_TMPDIR/basic_$(safename).yaml"
     local_path=local_path$(random_string)
@@ -173,6 +173,28 @@ EOF
     run_podman rm -f -t0 testctr
 }
 
+@test "podman run --env-file with directory and variable expansion" {
+    local envdir="$PODMAN_TMPDIR/envdir"
+    mkdir $envdir
+    cat >$envdir/01-base <<'EOF'
+base=one
+combined=${base}-two
+EOF
+    cat >$envdir/02-more <<'EOF'
+withdefault=${PODMAN_TEST_UNSET_VAR:-fallback}
+literal=$${base}
+fromhost=${PODMAN_TEST_HOST_VAR}
+EOF
+    # Hidden files are skipped
+    echo "hidden=yes" >$envdir/.hidden
+
+    export PODMAN_TEST_HOST_VAR=hostval
+    run_podman run --rm --env-file $envdir $IMAGE \
+               sh -c 'echo "$base|$combined|$withdefault|$literal|$fromhost|${hidden:-none}"'
+    unset PODMAN_TEST_HOST_VAR
+    is "$output" 'one|one-two|fallback|${base}|hostval|none' "env from directory with expansion"
+}
+
 # Obscure feature: '--env FOO*' will pass all env starting with FOO
 @test "podman run --env with glob" {
     # Set a bunch of different envariables with a common prefix
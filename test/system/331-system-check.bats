@@ -199,6 +199,39 @@ load helpers
     run_podman rmi $imageID
 }
 
+@test "podman system check - container storage missing" {
+    run_podman create $IMAGE true
+    containerID="$output"
+    run_podman_testing remove-container --container=$containerID
+    run_podman 125 system check
+    assert "$output" =~ "storage container is missing" "output from 'podman system check' with missing container storage"
+    run_podman system check --repair-containers
+    assert "$output" =~ "Repaired container $containerID: recreated missing storage container" "output from 'podman system check --repair-containers'"
+    run_podman system check
+    run_podman start --attach $containerID
+    run_podman rm $containerID
+}
+
+@test "podman system check - container configuration backup" {
+    run_podman create --name c-backup $IMAGE true
+    containerID="$output"
+    run_podman inspect --format '{{.StaticDir}}' $containerID
+    backup="$output/libpod-config.json"
+
+    # The backup follows changes of the configuration
+    run_podman rename c-backup c-backup-renamed
+    assert "$(< $backup)" =~ '"name": "c-backup-renamed"' "backup after rename"
+
+    # Containers created before the backup existed get it written
+    rm -f $backup
+    run_podman 0+w system check
+    assert "$output" =~ "Container $containerID has no configuration backup" "warning without backup"
+    run_podman system check --repair-containers
+    assert "$output" =~ "Repaired container $containerID: wrote missing configuration backup" "output from 'podman system check --repair-containers'"
+    test -e $backup || die "configuration backup was not written"
+    run_podman rm $containerID
+}
+
 function make_layer_blob() {
     local tmpdir=$(mktemp -d --tmpdir=${PODMAN_TMPDIR} make_layer_blob.XXXXXX)
     local blobfile
@@ -0,0 +1,75 @@
+#!/usr/bin/env bats   -*- bats -*-
+#
+# 415-seccomp - podman seccomp tests
+#
+
+load helpers
+
+# bats test_tags=ci:parallel
+@test "podman seccomp inspect" {
+    skip_if_remote "podman seccomp is not supported with podman-remote"
+
+    run_podman seccomp inspect
+    assert "$output" =~ '"defaultAction": "SCMP_ACT_ERRNO"' "default action of the default profile"
+
+    run_podman seccomp inspect --syscalls
+    assert "${lines[0]}" =~ "SYSCALL +ACTION" "header"
+    assert "${lines[1]}" =~ "^\* +SCMP_ACT_ERRNO$" "default action listed first"
+    assert "$output" =~ "mkdir +SCMP_ACT_ALLOW" "mkdir is allowed"
+
+    run_podman seccomp inspect --syscalls --format '{{.Syscall}}={{.Action}}'
+    assert "$output" =~ "read=SCMP_ACT_ALLOW" "custom format"
+
+    run_podman 125 seccomp inspect --format '{{.Syscall}}'
+    is "$output" "Error: --format can only be used with --syscalls"
+}
+
+# bats test_tags=ci:parallel
+@test "podman seccomp diff" {
+    skip_if_remote "podman seccomp is not supported with podman-remote"
+
+    profile=$PODMAN_TMPDIR/profile.json
+    cat >$profile <<EOF
+{
+    "defaultAction": "SCMP_ACT_KILL",
+    "syscalls": [{"names": ["bpf", "read"], "action": "SCMP_ACT_ALLOW"}]
+}
+EOF
+
+    run_podman seccomp diff $profile
+    assert "${lines[0]}" = "Default action: SCMP_ACT_ERRNO -> SCMP_ACT_KILL" "default action change"
+    assert "$output" =~ "bpf +SCMP_ACT_ALLOW \(conditional\) +SCMP_ACT_ALLOW" "bpf is allowed unconditionally"
+    assert "$output" =~ "mkdir +SCMP_ACT_ALLOW +SCMP_ACT_KILL" "mkdir is killed"
+    assert "$output" !~ "read " "read is allowed by both"
+
+    run_podman seccomp diff --format '{{.Syscall}}' $profile $profile
+    assert "$output" = "" "no difference with itself"
+
+    run_podman seccomp inspect
+    echo "$output" > $PODMAN_TMPDIR/default.json
+    run_podman seccomp diff --format json $PODMAN_TMPDIR/default.json
+    assert "$output" =~ '"changes": \[\]' "the default profile does not differ from itself"
+
+    run_podman 125 seccomp diff $PODMAN_TMPDIR/nonesuch.json
+    assert "$output" =~ "no such file or directory"
+}
+
+@test "podman seccomp generate" {
+    skip_if_remote "podman seccomp is not supported with podman-remote"
+
+    profile=$PODMAN_TMPDIR/profile.json
+    run_podman seccomp generate -o $profile -- --net none $IMAGE touch /tmp/file
+    test -e $profile || die "the profile was not written"
+    run_podman seccomp diff --format '{{.Syscall}} {{.Profile}}' $profile
+    assert "$output" =~ "mkdir SCMP_ACT_ERRNO" "mkdir was not recorded"
+
+    run_podman run --rm --net none --security-opt seccomp=$profile $IMAGE touch /tmp/file
+    run_podman 1 run --rm --net none --security-opt seccomp=$profile $IMAGE mkdir /tmp/dir
+    assert "$output" =~ "can't create directory" "mkdir is denied by the generated profile"
+
+    # the exit code of the container is passed through
+    run_podman 3 seccomp generate -o $profile -- --net none $IMAGE sh -c "exit 3"
+    assert "$output" =~ "The container exited with code 3" "warning"
+}
+
+# vim: filetype=sh
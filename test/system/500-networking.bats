@@ -323,8 +323,6 @@ load helpers.network
 
 # CANNOT BE PARALLELIZED due to iptables/nft commands
 @test "podman network reload" {
-    skip_if_remote "podman network reload does not have remote support"
-
     random_1=$(random_string 30)
     HOST_PORT=$(random_free_port)
     SERVER=http://127.0.0.1:$HOST_PORT
@@ -423,6 +421,29 @@ load helpers.network
     run curl -s -S $SERVER/index.txt
     is "$output" "$random_1" "curl 127.0.0.1:/index.txt"
 
+    run_podman 125 network reload --graceful $cid
+    is "$output" "Error: --graceful can only be used with --all" "--graceful without --all"
+
+    if ! is_rootless; then
+        # flush the firewall rules again, this time restore them in place
+        iptables -t nat -F "NETAVARK-HOSTPORT-DNAT" || true
+        nft delete table inet netavark              || true
+
+        run curl --max-time 1 -s $SERVER/index.txt
+        assert $status -eq 28 "curl did not time out"
+    fi
+
+    run_podman network reload --all --graceful
+    is "$output" "$cid" "Output does match container ID"
+
+    run_podman inspect $cid --format "{{(index .NetworkSettings.Networks \"$netname\").IPAddress}}
+{{(index .NetworkSettings.Networks \"$netname\").MacAddress}}"
+    is "${lines[0]}" "$ip1" "IP address changed after podman network reload --graceful"
+    is "${lines[1]}" "$mac1" "MAC address changed after podman network reload --graceful"
+
+    run curl -s -S $SERVER/index.txt
+    is "$output" "$random_1" "curl 127.0.0.1:/index.txt after graceful reload"
+
     # clean up the container
     run_podman rm -t 0 -f $cid
 
@@ -20,4 +20,55 @@ function setup() {
     assert "$output" == "test" "podman volume rm output"
 }
 
+@test "podman system renumber --dry-run" {
+    local cname=c-$(safename)
+    local vname=v-$(safename)
+    run_podman create --name $cname $IMAGE true
+    cid="$output"
+    run_podman volume create $vname
+
+    run_podman system renumber --dry-run --format '{{range .Locks}}{{.Type}} {{.Name}} {{.LockID}}{{"\n"}}{{end}}'
+    assert "$output" =~ "container $cname [0-9]+" "dry run lists the container"
+    assert "$output" =~ "volume $vname [0-9]+" "dry run lists the volume"
+    before="$output"
+
+    run_podman system renumber --dry-run
+    assert "$output" =~ "container +${cid:0:12} +$cname" "dry run table"
+    assert "$output" =~ "[0-9]+ of [0-9]+ locks would change" "dry run summary"
+
+    # Nothing was renumbered
+    run_podman system renumber --dry-run --format '{{range .Locks}}{{.Type}} {{.Name}} {{.LockID}}{{"\n"}}{{end}}'
+    assert "$output" == "$before" "locks after dry run"
+
+    run_podman 125 system renumber --format json
+    is "$output" "Error: --format can only be used with --dry-run"
+
+    run_podman system renumber
+    run_podman system renumber --dry-run
+    assert "$output" =~ "0 of [0-9]+ locks would change" "no lock changes after renumbering"
+
+    run_podman rm $cname
+    run_podman volume rm $vname
+}
+
+@test "podman system locks" {
+    local cname=c-$(safename)
+    run_podman create --name $cname $IMAGE true
+    cid="$output"
+
+    run_podman system locks
+    assert "$output" =~ "Lock type: (shm|file)" "lock type"
+    assert "$output" =~ "No lock conflicts have been detected." "no conflicts"
+
+    run_podman system locks --all
+    assert "$output" =~ "[0-9]+ +container +${cid:0:12} +$cname" "lock of the container"
+
+    run_podman inspect --format '{{.LockNumber}}' $cname
+    lock="$output"
+    run_podman system locks --format '{{range .Allocations}}{{if eq .Name "'$cname'"}}{{.LockID}}{{end}}{{end}}'
+    assert "$output" == "$lock" "lock of the container matches inspect"
+
+    run_podman rm $cname
+}
+
 # vim: filetype=sh
@@ -269,4 +269,29 @@ EOF
     assert "$output" =~ "--compression-level int.*compression level to use \(default 1\)" "containers.conf should set default compressionlevel to 1"
 }
 
+@test "podman registries.conf of the project" {
+    local project="$PODMAN_TMPDIR/project-$(safename)"
+    local shortname="i-$(safename)"
+    mkdir -p $project/.podman $project/sub/dir
+    cat >$project/.podman/registries.conf <<EOF
+unqualified-search-registries = []
+
+[aliases]
+"$shortname" = "localhost:1/project/$shortname"
+EOF
+
+    # The registries.conf is found in the parent directories
+    cd $project/sub/dir
+    CONTAINERS_REGISTRIES_CONF= run_podman 125 pull $shortname
+    assert "$output" =~ "localhost:1/project/$shortname" "short name resolved with the alias of the project"
+
+    # --registries-conf takes precedence over the project
+    local other_conf="$PODMAN_TMPDIR/other-registries.conf"
+    echo 'unqualified-search-registries = []' > $other_conf
+    CONTAINERS_REGISTRIES_CONF= run_podman 125 --registries-conf $other_conf pull $shortname
+    assert "$output" =~ "short-name \"$shortname\" did not resolve to an alias and no unqualified-search registries are defined in \"$other_conf\"" \
+           "--registries-conf overrides the project"
+    cd - > /dev/null
+}
+
 # vim: filetype=sh